@@ -0,0 +1,194 @@
+package api
+
+import (
+	"context"
+	"strings"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// AccountDelete godoc
+// @Summary      Deletes the user's account
+// @Description  Removes all user data, anonymizes analytics records, and revokes linked OAuth tokens where supported
+// @Tags         account
+// @Success      200 {object} string
+// @Failure      500 {object} string "internal server error"
+// @Router       /account/ [delete]
+func (api *API) AccountDelete(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	record, err := api.deleteAccount(c.Request.Context(), userID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to delete account")
+		Handle500(c)
+		return
+	}
+	_, err = database.GetAccountDeletionCollection(api.DB).InsertOne(c.Request.Context(), record)
+	if err != nil {
+		// the account is already gone at this point, so the request still succeeds
+		api.Logger.Error().Err(err).Msg("failed to write account deletion record")
+	}
+	// keeps LogRequestMiddleware from re-attaching the deleted user to this request's log
+	c.Set("user", primitive.NilObjectID)
+	c.JSON(200, gin.H{})
+}
+
+func (api *API) deleteAccount(ctx context.Context, userID primitive.ObjectID) (*database.AccountDeletionRecord, error) {
+	record := database.AccountDeletionRecord{
+		UserID:           userID,
+		DeletedAt:        primitive.NewDateTimeFromTime(api.GetCurrentTime()),
+		DeletedCounts:    map[string]int64{},
+		AnonymizedCounts: map[string]int64{},
+		RevokedServices:  []string{},
+	}
+	userFilter := bson.M{"user_id": userID}
+	user, err := database.GetUser(ctx, api.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	externalTokens, err := database.GetAllExternalTokens(ctx, api.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, token := range externalTokens {
+		revoked, err := external.RevokeExternalToken(token, api.ExternalConfig.RevokeOverrideURL)
+		if err != nil {
+			// a token the service no longer recognizes shouldn't block deletion
+			api.Logger.Error().Err(err).Str("serviceID", token.ServiceID).Msg("failed to revoke external token")
+			continue
+		}
+		if revoked {
+			record.RevokedServices = append(record.RevokedServices, token.ServiceID)
+		}
+	}
+
+	var dashboardTeams []database.DashboardTeam
+	err = database.FindWithCollection(ctx, database.GetDashboardTeamCollection(api.DB), userID, &[]bson.M{}, &dashboardTeams, nil)
+	if err != nil {
+		return nil, err
+	}
+	teamIDs := []primitive.ObjectID{}
+	for _, team := range dashboardTeams {
+		teamIDs = append(teamIDs, team.ID)
+	}
+	deleteResult, err := database.GetDashboardTeamMemberCollection(api.DB).DeleteMany(ctx, bson.M{"$or": []bson.M{
+		{"team_id": bson.M{"$in": teamIDs}},
+		// memberships in other users' teams
+		{"user_id": userID},
+	}})
+	if err != nil {
+		return nil, err
+	}
+	record.DeletedCounts["dashboard_team_members"] = deleteResult.DeletedCount
+	deleteResult, err = database.GetDashboardDataPointCollection(api.DB).DeleteMany(ctx, bson.M{"team_id": bson.M{"$in": teamIDs}})
+	if err != nil {
+		return nil, err
+	}
+	record.DeletedCounts["dashboard_data_points"] = deleteResult.DeletedCount
+
+	// login links and waitlist entries are keyed on the email rather than the user
+	emailFilter := bson.M{"email": strings.ToLower(user.Email)}
+	for _, collection := range []*mongo.Collection{
+		database.GetMagicLinkCollection(api.DB),
+		database.GetWaitlistCollection(api.DB),
+	} {
+		deleteResult, err := collection.DeleteMany(ctx, emailFilter)
+		if err != nil {
+			return nil, err
+		}
+		record.DeletedCounts[collection.Name()] = deleteResult.DeletedCount
+	}
+
+	collectionsToDelete := []*mongo.Collection{
+		database.GetTaskCollection(api.DB),
+		database.GetNoteCollection(api.DB),
+		database.GetCalendarEventCollection(api.DB),
+		database.GetCalendarAccountCollection(api.DB),
+		database.GetCalendarFeedCollection(api.DB),
+		database.GetConditionalResponseCollection(api.DB),
+		database.GetNotificationCollection(api.DB),
+		database.GetAvatarCollection(api.DB),
+		database.GetTaskActivityCollection(api.DB),
+		database.GetAuditLogCollection(api.DB),
+		database.GetPullRequestCollection(api.DB),
+		database.GetRepositoryCollection(api.DB),
+		database.GetViewCollection(api.DB),
+		database.GetTaskSectionCollection(api.DB),
+		database.GetDefaultSectionSettingsCollection(api.DB),
+		database.GetRecurringTaskTemplateCollection(api.DB),
+		database.GetUserSettingsCollection(api.DB),
+		database.GetJiraSitesCollection(api.DB),
+		database.GetJiraPrioritiesCollection(api.DB),
+		database.GetOauth1RequestsSecretsCollection(api.DB),
+		database.GetStateTokenCollection(api.DB),
+		database.GetFeedbackItemCollection(api.DB),
+		database.GetDashboardTeamCollection(api.DB),
+		database.GetTaskShareCollection(api.DB),
+		database.GetAvailabilityLinkCollection(api.DB),
+		database.GetNoteFolderCollection(api.DB),
+		database.GetShareViewCollection(api.DB),
+		database.GetMeetingPrepRulesCollection(api.DB),
+		database.GetActionItemSuggestionCollection(api.DB),
+		database.GetInviteCodeCollection(api.DB),
+		// usage is unique per user, provider, model and day, so it can't be kept without the user
+		database.GetLLMUsageCollection(api.DB),
+		database.GetExternalTokenHealthCollection(api.DB),
+		database.GetExternalTokenCollection(api.DB),
+		// internal tokens go last so a failure part way through leaves the user able to retry
+		database.GetInternalTokenCollection(api.DB),
+	}
+	for _, collection := range collectionsToDelete {
+		deleteResult, err := collection.DeleteMany(ctx, userFilter)
+		if err != nil {
+			return nil, err
+		}
+		record.DeletedCounts[collection.Name()] = deleteResult.DeletedCount
+	}
+
+	// usage and request logs are kept for aggregate metrics but detached from the user
+	collectionsToAnonymize := []*mongo.Collection{
+		database.GetLogEventsCollection(api.DB),
+		database.GetServerRequestCollection(api.DB),
+	}
+	for _, collection := range collectionsToAnonymize {
+		updateResult, err := collection.UpdateMany(ctx, userFilter, bson.M{"$unset": bson.M{"user_id": ""}})
+		if err != nil {
+			return nil, err
+		}
+		record.AnonymizedCounts[collection.Name()] = updateResult.ModifiedCount
+	}
+
+	// views of other users' shared items stay counted, without saying who viewed them
+	updateResult, err := database.GetShareViewCollection(api.DB).UpdateMany(
+		ctx,
+		bson.M{"viewer_user_id": userID},
+		bson.M{"$unset": bson.M{"viewer_user_id": ""}},
+	)
+	if err != nil {
+		return nil, err
+	}
+	record.AnonymizedCounts["share_views"] = updateResult.ModifiedCount
+
+	// organizations stay with their other admins
+	updateResult, err = database.GetOrganizationCollection(api.DB).UpdateMany(
+		ctx,
+		bson.M{"admin_user_ids": userID},
+		bson.M{"$pull": bson.M{"admin_user_ids": userID}},
+	)
+	if err != nil {
+		return nil, err
+	}
+	record.AnonymizedCounts["organizations"] = updateResult.ModifiedCount
+
+	deleteResult, err = database.GetUserCollection(api.DB).DeleteOne(ctx, bson.M{"_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	record.DeletedCounts["users"] = deleteResult.DeletedCount
+	return &record, nil
+}
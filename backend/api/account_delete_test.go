@@ -0,0 +1,153 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestAccountDelete(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+
+	revokeCalls := 0
+	revokeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		revokeCalls += 1
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer revokeServer.Close()
+	api.ExternalConfig.RevokeOverrideURL = revokeServer.URL
+
+	authToken := login("test_account_delete@resonant-kelpie-404a42.netlify.app", "")
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	otherAuthToken := login("test_account_delete_other@resonant-kelpie-404a42.netlify.app", "")
+	otherUserID := getUserIDFromAuthToken(t, api.DB, otherAuthToken)
+
+	title := "my task"
+	_, err := database.GetTaskCollection(api.DB).InsertMany(context.Background(), []interface{}{
+		database.Task{UserID: userID, Title: &title},
+		database.Task{UserID: otherUserID, Title: &title},
+	})
+	assert.NoError(t, err)
+	_, err = database.GetNoteCollection(api.DB).InsertOne(context.Background(), database.Note{UserID: userID, Title: &title})
+	assert.NoError(t, err)
+	_, err = database.GetViewCollection(api.DB).InsertOne(context.Background(), database.View{UserID: userID})
+	assert.NoError(t, err)
+	_, err = database.GetTaskShareCollection(api.DB).InsertOne(context.Background(), database.TaskShare{UserID: userID, Token: "account-delete-share"})
+	assert.NoError(t, err)
+	_, err = database.GetAvailabilityLinkCollection(api.DB).InsertOne(context.Background(), database.AvailabilityLink{UserID: userID, Secret: "account-delete-availability"})
+	assert.NoError(t, err)
+	_, err = database.GetNoteFolderCollection(api.DB).InsertOne(context.Background(), database.NoteFolder{UserID: userID, Name: "my folder"})
+	assert.NoError(t, err)
+	_, err = database.GetShareViewCollection(api.DB).InsertMany(context.Background(), []interface{}{
+		database.ShareView{UserID: userID, TaskID: primitive.NewObjectID()},
+		database.ShareView{UserID: otherUserID, TaskID: primitive.NewObjectID(), ViewerUserID: userID},
+	})
+	assert.NoError(t, err)
+	_, err = database.GetMeetingPrepRulesCollection(api.DB).InsertOne(context.Background(), database.MeetingPrepRules{UserID: userID, IsEnabled: true})
+	assert.NoError(t, err)
+	_, err = database.GetActionItemSuggestionCollection(api.DB).InsertOne(context.Background(), database.ActionItemSuggestion{UserID: userID, NoteID: primitive.NewObjectID()})
+	assert.NoError(t, err)
+	_, err = database.GetLLMUsageCollection(api.DB).InsertOne(context.Background(), database.LLMUsage{UserID: userID, Provider: "openai", Model: "gpt-4", Date: "2023-01-06", RequestCount: 1})
+	assert.NoError(t, err)
+	_, err = database.GetExternalTokenHealthCollection(api.DB).InsertOne(context.Background(), database.ExternalTokenHealthEvent{UserID: userID, ServiceID: external.TASK_SERVICE_ID_GITHUB, Status: constants.TokenHealthRefreshFailed})
+	assert.NoError(t, err)
+	_, err = database.GetExternalTokenCollection(api.DB).InsertOne(context.Background(), database.ExternalAPIToken{
+		UserID:    userID,
+		ServiceID: external.TASK_SERVICE_ID_GITHUB,
+		Token:     `{"access_token":"github-token"}`,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, database.InsertLogEvent(context.Background(), api.DB, userID, "test_event"))
+	_, err = database.GetInviteCodeCollection(api.DB).InsertOne(context.Background(), database.InviteCode{UserID: userID, Code: "account-delete-invite", IsReferral: true, MaxUses: 5})
+	assert.NoError(t, err)
+	_, err = database.GetMagicLinkCollection(api.DB).InsertOne(context.Background(), database.MagicLink{Nonce: "account-delete-nonce", Email: "test_account_delete@resonant-kelpie-404a42.netlify.app"})
+	assert.NoError(t, err)
+	_, err = database.JoinWaitlist(context.Background(), api.DB, "test_account_delete@resonant-kelpie-404a42.netlify.app", api.GetCurrentTime())
+	assert.NoError(t, err)
+	team, err := database.GetOrCreateDashboardTeam(context.Background(), api.DB, userID)
+	assert.NoError(t, err)
+	_, err = database.GetDashboardDataPointCollection(api.DB).InsertOne(context.Background(), database.DashboardDataPoint{TeamID: team.ID, GraphType: constants.DashboardGraphTypePRMergeCount, Value: 1})
+	assert.NoError(t, err)
+	orgResult, err := database.GetOrganizationCollection(api.DB).InsertOne(context.Background(), database.Organization{Domain: "account-delete.example.com", AdminUserIDs: []primitive.ObjectID{userID, otherUserID}})
+	assert.NoError(t, err)
+	orgID := orgResult.InsertedID.(primitive.ObjectID)
+
+	UnauthorizedTest(t, http.MethodDelete, "/account/", nil)
+	t.Run("Success", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodDelete, "/account/", nil, http.StatusOK, api)
+
+		for _, collectionName := range []string{"tasks", "notes", "views", "task_shares", "availability_links", "note_folders", "share_views", "meeting_prep_rules", "action_item_suggestions", "llm_usage", "external_token_health", "invite_codes", "external_api_tokens", "internal_api_tokens"} {
+			count, err := api.DB.Collection(collectionName).CountDocuments(context.Background(), bson.M{"user_id": userID})
+			assert.NoError(t, err)
+			assert.Equal(t, int64(0), count, collectionName)
+		}
+		count, err := database.GetUserCollection(api.DB).CountDocuments(context.Background(), bson.M{"_id": userID})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+
+		for _, collectionName := range []string{"magic_links", "waitlist"} {
+			count, err = api.DB.Collection(collectionName).CountDocuments(context.Background(), bson.M{"email": "test_account_delete@resonant-kelpie-404a42.netlify.app"})
+			assert.NoError(t, err)
+			assert.Equal(t, int64(0), count, collectionName)
+		}
+		count, err = database.GetDashboardDataPointCollection(api.DB).CountDocuments(context.Background(), bson.M{"team_id": team.ID})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+
+		// the organization stays with its other admin
+		var organization database.Organization
+		err = database.GetOrganizationCollection(api.DB).FindOne(context.Background(), bson.M{"_id": orgID}).Decode(&organization)
+		assert.NoError(t, err)
+		assert.Equal(t, []primitive.ObjectID{otherUserID}, organization.AdminUserIDs)
+
+		// log events are kept but no longer reference the user
+		count, err = database.GetLogEventsCollection(api.DB).CountDocuments(context.Background(), bson.M{"event_type": "test_event"})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+		count, err = database.GetLogEventsCollection(api.DB).CountDocuments(context.Background(), bson.M{"user_id": userID})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+
+		// views of the other user's task are kept without the viewer
+		count, err = database.GetShareViewCollection(api.DB).CountDocuments(context.Background(), bson.M{"user_id": otherUserID})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+		count, err = database.GetShareViewCollection(api.DB).CountDocuments(context.Background(), bson.M{"viewer_user_id": userID})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+
+		// only the google login token supports revocation
+		assert.Equal(t, 1, revokeCalls)
+
+		var record database.AccountDeletionRecord
+		err = database.GetAccountDeletionCollection(api.DB).FindOne(context.Background(), bson.M{"user_id": userID}).Decode(&record)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{external.TASK_SERVICE_ID_GOOGLE}, record.RevokedServices)
+		assert.Equal(t, int64(1), record.DeletedCounts["tasks"])
+		assert.Equal(t, int64(1), record.DeletedCounts["users"])
+
+		// other users are untouched
+		count, err = database.GetTaskCollection(api.DB).CountDocuments(context.Background(), bson.M{"user_id": otherUserID})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		// the session is gone
+		ServeRequest(t, authToken, http.MethodGet, "/ping_authed/", nil, http.StatusUnauthorized, api)
+	})
+	t.Run("RevocationFailureDoesNotBlock", func(t *testing.T) {
+		api.ExternalConfig.RevokeOverrideURL = "http://localhost:1/revoke"
+		ServeRequest(t, otherAuthToken, http.MethodDelete, "/account/", nil, http.StatusOK, api)
+		count, err := database.GetUserCollection(api.DB).CountDocuments(context.Background(), bson.M{"_id": otherUserID})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+}
@@ -0,0 +1,248 @@
+package api
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/exp/slices"
+	"golang.org/x/oauth2"
+)
+
+var adminRoles = map[string]bool{
+	constants.UserRoleAdmin:   true,
+	constants.UserRoleSupport: true,
+}
+
+type AdminUserLookupParams struct {
+	Email string `form:"email" binding:"required"`
+}
+
+type AdminUserResult struct {
+	ID                  primitive.ObjectID `json:"id"`
+	Email               string             `json:"email"`
+	Name                string             `json:"name"`
+	Role                string             `json:"role,omitempty"`
+	CreatedAt           string             `json:"created_at,omitempty"`
+	LastRefreshed       string             `json:"last_refreshed,omitempty"`
+	BusinessModeEnabled bool               `json:"business_mode_enabled"`
+	GPTSuggestionsLeft  int                `json:"gpt_suggestions_left"`
+}
+
+type AdminLinkedAccountResult struct {
+	ID                  primitive.ObjectID `json:"id"`
+	ServiceID           string             `json:"service_id"`
+	AccountID           string             `json:"account_id"`
+	DisplayID           string             `json:"display_id"`
+	IsPrimaryLogin      bool               `json:"is_primary_login"`
+	HasBadToken         bool               `json:"has_bad_token"`
+	HasRefreshToken     bool               `json:"has_refresh_token"`
+	TokenExpiry         string             `json:"token_expiry,omitempty"`
+	LastFullRefreshTime string             `json:"last_full_refresh_time,omitempty"`
+	Scopes              []string           `json:"scopes"`
+}
+
+type AdminResyncResult struct {
+	FailedSourceIDs []string `json:"failed_source_ids"`
+}
+
+type AdminImpersonateResult struct {
+	Token     string `json:"token"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+// AdminUserLookup finds a user by their email, so support can use their ID with the other admin endpoints
+func (api *API) AdminUserLookup(c *gin.Context) {
+	var params AdminUserLookupParams
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	user, err := database.GetUserByEmail(c.Request.Context(), api.DB, params.Email)
+	if err != nil {
+		HandleError(c, ErrorCodeNotFound, "user not found")
+		return
+	}
+	c.JSON(200, getAdminUserResult(user))
+}
+
+func (api *API) AdminUserGet(c *gin.Context) {
+	user, ok := api.getAdminTargetUser(c)
+	if !ok {
+		return
+	}
+	c.JSON(200, getAdminUserResult(user))
+}
+
+// AdminLinkedAccountsList shows the health of the user's linked account tokens, without the tokens themselves
+func (api *API) AdminLinkedAccountsList(c *gin.Context) {
+	user, ok := api.getAdminTargetUser(c)
+	if !ok {
+		return
+	}
+	var tokens []database.ExternalAPIToken
+	err := database.FindWithCollection(c.Request.Context(), database.GetExternalTokenCollection(api.DB), user.ID, nil, &tokens, nil)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch api tokens")
+		Handle500(c)
+		return
+	}
+	results := []AdminLinkedAccountResult{}
+	for _, token := range tokens {
+		result := AdminLinkedAccountResult{
+			ID:                  token.ID,
+			ServiceID:           token.ServiceID,
+			AccountID:           token.AccountID,
+			DisplayID:           token.DisplayID,
+			IsPrimaryLogin:      token.IsPrimaryLogin,
+			HasBadToken:         token.IsBadToken,
+			LastFullRefreshTime: formatSessionTime(token.LastFullRefreshTime),
+			Scopes:              token.Scopes,
+		}
+		if result.Scopes == nil {
+			result.Scopes = []string{}
+		}
+		// tokens which aren't from an oauth2 flow have no expiry or refresh token to report
+		var oauthToken oauth2.Token
+		if json.Unmarshal([]byte(token.Token), &oauthToken) == nil {
+			result.HasRefreshToken = oauthToken.RefreshToken != ""
+			if !oauthToken.Expiry.IsZero() {
+				result.TokenExpiry = oauthToken.Expiry.UTC().Format(time.RFC3339)
+			}
+		}
+		results = append(results, result)
+	}
+	c.JSON(200, results)
+}
+
+// AdminUserResync refetches the tasks and pull requests from each of the user's linked services, as
+// if they had refreshed every view
+func (api *API) AdminUserResync(c *gin.Context) {
+	user, ok := api.getAdminTargetUser(c)
+	if !ok {
+		return
+	}
+	var tokens []database.ExternalAPIToken
+	err := database.FindWithCollection(c.Request.Context(), database.GetExternalTokenCollection(api.DB), user.ID, nil, &tokens, nil)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch api tokens")
+		Handle500(c)
+		return
+	}
+	serviceIDs := []string{external.TASK_SERVICE_ID_GT}
+	for _, token := range tokens {
+		if !slices.Contains(serviceIDs, token.ServiceID) {
+			serviceIDs = append(serviceIDs, token.ServiceID)
+		}
+	}
+
+	failedFetchSources := make(map[string]bool)
+	for _, serviceID := range serviceIDs {
+		serviceFailedFetchSources, err := api.refreshServiceItems(c.Request.Context(), user.ID, serviceID)
+		if err != nil {
+			api.Logger.Error().Err(err).Str("serviceID", serviceID).Msg("failed to resync service")
+			Handle500(c)
+			return
+		}
+		for sourceID, failed := range serviceFailedFetchSources {
+			failedFetchSources[sourceID] = failedFetchSources[sourceID] || failed
+		}
+	}
+	err = api.updateViewsLastFetched(c.Request.Context(), user.ID, serviceIDs, failedFetchSources)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	api.recordAuditEvent(c, user.ID, database.AuditLog{EventType: constants.AuditEventResynced, ActorID: getUserIDFromContext(c)})
+
+	result := AdminResyncResult{FailedSourceIDs: []string{}}
+	for sourceID, failed := range failedFetchSources {
+		if failed {
+			result.FailedSourceIDs = append(result.FailedSourceIDs, sourceID)
+		}
+	}
+	sort.Strings(result.FailedSourceIDs)
+	c.JSON(200, result)
+}
+
+// AdminGPTQuotaReset gives the user back their full daily quota of GPT suggestions
+func (api *API) AdminGPTQuotaReset(c *gin.Context) {
+	user, ok := api.getAdminTargetUser(c)
+	if !ok {
+		return
+	}
+	_, err := database.GetUserCollection(api.DB).UpdateOne(
+		c.Request.Context(),
+		bson.M{"_id": user.ID},
+		bson.M{"$set": bson.M{"gpt_suggestions_left": constants.MAX_OVERVIEW_SUGGESTION}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to reset gpt quota")
+		Handle500(c)
+		return
+	}
+	api.recordAuditEvent(c, user.ID, database.AuditLog{EventType: constants.AuditEventGPTQuotaReset, ActorID: getUserIDFromContext(c)})
+	c.JSON(200, gin.H{"gpt_suggestions_left": constants.MAX_OVERVIEW_SUGGESTION})
+}
+
+// AdminImpersonate starts a read-only session as the user for debugging. Only admins can impersonate,
+// and the session shows up in the user's audit log
+func (api *API) AdminImpersonate(c *gin.Context) {
+	if c.GetString("admin_role") != constants.UserRoleAdmin {
+		HandleError(c, ErrorCodeForbidden, "only admins can impersonate users")
+		return
+	}
+	user, ok := api.getAdminTargetUser(c)
+	if !ok {
+		return
+	}
+	adminID := getUserIDFromContext(c)
+	if user.ID == adminID {
+		HandleBadRequest(c, "cannot impersonate yourself")
+		return
+	}
+	internalToken, err := database.CreateImpersonationToken(c.Request.Context(), api.DB, user.ID, adminID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	api.recordAuditEvent(c, user.ID, database.AuditLog{EventType: constants.AuditEventImpersonated, ActorID: adminID})
+	c.JSON(201, AdminImpersonateResult{
+		Token:     internalToken.Token,
+		ExpiresAt: formatSessionTime(internalToken.ExpiresAt),
+	})
+}
+
+func (api *API) getAdminTargetUser(c *gin.Context) (*database.User, bool) {
+	userID, err := primitive.ObjectIDFromHex(c.Param("user_id"))
+	if err != nil {
+		Handle404(c)
+		return nil, false
+	}
+	user, err := database.GetUser(c.Request.Context(), api.DB, userID)
+	if err != nil {
+		HandleError(c, ErrorCodeNotFound, "user not found")
+		return nil, false
+	}
+	return user, true
+}
+
+func getAdminUserResult(user *database.User) AdminUserResult {
+	return AdminUserResult{
+		ID:                  user.ID,
+		Email:               user.Email,
+		Name:                user.Name,
+		Role:                user.Role,
+		CreatedAt:           formatSessionTime(user.CreatedAt),
+		LastRefreshed:       formatSessionTime(user.LastRefreshed),
+		BusinessModeEnabled: user.BusinessModeEnabled != nil && *user.BusinessModeEnabled,
+		GPTSuggestionsLeft:  user.GPTSuggestionsLeft,
+	}
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestAdmin(t *testing.T) {
+	adminToken := login("test_admin@resonant-kelpie-404a42.netlify.app", "")
+	supportToken := login("test_admin_support@resonant-kelpie-404a42.netlify.app", "")
+	userToken := login("test_admin_user@resonant-kelpie-404a42.netlify.app", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	adminID := getUserIDFromAuthToken(t, api.DB, adminToken)
+	supportID := getUserIDFromAuthToken(t, api.DB, supportToken)
+	userID := getUserIDFromAuthToken(t, api.DB, userToken)
+
+	setRole := func(t *testing.T, userID primitive.ObjectID, role string) {
+		_, err := database.GetUserCollection(api.DB).UpdateOne(context.Background(), bson.M{"_id": userID}, bson.M{"$set": bson.M{"role": role}})
+		assert.NoError(t, err)
+	}
+	setRole(t, adminID, constants.UserRoleAdmin)
+	setRole(t, supportID, constants.UserRoleSupport)
+	userURL := "/admin/users/" + userID.Hex() + "/"
+
+	UnauthorizedTest(t, http.MethodGet, userURL, nil)
+	t.Run("NotAdmin", func(t *testing.T) {
+		response := ServeRequest(t, userToken, http.MethodGet, userURL, nil, http.StatusForbidden, api)
+		assert.Equal(t, `{"detail":"admin access is required to use this endpoint","code":"forbidden"}`, string(response))
+	})
+	t.Run("Lookup", func(t *testing.T) {
+		ServeRequest(t, supportToken, http.MethodGet, "/admin/users/", nil, http.StatusBadRequest, api)
+		ServeRequest(t, supportToken, http.MethodGet, "/admin/users/?email=nobody@resonant-kelpie-404a42.netlify.app", nil, http.StatusNotFound, api)
+		response := ServeRequest(t, supportToken, http.MethodGet, "/admin/users/?email=test_admin_user@resonant-kelpie-404a42.netlify.app", nil, http.StatusOK, api)
+		var result AdminUserResult
+		assert.NoError(t, json.Unmarshal(response, &result))
+		assert.Equal(t, userID, result.ID)
+		assert.Equal(t, "test_admin_user@resonant-kelpie-404a42.netlify.app", result.Email)
+	})
+	t.Run("GetUser", func(t *testing.T) {
+		ServeRequest(t, supportToken, http.MethodGet, "/admin/users/oops/", nil, http.StatusNotFound, api)
+		ServeRequest(t, supportToken, http.MethodGet, "/admin/users/"+primitive.NewObjectID().Hex()+"/", nil, http.StatusNotFound, api)
+		response := ServeRequest(t, supportToken, http.MethodGet, userURL, nil, http.StatusOK, api)
+		var result AdminUserResult
+		assert.NoError(t, json.Unmarshal(response, &result))
+		assert.Equal(t, userID, result.ID)
+	})
+	t.Run("LinkedAccounts", func(t *testing.T) {
+		response := ServeRequest(t, supportToken, http.MethodGet, userURL+"linked_accounts/", nil, http.StatusOK, api)
+		var result []AdminLinkedAccountResult
+		assert.NoError(t, json.Unmarshal(response, &result))
+		assert.Equal(t, 1, len(result))
+		assert.Equal(t, external.TASK_SERVICE_ID_GOOGLE, result[0].ServiceID)
+		assert.True(t, result[0].IsPrimaryLogin)
+		assert.False(t, result[0].HasBadToken)
+		assert.NotContains(t, string(response), "access_token")
+	})
+	t.Run("GPTQuotaReset", func(t *testing.T) {
+		_, err := database.GetUserCollection(api.DB).UpdateOne(context.Background(), bson.M{"_id": userID}, bson.M{"$set": bson.M{"gpt_suggestions_left": 0}})
+		assert.NoError(t, err)
+		ServeRequest(t, supportToken, http.MethodPost, userURL+"gpt_quota/reset/", nil, http.StatusOK, api)
+		user, err := database.GetUser(context.Background(), api.DB, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, constants.MAX_OVERVIEW_SUGGESTION, user.GPTSuggestionsLeft)
+
+		var auditLogs []database.AuditLog
+		err = database.FindWithCollection(context.Background(), database.GetAuditLogCollection(api.DB), userID, &[]bson.M{{"event_type": constants.AuditEventGPTQuotaReset}}, &auditLogs, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(auditLogs))
+		assert.Equal(t, supportID, auditLogs[0].ActorID)
+	})
+	t.Run("Impersonate", func(t *testing.T) {
+		response := ServeRequest(t, supportToken, http.MethodPost, userURL+"impersonate/", nil, http.StatusForbidden, api)
+		assert.Equal(t, `{"detail":"only admins can impersonate users","code":"forbidden"}`, string(response))
+		ServeRequest(t, adminToken, http.MethodPost, "/admin/users/"+adminID.Hex()+"/impersonate/", nil, http.StatusBadRequest, api)
+
+		response = ServeRequest(t, adminToken, http.MethodPost, userURL+"impersonate/", nil, http.StatusCreated, api)
+		var result AdminImpersonateResult
+		assert.NoError(t, json.Unmarshal(response, &result))
+		assert.NotEmpty(t, result.Token)
+
+		// the session acts as the user, but can't change anything
+		ServeRequest(t, result.Token, http.MethodGet, "/tasks/v4/", nil, http.StatusOK, api)
+		response = ServeRequest(t, result.Token, http.MethodPatch, "/tasks/modify/"+primitive.NewObjectID().Hex()+"/", nil, http.StatusForbidden, api)
+		assert.Equal(t, `{"detail":"impersonation sessions are read-only","code":"forbidden"}`, string(response))
+
+		// nor can it be used to reach the admin endpoints, even when the user is an admin
+		setRole(t, userID, constants.UserRoleAdmin)
+		ServeRequest(t, result.Token, http.MethodGet, userURL, nil, http.StatusForbidden, api)
+		setRole(t, userID, "")
+
+		response = ServeRequest(t, userToken, http.MethodGet, "/security/audit_log/", nil, http.StatusOK, api)
+		assert.Contains(t, string(response), `"event_type":"impersonated"`)
+		assert.Contains(t, string(response), `"is_support_action":true`)
+	})
+}
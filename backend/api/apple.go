@@ -0,0 +1,43 @@
+package api
+
+import (
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/gin-gonic/gin"
+)
+
+type AppleLinkParams struct {
+	AppleID             string `json:"apple_id" binding:"required"`
+	AppSpecificPassword string `json:"app_specific_password" binding:"required"`
+}
+
+// LinkApple godoc
+// @Summary      Links an iCloud account with an app-specific password
+// @Description  Syncs the account's Apple Reminders. iCloud doesn't offer oauth, so the password is created by the user at appleid.apple.com
+// @Tags         linked_accounts
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      AppleLinkParams  true "Apple ID and app-specific password"
+// @Success      200 {object} string
+// @Failure      400 {object} string "invalid or missing parameter, or iCloud rejected the credentials"
+// @Failure      500 {object} string "internal server error"
+// @Router       /link/apple/app_password/ [post]
+func (api *API) LinkApple(c *gin.Context) {
+	var params AppleLinkParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	apple := external.AppleService{Config: api.ExternalConfig.Apple}
+	err = apple.LinkAppSpecificPassword(api.DB, getUserIDFromContext(c), params.AppleID, params.AppSpecificPassword)
+	if err == external.ErrInvalidAppleCredentials {
+		HandleBadRequest(c, "iCloud rejected the Apple ID or app-specific password")
+		return
+	}
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to link apple account")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
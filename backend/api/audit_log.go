@@ -0,0 +1,81 @@
+package api
+
+import (
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AuditLogResult struct {
+	ID         primitive.ObjectID `json:"id"`
+	EventType  string             `json:"event_type"`
+	ServiceID  string             `json:"service_id,omitempty"`
+	AccountID  string             `json:"account_id,omitempty"`
+	ResourceID string             `json:"resource_id,omitempty"`
+	IPAddress  string             `json:"ip_address,omitempty"`
+	UserAgent  string             `json:"user_agent,omitempty"`
+	// the event was caused by support, e.g. through impersonation
+	IsSupportAction bool   `json:"is_support_action,omitempty"`
+	CreatedAt       string `json:"created_at"`
+}
+
+func (api *API) AuditLogList(c *gin.Context) {
+	pagination, err := getPagination(c)
+	if err != nil {
+		HandleBadRequest(c, err.Error())
+		return
+	}
+	if pagination == nil {
+		pagination = &database.Pagination{}
+	}
+	userID := getUserIDFromContext(c)
+	auditLogs, nextCursor, err := database.FindPageWithCollection[database.AuditLog](
+		c.Request.Context(),
+		database.GetAuditLogCollection(api.DB),
+		userID,
+		nil,
+		*pagination,
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch audit logs")
+		Handle500(c)
+		return
+	}
+	results := []AuditLogResult{}
+	for _, auditLog := range auditLogs {
+		result := AuditLogResult{
+			ID:        auditLog.ID,
+			EventType: auditLog.EventType,
+			ServiceID: auditLog.ServiceID,
+			AccountID: auditLog.AccountID,
+			IPAddress: auditLog.IPAddress,
+			UserAgent: auditLog.UserAgent,
+			CreatedAt: auditLog.CreatedAt.Time().UTC().Format(time.RFC3339),
+		}
+		if auditLog.ActorID != primitive.NilObjectID {
+			result.IsSupportAction = true
+		}
+		if auditLog.ResourceID != primitive.NilObjectID {
+			result.ResourceID = auditLog.ResourceID.Hex()
+		}
+		results = append(results, result)
+	}
+	c.JSON(200, PaginatedResult[AuditLogResult]{
+		Results:    results,
+		NextCursor: nextCursor,
+	})
+}
+
+// recordAuditEvent attaches the request's client details to the audit log. Recording is best effort so
+// that an audit log outage doesn't lock users out
+func (api *API) recordAuditEvent(c *gin.Context, userID primitive.ObjectID, auditLog database.AuditLog) {
+	auditLog.UserID = userID
+	auditLog.IPAddress = c.ClientIP()
+	auditLog.UserAgent = c.Request.UserAgent()
+	err := database.InsertAuditLog(c.Request.Context(), api.DB, auditLog)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to record audit event")
+	}
+}
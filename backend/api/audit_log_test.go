@@ -0,0 +1,54 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestAuditLogList(t *testing.T) {
+	authToken := login("test_audit_log@resonant-kelpie-404a42.netlify.app", "")
+	otherToken := login("test_audit_log_other@resonant-kelpie-404a42.netlify.app", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+
+	title := "shared note"
+	insertResult, err := database.GetNoteCollection(api.DB).InsertOne(context.Background(), database.Note{UserID: userID, Title: &title})
+	assert.NoError(t, err)
+	noteID := insertResult.InsertedID.(primitive.ObjectID)
+
+	UnauthorizedTest(t, "GET", "/security/audit_log/", nil)
+	t.Run("RecordsEvents", func(t *testing.T) {
+		ServeRequest(t, authToken, "GET", "/export/", nil, http.StatusOK, api)
+		ServeRequest(t, authToken, "PATCH", "/notes/modify/"+noteID.Hex()+"/", bytes.NewBuffer([]byte(`{"shared_until": "9999-01-01T00:00:00Z", "shared_access": "public"}`)), http.StatusOK, api)
+
+		body := ServeRequest(t, authToken, "GET", "/security/audit_log/", nil, http.StatusOK, api)
+		var result PaginatedResult[AuditLogResult]
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, 2, len(result.Results))
+		// newest first
+		assert.Equal(t, constants.AuditEventSharedLinkCreated, result.Results[0].EventType)
+		assert.Equal(t, noteID.Hex(), result.Results[0].ResourceID)
+		assert.Equal(t, constants.AuditEventDataExported, result.Results[1].EventType)
+		assert.NotEmpty(t, result.Results[1].IPAddress)
+	})
+	t.Run("Paginated", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "GET", "/security/audit_log/?limit=1", nil, http.StatusOK, api)
+		var result PaginatedResult[AuditLogResult]
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, 1, len(result.Results))
+		assert.NotEmpty(t, result.NextCursor)
+	})
+	t.Run("OtherUser", func(t *testing.T) {
+		body := ServeRequest(t, otherToken, "GET", "/security/audit_log/", nil, http.StatusOK, api)
+		assert.Equal(t, `{"results":[]}`, string(body))
+	})
+}
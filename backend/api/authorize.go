@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/franchizzle/task-manager/backend/config"
+	"github.com/franchizzle/task-manager/backend/constants"
 	"github.com/franchizzle/task-manager/backend/database"
 	"github.com/franchizzle/task-manager/backend/external"
 	"github.com/franchizzle/task-manager/backend/logging"
@@ -45,7 +46,7 @@ func (api *API) Link(c *gin.Context) {
 	}
 	stateTokenID := primitive.NilObjectID
 	if taskService.Details.AuthType == external.AuthTypeOauth2 {
-		insertedStateToken, err := database.CreateStateToken(api.DB, &internalToken.UserID, false)
+		insertedStateToken, err := database.CreateStateToken(c.Request.Context(), api.DB, &internalToken.UserID, false)
 		if err != nil {
 			Handle500(c)
 			return
@@ -92,37 +93,52 @@ func (api *API) LinkCallback(c *gin.Context) {
 	if taskServiceResult.Details.AuthType == external.AuthTypeOauth1 {
 		var redirectParams Oauth1RedirectParams
 		if c.ShouldBind(&redirectParams) != nil || redirectParams.Token == "" || redirectParams.Verifier == "" {
-			c.JSON(400, gin.H{"detail": "missing query params"})
+			HandleBadRequest(c, "missing query params")
 			return
 		}
 		callbackParams = external.CallbackParams{Oauth1Token: &redirectParams.Token, Oauth1Verifier: &redirectParams.Verifier}
 	} else if taskServiceResult.Details.AuthType == external.AuthTypeOauth2 {
 		var redirectParams Oauth2RedirectParams
 		if c.ShouldBind(&redirectParams) != nil || redirectParams.Code == "" || redirectParams.State == "" {
-			c.JSON(400, gin.H{"detail": "missing query params"})
+			HandleBadRequest(c, "missing query params")
 			return
 		}
 		stateTokenID, err := primitive.ObjectIDFromHex(redirectParams.State)
 		if err != nil {
-			c.JSON(400, gin.H{"detail": "invalid state token format"})
+			HandleBadRequest(c, "invalid state token format")
 			return
 		}
-		err = database.DeleteStateToken(api.DB, stateTokenID, &internalToken.UserID)
+		stateToken, err := database.GetStateToken(c.Request.Context(), api.DB, stateTokenID, &internalToken.UserID)
 		if err != nil {
-			c.JSON(400, gin.H{"detail": "invalid state token"})
+			HandleBadRequest(c, "invalid state token")
+			return
+		}
+		err = database.DeleteStateToken(c.Request.Context(), api.DB, stateTokenID, &internalToken.UserID)
+		if err != nil {
+			HandleBadRequest(c, "invalid state token")
 			return
 		}
 		callbackParams = external.CallbackParams{Oauth2Code: &redirectParams.Code}
+		if stateToken.RelinkTokenID != primitive.NilObjectID {
+			api.relinkCallback(c, taskServiceResult, callbackParams, internalToken.UserID, stateToken.RelinkTokenID)
+			return
+		}
 	}
 	err = taskServiceResult.Service.HandleLinkCallback(api.DB, callbackParams, internalToken.UserID)
 	if err != nil {
-		c.JSON(500, gin.H{"detail": err.Error()})
+		HandleError(c, ErrorCodeInternal, err.Error())
 		return
 	}
+	api.recordAuditEvent(c, internalToken.UserID, database.AuditLog{EventType: constants.AuditEventAccountLinked, ServiceID: taskServiceResult.Details.ID})
+
+	writeLinkSuccess(c)
+}
 
-	_, err = c.Writer.Write([]byte("<html><head><script>window.open('','_parent','');window.close();</script></head><body>Success</body></html>"))
+// writeLinkSuccess closes the window the account was linked in
+func writeLinkSuccess(c *gin.Context) {
+	_, err := c.Writer.Write([]byte("<html><head><script>window.open('','_parent','');window.close();</script></head><body>Success</body></html>"))
 	if err != nil {
-		c.JSON(500, gin.H{"detail": err.Error()})
+		HandleError(c, ErrorCodeInternal, err.Error())
 		return
 	}
 	c.Status(200)
@@ -153,7 +169,7 @@ func (api *API) LinkSlackApp(c *gin.Context) {
 	// don't need to check for errors on the bind because state will not be included (which will throw error)
 	if redirectParams.Code == "" {
 		logger.Error().Msg("invalid oauth params")
-		c.JSON(500, gin.H{"detail": "invalid oauth params"})
+		HandleError(c, ErrorCodeInternal, "invalid oauth params")
 		return
 	}
 
@@ -161,9 +177,9 @@ func (api *API) LinkSlackApp(c *gin.Context) {
 	_, err = slackService.Config.OauthConfig.Exchange(context.Background(), redirectParams.Code)
 	if err != nil {
 		logger.Error().Err(err).Msg("unable to exchange Slack app oauth keys")
-		c.JSON(500, gin.H{"detail": err.Error()})
+		HandleError(c, ErrorCodeInternal, err.Error())
 		return
 	}
 
-	c.Redirect(302, config.GetConfigValue("HOME_URL"))
+	c.Redirect(302, config.GetSettings().HomeURL)
 }
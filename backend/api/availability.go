@@ -0,0 +1,151 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/config"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/focustime"
+	"github.com/gin-gonic/gin"
+	guuid "github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	AVAILABILITY_MAX_RANGE_DAYS   = 31
+	AVAILABILITY_MIN_DURATION     = 5
+	AVAILABILITY_MAX_DURATION     = 8 * 60
+	AVAILABILITY_DEFAULT_DURATION = 30
+)
+
+type AvailabilityParams struct {
+	Start    *time.Time `form:"start" binding:"required"`
+	End      *time.Time `form:"end" binding:"required"`
+	Duration *int       `form:"duration"`
+}
+
+type AvailabilitySlot struct {
+	DatetimeStart time.Time `json:"datetime_start"`
+	DatetimeEnd   time.Time `json:"datetime_end"`
+}
+
+type AvailabilityLinkResult struct {
+	URL       string `json:"url"`
+	CreatedAt string `json:"created_at"`
+}
+
+func getAvailabilityLinkURL(secret string) string {
+	return config.GetSettings().ServerURL + "booking/" + secret + "/"
+}
+
+func getAvailabilityLinkResult(link database.AvailabilityLink) AvailabilityLinkResult {
+	return AvailabilityLinkResult{
+		URL:       getAvailabilityLinkURL(link.Secret),
+		CreatedAt: link.CreatedAt.Time().UTC().Format(time.RFC3339),
+	}
+}
+
+// AvailabilityGet returns the user's free slots of at least 'duration' minutes across all of their calendars
+func (api *API) AvailabilityGet(c *gin.Context) {
+	api.writeAvailability(c, getUserIDFromContext(c))
+}
+
+// AvailabilityShared is unauthenticated; possession of the link secret grants access to the user's
+// free slots, but not to any details of their events
+func (api *API) AvailabilityShared(c *gin.Context) {
+	link, err := database.GetAvailabilityLinkBySecret(c.Request.Context(), api.DB, c.Param("secret"))
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			Handle404(c)
+		} else {
+			Handle500(c)
+		}
+		return
+	}
+	api.writeAvailability(c, link.UserID)
+}
+
+func (api *API) writeAvailability(c *gin.Context, userID primitive.ObjectID) {
+	var params AvailabilityParams
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	if !params.End.After(*params.Start) {
+		HandleBadRequest(c, "'end' must be after 'start'", "end", "start")
+		return
+	}
+	if params.End.Sub(*params.Start) > AVAILABILITY_MAX_RANGE_DAYS*24*time.Hour {
+		HandleBadRequest(c, fmt.Sprintf("range must be at most %d days", AVAILABILITY_MAX_RANGE_DAYS))
+		return
+	}
+	duration := AVAILABILITY_DEFAULT_DURATION
+	if params.Duration != nil {
+		duration = *params.Duration
+	}
+	if duration < AVAILABILITY_MIN_DURATION || duration > AVAILABILITY_MAX_DURATION {
+		HandleBadRequest(c, fmt.Sprintf("'duration' must be between %d and %d", AVAILABILITY_MIN_DURATION, AVAILABILITY_MAX_DURATION), "duration")
+		return
+	}
+
+	events, err := focustime.GetEventsBetween(c.Request.Context(), api.DB, userID, *params.Start, *params.End)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch events for availability")
+		Handle500(c)
+		return
+	}
+	slots := []AvailabilitySlot{}
+	for _, window := range focustime.GetFreeSlots(events, *params.Start, *params.End, time.Duration(duration)*time.Minute) {
+		slots = append(slots, AvailabilitySlot{DatetimeStart: window.Start, DatetimeEnd: window.End})
+	}
+	c.JSON(200, slots)
+}
+
+// AvailabilityLinkCreate opts the user in to sharing their availability, returning their existing
+// link if they already have one
+func (api *API) AvailabilityLinkCreate(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	link, err := database.GetOrCreateAvailabilityLink(c.Request.Context(), api.DB, userID, guuid.New().String(), api.GetCurrentTime())
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	c.JSON(201, getAvailabilityLinkResult(*link))
+}
+
+func (api *API) AvailabilityLinkGet(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	var link database.AvailabilityLink
+	err := database.GetAvailabilityLinkCollection(api.DB).FindOne(c.Request.Context(), bson.M{"user_id": userID}).Decode(&link)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			Handle404(c)
+		} else {
+			api.Logger.Error().Err(err).Msg("failed to fetch availability link")
+			Handle500(c)
+		}
+		return
+	}
+	c.JSON(200, getAvailabilityLinkResult(link))
+}
+
+// AvailabilityLinkDelete opts the user out; the old link stops working, and opting in again creates a new one
+func (api *API) AvailabilityLinkDelete(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	deleteResult, err := database.GetAvailabilityLinkCollection(api.DB).DeleteOne(context.Background(), bson.M{"user_id": userID})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to delete availability link")
+		Handle500(c)
+		return
+	}
+	if deleteResult.DeletedCount == 0 {
+		Handle404(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestAvailability(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+
+	authToken := login("test_availability@resonant-kelpie-404a42.netlify.app", "")
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+
+	start := time.Date(2023, time.March, 1, 9, 0, 0, 0, time.UTC)
+	for _, accountID := range []string{"work@test.com", "personal@test.com"} {
+		_, err := database.GetCalendarEventCollection(api.DB).InsertOne(context.Background(), database.CalendarEvent{
+			UserID:          userID,
+			SourceAccountID: accountID,
+			Title:           "secret meeting",
+			DatetimeStart:   primitive.NewDateTimeFromTime(start.Add(time.Hour)),
+			DatetimeEnd:     primitive.NewDateTimeFromTime(start.Add(2 * time.Hour)),
+		})
+		assert.NoError(t, err)
+	}
+	_, err := database.GetCalendarEventCollection(api.DB).InsertOne(context.Background(), database.CalendarEvent{
+		UserID:          userID,
+		SourceAccountID: "personal@test.com",
+		Title:           "dentist",
+		DatetimeStart:   primitive.NewDateTimeFromTime(start.Add(150 * time.Minute)),
+		DatetimeEnd:     primitive.NewDateTimeFromTime(start.Add(3 * time.Hour)),
+	})
+	assert.NoError(t, err)
+
+	query := "?start=" + url.QueryEscape(start.Format(time.RFC3339)) + "&end=" + url.QueryEscape(start.Add(4*time.Hour).Format(time.RFC3339))
+	expectedSlots := []AvailabilitySlot{
+		{DatetimeStart: start, DatetimeEnd: start.Add(time.Hour)},
+		{DatetimeStart: start.Add(3 * time.Hour), DatetimeEnd: start.Add(4 * time.Hour)},
+	}
+	serveShared := func(path string, expectedStatus int) []byte {
+		router := GetRouter(api)
+		request, _ := http.NewRequest(http.MethodGet, path, nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, expectedStatus, recorder.Code)
+		return recorder.Body.Bytes()
+	}
+
+	UnauthorizedTest(t, http.MethodGet, "/availability/", nil)
+	UnauthorizedTest(t, http.MethodPost, "/availability/link/", nil)
+	t.Run("MissingParams", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodGet, "/availability/", nil, http.StatusBadRequest, api)
+	})
+	t.Run("InvalidRange", func(t *testing.T) {
+		invalidQuery := "?start=" + url.QueryEscape(start.Format(time.RFC3339)) + "&end=" + url.QueryEscape(start.Format(time.RFC3339))
+		body := ServeRequest(t, authToken, http.MethodGet, "/availability/"+invalidQuery, nil, http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"'end' must be after 'start'","code":"invalid_parameter","field_errors":[{"field":"end"},{"field":"start"}]}`, string(body))
+	})
+	t.Run("InvalidDuration", func(t *testing.T) {
+		body := ServeRequest(t, authToken, http.MethodGet, "/availability/"+query+"&duration=1", nil, http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"'duration' must be between 5 and 480","code":"invalid_parameter","field_errors":[{"field":"duration"}]}`, string(body))
+	})
+	t.Run("Success", func(t *testing.T) {
+		body := ServeRequest(t, authToken, http.MethodGet, "/availability/"+query, nil, http.StatusOK, api)
+		var slots []AvailabilitySlot
+		assert.NoError(t, json.Unmarshal(body, &slots))
+		assert.Equal(t, len(expectedSlots), len(slots))
+		for idx, slot := range slots {
+			assert.True(t, expectedSlots[idx].DatetimeStart.Equal(slot.DatetimeStart))
+			assert.True(t, expectedSlots[idx].DatetimeEnd.Equal(slot.DatetimeEnd))
+		}
+	})
+	t.Run("ShortDurationIncludesGap", func(t *testing.T) {
+		body := ServeRequest(t, authToken, http.MethodGet, "/availability/"+query+"&duration=30", nil, http.StatusOK, api)
+		var slots []AvailabilitySlot
+		assert.NoError(t, json.Unmarshal(body, &slots))
+		assert.Equal(t, 3, len(slots))
+	})
+	t.Run("LinkNotCreated", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodGet, "/availability/link/", nil, http.StatusNotFound, api)
+	})
+
+	var link AvailabilityLinkResult
+	t.Run("LinkCreate", func(t *testing.T) {
+		body := ServeRequest(t, authToken, http.MethodPost, "/availability/link/", nil, http.StatusCreated, api)
+		assert.NoError(t, json.Unmarshal(body, &link))
+		assert.Contains(t, link.URL, "/booking/")
+
+		// opting in again keeps the same link
+		body = ServeRequest(t, authToken, http.MethodPost, "/availability/link/", nil, http.StatusCreated, api)
+		var secondLink AvailabilityLinkResult
+		assert.NoError(t, json.Unmarshal(body, &secondLink))
+		assert.Equal(t, link.URL, secondLink.URL)
+
+		body = ServeRequest(t, authToken, http.MethodGet, "/availability/link/", nil, http.StatusOK, api)
+		assert.NoError(t, json.Unmarshal(body, &secondLink))
+		assert.Equal(t, link.URL, secondLink.URL)
+	})
+	linkPath := link.URL[strings.Index(link.URL, "/booking/"):]
+	t.Run("Shared", func(t *testing.T) {
+		body := serveShared(linkPath+query, http.StatusOK)
+		assert.NotContains(t, string(body), "secret meeting")
+		var slots []AvailabilitySlot
+		assert.NoError(t, json.Unmarshal(body, &slots))
+		assert.Equal(t, len(expectedSlots), len(slots))
+	})
+	t.Run("SharedInvalidSecret", func(t *testing.T) {
+		serveShared("/booking/not-a-secret/"+query, http.StatusNotFound)
+	})
+	t.Run("LinkDelete", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodDelete, "/availability/link/", nil, http.StatusOK, api)
+		ServeRequest(t, authToken, http.MethodDelete, "/availability/link/", nil, http.StatusNotFound, api)
+		serveShared(linkPath+query, http.StatusNotFound)
+	})
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/gin-gonic/gin"
+)
+
+type AzureDevOpsTokenLinkParams struct {
+	Organization        string `json:"organization" binding:"required"`
+	PersonalAccessToken string `json:"personal_access_token" binding:"required"`
+}
+
+// LinkAzureDevOpsToken godoc
+// @Summary      Links an Azure DevOps organization with a personal access token
+// @Description  For organizations which can't be linked through Entra. The token needs read & write access to work items and read access to code
+// @Tags         linked_accounts
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      AzureDevOpsTokenLinkParams  true "organization and token"
+// @Success      200 {object} string
+// @Failure      400 {object} string "invalid or missing parameter, or the token can't access the organization"
+// @Failure      500 {object} string "internal server error"
+// @Router       /link/azure_devops/token/ [post]
+func (api *API) LinkAzureDevOpsToken(c *gin.Context) {
+	var params AzureDevOpsTokenLinkParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	azureDevOps := external.AzureDevOpsService{Config: api.ExternalConfig.AzureDevOps}
+	err = azureDevOps.LinkPersonalAccessToken(api.DB, getUserIDFromContext(c), params.Organization, params.PersonalAccessToken)
+	if err == external.ErrInvalidAzureDevOpsToken {
+		HandleBadRequest(c, "personal access token can't access the organization")
+		return
+	}
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to link azure devops organization")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
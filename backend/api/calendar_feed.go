@@ -0,0 +1,271 @@
+package api
+
+import (
+	"strings"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/config"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	guuid "github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	CALENDAR_FEED_LOOKBACK_DAYS  = 30
+	CALENDAR_FEED_LOOKAHEAD_DAYS = 90
+	ICS_DATETIME_FORMAT          = "20060102T150405Z"
+	ICS_DATE_FORMAT              = "20060102"
+	ICS_MAX_LINE_LENGTH          = 75
+)
+
+type CalendarFeedResult struct {
+	ID        string `json:"id"`
+	URL       string `json:"url"`
+	CreatedAt string `json:"created_at"`
+}
+
+func getCalendarFeedURL(secret string) string {
+	return config.GetSettings().ServerURL + "feeds/" + secret + "/calendar.ics"
+}
+
+func getCalendarFeedResult(feed database.CalendarFeed) CalendarFeedResult {
+	return CalendarFeedResult{
+		ID:        feed.ID.Hex(),
+		URL:       getCalendarFeedURL(feed.Secret),
+		CreatedAt: feed.CreatedAt.Time().UTC().Format(time.RFC3339),
+	}
+}
+
+// CalendarFeedCreate godoc
+// @Summary      Creates a calendar feed
+// @Description  The feed URL can be subscribed to from other calendar apps, and shows the user's events and tasks with due dates
+// @Tags         calendars
+// @Produce      json
+// @Success      201 {object} CalendarFeedResult
+// @Failure      500 {object} string "internal server error"
+// @Router       /calendar_feeds/ [post]
+func (api *API) CalendarFeedCreate(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	feed := database.CalendarFeed{
+		ID:        primitive.NewObjectID(),
+		UserID:    userID,
+		Secret:    guuid.New().String(),
+		CreatedAt: primitive.NewDateTimeFromTime(api.GetCurrentTime()),
+	}
+	_, err := database.GetCalendarFeedCollection(api.DB).InsertOne(c.Request.Context(), feed)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to create calendar feed")
+		Handle500(c)
+		return
+	}
+	c.JSON(201, getCalendarFeedResult(feed))
+}
+
+// CalendarFeedsList godoc
+// @Summary      Lists the user's calendar feeds
+// @Description  Oldest first
+// @Tags         calendars
+// @Produce      json
+// @Success      200 {array}  CalendarFeedResult
+// @Failure      500 {object} string "internal server error"
+// @Router       /calendar_feeds/ [get]
+func (api *API) CalendarFeedsList(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	var feeds []database.CalendarFeed
+	err := database.FindWithCollection(
+		c.Request.Context(),
+		database.GetCalendarFeedCollection(api.DB),
+		userID,
+		&[]bson.M{},
+		&feeds,
+		options.Find().SetSort(bson.M{"created_at": 1}),
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch calendar feeds")
+		Handle500(c)
+		return
+	}
+	results := []CalendarFeedResult{}
+	for _, feed := range feeds {
+		results = append(results, getCalendarFeedResult(feed))
+	}
+	c.JSON(200, results)
+}
+
+// CalendarFeedDelete godoc
+// @Summary      Deletes a calendar feed
+// @Description  Its URL stops working for any calendar apps subscribed to it
+// @Tags         calendars
+// @Produce      json
+// @Param        feed_id  path  string  true  "Calendar feed ID"
+// @Success      200 {object} string "success"
+// @Failure      404 {object} string "feed not found"
+// @Failure      500 {object} string "internal server error"
+// @Router       /calendar_feeds/{feed_id}/ [delete]
+func (api *API) CalendarFeedDelete(c *gin.Context) {
+	feedID, err := primitive.ObjectIDFromHex(c.Param("feed_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	userID := getUserIDFromContext(c)
+	deleteResult, err := database.GetCalendarFeedCollection(api.DB).DeleteOne(
+		c.Request.Context(),
+		bson.M{"$and": []bson.M{
+			{"_id": feedID},
+			{"user_id": userID},
+		}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to delete calendar feed")
+		Handle500(c)
+		return
+	}
+	if deleteResult.DeletedCount == 0 {
+		Handle404(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+// CalendarFeedICS godoc
+// @Summary      Returns a calendar feed in iCalendar format
+// @Description  Unauthenticated; possession of the feed secret grants read-only access. Covers events from the past 30 days to the next 90, and incomplete tasks with due dates
+// @Tags         calendars
+// @Produce      text/calendar
+// @Param        secret  path  string  true  "Calendar feed secret"
+// @Success      200 {string} string "iCalendar feed"
+// @Failure      404 {object} string "feed not found"
+// @Failure      500 {object} string "internal server error"
+// @Router       /feeds/{secret}/calendar.ics [get]
+func (api *API) CalendarFeedICS(c *gin.Context) {
+	feed, err := database.GetCalendarFeedBySecret(c.Request.Context(), api.DB, c.Param("secret"))
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			Handle404(c)
+		} else {
+			Handle500(c)
+		}
+		return
+	}
+
+	now := api.GetCurrentTime()
+	events, err := database.GetCalendarEvents(c.Request.Context(), api.DB, feed.UserID, &[]bson.M{
+		{"datetime_start": bson.M{"$gte": now.AddDate(0, 0, -CALENDAR_FEED_LOOKBACK_DAYS)}},
+		{"datetime_start": bson.M{"$lte": now.AddDate(0, 0, CALENDAR_FEED_LOOKAHEAD_DAYS)}},
+	})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch events for calendar feed")
+		Handle500(c)
+		return
+	}
+	tasks, err := database.GetTasks(c.Request.Context(), api.DB, feed.UserID, &[]bson.M{
+		{"is_completed": false},
+		{"is_deleted": bson.M{"$ne": true}},
+		{"due_date": bson.M{"$exists": true}},
+		{"due_date": bson.M{"$ne": primitive.NewDateTimeFromTime(time.Time{})}},
+	}, nil)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch tasks for calendar feed")
+		Handle500(c)
+		return
+	}
+
+	c.Header("Content-Disposition", "inline; filename=calendar.ics")
+	c.Data(200, "text/calendar; charset=utf-8", []byte(generateICS(*events, *tasks, now)))
+}
+
+func generateICS(events []database.CalendarEvent, tasks []database.Task, now time.Time) string {
+	var builder strings.Builder
+	dtstamp := now.UTC().Format(ICS_DATETIME_FORMAT)
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//General Task//Calendar Feed//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:General Task",
+	}
+	for _, event := range events {
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:event-"+event.ID.Hex()+"@generaltask.com",
+			"DTSTAMP:"+dtstamp,
+			"DTSTART:"+event.DatetimeStart.Time().UTC().Format(ICS_DATETIME_FORMAT),
+			"DTEND:"+event.DatetimeEnd.Time().UTC().Format(ICS_DATETIME_FORMAT),
+			"SUMMARY:"+escapeICSText(event.Title),
+		)
+		if event.Body != "" {
+			lines = append(lines, "DESCRIPTION:"+escapeICSText(event.Body))
+		}
+		if event.Location != "" {
+			lines = append(lines, "LOCATION:"+escapeICSText(event.Location))
+		}
+		if event.Deeplink != "" {
+			lines = append(lines, "URL:"+event.Deeplink)
+		}
+		lines = append(lines, "END:VEVENT")
+	}
+	for _, task := range tasks {
+		if task.DueDate == nil {
+			continue
+		}
+		title := ""
+		if task.Title != nil {
+			title = *task.Title
+		}
+		// due dates are stored as midnight UTC, so they are exported as all-day events
+		dueDate := task.DueDate.Time().UTC()
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:task-"+task.ID.Hex()+"@generaltask.com",
+			"DTSTAMP:"+dtstamp,
+			"DTSTART;VALUE=DATE:"+dueDate.Format(ICS_DATE_FORMAT),
+			"DTEND;VALUE=DATE:"+dueDate.AddDate(0, 0, 1).Format(ICS_DATE_FORMAT),
+			"SUMMARY:"+escapeICSText(title),
+			"TRANSP:TRANSPARENT",
+		)
+		if task.Deeplink != "" {
+			lines = append(lines, "URL:"+task.Deeplink)
+		}
+		lines = append(lines, "END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+	for _, line := range lines {
+		builder.WriteString(foldICSLine(line))
+	}
+	return builder.String()
+}
+
+func escapeICSText(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", "",
+	).Replace(text)
+}
+
+// foldICSLine splits content lines longer than 75 octets per RFC 5545 section 3.1
+func foldICSLine(line string) string {
+	var builder strings.Builder
+	lineLength := 0
+	for _, char := range line {
+		charLength := len(string(char))
+		if lineLength+charLength > ICS_MAX_LINE_LENGTH {
+			builder.WriteString("\r\n ")
+			// the leading space counts towards the folded line's length
+			lineLength = 1
+		}
+		builder.WriteRune(char)
+		lineLength += charLength
+	}
+	builder.WriteString("\r\n")
+	return builder.String()
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCalendarFeed(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+
+	authToken := login("test_calendar_feed@resonant-kelpie-404a42.netlify.app", "")
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	otherAuthToken := login("test_calendar_feed_other@resonant-kelpie-404a42.netlify.app", "")
+
+	testTime := time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)
+	api.OverrideTime = &testTime
+
+	notCompleted := false
+	taskTitle := "file taxes"
+	dueDate := primitive.NewDateTimeFromTime(time.Date(2023, time.March, 3, 0, 0, 0, 0, time.UTC))
+	_, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), database.Task{
+		UserID:      userID,
+		Title:       &taskTitle,
+		DueDate:     &dueDate,
+		IsCompleted: &notCompleted,
+	})
+	assert.NoError(t, err)
+	_, err = database.GetCalendarEventCollection(api.DB).InsertOne(context.Background(), database.CalendarEvent{
+		UserID:        userID,
+		Title:         "standup, daily",
+		DatetimeStart: primitive.NewDateTimeFromTime(testTime.Add(time.Hour)),
+		DatetimeEnd:   primitive.NewDateTimeFromTime(testTime.Add(90 * time.Minute)),
+	})
+	assert.NoError(t, err)
+
+	UnauthorizedTest(t, http.MethodPost, "/calendar_feeds/", nil)
+	UnauthorizedTest(t, http.MethodGet, "/calendar_feeds/", nil)
+
+	var feed CalendarFeedResult
+	t.Run("Create", func(t *testing.T) {
+		body := ServeRequest(t, authToken, http.MethodPost, "/calendar_feeds/", nil, http.StatusCreated, api)
+		assert.NoError(t, json.Unmarshal(body, &feed))
+		assert.True(t, strings.HasSuffix(feed.URL, "/calendar.ics"))
+	})
+	t.Run("List", func(t *testing.T) {
+		body := ServeRequest(t, authToken, http.MethodGet, "/calendar_feeds/", nil, http.StatusOK, api)
+		var feeds []CalendarFeedResult
+		assert.NoError(t, json.Unmarshal(body, &feeds))
+		assert.Equal(t, 1, len(feeds))
+		assert.Equal(t, feed.ID, feeds[0].ID)
+
+		body = ServeRequest(t, otherAuthToken, http.MethodGet, "/calendar_feeds/", nil, http.StatusOK, api)
+		assert.Equal(t, "[]", string(body))
+	})
+	feedPath := feed.URL[strings.Index(feed.URL, "/feeds/"):]
+	t.Run("FetchICS", func(t *testing.T) {
+		router := GetRouter(api)
+		request, _ := http.NewRequest(http.MethodGet, feedPath, nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "text/calendar; charset=utf-8", recorder.Header().Get("Content-Type"))
+		body := recorder.Body.String()
+		assert.Contains(t, body, "SUMMARY:standup\\, daily\r\n")
+		assert.Contains(t, body, "DTSTART:20230301T130000Z\r\n")
+		assert.Contains(t, body, "SUMMARY:file taxes\r\n")
+		assert.Contains(t, body, "DTSTART;VALUE=DATE:20230303\r\n")
+	})
+	t.Run("DeleteOtherUser", func(t *testing.T) {
+		ServeRequest(t, otherAuthToken, http.MethodDelete, "/calendar_feeds/"+feed.ID+"/", nil, http.StatusNotFound, api)
+	})
+	t.Run("Revoke", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodDelete, "/calendar_feeds/"+feed.ID+"/", nil, http.StatusOK, api)
+
+		router := GetRouter(api)
+		request, _ := http.NewRequest(http.MethodGet, feedPath, nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+	t.Run("UnknownSecret", func(t *testing.T) {
+		router := GetRouter(api)
+		request, _ := http.NewRequest(http.MethodGet, "/feeds/not-a-secret/calendar.ics", nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
+
+func TestGenerateICS(t *testing.T) {
+	now := time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)
+	t.Run("Empty", func(t *testing.T) {
+		ics := generateICS([]database.CalendarEvent{}, []database.Task{}, now)
+		assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+		assert.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+		assert.NotContains(t, ics, "BEGIN:VEVENT")
+	})
+	t.Run("TaskWithoutDueDateSkipped", func(t *testing.T) {
+		title := "no due date"
+		ics := generateICS([]database.CalendarEvent{}, []database.Task{{ID: primitive.NewObjectID(), Title: &title}}, now)
+		assert.NotContains(t, ics, "no due date")
+	})
+}
+
+func TestEscapeICSText(t *testing.T) {
+	assert.Equal(t, `a\, b\; c\\d\ne`, escapeICSText("a, b; c\\d\r\ne"))
+}
+
+func TestFoldICSLine(t *testing.T) {
+	t.Run("Short", func(t *testing.T) {
+		assert.Equal(t, "SUMMARY:hi\r\n", foldICSLine("SUMMARY:hi"))
+	})
+	t.Run("Long", func(t *testing.T) {
+		folded := foldICSLine("SUMMARY:" + strings.Repeat("a", 100))
+		lines := strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n")
+		assert.Equal(t, 2, len(lines))
+		assert.Equal(t, 75, len(lines[0]))
+		assert.Equal(t, " "+strings.Repeat("a", 33), lines[1])
+	})
+	t.Run("Multibyte", func(t *testing.T) {
+		folded := foldICSLine(strings.Repeat("é", 50))
+		for _, line := range strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n") {
+			assert.LessOrEqual(t, len(line), 75)
+		}
+	})
+}
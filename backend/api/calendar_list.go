@@ -19,6 +19,7 @@ type CalendarResult struct {
 	AccessRole      string `json:"access_role,omitempty"`
 	ColorBackground string `json:"color_background,omitempty"`
 	ColorForeground string `json:"color_foreground,omitempty"`
+	Synced          bool   `json:"synced"`
 }
 
 type CalendarAccountResult struct {
@@ -39,7 +40,7 @@ func (api *API) CalendarsList(c *gin.Context) {
 		return
 	}
 
-	calendarAccounts, err := database.GetCalendarAccounts(api.DB, userID)
+	calendarAccounts, err := database.GetCalendarAccounts(c.Request.Context(), api.DB, userID)
 	if err != nil {
 		Handle500(c)
 		return
@@ -57,6 +58,7 @@ func (api *API) CalendarsList(c *gin.Context) {
 				AccessRole: calendar.AccessRole,
         ColorBackground: calendar.ColorBackground,
 				ColorForeground: calendar.ColorForeground,
+				Synced:          !calendar.SyncDisabled,
 			}
 			calendars = append(calendars, calendarResult)
 
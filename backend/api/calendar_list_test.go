@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"testing"
@@ -18,6 +19,7 @@ func TestCalendarList(t *testing.T) {
 	userID := getUserIDFromAuthToken(t, db, authToken)
 	notUserID := primitive.NewObjectID()
 	_, err = database.UpdateOrCreateCalendarAccount(
+		context.Background(),
 		db,
 		userID,
 		"123abc",
@@ -38,6 +40,7 @@ func TestCalendarList(t *testing.T) {
 	)
 	assert.NoError(t, err)
 	_, err = database.UpdateOrCreateCalendarAccount(
+		context.Background(),
 		db,
 		userID,
 		"123def",
@@ -65,6 +68,7 @@ func TestCalendarList(t *testing.T) {
 	)
 	assert.NoError(t, err)
 	_, err = database.UpdateOrCreateCalendarAccount(
+		context.Background(),
 		db,
 		userID,
 		"123abc",
@@ -84,6 +88,7 @@ func TestCalendarList(t *testing.T) {
 	)
 	assert.NoError(t, err)
 	_, err = database.UpdateOrCreateCalendarAccount(
+		context.Background(),
 		db,
 		userID,
 		"123def",
@@ -118,9 +123,9 @@ func TestCalendarList(t *testing.T) {
 		assert.Equal(t, 3, len(result))
 
 		assert.Equal(t, []CalendarAccountResult{
-			{AccountID: "360-no-scope", Calendars: []CalendarResult{{CalendarID: "cal1", ColorID: "col1", Title: "title1", CanWrite: true, AccessRole: "owner"}}, HasMulticalScope: false, HasPrimaryCalendarScope: false},
-			{AccountID: "account2", Calendars: []CalendarResult{{CalendarID: "cal2", ColorID: "col2", Title: "title2", CanWrite: false, AccessRole: "reader"}, {CalendarID: "cal3", ColorID: "col3", Title: "title3", CanWrite: true, AccessRole: "writer"}}, HasMulticalScope: true, HasPrimaryCalendarScope: false},
-			{AccountID: "single-cal", Calendars: []CalendarResult{{CalendarID: "cal2", ColorID: "col2", Title: "title2", CanWrite: false, AccessRole: "reader"}}, HasMulticalScope: false, HasPrimaryCalendarScope: true},
+			{AccountID: "360-no-scope", Calendars: []CalendarResult{{CalendarID: "cal1", ColorID: "col1", Title: "title1", CanWrite: true, AccessRole: "owner", Synced: true}}, HasMulticalScope: false, HasPrimaryCalendarScope: false},
+			{AccountID: "account2", Calendars: []CalendarResult{{CalendarID: "cal2", ColorID: "col2", Title: "title2", CanWrite: false, AccessRole: "reader", Synced: true}, {CalendarID: "cal3", ColorID: "col3", Title: "title3", CanWrite: true, AccessRole: "writer", Synced: true}}, HasMulticalScope: true, HasPrimaryCalendarScope: false},
+			{AccountID: "single-cal", Calendars: []CalendarResult{{CalendarID: "cal2", ColorID: "col2", Title: "title2", CanWrite: false, AccessRole: "reader", Synced: true}}, HasMulticalScope: false, HasPrimaryCalendarScope: true},
 		},
 			result)
 	})
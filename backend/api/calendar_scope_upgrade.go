@@ -0,0 +1,77 @@
+package api
+
+import (
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"golang.org/x/exp/slices"
+)
+
+type CalendarScopeUpgradeParams struct {
+	AccountID string `json:"account_id" binding:"required"`
+}
+
+// CalendarScopeUpgrade godoc
+// @Summary      Starts granting access to all of a Google account's calendars
+// @Description  Only the missing scope is asked for, and the grant is added to the existing account on callback rather than linking it again
+// @Tags         calendars
+// @Accept       json
+// @Produce      json
+// @Param        account_id   body     string  true "calendar account ID"
+// @Success      200 {object} RelinkLinkedAccountResult
+// @Failure      400 {object} string "invalid params, or the account already has the scope"
+// @Failure      404 {object} string "account not found"
+// @Failure      500 {object} string "internal server error"
+// @Router       /calendars/upgrade_scopes/ [post]
+func (api *API) CalendarScopeUpgrade(c *gin.Context) {
+	var params CalendarScopeUpgradeParams
+	err := c.ShouldBindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing 'account_id' parameter.", "account_id")
+		return
+	}
+	api.startGoogleScopeUpgrade(c, params.AccountID, external.GOOGLE_MULTI_CALENDAR_SCOPE, "account already has access to all calendars")
+}
+
+// startGoogleScopeUpgrade responds with the URL for granting the scope to the user's Google account
+func (api *API) startGoogleScopeUpgrade(c *gin.Context, accountID string, scope string, alreadyGrantedMessage string) {
+	userID := getUserIDFromContext(c)
+	externalToken, err := api.getUserGoogleToken(c, accountID)
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	if slices.Contains(externalToken.Scopes, scope) {
+		HandleBadRequest(c, alreadyGrantedMessage)
+		return
+	}
+	taskServiceResult, err := api.ExternalConfig.GetTaskServiceResult(external.TASK_SERVICE_ID_GOOGLE)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch task service")
+		Handle500(c)
+		return
+	}
+	// the callback relinks the account, which replaces its token in place
+	stateTokenID, err := database.CreateRelinkStateToken(c.Request.Context(), api.DB, userID, externalToken.ID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	googleService := taskServiceResult.Service.(external.GoogleService)
+	authURL := googleService.GetScopeUpgradeURL(stateTokenID, externalToken.AccountID, scope)
+	c.JSON(200, RelinkLinkedAccountResult{AuthorizationURL: authURL})
+}
+
+// getUserGoogleToken returns the token of the user's Google account
+func (api *API) getUserGoogleToken(c *gin.Context, accountID string) (*database.ExternalAPIToken, error) {
+	var externalToken database.ExternalAPIToken
+	err := database.GetExternalTokenCollection(api.DB).FindOne(
+		c.Request.Context(),
+		bson.M{"user_id": getUserIDFromContext(c), "service_id": external.TASK_SERVICE_ID_GOOGLE, "account_id": accountID},
+	).Decode(&externalToken)
+	if err != nil {
+		return nil, err
+	}
+	return &externalToken, nil
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"golang.org/x/oauth2"
+)
+
+func TestCalendarScopeUpgrade(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	email := "calendar_scope_upgrade@resonant-kelpie-404a42.netlify.app"
+	authToken := login(email, "")
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	_, err := database.GetCalendarAccountCollection(api.DB).InsertOne(context.Background(), database.CalendarAccount{
+		UserID:     userID,
+		IDExternal: email,
+		SourceID:   external.TASK_SOURCE_ID_GCAL,
+		Scopes:     []string{"https://www.googleapis.com/auth/calendar.events"},
+	})
+	assert.NoError(t, err)
+	upgradeBody := func(accountID string) io.Reader {
+		return bytes.NewBuffer([]byte(`{"account_id": "` + accountID + `"}`))
+	}
+
+	t.Run("MissingAccountID", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPost, "/calendars/upgrade_scopes/", bytes.NewBuffer([]byte(`{}`)), http.StatusBadRequest, api)
+	})
+	t.Run("AccountNotFound", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPost, "/calendars/upgrade_scopes/", upgradeBody("someone_else@resonant-kelpie-404a42.netlify.app"), http.StatusNotFound, api)
+	})
+	t.Run("Success", func(t *testing.T) {
+		response := ServeRequest(t, authToken, http.MethodPost, "/calendars/upgrade_scopes/", upgradeBody(email), http.StatusOK, api)
+		var result RelinkLinkedAccountResult
+		assert.NoError(t, json.Unmarshal(response, &result))
+		authURL, err := url.Parse(result.AuthorizationURL)
+		assert.NoError(t, err)
+		assert.Equal(t, external.GOOGLE_MULTI_CALENDAR_SCOPE, authURL.Query().Get("scope"))
+		assert.Equal(t, "true", authURL.Query().Get("include_granted_scopes"))
+		assert.Equal(t, email, authURL.Query().Get("login_hint"))
+
+		mockConfig := MockGoogleConfig{}
+		mockToken := oauth2.Token{AccessToken: "upgraded-token", RefreshToken: "test123"}
+		mockConfig.On("Exchange", mock.Anything, "code1234").Return(&mockToken, nil)
+		mockClient := MockHTTPClient{}
+		mockClient.On("Get", "https://www.googleapis.com/oauth2/v3/userinfo").Return(
+			&http.Response{Body: io.NopCloser(bytes.NewBufferString(fmt.Sprintf(`{"sub": "goog12345_%s", "email": "%s"}`, email, email)))},
+			nil,
+		)
+		mockClient.On("Get", "https://www.googleapis.com/oauth2/v1/tokeninfo?access_token=upgraded-token").Return(
+			&http.Response{Body: io.NopCloser(bytes.NewBufferString(`{"scope": "https://www.googleapis.com/auth/userinfo.email https://www.googleapis.com/auth/calendar.events https://www.googleapis.com/auth/calendar"}`))},
+			nil,
+		)
+		mockConfig.On("Client", mock.Anything, &mockToken).Return(&mockClient)
+		api.ExternalConfig.GoogleAuthorizeConfig = &mockConfig
+
+		request, _ := http.NewRequest(http.MethodGet, "/link/google/callback/?code=code1234&state="+authURL.Query().Get("state"), nil)
+		request.AddCookie(&http.Cookie{Name: "authToken", Value: authToken})
+		recorder := httptest.NewRecorder()
+		GetRouter(api).ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		tokens, err := database.GetExternalTokens(context.Background(), api.DB, userID, external.TASK_SERVICE_ID_GOOGLE)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(*tokens))
+		googleToken := (*tokens)[0]
+		assert.True(t, database.HasUserGrantedMultiCalendarScope(googleToken.Scopes))
+		// the account is still the one signed in with
+		assert.True(t, googleToken.IsPrimaryLogin)
+		assert.False(t, googleToken.IsUnlinkable)
+
+		var calendarAccount database.CalendarAccount
+		assert.NoError(t, database.GetCalendarAccountCollection(api.DB).FindOne(context.Background(), bson.M{"user_id": userID, "id_external": email}).Decode(&calendarAccount))
+		assert.True(t, database.HasUserGrantedMultiCalendarScope(calendarAccount.Scopes))
+	})
+	t.Run("AlreadyGranted", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPost, "/calendars/upgrade_scopes/", upgradeBody(email), http.StatusBadRequest, api)
+	})
+	UnauthorizedTest(t, http.MethodPost, "/calendars/upgrade_scopes/", nil)
+}
+
+func TestGoogleTasksScopeUpgrade(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	email := "google_tasks_scope_upgrade@resonant-kelpie-404a42.netlify.app"
+	authToken := login(email, "")
+	upgradeBody := func(accountID string) io.Reader {
+		return bytes.NewBuffer([]byte(`{"account_id": "` + accountID + `"}`))
+	}
+
+	t.Run("MissingAccountID", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPost, "/google_tasks/upgrade_scopes/", bytes.NewBuffer([]byte(`{}`)), http.StatusBadRequest, api)
+	})
+	t.Run("AccountNotFound", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPost, "/google_tasks/upgrade_scopes/", upgradeBody("someone_else@resonant-kelpie-404a42.netlify.app"), http.StatusNotFound, api)
+	})
+	t.Run("Success", func(t *testing.T) {
+		response := ServeRequest(t, authToken, http.MethodPost, "/google_tasks/upgrade_scopes/", upgradeBody(email), http.StatusOK, api)
+		var result RelinkLinkedAccountResult
+		assert.NoError(t, json.Unmarshal(response, &result))
+		authURL, err := url.Parse(result.AuthorizationURL)
+		assert.NoError(t, err)
+		assert.Equal(t, external.GOOGLE_TASKS_SCOPE, authURL.Query().Get("scope"))
+		assert.Equal(t, "true", authURL.Query().Get("include_granted_scopes"))
+	})
+	UnauthorizedTest(t, http.MethodPost, "/google_tasks/upgrade_scopes/", nil)
+}
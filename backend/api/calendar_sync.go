@@ -0,0 +1,46 @@
+package api
+
+import (
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type CalendarSyncModifyParams struct {
+	AccountID  string `json:"account_id" binding:"required"`
+	CalendarID string `json:"calendar_id" binding:"required"`
+	Synced     *bool  `json:"synced" binding:"required"`
+}
+
+// CalendarSyncModify godoc
+// @Summary      Turns syncing of a calendar on or off
+// @Description  Events from calendars that aren't synced are left out of the calendar, e.g. for subscribed holiday calendars
+// @Tags         calendars
+// @Accept       json
+// @Produce      json
+// @Param        account_id   body     string  true "calendar account ID"
+// @Param        calendar_id  body     string  true "calendar ID"
+// @Param        synced       body     bool    true "whether the calendar's events are synced"
+// @Success      200 {object} string "success"
+// @Failure      400 {object} string "invalid params"
+// @Failure      404 {object} string "calendar not found"
+// @Failure      500 {object} string "internal server error"
+// @Router       /settings/calendar_sync/ [patch]
+func (api *API) CalendarSyncModify(c *gin.Context) {
+	var params CalendarSyncModifyParams
+	err := c.ShouldBindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter.")
+		return
+	}
+	userID := getUserIDFromContext(c)
+	err = database.SetCalendarSyncDisabled(c.Request.Context(), api.DB, userID, params.AccountID, params.CalendarID, !*params.Synced)
+	if err == mongo.ErrNoDocuments {
+		Handle404(c)
+		return
+	} else if err != nil {
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
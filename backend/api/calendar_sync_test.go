@@ -0,0 +1,66 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalendarSyncModify(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	authToken := login("calendar_sync_modify@resonant-kelpie-404a42.netlify.app", "")
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	_, err := database.UpdateOrCreateCalendarAccount(
+		context.Background(),
+		api.DB,
+		userID,
+		"account1",
+		external.TASK_SOURCE_ID_GCAL,
+		&database.CalendarAccount{
+			UserID:     userID,
+			IDExternal: "account1",
+			Calendars: []database.Calendar{
+				{CalendarID: "cal1", AccessRole: "owner"},
+				{CalendarID: "holidays", AccessRole: "reader"},
+			},
+		},
+		nil,
+	)
+	assert.NoError(t, err)
+	getSynced := func(t *testing.T) map[string]bool {
+		response := ServeRequest(t, authToken, http.MethodGet, "/calendars/", nil, http.StatusOK, api)
+		var result []CalendarAccountResult
+		assert.NoError(t, json.Unmarshal(response, &result))
+		synced := map[string]bool{}
+		for _, account := range result {
+			for _, calendar := range account.Calendars {
+				synced[calendar.CalendarID] = calendar.Synced
+			}
+		}
+		return synced
+	}
+
+	t.Run("MissingParams", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPatch, "/settings/calendar_sync/", bytes.NewBuffer([]byte(`{"account_id": "account1", "calendar_id": "holidays"}`)), http.StatusBadRequest, api)
+		ServeRequest(t, authToken, http.MethodPatch, "/settings/calendar_sync/", bytes.NewBuffer([]byte(`{"account_id": "account1", "synced": false}`)), http.StatusBadRequest, api)
+	})
+	t.Run("CalendarNotFound", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPatch, "/settings/calendar_sync/", bytes.NewBuffer([]byte(`{"account_id": "account1", "calendar_id": "cal2", "synced": false}`)), http.StatusNotFound, api)
+		ServeRequest(t, authToken, http.MethodPatch, "/settings/calendar_sync/", bytes.NewBuffer([]byte(`{"account_id": "account2", "calendar_id": "cal1", "synced": false}`)), http.StatusNotFound, api)
+	})
+	t.Run("Success", func(t *testing.T) {
+		assert.Equal(t, map[string]bool{"cal1": true, "holidays": true}, getSynced(t))
+		ServeRequest(t, authToken, http.MethodPatch, "/settings/calendar_sync/", bytes.NewBuffer([]byte(`{"account_id": "account1", "calendar_id": "holidays", "synced": false}`)), http.StatusOK, api)
+		assert.Equal(t, map[string]bool{"cal1": true, "holidays": false}, getSynced(t))
+		ServeRequest(t, authToken, http.MethodPatch, "/settings/calendar_sync/", bytes.NewBuffer([]byte(`{"account_id": "account1", "calendar_id": "holidays", "synced": true}`)), http.StatusOK, api)
+		assert.Equal(t, map[string]bool{"cal1": true, "holidays": true}, getSynced(t))
+	})
+	UnauthorizedTest(t, http.MethodPatch, "/settings/calendar_sync/", nil)
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"strings"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// notifyMentionedUsers enqueues a copy of the notification for every user mentioned in the comment body
+func (api *API) notifyMentionedUsers(ctx context.Context, userID primitive.ObjectID, body string, notification database.Notification) error {
+	mentions := utils.ExtractMentions(body)
+	if len(mentions) == 0 {
+		return nil
+	}
+	mentionedUserIDs, err := api.resolveMentions(ctx, userID, mentions)
+	if err != nil {
+		return err
+	}
+	notifications := []database.Notification{}
+	for _, mentionedUserID := range mentionedUserIDs {
+		userNotification := notification
+		userNotification.UserID = mentionedUserID
+		userNotification.SenderUserID = userID
+		userNotification.Body = body
+		notifications = append(notifications, userNotification)
+	}
+	return database.EnqueueNotifications(ctx, api.DB, notifications)
+}
+
+// resolveMentions looks up mentions against the user's dashboard teammates, then against users who
+// share their (non open email provider) email domain. Unknown mentions and self mentions are dropped
+func (api *API) resolveMentions(ctx context.Context, userID primitive.ObjectID, mentions []string) ([]primitive.ObjectID, error) {
+	user, err := database.GetUser(ctx, api.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+	teammates, err := database.GetDashboardTeammatesByEmail(ctx, api.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+	domain, err := database.GetEmailDomain(user.Email)
+	if err != nil || utils.IsOpenEmailAddress(domain) {
+		domain = ""
+	}
+
+	mentionedUserIDs := []primitive.ObjectID{}
+	seen := map[primitive.ObjectID]bool{userID: true}
+	for _, mention := range mentions {
+		mentionedUserID, err := api.resolveMention(ctx, mention, teammates, domain)
+		if err != nil {
+			return nil, err
+		}
+		if mentionedUserID == primitive.NilObjectID || seen[mentionedUserID] {
+			continue
+		}
+		seen[mentionedUserID] = true
+		mentionedUserIDs = append(mentionedUserIDs, mentionedUserID)
+	}
+	return mentionedUserIDs, nil
+}
+
+func (api *API) resolveMention(ctx context.Context, mention string, teammates map[string]primitive.ObjectID, domain string) (primitive.ObjectID, error) {
+	var email string
+	if utils.IsEmailMention(mention) {
+		if teammateID, exists := teammates[mention]; exists {
+			return teammateID, nil
+		}
+		email = mention
+	} else {
+		// usernames match the local part of a teammate's email
+		for teammateEmail, teammateID := range teammates {
+			if strings.SplitN(teammateEmail, "@", 2)[0] == mention {
+				return teammateID, nil
+			}
+		}
+		email = mention + "@" + domain
+	}
+	if domain == "" || !strings.HasSuffix(email, "@"+domain) {
+		return primitive.NilObjectID, nil
+	}
+	mentionedUser, err := database.GetUserByEmail(ctx, api.DB, email)
+	if err == mongo.ErrNoDocuments {
+		return primitive.NilObjectID, nil
+	}
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	return mentionedUser.ID, nil
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestResolveMentions(t *testing.T) {
+	authToken := login("test_mentions@resonant-kelpie-404a42.netlify.app", "")
+	coworkerToken := login("test_mentions_coworker@resonant-kelpie-404a42.netlify.app", "")
+	teammateToken := login("test_mentions_teammate@gmail.com", "")
+	outsiderToken := login("test_mentions_outsider@gmail.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	coworkerID := getUserIDFromAuthToken(t, api.DB, coworkerToken)
+	teammateID := getUserIDFromAuthToken(t, api.DB, teammateToken)
+	getUserIDFromAuthToken(t, api.DB, outsiderToken)
+
+	team, err := database.GetOrCreateDashboardTeam(context.Background(), api.DB, userID)
+	assert.NoError(t, err)
+	_, err = database.GetDashboardTeamMemberCollection(api.DB).InsertOne(context.Background(), database.DashboardTeamMember{
+		TeamID:       team.ID,
+		UserID:       teammateID,
+		Email:        "test_mentions_teammate@gmail.com",
+		Role:         constants.DashboardTeamRoleMember,
+		InviteStatus: constants.DashboardTeamInviteStatusAccepted,
+	})
+	assert.NoError(t, err)
+
+	t.Run("SameDomainUsername", func(t *testing.T) {
+		userIDs, err := api.resolveMentions(context.Background(), userID, []string{"test_mentions_coworker"})
+		assert.NoError(t, err)
+		assert.Equal(t, []primitive.ObjectID{coworkerID}, userIDs)
+	})
+	t.Run("TeammateUsernameAndEmail", func(t *testing.T) {
+		userIDs, err := api.resolveMentions(context.Background(), userID, []string{"test_mentions_teammate", "test_mentions_teammate@gmail.com"})
+		assert.NoError(t, err)
+		assert.Equal(t, []primitive.ObjectID{teammateID}, userIDs)
+	})
+	t.Run("OutsiderIgnored", func(t *testing.T) {
+		userIDs, err := api.resolveMentions(context.Background(), userID, []string{"test_mentions_outsider@gmail.com", "test_mentions_outsider", "nobody"})
+		assert.NoError(t, err)
+		assert.Equal(t, []primitive.ObjectID{}, userIDs)
+	})
+	t.Run("SelfIgnored", func(t *testing.T) {
+		userIDs, err := api.resolveMentions(context.Background(), userID, []string{"test_mentions"})
+		assert.NoError(t, err)
+		assert.Equal(t, []primitive.ObjectID{}, userIDs)
+	})
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const CONTACTS_MAX_RESULTS = 100
+
+type ContactsListParams struct {
+	// only contacts whose email starts with this, for attendee autocomplete
+	Query string `form:"q"`
+}
+
+type contact struct {
+	Email        string             `bson:"_id"`
+	MeetingCount int                `bson:"meeting_count"`
+	LastMetAt    primitive.DateTime `bson:"last_met_at"`
+}
+
+type ContactResult struct {
+	Email        string `json:"email"`
+	MeetingCount int    `json:"meeting_count"`
+	LastMetAt    string `json:"last_met_at"`
+}
+
+// ContactsList godoc
+// @Summary      Lists the people the user meets with
+// @Description  Built from the attendees of the user's past events, most frequently met first
+// @Tags         contacts
+// @Produce      json
+// @Param        q  query  string  false "email prefix to filter by"
+// @Success      200 {array} ContactResult
+// @Failure      500 {object} string "internal server error"
+// @Router       /contacts/ [get]
+func (api *API) ContactsList(c *gin.Context) {
+	var params ContactsListParams
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	userID := getUserIDFromContext(c)
+	user, err := database.GetUser(c.Request.Context(), api.DB, userID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	calendarAccounts, err := database.GetCalendarAccounts(c.Request.Context(), api.DB, userID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	// the user shows up as an attendee of their own events, under any of their accounts
+	ownEmails := []string{user.Email}
+	for _, calendarAccount := range *calendarAccounts {
+		ownEmails = append(ownEmails, calendarAccount.IDExternal)
+	}
+
+	contacts, err := api.getContacts(c.Request.Context(), userID, ownEmails, strings.TrimSpace(params.Query), api.GetCurrentTime())
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to aggregate contacts")
+		Handle500(c)
+		return
+	}
+	results := []ContactResult{}
+	for _, contact := range contacts {
+		results = append(results, ContactResult{
+			Email:        contact.Email,
+			MeetingCount: contact.MeetingCount,
+			LastMetAt:    formatSessionTime(contact.LastMetAt),
+		})
+	}
+	c.JSON(200, results)
+}
+
+func (api *API) getContacts(ctx context.Context, userID primitive.ObjectID, ownEmails []string, query string, now time.Time) ([]contact, error) {
+	attendeeFilter := bson.M{"$nin": ownEmails}
+	if query != "" {
+		attendeeFilter["$regex"] = primitive.Regex{Pattern: "^" + regexp.QuoteMeta(query), Options: "i"}
+	}
+	// Only events that have already started count as meeting someone
+	matchStage := bson.D{
+		{Key: "$match", Value: bson.D{
+			{Key: "user_id", Value: userID},
+			{Key: "datetime_start", Value: bson.D{{Key: "$lte", Value: now}}},
+			{Key: "attendee_emails.0", Value: bson.D{{Key: "$exists", Value: true}}},
+		}},
+	}
+	unwindStage := bson.D{{Key: "$unwind", Value: "$attendee_emails"}}
+	attendeeMatchStage := bson.D{{Key: "$match", Value: bson.D{{Key: "attendee_emails", Value: attendeeFilter}}}}
+	// Group by attendee and event first, so an event on more than one of the user's calendars
+	// is only counted once
+	groupEventStage := bson.D{
+		{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "email", Value: "$attendee_emails"},
+				{Key: "id_external", Value: "$id_external"},
+				{Key: "source_id", Value: "$source_id"},
+			}},
+			{Key: "datetime_start", Value: bson.D{{Key: "$max", Value: "$datetime_start"}}},
+		}},
+	}
+	groupAttendeeStage := bson.D{
+		{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$_id.email"},
+			{Key: "meeting_count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "last_met_at", Value: bson.D{{Key: "$max", Value: "$datetime_start"}}},
+		}},
+	}
+	sortStage := bson.D{
+		{Key: "$sort", Value: bson.D{
+			{Key: "meeting_count", Value: -1},
+			{Key: "last_met_at", Value: -1},
+			{Key: "_id", Value: 1},
+		}},
+	}
+	limitStage := bson.D{{Key: "$limit", Value: CONTACTS_MAX_RESULTS}}
+
+	pipeline := mongo.Pipeline{matchStage, unwindStage, attendeeMatchStage, groupEventStage, groupAttendeeStage, sortStage, limitStage}
+	cursor, err := database.GetCalendarEventCollection(api.DB).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var contacts []contact
+	if err = cursor.All(ctx, &contacts); err != nil {
+		return nil, err
+	}
+	return contacts, nil
+}
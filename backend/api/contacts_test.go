@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestContactsList(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	email := "contacts_list@resonant-kelpie-404a42.netlify.app"
+	authToken := login(email, "")
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	now := time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)
+	api.OverrideTime = &now
+	_, err := database.GetCalendarAccountCollection(api.DB).InsertOne(context.Background(), database.CalendarAccount{
+		UserID:     userID,
+		IDExternal: "personal@resonant-kelpie-404a42.netlify.app",
+		SourceID:   external.TASK_SOURCE_ID_GCAL,
+	})
+	assert.NoError(t, err)
+	insertEvent := func(t *testing.T, userID primitive.ObjectID, idExternal string, calendarID string, startedAgo time.Duration, attendeeEmails ...string) {
+		_, err := database.GetCalendarEventCollection(api.DB).InsertOne(context.Background(), database.CalendarEvent{
+			UserID:         userID,
+			IDExternal:     idExternal,
+			SourceID:       external.TASK_SOURCE_ID_GCAL,
+			CalendarID:     calendarID,
+			DatetimeStart:  primitive.NewDateTimeFromTime(now.Add(-startedAgo)),
+			DatetimeEnd:    primitive.NewDateTimeFromTime(now.Add(-startedAgo + 30*time.Minute)),
+			AttendeeEmails: attendeeEmails,
+		})
+		assert.NoError(t, err)
+	}
+	insertEvent(t, userID, "standup1", "work", 48*time.Hour, email, "jane@test.com", "bob@test.com")
+	insertEvent(t, userID, "standup2", "work", 24*time.Hour, email, "jane@test.com", "bob@test.com")
+	// the same event on a second calendar only counts once
+	insertEvent(t, userID, "standup2", "personal", 24*time.Hour, "personal@resonant-kelpie-404a42.netlify.app", "jane@test.com", "bob@test.com")
+	insertEvent(t, userID, "lunch", "work", time.Hour, email, "jane@test.com")
+	insertEvent(t, userID, "coffee", "work", 72*time.Hour, email, "alice@test.com")
+	// upcoming events haven't been met yet
+	insertEvent(t, userID, "planning", "work", -time.Hour, email, "alice@test.com", "carol@test.com")
+	insertEvent(t, primitive.NewObjectID(), "other_user", "work", time.Hour, "dave@test.com")
+
+	getContacts := func(t *testing.T, url string) []ContactResult {
+		response := ServeRequest(t, authToken, http.MethodGet, url, nil, http.StatusOK, api)
+		var result []ContactResult
+		assert.NoError(t, json.Unmarshal(response, &result))
+		return result
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		assert.Equal(t, []ContactResult{
+			{Email: "jane@test.com", MeetingCount: 3, LastMetAt: "2023-03-01T11:00:00Z"},
+			{Email: "bob@test.com", MeetingCount: 2, LastMetAt: "2023-02-28T12:00:00Z"},
+			{Email: "alice@test.com", MeetingCount: 1, LastMetAt: "2023-02-26T12:00:00Z"},
+		}, getContacts(t, "/contacts/"))
+	})
+	t.Run("Autocomplete", func(t *testing.T) {
+		assert.Equal(t, []ContactResult{
+			{Email: "bob@test.com", MeetingCount: 2, LastMetAt: "2023-02-28T12:00:00Z"},
+		}, getContacts(t, "/contacts/?q=BO"))
+		assert.Equal(t, []ContactResult{}, getContacts(t, "/contacts/?q=.*"))
+	})
+	UnauthorizedTest(t, http.MethodGet, "/contacts/", nil)
+}
@@ -117,13 +117,13 @@ func (api *API) DailyTaskCompletionList(c *gin.Context) {
 	var dailyTaskCompletionParams DailyTaskCompletionParams
 	err := c.BindQuery(&dailyTaskCompletionParams)
 	if err != nil {
-		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		HandleBindError(c, &dailyTaskCompletionParams, err, "invalid or missing parameter")
 		return
 	}
 	userID := getUserIDFromContext(c)
 	result, err := api.GetDailyTaskCompletionList(userID, *dailyTaskCompletionParams.DatetimeStart, *dailyTaskCompletionParams.DatetimeEnd)
 	if err != nil {
-		c.JSON(500, gin.H{"detail": "internal server error"})
+		Handle500(c)
 		return
 	}
 	c.JSON(200, result)
@@ -129,18 +129,18 @@ func TestDailyTaskCompletionList(t *testing.T) {
 	UnauthorizedTest(t, http.MethodGet, "/daily_task_completion/", nil)
 	t.Run("MissingStartDate", func(t *testing.T) {
 		body := ServeRequest(t, authToken, http.MethodGet, "/daily_task_completion/?datetime_end=2023-03-01T00:00:00.000-04:00", nil, http.StatusBadRequest, api)
-		assert.Equal(t, `{"detail":"invalid or missing parameter"}`, string(body))
+		assert.Equal(t, `{"detail":"'datetime_start' is required","code":"invalid_parameter","field_errors":[{"field":"datetime_start","message":"'datetime_start' is required"}]}`, string(body))
 	})
 	t.Run("MissingEndDate", func(t *testing.T) {
 		body := ServeRequest(t, authToken, http.MethodGet, "/daily_task_completion/?datetime_start=2023-03-01T00:00:00.000-04:00", nil, http.StatusBadRequest, api)
-		assert.Equal(t, `{"detail":"invalid or missing parameter"}`, string(body))
+		assert.Equal(t, `{"detail":"'datetime_end' is required","code":"invalid_parameter","field_errors":[{"field":"datetime_end","message":"'datetime_end' is required"}]}`, string(body))
 	})
 	t.Run("BadParameter", func(t *testing.T) {
 		params := url.Values{}
 		params.Add("datetime_start", "bad")
 		params.Add("datetime_end", "bad")
 		body := ServeRequest(t, authToken, http.MethodGet, "/daily_task_completion/?datetime_start=bad&datetime_end=bad", nil, http.StatusBadRequest, api)
-		assert.Equal(t, `{"detail":"invalid or missing parameter"}`, string(body))
+		assert.Equal(t, `{"detail":"invalid or missing parameter","code":"bad_request"}`, string(body))
 	})
 	t.Run("NoResults", func(t *testing.T) {
 		params := url.Values{}
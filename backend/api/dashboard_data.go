@@ -85,6 +85,9 @@ const INDUSTRY_WEEKLY_AVERAGE = "Weekly average (Industry)"
 
 const GRAPH_NAME_GITHUB_PR = "Code review response time"
 const GRAPH_NAME_FOCUS_TIME = "Hours per day in big blocks"
+const GRAPH_NAME_GITHUB_PR_CYCLE_TIME = "Pull request cycle time"
+const GRAPH_NAME_GITHUB_PR_MERGE_COUNT = "Pull requests merged"
+const GRAPH_NAME_GITHUB_PR_REVIEW_TURNAROUND = "Review turnaround time"
 
 var GraphIDTeamPR = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}
 var GraphIDIndividualPR = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2}
@@ -100,15 +103,31 @@ var DataIDFocusTimeUserAverage = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0
 
 var SubjectIDTeam = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1}
 
+var GraphIDTeamPRCycleTime = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 2}
+var GraphIDIndividualPRCycleTime = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 3}
+var GraphIDTeamPRMergeCount = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 4}
+var GraphIDIndividualPRMergeCount = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 5}
+
+var DataIDPRCycleTimeTeamAverage = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 6}
+var DataIDPRCycleTimeUserAverage = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 7}
+var DataIDPRMergeCountTeamAverage = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 8}
+var DataIDPRMergeCountUserAverage = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 9}
+
+var GraphIDTeamPRReviewTurnaround = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2, 0}
+var GraphIDIndividualPRReviewTurnaround = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2, 1}
+
+var DataIDPRReviewTurnaroundTeamAverage = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2, 2}
+var DataIDPRReviewTurnaroundUserAverage = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2, 3}
+
 func (api *API) DashboardData(c *gin.Context) {
 	logger := logging.GetSentryLogger()
 	userID := getUserIDFromContext(c)
-	dashboardTeam, err := database.GetOrCreateDashboardTeam(api.DB, userID)
+	dashboardTeam, err := database.GetDashboardTeamForUser(c.Request.Context(), api.DB, userID)
 	if err != nil || dashboardTeam == nil {
 		Handle500(c)
 		return
 	}
-	dashboardTeamMembers, err := database.GetDashboardTeamMembers(api.DB, dashboardTeam.ID)
+	dashboardTeamMembers, err := database.GetDashboardTeamMembers(c.Request.Context(), api.DB, dashboardTeam.ID)
 	if err != nil || dashboardTeamMembers == nil {
 		Handle500(c)
 		return
@@ -116,7 +135,7 @@ func (api *API) DashboardData(c *gin.Context) {
 	// this lookback calculation is approximate for now, will refine as needed
 	now := api.GetCurrentTime()
 	lookbackDays := DEFAULT_LOOKBACK_DAYS + int(now.Weekday())
-	dashboardDataPoints, err := database.GetDashboardDataPoints(api.DB, dashboardTeam.ID, now, lookbackDays)
+	dashboardDataPoints, err := database.GetDashboardDataPoints(c.Request.Context(), api.DB, dashboardTeam.ID, now, lookbackDays)
 	if err != nil || dashboardDataPoints == nil {
 		Handle500(c)
 		return
@@ -128,15 +147,19 @@ func (api *API) DashboardData(c *gin.Context) {
 		ID:        SubjectIDTeam,
 		Name:      "Your Team",
 		Icon:      ICON_TEAM,
-		GraphIDs:  []primitive.ObjectID{GraphIDTeamFocusTime, GraphIDTeamPR},
+		GraphIDs:  []primitive.ObjectID{GraphIDTeamFocusTime, GraphIDTeamPR, GraphIDTeamPRCycleTime, GraphIDTeamPRMergeCount, GraphIDTeamPRReviewTurnaround},
 		IsDefault: true,
 	}}
 	for _, teamMember := range *dashboardTeamMembers {
+		// anonymized teams only expose team-level aggregates
+		if dashboardTeam.IsAnonymized {
+			break
+		}
 		subjects = append(subjects, DashboardSubject{
 			ID:       teamMember.ID,
 			Name:     teamMember.Name,
 			Icon:     ICON_USER,
-			GraphIDs: []primitive.ObjectID{GraphIDIndividualFocusTime, GraphIDIndividualPR},
+			GraphIDs: []primitive.ObjectID{GraphIDIndividualFocusTime, GraphIDIndividualPR, GraphIDIndividualPRCycleTime, GraphIDIndividualPRMergeCount, GraphIDIndividualPRReviewTurnaround},
 		})
 	}
 
@@ -144,6 +167,9 @@ func (api *API) DashboardData(c *gin.Context) {
 	for _, dataPoint := range *dashboardDataPoints {
 		subjectID := SubjectIDTeam
 		if dataPoint.IndividualID != primitive.NilObjectID {
+			if dashboardTeam.IsAnonymized {
+				continue
+			}
 			subjectID = dataPoint.IndividualID
 		}
 		intervalID := primitive.NilObjectID
@@ -178,6 +204,24 @@ func (api *API) DashboardData(c *gin.Context) {
 			} else {
 				dataID = DataIDFocusTimeUserAverage
 			}
+		} else if dataPoint.GraphType == constants.DashboardGraphTypePRCycleTime {
+			if subjectID == SubjectIDTeam {
+				dataID = DataIDPRCycleTimeTeamAverage
+			} else {
+				dataID = DataIDPRCycleTimeUserAverage
+			}
+		} else if dataPoint.GraphType == constants.DashboardGraphTypePRMergeCount {
+			if subjectID == SubjectIDTeam {
+				dataID = DataIDPRMergeCountTeamAverage
+			} else {
+				dataID = DataIDPRMergeCountUserAverage
+			}
+		} else if dataPoint.GraphType == constants.DashboardGraphTypePRReviewTurnaround {
+			if subjectID == SubjectIDTeam {
+				dataID = DataIDPRReviewTurnaroundTeamAverage
+			} else {
+				dataID = DataIDPRReviewTurnaroundUserAverage
+			}
 		} else {
 			logger.Error().Msgf("invalid data point graph type value: '%s'", dataPoint.GraphType)
 			continue
@@ -330,5 +374,99 @@ func getGraphs() map[primitive.ObjectID]DashboardGraph {
 			},
 		},
 	}
+	// industry averages are only collected for review response time
+	graphs[GraphIDTeamPRCycleTime] = DashboardGraph{
+		Name: GRAPH_NAME_GITHUB_PR_CYCLE_TIME,
+		Icon: ICON_GITHUB,
+		Lines: []DashboardLine{
+			{
+				Name:           TEAM_DAILY_AVERAGE,
+				Color:          COLOR_PINK,
+				AggregatedName: TEAM_WEEKLY_AVERAGE,
+				DataID:         DataIDPRCycleTimeTeamAverage,
+			},
+		},
+	}
+	graphs[GraphIDIndividualPRCycleTime] = DashboardGraph{
+		Name: GRAPH_NAME_GITHUB_PR_CYCLE_TIME,
+		Icon: ICON_GITHUB,
+		Lines: []DashboardLine{
+			{
+				Name:           TEAM_MEMBER_DAILY_AVERAGE,
+				Color:          COLOR_BLUE,
+				AggregatedName: TEAM_MEMBER_WEEKLY_AVERAGE,
+				DataID:         DataIDPRCycleTimeUserAverage,
+			},
+			{
+				Name:           TEAM_DAILY_AVERAGE,
+				Color:          COLOR_GRAY,
+				AggregatedName: TEAM_WEEKLY_AVERAGE,
+				DataID:         DataIDPRCycleTimeTeamAverage,
+				SubjectID:      &SubjectIDTeam,
+			},
+		},
+	}
+	graphs[GraphIDTeamPRMergeCount] = DashboardGraph{
+		Name: GRAPH_NAME_GITHUB_PR_MERGE_COUNT,
+		Icon: ICON_GITHUB,
+		Lines: []DashboardLine{
+			{
+				Name:           TEAM_DAILY_AVERAGE,
+				Color:          COLOR_PINK,
+				AggregatedName: TEAM_WEEKLY_AVERAGE,
+				DataID:         DataIDPRMergeCountTeamAverage,
+			},
+		},
+	}
+	graphs[GraphIDIndividualPRMergeCount] = DashboardGraph{
+		Name: GRAPH_NAME_GITHUB_PR_MERGE_COUNT,
+		Icon: ICON_GITHUB,
+		Lines: []DashboardLine{
+			{
+				Name:           TEAM_MEMBER_DAILY_AVERAGE,
+				Color:          COLOR_BLUE,
+				AggregatedName: TEAM_MEMBER_WEEKLY_AVERAGE,
+				DataID:         DataIDPRMergeCountUserAverage,
+			},
+			{
+				Name:           TEAM_DAILY_AVERAGE,
+				Color:          COLOR_GRAY,
+				AggregatedName: TEAM_WEEKLY_AVERAGE,
+				DataID:         DataIDPRMergeCountTeamAverage,
+				SubjectID:      &SubjectIDTeam,
+			},
+		},
+	}
+	graphs[GraphIDTeamPRReviewTurnaround] = DashboardGraph{
+		Name: GRAPH_NAME_GITHUB_PR_REVIEW_TURNAROUND,
+		Icon: ICON_GITHUB,
+		Lines: []DashboardLine{
+			{
+				Name:           TEAM_DAILY_AVERAGE,
+				Color:          COLOR_PINK,
+				AggregatedName: TEAM_WEEKLY_AVERAGE,
+				DataID:         DataIDPRReviewTurnaroundTeamAverage,
+			},
+		},
+	}
+	graphs[GraphIDIndividualPRReviewTurnaround] = DashboardGraph{
+		Name: GRAPH_NAME_GITHUB_PR_REVIEW_TURNAROUND,
+		Icon: ICON_GITHUB,
+		Lines: []DashboardLine{
+			{
+				Name:           TEAM_MEMBER_DAILY_AVERAGE,
+				Color:          COLOR_BLUE,
+				AggregatedName: TEAM_MEMBER_WEEKLY_AVERAGE,
+				DataID:         DataIDPRReviewTurnaroundUserAverage,
+			},
+			{
+				Name:           TEAM_DAILY_AVERAGE,
+				Color:          COLOR_GRAY,
+				AggregatedName: TEAM_WEEKLY_AVERAGE,
+				DataID:         DataIDPRReviewTurnaroundTeamAverage,
+				SubjectID:      &SubjectIDTeam,
+			},
+		},
+	}
 	return graphs
 }
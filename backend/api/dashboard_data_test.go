@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/franchizzle/task-manager/backend/constants"
@@ -26,11 +27,11 @@ func TestDashboardData(t *testing.T) {
 	api.OverrideTime = &testTime
 	router := GetRouter(api)
 	userID := getUserIDFromAuthToken(t, api.DB, authToken)
-	team, err := database.GetOrCreateDashboardTeam(api.DB, userID)
+	team, err := database.GetOrCreateDashboardTeam(context.Background(), api.DB, userID)
 	assert.NoError(t, err)
 
 	// wrong team
-	team2, err := database.GetOrCreateDashboardTeam(api.DB, primitive.NewObjectID())
+	team2, err := database.GetOrCreateDashboardTeam(context.Background(), api.DB, primitive.NewObjectID())
 	assert.NoError(t, err)
 
 	dashboardTeamMemberCollection := database.GetDashboardTeamMemberCollection(api.DB)
@@ -176,7 +177,10 @@ func TestDashboardData(t *testing.T) {
 			"icon": "team",
 			"graph_ids": [
 				"000000000000000000000003",
-				"000000000000000000000001"
+				"000000000000000000000001",
+				"000000000000000000000102",
+				"000000000000000000000104",
+				"000000000000000000000200"
 			],
 			"is_default": true
 		},
@@ -186,7 +190,10 @@ func TestDashboardData(t *testing.T) {
 			"icon": "user",
 			"graph_ids": [
 				"000000000000000000000004",
-				"000000000000000000000002"
+				"000000000000000000000002",
+				"000000000000000000000103",
+				"000000000000000000000105",
+				"000000000000000000000201"
 			],
 			"is_default": false
 		}
@@ -272,6 +279,106 @@ func TestDashboardData(t *testing.T) {
 				}
 			]
 		}
+,
+		"000000000000000000000102": {
+			"name": "Pull request cycle time",
+			"icon": "github",
+			"lines": [
+				{
+					"name": "Daily average (Your team)",
+					"color": "pink",
+					"aggregated_name": "Weekly average (Your team)",
+					"data_id": "000000000000000000000106",
+					"subject_id_override": null
+				}
+			]
+		},
+		"000000000000000000000103": {
+			"name": "Pull request cycle time",
+			"icon": "github",
+			"lines": [
+				{
+					"name": "Daily average (Team member)",
+					"color": "blue",
+					"aggregated_name": "Weekly average (Team member)",
+					"data_id": "000000000000000000000107",
+					"subject_id_override": null
+				},
+				{
+					"name": "Daily average (Your team)",
+					"color": "gray",
+					"aggregated_name": "Weekly average (Your team)",
+					"data_id": "000000000000000000000106",
+					"subject_id_override": "000000000000000000000101"
+				}
+			]
+		},
+		"000000000000000000000104": {
+			"name": "Pull requests merged",
+			"icon": "github",
+			"lines": [
+				{
+					"name": "Daily average (Your team)",
+					"color": "pink",
+					"aggregated_name": "Weekly average (Your team)",
+					"data_id": "000000000000000000000108",
+					"subject_id_override": null
+				}
+			]
+		},
+		"000000000000000000000105": {
+			"name": "Pull requests merged",
+			"icon": "github",
+			"lines": [
+				{
+					"name": "Daily average (Team member)",
+					"color": "blue",
+					"aggregated_name": "Weekly average (Team member)",
+					"data_id": "000000000000000000000109",
+					"subject_id_override": null
+				},
+				{
+					"name": "Daily average (Your team)",
+					"color": "gray",
+					"aggregated_name": "Weekly average (Your team)",
+					"data_id": "000000000000000000000108",
+					"subject_id_override": "000000000000000000000101"
+				}
+			]
+		},
+		"000000000000000000000200": {
+			"name": "Review turnaround time",
+			"icon": "github",
+			"lines": [
+				{
+					"name": "Daily average (Your team)",
+					"color": "pink",
+					"aggregated_name": "Weekly average (Your team)",
+					"data_id": "000000000000000000000202",
+					"subject_id_override": null
+				}
+			]
+		},
+		"000000000000000000000201": {
+			"name": "Review turnaround time",
+			"icon": "github",
+			"lines": [
+				{
+					"name": "Daily average (Team member)",
+					"color": "blue",
+					"aggregated_name": "Weekly average (Team member)",
+					"data_id": "000000000000000000000203",
+					"subject_id_override": null
+				},
+				{
+					"name": "Daily average (Your team)",
+					"color": "gray",
+					"aggregated_name": "Weekly average (Your team)",
+					"data_id": "000000000000000000000202",
+					"subject_id_override": "000000000000000000000101"
+				}
+			]
+		}
 	},
 	"data": {
 		"000000000000000000000101": {
@@ -326,6 +433,28 @@ func TestDashboardData(t *testing.T) {
 	}
 }`, prettyRender(dashboardResult, t))
 	})
+	t.Run("Anonymized", func(t *testing.T) {
+		_, err := database.GetDashboardTeamCollection(api.DB).UpdateOne(
+			context.Background(),
+			bson.M{"_id": team.ID},
+			bson.M{"$set": bson.M{"is_anonymized": true}},
+		)
+		assert.NoError(t, err)
+		request, _ := http.NewRequest("GET", "/dashboard/data/", nil)
+		request.Header.Add("Authorization", "Bearer "+authToken)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		var dashboardResult DashboardResult
+		err = json.Unmarshal(recorder.Body.Bytes(), &dashboardResult)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(dashboardResult.Subjects))
+		assert.Equal(t, SubjectIDTeam, dashboardResult.Subjects[0].ID)
+		assert.Equal(t, 1, len(dashboardResult.Data))
+		_, exists := dashboardResult.Data[teamMember1ID]
+		assert.False(t, exists)
+		assert.Equal(t, 24, dashboardResult.Data[SubjectIDTeam][primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, '1'}][DataIDPRChartTeamAverage].AggregatedValue)
+	})
 }
 
 func prettyRender(v any, t *testing.T) string {
@@ -9,13 +9,13 @@ import (
 
 func (api *API) DashboardFetch(c *gin.Context) {
 	userID := getUserIDFromContext(c)
-	tokens, err := database.GetAllExternalTokens(api.DB, userID)
+	tokens, err := database.GetAllExternalTokens(c.Request.Context(), api.DB, userID)
 	if err != nil {
 		Handle500(c)
 		return
 	}
 
-	_, _, err = api.fetchPRs(userID, tokens)
+	_, _, err = api.fetchPRs(c.Request.Context(), userID, tokens)
 	if err != nil {
 		Handle500(c)
 		return
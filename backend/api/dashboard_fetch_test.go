@@ -24,11 +24,11 @@ func TestDashboardFetch(t *testing.T) {
 	router := GetRouter(api)
 
 	userID := getUserIDFromAuthToken(t, api.DB, authToken)
-	team, err := database.GetOrCreateDashboardTeam(api.DB, userID)
+	team, err := database.GetOrCreateDashboardTeam(context.Background(), api.DB, userID)
 	assert.NoError(t, err)
 
 	// wrong team
-	team2, err := database.GetOrCreateDashboardTeam(api.DB, primitive.NewObjectID())
+	team2, err := database.GetOrCreateDashboardTeam(context.Background(), api.DB, primitive.NewObjectID())
 	assert.NoError(t, err)
 
 	dashboardTeamMemberCollection := database.GetDashboardTeamMemberCollection(api.DB)
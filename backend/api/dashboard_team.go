@@ -0,0 +1,338 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type DashboardTeamInviteParams struct {
+	Email    string `json:"email" binding:"required"`
+	Name     string `json:"name"`
+	GithubID string `json:"github_id"`
+	Role     string `json:"role"`
+}
+
+type DashboardTeamMemberModifyParams struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type DashboardTeamModifyParams struct {
+	IsAnonymized *bool `json:"is_anonymized" binding:"required"`
+}
+
+type DashboardTeamResult struct {
+	ID           primitive.ObjectID              `json:"id"`
+	Role         string                          `json:"role"`
+	IsAnonymized bool                            `json:"is_anonymized"`
+	Members      []DashboardTeamMemberRoleResult `json:"members"`
+}
+
+type DashboardTeamMemberRoleResult struct {
+	ID           primitive.ObjectID `json:"id"`
+	Name         string             `json:"name"`
+	Email        string             `json:"email"`
+	GithubID     string             `json:"github_id"`
+	Role         string             `json:"role"`
+	InviteStatus string             `json:"invite_status"`
+}
+
+type DashboardTeamInviteResult struct {
+	ID     primitive.ObjectID `json:"id"`
+	TeamID primitive.ObjectID `json:"team_id"`
+	Role   string             `json:"role"`
+}
+
+var dashboardTeamRoles = map[string]bool{
+	constants.DashboardTeamRoleAdmin:  true,
+	constants.DashboardTeamRoleMember: true,
+}
+
+func (api *API) DashboardTeamGet(c *gin.Context) {
+	team, role, ok := api.getDashboardTeamWithRole(c)
+	if !ok {
+		return
+	}
+	teamMembers, err := database.GetDashboardTeamMembers(c.Request.Context(), api.DB, team.ID)
+	if err != nil || teamMembers == nil {
+		Handle500(c)
+		return
+	}
+	memberResults := []DashboardTeamMemberRoleResult{}
+	for _, teamMember := range *teamMembers {
+		memberResults = append(memberResults, DashboardTeamMemberRoleResult{
+			ID:           teamMember.ID,
+			Name:         teamMember.Name,
+			Email:        teamMember.Email,
+			GithubID:     teamMember.GithubID,
+			Role:         teamMember.Role,
+			InviteStatus: teamMember.InviteStatus,
+		})
+	}
+	c.JSON(200, DashboardTeamResult{
+		ID:           team.ID,
+		Role:         role,
+		IsAnonymized: team.IsAnonymized,
+		Members:      memberResults,
+	})
+}
+
+// DashboardTeamModify updates team-wide settings. Only admins can change them
+func (api *API) DashboardTeamModify(c *gin.Context) {
+	var params DashboardTeamModifyParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	team, ok := api.getDashboardTeamForAdmin(c)
+	if !ok {
+		return
+	}
+	_, err = database.GetDashboardTeamCollection(api.DB).UpdateOne(
+		c.Request.Context(),
+		bson.M{"_id": team.ID},
+		bson.M{"$set": bson.M{"is_anonymized": *params.IsAnonymized}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update dashboard team")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+func (api *API) DashboardTeamInviteCreate(c *gin.Context) {
+	var params DashboardTeamInviteParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	if params.Role == "" {
+		params.Role = constants.DashboardTeamRoleMember
+	}
+	if !dashboardTeamRoles[params.Role] {
+		HandleBadRequest(c, "role must be one of admin or member", "role")
+		return
+	}
+	team, ok := api.getDashboardTeamForAdmin(c)
+	if !ok {
+		return
+	}
+
+	email := strings.ToLower(params.Email)
+	teamMemberCollection := database.GetDashboardTeamMemberCollection(api.DB)
+	count, err := teamMemberCollection.CountDocuments(c.Request.Context(), bson.M{"$and": []bson.M{
+		{"team_id": team.ID},
+		{"email": email},
+		{"invite_status": bson.M{"$exists": true}},
+	}})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to check for existing invite")
+		Handle500(c)
+		return
+	}
+	if count > 0 {
+		HandleBadRequest(c, "this email has already been invited")
+		return
+	}
+	insertResult, err := teamMemberCollection.InsertOne(c.Request.Context(), database.DashboardTeamMember{
+		TeamID:       team.ID,
+		Name:         params.Name,
+		Email:        email,
+		GithubID:     params.GithubID,
+		Role:         params.Role,
+		InviteStatus: constants.DashboardTeamInviteStatusPending,
+		CreatedAt:    primitive.NewDateTimeFromTime(api.GetCurrentTime()),
+	})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to create team invite")
+		HandleError(c, ErrorCodeServiceUnavailable, "failed to create team invite")
+		return
+	}
+	c.JSON(201, gin.H{"team_member_id": insertResult.InsertedID.(primitive.ObjectID)})
+}
+
+// DashboardTeamInvitesList returns the invites sent to the user's email that they haven't accepted yet
+func (api *API) DashboardTeamInvitesList(c *gin.Context) {
+	user, err := database.GetUser(c.Request.Context(), api.DB, getUserIDFromContext(c))
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	invites, err := database.GetPendingDashboardTeamInvites(c.Request.Context(), api.DB, strings.ToLower(user.Email))
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	inviteResults := []DashboardTeamInviteResult{}
+	for _, invite := range *invites {
+		inviteResults = append(inviteResults, DashboardTeamInviteResult{
+			ID:     invite.ID,
+			TeamID: invite.TeamID,
+			Role:   invite.Role,
+		})
+	}
+	c.JSON(200, inviteResults)
+}
+
+func (api *API) DashboardTeamInviteAccept(c *gin.Context) {
+	teamMemberID, err := primitive.ObjectIDFromHex(c.Param("team_member_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	userID := getUserIDFromContext(c)
+	user, err := database.GetUser(c.Request.Context(), api.DB, userID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	invite, err := database.GetDashboardTeamMember(c.Request.Context(), api.DB, teamMemberID)
+	// invites for other emails are reported as missing so their existence isn't leaked
+	if err != nil || invite.InviteStatus != constants.DashboardTeamInviteStatusPending || invite.Email != strings.ToLower(user.Email) {
+		Handle404(c)
+		return
+	}
+	_, err = database.GetDashboardTeamMembership(c.Request.Context(), api.DB, userID)
+	if err == nil {
+		HandleBadRequest(c, "user is already a member of a team")
+		return
+	}
+	if err != mongo.ErrNoDocuments {
+		api.Logger.Error().Err(err).Msg("failed to fetch dashboard team membership")
+		Handle500(c)
+		return
+	}
+	_, err = database.GetDashboardTeamMemberCollection(api.DB).UpdateOne(
+		c.Request.Context(),
+		bson.M{"_id": invite.ID},
+		bson.M{"$set": bson.M{
+			"user_id":       userID,
+			"invite_status": constants.DashboardTeamInviteStatusAccepted,
+		}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to accept team invite")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{"team_id": invite.TeamID})
+}
+
+func (api *API) DashboardTeamMemberModify(c *gin.Context) {
+	teamMemberID, err := primitive.ObjectIDFromHex(c.Param("team_member_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	var params DashboardTeamMemberModifyParams
+	err = c.BindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	if !dashboardTeamRoles[params.Role] {
+		HandleBadRequest(c, "role must be one of admin or member", "role")
+		return
+	}
+	team, ok := api.getDashboardTeamForAdmin(c)
+	if !ok {
+		return
+	}
+	updateResult, err := database.GetDashboardTeamMemberCollection(api.DB).UpdateOne(
+		c.Request.Context(),
+		bson.M{"$and": []bson.M{
+			{"_id": teamMemberID},
+			{"team_id": team.ID},
+		}},
+		bson.M{"$set": bson.M{"role": params.Role}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update team member role")
+		Handle500(c)
+		return
+	}
+	if updateResult.MatchedCount == 0 {
+		Handle404(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+// DashboardTeamMemberRemove removes a member or revokes an invite. Admins can remove anyone, other members can
+// only remove themselves to leave the team
+func (api *API) DashboardTeamMemberRemove(c *gin.Context) {
+	teamMemberID, err := primitive.ObjectIDFromHex(c.Param("team_member_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	team, role, ok := api.getDashboardTeamWithRole(c)
+	if !ok {
+		return
+	}
+	filters := []bson.M{
+		{"_id": teamMemberID},
+		{"team_id": team.ID},
+	}
+	if role != constants.DashboardTeamRoleAdmin {
+		filters = append(filters, bson.M{"user_id": getUserIDFromContext(c)})
+	}
+	deleteResult, err := database.GetDashboardTeamMemberCollection(api.DB).DeleteOne(c.Request.Context(), bson.M{"$and": filters})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to remove team member")
+		Handle500(c)
+		return
+	}
+	if deleteResult.DeletedCount == 0 {
+		Handle404(c)
+		return
+	}
+	c.JSON(204, gin.H{})
+}
+
+func (api *API) getDashboardTeamForAdmin(c *gin.Context) (*database.DashboardTeam, bool) {
+	team, role, ok := api.getDashboardTeamWithRole(c)
+	if !ok {
+		return nil, false
+	}
+	if role != constants.DashboardTeamRoleAdmin {
+		HandleError(c, ErrorCodeForbidden, "only team admins can manage the team")
+		return nil, false
+	}
+	return team, true
+}
+
+// getDashboardTeamWithRole returns the user's team and their role on it. Team owners are always admins
+func (api *API) getDashboardTeamWithRole(c *gin.Context) (*database.DashboardTeam, string, bool) {
+	userID := getUserIDFromContext(c)
+	membership, err := database.GetDashboardTeamMembership(c.Request.Context(), api.DB, userID)
+	if err == nil {
+		team, err := database.GetDashboardTeam(c.Request.Context(), api.DB, membership.TeamID)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to get dashboard team")
+			Handle500(c)
+			return nil, "", false
+		}
+		return team, membership.Role, true
+	}
+	if err != mongo.ErrNoDocuments {
+		api.Logger.Error().Err(err).Msg("failed to fetch dashboard team membership")
+		Handle500(c)
+		return nil, "", false
+	}
+	team, err := database.GetOrCreateDashboardTeam(c.Request.Context(), api.DB, userID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to get dashboard team")
+		Handle500(c)
+		return nil, "", false
+	}
+	return team, constants.DashboardTeamRoleAdmin, true
+}
@@ -1,8 +1,6 @@
 package api
 
 import (
-	"context"
-
 	"github.com/franchizzle/task-manager/backend/database"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -24,20 +22,17 @@ func (api *API) DashboardTeamMemberCreate(c *gin.Context) {
 	var teamMemberCreateParams DashboardTeamMemberCreateParams
 	err := c.BindJSON(&teamMemberCreateParams)
 	if err != nil {
-		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		HandleBindError(c, &teamMemberCreateParams, err, "invalid or missing parameter")
 		return
 	}
 
-	userID := getUserIDFromContext(c)
-	dashboardTeam, err := database.GetOrCreateDashboardTeam(api.DB, userID)
-	if err != nil || dashboardTeam == nil {
-		api.Logger.Error().Err(err).Msg("failed to get dashboard team")
-		c.JSON(500, gin.H{"detail": "failed to get dashboard team"})
+	dashboardTeam, ok := api.getDashboardTeamForAdmin(c)
+	if !ok {
 		return
 	}
 
 	teamMemberCollection := database.GetDashboardTeamMemberCollection(api.DB)
-	insertResult, err := teamMemberCollection.InsertOne(context.Background(), database.DashboardTeamMember{
+	insertResult, err := teamMemberCollection.InsertOne(c.Request.Context(), database.DashboardTeamMember{
 		TeamID:   dashboardTeam.ID,
 		Name:     teamMemberCreateParams.Name,
 		Email:    teamMemberCreateParams.Email,
@@ -46,7 +41,7 @@ func (api *API) DashboardTeamMemberCreate(c *gin.Context) {
 
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to create team member")
-		c.JSON(503, gin.H{"detail": "failed to create team member"})
+		HandleError(c, ErrorCodeServiceUnavailable, "failed to create team member")
 		return
 	}
 
@@ -60,21 +55,18 @@ func (api *API) DashboardTeamMemberDelete(c *gin.Context) {
 		Handle404(c)
 		return
 	}
-	userID := getUserIDFromContext(c)
-	dashboardTeam, err := database.GetOrCreateDashboardTeam(api.DB, userID)
-	if err != nil || dashboardTeam == nil {
-		api.Logger.Error().Err(err).Msg("failed to get dashboard team")
-		c.JSON(500, gin.H{"detail": "failed to get dashboard team"})
+	dashboardTeam, ok := api.getDashboardTeamForAdmin(c)
+	if !ok {
 		return
 	}
 	teamMemberCollection := database.GetDashboardTeamMemberCollection(api.DB)
-	deletedResult, err := teamMemberCollection.DeleteOne(context.Background(), database.DashboardTeamMember{
+	deletedResult, err := teamMemberCollection.DeleteOne(c.Request.Context(), database.DashboardTeamMember{
 		ID:     teamMemberID,
 		TeamID: dashboardTeam.ID,
 	})
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to delete team member")
-		c.JSON(500, gin.H{"detail": "failed to delete team member"})
+		HandleError(c, ErrorCodeInternal, "failed to delete team member")
 		return
 	}
 	if deletedResult.DeletedCount == 0 {
@@ -85,19 +77,12 @@ func (api *API) DashboardTeamMemberDelete(c *gin.Context) {
 }
 
 func (api *API) DashboardTeamMembersList(c *gin.Context) {
-	userID := getUserIDFromContext(c)
-	dashboardTeam, err := database.GetOrCreateDashboardTeam(api.DB, userID)
-	if err != nil {
-		api.Logger.Error().Err(err).Msg("failed to get dashboard team")
-		c.JSON(500, gin.H{"detail": "failed to get dashboard team"})
-		return
-	}
-	if err != nil || dashboardTeam == nil {
-		Handle500(c)
+	dashboardTeam, _, ok := api.getDashboardTeamWithRole(c)
+	if !ok {
 		return
 	}
 
-	dashboardTeamMembers, err := database.GetDashboardTeamMembers(api.DB, dashboardTeam.ID)
+	dashboardTeamMembers, err := database.GetDashboardTeamMembers(c.Request.Context(), api.DB, dashboardTeam.ID)
 	if err != nil || dashboardTeamMembers == nil {
 		Handle500(c)
 		return
@@ -37,7 +37,7 @@ func TestDashboardTeamMemberCreate(t *testing.T) {
 	})
 	t.Run("SuccessNameOnly", func(t *testing.T) {
 		database.GetDashboardTeamMemberCollection(api.DB).DeleteMany(context.Background(), bson.M{})
-		dashboardTeam, err := database.GetOrCreateDashboardTeam(api.DB, userID)
+		dashboardTeam, err := database.GetOrCreateDashboardTeam(context.Background(), api.DB, userID)
 		assert.NoError(t, err)
 
 		bodyParams, err := json.Marshal(DashboardTeamMemberCreateParams{
@@ -51,7 +51,7 @@ func TestDashboardTeamMemberCreate(t *testing.T) {
 
 		assert.NoError(t, err)
 
-		teamMembers, err := database.GetDashboardTeamMembers(api.DB, dashboardTeam.ID)
+		teamMembers, err := database.GetDashboardTeamMembers(context.Background(), api.DB, dashboardTeam.ID)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(*teamMembers))
 		teamMember := (*teamMembers)[0]
@@ -63,7 +63,7 @@ func TestDashboardTeamMemberCreate(t *testing.T) {
 	})
 	t.Run("SuccessNameAndEmail", func(t *testing.T) {
 		database.GetDashboardTeamMemberCollection(api.DB).DeleteMany(context.Background(), bson.M{})
-		dashboardTeam, err := database.GetOrCreateDashboardTeam(api.DB, userID)
+		dashboardTeam, err := database.GetOrCreateDashboardTeam(context.Background(), api.DB, userID)
 		assert.NoError(t, err)
 
 		bodyParams, err := json.Marshal(DashboardTeamMemberCreateParams{
@@ -78,7 +78,7 @@ func TestDashboardTeamMemberCreate(t *testing.T) {
 
 		assert.NoError(t, err)
 
-		teamMembers, err := database.GetDashboardTeamMembers(api.DB, dashboardTeam.ID)
+		teamMembers, err := database.GetDashboardTeamMembers(context.Background(), api.DB, dashboardTeam.ID)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(*teamMembers))
 		teamMember := (*teamMembers)[0]
@@ -91,7 +91,7 @@ func TestDashboardTeamMemberCreate(t *testing.T) {
 	})
 	t.Run("SuccessAllFields", func(t *testing.T) {
 		database.GetDashboardTeamMemberCollection(api.DB).DeleteMany(context.Background(), bson.M{})
-		dashboardTeam, err := database.GetOrCreateDashboardTeam(api.DB, userID)
+		dashboardTeam, err := database.GetOrCreateDashboardTeam(context.Background(), api.DB, userID)
 		assert.NoError(t, err)
 
 		bodyParams, err := json.Marshal(DashboardTeamMemberCreateParams{
@@ -107,7 +107,7 @@ func TestDashboardTeamMemberCreate(t *testing.T) {
 
 		assert.NoError(t, err)
 
-		teamMembers, err := database.GetDashboardTeamMembers(api.DB, dashboardTeam.ID)
+		teamMembers, err := database.GetDashboardTeamMembers(context.Background(), api.DB, dashboardTeam.ID)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(*teamMembers))
 		teamMember := (*teamMembers)[0]
@@ -144,7 +144,7 @@ func TestDashboardTeamMemberDelete(t *testing.T) {
 		ServeRequest(t, authToken, "DELETE", "/dashboard/team_members/"+newTeamID.Hex()+"/", nil, http.StatusNotFound, api)
 	})
 	t.Run("Success", func(t *testing.T) {
-		dashboardTeam, err := database.GetOrCreateDashboardTeam(api.DB, userID)
+		dashboardTeam, err := database.GetOrCreateDashboardTeam(context.Background(), api.DB, userID)
 		insertedResult, err := teamMemberCollection.InsertOne(context.Background(), database.DashboardTeamMember{
 			TeamID:   dashboardTeam.ID,
 			Name:     "Scott",
@@ -176,7 +176,7 @@ func TestDashboardTeamMemberList(t *testing.T) {
 		assert.Equal(t, 0, len(result))
 	})
 	t.Run("Success", func(t *testing.T) {
-		dashboardTeam, err := database.GetOrCreateDashboardTeam(api.DB, userID)
+		dashboardTeam, err := database.GetOrCreateDashboardTeam(context.Background(), api.DB, userID)
 		assert.NoError(t, err)
 
 		teamMember1 := database.DashboardTeamMember{
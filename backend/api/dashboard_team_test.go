@@ -0,0 +1,128 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDashboardTeam(t *testing.T) {
+	ownerToken := login("test_dashboard_team_owner@resonant-kelpie-404a42.netlify.app", "")
+	memberToken := login("test_dashboard_team_member@resonant-kelpie-404a42.netlify.app", "")
+	otherToken := login("test_dashboard_team_other@resonant-kelpie-404a42.netlify.app", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	ownerID := getUserIDFromAuthToken(t, api.DB, ownerToken)
+	memberID := getUserIDFromAuthToken(t, api.DB, memberToken)
+	team, err := database.GetOrCreateDashboardTeam(context.Background(), api.DB, ownerID)
+	assert.NoError(t, err)
+
+	UnauthorizedTest(t, "GET", "/dashboard/team/", nil)
+	UnauthorizedTest(t, "POST", "/dashboard/team/invites/", nil)
+	NoBusinessAccessTest(t, "GET", "/dashboard/team/", api, ownerToken)
+	EnableBusinessAccess(t, api, ownerID)
+	EnableBusinessAccess(t, api, memberID)
+
+	var teamMemberID primitive.ObjectID
+	t.Run("InviteInvalidRole", func(t *testing.T) {
+		body := ServeRequest(t, ownerToken, "POST", "/dashboard/team/invites/", bytes.NewBuffer([]byte(`{"email": "test_dashboard_team_member@resonant-kelpie-404a42.netlify.app", "role": "owner"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"role must be one of admin or member","code":"invalid_parameter","field_errors":[{"field":"role"}]}`, string(body))
+	})
+	t.Run("InviteSuccess", func(t *testing.T) {
+		body := ServeRequest(t, ownerToken, "POST", "/dashboard/team/invites/", bytes.NewBuffer([]byte(`{"email": "Test_Dashboard_Team_Member@resonant-kelpie-404a42.netlify.app", "name": "member"}`)), http.StatusCreated, api)
+		var result map[string]primitive.ObjectID
+		assert.NoError(t, json.Unmarshal(body, &result))
+		teamMemberID = result["team_member_id"]
+
+		teamMember, err := database.GetDashboardTeamMember(context.Background(), api.DB, teamMemberID)
+		assert.NoError(t, err)
+		assert.Equal(t, team.ID, teamMember.TeamID)
+		assert.Equal(t, "test_dashboard_team_member@resonant-kelpie-404a42.netlify.app", teamMember.Email)
+		assert.Equal(t, constants.DashboardTeamRoleMember, teamMember.Role)
+		assert.Equal(t, constants.DashboardTeamInviteStatusPending, teamMember.InviteStatus)
+	})
+	t.Run("InviteDuplicate", func(t *testing.T) {
+		body := ServeRequest(t, ownerToken, "POST", "/dashboard/team/invites/", bytes.NewBuffer([]byte(`{"email": "test_dashboard_team_member@resonant-kelpie-404a42.netlify.app"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"this email has already been invited","code":"bad_request"}`, string(body))
+	})
+	t.Run("ListInvites", func(t *testing.T) {
+		body := ServeRequest(t, memberToken, "GET", "/dashboard/team/invites/", nil, http.StatusOK, api)
+		var result []DashboardTeamInviteResult
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, []DashboardTeamInviteResult{{ID: teamMemberID, TeamID: team.ID, Role: constants.DashboardTeamRoleMember}}, result)
+	})
+	t.Run("AcceptWrongUser", func(t *testing.T) {
+		ServeRequest(t, otherToken, "POST", "/dashboard/team/invites/"+teamMemberID.Hex()+"/accept/", nil, http.StatusNotFound, api)
+	})
+	t.Run("AcceptSuccess", func(t *testing.T) {
+		ServeRequest(t, memberToken, "POST", "/dashboard/team/invites/"+teamMemberID.Hex()+"/accept/", nil, http.StatusOK, api)
+		teamMember, err := database.GetDashboardTeamMember(context.Background(), api.DB, teamMemberID)
+		assert.NoError(t, err)
+		assert.Equal(t, memberID, teamMember.UserID)
+		assert.Equal(t, constants.DashboardTeamInviteStatusAccepted, teamMember.InviteStatus)
+	})
+	t.Run("GetAsMember", func(t *testing.T) {
+		body := ServeRequest(t, memberToken, "GET", "/dashboard/team/", nil, http.StatusOK, api)
+		var result DashboardTeamResult
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, team.ID, result.ID)
+		assert.Equal(t, constants.DashboardTeamRoleMember, result.Role)
+		assert.Equal(t, 1, len(result.Members))
+	})
+	t.Run("LegacyMemberRoutesUseTeam", func(t *testing.T) {
+		body := ServeRequest(t, memberToken, "GET", "/dashboard/team_members/", nil, http.StatusOK, api)
+		var result []DashboardTeamMemberResult
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, 1, len(result))
+		assert.Equal(t, teamMemberID.Hex(), result[0].ID)
+
+		ServeRequest(t, memberToken, "POST", "/dashboard/team_members/", bytes.NewBuffer([]byte(`{"name": "someone"}`)), http.StatusForbidden, api)
+		ServeRequest(t, memberToken, "DELETE", "/dashboard/team_members/"+teamMemberID.Hex()+"/", nil, http.StatusForbidden, api)
+		_, err := database.GetDashboardTeamMember(context.Background(), api.DB, teamMemberID)
+		assert.NoError(t, err)
+	})
+	t.Run("MemberCannotInvite", func(t *testing.T) {
+		ServeRequest(t, memberToken, "POST", "/dashboard/team/invites/", bytes.NewBuffer([]byte(`{"email": "someone@resonant-kelpie-404a42.netlify.app"}`)), http.StatusForbidden, api)
+	})
+	t.Run("MemberCannotChangeRole", func(t *testing.T) {
+		ServeRequest(t, memberToken, "PATCH", "/dashboard/team/members/"+teamMemberID.Hex()+"/", bytes.NewBuffer([]byte(`{"role": "admin"}`)), http.StatusForbidden, api)
+	})
+	t.Run("MemberCannotModifyTeam", func(t *testing.T) {
+		ServeRequest(t, memberToken, "PATCH", "/dashboard/team/", bytes.NewBuffer([]byte(`{"is_anonymized": true}`)), http.StatusForbidden, api)
+	})
+	t.Run("ModifyTeamMissingParam", func(t *testing.T) {
+		ServeRequest(t, ownerToken, "PATCH", "/dashboard/team/", bytes.NewBuffer([]byte(`{}`)), http.StatusBadRequest, api)
+	})
+	t.Run("ModifyTeamSuccess", func(t *testing.T) {
+		ServeRequest(t, ownerToken, "PATCH", "/dashboard/team/", bytes.NewBuffer([]byte(`{"is_anonymized": true}`)), http.StatusOK, api)
+		updatedTeam, err := database.GetDashboardTeam(context.Background(), api.DB, team.ID)
+		assert.NoError(t, err)
+		assert.True(t, updatedTeam.IsAnonymized)
+
+		body := ServeRequest(t, memberToken, "GET", "/dashboard/team/", nil, http.StatusOK, api)
+		var result DashboardTeamResult
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.True(t, result.IsAnonymized)
+	})
+	t.Run("ModifyRoleSuccess", func(t *testing.T) {
+		ServeRequest(t, ownerToken, "PATCH", "/dashboard/team/members/"+teamMemberID.Hex()+"/", bytes.NewBuffer([]byte(`{"role": "admin"}`)), http.StatusOK, api)
+		teamMember, err := database.GetDashboardTeamMember(context.Background(), api.DB, teamMemberID)
+		assert.NoError(t, err)
+		assert.Equal(t, constants.DashboardTeamRoleAdmin, teamMember.Role)
+	})
+	t.Run("RemoveNotFound", func(t *testing.T) {
+		ServeRequest(t, ownerToken, "DELETE", "/dashboard/team/members/"+primitive.NewObjectID().Hex()+"/", nil, http.StatusNotFound, api)
+	})
+	t.Run("RemoveSuccess", func(t *testing.T) {
+		ServeRequest(t, ownerToken, "DELETE", "/dashboard/team/members/"+teamMemberID.Hex()+"/", nil, http.StatusNoContent, api)
+		_, err := database.GetDashboardTeamMember(context.Background(), api.DB, teamMemberID)
+		assert.Error(t, err)
+	})
+}
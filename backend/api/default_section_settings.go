@@ -0,0 +1,94 @@
+package api
+
+import (
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// sources whose new tasks can be sent to a default section
+var defaultSectionSourceIDs = map[string]bool{
+	external.TASK_SOURCE_ID_GT_TASK:     true,
+	external.TASK_SOURCE_ID_LINEAR:      true,
+	external.TASK_SOURCE_ID_JIRA:        true,
+	external.TASK_SOURCE_ID_ASANA:       true,
+	external.TASK_SOURCE_ID_SLACK_SAVED: true,
+}
+
+type DefaultSectionSettingModifyParams struct {
+	TaskSectionID string `json:"task_section_id" binding:"required"`
+}
+
+type DefaultSectionSettingResult struct {
+	SourceID      string `json:"source_id"`
+	TaskSectionID string `json:"task_section_id"`
+}
+
+// DefaultSectionSettingsList returns the section new tasks land in for each source the user has set one for
+func (api *API) DefaultSectionSettingsList(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	settings, err := database.GetDefaultSectionSettings(c.Request.Context(), api.DB, userID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	results := []DefaultSectionSettingResult{}
+	for _, setting := range *settings {
+		results = append(results, DefaultSectionSettingResult{
+			SourceID:      setting.SourceID,
+			TaskSectionID: setting.TaskSectionID.Hex(),
+		})
+	}
+	c.JSON(200, results)
+}
+
+// DefaultSectionSettingModify sets the section new tasks from the source land in
+func (api *API) DefaultSectionSettingModify(c *gin.Context) {
+	sourceID := c.Param("source_id")
+	if !defaultSectionSourceIDs[sourceID] {
+		Handle404(c)
+		return
+	}
+	var params DefaultSectionSettingModifyParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing 'task_section_id' parameter", "task_section_id")
+		return
+	}
+	userID := getUserIDFromContext(c)
+	taskSectionID, err := getValidTaskSection(params.TaskSectionID, userID, api.DB)
+	if err != nil {
+		HandleBadRequest(c, "'task_section_id' is not a valid ID", "task_section_id")
+		return
+	}
+	err = database.UpdateOrCreateDefaultSectionSetting(c.Request.Context(), api.DB, userID, sourceID, taskSectionID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+// DefaultSectionSettingDelete sends new tasks from the source back to the task inbox
+func (api *API) DefaultSectionSettingDelete(c *gin.Context) {
+	sourceID := c.Param("source_id")
+	userID := getUserIDFromContext(c)
+	res, err := database.GetDefaultSectionSettingsCollection(api.DB).DeleteOne(
+		c.Request.Context(),
+		bson.M{"$and": []bson.M{
+			{"user_id": userID},
+			{"source_id": sourceID},
+		}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to delete default section setting")
+		Handle500(c)
+		return
+	}
+	if res.DeletedCount != 1 {
+		Handle404(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDefaultSectionSettings(t *testing.T) {
+	authToken := login("test_default_section_settings@resonant-kelpie-404a42.netlify.app", "")
+	otherToken := login("test_default_section_settings_other@resonant-kelpie-404a42.netlify.app", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+
+	listSettings := func(t *testing.T, token string) []DefaultSectionSettingResult {
+		response := ServeRequest(t, token, http.MethodGet, "/settings/default_sections/", nil, http.StatusOK, api)
+		var settings []DefaultSectionSettingResult
+		assert.NoError(t, json.Unmarshal(response, &settings))
+		return settings
+	}
+	createTask := func(t *testing.T) *database.Task {
+		response := ServeRequest(t, authToken, http.MethodPost, "/tasks/create/gt_task/", bytes.NewBuffer([]byte(`{"title": "foobar"}`)), http.StatusOK, api)
+		var result map[string]string
+		assert.NoError(t, json.Unmarshal(response, &result))
+		taskID, err := primitive.ObjectIDFromHex(result["task_id"])
+		assert.NoError(t, err)
+		task, err := database.GetTask(context.Background(), api.DB, taskID, userID)
+		assert.NoError(t, err)
+		return task
+	}
+
+	response := ServeRequest(t, authToken, http.MethodPost, "/sections/create/", bytes.NewBuffer([]byte(`{"name": "Work"}`)), http.StatusCreated, api)
+	var sectionResult map[string]string
+	assert.NoError(t, json.Unmarshal(response, &sectionResult))
+	sectionID := sectionResult["id"]
+
+	UnauthorizedTest(t, http.MethodGet, "/settings/default_sections/", nil)
+	UnauthorizedTest(t, http.MethodPut, "/settings/default_sections/gt_task/", nil)
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, []DefaultSectionSettingResult{}, listSettings(t, authToken))
+		assert.Equal(t, constants.IDTaskSectionDefault, createTask(t).IDTaskSection)
+	})
+	t.Run("InvalidSource", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPut, "/settings/default_sections/gcal/", bytes.NewBuffer([]byte(`{"task_section_id": "`+sectionID+`"}`)), http.StatusNotFound, api)
+	})
+	t.Run("MissingSection", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPut, "/settings/default_sections/gt_task/", bytes.NewBuffer([]byte(`{}`)), http.StatusBadRequest, api)
+	})
+	t.Run("OtherUsersSection", func(t *testing.T) {
+		response := ServeRequest(t, otherToken, http.MethodPut, "/settings/default_sections/gt_task/", bytes.NewBuffer([]byte(`{"task_section_id": "`+sectionID+`"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"'task_section_id' is not a valid ID","code":"invalid_parameter","field_errors":[{"field":"task_section_id"}]}`, string(response))
+	})
+	t.Run("Success", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPut, "/settings/default_sections/gt_task/", bytes.NewBuffer([]byte(`{"task_section_id": "`+constants.IDTaskSectionDefault.Hex()+`"}`)), http.StatusOK, api)
+		ServeRequest(t, authToken, http.MethodPut, "/settings/default_sections/gt_task/", bytes.NewBuffer([]byte(`{"task_section_id": "`+sectionID+`"}`)), http.StatusOK, api)
+		assert.Equal(t, []DefaultSectionSettingResult{{SourceID: external.TASK_SOURCE_ID_GT_TASK, TaskSectionID: sectionID}}, listSettings(t, authToken))
+		assert.Equal(t, []DefaultSectionSettingResult{}, listSettings(t, otherToken))
+		assert.Equal(t, sectionID, createTask(t).IDTaskSection.Hex())
+	})
+	t.Run("ExplicitSectionWins", func(t *testing.T) {
+		response := ServeRequest(t, authToken, http.MethodPost, "/tasks/create/gt_task/", bytes.NewBuffer([]byte(`{"title": "foobar", "id_task_section": "`+constants.IDTaskSectionDefault.Hex()+`"}`)), http.StatusOK, api)
+		var result map[string]string
+		assert.NoError(t, json.Unmarshal(response, &result))
+		taskID, _ := primitive.ObjectIDFromHex(result["task_id"])
+		task, err := database.GetTask(context.Background(), api.DB, taskID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, constants.IDTaskSectionDefault, task.IDTaskSection)
+	})
+	t.Run("Delete", func(t *testing.T) {
+		ServeRequest(t, otherToken, http.MethodDelete, "/settings/default_sections/gt_task/", nil, http.StatusNotFound, api)
+		ServeRequest(t, authToken, http.MethodDelete, "/settings/default_sections/gt_task/", nil, http.StatusOK, api)
+		assert.Equal(t, []DefaultSectionSettingResult{}, listSettings(t, authToken))
+		assert.Equal(t, constants.IDTaskSectionDefault, createTask(t).IDTaskSection)
+	})
+	t.Run("DeletingSectionClearsSetting", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPut, "/settings/default_sections/linear_task/", bytes.NewBuffer([]byte(`{"task_section_id": "`+sectionID+`"}`)), http.StatusOK, api)
+		ServeRequest(t, authToken, http.MethodDelete, "/sections/delete/"+sectionID+"/", nil, http.StatusOK, api)
+		assert.Equal(t, []DefaultSectionSettingResult{}, listSettings(t, authToken))
+		assert.Equal(t, constants.IDTaskSectionDefault, database.GetDefaultTaskSectionID(context.Background(), api.DB, userID, external.TASK_SOURCE_ID_LINEAR))
+	})
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorCode is the machine-readable reason a request failed, so clients don't need to match on the
+// human readable detail
+type ErrorCode string
+
+const (
+	ErrorCodeBadRequest         ErrorCode = "bad_request"
+	ErrorCodeInvalidParameter   ErrorCode = "invalid_parameter"
+	ErrorCodeUnauthorized       ErrorCode = "unauthorized"
+	ErrorCodeForbidden          ErrorCode = "forbidden"
+	ErrorCodeNotFound           ErrorCode = "not_found"
+	ErrorCodeAlreadyExists      ErrorCode = "already_exists"
+	ErrorCodeTooManyRequests    ErrorCode = "too_many_requests"
+	ErrorCodeInternal           ErrorCode = "internal_error"
+	ErrorCodeNotImplemented     ErrorCode = "not_implemented"
+	ErrorCodeServiceUnavailable ErrorCode = "service_unavailable"
+)
+
+var errorCodeStatuses = map[ErrorCode]int{
+	ErrorCodeBadRequest:         400,
+	ErrorCodeInvalidParameter:   400,
+	ErrorCodeUnauthorized:       401,
+	ErrorCodeForbidden:          403,
+	ErrorCodeNotFound:           404,
+	ErrorCodeAlreadyExists:      409,
+	ErrorCodeTooManyRequests:    429,
+	ErrorCodeInternal:           500,
+	ErrorCodeNotImplemented:     501,
+	ErrorCodeServiceUnavailable: 503,
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message,omitempty"`
+}
+
+// ErrorResponse is the body of every error response
+type ErrorResponse struct {
+	Detail      string                 `json:"detail"`
+	Code        ErrorCode              `json:"code"`
+	FieldErrors []FieldError           `json:"field_errors,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// APIError is an error which is sent back to the client as it is, rather than as an internal error
+type APIError struct {
+	Code        ErrorCode
+	Detail      string
+	FieldErrors []FieldError
+	Metadata    map[string]interface{}
+}
+
+func NewAPIError(code ErrorCode, detail string) *APIError {
+	return &APIError{Code: code, Detail: detail}
+}
+
+func (err *APIError) Error() string {
+	return err.Detail
+}
+
+// WithFields marks the request fields which caused the error
+func (err *APIError) WithFields(fields ...string) *APIError {
+	for _, field := range fields {
+		err.FieldErrors = append(err.FieldErrors, FieldError{Field: field})
+	}
+	return err
+}
+
+func (err *APIError) WithMetadata(key string, value interface{}) *APIError {
+	if err.Metadata == nil {
+		err.Metadata = map[string]interface{}{}
+	}
+	err.Metadata[key] = value
+	return err
+}
+
+func (err *APIError) Status() int {
+	status, exists := errorCodeStatuses[err.Code]
+	if !exists {
+		return 500
+	}
+	return status
+}
+
+func (err *APIError) Response() ErrorResponse {
+	return ErrorResponse{
+		Detail:      err.Detail,
+		Code:        err.Code,
+		FieldErrors: err.FieldErrors,
+		Metadata:    err.Metadata,
+	}
+}
+
+// HandleAPIError responds with the error's status for an APIError, and an internal error otherwise
+func HandleAPIError(c *gin.Context, err error) {
+	apiError := toAPIError(err)
+	c.JSON(apiError.Status(), apiError.Response())
+}
+
+// AbortWithAPIError is HandleAPIError for middleware, stopping any later handlers from running
+func AbortWithAPIError(c *gin.Context, err error) {
+	apiError := toAPIError(err)
+	c.AbortWithStatusJSON(apiError.Status(), apiError.Response())
+}
+
+func HandleError(c *gin.Context, code ErrorCode, detail string) {
+	HandleAPIError(c, NewAPIError(code, detail))
+}
+
+// HandleBadRequest responds with a 400, naming the request fields at fault if there are any
+func HandleBadRequest(c *gin.Context, detail string, fields ...string) {
+	code := ErrorCodeBadRequest
+	if len(fields) > 0 {
+		code = ErrorCodeInvalidParameter
+	}
+	HandleAPIError(c, NewAPIError(code, detail).WithFields(fields...))
+}
+
+func toAPIError(err error) *APIError {
+	var apiError *APIError
+	if errors.As(err, &apiError) {
+		return apiError
+	}
+	return NewAPIError(ErrorCodeInternal, "internal server error")
+}
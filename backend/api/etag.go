@@ -0,0 +1,44 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// jsonWithETag responds like c.JSON(200, result), tagged with a hash of the response body. Clients
+// sending that hash back in If-None-Match get an empty 304 when nothing has changed
+func jsonWithETag(c *gin.Context, result interface{}) {
+	body, err := json.Marshal(result)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	hash := sha256.Sum256(body)
+	etag := `"` + base64.RawURLEncoding.EncodeToString(hash[:]) + `"`
+
+	c.Header("ETag", etag)
+	// private since responses are per user, no-cache so clients revalidate instead of reusing stale lists
+	c.Header("Cache-Control", "private, no-cache")
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.AbortWithStatus(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+func etagMatches(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		// proxies that compress responses may weaken the etag, which is fine for a GET
+		candidate = strings.TrimPrefix(candidate, "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONWithETag(t *testing.T) {
+	serve := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(recorder)
+		c.Request, _ = http.NewRequest("GET", "/tasks/v4/", nil)
+		if ifNoneMatch != "" {
+			c.Request.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		jsonWithETag(c, []string{"task"})
+		return recorder
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		recorder := serve("")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, `["task"]`, recorder.Body.String())
+		assert.NotEmpty(t, recorder.Header().Get("ETag"))
+		assert.Equal(t, "private, no-cache", recorder.Header().Get("Cache-Control"))
+	})
+	t.Run("NotModified", func(t *testing.T) {
+		etag := serve("").Header().Get("ETag")
+		recorder := serve(etag)
+		assert.Equal(t, http.StatusNotModified, recorder.Code)
+		assert.Empty(t, recorder.Body.String())
+		assert.Equal(t, etag, recorder.Header().Get("ETag"))
+	})
+	t.Run("Changed", func(t *testing.T) {
+		recorder := serve(`"stale"`)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, `["task"]`, recorder.Body.String())
+	})
+}
+
+func TestETagMatches(t *testing.T) {
+	assert.True(t, etagMatches(`"abc"`, `"abc"`))
+	assert.True(t, etagMatches(`W/"abc"`, `"abc"`))
+	assert.True(t, etagMatches(`"xyz", "abc"`, `"abc"`))
+	assert.True(t, etagMatches(`*`, `"abc"`))
+	assert.False(t, etagMatches(``, `"abc"`))
+	assert.False(t, etagMatches(`"abcd"`, `"abc"`))
+}
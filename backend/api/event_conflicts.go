@@ -0,0 +1,102 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const EVENT_CONFLICTS_MAX_RANGE_DAYS = 31
+
+type EventConflictEvent struct {
+	ID            primitive.ObjectID `json:"id"`
+	AccountID     string             `json:"account_id"`
+	CalendarID    string             `json:"calendar_id"`
+	Title         string             `json:"title"`
+	DatetimeStart primitive.DateTime `json:"datetime_start"`
+	DatetimeEnd   primitive.DateTime `json:"datetime_end"`
+}
+
+type EventConflictResult struct {
+	Events         [2]EventConflictEvent `json:"events"`
+	OverlapMinutes int                   `json:"overlap_minutes"`
+	// the events are on calendars from different accounts, e.g. work and personal
+	IsCrossAccount bool `json:"is_cross_account"`
+}
+
+// EventConflictsList returns every pair of overlapping events across all of the user's calendar
+// accounts in the time range
+func (api *API) EventConflictsList(c *gin.Context) {
+	var params EventListParams
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	if !params.DatetimeEnd.After(*params.DatetimeStart) {
+		HandleBadRequest(c, "'datetime_end' must be after 'datetime_start'", "datetime_end", "datetime_start")
+		return
+	}
+	if params.DatetimeEnd.Sub(*params.DatetimeStart) > EVENT_CONFLICTS_MAX_RANGE_DAYS*24*time.Hour {
+		HandleBadRequest(c, fmt.Sprintf("range must be at most %d days", EVENT_CONFLICTS_MAX_RANGE_DAYS))
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	events, err := database.GetCalendarEvents(c.Request.Context(), api.DB, userID, &[]bson.M{
+		{"datetime_end": bson.M{"$gt": *params.DatetimeStart}},
+		{"datetime_start": bson.M{"$lt": *params.DatetimeEnd}},
+	})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch events for conflicts")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, getEventConflicts(*events))
+}
+
+// getEventConflicts pairs up overlapping events. The same event showing up on more than one
+// calendar, e.g. an invite sent to both of the user's accounts, isn't a conflict with itself
+func getEventConflicts(events []database.CalendarEvent) []EventConflictResult {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].DatetimeStart < events[j].DatetimeStart
+	})
+	conflicts := []EventConflictResult{}
+	for i, event := range events {
+		for _, other := range events[i+1:] {
+			// sorted by start, so no later event can overlap this one either
+			if other.DatetimeStart >= event.DatetimeEnd {
+				break
+			}
+			if other.IDExternal == event.IDExternal && other.SourceID == event.SourceID {
+				continue
+			}
+			overlapEnd := event.DatetimeEnd
+			if other.DatetimeEnd < overlapEnd {
+				overlapEnd = other.DatetimeEnd
+			}
+			conflicts = append(conflicts, EventConflictResult{
+				Events:         [2]EventConflictEvent{getEventConflictEvent(event), getEventConflictEvent(other)},
+				OverlapMinutes: int(overlapEnd.Time().Sub(other.DatetimeStart.Time()).Minutes()),
+				IsCrossAccount: event.SourceAccountID != other.SourceAccountID,
+			})
+		}
+	}
+	return conflicts
+}
+
+func getEventConflictEvent(event database.CalendarEvent) EventConflictEvent {
+	return EventConflictEvent{
+		ID:            event.ID,
+		AccountID:     event.SourceAccountID,
+		CalendarID:    event.CalendarID,
+		Title:         event.Title,
+		DatetimeStart: event.DatetimeStart,
+		DatetimeEnd:   event.DatetimeEnd,
+	}
+}
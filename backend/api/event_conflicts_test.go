@@ -0,0 +1,115 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGetEventConflicts(t *testing.T) {
+	start := time.Date(2023, time.March, 1, 9, 0, 0, 0, time.UTC)
+	getEvent := func(idExternal string, accountID string, startsIn time.Duration, duration time.Duration) database.CalendarEvent {
+		return database.CalendarEvent{
+			ID:              primitive.NewObjectID(),
+			IDExternal:      idExternal,
+			SourceID:        "gcal",
+			SourceAccountID: accountID,
+			DatetimeStart:   primitive.NewDateTimeFromTime(start.Add(startsIn)),
+			DatetimeEnd:     primitive.NewDateTimeFromTime(start.Add(startsIn + duration)),
+		}
+	}
+
+	t.Run("NoConflicts", func(t *testing.T) {
+		events := []database.CalendarEvent{
+			getEvent("standup", "work@test.com", 0, 30*time.Minute),
+			getEvent("gym", "personal@test.com", 30*time.Minute, time.Hour),
+		}
+		assert.Equal(t, []EventConflictResult{}, getEventConflicts(events))
+	})
+	t.Run("SameEventOnTwoCalendars", func(t *testing.T) {
+		events := []database.CalendarEvent{
+			getEvent("offsite", "work@test.com", 0, time.Hour),
+			getEvent("offsite", "personal@test.com", 0, time.Hour),
+		}
+		assert.Equal(t, []EventConflictResult{}, getEventConflicts(events))
+	})
+	t.Run("Conflicts", func(t *testing.T) {
+		planning := getEvent("planning", "work@test.com", time.Hour, 2*time.Hour)
+		dentist := getEvent("dentist", "personal@test.com", 90*time.Minute, time.Hour)
+		oneOnOne := getEvent("1:1", "work@test.com", 2*time.Hour, 30*time.Minute)
+		conflicts := getEventConflicts([]database.CalendarEvent{oneOnOne, dentist, planning})
+		assert.Equal(t, 3, len(conflicts))
+
+		assert.Equal(t, planning.ID, conflicts[0].Events[0].ID)
+		assert.Equal(t, dentist.ID, conflicts[0].Events[1].ID)
+		assert.Equal(t, 60, conflicts[0].OverlapMinutes)
+		assert.True(t, conflicts[0].IsCrossAccount)
+
+		assert.Equal(t, planning.ID, conflicts[1].Events[0].ID)
+		assert.Equal(t, oneOnOne.ID, conflicts[1].Events[1].ID)
+		assert.Equal(t, 30, conflicts[1].OverlapMinutes)
+		assert.False(t, conflicts[1].IsCrossAccount)
+
+		assert.Equal(t, dentist.ID, conflicts[2].Events[0].ID)
+		assert.Equal(t, oneOnOne.ID, conflicts[2].Events[1].ID)
+		assert.Equal(t, 30, conflicts[2].OverlapMinutes)
+		assert.True(t, conflicts[2].IsCrossAccount)
+	})
+}
+
+func TestEventConflictsList(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+
+	authToken := login("test_event_conflicts@resonant-kelpie-404a42.netlify.app", "")
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+
+	start := time.Date(2023, time.March, 1, 9, 0, 0, 0, time.UTC)
+	for _, event := range []database.CalendarEvent{
+		{IDExternal: "planning", SourceAccountID: "work@test.com", Title: "Planning", DatetimeStart: primitive.NewDateTimeFromTime(start), DatetimeEnd: primitive.NewDateTimeFromTime(start.Add(time.Hour))},
+		{IDExternal: "dentist", SourceAccountID: "personal@test.com", Title: "Dentist", DatetimeStart: primitive.NewDateTimeFromTime(start.Add(45 * time.Minute)), DatetimeEnd: primitive.NewDateTimeFromTime(start.Add(2 * time.Hour))},
+		// outside the requested range
+		{IDExternal: "late", SourceAccountID: "work@test.com", Title: "Late", DatetimeStart: primitive.NewDateTimeFromTime(start.Add(5 * time.Hour)), DatetimeEnd: primitive.NewDateTimeFromTime(start.Add(6 * time.Hour))},
+		{IDExternal: "later", SourceAccountID: "personal@test.com", Title: "Later", DatetimeStart: primitive.NewDateTimeFromTime(start.Add(5 * time.Hour)), DatetimeEnd: primitive.NewDateTimeFromTime(start.Add(6 * time.Hour))},
+	} {
+		event.UserID = userID
+		event.SourceID = "gcal"
+		_, err := database.GetCalendarEventCollection(api.DB).InsertOne(context.Background(), event)
+		assert.NoError(t, err)
+	}
+	getQuery := func(end time.Time) string {
+		return "?datetime_start=" + url.QueryEscape(start.Format(time.RFC3339)) + "&datetime_end=" + url.QueryEscape(end.Format(time.RFC3339))
+	}
+
+	UnauthorizedTest(t, http.MethodGet, "/events/conflicts/", nil)
+	t.Run("MissingParams", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodGet, "/events/conflicts/", nil, http.StatusBadRequest, api)
+	})
+	t.Run("EndBeforeStart", func(t *testing.T) {
+		response := ServeRequest(t, authToken, http.MethodGet, "/events/conflicts/"+getQuery(start.Add(-time.Hour)), nil, http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"'datetime_end' must be after 'datetime_start'","code":"invalid_parameter","field_errors":[{"field":"datetime_end"},{"field":"datetime_start"}]}`, string(response))
+	})
+	t.Run("RangeTooLong", func(t *testing.T) {
+		response := ServeRequest(t, authToken, http.MethodGet, "/events/conflicts/"+getQuery(start.AddDate(0, 0, 32)), nil, http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"range must be at most 31 days","code":"bad_request"}`, string(response))
+	})
+	t.Run("Success", func(t *testing.T) {
+		response := ServeRequest(t, authToken, http.MethodGet, "/events/conflicts/"+getQuery(start.Add(4*time.Hour)), nil, http.StatusOK, api)
+		var conflicts []EventConflictResult
+		err := json.Unmarshal(response, &conflicts)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(conflicts))
+		assert.Equal(t, "Planning", conflicts[0].Events[0].Title)
+		assert.Equal(t, "work@test.com", conflicts[0].Events[0].AccountID)
+		assert.Equal(t, "Dentist", conflicts[0].Events[1].Title)
+		assert.Equal(t, 15, conflicts[0].OverlapMinutes)
+		assert.True(t, conflicts[0].IsCrossAccount)
+	})
+}
@@ -1,11 +1,14 @@
 package api
 
 import (
+	"context"
 	"fmt"
+
 	"github.com/franchizzle/task-manager/backend/database"
 	"github.com/franchizzle/task-manager/backend/external"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 func (api *API) EventCreate(c *gin.Context) {
@@ -20,7 +23,7 @@ func (api *API) EventCreate(c *gin.Context) {
 	err = c.BindJSON(&eventCreateObject)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("invalid or missing parameter, err")
-		c.JSON(400, gin.H{"detail": "invalid or missing parameter."})
+		HandleBindError(c, &eventCreateObject, err, "invalid or missing parameter.")
 		return
 	}
 
@@ -29,10 +32,10 @@ func (api *API) EventCreate(c *gin.Context) {
 	linkedSourceID := ""
 	if eventCreateObject.LinkedTaskID != primitive.NilObjectID {
 		// check that the task exists
-		linkedTask, err := database.GetTask(api.DB, eventCreateObject.LinkedTaskID, userID)
+		linkedTask, err := database.GetTask(c.Request.Context(), api.DB, eventCreateObject.LinkedTaskID, userID)
 		if err != nil {
 			api.Logger.Error().Err(err).Msgf("linked task not found: %s, err", eventCreateObject.LinkedTaskID.Hex())
-			c.JSON(400, gin.H{"detail": fmt.Sprintf("linked task not found: %s", eventCreateObject.LinkedTaskID.Hex())})
+			HandleBadRequest(c, fmt.Sprintf("linked task not found: %s", eventCreateObject.LinkedTaskID.Hex()), "task_id")
 			return
 		}
 		linkedSourceID = linkedTask.SourceID
@@ -40,20 +43,20 @@ func (api *API) EventCreate(c *gin.Context) {
 
 	if eventCreateObject.LinkedViewID != primitive.NilObjectID {
 		// check that the view exists
-		_, err := database.GetView(api.DB, userID, eventCreateObject.LinkedViewID)
+		_, err := database.GetView(c.Request.Context(), api.DB, userID, eventCreateObject.LinkedViewID)
 		if err != nil {
 			api.Logger.Error().Err(err).Msgf("linked view not found: %s, err", eventCreateObject.LinkedViewID.Hex())
-			c.JSON(400, gin.H{"detail": fmt.Sprintf("linked view not found: %s", eventCreateObject.LinkedViewID.Hex())})
+			HandleBadRequest(c, fmt.Sprintf("linked view not found: %s", eventCreateObject.LinkedViewID.Hex()), "view_id")
 			return
 		}
 	}
 
 	if eventCreateObject.LinkedPullRequestID != primitive.NilObjectID {
 		// check that the task exists
-		linkedPR, err := database.GetPullRequest(api.DB, eventCreateObject.LinkedPullRequestID, userID)
+		linkedPR, err := database.GetPullRequest(c.Request.Context(), api.DB, eventCreateObject.LinkedPullRequestID, userID)
 		if err != nil {
 			api.Logger.Error().Err(err).Msgf("linked PR not found: %s, err", eventCreateObject.LinkedPullRequestID.Hex())
-			c.JSON(400, gin.H{"detail": fmt.Sprintf("linked PR not found: %s", eventCreateObject.LinkedPullRequestID.Hex())})
+			HandleBadRequest(c, fmt.Sprintf("linked PR not found: %s", eventCreateObject.LinkedPullRequestID.Hex()), "pr_id")
 			return
 		}
 		linkedSourceID = linkedPR.SourceID
@@ -63,16 +66,27 @@ func (api *API) EventCreate(c *gin.Context) {
 	externalEventID := primitive.NewObjectID()
 	eventCreateObject.ID = externalEventID
 
-	err = taskSourceResult.Source.CreateNewEvent(api.DB, userID, eventCreateObject.AccountID, eventCreateObject)
+	err = taskSourceResult.Source.CreateNewEvent(c.Request.Context(), api.DB, userID, eventCreateObject.AccountID, eventCreateObject)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to update external task source")
 		Handle500(c)
 		return
 	}
 
+	insertedEvent, err := saveCreatedEvent(c.Request.Context(), api.DB, userID, sourceID, eventCreateObject, linkedSourceID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to create calendar event in database")
+		Handle500(c)
+		return
+	}
+	c.JSON(201, gin.H{"id": insertedEvent.ID.Hex()})
+}
+
+// saveCreatedEvent stores an event created with the source, so it shows up before the next calendar refresh
+func saveCreatedEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, sourceID string, eventCreateObject external.EventCreateObject, linkedSourceID string) (*database.CalendarEvent, error) {
 	event := database.CalendarEvent{
 		UserID:              userID,
-		IDExternal:          externalEventID.Hex(),
+		IDExternal:          eventCreateObject.ID.Hex(),
 		SourceID:            sourceID,
 		SourceAccountID:     eventCreateObject.AccountID,
 		CalendarID:          eventCreateObject.CalendarID,
@@ -85,20 +99,13 @@ func (api *API) EventCreate(c *gin.Context) {
 		LinkedPullRequestID: eventCreateObject.LinkedPullRequestID,
 		LinkedSourceID:      linkedSourceID,
 	}
-
-	insertedEvent, err := database.UpdateOrCreateCalendarEvent(
-		api.DB,
+	return database.UpdateOrCreateCalendarEvent(
+		ctx,
+		db,
 		userID,
-		externalEventID.Hex(),
+		eventCreateObject.ID.Hex(),
 		sourceID,
 		event,
 		nil,
 	)
-
-	if err != nil {
-		api.Logger.Error().Err(err).Msg("failed to create calendar event in database")
-		Handle500(c)
-		return
-	}
-	c.JSON(201, gin.H{"id": insertedEvent.ID.Hex()})
 }
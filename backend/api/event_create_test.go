@@ -47,7 +47,7 @@ func TestEventCreate(t *testing.T) {
 	UnauthorizedTest(t, "POST", url, bytes.NewBuffer([]byte(`{"account_id": "duck@duck.com", "summary": "duck"}`)))
 	t.Run("SuccessNoLinkedTask", func(t *testing.T) {
 		eventID := makeCreateRequest(t, &defaultEventCreateObject, http.StatusCreated, "", url, authToken, api)
-		dbEvent, err := database.GetCalendarEvent(api.DB, eventID, userID)
+		dbEvent, err := database.GetCalendarEvent(context.Background(), api.DB, eventID, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, eventID, dbEvent.ID)
 		checkEventMatchesCreateObject(t, *dbEvent, defaultEventCreateObject)
@@ -56,7 +56,7 @@ func TestEventCreate(t *testing.T) {
 		eventCreateObj := defaultEventCreateObject
 		eventCreateObj.CalendarID = "calendar_id"
 		eventID := makeCreateRequest(t, &eventCreateObj, http.StatusCreated, "", url, authToken, api)
-		dbEvent, err := database.GetCalendarEvent(api.DB, eventID, userID)
+		dbEvent, err := database.GetCalendarEvent(context.Background(), api.DB, eventID, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, eventID, dbEvent.ID)
 		checkEventMatchesCreateObject(t, *dbEvent, eventCreateObj)
@@ -72,7 +72,7 @@ func TestEventCreate(t *testing.T) {
 		eventCreateObject.LinkedViewID = viewID
 
 		eventID := makeCreateRequest(t, &eventCreateObject, http.StatusCreated, "", url, authToken, api)
-		dbEvent, err := database.GetCalendarEvent(api.DB, eventID, userID)
+		dbEvent, err := database.GetCalendarEvent(context.Background(), api.DB, eventID, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, eventID, dbEvent.ID)
 		checkEventMatchesCreateObject(t, *dbEvent, eventCreateObject)
@@ -92,7 +92,7 @@ func TestEventCreate(t *testing.T) {
 		eventCreateObject.LinkedTaskID = taskID
 
 		eventID := makeCreateRequest(t, &eventCreateObject, http.StatusCreated, "", url, authToken, api)
-		dbEvent, err := database.GetCalendarEvent(api.DB, eventID, userID)
+		dbEvent, err := database.GetCalendarEvent(context.Background(), api.DB, eventID, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, eventID, dbEvent.ID)
 		checkEventMatchesCreateObject(t, *dbEvent, eventCreateObject)
@@ -110,7 +110,7 @@ func TestEventCreate(t *testing.T) {
 		eventCreateObject.LinkedPullRequestID = prID
 
 		eventID := makeCreateRequest(t, &eventCreateObject, http.StatusCreated, "", url, authToken, api)
-		dbEvent, err := database.GetCalendarEvent(api.DB, eventID, userID)
+		dbEvent, err := database.GetCalendarEvent(context.Background(), api.DB, eventID, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, eventID, dbEvent.ID)
 		checkEventMatchesCreateObject(t, *dbEvent, eventCreateObject)
@@ -119,7 +119,7 @@ func TestEventCreate(t *testing.T) {
 		eventCreateObject := defaultEventCreateObject
 		nonExistentLinkedViewID := primitive.NewObjectID()
 		eventCreateObject.LinkedViewID = nonExistentLinkedViewID
-		makeCreateRequest(t, &eventCreateObject, http.StatusBadRequest, fmt.Sprintf(`{"detail":"linked view not found: %s"}`, nonExistentLinkedViewID.Hex()), url, authToken, api)
+		makeCreateRequest(t, &eventCreateObject, http.StatusBadRequest, fmt.Sprintf(`{"detail":"linked view not found: %s","code":"invalid_parameter","field_errors":[{"field":"view_id"}]}`, nonExistentLinkedViewID.Hex()), url, authToken, api)
 	})
 	t.Run("LinkedViewFromWrongUser", func(t *testing.T) {
 		viewCollection := database.GetViewCollection(db)
@@ -130,13 +130,13 @@ func TestEventCreate(t *testing.T) {
 		viewID := mongoResult.InsertedID.(primitive.ObjectID)
 		eventCreateObject := defaultEventCreateObject
 		eventCreateObject.LinkedViewID = viewID
-		makeCreateRequest(t, &eventCreateObject, http.StatusBadRequest, fmt.Sprintf(`{"detail":"linked view not found: %s"}`, viewID.Hex()), url, authToken, api)
+		makeCreateRequest(t, &eventCreateObject, http.StatusBadRequest, fmt.Sprintf(`{"detail":"linked view not found: %s","code":"invalid_parameter","field_errors":[{"field":"view_id"}]}`, viewID.Hex()), url, authToken, api)
 	})
 	t.Run("NonExistentLinkedTask", func(t *testing.T) {
 		eventCreateObject := defaultEventCreateObject
 		nonExistentLinkedTaskID := primitive.NewObjectID()
 		eventCreateObject.LinkedTaskID = nonExistentLinkedTaskID
-		makeCreateRequest(t, &eventCreateObject, http.StatusBadRequest, fmt.Sprintf(`{"detail":"linked task not found: %s"}`, nonExistentLinkedTaskID.Hex()), url, authToken, api)
+		makeCreateRequest(t, &eventCreateObject, http.StatusBadRequest, fmt.Sprintf(`{"detail":"linked task not found: %s","code":"invalid_parameter","field_errors":[{"field":"task_id"}]}`, nonExistentLinkedTaskID.Hex()), url, authToken, api)
 	})
 	t.Run("LinkedTaskFromWrongUser", func(t *testing.T) {
 		taskCollection := database.GetTaskCollection(db)
@@ -151,13 +151,13 @@ func TestEventCreate(t *testing.T) {
 		taskID := mongoResult.InsertedID.(primitive.ObjectID)
 		eventCreateObject := defaultEventCreateObject
 		eventCreateObject.LinkedTaskID = taskID
-		makeCreateRequest(t, &eventCreateObject, http.StatusBadRequest, fmt.Sprintf(`{"detail":"linked task not found: %s"}`, taskID.Hex()), url, authToken, api)
+		makeCreateRequest(t, &eventCreateObject, http.StatusBadRequest, fmt.Sprintf(`{"detail":"linked task not found: %s","code":"invalid_parameter","field_errors":[{"field":"task_id"}]}`, taskID.Hex()), url, authToken, api)
 	})
 	t.Run("NonExistentLinkedPR", func(t *testing.T) {
 		eventCreateObject := defaultEventCreateObject
 		nonExistentLinkedPRID := primitive.NewObjectID()
 		eventCreateObject.LinkedPullRequestID = nonExistentLinkedPRID
-		makeCreateRequest(t, &eventCreateObject, http.StatusBadRequest, fmt.Sprintf(`{"detail":"linked PR not found: %s"}`, nonExistentLinkedPRID.Hex()), url, authToken, api)
+		makeCreateRequest(t, &eventCreateObject, http.StatusBadRequest, fmt.Sprintf(`{"detail":"linked PR not found: %s","code":"invalid_parameter","field_errors":[{"field":"pr_id"}]}`, nonExistentLinkedPRID.Hex()), url, authToken, api)
 	})
 	t.Run("LinkedPRFromWrongUser", func(t *testing.T) {
 		prCollection := database.GetPullRequestCollection(db)
@@ -170,7 +170,7 @@ func TestEventCreate(t *testing.T) {
 		prID := mongoResult.InsertedID.(primitive.ObjectID)
 		eventCreateObject := defaultEventCreateObject
 		eventCreateObject.LinkedPullRequestID = prID
-		makeCreateRequest(t, &eventCreateObject, http.StatusBadRequest, fmt.Sprintf(`{"detail":"linked PR not found: %s"}`, prID.Hex()), url, authToken, api)
+		makeCreateRequest(t, &eventCreateObject, http.StatusBadRequest, fmt.Sprintf(`{"detail":"linked PR not found: %s","code":"invalid_parameter","field_errors":[{"field":"pr_id"}]}`, prID.Hex()), url, authToken, api)
 	})
 	t.Run("UnsupportedService", func(t *testing.T) {
 		body, err := json.Marshal(defaultEventCreateObject)
@@ -180,17 +180,22 @@ func TestEventCreate(t *testing.T) {
 	t.Run("MissingAccountID", func(t *testing.T) {
 		eventCreateObject := defaultEventCreateObject
 		eventCreateObject.AccountID = ""
-		makeCreateRequest(t, &eventCreateObject, http.StatusBadRequest, `{"detail":"invalid or missing parameter."}`, url, authToken, api)
+		makeCreateRequest(t, &eventCreateObject, http.StatusBadRequest, `{"detail":"'account_id' is required","code":"invalid_parameter","field_errors":[{"field":"account_id","message":"'account_id' is required"}]}`, url, authToken, api)
 	})
 	t.Run("MissingStartTime", func(t *testing.T) {
 		eventCreateObject := defaultEventCreateObject
 		eventCreateObject.DatetimeStart = nil
-		makeCreateRequest(t, &eventCreateObject, http.StatusBadRequest, `{"detail":"invalid or missing parameter."}`, url, authToken, api)
+		makeCreateRequest(t, &eventCreateObject, http.StatusBadRequest, `{"detail":"'datetime_start' is required","code":"invalid_parameter","field_errors":[{"field":"datetime_start","message":"'datetime_start' is required"},{"field":"datetime_end","message":"'datetime_end' must be after 'datetime_start'"}]}`, url, authToken, api)
 	})
 	t.Run("MissingEndTime", func(t *testing.T) {
 		eventCreateObject := defaultEventCreateObject
 		eventCreateObject.DatetimeEnd = nil
-		makeCreateRequest(t, &eventCreateObject, http.StatusBadRequest, `{"detail":"invalid or missing parameter."}`, url, authToken, api)
+		makeCreateRequest(t, &eventCreateObject, http.StatusBadRequest, `{"detail":"'datetime_end' is required","code":"invalid_parameter","field_errors":[{"field":"datetime_end","message":"'datetime_end' is required"}]}`, url, authToken, api)
+	})
+	t.Run("EndBeforeStart", func(t *testing.T) {
+		eventCreateObject := defaultEventCreateObject
+		eventCreateObject.DatetimeEnd = eventCreateObject.DatetimeStart
+		makeCreateRequest(t, &eventCreateObject, http.StatusBadRequest, `{"detail":"'datetime_end' must be after 'datetime_start'","code":"invalid_parameter","field_errors":[{"field":"datetime_end","message":"'datetime_end' must be after 'datetime_start'"}]}`, url, authToken, api)
 	})
 }
 
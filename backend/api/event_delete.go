@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
 	"go.mongodb.org/mongo-driver/bson"
@@ -21,12 +22,23 @@ func (api *API) EventDelete(c *gin.Context) {
 	}
 	userID := getUserIDFromContext(c)
 
-	event, err := database.GetCalendarEvent(api.DB, eventID, userID)
+	event, err := database.GetCalendarEvent(c.Request.Context(), api.DB, eventID, userID)
 	if err != nil {
-		c.JSON(404, gin.H{"detail": "event not found", "eventID": eventID})
+		HandleAPIError(c, NewAPIError(ErrorCodeNotFound, "event not found").WithMetadata("eventID", eventID))
 		return
 	}
 
+	// deleting the series removes every occurrence, rather than just this one
+	isSeries := c.Query("scope") == external.EventModifyScopeSeries
+	if isSeries && event.RecurringEventID == "" {
+		HandleBadRequest(c, "event is not recurring")
+		return
+	}
+	externalID := event.IDExternal
+	if isSeries {
+		externalID = event.RecurringEventID
+	}
+
 	taskSourceResult, err := api.ExternalConfig.GetSourceResult(event.SourceID)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to load external event source")
@@ -34,7 +46,7 @@ func (api *API) EventDelete(c *gin.Context) {
 		return
 	}
 
-	err = taskSourceResult.Source.DeleteEvent(api.DB, userID, event.SourceAccountID, event.IDExternal, event.CalendarID)
+	err = taskSourceResult.Source.DeleteEvent(c.Request.Context(), api.DB, userID, event.SourceAccountID, externalID, event.CalendarID)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to update external task source")
 		Handle500(c)
@@ -42,6 +54,24 @@ func (api *API) EventDelete(c *gin.Context) {
 	}
 
 	eventCollection := database.GetCalendarEventCollection(api.DB)
+	if isSeries {
+		_, err = eventCollection.DeleteMany(
+			context.Background(),
+			bson.M{"$and": []bson.M{
+				{"user_id": userID},
+				{"source_account_id": event.SourceAccountID},
+				{"calendar_id": event.CalendarID},
+				{"recurring_event_id": event.RecurringEventID},
+			}},
+		)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to update internal DB")
+			Handle500(c)
+			return
+		}
+		c.JSON(200, gin.H{})
+		return
+	}
 	res, err := eventCollection.DeleteOne(
 		context.Background(),
 		bson.M{"$and": []bson.M{
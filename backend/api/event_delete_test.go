@@ -98,4 +98,33 @@ func TestEventDelete(t *testing.T) {
 		count, _ := eventCollection.CountDocuments(context.Background(), bson.M{"_id": calendarTaskID2})
 		assert.Equal(t, int64(0), count)
 	})
+
+	t.Run("Series", func(t *testing.T) {
+		insertOccurrence := func(idExternal string, recurringEventID string) primitive.ObjectID {
+			result, err := eventCollection.InsertOne(context.Background(), database.CalendarEvent{
+				UserID:           userID,
+				SourceAccountID:  "account_id",
+				CalendarID:       "cal_1",
+				IDExternal:       idExternal,
+				SourceID:         external.TASK_SOURCE_ID_GCAL,
+				RecurringEventID: recurringEventID,
+			})
+			assert.NoError(t, err)
+			return result.InsertedID.(primitive.ObjectID)
+		}
+		firstID := insertOccurrence("series_id_1", "series_id")
+		insertOccurrence("series_id_2", "series_id")
+		otherSeriesID := insertOccurrence("other_series_id_1", "other_series_id")
+		singleID := insertOccurrence("single_id", "")
+
+		ServeRequest(t, authToken, "DELETE", "/events/delete/"+singleID.Hex()+"/?scope=series", nil, http.StatusBadRequest, nil)
+		ServeRequest(t, authToken, "DELETE", "/events/delete/"+firstID.Hex()+"/?scope=series", nil, http.StatusOK, api)
+
+		count, err := eventCollection.CountDocuments(context.Background(), bson.M{"recurring_event_id": "series_id"})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+		count, err = eventCollection.CountDocuments(context.Background(), bson.M{"_id": bson.M{"$in": []primitive.ObjectID{otherSeriesID, singleID}}})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
 }
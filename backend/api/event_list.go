@@ -9,6 +9,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/rs/zerolog/log"
 
+	"github.com/franchizzle/task-manager/backend/constants"
 	"github.com/franchizzle/task-manager/backend/database"
 	"github.com/franchizzle/task-manager/backend/external"
 	"github.com/franchizzle/task-manager/backend/utils"
@@ -42,13 +43,18 @@ type EventResult struct {
 	Logo                string               `json:"logo"`
 	ColorBackground     string               `json:"color_background,omitempty"`
 	ColorForeground     string               `json:"color_foreground,omitempty"`
+	// shown when the event has no color of its own
+	CalendarColorBackground string `json:"calendar_color_background,omitempty"`
+	CalendarColorForeground string `json:"calendar_color_foreground,omitempty"`
+	IsRecurring             bool   `json:"is_recurring"`
+	Category                string `json:"category"`
 }
 
 func (api *API) EventsList(c *gin.Context) {
 	var eventListParams EventListParams
 	err := c.BindQuery(&eventListParams)
 	if err != nil {
-		c.JSON(400, gin.H{"detail": "invalid or missing parameter."})
+		HandleBindError(c, &eventListParams, err, "invalid or missing parameter.")
 		return
 	}
 
@@ -87,6 +93,8 @@ func (api *API) EventsList(c *gin.Context) {
 	}
 
 	calendarEventChannels := []chan external.CalendarResult{}
+	// the source of each channel, since accounts of different services can share an account ID
+	calendarEventSourceIDs := []string{}
 	// Loop through linked accounts and fetch relevant items
 	for _, token := range tokens {
 		taskServiceResult, err := api.ExternalConfig.GetTaskServiceResult(token.ServiceID)
@@ -96,13 +104,14 @@ func (api *API) EventsList(c *gin.Context) {
 		}
 		for _, taskSourceResult := range taskServiceResult.Sources {
 			var calendarEvents = make(chan external.CalendarResult)
-			go taskSourceResult.Source.GetEvents(api.DB, userID, token.AccountID, *eventListParams.DatetimeStart, *eventListParams.DatetimeEnd, token.Scopes, calendarEvents)
+			go taskSourceResult.Source.GetEvents(c.Request.Context(), api.DB, userID, token.AccountID, *eventListParams.DatetimeStart, *eventListParams.DatetimeEnd, token.Scopes, calendarEvents)
 			calendarEventChannels = append(calendarEventChannels, calendarEvents)
+			calendarEventSourceIDs = append(calendarEventSourceIDs, taskSourceResult.Details.ID)
 		}
 	}
 
 	calendarEvents := []EventResult{}
-	for _, calendarEventChannel := range calendarEventChannels {
+	for idx, calendarEventChannel := range calendarEventChannels {
 		calendarResult := <-calendarEventChannel
 		if calendarResult.Error != nil {
 			log.Error().Err(calendarResult.Error).Send()
@@ -116,7 +125,7 @@ func (api *API) EventsList(c *gin.Context) {
 			}
 			calendarEventsForChannel = append(calendarEventsForChannel, result)
 		}
-		err := api.adjustForCompletedEvents(userID, &calendarEventsForChannel, *eventListParams.DatetimeStart, *eventListParams.DatetimeEnd)
+		err := api.adjustForCompletedEvents(c.Request.Context(), userID, calendarEventSourceIDs[idx], &calendarEventsForChannel, *eventListParams.DatetimeStart, *eventListParams.DatetimeEnd)
 		if err != nil {
 			api.Logger.Error().Err(err).Msg("failed to adjust for completed events")
 			Handle500(c)
@@ -139,9 +148,6 @@ func (api *API) calendarEventToResult(event *database.CalendarEvent, userID prim
 		log.Debug().Msg("event is empty")
 		return EventResult{}, errors.New("event is empty")
 	}
-	if event.EventType == "outOfOffice" {
-		return EventResult{}, errors.New("event is out of office")
-	}
 	taskSourceResult, err := api.ExternalConfig.GetSourceResult(event.SourceID)
 	if err != nil {
 		log.Error().Err(err).Msgf("could not find task source: %s for event: %+v", event.SourceID, event)
@@ -190,16 +196,27 @@ func (api *API) calendarEventToResult(event *database.CalendarEvent, userID prim
 			Platform: event.CallPlatform,
 			URL:      event.CallURL,
 		},
-		Logo:                logo,
-		LinkedTaskID:        linkedTaskID,
-		LinkedViewID:        linkedViewID,
-		LinkedPullRequestID: linkedPRID,
-		LinkedNoteID:        linkedNoteID,
-		ColorBackground:     event.ColorBackground,
-		ColorForeground:     event.ColorForeground,
+		Logo:                    logo,
+		LinkedTaskID:            linkedTaskID,
+		LinkedViewID:            linkedViewID,
+		LinkedPullRequestID:     linkedPRID,
+		LinkedNoteID:            linkedNoteID,
+		ColorBackground:         event.ColorBackground,
+		ColorForeground:         event.ColorForeground,
+		CalendarColorBackground: event.CalendarColorBackground,
+		CalendarColorForeground: event.CalendarColorForeground,
+		IsRecurring:             event.RecurringEventID != "",
+		Category:                getEventResultCategory(event.Category),
 	}, nil
 }
 
+func getEventResultCategory(category string) string {
+	if category == "" {
+		return constants.EventCategoryDefault
+	}
+	return category
+}
+
 func (api *API) EventDetail(c *gin.Context) {
 	eventIDHex := c.Param("event_id")
 	eventID, err := primitive.ObjectIDFromHex(eventIDHex)
@@ -211,7 +228,7 @@ func (api *API) EventDetail(c *gin.Context) {
 
 	userID := getUserIDFromContext(c)
 
-	event, err := database.GetCalendarEvent(api.DB, eventID, userID)
+	event, err := database.GetCalendarEvent(c.Request.Context(), api.DB, eventID, userID)
 	if err != nil {
 		Handle404(c)
 		return
@@ -226,13 +243,14 @@ func (api *API) EventDetail(c *gin.Context) {
 	c.JSON(200, eventResult)
 }
 
-func (api *API) adjustForCompletedEvents(userID primitive.ObjectID, calendarEvents *[]EventResult, datetimeStart time.Time, datetimeEnd time.Time) error {
+func (api *API) adjustForCompletedEvents(ctx context.Context, userID primitive.ObjectID, sourceID string, calendarEvents *[]EventResult, datetimeStart time.Time, datetimeEnd time.Time) error {
 	if calendarEvents == nil || len(*calendarEvents) == 0 {
 		return nil
 	}
 	sourceAccountID := (*calendarEvents)[0].AccountID
-	existingCalendarEvents, err := database.GetCalendarEvents(api.DB, userID, &[]bson.M{
+	existingCalendarEvents, err := database.GetCalendarEvents(ctx, api.DB, userID, &[]bson.M{
 		{"source_account_id": sourceAccountID},
+		{"source_id": sourceID},
 		{"datetime_end": bson.M{"$gte": datetimeStart}},
 		{"datetime_start": bson.M{"$lte": datetimeEnd}},
 	})
@@ -247,7 +265,7 @@ func (api *API) adjustForCompletedEvents(userID primitive.ObjectID, calendarEven
 
 	for _, existingCalendarEvent := range *existingCalendarEvents {
 		if !fetchedCalendarIDs[existingCalendarEvent.ID] {
-			_, err := database.GetCalendarEventCollection(api.DB).DeleteOne(context.Background(), bson.M{"_id": existingCalendarEvent.ID})
+			_, err := database.GetCalendarEventCollection(api.DB).DeleteOne(ctx, bson.M{"_id": existingCalendarEvent.ID})
 			if err != nil {
 				api.Logger.Error().Err(err).Msg("failed to delete calendar event")
 				return err
@@ -14,6 +14,7 @@ import (
 	"google.golang.org/api/calendar/v3"
 	"google.golang.org/api/googleapi"
 
+	"github.com/franchizzle/task-manager/backend/constants"
 	"github.com/franchizzle/task-manager/backend/database"
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/bson"
@@ -143,14 +144,14 @@ func TestEventList(t *testing.T) {
 	UnauthorizedTest(t, "GET", "/events/", nil)
 	t.Run("MissingParameter", func(t *testing.T) {
 		response := ServeRequest(t, authToken, "GET", "/events/", nil, http.StatusBadRequest, api)
-		assert.Equal(t, "{\"detail\":\"invalid or missing parameter.\"}", string(response))
+		assert.Equal(t, "{\"detail\":\"'datetime_start' is required\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"datetime_start\",\"message\":\"'datetime_start' is required\"},{\"field\":\"datetime_end\",\"message\":\"'datetime_end' is required\"}]}", string(response))
 	})
 	t.Run("BadParameter", func(t *testing.T) {
 		params := url.Values{}
 		params.Add("datetime_start", "oof")
 		params.Add("datetime_end", "ooooof")
 		response := ServeRequest(t, authToken, "GET", "/events/?"+params.Encode(), nil, http.StatusBadRequest, api)
-		assert.Equal(t, "{\"detail\":\"invalid or missing parameter.\"}", string(response))
+		assert.Equal(t, "{\"detail\":\"invalid or missing parameter.\",\"code\":\"bad_request\"}", string(response))
 	})
 	t.Run("FailedToLoadToken", func(t *testing.T) {
 		params := url.Values{}
@@ -217,10 +218,12 @@ func TestEventList(t *testing.T) {
 		var eventResult []EventResult
 		err = json.Unmarshal(response, &eventResult)
 		assert.NoError(t, err)
-		assert.Equal(t, 2, len(eventResult))
+		assert.Equal(t, 3, len(eventResult))
 		assert.Equal(t, "New Event", eventResult[0].Title)
+		assert.Equal(t, constants.EventCategoryDefault, eventResult[0].Category)
 		assert.Equal(t, "Normal Event", eventResult[1].Title)
-		// ooo event should not be in result
+		assert.Equal(t, "ooo Event", eventResult[2].Title)
+		assert.Equal(t, constants.EventCategoryOutOfOffice, eventResult[2].Category)
 
 		// normal_event2 should be deleted and replaced by new_event
 		count, err := eventCollection.CountDocuments(context.Background(), bson.M{"user_id": userID})
@@ -300,6 +303,7 @@ func TestGetLinkedNoteID(t *testing.T) {
 	eventID := primitive.NewObjectID()
 
 	insertResult, err := database.GetOrCreateNote(
+		context.Background(),
 		api.DB,
 		userID,
 		"external_id",
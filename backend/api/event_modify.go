@@ -1,6 +1,9 @@
 package api
 
 import (
+	"context"
+	"time"
+
 	"github.com/franchizzle/task-manager/backend/database"
 	"github.com/franchizzle/task-manager/backend/external"
 	"github.com/gin-gonic/gin"
@@ -13,29 +16,44 @@ func (api *API) EventModify(c *gin.Context) {
 	eventID, err := primitive.ObjectIDFromHex(eventIDHex)
 	if err != nil {
 		// This means the event ID is improperly formatted
-		c.JSON(400, gin.H{"detail": "event ID missing or malformed"})
+		HandleBadRequest(c, "event ID missing or malformed")
 		return
 	}
 	var modifyParams external.EventModifyObject
 	err = c.BindJSON(&modifyParams)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("invalid or missing parameter")
-		c.JSON(400, gin.H{"detail": "parameter missing or malformed"})
+		HandleBindError(c, &modifyParams, err, "parameter missing or malformed")
 		return
 	}
 
 	// check that modifyParams isn't empty
-	emptyObj := external.EventModifyObject{AccountID: modifyParams.AccountID}
+	emptyObj := external.EventModifyObject{AccountID: modifyParams.AccountID, Scope: modifyParams.Scope}
 	if modifyParams == emptyObj {
-		c.JSON(400, gin.H{"detail": "parameter missing"})
+		HandleBadRequest(c, "parameter missing")
+		return
+	}
+	if modifyParams.Scope != "" && modifyParams.Scope != external.EventModifyScopeInstance && modifyParams.Scope != external.EventModifyScopeSeries {
+		HandleBadRequest(c, "invalid scope", "scope")
 		return
 	}
+	if modifyParams.ColorID != nil && *modifyParams.ColorID != "" {
+		if _, exists := external.GoogleEventColors[*modifyParams.ColorID]; !exists {
+			HandleBadRequest(c, "invalid color_id", "color_id")
+			return
+		}
+	}
 
 	userID := getUserIDFromContext(c)
 
-	event, err := database.GetCalendarEvent(api.DB, eventID, userID)
+	event, err := database.GetCalendarEvent(c.Request.Context(), api.DB, eventID, userID)
 	if err != nil {
-		c.JSON(404, gin.H{"detail": "event not found", "eventID": eventID})
+		HandleAPIError(c, NewAPIError(ErrorCodeNotFound, "event not found").WithMetadata("eventID", eventID))
+		return
+	}
+	isSeries := modifyParams.Scope == external.EventModifyScopeSeries
+	if isSeries && event.RecurringEventID == "" {
+		HandleBadRequest(c, "event is not recurring")
 		return
 	}
 
@@ -46,14 +64,18 @@ func (api *API) EventModify(c *gin.Context) {
 		return
 	}
 
-	err = eventSourceResult.Source.ModifyEvent(api.DB, userID, modifyParams.AccountID, event.IDExternal, &modifyParams)
+	err = eventSourceResult.Source.ModifyEvent(c.Request.Context(), api.DB, userID, modifyParams.AccountID, event.IDExternal, &modifyParams)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to update external task source")
 		Handle500(c)
 		return
 	}
 
-	err = api.updateEventInDB(modifyParams, event, userID)
+	if isSeries {
+		err = api.updateSeriesInDB(c.Request.Context(), modifyParams, event, userID)
+	} else {
+		err = api.updateEventInDB(c.Request.Context(), modifyParams, event, userID)
+	}
 	if err != nil {
 		Handle500(c)
 		return
@@ -61,7 +83,7 @@ func (api *API) EventModify(c *gin.Context) {
 	c.JSON(200, gin.H{})
 }
 
-func (api *API) updateEventInDB(modifyParams external.EventModifyObject, event *database.CalendarEvent, userID primitive.ObjectID) error {
+func (api *API) updateEventInDB(ctx context.Context, modifyParams external.EventModifyObject, event *database.CalendarEvent, userID primitive.ObjectID) error {
 	if modifyParams.Summary != nil {
 		event.Title = *modifyParams.Summary
 	}
@@ -74,13 +96,58 @@ func (api *API) updateEventInDB(modifyParams external.EventModifyObject, event *
 	if modifyParams.DatetimeEnd != nil {
 		event.DatetimeEnd = primitive.NewDateTimeFromTime(*modifyParams.DatetimeEnd)
 	}
+	if modifyParams.ColorID != nil && *modifyParams.ColorID != "" {
+		event.ColorID = *modifyParams.ColorID
+		event.ColorBackground = external.GoogleEventColors[event.ColorID].Background
+		event.ColorForeground = external.GoogleEventColors[event.ColorID].Foreground
+	}
 
-	_, err := database.UpdateOrCreateCalendarEvent(api.DB, userID, event.IDExternal, event.SourceID, event, &[]bson.M{
+	_, err := database.UpdateOrCreateCalendarEvent(ctx, api.DB, userID, event.IDExternal, event.SourceID, event, &[]bson.M{
 		{"source_account_id": event.SourceAccountID},
 		{"calendar_id": event.CalendarID},
 	})
 	if err != nil {
 		return err
 	}
+	if modifyParams.ColorID != nil && *modifyParams.ColorID == "" {
+		return database.ClearCalendarEventColor(ctx, api.DB, event.ID, userID)
+	}
+	return nil
+}
+
+// updateSeriesInDB applies a series change to every stored occurrence, moving each by as much as
+// the modified occurrence moved
+func (api *API) updateSeriesInDB(ctx context.Context, modifyParams external.EventModifyObject, event *database.CalendarEvent, userID primitive.ObjectID) error {
+	instances, err := database.GetCalendarEvents(ctx, api.DB, userID, &[]bson.M{
+		{"source_account_id": event.SourceAccountID},
+		{"calendar_id": event.CalendarID},
+		{"recurring_event_id": event.RecurringEventID},
+	})
+	if err != nil {
+		return err
+	}
+	var startShift, endShift time.Duration
+	if modifyParams.DatetimeStart != nil {
+		startShift = modifyParams.DatetimeStart.Sub(event.DatetimeStart.Time())
+	}
+	if modifyParams.DatetimeEnd != nil {
+		endShift = modifyParams.DatetimeEnd.Sub(event.DatetimeEnd.Time())
+	}
+	for _, instance := range *instances {
+		instance := instance
+		instanceParams := modifyParams
+		if modifyParams.DatetimeStart != nil {
+			datetimeStart := instance.DatetimeStart.Time().Add(startShift)
+			instanceParams.DatetimeStart = &datetimeStart
+		}
+		if modifyParams.DatetimeEnd != nil {
+			datetimeEnd := instance.DatetimeEnd.Time().Add(endShift)
+			instanceParams.DatetimeEnd = &datetimeEnd
+		}
+		err = api.updateEventInDB(ctx, instanceParams, &instance, userID)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/franchizzle/task-manager/backend/external"
 	"github.com/franchizzle/task-manager/backend/testutils"
@@ -87,7 +88,7 @@ func TestEventModify(t *testing.T) {
 		router.ServeHTTP(recorder, request)
 		assert.Equal(t, http.StatusOK, recorder.Code)
 
-		event, err := database.GetCalendarEvent(api.DB, eventObjectID, userID)
+		event, err := database.GetCalendarEvent(context.Background(), api.DB, eventObjectID, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, "initial summary", event.Title)
 		assert.Equal(t, "initial description", event.Body)
@@ -111,7 +112,7 @@ func TestEventModify(t *testing.T) {
 		router.ServeHTTP(recorder, request)
 		assert.Equal(t, http.StatusOK, recorder.Code)
 
-		event, err := database.GetCalendarEvent(api.DB, eventObjectID, userID)
+		event, err := database.GetCalendarEvent(context.Background(), api.DB, eventObjectID, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, "new summary", event.Title)
 		assert.Equal(t, "new description", event.Body)
@@ -135,7 +136,7 @@ func TestEventModify(t *testing.T) {
 		router.ServeHTTP(recorder, request)
 		assert.Equal(t, http.StatusOK, recorder.Code)
 
-		event, err := database.GetCalendarEvent(api.DB, eventObjectID, userID)
+		event, err := database.GetCalendarEvent(context.Background(), api.DB, eventObjectID, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, "new summary", event.Title)
 		assert.Equal(t, "new description", event.Body)
@@ -156,16 +157,40 @@ func TestEventModify(t *testing.T) {
 		router.ServeHTTP(recorder, request)
 		assert.Equal(t, http.StatusOK, recorder.Code)
 
-		event, err = database.GetCalendarEvent(api.DB, eventWithSameExternalID.InsertedID.(primitive.ObjectID), userID)
+		event, err = database.GetCalendarEvent(context.Background(), api.DB, eventWithSameExternalID.InsertedID.(primitive.ObjectID), userID)
 		assert.NoError(t, err)
 		assert.Equal(t, "new summary 2", event.Title)
 		assert.Equal(t, "new description 2", event.Body)
 
-		event, err = database.GetCalendarEvent(api.DB, eventObjectID, userID)
+		event, err = database.GetCalendarEvent(context.Background(), api.DB, eventObjectID, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, "new summary", event.Title)
 		assert.Equal(t, "new description", event.Body)
 	})
+	t.Run("InvalidColorID", func(t *testing.T) {
+		body := bytes.NewBuffer([]byte(`{"account_id": "duck@duck.com", "color_id": "12"}`))
+		response := ServeRequest(t, authToken, "PATCH", validUrl, body, http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"invalid color_id","code":"invalid_parameter","field_errors":[{"field":"color_id"}]}`, string(response))
+	})
+	t.Run("Color", func(t *testing.T) {
+		body := bytes.NewBuffer([]byte(`{"account_id": "duck@duck.com", "color_id": "11"}`))
+		ServeRequest(t, authToken, "PATCH", validUrl, body, http.StatusOK, api)
+		event, err := database.GetCalendarEvent(context.Background(), api.DB, eventObjectID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, "11", event.ColorID)
+		assert.Equal(t, "#dc2127", event.ColorBackground)
+		assert.Equal(t, "#1d1d1d", event.ColorForeground)
+
+		// resetting goes back to the calendar's color
+		body = bytes.NewBuffer([]byte(`{"account_id": "duck@duck.com", "color_id": ""}`))
+		ServeRequest(t, authToken, "PATCH", validUrl, body, http.StatusOK, api)
+		event, err = database.GetCalendarEvent(context.Background(), api.DB, eventObjectID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, "", event.ColorID)
+		assert.Equal(t, "", event.ColorBackground)
+		assert.Equal(t, "", event.ColorForeground)
+		assert.Equal(t, "new summary", event.Title)
+	})
 	t.Run("NoBody", func(t *testing.T) {
 		ServeRequest(t, authToken, "PATCH", validUrl, nil, http.StatusBadRequest, nil)
 	})
@@ -188,3 +213,71 @@ func TestEventModify(t *testing.T) {
 		ServeRequest(t, otherUserAuthToken, "PATCH", validUrl, body, http.StatusNotFound, nil)
 	})
 }
+
+func TestEventModifySeries(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	authToken := login("test_event_modify_series@resonant-kelpie-404a42.netlify.app", "")
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	accountID := "duck@duck.com"
+
+	// every request gets the occurrence back, so the series is treated as starting at the same time
+	calendarModifyServer := testutils.GetMockAPIServer(t, 200, `{"id": "series_id_1", "recurringEventId": "series_id", "start": {"dateTime": "2023-03-01T09:00:00Z"}, "end": {"dateTime": "2023-03-01T09:30:00Z"}}`)
+	defer calendarModifyServer.Close()
+	api.ExternalConfig.GoogleOverrideURLs.CalendarModifyURL = &calendarModifyServer.URL
+
+	start := time.Date(2023, time.March, 1, 9, 0, 0, 0, time.UTC)
+	insertEvent := func(idExternal string, recurringEventID string, datetimeStart time.Time) primitive.ObjectID {
+		result, err := database.GetCalendarEventCollection(api.DB).InsertOne(context.Background(), database.CalendarEvent{
+			UserID:           userID,
+			SourceAccountID:  accountID,
+			CalendarID:       accountID,
+			IDExternal:       idExternal,
+			SourceID:         external.TASK_SOURCE_ID_GCAL,
+			Title:            "weekly sync",
+			DatetimeStart:    primitive.NewDateTimeFromTime(datetimeStart),
+			DatetimeEnd:      primitive.NewDateTimeFromTime(datetimeStart.Add(30 * time.Minute)),
+			RecurringEventID: recurringEventID,
+		})
+		assert.NoError(t, err)
+		return result.InsertedID.(primitive.ObjectID)
+	}
+	firstID := insertEvent("series_id_1", "series_id", start)
+	secondID := insertEvent("series_id_2", "series_id", start.AddDate(0, 0, 7))
+	singleID := insertEvent("single_id", "", start)
+
+	t.Run("InvalidScope", func(t *testing.T) {
+		body := bytes.NewBuffer([]byte(`{"account_id": "duck@duck.com", "summary": "duck", "scope": "everything"}`))
+		ServeRequest(t, authToken, "PATCH", "/events/modify/"+firstID.Hex()+"/", body, http.StatusBadRequest, api)
+	})
+	t.Run("NotRecurring", func(t *testing.T) {
+		body := bytes.NewBuffer([]byte(`{"account_id": "duck@duck.com", "summary": "duck", "scope": "series"}`))
+		response := ServeRequest(t, authToken, "PATCH", "/events/modify/"+singleID.Hex()+"/", body, http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"event is not recurring","code":"bad_request"}`, string(response))
+	})
+	t.Run("Instance", func(t *testing.T) {
+		body := bytes.NewBuffer([]byte(`{"account_id": "duck@duck.com", "summary": "one off", "scope": "instance"}`))
+		ServeRequest(t, authToken, "PATCH", "/events/modify/"+firstID.Hex()+"/", body, http.StatusOK, api)
+
+		event, err := database.GetCalendarEvent(context.Background(), api.DB, firstID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, "one off", event.Title)
+		event, err = database.GetCalendarEvent(context.Background(), api.DB, secondID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, "weekly sync", event.Title)
+	})
+	t.Run("Series", func(t *testing.T) {
+		body := bytes.NewBuffer([]byte(`{"account_id": "duck@duck.com", "summary": "renamed sync", "datetime_start": "2023-03-01T10:00:00Z", "scope": "series"}`))
+		ServeRequest(t, authToken, "PATCH", "/events/modify/"+firstID.Hex()+"/", body, http.StatusOK, api)
+
+		for idx, eventID := range []primitive.ObjectID{firstID, secondID} {
+			event, err := database.GetCalendarEvent(context.Background(), api.DB, eventID, userID)
+			assert.NoError(t, err)
+			assert.Equal(t, "renamed sync", event.Title)
+			assert.True(t, start.AddDate(0, 0, 7*idx).Add(time.Hour).Equal(event.DatetimeStart.Time()))
+		}
+		event, err := database.GetCalendarEvent(context.Background(), api.DB, singleID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, "weekly sync", event.Title)
+	})
+}
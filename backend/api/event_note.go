@@ -0,0 +1,33 @@
+package api
+
+import (
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/meetingnotes"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// EventNoteCreate returns the event's meeting notes, creating them if the event doesn't have any yet
+func (api *API) EventNoteCreate(c *gin.Context) {
+	eventID, err := primitive.ObjectIDFromHex(c.Param("event_id"))
+	if err != nil {
+		// This means the event ID is improperly formatted
+		Handle404(c)
+		return
+	}
+	userID := getUserIDFromContext(c)
+
+	event, err := database.GetCalendarEvent(c.Request.Context(), api.DB, eventID, userID)
+	if err != nil {
+		Handle404(c)
+		return
+	}
+
+	note, _, err := meetingnotes.GetOrCreateEventNote(c.Request.Context(), api.DB, userID, event)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to create meeting notes")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{"note_id": note.ID})
+}
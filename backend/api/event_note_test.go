@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestEventNoteCreate(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+
+	authToken := login("TestEventNoteCreate@resonant-kelpie-404a42.netlify.app", "")
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	insertResult, err := database.GetCalendarEventCollection(api.DB).InsertOne(context.Background(), database.CalendarEvent{
+		Title:          "Team sync",
+		IDExternal:     "team_sync",
+		SourceID:       external.TASK_SOURCE_ID_GCAL,
+		UserID:         userID,
+		Body:           "Review <b>roadmap</b>",
+		DatetimeStart:  primitive.NewDateTimeFromTime(time.Now()),
+		DatetimeEnd:    primitive.NewDateTimeFromTime(time.Now().Add(time.Hour)),
+		AttendeeEmails: []string{"TestEventNoteCreate@resonant-kelpie-404a42.netlify.app", "teammate@resonant-kelpie-404a42.netlify.app"},
+	})
+	assert.NoError(t, err)
+	eventID := insertResult.InsertedID.(primitive.ObjectID)
+
+	UnauthorizedTest(t, "POST", "/events/"+eventID.Hex()+"/note/", nil)
+	t.Run("InvalidID", func(t *testing.T) {
+		ServeRequest(t, authToken, "POST", "/events/123/note/", nil, http.StatusNotFound, api)
+	})
+	t.Run("WrongUser", func(t *testing.T) {
+		authToken2 := login("wronguserforeventnote@resonant-kelpie-404a42.netlify.app", "")
+		ServeRequest(t, authToken2, "POST", "/events/"+eventID.Hex()+"/note/", nil, http.StatusNotFound, api)
+	})
+	t.Run("Success", func(t *testing.T) {
+		response := ServeRequest(t, authToken, "POST", "/events/"+eventID.Hex()+"/note/", nil, http.StatusOK, api)
+		var result map[string]primitive.ObjectID
+		err := json.Unmarshal(response, &result)
+		assert.NoError(t, err)
+
+		note, err := database.GetNote(context.Background(), api.DB, result["note_id"], userID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Team sync", *note.Title)
+		assert.Equal(t, eventID, note.LinkedEventID)
+		assert.Equal(t, database.SharedAccessMeetingAttendees, *note.SharedAccess)
+		assert.Contains(t, *note.Body, "- teammate@resonant-kelpie-404a42.netlify.app")
+		assert.Contains(t, *note.Body, "## Agenda\nReview roadmap")
+
+		// the same note is returned on later requests
+		response = ServeRequest(t, authToken, "POST", "/events/"+eventID.Hex()+"/note/", nil, http.StatusOK, api)
+		var secondResult map[string]primitive.ObjectID
+		err = json.Unmarshal(response, &secondResult)
+		assert.NoError(t, err)
+		assert.Equal(t, result["note_id"], secondResult["note_id"])
+	})
+}
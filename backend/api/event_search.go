@@ -0,0 +1,61 @@
+package api
+
+import (
+	"strings"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+)
+
+type EventSearchParams struct {
+	Query string     `form:"q" binding:"required"`
+	Start *time.Time `form:"start"`
+	End   *time.Time `form:"end"`
+}
+
+// EventSearch godoc
+// @Summary      Searches the user's calendar events
+// @Description  Matches the title, body, location and attendee emails of events, earliest first
+// @Tags         events
+// @Produce      json
+// @Param        q      query  string  true  "search terms"
+// @Param        start  query  string  false "only events ending after this time"
+// @Param        end    query  string  false "only events starting before this time"
+// @Success      200 {array} EventResult
+// @Failure      400 {object} string "invalid params"
+// @Failure      500 {object} string "internal server error"
+// @Router       /events/search/ [get]
+func (api *API) EventSearch(c *gin.Context) {
+	var params EventSearchParams
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	query := strings.TrimSpace(params.Query)
+	if query == "" {
+		HandleBadRequest(c, "'q' must not be empty", "q")
+		return
+	}
+	if params.Start != nil && params.End != nil && !params.End.After(*params.Start) {
+		HandleBadRequest(c, "'end' must be after 'start'", "end", "start")
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	events, err := database.SearchCalendarEvents(c.Request.Context(), api.DB, userID, query, params.Start, params.End)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	results := []EventResult{}
+	for index := range *events {
+		result, err := api.calendarEventToResult(&(*events)[index], userID)
+		if err != nil {
+			continue
+		}
+		results = append(results, result)
+	}
+	c.JSON(200, results)
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestEventSearch(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	// $text queries need the text index
+	assert.NoError(t, database.EnsureIndexes(context.Background(), api.DB))
+	authToken := login("event_search@resonant-kelpie-404a42.netlify.app", "")
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	start := time.Date(2023, time.March, 1, 9, 0, 0, 0, time.UTC)
+	insertEvent := func(t *testing.T, userID primitive.ObjectID, event database.CalendarEvent, startsIn time.Duration) primitive.ObjectID {
+		event.UserID = userID
+		event.SourceID = external.TASK_SOURCE_ID_GCAL
+		event.DatetimeStart = primitive.NewDateTimeFromTime(start.Add(startsIn))
+		event.DatetimeEnd = primitive.NewDateTimeFromTime(start.Add(startsIn + time.Hour))
+		result, err := database.GetCalendarEventCollection(api.DB).InsertOne(context.Background(), event)
+		assert.NoError(t, err)
+		return result.InsertedID.(primitive.ObjectID)
+	}
+	dentistID := insertEvent(t, userID, database.CalendarEvent{IDExternal: "dentist", Title: "Dentist appointment"}, 48*time.Hour)
+	checkupID := insertEvent(t, userID, database.CalendarEvent{IDExternal: "checkup", Title: "Checkup", Body: "bring the dentist forms"}, 0)
+	offsiteID := insertEvent(t, userID, database.CalendarEvent{IDExternal: "offsite", Title: "Offsite", Location: "Lighthouse Cafe"}, 24*time.Hour)
+	oneOnOneID := insertEvent(t, userID, database.CalendarEvent{IDExternal: "1:1", Title: "1:1", AttendeeEmails: []string{"jane@resonant-kelpie-404a42.netlify.app"}}, time.Hour)
+	insertEvent(t, primitive.NewObjectID(), database.CalendarEvent{IDExternal: "other_dentist", Title: "Dentist"}, 0)
+
+	search := func(t *testing.T, query url.Values) []primitive.ObjectID {
+		response := ServeRequest(t, authToken, http.MethodGet, "/events/search/?"+query.Encode(), nil, http.StatusOK, api)
+		var results []EventResult
+		assert.NoError(t, json.Unmarshal(response, &results))
+		ids := []primitive.ObjectID{}
+		for _, result := range results {
+			ids = append(ids, result.ID)
+		}
+		return ids
+	}
+
+	t.Run("MissingQuery", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodGet, "/events/search/", nil, http.StatusBadRequest, api)
+		ServeRequest(t, authToken, http.MethodGet, "/events/search/?q=%20", nil, http.StatusBadRequest, api)
+	})
+	t.Run("InvalidRange", func(t *testing.T) {
+		query := url.Values{"q": {"dentist"}, "start": {start.Format(time.RFC3339)}, "end": {start.Format(time.RFC3339)}}
+		ServeRequest(t, authToken, http.MethodGet, "/events/search/?"+query.Encode(), nil, http.StatusBadRequest, api)
+	})
+	t.Run("TitleAndBody", func(t *testing.T) {
+		// earliest first, and never other users' events
+		assert.Equal(t, []primitive.ObjectID{checkupID, dentistID}, search(t, url.Values{"q": {"dentist"}}))
+	})
+	t.Run("Location", func(t *testing.T) {
+		assert.Equal(t, []primitive.ObjectID{offsiteID}, search(t, url.Values{"q": {"lighthouse"}}))
+	})
+	t.Run("AttendeeEmail", func(t *testing.T) {
+		assert.Equal(t, []primitive.ObjectID{oneOnOneID}, search(t, url.Values{"q": {"jane@resonant-kelpie-404a42.netlify.app"}}))
+	})
+	t.Run("TimeRange", func(t *testing.T) {
+		query := url.Values{"q": {"dentist"}, "start": {start.Add(24 * time.Hour).Format(time.RFC3339)}, "end": {start.Add(72 * time.Hour).Format(time.RFC3339)}}
+		assert.Equal(t, []primitive.ObjectID{dentistID}, search(t, query))
+	})
+	t.Run("NoResults", func(t *testing.T) {
+		assert.Equal(t, []primitive.ObjectID{}, search(t, url.Values{"q": {"volleyball"}}))
+	})
+	UnauthorizedTest(t, http.MethodGet, "/events/search/?q=dentist", nil)
+}
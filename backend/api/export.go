@@ -0,0 +1,215 @@
+package api
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// documents are fetched in batches so large accounts are never held in memory at once
+const EXPORT_CURSOR_BATCH_SIZE = 100
+
+type exportCollection struct {
+	Name       string
+	Collection *mongo.Collection
+	Columns    []string
+}
+
+func (api *API) getExportCollections() []exportCollection {
+	return []exportCollection{
+		{
+			Name:       "tasks",
+			Collection: database.GetTaskCollection(api.DB),
+			Columns:    []string{"_id", "title", "body", "source_id", "deeplink", "id_task_section", "is_completed", "is_deleted", "due_date", "priority_normalized", "created_at_external", "updated_at", "completed_at"},
+		},
+		{
+			Name:       "notes",
+			Collection: database.GetNoteCollection(api.DB),
+			Columns:    []string{"_id", "title", "body", "author", "linked_event_id", "is_deleted", "created_at", "updated_at", "shared_until"},
+		},
+		{
+			Name:       "events",
+			Collection: database.GetCalendarEventCollection(api.DB),
+			Columns:    []string{"_id", "title", "body", "location", "source_id", "calendar_id", "deeplink", "datetime_start", "datetime_end", "attendee_emails"},
+		},
+		{
+			Name:       "pull_requests",
+			Collection: database.GetPullRequestCollection(api.DB),
+			Columns:    []string{"_id", "title", "body", "repository_name", "number", "author", "branch", "base_branch", "required_action", "deeplink", "is_completed", "created_at_external", "last_updated_at", "completed_at"},
+		},
+		{
+			Name:       "settings",
+			Collection: database.GetUserSettingsCollection(api.DB),
+			Columns:    []string{"field_key", "field_value"},
+		},
+	}
+}
+
+// Export godoc
+// @Summary      Exports all user data
+// @Description  Streams a zip archive containing tasks, notes, events, pull requests, and settings as JSON and CSV
+// @Tags         export
+// @Produce      application/zip
+// @Success      200 {file} file
+// @Router       /export/ [get]
+func (api *API) Export(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	api.recordAuditEvent(c, userID, database.AuditLog{EventType: constants.AuditEventDataExported})
+	filename := fmt.Sprintf("general_task_export_%s.zip", api.GetCurrentTime().Format(constants.YEAR_MONTH_DAY_FORMAT))
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Status(200)
+
+	// headers have already been sent once the archive starts streaming, so failures are logged and the archive is truncated
+	err := api.writeExportArchive(c.Request.Context(), c.Writer, userID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to write export archive")
+	}
+}
+
+func (api *API) writeExportArchive(ctx context.Context, writer io.Writer, userID primitive.ObjectID) error {
+	zipWriter := zip.NewWriter(writer)
+	for _, export := range api.getExportCollections() {
+		// zip entries must be written one at a time, so each collection is read once per format
+		jsonWriter, err := zipWriter.Create(export.Name + ".json")
+		if err != nil {
+			return err
+		}
+		err = writeExportJSON(ctx, jsonWriter, export, userID)
+		if err != nil {
+			return err
+		}
+		csvWriter, err := zipWriter.Create(export.Name + ".csv")
+		if err != nil {
+			return err
+		}
+		err = writeExportCSV(ctx, csvWriter, export, userID)
+		if err != nil {
+			return err
+		}
+	}
+	return zipWriter.Close()
+}
+
+// reads stop once the request's context is done, i.e. when the client disconnects
+func getExportCursor(ctx context.Context, export exportCollection, userID primitive.ObjectID) (*mongo.Cursor, error) {
+	return export.Collection.Find(
+		ctx,
+		bson.M{"user_id": userID},
+		options.Find().SetSort(bson.M{"_id": 1}).SetBatchSize(EXPORT_CURSOR_BATCH_SIZE),
+	)
+}
+
+func writeExportJSON(ctx context.Context, writer io.Writer, export exportCollection, userID primitive.ObjectID) error {
+	cursor, err := getExportCursor(ctx, export, userID)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	_, err = io.WriteString(writer, "[")
+	if err != nil {
+		return err
+	}
+	isFirst := true
+	for cursor.Next(ctx) {
+		var document bson.M
+		err = cursor.Decode(&document)
+		if err != nil {
+			return err
+		}
+		delete(document, "user_id")
+		documentJSON, err := json.Marshal(document)
+		if err != nil {
+			return err
+		}
+		if !isFirst {
+			_, err = io.WriteString(writer, ",")
+			if err != nil {
+				return err
+			}
+		}
+		isFirst = false
+		_, err = writer.Write(documentJSON)
+		if err != nil {
+			return err
+		}
+	}
+	if cursor.Err() != nil {
+		return cursor.Err()
+	}
+	_, err = io.WriteString(writer, "]")
+	return err
+}
+
+func writeExportCSV(ctx context.Context, writer io.Writer, export exportCollection, userID primitive.ObjectID) error {
+	cursor, err := getExportCursor(ctx, export, userID)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	csvWriter := csv.NewWriter(writer)
+	err = csvWriter.Write(export.Columns)
+	if err != nil {
+		return err
+	}
+	for cursor.Next(ctx) {
+		var document bson.M
+		err = cursor.Decode(&document)
+		if err != nil {
+			return err
+		}
+		err = csvWriter.Write(getExportCSVRow(document, export.Columns))
+		if err != nil {
+			return err
+		}
+	}
+	if cursor.Err() != nil {
+		return cursor.Err()
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+func getExportCSVRow(document bson.M, columns []string) []string {
+	row := []string{}
+	for _, column := range columns {
+		row = append(row, formatExportCSVValue(document[column]))
+	}
+	return row
+}
+
+func formatExportCSVValue(value interface{}) string {
+	switch typedValue := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typedValue
+	case primitive.ObjectID:
+		return typedValue.Hex()
+	case primitive.DateTime:
+		return typedValue.Time().UTC().Format(time.RFC3339)
+	case bool, int32, int64, float64:
+		return fmt.Sprint(typedValue)
+	default:
+		// nested documents and arrays are embedded as JSON
+		valueJSON, err := json.Marshal(typedValue)
+		if err != nil {
+			return fmt.Sprint(typedValue)
+		}
+		return string(valueJSON)
+	}
+}
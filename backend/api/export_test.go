@@ -0,0 +1,96 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func readExportZipFile(t *testing.T, archive *zip.Reader, name string) []byte {
+	for _, file := range archive.File {
+		if file.Name == name {
+			reader, err := file.Open()
+			assert.NoError(t, err)
+			defer reader.Close()
+			contents, err := io.ReadAll(reader)
+			assert.NoError(t, err)
+			return contents
+		}
+	}
+	t.Fatalf("missing file %s in export", name)
+	return nil
+}
+
+func TestExport(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+
+	authToken := login("test_export@resonant-kelpie-404a42.netlify.app", "")
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+
+	taskTitle := "task, with comma"
+	_, err := database.GetTaskCollection(api.DB).InsertMany(context.Background(), []interface{}{
+		database.Task{UserID: userID, Title: &taskTitle},
+		database.Task{UserID: primitive.NewObjectID(), Title: &taskTitle},
+	})
+	assert.NoError(t, err)
+	noteTitle := "my note"
+	_, err = database.GetNoteCollection(api.DB).InsertOne(context.Background(), database.Note{UserID: userID, Title: &noteTitle})
+	assert.NoError(t, err)
+	assert.NoError(t, database.UpdateUserSetting(context.Background(), api.DB, userID, constants.SettingFieldDailyDigestEnabled, "false"))
+
+	UnauthorizedTest(t, http.MethodGet, "/export/", nil)
+	t.Run("Success", func(t *testing.T) {
+		body := ServeRequest(t, authToken, http.MethodGet, "/export/", nil, http.StatusOK, api)
+		archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		assert.NoError(t, err)
+		assert.Equal(t, 10, len(archive.File))
+
+		var tasks []map[string]interface{}
+		assert.NoError(t, json.Unmarshal(readExportZipFile(t, archive, "tasks.json"), &tasks))
+		assert.Equal(t, 1, len(tasks))
+		assert.Equal(t, "task, with comma", tasks[0]["title"])
+		assert.NotContains(t, tasks[0], "user_id")
+
+		rows, err := csv.NewReader(bytes.NewReader(readExportZipFile(t, archive, "tasks.csv"))).ReadAll()
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(rows))
+		assert.Equal(t, "_id", rows[0][0])
+		assert.Equal(t, "task, with comma", rows[1][1])
+
+		var notes []map[string]interface{}
+		assert.NoError(t, json.Unmarshal(readExportZipFile(t, archive, "notes.json"), &notes))
+		assert.Equal(t, 1, len(notes))
+
+		var events []map[string]interface{}
+		assert.NoError(t, json.Unmarshal(readExportZipFile(t, archive, "events.json"), &events))
+		assert.Equal(t, 0, len(events))
+
+		rows, err = csv.NewReader(bytes.NewReader(readExportZipFile(t, archive, "settings.csv"))).ReadAll()
+		assert.NoError(t, err)
+		assert.Contains(t, rows, []string{constants.SettingFieldDailyDigestEnabled, "false"})
+	})
+}
+
+func TestFormatExportCSVValue(t *testing.T) {
+	objectID := primitive.NewObjectID()
+	assert.Equal(t, "", formatExportCSVValue(nil))
+	assert.Equal(t, "hello", formatExportCSVValue("hello"))
+	assert.Equal(t, objectID.Hex(), formatExportCSVValue(objectID))
+	assert.Equal(t, "2023-01-02T03:04:05Z", formatExportCSVValue(primitive.NewDateTimeFromTime(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC))))
+	assert.Equal(t, "true", formatExportCSVValue(true))
+	assert.Equal(t, "42", formatExportCSVValue(int32(42)))
+	assert.Equal(t, `["a","b"]`, formatExportCSVValue(bson.A{"a", "b"}))
+}
@@ -19,14 +19,14 @@ func (api *API) FeedbackAdd(c *gin.Context) {
 	err := c.BindJSON(&params)
 	if err != nil || params.Feedback == "" {
 		api.Logger.Error().Err(err).Msg("error")
-		c.JSON(400, gin.H{"detail": "invalid or missing 'feedback' parameter."})
+		HandleBindError(c, &params, err, "invalid or missing 'feedback' parameter.", "feedback")
 		return
 	}
 
 	feedbackCollection := database.GetFeedbackItemCollection(api.DB)
 
 	userID, _ := c.Get("user")
-	user, err := database.GetUser(api.DB, userID.(primitive.ObjectID))
+	user, err := database.GetUser(c.Request.Context(), api.DB, userID.(primitive.ObjectID))
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to find user")
 		Handle500(c)
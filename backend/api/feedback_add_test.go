@@ -27,7 +27,7 @@ func TestFeedbackAdd(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"invalid or missing 'feedback' parameter.\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"invalid or missing 'feedback' parameter.\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"feedback\"}]}", string(body))
 	})
 	t.Run("MissingFeedback", func(t *testing.T) {
 		api, dbCleanup := GetAPIWithDBCleanup()
@@ -43,7 +43,7 @@ func TestFeedbackAdd(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"invalid or missing 'feedback' parameter.\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"invalid or missing 'feedback' parameter.\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"feedback\"}]}", string(body))
 	})
 	t.Run("Success", func(t *testing.T) {
 		api, dbCleanup := GetAPIWithDBCleanup()
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// fields the task list needs to nest subtasks and filter out meeting prep tasks
+var taskRequiredFields = []string{
+	"parent_task_id",
+	"id_task_section",
+	"id_ordering",
+	"ordering_key",
+	"source_id",
+	"is_completed",
+	"is_deleted",
+	"is_meeting_preparation_task",
+	"meeting_preparation_params",
+}
+
+// getFieldsFindOptions reads the comma separated fields query param into find options projecting
+// model down to those fields plus requiredFields. Returns nil when the client wants every field.
+// Fields left out of the projection come back as zero values in the response
+func getFieldsFindOptions(c *gin.Context, model interface{}, requiredFields []string) (*options.FindOptions, error) {
+	fieldsParam := c.Query("fields")
+	if fieldsParam == "" {
+		return nil, nil
+	}
+	fields := append([]string{}, requiredFields...)
+	for _, field := range strings.Split(fieldsParam, ",") {
+		field = strings.TrimSpace(field)
+		if field != "" {
+			fields = append(fields, field)
+		}
+	}
+	projection, err := database.GetFieldProjection(model, fields)
+	if err != nil {
+		return nil, err
+	}
+	return options.Find().SetProjection(projection), nil
+}
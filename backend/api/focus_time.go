@@ -0,0 +1,130 @@
+package api
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/franchizzle/task-manager/backend/focustime"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type FocusTimePreviewParams struct {
+	Days *int `form:"days"`
+}
+
+type FocusTimeConfirmParams struct {
+	AccountID  string                  `json:"account_id" binding:"required"`
+	CalendarID string                  `json:"calendar_id"`
+	Blocks     []FocusTimeConfirmBlock `json:"blocks" binding:"required"`
+}
+
+type FocusTimeConfirmBlock struct {
+	TaskID        primitive.ObjectID `json:"task_id" binding:"required"`
+	DatetimeStart *time.Time         `json:"datetime_start" binding:"required"`
+	DatetimeEnd   *time.Time         `json:"datetime_end" binding:"required"`
+}
+
+// FocusTimePreview proposes focus blocks for the user's unscheduled tasks, which are only added
+// to the calendar once they're sent to FocusTimeConfirm
+func (api *API) FocusTimePreview(c *gin.Context) {
+	var params FocusTimePreviewParams
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	days := focustime.DEFAULT_SCHEDULING_DAYS
+	if params.Days != nil {
+		days = *params.Days
+	}
+	if days < 1 || days > focustime.MAX_SCHEDULING_DAYS {
+		HandleBadRequest(c, fmt.Sprintf("'days' must be between 1 and %d", focustime.MAX_SCHEDULING_DAYS), "days")
+		return
+	}
+	timezoneOffset, err := api.getTimezoneOffset(c)
+	if err != nil {
+		HandleBadRequest(c, err.Error())
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	timeNow := api.GetCurrentLocalizedTime(timezoneOffset)
+	blocks, err := focustime.GetFocusBlocks(c.Request.Context(), api.DB, userID, timeNow, timeNow.AddDate(0, 0, days), api.getWorkingHours(userID))
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to schedule focus time")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, blocks)
+}
+
+// FocusTimeConfirm adds the accepted focus blocks to the user's Google calendar, linked to their tasks
+func (api *API) FocusTimeConfirm(c *gin.Context) {
+	var params FocusTimeConfirmParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	if len(params.Blocks) == 0 {
+		HandleBadRequest(c, "'blocks' must not be empty", "blocks")
+		return
+	}
+	userID := getUserIDFromContext(c)
+	taskSourceResult, err := api.ExternalConfig.GetSourceResult(external.TASK_SOURCE_ID_GCAL)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+
+	tasks := []database.Task{}
+	isScheduled := map[primitive.ObjectID]bool{}
+	for _, block := range params.Blocks {
+		if !block.DatetimeEnd.After(*block.DatetimeStart) {
+			HandleBadRequest(c, "'datetime_end' must be after 'datetime_start'", "datetime_end", "datetime_start")
+			return
+		}
+		if isScheduled[block.TaskID] {
+			HandleBadRequest(c, fmt.Sprintf("task '%s' is scheduled more than once", block.TaskID.Hex()))
+			return
+		}
+		isScheduled[block.TaskID] = true
+		task, err := database.GetTask(c.Request.Context(), api.DB, block.TaskID, userID)
+		if err != nil || task.Title == nil {
+			HandleBadRequest(c, fmt.Sprintf("linked task not found: %s", block.TaskID.Hex()))
+			return
+		}
+		tasks = append(tasks, *task)
+	}
+
+	eventIDs := []primitive.ObjectID{}
+	for idx, block := range params.Blocks {
+		// the source uses the ID as the external event ID
+		eventCreateObject := external.EventCreateObject{
+			ID:            primitive.NewObjectID(),
+			AccountID:     params.AccountID,
+			CalendarID:    params.CalendarID,
+			Summary:       *tasks[idx].Title,
+			DatetimeStart: block.DatetimeStart,
+			DatetimeEnd:   block.DatetimeEnd,
+			LinkedTaskID:  block.TaskID,
+		}
+		err = taskSourceResult.Source.CreateNewEvent(c.Request.Context(), api.DB, userID, params.AccountID, eventCreateObject)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to create focus block")
+			HandleAPIError(c, NewAPIError(ErrorCodeInternal, "failed to create focus block").WithMetadata("event_ids", eventIDs))
+			return
+		}
+		event, err := saveCreatedEvent(c.Request.Context(), api.DB, userID, external.TASK_SOURCE_ID_GCAL, eventCreateObject, tasks[idx].SourceID)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to create calendar event in database")
+			HandleAPIError(c, NewAPIError(ErrorCodeInternal, "failed to create focus block").WithMetadata("event_ids", eventIDs))
+			return
+		}
+		eventIDs = append(eventIDs, event.ID)
+	}
+	c.JSON(201, gin.H{"event_ids": eventIDs})
+}
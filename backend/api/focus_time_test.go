@@ -0,0 +1,133 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/focustime"
+	"github.com/franchizzle/task-manager/backend/testutils"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestFocusTime(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	router := GetRouter(api)
+	// a Friday
+	currentTime := time.Date(2023, time.January, 6, 8, 0, 0, 0, time.UTC)
+	api.OverrideTime = &currentTime
+	calendarCreateServer := testutils.GetMockAPIServer(t, 200, "{}")
+	defer calendarCreateServer.Close()
+	api.ExternalConfig.GoogleOverrideURLs.CalendarCreateURL = &calendarCreateServer.URL
+
+	authToken := login("test_focus_time@resonant-kelpie-404a42.netlify.app", "")
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+
+	notCompleted := false
+	insertTask := func(title string, idOrdering int, duration time.Duration) primitive.ObjectID {
+		timeAllocation := duration.Nanoseconds()
+		result, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), database.Task{
+			UserID:         userID,
+			Title:          &title,
+			IsCompleted:    &notCompleted,
+			IDOrdering:     idOrdering,
+			SourceID:       "gt_task",
+			TimeAllocation: &timeAllocation,
+		})
+		assert.NoError(t, err)
+		return result.InsertedID.(primitive.ObjectID)
+	}
+	firstTaskID := insertTask("Write design doc", 1, 2*time.Hour)
+	secondTaskID := insertTask("Review roadmap", 2, time.Hour)
+	_, err := database.GetCalendarEventCollection(api.DB).InsertOne(context.Background(), database.CalendarEvent{
+		UserID:        userID,
+		Title:         "Standup",
+		DatetimeStart: primitive.NewDateTimeFromTime(currentTime.Add(time.Hour)),
+		DatetimeEnd:   primitive.NewDateTimeFromTime(currentTime.Add(2 * time.Hour)),
+	})
+	assert.NoError(t, err)
+
+	preview := func(url string, expectedStatus int) []byte {
+		request, _ := http.NewRequest("GET", url, nil)
+		request.Header.Set("Authorization", "Bearer "+authToken)
+		request.Header.Set("Timezone-Offset", "0")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, expectedStatus, recorder.Code)
+		body, err := io.ReadAll(recorder.Body)
+		assert.NoError(t, err)
+		return body
+	}
+
+	UnauthorizedTest(t, "GET", "/focus_time/preview/", nil)
+	UnauthorizedTest(t, "POST", "/focus_time/confirm/", nil)
+	t.Run("PreviewInvalidDays", func(t *testing.T) {
+		response := preview("/focus_time/preview/?days=15", http.StatusBadRequest)
+		assert.Equal(t, `{"detail":"'days' must be between 1 and 14","code":"invalid_parameter","field_errors":[{"field":"days"}]}`, string(response))
+	})
+
+	var blocks []focustime.FocusBlock
+	t.Run("Preview", func(t *testing.T) {
+		err := json.Unmarshal(preview("/focus_time/preview/?days=1", http.StatusOK), &blocks)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(blocks))
+		assert.Equal(t, firstTaskID, blocks[0].TaskID)
+		assert.Equal(t, "Write design doc", blocks[0].Title)
+		assert.True(t, currentTime.Add(2*time.Hour).Equal(blocks[0].DatetimeStart))
+		assert.True(t, currentTime.Add(4*time.Hour).Equal(blocks[0].DatetimeEnd))
+		assert.Equal(t, secondTaskID, blocks[1].TaskID)
+		assert.True(t, currentTime.Add(4*time.Hour).Equal(blocks[1].DatetimeStart))
+	})
+	t.Run("ConfirmInvalidTask", func(t *testing.T) {
+		body, _ := json.Marshal(FocusTimeConfirmParams{AccountID: "duck@test.com", Blocks: []FocusTimeConfirmBlock{{
+			TaskID:        primitive.NewObjectID(),
+			DatetimeStart: &blocks[0].DatetimeStart,
+			DatetimeEnd:   &blocks[0].DatetimeEnd,
+		}}})
+		ServeRequest(t, authToken, "POST", "/focus_time/confirm/", bytes.NewBuffer(body), http.StatusBadRequest, api)
+	})
+	t.Run("ConfirmInvalidTimes", func(t *testing.T) {
+		body, _ := json.Marshal(FocusTimeConfirmParams{AccountID: "duck@test.com", Blocks: []FocusTimeConfirmBlock{{
+			TaskID:        firstTaskID,
+			DatetimeStart: &blocks[0].DatetimeEnd,
+			DatetimeEnd:   &blocks[0].DatetimeStart,
+		}}})
+		response := ServeRequest(t, authToken, "POST", "/focus_time/confirm/", bytes.NewBuffer(body), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"'datetime_end' must be after 'datetime_start'","code":"invalid_parameter","field_errors":[{"field":"datetime_end"},{"field":"datetime_start"}]}`, string(response))
+	})
+	t.Run("Confirm", func(t *testing.T) {
+		body, _ := json.Marshal(FocusTimeConfirmParams{AccountID: "duck@test.com", Blocks: []FocusTimeConfirmBlock{{
+			TaskID:        firstTaskID,
+			DatetimeStart: &blocks[0].DatetimeStart,
+			DatetimeEnd:   &blocks[0].DatetimeEnd,
+		}}})
+		response := ServeRequest(t, authToken, "POST", "/focus_time/confirm/", bytes.NewBuffer(body), http.StatusCreated, api)
+		var result map[string][]primitive.ObjectID
+		err := json.Unmarshal(response, &result)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(result["event_ids"]))
+
+		event, err := database.GetCalendarEvent(context.Background(), api.DB, result["event_ids"][0], userID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Write design doc", event.Title)
+		assert.Equal(t, firstTaskID, event.LinkedTaskID)
+		assert.Equal(t, "gt_task", event.LinkedSourceID)
+		assert.True(t, blocks[0].DatetimeStart.Equal(event.DatetimeStart.Time()))
+
+		// the scheduled task is no longer proposed, and its block is now taken
+		var newBlocks []focustime.FocusBlock
+		err = json.Unmarshal(preview("/focus_time/preview/?days=1", http.StatusOK), &newBlocks)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(newBlocks))
+		assert.Equal(t, secondTaskID, newBlocks[0].TaskID)
+		assert.True(t, currentTime.Add(4*time.Hour).Equal(newBlocks[0].DatetimeStart))
+	})
+}
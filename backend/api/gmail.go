@@ -0,0 +1,156 @@
+package api
+
+import (
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/exp/slices"
+)
+
+type GmailScopeUpgradeParams struct {
+	AccountID string `json:"account_id" binding:"required"`
+}
+
+type GmailLabelsListParams struct {
+	AccountID string `form:"account_id" binding:"required"`
+}
+
+type GmailLabelResult struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	IsTaskLabel bool   `json:"is_task_label"`
+}
+
+type GmailTaskLabelModifyParams struct {
+	AccountID string `json:"account_id" binding:"required"`
+	LabelID   string `json:"label_id" binding:"required"`
+}
+
+// GmailScopeUpgrade godoc
+// @Summary      Starts granting access to a Google account's emails
+// @Description  Turns on turning labelled emails into tasks for an account that's already linked. Only the gmail scope is asked for, and the grant is added to the existing account on callback
+// @Tags         linked_accounts
+// @Accept       json
+// @Produce      json
+// @Param        account_id   body     string  true "Google account ID"
+// @Success      200 {object} RelinkLinkedAccountResult
+// @Failure      400 {object} string "invalid params, or the account already has the scope"
+// @Failure      404 {object} string "account not found"
+// @Failure      500 {object} string "internal server error"
+// @Router       /gmail/upgrade_scopes/ [post]
+func (api *API) GmailScopeUpgrade(c *gin.Context) {
+	var params GmailScopeUpgradeParams
+	err := c.ShouldBindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing 'account_id' parameter.", "account_id")
+		return
+	}
+	api.startGoogleScopeUpgrade(c, params.AccountID, external.GOOGLE_GMAIL_SCOPE, "account already has access to Gmail")
+}
+
+// GmailLabelsList godoc
+// @Summary      Lists the labels of a Google account's emails
+// @Description  Emails with the account's task label are turned into tasks. Starred is used until a label is picked
+// @Tags         linked_accounts
+// @Produce      json
+// @Param        account_id   query    string  true "Google account ID"
+// @Success      200 {array}  GmailLabelResult
+// @Failure      400 {object} string "invalid params, or the account hasn't granted access to Gmail"
+// @Failure      404 {object} string "account not found"
+// @Failure      500 {object} string "internal server error"
+// @Router       /gmail/labels/ [get]
+func (api *API) GmailLabelsList(c *gin.Context) {
+	var params GmailLabelsListParams
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing 'account_id' parameter.", "account_id")
+		return
+	}
+	externalToken, gmailSource, ok := api.getGmailSourceForAccount(c, params.AccountID)
+	if !ok {
+		return
+	}
+	labels, err := gmailSource.GetLabels(api.DB, externalToken.UserID, externalToken.AccountID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	taskLabelID := external.GetGmailTaskLabelID(externalToken)
+	results := []GmailLabelResult{}
+	for _, label := range labels {
+		results = append(results, GmailLabelResult{
+			ID:          label.Id,
+			Name:        label.Name,
+			IsTaskLabel: label.Id == taskLabelID,
+		})
+	}
+	c.JSON(200, results)
+}
+
+// GmailTaskLabelModify godoc
+// @Summary      Sets the label which turns a Google account's emails into tasks
+// @Description  Emails with the label are synced as tasks on the next refresh, and the label is removed from them when their task is completed
+// @Tags         linked_accounts
+// @Accept       json
+// @Produce      json
+// @Param        payload  body      GmailTaskLabelModifyParams  true "account and label"
+// @Success      200 {object} string
+// @Failure      400 {object} string "invalid params, the account hasn't granted access to Gmail, or the label doesn't exist"
+// @Failure      404 {object} string "account not found"
+// @Failure      500 {object} string "internal server error"
+// @Router       /gmail/task_label/ [patch]
+func (api *API) GmailTaskLabelModify(c *gin.Context) {
+	var params GmailTaskLabelModifyParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	externalToken, gmailSource, ok := api.getGmailSourceForAccount(c, params.AccountID)
+	if !ok {
+		return
+	}
+	labels, err := gmailSource.GetLabels(api.DB, externalToken.UserID, externalToken.AccountID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	labelExists := false
+	for _, label := range labels {
+		if label.Id == params.LabelID {
+			labelExists = true
+			break
+		}
+	}
+	if !labelExists {
+		HandleBadRequest(c, "label not found")
+		return
+	}
+	err = database.UpdateGmailTaskLabel(c.Request.Context(), api.DB, externalToken.ID, params.LabelID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+// getGmailSourceForAccount returns the token of the user's Google account and the gmail source,
+// responding with an error if the account can't be found or hasn't granted access to Gmail
+func (api *API) getGmailSourceForAccount(c *gin.Context, accountID string) (*database.ExternalAPIToken, external.GmailSource, bool) {
+	externalToken, err := api.getUserGoogleToken(c, accountID)
+	if err != nil {
+		Handle404(c)
+		return nil, external.GmailSource{}, false
+	}
+	if !slices.Contains(externalToken.Scopes, external.GOOGLE_GMAIL_SCOPE) {
+		HandleBadRequest(c, "account hasn't granted access to Gmail")
+		return nil, external.GmailSource{}, false
+	}
+	taskSourceResult, err := api.ExternalConfig.GetSourceResult(external.TASK_SOURCE_ID_GMAIL)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch task source")
+		Handle500(c)
+		return nil, external.GmailSource{}, false
+	}
+	return externalToken, taskSourceResult.Source.(external.GmailSource), true
+}
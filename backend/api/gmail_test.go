@@ -0,0 +1,72 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGmailScopeUpgrade(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	email := "gmail_scope_upgrade@resonant-kelpie-404a42.netlify.app"
+	authToken := login(email, "")
+	upgradeBody := func(accountID string) io.Reader {
+		return bytes.NewBuffer([]byte(`{"account_id": "` + accountID + `"}`))
+	}
+
+	t.Run("MissingAccountID", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPost, "/gmail/upgrade_scopes/", bytes.NewBuffer([]byte(`{}`)), http.StatusBadRequest, api)
+	})
+	t.Run("AccountNotFound", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPost, "/gmail/upgrade_scopes/", upgradeBody("someone_else@resonant-kelpie-404a42.netlify.app"), http.StatusNotFound, api)
+	})
+	t.Run("Success", func(t *testing.T) {
+		response := ServeRequest(t, authToken, http.MethodPost, "/gmail/upgrade_scopes/", upgradeBody(email), http.StatusOK, api)
+		var result RelinkLinkedAccountResult
+		assert.NoError(t, json.Unmarshal(response, &result))
+		authURL, err := url.Parse(result.AuthorizationURL)
+		assert.NoError(t, err)
+		assert.Equal(t, external.GOOGLE_GMAIL_SCOPE, authURL.Query().Get("scope"))
+	})
+	UnauthorizedTest(t, http.MethodPost, "/gmail/upgrade_scopes/", nil)
+}
+
+func TestGmailLabelsList(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	email := "gmail_labels_list@resonant-kelpie-404a42.netlify.app"
+	authToken := login(email, "")
+
+	t.Run("MissingAccountID", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodGet, "/gmail/labels/", nil, http.StatusBadRequest, api)
+	})
+	t.Run("AccountNotFound", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodGet, "/gmail/labels/?account_id=someone_else@resonant-kelpie-404a42.netlify.app", nil, http.StatusNotFound, api)
+	})
+	t.Run("MissingScope", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodGet, "/gmail/labels/?account_id="+url.QueryEscape(email), nil, http.StatusBadRequest, api)
+	})
+	UnauthorizedTest(t, http.MethodGet, "/gmail/labels/", nil)
+}
+
+func TestGmailTaskLabelModify(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	email := "gmail_task_label_modify@resonant-kelpie-404a42.netlify.app"
+	authToken := login(email, "")
+
+	t.Run("MissingLabelID", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPatch, "/gmail/task_label/", bytes.NewBuffer([]byte(`{"account_id": "`+email+`"}`)), http.StatusBadRequest, api)
+	})
+	t.Run("MissingScope", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPatch, "/gmail/task_label/", bytes.NewBuffer([]byte(`{"account_id": "`+email+`", "label_id": "Label_1"}`)), http.StatusBadRequest, api)
+	})
+	UnauthorizedTest(t, http.MethodPatch, "/gmail/task_label/", nil)
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/gin-gonic/gin"
+)
+
+type GoogleTasksScopeUpgradeParams struct {
+	AccountID string `json:"account_id" binding:"required"`
+}
+
+// GoogleTasksScopeUpgrade godoc
+// @Summary      Starts granting access to a Google account's tasks
+// @Description  Turns on syncing Google Tasks for an account that's already linked. Only the tasks scope is asked for, and the grant is added to the existing account on callback
+// @Tags         linked_accounts
+// @Accept       json
+// @Produce      json
+// @Param        account_id   body     string  true "Google account ID"
+// @Success      200 {object} RelinkLinkedAccountResult
+// @Failure      400 {object} string "invalid params, or the account already has the scope"
+// @Failure      404 {object} string "account not found"
+// @Failure      500 {object} string "internal server error"
+// @Router       /google_tasks/upgrade_scopes/ [post]
+func (api *API) GoogleTasksScopeUpgrade(c *gin.Context) {
+	var params GoogleTasksScopeUpgradeParams
+	err := c.ShouldBindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing 'account_id' parameter.", "account_id")
+		return
+	}
+	api.startGoogleScopeUpgrade(c, params.AccountID, external.GOOGLE_TASKS_SCOPE, "account already has access to Google Tasks")
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/jobs"
+	"github.com/gin-gonic/gin"
+)
+
+const HEALTH_STATUS_OK = "ok"
+const HEALTH_STATUS_ERROR = "error"
+
+const READINESS_DB_PING_TIMEOUT = 2 * time.Second
+
+// the background workers are considered down after missing a few heartbeats in a row
+const WORKER_HEARTBEAT_MAX_AGE = 3 * jobs.WORKER_HEARTBEAT_INTERVAL
+
+type ReadinessResult struct {
+	Status       string                      `json:"status"`
+	Dependencies map[string]DependencyStatus `json:"dependencies"`
+}
+
+type DependencyStatus struct {
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Healthz godoc
+// @Summary      Liveness check
+// @Description  succeeds as long as the server process is serving requests
+// @Tags         utils
+// @Success      200 {object} ReadinessResult
+// @Router       /healthz [get]
+func (api *API) Healthz(c *gin.Context) {
+	c.JSON(200, ReadinessResult{Status: HEALTH_STATUS_OK, Dependencies: map[string]DependencyStatus{}})
+}
+
+// Readyz godoc
+// @Summary      Readiness check
+// @Description  checks the database, external service config, and background workers, returning 503 if any are unhealthy
+// @Tags         utils
+// @Success      200 {object} ReadinessResult
+// @Failure      503 {object} ReadinessResult
+// @Router       /readyz [get]
+func (api *API) Readyz(c *gin.Context) {
+	dependencies := map[string]DependencyStatus{
+		"mongodb":         api.getDatabaseStatus(c.Request.Context()),
+		"external_config": api.getExternalConfigStatus(),
+		"worker":          api.getWorkerStatus(jobs.GetLastWorkerHeartbeat()),
+	}
+	result := ReadinessResult{Status: HEALTH_STATUS_OK, Dependencies: dependencies}
+	for name, dependency := range dependencies {
+		if dependency.Status != HEALTH_STATUS_OK {
+			api.Logger.Error().Msgf("readiness check failed for %s: %s", name, dependency.Detail)
+			result.Status = HEALTH_STATUS_ERROR
+		}
+	}
+	if result.Status != HEALTH_STATUS_OK {
+		c.JSON(503, result)
+		return
+	}
+	c.JSON(200, result)
+}
+
+func (api *API) getDatabaseStatus(ctx context.Context) DependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, READINESS_DB_PING_TIMEOUT)
+	defer cancel()
+	err := api.DB.Client().Ping(ctx, nil)
+	if err != nil {
+		return DependencyStatus{Status: HEALTH_STATUS_ERROR, Detail: "failed to ping database"}
+	}
+	return DependencyStatus{Status: HEALTH_STATUS_OK}
+}
+
+func (api *API) getExternalConfigStatus() DependencyStatus {
+	misconfigured := api.ExternalConfig.GetMisconfiguredOauthConfigs()
+	if len(misconfigured) > 0 {
+		return DependencyStatus{Status: HEALTH_STATUS_ERROR, Detail: "missing oauth client credentials: " + strings.Join(misconfigured, ", ")}
+	}
+	return DependencyStatus{Status: HEALTH_STATUS_OK}
+}
+
+func (api *API) getWorkerStatus(lastHeartbeat time.Time) DependencyStatus {
+	if lastHeartbeat.IsZero() {
+		return DependencyStatus{Status: HEALTH_STATUS_ERROR, Detail: "no heartbeat received"}
+	}
+	age := api.GetCurrentTime().Sub(lastHeartbeat)
+	if age > WORKER_HEARTBEAT_MAX_AGE {
+		return DependencyStatus{Status: HEALTH_STATUS_ERROR, Detail: fmt.Sprintf("last heartbeat was %s ago", age.Round(time.Second))}
+	}
+	return DependencyStatus{Status: HEALTH_STATUS_OK}
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/franchizzle/task-manager/backend/jobs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthz(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	body := ServeRequest(t, "", "GET", "/healthz", nil, http.StatusOK, api)
+	assert.Equal(t, `{"status":"ok","dependencies":{}}`, string(body))
+}
+
+func TestReadyz(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	testTime := time.Date(2023, time.January, 4, 20, 0, 0, 0, time.UTC)
+	api.OverrideTime = &testTime
+
+	t.Run("Success", func(t *testing.T) {
+		jobs.RecordWorkerHeartbeat(testTime.Add(-time.Minute))
+		body := ServeRequest(t, "", "GET", "/readyz", nil, http.StatusOK, api)
+		assert.Equal(t, `{"status":"ok","dependencies":{"external_config":{"status":"ok"},"mongodb":{"status":"ok"},"worker":{"status":"ok"}}}`, string(body))
+	})
+	t.Run("StaleHeartbeat", func(t *testing.T) {
+		jobs.RecordWorkerHeartbeat(testTime.Add(-10 * time.Minute))
+		body := ServeRequest(t, "", "GET", "/readyz", nil, http.StatusServiceUnavailable, api)
+		var result ReadinessResult
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, HEALTH_STATUS_ERROR, result.Status)
+		assert.Equal(t, DependencyStatus{Status: HEALTH_STATUS_ERROR, Detail: "last heartbeat was 10m0s ago"}, result.Dependencies["worker"])
+		assert.Equal(t, HEALTH_STATUS_OK, result.Dependencies["mongodb"].Status)
+	})
+	t.Run("MisconfiguredExternalConfig", func(t *testing.T) {
+		jobs.RecordWorkerHeartbeat(testTime)
+		api.ExternalConfig.Asana = &external.OauthConfig{}
+		defer func() { api.ExternalConfig = external.GetConfig() }()
+		body := ServeRequest(t, "", "GET", "/readyz", nil, http.StatusServiceUnavailable, api)
+		var result ReadinessResult
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, DependencyStatus{Status: HEALTH_STATUS_ERROR, Detail: "missing oauth client credentials: asana"}, result.Dependencies["external_config"])
+		assert.Equal(t, HEALTH_STATUS_OK, result.Dependencies["worker"].Status)
+	})
+}
+
+func TestGetWorkerStatus(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	testTime := time.Date(2023, time.January, 4, 20, 0, 0, 0, time.UTC)
+	api.OverrideTime = &testTime
+
+	assert.Equal(t, DependencyStatus{Status: HEALTH_STATUS_ERROR, Detail: "no heartbeat received"}, api.getWorkerStatus(time.Time{}))
+	assert.Equal(t, DependencyStatus{Status: HEALTH_STATUS_OK}, api.getWorkerStatus(testTime.Add(-3*time.Minute)))
+	assert.Equal(t, HEALTH_STATUS_ERROR, api.getWorkerStatus(testTime.Add(-3*time.Minute-time.Second)).Status)
+}
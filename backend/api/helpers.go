@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"regexp"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/franchizzle/task-manager/backend/database"
 	"github.com/franchizzle/task-manager/backend/logging"
+	"github.com/franchizzle/task-manager/backend/settings"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -76,8 +78,31 @@ func GetTimezoneOffsetFromHeader(c *gin.Context) (time.Duration, error) {
 	return duration, nil
 }
 
-func getValidExternalOwnerAssignedTask(db *mongo.Database, userID primitive.ObjectID, taskTitle string) (*database.User, string, error) {
-	fromToken, err := database.GetUser(db, userID)
+// getTimezoneOffset returns the offset of the timezone the user picked in their settings, falling
+// back to the Timezone-Offset header when they left it to be detected automatically
+func (api *API) getTimezoneOffset(c *gin.Context) (time.Duration, error) {
+	location, err := settings.GetTimezoneOverride(api.DB, getUserIDFromContext(c))
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to load timezone setting")
+	}
+	if location == nil {
+		return GetTimezoneOffsetFromHeader(c)
+	}
+	_, offsetSeconds := api.GetCurrentTime().In(location).Zone()
+	return -time.Duration(offsetSeconds) * time.Second, nil
+}
+
+// getWorkingHours returns the user's working hours, or the defaults if they can't be loaded
+func (api *API) getWorkingHours(userID primitive.ObjectID) settings.WorkingHours {
+	workingHours, err := settings.GetWorkingHours(api.DB, userID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to load working hours")
+	}
+	return workingHours
+}
+
+func getValidExternalOwnerAssignedTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, taskTitle string) (*database.User, string, error) {
+	fromToken, err := database.GetUser(ctx, db, userID)
 	if err != nil {
 		return nil, "", err
 	}
@@ -91,7 +116,7 @@ func getValidExternalOwnerAssignedTask(db *mongo.Database, userID primitive.Obje
 		name := regex.FindString(taskTitle)
 		name = strings.Trim(name, "<to ")
 		name = strings.Trim(name, ">")
-		matchingUser, err := database.GetGeneralTaskUserByName(db, name)
+		matchingUser, err := database.GetGeneralTaskUserByName(ctx, db, name)
 		if err != nil {
 			return nil, "", err
 		}
@@ -2,9 +2,14 @@ package api
 
 import (
 	"context"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/franchizzle/task-manager/backend/constants"
 	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/settings"
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -24,24 +29,71 @@ func TestGetValidExternalOwnerAssignedTask(t *testing.T) {
 	assert.NoError(t, err)
 
 	t.Run("InvalidCallingUser", func(t *testing.T) {
-		_, title, err := getValidExternalOwnerAssignedTask(api.DB, primitive.NewObjectID(), "HELLO!")
+		_, title, err := getValidExternalOwnerAssignedTask(context.Background(), api.DB, primitive.NewObjectID(), "HELLO!")
 		assert.Error(t, err)
 		assert.Equal(t, "", title)
 	})
 	t.Run("InvalidDestinationUser", func(t *testing.T) {
-		_, title, err := getValidExternalOwnerAssignedTask(api.DB, primitive.NewObjectID(), "<to example>HELLO!")
+		_, title, err := getValidExternalOwnerAssignedTask(context.Background(), api.DB, primitive.NewObjectID(), "<to example>HELLO!")
 		assert.Error(t, err)
 		assert.Equal(t, "", title)
 	})
 	t.Run("InvalidTitle", func(t *testing.T) {
-		_, title, err := getValidExternalOwnerAssignedTask(api.DB, julianUser.InsertedID.(primitive.ObjectID), "HELLO!")
+		_, title, err := getValidExternalOwnerAssignedTask(context.Background(), api.DB, julianUser.InsertedID.(primitive.ObjectID), "HELLO!")
 		assert.Error(t, err)
 		assert.Equal(t, "", title)
 	})
 	t.Run("Success", func(t *testing.T) {
-		user, title, err := getValidExternalOwnerAssignedTask(api.DB, julianUser.InsertedID.(primitive.ObjectID), "<to john>Hello there!")
+		user, title, err := getValidExternalOwnerAssignedTask(context.Background(), api.DB, julianUser.InsertedID.(primitive.ObjectID), "<to john>Hello there!")
 		assert.NoError(t, err)
 		assert.Equal(t, "Hello there! from: julian@resonant-kelpie-404a42.netlify.app", title)
 		assert.Equal(t, johnUser.InsertedID.(primitive.ObjectID), user.ID)
 	})
 }
+
+func TestGetTimezoneOffset(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	// in winter, so Los Angeles is 8 hours behind UTC
+	currentTime := time.Date(2023, time.January, 6, 12, 0, 0, 0, time.UTC)
+	api.OverrideTime = &currentTime
+
+	authToken := login("test_timezone_offset@resonant-kelpie-404a42.netlify.app", "")
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	getContext := func(timezoneOffsetHeader string) *gin.Context {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("GET", "/", nil)
+		if timezoneOffsetHeader != "" {
+			c.Request.Header.Set("Timezone-Offset", timezoneOffsetHeader)
+		}
+		c.Set("user", userID)
+		return c
+	}
+
+	t.Run("FromHeader", func(t *testing.T) {
+		offset, err := api.getTimezoneOffset(getContext("300"))
+		assert.NoError(t, err)
+		assert.Equal(t, 5*time.Hour, offset)
+	})
+	t.Run("MissingHeader", func(t *testing.T) {
+		_, err := api.getTimezoneOffset(getContext(""))
+		assert.EqualError(t, err, "Timezone-Offset header is required")
+	})
+	t.Run("Setting", func(t *testing.T) {
+		err := settings.UpdateUserSetting(api.DB, userID, constants.SettingFieldTimezone, "America/Los_Angeles")
+		assert.NoError(t, err)
+		offset, err := api.getTimezoneOffset(getContext("300"))
+		assert.NoError(t, err)
+		assert.Equal(t, 8*time.Hour, offset)
+		offset, err = api.getTimezoneOffset(getContext(""))
+		assert.NoError(t, err)
+		assert.Equal(t, 8*time.Hour, offset)
+	})
+}
+
+// setAllDayWorkingHours stops working hours from depending on what day the test time falls on
+func setAllDayWorkingHours(t *testing.T, api *API, userID primitive.ObjectID) {
+	assert.NoError(t, settings.UpdateUserSetting(api.DB, userID, constants.SettingFieldWorkingHoursStart, "00:00"))
+	assert.NoError(t, settings.UpdateUserSetting(api.DB, userID, constants.SettingFieldWorkingHoursEnd, "24:00"))
+	assert.NoError(t, settings.UpdateUserSetting(api.DB, userID, constants.SettingFieldWorkdays, constants.ChoiceKeyEveryDay))
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const IMPORT_MAX_FILE_BYTES = 10 * 1024 * 1024
+
+type ImportParams struct {
+	Source string `form:"source" binding:"required"`
+	DryRun bool   `form:"dry_run"`
+}
+
+type ImportTaskResult struct {
+	IDExternal  string `json:"id_external"`
+	Title       string `json:"title"`
+	IsDuplicate bool   `json:"is_duplicate"`
+}
+
+type ImportResult struct {
+	DryRun           bool               `json:"dry_run"`
+	CreatedCount     int                `json:"created_count"`
+	DuplicateCount   int                `json:"duplicate_count"`
+	CompletedSkipped int                `json:"completed_skipped_count"`
+	Tasks            []ImportTaskResult `json:"tasks"`
+}
+
+// Import godoc
+// @Summary      Imports tasks from a Todoist or Asana export file
+// @Description  The request body is the raw CSV export. Tasks already imported (matched by id_external) are skipped
+// @Tags         import
+// @Accept       text/csv
+// @Produce      json
+// @Param        source   query     string  true   "todoist or asana"
+// @Param        dry_run  query     bool    false  "report what would be created without creating anything"
+// @Success      200 {object} ImportResult
+// @Failure      400 {object} string "invalid params"
+// @Failure      500 {object} string "internal server error"
+// @Router       /import/ [post]
+func (api *API) Import(c *gin.Context) {
+	var params ImportParams
+	err := c.BindQuery(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	parser, err := external.GetImportParser(params.Source)
+	if err != nil {
+		HandleBadRequest(c, err.Error())
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, IMPORT_MAX_FILE_BYTES+1))
+	if err != nil {
+		HandleBadRequest(c, "failed to read export file")
+		return
+	}
+	if len(body) > IMPORT_MAX_FILE_BYTES {
+		HandleBadRequest(c, "export file is too large")
+		return
+	}
+	importedTasks, err := parser(bytes.NewReader(body))
+	if err != nil {
+		HandleBadRequest(c, "failed to parse export file: "+err.Error())
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	result, err := api.importTasks(c.Request.Context(), userID, importedTasks, params.DryRun)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to import tasks")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, result)
+}
+
+func (api *API) importTasks(ctx context.Context, userID primitive.ObjectID, importedTasks []external.ImportedTask, dryRun bool) (*ImportResult, error) {
+	result := ImportResult{DryRun: dryRun, Tasks: []ImportTaskResult{}}
+
+	idExternals := []string{}
+	for _, importedTask := range importedTasks {
+		idExternals = append(idExternals, importedTask.IDExternal)
+	}
+	existingTasks, err := database.GetTasks(ctx, api.DB, userID, &[]bson.M{
+		{"source_id": external.TASK_SOURCE_ID_GT_TASK},
+		{"id_external": bson.M{"$in": idExternals}},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	seenIDExternals := map[string]bool{}
+	for _, task := range *existingTasks {
+		seenIDExternals[task.IDExternal] = true
+	}
+
+	now := primitive.NewDateTimeFromTime(api.GetCurrentTime())
+	taskSectionID := database.GetDefaultTaskSectionID(ctx, api.DB, userID, external.TASK_SOURCE_ID_GT_TASK)
+	tasksToCreate := []interface{}{}
+	for _, importedTask := range importedTasks {
+		// completed tasks are history rather than work, so they are left behind
+		if importedTask.IsCompleted {
+			result.CompletedSkipped += 1
+			continue
+		}
+		isDuplicate := seenIDExternals[importedTask.IDExternal]
+		result.Tasks = append(result.Tasks, ImportTaskResult{
+			IDExternal:  importedTask.IDExternal,
+			Title:       importedTask.Title,
+			IsDuplicate: isDuplicate,
+		})
+		if isDuplicate {
+			result.DuplicateCount += 1
+			continue
+		}
+		// the same task can appear twice in one file (e.g. Todoist backups of nested projects)
+		seenIDExternals[importedTask.IDExternal] = true
+		result.CreatedCount += 1
+		tasksToCreate = append(tasksToCreate, getTaskFromImportedTask(userID, importedTask, taskSectionID, now))
+	}
+
+	if dryRun || len(tasksToCreate) == 0 {
+		return &result, nil
+	}
+	_, err = database.GetTaskCollection(api.DB).InsertMany(ctx, tasksToCreate)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func getTaskFromImportedTask(userID primitive.ObjectID, importedTask external.ImportedTask, taskSectionID primitive.ObjectID, now primitive.DateTime) database.Task {
+	title := importedTask.Title
+	body := importedTask.Body
+	timeAllocation := time.Hour.Nanoseconds()
+	completed := false
+	deleted := false
+	task := database.Task{
+		UserID:            userID,
+		IDExternal:        importedTask.IDExternal,
+		IDTaskSection:     taskSectionID,
+		SourceID:          external.TASK_SOURCE_ID_GT_TASK,
+		SourceAccountID:   external.GeneralTaskDefaultAccountID,
+		Title:             &title,
+		Body:              &body,
+		TimeAllocation:    &timeAllocation,
+		IsCompleted:       &completed,
+		IsDeleted:         &deleted,
+		CreatedAtExternal: now,
+		UpdatedAt:         now,
+	}
+	if importedTask.DueDate != nil {
+		dueDate := primitive.NewDateTimeFromTime(*importedTask.DueDate)
+		task.DueDate = &dueDate
+	}
+	return task
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestImport(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+
+	authToken := login("test_import@resonant-kelpie-404a42.netlify.app", "")
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+
+	asanaExport := "Task ID,Created At,Completed At,Name,Due Date,Notes\n" +
+		"1,2023-01-01,,first task,2023-01-20,some notes\n" +
+		"2,2023-01-01,,second task,,\n" +
+		"3,2023-01-01,2023-01-02,finished task,,\n"
+
+	UnauthorizedTest(t, http.MethodPost, "/import/?source=asana", nil)
+	t.Run("MissingSource", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPost, "/import/", strings.NewReader(asanaExport), http.StatusBadRequest, api)
+	})
+	t.Run("UnsupportedSource", func(t *testing.T) {
+		body := ServeRequest(t, authToken, http.MethodPost, "/import/?source=trello", strings.NewReader(asanaExport), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"unsupported import source","code":"bad_request"}`, string(body))
+	})
+	t.Run("InvalidFile", func(t *testing.T) {
+		body := ServeRequest(t, authToken, http.MethodPost, "/import/?source=asana", strings.NewReader("Name\nfoo\n"), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"failed to parse export file: export file is missing column Task ID","code":"bad_request"}`, string(body))
+	})
+	t.Run("DryRun", func(t *testing.T) {
+		body := ServeRequest(t, authToken, http.MethodPost, "/import/?source=asana&dry_run=true", strings.NewReader(asanaExport), http.StatusOK, api)
+		var result ImportResult
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.True(t, result.DryRun)
+		assert.Equal(t, 2, result.CreatedCount)
+		assert.Equal(t, 0, result.DuplicateCount)
+		assert.Equal(t, 1, result.CompletedSkipped)
+
+		tasks, err := database.GetTasks(context.Background(), api.DB, userID, &[]bson.M{{"id_external": bson.M{"$in": []string{"asana_1", "asana_2"}}}}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(*tasks))
+	})
+	t.Run("Success", func(t *testing.T) {
+		body := ServeRequest(t, authToken, http.MethodPost, "/import/?source=asana", strings.NewReader(asanaExport), http.StatusOK, api)
+		var result ImportResult
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, 2, result.CreatedCount)
+
+		tasks, err := database.GetTasks(context.Background(), api.DB, userID, &[]bson.M{{"id_external": "asana_1"}}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(*tasks))
+		task := (*tasks)[0]
+		assert.Equal(t, "first task", *task.Title)
+		assert.Equal(t, "some notes", *task.Body)
+		assert.Equal(t, external.TASK_SOURCE_ID_GT_TASK, task.SourceID)
+		assert.NotNil(t, task.DueDate)
+	})
+	t.Run("Deduplicates", func(t *testing.T) {
+		body := ServeRequest(t, authToken, http.MethodPost, "/import/?source=asana", strings.NewReader(asanaExport), http.StatusOK, api)
+		var result ImportResult
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, 0, result.CreatedCount)
+		assert.Equal(t, 2, result.DuplicateCount)
+		assert.True(t, result.Tasks[0].IsDuplicate)
+	})
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	guuid "github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AdminInviteCodeCreateParams struct {
+	MaxUses int `json:"max_uses" binding:"required,min=1"`
+}
+
+type InviteCodeResult struct {
+	ID         primitive.ObjectID `json:"id"`
+	Code       string             `json:"code"`
+	IsReferral bool               `json:"is_referral"`
+	MaxUses    int                `json:"max_uses"`
+	Uses       int                `json:"uses"`
+	CreatedAt  string             `json:"created_at"`
+}
+
+// newInviteCode is short enough to be typed in by hand
+func newInviteCode() string {
+	return strings.ToUpper(strings.ReplaceAll(guuid.New().String(), "-", "")[:10])
+}
+
+// ReferralInviteCodeGet returns the invite code the user can share to get people past the waitlist, along
+// with how many times it's been used
+func (api *API) ReferralInviteCodeGet(c *gin.Context) {
+	inviteCode, err := database.GetOrCreateReferralInviteCode(c.Request.Context(), api.DB, getUserIDFromContext(c), newInviteCode(), constants.REFERRAL_INVITE_CODE_MAX_USES, api.GetCurrentTime())
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	c.JSON(200, getInviteCodeResult(*inviteCode))
+}
+
+func (api *API) AdminInviteCodesList(c *gin.Context) {
+	cursor, err := database.GetInviteCodeCollection(api.DB).Find(
+		c.Request.Context(),
+		bson.M{"is_referral": false},
+		options.Find().SetSort(bson.M{"created_at": -1}),
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch invite codes")
+		Handle500(c)
+		return
+	}
+	var inviteCodes []database.InviteCode
+	err = cursor.All(c.Request.Context(), &inviteCodes)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch invite codes")
+		Handle500(c)
+		return
+	}
+	results := []InviteCodeResult{}
+	for _, inviteCode := range inviteCodes {
+		results = append(results, getInviteCodeResult(inviteCode))
+	}
+	c.JSON(200, results)
+}
+
+func (api *API) AdminInviteCodeCreate(c *gin.Context) {
+	var params AdminInviteCodeCreateParams
+	err := c.ShouldBindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing 'max_uses' parameter.", "max_uses")
+		return
+	}
+	inviteCode := database.InviteCode{
+		Code:      newInviteCode(),
+		UserID:    getUserIDFromContext(c),
+		MaxUses:   params.MaxUses,
+		CreatedAt: primitive.NewDateTimeFromTime(api.GetCurrentTime()),
+	}
+	result, err := database.GetInviteCodeCollection(api.DB).InsertOne(c.Request.Context(), &inviteCode)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to create invite code")
+		Handle500(c)
+		return
+	}
+	inviteCode.ID = result.InsertedID.(primitive.ObjectID)
+	c.JSON(201, getInviteCodeResult(inviteCode))
+}
+
+func getInviteCodeResult(inviteCode database.InviteCode) InviteCodeResult {
+	return InviteCodeResult{
+		ID:         inviteCode.ID,
+		Code:       inviteCode.Code,
+		IsReferral: inviteCode.IsReferral,
+		MaxUses:    inviteCode.MaxUses,
+		Uses:       inviteCode.Uses,
+		CreatedAt:  formatSessionTime(inviteCode.CreatedAt),
+	}
+}
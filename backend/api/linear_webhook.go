@@ -74,7 +74,7 @@ func (api *API) LinearWebhook(c *gin.Context) {
 	requestIP := c.Request.Header.Get("X-Forwarded-For")
 	if !strings.Contains(requestIP, ValidLinearIP1) && !strings.Contains(requestIP, ValidLinearIP2) {
 		api.Logger.Error().Msg("incorrect IP for linear webhook: " + requestIP)
-		c.JSON(400, gin.H{"detail": "invalid request format"})
+		HandleBadRequest(c, "invalid request format")
 		return
 	}
 
@@ -82,7 +82,7 @@ func (api *API) LinearWebhook(c *gin.Context) {
 	body, err := io.ReadAll(c.Request.Body)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("unable to read linear webhook request body")
-		c.JSON(400, gin.H{"detail": "unable to read request body"})
+		HandleBadRequest(c, "unable to read request body")
 		return
 	}
 	// this is required, as the first write fully consumes the body
@@ -94,7 +94,7 @@ func (api *API) LinearWebhook(c *gin.Context) {
 	err = json.Unmarshal(body, &webhookPayload)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("unable to process linear webhook payload")
-		c.JSON(400, gin.H{"detail": "unable to process linear webhook payload"})
+		HandleBadRequest(c, "unable to process linear webhook payload")
 		return
 	}
 
@@ -102,27 +102,27 @@ func (api *API) LinearWebhook(c *gin.Context) {
 	case IssueType:
 		var issuePayload LinearIssuePayload
 		if err := json.Unmarshal([]byte(*webhookPayload.RawData), &issuePayload); (err != nil || issuePayload == LinearIssuePayload{}) {
-			c.JSON(400, gin.H{"detail": "unable to unmarshal linear issue object"})
+			HandleBadRequest(c, "unable to unmarshal linear issue object")
 			return
 		}
 		err = api.processLinearIssueWebhook(c, webhookPayload, issuePayload)
 		if err != nil {
-			c.JSON(400, gin.H{"detail": "unable to process linear issue webhook"})
+			HandleBadRequest(c, "unable to process linear issue webhook")
 			return
 		}
 	case CommentType:
 		var commentPayload LinearCommentPayload
 		if err := json.Unmarshal([]byte(*webhookPayload.RawData), &commentPayload); (err != nil || commentPayload == LinearCommentPayload{}) {
-			c.JSON(400, gin.H{"detail": "unable to unmarshal linear issue object"})
+			HandleBadRequest(c, "unable to unmarshal linear issue object")
 			return
 		}
 		err = api.processLinearCommentWebhook(c, webhookPayload, commentPayload)
 		if err != nil {
-			c.JSON(400, gin.H{"detail": "unable to process linear comment webhook"})
+			HandleBadRequest(c, "unable to process linear comment webhook")
 			return
 		}
 	default:
-		c.JSON(400, gin.H{"detail": "unrecognized linear payload format"})
+		HandleBadRequest(c, "unrecognized linear payload format")
 		return
 	}
 
@@ -130,11 +130,11 @@ func (api *API) LinearWebhook(c *gin.Context) {
 }
 
 func (api *API) processLinearIssueWebhook(c *gin.Context, webhookPayload LinearWebhookPayload, issuePayload LinearIssuePayload) error {
-	token, err := database.GetExternalTokenByExternalID(api.DB, issuePayload.AssigneeID, external.TASK_SERVICE_ID_LINEAR, false)
+	token, err := database.GetExternalTokenByExternalID(c.Request.Context(), api.DB, issuePayload.AssigneeID, external.TASK_SERVICE_ID_LINEAR, false)
 	if err != nil {
 		// if the owner of the task is not found, we must check if the task exists
 		// if the task does exist, we must delete as we do not want it to show on the user's list
-		api.removeTaskOwnerIfExists(issuePayload)
+		api.removeTaskOwnerIfExists(c.Request.Context(), issuePayload)
 		return err
 	}
 
@@ -143,23 +143,23 @@ func (api *API) processLinearIssueWebhook(c *gin.Context, webhookPayload LinearW
 
 	switch webhookPayload.Action {
 	case CreateAction:
-		err = api.createOrModifyIssueFromPayload(userID, accountID, webhookPayload, issuePayload)
+		err = api.createOrModifyIssueFromPayload(c.Request.Context(), userID, accountID, webhookPayload, issuePayload)
 	case UpdateAction:
-		err = api.createOrModifyIssueFromPayload(userID, accountID, webhookPayload, issuePayload)
+		err = api.createOrModifyIssueFromPayload(c.Request.Context(), userID, accountID, webhookPayload, issuePayload)
 	case RemoveAction:
-		err = api.removeIssueFromPayload(userID, issuePayload)
+		err = api.removeIssueFromPayload(c.Request.Context(), userID, issuePayload)
 	default:
 		err = errors.New("action type not recognized")
 	}
 	return err
 }
 
-func (api *API) createOrModifyIssueFromPayload(userID primitive.ObjectID, accountID string, webhookPayload LinearWebhookPayload, issuePayload LinearIssuePayload) error {
+func (api *API) createOrModifyIssueFromPayload(ctx context.Context, userID primitive.ObjectID, accountID string, webhookPayload LinearWebhookPayload, issuePayload LinearIssuePayload) error {
 	logger := logging.GetSentryLogger()
 
 	task := populateLinearTask(userID, accountID, webhookPayload, issuePayload)
 
-	statuses, err := api.getTaskStatuses(userID, accountID, issuePayload)
+	statuses, err := api.getTaskStatuses(ctx, userID, accountID, issuePayload)
 	if err != nil {
 		return err
 	}
@@ -172,7 +172,7 @@ func (api *API) createOrModifyIssueFromPayload(userID primitive.ObjectID, accoun
 		task.IsCompleted = &isCompleted
 	}
 
-	dbTask, err := database.GetTaskByExternalIDWithoutUser(api.DB, issuePayload.ID, false)
+	dbTask, err := database.GetTaskByExternalIDWithoutUser(ctx, api.DB, issuePayload.ID, false)
 	if err != nil && err != mongo.ErrNoDocuments {
 		logger := logging.GetSentryLogger()
 		logger.Error().Err(err).Msg("could not find matching linear issue")
@@ -180,9 +180,12 @@ func (api *API) createOrModifyIssueFromPayload(userID primitive.ObjectID, accoun
 	}
 	if dbTask != nil && dbTask.UserID == userID && dbTask.IDTaskSection != primitive.NilObjectID {
 		task.IDTaskSection = dbTask.IDTaskSection
+	} else {
+		task.IDTaskSection = database.GetDefaultTaskSectionID(ctx, api.DB, userID, external.TASK_SOURCE_ID_LINEAR)
 	}
 
 	_, err = database.UpdateOrCreateTask(
+		ctx,
 		api.DB,
 		userID,
 		task.IDExternal,
@@ -198,8 +201,8 @@ func (api *API) createOrModifyIssueFromPayload(userID primitive.ObjectID, accoun
 	return nil
 }
 
-func (api *API) removeIssueFromPayload(userID primitive.ObjectID, issuePayload LinearIssuePayload) error {
-	task, err := database.GetTaskByExternalIDWithoutUser(api.DB, issuePayload.ID, false)
+func (api *API) removeIssueFromPayload(ctx context.Context, userID primitive.ObjectID, issuePayload LinearIssuePayload) error {
+	task, err := database.GetTaskByExternalIDWithoutUser(ctx, api.DB, issuePayload.ID, false)
 	if err != nil {
 		return err
 	}
@@ -216,12 +219,12 @@ func (api *API) removeIssueFromPayload(userID primitive.ObjectID, issuePayload L
 func (api *API) processLinearCommentWebhook(c *gin.Context, webhookPayload LinearWebhookPayload, commentPayload LinearCommentPayload) error {
 	var err error
 	logger := logging.GetSentryLogger()
-	task, err := database.GetTaskByExternalIDWithoutUser(api.DB, commentPayload.IssueID, false)
+	task, err := database.GetTaskByExternalIDWithoutUser(c.Request.Context(), api.DB, commentPayload.IssueID, false)
 	if err != nil {
 		return err
 	}
 
-	token, err := database.GetExternalToken(api.DB, task.SourceAccountID, external.TASK_SERVICE_ID_LINEAR)
+	token, err := database.GetExternalToken(c.Request.Context(), api.DB, task.SourceAccountID, external.TASK_SERVICE_ID_LINEAR)
 	if err != nil {
 		return err
 	}
@@ -232,7 +235,7 @@ func (api *API) processLinearCommentWebhook(c *gin.Context, webhookPayload Linea
 
 	switch webhookPayload.Action {
 	case CreateAction:
-		err = api.createCommentFromPayload(userID, userIDExternal, accountID, commentPayload, task)
+		err = api.createCommentFromPayload(c.Request.Context(), userID, userIDExternal, accountID, commentPayload, task)
 	case UpdateAction:
 		err = api.modifyCommentFromPayload(userID, commentPayload, task)
 	case RemoveAction:
@@ -244,8 +247,8 @@ func (api *API) processLinearCommentWebhook(c *gin.Context, webhookPayload Linea
 	return err
 }
 
-func (api *API) createCommentFromPayload(userID primitive.ObjectID, externalUserID string, accountID string, commentPayload LinearCommentPayload, task *database.Task) error {
-	userStruct, err := api.getLinearUserInfo(userID, accountID, externalUserID)
+func (api *API) createCommentFromPayload(ctx context.Context, userID primitive.ObjectID, externalUserID string, accountID string, commentPayload LinearCommentPayload, task *database.Task) error {
+	userStruct, err := api.getLinearUserInfo(ctx, userID, accountID, externalUserID)
 	if err != nil {
 		return err
 	}
@@ -321,7 +324,7 @@ func (api *API) updateComments(task *database.Task, userID primitive.ObjectID, c
 	return api.UpdateTaskInDBWithError(task, userID, &updateTask)
 }
 
-func (api *API) getLinearStatusClient(userID primitive.ObjectID, accountID string) (*graphql.Client, error) {
+func (api *API) getLinearStatusClient(ctx context.Context, userID primitive.ObjectID, accountID string) (*graphql.Client, error) {
 	logger := logging.GetSentryLogger()
 
 	linearTaskSource, err := api.getLinearTaskSource()
@@ -330,7 +333,7 @@ func (api *API) getLinearStatusClient(userID primitive.ObjectID, accountID strin
 		return nil, err
 	}
 
-	client, err := external.GetLinearClient(linearTaskSource.Linear.Config.ConfigValues.StatusFetchURL, api.DB, userID, accountID)
+	client, err := external.GetLinearClient(ctx, linearTaskSource.Linear.Config.ConfigValues.StatusFetchURL, api.DB, userID, accountID)
 	if err != nil {
 		logger.Error().Err(err).Msg("unable to create linear client")
 		return nil, err
@@ -339,7 +342,7 @@ func (api *API) getLinearStatusClient(userID primitive.ObjectID, accountID strin
 	return client, nil
 }
 
-func (api *API) getLinearUserInfo(userID primitive.ObjectID, accountID string, externalUserID string) (*external.LinearExternalUserInfoQuery, error) {
+func (api *API) getLinearUserInfo(ctx context.Context, userID primitive.ObjectID, accountID string, externalUserID string) (*external.LinearExternalUserInfoQuery, error) {
 	logger := logging.GetSentryLogger()
 
 	linearTaskSource, err := api.getLinearTaskSource()
@@ -348,13 +351,13 @@ func (api *API) getLinearUserInfo(userID primitive.ObjectID, accountID string, e
 		return nil, err
 	}
 
-	client, err := external.GetBasicLinearClient(linearTaskSource.Linear.Config.ConfigValues.UserInfoURL, api.DB, userID, accountID)
+	client, err := external.GetBasicLinearClient(ctx, linearTaskSource.Linear.Config.ConfigValues.UserInfoURL, api.DB, userID, accountID)
 	if err != nil {
 		logger.Error().Err(err).Msg("unable to create linear client")
 		return nil, err
 	}
 
-	return external.GetLinearUserInfoStructByID(client, externalUserID)
+	return external.GetLinearUserInfoStructByID(ctx, client, externalUserID)
 }
 
 func populateLinearTask(userID primitive.ObjectID, accountID string, webhookPayload LinearWebhookPayload, issuePayload LinearIssuePayload) *database.Task {
@@ -404,8 +407,8 @@ func getCompletedLinearStatus(teamStatuses []*database.ExternalTaskStatus) *data
 	return nil
 }
 
-func (api *API) removeTaskOwnerIfExists(issuePayload LinearIssuePayload) {
-	task, err := database.GetTaskByExternalIDWithoutUser(api.DB, issuePayload.ID, false)
+func (api *API) removeTaskOwnerIfExists(ctx context.Context, issuePayload LinearIssuePayload) {
+	task, err := database.GetTaskByExternalIDWithoutUser(ctx, api.DB, issuePayload.ID, false)
 	if err != nil {
 		// don't log this error because could be a task that shouldn't exist in GT
 		return
@@ -413,7 +416,7 @@ func (api *API) removeTaskOwnerIfExists(issuePayload LinearIssuePayload) {
 
 	taskCollection := database.GetTaskCollection(api.DB)
 	_, err = taskCollection.DeleteOne(
-		context.Background(),
+		ctx,
 		bson.M{"_id": task.ID},
 	)
 	if err != nil {
@@ -422,16 +425,16 @@ func (api *API) removeTaskOwnerIfExists(issuePayload LinearIssuePayload) {
 	}
 }
 
-func (api *API) getTaskStatuses(userID primitive.ObjectID, accountID string, issuePayload LinearIssuePayload) ([]*database.ExternalTaskStatus, error) {
+func (api *API) getTaskStatuses(ctx context.Context, userID primitive.ObjectID, accountID string, issuePayload LinearIssuePayload) ([]*database.ExternalTaskStatus, error) {
 	logger := logging.GetSentryLogger()
 
-	client, err := api.getLinearStatusClient(userID, accountID)
+	client, err := api.getLinearStatusClient(ctx, userID, accountID)
 	if err != nil {
 		logger.Error().Err(err).Msg("unable to generate linear client")
 		return nil, err
 	}
 
-	statuses, err := external.GetLinearWorkflowStates(client)
+	statuses, err := external.GetLinearWorkflowStates(ctx, client)
 	if err != nil {
 		logger.Error().Err(err).Msg("unable to get linear workflow states")
 		return nil, err
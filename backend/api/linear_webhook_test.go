@@ -33,7 +33,7 @@ func TestWebhookHandler(t *testing.T) {
 
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"invalid request format\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"invalid request format\",\"code\":\"bad_request\"}", string(body))
 	})
 
 	t.Run("InvalidFormat", func(t *testing.T) {
@@ -49,7 +49,7 @@ func TestWebhookHandler(t *testing.T) {
 
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"unable to process linear webhook payload\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"unable to process linear webhook payload\",\"code\":\"bad_request\"}", string(body))
 	})
 	t.Run("InvalidType", func(t *testing.T) {
 		request, _ := http.NewRequest(
@@ -64,7 +64,7 @@ func TestWebhookHandler(t *testing.T) {
 
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"unrecognized linear payload format\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"unrecognized linear payload format\",\"code\":\"bad_request\"}", string(body))
 	})
 }
 
@@ -117,7 +117,7 @@ func TestProcessComments(t *testing.T) {
 
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"unable to process linear comment webhook\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"unable to process linear comment webhook\",\"code\":\"bad_request\"}", string(body))
 	})
 	t.Run("InvalidAction", func(t *testing.T) {
 		request, _ := http.NewRequest(
@@ -132,7 +132,7 @@ func TestProcessComments(t *testing.T) {
 
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"unable to process linear comment webhook\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"unable to process linear comment webhook\",\"code\":\"bad_request\"}", string(body))
 	})
 	t.Run("CreateCommentSuccess", func(t *testing.T) {
 		request, _ := http.NewRequest(
@@ -145,7 +145,7 @@ func TestProcessComments(t *testing.T) {
 		router.ServeHTTP(recorder, request)
 		assert.Equal(t, http.StatusOK, recorder.Code)
 
-		task, err := database.GetTaskByExternalIDWithoutUser(db, "externalID", false)
+		task, err := database.GetTaskByExternalIDWithoutUser(context.Background(), db, "externalID", false)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(*task.Comments))
 	})
@@ -160,7 +160,7 @@ func TestProcessComments(t *testing.T) {
 		router.ServeHTTP(recorder, request)
 		assert.Equal(t, http.StatusOK, recorder.Code)
 
-		task, err := database.GetTaskByExternalIDWithoutUser(db, "externalID", false)
+		task, err := database.GetTaskByExternalIDWithoutUser(context.Background(), db, "externalID", false)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(*task.Comments))
 	})
@@ -175,7 +175,7 @@ func TestProcessComments(t *testing.T) {
 		router.ServeHTTP(recorder, request)
 		assert.Equal(t, http.StatusOK, recorder.Code)
 
-		task, err := database.GetTaskByExternalIDWithoutUser(db, "externalID", false)
+		task, err := database.GetTaskByExternalIDWithoutUser(context.Background(), db, "externalID", false)
 		assert.NoError(t, err)
 		assert.Equal(t, 2, len(*task.Comments))
 	})
@@ -190,7 +190,7 @@ func TestProcessComments(t *testing.T) {
 		router.ServeHTTP(recorder, request)
 		assert.Equal(t, http.StatusOK, recorder.Code)
 
-		task, err := database.GetTaskByExternalIDWithoutUser(db, "externalID", false)
+		task, err := database.GetTaskByExternalIDWithoutUser(context.Background(), db, "externalID", false)
 		assert.NoError(t, err)
 		assert.Equal(t, 2, len(*task.Comments))
 		assert.Equal(t, "modified text", (*task.Comments)[0].Body)
@@ -206,7 +206,7 @@ func TestProcessComments(t *testing.T) {
 		router.ServeHTTP(recorder, request)
 		assert.Equal(t, http.StatusOK, recorder.Code)
 
-		task, err := database.GetTaskByExternalIDWithoutUser(db, "externalID", false)
+		task, err := database.GetTaskByExternalIDWithoutUser(context.Background(), db, "externalID", false)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(*task.Comments))
 	})
@@ -277,7 +277,7 @@ func TestProcessIssue(t *testing.T) {
 
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"unable to process linear issue webhook\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"unable to process linear issue webhook\",\"code\":\"bad_request\"}", string(body))
 	})
 	t.Run("InvalidIssuePayload", func(t *testing.T) {
 		request, _ := http.NewRequest(
@@ -292,7 +292,7 @@ func TestProcessIssue(t *testing.T) {
 
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"unable to unmarshal linear issue object\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"unable to unmarshal linear issue object\",\"code\":\"bad_request\"}", string(body))
 	})
 	t.Run("CreateIssueSuccess", func(t *testing.T) {
 		request, _ := http.NewRequest(
@@ -305,7 +305,7 @@ func TestProcessIssue(t *testing.T) {
 		router.ServeHTTP(recorder, request)
 		assert.Equal(t, http.StatusOK, recorder.Code)
 
-		task, err := database.GetTaskByExternalIDWithoutUser(db, "aaad850c-8df6-482f-90b0-82725bd54155", false)
+		task, err := database.GetTaskByExternalIDWithoutUser(context.Background(), db, "aaad850c-8df6-482f-90b0-82725bd54155", false)
 		assert.NoError(t, err)
 		assert.Equal(t, "Hello there!", *task.Title)
 		assert.Equal(t, "6942069422", task.CompletedStatus.ExternalID)
@@ -322,12 +322,12 @@ func TestProcessIssue(t *testing.T) {
 		router.ServeHTTP(recorder, request)
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 
-		_, err := database.GetTaskByExternalIDWithoutUser(db, "aaad850c-8df6-482f-90b0-82725bd54155", false)
+		_, err := database.GetTaskByExternalIDWithoutUser(context.Background(), db, "aaad850c-8df6-482f-90b0-82725bd54155", false)
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"unable to process linear issue webhook\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"unable to process linear issue webhook\",\"code\":\"bad_request\"}", string(body))
 	})
 	t.Run("ModifyIssueSuccess", func(t *testing.T) {
 		request, _ := http.NewRequest(
@@ -340,12 +340,12 @@ func TestProcessIssue(t *testing.T) {
 		router.ServeHTTP(recorder, request)
 		assert.Equal(t, http.StatusOK, recorder.Code)
 
-		task, err := database.GetTaskByExternalIDWithoutUser(db, "aaad850c-8df6-482f-90b0-82725bd54155", false)
+		task, err := database.GetTaskByExternalIDWithoutUser(context.Background(), db, "aaad850c-8df6-482f-90b0-82725bd54155", false)
 		assert.NoError(t, err)
 		assert.Equal(t, "Hello there!", *task.Title)
 		assert.Equal(t, "6942069422", task.CompletedStatus.ExternalID)
 
-		task, err = database.GetTaskByExternalIDWithoutUser(db, "aaad850c-8df6-482f-90b0-82725bd54155", false)
+		task, err = database.GetTaskByExternalIDWithoutUser(context.Background(), db, "aaad850c-8df6-482f-90b0-82725bd54155", false)
 		assert.NoError(t, err)
 		taskCollection := database.GetTaskCollection(db)
 		newSectionID := primitive.NewObjectID()
@@ -362,7 +362,7 @@ func TestProcessIssue(t *testing.T) {
 		router.ServeHTTP(recorder, request)
 		assert.Equal(t, http.StatusOK, recorder.Code)
 
-		task, err = database.GetTaskByExternalIDWithoutUser(db, "aaad850c-8df6-482f-90b0-82725bd54155", false)
+		task, err = database.GetTaskByExternalIDWithoutUser(context.Background(), db, "aaad850c-8df6-482f-90b0-82725bd54155", false)
 		assert.NoError(t, err)
 		assert.Equal(t, "Hello there 2.0!", *task.Title)
 		assert.Equal(t, "6942069422", task.CompletedStatus.ExternalID)
@@ -379,7 +379,7 @@ func TestProcessIssue(t *testing.T) {
 		router.ServeHTTP(recorder, request)
 		assert.Equal(t, http.StatusOK, recorder.Code)
 
-		task, err := database.GetTaskByExternalIDWithoutUser(db, "aaad850c-8df6-482f-90b0-82725bd54155", false)
+		task, err := database.GetTaskByExternalIDWithoutUser(context.Background(), db, "aaad850c-8df6-482f-90b0-82725bd54155", false)
 		assert.NoError(t, err)
 		assert.Equal(t, "Hello there 2.0!", *task.Title)
 		assert.Equal(t, "6942069422", task.CompletedStatus.ExternalID)
@@ -399,7 +399,7 @@ func TestProcessIssue(t *testing.T) {
 
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"unable to process linear issue webhook\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"unable to process linear issue webhook\",\"code\":\"bad_request\"}", string(body))
 	})
 	t.Run("RemoveIssueSuccess", func(t *testing.T) {
 		request, _ := http.NewRequest(
@@ -412,7 +412,7 @@ func TestProcessIssue(t *testing.T) {
 		router.ServeHTTP(recorder, request)
 		assert.Equal(t, http.StatusOK, recorder.Code)
 
-		task, err := database.GetTaskByExternalIDWithoutUser(db, "aaad850c-8df6-482f-90b0-82725bd54155", false)
+		task, err := database.GetTaskByExternalIDWithoutUser(context.Background(), db, "aaad850c-8df6-482f-90b0-82725bd54155", false)
 		assert.NoError(t, err)
 		assert.Equal(t, true, *task.IsDeleted)
 	})
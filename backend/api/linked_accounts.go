@@ -4,11 +4,13 @@ import (
 	"context"
 
 	"github.com/franchizzle/task-manager/backend/config"
+	"github.com/franchizzle/task-manager/backend/constants"
 	"github.com/franchizzle/task-manager/backend/database"
 	"github.com/franchizzle/task-manager/backend/external"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/exp/slices"
 )
 
 type SupportedAccountType struct {
@@ -26,10 +28,24 @@ type linkedAccount struct {
 	LogoV2       string `json:"logo_v2"`
 	IsUnlinkable bool   `json:"is_unlinkable"`
 	HasBadToken  bool   `json:"has_bad_token"`
+	// why and when the token stopped working, if it has
+	BadTokenReason string             `json:"bad_token_reason,omitempty"`
+	BadTokenAt     string             `json:"bad_token_at,omitempty"`
+	TokenHealth    []tokenHealthEvent `json:"token_health,omitempty"`
+	// whether syncing Google Tasks has been turned on, for Google accounts
+	HasGoogleTasksScope bool `json:"has_google_tasks_scope,omitempty"`
+	// whether turning labelled emails into tasks has been turned on, for Google accounts
+	HasGmailScope bool `json:"has_gmail_scope,omitempty"`
+}
+
+type tokenHealthEvent struct {
+	Status    string `json:"status"`
+	Detail    string `json:"detail"`
+	CreatedAt string `json:"created_at"`
 }
 
 func (api *API) SupportedAccountTypesList(c *gin.Context) {
-	serverURL := config.GetConfigValue("SERVER_URL")
+	serverURL := config.GetSettings().ServerURL
 	nameToService := api.ExternalConfig.GetNameToService()
 	supportedAccountTypes := []SupportedAccountType{}
 	for serviceName, service := range nameToService {
@@ -38,6 +54,9 @@ func (api *API) SupportedAccountTypesList(c *gin.Context) {
 		if !service.Details.IsLinkable || serviceName == external.TASK_SERVICE_ID_SLACK_APP {
 			continue
 		}
+		if !external.IsTaskServiceConfigured(serviceName) {
+			continue
+		}
 		supportedAccountTypes = append(supportedAccountTypes, SupportedAccountType{
 			Name:             service.Details.Name,
 			Logo:             service.Details.Logo,
@@ -77,7 +96,12 @@ func (api *API) LinkedAccountsList(c *gin.Context) {
 			Handle500(c)
 			return
 		}
-		linkedAccounts = append(linkedAccounts, linkedAccount{
+		healthEvents, err := database.GetExternalTokenHealthEvents(c.Request.Context(), api.DB, token.ID, constants.TOKEN_HEALTH_HISTORY_LIMIT)
+		if err != nil {
+			Handle500(c)
+			return
+		}
+		account := linkedAccount{
 			ID:           token.ID.Hex(),
 			DisplayID:    token.DisplayID,
 			Name:         taskServiceResult.Details.Name,
@@ -85,7 +109,23 @@ func (api *API) LinkedAccountsList(c *gin.Context) {
 			LogoV2:       taskServiceResult.Details.LogoV2,
 			IsUnlinkable: token.IsUnlinkable,
 			HasBadToken:  token.IsBadToken,
-		})
+		}
+		if token.ServiceID == external.TASK_SERVICE_ID_GOOGLE {
+			account.HasGoogleTasksScope = slices.Contains(token.Scopes, external.GOOGLE_TASKS_SCOPE)
+			account.HasGmailScope = slices.Contains(token.Scopes, external.GOOGLE_GMAIL_SCOPE)
+		}
+		if token.IsBadToken {
+			account.BadTokenReason = token.BadTokenReason
+			account.BadTokenAt = formatSessionTime(token.BadTokenAt)
+		}
+		for _, event := range healthEvents {
+			account.TokenHealth = append(account.TokenHealth, tokenHealthEvent{
+				Status:    event.Status,
+				Detail:    event.Detail,
+				CreatedAt: formatSessionTime(event.CreatedAt),
+			})
+		}
+		linkedAccounts = append(linkedAccounts, account)
 	}
 	c.JSON(200, linkedAccounts)
 }
@@ -115,7 +155,7 @@ func (api *API) DeleteLinkedAccount(c *gin.Context) {
 		return
 	}
 	if !accountToDelete.IsUnlinkable {
-		c.JSON(400, gin.H{"detail": "account is not unlinkable"})
+		HandleBadRequest(c, "account is not unlinkable")
 		return
 	}
 	if accountToDelete.ServiceID == external.TASK_SERVICE_ID_GITHUB {
@@ -159,5 +199,10 @@ func (api *API) DeleteLinkedAccount(c *gin.Context) {
 		Handle500(c)
 		return
 	}
+	api.recordAuditEvent(c, accountToDelete.UserID, database.AuditLog{
+		EventType: constants.AuditEventAccountUnlinked,
+		ServiceID: accountToDelete.ServiceID,
+		AccountID: accountToDelete.AccountID,
+	})
 	c.JSON(200, gin.H{})
 }
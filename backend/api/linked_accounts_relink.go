@@ -0,0 +1,183 @@
+package api
+
+import (
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type RelinkLinkedAccountResult struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+// RelinkLinkedAccount godoc
+// @Summary      Starts linking an account again
+// @Description  Used to fix accounts with bad tokens. The new token replaces the old one in place, so the account's repositories, calendars and settings are kept
+// @Tags         linked_accounts
+// @Accept       json
+// @Produce      json
+// @Param        account_id  path  string  true "linked account ID"
+// @Success      200 {object} RelinkLinkedAccountResult
+// @Failure      400 {object} string "account can't be relinked"
+// @Failure      404 {object} string "account not found"
+// @Failure      500 {object} string "internal server error"
+// @Router       /linked_accounts/{account_id}/relink/ [post]
+func (api *API) RelinkLinkedAccount(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	tokenID, err := primitive.ObjectIDFromHex(c.Param("account_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	var externalToken database.ExternalAPIToken
+	err = database.GetExternalTokenCollection(api.DB).FindOne(c.Request.Context(), bson.M{"_id": tokenID, "user_id": userID}).Decode(&externalToken)
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	taskServiceResult, err := api.ExternalConfig.GetTaskServiceResult(externalToken.ServiceID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch task service")
+		Handle500(c)
+		return
+	}
+	if taskServiceResult.Details.AuthType != external.AuthTypeOauth2 {
+		HandleBadRequest(c, "account can't be relinked")
+		return
+	}
+	stateTokenID, err := database.CreateRelinkStateToken(c.Request.Context(), api.DB, userID, externalToken.ID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	authURL, err := taskServiceResult.Service.GetLinkURL(stateTokenID, userID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	c.JSON(200, RelinkLinkedAccountResult{AuthorizationURL: *authURL})
+}
+
+// relinkCallback links the account as usual, then puts back the fields of the existing token that linking
+// resets. The token is only replaced when the user signs in to the same account; linking any other
+// account they sign in to is undone
+func (api *API) relinkCallback(c *gin.Context, taskServiceResult *external.TaskServiceResult, callbackParams external.CallbackParams, userID primitive.ObjectID, relinkTokenID primitive.ObjectID) {
+	serviceID := taskServiceResult.Details.ID
+	tokensBefore, err := database.GetExternalTokens(c.Request.Context(), api.DB, userID, serviceID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	var relinkToken *database.ExternalAPIToken
+	tokenStringsBefore := map[primitive.ObjectID]string{}
+	for index, token := range *tokensBefore {
+		tokenStringsBefore[token.ID] = token.Token
+		if token.ID == relinkTokenID {
+			relinkToken = &(*tokensBefore)[index]
+		}
+	}
+	if relinkToken == nil {
+		HandleError(c, ErrorCodeNotFound, "account not found")
+		return
+	}
+
+	err = taskServiceResult.Service.HandleLinkCallback(api.DB, callbackParams, userID)
+	if err != nil {
+		HandleError(c, ErrorCodeInternal, err.Error())
+		return
+	}
+
+	tokensAfter, err := database.GetExternalTokens(c.Request.Context(), api.DB, userID, serviceID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	relinkTokenChanged := false
+	otherTokenChanged := false
+	relinkedAccountID := ""
+	var relinkedScopes []string
+	newTokenIDs := []primitive.ObjectID{}
+	for _, token := range *tokensAfter {
+		if token.ID == relinkTokenID {
+			relinkedAccountID = token.AccountID
+			relinkedScopes = token.Scopes
+		}
+		tokenStringBefore, existed := tokenStringsBefore[token.ID]
+		if !existed {
+			newTokenIDs = append(newTokenIDs, token.ID)
+		} else if token.Token != tokenStringBefore {
+			if token.ID == relinkTokenID {
+				relinkTokenChanged = true
+			} else {
+				otherTokenChanged = true
+			}
+		}
+	}
+	// some services, like Linear, upsert on the service rather than the account, so check the account
+	// the token is for as well as which token changed
+	linkedOtherAccount := len(newTokenIDs) > 0 || otherTokenChanged
+	if relinkedAccountID != relinkToken.AccountID || (!relinkTokenChanged && linkedOtherAccount) {
+		err = api.undoRelink(c, userID, relinkToken, newTokenIDs)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to undo linking a different account while relinking")
+			Handle500(c)
+			return
+		}
+		HandleBadRequest(c, "signed in to a different account than the one being relinked")
+		return
+	}
+
+	_, err = database.GetExternalTokenCollection(api.DB).UpdateOne(
+		c.Request.Context(),
+		bson.M{"_id": relinkTokenID},
+		bson.M{"$set": bson.M{
+			"is_unlinkable":           relinkToken.IsUnlinkable,
+			"is_primary_login":        relinkToken.IsPrimaryLogin,
+			"last_full_refresh_time":  relinkToken.LastFullRefreshTime,
+			"timezone":                relinkToken.Timezone,
+			"is_bad_token":            false,
+			"refresh_failure_count":   0,
+			"next_refresh_attempt_at": primitive.DateTime(0),
+			"bad_token_at":            primitive.DateTime(0),
+			"bad_token_reason":        "",
+		}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to restore relinked account")
+		Handle500(c)
+		return
+	}
+	if serviceID == external.TASK_SERVICE_ID_GOOGLE {
+		// so the calendar account shows the scopes granted while relinking before its next fetch
+		err = database.UpdateCalendarAccountScopes(c.Request.Context(), api.DB, userID, relinkToken.AccountID, external.TASK_SOURCE_ID_GCAL, relinkedScopes)
+		if err != nil {
+			Handle500(c)
+			return
+		}
+	}
+	_ = database.InsertExternalTokenHealthEvent(c.Request.Context(), api.DB, database.ExternalTokenHealthEvent{
+		TokenID:   relinkTokenID,
+		UserID:    userID,
+		ServiceID: serviceID,
+		Status:    constants.TokenHealthRelinked,
+		CreatedAt: primitive.NewDateTimeFromTime(api.GetCurrentTime()),
+	})
+	api.recordAuditEvent(c, userID, database.AuditLog{EventType: constants.AuditEventAccountRelinked, ServiceID: serviceID, AccountID: relinkToken.AccountID})
+	writeLinkSuccess(c)
+}
+
+// undoRelink removes the accounts linked while relinking and puts back the token being relinked
+func (api *API) undoRelink(c *gin.Context, userID primitive.ObjectID, relinkToken *database.ExternalAPIToken, newTokenIDs []primitive.ObjectID) error {
+	externalAPITokenCollection := database.GetExternalTokenCollection(api.DB)
+	if len(newTokenIDs) > 0 {
+		_, err := externalAPITokenCollection.DeleteMany(c.Request.Context(), bson.M{"_id": bson.M{"$in": newTokenIDs}, "user_id": userID})
+		if err != nil {
+			return err
+		}
+	}
+	_, err := externalAPITokenCollection.ReplaceOne(c.Request.Context(), bson.M{"_id": relinkToken.ID}, relinkToken)
+	return err
+}
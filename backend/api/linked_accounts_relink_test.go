@@ -0,0 +1,97 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/franchizzle/task-manager/backend/testutils"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRelinkLinkedAccount(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	tokenServer := testutils.GetMockAPIServer(t, http.StatusOK, LinearTokenPayload)
+	(api.ExternalConfig.Linear.OauthConfig.(*external.OauthConfig)).Config.Endpoint.TokenURL = tokenServer.URL
+	userInfoServer := testutils.GetMockAPIServer(t, http.StatusOK, LinearUserInfoPayload)
+	api.ExternalConfig.Linear.ConfigValues.UserInfoURL = &userInfoServer.URL
+
+	insertBadLinearToken := func(t *testing.T, authToken string, accountID string) primitive.ObjectID {
+		result, err := database.GetExternalTokenCollection(api.DB).InsertOne(context.Background(), &database.ExternalAPIToken{
+			ServiceID:      external.TASK_SERVICE_ID_LINEAR,
+			UserID:         getUserIDFromAuthToken(t, api.DB, authToken),
+			AccountID:      accountID,
+			DisplayID:      "Linear",
+			Token:          `{"access_token":"old-token"}`,
+			IsUnlinkable:   true,
+			IsBadToken:     true,
+			BadTokenReason: "Token has been expired or revoked",
+		})
+		assert.NoError(t, err)
+		return result.InsertedID.(primitive.ObjectID)
+	}
+	relink := func(t *testing.T, authToken string, tokenID primitive.ObjectID, expectedCode int) {
+		response := ServeRequest(t, authToken, http.MethodPost, "/linked_accounts/"+tokenID.Hex()+"/relink/", nil, http.StatusOK, api)
+		var result RelinkLinkedAccountResult
+		assert.NoError(t, json.Unmarshal(response, &result))
+		authURL, err := url.Parse(result.AuthorizationURL)
+		assert.NoError(t, err)
+		state := authURL.Query().Get("state")
+		assert.NotEmpty(t, state)
+
+		request, _ := http.NewRequest(http.MethodGet, "/link/linear/callback/?code=123abc&state="+state, nil)
+		request.AddCookie(&http.Cookie{Name: "authToken", Value: authToken})
+		recorder := httptest.NewRecorder()
+		GetRouter(api).ServeHTTP(recorder, request)
+		assert.Equal(t, expectedCode, recorder.Code)
+	}
+	countLinearTokens := func(t *testing.T, authToken string) int64 {
+		count, err := database.GetExternalTokenCollection(api.DB).CountDocuments(context.Background(), bson.M{"user_id": getUserIDFromAuthToken(t, api.DB, authToken), "service_id": external.TASK_SERVICE_ID_LINEAR})
+		assert.NoError(t, err)
+		return count
+	}
+
+	t.Run("NotFound", func(t *testing.T) {
+		authToken := login("relink_not_found@resonant-kelpie-404a42.netlify.app", "")
+		ServeRequest(t, authToken, http.MethodPost, "/linked_accounts/123/relink/", nil, http.StatusNotFound, api)
+		ServeRequest(t, authToken, http.MethodPost, "/linked_accounts/"+primitive.NewObjectID().Hex()+"/relink/", nil, http.StatusNotFound, api)
+		otherAuthToken := login("relink_other_user@resonant-kelpie-404a42.netlify.app", "")
+		tokenID := insertBadLinearToken(t, otherAuthToken, "sample-linear-id")
+		ServeRequest(t, authToken, http.MethodPost, "/linked_accounts/"+tokenID.Hex()+"/relink/", nil, http.StatusNotFound, api)
+	})
+	t.Run("Success", func(t *testing.T) {
+		authToken := login("relink_success@resonant-kelpie-404a42.netlify.app", "")
+		tokenID := insertBadLinearToken(t, authToken, "test@resonant-kelpie-404a42.netlify.app")
+		relink(t, authToken, tokenID, http.StatusOK)
+
+		assert.Equal(t, int64(1), countLinearTokens(t, authToken))
+		var externalToken database.ExternalAPIToken
+		assert.NoError(t, database.GetExternalTokenCollection(api.DB).FindOne(context.Background(), bson.M{"_id": tokenID}).Decode(&externalToken))
+		assert.Contains(t, externalToken.Token, "sample-linear-access-token")
+		assert.False(t, externalToken.IsBadToken)
+		assert.Empty(t, externalToken.BadTokenReason)
+		assert.True(t, externalToken.IsUnlinkable)
+		assert.Equal(t, "test@resonant-kelpie-404a42.netlify.app", externalToken.AccountID)
+	})
+	t.Run("DifferentAccount", func(t *testing.T) {
+		authToken := login("relink_different_account@resonant-kelpie-404a42.netlify.app", "")
+		tokenID := insertBadLinearToken(t, authToken, "other@resonant-kelpie-404a42.netlify.app")
+		relink(t, authToken, tokenID, http.StatusBadRequest)
+
+		assert.Equal(t, int64(1), countLinearTokens(t, authToken))
+		var externalToken database.ExternalAPIToken
+		assert.NoError(t, database.GetExternalTokenCollection(api.DB).FindOne(context.Background(), bson.M{"_id": tokenID}).Decode(&externalToken))
+		assert.True(t, externalToken.IsBadToken)
+		assert.Equal(t, "other@resonant-kelpie-404a42.netlify.app", externalToken.AccountID)
+		assert.Equal(t, `{"access_token":"old-token"}`, externalToken.Token)
+	})
+	UnauthorizedTest(t, http.MethodPost, "/linked_accounts/"+primitive.NewObjectID().Hex()+"/relink/", nil)
+}
@@ -2,12 +2,15 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/franchizzle/task-manager/backend/constants"
 	"github.com/franchizzle/task-manager/backend/database"
 	"github.com/franchizzle/task-manager/backend/external"
 	"github.com/stretchr/testify/assert"
@@ -86,6 +89,39 @@ func TestLinkedAccountsList(t *testing.T) {
 		googleTokenID := getGoogleTokenFromAuthToken(t, api.DB, authToken).ID.Hex()
 		assert.Equal(t, "[{\"id\":\""+googleTokenID+"\",\"display_id\":\"linkedaccounts3@resonant-kelpie-404a42.netlify.app\",\"name\":\"Google Calendar\",\"logo\":\"/images/gcal.png\",\"logo_v2\":\"gcal\",\"is_unlinkable\":false,\"has_bad_token\":false},{\"id\":\""+linearTokenID+"\",\"display_id\":\"Linear\",\"name\":\"Linear\",\"logo\":\"/images/linear.png\",\"logo_v2\":\"linear\",\"is_unlinkable\":true,\"has_bad_token\":true}]", string(body))
 	})
+	t.Run("BadTokenHealth", func(t *testing.T) {
+		authToken := login("linkedaccounts4@resonant-kelpie-404a42.netlify.app", "")
+		linearTokenID := insertLinearToken(t, api.DB, authToken, true)
+		brokeAt := time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)
+		_, err := database.GetExternalTokenCollection(api.DB).UpdateOne(
+			context.Background(),
+			bson.M{"_id": linearTokenID},
+			bson.M{"$set": bson.M{"bad_token_at": primitive.NewDateTimeFromTime(brokeAt), "bad_token_reason": "invalid_grant"}},
+		)
+		assert.NoError(t, err)
+		for i, status := range []string{constants.TokenHealthRefreshFailed, constants.TokenHealthBad} {
+			assert.NoError(t, database.InsertExternalTokenHealthEvent(context.Background(), api.DB, database.ExternalTokenHealthEvent{
+				TokenID:   linearTokenID,
+				Status:    status,
+				Detail:    "invalid_grant",
+				CreatedAt: primitive.NewDateTimeFromTime(brokeAt.Add(time.Duration(i-1) * time.Hour)),
+			}))
+		}
+
+		body := ServeRequest(t, authToken, "GET", "/linked_accounts/", nil, http.StatusOK, api)
+		var accounts []linkedAccount
+		assert.NoError(t, json.Unmarshal(body, &accounts))
+		assert.Equal(t, 2, len(accounts))
+		assert.Empty(t, accounts[0].BadTokenReason)
+		assert.Equal(t, linearTokenID.Hex(), accounts[1].ID)
+		assert.True(t, accounts[1].HasBadToken)
+		assert.Equal(t, "invalid_grant", accounts[1].BadTokenReason)
+		assert.Equal(t, "2023-03-01T12:00:00Z", accounts[1].BadTokenAt)
+		assert.Equal(t, []tokenHealthEvent{
+			{Status: constants.TokenHealthBad, Detail: "invalid_grant", CreatedAt: "2023-03-01T12:00:00Z"},
+			{Status: constants.TokenHealthRefreshFailed, Detail: "invalid_grant", CreatedAt: "2023-03-01T11:00:00Z"},
+		}, accounts[1].TokenHealth)
+	})
 	UnauthorizedTest(t, "GET", "/linked_accounts/", nil)
 }
 
@@ -104,7 +140,7 @@ func TestDeleteLinkedAccount(t *testing.T) {
 		authToken := login("approved@resonant-kelpie-404a42.netlify.app", "")
 		googleAccountID := getGoogleTokenFromAuthToken(t, api.DB, authToken).ID
 		body := ServeRequest(t, authToken, "DELETE", "/linked_accounts/"+googleAccountID.Hex()+"/", nil, http.StatusBadRequest, api)
-		assert.Equal(t, "{\"detail\":\"account is not unlinkable\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"account is not unlinkable\",\"code\":\"bad_request\"}", string(body))
 	})
 	t.Run("AccountDifferentUser", func(t *testing.T) {
 		authToken := login("approved@resonant-kelpie-404a42.netlify.app", "")
@@ -190,7 +226,7 @@ func TestDeleteLinkedAccount(t *testing.T) {
 		notUserID := primitive.NewObjectID()
 		accountID := "correctAccountID"
 
-		calendarAccountToDelete, err := database.UpdateOrCreateCalendarAccount(api.DB, userID, "123abc", "foobar_source",
+		calendarAccountToDelete, err := database.UpdateOrCreateCalendarAccount(context.Background(), api.DB, userID, "123abc", "foobar_source",
 			&database.CalendarAccount{
 				UserID:     userID,
 				IDExternal: accountID,
@@ -199,7 +235,7 @@ func TestDeleteLinkedAccount(t *testing.T) {
 			}, nil)
 		assert.NoError(t, err)
 
-		calendarAccountNotToDelete, err := database.UpdateOrCreateCalendarAccount(api.DB, notUserID, "123abc", "foobar_source",
+		calendarAccountNotToDelete, err := database.UpdateOrCreateCalendarAccount(context.Background(), api.DB, notUserID, "123abc", "foobar_source",
 			&database.CalendarAccount{
 				UserID:     notUserID,
 				IDExternal: "otherAccountID",
@@ -15,12 +15,12 @@ func (api *API) LogEventAdd(c *gin.Context) {
 	err := c.BindJSON(&params)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("error")
-		c.JSON(400, gin.H{"detail": "invalid or missing 'event_type' parameter."})
+		HandleBindError(c, &params, err, "invalid or missing 'event_type' parameter.", "event_type")
 		return
 	}
 
 	userID, _ := c.Get("user")
-	err = database.InsertLogEvent(api.DB, userID.(primitive.ObjectID), params.EventType)
+	err = database.InsertLogEvent(c.Request.Context(), api.DB, userID.(primitive.ObjectID), params.EventType)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to insert waitlist entry")
 		Handle500(c)
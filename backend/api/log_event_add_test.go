@@ -27,7 +27,7 @@ func TestLogEventAdd(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"invalid or missing 'event_type' parameter.\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"invalid or missing 'event_type' parameter.\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"event_type\"}]}", string(body))
 	})
 	t.Run("MissingEventType", func(t *testing.T) {
 		api, dbCleanup := GetAPIWithDBCleanup()
@@ -43,7 +43,7 @@ func TestLogEventAdd(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"invalid or missing 'event_type' parameter.\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"'event_type' is required\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"event_type\",\"message\":\"'event_type' is required\"}]}", string(body))
 	})
 	t.Run("BadEventType", func(t *testing.T) {
 		api, dbCleanup := GetAPIWithDBCleanup()
@@ -59,7 +59,7 @@ func TestLogEventAdd(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"invalid or missing 'event_type' parameter.\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"invalid or missing 'event_type' parameter.\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"event_type\"}]}", string(body))
 	})
 	t.Run("Success", func(t *testing.T) {
 		addLogEvent(t, authToken)
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,7 +11,6 @@ import (
 	"github.com/franchizzle/task-manager/backend/database"
 	"github.com/franchizzle/task-manager/backend/external"
 	"github.com/gin-gonic/gin"
-	guuid "github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -23,8 +23,9 @@ type GoogleRedirectParams struct {
 }
 
 type LoginRedirectParams struct {
-	ForcePrompt bool `form:"force_prompt"`
-	UseDeeplink bool `form:"use_deeplink"`
+	ForcePrompt bool   `form:"force_prompt"`
+	UseDeeplink bool   `form:"use_deeplink"`
+	InviteCode  string `form:"invite_code"`
 }
 
 // Login godoc
@@ -35,13 +36,14 @@ type LoginRedirectParams struct {
 // @Produce      json
 // @Param        force_prompt   	query     string  false "should use prompt"
 // @Param        use_deeplink   	query     string  false "should use deeplink"
+// @Param        invite_code   	query     string  false "invite code, to sign up while the waitlist is enabled"
 // @Success      302 {object} string "URL redirect"
 // @Failure      500 {object} string "internal server error"
 // @Router       /login/ [get]
 func (api *API) Login(c *gin.Context) {
 	var params LoginRedirectParams
 	forcePrompt := c.ShouldBind(&params) == nil && params.ForcePrompt
-	insertedStateToken, err := database.CreateStateToken(api.DB, nil, params.UseDeeplink)
+	insertedStateToken, err := database.CreateStateToken(c.Request.Context(), api.DB, nil, params.UseDeeplink)
 	if err != nil {
 		Handle500(c)
 		return
@@ -61,10 +63,18 @@ func (api *API) Login(c *gin.Context) {
 		Handle500(c)
 		return
 	}
-	c.SetCookie("loginStateToken", *insertedStateToken, constants.DAY, "/", config.GetConfigValue("COOKIE_DOMAIN"), false, false)
+	setLoginCookies(c, stateTokenID, params)
 	c.Redirect(302, *authURL)
 }
 
+// setLoginCookies remembers the login redirect in the browser, to be checked by the callback
+func setLoginCookies(c *gin.Context, stateTokenID primitive.ObjectID, params LoginRedirectParams) {
+	c.SetCookie("loginStateToken", stateTokenID.Hex(), constants.DAY, "/", config.GetSettings().CookieDomain, false, false)
+	if params.InviteCode != "" {
+		c.SetCookie("loginInviteCode", params.InviteCode, constants.DAY, "/", config.GetSettings().CookieDomain, false, false)
+	}
+}
+
 // LoginCallback godoc
 // @Summary      Begins General Task login process
 // @Description  Finished the logging in process
@@ -82,38 +92,13 @@ func (api *API) Login(c *gin.Context) {
 func (api *API) LoginCallback(c *gin.Context) {
 	var redirectParams GoogleRedirectParams
 	if c.ShouldBind(&redirectParams) != nil || redirectParams.State == "" || redirectParams.Code == "" || redirectParams.Scope == "" {
-		c.Redirect(302, config.GetConfigValue("HOME_URL"))
+		c.Redirect(302, config.GetSettings().HomeURL)
 		return
 	}
 
-	useDeeplinkRedirect := false
-	if !api.SkipStateTokenCheck {
-		stateTokenID, err := primitive.ObjectIDFromHex(redirectParams.State)
-		if err != nil {
-			c.JSON(400, gin.H{"detail": "invalid state token format"})
-			return
-		}
-		stateTokenFromCookie, _ := c.Cookie("loginStateToken")
-		stateTokenIDFromCookie, err := primitive.ObjectIDFromHex(stateTokenFromCookie)
-		if err != nil {
-			c.JSON(400, gin.H{"detail": "invalid state token cookie format"})
-			return
-		}
-		if stateTokenID != stateTokenIDFromCookie {
-			c.JSON(400, gin.H{"detail": "state token does not match cookie"})
-			return
-		}
-		token, err := database.GetStateToken(api.DB, stateTokenID, nil)
-		if err != nil {
-			c.JSON(400, gin.H{"detail": "invalid state token"})
-			return
-		}
-		useDeeplinkRedirect = token.UseDeeplink
-		err = database.DeleteStateToken(api.DB, stateTokenID, nil)
-		if err != nil {
-			c.JSON(400, gin.H{"detail": "invalid state token"})
-			return
-		}
+	useDeeplinkRedirect, ok := api.checkLoginStateToken(c, redirectParams.State)
+	if !ok {
+		return
 	}
 
 	googleService := external.GoogleService{
@@ -121,15 +106,87 @@ func (api *API) LoginCallback(c *gin.Context) {
 		LinkConfig:   api.ExternalConfig.GoogleAuthorizeConfig,
 		OverrideURLs: api.ExternalConfig.GoogleOverrideURLs,
 	}
-	userID, userIsNew, _, err := googleService.HandleSignupCallback(api.DB, external.CallbackParams{Oauth2Code: &redirectParams.Code})
-	if err != nil {
+	inviteCode, _ := c.Cookie("loginInviteCode")
+	userID, userIsNew, _, err := googleService.HandleSignupCallback(c.Request.Context(), api.DB, external.CallbackParams{
+		Oauth2Code: &redirectParams.Code,
+		AuthorizeNewUser: func(email string) error {
+			return api.authorizeNewUser(c.Request.Context(), email, inviteCode)
+		},
+	})
+	var apiError *APIError
+	if errors.As(err, &apiError) {
+		HandleAPIError(c, apiError)
+		return
+	} else if err != nil {
 		api.Logger.Error().Err(err).Msg("Failed to handle signup")
 		Handle500(c)
 		return
 	}
 
-	if userIsNew != nil && *userIsNew {
-		err = createNewUserTasks(userID, api.DB)
+	api.completeLogin(c, userID, userIsNew != nil && *userIsNew, external.TASK_SERVICE_ID_GOOGLE, useDeeplinkRedirect)
+}
+
+// checkLoginStateToken checks the state of an OAuth login redirect was created by this browser, returning
+// whether the login should end with a deeplink to the desktop app
+func (api *API) checkLoginStateToken(c *gin.Context, state string) (bool, bool) {
+	if api.SkipStateTokenCheck {
+		return false, true
+	}
+	stateTokenID, err := primitive.ObjectIDFromHex(state)
+	if err != nil {
+		HandleBadRequest(c, "invalid state token format")
+		return false, false
+	}
+	stateTokenFromCookie, _ := c.Cookie("loginStateToken")
+	stateTokenIDFromCookie, err := primitive.ObjectIDFromHex(stateTokenFromCookie)
+	if err != nil {
+		HandleBadRequest(c, "invalid state token cookie format")
+		return false, false
+	}
+	if stateTokenID != stateTokenIDFromCookie {
+		HandleBadRequest(c, "state token does not match cookie")
+		return false, false
+	}
+	token, err := database.GetStateToken(c.Request.Context(), api.DB, stateTokenID, nil)
+	if err != nil {
+		HandleBadRequest(c, "invalid state token")
+		return false, false
+	}
+	err = database.DeleteStateToken(c.Request.Context(), api.DB, stateTokenID, nil)
+	if err != nil {
+		HandleBadRequest(c, "invalid state token")
+		return false, false
+	}
+	return token.UseDeeplink, true
+}
+
+// completeLogin starts a session for the user who signed in, and redirects them back to the app
+func (api *API) completeLogin(c *gin.Context, userID primitive.ObjectID, userIsNew bool, serviceID string, useDeeplinkRedirect bool) {
+	session, ok := api.startSession(c, userID, userIsNew, serviceID)
+	if !ok {
+		return
+	}
+
+	if inviteCode, _ := c.Cookie("loginInviteCode"); inviteCode != "" {
+		c.SetCookie("loginInviteCode", "", -1, "/", config.GetSettings().CookieDomain, false, false)
+	}
+
+	if useDeeplinkRedirect {
+		c.Redirect(302, fmt.Sprintf(constants.DeeplinkAuthentication, session.Token))
+	} else {
+		c.SetCookie("authToken", session.Token, constants.SESSION_DURATION, "/", config.GetSettings().CookieDomain, false, false)
+		if userIsNew {
+			c.Redirect(302, config.GetSettings().HomeURL+"tos-summary")
+		} else {
+			c.Redirect(302, config.GetSettings().HomeURL)
+		}
+	}
+}
+
+// startSession creates a session for the user who signed in, setting them up first if they're new
+func (api *API) startSession(c *gin.Context, userID primitive.ObjectID, userIsNew bool, serviceID string) (*database.InternalAPIToken, bool) {
+	if userIsNew {
+		err := createNewUserTasks(userID, api.DB)
 		if err != nil {
 			api.Logger.Error().Err(err).Msg("failed to create starter tasks")
 		}
@@ -139,28 +196,14 @@ func (api *API) LoginCallback(c *gin.Context) {
 		}
 	}
 
-	internalToken := guuid.New().String()
-	internalAPITokenCollection := database.GetInternalTokenCollection(api.DB)
-	_, err = internalAPITokenCollection.InsertOne(
-		context.Background(),
-		&database.InternalAPIToken{UserID: userID, Token: internalToken},
-	)
+	session, err := database.CreateInternalToken(c.Request.Context(), api.DB, userID, c.Request.UserAgent(), c.ClientIP())
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to create internal token record")
 		Handle500(c)
-		return
-	}
-
-	if useDeeplinkRedirect {
-		c.Redirect(302, fmt.Sprintf(constants.DeeplinkAuthentication, internalToken))
-	} else {
-		c.SetCookie("authToken", internalToken, constants.MONTH, "/", config.GetConfigValue("COOKIE_DOMAIN"), false, false)
-		if userIsNew != nil && *userIsNew {
-			c.Redirect(302, config.GetConfigValue("HOME_URL")+"tos-summary")
-		} else {
-			c.Redirect(302, config.GetConfigValue("HOME_URL"))
-		}
+		return nil, false
 	}
+	api.recordAuditEvent(c, userID, database.AuditLog{EventType: constants.AuditEventLogin, ServiceID: serviceID})
+	return session, true
 }
 
 func createNewUserTasks(userID primitive.ObjectID, db *mongo.Database) error {
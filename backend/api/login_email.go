@@ -0,0 +1,205 @@
+package api
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/config"
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/templating"
+	"github.com/franchizzle/task-manager/backend/tokens"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const MAGIC_LINK_TOKEN_PURPOSE = "magic_link"
+
+// the service ID recorded in the audit log for logins with a magic link
+const LOGIN_SERVICE_ID_EMAIL = "email"
+
+type LoginEmailParams struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type LoginEmailConfirmParams struct {
+	Token      string `json:"token" binding:"required"`
+	InviteCode string `json:"invite_code"`
+}
+
+type LoginEmailConfirmResult struct {
+	Token     string `json:"token"`
+	IsNewUser bool   `json:"is_new_user"`
+}
+
+// LoginEmail godoc
+// @Summary      Emails a login link
+// @Description  For users who can't sign in with Google or another provider. The response is the same whether or not the email has an account
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        email   	body     string  true "email"
+// @Success      200 {object} string "success"
+// @Failure      400 {object} string "invalid params"
+// @Failure      500 {object} string "internal server error"
+// @Router       /login/email/ [post]
+func (api *API) LoginEmail(c *gin.Context) {
+	var params LoginEmailParams
+	err := c.ShouldBindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing 'email' parameter.", "email")
+		return
+	}
+	email := strings.ToLower(params.Email)
+	now := api.GetCurrentTime()
+
+	recentLinkCount, err := database.CountMagicLinksSince(c.Request.Context(), api.DB, email, now.Add(-time.Duration(constants.MAGIC_LINK_RATE_INTERVAL)*time.Second))
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	if recentLinkCount >= constants.MAGIC_LINK_MAX_PER_INTERVAL {
+		// answered like any other request, so the limit doesn't reveal anything about the email
+		api.Logger.Warn().Msg("too many magic links requested for email")
+		c.JSON(200, gin.H{})
+		return
+	}
+
+	issuer, err := tokens.NewIssuer(config.GetSettings().MagicLinkSigningSecret)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to create magic link issuer")
+		Handle500(c)
+		return
+	}
+	token, claims, err := issuer.Issue(email, MAGIC_LINK_TOKEN_PURPOSE, time.Duration(constants.MAGIC_LINK_DURATION)*time.Second, now)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to issue magic link token")
+		Handle500(c)
+		return
+	}
+	err = database.CreateMagicLink(c.Request.Context(), api.DB, &database.MagicLink{
+		Nonce:     claims.Nonce,
+		Email:     email,
+		CreatedAt: primitive.NewDateTimeFromTime(now),
+		ExpiresAt: primitive.NewDateTimeFromTime(time.Unix(claims.ExpiresAt, 0)),
+	})
+	if err != nil {
+		Handle500(c)
+		return
+	}
+
+	htmlBody, err := templating.RenderEmail(getMagicLinkEmailContent(token))
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to render magic link email")
+		Handle500(c)
+		return
+	}
+	err = api.EmailSender.SendEmail(email, "Your General Task login link", htmlBody)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to send magic link email")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+// LoginEmailConfirm godoc
+// @Summary      Logs in with the token from a login link
+// @Description  Each link can only be used once. Users are created on their first login, if they have access
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        token   	body     string  true "token from the login link"
+// @Param        invite_code   	body     string  false "invite code, to sign up while the waitlist is enabled"
+// @Success      200 {object} LoginEmailConfirmResult
+// @Failure      400 {object} string "invalid params"
+// @Failure      401 {object} string "invalid or expired login link"
+// @Failure      403 {object} string "user not approved for use"
+// @Failure      500 {object} string "internal server error"
+// @Router       /login/email/confirm/ [post]
+func (api *API) LoginEmailConfirm(c *gin.Context) {
+	var params LoginEmailConfirmParams
+	err := c.ShouldBindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing 'token' parameter.", "token")
+		return
+	}
+	issuer, err := tokens.NewIssuer(config.GetSettings().MagicLinkSigningSecret)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to create magic link issuer")
+		Handle500(c)
+		return
+	}
+	now := api.GetCurrentTime()
+	claims, err := issuer.Verify(params.Token, MAGIC_LINK_TOKEN_PURPOSE, now)
+	if err != nil {
+		HandleError(c, ErrorCodeUnauthorized, "invalid or expired login link")
+		return
+	}
+	_, err = database.UseMagicLink(c.Request.Context(), api.DB, claims.Nonce, now)
+	if err == mongo.ErrNoDocuments {
+		HandleError(c, ErrorCodeUnauthorized, "invalid or expired login link")
+		return
+	} else if err != nil {
+		Handle500(c)
+		return
+	}
+
+	email := claims.Subject
+	userIsNew := false
+	user, err := database.GetUserByEmail(c.Request.Context(), api.DB, email)
+	if err == mongo.ErrNoDocuments {
+		err = api.authorizeNewUser(c.Request.Context(), email, params.InviteCode)
+		if err != nil {
+			HandleAPIError(c, err)
+			return
+		}
+		user, userIsNew, err = api.createEmailLoginUser(c, email)
+	} else if err == nil && !user.EmailVerified {
+		// using the link proves the user owns the email, so other providers can be merged into the account
+		_, err = database.GetUserCollection(api.DB).UpdateOne(c.Request.Context(), bson.M{"_id": user.ID}, bson.M{"$set": bson.M{"email_verified": true}})
+	}
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to load magic link user")
+		Handle500(c)
+		return
+	}
+
+	session, ok := api.startSession(c, user.ID, userIsNew, LOGIN_SERVICE_ID_EMAIL)
+	if !ok {
+		return
+	}
+	c.SetCookie("authToken", session.Token, constants.SESSION_DURATION, "/", config.GetSettings().CookieDomain, false, false)
+	c.JSON(200, LoginEmailConfirmResult{Token: session.Token, IsNewUser: userIsNew})
+}
+
+// createEmailLoginUser upserts on the email, so two links used at once can't both create a user. The email is
+// verified by the link, so a later Google login is merged into this user
+func (api *API) createEmailLoginUser(c *gin.Context, email string) (*database.User, bool, error) {
+	result, err := database.GetUserCollection(api.DB).UpdateOne(
+		c.Request.Context(),
+		bson.M{"email": email},
+		bson.M{"$setOnInsert": bson.M{"name": "", "email_verified": true, "created_at": primitive.NewDateTimeFromTime(api.GetCurrentTime().UTC())}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return nil, false, err
+	}
+	user, err := database.GetUserByEmail(c.Request.Context(), api.DB, email)
+	return user, result.UpsertedCount > 0, err
+}
+
+func getMagicLinkEmailContent(token string) templating.EmailContent {
+	return templating.EmailContent{
+		Heading:    "Log in to General Task",
+		Subheading: "This link expires in 15 minutes and can only be used once. If you didn't ask to log in, you can ignore this email.",
+		Sections: []templating.EmailSection{{
+			Title: "Your login link",
+			Items: []templating.EmailItem{{Title: "Log in", Link: config.GetSettings().HomeURL + "login/email/?token=" + url.QueryEscape(token)}},
+		}},
+	}
+}
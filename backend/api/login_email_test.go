@@ -0,0 +1,108 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestLoginEmail(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	sender := MockEmailSender{}
+	api.EmailSender = &sender
+	tokenPattern := regexp.MustCompile(`login/email/\?token=([A-Za-z0-9_\-.]+)`)
+
+	requestLink := func(t *testing.T, email string) string {
+		sender.SentEmails = nil
+		ServeRequest(t, "", http.MethodPost, "/login/email/", bytes.NewBuffer([]byte(`{"email": "`+email+`"}`)), http.StatusOK, api)
+		assert.Equal(t, 1, len(sender.SentEmails))
+		match := tokenPattern.FindStringSubmatch(sender.SentEmails[0].HTMLBody)
+		assert.Equal(t, 2, len(match))
+		return match[1]
+	}
+	confirm := func(t *testing.T, token string, expectedCode int) LoginEmailConfirmResult {
+		response := ServeRequest(t, "", http.MethodPost, "/login/email/confirm/", bytes.NewBuffer([]byte(`{"token": "`+token+`"}`)), expectedCode, api)
+		var result LoginEmailConfirmResult
+		if expectedCode == http.StatusOK {
+			assert.NoError(t, json.Unmarshal(response, &result))
+		}
+		return result
+	}
+
+	t.Run("InvalidEmail", func(t *testing.T) {
+		ServeRequest(t, "", http.MethodPost, "/login/email/", bytes.NewBuffer([]byte(`{"email": "oops"}`)), http.StatusBadRequest, api)
+	})
+	t.Run("InvalidToken", func(t *testing.T) {
+		confirm(t, "oops", http.StatusUnauthorized)
+	})
+	t.Run("NewUser", func(t *testing.T) {
+		token := requestLink(t, "Magic_Link@resonant-kelpie-404a42.netlify.app")
+		assert.Equal(t, "magic_link@resonant-kelpie-404a42.netlify.app", sender.SentEmails[0].ToEmail)
+		result := confirm(t, token, http.StatusOK)
+		assert.True(t, result.IsNewUser)
+		assert.NotEmpty(t, result.Token)
+		user, err := database.GetUserByEmail(context.Background(), api.DB, "magic_link@resonant-kelpie-404a42.netlify.app")
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, getUserIDFromAuthToken(t, api.DB, result.Token))
+
+		// links can only be used once
+		confirm(t, token, http.StatusUnauthorized)
+	})
+	t.Run("ExistingUser", func(t *testing.T) {
+		authToken := login("magic_link_existing@resonant-kelpie-404a42.netlify.app", "")
+		userID := getUserIDFromAuthToken(t, api.DB, authToken)
+		result := confirm(t, requestLink(t, "magic_link_existing@resonant-kelpie-404a42.netlify.app"), http.StatusOK)
+		assert.False(t, result.IsNewUser)
+		assert.Equal(t, userID, getUserIDFromAuthToken(t, api.DB, result.Token))
+	})
+	t.Run("GoogleLoginAfterSignup", func(t *testing.T) {
+		email := "magic_link_google@resonant-kelpie-404a42.netlify.app"
+		result := confirm(t, requestLink(t, email), http.StatusOK)
+		assert.True(t, result.IsNewUser)
+		userID := getUserIDFromAuthToken(t, api.DB, result.Token)
+
+		recorder := makeLoginCallbackRequestWithUserInfo("googleToken", `{"sub": "goog_magic_link", "email": "`+email+`", "email_verified": true}`, "example-token", "example-token", true, false)
+		assert.Equal(t, http.StatusFound, recorder.Code)
+		count, err := database.GetUserCollection(api.DB).CountDocuments(context.Background(), bson.M{"email": email})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+		user, err := database.GetUserByEmail(context.Background(), api.DB, email)
+		assert.NoError(t, err)
+		assert.Equal(t, userID, user.ID)
+		assert.Equal(t, "goog_magic_link", user.GoogleID)
+		assert.True(t, user.EmailVerified)
+	})
+	t.Run("ExistingUserVerified", func(t *testing.T) {
+		authToken := login("magic_link_verify@resonant-kelpie-404a42.netlify.app", "")
+		userID := getUserIDFromAuthToken(t, api.DB, authToken)
+		confirm(t, requestLink(t, "magic_link_verify@resonant-kelpie-404a42.netlify.app"), http.StatusOK)
+		user, err := database.GetUser(context.Background(), api.DB, userID)
+		assert.NoError(t, err)
+		assert.True(t, user.EmailVerified)
+	})
+	t.Run("Expired", func(t *testing.T) {
+		token := requestLink(t, "magic_link_expired@resonant-kelpie-404a42.netlify.app")
+		expiredTime := api.GetCurrentTime().Add(time.Duration(constants.MAGIC_LINK_DURATION+1) * time.Second)
+		api.OverrideTime = &expiredTime
+		defer func() { api.OverrideTime = nil }()
+		confirm(t, token, http.StatusUnauthorized)
+	})
+	t.Run("RateLimited", func(t *testing.T) {
+		for i := int64(0); i < constants.MAGIC_LINK_MAX_PER_INTERVAL; i++ {
+			requestLink(t, "magic_link_limited@resonant-kelpie-404a42.netlify.app")
+		}
+		sender.SentEmails = nil
+		ServeRequest(t, "", http.MethodPost, "/login/email/", bytes.NewBuffer([]byte(`{"email": "magic_link_limited@resonant-kelpie-404a42.netlify.app"}`)), http.StatusOK, api)
+		assert.Equal(t, 0, len(sender.SentEmails))
+	})
+}
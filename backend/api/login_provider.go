@@ -0,0 +1,103 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// LoginWithProvider godoc
+// @Summary      Begins logging in with a provider other than Google
+// @Description  Signing in with a provider uses the existing account with the same verified email
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        provider_id   	path      string  true  "github or microsoft"
+// @Param        use_deeplink   	query     string  false "should use deeplink"
+// @Param        invite_code   	query     string  false "invite code, to sign up while the waitlist is enabled"
+// @Success      302 {object} string "URL redirect"
+// @Failure      404 {object} string "provider not found"
+// @Failure      500 {object} string "internal server error"
+// @Router       /login/providers/{provider_id}/ [get]
+func (api *API) LoginWithProvider(c *gin.Context) {
+	provider, err := api.ExternalConfig.GetLoginProvider(c.Param("provider_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	var params LoginRedirectParams
+	_ = c.ShouldBind(&params)
+	insertedStateToken, err := database.CreateStateToken(c.Request.Context(), api.DB, nil, params.UseDeeplink)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	stateTokenID, err := primitive.ObjectIDFromHex(*insertedStateToken)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	setLoginCookies(c, stateTokenID, params)
+	c.Redirect(302, provider.GetLoginURL(stateTokenID))
+}
+
+// LoginWithProviderCallback godoc
+// @Summary      Finishes logging in with a provider other than Google
+// @Description  Callback for the /login/providers/{provider_id}/ redirect
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        provider_id   	path      string  true  "github or microsoft"
+// @Param        code   	query     string  true "OAuth Code"
+// @Param        state   	query     string  true "OAuth State"
+// @Success      302 {object} string "URL redirect"
+// @Failure      400 {object} string "invalid params"
+// @Failure      403 {object} string "user not approved for use, or email not verified"
+// @Failure      404 {object} string "provider not found"
+// @Failure      500 {object} string "internal server error"
+// @Router       /login/providers/{provider_id}/callback/ [get]
+func (api *API) LoginWithProviderCallback(c *gin.Context) {
+	providerID := c.Param("provider_id")
+	provider, err := api.ExternalConfig.GetLoginProvider(providerID)
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	var redirectParams Oauth2RedirectParams
+	err = c.ShouldBindQuery(&redirectParams)
+	if err != nil {
+		HandleBindError(c, &redirectParams, err, "missing query params")
+		return
+	}
+	useDeeplinkRedirect, ok := api.checkLoginStateToken(c, redirectParams.State)
+	if !ok {
+		return
+	}
+
+	userInfo, err := provider.GetLoginUserInfo(c.Request.Context(), redirectParams.Code)
+	if err != nil {
+		api.Logger.Error().Err(err).Str("providerID", providerID).Msg("failed to fetch login user info")
+		Handle500(c)
+		return
+	}
+	inviteCode, _ := c.Cookie("loginInviteCode")
+	userID, userIsNew, err := external.GetOrCreateLoginUser(c.Request.Context(), api.DB, providerID, *userInfo, func(email string) error {
+		return api.authorizeNewUser(c.Request.Context(), email, inviteCode)
+	})
+	var apiError *APIError
+	if errors.As(err, &apiError) {
+		HandleAPIError(c, apiError)
+		return
+	} else if err == external.ErrLoginEmailNotVerified {
+		HandleError(c, ErrorCodeForbidden, providerID+" has not verified this email. Verify it with "+providerID+" and try again")
+		return
+	} else if err != nil {
+		api.Logger.Error().Err(err).Str("providerID", providerID).Msg("failed to handle login")
+		Handle500(c)
+		return
+	}
+	api.completeLogin(c, userID, userIsNew, providerID, useDeeplinkRedirect)
+}
@@ -51,7 +51,7 @@ func TestLoginRedirect(t *testing.T) {
 
 		stateTokenID, err := primitive.ObjectIDFromHex(stateToken)
 		assert.NoError(t, err)
-		token, err := database.GetStateToken(api.DB, stateTokenID, nil)
+		token, err := database.GetStateToken(context.Background(), api.DB, stateTokenID, nil)
 		assert.NoError(t, err)
 		assert.False(t, token.UseDeeplink)
 	})
@@ -78,7 +78,7 @@ func TestLoginRedirect(t *testing.T) {
 
 		stateTokenID, err := primitive.ObjectIDFromHex(stateToken)
 		assert.NoError(t, err)
-		token, err := database.GetStateToken(api.DB, stateTokenID, nil)
+		token, err := database.GetStateToken(context.Background(), api.DB, stateTokenID, nil)
 		assert.NoError(t, err)
 		assert.False(t, token.UseDeeplink)
 	})
@@ -105,12 +105,56 @@ func TestLoginRedirect(t *testing.T) {
 
 		stateTokenID, err := primitive.ObjectIDFromHex(stateToken)
 		assert.NoError(t, err)
-		token, err := database.GetStateToken(api.DB, stateTokenID, nil)
+		token, err := database.GetStateToken(context.Background(), api.DB, stateTokenID, nil)
 		assert.NoError(t, err)
 		assert.True(t, token.UseDeeplink)
 	})
 }
 
+func TestLoginWithProvider(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	api.ExternalConfig.GithubLoginConfig = &external.OauthConfig{Config: &oauth2.Config{
+		ClientID:    "123",
+		RedirectURL: "g.com",
+		Endpoint:    oauth2.Endpoint{AuthURL: "https://github.com/login/oauth/authorize"},
+	}}
+	router := GetRouter(api)
+
+	t.Run("UnknownProvider", func(t *testing.T) {
+		request, _ := http.NewRequest("GET", "/login/providers/myspace/", nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+	t.Run("Success", func(t *testing.T) {
+		request, _ := http.NewRequest("GET", "/login/providers/github/?invite_code=ABC", nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusFound, recorder.Code)
+
+		cookies := map[string]string{}
+		for _, c := range recorder.Result().Cookies() {
+			cookies[c.Name] = c.Value
+		}
+		assert.Equal(t, "ABC", cookies["loginInviteCode"])
+		assert.Equal(t, "https://github.com/login/oauth/authorize?client_id=123&redirect_uri=g.com&response_type=code&state="+cookies["loginStateToken"], recorder.Header().Get("Location"))
+	})
+	t.Run("CallbackMissingParams", func(t *testing.T) {
+		request, _ := http.NewRequest("GET", "/login/providers/github/callback/?state=oops", nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+	t.Run("CallbackStateTokenMismatch", func(t *testing.T) {
+		request, _ := http.NewRequest("GET", "/login/providers/github/callback/?state="+primitive.NewObjectID().Hex()+"&code=code1234", nil)
+		request.AddCookie(&http.Cookie{Name: "loginStateToken", Value: primitive.NewObjectID().Hex()})
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
+
 func TestLoginCallback(t *testing.T) {
 	api, dbCleanup := GetAPIWithDBCleanup()
 	defer dbCleanup()
@@ -153,28 +197,28 @@ func TestLoginCallback(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"invalid state token format\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"invalid state token format\",\"code\":\"bad_request\"}", string(body))
 	})
 	t.Run("BadStateTokenCookieFormat", func(t *testing.T) {
 		recorder := makeLoginCallbackRequest("noice420", "approved@resonant-kelpie-404a42.netlify.app", "", "6088e1c97018a22f240aa573", "example-token", false, false)
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"invalid state token cookie format\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"invalid state token cookie format\",\"code\":\"bad_request\"}", string(body))
 	})
 	t.Run("StateTokensDontMatch", func(t *testing.T) {
 		recorder := makeLoginCallbackRequest("noice420", "approved@resonant-kelpie-404a42.netlify.app", "", "6088e1c97018a22f240aa573", "6088e1c97018a22f240aa574", false, false)
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"state token does not match cookie\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"state token does not match cookie\",\"code\":\"bad_request\"}", string(body))
 	})
 	t.Run("InvalidStateToken", func(t *testing.T) {
 		recorder := makeLoginCallbackRequest("noice420", "approved@resonant-kelpie-404a42.netlify.app", "", "6088e1c97018a22f240aa573", "6088e1c97018a22f240aa573", false, false)
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"invalid state token\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"invalid state token\",\"code\":\"bad_request\"}", string(body))
 	})
 	t.Run("SuccessSecondTime", func(t *testing.T) {
 		// Verifies request succeeds on second auth (no refresh token supplied)
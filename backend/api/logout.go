@@ -22,7 +22,7 @@ import (
 func (api *API) Logout(c *gin.Context) {
 	token, err := getToken(c)
 	if err != nil {
-		c.AbortWithStatusJSON(401, gin.H{"detail": "incorrect auth token format"})
+		AbortWithAPIError(c, NewAPIError(ErrorCodeUnauthorized, "incorrect auth token format"))
 		return
 	}
 
@@ -34,7 +34,7 @@ func (api *API) Logout(c *gin.Context) {
 		return
 	}
 	if result.DeletedCount == 0 {
-		c.AbortWithStatusJSON(401, gin.H{"detail": "unauthorized"})
+		AbortWithAPIError(c, NewAPIError(ErrorCodeUnauthorized, "unauthorized"))
 	} else {
 		c.JSON(200, gin.H{})
 	}
@@ -0,0 +1,215 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/focustime"
+	"github.com/franchizzle/task-manager/backend/settings"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const MEETING_ANALYTICS_DEFAULT_DAYS = 7
+const MEETING_ANALYTICS_MAX_DAYS = 90
+
+type MeetingAnalyticsParams struct {
+	Days *int `form:"days"`
+}
+
+type MeetingAnalyticsDay struct {
+	Date           string `json:"date"`
+	MeetingMinutes int    `json:"meeting_minutes"`
+	IsWorkday      bool   `json:"is_workday"`
+	// the longest stretch of the working day without meetings, only set on workdays
+	LongestFreeBlockMinutes int `json:"longest_free_block_minutes"`
+}
+
+type MeetingAnalyticsWeek struct {
+	DateStart      string `json:"date_start"`
+	DateEnd        string `json:"date_end"`
+	MeetingMinutes int    `json:"meeting_minutes"`
+}
+
+type MeetingAnalyticsResult struct {
+	DateStart      string                 `json:"date_start"`
+	DateEnd        string                 `json:"date_end"`
+	Days           []MeetingAnalyticsDay  `json:"days"`
+	Weeks          []MeetingAnalyticsWeek `json:"weeks"`
+	MeetingMinutes int                    `json:"meeting_minutes"`
+	// the average longest free block on workdays in minutes. The lower it is, the more the
+	// user's time is broken up by meetings
+	FragmentationScore int `json:"fragmentation_score"`
+	// the same period right before this one, to compare against
+	PreviousMeetingMinutes     int  `json:"previous_meeting_minutes"`
+	PreviousFragmentationScore int  `json:"previous_fragmentation_score"`
+	MeetingMinutesChangePct    *int `json:"meeting_minutes_change_pct"`
+}
+
+// MeetingAnalytics godoc
+// @Summary      Returns how much of the user's time goes to meetings
+// @Description  Meeting time per day and week, how fragmented working hours are, and the change from the previous period. The period ends at the end of today
+// @Tags         analytics
+// @Produce      json
+// @Param        days  query  int  false "length of the period in days, 7 by default"
+// @Success      200 {object} MeetingAnalyticsResult
+// @Failure      400 {object} string "invalid params"
+// @Failure      500 {object} string "internal server error"
+// @Router       /analytics/meetings/ [get]
+func (api *API) MeetingAnalytics(c *gin.Context) {
+	var params MeetingAnalyticsParams
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	days := MEETING_ANALYTICS_DEFAULT_DAYS
+	if params.Days != nil {
+		days = *params.Days
+	}
+	if days < 1 || days > MEETING_ANALYTICS_MAX_DAYS {
+		HandleBadRequest(c, fmt.Sprintf("'days' must be between 1 and %d", MEETING_ANALYTICS_MAX_DAYS), "days")
+		return
+	}
+	timezoneOffset, err := api.getTimezoneOffset(c)
+	if err != nil {
+		HandleBadRequest(c, err.Error())
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	timeNow := api.GetCurrentLocalizedTime(timezoneOffset)
+	periodEnd := time.Date(timeNow.Year(), timeNow.Month(), timeNow.Day(), 0, 0, 0, 0, timeNow.Location()).AddDate(0, 0, 1)
+	periodStart := periodEnd.AddDate(0, 0, -days)
+	previousPeriodStart := periodStart.AddDate(0, 0, -days)
+
+	// out of office, focus time and working location events, as well as events linked to tasks,
+	// views, or pull requests, aren't meetings
+	events, err := database.GetCalendarEvents(c.Request.Context(), api.DB, userID, &[]bson.M{
+		{"datetime_end": bson.M{"$gt": previousPeriodStart}},
+		{"datetime_start": bson.M{"$lt": periodEnd}},
+		{"category": bson.M{"$nin": []string{constants.EventCategoryOutOfOffice, constants.EventCategoryFocusTime, constants.EventCategoryWorkingLocation}}},
+		{"linked_task_id": bson.M{"$exists": false}},
+		{"linked_view_id": bson.M{"$exists": false}},
+		{"linked_pull_request_id": bson.M{"$exists": false}},
+	})
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	meetings := getMeetingEvents(*events)
+	workingHours := api.getWorkingHours(userID)
+
+	result := getMeetingAnalytics(meetings, periodStart, days, workingHours)
+	previous := getMeetingAnalytics(meetings, previousPeriodStart, days, workingHours)
+	result.PreviousMeetingMinutes = previous.MeetingMinutes
+	result.PreviousFragmentationScore = previous.FragmentationScore
+	if previous.MeetingMinutes > 0 {
+		changePct := (result.MeetingMinutes - previous.MeetingMinutes) * 100 / previous.MeetingMinutes
+		result.MeetingMinutesChangePct = &changePct
+	}
+	c.JSON(200, result)
+}
+
+// getMeetingEvents leaves out all day events, which block off the day rather than being meetings
+func getMeetingEvents(events []database.CalendarEvent) []database.CalendarEvent {
+	meetings := []database.CalendarEvent{}
+	for _, event := range events {
+		if event.DatetimeEnd.Time().Sub(event.DatetimeStart.Time()) >= 24*time.Hour {
+			continue
+		}
+		meetings = append(meetings, event)
+	}
+	return meetings
+}
+
+// getMeetingAnalytics sums up the meetings in the days from start, which should be midnight in the
+// user's timezone. Overlapping meetings, or the same meeting on more than one calendar, are only
+// counted once
+func getMeetingAnalytics(meetings []database.CalendarEvent, start time.Time, days int, workingHours settings.WorkingHours) MeetingAnalyticsResult {
+	end := start.AddDate(0, 0, days)
+	busy := mergeMeetingWindows(meetings, start.Location())
+	result := MeetingAnalyticsResult{
+		DateStart: start.Format("2006-01-02"),
+		DateEnd:   end.AddDate(0, 0, -1).Format("2006-01-02"),
+		Days:      []MeetingAnalyticsDay{},
+		Weeks:     []MeetingAnalyticsWeek{},
+	}
+	longestFreeBlockTotal := 0
+	workdays := 0
+	for index := 0; index < days; index++ {
+		dayStart := start.AddDate(0, 0, index)
+		dayEnd := dayStart.AddDate(0, 0, 1)
+		day := MeetingAnalyticsDay{
+			Date:           dayStart.Format("2006-01-02"),
+			MeetingMinutes: getOverlapMinutes(busy, dayStart, dayEnd),
+			IsWorkday:      workingHours.IsWorkday(dayStart.Weekday()),
+		}
+		if day.IsWorkday {
+			for _, window := range focustime.GetFreeWindows(meetings, dayStart, dayEnd, workingHours) {
+				freeMinutes := int(window.End.Sub(window.Start).Minutes())
+				if freeMinutes > day.LongestFreeBlockMinutes {
+					day.LongestFreeBlockMinutes = freeMinutes
+				}
+			}
+			longestFreeBlockTotal += day.LongestFreeBlockMinutes
+			workdays++
+		}
+		result.Days = append(result.Days, day)
+		result.MeetingMinutes += day.MeetingMinutes
+
+		if index%NUM_DAYS_IN_WEEK == 0 {
+			result.Weeks = append(result.Weeks, MeetingAnalyticsWeek{DateStart: day.Date})
+		}
+		week := &result.Weeks[len(result.Weeks)-1]
+		week.DateEnd = day.Date
+		week.MeetingMinutes += day.MeetingMinutes
+	}
+	if workdays > 0 {
+		result.FragmentationScore = longestFreeBlockTotal / workdays
+	}
+	return result
+}
+
+// mergeMeetingWindows returns the times the meetings take up, sorted and without overlaps
+func mergeMeetingWindows(meetings []database.CalendarEvent, location *time.Location) []focustime.Window {
+	windows := []focustime.Window{}
+	for _, meeting := range meetings {
+		windows = append(windows, focustime.Window{Start: meeting.DatetimeStart.Time().In(location), End: meeting.DatetimeEnd.Time().In(location)})
+	}
+	sort.Slice(windows, func(i, j int) bool {
+		return windows[i].Start.Before(windows[j].Start)
+	})
+	merged := []focustime.Window{}
+	for _, window := range windows {
+		if len(merged) > 0 && !window.Start.After(merged[len(merged)-1].End) {
+			if window.End.After(merged[len(merged)-1].End) {
+				merged[len(merged)-1].End = window.End
+			}
+			continue
+		}
+		merged = append(merged, window)
+	}
+	return merged
+}
+
+func getOverlapMinutes(windows []focustime.Window, start time.Time, end time.Time) int {
+	var overlap time.Duration
+	for _, window := range windows {
+		overlapStart := window.Start
+		if overlapStart.Before(start) {
+			overlapStart = start
+		}
+		overlapEnd := window.End
+		if overlapEnd.After(end) {
+			overlapEnd = end
+		}
+		if overlapEnd.After(overlapStart) {
+			overlap += overlapEnd.Sub(overlapStart)
+		}
+	}
+	return int(overlap.Minutes())
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/settings"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func getMeetingEvent(start time.Time, duration time.Duration) database.CalendarEvent {
+	return database.CalendarEvent{
+		DatetimeStart: primitive.NewDateTimeFromTime(start),
+		DatetimeEnd:   primitive.NewDateTimeFromTime(start.Add(duration)),
+	}
+}
+
+func TestGetMeetingAnalytics(t *testing.T) {
+	// a Monday
+	monday := time.Date(2023, time.February, 27, 0, 0, 0, 0, time.UTC)
+
+	t.Run("NoMeetings", func(t *testing.T) {
+		result := getMeetingAnalytics([]database.CalendarEvent{}, monday, 7, settings.DefaultWorkingHours)
+		assert.Equal(t, 0, result.MeetingMinutes)
+		assert.Equal(t, 480, result.FragmentationScore)
+		assert.Equal(t, 7, len(result.Days))
+		assert.Equal(t, []MeetingAnalyticsWeek{{DateStart: "2023-02-27", DateEnd: "2023-03-05"}}, result.Weeks)
+	})
+	t.Run("Success", func(t *testing.T) {
+		meetings := []database.CalendarEvent{
+			getMeetingEvent(monday.Add(10*time.Hour), time.Hour),
+			// the same meeting on a second calendar
+			getMeetingEvent(monday.Add(10*time.Hour), time.Hour),
+			getMeetingEvent(monday.Add(10*time.Hour+30*time.Minute), time.Hour),
+			// booked all working day Wednesday
+			getMeetingEvent(monday.AddDate(0, 0, 2).Add(9*time.Hour), 8*time.Hour),
+			getMeetingEvent(monday.AddDate(0, 0, 5).Add(12*time.Hour), time.Hour),
+			// the following week
+			getMeetingEvent(monday.AddDate(0, 0, 7).Add(12*time.Hour), 30*time.Minute),
+		}
+		result := getMeetingAnalytics(meetings, monday, 8, settings.DefaultWorkingHours)
+		assert.Equal(t, "2023-02-27", result.DateStart)
+		assert.Equal(t, "2023-03-06", result.DateEnd)
+		assert.Equal(t, 660, result.MeetingMinutes)
+		assert.Equal(t, MeetingAnalyticsDay{Date: "2023-02-27", MeetingMinutes: 90, IsWorkday: true, LongestFreeBlockMinutes: 330}, result.Days[0])
+		assert.Equal(t, MeetingAnalyticsDay{Date: "2023-03-01", MeetingMinutes: 480, IsWorkday: true, LongestFreeBlockMinutes: 0}, result.Days[2])
+		assert.Equal(t, MeetingAnalyticsDay{Date: "2023-03-04", MeetingMinutes: 60, IsWorkday: false, LongestFreeBlockMinutes: 0}, result.Days[5])
+		assert.Equal(t, MeetingAnalyticsDay{Date: "2023-03-06", MeetingMinutes: 30, IsWorkday: true, LongestFreeBlockMinutes: 270}, result.Days[7])
+		// (330 + 480 + 0 + 480 + 480 + 270) / 6 workdays
+		assert.Equal(t, 340, result.FragmentationScore)
+		assert.Equal(t, []MeetingAnalyticsWeek{
+			{DateStart: "2023-02-27", DateEnd: "2023-03-05", MeetingMinutes: 630},
+			{DateStart: "2023-03-06", DateEnd: "2023-03-06", MeetingMinutes: 30},
+		}, result.Weeks)
+	})
+	t.Run("MeetingAcrossMidnight", func(t *testing.T) {
+		meetings := []database.CalendarEvent{getMeetingEvent(monday.Add(23*time.Hour), 2*time.Hour)}
+		result := getMeetingAnalytics(meetings, monday, 2, settings.DefaultWorkingHours)
+		assert.Equal(t, 60, result.Days[0].MeetingMinutes)
+		assert.Equal(t, 60, result.Days[1].MeetingMinutes)
+	})
+}
+
+func TestGetMeetingEvents(t *testing.T) {
+	start := time.Date(2023, time.February, 27, 0, 0, 0, 0, time.UTC)
+	meeting := getMeetingEvent(start.Add(10*time.Hour), time.Hour)
+	allDayEvent := getMeetingEvent(start, 24*time.Hour)
+	assert.Equal(t, []database.CalendarEvent{meeting}, getMeetingEvents([]database.CalendarEvent{allDayEvent, meeting}))
+}
+
+func TestMeetingAnalytics(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	router := GetRouter(api)
+	// a Wednesday, so the last 7 days start on Thursday
+	currentTime := time.Date(2023, time.March, 8, 12, 0, 0, 0, time.UTC)
+	api.OverrideTime = &currentTime
+	authToken := login("meeting_analytics@resonant-kelpie-404a42.netlify.app", "")
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	insertEvent := func(event database.CalendarEvent) {
+		event.UserID = userID
+		_, err := database.GetCalendarEventCollection(api.DB).InsertOne(context.Background(), event)
+		assert.NoError(t, err)
+	}
+	tuesday := time.Date(2023, time.March, 7, 10, 0, 0, 0, time.UTC)
+	insertEvent(getMeetingEvent(tuesday, time.Hour))
+	focusBlock := getMeetingEvent(tuesday.Add(2*time.Hour), time.Hour)
+	focusBlock.LinkedTaskID = primitive.NewObjectID()
+	insertEvent(focusBlock)
+	outOfOffice := getMeetingEvent(tuesday.Add(4*time.Hour), 2*time.Hour)
+	outOfOffice.Category = constants.EventCategoryOutOfOffice
+	insertEvent(outOfOffice)
+	insertEvent(getMeetingEvent(tuesday.AddDate(0, 0, -7), 2*time.Hour))
+
+	getAnalytics := func(url string, expectedStatus int) []byte {
+		request, _ := http.NewRequest(http.MethodGet, url, nil)
+		request.Header.Set("Authorization", "Bearer "+authToken)
+		request.Header.Set("Timezone-Offset", "0")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, expectedStatus, recorder.Code)
+		body, err := io.ReadAll(recorder.Body)
+		assert.NoError(t, err)
+		return body
+	}
+
+	t.Run("InvalidDays", func(t *testing.T) {
+		getAnalytics("/analytics/meetings/?days=0", http.StatusBadRequest)
+		getAnalytics("/analytics/meetings/?days=91", http.StatusBadRequest)
+	})
+	t.Run("Success", func(t *testing.T) {
+		var result MeetingAnalyticsResult
+		assert.NoError(t, json.Unmarshal(getAnalytics("/analytics/meetings/", http.StatusOK), &result))
+		assert.Equal(t, "2023-03-02", result.DateStart)
+		assert.Equal(t, "2023-03-08", result.DateEnd)
+		assert.Equal(t, 60, result.MeetingMinutes)
+		assert.Equal(t, 120, result.PreviousMeetingMinutes)
+		assert.Equal(t, -50, *result.MeetingMinutesChangePct)
+		assert.Equal(t, 7, len(result.Days))
+		assert.Equal(t, 1, len(result.Weeks))
+	})
+	UnauthorizedTest(t, http.MethodGet, "/analytics/meetings/", nil)
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+)
+
+type MeetingPrepRulesResult struct {
+	IsEnabled        bool                           `json:"is_enabled"`
+	LeadTimeMinutes  int                            `json:"lead_time_minutes"`
+	MinimumAttendees int                            `json:"minimum_attendees"`
+	Calendars        []database.MeetingPrepCalendar `json:"calendars"`
+}
+
+type MeetingPrepRulesModifyParams struct {
+	IsEnabled        *bool                           `json:"is_enabled"`
+	LeadTimeMinutes  *int                            `json:"lead_time_minutes"`
+	MinimumAttendees *int                            `json:"minimum_attendees"`
+	Calendars        *[]database.MeetingPrepCalendar `json:"calendars"`
+}
+
+func (api *API) MeetingPrepRulesGet(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	rules, err := database.GetMeetingPrepRules(c.Request.Context(), api.DB, userID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to get meeting prep rules")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, getMeetingPrepRulesResult(rules))
+}
+
+func (api *API) MeetingPrepRulesModify(c *gin.Context) {
+	var params MeetingPrepRulesModifyParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	if params.LeadTimeMinutes != nil && (*params.LeadTimeMinutes < 1 || *params.LeadTimeMinutes > constants.MEETING_PREP_MAX_LEAD_TIME_MINUTES) {
+		HandleBadRequest(c, fmt.Sprintf("'lead_time_minutes' must be between 1 and %d", constants.MEETING_PREP_MAX_LEAD_TIME_MINUTES), "lead_time_minutes")
+		return
+	}
+	if params.MinimumAttendees != nil && (*params.MinimumAttendees < 0 || *params.MinimumAttendees > constants.MEETING_PREP_MAX_MINIMUM_ATTENDEES) {
+		HandleBadRequest(c, fmt.Sprintf("'minimum_attendees' must be between 0 and %d", constants.MEETING_PREP_MAX_MINIMUM_ATTENDEES), "minimum_attendees")
+		return
+	}
+	userID := getUserIDFromContext(c)
+
+	rules, err := database.GetMeetingPrepRules(c.Request.Context(), api.DB, userID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to get meeting prep rules")
+		Handle500(c)
+		return
+	}
+	if params.Calendars != nil {
+		calendarAccounts, err := database.GetCalendarAccounts(c.Request.Context(), api.DB, userID)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to get calendar accounts")
+			Handle500(c)
+			return
+		}
+		for _, calendar := range *params.Calendars {
+			if !isUserCalendar(calendarAccounts, calendar) {
+				HandleBadRequest(c, fmt.Sprintf("invalid calendar '%s' for account '%s'", calendar.CalendarID, calendar.AccountID))
+				return
+			}
+		}
+		rules.Calendars = *params.Calendars
+	}
+	if params.IsEnabled != nil {
+		rules.IsEnabled = *params.IsEnabled
+	}
+	if params.LeadTimeMinutes != nil {
+		rules.LeadTimeMinutes = *params.LeadTimeMinutes
+	}
+	if params.MinimumAttendees != nil {
+		rules.MinimumAttendees = *params.MinimumAttendees
+	}
+
+	err = database.UpsertMeetingPrepRules(c.Request.Context(), api.DB, rules)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update meeting prep rules")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, getMeetingPrepRulesResult(rules))
+}
+
+func getMeetingPrepRulesResult(rules *database.MeetingPrepRules) MeetingPrepRulesResult {
+	calendars := rules.Calendars
+	if calendars == nil {
+		calendars = []database.MeetingPrepCalendar{}
+	}
+	return MeetingPrepRulesResult{
+		IsEnabled:        rules.IsEnabled,
+		LeadTimeMinutes:  rules.LeadTimeMinutes,
+		MinimumAttendees: rules.MinimumAttendees,
+		Calendars:        calendars,
+	}
+}
+
+func isUserCalendar(calendarAccounts *[]database.CalendarAccount, calendar database.MeetingPrepCalendar) bool {
+	for _, calendarAccount := range *calendarAccounts {
+		if calendarAccount.IDExternal != calendar.AccountID {
+			continue
+		}
+		if calendar.CalendarID == "primary" {
+			return true
+		}
+		for _, accountCalendar := range calendarAccount.Calendars {
+			if accountCalendar.CalendarID == calendar.CalendarID {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeetingPrepRules(t *testing.T) {
+	authToken := login("test_meeting_prep_rules@resonant-kelpie-404a42.netlify.app", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+
+	_, err := database.UpdateOrCreateCalendarAccount(context.Background(), api.DB, userID, "acctid", "foobar_source",
+		&database.CalendarAccount{
+			UserID:     userID,
+			IDExternal: "acctid",
+			Calendars:  []database.Calendar{{AccessRole: constants.AccessControlOwner, CalendarID: "calid"}},
+		}, nil)
+	assert.NoError(t, err)
+
+	UnauthorizedTest(t, "GET", "/meeting_preparation/rules/", nil)
+	UnauthorizedTest(t, "PATCH", "/meeting_preparation/rules/", nil)
+	t.Run("GetDefault", func(t *testing.T) {
+		response := ServeRequest(t, authToken, "GET", "/meeting_preparation/rules/", nil, http.StatusOK, api)
+		assert.Equal(t, `{"is_enabled":true,"lead_time_minutes":1440,"minimum_attendees":0,"calendars":[]}`, string(response))
+	})
+	t.Run("InvalidLeadTime", func(t *testing.T) {
+		response := ServeRequest(t, authToken, "PATCH", "/meeting_preparation/rules/", bytes.NewBuffer([]byte(`{"lead_time_minutes": 0}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"'lead_time_minutes' must be between 1 and 1440","code":"invalid_parameter","field_errors":[{"field":"lead_time_minutes"}]}`, string(response))
+	})
+	t.Run("InvalidMinimumAttendees", func(t *testing.T) {
+		response := ServeRequest(t, authToken, "PATCH", "/meeting_preparation/rules/", bytes.NewBuffer([]byte(`{"minimum_attendees": -1}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"'minimum_attendees' must be between 0 and 100","code":"invalid_parameter","field_errors":[{"field":"minimum_attendees"}]}`, string(response))
+	})
+	t.Run("InvalidCalendar", func(t *testing.T) {
+		response := ServeRequest(t, authToken, "PATCH", "/meeting_preparation/rules/", bytes.NewBuffer([]byte(`{"calendars": [{"account_id": "acctid", "calendar_id": "other_calid"}]}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"invalid calendar 'other_calid' for account 'acctid'","code":"bad_request"}`, string(response))
+	})
+	t.Run("Success", func(t *testing.T) {
+		response := ServeRequest(t, authToken, "PATCH", "/meeting_preparation/rules/", bytes.NewBuffer([]byte(`{"lead_time_minutes": 15, "minimum_attendees": 2, "calendars": [{"account_id": "acctid", "calendar_id": "calid"}]}`)), http.StatusOK, api)
+		expected := `{"is_enabled":true,"lead_time_minutes":15,"minimum_attendees":2,"calendars":[{"account_id":"acctid","calendar_id":"calid"}]}`
+		assert.Equal(t, expected, string(response))
+
+		// unset fields are left unchanged
+		response = ServeRequest(t, authToken, "PATCH", "/meeting_preparation/rules/", bytes.NewBuffer([]byte(`{"is_enabled": false}`)), http.StatusOK, api)
+		assert.Equal(t, `{"is_enabled":false,"lead_time_minutes":15,"minimum_attendees":2,"calendars":[{"account_id":"acctid","calendar_id":"calid"}]}`, string(response))
+		response = ServeRequest(t, authToken, "GET", "/meeting_preparation/rules/", nil, http.StatusOK, api)
+		assert.Equal(t, `{"is_enabled":false,"lead_time_minutes":15,"minimum_attendees":2,"calendars":[{"account_id":"acctid","calendar_id":"calid"}]}`, string(response))
+	})
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/franchizzle/task-manager/backend/constants"
 	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/meetingprep"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -15,19 +16,19 @@ import (
 
 func (api *API) MeetingPreparationTasksList(c *gin.Context) {
 	userID := getUserIDFromContext(c)
-	_, err := database.GetUser(api.DB, userID)
+	_, err := database.GetUser(c.Request.Context(), api.DB, userID)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to get user")
 		Handle500(c)
 		return
 	}
-	timezoneOffset, err := GetTimezoneOffsetFromHeader(c)
+	timezoneOffset, err := api.getTimezoneOffset(c)
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		HandleBadRequest(c, err.Error())
 		return
 	}
 
-	meetingTasksResult, err := api.GetMeetingPreparationTasksResult(userID, timezoneOffset)
+	meetingTasksResult, err := api.GetMeetingPreparationTasksResult(c.Request.Context(), userID, timezoneOffset)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to get meeting preparation tasks")
 		Handle500(c)
@@ -36,9 +37,9 @@ func (api *API) MeetingPreparationTasksList(c *gin.Context) {
 	c.JSON(200, meetingTasksResult)
 }
 
-func (api *API) GetMeetingPreparationTasksResult(userID primitive.ObjectID, timezoneOffset time.Duration) ([]*TaskResultV4, error) {
+func (api *API) GetMeetingPreparationTasksResult(ctx context.Context, userID primitive.ObjectID, timezoneOffset time.Duration) ([]*TaskResultV4, error) {
 	timeNow := api.GetCurrentLocalizedTime(timezoneOffset)
-	eventsUntilEndOfDay, err := database.GetEventsUntilEndOfDay(api.DB, userID, timeNow)
+	eventsUntilEndOfDay, err := database.GetEventsUntilEndOfDay(ctx, api.DB, userID, timeNow, api.getWorkingHours(userID).EndOfDay(timeNow))
 	if err != nil && err != mongo.ErrNoDocuments {
 		return nil, err
 	}
@@ -52,7 +53,7 @@ func (api *API) GetMeetingPreparationTasksResult(userID primitive.ObjectID, time
 	}
 	var tasks *[]database.Task
 	if isMeetingPreparationAdded {
-		tasks, err = api.GetAndUpdateMeetingPreparationTasksFromEvents(userID, eventsUntilEndOfDay)
+		tasks, err = api.GetAndUpdateMeetingPreparationTasksFromEvents(ctx, userID, eventsUntilEndOfDay, timeNow)
 		if err != nil {
 			return nil, err
 		}
@@ -67,13 +68,13 @@ func (api *API) GetMeetingPreparationTasksResult(userID primitive.ObjectID, time
 	}
 
 	// limits response to 100 items by DB
-	completedMeetingTasks, err := database.GetEarlierCompletedMeetingPrepTasks(api.DB, userID, timeNow)
+	completedMeetingTasks, err := database.GetEarlierCompletedMeetingPrepTasks(ctx, api.DB, userID, timeNow)
 	if err != nil {
 		return nil, err
 	}
 
 	// limits response to 100 items by DB
-	deletedMeetingTasks, err := database.GetEarlierDeletedMeetingPrepTasks(api.DB, userID, timeNow)
+	deletedMeetingTasks, err := database.GetEarlierDeletedMeetingPrepTasks(ctx, api.DB, userID, timeNow)
 	if err != nil {
 		return nil, err
 	}
@@ -119,24 +120,16 @@ func (api *API) IsMeetingPreparationAdded(userID primitive.ObjectID) (bool, erro
 	return false, nil
 }
 
-func (api *API) GetAndUpdateMeetingPreparationTasksFromEvents(userID primitive.ObjectID, events *[]database.CalendarEvent) (*[]database.Task, error) {
-	calendarAccount, err := database.GetCalendarAccounts(api.DB, userID)
+func (api *API) GetAndUpdateMeetingPreparationTasksFromEvents(ctx context.Context, userID primitive.ObjectID, events *[]database.CalendarEvent, timeNow time.Time) (*[]database.Task, error) {
+	matchingEvents, err := meetingprep.GetMatchingEvents(ctx, api.DB, userID, *events, timeNow)
 	if err != nil {
 		return nil, err
 	}
-	calendarToAccessRole := createCalendarToAccessRoleMap(calendarAccount)
 
 	var tasks []database.Task
 	taskCollection := database.GetTaskCollection(api.DB)
-	for _, event := range *events {
-		if accessRole, ok := calendarToAccessRole[calendarKey{event.SourceAccountID, event.CalendarID}]; ok {
-			if accessRole != constants.AccessControlOwner {
-				continue // only create meeting prep tasks for "owned" calendars
-			}
-		} else {
-			continue
-		}
-		task, err := getOrCreateMeetingPrepTask(userID, event, taskCollection)
+	for _, event := range matchingEvents {
+		task, _, err := meetingprep.GetOrCreatePrepTask(ctx, api.DB, userID, event)
 		if err != nil {
 			return nil, err
 		}
@@ -152,58 +145,6 @@ func (api *API) GetAndUpdateMeetingPreparationTasksFromEvents(userID primitive.O
 	return &tasks, nil
 }
 
-func getOrCreateMeetingPrepTask(userID primitive.ObjectID, event database.CalendarEvent, taskCollection *mongo.Collection) (database.Task, error) {
-	// Check if meeting preparation task exists
-	var task database.Task
-	err := taskCollection.FindOne(
-		context.Background(),
-		bson.M{"$and": []bson.M{
-			{"user_id": userID},
-			{"is_meeting_preparation_task": true},
-			{"meeting_preparation_params.id_external": event.IDExternal},
-			{"source_id": event.SourceID},
-		},
-		}).Decode(&task)
-
-	if err != nil && err != mongo.ErrNoDocuments {
-		// if DB error not related to no documents, return err
-		return database.Task{}, err
-	}
-	if err != nil && err == mongo.ErrNoDocuments {
-		// if no documents, create one
-		isCompleted := false
-		isDeleted := false
-		taskToInsert := database.Task{
-			Title:                    &event.Title,
-			UserID:                   userID,
-			IsCompleted:              &isCompleted,
-			IsDeleted:                &isDeleted,
-			SourceID:                 event.SourceID,
-			CreatedAtExternal:        primitive.NewDateTimeFromTime(time.Now()),
-			UpdatedAt:                primitive.NewDateTimeFromTime(time.Now()),
-			IsMeetingPreparationTask: true,
-			MeetingPreparationParams: &database.MeetingPreparationParams{
-				CalendarEventID:               event.ID,
-				IDExternal:                    event.IDExternal,
-				DatetimeStart:                 event.DatetimeStart,
-				DatetimeEnd:                   event.DatetimeEnd,
-				HasBeenAutomaticallyCompleted: false,
-				EventMovedOrDeleted:           false,
-			},
-		}
-
-		insertResult, err := taskCollection.InsertOne(context.Background(), taskToInsert)
-		if err != nil {
-			return database.Task{}, err
-		}
-		taskToInsert.ID = insertResult.InsertedID.(primitive.ObjectID)
-		return taskToInsert, nil
-	} else {
-		// if task exists, add task to list
-		return task, nil
-	}
-}
-
 func updateActiveTaskTimingOrCompletionIfNeeded(userID primitive.ObjectID, event database.CalendarEvent, task database.Task, taskCollection *mongo.Collection) (database.Task, error) {
 	updateFields := bson.M{}
 	// Update meeting prep start time if it's different from event start time
@@ -216,6 +157,14 @@ func updateActiveTaskTimingOrCompletionIfNeeded(userID primitive.ObjectID, event
 		task.MeetingPreparationParams.DatetimeEnd = event.DatetimeEnd
 		updateFields["meeting_preparation_params.datetime_end"] = event.DatetimeEnd
 	}
+	// keep the task in the colors the event is shown in
+	colorBackground, colorForeground := database.GetCalendarEventColors(event)
+	if colorBackground != task.MeetingPreparationParams.ColorBackground || colorForeground != task.MeetingPreparationParams.ColorForeground {
+		task.MeetingPreparationParams.ColorBackground = colorBackground
+		task.MeetingPreparationParams.ColorForeground = colorForeground
+		updateFields["meeting_preparation_params.color_background"] = colorBackground
+		updateFields["meeting_preparation_params.color_foreground"] = colorForeground
+	}
 	// if a pre-existing task, and was not manually marked complete, let's unmark completion
 	if task.MeetingPreparationParams.HasBeenAutomaticallyCompleted {
 		completed := false
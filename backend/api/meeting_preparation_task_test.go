@@ -26,9 +26,10 @@ func TestMeetingPreparationTask(t *testing.T) {
 	defer dbCleanup()
 	testTime := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
 	api.OverrideTime = &testTime
+	setAllDayWorkingHours(t, api, userID)
 	router := GetRouter(api)
 
-	_, err = database.UpdateOrCreateCalendarAccount(db, userID, "123abc", "foobar_source",
+	_, err = database.UpdateOrCreateCalendarAccount(context.Background(), db, userID, "123abc", "foobar_source",
 		&database.CalendarAccount{
 			UserID:     userID,
 			IDExternal: "acctid",
@@ -67,7 +68,7 @@ func TestMeetingPreparationTask(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, `{"error":"Timezone-Offset header is required"}`, string(body))
+		assert.Equal(t, `{"detail":"Timezone-Offset header is required","code":"bad_request"}`, string(body))
 	})
 	t.Run("NoEvents", func(t *testing.T) {
 		request, _ := http.NewRequest("GET", "/meeting_preparation_tasks/", nil)
@@ -85,7 +86,7 @@ func TestMeetingPreparationTask(t *testing.T) {
 		authtoken2 := login("test_meeting_prep_endpoint_not_added@resonant-kelpie-404a42.netlify.app", "")
 		userID2 := getUserIDFromAuthToken(t, db, authtoken2)
 
-		_, err = database.UpdateOrCreateCalendarAccount(db, userID2, "123abc", "foobar_source",
+		_, err = database.UpdateOrCreateCalendarAccount(context.Background(), db, userID2, "123abc", "foobar_source",
 			&database.CalendarAccount{
 				UserID:     userID2,
 				IDExternal: "acctid",
@@ -114,7 +115,7 @@ func TestMeetingPreparationTask(t *testing.T) {
 			CompletedAt: primitive.NewDateTimeFromTime(time.Unix(3, 0)),
 		}
 
-		_, err = database.UpdateOrCreateTask(db, userID2, "123123", "generaltask", taskToInsert, taskToInsert, nil)
+		_, err = database.UpdateOrCreateTask(context.Background(), db, userID2, "123123", "generaltask", taskToInsert, taskToInsert, nil)
 		assert.NoError(t, err)
 
 		_, err = createTestEvent(calendarEventCollection, userID2, "Event1", primitive.NewObjectID().Hex(), timeOneHourLater, timeOneDayLater, primitive.NilObjectID, "acctid", "calid")
@@ -161,7 +162,8 @@ func TestGetMeetingPreparationTasksResult(t *testing.T) {
 	assert.NoError(t, err)
 	defer dbCleanup()
 	userID := getUserIDFromAuthToken(t, db, authtoken)
-	_, err = database.UpdateOrCreateCalendarAccount(db, userID, "123abc", "foobar_source",
+	setAllDayWorkingHours(t, api, userID)
+	_, err = database.UpdateOrCreateCalendarAccount(context.Background(), db, userID, "123abc", "foobar_source",
 		&database.CalendarAccount{
 			UserID:     userID,
 			IDExternal: "acctid",
@@ -200,35 +202,35 @@ func TestGetMeetingPreparationTasksResult(t *testing.T) {
 	externalEventID := primitive.NewObjectID().Hex()
 
 	t.Run("NoEvents", func(t *testing.T) {
-		result, err := api.GetMeetingPreparationTasksResult(userID, 0)
+		result, err := api.GetMeetingPreparationTasksResult(context.Background(), userID, 0)
 		assert.NoError(t, err)
 		assert.Equal(t, []*TaskResultV4{}, result)
 	})
 	t.Run("EventDifferentUser", func(t *testing.T) {
 		_, err = createTestEvent(calendarEventCollection, primitive.NewObjectID(), "Event1", primitive.NewObjectID().Hex(), timeOneHourLater, timeOneDayLater, primitive.NilObjectID, "acctid", "calid")
 		assert.NoError(t, err)
-		result, err := api.GetMeetingPreparationTasksResult(userID, 0)
+		result, err := api.GetMeetingPreparationTasksResult(context.Background(), userID, 0)
 		assert.NoError(t, err)
 		assert.Equal(t, []*TaskResultV4{}, result)
 	})
 	t.Run("EventEarlierToday", func(t *testing.T) {
 		_, err = createTestEvent(calendarEventCollection, userID, "Event1", primitive.NewObjectID().Hex(), timeOneHourEarlier, timeOneDayLater, primitive.NilObjectID, "acctid", "calid")
 		assert.NoError(t, err)
-		result, err := api.GetMeetingPreparationTasksResult(userID, 0)
+		result, err := api.GetMeetingPreparationTasksResult(context.Background(), userID, 0)
 		assert.NoError(t, err)
 		assert.Equal(t, []*TaskResultV4{}, result)
 	})
 	t.Run("EventTomorrow", func(t *testing.T) {
 		_, err = createTestEvent(calendarEventCollection, userID, "Event1", primitive.NewObjectID().Hex(), timeOneDayLater, timeOneDayLater.Add(1*time.Hour), primitive.NilObjectID, "acctid", "calid")
 		assert.NoError(t, err)
-		result, err := api.GetMeetingPreparationTasksResult(userID, 0)
+		result, err := api.GetMeetingPreparationTasksResult(context.Background(), userID, 0)
 		assert.NoError(t, err)
 		assert.Equal(t, []*TaskResultV4{}, result)
 	})
 	t.Run("EventLaterToday", func(t *testing.T) {
 		_, err = createTestEvent(calendarEventCollection, userID, "Event1", externalEventID, timeOneHourLater, timeOneDayLater, primitive.NilObjectID, "acctid", "calid")
 		assert.NoError(t, err)
-		result, err := api.GetMeetingPreparationTasksResult(userID, 0)
+		result, err := api.GetMeetingPreparationTasksResult(context.Background(), userID, 0)
 		assert.NoError(t, err)
 		assert.Len(t, result, 1)
 		assert.Equal(t, "Event1", result[0].Title)
@@ -237,7 +239,7 @@ func TestGetMeetingPreparationTasksResult(t *testing.T) {
 	t.Run("EventMovedToLaterToday", func(t *testing.T) {
 		_, err = eventCollection.UpdateOne(context.Background(), bson.M{"id_external": externalEventID}, bson.M{"$set": bson.M{"datetime_start": primitive.NewDateTimeFromTime(timeOneHourLater.Add(1 * time.Hour))}})
 		assert.NoError(t, err)
-		result, err := api.GetMeetingPreparationTasksResult(userID, 0)
+		result, err := api.GetMeetingPreparationTasksResult(context.Background(), userID, 0)
 		assert.NoError(t, err)
 		assert.Len(t, result, 1)
 		assert.Equal(t, "Event1", result[0].Title)
@@ -251,7 +253,7 @@ func TestGetMeetingPreparationTasksResult(t *testing.T) {
 		_, err = createTestMeetingPreparationTask(taskCollection, userID, "Event2", idExternal, false, timeTwoHoursLater, timeOneDayLater, insertResult.InsertedID.(primitive.ObjectID))
 		assert.NoError(t, err)
 
-		result, err := api.GetMeetingPreparationTasksResult(userID, 0)
+		result, err := api.GetMeetingPreparationTasksResult(context.Background(), userID, 0)
 		assert.NoError(t, err)
 		assert.Len(t, result, 2)
 		assert.Equal(t, "Event2", result[0].Title)
@@ -261,7 +263,7 @@ func TestGetMeetingPreparationTasksResult(t *testing.T) {
 		_, err = createTestMeetingPreparationTask(taskCollection, userID, "Event3", "missing", false, timeTwoHoursLater, timeOneDayLater, primitive.NilObjectID)
 		assert.NoError(t, err)
 
-		result, err := api.GetMeetingPreparationTasksResult(userID, 0)
+		result, err := api.GetMeetingPreparationTasksResult(context.Background(), userID, 0)
 		assert.NoError(t, err)
 
 		// Event3 should not appear in this list
@@ -272,7 +274,7 @@ func TestGetMeetingPreparationTasksResult(t *testing.T) {
 	t.Run("EventIsNotOnOwnedCalendar", func(t *testing.T) {
 		_, err = createTestEvent(calendarEventCollection, userID, "Event4", primitive.NewObjectID().Hex(), timeOneHourLater, timeOneDayLater, primitive.NilObjectID, "acctid", "other_calid")
 		assert.NoError(t, err)
-		result, err := api.GetMeetingPreparationTasksResult(userID, 0)
+		result, err := api.GetMeetingPreparationTasksResult(context.Background(), userID, 0)
 		assert.NoError(t, err)
 
 		// Event4 should not appear in this list
@@ -289,7 +291,7 @@ func TestGetMeetingPreparationTasksResult(t *testing.T) {
 		insertResult, err := createTestMeetingPreparationTask(taskCollection, userID, "reticulate splines", idExternal, false, timeZero, timeZero, primitive.NilObjectID)
 		assert.NoError(t, err)
 
-		res, err := api.GetMeetingPreparationTasksResult(userID, 0)
+		res, err := api.GetMeetingPreparationTasksResult(context.Background(), userID, 0)
 		assert.NoError(t, err)
 
 		var item database.Task
@@ -317,7 +319,7 @@ func TestGetMeetingPreparationTasksResult(t *testing.T) {
 		_, err = calendarEventCollection.UpdateOne(context.Background(), bson.M{"_id": insertResult.InsertedID.(primitive.ObjectID)}, bson.M{"$set": bson.M{"datetime_start": primitive.NewDateTimeFromTime(timeOneDayLater)}})
 		assert.NoError(t, err)
 
-		res, err := api.GetMeetingPreparationTasksResult(userID, 0)
+		res, err := api.GetMeetingPreparationTasksResult(context.Background(), userID, 0)
 		assert.NoError(t, err)
 
 		var item database.Task
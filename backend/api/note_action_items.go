@@ -0,0 +1,276 @@
+package api
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/franchizzle/task-manager/backend/llm"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ActionItemSuggestionResult struct {
+	ID     primitive.ObjectID `json:"id"`
+	NoteID primitive.ObjectID `json:"note_id"`
+	Title  string             `json:"title"`
+}
+
+// strips list markers such as "- ", "* ", "1. " and "1) " from the start of a line
+var actionItemPrefix = regexp.MustCompile(`^\s*([-*•]|\d+[.)])\s*`)
+
+// NoteExtractActionItems suggests tasks from the note's content. Suggestions are saved as pending
+// until the user accepts or dismisses them
+func (api *API) NoteExtractActionItems(c *gin.Context) {
+	noteID, err := primitive.ObjectIDFromHex(c.Param("note_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	userID := getUserIDFromContext(c)
+	user, err := database.GetUser(c.Request.Context(), api.DB, userID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to find user")
+		Handle500(c)
+		return
+	}
+	timezoneOffset, err := api.getTimezoneOffset(c)
+	if err != nil {
+		HandleBadRequest(c, err.Error())
+		return
+	}
+
+	note, err := database.GetNote(c.Request.Context(), api.DB, noteID, userID)
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	if note.Body == nil || strings.TrimSpace(*note.Body) == "" {
+		HandleBadRequest(c, "note has no content")
+		return
+	}
+	title := ""
+	if note.Title != nil {
+		title = *note.Title
+	}
+	prompt := getActionItemsPrompt(title, *note.Body)
+	if utf8.RuneCountInString(prompt) > constants.MAX_GPT_PROMPT_LENGTH {
+		HandleBadRequest(c, "note is too long to extract action items")
+		return
+	}
+
+	hasSuggestionsLeft, err := api.hasGPTSuggestionsLeft(user, timezoneOffset)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("error fetching suggestions remaining")
+		Handle500(c)
+		return
+	}
+	if !hasSuggestionsLeft {
+		HandleBadRequest(c, "no remaining suggestions for user")
+		return
+	}
+	err = api.decrementGPTRemainingByOne(user, timezoneOffset)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("unable to decrement suggestions remaining")
+		Handle500(c)
+		return
+	}
+
+	completion, err := api.getLLMCompletion(c.Request.Context(), userID, llm.CompletionRequest{
+		MaxTokens:   1000,
+		Temperature: 0.2,
+		Prompt:      prompt,
+	})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch action items")
+		Handle500(c)
+		return
+	}
+
+	actionItems := parseActionItems(completion)
+	results := []ActionItemSuggestionResult{}
+	if len(actionItems) == 0 {
+		c.JSON(200, results)
+		return
+	}
+	suggestions := []interface{}{}
+	for _, actionItem := range actionItems {
+		suggestions = append(suggestions, database.ActionItemSuggestion{
+			UserID:    userID,
+			NoteID:    noteID,
+			Title:     actionItem,
+			Status:    constants.ActionItemStatusPending,
+			CreatedAt: primitive.NewDateTimeFromTime(time.Now()),
+		})
+	}
+	insertResult, err := database.GetActionItemSuggestionCollection(api.DB).InsertMany(c.Request.Context(), suggestions)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to save action items")
+		Handle500(c)
+		return
+	}
+	for idx, insertedID := range insertResult.InsertedIDs {
+		results = append(results, ActionItemSuggestionResult{
+			ID:     insertedID.(primitive.ObjectID),
+			NoteID: noteID,
+			Title:  actionItems[idx],
+		})
+	}
+	c.JSON(200, results)
+}
+
+func (api *API) NoteActionItemsList(c *gin.Context) {
+	noteID, err := primitive.ObjectIDFromHex(c.Param("note_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	userID := getUserIDFromContext(c)
+	suggestions, err := database.GetPendingActionItemSuggestions(c.Request.Context(), api.DB, userID, noteID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	results := []ActionItemSuggestionResult{}
+	for _, suggestion := range *suggestions {
+		results = append(results, ActionItemSuggestionResult{
+			ID:     suggestion.ID,
+			NoteID: suggestion.NoteID,
+			Title:  suggestion.Title,
+		})
+	}
+	c.JSON(200, results)
+}
+
+// ActionItemAccept creates a task from a pending suggestion at the top of the user's default section
+func (api *API) ActionItemAccept(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	suggestion, ok := api.getPendingActionItemSuggestion(c, userID)
+	if !ok {
+		return
+	}
+	taskSourceResult, err := api.ExternalConfig.GetSourceResult(external.TASK_SOURCE_ID_GT_TASK)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to load task source")
+		Handle500(c)
+		return
+	}
+	// resolved before the task is created so that concurrent requests can't create it twice
+	err = database.UpdatePendingActionItemSuggestion(c.Request.Context(), api.DB, suggestion.ID, userID, bson.M{"status": constants.ActionItemStatusAccepted})
+	if err == mongo.ErrNoDocuments {
+		HandleBadRequest(c, "action item has already been resolved")
+		return
+	} else if err != nil {
+		Handle500(c)
+		return
+	}
+
+	taskSectionID := database.GetDefaultTaskSectionID(c.Request.Context(), api.DB, userID, external.TASK_SOURCE_ID_GT_TASK)
+	taskID, err := taskSourceResult.Source.CreateNewTask(c.Request.Context(), api.DB, userID, external.GeneralTaskDefaultAccountID, external.TaskCreationObject{
+		Title:         suggestion.Title,
+		IDTaskSection: taskSectionID,
+	})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to create task from action item")
+		// put the suggestion back so that it can be accepted again
+		_, err = database.GetActionItemSuggestionCollection(api.DB).UpdateOne(
+			context.Background(),
+			bson.M{"$and": []bson.M{{"_id": suggestion.ID}, {"user_id": userID}}},
+			bson.M{"$set": bson.M{"status": constants.ActionItemStatusPending}},
+		)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to reset action item")
+		}
+		Handle500(c)
+		return
+	}
+	IDOrdering := constants.DefaultTaskIDOrdering
+	err = api.ReOrderTask(c, taskID, userID, &IDOrdering, nil, &database.Task{IDTaskSection: taskSectionID})
+	if err != nil {
+		HandleError(c, ErrorCodeInternal, "failed to move task to front of folder")
+		return
+	}
+	_, err = database.GetActionItemSuggestionCollection(api.DB).UpdateOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{{"_id": suggestion.ID}, {"user_id": userID}}},
+		bson.M{"$set": bson.M{"task_id": taskID}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to link action item to task")
+	}
+	api.recordTaskActivity(c.Request.Context(), []database.TaskActivity{{
+		UserID:   userID,
+		TaskID:   taskID,
+		Type:     constants.TaskActivityCreated,
+		NewValue: suggestion.Title,
+	}})
+	c.JSON(200, gin.H{"task_id": taskID})
+}
+
+func (api *API) ActionItemDismiss(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	suggestion, ok := api.getPendingActionItemSuggestion(c, userID)
+	if !ok {
+		return
+	}
+	err := database.UpdatePendingActionItemSuggestion(c.Request.Context(), api.DB, suggestion.ID, userID, bson.M{"status": constants.ActionItemStatusDismissed})
+	if err == mongo.ErrNoDocuments {
+		HandleBadRequest(c, "action item has already been resolved")
+		return
+	} else if err != nil {
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+func (api *API) getPendingActionItemSuggestion(c *gin.Context, userID primitive.ObjectID) (*database.ActionItemSuggestion, bool) {
+	suggestionID, err := primitive.ObjectIDFromHex(c.Param("action_item_id"))
+	if err != nil {
+		Handle404(c)
+		return nil, false
+	}
+	suggestion, err := database.GetActionItemSuggestion(c.Request.Context(), api.DB, suggestionID, userID)
+	if err != nil {
+		Handle404(c)
+		return nil, false
+	}
+	if suggestion.Status != constants.ActionItemStatusPending {
+		HandleBadRequest(c, "action item has already been resolved")
+		return nil, false
+	}
+	return suggestion, true
+}
+
+func getActionItemsPrompt(title string, body string) string {
+	return `The following are notes from a meeting titled "` + sanitizeGPTString(title) + `":
+	"""
+	` + body + `
+	"""
+	List the action items from these notes that someone needs to follow up on, one per line, starting each line with "- ". Phrase each action item as a short task title starting with a verb. Only include action items found in the notes. If there are none, respond with "None".
+	`
+}
+
+func parseActionItems(text string) []string {
+	actionItems := []string{}
+	seen := map[string]bool{}
+	for _, line := range strings.Split(text, "\n") {
+		actionItem := strings.TrimSpace(actionItemPrefix.ReplaceAllString(line, ""))
+		if actionItem == "" || strings.EqualFold(strings.TrimSuffix(actionItem, "."), "none") || seen[actionItem] {
+			continue
+		}
+		seen[actionItem] = true
+		actionItems = append(actionItems, actionItem)
+		if len(actionItems) == constants.MAX_ACTION_ITEMS_PER_NOTE {
+			break
+		}
+	}
+	return actionItems
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/llm"
+	"github.com/franchizzle/task-manager/backend/testutils"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNoteExtractActionItems(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	router := GetRouter(api)
+	currentTime := time.Now().UTC()
+	api.OverrideTime = &currentTime
+
+	email := "test_action_items@yahoo.com"
+	authToken := login(email, "")
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	setSuggestionsLeft := func(suggestionsLeft int) {
+		_, err := database.GetUserCollection(api.DB).UpdateOne(context.Background(), bson.M{"_id": userID}, bson.M{"$set": bson.M{"gpt_suggestions_left": suggestionsLeft, "gpt_last_suggestion_time": primitive.NewDateTimeFromTime(currentTime)}})
+		assert.NoError(t, err)
+	}
+	insertNote := func(body string) primitive.ObjectID {
+		title := "Roadmap review"
+		insertResult, err := database.GetNoteCollection(api.DB).InsertOne(context.Background(), database.Note{UserID: userID, Title: &title, Body: &body})
+		assert.NoError(t, err)
+		return insertResult.InsertedID.(primitive.ObjectID)
+	}
+	extractActionItems := func(noteID primitive.ObjectID, expectedStatus int) []byte {
+		request, _ := http.NewRequest("POST", "/notes/"+noteID.Hex()+"/extract_action_items/", nil)
+		request.Header.Set("Authorization", "Bearer "+authToken)
+		request.Header.Set("Timezone-Offset", "0")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, expectedStatus, recorder.Code)
+		body, err := io.ReadAll(recorder.Body)
+		assert.NoError(t, err)
+		return body
+	}
+	noteID := insertNote("Alice will send the launch plan. Bob to follow up with design.")
+
+	UnauthorizedTest(t, "POST", "/notes/"+noteID.Hex()+"/extract_action_items/", nil)
+	t.Run("NoteNotFound", func(t *testing.T) {
+		extractActionItems(primitive.NewObjectID(), http.StatusNotFound)
+	})
+	t.Run("EmptyNote", func(t *testing.T) {
+		response := extractActionItems(insertNote("  "), http.StatusBadRequest)
+		assert.Equal(t, `{"detail":"note has no content","code":"bad_request"}`, string(response))
+	})
+	t.Run("NoSuggestionsLeft", func(t *testing.T) {
+		setSuggestionsLeft(0)
+		response := extractActionItems(noteID, http.StatusBadRequest)
+		assert.Equal(t, `{"detail":"no remaining suggestions for user","code":"bad_request"}`, string(response))
+	})
+	t.Run("Success", func(t *testing.T) {
+		setSuggestionsLeft(constants.MAX_OVERVIEW_SUGGESTION)
+		server := testutils.GetMockAPIServer(t, http.StatusOK, `{"id": "1", "choices": [{"text": "\n- Send the launch plan\n- Follow up with design\n- Send the launch plan"}]}`)
+		defer server.Close()
+		api.LLMClient = llm.NewOpenAIClient(llm.Config{BaseURL: server.URL})
+
+		response := extractActionItems(noteID, http.StatusOK)
+		var results []ActionItemSuggestionResult
+		err := json.Unmarshal(response, &results)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(results))
+		assert.Equal(t, "Send the launch plan", results[0].Title)
+		assert.Equal(t, "Follow up with design", results[1].Title)
+
+		user, err := database.GetUser(context.Background(), api.DB, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, constants.MAX_OVERVIEW_SUGGESTION-1, user.GPTSuggestionsLeft)
+
+		response = ServeRequest(t, authToken, "GET", "/notes/"+noteID.Hex()+"/action_items/", nil, http.StatusOK, api)
+		var pendingResults []ActionItemSuggestionResult
+		err = json.Unmarshal(response, &pendingResults)
+		assert.NoError(t, err)
+		assert.Equal(t, results, pendingResults)
+
+		t.Run("Accept", func(t *testing.T) {
+			response := ServeRequest(t, authToken, "POST", "/action_items/"+results[0].ID.Hex()+"/accept/", nil, http.StatusOK, api)
+			var acceptResult map[string]primitive.ObjectID
+			err := json.Unmarshal(response, &acceptResult)
+			assert.NoError(t, err)
+			task, err := database.GetTask(context.Background(), api.DB, acceptResult["task_id"], userID)
+			assert.NoError(t, err)
+			assert.Equal(t, "Send the launch plan", *task.Title)
+			assert.Equal(t, constants.IDTaskSectionDefault, task.IDTaskSection)
+
+			response = ServeRequest(t, authToken, "POST", "/action_items/"+results[0].ID.Hex()+"/accept/", nil, http.StatusBadRequest, api)
+			assert.Equal(t, `{"detail":"action item has already been resolved","code":"bad_request"}`, string(response))
+		})
+		t.Run("Dismiss", func(t *testing.T) {
+			ServeRequest(t, authToken, "POST", "/action_items/"+results[1].ID.Hex()+"/dismiss/", nil, http.StatusOK, api)
+			response := ServeRequest(t, authToken, "GET", "/notes/"+noteID.Hex()+"/action_items/", nil, http.StatusOK, api)
+			assert.Equal(t, `[]`, string(response))
+		})
+		t.Run("WrongUser", func(t *testing.T) {
+			otherToken := login("test_action_items_other@yahoo.com", "")
+			ServeRequest(t, otherToken, "POST", "/action_items/"+results[1].ID.Hex()+"/accept/", nil, http.StatusNotFound, api)
+		})
+	})
+}
+
+func TestParseActionItems(t *testing.T) {
+	assert.Equal(t, []string{"Send the launch plan", "Book a room", "Email Sam"}, parseActionItems("- Send the launch plan\n* Book a room\n\n1. Email Sam"))
+	assert.Equal(t, []string{}, parseActionItems("None."))
+}
@@ -0,0 +1,217 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/collab"
+	"github.com/franchizzle/task-manager/backend/config"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	NOTE_EDITOR_MAX_MESSAGE_BYTES = 64 * 1024
+	NOTE_EDITOR_SEND_BUFFER       = 64
+	NOTE_EDITOR_WRITE_TIMEOUT     = 10 * time.Second
+)
+
+var noteEditorUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     checkNoteEditorOrigin,
+}
+
+// checkNoteEditorOrigin accepts the same origins as CORSMiddleware, since websockets aren't covered by CORS
+func checkNoteEditorOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	settings := config.GetSettings()
+	return slices.Contains(settings.AccessControlAllowOrigins, origin) || origin == settings.DefaultAccessControlAllowOrigin
+}
+
+// noteEditor is a single websocket connection editing a note. Messages are queued and written by
+// a separate goroutine so the hub never blocks on a slow connection
+type noteEditor struct {
+	send      chan collab.ServerMessage
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newNoteEditor() *noteEditor {
+	return &noteEditor{
+		send: make(chan collab.ServerMessage, NOTE_EDITOR_SEND_BUFFER),
+		done: make(chan struct{}),
+	}
+}
+
+func (editor *noteEditor) Send(message collab.ServerMessage) {
+	select {
+	case editor.send <- message:
+	case <-editor.done:
+	default:
+		// the editor has fallen too far behind to stay in sync, so drop the connection and let it reload
+		editor.close()
+	}
+}
+
+func (editor *noteEditor) close() {
+	editor.closeOnce.Do(func() { close(editor.done) })
+}
+
+func (editor *noteEditor) writeMessages(conn *websocket.Conn) {
+	defer conn.Close()
+	for {
+		select {
+		case message := <-editor.send:
+			conn.SetWriteDeadline(time.Now().Add(NOTE_EDITOR_WRITE_TIMEOUT))
+			if err := conn.WriteJSON(message); err != nil {
+				editor.close()
+				return
+			}
+		case <-editor.done:
+			conn.SetWriteDeadline(time.Now().Add(NOTE_EDITOR_WRITE_TIMEOUT))
+			conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			return
+		}
+	}
+}
+
+// NoteCollaborate godoc
+// @Summary      Opens a websocket for editing a note together with other users
+// @Description  Sends the current note body, then accepts and broadcasts text operations. The auth token may be passed as a token query param
+// @Tags         notes
+// @Param        note_id  path  string  true  "Note ID"
+// @Param        token    query string  false "Auth token"
+// @Success      101
+// @Failure      401 {object} string "unauthorized"
+// @Failure      404 {object} string "note not found"
+// @Router       /ws/notes/{note_id}/ [get]
+func (api *API) NoteCollaborate(c *gin.Context) {
+	noteID, err := primitive.ObjectIDFromHex(c.Param("note_id"))
+	if err != nil {
+		// This means the note ID is improperly formatted
+		Handle404(c)
+		return
+	}
+	userID, ok := api.getNoteEditorUserID(c)
+	if !ok {
+		HandleError(c, ErrorCodeUnauthorized, "unauthorized")
+		return
+	}
+	note, err := api.getEditableNote(c.Request.Context(), noteID, userID)
+	if err == errNoteNotEditable {
+		HandleError(c, ErrorCodeForbidden, "note is shared read-only")
+		return
+	}
+	if err != nil {
+		Handle404(c)
+		return
+	}
+
+	conn, err := noteEditorUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// the upgrader has already responded with an error
+		api.Logger.Error().Err(err).Msg("failed to upgrade note editor connection")
+		return
+	}
+	conn.SetReadLimit(NOTE_EDITOR_MAX_MESSAGE_BYTES)
+	editor := newNoteEditor()
+	go editor.writeMessages(conn)
+	defer editor.close()
+
+	documentID := noteID.Hex()
+	err = api.NoteEditors.Join(documentID, editor, func() (string, error) {
+		if note.Body == nil {
+			return "", nil
+		}
+		return *note.Body, nil
+	})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to open note for editing")
+		return
+	}
+	defer api.NoteEditors.Leave(documentID, editor)
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var message collab.ClientMessage
+		if err := json.Unmarshal(data, &message); err != nil {
+			editor.Send(collab.ServerMessage{Type: collab.MessageTypeError, Detail: "invalid message"})
+			continue
+		}
+		api.NoteEditors.Submit(documentID, editor, message)
+	}
+}
+
+// getNoteEditorUserID falls back to the token query param because browsers can't set headers on websocket requests
+func (api *API) getNoteEditorUserID(c *gin.Context) (primitive.ObjectID, bool) {
+	// impersonation sessions are read-only, and the editor saves every change
+	if _, isImpersonating := c.Get("impersonator"); isImpersonating {
+		return primitive.NilObjectID, false
+	}
+	if userID, exists := c.Get("user"); exists {
+		return userID.(primitive.ObjectID), true
+	}
+	token := c.Query("token")
+	if token == "" {
+		return primitive.NilObjectID, false
+	}
+	internalToken, err := database.GetInternalToken(c.Request.Context(), api.DB, token)
+	if err != nil || internalToken.ImpersonatorID != primitive.NilObjectID {
+		return primitive.NilObjectID, false
+	}
+	// set the user so the request is logged against them
+	c.Set("user", internalToken.UserID)
+	return internalToken.UserID, true
+}
+
+var errNoteNotEditable = errors.New("note is shared read-only")
+
+// getEditableNote returns the note if the user owns it, or it's shared with them as a meeting attendee
+// or member of the owner's domain. Anyone can read a public note, so only its owner can edit it
+func (api *API) getEditableNote(ctx context.Context, noteID primitive.ObjectID, userID primitive.ObjectID) (*database.Note, error) {
+	note, err := database.GetNote(ctx, api.DB, noteID, userID)
+	if err != nil {
+		note, err = database.GetSharedNoteWithAuth(ctx, api.DB, noteID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if note.SharedAccess == nil || *note.SharedAccess == database.SharedAccessPublic {
+			return nil, errNoteNotEditable
+		}
+	}
+	if note.IsDeleted != nil && *note.IsDeleted {
+		return nil, errors.New("note is deleted")
+	}
+	return note, nil
+}
+
+func (api *API) saveCollaborativeNote(documentID string, text string) error {
+	noteID, err := primitive.ObjectIDFromHex(documentID)
+	if err != nil {
+		return err
+	}
+	_, err = database.GetNoteCollection(api.DB).UpdateOne(
+		context.Background(),
+		bson.M{"_id": noteID},
+		bson.M{"$set": bson.M{"body": text, "updated_at": primitive.NewDateTimeFromTime(api.GetCurrentTime())}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to save collaborative note")
+	}
+	return err
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/collab"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNoteCollaborate(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+
+	authToken := login("test_note_collaborate@resonant-kelpie-404a42.netlify.app", "")
+	otherAuthToken := login("test_note_collaborate_other@resonant-kelpie-404a42.netlify.app", "")
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+
+	body := "meeting notes"
+	publicAccess := database.SharedAccessPublic
+	domainAccess := database.SharedAccessDomain
+	createNote := func(sharedAccess *database.SharedAccess) primitive.ObjectID {
+		result, err := database.GetNoteCollection(api.DB).InsertOne(context.Background(), database.Note{
+			UserID:       userID,
+			Body:         &body,
+			SharedAccess: sharedAccess,
+			SharedUntil:  primitive.NewDateTimeFromTime(api.GetCurrentTime().AddDate(0, 0, 1)),
+		})
+		assert.NoError(t, err)
+		return result.InsertedID.(primitive.ObjectID)
+	}
+	sharedNoteID := createNote(&domainAccess)
+	publicNoteID := createNote(&publicAccess)
+
+	server := httptest.NewServer(GetRouter(api))
+	defer server.Close()
+	connect := func(noteID primitive.ObjectID, token string) (*websocket.Conn, *http.Response, error) {
+		url := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/notes/" + noteID.Hex() + "/?token=" + token
+		return websocket.DefaultDialer.Dial(url, nil)
+	}
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		_, response, err := connect(sharedNoteID, "")
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+	})
+	t.Run("NoteNotFound", func(t *testing.T) {
+		_, response, err := connect(primitive.NewObjectID(), authToken)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
+	t.Run("PublicNoteIsReadOnly", func(t *testing.T) {
+		_, response, err := connect(publicNoteID, otherAuthToken)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, response.StatusCode)
+	})
+	t.Run("OwnerCanEditPublicNote", func(t *testing.T) {
+		owner, _, err := connect(publicNoteID, authToken)
+		assert.NoError(t, err)
+		defer owner.Close()
+		var message collab.ServerMessage
+		assert.NoError(t, owner.ReadJSON(&message))
+		assert.Equal(t, collab.MessageTypeInit, message.Type)
+	})
+	t.Run("Success", func(t *testing.T) {
+		owner, _, err := connect(sharedNoteID, authToken)
+		assert.NoError(t, err)
+		defer owner.Close()
+		attendee, _, err := connect(sharedNoteID, otherAuthToken)
+		assert.NoError(t, err)
+		defer attendee.Close()
+
+		var message collab.ServerMessage
+		assert.NoError(t, owner.ReadJSON(&message))
+		assert.Equal(t, collab.ServerMessage{Type: collab.MessageTypeInit, Text: body}, message)
+		assert.NoError(t, attendee.ReadJSON(&message))
+		assert.Equal(t, collab.ServerMessage{Type: collab.MessageTypeInit, Text: body}, message)
+
+		insert := collab.Operation{Type: collab.OperationInsert, Position: 0, Text: "# "}
+		assert.NoError(t, owner.WriteJSON(collab.ClientMessage{Revision: 0, Operations: []collab.Operation{insert}}))
+		assert.NoError(t, owner.ReadJSON(&message))
+		assert.Equal(t, collab.ServerMessage{Type: collab.MessageTypeAck, Revision: 1}, message)
+		assert.NoError(t, attendee.ReadJSON(&message))
+		assert.Equal(t, collab.ServerMessage{Type: collab.MessageTypeOperations, Revision: 1, Operations: []collab.Operation{insert}}, message)
+
+		// the change is saved after it's acknowledged
+		assert.Eventually(t, func() bool {
+			note, err := database.GetNote(context.Background(), api.DB, sharedNoteID, userID)
+			return err == nil && *note.Body == "# meeting notes"
+		}, time.Second, 10*time.Millisecond)
+	})
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type NoteCommentParams struct {
+	Body string `json:"body" binding:"required"`
+}
+
+func (api *API) NoteAddComment(c *gin.Context) {
+	noteID, err := primitive.ObjectIDFromHex(c.Param("note_id"))
+	if err != nil {
+		// This means the note ID is improperly formatted
+		Handle404(c)
+		return
+	}
+	var commentParams NoteCommentParams
+	err = c.BindJSON(&commentParams)
+	if err != nil {
+		HandleBindError(c, &commentParams, err, "parameter missing or malformatted")
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	note, err := database.GetNote(c.Request.Context(), api.DB, noteID, userID)
+	if err != nil {
+		HandleAPIError(c, NewAPIError(ErrorCodeNotFound, "note not found.").WithMetadata("noteId", noteID))
+		return
+	}
+	user, err := database.GetUser(c.Request.Context(), api.DB, userID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+
+	comment := database.Comment{
+		ExternalID: uuid.New().String(),
+		Body:       commentParams.Body,
+		User: database.ExternalUser{
+			ExternalID:  userID.Hex(),
+			Name:        user.Name,
+			DisplayName: user.Name,
+			Email:       user.Email,
+		},
+		CreatedAt: primitive.NewDateTimeFromTime(time.Now()),
+	}
+	_, err = database.GetNoteCollection(api.DB).UpdateOne(
+		c.Request.Context(),
+		bson.M{"$and": []bson.M{
+			{"_id": note.ID},
+			{"user_id": userID},
+		}},
+		bson.M{"$push": bson.M{"comments": comment}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to add note comment")
+		Handle500(c)
+		return
+	}
+	// the comment is already saved, so failing to notify mentioned users shouldn't fail the request
+	err = api.notifyMentionedUsers(c.Request.Context(), userID, comment.Body, database.Notification{
+		Type:   constants.NotificationTypeNoteCommentMention,
+		NoteID: note.ID,
+	})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to notify mentioned users")
+	}
+	c.JSON(200, gin.H{})
+}
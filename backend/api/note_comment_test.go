@@ -0,0 +1,58 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNoteAddComment(t *testing.T) {
+	authToken := login("test_note_comment@resonant-kelpie-404a42.netlify.app", "")
+	coworkerToken := login("test_note_comment_coworker@resonant-kelpie-404a42.netlify.app", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	coworkerID := getUserIDFromAuthToken(t, api.DB, coworkerToken)
+
+	title := "standup notes"
+	insertResult, err := database.GetNoteCollection(api.DB).InsertOne(context.Background(), database.Note{
+		UserID: userID,
+		Title:  &title,
+	})
+	assert.NoError(t, err)
+	noteID := insertResult.InsertedID.(primitive.ObjectID)
+	url := "/notes/" + noteID.Hex() + "/comments/add/"
+
+	UnauthorizedTest(t, "POST", url, nil)
+	t.Run("MissingBody", func(t *testing.T) {
+		ServeRequest(t, authToken, "POST", url, bytes.NewBuffer([]byte(`{}`)), http.StatusBadRequest, api)
+	})
+	t.Run("NotOwner", func(t *testing.T) {
+		ServeRequest(t, coworkerToken, "POST", url, bytes.NewBuffer([]byte(`{"body": "hello"}`)), http.StatusNotFound, api)
+	})
+	t.Run("Success", func(t *testing.T) {
+		ServeRequest(t, authToken, "POST", url, bytes.NewBuffer([]byte(`{"body": "@test_note_comment_coworker can you add the agenda?"}`)), http.StatusOK, api)
+
+		note, err := database.GetNote(context.Background(), api.DB, noteID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(*note.Comments))
+		assert.Equal(t, "@test_note_comment_coworker can you add the agenda?", (*note.Comments)[0].Body)
+		assert.Equal(t, "test_note_comment@resonant-kelpie-404a42.netlify.app", (*note.Comments)[0].User.Email)
+
+		var notifications []database.Notification
+		cursor, err := database.GetNotificationCollection(api.DB).Find(context.Background(), bson.M{"note_id": noteID})
+		assert.NoError(t, err)
+		assert.NoError(t, cursor.All(context.Background(), &notifications))
+		assert.Equal(t, 1, len(notifications))
+		assert.Equal(t, coworkerID, notifications[0].UserID)
+		assert.Equal(t, userID, notifications[0].SenderUserID)
+		assert.Equal(t, constants.NotificationTypeNoteCommentMention, notifications[0].Type)
+	})
+}
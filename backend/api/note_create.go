@@ -17,23 +17,32 @@ type NoteCreateParams struct {
 	SharedUntil   primitive.DateTime     `json:"shared_until"`
 	SharedAccess  *database.SharedAccess `json:"shared_access,omitempty"`
 	LinkedEventID primitive.ObjectID     `json:"linked_event_id,omitempty"`
+	FolderID      primitive.ObjectID     `json:"folder_id,omitempty"`
 }
 
 func (api *API) NoteCreate(c *gin.Context) {
 	var noteCreateParams NoteCreateParams
 	err := c.BindJSON(&noteCreateParams)
 	if err != nil {
-		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		HandleBindError(c, &noteCreateParams, err, "invalid or missing parameter")
 		return
 	}
 	userID := getUserIDFromContext(c)
 
 	if noteCreateParams.LinkedEventID != primitive.NilObjectID {
 		// check that the event exists
-		_, err = database.GetCalendarEvent(api.DB, noteCreateParams.LinkedEventID, userID)
+		_, err = database.GetCalendarEvent(c.Request.Context(), api.DB, noteCreateParams.LinkedEventID, userID)
 		if err != nil {
 			api.Logger.Error().Err(err).Msgf("linked event not found: %s, err", noteCreateParams.LinkedEventID.Hex())
-			c.JSON(400, gin.H{"detail": fmt.Sprintf("linked event not found: %s", noteCreateParams.LinkedEventID.Hex())})
+			HandleBadRequest(c, fmt.Sprintf("linked event not found: %s", noteCreateParams.LinkedEventID.Hex()), "linked_event_id")
+			return
+		}
+	}
+
+	if noteCreateParams.FolderID != primitive.NilObjectID {
+		_, err = database.GetNoteFolder(c.Request.Context(), api.DB, noteCreateParams.FolderID, userID)
+		if err != nil {
+			HandleBadRequest(c, "folder not found")
 			return
 		}
 	}
@@ -41,7 +50,15 @@ func (api *API) NoteCreate(c *gin.Context) {
 	sharedAccessValid := database.CheckNoteSharingAccessValid(noteCreateParams.SharedAccess)
 	if !sharedAccessValid {
 		api.Logger.Error().Err(err).Msg("invalid shared access token")
-		c.JSON(400, gin.H{"detail": "invalid shared access token"})
+		HandleBadRequest(c, "invalid shared access token")
+		return
+	}
+	// notes without shared_access are public
+	sharedAccess := database.SharedAccessPublic
+	if noteCreateParams.SharedAccess != nil {
+		sharedAccess = *noteCreateParams.SharedAccess
+	}
+	if !api.checkSharingPolicy(c, userID, sharedAccess, noteCreateParams.SharedUntil.Time()) {
 		return
 	}
 
@@ -55,10 +72,11 @@ func (api *API) NoteCreate(c *gin.Context) {
 		SharedUntil:   noteCreateParams.SharedUntil,
 		SharedAccess:  noteCreateParams.SharedAccess,
 		LinkedEventID: noteCreateParams.LinkedEventID,
+		FolderID:      noteCreateParams.FolderID,
 	}
 	insertResult, err := database.GetNoteCollection(api.DB).InsertOne(context.Background(), newNote)
 	if err != nil {
-		c.JSON(503, gin.H{"detail": "failed to create note"})
+		HandleError(c, ErrorCodeServiceUnavailable, "failed to create note")
 		return
 	}
 
@@ -22,7 +22,7 @@ func TestCreateNote(t *testing.T) {
 	UnauthorizedTest(t, "POST", "/notes/create/", bytes.NewBuffer([]byte(`{"title": "duck@duck.com"}`)))
 	t.Run("MissingTitle", func(t *testing.T) {
 		response := ServeRequest(t, authToken, "POST", "/notes/create/", nil, http.StatusBadRequest, api)
-		assert.Equal(t, "{\"detail\":\"invalid or missing parameter\"}", string(response))
+		assert.Equal(t, "{\"detail\":\"invalid or missing parameter\",\"code\":\"bad_request\"}", string(response))
 	})
 	t.Run("NoLinkedEvent", func(t *testing.T) {
 		authToken = login("create_task_no_linked@resonant-kelpie-404a42.netlify.app", "")
@@ -42,7 +42,7 @@ func TestCreateNote(t *testing.T) {
 
 		body := ServeRequest(t, authToken, "POST", "/notes/create/", bytes.NewBuffer([]byte(`{"title": "buy more dogecoin", "body": "test body", "author": "test author", "is_shared": true}`)), http.StatusOK, nil)
 
-		notes, err := database.GetNotes(api.DB, userID)
+		notes, err := database.GetNotes(context.Background(), api.DB, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(*notes))
 		note := (*notes)[0]
@@ -76,7 +76,7 @@ func TestCreateNote(t *testing.T) {
 
 		body := ServeRequest(t, authToken, "POST", "/notes/create/", bytes.NewBuffer(bodyParams), http.StatusOK, nil)
 
-		notes, err := database.GetNotes(api.DB, userID)
+		notes, err := database.GetNotes(context.Background(), api.DB, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(*notes))
 		note := (*notes)[0]
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type DailyNoteQueryParams struct {
+	IncludeCompletedTasks bool `form:"include_completed_tasks"`
+}
+
+type DailyNoteModifyParams struct {
+	Title *string `json:"title"`
+	Body  *string `json:"body"`
+}
+
+type DailyNoteCompletedTask struct {
+	ID          primitive.ObjectID `json:"id"`
+	Title       string             `json:"title"`
+	CompletedAt string             `json:"completed_at"`
+}
+
+type DailyNoteResult struct {
+	*NoteResult
+	DailyNoteDate string `json:"daily_note_date"`
+	// only included when include_completed_tasks is set
+	CompletedTasks *[]DailyNoteCompletedTask `json:"completed_tasks,omitempty"`
+}
+
+// DailyNoteGet returns the user's note for the date, creating an empty one the first time it's opened
+func (api *API) DailyNoteGet(c *gin.Context) {
+	date, ok := getDailyNoteDate(c)
+	if !ok {
+		return
+	}
+	userID := getUserIDFromContext(c)
+	note, err := database.GetOrCreateDailyNote(c.Request.Context(), api.DB, userID, date.Format(constants.YEAR_MONTH_DAY_FORMAT), getDailyNoteTitle(date))
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	api.respondWithDailyNote(c, note, date)
+}
+
+// DailyNoteModify updates the user's note for the date, creating it first if needed
+func (api *API) DailyNoteModify(c *gin.Context) {
+	date, ok := getDailyNoteDate(c)
+	if !ok {
+		return
+	}
+	var params DailyNoteModifyParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "parameter missing or malformatted")
+		return
+	}
+	if params == (DailyNoteModifyParams{}) {
+		HandleBadRequest(c, "note changes missing")
+		return
+	}
+	if params.Title != nil && *params.Title == "" {
+		HandleBadRequest(c, "title cannot be empty", "title")
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	note, err := database.GetOrCreateDailyNote(c.Request.Context(), api.DB, userID, date.Format(constants.YEAR_MONTH_DAY_FORMAT), getDailyNoteTitle(date))
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	updateFields := database.Note{
+		Title:     params.Title,
+		Body:      params.Body,
+		UpdatedAt: primitive.NewDateTimeFromTime(time.Now()),
+	}
+	err = api.UpdateNoteInDBWithError(note, userID, &updateFields)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	if params.Title != nil {
+		note.Title = params.Title
+	}
+	if params.Body != nil {
+		note.Body = params.Body
+	}
+	note.UpdatedAt = updateFields.UpdatedAt
+	api.respondWithDailyNote(c, note, date)
+}
+
+func (api *API) respondWithDailyNote(c *gin.Context, note *database.Note, date time.Time) {
+	var queryParams DailyNoteQueryParams
+	err := c.ShouldBindQuery(&queryParams)
+	if err != nil {
+		HandleBindError(c, &queryParams, err, "invalid or missing parameter")
+		return
+	}
+	result := DailyNoteResult{
+		NoteResult:    api.noteToNoteResult(c.Request.Context(), note),
+		DailyNoteDate: note.DailyNoteDate,
+	}
+	if queryParams.IncludeCompletedTasks {
+		timezoneOffset, err := api.getTimezoneOffset(c)
+		if err != nil {
+			HandleBadRequest(c, err.Error())
+			return
+		}
+		completedTasks, err := api.getDailyNoteCompletedTasks(c.Request.Context(), note.UserID, date, timezoneOffset)
+		if err != nil {
+			Handle500(c)
+			return
+		}
+		result.CompletedTasks = &completedTasks
+	}
+	c.JSON(200, result)
+}
+
+// getDailyNoteCompletedTasks returns the tasks completed on the date in the user's timezone
+func (api *API) getDailyNoteCompletedTasks(ctx context.Context, userID primitive.ObjectID, date time.Time, timezoneOffset time.Duration) ([]DailyNoteCompletedTask, error) {
+	dayStart := date.Add(timezoneOffset)
+	tasks, err := database.GetTasksCompletedBetween(ctx, api.DB, userID, dayStart, dayStart.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	completedTasks := []DailyNoteCompletedTask{}
+	for _, task := range *tasks {
+		title := ""
+		if task.Title != nil {
+			title = *task.Title
+		}
+		completedTasks = append(completedTasks, DailyNoteCompletedTask{
+			ID:          task.ID,
+			Title:       title,
+			CompletedAt: task.CompletedAt.Time().UTC().Format(time.RFC3339),
+		})
+	}
+	return completedTasks, nil
+}
+
+// getDailyNoteDate parses the date param as midnight UTC on the date, writing a 400 if it's invalid
+func getDailyNoteDate(c *gin.Context) (time.Time, bool) {
+	date, err := time.Parse(constants.YEAR_MONTH_DAY_FORMAT, c.Param("date"))
+	if err != nil {
+		HandleBadRequest(c, "'date' must be formatted as YYYY-MM-DD", "date")
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+func getDailyNoteTitle(date time.Time) string {
+	return date.Format("Monday, January 2, 2006")
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDailyNote(t *testing.T) {
+	authToken := login("test_daily_note@resonant-kelpie-404a42.netlify.app", "")
+	otherToken := login("test_daily_note_other@resonant-kelpie-404a42.netlify.app", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+
+	getDailyNote := func(t *testing.T, token string, url string) DailyNoteResult {
+		response := ServeRequest(t, token, http.MethodGet, url, nil, http.StatusOK, api)
+		var result DailyNoteResult
+		assert.NoError(t, json.Unmarshal(response, &result))
+		return result
+	}
+
+	UnauthorizedTest(t, http.MethodGet, "/notes/daily/2023-03-01/", nil)
+	t.Run("InvalidDate", func(t *testing.T) {
+		response := ServeRequest(t, authToken, http.MethodGet, "/notes/daily/03-01-2023/", nil, http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"'date' must be formatted as YYYY-MM-DD","code":"invalid_parameter","field_errors":[{"field":"date"}]}`, string(response))
+	})
+
+	var noteID primitive.ObjectID
+	t.Run("CreatedOnFirstGet", func(t *testing.T) {
+		result := getDailyNote(t, authToken, "/notes/daily/2023-03-01/")
+		assert.Equal(t, "Wednesday, March 1, 2023", result.Title)
+		assert.Equal(t, "", result.Body)
+		assert.Equal(t, "2023-03-01", result.DailyNoteDate)
+		assert.Nil(t, result.CompletedTasks)
+		noteID = result.ID
+
+		assert.Equal(t, noteID, getDailyNote(t, authToken, "/notes/daily/2023-03-01/").ID)
+		assert.NotEqual(t, noteID, getDailyNote(t, authToken, "/notes/daily/2023-03-02/").ID)
+	})
+	t.Run("OtherUser", func(t *testing.T) {
+		assert.NotEqual(t, noteID, getDailyNote(t, otherToken, "/notes/daily/2023-03-01/").ID)
+	})
+	t.Run("ModifyMissingParams", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPut, "/notes/daily/2023-03-01/", bytes.NewBuffer([]byte(`{}`)), http.StatusBadRequest, api)
+	})
+	t.Run("Modify", func(t *testing.T) {
+		response := ServeRequest(t, authToken, http.MethodPut, "/notes/daily/2023-03-01/", bytes.NewBuffer([]byte(`{"body": "plan the launch"}`)), http.StatusOK, api)
+		var result DailyNoteResult
+		assert.NoError(t, json.Unmarshal(response, &result))
+		assert.Equal(t, noteID, result.ID)
+		assert.Equal(t, "plan the launch", result.Body)
+		assert.Equal(t, "plan the launch", getDailyNote(t, authToken, "/notes/daily/2023-03-01/").Body)
+	})
+	t.Run("ModifyCreatesNote", func(t *testing.T) {
+		response := ServeRequest(t, authToken, http.MethodPut, "/notes/daily/2023-03-03/", bytes.NewBuffer([]byte(`{"title": "offsite"}`)), http.StatusOK, api)
+		var result DailyNoteResult
+		assert.NoError(t, json.Unmarshal(response, &result))
+		assert.Equal(t, "offsite", result.Title)
+		assert.Equal(t, result.ID, getDailyNote(t, authToken, "/notes/daily/2023-03-03/").ID)
+	})
+	t.Run("IncludeCompletedTasks", func(t *testing.T) {
+		completedTrue := true
+		completedTask := func(title string, completedAt time.Time) {
+			_, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), database.Task{
+				UserID:      userID,
+				SourceID:    external.TASK_SOURCE_ID_GT_TASK,
+				Title:       &title,
+				IsCompleted: &completedTrue,
+				CompletedAt: primitive.NewDateTimeFromTime(completedAt),
+			})
+			assert.NoError(t, err)
+		}
+		completedTask("ship it", time.Date(2023, time.March, 1, 18, 0, 0, 0, time.UTC))
+		completedTask("too late", time.Date(2023, time.March, 2, 9, 0, 0, 0, time.UTC))
+		completedTask("too early", time.Date(2023, time.March, 1, 9, 0, 0, 0, time.UTC))
+
+		// the day starts at 10:00 UTC for a user 10 hours behind
+		router := GetRouter(api)
+		request, _ := http.NewRequest(http.MethodGet, "/notes/daily/2023-03-01/?include_completed_tasks=true", nil)
+		request.Header.Set("Authorization", "Bearer "+authToken)
+		request.Header.Set("Timezone-Offset", "600")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		var result DailyNoteResult
+		assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+		assert.Equal(t, 1, len(*result.CompletedTasks))
+		assert.Equal(t, "ship it", (*result.CompletedTasks)[0].Title)
+		assert.Equal(t, "2023-03-01T18:00:00Z", (*result.CompletedTasks)[0].CompletedAt)
+	})
+}
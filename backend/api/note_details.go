@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/templating"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -25,13 +26,13 @@ func (api *API) NoteDetails(c *gin.Context) {
 
 	var note *database.Note
 	if userID != nil {
-		note, err = database.GetSharedNoteWithAuth(api.DB, noteID, *userID)
+		note, err = database.GetSharedNoteWithAuth(c.Request.Context(), api.DB, noteID, *userID)
 		if err != nil {
 			Handle404(c)
 			return
 		}
 	} else {
-		note, err = database.GetSharedNote(api.DB, noteID)
+		note, err = database.GetSharedNote(c.Request.Context(), api.DB, noteID)
 		if err != nil {
 			Handle404(c)
 			return
@@ -43,6 +44,25 @@ func (api *API) NoteDetails(c *gin.Context) {
 		return
 	}
 
-	noteResult := api.noteToNoteResult(note)
+	noteResult := api.noteToNoteResult(c.Request.Context(), note)
+	noteResult.BodyHTML, err = templating.RenderMarkdown(noteResult.Body)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to render note body")
+		Handle500(c)
+		return
+	}
+	owner, err := database.GetUser(c.Request.Context(), api.DB, note.UserID)
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	ownerProfile := getCollaboratorProfile(owner)
+	noteResult.Owner = &ownerProfile
+	noteResult.Collaborators, err = api.getCollaboratorProfiles(c.Request.Context(), note.UserID, note.Comments)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	api.recordShareView(c, database.ShareView{UserID: note.UserID, NoteID: note.ID})
 	c.JSON(200, noteResult)
 }
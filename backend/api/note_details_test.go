@@ -1,6 +1,8 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -26,6 +28,7 @@ func TestNoteDetail(t *testing.T) {
 	startTime, _ := time.Parse(time.RFC3339, "2021-03-06T15:00:00-05:00")
 	endTime, _ := time.Parse(time.RFC3339, "2021-03-06T15:30:00-05:00")
 	event, err := database.GetOrCreateCalendarEvent(
+		context.Background(),
 		db,
 		userID,
 		"123abc",
@@ -40,6 +43,7 @@ func TestNoteDetail(t *testing.T) {
 	)
 	assert.NoError(t, err)
 	note1, err := database.GetOrCreateNote(
+		context.Background(),
 		db,
 		userID,
 		"123abc",
@@ -53,6 +57,7 @@ func TestNoteDetail(t *testing.T) {
 	)
 	assert.NoError(t, err)
 	note2, err := database.GetOrCreateNote(
+		context.Background(),
 		db,
 		userID,
 		"123abcdef",
@@ -80,7 +85,7 @@ func TestNoteDetail(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, recorder.Code)
 		response, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"not found\"}", string(response))
+		assert.Equal(t, "{\"detail\":\"not found\",\"code\":\"unauthorized\"}", string(response))
 	})
 	t.Run("NoteIsNotShared", func(t *testing.T) {
 		request, _ := http.NewRequest(
@@ -92,7 +97,7 @@ func TestNoteDetail(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"not found\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"not found\",\"code\":\"unauthorized\"}", string(body))
 	})
 	t.Run("Success", func(t *testing.T) {
 		request, _ := http.NewRequest(
@@ -106,7 +111,37 @@ func TestNoteDetail(t *testing.T) {
 		assert.NoError(t, err)
 
 		assert.Equal(t,
-			fmt.Sprintf(`{"id":"%s","title":"title1","created_at":"1970-01-01T00:00:00Z","updated_at":"1970-01-01T00:00:00Z","shared_until":"9999-01-01T00:00:00Z","linked_event_id":"%s","linked_event_start":"2021-03-06T20:00:00Z","linked_event_end":"2021-03-06T20:30:00Z"}`, note1.ID.Hex(), event.ID.Hex()),
+			fmt.Sprintf(`{"id":"%[1]s","title":"title1","created_at":"1970-01-01T00:00:00Z","updated_at":"1970-01-01T00:00:00Z","shared_until":"9999-01-01T00:00:00Z","linked_event_id":"%[2]s","linked_event_start":"2021-03-06T20:00:00Z","linked_event_end":"2021-03-06T20:30:00Z","owner":{"id":"%[3]s","name":"test_notes_detail","avatar_url":"%[4]s"},"collaborators":{"%[3]s":{"id":"%[3]s","name":"test_notes_detail","avatar_url":"%[4]s"}}}`, note1.ID.Hex(), event.ID.Hex(), userID.Hex(), getGravatarURL("test_notes_detail@resonant-kelpie-404a42.netlify.app")),
 			string(body))
 	})
+	t.Run("RendersMarkdownBody", func(t *testing.T) {
+		title := "markdown"
+		body := "**bold** <script>alert(1)</script>"
+		note, err := database.GetOrCreateNote(
+			context.Background(),
+			db,
+			userID,
+			"markdown123",
+			"foobar_source",
+			&database.Note{
+				UserID:      userID,
+				Title:       &title,
+				Body:        &body,
+				SharedUntil: *testutils.CreateDateTime("9999-01-01"),
+			},
+		)
+		assert.NoError(t, err)
+		request, _ := http.NewRequest(
+			"GET",
+			fmt.Sprintf("/notes/detail/%s/", note.ID.Hex()),
+			nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		var result NoteResult
+		assert.NoError(t, json.NewDecoder(recorder.Body).Decode(&result))
+		assert.Equal(t, body, result.Body)
+		assert.Contains(t, result.BodyHTML, "<strong>bold</strong>")
+		assert.NotContains(t, result.BodyHTML, "<script>")
+	})
 }
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type NoteFolderCreateParams struct {
+	IDOrdering int    `json:"id_ordering"`
+	Name       string `json:"name" binding:"required"`
+}
+
+type NoteFolderModifyParams struct {
+	IDOrdering int    `json:"id_ordering"`
+	Name       string `json:"name"`
+}
+
+type NoteFolderResult struct {
+	ID         primitive.ObjectID `json:"id"`
+	IDOrdering int                `json:"id_ordering"`
+	Name       string             `json:"name"`
+}
+
+func (api *API) NoteFolderList(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	folders, err := database.GetNoteFolders(c.Request.Context(), api.DB, userID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	folderResults := []NoteFolderResult{}
+	for _, folder := range *folders {
+		folderResults = append(folderResults, NoteFolderResult{
+			ID:         folder.ID,
+			IDOrdering: folder.IDOrdering,
+			Name:       folder.Name,
+		})
+	}
+	c.JSON(200, folderResults)
+}
+
+func (api *API) NoteFolderAdd(c *gin.Context) {
+	var params NoteFolderCreateParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing 'name' parameter", "name")
+		return
+	}
+	userID := getUserIDFromContext(c)
+	folderCollection := database.GetNoteFolderCollection(api.DB)
+	mongoResult, err := folderCollection.InsertOne(
+		c.Request.Context(),
+		&database.NoteFolder{
+			UserID:     userID,
+			Name:       params.Name,
+			IDOrdering: params.IDOrdering,
+		},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to insert note folder")
+		Handle500(c)
+		return
+	}
+	folderID := mongoResult.InsertedID.(primitive.ObjectID)
+	if params.IDOrdering != 0 {
+		err = database.MoveToPosition(c.Request.Context(), folderCollection, userID, folderID, nil, params.IDOrdering)
+		if err != nil {
+			Handle500(c)
+			return
+		}
+	}
+	c.JSON(201, gin.H{"id": folderID.Hex()})
+}
+
+func (api *API) NoteFolderModify(c *gin.Context) {
+	folderID, err := primitive.ObjectIDFromHex(c.Param("folder_id"))
+	if err != nil {
+		// This means the folder ID is improperly formatted
+		Handle404(c)
+		return
+	}
+	var params NoteFolderModifyParams
+	err = c.BindJSON(&params)
+	if err != nil || (params.Name == "" && params.IDOrdering == 0) {
+		HandleBindError(c, &params, err, "invalid or missing note folder modify parameter")
+		return
+	}
+
+	folderCollection := database.GetNoteFolderCollection(api.DB)
+	userID := getUserIDFromContext(c)
+
+	updateFields := bson.M{}
+	if params.Name != "" {
+		updateFields["name"] = params.Name
+	}
+	if params.IDOrdering != 0 {
+		updateFields["id_ordering"] = params.IDOrdering
+	}
+	res, err := folderCollection.UpdateOne(
+		c.Request.Context(),
+		bson.M{"$and": []bson.M{
+			{"_id": folderID},
+			{"user_id": userID},
+		}},
+		bson.M{"$set": updateFields},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update note folder")
+		Handle500(c)
+		return
+	}
+	if res.MatchedCount != 1 {
+		Handle404(c)
+		return
+	}
+	if params.IDOrdering != 0 {
+		err = database.MoveToPosition(c.Request.Context(), folderCollection, userID, folderID, nil, params.IDOrdering)
+		if err != nil {
+			Handle500(c)
+			return
+		}
+	}
+	c.JSON(200, gin.H{})
+}
+
+// NoteFolderDelete deletes the folder and moves the notes in it out to the top level
+func (api *API) NoteFolderDelete(c *gin.Context) {
+	folderID, err := primitive.ObjectIDFromHex(c.Param("folder_id"))
+	if err != nil {
+		// This means the folder ID is improperly formatted
+		Handle404(c)
+		return
+	}
+	userID := getUserIDFromContext(c)
+
+	res, err := database.GetNoteFolderCollection(api.DB).DeleteOne(
+		c.Request.Context(),
+		bson.M{"$and": []bson.M{
+			{"_id": folderID},
+			{"user_id": userID},
+		}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to delete note folder")
+		Handle500(c)
+		return
+	}
+	if res.DeletedCount != 1 {
+		Handle404(c)
+		return
+	}
+
+	_, err = database.GetNoteCollection(api.DB).UpdateMany(
+		c.Request.Context(),
+		bson.M{"$and": []bson.M{
+			{"folder_id": folderID},
+			{"user_id": userID},
+		}},
+		bson.M{"$unset": bson.M{"folder_id": ""}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to move notes out of deleted folder")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
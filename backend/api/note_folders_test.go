@@ -0,0 +1,121 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNoteFolders(t *testing.T) {
+	authToken := login("test_note_folders@resonant-kelpie-404a42.netlify.app", "")
+	otherToken := login("test_note_folders_other@resonant-kelpie-404a42.netlify.app", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+
+	createFolder := func(t *testing.T, body string) string {
+		response := ServeRequest(t, authToken, http.MethodPost, "/note_folders/create/", bytes.NewBuffer([]byte(body)), http.StatusCreated, api)
+		var result map[string]string
+		assert.NoError(t, json.Unmarshal(response, &result))
+		return result["id"]
+	}
+	listFolders := func(t *testing.T) []NoteFolderResult {
+		response := ServeRequest(t, authToken, http.MethodGet, "/note_folders/", nil, http.StatusOK, api)
+		var folders []NoteFolderResult
+		assert.NoError(t, json.Unmarshal(response, &folders))
+		return folders
+	}
+	listNotes := func(t *testing.T, query string) []NoteResult {
+		response := ServeRequest(t, authToken, http.MethodGet, "/notes/"+query, nil, http.StatusOK, api)
+		var notes []NoteResult
+		assert.NoError(t, json.Unmarshal(response, &notes))
+		return notes
+	}
+
+	UnauthorizedTest(t, http.MethodGet, "/note_folders/", nil)
+	UnauthorizedTest(t, http.MethodPost, "/note_folders/create/", nil)
+	t.Run("CreateMissingName", func(t *testing.T) {
+		response := ServeRequest(t, authToken, http.MethodPost, "/note_folders/create/", bytes.NewBuffer([]byte(`{}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"'name' is required","code":"invalid_parameter","field_errors":[{"field":"name","message":"'name' is required"}]}`, string(response))
+	})
+
+	var workFolderID, personalFolderID string
+	t.Run("Create", func(t *testing.T) {
+		workFolderID = createFolder(t, `{"name": "work", "id_ordering": 1}`)
+		personalFolderID = createFolder(t, `{"name": "personal", "id_ordering": 2}`)
+		folders := listFolders(t)
+		assert.Equal(t, 2, len(folders))
+		assert.Equal(t, "work", folders[0].Name)
+		assert.Equal(t, "personal", folders[1].Name)
+	})
+	t.Run("Reorder", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPatch, "/note_folders/modify/"+personalFolderID+"/", bytes.NewBuffer([]byte(`{"id_ordering": 1}`)), http.StatusOK, api)
+		folders := listFolders(t)
+		assert.Equal(t, "personal", folders[0].Name)
+		assert.Equal(t, 1, folders[0].IDOrdering)
+		assert.Equal(t, "work", folders[1].Name)
+		assert.Equal(t, 2, folders[1].IDOrdering)
+	})
+	t.Run("Rename", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPatch, "/note_folders/modify/"+workFolderID+"/", bytes.NewBuffer([]byte(`{"name": "office"}`)), http.StatusOK, api)
+		assert.Equal(t, "office", listFolders(t)[1].Name)
+	})
+	t.Run("ModifyMissingParams", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPatch, "/note_folders/modify/"+workFolderID+"/", bytes.NewBuffer([]byte(`{}`)), http.StatusBadRequest, api)
+	})
+	t.Run("ModifyOtherUsersFolder", func(t *testing.T) {
+		ServeRequest(t, otherToken, http.MethodPatch, "/note_folders/modify/"+workFolderID+"/", bytes.NewBuffer([]byte(`{"name": "mine"}`)), http.StatusNotFound, api)
+		ServeRequest(t, otherToken, http.MethodDelete, "/note_folders/delete/"+workFolderID+"/", nil, http.StatusNotFound, api)
+	})
+
+	var noteID string
+	t.Run("CreateNoteInFolder", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPost, "/notes/create/", bytes.NewBuffer([]byte(`{"title": "top level"}`)), http.StatusOK, api)
+		response := ServeRequest(t, authToken, http.MethodPost, "/notes/create/", bytes.NewBuffer([]byte(`{"title": "standup", "folder_id": "`+workFolderID+`"}`)), http.StatusOK, api)
+		var result map[string]string
+		assert.NoError(t, json.Unmarshal(response, &result))
+		noteID = result["note_id"]
+
+		assert.Equal(t, 2, len(listNotes(t, "")))
+		notes := listNotes(t, "?folder_id="+workFolderID)
+		assert.Equal(t, 1, len(notes))
+		assert.Equal(t, "standup", notes[0].Title)
+		assert.Equal(t, workFolderID, notes[0].FolderID)
+		assert.Equal(t, 0, len(listNotes(t, "?folder_id="+personalFolderID)))
+	})
+	t.Run("CreateNoteInOtherUsersFolder", func(t *testing.T) {
+		response := ServeRequest(t, otherToken, http.MethodPost, "/notes/create/", bytes.NewBuffer([]byte(`{"title": "standup", "folder_id": "`+workFolderID+`"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"folder not found","code":"bad_request"}`, string(response))
+	})
+	t.Run("InvalidFolderFilter", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodGet, "/notes/?folder_id=123", nil, http.StatusBadRequest, api)
+	})
+	t.Run("MoveNote", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPatch, "/notes/modify/"+noteID+"/", bytes.NewBuffer([]byte(`{"folder_id": "`+personalFolderID+`"}`)), http.StatusOK, api)
+		assert.Equal(t, 1, len(listNotes(t, "?folder_id="+personalFolderID)))
+
+		ServeRequest(t, authToken, http.MethodPatch, "/notes/modify/"+noteID+"/", bytes.NewBuffer([]byte(`{"folder_id": ""}`)), http.StatusOK, api)
+		assert.Equal(t, 0, len(listNotes(t, "?folder_id="+personalFolderID)))
+	})
+	t.Run("MoveNoteToMissingFolder", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPatch, "/notes/modify/"+noteID+"/", bytes.NewBuffer([]byte(`{"folder_id": "`+primitive.NewObjectID().Hex()+`"}`)), http.StatusBadRequest, api)
+	})
+	t.Run("Delete", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPatch, "/notes/modify/"+noteID+"/", bytes.NewBuffer([]byte(`{"folder_id": "`+workFolderID+`"}`)), http.StatusOK, api)
+		ServeRequest(t, authToken, http.MethodDelete, "/note_folders/delete/"+workFolderID+"/", nil, http.StatusOK, api)
+		assert.Equal(t, 1, len(listFolders(t)))
+
+		// the note is kept, outside of any folder
+		noteObjectID, _ := primitive.ObjectIDFromHex(noteID)
+		note, err := database.GetNote(context.Background(), api.DB, noteObjectID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, primitive.NilObjectID, note.FolderID)
+		ServeRequest(t, authToken, http.MethodDelete, "/note_folders/delete/"+workFolderID+"/", nil, http.StatusNotFound, api)
+	})
+}
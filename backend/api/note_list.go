@@ -4,6 +4,8 @@ import (
 	"context"
 	"github.com/franchizzle/task-manager/backend/constants"
 	"github.com/franchizzle/task-manager/backend/logging"
+	"github.com/franchizzle/task-manager/backend/settings"
+	"sort"
 	"time"
 
 	"github.com/franchizzle/task-manager/backend/database"
@@ -13,18 +15,28 @@ import (
 )
 
 type NoteResult struct {
-	ID               primitive.ObjectID `json:"id,omitempty"`
-	Title            string             `json:"title,omitempty"`
-	Body             string             `json:"body,omitempty"`
-	Author           string             `json:"author,omitempty"`
-	CreatedAt        string             `json:"created_at,omitempty"`
-	UpdatedAt        string             `json:"updated_at,omitempty"`
-	SharedUntil      string             `json:"shared_until,omitempty"`
-	IsDeleted        bool               `json:"is_deleted,omitempty"`
-	LinkedEventID    string             `json:"linked_event_id,omitempty"`
-	LinkedEventStart string             `json:"linked_event_start,omitempty"`
-	LinkedEventEnd   string             `json:"linked_event_end,omitempty"`
-	SharedAccess     string             `json:"shared_access,omitempty"`
+	ID               primitive.ObjectID  `json:"id,omitempty"`
+	Title            string              `json:"title,omitempty"`
+	Body             string              `json:"body,omitempty"`
+	BodyHTML         string              `json:"body_html,omitempty"`
+	Author           string              `json:"author,omitempty"`
+	CreatedAt        string              `json:"created_at,omitempty"`
+	UpdatedAt        string              `json:"updated_at,omitempty"`
+	SharedUntil      string              `json:"shared_until,omitempty"`
+	IsDeleted        bool                `json:"is_deleted,omitempty"`
+	IsPinned         bool                `json:"is_pinned,omitempty"`
+	IsArchived       bool                `json:"is_archived,omitempty"`
+	DeletedAt        string              `json:"deleted_at,omitempty"`
+	FolderID         string              `json:"folder_id,omitempty"`
+	LinkedEventID    string              `json:"linked_event_id,omitempty"`
+	LinkedEventStart string              `json:"linked_event_start,omitempty"`
+	LinkedEventEnd   string              `json:"linked_event_end,omitempty"`
+	SharedAccess     string              `json:"shared_access,omitempty"`
+	Slug             string              `json:"slug,omitempty"`
+	Comments         *[]database.Comment `json:"comments,omitempty"`
+	// only filled in on shared note details
+	Owner         *CollaboratorProfile           `json:"owner,omitempty"`
+	Collaborators map[string]CollaboratorProfile `json:"collaborators,omitempty"`
 }
 
 func (api *API) NotesList(c *gin.Context) {
@@ -39,27 +51,102 @@ func (api *API) NotesList(c *gin.Context) {
 		return
 	}
 
-	notes, err := database.GetNotes(api.DB, userID)
+	pagination, err := getPagination(c)
+	if err != nil {
+		HandleBadRequest(c, err.Error())
+		return
+	}
+	fieldsOptions, err := getFieldsFindOptions(c, database.Note{}, nil)
+	if err != nil {
+		HandleBadRequest(c, err.Error())
+		return
+	}
+	// folder_id scopes the list to the notes in that folder
+	var additionalFilters *[]bson.M
+	var folderID primitive.ObjectID
+	if folderIDHex := c.Query("folder_id"); folderIDHex != "" {
+		folderID, err = primitive.ObjectIDFromHex(folderIDHex)
+		if err != nil {
+			HandleBadRequest(c, "'folder_id' is not a valid ID", "folder_id")
+			return
+		}
+		additionalFilters = &[]bson.M{{"folder_id": folderID}}
+	}
+	if pagination != nil {
+		notes, nextCursor, err := database.FindPageWithCollection[database.Note](c.Request.Context(), database.GetNoteCollection(api.DB), userID, additionalFilters, *pagination, fieldsOptions)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to fetch notes page")
+			Handle500(c)
+			return
+		}
+		c.JSON(200, PaginatedResult[*NoteResult]{
+			Results:    api.noteListToNoteResultList(c.Request.Context(), &notes),
+			NextCursor: nextCursor,
+		})
+		return
+	}
+
+	var notes *[]database.Note
+	if additionalFilters != nil {
+		notes, err = database.GetNotesInFolder(c.Request.Context(), api.DB, userID, folderID, fieldsOptions)
+	} else {
+		notes, err = database.GetNotes(c.Request.Context(), api.DB, userID, fieldsOptions)
+	}
 	if err != nil {
 		Handle500(c)
 		return
 	}
-	noteResults := api.noteListToNoteResultList(notes)
+	sortingPreference, err := settings.GetUserSettingValue(api.DB, userID, settings.NoteSortingPreferenceSetting)
+	if err != nil {
+		sortingPreference = settings.NoteSortingPreferenceSetting.DefaultChoice
+	}
+	sortingDirection, err := settings.GetUserSettingValue(api.DB, userID, settings.NoteSortingDirectionSetting)
+	if err != nil {
+		sortingDirection = settings.NoteSortingDirectionSetting.DefaultChoice
+	}
+	sortNotes(*notes, sortingPreference, sortingDirection)
+	noteResults := api.noteListToNoteResultList(c.Request.Context(), notes)
 	c.JSON(200, noteResults)
 }
 
-func (api *API) noteListToNoteResultList(notes *[]database.Note) []*NoteResult {
+// sortNotes orders notes by the user's note sorting settings, with pinned notes first and archived
+// notes last. Pages of notes stay in creation order so the cursor keeps working
+func sortNotes(notes []database.Note, sortingPreference string, sortingDirection string) {
+	sort.SliceStable(notes, func(i, j int) bool {
+		a := notes[i]
+		b := notes[j]
+		aIsPinned := a.IsPinned != nil && *a.IsPinned
+		bIsPinned := b.IsPinned != nil && *b.IsPinned
+		if aIsPinned != bIsPinned {
+			return aIsPinned
+		}
+		aIsArchived := a.IsArchived != nil && *a.IsArchived
+		bIsArchived := b.IsArchived != nil && *b.IsArchived
+		if aIsArchived != bIsArchived {
+			return bIsArchived
+		}
+		if sortingDirection == constants.ChoiceKeyDescending {
+			a, b = b, a
+		}
+		if sortingPreference == constants.ChoiceKeyCreatedAt {
+			return a.CreatedAt < b.CreatedAt
+		}
+		return a.UpdatedAt < b.UpdatedAt
+	})
+}
+
+func (api *API) noteListToNoteResultList(ctx context.Context, notes *[]database.Note) []*NoteResult {
 	noteResults := []*NoteResult{}
 	for _, note := range *notes {
 		// for implicit memory aliasing
 		tempNote := note
-		result := api.noteToNoteResult(&tempNote)
+		result := api.noteToNoteResult(ctx, &tempNote)
 		noteResults = append(noteResults, result)
 	}
 	return noteResults
 }
 
-func (api *API) noteToNoteResult(note *database.Note) *NoteResult {
+func (api *API) noteToNoteResult(ctx context.Context, note *database.Note) *NoteResult {
 	body := ""
 	if note.Body != nil {
 		body = *note.Body
@@ -72,6 +159,8 @@ func (api *API) noteToNoteResult(note *database.Note) *NoteResult {
 	if note.IsDeleted != nil && *note.IsDeleted {
 		isDeleted = true
 	}
+	isPinned := note.IsPinned != nil && *note.IsPinned
+	isArchived := note.IsArchived != nil && *note.IsArchived
 	noteResult := NoteResult{
 		ID:          note.ID,
 		Title:       title,
@@ -81,6 +170,10 @@ func (api *API) noteToNoteResult(note *database.Note) *NoteResult {
 		UpdatedAt:   note.UpdatedAt.Time().UTC().Format(time.RFC3339),
 		SharedUntil: note.SharedUntil.Time().UTC().Format(time.RFC3339),
 		IsDeleted:   isDeleted,
+		IsPinned:    isPinned,
+		IsArchived:  isArchived,
+		Slug:        note.Slug,
+		Comments:    note.Comments,
 	}
 	var sharedAccess string
 	if note.SharedAccess != nil {
@@ -93,9 +186,15 @@ func (api *API) noteToNoteResult(note *database.Note) *NoteResult {
 		}
 	}
 	noteResult.SharedAccess = sharedAccess
+	if note.FolderID != primitive.NilObjectID {
+		noteResult.FolderID = note.FolderID.Hex()
+	}
+	if note.DeletedAt != 0 {
+		noteResult.DeletedAt = note.DeletedAt.Time().UTC().Format(time.RFC3339)
+	}
 	if note.LinkedEventID != primitive.NilObjectID {
 		noteResult.LinkedEventID = note.LinkedEventID.Hex()
-		calEvent, err := database.GetCalendarEventWithoutUserID(api.DB, note.LinkedEventID)
+		calEvent, err := database.GetCalendarEventWithoutUserID(ctx, api.DB, note.LinkedEventID)
 		if err != nil {
 			logging.GetSentryLogger().Error().Err(err).Msgf("could not fetch calendar event ID: %s", note.LinkedEventID)
 		} else {
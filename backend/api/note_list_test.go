@@ -1,10 +1,12 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"testing"
 
+	"github.com/franchizzle/task-manager/backend/constants"
 	"github.com/franchizzle/task-manager/backend/database"
 	"github.com/franchizzle/task-manager/backend/testutils"
 	"github.com/stretchr/testify/assert"
@@ -22,6 +24,7 @@ func TestNotesList(t *testing.T) {
 	userID := getUserIDFromAuthToken(t, db, authToken)
 	notUserID := primitive.NewObjectID()
 	task1, err := database.GetOrCreateNote(
+		context.Background(),
 		db,
 		userID,
 		"123abc",
@@ -34,6 +37,7 @@ func TestNotesList(t *testing.T) {
 	)
 	assert.NoError(t, err)
 	task2, err := database.GetOrCreateNote(
+		context.Background(),
 		db,
 		userID,
 		"123abcdef",
@@ -46,6 +50,7 @@ func TestNotesList(t *testing.T) {
 	)
 	assert.NoError(t, err)
 	_, err = database.GetOrCreateNote(
+		context.Background(),
 		db,
 		userID,
 		"123abc",
@@ -59,6 +64,7 @@ func TestNotesList(t *testing.T) {
 	isDeleted := true
 	domain := database.SharedAccessDomain
 	task3, err := database.GetOrCreateNote(
+		context.Background(),
 		db,
 		userID,
 		"123abcdogecoin",
@@ -110,4 +116,84 @@ func TestNotesList(t *testing.T) {
 			},
 		}, result)
 	})
+	t.Run("Paginated", func(t *testing.T) {
+		api, dbCleanup := GetAPIWithDBCleanup()
+		defer dbCleanup()
+
+		response := ServeRequest(t, authToken, "GET", "/notes/?limit=2", nil, http.StatusOK, api)
+		var result PaginatedResult[NoteResult]
+		err = json.Unmarshal(response, &result)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(result.Results))
+		assert.Equal(t, task3.ID, result.Results[0].ID)
+		assert.Equal(t, task2.ID, result.Results[1].ID)
+		assert.NotEmpty(t, result.NextCursor)
+
+		response = ServeRequest(t, authToken, "GET", "/notes/?limit=2&cursor="+result.NextCursor, nil, http.StatusOK, api)
+		result = PaginatedResult[NoteResult]{}
+		err = json.Unmarshal(response, &result)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(result.Results))
+		assert.Equal(t, task1.ID, result.Results[0].ID)
+		assert.Empty(t, result.NextCursor)
+	})
+	t.Run("Fields", func(t *testing.T) {
+		api, dbCleanup := GetAPIWithDBCleanup()
+		defer dbCleanup()
+
+		response := ServeRequest(t, authToken, "GET", "/notes/?fields=title", nil, http.StatusOK, api)
+		var result []NoteResult
+		err = json.Unmarshal(response, &result)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(result))
+		assert.Equal(t, task1.ID, result[0].ID)
+		assert.Equal(t, "title1", result[0].Title)
+		// shared_until wasn't requested so it isn't loaded
+		assert.Equal(t, "1970-01-01T00:00:00Z", result[0].SharedUntil)
+	})
+	t.Run("InvalidFields", func(t *testing.T) {
+		api, dbCleanup := GetAPIWithDBCleanup()
+		defer dbCleanup()
+
+		ServeRequest(t, authToken, "GET", "/notes/?fields=title,not_a_field", nil, http.StatusBadRequest, api)
+	})
+	t.Run("InvalidPagination", func(t *testing.T) {
+		api, dbCleanup := GetAPIWithDBCleanup()
+		defer dbCleanup()
+
+		ServeRequest(t, authToken, "GET", "/notes/?limit=0", nil, http.StatusBadRequest, api)
+		ServeRequest(t, authToken, "GET", "/notes/?limit=abc", nil, http.StatusBadRequest, api)
+		ServeRequest(t, authToken, "GET", "/notes/?cursor=notacursor", nil, http.StatusBadRequest, api)
+	})
+}
+
+func TestSortNotes(t *testing.T) {
+	isTrue := true
+	getNotes := func() []database.Note {
+		titles := []string{"a", "b", "c", "pinned", "archived"}
+		return []database.Note{
+			{Title: &titles[0], CreatedAt: *testutils.CreateDateTime("2020-01-02"), UpdatedAt: *testutils.CreateDateTime("2020-01-05")},
+			{Title: &titles[1], CreatedAt: *testutils.CreateDateTime("2020-01-01"), UpdatedAt: *testutils.CreateDateTime("2020-01-03")},
+			{Title: &titles[2], CreatedAt: *testutils.CreateDateTime("2020-01-03"), UpdatedAt: *testutils.CreateDateTime("2020-01-04")},
+			{Title: &titles[3], CreatedAt: *testutils.CreateDateTime("2020-01-01"), UpdatedAt: *testutils.CreateDateTime("2020-01-01"), IsPinned: &isTrue},
+			{Title: &titles[4], CreatedAt: *testutils.CreateDateTime("2020-01-09"), UpdatedAt: *testutils.CreateDateTime("2020-01-09"), IsArchived: &isTrue},
+		}
+	}
+	getTitles := func(notes []database.Note) []string {
+		titles := []string{}
+		for _, note := range notes {
+			titles = append(titles, *note.Title)
+		}
+		return titles
+	}
+	t.Run("UpdatedAtDescending", func(t *testing.T) {
+		notes := getNotes()
+		sortNotes(notes, constants.ChoiceKeyUpdatedAt, constants.ChoiceKeyDescending)
+		assert.Equal(t, []string{"pinned", "a", "c", "b", "archived"}, getTitles(notes))
+	})
+	t.Run("CreatedAtAscending", func(t *testing.T) {
+		notes := getNotes()
+		sortNotes(notes, constants.ChoiceKeyCreatedAt, constants.ChoiceKeyAscending)
+		assert.Equal(t, []string{"pinned", "b", "a", "c", "archived"}, getTitles(notes))
+	})
 }
@@ -22,6 +22,10 @@ type NoteChangeable struct {
 	SharedUntil  *primitive.DateTime `json:"shared_until,omitempty"`
 	SharedAccess *string             `json:"shared_access,omitempty" bson:"shared_access,omitempty"`
 	IsDeleted    *bool               `json:"is_deleted,omitempty"`
+	IsPinned     *bool               `json:"is_pinned,omitempty"`
+	IsArchived   *bool               `json:"is_archived,omitempty"`
+	// "" moves the note out of its folder
+	FolderID *string `json:"folder_id,omitempty"`
 }
 
 type NoteModifyParams struct {
@@ -39,21 +43,21 @@ func (api *API) NoteModify(c *gin.Context) {
 	var modifyParams NoteModifyParams
 	err = c.BindJSON(&modifyParams)
 	if err != nil {
-		c.JSON(400, gin.H{"detail": "parameter missing or malformatted"})
+		HandleBindError(c, &modifyParams, err, "parameter missing or malformatted")
 		return
 	}
 
 	userID := getUserIDFromContext(c)
 
-	note, err := database.GetNote(api.DB, noteID, userID)
+	note, err := database.GetNote(c.Request.Context(), api.DB, noteID, userID)
 	if err != nil {
-		c.JSON(404, gin.H{"detail": "note not found.", "noteId": noteID})
+		HandleAPIError(c, NewAPIError(ErrorCodeNotFound, "note not found.").WithMetadata("noteId", noteID))
 		return
 	}
 
 	// check if all fields are empty
 	if modifyParams == (NoteModifyParams{}) {
-		c.JSON(400, gin.H{"detail": "note changes missing"})
+		HandleBadRequest(c, "note changes missing")
 		return
 	}
 
@@ -67,7 +71,7 @@ func (api *API) NoteModify(c *gin.Context) {
 		} else if *modifyParams.SharedAccess == constants.StringSharedAccessMeetingAttendees {
 			_sharedAccess = database.SharedAccessMeetingAttendees
 		} else {
-			c.JSON(400, gin.H{"detail": "invalid shared access token"})
+			HandleBadRequest(c, "invalid shared access token")
 			return
 		}
 		sharedAccess = &_sharedAccess
@@ -76,15 +80,41 @@ func (api *API) NoteModify(c *gin.Context) {
 	sharedAccessValid := database.CheckNoteSharingAccessValid(sharedAccess)
 	if !sharedAccessValid {
 		api.Logger.Error().Err(err).Msg("invalid shared access token")
-		c.JSON(400, gin.H{"detail": "invalid shared access token"})
+		HandleBadRequest(c, "invalid shared access token")
 		return
 	}
 
+	var folderID primitive.ObjectID
+	if modifyParams.FolderID != nil && *modifyParams.FolderID != "" {
+		folderID, err = primitive.ObjectIDFromHex(*modifyParams.FolderID)
+		if err != nil {
+			HandleBadRequest(c, "'folder_id' is not a valid ID", "folder_id")
+			return
+		}
+		_, err = database.GetNoteFolder(c.Request.Context(), api.DB, folderID, userID)
+		if err != nil {
+			HandleBadRequest(c, "folder not found")
+			return
+		}
+	}
+
 	if modifyParams.NoteChangeable != (NoteChangeable{}) {
 		sharedUntil := note.SharedUntil
 		if modifyParams.NoteChangeable.SharedUntil != nil {
 			sharedUntil = *modifyParams.NoteChangeable.SharedUntil
 		}
+		if modifyParams.NoteChangeable.SharedUntil != nil || sharedAccess != nil {
+			// notes without shared_access are public
+			effectiveSharedAccess := database.SharedAccessPublic
+			if sharedAccess != nil {
+				effectiveSharedAccess = *sharedAccess
+			} else if note.SharedAccess != nil {
+				effectiveSharedAccess = *note.SharedAccess
+			}
+			if !api.checkSharingPolicy(c, userID, effectiveSharedAccess, sharedUntil.Time()) {
+				return
+			}
+		}
 		updatedNote := database.Note{
 			UserID:       userID,
 			Title:        modifyParams.NoteChangeable.Title,
@@ -93,11 +123,35 @@ func (api *API) NoteModify(c *gin.Context) {
 			SharedUntil:  sharedUntil,
 			SharedAccess: sharedAccess,
 			IsDeleted:    modifyParams.NoteChangeable.IsDeleted,
+			IsPinned:     modifyParams.NoteChangeable.IsPinned,
+			IsArchived:   modifyParams.NoteChangeable.IsArchived,
 			UpdatedAt:    primitive.NewDateTimeFromTime(time.Now()),
 			CreatedAt:    note.CreatedAt,
+			FolderID:     folderID,
+		}
+		if updatedNote.IsDeleted != nil && *updatedNote.IsDeleted {
+			updatedNote.DeletedAt = primitive.NewDateTimeFromTime(time.Now())
 		}
 
 		api.UpdateNoteInDB(c, note, userID, &updatedNote)
+		if modifyParams.FolderID != nil && *modifyParams.FolderID == "" {
+			_, err = database.GetNoteCollection(api.DB).UpdateOne(
+				context.Background(),
+				bson.M{"$and": []bson.M{
+					{"_id": note.ID},
+					{"user_id": userID},
+				}},
+				bson.M{"$unset": bson.M{"folder_id": ""}},
+			)
+			if err != nil {
+				api.Logger.Error().Err(err).Msg("failed to move note out of folder")
+				Handle500(c)
+				return
+			}
+		}
+		if modifyParams.NoteChangeable.SharedUntil != nil && modifyParams.NoteChangeable.SharedUntil.Time().After(time.Now()) {
+			api.recordAuditEvent(c, userID, database.AuditLog{EventType: constants.AuditEventSharedLinkCreated, ResourceID: note.ID})
+		}
 	}
 
 	c.JSON(200, gin.H{})
@@ -22,6 +22,7 @@ func TestNoteModifyEditFields(t *testing.T) {
 	defer dbCleanup()
 	userID := getUserIDFromAuthToken(t, db, authToken)
 	note1, err := database.GetOrCreateNote(
+		context.Background(),
 		db,
 		userID,
 		"123abc",
@@ -40,7 +41,7 @@ func TestNoteModifyEditFields(t *testing.T) {
 	UnauthorizedTest(t, "PATCH", "/notes/modify/123/", nil)
 	t.Run("InvalidNoteID", func(t *testing.T) {
 		response := ServeRequest(t, authToken, "PATCH", "/notes/modify/123/", nil, http.StatusNotFound, nil)
-		assert.Equal(t, "{\"detail\":\"not found\"}", string(response))
+		assert.Equal(t, "{\"detail\":\"not found\",\"code\":\"unauthorized\"}", string(response))
 	})
 	t.Run("Success", func(t *testing.T) {
 		response := ServeRequest(t, authToken, "PATCH", "/notes/modify/"+note1.ID.Hex()+"/",
@@ -58,9 +59,29 @@ func TestNoteModifyEditFields(t *testing.T) {
 		assert.Equal(t, *testutils.CreateDateTime("2020-04-20"), note.CreatedAt)
 		assert.Greater(t, note.UpdatedAt, *testutils.CreateDateTime("2020-04-20"))
 		assert.Equal(t, *testutils.CreateDateTime("9999-01-01"), note.SharedUntil)
+		assert.Greater(t, note.DeletedAt, *testutils.CreateDateTime("2020-04-20"))
 		assert.NotNil(t, note.IsDeleted)
 		if note.IsDeleted != nil {
 			assert.True(t, *note.IsDeleted)
 		}
 	})
+	t.Run("PinAndArchive", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", "/notes/modify/"+note1.ID.Hex()+"/",
+			bytes.NewBuffer([]byte(`{"is_pinned": true, "is_archived": true}`)), http.StatusOK, nil)
+
+		var note database.Note
+		err = database.GetNoteCollection(db).FindOne(context.Background(), bson.M{"_id": note1.ID}).Decode(&note)
+		assert.NoError(t, err)
+		assert.True(t, *note.IsPinned)
+		assert.True(t, *note.IsArchived)
+		// other fields are left alone
+		assert.Equal(t, "new title", *note.Title)
+
+		ServeRequest(t, authToken, "PATCH", "/notes/modify/"+note1.ID.Hex()+"/",
+			bytes.NewBuffer([]byte(`{"is_pinned": false}`)), http.StatusOK, nil)
+		err = database.GetNoteCollection(db).FindOne(context.Background(), bson.M{"_id": note1.ID}).Decode(&note)
+		assert.NoError(t, err)
+		assert.False(t, *note.IsPinned)
+		assert.True(t, *note.IsArchived)
+	})
 }
@@ -27,13 +27,13 @@ func (api *API) NotePreview(c *gin.Context) {
 
 	var note *database.Note
 	if userID != nil {
-		note, err = database.GetSharedNoteWithAuth(api.DB, noteID, *userID)
+		note, err = database.GetSharedNoteWithAuth(c.Request.Context(), api.DB, noteID, *userID)
 		if err != nil {
 			notFoundRedirect(c, noteIDHex)
 			return
 		}
 	} else {
-		note, err = database.GetSharedNote(api.DB, noteID)
+		note, err = database.GetSharedNote(c.Request.Context(), api.DB, noteID)
 		if err != nil {
 			notFoundRedirect(c, noteIDHex)
 			return
@@ -49,6 +49,7 @@ func (api *API) NotePreview(c *gin.Context) {
 	if note.Title != nil {
 		previewTitle = html.EscapeString(*note.Title)
 	}
+	previewAuthor := html.EscapeString(note.Author)
 	noteURL := getNoteURL(note.ID.Hex())
 	body := []byte(`
 <!DOCTYPE html>
@@ -60,11 +61,11 @@ func (api *API) NotePreview(c *gin.Context) {
 	<meta property="og:title" content="` + previewTitle + `" />
 	<meta name="twitter:title" content="` + previewTitle + `">
 
-	<meta content="Note shared by ` + note.Author + ` via General Task." property="og:description">
-	<meta content="Note shared by ` + note.Author + ` via General Task." property="twitter:description">
+	<meta content="Note shared by ` + previewAuthor + ` via General Task." property="og:description">
+	<meta content="Note shared by ` + previewAuthor + ` via General Task." property="twitter:description">
 
 	<meta property="og:type" content="website" />
-	<meta property="og:url" content="` + config.GetConfigValue("SERVER_URL") + "note/" + note.ID.Hex() + `/" />
+	<meta property="og:url" content="` + config.GetSettings().ServerURL + "note/" + note.ID.Hex() + `/" />
 </head>
 <body>
 </body>
@@ -87,5 +88,5 @@ func notFoundRedirect(c *gin.Context, noteIDHex string) {
 }
 
 func getNoteURL(noteIDHex string) string {
-	return config.GetConfigValue("HOME_URL") + "note/" + noteIDHex
+	return config.GetSettings().HomeURL + "note/" + noteIDHex
 }
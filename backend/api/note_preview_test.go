@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -23,6 +24,7 @@ func TestNotePreview(t *testing.T) {
 	defer dbCleanup()
 	userID := getUserIDFromAuthToken(t, db, authToken)
 	note1, err := database.GetOrCreateNote(
+		context.Background(),
 		db,
 		userID,
 		"123abc",
@@ -36,6 +38,7 @@ func TestNotePreview(t *testing.T) {
 	)
 	assert.NoError(t, err)
 	note2, err := database.GetOrCreateNote(
+		context.Background(),
 		db,
 		userID,
 		"123abcdef",
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/franchizzle/task-manager/backend/config"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/templating"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	NOTE_SLUG_MIN_LENGTH = 3
+	NOTE_SLUG_MAX_LENGTH = 80
+)
+
+// lowercase words separated by single hyphens, e.g. "q3-planning-summary"
+var noteSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+type NoteSlugParams struct {
+	Slug string `json:"slug" binding:"required"`
+}
+
+type NoteSlugResult struct {
+	Slug string `json:"slug"`
+	URL  string `json:"url"`
+}
+
+// NoteSlugClaim gives a publicly shared note a readable /p/ link. Claiming a new slug releases the
+// note's previous one
+func (api *API) NoteSlugClaim(c *gin.Context) {
+	noteID, err := primitive.ObjectIDFromHex(c.Param("note_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	var params NoteSlugParams
+	err = c.BindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	slug := strings.ToLower(strings.TrimSpace(params.Slug))
+	if len(slug) < NOTE_SLUG_MIN_LENGTH || len(slug) > NOTE_SLUG_MAX_LENGTH || !noteSlugPattern.MatchString(slug) {
+		HandleBadRequest(c, "'slug' must be 3 to 80 lowercase letters, numbers and single hyphens", "slug")
+		return
+	}
+	userID := getUserIDFromContext(c)
+	note, err := database.GetNote(c.Request.Context(), api.DB, noteID, userID)
+	if err != nil || (note.IsDeleted != nil && *note.IsDeleted) {
+		Handle404(c)
+		return
+	}
+	isPublic := note.SharedAccess == nil || *note.SharedAccess == database.SharedAccessPublic
+	if !isPublic || note.SharedUntil.Time().Before(api.GetCurrentTime()) {
+		HandleBadRequest(c, "only publicly shared notes can have a slug")
+		return
+	}
+
+	noteCollection := database.GetNoteCollection(api.DB)
+	count, err := noteCollection.CountDocuments(c.Request.Context(), bson.M{"$and": []bson.M{
+		{"slug": slug},
+		{"_id": bson.M{"$ne": note.ID}},
+	}})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to check note slug")
+		Handle500(c)
+		return
+	}
+	if count > 0 {
+		HandleBadRequest(c, "slug is already taken", "slug")
+		return
+	}
+	_, err = noteCollection.UpdateOne(
+		c.Request.Context(),
+		bson.M{"$and": []bson.M{{"_id": note.ID}, {"user_id": userID}}},
+		bson.M{"$set": bson.M{"slug": slug}},
+	)
+	// the unique index catches a slug claimed since the check above
+	if mongo.IsDuplicateKeyError(err) {
+		HandleBadRequest(c, "slug is already taken", "slug")
+		return
+	}
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update note slug")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, NoteSlugResult{Slug: slug, URL: getPublicNoteURL(slug)})
+}
+
+// NoteSlugRevoke releases the note's slug, so its /p/ link stops working and the slug can be claimed again
+func (api *API) NoteSlugRevoke(c *gin.Context) {
+	noteID, err := primitive.ObjectIDFromHex(c.Param("note_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	userID := getUserIDFromContext(c)
+	updateResult, err := database.GetNoteCollection(api.DB).UpdateOne(
+		c.Request.Context(),
+		bson.M{"$and": []bson.M{{"_id": noteID}, {"user_id": userID}}},
+		bson.M{"$unset": bson.M{"slug": ""}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to remove note slug")
+		Handle500(c)
+		return
+	}
+	if updateResult.MatchedCount == 0 {
+		Handle404(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+// PublicNote renders a public note as a page, with OpenGraph metadata for link previews. The link
+// stops working when the note is no longer shared publicly, but the slug stays claimed until revoked
+func (api *API) PublicNote(c *gin.Context) {
+	note, err := database.GetPublicNoteBySlug(c.Request.Context(), api.DB, c.Param("slug"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	body := ""
+	if note.Body != nil {
+		body = *note.Body
+	}
+	bodyHTML, err := templating.RenderMarkdown(body)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to render note body")
+		Handle500(c)
+		return
+	}
+	title := ""
+	if note.Title != nil {
+		title = *note.Title
+	}
+	page, err := templating.RenderPublicNote(templating.PublicNote{
+		Title:    title,
+		Author:   note.Author,
+		BodyHTML: bodyHTML,
+		URL:      getPublicNoteURL(note.Slug),
+	})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to render public note")
+		Handle500(c)
+		return
+	}
+	api.recordShareView(c, database.ShareView{UserID: note.UserID, NoteID: note.ID})
+	c.Data(200, "text/html; charset=utf-8", []byte(page))
+}
+
+func getPublicNoteURL(slug string) string {
+	return config.GetSettings().ServerURL + "p/" + slug + "/"
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/testutils"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNoteSlug(t *testing.T) {
+	authToken := login("test_note_slug@resonant-kelpie-404a42.netlify.app", "")
+	otherAuthToken := login("test_note_slug_other@resonant-kelpie-404a42.netlify.app", "")
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	assert.NoError(t, database.EnsureIndexes(context.Background(), db))
+	userID := getUserIDFromAuthToken(t, db, authToken)
+	otherUserID := getUserIDFromAuthToken(t, db, otherAuthToken)
+
+	title := "Planning sync"
+	body := "# Decisions\n\n- ship it"
+	insertNote := func(userID primitive.ObjectID, sharedUntil string, sharedAccess *database.SharedAccess) primitive.ObjectID {
+		result, err := database.GetNoteCollection(db).InsertOne(context.Background(), database.Note{
+			UserID:       userID,
+			Title:        &title,
+			Body:         &body,
+			Author:       "Jane",
+			SharedUntil:  *testutils.CreateDateTime(sharedUntil),
+			SharedAccess: sharedAccess,
+		})
+		assert.NoError(t, err)
+		return result.InsertedID.(primitive.ObjectID)
+	}
+	domain := database.SharedAccessDomain
+	noteID := insertNote(userID, "9999-01-01", nil)
+	otherNoteID := insertNote(otherUserID, "9999-01-01", nil)
+	expiredNoteID := insertNote(userID, "1999-01-01", nil)
+	domainNoteID := insertNote(userID, "9999-01-01", &domain)
+	claimSlug := func(authToken string, noteID primitive.ObjectID, slug string, expectedStatus int) []byte {
+		return ServeRequest(t, authToken, "PUT", "/notes/"+noteID.Hex()+"/slug/", bytes.NewBuffer([]byte(`{"slug": "`+slug+`"}`)), expectedStatus, nil)
+	}
+
+	UnauthorizedTest(t, "PUT", "/notes/"+noteID.Hex()+"/slug/", nil)
+	t.Run("InvalidSlug", func(t *testing.T) {
+		claimSlug(authToken, noteID, "ab", http.StatusBadRequest)
+		claimSlug(authToken, noteID, "two--hyphens", http.StatusBadRequest)
+		claimSlug(authToken, noteID, "-leading", http.StatusBadRequest)
+		claimSlug(authToken, noteID, "no spaces", http.StatusBadRequest)
+	})
+	t.Run("NotPublic", func(t *testing.T) {
+		claimSlug(authToken, expiredNoteID, "expired-note", http.StatusBadRequest)
+		claimSlug(authToken, domainNoteID, "domain-note", http.StatusBadRequest)
+	})
+	t.Run("OtherUsersNote", func(t *testing.T) {
+		claimSlug(authToken, otherNoteID, "not-mine", http.StatusNotFound)
+	})
+	t.Run("Success", func(t *testing.T) {
+		response := claimSlug(authToken, noteID, "Planning-Sync", http.StatusOK)
+		var result NoteSlugResult
+		assert.NoError(t, json.Unmarshal(response, &result))
+		assert.Equal(t, "planning-sync", result.Slug)
+		assert.Equal(t, getPublicNoteURL("planning-sync"), result.URL)
+
+		note, err := database.GetNote(context.Background(), db, noteID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, "planning-sync", note.Slug)
+	})
+	t.Run("Taken", func(t *testing.T) {
+		claimSlug(otherAuthToken, otherNoteID, "planning-sync", http.StatusBadRequest)
+		// claiming the note's own slug again is fine
+		claimSlug(authToken, noteID, "planning-sync", http.StatusOK)
+	})
+	t.Run("PublicNote", func(t *testing.T) {
+		response := ServeRequest(t, "", "GET", "/p/planning-sync/", nil, http.StatusOK, nil)
+		assert.Contains(t, string(response), `<meta property="og:title" content="Planning sync">`)
+		assert.Contains(t, string(response), `<meta property="og:description" content="Decisions - ship it">`)
+		assert.Contains(t, string(response), "<li>ship it</li>")
+
+		ServeRequest(t, "", "GET", "/p/not-a-slug/", nil, http.StatusNotFound, nil)
+	})
+	t.Run("PublicNoteNoLongerShared", func(t *testing.T) {
+		_, err := database.GetNoteCollection(db).UpdateOne(context.Background(), bson.M{"_id": noteID}, bson.M{"$set": bson.M{"shared_access": database.SharedAccessDomain}})
+		assert.NoError(t, err)
+		ServeRequest(t, "", "GET", "/p/planning-sync/", nil, http.StatusNotFound, nil)
+		_, err = database.GetNoteCollection(db).UpdateOne(context.Background(), bson.M{"_id": noteID}, bson.M{"$unset": bson.M{"shared_access": ""}})
+		assert.NoError(t, err)
+	})
+	t.Run("Revoke", func(t *testing.T) {
+		ServeRequest(t, otherAuthToken, "DELETE", "/notes/"+noteID.Hex()+"/slug/", nil, http.StatusNotFound, nil)
+		ServeRequest(t, authToken, "DELETE", "/notes/"+noteID.Hex()+"/slug/", nil, http.StatusOK, nil)
+		ServeRequest(t, "", "GET", "/p/planning-sync/", nil, http.StatusNotFound, nil)
+		// the slug is free again
+		claimSlug(otherAuthToken, otherNoteID, "planning-sync", http.StatusOK)
+	})
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (api *API) NotesTrashList(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	notes, err := database.GetDeletedNotes(c.Request.Context(), api.DB, userID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	c.JSON(200, api.noteListToNoteResultList(c.Request.Context(), notes))
+}
+
+func (api *API) NoteRestore(c *gin.Context) {
+	note, ok := api.getDeletedNoteFromParams(c)
+	if !ok {
+		return
+	}
+	_, err := database.GetNoteCollection(api.DB).UpdateOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"_id": note.ID},
+			{"user_id": note.UserID},
+		}},
+		bson.M{
+			"$set":   bson.M{"is_deleted": false, "updated_at": primitive.NewDateTimeFromTime(api.GetCurrentTime())},
+			"$unset": bson.M{"deleted_at": ""},
+		},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to restore note")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+func (api *API) NoteDeletePermanently(c *gin.Context) {
+	note, ok := api.getDeletedNoteFromParams(c)
+	if !ok {
+		return
+	}
+	_, err := database.GetNoteCollection(api.DB).DeleteOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"_id": note.ID},
+			{"user_id": note.UserID},
+		}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to permanently delete note")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+// getDeletedNoteFromParams writes the error response itself and returns false if the note can't be used
+func (api *API) getDeletedNoteFromParams(c *gin.Context) (*database.Note, bool) {
+	noteID, err := primitive.ObjectIDFromHex(c.Param("note_id"))
+	if err != nil {
+		Handle404(c)
+		return nil, false
+	}
+	userID := getUserIDFromContext(c)
+	note, err := database.GetNote(c.Request.Context(), api.DB, noteID, userID)
+	if err != nil {
+		HandleAPIError(c, NewAPIError(ErrorCodeNotFound, "note not found.").WithMetadata("noteId", noteID))
+		return nil, false
+	}
+	if note.IsDeleted == nil || !*note.IsDeleted {
+		HandleBadRequest(c, "note is not in the trash")
+		return nil, false
+	}
+	return note, true
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestNotesTrash(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+
+	authToken := login("test_notes_trash@resonant-kelpie-404a42.netlify.app", "")
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+
+	deleted := true
+	notDeleted := false
+	deletedTitle := "deleted note"
+	activeTitle := "active note"
+	createNote := func(title *string, isDeleted *bool, noteUserID primitive.ObjectID) primitive.ObjectID {
+		result, err := database.GetNoteCollection(api.DB).InsertOne(context.Background(), database.Note{
+			UserID:    noteUserID,
+			Title:     title,
+			IsDeleted: isDeleted,
+			DeletedAt: primitive.NewDateTimeFromTime(api.GetCurrentTime()),
+		})
+		assert.NoError(t, err)
+		return result.InsertedID.(primitive.ObjectID)
+	}
+	deletedNoteID := createNote(&deletedTitle, &deleted, userID)
+	activeNoteID := createNote(&activeTitle, &notDeleted, userID)
+	otherUserNoteID := createNote(&deletedTitle, &deleted, primitive.NewObjectID())
+
+	UnauthorizedTest(t, http.MethodGet, "/notes/trash/", nil)
+	t.Run("List", func(t *testing.T) {
+		body := ServeRequest(t, authToken, http.MethodGet, "/notes/trash/", nil, http.StatusOK, api)
+		var notes []NoteResult
+		assert.NoError(t, json.Unmarshal(body, &notes))
+		assert.Equal(t, 1, len(notes))
+		assert.Equal(t, deletedNoteID, notes[0].ID)
+		assert.True(t, notes[0].IsDeleted)
+		assert.NotEmpty(t, notes[0].DeletedAt)
+	})
+	t.Run("RestoreNotInTrash", func(t *testing.T) {
+		body := ServeRequest(t, authToken, http.MethodPost, "/notes/restore/"+activeNoteID.Hex()+"/", nil, http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"note is not in the trash","code":"bad_request"}`, string(body))
+	})
+	t.Run("RestoreOtherUser", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPost, "/notes/restore/"+otherUserNoteID.Hex()+"/", nil, http.StatusNotFound, api)
+	})
+	t.Run("DeleteNotInTrash", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodDelete, "/notes/delete/"+activeNoteID.Hex()+"/", nil, http.StatusBadRequest, api)
+	})
+	t.Run("Restore", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPost, "/notes/restore/"+deletedNoteID.Hex()+"/", nil, http.StatusOK, api)
+		note, err := database.GetNote(context.Background(), api.DB, deletedNoteID, userID)
+		assert.NoError(t, err)
+		assert.False(t, *note.IsDeleted)
+		assert.Equal(t, primitive.DateTime(0), note.DeletedAt)
+	})
+	t.Run("DeletePermanently", func(t *testing.T) {
+		_, err := database.GetNoteCollection(api.DB).UpdateOne(context.Background(), bson.M{"_id": deletedNoteID}, bson.M{"$set": bson.M{"is_deleted": true}})
+		assert.NoError(t, err)
+		ServeRequest(t, authToken, http.MethodDelete, "/notes/delete/"+deletedNoteID.Hex()+"/", nil, http.StatusOK, api)
+		err = database.GetNoteCollection(api.DB).FindOne(context.Background(), bson.M{"_id": deletedNoteID}).Err()
+		assert.Equal(t, mongo.ErrNoDocuments, err)
+	})
+}
@@ -0,0 +1,212 @@
+package api
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const OPENAPI_VERSION = "3.0.3"
+const V1_API_VERSION = "1.0.0"
+
+// v1Route describes one public endpoint. The same table registers the gin handler and generates
+// the OpenAPI document, so the two can't drift apart
+type v1Route struct {
+	Method  string
+	Path    string
+	Summary string
+	Tag     string
+	// Params is a struct whose form tags are the accepted query parameters
+	Params interface{}
+	// Response is a value of the type returned with a 200
+	Response interface{}
+	Handler  func(*API, *gin.Context)
+}
+
+type openAPISpecBuilder struct {
+	schemas gin.H
+}
+
+func buildOpenAPISpec(routes []v1Route) gin.H {
+	builder := openAPISpecBuilder{schemas: gin.H{}}
+	paths := gin.H{}
+	for _, route := range routes {
+		path := getOpenAPIPath(route.Path)
+		if _, exists := paths[path]; !exists {
+			paths[path] = gin.H{}
+		}
+		paths[path].(gin.H)[strings.ToLower(route.Method)] = builder.getOperation(route)
+	}
+	return gin.H{
+		"openapi": OPENAPI_VERSION,
+		"info": gin.H{
+			"title":       "General Task API",
+			"version":     V1_API_VERSION,
+			"description": "Endpoints under /v1/ keep their response contracts stable. Fields may be added, but existing fields won't be removed or change type",
+		},
+		"paths": paths,
+		"components": gin.H{
+			"schemas": builder.schemas,
+			"securitySchemes": gin.H{
+				"bearerAuth": gin.H{"type": "http", "scheme": "bearer"},
+			},
+		},
+		"security": []gin.H{{"bearerAuth": []string{}}},
+	}
+}
+
+// getOpenAPIPath converts gin path params (/tasks/:task_id/) to OpenAPI ones (/tasks/{task_id}/)
+func getOpenAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func (builder *openAPISpecBuilder) getOperation(route v1Route) gin.H {
+	parameters := []gin.H{}
+	for _, segment := range strings.Split(route.Path, "/") {
+		if strings.HasPrefix(segment, ":") {
+			parameters = append(parameters, gin.H{
+				"name":     segment[1:],
+				"in":       "path",
+				"required": true,
+				"schema":   gin.H{"type": "string"},
+			})
+		}
+	}
+	if route.Params != nil {
+		parameters = append(parameters, builder.getQueryParameters(reflect.TypeOf(route.Params))...)
+	}
+	errorContent := gin.H{
+		"application/json": gin.H{"schema": builder.getSchema(reflect.TypeOf(ErrorResponse{}))},
+	}
+	return gin.H{
+		"summary":    route.Summary,
+		"tags":       []string{route.Tag},
+		"parameters": parameters,
+		"responses": gin.H{
+			"200": gin.H{
+				"description": "success",
+				"content": gin.H{
+					"application/json": gin.H{"schema": builder.getSchema(reflect.TypeOf(route.Response))},
+				},
+			},
+			"400": gin.H{"description": "invalid parameters", "content": errorContent},
+			"401": gin.H{"description": "missing or invalid auth token", "content": errorContent},
+			"404": gin.H{"description": "not found", "content": errorContent},
+		},
+	}
+}
+
+func (builder *openAPISpecBuilder) getQueryParameters(paramsType reflect.Type) []gin.H {
+	parameters := []gin.H{}
+	for i := 0; i < paramsType.NumField(); i++ {
+		field := paramsType.Field(i)
+		if field.Anonymous {
+			parameters = append(parameters, builder.getQueryParameters(field.Type)...)
+			continue
+		}
+		name := strings.Split(field.Tag.Get("form"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		parameter := gin.H{
+			"name":     name,
+			"in":       "query",
+			"required": field.Tag.Get("binding") == "required",
+			"schema":   builder.getSchema(field.Type),
+		}
+		if description := field.Tag.Get("doc"); description != "" {
+			parameter["description"] = description
+		}
+		parameters = append(parameters, parameter)
+	}
+	return parameters
+}
+
+// getSchema builds a schema from json tags. Named structs are added to components and referenced,
+// generic ones like PaginatedResult[T] are inlined since their names aren't valid component keys
+func (builder *openAPISpecBuilder) getSchema(schemaType reflect.Type) gin.H {
+	for schemaType.Kind() == reflect.Pointer {
+		schemaType = schemaType.Elem()
+	}
+	switch schemaType {
+	case reflect.TypeOf(time.Time{}):
+		return gin.H{"type": "string", "format": "date-time"}
+	case reflect.TypeOf(primitive.ObjectID{}):
+		return gin.H{"type": "string"}
+	}
+	switch schemaType.Kind() {
+	case reflect.String:
+		return gin.H{"type": "string"}
+	case reflect.Bool:
+		return gin.H{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return gin.H{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return gin.H{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return gin.H{"type": "array", "items": builder.getSchema(schemaType.Elem())}
+	case reflect.Map:
+		return gin.H{"type": "object", "additionalProperties": builder.getSchema(schemaType.Elem())}
+	case reflect.Struct:
+		name := schemaType.Name()
+		if name == "" || strings.Contains(name, "[") {
+			return builder.getObjectSchema(schemaType)
+		}
+		if _, exists := builder.schemas[name]; !exists {
+			// reserve the name first so self-referencing types don't recurse forever
+			builder.schemas[name] = gin.H{}
+			builder.schemas[name] = builder.getObjectSchema(schemaType)
+		}
+		return gin.H{"$ref": "#/components/schemas/" + name}
+	}
+	return gin.H{}
+}
+
+func (builder *openAPISpecBuilder) getObjectSchema(structType reflect.Type) gin.H {
+	properties := gin.H{}
+	required := []string{}
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tagParts := strings.Split(field.Tag.Get("json"), ",")
+		name := tagParts[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		property := builder.getSchema(field.Type)
+		if description := field.Tag.Get("doc"); description != "" {
+			if _, isRef := property["$ref"]; isRef {
+				// siblings of $ref are ignored in OpenAPI 3.0, so wrap it
+				property = gin.H{"allOf": []gin.H{property}}
+			}
+			property["description"] = description
+		}
+		properties[name] = property
+		isOmitEmpty := false
+		for _, option := range tagParts[1:] {
+			isOmitEmpty = isOmitEmpty || option == "omitempty"
+		}
+		if !isOmitEmpty && field.Type.Kind() != reflect.Pointer {
+			required = append(required, name)
+		}
+	}
+	schema := gin.H{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
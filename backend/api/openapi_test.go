@@ -0,0 +1,34 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOpenAPIPath(t *testing.T) {
+	assert.Equal(t, "/v1/tasks/", getOpenAPIPath("/v1/tasks/"))
+	assert.Equal(t, "/v1/tasks/{task_id}/", getOpenAPIPath("/v1/tasks/:task_id/"))
+}
+
+func TestOpenAPISchema(t *testing.T) {
+	spec := buildOpenAPISpec(v1Routes)
+	schemas := spec["components"].(gin.H)["schemas"].(gin.H)
+	taskSchema := schemas["V1Task"].(gin.H)
+	properties := taskSchema["properties"].(gin.H)
+	assert.Equal(t, gin.H{"type": "string", "format": "date-time"}, properties["due_date"])
+	assert.Contains(t, taskSchema["required"], "title")
+	assert.NotContains(t, taskSchema["required"], "due_date")
+
+	eventsOperation := spec["paths"].(gin.H)["/v1/events/"].(gin.H)["get"].(gin.H)
+	parameterNames := []string{}
+	for _, parameter := range eventsOperation["parameters"].([]gin.H) {
+		parameterNames = append(parameterNames, parameter["name"].(string))
+	}
+	assert.Equal(t, []string{"limit", "cursor", "datetime_start", "datetime_end"}, parameterNames)
+
+	errorSchema := schemas["ErrorResponse"].(gin.H)
+	assert.Equal(t, []string{"detail", "code"}, errorSchema["required"])
+	assert.Equal(t, gin.H{"$ref": "#/components/schemas/ErrorResponse"}, eventsOperation["responses"].(gin.H)["400"].(gin.H)["content"].(gin.H)["application/json"].(gin.H)["schema"])
+}
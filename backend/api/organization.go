@@ -0,0 +1,186 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/utils"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type OrganizationSharingPolicyResult struct {
+	Domain               string `json:"domain"`
+	DisablePublicSharing bool   `json:"disable_public_sharing"`
+	RequireDomainSharing bool   `json:"require_domain_sharing"`
+	MaxSharedDays        int    `json:"max_shared_days"`
+	IsAdmin              bool   `json:"is_admin"`
+}
+
+type OrganizationSharingPolicyModifyParams struct {
+	DisablePublicSharing *bool `json:"disable_public_sharing"`
+	RequireDomainSharing *bool `json:"require_domain_sharing"`
+	MaxSharedDays        *int  `json:"max_shared_days"`
+}
+
+// OrganizationSharingPolicyGet returns the sharing policy for the user's email domain, which is
+// empty until an admin sets one
+func (api *API) OrganizationSharingPolicyGet(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	domain, err := api.getUserEmailDomain(c.Request.Context(), userID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	organization, err := database.GetOrganizationByDomain(c.Request.Context(), api.DB, domain)
+	if err != nil && err != mongo.ErrNoDocuments {
+		Handle500(c)
+		return
+	}
+	if organization == nil {
+		organization = &database.Organization{Domain: domain}
+	}
+	c.JSON(200, getOrganizationSharingPolicyResult(organization, userID))
+}
+
+// OrganizationSharingPolicyModify updates the sharing policy for the user's email domain. The
+// first user to set a policy for their domain becomes the organization's admin
+func (api *API) OrganizationSharingPolicyModify(c *gin.Context) {
+	var params OrganizationSharingPolicyModifyParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	if params.MaxSharedDays != nil && *params.MaxSharedDays < 0 {
+		HandleBadRequest(c, "'max_shared_days' cannot be negative", "max_shared_days")
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	domain, err := api.getUserEmailDomain(c.Request.Context(), userID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	if utils.IsOpenEmailAddress(domain) {
+		HandleBadRequest(c, "sharing policies can't be set for personal email domains")
+		return
+	}
+	organization, err := database.GetOrganizationByDomain(c.Request.Context(), api.DB, domain)
+	if err != nil && err != mongo.ErrNoDocuments {
+		Handle500(c)
+		return
+	}
+	if organization != nil && !isOrganizationAdmin(organization, userID) {
+		HandleError(c, ErrorCodeForbidden, "only organization admins can change the sharing policy")
+		return
+	}
+
+	now := primitive.NewDateTimeFromTime(api.GetCurrentTime())
+	updateFields := bson.M{"updated_at": now}
+	if params.DisablePublicSharing != nil {
+		updateFields["disable_public_sharing"] = *params.DisablePublicSharing
+	}
+	if params.RequireDomainSharing != nil {
+		updateFields["require_domain_sharing"] = *params.RequireDomainSharing
+	}
+	if params.MaxSharedDays != nil {
+		updateFields["max_shared_days"] = *params.MaxSharedDays
+	}
+	var updatedOrganization database.Organization
+	err = database.GetOrganizationCollection(api.DB).FindOneAndUpdate(
+		c.Request.Context(),
+		bson.M{"domain": domain},
+		bson.M{
+			"$set": updateFields,
+			"$setOnInsert": bson.M{
+				"admin_user_ids": []primitive.ObjectID{userID},
+				"created_at":     now,
+			},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&updatedOrganization)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update organization sharing policy")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, getOrganizationSharingPolicyResult(&updatedOrganization, userID))
+}
+
+func getOrganizationSharingPolicyResult(organization *database.Organization, userID primitive.ObjectID) OrganizationSharingPolicyResult {
+	return OrganizationSharingPolicyResult{
+		Domain:               organization.Domain,
+		DisablePublicSharing: organization.DisablePublicSharing,
+		RequireDomainSharing: organization.RequireDomainSharing,
+		MaxSharedDays:        organization.MaxSharedDays,
+		IsAdmin:              isOrganizationAdmin(organization, userID),
+	}
+}
+
+func isOrganizationAdmin(organization *database.Organization, userID primitive.ObjectID) bool {
+	for _, adminUserID := range organization.AdminUserIDs {
+		if adminUserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func (api *API) getUserEmailDomain(ctx context.Context, userID primitive.ObjectID) (string, error) {
+	user, err := database.GetUser(ctx, api.DB, userID)
+	if err != nil {
+		return "", err
+	}
+	return database.GetEmailDomain(user.Email)
+}
+
+// checkSharingPolicy writes a 400 response and returns false when the policy of the user's
+// organization doesn't allow sharing with the access level until sharedUntil
+func (api *API) checkSharingPolicy(c *gin.Context, userID primitive.ObjectID, sharedAccess database.SharedAccess, sharedUntil time.Time) bool {
+	if !sharedUntil.After(api.GetCurrentTime()) {
+		return true
+	}
+	domain, err := api.getUserEmailDomain(c.Request.Context(), userID)
+	if err != nil {
+		Handle500(c)
+		return false
+	}
+	organization, err := database.GetOrganizationByDomain(c.Request.Context(), api.DB, domain)
+	if err == mongo.ErrNoDocuments {
+		return true
+	} else if err != nil {
+		Handle500(c)
+		return false
+	}
+	violation := getSharingPolicyViolation(organization, sharedAccess, sharedUntil, api.GetCurrentTime())
+	if violation != "" {
+		HandleBadRequest(c, violation)
+		return false
+	}
+	return true
+}
+
+// getSharingPolicyViolation returns why the organization doesn't allow sharing with the access level
+// until sharedUntil, or "" if it's allowed. Links that have already expired aren't shared at all
+func getSharingPolicyViolation(organization *database.Organization, sharedAccess database.SharedAccess, sharedUntil time.Time, now time.Time) string {
+	if !sharedUntil.After(now) {
+		return ""
+	}
+	if organization.RequireDomainSharing && sharedAccess != database.SharedAccessDomain {
+		return "your organization only allows sharing with your domain"
+	}
+	if organization.DisablePublicSharing && sharedAccess == database.SharedAccessPublic {
+		return "your organization doesn't allow public sharing"
+	}
+	if organization.MaxSharedDays > 0 && sharedUntil.After(now.AddDate(0, 0, organization.MaxSharedDays)) {
+		return fmt.Sprintf("your organization only allows sharing for up to %d days", organization.MaxSharedDays)
+	}
+	return ""
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGetSharingPolicyViolation(t *testing.T) {
+	now := time.Date(2023, time.March, 1, 9, 0, 0, 0, time.UTC)
+	nextWeek := now.AddDate(0, 0, 7)
+
+	t.Run("NoPolicy", func(t *testing.T) {
+		assert.Equal(t, "", getSharingPolicyViolation(&database.Organization{}, database.SharedAccessPublic, nextWeek, now))
+	})
+	t.Run("NotShared", func(t *testing.T) {
+		organization := &database.Organization{RequireDomainSharing: true}
+		assert.Equal(t, "", getSharingPolicyViolation(organization, database.SharedAccessPublic, now.Add(-time.Hour), now))
+	})
+	t.Run("DisablePublicSharing", func(t *testing.T) {
+		organization := &database.Organization{DisablePublicSharing: true}
+		assert.Equal(t, "your organization doesn't allow public sharing", getSharingPolicyViolation(organization, database.SharedAccessPublic, nextWeek, now))
+		assert.Equal(t, "", getSharingPolicyViolation(organization, database.SharedAccessDomain, nextWeek, now))
+		assert.Equal(t, "", getSharingPolicyViolation(organization, database.SharedAccessMeetingAttendees, nextWeek, now))
+	})
+	t.Run("RequireDomainSharing", func(t *testing.T) {
+		organization := &database.Organization{RequireDomainSharing: true}
+		assert.Equal(t, "your organization only allows sharing with your domain", getSharingPolicyViolation(organization, database.SharedAccessPublic, nextWeek, now))
+		assert.Equal(t, "your organization only allows sharing with your domain", getSharingPolicyViolation(organization, database.SharedAccessMeetingAttendees, nextWeek, now))
+		assert.Equal(t, "", getSharingPolicyViolation(organization, database.SharedAccessDomain, nextWeek, now))
+	})
+	t.Run("MaxSharedDays", func(t *testing.T) {
+		organization := &database.Organization{MaxSharedDays: 7}
+		assert.Equal(t, "", getSharingPolicyViolation(organization, database.SharedAccessPublic, nextWeek, now))
+		assert.Equal(t, "your organization only allows sharing for up to 7 days", getSharingPolicyViolation(organization, database.SharedAccessPublic, nextWeek.Add(time.Minute), now))
+	})
+}
+
+func TestOrganizationSharingPolicy(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+
+	adminToken := login("test_organization_admin@sharing-policy.com", "")
+	memberToken := login("test_organization_member@sharing-policy.com", "")
+	personalToken := login("test_organization_policy@gmail.com", "")
+	memberID := getUserIDFromAuthToken(t, api.DB, memberToken)
+
+	UnauthorizedTest(t, http.MethodGet, "/organization/sharing_policy/", nil)
+	t.Run("NoPolicy", func(t *testing.T) {
+		body := ServeRequest(t, memberToken, http.MethodGet, "/organization/sharing_policy/", nil, http.StatusOK, api)
+		assert.Equal(t, `{"domain":"sharing-policy.com","disable_public_sharing":false,"require_domain_sharing":false,"max_shared_days":0,"is_admin":false}`, string(body))
+	})
+	t.Run("NegativeMaxSharedDays", func(t *testing.T) {
+		body := ServeRequest(t, adminToken, http.MethodPatch, "/organization/sharing_policy/", bytes.NewBuffer([]byte(`{"max_shared_days": -1}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"'max_shared_days' cannot be negative","code":"invalid_parameter","field_errors":[{"field":"max_shared_days"}]}`, string(body))
+	})
+	t.Run("PersonalDomain", func(t *testing.T) {
+		ServeRequest(t, personalToken, http.MethodPatch, "/organization/sharing_policy/", bytes.NewBuffer([]byte(`{"disable_public_sharing": true}`)), http.StatusBadRequest, api)
+	})
+	t.Run("FirstUserBecomesAdmin", func(t *testing.T) {
+		body := ServeRequest(t, adminToken, http.MethodPatch, "/organization/sharing_policy/", bytes.NewBuffer([]byte(`{"disable_public_sharing": true, "max_shared_days": 7}`)), http.StatusOK, api)
+		var result OrganizationSharingPolicyResult
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.True(t, result.IsAdmin)
+		assert.True(t, result.DisablePublicSharing)
+		assert.Equal(t, 7, result.MaxSharedDays)
+	})
+	t.Run("NotAdmin", func(t *testing.T) {
+		body := ServeRequest(t, memberToken, http.MethodPatch, "/organization/sharing_policy/", bytes.NewBuffer([]byte(`{"disable_public_sharing": false}`)), http.StatusForbidden, api)
+		assert.Equal(t, `{"detail":"only organization admins can change the sharing policy","code":"forbidden"}`, string(body))
+	})
+
+	insertResult, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), database.Task{UserID: memberID, SourceID: external.TASK_SOURCE_ID_GT_TASK})
+	assert.NoError(t, err)
+	taskID := insertResult.InsertedID.(primitive.ObjectID)
+	modifyURL := fmt.Sprintf("/tasks/modify/%s/", taskID.Hex())
+	sharedUntil := func(days int) string {
+		return time.Now().AddDate(0, 0, days).UTC().Format(time.RFC3339)
+	}
+	t.Run("TaskPublicSharing", func(t *testing.T) {
+		body := ServeRequest(t, memberToken, http.MethodPatch, modifyURL, bytes.NewBuffer([]byte(fmt.Sprintf(`{"shared_access": "public", "shared_until": "%s"}`, sharedUntil(1)))), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"your organization doesn't allow public sharing","code":"bad_request"}`, string(body))
+	})
+	t.Run("TaskSharedTooLong", func(t *testing.T) {
+		body := ServeRequest(t, memberToken, http.MethodPatch, modifyURL, bytes.NewBuffer([]byte(fmt.Sprintf(`{"shared_access": "domain", "shared_until": "%s"}`, sharedUntil(30)))), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"your organization only allows sharing for up to 7 days","code":"bad_request"}`, string(body))
+	})
+	t.Run("TaskDomainSharing", func(t *testing.T) {
+		ServeRequest(t, memberToken, http.MethodPatch, modifyURL, bytes.NewBuffer([]byte(fmt.Sprintf(`{"shared_access": "domain", "shared_until": "%s"}`, sharedUntil(1)))), http.StatusOK, api)
+	})
+	t.Run("TaskShareLink", func(t *testing.T) {
+		body := ServeRequest(t, memberToken, http.MethodPost, fmt.Sprintf("/tasks/%s/shares/", taskID.Hex()), bytes.NewBuffer([]byte(`{}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"your organization doesn't allow public sharing","code":"bad_request"}`, string(body))
+	})
+	t.Run("NotePublicSharing", func(t *testing.T) {
+		body := ServeRequest(t, memberToken, http.MethodPost, "/notes/create/", bytes.NewBuffer([]byte(fmt.Sprintf(`{"title": "notes", "shared_until": "%s"}`, sharedUntil(1)))), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"your organization doesn't allow public sharing","code":"bad_request"}`, string(body))
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"golang.org/x/exp/slices"
@@ -14,11 +15,15 @@ import (
 	"github.com/franchizzle/task-manager/backend/database"
 	"github.com/franchizzle/task-manager/backend/external"
 	"github.com/franchizzle/task-manager/backend/logging"
+	"github.com/franchizzle/task-manager/backend/meetingprep"
+	"github.com/franchizzle/task-manager/backend/ordering"
+	"github.com/franchizzle/task-manager/backend/settings"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type SourcesResult struct {
@@ -32,18 +37,22 @@ type ViewItem interface {
 }
 
 type OverviewResult[T ViewItem] struct {
-	ID                     primitive.ObjectID `json:"id"`
-	Name                   string             `json:"name"`
-	Type                   constants.ViewType `json:"type"`
-	Logo                   string             `json:"logo"`
-	IsLinked               bool               `json:"is_linked"`
-	Sources                []SourcesResult    `json:"sources"`
-	TaskSectionID          primitive.ObjectID `json:"task_section_id"`
-	IsReorderable          bool               `json:"is_reorderable"`
-	IDOrdering             int                `json:"ordering_id"`
-	ViewItems              []*T               `json:"view_items"`
-	ViewItemIDs            []string           `json:"view_item_ids"`
-	HasTasksCompletedToday bool               `json:"has_tasks_completed_today"`
+	ID                     primitive.ObjectID      `json:"id"`
+	Name                   string                  `json:"name"`
+	Type                   constants.ViewType      `json:"type"`
+	Logo                   string                  `json:"logo"`
+	IsLinked               bool                    `json:"is_linked"`
+	Sources                []SourcesResult         `json:"sources"`
+	TaskSectionID          primitive.ObjectID      `json:"task_section_id"`
+	IsReorderable          bool                    `json:"is_reorderable"`
+	IDOrdering             int                     `json:"ordering_id"`
+	ViewItems              []*T                    `json:"view_items"`
+	ViewItemIDs            []string                `json:"view_item_ids"`
+	HasTasksCompletedToday bool                    `json:"has_tasks_completed_today"`
+	LinearCycleFilter      string                  `json:"linear_cycle_filter,omitempty"`
+	LinearSortBy           string                  `json:"linear_sort_by,omitempty"`
+	CustomFilter           *CustomViewFilterParams `json:"filter,omitempty"`
+	LastFetched            string                  `json:"last_fetched,omitempty"`
 }
 
 type SupportedViewItem struct {
@@ -81,23 +90,23 @@ func GetBooleanQueryParameter(c *gin.Context, key string) (bool, error) {
 func (api *API) OverviewViewsList(c *gin.Context) {
 	showMovedOrDeleted, err := GetBooleanQueryParameter(c, constants.ShowMovedOrDeleted)
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		HandleBadRequest(c, err.Error())
 		return
 	}
 	ignoreMeetingPreparation, err := GetBooleanQueryParameter(c, constants.IgnoreMeetingPreparation)
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		HandleBadRequest(c, err.Error())
 		return
 	}
 
-	timezoneOffset, err := GetTimezoneOffsetFromHeader(c)
+	timezoneOffset, err := api.getTimezoneOffset(c)
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		HandleBadRequest(c, err.Error())
 		return
 	}
 
 	userID := getUserIDFromContext(c)
-	_, err = database.GetUser(api.DB, userID)
+	_, err = database.GetUser(c.Request.Context(), api.DB, userID)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to find user")
 		Handle500(c)
@@ -121,6 +130,12 @@ func (api *API) OverviewViewsList(c *gin.Context) {
 		Handle500(c)
 		return
 	}
+	sort.SliceStable(views, func(i, j int) bool {
+		return ordering.Less(views[i].OrderingKey, views[i].IDOrdering, views[j].OrderingKey, views[j].IDOrdering)
+	})
+	for idx := range views {
+		views[idx].IDOrdering = idx + 1
+	}
 	err = api.UpdateViewsLinkedStatus(&views, userID)
 	if err != nil {
 		log.Print("failed to get views")
@@ -130,7 +145,7 @@ func (api *API) OverviewViewsList(c *gin.Context) {
 		return
 	}
 
-	result, err := api.GetOverviewResults(views, userID, timezoneOffset, showMovedOrDeleted, ignoreMeetingPreparation)
+	result, err := api.GetOverviewResults(c.Request.Context(), views, userID, timezoneOffset, showMovedOrDeleted, ignoreMeetingPreparation)
 	if err != nil {
 		log.Print("failed to get results")
 		api.Logger.Error().Err(err).Msg("failed to load views")
@@ -140,29 +155,39 @@ func (api *API) OverviewViewsList(c *gin.Context) {
 	sort.SliceStable(result, func(i, j int) bool {
 		return result[i].GetOrderingID() < result[j].GetOrderingID()
 	})
-	c.JSON(200, result)
+	jsonWithETag(c, result)
 }
 
-func (api *API) GetOverviewResults(views []database.View, userID primitive.ObjectID, timezoneOffset time.Duration, showMovedOrDeleted bool, ignoreMeetingPreparation bool) ([]OrderingIDGetter, error) {
+func (api *API) GetOverviewResults(ctx context.Context, views []database.View, userID primitive.ObjectID, timezoneOffset time.Duration, showMovedOrDeleted bool, ignoreMeetingPreparation bool) ([]OrderingIDGetter, error) {
 	result := []OrderingIDGetter{}
 	for _, view := range views {
 		var singleOverviewResult OrderingIDGetter
 		var err error
 		switch view.Type {
 		case string(constants.ViewTaskSection):
-			singleOverviewResult, err = api.GetTaskSectionOverviewResult(view, userID, timezoneOffset)
+			singleOverviewResult, err = api.GetTaskSectionOverviewResult(ctx, view, userID, timezoneOffset)
 		case string(constants.ViewJira):
-			singleOverviewResult, err = api.GetJiraOverviewResult(view, userID, timezoneOffset)
+			singleOverviewResult, err = api.GetJiraOverviewResult(ctx, view, userID, timezoneOffset)
 		case string(constants.ViewLinear):
-			singleOverviewResult, err = api.GetLinearOverviewResult(view, userID, timezoneOffset)
+			singleOverviewResult, err = api.GetLinearOverviewResult(ctx, view, userID, timezoneOffset)
 		case string(constants.ViewSlack):
-			singleOverviewResult, err = api.GetSlackOverviewResult(view, userID, timezoneOffset)
+			singleOverviewResult, err = api.GetSlackOverviewResult(ctx, view, userID, timezoneOffset)
 		case string(constants.ViewGithub):
-			singleOverviewResult, err = api.GetGithubOverviewResult(view, userID, timezoneOffset)
+			singleOverviewResult, err = api.GetGithubOverviewResult(ctx, view, userID, timezoneOffset)
 		case string(constants.ViewMeetingPreparation):
-			singleOverviewResult, err = api.GetMeetingPreparationOverviewResult(view, userID, timezoneOffset, showMovedOrDeleted, ignoreMeetingPreparation)
+			singleOverviewResult, err = api.GetMeetingPreparationOverviewResult(ctx, view, userID, timezoneOffset, showMovedOrDeleted, ignoreMeetingPreparation)
 		case string(constants.ViewDueToday):
-			singleOverviewResult, err = api.GetDueTodayOverviewResult(view, userID, timezoneOffset)
+			singleOverviewResult, err = api.GetDueTodayOverviewResult(ctx, view, userID, timezoneOffset)
+		case string(constants.ViewOverdue):
+			singleOverviewResult, err = api.GetOverdueOverviewResult(ctx, view, userID, timezoneOffset)
+		case string(constants.ViewAssignedToMe):
+			singleOverviewResult, err = api.GetAssignedToMeOverviewResult(ctx, view, userID, timezoneOffset)
+		case string(constants.ViewWaitingOnOthers):
+			singleOverviewResult, err = api.GetWaitingOnOthersOverviewResult(ctx, view, userID, timezoneOffset)
+		case string(constants.ViewJiraJQL):
+			singleOverviewResult, err = api.GetJiraJQLOverviewResult(ctx, view, userID)
+		case string(constants.ViewCustom):
+			singleOverviewResult, err = api.GetCustomOverviewResult(ctx, view, userID, timezoneOffset)
 		default:
 			err = errors.New("invalid view type")
 		}
@@ -171,37 +196,55 @@ func (api *API) GetOverviewResults(views []database.View, userID primitive.Objec
 		}
 		if singleOverviewResult != nil {
 			// allow for the case of removing an obsolete view without an error
+			setOverviewResultLastFetched(singleOverviewResult, view.LastFetched)
 			result = append(result, singleOverviewResult)
 		}
 	}
 	return result, nil
 }
 
-func (api *API) GetTaskSectionOverviewResult(view database.View, userID primitive.ObjectID, timezoneOffset time.Duration) (*OverviewResult[TaskResult], error) {
+// setOverviewResultLastFetched copies over when the view's source was last fetched, if it ever was
+func setOverviewResultLastFetched(result OrderingIDGetter, lastFetched primitive.DateTime) {
+	if lastFetched == 0 {
+		return
+	}
+	formattedLastFetched := lastFetched.Time().UTC().Format(time.RFC3339)
+	switch overviewResult := result.(type) {
+	case *OverviewResult[TaskResult]:
+		if overviewResult != nil {
+			overviewResult.LastFetched = formattedLastFetched
+		}
+	case *OverviewResult[PullRequestResult]:
+		if overviewResult != nil {
+			overviewResult.LastFetched = formattedLastFetched
+		}
+	}
+}
+
+func (api *API) GetTaskSectionOverviewResult(ctx context.Context, view database.View, userID primitive.ObjectID, timezoneOffset time.Duration) (*OverviewResult[TaskResult], error) {
 	if view.UserID != userID {
 		return nil, errors.New("invalid user")
 	}
-	name, err := database.GetTaskSectionName(api.DB, view.TaskSectionID, userID)
+	name, err := database.GetTaskSectionName(ctx, api.DB, view.TaskSectionID, userID)
 	if err != nil {
 		if err != mongo.ErrNoDocuments {
 			return nil, err
 		}
-		_, err = database.GetViewCollection(api.DB).DeleteOne(context.Background(), bson.M{"_id": view.ID})
+		_, err = database.GetViewCollection(api.DB).DeleteOne(ctx, bson.M{"_id": view.ID})
 		return nil, err
 	}
 
-	tasks, err := database.GetTasks(api.DB, userID, &[]bson.M{
+	tasks, err := database.GetTasks(ctx, api.DB, userID, &[]bson.M{
 		{"is_deleted": bson.M{"$ne": true}},
 		{"id_task_section": view.TaskSectionID},
 	}, nil)
 	if err != nil {
 		return nil, err
 	}
-	sort.Slice(*tasks, func(i, j int) bool {
-		return (*tasks)[i].IDOrdering < (*tasks)[j].IDOrdering
+	sort.SliceStable(*tasks, func(i, j int) bool {
+		return ordering.Less((*tasks)[i].OrderingKey, (*tasks)[i].IDOrdering, (*tasks)[j].OrderingKey, (*tasks)[j].IDOrdering)
 	})
 
-	// Reset ID orderings to begin at 1
 	taskResults := api.taskListToTaskResultList(tasks, userID)
 	usableTaskResults := []*TaskResult{}
 	completedTaskResults := []*TaskResult{}
@@ -213,26 +256,8 @@ func (api *API) GetTaskSectionOverviewResult(view database.View, userID primitiv
 		}
 	}
 
-	taskCollection := database.GetTaskCollection(api.DB)
-	orderingID := 1
-	for _, task := range usableTaskResults {
-		if task.IDOrdering != orderingID {
-			task.IDOrdering = orderingID
-			res, err := taskCollection.UpdateOne(
-				context.Background(),
-				bson.M{"_id": task.ID},
-				bson.M{"$set": bson.M{"id_ordering": task.IDOrdering}},
-			)
-			if err != nil {
-				return nil, err
-			}
-			if res.MatchedCount != 1 {
-				api.Logger.Error().Interface("taskResult", task).Msgf("did not find task to update ordering ID (ID=%v)", task.ID)
-				return nil, errors.New("failed to update task ordering")
-			}
-		}
-		orderingID++
-	}
+	// ordering IDs are positions in the section, starting at 1
+	setOrderingIDs(usableTaskResults)
 
 	timeNow := api.GetCurrentLocalizedTime(timezoneOffset)
 	timeStartOfDay := time.Date(timeNow.Year(), timeNow.Month(), timeNow.Day(), 0, 0, 0, 0, time.FixedZone("", 0))
@@ -316,19 +341,9 @@ func (api *API) UpdateViewsLinkedStatus(views *[]database.View, userID primitive
 		if view.UserID != userID {
 			return errors.New("invalid user")
 		}
-		var serviceID string
-		if view.Type == string(constants.ViewTaskSection) || view.Type == string(constants.ViewMeetingPreparation) || view.Type == string(constants.ViewDueToday) {
-			serviceID = external.TaskServiceGeneralTask.ID
-		} else if view.Type == string(constants.ViewJira) {
-			serviceID = external.TaskServiceAtlassian.ID
-		} else if view.Type == string(constants.ViewLinear) {
-			serviceID = external.TaskServiceLinear.ID
-		} else if view.Type == string(constants.ViewSlack) {
-			serviceID = external.TaskServiceSlack.ID
-		} else if view.Type == string(constants.ViewGithub) {
-			serviceID = external.TaskServiceGithub.ID
-		} else {
-			return errors.New("invalid view type")
+		serviceID, err := getViewServiceID(view.Type)
+		if err != nil {
+			return err
 		}
 		isLinked, err := api.IsServiceLinked(api.DB, userID, serviceID)
 		if err != nil {
@@ -352,7 +367,24 @@ func (api *API) UpdateViewsLinkedStatus(views *[]database.View, userID primitive
 	return nil
 }
 
-func (api *API) GetJiraOverviewResult(view database.View, userID primitive.ObjectID, timezoneOffset time.Duration) (*OverviewResult[TaskResult], error) {
+// getViewServiceID returns the task service whose items back views of the type
+func getViewServiceID(viewType string) (string, error) {
+	switch viewType {
+	case string(constants.ViewTaskSection), string(constants.ViewMeetingPreparation), string(constants.ViewDueToday), string(constants.ViewOverdue), string(constants.ViewAssignedToMe), string(constants.ViewWaitingOnOthers), string(constants.ViewCustom):
+		return external.TaskServiceGeneralTask.ID, nil
+	case string(constants.ViewJira), string(constants.ViewJiraJQL):
+		return external.TaskServiceAtlassian.ID, nil
+	case string(constants.ViewLinear):
+		return external.TaskServiceLinear.ID, nil
+	case string(constants.ViewSlack):
+		return external.TaskServiceSlack.ID, nil
+	case string(constants.ViewGithub):
+		return external.TaskServiceGithub.ID, nil
+	}
+	return "", errors.New("invalid view type")
+}
+
+func (api *API) GetJiraOverviewResult(ctx context.Context, view database.View, userID primitive.ObjectID, timezoneOffset time.Duration) (*OverviewResult[TaskResult], error) {
 	if view.UserID != userID {
 		return nil, errors.New("invalid user")
 	}
@@ -379,10 +411,11 @@ func (api *API) GetJiraOverviewResult(view database.View, userID primitive.Objec
 		return &result, nil
 	}
 
-	jiraTasks, err := database.GetTasks(api.DB, userID, &[]bson.M{
+	jiraTasks, err := database.GetTasks(ctx, api.DB, userID, &[]bson.M{
 		{"is_completed": false},
 		{"is_deleted": bson.M{"$ne": true}},
 		{"source_id": external.TASK_SOURCE_ID_JIRA},
+		{"jira_task_params.is_only_in_jql_view": bson.M{"$ne": true}},
 	}, nil)
 	if err != nil {
 		return nil, err
@@ -400,7 +433,55 @@ func (api *API) GetJiraOverviewResult(view database.View, userID primitive.Objec
 	return &result, nil
 }
 
-func (api *API) GetLinearOverviewResult(view database.View, userID primitive.ObjectID, timezoneOffset time.Duration) (*OverviewResult[TaskResult], error) {
+// GetJiraJQLOverviewResult returns the issues that matched the view's query on the last JIRA sync
+func (api *API) GetJiraJQLOverviewResult(ctx context.Context, view database.View, userID primitive.ObjectID) (*OverviewResult[TaskResult], error) {
+	if view.UserID != userID {
+		return nil, errors.New("invalid user")
+	}
+	authURL := config.GetAuthorizationURL(external.TASK_SERVICE_ID_ATLASSIAN)
+	name := view.Name
+	if name == "" {
+		name = constants.ViewJiraJQLName
+	}
+	result := OverviewResult[TaskResult]{
+		ID:       view.ID,
+		Name:     name,
+		Logo:     external.TaskServiceAtlassian.LogoV2,
+		Type:     constants.ViewJiraJQL,
+		IsLinked: view.IsLinked,
+		Sources: []SourcesResult{
+			{
+				Name:             constants.ViewJiraSourceName,
+				AuthorizationURL: &authURL,
+			},
+		},
+		TaskSectionID: view.TaskSectionID,
+		IsReorderable: view.IsReorderable,
+		IDOrdering:    view.IDOrdering,
+		ViewItems:     []*TaskResult{},
+		ViewItemIDs:   []string{},
+	}
+	if !view.IsLinked {
+		return &result, nil
+	}
+
+	jiraTasks, err := database.GetTasks(ctx, api.DB, userID, &[]bson.M{
+		{"is_completed": false},
+		{"is_deleted": bson.M{"$ne": true}},
+		{"source_id": external.TASK_SOURCE_ID_JIRA},
+		{"source_account_id": view.AccountID},
+		{"jira_task_params.jql_view_ids": view.ID},
+	}, options.Find().SetLimit(int64(constants.JIRA_JQL_VIEW_MAX_RESULTS)))
+	if err != nil {
+		return nil, err
+	}
+	taskResults := api.taskListToTaskResultList(jiraTasks, userID)
+	result.ViewItems = taskResults
+	result.ViewItemIDs = GetTaskSectionViewItemIDs(taskResults)
+	return &result, nil
+}
+
+func (api *API) GetLinearOverviewResult(ctx context.Context, view database.View, userID primitive.ObjectID, timezoneOffset time.Duration) (*OverviewResult[TaskResult], error) {
 	if view.UserID != userID {
 		return nil, errors.New("invalid user")
 	}
@@ -427,14 +508,21 @@ func (api *API) GetLinearOverviewResult(view database.View, userID primitive.Obj
 		return &result, nil
 	}
 
-	linearTasks, err := database.GetTasks(api.DB, userID, &[]bson.M{
+	filters := []bson.M{
 		{"is_completed": false},
 		{"is_deleted": bson.M{"$ne": true}},
 		{"source_id": external.TASK_SOURCE_ID_LINEAR},
-	}, nil)
+	}
+	if cycleFilter := getLinearCycleFilter(view.LinearCycleFilter); cycleFilter != nil {
+		filters = append(filters, cycleFilter)
+	}
+	linearTasks, err := database.GetTasks(ctx, api.DB, userID, &filters, nil)
 	if err != nil {
 		return nil, err
 	}
+	if view.LinearSortBy == constants.LinearSortByCycle {
+		sortTasksByLinearCycle(*linearTasks)
+	}
 	taskResults := api.taskListToTaskResultList(linearTasks, userID)
 
 	timeNow := api.GetCurrentLocalizedTime(timezoneOffset)
@@ -445,10 +533,35 @@ func (api *API) GetLinearOverviewResult(view database.View, userID primitive.Obj
 	result.ViewItems = taskResults
 	result.ViewItemIDs = GetTaskSectionViewItemIDs(taskResults)
 	result.HasTasksCompletedToday = taskCompletedInLastDay
+	result.LinearCycleFilter = view.LinearCycleFilter
+	result.LinearSortBy = view.LinearSortBy
 	return &result, nil
 }
 
-func (api *API) GetSlackOverviewResult(view database.View, userID primitive.ObjectID, timezoneOffset time.Duration) (*OverviewResult[TaskResult], error) {
+func getLinearCycleFilter(cycleFilter string) bson.M {
+	switch cycleFilter {
+	case constants.LinearCycleFilterCurrent:
+		return bson.M{"linear_cycle.is_current_cycle": true}
+	case constants.LinearCycleFilterNext:
+		return bson.M{"linear_cycle.is_next_cycle": true}
+	case constants.LinearCycleFilterPrevious:
+		return bson.M{"linear_cycle.is_previous_cycle": true}
+	}
+	return nil
+}
+
+// sortTasksByLinearCycle puts the earliest cycle first and issues without a cycle last
+func sortTasksByLinearCycle(tasks []database.Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		cycleI, cycleJ := tasks[i].LinearCycle, tasks[j].LinearCycle
+		if (cycleI.ID == "") != (cycleJ.ID == "") {
+			return cycleJ.ID == ""
+		}
+		return cycleI.StartsAt < cycleJ.StartsAt
+	})
+}
+
+func (api *API) GetSlackOverviewResult(ctx context.Context, view database.View, userID primitive.ObjectID, timezoneOffset time.Duration) (*OverviewResult[TaskResult], error) {
 	if view.UserID != userID {
 		return nil, errors.New("invalid user")
 	}
@@ -475,7 +588,7 @@ func (api *API) GetSlackOverviewResult(view database.View, userID primitive.Obje
 		return &result, nil
 	}
 
-	slackTasks, err := database.GetTasks(api.DB, userID, &[]bson.M{
+	slackTasks, err := database.GetTasks(ctx, api.DB, userID, &[]bson.M{
 		{"is_completed": false},
 		{"is_deleted": bson.M{"$ne": true}},
 		{"source_id": external.TASK_SOURCE_ID_SLACK_SAVED},
@@ -496,7 +609,7 @@ func (api *API) GetSlackOverviewResult(view database.View, userID primitive.Obje
 	return &result, nil
 }
 
-func (api *API) GetGithubOverviewResult(view database.View, userID primitive.ObjectID, timezoneOffset time.Duration) (*OverviewResult[PullRequestResult], error) {
+func (api *API) GetGithubOverviewResult(ctx context.Context, view database.View, userID primitive.ObjectID, timezoneOffset time.Duration) (*OverviewResult[PullRequestResult], error) {
 	if view.UserID != userID {
 		return nil, errors.New("invalid user")
 	}
@@ -525,17 +638,16 @@ func (api *API) GetGithubOverviewResult(view database.View, userID primitive.Obj
 
 	var repository database.Repository
 	repositoryCollection := database.GetRepositoryCollection(api.DB)
-	err := repositoryCollection.FindOne(context.Background(), bson.M{"$and": []bson.M{{"repository_id": view.GithubID, "user_id": userID}}}).Decode(&repository)
+	err := repositoryCollection.FindOne(ctx, bson.M{"$and": []bson.M{{"repository_id": view.GithubID, "user_id": userID}}}).Decode(&repository)
 	if err != nil {
 		return nil, err
 	}
 
-	githubPRs, err := database.GetPullRequests(api.DB, userID,
-		&[]bson.M{
-			{"is_completed": false},
-			{"repository_id": view.GithubID},
-		},
-	)
+	filters := append([]bson.M{
+		{"is_completed": false},
+		{"repository_id": view.GithubID},
+	}, database.GetUnhiddenPullRequestFilters(api.GetCurrentTime())...)
+	githubPRs, err := database.GetPullRequests(ctx, api.DB, userID, &filters)
 	if err != nil {
 		return nil, err
 	}
@@ -559,32 +671,32 @@ func (api *API) GetGithubOverviewResult(view database.View, userID primitive.Obj
 	return &result, nil
 }
 
-func (api *API) CreateMeetingPreparationTaskList(userID primitive.ObjectID, timezoneOffset time.Duration, showMovedOrDeleted bool) (*[]database.Task, error) {
+func (api *API) CreateMeetingPreparationTaskList(ctx context.Context, userID primitive.ObjectID, timezoneOffset time.Duration, showMovedOrDeleted bool) (*[]database.Task, error) {
 	timeNow := api.GetCurrentLocalizedTime(timezoneOffset)
-	events, err := database.GetEventsUntilEndOfDay(api.DB, userID, timeNow)
+	events, err := database.GetEventsUntilEndOfDay(ctx, api.DB, userID, timeNow, api.getWorkingHours(userID).EndOfDay(timeNow))
 	if err != nil {
 		return nil, err
 	}
 
 	// Create new meeting prep tasks for events. Ignore events if meeting prep task already exists
-	err = CreateMeetingTasksFromEvents(api.DB, userID, events)
+	err = CreateMeetingTasksFromEvents(ctx, api.DB, userID, events, timeNow)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get all meeting prep tasks for user
-	meetingTasks, err := database.GetMeetingPreparationTasks(api.DB, userID)
+	meetingTasks, err := database.GetMeetingPreparationTasks(ctx, api.DB, userID)
 	if err != nil {
 		return nil, err
 	}
 
 	if showMovedOrDeleted {
 		// Sync existing Meeting prep tasks with their events
-		err = api.SyncMeetingTasksWithEvents(meetingTasks, userID, timezoneOffset)
+		err = api.SyncMeetingTasksWithEvents(ctx, meetingTasks, userID, timezoneOffset)
 		if err != nil {
 			return nil, err
 		}
-		meetingTasks, err = database.GetMeetingPreparationTasks(api.DB, userID)
+		meetingTasks, err = database.GetMeetingPreparationTasks(ctx, api.DB, userID)
 		if err != nil {
 			return nil, err
 		}
@@ -597,7 +709,7 @@ func (api *API) CreateMeetingPreparationTaskList(userID primitive.ObjectID, time
 	return meetingTasks, nil
 }
 
-func (api *API) GetMeetingPreparationOverviewResult(view database.View, userID primitive.ObjectID, timezoneOffset time.Duration, showMovedOrDeleted bool, ignoreMeetingPreparation bool) (*OverviewResult[TaskResult], error) {
+func (api *API) GetMeetingPreparationOverviewResult(ctx context.Context, view database.View, userID primitive.ObjectID, timezoneOffset time.Duration, showMovedOrDeleted bool, ignoreMeetingPreparation bool) (*OverviewResult[TaskResult], error) {
 	if view.UserID != userID {
 		return nil, errors.New("invalid user")
 	}
@@ -622,7 +734,7 @@ func (api *API) GetMeetingPreparationOverviewResult(view database.View, userID p
 		}, nil
 	}
 
-	meetingTasks, err := api.CreateMeetingPreparationTaskList(userID, timezoneOffset, showMovedOrDeleted)
+	meetingTasks, err := api.CreateMeetingPreparationTaskList(ctx, userID, timezoneOffset, showMovedOrDeleted)
 	if err != nil {
 		return nil, err
 	}
@@ -656,7 +768,7 @@ func (api *API) GetMeetingPreparationOverviewResult(view database.View, userID p
 	}, nil
 }
 
-func (api *API) GetDueTodayOverviewResult(view database.View, userID primitive.ObjectID, timezoneOffset time.Duration) (*OverviewResult[TaskResult], error) {
+func (api *API) GetDueTodayOverviewResult(ctx context.Context, view database.View, userID primitive.ObjectID, timezoneOffset time.Duration) (*OverviewResult[TaskResult], error) {
 	if view.UserID != userID {
 		return nil, errors.New("invalid user")
 	}
@@ -676,7 +788,7 @@ func (api *API) GetDueTodayOverviewResult(view database.View, userID primitive.O
 
 	timeNow := api.GetCurrentLocalizedTime(timezoneOffset)
 	timeEndOfDay := time.Date(timeNow.Year(), timeNow.Month(), timeNow.Day(), 23, 59, 59, 0, time.FixedZone("", 0))
-	dueTasks, err := database.GetTasks(api.DB, userID, &[]bson.M{
+	dueTasks, err := database.GetTasks(ctx, api.DB, userID, &[]bson.M{
 		{"is_completed": false},
 		{"is_deleted": bson.M{"$ne": true}},
 		{"due_date": bson.M{"$lte": primitive.NewDateTimeFromTime(timeEndOfDay)}},
@@ -688,9 +800,9 @@ func (api *API) GetDueTodayOverviewResult(view database.View, userID primitive.O
 		return nil, err
 	}
 	taskResults := api.taskListToTaskResultList(dueTasks, userID)
-	taskResults = reorderTaskResultsByDueDate(taskResults)
+	taskResults = api.sortViewTaskResults(userID, constants.ViewDueToday, taskResults)
 	for _, result := range taskResults {
-		subTasks := api.getSubtaskResults(result.ID, userID)
+		subTasks := api.getSubtaskResults(ctx, result.ID, userID)
 		if subTasks != nil {
 			result.SubTasks = subTasks
 		}
@@ -715,6 +827,172 @@ func (api *API) GetDueTodayOverviewResult(view database.View, userID primitive.O
 	return &result, nil
 }
 
+// GetOverdueOverviewResult lists the user's tasks which were due before today
+func (api *API) GetOverdueOverviewResult(ctx context.Context, view database.View, userID primitive.ObjectID, timezoneOffset time.Duration) (*OverviewResult[TaskResult], error) {
+	if view.UserID != userID {
+		return nil, errors.New("invalid user")
+	}
+	result := OverviewResult[TaskResult]{
+		ID:            view.ID,
+		Name:          constants.ViewOverdueName,
+		Logo:          external.TaskServiceGeneralTask.LogoV2,
+		Type:          constants.ViewOverdue,
+		IsLinked:      true,
+		Sources:       []SourcesResult{},
+		TaskSectionID: view.TaskSectionID,
+		IsReorderable: view.IsReorderable,
+		IDOrdering:    view.IDOrdering,
+		ViewItems:     []*TaskResult{},
+		ViewItemIDs:   []string{},
+	}
+
+	timeNow := api.GetCurrentLocalizedTime(timezoneOffset)
+	timeStartOfDay := time.Date(timeNow.Year(), timeNow.Month(), timeNow.Day(), 0, 0, 0, 0, time.FixedZone("", 0))
+	overdueFilters := []bson.M{
+		{"due_date": bson.M{"$lt": primitive.NewDateTimeFromTime(timeStartOfDay)}},
+		{"due_date": bson.M{"$ne": primitive.NewDateTimeFromTime(time.Time{})}},
+		{"due_date": bson.M{"$ne": primitive.NewDateTimeFromTime(time.Unix(0, 0))}},
+		{"due_date": bson.M{"$gte": primitive.NewDateTimeFromTime(time.Unix(63090000, 0))}},
+	}
+	taskFilters := append([]bson.M{
+		{"is_completed": false},
+		{"is_deleted": bson.M{"$ne": true}},
+	}, overdueFilters...)
+	overdueTasks, err := database.GetTasks(ctx, api.DB, userID, &taskFilters, nil)
+	if err != nil {
+		return nil, err
+	}
+	taskResults := api.taskListToTaskResultList(overdueTasks, userID)
+	taskResults = api.sortViewTaskResults(userID, constants.ViewOverdue, taskResults)
+	for _, result := range taskResults {
+		subTasks := api.getSubtaskResults(ctx, result.ID, userID)
+		if subTasks != nil {
+			result.SubTasks = subTasks
+		}
+	}
+
+	result.HasTasksCompletedToday = api.getCompletedInLastDay(database.GetTaskCollection(api.DB), userID, timeStartOfDay, &overdueFilters)
+	result.ViewItems = taskResults
+	result.ViewItemIDs = GetTaskSectionViewItemIDs(taskResults)
+	return &result, nil
+}
+
+// GetWaitingOnOthersOverviewResult lists the user's tasks which they've assigned to teammates
+func (api *API) GetWaitingOnOthersOverviewResult(ctx context.Context, view database.View, userID primitive.ObjectID, timezoneOffset time.Duration) (*OverviewResult[TaskResult], error) {
+	if view.UserID != userID {
+		return nil, errors.New("invalid user")
+	}
+	result := OverviewResult[TaskResult]{
+		ID:            view.ID,
+		Name:          constants.ViewWaitingOnOthersName,
+		Logo:          external.TaskServiceGeneralTask.LogoV2,
+		Type:          constants.ViewWaitingOnOthers,
+		IsLinked:      true,
+		Sources:       []SourcesResult{},
+		TaskSectionID: view.TaskSectionID,
+		IsReorderable: view.IsReorderable,
+		IDOrdering:    view.IDOrdering,
+		ViewItems:     []*TaskResult{},
+		ViewItemIDs:   []string{},
+	}
+
+	delegatedFilter := bson.M{"assignee_id": bson.M{"$exists": true, "$ne": userID}}
+	delegatedTasks, err := database.GetTasks(ctx, api.DB, userID, &[]bson.M{
+		{"is_completed": false},
+		{"is_deleted": bson.M{"$ne": true}},
+		delegatedFilter,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	taskResults := api.taskListToTaskResultList(delegatedTasks, userID)
+	taskResults = api.sortViewTaskResults(userID, constants.ViewWaitingOnOthers, taskResults)
+
+	timeNow := api.GetCurrentLocalizedTime(timezoneOffset)
+	timeStartOfDay := time.Date(timeNow.Year(), timeNow.Month(), timeNow.Day(), 0, 0, 0, 0, time.FixedZone("", 0))
+	result.HasTasksCompletedToday = api.getCompletedInLastDay(database.GetTaskCollection(api.DB), userID, timeStartOfDay, &[]bson.M{delegatedFilter})
+	result.ViewItems = taskResults
+	result.ViewItemIDs = GetTaskSectionViewItemIDs(taskResults)
+	return &result, nil
+}
+
+// GetAssignedToMeOverviewResult lists tasks teammates have assigned to the user, which belong to the teammates
+func (api *API) GetAssignedToMeOverviewResult(ctx context.Context, view database.View, userID primitive.ObjectID, timezoneOffset time.Duration) (*OverviewResult[TaskResult], error) {
+	if view.UserID != userID {
+		return nil, errors.New("invalid user")
+	}
+	result := OverviewResult[TaskResult]{
+		ID:            view.ID,
+		Name:          constants.ViewAssignedToMeName,
+		Logo:          external.TaskServiceGeneralTask.LogoV2,
+		Type:          constants.ViewAssignedToMe,
+		IsLinked:      true,
+		Sources:       []SourcesResult{},
+		TaskSectionID: view.TaskSectionID,
+		IsReorderable: view.IsReorderable,
+		IDOrdering:    view.IDOrdering,
+		ViewItems:     []*TaskResult{},
+		ViewItemIDs:   []string{},
+	}
+
+	assignedTasks, err := database.GetAssignedTasks(ctx, api.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+	taskResults := []*TaskResult{}
+	for _, task := range *assignedTasks {
+		// for implicit memory aliasing
+		tempTask := task
+		taskResults = append(taskResults, api.taskBaseToTaskResult(&tempTask, task.UserID))
+	}
+	taskResults = reorderTaskResultsByDueDate(taskResults)
+
+	result.ViewItems = taskResults
+	result.ViewItemIDs = GetTaskSectionViewItemIDs(taskResults)
+	return &result, nil
+}
+
+// sortViewTaskResults orders the results of views which can't be reordered manually by the user's
+// sorting settings for the view type
+func (api *API) sortViewTaskResults(userID primitive.ObjectID, viewType constants.ViewType, taskResults []*TaskResult) []*TaskResult {
+	sortingPreferenceSetting, sortingDirectionSetting := settings.GetViewTaskSortingSettings(viewType)
+	sortingPreference, err := settings.GetUserSettingValue(api.DB, userID, sortingPreferenceSetting)
+	if err != nil {
+		sortingPreference = sortingPreferenceSetting.DefaultChoice
+	}
+	sortingDirection, err := settings.GetUserSettingValue(api.DB, userID, sortingDirectionSetting)
+	if err != nil {
+		sortingDirection = sortingDirectionSetting.DefaultChoice
+	}
+	return sortTaskResults(taskResults, sortingPreference, sortingDirection)
+}
+
+func sortTaskResults(taskResults []*TaskResult, sortingPreference string, sortingDirection string) []*TaskResult {
+	sort.SliceStable(taskResults, func(i, j int) bool {
+		a := taskResults[i]
+		b := taskResults[j]
+		if sortingDirection == constants.ChoiceKeyDescending {
+			a, b = b, a
+		}
+		switch sortingPreference {
+		case constants.ChoiceKeyPriority:
+			return a.PriorityNormalized < b.PriorityNormalized
+		case constants.ChoiceKeyCreatedAt:
+			return a.CreatedAt < b.CreatedAt
+		case constants.ChoiceKeyUpdatedAt:
+			return a.UpdatedAt < b.UpdatedAt
+		default:
+			aTime, _ := time.Parse(constants.YEAR_MONTH_DAY_FORMAT, a.DueDate)
+			bTime, _ := time.Parse(constants.YEAR_MONTH_DAY_FORMAT, b.DueDate)
+			return aTime.Unix() < bTime.Unix()
+		}
+	})
+	for idx, result := range taskResults {
+		result.IDOrdering = idx
+	}
+	return taskResults
+}
+
 func reorderTaskResultsByDueDate(taskResults []*TaskResult) []*TaskResult {
 	sort.SliceStable(taskResults, func(i, j int) bool {
 		a := taskResults[i]
@@ -729,43 +1007,19 @@ func reorderTaskResultsByDueDate(taskResults []*TaskResult) []*TaskResult {
 	return taskResults
 }
 
-type calendarKey struct {
-	accountID  string
-	calendarID string
-}
-
-func createCalendarToAccessRoleMap(calendarAccounts *[]database.CalendarAccount) map[calendarKey]string {
-	calendarToAccessRole := make(map[calendarKey]string)
-	for _, calendarAccount := range *calendarAccounts {
-		for _, calendar := range calendarAccount.Calendars {
-			calendarToAccessRole[calendarKey{calendarAccount.IDExternal, calendar.CalendarID}] = calendar.AccessRole
-		}
-		calendarToAccessRole[calendarKey{calendarAccount.IDExternal, "primary"}] = constants.AccessControlOwner
-	}
-	return calendarToAccessRole
-}
-
-func CreateMeetingTasksFromEvents(db *mongo.Database, userID primitive.ObjectID, events *[]database.CalendarEvent) error {
-	calendarAccounts, err := database.GetCalendarAccounts(db, userID)
+func CreateMeetingTasksFromEvents(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, events *[]database.CalendarEvent, timeNow time.Time) error {
+	matchingEvents, err := meetingprep.GetMatchingEvents(ctx, db, userID, *events, timeNow)
 	if err != nil {
 		return err
 	}
-	calendarToAccessRole := createCalendarToAccessRoleMap(calendarAccounts)
 
 	taskCollection := database.GetTaskCollection(db)
-	for _, event := range *events {
-		if accessRole, ok := calendarToAccessRole[calendarKey{event.SourceAccountID, event.CalendarID}]; ok {
-			if accessRole != constants.AccessControlOwner {
-				continue // only create meeting prep tasks for "owned" calendars
-			}
-		} else {
-			continue // could not find the calendar in db
-		}
+	for _, event := range matchingEvents {
 
 		// Check if meeting prep task exists
 		var meetingTask *database.Task
 		err := taskCollection.FindOne(
-			context.Background(),
+			ctx,
 			bson.M{"$and": []bson.M{
 				{"user_id": userID},
 				{"is_meeting_preparation_task": true},
@@ -780,7 +1034,7 @@ func CreateMeetingTasksFromEvents(db *mongo.Database, userID primitive.ObjectID,
 		// Update meeting prep task for event
 		if meetingTask != nil {
 			_, err := taskCollection.UpdateOne(
-				context.Background(),
+				ctx,
 				bson.M{"_id": meetingTask.ID},
 				bson.M{"$set": bson.M{
 					"title": event.Title,
@@ -795,7 +1049,8 @@ func CreateMeetingTasksFromEvents(db *mongo.Database, userID primitive.ObjectID,
 		// Create meeting prep task for event if one does not exist
 		isCompleted := false
 		isDeleted := false
-		_, err = taskCollection.InsertOne(context.Background(), database.Task{
+		colorBackground, colorForeground := database.GetCalendarEventColors(event)
+		_, err = taskCollection.InsertOne(ctx, database.Task{
 			Title:                    &event.Title,
 			UserID:                   userID,
 			IsCompleted:              &isCompleted,
@@ -811,6 +1066,8 @@ func CreateMeetingTasksFromEvents(db *mongo.Database, userID primitive.ObjectID,
 				DatetimeEnd:                   event.DatetimeEnd,
 				HasBeenAutomaticallyCompleted: false,
 				EventMovedOrDeleted:           false,
+				ColorBackground:               colorBackground,
+				ColorForeground:               colorForeground,
 			},
 		})
 		if err != nil {
@@ -820,11 +1077,11 @@ func CreateMeetingTasksFromEvents(db *mongo.Database, userID primitive.ObjectID,
 	return nil
 }
 
-func (api *API) SyncMeetingTasksWithEvents(meetingTasks *[]database.Task, userID primitive.ObjectID, timezoneOffset time.Duration) error {
+func (api *API) SyncMeetingTasksWithEvents(ctx context.Context, meetingTasks *[]database.Task, userID primitive.ObjectID, timezoneOffset time.Duration) error {
 	timeNow := api.GetCurrentLocalizedTime(timezoneOffset)
 	taskCollection := database.GetTaskCollection(api.DB)
 	for _, task := range *meetingTasks {
-		event, err := database.GetCalendarEventByExternalId(api.DB, task.MeetingPreparationParams.IDExternal, userID)
+		event, err := database.GetCalendarEventByExternalId(ctx, api.DB, task.MeetingPreparationParams.IDExternal, userID)
 		eventMovedOrDeleted := task.MeetingPreparationParams.EventMovedOrDeleted
 
 		if err != nil && err != mongo.ErrNoDocuments {
@@ -843,6 +1100,9 @@ func (api *API) SyncMeetingTasksWithEvents(meetingTasks *[]database.Task, userID
 			task.MeetingPreparationParams.EventMovedOrDeleted = eventMovedOrDeleted
 			task.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
 		}
+		if event != nil {
+			task.MeetingPreparationParams.ColorBackground, task.MeetingPreparationParams.ColorForeground = database.GetCalendarEventColors(*event)
+		}
 
 		if event == nil {
 			task.MeetingPreparationParams.EventMovedOrDeleted = true
@@ -854,7 +1114,7 @@ func (api *API) SyncMeetingTasksWithEvents(meetingTasks *[]database.Task, userID
 			task.MeetingPreparationParams.EventMovedOrDeleted = true
 		}
 
-		_, err = taskCollection.UpdateOne(context.Background(),
+		_, err = taskCollection.UpdateOne(ctx,
 			bson.M{"$and": []bson.M{
 				{"_id": task.ID},
 				{"user_id": userID},
@@ -867,6 +1127,8 @@ func (api *API) SyncMeetingTasksWithEvents(meetingTasks *[]database.Task, userID
 				"meeting_preparation_params.datetime_end":                     task.MeetingPreparationParams.DatetimeEnd,
 				"meeting_preparation_params.event_moved_or_deleted":           task.MeetingPreparationParams.EventMovedOrDeleted,
 				"meeting_preparation_params.has_been_automatically_completed": task.MeetingPreparationParams.HasBeenAutomaticallyCompleted,
+				"meeting_preparation_params.color_background":                 task.MeetingPreparationParams.ColorBackground,
+				"meeting_preparation_params.color_foreground":                 task.MeetingPreparationParams.ColorForeground,
 			}},
 		)
 		if err != nil {
@@ -926,23 +1188,33 @@ func (api *API) GetMeetingPrepTaskResult(userID primitive.ObjectID, expirationTi
 }
 
 type ViewCreateParams struct {
-	Type          string  `json:"type" binding:"required"`
-	TaskSectionID *string `json:"task_section_id"`
-	GithubID      *string `json:"github_id"`
+	Type          string                  `json:"type" binding:"required"`
+	TaskSectionID *string                 `json:"task_section_id"`
+	GithubID      *string                 `json:"github_id"`
+	Name          *string                 `json:"name"`
+	AccountID     *string                 `json:"account_id"`
+	JQL           *string                 `json:"jql"`
+	Filter        *CustomViewFilterParams `json:"filter"`
 }
 
 func (api *API) OverviewViewAdd(c *gin.Context) {
 	var viewCreateParams ViewCreateParams
 	err := c.BindJSON(&viewCreateParams)
 	if err != nil {
-		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		HandleBindError(c, &viewCreateParams, err, "invalid or missing parameter")
 		return
 	}
 	if viewCreateParams.Type == string(constants.ViewTaskSection) && viewCreateParams.TaskSectionID == nil {
-		c.JSON(400, gin.H{"detail": "'task_section_id' is required for task section type views"})
+		HandleBadRequest(c, "'task_section_id' is required for task section type views", "task_section_id")
 		return
 	} else if viewCreateParams.Type == string(constants.ViewGithub) && viewCreateParams.GithubID == nil {
-		c.JSON(400, gin.H{"detail": "'id_github' is required for github type views"})
+		HandleBadRequest(c, "'id_github' is required for github type views", "id_github")
+		return
+	} else if viewCreateParams.Type == string(constants.ViewJiraJQL) && (viewCreateParams.AccountID == nil || viewCreateParams.JQL == nil || strings.TrimSpace(*viewCreateParams.JQL) == "") {
+		HandleBadRequest(c, "'account_id' and 'jql' are required for jira jql type views", "account_id", "jql")
+		return
+	} else if viewCreateParams.Type == string(constants.ViewJiraJQL) && len(*viewCreateParams.JQL) > constants.JIRA_JQL_MAX_LENGTH {
+		HandleBadRequest(c, fmt.Sprintf("'jql' must be at most %d characters", constants.JIRA_JQL_MAX_LENGTH), "jql")
 		return
 	}
 
@@ -954,17 +1226,19 @@ func (api *API) OverviewViewAdd(c *gin.Context) {
 		return
 	}
 	if viewExists {
-		c.JSON(400, gin.H{"detail": "view already exists"})
+		HandleBadRequest(c, "view already exists")
 		return
 	}
 	var serviceID string
 	taskSectionID := primitive.NilObjectID
 	var githubID string
+	var name, accountID, JQL string
+	var customFilter *database.CustomViewFilter
 	if viewCreateParams.Type == string(constants.ViewTaskSection) {
 		serviceID = external.TASK_SERVICE_ID_GT
 		taskSectionID, err = getValidTaskSection(*viewCreateParams.TaskSectionID, userID, api.DB)
 		if err != nil {
-			c.JSON(400, gin.H{"detail": "'task_section_id' is not a valid ID"})
+			HandleBadRequest(c, "'task_section_id' is not a valid ID", "task_section_id")
 			return
 		}
 	} else if viewCreateParams.Type == string(constants.ViewJira) {
@@ -980,12 +1254,33 @@ func (api *API) OverviewViewAdd(c *gin.Context) {
 			return
 		}
 		if !isValidGithubRepository {
-			c.JSON(400, gin.H{"detail": "invalid 'id_github'"})
+			HandleBadRequest(c, "invalid 'id_github'", "id_github")
 			return
 		}
 		githubID = *viewCreateParams.GithubID
-	} else if viewCreateParams.Type != string(constants.ViewJira) && viewCreateParams.Type != string(constants.ViewLinear) && viewCreateParams.Type != string(constants.ViewSlack) && viewCreateParams.Type != string(constants.ViewMeetingPreparation) && viewCreateParams.Type != string(constants.ViewDueToday) {
-		c.JSON(400, gin.H{"detail": "unsupported 'type'"})
+	} else if viewCreateParams.Type == string(constants.ViewJiraJQL) {
+		serviceID = external.TASK_SERVICE_ID_ATLASSIAN
+		accountID = *viewCreateParams.AccountID
+		JQL = strings.TrimSpace(*viewCreateParams.JQL)
+		if !api.validateJQLForView(c, userID, accountID, JQL) {
+			return
+		}
+		if viewCreateParams.Name != nil {
+			name = *viewCreateParams.Name
+		}
+	} else if viewCreateParams.Type == string(constants.ViewCustom) {
+		serviceID = external.TASK_SERVICE_ID_GT
+		var ok bool
+		name, ok = getValidCustomViewName(c, viewCreateParams.Name)
+		if !ok {
+			return
+		}
+		customFilter = api.getValidCustomViewFilter(c, userID, viewCreateParams.Filter)
+		if customFilter == nil {
+			return
+		}
+	} else if viewCreateParams.Type != string(constants.ViewJira) && viewCreateParams.Type != string(constants.ViewLinear) && viewCreateParams.Type != string(constants.ViewSlack) && viewCreateParams.Type != string(constants.ViewMeetingPreparation) && viewCreateParams.Type != string(constants.ViewDueToday) && viewCreateParams.Type != string(constants.ViewOverdue) && viewCreateParams.Type != string(constants.ViewAssignedToMe) && viewCreateParams.Type != string(constants.ViewWaitingOnOthers) {
+		HandleBadRequest(c, "unsupported 'type'", "type")
 		return
 	}
 
@@ -1002,6 +1297,10 @@ func (api *API) OverviewViewAdd(c *gin.Context) {
 		IsLinked:      isLinked,
 		TaskSectionID: taskSectionID,
 		GithubID:      githubID,
+		Name:          name,
+		AccountID:     accountID,
+		JQL:           JQL,
+		CustomFilter:  customFilter,
 	}
 
 	viewCollection := database.GetViewCollection(api.DB)
@@ -1016,6 +1315,28 @@ func (api *API) OverviewViewAdd(c *gin.Context) {
 	})
 }
 
+// validateJQLForView checks that the account is linked and that JIRA accepts the query, writing a
+// 400 if not so the user finds out about typos now rather than on the next sync
+func (api *API) validateJQLForView(c *gin.Context, userID primitive.ObjectID, accountID string, JQL string) bool {
+	token, err := database.GetExternalToken(c.Request.Context(), api.DB, accountID, external.TASK_SERVICE_ID_ATLASSIAN)
+	if err != nil || token.UserID != userID {
+		HandleBadRequest(c, "invalid 'account_id'", "account_id")
+		return false
+	}
+	jira := external.JIRASource{Atlassian: external.AtlassianService{Config: api.ExternalConfig.Atlassian}}
+	JQLErrors, err := jira.ValidateJQL(c.Request.Context(), userID, accountID, JQL)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to validate JQL")
+		HandleBadRequest(c, "unable to validate 'jql' with JIRA", "jql")
+		return false
+	}
+	if len(JQLErrors) > 0 {
+		HandleBadRequest(c, "invalid 'jql': "+strings.Join(JQLErrors, " "), "jql")
+		return false
+	}
+	return true
+}
+
 func (api *API) ViewDoesExist(db *mongo.Database, userID primitive.ObjectID, params ViewCreateParams) (bool, error) {
 	viewCollection := database.GetViewCollection(db)
 	dbQuery := bson.M{
@@ -1038,7 +1359,15 @@ func (api *API) ViewDoesExist(db *mongo.Database, userID primitive.ObjectID, par
 			return false, errors.New("'github_id' is required for github type views")
 		}
 		dbQuery["$and"] = append(dbQuery["$and"].([]bson.M), bson.M{"github_id": *params.GithubID})
-	} else if params.Type != string(constants.ViewLinear) && params.Type != string(constants.ViewSlack) && params.Type != string(constants.ViewJira) && params.Type != string(constants.ViewMeetingPreparation) && params.Type != string(constants.ViewDueToday) {
+	} else if params.Type == string(constants.ViewJiraJQL) {
+		if params.AccountID == nil || params.JQL == nil {
+			return false, errors.New("'account_id' and 'jql' are required for jira jql type views")
+		}
+		dbQuery["$and"] = append(dbQuery["$and"].([]bson.M), bson.M{"account_id": *params.AccountID}, bson.M{"jql": strings.TrimSpace(*params.JQL)})
+	} else if params.Type == string(constants.ViewCustom) {
+		// users can add as many custom views as they like
+		return false, nil
+	} else if params.Type != string(constants.ViewLinear) && params.Type != string(constants.ViewSlack) && params.Type != string(constants.ViewJira) && params.Type != string(constants.ViewMeetingPreparation) && params.Type != string(constants.ViewDueToday) && params.Type != string(constants.ViewOverdue) && params.Type != string(constants.ViewAssignedToMe) && params.Type != string(constants.ViewWaitingOnOthers) {
 		return false, errors.New("unsupported view type")
 	}
 	count, err := viewCollection.CountDocuments(context.Background(), dbQuery)
@@ -1058,38 +1387,28 @@ func (api *API) OverviewViewBulkModify(c *gin.Context) {
 	var viewModifyParams ViewBulkModifyParams
 	err := c.BindJSON(&viewModifyParams)
 	if err != nil {
-		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		HandleBindError(c, &viewModifyParams, err, "invalid or missing parameter")
 		return
 	}
 
-	var operations []mongo.WriteModel
-	for index, viewIDHex := range viewModifyParams.OrderedViewIDs {
+	viewIDs := []primitive.ObjectID{}
+	for _, viewIDHex := range viewModifyParams.OrderedViewIDs {
 		viewID, err := primitive.ObjectIDFromHex(viewIDHex)
 		if err != nil {
-			c.JSON(400, gin.H{"detail": "malformatted view ID"})
+			HandleBadRequest(c, "malformatted view ID", "ordered_view_ids")
 			return
 		}
-		newIDOrdering := index + 1
-		operation := mongo.NewUpdateOneModel()
-		operation.SetFilter(bson.M{
-			"$and": []bson.M{
-				{"user_id": userID},
-				{"_id": viewID},
-			},
-		})
-		operation.SetUpdate(bson.M{"$set": bson.M{"id_ordering": newIDOrdering}})
-		operations = append(operations, operation)
+		viewIDs = append(viewIDs, viewID)
 	}
 
-	viewCollection := database.GetViewCollection(api.DB)
-	result, err := viewCollection.BulkWrite(context.Background(), operations)
+	matchedCount, err := database.SetOrderingKeys(c.Request.Context(), database.GetViewCollection(api.DB), userID, viewIDs)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to bulk modify view ordering")
 		Handle500(c)
 		return
 	}
-	if result.MatchedCount != int64(len(operations)) {
-		c.JSON(400, gin.H{"detail": "invalid or duplicate view IDs provided"})
+	if matchedCount != int64(len(viewIDs)) {
+		HandleBadRequest(c, "invalid or duplicate view IDs provided", "ordered_view_ids")
 		return
 	}
 	c.JSON(200, gin.H{})
@@ -1108,7 +1427,7 @@ func (api *API) OverviewViewModify(c *gin.Context) {
 	var viewModifyParams ViewModifyParams
 	err = c.BindJSON(&viewModifyParams)
 	if err != nil {
-		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		HandleBindError(c, &viewModifyParams, err, "invalid or missing parameter")
 		return
 	}
 
@@ -1132,11 +1451,68 @@ func (api *API) OverviewViewModify(c *gin.Context) {
 		return
 	}
 
-	err = database.AdjustOrderingIDsForCollection(viewCollection, userID, viewID, viewModifyParams.IDOrdering)
+	err = database.MoveToPosition(c.Request.Context(), viewCollection, userID, viewID, nil, viewModifyParams.IDOrdering)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+type LinearViewModifyParams struct {
+	CycleFilter *string `json:"linear_cycle_filter"`
+	SortBy      *string `json:"linear_sort_by"`
+}
+
+// OverviewLinearViewModify updates how the Linear view filters and orders issues. An empty string
+// clears the setting
+func (api *API) OverviewLinearViewModify(c *gin.Context) {
+	viewID, err := getViewIDFromContext(c)
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	var params LinearViewModifyParams
+	err = c.BindJSON(&params)
+	if err != nil || (params.CycleFilter == nil && params.SortBy == nil) {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	updateFields := bson.M{}
+	if params.CycleFilter != nil {
+		if *params.CycleFilter != "" && getLinearCycleFilter(*params.CycleFilter) == nil {
+			HandleBadRequest(c, "invalid 'linear_cycle_filter'", "linear_cycle_filter")
+			return
+		}
+		updateFields["linear_cycle_filter"] = *params.CycleFilter
+	}
+	if params.SortBy != nil {
+		if *params.SortBy != "" && *params.SortBy != constants.LinearSortByCycle {
+			HandleBadRequest(c, "invalid 'linear_sort_by'", "linear_sort_by")
+			return
+		}
+		updateFields["linear_sort_by"] = *params.SortBy
+	}
+
+	userID := getUserIDFromContext(c)
+	result, err := database.GetViewCollection(api.DB).UpdateOne(
+		c.Request.Context(),
+		bson.M{"$and": []bson.M{
+			{"user_id": userID},
+			{"_id": viewID},
+			{"type": constants.ViewLinear},
+		}},
+		bson.M{"$set": updateFields},
+	)
 	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to modify linear view settings")
 		Handle500(c)
 		return
 	}
+	if result.MatchedCount != 1 {
+		Handle404(c)
+		return
+	}
 	c.JSON(200, gin.H{})
 }
 
@@ -1148,7 +1524,7 @@ func (api *API) OverviewViewDelete(c *gin.Context) {
 		Handle404(c)
 		return
 	}
-	_, err = database.GetUser(api.DB, userID)
+	_, err = database.GetUser(c.Request.Context(), api.DB, userID)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to find user")
 		Handle500(c)
@@ -1174,7 +1550,7 @@ func (api *API) OverviewViewDelete(c *gin.Context) {
 }
 func (api *API) OverviewSupportedViewsList(c *gin.Context) {
 	userID := getUserIDFromContext(c)
-	supportedTaskSectionViews, err := api.getSupportedTaskSectionViews(api.DB, userID)
+	supportedTaskSectionViews, err := api.getSupportedTaskSectionViews(c.Request.Context(), api.DB, userID)
 	if err != nil {
 		Handle500(c)
 		return
@@ -1249,6 +1625,45 @@ func (api *API) OverviewSupportedViewsList(c *gin.Context) {
 				},
 			},
 		},
+		{
+			Type:     constants.ViewOverdue,
+			Name:     "Overdue Tasks",
+			Logo:     external.TaskServiceGeneralTask.LogoV2,
+			IsNested: false,
+			IsLinked: true,
+			Views: []SupportedViewItem{
+				{
+					Name:    "Overdue Tasks View",
+					IsAdded: true,
+				},
+			},
+		},
+		{
+			Type:     constants.ViewAssignedToMe,
+			Name:     "Tasks Assigned to Me",
+			Logo:     external.TaskServiceGeneralTask.LogoV2,
+			IsNested: false,
+			IsLinked: true,
+			Views: []SupportedViewItem{
+				{
+					Name:    "Assigned to Me View",
+					IsAdded: true,
+				},
+			},
+		},
+		{
+			Type:     constants.ViewWaitingOnOthers,
+			Name:     "Tasks Waiting on Others",
+			Logo:     external.TaskServiceGeneralTask.LogoV2,
+			IsNested: false,
+			IsLinked: true,
+			Views: []SupportedViewItem{
+				{
+					Name:    "Waiting on Others View",
+					IsAdded: true,
+				},
+			},
+		},
 		{
 			Type:     constants.ViewTaskSection,
 			Name:     "Task Folders",
@@ -1318,8 +1733,8 @@ func (api *API) OverviewSupportedViewsList(c *gin.Context) {
 	c.JSON(200, supportedViews)
 }
 
-func (api *API) getSupportedTaskSectionViews(db *mongo.Database, userID primitive.ObjectID) ([]SupportedViewItem, error) {
-	sections, err := database.GetTaskSections(db, userID)
+func (api *API) getSupportedTaskSectionViews(ctx context.Context, db *mongo.Database, userID primitive.ObjectID) ([]SupportedViewItem, error) {
+	sections, err := database.GetTaskSections(ctx, db, userID, false)
 	if err != nil || sections == nil {
 		api.Logger.Error().Err(err).Msg("failed to fetch sections for user")
 		return []SupportedViewItem{}, err
@@ -1333,7 +1748,7 @@ func (api *API) getSupportedTaskSectionViews(db *mongo.Database, userID primitiv
 	sort.SliceStable(*sections, func(i, j int) bool {
 		a := (*sections)[i]
 		b := (*sections)[j]
-		return a.IDOrdering < b.IDOrdering
+		return ordering.Less(a.OrderingKey, a.IDOrdering, b.OrderingKey, b.IDOrdering)
 	})
 	for _, section := range *sections {
 		supportedViewItems = append(supportedViewItems, SupportedViewItem{
@@ -1398,7 +1813,7 @@ func (api *API) getViewFromSupportedView(db *mongo.Database, userID primitive.Ob
 		return api.getView(db, userID, viewType, &[]bson.M{
 			{"task_section_id": view.TaskSectionID},
 		})
-	} else if slices.Contains([]constants.ViewType{constants.ViewJira, constants.ViewLinear, constants.ViewSlack, constants.ViewMeetingPreparation, constants.ViewDueToday}, viewType) {
+	} else if slices.Contains([]constants.ViewType{constants.ViewJira, constants.ViewLinear, constants.ViewSlack, constants.ViewMeetingPreparation, constants.ViewDueToday, constants.ViewOverdue, constants.ViewAssignedToMe, constants.ViewWaitingOnOthers}, viewType) {
 		return api.getView(db, userID, viewType, nil)
 	} else if viewType == constants.ViewGithub {
 		return api.getView(db, userID, viewType, &[]bson.M{
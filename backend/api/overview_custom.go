@@ -0,0 +1,218 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CustomViewFilterParams struct {
+	SourceIDs     []string `json:"source_ids,omitempty"`
+	TaskSectionID string   `json:"task_section_id,omitempty"`
+	Labels        []string `json:"labels,omitempty"`
+	DueWindow     string   `json:"due_window,omitempty"`
+}
+
+type CustomViewModifyParams struct {
+	Name   *string                 `json:"name"`
+	Filter *CustomViewFilterParams `json:"filter"`
+}
+
+// GetCustomOverviewResult lists the user's open tasks which match the view's filter
+func (api *API) GetCustomOverviewResult(ctx context.Context, view database.View, userID primitive.ObjectID, timezoneOffset time.Duration) (*OverviewResult[TaskResult], error) {
+	if view.UserID != userID {
+		return nil, errors.New("invalid user")
+	}
+	result := OverviewResult[TaskResult]{
+		ID:            view.ID,
+		Name:          view.Name,
+		Logo:          external.TaskServiceGeneralTask.LogoV2,
+		Type:          constants.ViewCustom,
+		IsLinked:      true,
+		Sources:       []SourcesResult{},
+		TaskSectionID: view.TaskSectionID,
+		IsReorderable: view.IsReorderable,
+		IDOrdering:    view.IDOrdering,
+		ViewItems:     []*TaskResult{},
+		ViewItemIDs:   []string{},
+	}
+	if view.CustomFilter == nil {
+		return &result, nil
+	}
+	result.CustomFilter = getCustomViewFilterResult(view.CustomFilter)
+
+	timeNow := api.GetCurrentLocalizedTime(timezoneOffset)
+	timeStartOfDay := time.Date(timeNow.Year(), timeNow.Month(), timeNow.Day(), 0, 0, 0, 0, time.FixedZone("", 0))
+	customFilters := getCustomViewTaskFilters(view.CustomFilter, timeStartOfDay)
+	taskFilters := append([]bson.M{
+		{"is_completed": false},
+		{"is_deleted": bson.M{"$ne": true}},
+	}, customFilters...)
+	tasks, err := database.GetTasks(ctx, api.DB, userID, &taskFilters, nil)
+	if err != nil {
+		return nil, err
+	}
+	taskResults := api.taskListToTaskResultList(tasks, userID)
+	taskResults = sortTaskResults(taskResults, constants.ChoiceKeyDueDate, constants.ChoiceKeyAscending)
+
+	result.HasTasksCompletedToday = api.getCompletedInLastDay(database.GetTaskCollection(api.DB), userID, timeStartOfDay, &customFilters)
+	result.ViewItems = taskResults
+	result.ViewItemIDs = GetTaskSectionViewItemIDs(taskResults)
+	return &result, nil
+}
+
+// getCustomViewTaskFilters returns the task query for each of the filter's criteria
+func getCustomViewTaskFilters(filter *database.CustomViewFilter, timeStartOfDay time.Time) []bson.M {
+	filters := []bson.M{}
+	if len(filter.SourceIDs) > 0 {
+		filters = append(filters, bson.M{"source_id": bson.M{"$in": filter.SourceIDs}})
+	}
+	if filter.TaskSectionID != primitive.NilObjectID {
+		filters = append(filters, bson.M{"id_task_section": filter.TaskSectionID})
+	}
+	if len(filter.Labels) > 0 {
+		filters = append(filters, bson.M{"labels": bson.M{"$in": filter.Labels}})
+	}
+	// due dates before 1972 are placeholders for tasks without one
+	minimumDueDate := primitive.NewDateTimeFromTime(time.Unix(63090000, 0))
+	switch filter.DueWindow {
+	case constants.CustomViewDueWindowOverdue:
+		filters = append(filters, bson.M{"due_date": bson.M{"$gte": minimumDueDate, "$lt": primitive.NewDateTimeFromTime(timeStartOfDay)}})
+	case constants.CustomViewDueWindowToday:
+		filters = append(filters, bson.M{"due_date": bson.M{"$gte": primitive.NewDateTimeFromTime(timeStartOfDay), "$lt": primitive.NewDateTimeFromTime(timeStartOfDay.AddDate(0, 0, 1))}})
+	case constants.CustomViewDueWindowNext7Days:
+		filters = append(filters, bson.M{"due_date": bson.M{"$gte": primitive.NewDateTimeFromTime(timeStartOfDay), "$lt": primitive.NewDateTimeFromTime(timeStartOfDay.AddDate(0, 0, 7))}})
+	case constants.CustomViewDueWindowNoDueDate:
+		filters = append(filters, bson.M{"$or": []bson.M{
+			{"due_date": nil},
+			{"due_date": bson.M{"$lt": minimumDueDate}},
+		}})
+	}
+	return filters
+}
+
+func getCustomViewFilterResult(filter *database.CustomViewFilter) *CustomViewFilterParams {
+	result := CustomViewFilterParams{
+		SourceIDs: filter.SourceIDs,
+		Labels:    filter.Labels,
+		DueWindow: filter.DueWindow,
+	}
+	if filter.TaskSectionID != primitive.NilObjectID {
+		result.TaskSectionID = filter.TaskSectionID.Hex()
+	}
+	return &result
+}
+
+// getValidCustomViewName writes a 400 and returns false if the name can't be used for a custom view
+func getValidCustomViewName(c *gin.Context, name *string) (string, bool) {
+	if name == nil || strings.TrimSpace(*name) == "" {
+		HandleBadRequest(c, "'name' is required for custom type views", "name")
+		return "", false
+	}
+	trimmedName := strings.TrimSpace(*name)
+	if len(trimmedName) > constants.CUSTOM_VIEW_NAME_MAX_LENGTH {
+		HandleBadRequest(c, fmt.Sprintf("'name' must be at most %d characters", constants.CUSTOM_VIEW_NAME_MAX_LENGTH), "name")
+		return "", false
+	}
+	return trimmedName, true
+}
+
+// getValidCustomViewFilter checks the filter's criteria against the user's sources and sections,
+// writing a 400 and returning nil if any of them are invalid
+func (api *API) getValidCustomViewFilter(c *gin.Context, userID primitive.ObjectID, params *CustomViewFilterParams) *database.CustomViewFilter {
+	if params == nil || (len(params.SourceIDs) == 0 && params.TaskSectionID == "" && len(params.Labels) == 0 && params.DueWindow == "") {
+		HandleBadRequest(c, "'filter' must include at least one of 'source_ids', 'task_section_id', 'labels' or 'due_window'", "filter", "source_ids", "task_section_id", "labels", "due_window")
+		return nil
+	}
+	filter := database.CustomViewFilter{DueWindow: params.DueWindow}
+	for _, sourceID := range params.SourceIDs {
+		if _, err := api.ExternalConfig.GetSourceResult(sourceID); err != nil {
+			HandleBadRequest(c, "invalid 'source_ids'", "source_ids")
+			return nil
+		}
+		filter.SourceIDs = append(filter.SourceIDs, sourceID)
+	}
+	if params.TaskSectionID != "" {
+		taskSectionID, err := getValidTaskSection(params.TaskSectionID, userID, api.DB)
+		if err != nil {
+			HandleBadRequest(c, "'task_section_id' is not a valid ID", "task_section_id")
+			return nil
+		}
+		filter.TaskSectionID = taskSectionID
+	}
+	for _, label := range params.Labels {
+		if strings.TrimSpace(label) == "" {
+			HandleBadRequest(c, "invalid 'labels'", "labels")
+			return nil
+		}
+		filter.Labels = append(filter.Labels, strings.TrimSpace(label))
+	}
+	switch params.DueWindow {
+	case "", constants.CustomViewDueWindowOverdue, constants.CustomViewDueWindowToday, constants.CustomViewDueWindowNext7Days, constants.CustomViewDueWindowNoDueDate:
+	default:
+		HandleBadRequest(c, "invalid 'due_window'", "due_window")
+		return nil
+	}
+	return &filter
+}
+
+// OverviewCustomViewModify renames a custom view or replaces its filter
+func (api *API) OverviewCustomViewModify(c *gin.Context) {
+	viewID, err := getViewIDFromContext(c)
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	var params CustomViewModifyParams
+	err = c.BindJSON(&params)
+	if err != nil || (params.Name == nil && params.Filter == nil) {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	updateFields := bson.M{}
+	if params.Name != nil {
+		name, ok := getValidCustomViewName(c, params.Name)
+		if !ok {
+			return
+		}
+		updateFields["name"] = name
+	}
+	if params.Filter != nil {
+		filter := api.getValidCustomViewFilter(c, userID, params.Filter)
+		if filter == nil {
+			return
+		}
+		updateFields["custom_filter"] = filter
+	}
+
+	result, err := database.GetViewCollection(api.DB).UpdateOne(
+		c.Request.Context(),
+		bson.M{"$and": []bson.M{
+			{"user_id": userID},
+			{"_id": viewID},
+			{"type": constants.ViewCustom},
+		}},
+		bson.M{"$set": updateFields},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to modify custom view")
+		Handle500(c)
+		return
+	}
+	if result.MatchedCount != 1 {
+		Handle404(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGetCustomViewTaskFilters(t *testing.T) {
+	startOfDay := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	minimumDueDate := primitive.NewDateTimeFromTime(time.Unix(63090000, 0))
+
+	t.Run("AllCriteria", func(t *testing.T) {
+		taskSectionID := primitive.NewObjectID()
+		filters := getCustomViewTaskFilters(&database.CustomViewFilter{
+			SourceIDs:     []string{external.TASK_SOURCE_ID_GT_TASK, external.TASK_SOURCE_ID_LINEAR},
+			TaskSectionID: taskSectionID,
+			Labels:        []string{"bug"},
+			DueWindow:     constants.CustomViewDueWindowNext7Days,
+		}, startOfDay)
+		assert.Equal(t, []bson.M{
+			{"source_id": bson.M{"$in": []string{external.TASK_SOURCE_ID_GT_TASK, external.TASK_SOURCE_ID_LINEAR}}},
+			{"id_task_section": taskSectionID},
+			{"labels": bson.M{"$in": []string{"bug"}}},
+			{"due_date": bson.M{"$gte": primitive.NewDateTimeFromTime(startOfDay), "$lt": primitive.NewDateTimeFromTime(startOfDay.AddDate(0, 0, 7))}},
+		}, filters)
+	})
+	t.Run("Overdue", func(t *testing.T) {
+		filters := getCustomViewTaskFilters(&database.CustomViewFilter{DueWindow: constants.CustomViewDueWindowOverdue}, startOfDay)
+		assert.Equal(t, []bson.M{
+			{"due_date": bson.M{"$gte": minimumDueDate, "$lt": primitive.NewDateTimeFromTime(startOfDay)}},
+		}, filters)
+	})
+	t.Run("NoDueDate", func(t *testing.T) {
+		filters := getCustomViewTaskFilters(&database.CustomViewFilter{DueWindow: constants.CustomViewDueWindowNoDueDate}, startOfDay)
+		assert.Equal(t, []bson.M{
+			{"$or": []bson.M{{"due_date": nil}, {"due_date": bson.M{"$lt": minimumDueDate}}}},
+		}, filters)
+	})
+}
+
+func TestOverviewCustomView(t *testing.T) {
+	authToken := login("test_custom_view@resonant-kelpie-404a42.netlify.app", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	viewCollection := database.GetViewCollection(api.DB)
+
+	createView := func(t *testing.T, body string, expectedStatus int) []byte {
+		return ServeRequest(t, authToken, http.MethodPost, "/overview/views/", bytes.NewBuffer([]byte(body)), expectedStatus, api)
+	}
+
+	t.Run("MissingName", func(t *testing.T) {
+		body := createView(t, `{"type": "custom", "filter": {"labels": ["bug"]}}`, http.StatusBadRequest)
+		assert.Equal(t, `{"detail":"'name' is required for custom type views","code":"invalid_parameter","field_errors":[{"field":"name"}]}`, string(body))
+	})
+	t.Run("EmptyFilter", func(t *testing.T) {
+		body := createView(t, `{"type": "custom", "name": "Bugs", "filter": {}}`, http.StatusBadRequest)
+		assert.Equal(t, `{"detail":"'filter' must include at least one of 'source_ids', 'task_section_id', 'labels' or 'due_window'","code":"invalid_parameter","field_errors":[{"field":"filter"},{"field":"source_ids"},{"field":"task_section_id"},{"field":"labels"},{"field":"due_window"}]}`, string(body))
+	})
+	t.Run("InvalidSource", func(t *testing.T) {
+		body := createView(t, `{"type": "custom", "name": "Bugs", "filter": {"source_ids": ["gabagool"]}}`, http.StatusBadRequest)
+		assert.Equal(t, `{"detail":"invalid 'source_ids'","code":"invalid_parameter","field_errors":[{"field":"source_ids"}]}`, string(body))
+	})
+	t.Run("OtherUsersTaskSection", func(t *testing.T) {
+		body := createView(t, fmt.Sprintf(`{"type": "custom", "name": "Bugs", "filter": {"task_section_id": "%s"}}`, primitive.NewObjectID().Hex()), http.StatusBadRequest)
+		assert.Equal(t, `{"detail":"'task_section_id' is not a valid ID","code":"invalid_parameter","field_errors":[{"field":"task_section_id"}]}`, string(body))
+	})
+	t.Run("InvalidDueWindow", func(t *testing.T) {
+		body := createView(t, `{"type": "custom", "name": "Bugs", "filter": {"due_window": "someday"}}`, http.StatusBadRequest)
+		assert.Equal(t, `{"detail":"invalid 'due_window'","code":"invalid_parameter","field_errors":[{"field":"due_window"}]}`, string(body))
+	})
+
+	var viewID primitive.ObjectID
+	t.Run("Success", func(t *testing.T) {
+		notCompleted := false
+		labels := []string{"bug", "frontend"}
+		otherLabels := []string{"docs"}
+		taskResult, err := database.GetTaskCollection(api.DB).InsertMany(context.Background(), []interface{}{
+			database.Task{UserID: userID, IsCompleted: &notCompleted, SourceID: external.TASK_SOURCE_ID_GT_TASK, Labels: &labels},
+			database.Task{UserID: userID, IsCompleted: &notCompleted, SourceID: external.TASK_SOURCE_ID_GT_TASK, Labels: &otherLabels},
+			database.Task{UserID: userID, IsCompleted: &notCompleted, SourceID: external.TASK_SOURCE_ID_LINEAR, Labels: &labels},
+		})
+		assert.NoError(t, err)
+		bugTaskID := taskResult.InsertedIDs[0].(primitive.ObjectID)
+
+		body := createView(t, fmt.Sprintf(`{"type": "custom", "name": " Bugs ", "filter": {"source_ids": ["%s"], "labels": ["bug"]}}`, external.TASK_SOURCE_ID_GT_TASK), http.StatusOK)
+		var result map[string]string
+		assert.NoError(t, json.Unmarshal(body, &result))
+		viewID, err = primitive.ObjectIDFromHex(result["id"])
+		assert.NoError(t, err)
+		// custom views aren't deduplicated
+		createView(t, fmt.Sprintf(`{"type": "custom", "name": "Bugs", "filter": {"source_ids": ["%s"], "labels": ["bug"]}}`, external.TASK_SOURCE_ID_GT_TASK), http.StatusOK)
+
+		var view database.View
+		assert.NoError(t, viewCollection.FindOne(context.Background(), bson.M{"_id": viewID}).Decode(&view))
+		assert.Equal(t, "Bugs", view.Name)
+		assert.Equal(t, &database.CustomViewFilter{SourceIDs: []string{external.TASK_SOURCE_ID_GT_TASK}, Labels: []string{"bug"}}, view.CustomFilter)
+
+		overviewResult, err := api.GetCustomOverviewResult(context.Background(), view, userID, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, "Bugs", overviewResult.Name)
+		assert.Equal(t, constants.ViewCustom, overviewResult.Type)
+		assert.Equal(t, []string{bugTaskID.Hex()}, overviewResult.ViewItemIDs)
+		assert.Equal(t, []string{"bug"}, overviewResult.CustomFilter.Labels)
+	})
+	t.Run("Modify", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPatch, fmt.Sprintf("/overview/views/%s/custom/", viewID.Hex()), bytes.NewBuffer([]byte(`{"name": "Docs", "filter": {"labels": ["docs"]}}`)), http.StatusOK, api)
+		var view database.View
+		assert.NoError(t, viewCollection.FindOne(context.Background(), bson.M{"_id": viewID}).Decode(&view))
+		assert.Equal(t, "Docs", view.Name)
+		assert.Equal(t, &database.CustomViewFilter{Labels: []string{"docs"}}, view.CustomFilter)
+	})
+	t.Run("ModifyInvalidFilter", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPatch, fmt.Sprintf("/overview/views/%s/custom/", viewID.Hex()), bytes.NewBuffer([]byte(`{"filter": {}}`)), http.StatusBadRequest, api)
+	})
+	t.Run("ModifyOtherViewType", func(t *testing.T) {
+		insertResult, err := viewCollection.InsertOne(context.Background(), database.View{UserID: userID, Type: string(constants.ViewDueToday)})
+		assert.NoError(t, err)
+		otherViewID := insertResult.InsertedID.(primitive.ObjectID)
+		ServeRequest(t, authToken, http.MethodPatch, fmt.Sprintf("/overview/views/%s/custom/", otherViewID.Hex()), bytes.NewBuffer([]byte(`{"name": "Docs"}`)), http.StatusNotFound, api)
+	})
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OverviewViewRefresh re-fetches only the service backing the view and returns the refreshed view,
+// so the client doesn't need to refetch the whole overview page
+func (api *API) OverviewViewRefresh(c *gin.Context) {
+	viewID, err := getViewIDFromContext(c)
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	timezoneOffset, err := api.getTimezoneOffset(c)
+	if err != nil {
+		HandleBadRequest(c, err.Error())
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	view, err := database.GetView(c.Request.Context(), api.DB, userID, viewID)
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	serviceID, err := getViewServiceID(view.Type)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to get view service")
+		Handle500(c)
+		return
+	}
+
+	failedFetchSources, err := api.refreshServiceItems(c.Request.Context(), userID, serviceID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to refresh view")
+		Handle500(c)
+		return
+	}
+	err = api.updateViewsLastFetched(c.Request.Context(), userID, []string{serviceID}, failedFetchSources)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+
+	// reload the view to pick up the new last fetched time
+	view, err = database.GetView(c.Request.Context(), api.DB, userID, viewID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	views := []database.View{*view}
+	err = api.UpdateViewsLinkedStatus(&views, userID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	result, err := api.GetOverviewResults(c.Request.Context(), views, userID, timezoneOffset, false, false)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to load refreshed view")
+		Handle500(c)
+		return
+	}
+	if len(result) == 0 {
+		// the view was removed while loading it, e.g. its task section was deleted
+		Handle404(c)
+		return
+	}
+	c.JSON(200, result[0])
+}
+
+// refreshServiceItems fetches the user's tasks or pull requests from just the one service,
+// completing any which are no longer returned by it
+func (api *API) refreshServiceItems(ctx context.Context, userID primitive.ObjectID, serviceID string) (map[string]bool, error) {
+	var tokens []database.ExternalAPIToken
+	if serviceID == external.TASK_SERVICE_ID_GT {
+		// dummy token for gt_task fetch logic
+		tokens = []database.ExternalAPIToken{{
+			AccountID: external.GeneralTaskDefaultAccountID,
+			ServiceID: external.TASK_SERVICE_ID_GT,
+		}}
+	} else {
+		serviceTokens, err := database.GetExternalTokens(ctx, api.DB, userID, serviceID)
+		if err != nil {
+			return nil, err
+		}
+		tokens = *serviceTokens
+	}
+
+	if serviceID == external.TASK_SERVICE_ID_GITHUB {
+		currentPRs, err := database.GetActivePRs(ctx, api.DB, userID)
+		if err != nil {
+			return nil, err
+		}
+		fetchedPRs, failedFetchSources, err := api.fetchPRs(ctx, userID, tokens)
+		if err != nil {
+			return nil, err
+		}
+		return failedFetchSources, api.adjustForCompletedPullRequests(ctx, api.DB, currentPRs, &fetchedPRs, failedFetchSources)
+	}
+
+	activeTasks, err := database.GetActiveTasks(ctx, api.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+	taskServiceResult, err := api.ExternalConfig.GetTaskServiceResult(serviceID)
+	if err != nil {
+		return nil, err
+	}
+	serviceSourceIDs := make(map[string]bool)
+	for _, taskSourceResult := range taskServiceResult.Sources {
+		serviceSourceIDs[taskSourceResult.Details.ID] = true
+	}
+	// only tasks from this service can be completed by the fetch
+	currentTasks := []database.Task{}
+	for _, task := range *activeTasks {
+		if serviceSourceIDs[task.SourceID] {
+			currentTasks = append(currentTasks, task)
+		}
+	}
+
+	fetchedTasks, failedFetchSources, err := api.fetchTasksForTokens(ctx, api.DB, userID, tokens, true)
+	if err != nil {
+		return nil, err
+	}
+	return failedFetchSources, api.adjustForCompletedTasks(ctx, api.DB, &currentTasks, fetchedTasks, failedFetchSources)
+}
+
+// updateViewsLastFetched marks the user's views backed by the services as fetched now,
+// skipping services which had a source fail to fetch
+func (api *API) updateViewsLastFetched(ctx context.Context, userID primitive.ObjectID, serviceIDs []string, failedFetchSources map[string]bool) error {
+	fetchedServiceIDs := make(map[string]bool)
+	for _, serviceID := range serviceIDs {
+		taskServiceResult, err := api.ExternalConfig.GetTaskServiceResult(serviceID)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("error loading task service")
+			return err
+		}
+		fetchedServiceIDs[serviceID] = true
+		for _, taskSourceResult := range taskServiceResult.Sources {
+			if failedFetchSources[taskSourceResult.Details.ID] {
+				fetchedServiceIDs[serviceID] = false
+			}
+		}
+	}
+
+	var views []database.View
+	err := database.FindWithCollection(ctx, database.GetViewCollection(api.DB), userID, nil, &views, nil)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to find views")
+		return err
+	}
+	viewIDs := []primitive.ObjectID{}
+	for _, view := range views {
+		serviceID, err := getViewServiceID(view.Type)
+		if err == nil && fetchedServiceIDs[serviceID] {
+			viewIDs = append(viewIDs, view.ID)
+		}
+	}
+	if len(viewIDs) == 0 {
+		return nil
+	}
+	return database.UpdateViewsLastFetched(ctx, api.DB, userID, &[]bson.M{{"_id": bson.M{"$in": viewIDs}}}, time.Now())
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGetViewServiceID(t *testing.T) {
+	serviceID, err := getViewServiceID(string(constants.ViewCustom))
+	assert.NoError(t, err)
+	assert.Equal(t, external.TASK_SERVICE_ID_GT, serviceID)
+	serviceID, err = getViewServiceID(string(constants.ViewJiraJQL))
+	assert.NoError(t, err)
+	assert.Equal(t, external.TASK_SERVICE_ID_ATLASSIAN, serviceID)
+	serviceID, err = getViewServiceID(string(constants.ViewGithub))
+	assert.NoError(t, err)
+	assert.Equal(t, external.TASK_SERVICE_ID_GITHUB, serviceID)
+	_, err = getViewServiceID("gabagool")
+	assert.EqualError(t, err, "invalid view type")
+}
+
+func TestSetOverviewResultLastFetched(t *testing.T) {
+	lastFetched := time.Date(2023, time.March, 1, 12, 30, 0, 0, time.UTC)
+	t.Run("NeverFetched", func(t *testing.T) {
+		result := &OverviewResult[TaskResult]{}
+		setOverviewResultLastFetched(result, 0)
+		assert.Equal(t, "", result.LastFetched)
+	})
+	t.Run("Tasks", func(t *testing.T) {
+		result := &OverviewResult[TaskResult]{}
+		setOverviewResultLastFetched(result, primitive.NewDateTimeFromTime(lastFetched))
+		assert.Equal(t, "2023-03-01T12:30:00Z", result.LastFetched)
+	})
+	t.Run("PullRequests", func(t *testing.T) {
+		result := &OverviewResult[PullRequestResult]{}
+		setOverviewResultLastFetched(result, primitive.NewDateTimeFromTime(lastFetched))
+		assert.Equal(t, "2023-03-01T12:30:00Z", result.LastFetched)
+	})
+}
+
+func TestOverviewViewRefresh(t *testing.T) {
+	authToken := login("test_overview_view_refresh@resonant-kelpie-404a42.netlify.app", "")
+	otherToken := login("test_overview_view_refresh_other@resonant-kelpie-404a42.netlify.app", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	viewCollection := database.GetViewCollection(api.DB)
+
+	insertResult, err := viewCollection.InsertMany(context.Background(), []interface{}{
+		database.View{UserID: userID, Type: string(constants.ViewDueToday), IsLinked: true},
+		database.View{UserID: userID, Type: string(constants.ViewOverdue), IsLinked: true},
+		database.View{UserID: userID, Type: string(constants.ViewGithub)},
+	})
+	assert.NoError(t, err)
+	dueTodayViewID := insertResult.InsertedIDs[0].(primitive.ObjectID)
+	overdueViewID := insertResult.InsertedIDs[1].(primitive.ObjectID)
+	githubViewID := insertResult.InsertedIDs[2].(primitive.ObjectID)
+
+	UnauthorizedTest(t, http.MethodPost, fmt.Sprintf("/overview/views/%s/refresh/", dueTodayViewID.Hex()), nil)
+	t.Run("InvalidViewID", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPost, "/overview/views/123/refresh/", nil, http.StatusNotFound, api)
+	})
+	t.Run("OtherUsersView", func(t *testing.T) {
+		ServeRequest(t, otherToken, http.MethodPost, fmt.Sprintf("/overview/views/%s/refresh/", dueTodayViewID.Hex()), nil, http.StatusNotFound, api)
+	})
+	t.Run("Success", func(t *testing.T) {
+		body := ServeRequest(t, authToken, http.MethodPost, fmt.Sprintf("/overview/views/%s/refresh/", dueTodayViewID.Hex()), nil, http.StatusOK, api)
+		var result OverviewResult[TaskResult]
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, dueTodayViewID, result.ID)
+		assert.Equal(t, constants.ViewDueToday, result.Type)
+		lastFetched, err := time.Parse(time.RFC3339, result.LastFetched)
+		assert.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), lastFetched, time.Minute)
+
+		// views backed by the same service are refreshed along with it
+		var overdueView database.View
+		assert.NoError(t, viewCollection.FindOne(context.Background(), bson.M{"_id": overdueViewID}).Decode(&overdueView))
+		assert.NotEqual(t, primitive.DateTime(0), overdueView.LastFetched)
+		var githubView database.View
+		assert.NoError(t, viewCollection.FindOne(context.Background(), bson.M{"_id": githubViewID}).Decode(&githubView))
+		assert.Equal(t, primitive.DateTime(0), githubView.LastFetched)
+	})
+}
@@ -2,7 +2,10 @@ package api
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"math/rand"
 	"regexp"
 	"strings"
@@ -12,8 +15,8 @@ import (
 	"github.com/franchizzle/task-manager/backend/config"
 	"github.com/franchizzle/task-manager/backend/constants"
 	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/llm"
 	"github.com/gin-gonic/gin"
-	gogpt "github.com/sashabaranov/go-gpt3"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -21,9 +24,19 @@ import (
 type GPTView struct {
 	ID        primitive.ObjectID `json:"id"`
 	Name      string             `json:"name"`
+	Type      constants.ViewType `json:"type"`
 	ViewItems []GPTTask          `json:"view_items"`
 }
 
+// built in views are described in the prompt, since their names alone don't say why the tasks are in them
+var gptViewDescriptions = map[constants.ViewType]string{
+	constants.ViewMeetingPreparation: "tasks to prepare for my meetings today",
+	constants.ViewDueToday:           "tasks due today or earlier",
+	constants.ViewOverdue:            "tasks past their due date",
+	constants.ViewAssignedToMe:       "tasks my coworkers have assigned to me",
+	constants.ViewWaitingOnOthers:    "tasks I have assigned to my coworkers and am waiting on",
+}
+
 type GPTTask struct {
 	Title string `json:"title"`
 }
@@ -33,6 +46,10 @@ type Suggestion struct {
 	Reasoning string             `json:"reasoning"`
 }
 
+type SuggestionReasoning struct {
+	Text string `json:"text"`
+}
+
 /******
 *
 * WARNING, EXPERIMENTAL
@@ -40,28 +57,106 @@ type Suggestion struct {
 *******/
 func (api *API) OverviewViewsSuggestion(c *gin.Context) {
 	userID := getUserIDFromContext(c)
-	user, err := database.GetUser(api.DB, userID)
+	user, timezoneOffset, gptViews, ok := api.getSuggestionViews(c, userID)
+	if !ok {
+		return
+	}
+	cacheKey := getSuggestionCacheKey(userID, gptViews)
+	if suggestions, exists := api.SuggestionCache.Get(cacheKey); exists {
+		c.JSON(200, suggestions)
+		return
+	}
+	prompt, ok := api.startSuggestion(c, user, timezoneOffset, gptViews)
+	if !ok {
+		return
+	}
+
+	completion, err := api.getLLMCompletion(c.Request.Context(), userID, llm.CompletionRequest{
+		MaxTokens:   3000,
+		Temperature: 0.2,
+		Prompt:      prompt,
+	})
 	if err != nil {
-		api.Logger.Error().Err(err).Msg("failed to find user")
+		api.Logger.Error().Err(err).Msg("failed to fetch suggestion")
 		Handle500(c)
 		return
 	}
-
-	timezoneOffset, err := GetTimezoneOffsetFromHeader(c)
+	suggestions, err := getSuggestionsFromCompletion(completion, gptViews)
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		api.Logger.Error().Err(err).Msg("failed to fetch suggestions")
+		Handle500(c)
 		return
 	}
+	api.SuggestionCache.Set(cacheKey, suggestions)
+	c.JSON(200, suggestions)
+}
 
-	suggestionsLeft, err := api.getRemainingSuggestionsForUser(user, timezoneOffset)
+// OverviewViewsSuggestionStream sends the completion as reasoning events while it's generated,
+// followed by a suggestions event with the same result as OverviewViewsSuggestion
+func (api *API) OverviewViewsSuggestionStream(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	user, timezoneOffset, gptViews, ok := api.getSuggestionViews(c, userID)
+	if !ok {
+		return
+	}
+	cacheKey := getSuggestionCacheKey(userID, gptViews)
+	cachedSuggestions, isCached := api.SuggestionCache.Get(cacheKey)
+	prompt := ""
+	if !isCached {
+		prompt, ok = api.startSuggestion(c, user, timezoneOffset, gptViews)
+		if !ok {
+			return
+		}
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(200)
+	if isCached {
+		c.SSEvent("suggestions", cachedSuggestions)
+		return
+	}
+	c.Writer.Flush()
+
+	completion, err := api.getLLMCompletionStream(c.Request.Context(), userID, llm.CompletionRequest{
+		MaxTokens:   3000,
+		Temperature: 0.2,
+		Prompt:      prompt,
+	}, func(text string) {
+		c.SSEvent("reasoning", SuggestionReasoning{Text: text})
+		c.Writer.Flush()
+	})
 	if err != nil {
-		c.JSON(400, gin.H{"error": "error fetching suggestions"})
+		api.Logger.Error().Err(err).Msg("failed to fetch suggestion")
+		c.SSEvent("error", gin.H{"error": "failed to fetch suggestions"})
 		return
 	}
-	if suggestionsLeft < 1 && !strings.HasSuffix(strings.ToLower(user.Email), "@resonant-kelpie-404a42.netlify.app") {
-		c.JSON(400, gin.H{"error": "no remaining suggestions for user"})
+	suggestions, err := getSuggestionsFromCompletion(completion, gptViews)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch suggestions")
+		c.SSEvent("error", gin.H{"error": "failed to fetch suggestions"})
 		return
 	}
+	api.SuggestionCache.Set(cacheKey, suggestions)
+	c.SSEvent("suggestions", suggestions)
+}
+
+// getSuggestionViews loads the user's views as they're described to GPT
+func (api *API) getSuggestionViews(c *gin.Context, userID primitive.ObjectID) (*database.User, time.Duration, []GPTView, bool) {
+	user, err := database.GetUser(c.Request.Context(), api.DB, userID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to find user")
+		Handle500(c)
+		return nil, 0, nil, false
+	}
+
+	timezoneOffset, err := api.getTimezoneOffset(c)
+	if err != nil {
+		HandleBadRequest(c, err.Error())
+		return nil, 0, nil, false
+	}
 
 	cursor, err := database.GetViewCollection(api.DB).Find(
 		context.Background(),
@@ -70,7 +165,7 @@ func (api *API) OverviewViewsSuggestion(c *gin.Context) {
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to find views")
 		Handle500(c)
-		return
+		return nil, 0, nil, false
 	}
 
 	var views []database.View
@@ -78,26 +173,26 @@ func (api *API) OverviewViewsSuggestion(c *gin.Context) {
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to find views")
 		Handle500(c)
-		return
+		return nil, 0, nil, false
 	}
 
 	showMovedOrDeleted, err := GetBooleanQueryParameter(c, constants.ShowMovedOrDeleted)
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
-		return
+		HandleBadRequest(c, err.Error())
+		return nil, 0, nil, false
 	}
 
 	ignoreMeetingPreparation, err := GetBooleanQueryParameter(c, constants.IgnoreMeetingPreparation)
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
-		return
+		HandleBadRequest(c, err.Error())
+		return nil, 0, nil, false
 	}
 
-	overviewResponse, err := api.GetOverviewResults(views, userID, timezoneOffset, showMovedOrDeleted, ignoreMeetingPreparation)
+	overviewResponse, err := api.GetOverviewResults(c.Request.Context(), views, userID, timezoneOffset, showMovedOrDeleted, ignoreMeetingPreparation)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to load views")
 		Handle500(c)
-		return
+		return nil, 0, nil, false
 	}
 
 	var gptViews []GPTView
@@ -105,65 +200,55 @@ func (api *API) OverviewViewsSuggestion(c *gin.Context) {
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("unable to marshal overview response")
 		Handle500(c)
-		return
+		return nil, 0, nil, false
 	}
 	err = json.Unmarshal(jsonBytes, &gptViews)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("error unmarshaling overview response")
 		Handle500(c)
-		return
+		return nil, 0, nil, false
 	}
+	return user, timezoneOffset, gptViews, true
+}
 
-	promptConstruction := ""
-	for _, gptView := range gptViews {
-		nameSanitized := sanitizeGPTString(gptView.Name)
-		promptConstruction = promptConstruction + `"` + nameSanitized + `" with tasks (`
-		for _, gptTask := range gptView.ViewItems {
-			promptConstruction = promptConstruction + `"` + gptTask.Title + `", `
-		}
-		promptConstruction = promptConstruction + `), `
+// startSuggestion builds the prompt and uses one of the user's suggestions for it
+func (api *API) startSuggestion(c *gin.Context, user *database.User, timezoneOffset time.Duration, gptViews []GPTView) (string, bool) {
+	hasSuggestionsLeft, err := api.hasGPTSuggestionsLeft(user, timezoneOffset)
+	if err != nil {
+		HandleBadRequest(c, "error fetching suggestions")
+		return "", false
+	}
+	if !hasSuggestionsLeft {
+		HandleBadRequest(c, "no remaining suggestions for user")
+		return "", false
 	}
 
-	if utf8.RuneCountInString(getPrompt(promptConstruction)) > 4000 {
-		api.Logger.Error().Err(err).Msg("prompt too long for suggestion")
-		c.JSON(400, gin.H{"error": "prompt is too long for suggestion"})
-		return
+	prompt := getPrompt(getGPTViewsPromptSection(gptViews))
+	if utf8.RuneCountInString(prompt) > constants.MAX_GPT_PROMPT_LENGTH {
+		api.Logger.Error().Msg("prompt too long for suggestion")
+		HandleBadRequest(c, "prompt is too long for suggestion")
+		return "", false
 	}
 
 	err = api.decrementGPTRemainingByOne(user, timezoneOffset)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("unable to decrement suggestions remaining")
 		Handle500(c)
-		return
-	}
-
-	token := config.GetConfigValue("OPEN_AI_CLIENT_SECRET")
-	client := gogpt.NewClient(token)
-	if api.ExternalConfig.OpenAIOverrideURL != "" {
-		client.BaseURL = api.ExternalConfig.OpenAIOverrideURL
-	}
-	ctx := context.Background()
-	req := gogpt.CompletionRequest{
-		Model:            gogpt.GPT3TextDavinci003,
-		MaxTokens:        3000,
-		Temperature:      0.2,
-		TopP:             1.0,
-		FrequencyPenalty: 0.0,
-		PresencePenalty:  0.0,
-		BestOf:           1,
-		Prompt:           getPrompt(promptConstruction),
+		return "", false
 	}
+	return prompt, true
+}
 
-	resp, err := client.CreateCompletion(ctx, req)
-	if err != nil {
-		api.Logger.Error().Err(err).Msg("failed to fetch suggestion")
-		Handle500(c)
-		return
-	}
+// getSuggestionCacheKey changes whenever a view is added, removed, renamed, or its tasks change
+func getSuggestionCacheKey(userID primitive.ObjectID, gptViews []GPTView) string {
+	composition, _ := json.Marshal(gptViews)
+	hash := sha256.Sum256(composition)
+	return userID.Hex() + ":" + hex.EncodeToString(hash[:])
+}
 
+func getSuggestionsFromCompletion(completion string, gptViews []GPTView) ([]Suggestion, error) {
 	response := []Suggestion{}
-	idx := 0
-	for _, suggestion := range strings.Split(resp.Choices[0].Text, "\n") {
+	for _, suggestion := range strings.Split(completion, "\n") {
 		suggestionResponse := Suggestion{}
 		if suggestion == "" {
 			continue
@@ -187,13 +272,10 @@ func (api *API) OverviewViewsSuggestion(c *gin.Context) {
 			}
 		}
 		response = append(response, suggestionResponse)
-		idx++
 	}
 
-	if len(response) != len(views) {
-		api.Logger.Error().Err(err).Msg("failed to fetch suggestions")
-		Handle500(c)
-		return
+	if len(response) != len(gptViews) {
+		return nil, fmt.Errorf("expected %d suggestions, got %d", len(gptViews), len(response))
 	}
 
 	// not most efficient, but easy to understand
@@ -221,8 +303,7 @@ func (api *API) OverviewViewsSuggestion(c *gin.Context) {
 			missingList = removeFromList(missingList, missingList[randomIndex].ID)
 		}
 	}
-
-	c.JSON(200, response)
+	return response, nil
 }
 
 func removeFromList(idList []GPTView, idToRemove primitive.ObjectID) []GPTView {
@@ -234,6 +315,23 @@ func removeFromList(idList []GPTView, idToRemove primitive.ObjectID) []GPTView {
 	return idList
 }
 
+func getGPTViewsPromptSection(gptViews []GPTView) string {
+	promptConstruction := ""
+	for _, gptView := range gptViews {
+		nameSanitized := sanitizeGPTString(gptView.Name)
+		promptConstruction = promptConstruction + `"` + nameSanitized + `" `
+		if description, ok := gptViewDescriptions[gptView.Type]; ok {
+			promptConstruction = promptConstruction + `containing ` + description + ` `
+		}
+		promptConstruction = promptConstruction + `with tasks (`
+		for _, gptTask := range gptView.ViewItems {
+			promptConstruction = promptConstruction + `"` + gptTask.Title + `", `
+		}
+		promptConstruction = promptConstruction + `), `
+	}
+	return promptConstruction
+}
+
 func getPrompt(sectionString string) string {
 	return `I have folders in which I keep tasks. The tasks in general are related to the folder. The folders are as follows: ` + sectionString + `
 	I am an employee at a startup, and I value efficient engineering, unblocking my coworkers before starting my own work, being prepared for meetings, and helping the company towards its goals. I would like to feel as productive as possible.
@@ -243,16 +341,16 @@ func getPrompt(sectionString string) string {
 
 func (api *API) OverviewViewsSuggestionsRemaining(c *gin.Context) {
 	userID := getUserIDFromContext(c)
-	user, err := database.GetUser(api.DB, userID)
+	user, err := database.GetUser(c.Request.Context(), api.DB, userID)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to find user")
 		Handle500(c)
 		return
 	}
 
-	timezoneOffset, err := GetTimezoneOffsetFromHeader(c)
+	timezoneOffset, err := api.getTimezoneOffset(c)
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		HandleBadRequest(c, err.Error())
 		return
 	}
 
@@ -267,11 +365,11 @@ func (api *API) OverviewViewsSuggestionsRemaining(c *gin.Context) {
 }
 
 func (api *API) getRemainingSuggestionsForUser(user *database.User, timezoneOffset time.Duration) (int, error) {
-	lastSuggestionPrim := user.GPTLastSuggestionTime
-	lastSuggestion := lastSuggestionPrim.Time().UTC()
-	refreshTime := time.Date(lastSuggestion.Year(), lastSuggestion.Month(), lastSuggestion.Day(), 23, 59, 59, 0, time.FixedZone("", 0))
-
+	// the quota refreshes at the end of the user's day, rather than at midnight UTC
 	timeNow := api.GetCurrentLocalizedTime(timezoneOffset)
+	lastSuggestion := user.GPTLastSuggestionTime.Time().In(timeNow.Location())
+	refreshTime := time.Date(lastSuggestion.Year(), lastSuggestion.Month(), lastSuggestion.Day(), 23, 59, 59, 0, timeNow.Location())
+
 	if timeNow.Sub(refreshTime) > 0 && user.GPTSuggestionsLeft != constants.MAX_OVERVIEW_SUGGESTION {
 		_, err := database.GetUserCollection(api.DB).UpdateOne(
 			context.Background(),
@@ -290,6 +388,50 @@ func (api *API) getRemainingSuggestionsForUser(user *database.User, timezoneOffs
 	return user.GPTSuggestionsLeft, nil
 }
 
+// hasGPTSuggestionsLeft checks the user's daily GPT quota, which is shared by every GPT powered feature
+func (api *API) hasGPTSuggestionsLeft(user *database.User, timezoneOffset time.Duration) (bool, error) {
+	suggestionsLeft, err := api.getRemainingSuggestionsForUser(user, timezoneOffset)
+	if err != nil {
+		return false, err
+	}
+	return suggestionsLeft > 0 || strings.HasSuffix(strings.ToLower(user.Email), "@resonant-kelpie-404a42.netlify.app"), nil
+}
+
+// getLLMCompletion completes the prompt with the configured provider and records the tokens it used
+func (api *API) getLLMCompletion(ctx context.Context, userID primitive.ObjectID, request llm.CompletionRequest) (string, error) {
+	completion, err := api.LLMClient.Complete(ctx, request)
+	if err != nil {
+		return "", err
+	}
+	api.recordLLMUsage(ctx, userID, completion.Usage)
+	return completion.Text, nil
+}
+
+func (api *API) getLLMCompletionStream(ctx context.Context, userID primitive.ObjectID, request llm.CompletionRequest, onText func(text string)) (string, error) {
+	completion, err := api.LLMClient.CompleteStream(ctx, request, onText)
+	if err != nil {
+		return "", err
+	}
+	api.recordLLMUsage(ctx, userID, completion.Usage)
+	return completion.Text, nil
+}
+
+func (api *API) recordLLMUsage(ctx context.Context, userID primitive.ObjectID, usage llm.Usage) {
+	date := api.GetCurrentTime().Format(constants.YEAR_MONTH_DAY_FORMAT)
+	err := database.IncrementLLMUsage(ctx, api.DB, userID, api.LLMClient.Provider(), api.LLMClient.Model(), date, usage.PromptTokens, usage.CompletionTokens)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to record llm usage")
+	}
+}
+
+func getOverviewSuggestionCacheTTL() time.Duration {
+	ttlMinutes := config.GetSettings().OverviewSuggestionCacheTTLMinutes
+	if ttlMinutes < 1 {
+		return constants.DEFAULT_OVERVIEW_SUGGESTION_CACHE_TTL
+	}
+	return time.Duration(ttlMinutes) * time.Minute
+}
+
 func sanitizeGPTString(name string) string {
 	// from https://www.golangprograms.com/how-to-remove-special-characters-from-a-string-in-golang.html
 	// remove special characters from the string to prevent prompt hacking
@@ -12,6 +12,7 @@ import (
 	"github.com/franchizzle/task-manager/backend/constants"
 	"github.com/franchizzle/task-manager/backend/database"
 	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/franchizzle/task-manager/backend/llm"
 	"github.com/franchizzle/task-manager/backend/testutils"
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/bson"
@@ -27,7 +28,7 @@ func TestOverviewSuggestions(t *testing.T) {
 
 	t.Run("NoTokens", func(t *testing.T) {
 		server := testutils.GetMockAPIServer(t, http.StatusOK, `{"id": "1", "choices": [{"text": "1. Task Inbox: This is the reasoning\n2. Linear Issues: Reasoning 2\n3. Slack Messages: Reasoning 3"}]}`)
-		api.ExternalConfig.OpenAIOverrideURL = server.URL
+		api.LLMClient = llm.NewOpenAIClient(llm.Config{BaseURL: server.URL})
 		currentTime := time.Now().UTC()
 		api.OverrideTime = &currentTime
 
@@ -47,7 +48,7 @@ func TestOverviewSuggestions(t *testing.T) {
 
 	t.Run("InvalidResponse", func(t *testing.T) {
 		server := testutils.GetMockAPIServer(t, http.StatusOK, `{"id": "1", "choices": [{"text": "1. Task Inbox: This is the reasoning"}]}`)
-		api.ExternalConfig.OpenAIOverrideURL = server.URL
+		api.LLMClient = llm.NewOpenAIClient(llm.Config{BaseURL: server.URL})
 		currentTime := time.Now().UTC()
 		api.OverrideTime = &currentTime
 
@@ -72,7 +73,7 @@ func TestOverviewSuggestions(t *testing.T) {
 
 	t.Run("PromptTooLong", func(t *testing.T) {
 		server := testutils.GetMockAPIServer(t, http.StatusOK, `{"id": "1", "choices": [{"text": "1. Task Inbox: This is the reasoning\n2. Linear Issues: Reasoning 2\n3. Slack Messages: Reasoning 3"}]}`)
-		api.ExternalConfig.OpenAIOverrideURL = server.URL
+		api.LLMClient = llm.NewOpenAIClient(llm.Config{BaseURL: server.URL})
 		currentTime := time.Now().UTC()
 		api.OverrideTime = &currentTime
 
@@ -110,13 +111,13 @@ func TestOverviewSuggestions(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		responseBody := fmt.Sprint(`{"error":"prompt is too long for suggestion"}`)
+		responseBody := fmt.Sprint(`{"detail":"prompt is too long for suggestion","code":"bad_request"}`)
 		assert.Equal(t, responseBody, string(body))
 	})
 
 	t.Run("Success", func(t *testing.T) {
 		server := testutils.GetMockAPIServer(t, http.StatusOK, `{"id": "1", "choices": [{"text": "1. Task Inbox: This is the reasoning\n2. Linear Issues: Reasoning 2\n3. Slack Messages: Reasoning 3"}]}`)
-		api.ExternalConfig.OpenAIOverrideURL = server.URL
+		api.LLMClient = llm.NewOpenAIClient(llm.Config{BaseURL: server.URL})
 		currentTime := time.Now().UTC()
 		api.OverrideTime = &currentTime
 
@@ -141,8 +142,68 @@ func TestOverviewSuggestions(t *testing.T) {
 		err = userCollection.FindOne(context.Background(), bson.M{"email": "test_overview_suggestion@resonant-kelpie-404a42.netlify.app"}).Decode(&resultUser)
 		assert.NoError(t, err)
 		assert.Equal(t, constants.MAX_OVERVIEW_SUGGESTION-1, resultUser.GPTSuggestionsLeft)
+
+		t.Run("Cached", func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			cachedBody, err := io.ReadAll(recorder.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, string(body), string(cachedBody))
+
+			err = userCollection.FindOne(context.Background(), bson.M{"email": "test_overview_suggestion@resonant-kelpie-404a42.netlify.app"}).Decode(&resultUser)
+			assert.NoError(t, err)
+			assert.Equal(t, constants.MAX_OVERVIEW_SUGGESTION-1, resultUser.GPTSuggestionsLeft)
+		})
 	})
 
+	t.Run("Stream", func(t *testing.T) {
+		server := testutils.GetMockAPIServer(t, http.StatusOK, "data: {\"choices\": [{\"text\": \"1. Task Inbox: This is the reasoning\\n\"}]}\n\n"+
+			"data: {\"choices\": [{\"text\": \"2. Linear Issues: Reasoning 2\\n3. Slack Messages: Reasoning 3\"}]}\n\n"+
+			"data: [DONE]\n\n")
+		api.LLMClient = llm.NewOpenAIClient(llm.Config{BaseURL: server.URL})
+		currentTime := time.Now().UTC()
+		api.OverrideTime = &currentTime
+
+		authtoken := login("test_overview_suggestion_stream@resonant-kelpie-404a42.netlify.app", "")
+		request, _ := http.NewRequest("GET", "/overview/views/suggestion/stream/", nil)
+		request.Header.Set("Authorization", "Bearer "+authtoken)
+		request.Header.Set("Timezone-Offset", "0")
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "text/event-stream", recorder.Header().Get("Content-Type"))
+		body, err := io.ReadAll(recorder.Body)
+		assert.NoError(t, err)
+		assert.Contains(t, string(body), "event:reasoning\ndata:{\"text\":\"1. Task Inbox: This is the reasoning\\n\"}\n\n")
+		assert.Regexp(t, `event:suggestions\ndata:\[{"id":"[a-z0-9]{24}","reasoning":"This is the reasoning"},{"id":"[a-z0-9]{24}","reasoning":"Reasoning 2"},{"id":"[a-z0-9]{24}","reasoning":"Reasoning 3"}\]`, string(body))
+
+		recorder = httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		cachedBody, err := io.ReadAll(recorder.Body)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(cachedBody), "event:reasoning")
+		assert.Contains(t, string(body), string(cachedBody))
+	})
+}
+
+func TestGetSuggestionCacheKey(t *testing.T) {
+	userID := primitive.NewObjectID()
+	views := []GPTView{{ID: primitive.NewObjectID(), Name: "Task Inbox", ViewItems: []GPTTask{{Title: "Write blog post"}}}}
+	key := getSuggestionCacheKey(userID, views)
+	assert.Equal(t, key, getSuggestionCacheKey(userID, []GPTView{{ID: views[0].ID, Name: "Task Inbox", ViewItems: []GPTTask{{Title: "Write blog post"}}}}))
+	assert.NotEqual(t, key, getSuggestionCacheKey(primitive.NewObjectID(), views))
+	assert.NotEqual(t, key, getSuggestionCacheKey(userID, []GPTView{{ID: views[0].ID, Name: "Task Inbox", ViewItems: []GPTTask{{Title: "Fix login bug"}}}}))
+}
+
+func TestGetGPTViewsPromptSection(t *testing.T) {
+	views := []GPTView{
+		{Name: "Task Inbox!", Type: constants.ViewTaskSection, ViewItems: []GPTTask{{Title: "Write blog post"}}},
+		{Name: "Overdue", Type: constants.ViewOverdue, ViewItems: []GPTTask{{Title: "File taxes"}, {Title: "Renew passport"}}},
+	}
+	assert.Equal(t, `"Task Inbox" with tasks ("Write blog post", ), "Overdue" containing tasks past their due date with tasks ("File taxes", "Renew passport", ), `, getGPTViewsPromptSection(views))
 }
 
 func TestOverviewRemaining(t *testing.T) {
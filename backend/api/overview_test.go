@@ -7,12 +7,16 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/franchizzle/task-manager/backend/constants"
 	"github.com/franchizzle/task-manager/backend/database"
 	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/franchizzle/task-manager/backend/ordering"
+	"github.com/franchizzle/task-manager/backend/settings"
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -35,7 +39,26 @@ func TestOverview(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, `{"error":"invalid or missing parameter"}`, string(body))
+		assert.Equal(t, `{"detail":"invalid or missing parameter","code":"bad_request"}`, string(body))
+	})
+	t.Run("NotModified", func(t *testing.T) {
+		request, _ := http.NewRequest("GET", "/overview/views/", nil)
+		request.Header.Set("Authorization", "Bearer "+authtoken)
+		request.Header.Set("Timezone-Offset", "420")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		etag := recorder.Header().Get("ETag")
+		assert.NotEmpty(t, etag)
+
+		request, _ = http.NewRequest("GET", "/overview/views/", nil)
+		request.Header.Set("Authorization", "Bearer "+authtoken)
+		request.Header.Set("Timezone-Offset", "420")
+		request.Header.Set("If-None-Match", etag)
+		recorder = httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusNotModified, recorder.Code)
+		assert.Empty(t, recorder.Body.String())
 	})
 	t.Run("ValidShowMovedOrDeletedQueryParam", func(t *testing.T) {
 		// True
@@ -99,7 +122,7 @@ func TestOverview(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, `{"error":"Timezone-Offset header is required"}`, string(body))
+		assert.Equal(t, `{"detail":"Timezone-Offset header is required","code":"bad_request"}`, string(body))
 	})
 
 }
@@ -109,13 +132,13 @@ func TestGetOverviewResults(t *testing.T) {
 	defer dbCleanup()
 
 	t.Run("NoViews", func(t *testing.T) {
-		result, err := api.GetOverviewResults([]database.View{}, primitive.NewObjectID(), 0, true, false)
+		result, err := api.GetOverviewResults(context.Background(), []database.View{}, primitive.NewObjectID(), 0, true, false)
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		assert.Equal(t, 0, len(result))
 	})
 	t.Run("InvalidViewType", func(t *testing.T) {
-		result, err := api.GetOverviewResults([]database.View{{
+		result, err := api.GetOverviewResults(context.Background(), []database.View{{
 			Type: "invalid",
 		}}, primitive.NewObjectID(), 0, true, false)
 		assert.Error(t, err)
@@ -153,7 +176,7 @@ func TestGetOverviewResults(t *testing.T) {
 		})
 		assert.NoError(t, err)
 
-		result, err := api.GetOverviewResults(views, userID, 0, true, false)
+		result, err := api.GetOverviewResults(context.Background(), views, userID, 0, true, false)
 		expectedViewResult := OverviewResult[TaskResult]{
 			ID:            views[0].ID,
 			Name:          taskSectionName,
@@ -218,7 +241,7 @@ func TestGetTaskSectionOverviewResult(t *testing.T) {
 	}
 
 	t.Run("EmptyViewItems", func(t *testing.T) {
-		result, err := api.GetTaskSectionOverviewResult(view, userID, 0)
+		result, err := api.GetTaskSectionOverviewResult(context.Background(), view, userID, 0)
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		expectedViewResult.ViewItems = []*TaskResult{}
@@ -268,7 +291,7 @@ func TestGetTaskSectionOverviewResult(t *testing.T) {
 
 		api, dbCleanup := GetAPIWithDBCleanup()
 		defer dbCleanup()
-		result, err := api.GetTaskSectionOverviewResult(view, userID, 0)
+		result, err := api.GetTaskSectionOverviewResult(context.Background(), view, userID, 0)
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		// Check results are in the correct order, and the IDOrderings begin at 1
@@ -290,14 +313,14 @@ func TestGetTaskSectionOverviewResult(t *testing.T) {
 		assertOverviewViewResultEqual(t, expectedViewResult, *result)
 	})
 	t.Run("InvalidUser", func(t *testing.T) {
-		result, err := api.GetTaskSectionOverviewResult(view, primitive.NewObjectID(), 0)
+		result, err := api.GetTaskSectionOverviewResult(context.Background(), view, primitive.NewObjectID(), 0)
 		assert.Error(t, err)
 		assert.Equal(t, "invalid user", err.Error())
 		assert.Nil(t, result)
 	})
 	t.Run("InvalidSectionIDGracefullyHandled", func(t *testing.T) {
 		view.TaskSectionID = primitive.NewObjectID()
-		result, err := api.GetTaskSectionOverviewResult(view, userID, 0)
+		result, err := api.GetTaskSectionOverviewResult(context.Background(), view, userID, 0)
 		assert.NoError(t, err)
 		assert.Nil(t, result)
 	})
@@ -342,7 +365,7 @@ func TestGetJiraOverviewResult(t *testing.T) {
 		TaskSectionID: primitive.NilObjectID,
 	}
 	t.Run("EmptyViewItems", func(t *testing.T) {
-		result, err := api.GetJiraOverviewResult(view, userID, 0)
+		result, err := api.GetJiraOverviewResult(context.Background(), view, userID, 0)
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		expectedViewResult.ViewItems = []*TaskResult{}
@@ -400,7 +423,7 @@ func TestGetJiraOverviewResult(t *testing.T) {
 		assert.NoError(t, err)
 
 		taskID := taskResult.InsertedID.(primitive.ObjectID)
-		result, err := api.GetJiraOverviewResult(view, userID, 0)
+		result, err := api.GetJiraOverviewResult(context.Background(), view, userID, 0)
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		expectedViewResult.ViewItems = []*TaskResult{
@@ -413,14 +436,14 @@ func TestGetJiraOverviewResult(t *testing.T) {
 		assertOverviewViewResultEqual(t, expectedViewResult, *result)
 	})
 	t.Run("InvalidUser", func(t *testing.T) {
-		result, err := api.GetJiraOverviewResult(view, primitive.NewObjectID(), 0)
+		result, err := api.GetJiraOverviewResult(context.Background(), view, primitive.NewObjectID(), 0)
 		assert.Error(t, err)
 		assert.Equal(t, "invalid user", err.Error())
 		assert.Nil(t, result)
 	})
 	t.Run("ViewNotLinked", func(t *testing.T) {
 		view.IsLinked = false
-		result, err := api.GetJiraOverviewResult(view, userID, 0)
+		result, err := api.GetJiraOverviewResult(context.Background(), view, userID, 0)
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		expectedViewResult.IsLinked = false
@@ -470,7 +493,7 @@ func TestGetLinearOverviewResult(t *testing.T) {
 		TaskSectionID: primitive.NilObjectID,
 	}
 	t.Run("EmptyViewItems", func(t *testing.T) {
-		result, err := api.GetLinearOverviewResult(view, userID, 0)
+		result, err := api.GetLinearOverviewResult(context.Background(), view, userID, 0)
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		expectedViewResult.ViewItems = []*TaskResult{}
@@ -528,7 +551,7 @@ func TestGetLinearOverviewResult(t *testing.T) {
 		assert.NoError(t, err)
 
 		taskID := taskResult.InsertedID.(primitive.ObjectID)
-		result, err := api.GetLinearOverviewResult(view, userID, 0)
+		result, err := api.GetLinearOverviewResult(context.Background(), view, userID, 0)
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		expectedViewResult.ViewItems = []*TaskResult{
@@ -540,15 +563,60 @@ func TestGetLinearOverviewResult(t *testing.T) {
 		expectedViewResult.HasTasksCompletedToday = true
 		assertOverviewViewResultEqual(t, expectedViewResult, *result)
 	})
+	t.Run("CycleFilterAndSort", func(t *testing.T) {
+		taskCollection := database.GetTaskCollection(api.DB)
+		notCompleted := false
+		nextCycleResult, err := taskCollection.InsertOne(context.Background(), database.Task{
+			UserID:      userID,
+			IsCompleted: &notCompleted,
+			SourceID:    external.TASK_SOURCE_ID_LINEAR,
+			LinearCycle: database.LinearCycle{
+				ID:          "next-cycle",
+				StartsAt:    primitive.NewDateTimeFromTime(time.Now().Add(7 * 24 * time.Hour)),
+				IsNextCycle: true,
+			},
+		})
+		assert.NoError(t, err)
+		currentCycleResult, err := taskCollection.InsertOne(context.Background(), database.Task{
+			UserID:      userID,
+			IsCompleted: &notCompleted,
+			SourceID:    external.TASK_SOURCE_ID_LINEAR,
+			LinearCycle: database.LinearCycle{
+				ID:             "current-cycle",
+				StartsAt:       primitive.NewDateTimeFromTime(time.Now()),
+				IsCurrentCycle: true,
+			},
+		})
+		assert.NoError(t, err)
+		nextCycleTaskID := nextCycleResult.InsertedID.(primitive.ObjectID)
+		currentCycleTaskID := currentCycleResult.InsertedID.(primitive.ObjectID)
+		noCycleTaskID := expectedViewResult.ViewItems[0].ID
+
+		filteredView := view
+		filteredView.LinearCycleFilter = constants.LinearCycleFilterCurrent
+		result, err := api.GetLinearOverviewResult(context.Background(), filteredView, userID, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{currentCycleTaskID.Hex()}, result.ViewItemIDs)
+		assert.Equal(t, constants.LinearCycleFilterCurrent, result.LinearCycleFilter)
+
+		sortedView := view
+		sortedView.LinearSortBy = constants.LinearSortByCycle
+		result, err = api.GetLinearOverviewResult(context.Background(), sortedView, userID, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{currentCycleTaskID.Hex(), nextCycleTaskID.Hex(), noCycleTaskID.Hex()}, result.ViewItemIDs)
+
+		_, err = taskCollection.DeleteMany(context.Background(), bson.M{"_id": bson.M{"$in": []primitive.ObjectID{nextCycleTaskID, currentCycleTaskID}}})
+		assert.NoError(t, err)
+	})
 	t.Run("InvalidUser", func(t *testing.T) {
-		result, err := api.GetLinearOverviewResult(view, primitive.NewObjectID(), 0)
+		result, err := api.GetLinearOverviewResult(context.Background(), view, primitive.NewObjectID(), 0)
 		assert.Error(t, err)
 		assert.Equal(t, "invalid user", err.Error())
 		assert.Nil(t, result)
 	})
 	t.Run("ViewNotLinked", func(t *testing.T) {
 		view.IsLinked = false
-		result, err := api.GetLinearOverviewResult(view, userID, 0)
+		result, err := api.GetLinearOverviewResult(context.Background(), view, userID, 0)
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		expectedViewResult.IsLinked = false
@@ -597,7 +665,7 @@ func TestGetSlackOverviewResult(t *testing.T) {
 		TaskSectionID: primitive.NilObjectID,
 	}
 	t.Run("EmptyViewItems", func(t *testing.T) {
-		result, err := api.GetSlackOverviewResult(view, userID, 0)
+		result, err := api.GetSlackOverviewResult(context.Background(), view, userID, 0)
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		expectedViewResult.ViewItems = []*TaskResult{}
@@ -655,7 +723,7 @@ func TestGetSlackOverviewResult(t *testing.T) {
 		assert.NoError(t, err)
 
 		taskID := taskResult.InsertedID.(primitive.ObjectID)
-		result, err := api.GetSlackOverviewResult(view, userID, 0)
+		result, err := api.GetSlackOverviewResult(context.Background(), view, userID, 0)
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		expectedViewResult.ViewItems = []*TaskResult{
@@ -668,14 +736,14 @@ func TestGetSlackOverviewResult(t *testing.T) {
 		assertOverviewViewResultEqual(t, expectedViewResult, *result)
 	})
 	t.Run("InvalidUser", func(t *testing.T) {
-		result, err := api.GetSlackOverviewResult(view, primitive.NewObjectID(), 0)
+		result, err := api.GetSlackOverviewResult(context.Background(), view, primitive.NewObjectID(), 0)
 		assert.Error(t, err)
 		assert.Equal(t, "invalid user", err.Error())
 		assert.Nil(t, result)
 	})
 	t.Run("ViewNotLinked", func(t *testing.T) {
 		view.IsLinked = false
-		result, err := api.GetSlackOverviewResult(view, userID, 0)
+		result, err := api.GetSlackOverviewResult(context.Background(), view, userID, 0)
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		expectedViewResult.IsLinked = false
@@ -749,7 +817,7 @@ func TestGetGithubOverviewResult(t *testing.T) {
 		TaskSectionID: primitive.NilObjectID,
 	}
 	t.Run("EmptyViewItems", func(t *testing.T) {
-		result, err := api.GetGithubOverviewResult(view, userID, 0)
+		result, err := api.GetGithubOverviewResult(context.Background(), view, userID, 0)
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		expectedViewResult.ViewItems = []*PullRequestResult{}
@@ -825,7 +893,7 @@ func TestGetGithubOverviewResult(t *testing.T) {
 		pullRequestID := pullResult.InsertedID.(primitive.ObjectID)
 		pullRequestID2 := pullResult2.InsertedID.(primitive.ObjectID)
 		pullRequestID3 := pullResult3.InsertedID.(primitive.ObjectID)
-		result, err := api.GetGithubOverviewResult(view, userID, 0)
+		result, err := api.GetGithubOverviewResult(context.Background(), view, userID, 0)
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		// verify sorting is happening (more thorough tests exists for the PR endpoint)
@@ -855,14 +923,14 @@ func TestGetGithubOverviewResult(t *testing.T) {
 		assert.Equal(t, expectedViewResult.ViewItems[0].Comments, result.ViewItems[0].Comments)
 	})
 	t.Run("InvalidUser", func(t *testing.T) {
-		result, err := api.GetGithubOverviewResult(view, primitive.NewObjectID(), 0)
+		result, err := api.GetGithubOverviewResult(context.Background(), view, primitive.NewObjectID(), 0)
 		assert.Error(t, err)
 		assert.Equal(t, "invalid user", err.Error())
 		assert.Nil(t, result)
 	})
 	t.Run("ViewNotLinked", func(t *testing.T) {
 		view.IsLinked = false
-		result, err := api.GetGithubOverviewResult(view, userID, 0)
+		result, err := api.GetGithubOverviewResult(context.Background(), view, userID, 0)
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		expectedViewResult.IsLinked = false
@@ -889,6 +957,7 @@ func TestGetMeetingPreparationOverviewResult(t *testing.T) {
 	testTime := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
 	timezoneOffset := time.Hour * 12
 	api.OverrideTime = &testTime
+	setAllDayWorkingHours(t, api, userID)
 
 	timeOneHourAgo := api.GetCurrentTime().Add(-1 * time.Hour)
 	timeOneHourLater := api.GetCurrentTime().Add(1 * time.Hour)
@@ -898,7 +967,7 @@ func TestGetMeetingPreparationOverviewResult(t *testing.T) {
 	timeOneDayLater := api.GetCurrentTime().Add(24 * time.Hour)
 	timeZero := time.Date(0, 0, 0, 0, 0, 0, 0, time.UTC)
 
-	_, err = database.UpdateOrCreateCalendarAccount(db, userID, "123abc", "foobar_source",
+	_, err = database.UpdateOrCreateCalendarAccount(context.Background(), db, userID, "123abc", "foobar_source",
 		&database.CalendarAccount{
 			UserID:     userID,
 			IDExternal: "acctid",
@@ -916,13 +985,13 @@ func TestGetMeetingPreparationOverviewResult(t *testing.T) {
 	assert.NoError(t, err)
 
 	t.Run("InvalidUser", func(t *testing.T) {
-		res, err := api.GetMeetingPreparationOverviewResult(view, primitive.NewObjectID(), timezoneOffset, true, false)
+		res, err := api.GetMeetingPreparationOverviewResult(context.Background(), view, primitive.NewObjectID(), timezoneOffset, true, false)
 		assert.Error(t, err)
 		assert.Equal(t, "invalid user", err.Error())
 		assert.Nil(t, res)
 	})
 	t.Run("NoEvents", func(t *testing.T) {
-		res, err := api.GetMeetingPreparationOverviewResult(view, userID, timezoneOffset, true, false)
+		res, err := api.GetMeetingPreparationOverviewResult(context.Background(), view, userID, timezoneOffset, true, false)
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
 		assert.Equal(t, 0, len(res.ViewItems))
@@ -930,7 +999,7 @@ func TestGetMeetingPreparationOverviewResult(t *testing.T) {
 	t.Run("EventStartTimeHasPassed", func(t *testing.T) {
 		_, err := createTestEvent(calendarEventCollection, userID, "coffee", primitive.NewObjectID().Hex(), timeOneHourAgo, timeOneHourLater, primitive.NilObjectID, "acctid", "calid")
 		assert.NoError(t, err)
-		res, err := api.GetMeetingPreparationOverviewResult(view, userID, timezoneOffset, true, false)
+		res, err := api.GetMeetingPreparationOverviewResult(context.Background(), view, userID, timezoneOffset, true, false)
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
 		assert.Equal(t, 0, len(res.ViewItems))
@@ -940,7 +1009,7 @@ func TestGetMeetingPreparationOverviewResult(t *testing.T) {
 		assert.NoError(t, err)
 		_, err = createTestEvent(calendarEventCollection, userID, "chat", primitive.NewObjectID().Hex(), timeEarlyTomorrow, timeEarlyTomorrow, primitive.NilObjectID, "acctid", "calid")
 		assert.NoError(t, err)
-		res, err := api.GetMeetingPreparationOverviewResult(view, userID, timezoneOffset, true, false)
+		res, err := api.GetMeetingPreparationOverviewResult(context.Background(), view, userID, timezoneOffset, true, false)
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
 		assert.Equal(t, 0, len(res.ViewItems))
@@ -951,7 +1020,7 @@ func TestGetMeetingPreparationOverviewResult(t *testing.T) {
 		// shouldn't show task to cal events
 		_, err = createTestEvent(calendarEventCollection, userID, "EventTask", primitive.NewObjectID().Hex(), timeOneHourLater, timeOneDayLater, primitive.NewObjectID(), "acctid", "calid")
 		assert.NoError(t, err)
-		res, err := api.GetMeetingPreparationOverviewResult(view, userID, timezoneOffset, true, false)
+		res, err := api.GetMeetingPreparationOverviewResult(context.Background(), view, userID, timezoneOffset, true, false)
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
 		assert.Equal(t, 1, len(res.ViewItems))
@@ -967,7 +1036,7 @@ func TestGetMeetingPreparationOverviewResult(t *testing.T) {
 		_, err = createTestMeetingPreparationTask(taskCollection, userID, "Event2", idExternal, false, timeTwoHoursLater, timeOneDayLater, insertResult.InsertedID.(primitive.ObjectID))
 		assert.NoError(t, err)
 
-		res, err := api.GetMeetingPreparationOverviewResult(view, userID, timezoneOffset, true, false)
+		res, err := api.GetMeetingPreparationOverviewResult(context.Background(), view, userID, timezoneOffset, true, false)
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
 		assert.Equal(t, 2, len(res.ViewItems))
@@ -985,7 +1054,7 @@ func TestGetMeetingPreparationOverviewResult(t *testing.T) {
 		insertResult, err := createTestMeetingPreparationTask(taskCollection, userID, "reticulate splines", idExternal, false, timeZero, timeZero, primitive.NilObjectID)
 		assert.NoError(t, err)
 
-		res, err := api.GetMeetingPreparationOverviewResult(view, userID, timezoneOffset, true, false)
+		res, err := api.GetMeetingPreparationOverviewResult(context.Background(), view, userID, timezoneOffset, true, false)
 		assert.NoError(t, err)
 
 		var item database.Task
@@ -1003,7 +1072,7 @@ func TestGetMeetingPreparationOverviewResult(t *testing.T) {
 		insertResult, err := createTestMeetingPreparationTask(taskCollection, userID, "to the moon", primitive.NewObjectID().Hex(), true, timeZero, timeZero, primitive.NilObjectID)
 		assert.NoError(t, err)
 
-		res, err := api.GetMeetingPreparationOverviewResult(view, userID, timezoneOffset, true, false)
+		res, err := api.GetMeetingPreparationOverviewResult(context.Background(), view, userID, timezoneOffset, true, false)
 		assert.NoError(t, err)
 
 		var item database.Task
@@ -1021,7 +1090,7 @@ func TestGetMeetingPreparationOverviewResult(t *testing.T) {
 		insertResult, err := createTestMeetingPreparationTask(taskCollection, userID, "Event3", idExternal, false, timeTwoHoursLater, timeOneDayLater, primitive.NewObjectID())
 		assert.NoError(t, err)
 
-		res, err := api.GetMeetingPreparationOverviewResult(view, userID, timezoneOffset, false, false)
+		res, err := api.GetMeetingPreparationOverviewResult(context.Background(), view, userID, timezoneOffset, false, false)
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
 		// Event3 shouldn't be included in the results
@@ -1041,7 +1110,7 @@ func TestGetMeetingPreparationOverviewResult(t *testing.T) {
 		insertResult, err := createTestMeetingPreparationTask(taskCollection, userID, "Event3", idExternal, false, timeTwoHoursLater, timeOneDayLater, primitive.NewObjectID())
 		assert.NoError(t, err)
 
-		res, err := api.GetMeetingPreparationOverviewResult(view, userID, timezoneOffset, true, false)
+		res, err := api.GetMeetingPreparationOverviewResult(context.Background(), view, userID, timezoneOffset, true, false)
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
 
@@ -1081,7 +1150,7 @@ func TestGetMeetingPreparationOverviewResult(t *testing.T) {
 		_, err = calendarEventCollection.UpdateOne(context.Background(), bson.M{"_id": insertResult.InsertedID.(primitive.ObjectID)}, bson.M{"$set": bson.M{"datetime_start": primitive.NewDateTimeFromTime(timeOneDayLater)}})
 		assert.NoError(t, err)
 
-		res, err := api.GetMeetingPreparationOverviewResult(view, userID, timezoneOffset, true, false)
+		res, err := api.GetMeetingPreparationOverviewResult(context.Background(), view, userID, timezoneOffset, true, false)
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
 
@@ -1091,7 +1160,7 @@ func TestGetMeetingPreparationOverviewResult(t *testing.T) {
 		assert.True(t, item.MeetingPreparationParams.EventMovedOrDeleted)
 	})
 	t.Run("IgnoreMeetingPrepResult", func(t *testing.T) {
-		res, err := api.GetMeetingPreparationOverviewResult(view, userID, timezoneOffset, true, true)
+		res, err := api.GetMeetingPreparationOverviewResult(context.Background(), view, userID, timezoneOffset, true, true)
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
 		assert.Equal(t, 0, len(res.ViewItems))
@@ -1162,7 +1231,7 @@ func TestGetDueTodayOverviewResult(t *testing.T) {
 	}
 
 	t.Run("EmptyViewItems", func(t *testing.T) {
-		result, err := api.GetDueTodayOverviewResult(view, userID, 0)
+		result, err := api.GetDueTodayOverviewResult(context.Background(), view, userID, 0)
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		expectedViewResult.ViewItems = []*TaskResult{}
@@ -1288,7 +1357,7 @@ func TestGetDueTodayOverviewResult(t *testing.T) {
 		secondTaskID := taskResult.InsertedIDs[1].(primitive.ObjectID)
 		thirdTaskID := taskResult.InsertedIDs[2].(primitive.ObjectID)
 
-		result, err := api.GetDueTodayOverviewResult(view, userID, 0)
+		result, err := api.GetDueTodayOverviewResult(context.Background(), view, userID, 0)
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		// Check results are in the correct order, and the IDOrderings begin at 1
@@ -1311,13 +1380,171 @@ func TestGetDueTodayOverviewResult(t *testing.T) {
 		assertOverviewViewResultEqual(t, expectedViewResult, *result)
 	})
 	t.Run("InvalidUser", func(t *testing.T) {
-		result, err := api.GetDueTodayOverviewResult(view, primitive.NewObjectID(), 0)
+		result, err := api.GetDueTodayOverviewResult(context.Background(), view, primitive.NewObjectID(), 0)
 		assert.Error(t, err)
 		assert.Equal(t, "invalid user", err.Error())
 		assert.Nil(t, result)
 	})
 }
 
+func TestGetOverdueOverviewResult(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+
+	userID := primitive.NewObjectID()
+	view := database.View{
+		ID:         primitive.NewObjectID(),
+		UserID:     userID,
+		IDOrdering: 2,
+		Type:       string(constants.ViewOverdue),
+		IsLinked:   true,
+	}
+	expectedViewResult := OverviewResult[TaskResult]{
+		ID:            view.ID,
+		Name:          "Overdue",
+		Type:          constants.ViewOverdue,
+		Logo:          external.TaskServiceGeneralTask.LogoV2,
+		IsLinked:      true,
+		Sources:       []SourcesResult{},
+		IDOrdering:    2,
+		TaskSectionID: primitive.NilObjectID,
+	}
+
+	t.Run("EmptyViewItems", func(t *testing.T) {
+		result, err := api.GetOverdueOverviewResult(context.Background(), view, userID, 0)
+		assert.NoError(t, err)
+		expectedViewResult.ViewItems = []*TaskResult{}
+		assertOverviewViewResultEqual(t, expectedViewResult, *result)
+	})
+	t.Run("Success", func(t *testing.T) {
+		notCompleted := false
+		completed := true
+		timeNow := api.GetCurrentLocalizedTime(0)
+		startOfDay := time.Date(timeNow.Year(), timeNow.Month(), timeNow.Day(), 0, 0, 0, 0, time.UTC)
+		lastWeek := primitive.NewDateTimeFromTime(startOfDay.AddDate(0, 0, -7))
+		yesterday := primitive.NewDateTimeFromTime(startOfDay.AddDate(0, 0, -1))
+		today := primitive.NewDateTimeFromTime(startOfDay)
+		taskResult, err := database.GetTaskCollection(api.DB).InsertMany(context.Background(), []interface{}{
+			database.Task{UserID: userID, IsCompleted: &notCompleted, SourceID: external.TASK_SOURCE_ID_GT_TASK, DueDate: &yesterday},
+			database.Task{UserID: userID, IsCompleted: &notCompleted, SourceID: external.TASK_SOURCE_ID_GT_TASK, DueDate: &lastWeek},
+			// due today
+			database.Task{UserID: userID, IsCompleted: &notCompleted, SourceID: external.TASK_SOURCE_ID_GT_TASK, DueDate: &today},
+			// no due date
+			database.Task{UserID: userID, IsCompleted: &notCompleted, SourceID: external.TASK_SOURCE_ID_GT_TASK},
+			// completed today
+			database.Task{UserID: userID, IsCompleted: &completed, SourceID: external.TASK_SOURCE_ID_GT_TASK, DueDate: &yesterday, CompletedAt: primitive.NewDateTimeFromTime(time.Now())},
+			// other user
+			database.Task{UserID: primitive.NewObjectID(), IsCompleted: &notCompleted, SourceID: external.TASK_SOURCE_ID_GT_TASK, DueDate: &yesterday},
+		})
+		assert.NoError(t, err)
+		yesterdayTaskID := taskResult.InsertedIDs[0].(primitive.ObjectID)
+		lastWeekTaskID := taskResult.InsertedIDs[1].(primitive.ObjectID)
+
+		result, err := api.GetOverdueOverviewResult(context.Background(), view, userID, 0)
+		assert.NoError(t, err)
+		expectedViewResult.ViewItems = []*TaskResult{{ID: lastWeekTaskID}, {ID: yesterdayTaskID}}
+		expectedViewResult.ViewItemIDs = []string{lastWeekTaskID.Hex(), yesterdayTaskID.Hex()}
+		expectedViewResult.HasTasksCompletedToday = true
+		assertOverviewViewResultEqual(t, expectedViewResult, *result)
+	})
+	t.Run("SortingSetting", func(t *testing.T) {
+		err := settings.UpdateUserSetting(api.DB, userID, "overdue_task_sorting_direction", constants.ChoiceKeyDescending)
+		assert.NoError(t, err)
+		result, err := api.GetOverdueOverviewResult(context.Background(), view, userID, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(result.ViewItems))
+		assert.Equal(t, expectedViewResult.ViewItemIDs[1], result.ViewItemIDs[0])
+		assert.Equal(t, expectedViewResult.ViewItemIDs[0], result.ViewItemIDs[1])
+	})
+	t.Run("InvalidUser", func(t *testing.T) {
+		result, err := api.GetOverdueOverviewResult(context.Background(), view, primitive.NewObjectID(), 0)
+		assert.EqualError(t, err, "invalid user")
+		assert.Nil(t, result)
+	})
+}
+
+func TestGetWaitingOnOthersOverviewResult(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+
+	userID := primitive.NewObjectID()
+	teammateID := primitive.NewObjectID()
+	view := database.View{
+		ID:         primitive.NewObjectID(),
+		UserID:     userID,
+		IDOrdering: 3,
+		Type:       string(constants.ViewWaitingOnOthers),
+		IsLinked:   true,
+	}
+	expectedViewResult := OverviewResult[TaskResult]{
+		ID:            view.ID,
+		Name:          "Waiting on Others",
+		Type:          constants.ViewWaitingOnOthers,
+		Logo:          external.TaskServiceGeneralTask.LogoV2,
+		IsLinked:      true,
+		Sources:       []SourcesResult{},
+		IDOrdering:    3,
+		TaskSectionID: primitive.NilObjectID,
+	}
+
+	notCompleted := false
+	completed := true
+	taskResult, err := database.GetTaskCollection(api.DB).InsertMany(context.Background(), []interface{}{
+		database.Task{UserID: userID, IsCompleted: &notCompleted, SourceID: external.TASK_SOURCE_ID_GT_TASK, AssigneeID: teammateID},
+		// not assigned
+		database.Task{UserID: userID, IsCompleted: &notCompleted, SourceID: external.TASK_SOURCE_ID_GT_TASK},
+		// assigned to the user themselves
+		database.Task{UserID: userID, IsCompleted: &notCompleted, SourceID: external.TASK_SOURCE_ID_GT_TASK, AssigneeID: userID},
+		// completed
+		database.Task{UserID: userID, IsCompleted: &completed, SourceID: external.TASK_SOURCE_ID_GT_TASK, AssigneeID: teammateID},
+		// assigned to the user by a teammate
+		database.Task{UserID: teammateID, IsCompleted: &notCompleted, SourceID: external.TASK_SOURCE_ID_GT_TASK, AssigneeID: userID},
+	})
+	assert.NoError(t, err)
+	assignedTaskID := taskResult.InsertedIDs[0].(primitive.ObjectID)
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := api.GetWaitingOnOthersOverviewResult(context.Background(), view, userID, 0)
+		assert.NoError(t, err)
+		expectedViewResult.ViewItems = []*TaskResult{{ID: assignedTaskID}}
+		expectedViewResult.ViewItemIDs = []string{assignedTaskID.Hex()}
+		assertOverviewViewResultEqual(t, expectedViewResult, *result)
+	})
+	t.Run("InvalidUser", func(t *testing.T) {
+		result, err := api.GetWaitingOnOthersOverviewResult(context.Background(), view, primitive.NewObjectID(), 0)
+		assert.EqualError(t, err, "invalid user")
+		assert.Nil(t, result)
+	})
+}
+
+func TestSortTaskResults(t *testing.T) {
+	getTasks := func() []*TaskResult {
+		return []*TaskResult{
+			{Title: "a", DueDate: "2000-02-01", PriorityNormalized: 2, CreatedAt: "2000-01-03T00:00:00Z"},
+			{Title: "b", DueDate: "2000-01-01", PriorityNormalized: 3, CreatedAt: "2000-01-01T00:00:00Z"},
+			{Title: "c", DueDate: "2000-03-01", PriorityNormalized: 1, CreatedAt: "2000-01-02T00:00:00Z"},
+		}
+	}
+	getTitles := func(tasks []*TaskResult) []string {
+		titles := []string{}
+		for idx, task := range tasks {
+			assert.Equal(t, idx, task.IDOrdering)
+			titles = append(titles, task.Title)
+		}
+		return titles
+	}
+	t.Run("DueDate", func(t *testing.T) {
+		assert.Equal(t, []string{"b", "a", "c"}, getTitles(sortTaskResults(getTasks(), constants.ChoiceKeyDueDate, constants.ChoiceKeyAscending)))
+		assert.Equal(t, []string{"c", "a", "b"}, getTitles(sortTaskResults(getTasks(), constants.ChoiceKeyDueDate, constants.ChoiceKeyDescending)))
+	})
+	t.Run("Priority", func(t *testing.T) {
+		assert.Equal(t, []string{"c", "a", "b"}, getTitles(sortTaskResults(getTasks(), constants.ChoiceKeyPriority, constants.ChoiceKeyAscending)))
+	})
+	t.Run("CreatedAt", func(t *testing.T) {
+		assert.Equal(t, []string{"a", "c", "b"}, getTitles(sortTaskResults(getTasks(), constants.ChoiceKeyCreatedAt, constants.ChoiceKeyDescending)))
+	})
+}
+
 func testReorderTaskResultsByDueDate(t *testing.T) {
 	t.Run("EmptyResults", func(t *testing.T) {
 		tasks := []*TaskResult{}
@@ -1593,7 +1820,7 @@ func TestOverviewModify(t *testing.T) {
 	t.Run("MissingParams", func(t *testing.T) {
 		// Expected Result: [1, 2, 3]
 		body := ServeRequest(t, authToken, "PATCH", "/overview/views/bulk_modify/", bytes.NewBuffer([]byte(`{}`)), http.StatusBadRequest, nil)
-		assert.Equal(t, "{\"detail\":\"invalid or missing parameter\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"'ordered_view_ids' is required\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"ordered_view_ids\",\"message\":\"'ordered_view_ids' is required\"}]}", string(body))
 		checkViewPosition(t, viewCollection, firstViewID, 1)
 		checkViewPosition(t, viewCollection, secondViewID, 2)
 		checkViewPosition(t, viewCollection, thirdViewID, 3)
@@ -1602,7 +1829,7 @@ func TestOverviewModify(t *testing.T) {
 	t.Run("MalformattedParams", func(t *testing.T) {
 		// Expected Result: [1, 2, 3]
 		body := ServeRequest(t, authToken, "PATCH", "/overview/views/bulk_modify/", bytes.NewBuffer([]byte(`{"ordered_view_ids": [1, "oops"]}`)), http.StatusBadRequest, nil)
-		assert.Equal(t, "{\"detail\":\"invalid or missing parameter\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"invalid or missing parameter\",\"code\":\"bad_request\"}", string(body))
 		checkViewPosition(t, viewCollection, firstViewID, 1)
 		checkViewPosition(t, viewCollection, secondViewID, 2)
 		checkViewPosition(t, viewCollection, thirdViewID, 3)
@@ -1612,7 +1839,7 @@ func TestOverviewModify(t *testing.T) {
 		// Expected Result: [1, 2, 3]
 		params := fmt.Sprintf(`{"ordered_view_ids": ["%s"]}`, fourthViewID.Hex())
 		body := ServeRequest(t, authToken, "PATCH", "/overview/views/bulk_modify/", bytes.NewBuffer([]byte(params)), http.StatusBadRequest, nil)
-		assert.Equal(t, "{\"detail\":\"invalid or duplicate view IDs provided\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"invalid or duplicate view IDs provided\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"ordered_view_ids\"}]}", string(body))
 		checkViewPosition(t, viewCollection, firstViewID, 1)
 		checkViewPosition(t, viewCollection, secondViewID, 2)
 		checkViewPosition(t, viewCollection, thirdViewID, 3)
@@ -1630,6 +1857,58 @@ func TestOverviewModify(t *testing.T) {
 	})
 }
 
+func TestOverviewLinearViewModify(t *testing.T) {
+	authToken := login("testModifyLinearView@resonant-kelpie-404a42.netlify.app", "")
+
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, db, authToken)
+
+	viewCollection := database.GetViewCollection(db)
+	result, err := viewCollection.InsertMany(context.Background(), []interface{}{
+		database.View{UserID: userID, Type: string(constants.ViewLinear)},
+		database.View{UserID: userID, Type: string(constants.ViewSlack)},
+	})
+	assert.NoError(t, err)
+	linearViewID := result.InsertedIDs[0].(primitive.ObjectID)
+	slackViewID := result.InsertedIDs[1].(primitive.ObjectID)
+	url := fmt.Sprintf("/overview/views/%s/linear/", linearViewID.Hex())
+
+	UnauthorizedTest(t, "PATCH", url, nil)
+	t.Run("MissingParams", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", url, bytes.NewBuffer([]byte(`{}`)), http.StatusBadRequest, nil)
+	})
+	t.Run("InvalidCycleFilter", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "PATCH", url, bytes.NewBuffer([]byte(`{"linear_cycle_filter": "someday"}`)), http.StatusBadRequest, nil)
+		assert.Equal(t, `{"detail":"invalid 'linear_cycle_filter'","code":"invalid_parameter","field_errors":[{"field":"linear_cycle_filter"}]}`, string(body))
+	})
+	t.Run("InvalidSortBy", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "PATCH", url, bytes.NewBuffer([]byte(`{"linear_sort_by": "vibes"}`)), http.StatusBadRequest, nil)
+		assert.Equal(t, `{"detail":"invalid 'linear_sort_by'","code":"invalid_parameter","field_errors":[{"field":"linear_sort_by"}]}`, string(body))
+	})
+	t.Run("NotLinearView", func(t *testing.T) {
+		slackURL := fmt.Sprintf("/overview/views/%s/linear/", slackViewID.Hex())
+		ServeRequest(t, authToken, "PATCH", slackURL, bytes.NewBuffer([]byte(`{"linear_sort_by": "cycle"}`)), http.StatusNotFound, nil)
+	})
+	t.Run("Success", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", url, bytes.NewBuffer([]byte(`{"linear_cycle_filter": "next", "linear_sort_by": "cycle"}`)), http.StatusOK, nil)
+		var view database.View
+		err := viewCollection.FindOne(context.Background(), bson.M{"_id": linearViewID}).Decode(&view)
+		assert.NoError(t, err)
+		assert.Equal(t, constants.LinearCycleFilterNext, view.LinearCycleFilter)
+		assert.Equal(t, constants.LinearSortByCycle, view.LinearSortBy)
+	})
+	t.Run("SuccessClearFilter", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", url, bytes.NewBuffer([]byte(`{"linear_cycle_filter": ""}`)), http.StatusOK, nil)
+		var view database.View
+		err := viewCollection.FindOne(context.Background(), bson.M{"_id": linearViewID}).Decode(&view)
+		assert.NoError(t, err)
+		assert.Equal(t, "", view.LinearCycleFilter)
+		assert.Equal(t, constants.LinearSortByCycle, view.LinearSortBy)
+	})
+}
+
 func TestOverviewAdd(t *testing.T) {
 	authToken := login("testAddView@resonant-kelpie-404a42.netlify.app", "")
 
@@ -1681,7 +1960,7 @@ func TestOverviewAdd(t *testing.T) {
 	t.Run("MissingTaskSectionId", func(t *testing.T) {
 		viewCollection.DeleteMany(context.Background(), bson.M{"user_id": userID})
 		body := ServeRequest(t, authToken, "POST", "/overview/views/", bytes.NewBuffer([]byte(`{"type": "task_section"}`)), http.StatusBadRequest, nil)
-		assert.Equal(t, "{\"detail\":\"'task_section_id' is required for task section type views\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"'task_section_id' is required for task section type views\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"task_section_id\"}]}", string(body))
 
 		count, err := viewCollection.CountDocuments(context.Background(), bson.M{"user_id": userID})
 		assert.NoError(t, err)
@@ -1797,7 +2076,7 @@ func TestOverviewAdd(t *testing.T) {
 	t.Run("AddGithubViewMissingGithubID", func(t *testing.T) {
 		viewCollection.DeleteMany(context.Background(), bson.M{"user_id": userID})
 		body := ServeRequest(t, authToken, "POST", "/overview/views/", bytes.NewBuffer([]byte(`{"type": "github"}`)), http.StatusBadRequest, nil)
-		assert.Equal(t, "{\"detail\":\"'id_github' is required for github type views\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"'id_github' is required for github type views\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"id_github\"}]}", string(body))
 
 		count, err := viewCollection.CountDocuments(context.Background(), bson.M{"user_id": userID})
 		assert.NoError(t, err)
@@ -1806,7 +2085,7 @@ func TestOverviewAdd(t *testing.T) {
 	t.Run("AddGithubViewMalformattedGithubID", func(t *testing.T) {
 		viewCollection.DeleteMany(context.Background(), bson.M{"user_id": userID})
 		body := ServeRequest(t, authToken, "POST", "/overview/views/", bytes.NewBuffer([]byte(`{"type": "github", "github_id": 123}`)), http.StatusBadRequest, nil)
-		assert.Equal(t, "{\"detail\":\"invalid or missing parameter\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"invalid or missing parameter\",\"code\":\"bad_request\"}", string(body))
 
 		count, err := viewCollection.CountDocuments(context.Background(), bson.M{"user_id": userID})
 		assert.NoError(t, err)
@@ -1815,7 +2094,31 @@ func TestOverviewAdd(t *testing.T) {
 	t.Run("AddGithubViewInvalidGithubID", func(t *testing.T) {
 		viewCollection.DeleteMany(context.Background(), bson.M{"user_id": userID})
 		body := ServeRequest(t, authToken, "POST", "/overview/views/", bytes.NewBuffer([]byte(`{"type": "github", "github_id": "foobar"}`)), http.StatusBadRequest, nil)
-		assert.Equal(t, "{\"detail\":\"invalid 'id_github'\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"invalid 'id_github'\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"id_github\"}]}", string(body))
+
+		count, err := viewCollection.CountDocuments(context.Background(), bson.M{"user_id": userID})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+	t.Run("AddJiraJQLViewMissingJQL", func(t *testing.T) {
+		viewCollection.DeleteMany(context.Background(), bson.M{"user_id": userID})
+		body := ServeRequest(t, authToken, "POST", "/overview/views/", bytes.NewBuffer([]byte(`{"type": "jira_jql", "account_id": "sample-account"}`)), http.StatusBadRequest, nil)
+		assert.Equal(t, "{\"detail\":\"'account_id' and 'jql' are required for jira jql type views\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"account_id\"},{\"field\":\"jql\"}]}", string(body))
+
+		count, err := viewCollection.CountDocuments(context.Background(), bson.M{"user_id": userID})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+	t.Run("AddJiraJQLViewJQLTooLong", func(t *testing.T) {
+		viewCollection.DeleteMany(context.Background(), bson.M{"user_id": userID})
+		JQL := "project = MOON" + strings.Repeat(" ", constants.JIRA_JQL_MAX_LENGTH)
+		body := ServeRequest(t, authToken, "POST", "/overview/views/", bytes.NewBuffer([]byte(fmt.Sprintf(`{"type": "jira_jql", "account_id": "sample-account", "jql": "%s"}`, JQL))), http.StatusBadRequest, nil)
+		assert.Equal(t, "{\"detail\":\"'jql' must be at most 2000 characters\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"jql\"}]}", string(body))
+	})
+	t.Run("AddJiraJQLViewUnlinkedAccount", func(t *testing.T) {
+		viewCollection.DeleteMany(context.Background(), bson.M{"user_id": userID})
+		body := ServeRequest(t, authToken, "POST", "/overview/views/", bytes.NewBuffer([]byte(`{"type": "jira_jql", "account_id": "sample-account", "jql": "project = MOON"}`)), http.StatusBadRequest, nil)
+		assert.Equal(t, "{\"detail\":\"invalid 'account_id'\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"account_id\"}]}", string(body))
 
 		count, err := viewCollection.CountDocuments(context.Background(), bson.M{"user_id": userID})
 		assert.NoError(t, err)
@@ -1946,7 +2249,7 @@ func TestOverviewSupportedViewsList(t *testing.T) {
 		externalAPITokenCollection.DeleteMany(context.Background(), bson.M{"user_id": userID})
 		body := ServeRequest(t, authToken, "GET", "/overview/supported_views/", nil, http.StatusOK, nil)
 
-		expectedBody := fmt.Sprintf("[{\"type\":\"meeting_preparation\",\"name\":\"Meeting Preparation for the day\",\"logo\":\"gcal\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Meeting Preparation\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"due_today\",\"name\":\"Tasks Due Today\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Tasks Due Today View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"task_section\",\"name\":\"Task Folders\",\"logo\":\"generaltask\",\"is_nested\":true,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Task Inbox\",\"is_added\":false,\"task_section_id\":\"000000000000000000000001\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"},{\"name\":\"Duck section\",\"is_added\":false,\"task_section_id\":\"%s\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"jira\",\"name\":\"Jira\",\"logo\":\"jira\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/atlassian/\",\"views\":[{\"name\":\"Jira View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"linear\",\"name\":\"Linear\",\"logo\":\"linear\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/linear/\",\"views\":[{\"name\":\"Linear View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"slack\",\"name\":\"Slack\",\"logo\":\"slack\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/slack/\",\"views\":[{\"name\":\"Slack View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"github\",\"name\":\"GitHub\",\"logo\":\"github\",\"is_nested\":true,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/github/\",\"views\":[]}]", taskSectionObjectID.Hex())
+		expectedBody := fmt.Sprintf("[{\"type\":\"meeting_preparation\",\"name\":\"Meeting Preparation for the day\",\"logo\":\"gcal\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Meeting Preparation\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"due_today\",\"name\":\"Tasks Due Today\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Tasks Due Today View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"overdue\",\"name\":\"Overdue Tasks\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Overdue Tasks View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"assigned_to_me\",\"name\":\"Tasks Assigned to Me\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Assigned to Me View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"waiting_on_others\",\"name\":\"Tasks Waiting on Others\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Waiting on Others View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"task_section\",\"name\":\"Task Folders\",\"logo\":\"generaltask\",\"is_nested\":true,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Task Inbox\",\"is_added\":false,\"task_section_id\":\"000000000000000000000001\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"},{\"name\":\"Duck section\",\"is_added\":false,\"task_section_id\":\"%s\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"jira\",\"name\":\"Jira\",\"logo\":\"jira\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/atlassian/\",\"views\":[{\"name\":\"Jira View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"linear\",\"name\":\"Linear\",\"logo\":\"linear\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/linear/\",\"views\":[{\"name\":\"Linear View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"slack\",\"name\":\"Slack\",\"logo\":\"slack\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/slack/\",\"views\":[{\"name\":\"Slack View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"github\",\"name\":\"GitHub\",\"logo\":\"github\",\"is_nested\":true,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/github/\",\"views\":[]}]", taskSectionObjectID.Hex())
 		assert.Equal(t, expectedBody, string(body))
 	})
 	t.Run("TestTaskSectionIsAdded", func(t *testing.T) {
@@ -1961,7 +2264,7 @@ func TestOverviewSupportedViewsList(t *testing.T) {
 		assert.NoError(t, err)
 		addedViewId := view.InsertedID.(primitive.ObjectID).Hex()
 		body := ServeRequest(t, authToken, "GET", "/overview/supported_views/", nil, http.StatusOK, nil)
-		expectedBody := fmt.Sprintf("[{\"type\":\"meeting_preparation\",\"name\":\"Meeting Preparation for the day\",\"logo\":\"gcal\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Meeting Preparation\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"due_today\",\"name\":\"Tasks Due Today\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Tasks Due Today View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"task_section\",\"name\":\"Task Folders\",\"logo\":\"generaltask\",\"is_nested\":true,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Task Inbox\",\"is_added\":false,\"task_section_id\":\"000000000000000000000001\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"},{\"name\":\"Duck section\",\"is_added\":true,\"task_section_id\":\"%s\",\"github_id\":\"\",\"view_id\":\"%s\"}]},{\"type\":\"jira\",\"name\":\"Jira\",\"logo\":\"jira\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/atlassian/\",\"views\":[{\"name\":\"Jira View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"linear\",\"name\":\"Linear\",\"logo\":\"linear\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/linear/\",\"views\":[{\"name\":\"Linear View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"slack\",\"name\":\"Slack\",\"logo\":\"slack\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/slack/\",\"views\":[{\"name\":\"Slack View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"github\",\"name\":\"GitHub\",\"logo\":\"github\",\"is_nested\":true,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/github/\",\"views\":[]}]", taskSectionID, addedViewId)
+		expectedBody := fmt.Sprintf("[{\"type\":\"meeting_preparation\",\"name\":\"Meeting Preparation for the day\",\"logo\":\"gcal\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Meeting Preparation\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"due_today\",\"name\":\"Tasks Due Today\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Tasks Due Today View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"overdue\",\"name\":\"Overdue Tasks\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Overdue Tasks View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"assigned_to_me\",\"name\":\"Tasks Assigned to Me\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Assigned to Me View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"waiting_on_others\",\"name\":\"Tasks Waiting on Others\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Waiting on Others View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"task_section\",\"name\":\"Task Folders\",\"logo\":\"generaltask\",\"is_nested\":true,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Task Inbox\",\"is_added\":false,\"task_section_id\":\"000000000000000000000001\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"},{\"name\":\"Duck section\",\"is_added\":true,\"task_section_id\":\"%s\",\"github_id\":\"\",\"view_id\":\"%s\"}]},{\"type\":\"jira\",\"name\":\"Jira\",\"logo\":\"jira\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/atlassian/\",\"views\":[{\"name\":\"Jira View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"linear\",\"name\":\"Linear\",\"logo\":\"linear\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/linear/\",\"views\":[{\"name\":\"Linear View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"slack\",\"name\":\"Slack\",\"logo\":\"slack\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/slack/\",\"views\":[{\"name\":\"Slack View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"github\",\"name\":\"GitHub\",\"logo\":\"github\",\"is_nested\":true,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/github/\",\"views\":[]}]", taskSectionID, addedViewId)
 		assert.Equal(t, expectedBody, string(body))
 	})
 	t.Run("TestLinearIsAddedIsUnlinked", func(t *testing.T) {
@@ -1975,7 +2278,7 @@ func TestOverviewSupportedViewsList(t *testing.T) {
 		assert.NoError(t, err)
 		addedViewId := view.InsertedID.(primitive.ObjectID).Hex()
 		body := ServeRequest(t, authToken, "GET", "/overview/supported_views/", nil, http.StatusOK, nil)
-		expectedBody := fmt.Sprintf("[{\"type\":\"meeting_preparation\",\"name\":\"Meeting Preparation for the day\",\"logo\":\"gcal\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Meeting Preparation\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"due_today\",\"name\":\"Tasks Due Today\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Tasks Due Today View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"task_section\",\"name\":\"Task Folders\",\"logo\":\"generaltask\",\"is_nested\":true,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Task Inbox\",\"is_added\":false,\"task_section_id\":\"000000000000000000000001\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"},{\"name\":\"Duck section\",\"is_added\":false,\"task_section_id\":\"%s\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"jira\",\"name\":\"Jira\",\"logo\":\"jira\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/atlassian/\",\"views\":[{\"name\":\"Jira View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"linear\",\"name\":\"Linear\",\"logo\":\"linear\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/linear/\",\"views\":[{\"name\":\"Linear View\",\"is_added\":true,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"%s\"}]},{\"type\":\"slack\",\"name\":\"Slack\",\"logo\":\"slack\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/slack/\",\"views\":[{\"name\":\"Slack View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"github\",\"name\":\"GitHub\",\"logo\":\"github\",\"is_nested\":true,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/github/\",\"views\":[]}]", taskSectionID, addedViewId)
+		expectedBody := fmt.Sprintf("[{\"type\":\"meeting_preparation\",\"name\":\"Meeting Preparation for the day\",\"logo\":\"gcal\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Meeting Preparation\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"due_today\",\"name\":\"Tasks Due Today\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Tasks Due Today View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"overdue\",\"name\":\"Overdue Tasks\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Overdue Tasks View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"assigned_to_me\",\"name\":\"Tasks Assigned to Me\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Assigned to Me View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"waiting_on_others\",\"name\":\"Tasks Waiting on Others\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Waiting on Others View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"task_section\",\"name\":\"Task Folders\",\"logo\":\"generaltask\",\"is_nested\":true,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Task Inbox\",\"is_added\":false,\"task_section_id\":\"000000000000000000000001\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"},{\"name\":\"Duck section\",\"is_added\":false,\"task_section_id\":\"%s\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"jira\",\"name\":\"Jira\",\"logo\":\"jira\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/atlassian/\",\"views\":[{\"name\":\"Jira View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"linear\",\"name\":\"Linear\",\"logo\":\"linear\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/linear/\",\"views\":[{\"name\":\"Linear View\",\"is_added\":true,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"%s\"}]},{\"type\":\"slack\",\"name\":\"Slack\",\"logo\":\"slack\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/slack/\",\"views\":[{\"name\":\"Slack View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"github\",\"name\":\"GitHub\",\"logo\":\"github\",\"is_nested\":true,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/github/\",\"views\":[]}]", taskSectionID, addedViewId)
 		assert.Equal(t, expectedBody, string(body))
 	})
 	t.Run("TestLinearIsAddedIsLinked", func(t *testing.T) {
@@ -1994,7 +2297,7 @@ func TestOverviewSupportedViewsList(t *testing.T) {
 			ServiceID: external.TASK_SERVICE_ID_LINEAR,
 		})
 		body := ServeRequest(t, authToken, "GET", "/overview/supported_views/", nil, http.StatusOK, nil)
-		expectedBody := fmt.Sprintf("[{\"type\":\"meeting_preparation\",\"name\":\"Meeting Preparation for the day\",\"logo\":\"gcal\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Meeting Preparation\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"due_today\",\"name\":\"Tasks Due Today\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Tasks Due Today View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"task_section\",\"name\":\"Task Folders\",\"logo\":\"generaltask\",\"is_nested\":true,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Task Inbox\",\"is_added\":false,\"task_section_id\":\"000000000000000000000001\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"},{\"name\":\"Duck section\",\"is_added\":false,\"task_section_id\":\"%s\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"jira\",\"name\":\"Jira\",\"logo\":\"jira\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/atlassian/\",\"views\":[{\"name\":\"Jira View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"linear\",\"name\":\"Linear\",\"logo\":\"linear\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Linear View\",\"is_added\":true,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"%s\"}]},{\"type\":\"slack\",\"name\":\"Slack\",\"logo\":\"slack\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/slack/\",\"views\":[{\"name\":\"Slack View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"github\",\"name\":\"GitHub\",\"logo\":\"github\",\"is_nested\":true,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/github/\",\"views\":[]}]", taskSectionID, addedViewId)
+		expectedBody := fmt.Sprintf("[{\"type\":\"meeting_preparation\",\"name\":\"Meeting Preparation for the day\",\"logo\":\"gcal\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Meeting Preparation\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"due_today\",\"name\":\"Tasks Due Today\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Tasks Due Today View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"overdue\",\"name\":\"Overdue Tasks\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Overdue Tasks View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"assigned_to_me\",\"name\":\"Tasks Assigned to Me\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Assigned to Me View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"waiting_on_others\",\"name\":\"Tasks Waiting on Others\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Waiting on Others View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"task_section\",\"name\":\"Task Folders\",\"logo\":\"generaltask\",\"is_nested\":true,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Task Inbox\",\"is_added\":false,\"task_section_id\":\"000000000000000000000001\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"},{\"name\":\"Duck section\",\"is_added\":false,\"task_section_id\":\"%s\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"jira\",\"name\":\"Jira\",\"logo\":\"jira\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/atlassian/\",\"views\":[{\"name\":\"Jira View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"linear\",\"name\":\"Linear\",\"logo\":\"linear\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Linear View\",\"is_added\":true,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"%s\"}]},{\"type\":\"slack\",\"name\":\"Slack\",\"logo\":\"slack\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/slack/\",\"views\":[{\"name\":\"Slack View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"github\",\"name\":\"GitHub\",\"logo\":\"github\",\"is_nested\":true,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/github/\",\"views\":[]}]", taskSectionID, addedViewId)
 		assert.Equal(t, expectedBody, string(body))
 	})
 	t.Run("TestSlackIsAddedIsUnlinked", func(t *testing.T) {
@@ -2008,7 +2311,7 @@ func TestOverviewSupportedViewsList(t *testing.T) {
 		assert.NoError(t, err)
 		addedViewId := view.InsertedID.(primitive.ObjectID).Hex()
 		body := ServeRequest(t, authToken, "GET", "/overview/supported_views/", nil, http.StatusOK, nil)
-		expectedBody := fmt.Sprintf("[{\"type\":\"meeting_preparation\",\"name\":\"Meeting Preparation for the day\",\"logo\":\"gcal\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Meeting Preparation\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"due_today\",\"name\":\"Tasks Due Today\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Tasks Due Today View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"task_section\",\"name\":\"Task Folders\",\"logo\":\"generaltask\",\"is_nested\":true,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Task Inbox\",\"is_added\":false,\"task_section_id\":\"000000000000000000000001\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"},{\"name\":\"Duck section\",\"is_added\":false,\"task_section_id\":\"%s\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"jira\",\"name\":\"Jira\",\"logo\":\"jira\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/atlassian/\",\"views\":[{\"name\":\"Jira View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"linear\",\"name\":\"Linear\",\"logo\":\"linear\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/linear/\",\"views\":[{\"name\":\"Linear View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"slack\",\"name\":\"Slack\",\"logo\":\"slack\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/slack/\",\"views\":[{\"name\":\"Slack View\",\"is_added\":true,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"%s\"}]},{\"type\":\"github\",\"name\":\"GitHub\",\"logo\":\"github\",\"is_nested\":true,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/github/\",\"views\":[]}]", taskSectionID, addedViewId)
+		expectedBody := fmt.Sprintf("[{\"type\":\"meeting_preparation\",\"name\":\"Meeting Preparation for the day\",\"logo\":\"gcal\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Meeting Preparation\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"due_today\",\"name\":\"Tasks Due Today\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Tasks Due Today View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"overdue\",\"name\":\"Overdue Tasks\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Overdue Tasks View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"assigned_to_me\",\"name\":\"Tasks Assigned to Me\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Assigned to Me View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"waiting_on_others\",\"name\":\"Tasks Waiting on Others\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Waiting on Others View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"task_section\",\"name\":\"Task Folders\",\"logo\":\"generaltask\",\"is_nested\":true,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Task Inbox\",\"is_added\":false,\"task_section_id\":\"000000000000000000000001\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"},{\"name\":\"Duck section\",\"is_added\":false,\"task_section_id\":\"%s\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"jira\",\"name\":\"Jira\",\"logo\":\"jira\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/atlassian/\",\"views\":[{\"name\":\"Jira View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"linear\",\"name\":\"Linear\",\"logo\":\"linear\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/linear/\",\"views\":[{\"name\":\"Linear View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"slack\",\"name\":\"Slack\",\"logo\":\"slack\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/slack/\",\"views\":[{\"name\":\"Slack View\",\"is_added\":true,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"%s\"}]},{\"type\":\"github\",\"name\":\"GitHub\",\"logo\":\"github\",\"is_nested\":true,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/github/\",\"views\":[]}]", taskSectionID, addedViewId)
 		assert.Equal(t, expectedBody, string(body))
 	})
 	t.Run("TestSlackIsAddedIsLinked", func(t *testing.T) {
@@ -2027,7 +2330,7 @@ func TestOverviewSupportedViewsList(t *testing.T) {
 			ServiceID: external.TASK_SERVICE_ID_SLACK,
 		})
 		body := ServeRequest(t, authToken, "GET", "/overview/supported_views/", nil, http.StatusOK, nil)
-		expectedBody := fmt.Sprintf("[{\"type\":\"meeting_preparation\",\"name\":\"Meeting Preparation for the day\",\"logo\":\"gcal\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Meeting Preparation\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"due_today\",\"name\":\"Tasks Due Today\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Tasks Due Today View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"task_section\",\"name\":\"Task Folders\",\"logo\":\"generaltask\",\"is_nested\":true,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Task Inbox\",\"is_added\":false,\"task_section_id\":\"000000000000000000000001\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"},{\"name\":\"Duck section\",\"is_added\":false,\"task_section_id\":\"%s\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"jira\",\"name\":\"Jira\",\"logo\":\"jira\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/atlassian/\",\"views\":[{\"name\":\"Jira View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"linear\",\"name\":\"Linear\",\"logo\":\"linear\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/linear/\",\"views\":[{\"name\":\"Linear View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"slack\",\"name\":\"Slack\",\"logo\":\"slack\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Slack View\",\"is_added\":true,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"%s\"}]},{\"type\":\"github\",\"name\":\"GitHub\",\"logo\":\"github\",\"is_nested\":true,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/github/\",\"views\":[]}]", taskSectionID, addedViewId)
+		expectedBody := fmt.Sprintf("[{\"type\":\"meeting_preparation\",\"name\":\"Meeting Preparation for the day\",\"logo\":\"gcal\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Meeting Preparation\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"due_today\",\"name\":\"Tasks Due Today\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Tasks Due Today View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"overdue\",\"name\":\"Overdue Tasks\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Overdue Tasks View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"assigned_to_me\",\"name\":\"Tasks Assigned to Me\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Assigned to Me View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"waiting_on_others\",\"name\":\"Tasks Waiting on Others\",\"logo\":\"generaltask\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Waiting on Others View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"task_section\",\"name\":\"Task Folders\",\"logo\":\"generaltask\",\"is_nested\":true,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Task Inbox\",\"is_added\":false,\"task_section_id\":\"000000000000000000000001\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"},{\"name\":\"Duck section\",\"is_added\":false,\"task_section_id\":\"%s\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"jira\",\"name\":\"Jira\",\"logo\":\"jira\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/atlassian/\",\"views\":[{\"name\":\"Jira View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"linear\",\"name\":\"Linear\",\"logo\":\"linear\",\"is_nested\":false,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/linear/\",\"views\":[{\"name\":\"Linear View\",\"is_added\":false,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"000000000000000000000000\"}]},{\"type\":\"slack\",\"name\":\"Slack\",\"logo\":\"slack\",\"is_nested\":false,\"is_linked\":true,\"authorization_url\":\"\",\"views\":[{\"name\":\"Slack View\",\"is_added\":true,\"task_section_id\":\"000000000000000000000000\",\"github_id\":\"\",\"view_id\":\"%s\"}]},{\"type\":\"github\",\"name\":\"GitHub\",\"logo\":\"github\",\"is_nested\":true,\"is_linked\":false,\"authorization_url\":\"http://localhost:8080/link/github/\",\"views\":[]}]", taskSectionID, addedViewId)
 
 		assert.Equal(t, expectedBody, string(body))
 	})
@@ -2093,5 +2396,16 @@ func checkViewPosition(t *testing.T, viewCollection *mongo.Collection, viewID pr
 	var view database.View
 	err := viewCollection.FindOne(context.Background(), bson.M{"_id": viewID}).Decode(&view)
 	assert.NoError(t, err)
-	assert.Equal(t, position, view.IDOrdering)
+	var views []database.View
+	cursor, err := viewCollection.Find(context.Background(), bson.M{"user_id": view.UserID})
+	assert.NoError(t, err)
+	assert.NoError(t, cursor.All(context.Background(), &views))
+	sort.SliceStable(views, func(i, j int) bool {
+		return ordering.Less(views[i].OrderingKey, views[i].IDOrdering, views[j].OrderingKey, views[j].IDOrdering)
+	})
+	for idx := range views {
+		if views[idx].ID == viewID {
+			assert.Equal(t, position, idx+1)
+		}
+	}
 }
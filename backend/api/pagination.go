@@ -0,0 +1,40 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+)
+
+// PaginatedResult is returned by list endpoints when the client passes limit or cursor. Passing
+// next_cursor back as cursor fetches the following page; it's omitted on the last page
+type PaginatedResult[T any] struct {
+	Results    []T    `json:"results"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// getPagination returns nil when the client hasn't asked for a page, so the endpoint can keep
+// returning its full list
+func getPagination(c *gin.Context) (*database.Pagination, error) {
+	var pagination database.Pagination
+	err := c.ShouldBindQuery(&pagination)
+	if err != nil {
+		return nil, errors.New("invalid pagination parameters")
+	}
+	if pagination.Limit == nil && pagination.Cursor == nil {
+		return nil, nil
+	}
+	if pagination.Limit != nil && (*pagination.Limit < 1 || *pagination.Limit > constants.MAX_PAGE_LIMIT) {
+		return nil, fmt.Errorf("limit must be between 1 and %d", constants.MAX_PAGE_LIMIT)
+	}
+	if pagination.Cursor != nil && *pagination.Cursor != "" {
+		_, err = database.DecodePageCursor(*pagination.Cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &pagination, nil
+}
@@ -0,0 +1,290 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/franchizzle/task-manager/backend/config"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	PROFILE_NAME_MAX_LENGTH   = 100
+	GREETING_NAME_MAX_LENGTH  = 50
+	PROFILE_LOCALE_MAX_LENGTH = 35
+	AVATAR_MAX_FILE_BYTES     = 1024 * 1024
+	AVATAR_CACHE_CONTROL      = "public, max-age=86400"
+	GRAVATAR_URL              = "https://www.gravatar.com/avatar/"
+	GRAVATAR_DEFAULT_IMAGE    = "identicon"
+)
+
+// language, then optional script and region, e.g. en, en-US or zh-Hant-TW
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z][a-z]{3})?(-([A-Z]{2}|[0-9]{3}))?$`)
+
+var avatarContentTypes = []string{"image/png", "image/jpeg", "image/gif", "image/webp"}
+
+type ProfileResult struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	GreetingName string `json:"greeting_name"`
+	Locale       string `json:"locale"`
+	UseGravatar  bool   `json:"use_gravatar"`
+	AvatarURL    string `json:"avatar_url"`
+}
+
+type ProfileParams struct {
+	Name *string `json:"name"`
+	// an empty greeting name or locale clears it
+	GreetingName *string `json:"greeting_name"`
+	Locale       *string `json:"locale"`
+	UseGravatar  *bool   `json:"use_gravatar"`
+}
+
+// CollaboratorProfile is what other people see of a user on their shared tasks and notes
+type CollaboratorProfile struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+func (api *API) ProfileGet(c *gin.Context) {
+	user, err := database.GetUser(c.Request.Context(), api.DB, getUserIDFromContext(c))
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	c.JSON(200, getProfileResult(user))
+}
+
+func (api *API) ProfileUpdate(c *gin.Context) {
+	var params ProfileParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	set := bson.M{}
+	unset := bson.M{}
+	if params.Name != nil {
+		name := strings.TrimSpace(*params.Name)
+		if name == "" || utf8.RuneCountInString(name) > PROFILE_NAME_MAX_LENGTH {
+			HandleBadRequest(c, fmt.Sprintf("'name' must be between 1 and %d characters", PROFILE_NAME_MAX_LENGTH), "name")
+			return
+		}
+		set["name"] = name
+	}
+	if params.GreetingName != nil {
+		greetingName := strings.TrimSpace(*params.GreetingName)
+		if utf8.RuneCountInString(greetingName) > GREETING_NAME_MAX_LENGTH {
+			HandleBadRequest(c, fmt.Sprintf("'greeting_name' must be at most %d characters", GREETING_NAME_MAX_LENGTH), "greeting_name")
+			return
+		}
+		setOrUnset(set, unset, "greeting_name", greetingName)
+	}
+	if params.Locale != nil {
+		if *params.Locale != "" && (len(*params.Locale) > PROFILE_LOCALE_MAX_LENGTH || !localePattern.MatchString(*params.Locale)) {
+			HandleBadRequest(c, "'locale' must be a language tag such as en or en-US", "locale")
+			return
+		}
+		setOrUnset(set, unset, "locale", *params.Locale)
+	}
+	if params.UseGravatar != nil {
+		set["use_gravatar"] = *params.UseGravatar
+	}
+	if len(set) == 0 && len(unset) == 0 {
+		HandleBadRequest(c, "no profile fields to update")
+		return
+	}
+
+	update := bson.M{}
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	userID := getUserIDFromContext(c)
+	var user database.User
+	err = database.GetUserCollection(api.DB).FindOneAndUpdate(
+		context.Background(),
+		bson.M{"_id": userID},
+		update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&user)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update profile")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, getProfileResult(&user))
+}
+
+func setOrUnset(set bson.M, unset bson.M, key string, value string) {
+	if value == "" {
+		unset[key] = ""
+	} else {
+		set[key] = value
+	}
+}
+
+// ProfileAvatarUpload takes the raw image as the request body
+func (api *API) ProfileAvatarUpload(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, AVATAR_MAX_FILE_BYTES+1))
+	if err != nil {
+		HandleBadRequest(c, "failed to read avatar")
+		return
+	}
+	if len(body) == 0 {
+		HandleBadRequest(c, "avatar is empty")
+		return
+	}
+	if len(body) > AVATAR_MAX_FILE_BYTES {
+		HandleBadRequest(c, "avatar is too large")
+		return
+	}
+	// the detected type is used rather than the request's, so the avatar route can't serve other content
+	contentType := http.DetectContentType(body)
+	if !isAvatarContentType(contentType) {
+		HandleBadRequest(c, "avatar must be a png, jpeg, gif or webp image")
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	avatar := database.Avatar{UserID: userID, ContentType: contentType, Data: body}
+	err = database.UpsertAvatar(c.Request.Context(), api.DB, &avatar)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	user, err := database.GetUser(c.Request.Context(), api.DB, userID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	// the cached user may not have the new avatar yet
+	user.AvatarUpdatedAt = avatar.UpdatedAt
+	c.JSON(200, getProfileResult(user))
+}
+
+func (api *API) ProfileAvatarDelete(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	err := database.DeleteAvatar(c.Request.Context(), api.DB, userID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	user, err := database.GetUser(c.Request.Context(), api.DB, userID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	user.AvatarUpdatedAt = 0
+	c.JSON(200, getProfileResult(user))
+}
+
+// AvatarGet serves uploaded avatars without logging in, so they show up on shared tasks and notes
+func (api *API) AvatarGet(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.Param("user_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	avatar, err := database.GetAvatar(c.Request.Context(), api.DB, userID)
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	c.Header("Cache-Control", AVATAR_CACHE_CONTROL)
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Data(200, avatar.ContentType, avatar.Data)
+}
+
+func isAvatarContentType(contentType string) bool {
+	for _, avatarContentType := range avatarContentTypes {
+		if contentType == avatarContentType {
+			return true
+		}
+	}
+	return false
+}
+
+func getProfileResult(user *database.User) ProfileResult {
+	return ProfileResult{
+		ID:           user.ID.Hex(),
+		Name:         user.Name,
+		Email:        user.Email,
+		GreetingName: user.GreetingName,
+		Locale:       user.Locale,
+		UseGravatar:  user.UseGravatar == nil || *user.UseGravatar,
+		AvatarURL:    getAvatarURL(user),
+	}
+}
+
+// getAvatarURL prefers the uploaded avatar, falling back to Gravatar unless the user turned it off
+func getAvatarURL(user *database.User) string {
+	if user.AvatarUpdatedAt != 0 {
+		// the version changes the URL when the avatar does, since it's cached by browsers
+		return fmt.Sprintf("%savatars/%s/?v=%d", config.GetSettings().ServerURL, user.ID.Hex(), user.AvatarUpdatedAt.Time().Unix())
+	}
+	if user.UseGravatar != nil && !*user.UseGravatar {
+		return ""
+	}
+	return getGravatarURL(user.Email)
+}
+
+func getGravatarURL(email string) string {
+	hash := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+	return GRAVATAR_URL + hex.EncodeToString(hash[:]) + "?d=" + GRAVATAR_DEFAULT_IMAGE
+}
+
+// getDisplayName falls back to the part of the email before the @, so collaborators don't see the full address
+func getDisplayName(user *database.User) string {
+	if user.Name != "" {
+		return user.Name
+	}
+	username, _, _ := strings.Cut(user.Email, "@")
+	return username
+}
+
+func getCollaboratorProfile(user *database.User) CollaboratorProfile {
+	return CollaboratorProfile{
+		ID:        user.ID.Hex(),
+		Name:      getDisplayName(user),
+		AvatarURL: getAvatarURL(user),
+	}
+}
+
+// getCollaboratorProfiles returns the profiles of the owner and of the comment authors who are users,
+// keyed by user ID. In-app comments are stored with the author's user ID as their external ID
+func (api *API) getCollaboratorProfiles(ctx context.Context, ownerID primitive.ObjectID, comments *[]database.Comment) (map[string]CollaboratorProfile, error) {
+	userIDs := []primitive.ObjectID{ownerID}
+	if comments != nil {
+		for _, comment := range *comments {
+			userID, err := primitive.ObjectIDFromHex(comment.User.ExternalID)
+			if err == nil {
+				userIDs = append(userIDs, userID)
+			}
+		}
+	}
+	users, err := database.GetUsers(ctx, api.DB, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	profiles := map[string]CollaboratorProfile{}
+	for _, user := range users {
+		profiles[user.ID.Hex()] = getCollaboratorProfile(&user)
+	}
+	return profiles, nil
+}
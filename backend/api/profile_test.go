@@ -0,0 +1,164 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/config"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestProfile(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+
+	email := "test_profile@resonant-kelpie-404a42.netlify.app"
+	authToken := login(email, "")
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	pngImage := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 64)...)
+
+	UnauthorizedTest(t, http.MethodGet, "/profile/", nil)
+	t.Run("GetDefaults", func(t *testing.T) {
+		body := ServeRequest(t, authToken, http.MethodGet, "/profile/", nil, http.StatusOK, api)
+		assert.Equal(t, fmt.Sprintf(`{"id":"%s","name":"","email":"%s","greeting_name":"","locale":"","use_gravatar":true,"avatar_url":"%s"}`, userID.Hex(), email, getGravatarURL(email)), string(body))
+	})
+
+	UnauthorizedTest(t, http.MethodPatch, "/profile/", nil)
+	t.Run("UpdateEmptyPayload", func(t *testing.T) {
+		body := ServeRequest(t, authToken, http.MethodPatch, "/profile/", bytes.NewBuffer([]byte(`{}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"no profile fields to update","code":"bad_request"}`, string(body))
+	})
+	t.Run("UpdateEmptyName", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPatch, "/profile/", bytes.NewBuffer([]byte(`{"name": "  "}`)), http.StatusBadRequest, api)
+	})
+	t.Run("UpdateInvalidLocale", func(t *testing.T) {
+		body := ServeRequest(t, authToken, http.MethodPatch, "/profile/", bytes.NewBuffer([]byte(`{"locale": "english"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"'locale' must be a language tag such as en or en-US","code":"invalid_parameter","field_errors":[{"field":"locale"}]}`, string(body))
+	})
+	t.Run("UpdateSuccess", func(t *testing.T) {
+		body := ServeRequest(t, authToken, http.MethodPatch, "/profile/", bytes.NewBuffer([]byte(`{"name": " Jane Doe ", "greeting_name": "Jane", "locale": "en-GB", "use_gravatar": false}`)), http.StatusOK, api)
+		var result ProfileResult
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, ProfileResult{ID: userID.Hex(), Name: "Jane Doe", Email: email, GreetingName: "Jane", Locale: "en-GB"}, result)
+	})
+	t.Run("UpdateClearsGreetingName", func(t *testing.T) {
+		body := ServeRequest(t, authToken, http.MethodPatch, "/profile/", bytes.NewBuffer([]byte(`{"greeting_name": ""}`)), http.StatusOK, api)
+		var result ProfileResult
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, "", result.GreetingName)
+		assert.Equal(t, "Jane Doe", result.Name)
+		assert.Equal(t, "en-GB", result.Locale)
+	})
+
+	UnauthorizedTest(t, http.MethodPut, "/profile/avatar/", nil)
+	t.Run("UploadNotAnImage", func(t *testing.T) {
+		body := ServeRequest(t, authToken, http.MethodPut, "/profile/avatar/", strings.NewReader("<svg onload=alert(1)></svg>"), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"avatar must be a png, jpeg, gif or webp image","code":"bad_request"}`, string(body))
+	})
+	t.Run("UploadTooLarge", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPut, "/profile/avatar/", bytes.NewReader(append(pngImage, make([]byte, AVATAR_MAX_FILE_BYTES)...)), http.StatusBadRequest, api)
+	})
+	t.Run("UploadSuccess", func(t *testing.T) {
+		body := ServeRequest(t, authToken, http.MethodPut, "/profile/avatar/", bytes.NewReader(pngImage), http.StatusOK, api)
+		var result ProfileResult
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.True(t, strings.HasPrefix(result.AvatarURL, config.GetSettings().ServerURL+"avatars/"+userID.Hex()+"/?v="))
+
+		body = ServeRequest(t, "", http.MethodGet, "/avatars/"+userID.Hex()+"/", nil, http.StatusOK, api)
+		assert.Equal(t, pngImage, body)
+	})
+	t.Run("GetMissingAvatar", func(t *testing.T) {
+		ServeRequest(t, "", http.MethodGet, "/avatars/"+primitive.NewObjectID().Hex()+"/", nil, http.StatusNotFound, api)
+	})
+	t.Run("DeleteAvatar", func(t *testing.T) {
+		body := ServeRequest(t, authToken, http.MethodDelete, "/profile/avatar/", nil, http.StatusOK, api)
+		var result ProfileResult
+		assert.NoError(t, json.Unmarshal(body, &result))
+		// gravatar was turned off above
+		assert.Equal(t, "", result.AvatarURL)
+		ServeRequest(t, "", http.MethodGet, "/avatars/"+userID.Hex()+"/", nil, http.StatusNotFound, api)
+	})
+
+	t.Run("SharedTaskCollaborators", func(t *testing.T) {
+		commenterEmail := "test_profile_commenter@resonant-kelpie-404a42.netlify.app"
+		commenterID := getUserIDFromAuthToken(t, api.DB, login(commenterEmail, ""))
+		sharedAccess := database.SharedAccessPublic
+		mongoResult, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), &database.Task{
+			UserID:       userID,
+			SharedUntil:  primitive.NewDateTimeFromTime(time.Now().Add(time.Hour)),
+			SharedAccess: &sharedAccess,
+			Comments: &[]database.Comment{
+				{Body: "in app", User: database.ExternalUser{ExternalID: commenterID.Hex(), Email: commenterEmail}},
+				{Body: "from linear", User: database.ExternalUser{ExternalID: "linear-user", Email: "someone@example.com"}},
+			},
+		})
+		assert.NoError(t, err)
+		taskID := mongoResult.InsertedID.(primitive.ObjectID).Hex()
+
+		body := ServeRequest(t, authToken, http.MethodGet, "/shareable_tasks/detail/"+taskID+"/", nil, http.StatusOK, api)
+		var result ShareableTaskDetailsResponse
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, CollaboratorProfile{ID: userID.Hex(), Name: "Jane Doe"}, result.Owner)
+		assert.Equal(t, map[string]CollaboratorProfile{
+			userID.Hex():      {ID: userID.Hex(), Name: "Jane Doe"},
+			commenterID.Hex(): {ID: commenterID.Hex(), Name: "test_profile_commenter", AvatarURL: getGravatarURL(commenterEmail)},
+		}, result.Collaborators)
+	})
+	t.Run("NameIsEscapedInSharedTaskPreview", func(t *testing.T) {
+		ownerToken := login("test_profile_escaped@resonant-kelpie-404a42.netlify.app", "")
+		ownerID := getUserIDFromAuthToken(t, api.DB, ownerToken)
+		ServeRequest(t, ownerToken, http.MethodPatch, "/profile/", bytes.NewBuffer([]byte(`{"name": "\"><script>alert(1)</script>"}`)), http.StatusOK, api)
+		sharedAccess := database.SharedAccessPublic
+		mongoResult, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), &database.Task{
+			UserID:       ownerID,
+			SharedUntil:  primitive.NewDateTimeFromTime(time.Now().Add(time.Hour)),
+			SharedAccess: &sharedAccess,
+		})
+		assert.NoError(t, err)
+		taskID := mongoResult.InsertedID.(primitive.ObjectID).Hex()
+
+		body := ServeRequest(t, "", http.MethodGet, "/shareable_tasks/"+taskID+"/", nil, http.StatusOK, api)
+		assert.Contains(t, string(body), `Task shared by &#34;&gt;&lt;script&gt;alert(1)&lt;/script&gt; via General Task.`)
+		assert.NotContains(t, string(body), "<script>")
+	})
+}
+
+func TestGetAvatarURL(t *testing.T) {
+	userID := primitive.NewObjectID()
+	useGravatar := false
+	uploadedAt := time.Date(2023, time.January, 6, 9, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, getGravatarURL("jane@example.com"), getAvatarURL(&database.User{ID: userID, Email: "jane@example.com"}))
+	assert.Equal(t, "", getAvatarURL(&database.User{ID: userID, Email: "jane@example.com", UseGravatar: &useGravatar}))
+	assert.Equal(t,
+		fmt.Sprintf("%savatars/%s/?v=%d", config.GetSettings().ServerURL, userID.Hex(), uploadedAt.Unix()),
+		getAvatarURL(&database.User{ID: userID, Email: "jane@example.com", UseGravatar: &useGravatar, AvatarUpdatedAt: primitive.NewDateTimeFromTime(uploadedAt)}))
+}
+
+func TestGetGravatarURL(t *testing.T) {
+	// gravatar hashes the trimmed, lowercased email
+	assert.Equal(t, getGravatarURL("jane@example.com"), getGravatarURL(" Jane@Example.com "))
+	assert.Equal(t, "https://www.gravatar.com/avatar/8c87b489ce35cf2e2f39f80e282cb2e804932a56a213983eeeb428407d43b52d?d=identicon", getGravatarURL("jane@example.com"))
+}
+
+func TestGetDisplayName(t *testing.T) {
+	assert.Equal(t, "Jane Doe", getDisplayName(&database.User{Name: "Jane Doe", Email: "jane@example.com"}))
+	assert.Equal(t, "jane", getDisplayName(&database.User{Email: "jane@example.com"}))
+}
+
+func TestLocalePattern(t *testing.T) {
+	for _, locale := range []string{"en", "en-US", "zh-Hant-TW", "es-419", "fil"} {
+		assert.True(t, localePattern.MatchString(locale), locale)
+	}
+	for _, locale := range []string{"", "english", "EN", "en_US", "en-us", "en-US-x"} {
+		assert.False(t, localePattern.MatchString(locale), locale)
+	}
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/gin-gonic/gin"
+)
+
+type PullRequestReviewParams struct {
+	Event string `json:"event" binding:"required"`
+	Body  string `json:"body"`
+}
+
+type PullRequestMergeParams struct {
+	MergeMethod string `json:"merge_method"`
+}
+
+var reviewEvents = map[string]string{
+	"approve":         external.ReviewEventApprove,
+	"request_changes": external.ReviewEventRequestChanges,
+	"comment":         external.ReviewEventComment,
+}
+
+var mergeMethods = map[string]bool{
+	"":                         true,
+	external.MergeMethodMerge:  true,
+	external.MergeMethodSquash: true,
+	external.MergeMethodRebase: true,
+}
+
+func (api *API) PullRequestReview(c *gin.Context) {
+	var params PullRequestReviewParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	event, exists := reviewEvents[params.Event]
+	if !exists {
+		HandleBadRequest(c, "event must be one of approve, request_changes, or comment", "event")
+		return
+	}
+	// Github rejects reviews without a body unless they're approvals
+	if event != external.ReviewEventApprove && params.Body == "" {
+		HandleBadRequest(c, "body is required to request changes or comment", "body")
+		return
+	}
+
+	pullRequest, githubPR, ok := api.getGithubPullRequest(c)
+	if !ok {
+		return
+	}
+	err = githubPR.SubmitReview(c.Request.Context(), api.DB, pullRequest.UserID, pullRequest.SourceAccountID, pullRequest, external.PullRequestReviewObject{
+		Event: event,
+		Body:  params.Body,
+	})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to submit Github PR review")
+		HandleError(c, ErrorCodeServiceUnavailable, "failed to submit review to Github")
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+func (api *API) PullRequestMerge(c *gin.Context) {
+	var params PullRequestMergeParams
+	// the body is optional, so an empty one is fine
+	if c.Request.ContentLength > 0 {
+		err := c.BindJSON(&params)
+		if err != nil {
+			HandleBindError(c, &params, err, "invalid or missing parameter")
+			return
+		}
+	}
+	if !mergeMethods[params.MergeMethod] {
+		HandleBadRequest(c, "merge_method must be one of merge, squash, or rebase", "merge_method")
+		return
+	}
+
+	pullRequest, githubPR, ok := api.getGithubPullRequest(c)
+	if !ok {
+		return
+	}
+	err := githubPR.MergePullRequest(c.Request.Context(), api.DB, pullRequest.UserID, pullRequest.SourceAccountID, pullRequest, params.MergeMethod)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to merge Github PR")
+		HandleError(c, ErrorCodeServiceUnavailable, "failed to merge pull request on Github")
+		return
+	}
+	err = database.MarkCompleteWithCollection(c.Request.Context(), database.GetPullRequestCollection(api.DB), pullRequest.ID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to mark merged PR complete")
+		Handle500(c)
+		return
+	}
+	err = database.SetPullRequestMergedAt(c.Request.Context(), api.DB, pullRequest.ID, api.GetCurrentTime())
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+// getGithubPullRequest loads the pull request in the URL, responding with an error if it doesn't
+// belong to the user or isn't from Github
+func (api *API) getGithubPullRequest(c *gin.Context) (*database.PullRequest, external.GithubPRSource, bool) {
+	pullRequest, ok := api.getPullRequest(c)
+	if !ok {
+		return nil, external.GithubPRSource{}, false
+	}
+	taskSourceResult, err := api.ExternalConfig.GetSourceResult(pullRequest.SourceID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to load external task source")
+		Handle500(c)
+		return nil, external.GithubPRSource{}, false
+	}
+	githubPR, ok := taskSourceResult.Source.(external.GithubPRSource)
+	if !ok {
+		HandleBadRequest(c, "pull request is not from Github")
+		return nil, external.GithubPRSource{}, false
+	}
+	return pullRequest, githubPR, true
+}
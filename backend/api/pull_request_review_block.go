@@ -0,0 +1,116 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/franchizzle/task-manager/backend/focustime"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PullRequestReviewBlockParams struct {
+	AccountID  string `json:"account_id" binding:"required"`
+	CalendarID string `json:"calendar_id"`
+}
+
+type PullRequestReviewBlockResult struct {
+	EventID       primitive.ObjectID `json:"event_id"`
+	DatetimeStart primitive.DateTime `json:"datetime_start"`
+	DatetimeEnd   primitive.DateTime `json:"datetime_end"`
+}
+
+// PullRequestReviewBlock puts a block for reviewing the pull request on the user's Google calendar, at
+// the earliest free time in their working hours. The block is sized by how many lines the pull request
+// changes, and linked to it. An upcoming block that's already linked is returned instead of adding another
+func (api *API) PullRequestReviewBlock(c *gin.Context) {
+	var params PullRequestReviewBlockParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	timezoneOffset, err := api.getTimezoneOffset(c)
+	if err != nil {
+		HandleBadRequest(c, err.Error())
+		return
+	}
+	userID := getUserIDFromContext(c)
+	pullRequest, ok := api.getPullRequest(c)
+	if !ok {
+		return
+	}
+	if pullRequest.RequiredAction != external.ActionReviewPR {
+		HandleBadRequest(c, "pull request is not waiting on a review from the user")
+		return
+	}
+
+	timeNow := api.GetCurrentLocalizedTime(timezoneOffset)
+	linkedEvents, err := database.GetCalendarEvents(c.Request.Context(), api.DB, userID, &[]bson.M{
+		{"linked_pull_request_id": pullRequest.ID},
+		{"datetime_end": bson.M{"$gt": timeNow}},
+	})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch linked events")
+		Handle500(c)
+		return
+	}
+	if len(*linkedEvents) > 0 {
+		c.JSON(200, getPullRequestReviewBlockResult((*linkedEvents)[0]))
+		return
+	}
+
+	end := timeNow.AddDate(0, 0, focustime.DEFAULT_SCHEDULING_DAYS)
+	events, err := focustime.GetEventsBetween(c.Request.Context(), api.DB, userID, timeNow, end)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch events")
+		Handle500(c)
+		return
+	}
+	duration := focustime.GetReviewDuration(pullRequest.Additions, pullRequest.Deletions)
+	slot := focustime.GetEarliestSlot(focustime.GetFreeWindows(events, timeNow, end, api.getWorkingHours(userID)), duration)
+	if slot == nil {
+		HandleBadRequest(c, fmt.Sprintf("no free time for a %d minute review in the next %d days", int(duration.Minutes()), focustime.DEFAULT_SCHEDULING_DAYS))
+		return
+	}
+
+	taskSourceResult, err := api.ExternalConfig.GetSourceResult(external.TASK_SOURCE_ID_GCAL)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	// the source uses the ID as the external event ID
+	eventCreateObject := external.EventCreateObject{
+		ID:                  primitive.NewObjectID(),
+		AccountID:           params.AccountID,
+		CalendarID:          params.CalendarID,
+		Summary:             "Review: " + pullRequest.Title,
+		Description:         pullRequest.Deeplink,
+		DatetimeStart:       &slot.Start,
+		DatetimeEnd:         &slot.End,
+		LinkedPullRequestID: pullRequest.ID,
+	}
+	err = taskSourceResult.Source.CreateNewEvent(c.Request.Context(), api.DB, userID, params.AccountID, eventCreateObject)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to create review block")
+		Handle500(c)
+		return
+	}
+	event, err := saveCreatedEvent(c.Request.Context(), api.DB, userID, external.TASK_SOURCE_ID_GCAL, eventCreateObject, pullRequest.SourceID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to create calendar event in database")
+		Handle500(c)
+		return
+	}
+	c.JSON(201, getPullRequestReviewBlockResult(*event))
+}
+
+func getPullRequestReviewBlockResult(event database.CalendarEvent) PullRequestReviewBlockResult {
+	return PullRequestReviewBlockResult{
+		EventID:       event.ID,
+		DatetimeStart: event.DatetimeStart,
+		DatetimeEnd:   event.DatetimeEnd,
+	}
+}
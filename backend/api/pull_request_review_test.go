@@ -0,0 +1,139 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestPullRequestReview(t *testing.T) {
+	authToken := login("test_pull_request_review@resonant-kelpie-404a42.netlify.app", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	pullRequest, err := database.GetOrCreatePullRequest(
+		context.Background(),
+		api.DB,
+		userID,
+		"review_pr",
+		external.TASK_SOURCE_ID_GITHUB_PR,
+		&database.PullRequest{
+			UserID:         userID,
+			IDExternal:     "review_pr",
+			SourceID:       external.TASK_SOURCE_ID_GITHUB_PR,
+			RepositoryName: "dankmemes/ExampleRepository",
+			Number:         1,
+		},
+	)
+	assert.NoError(t, err)
+	otherPullRequest, err := database.GetOrCreatePullRequest(
+		context.Background(),
+		api.DB,
+		userID,
+		"other_pr",
+		"foobar_source",
+		&database.PullRequest{UserID: userID, IDExternal: "other_pr", SourceID: "foobar_source"},
+	)
+	assert.NoError(t, err)
+	url := "/pull_requests/" + pullRequest.ID.Hex() + "/review/"
+
+	UnauthorizedTest(t, "POST", url, nil)
+	t.Run("MissingEvent", func(t *testing.T) {
+		ServeRequest(t, authToken, "POST", url, bytes.NewBuffer([]byte(`{}`)), http.StatusBadRequest, api)
+	})
+	t.Run("InvalidEvent", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "POST", url, bytes.NewBuffer([]byte(`{"event": "dismiss"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"event must be one of approve, request_changes, or comment","code":"invalid_parameter","field_errors":[{"field":"event"}]}`, string(body))
+	})
+	t.Run("MissingBody", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "POST", url, bytes.NewBuffer([]byte(`{"event": "request_changes"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"body is required to request changes or comment","code":"invalid_parameter","field_errors":[{"field":"body"}]}`, string(body))
+	})
+	t.Run("PullRequestNotFound", func(t *testing.T) {
+		ServeRequest(t, authToken, "POST", "/pull_requests/"+primitive.NewObjectID().Hex()+"/review/", bytes.NewBuffer([]byte(`{"event": "approve"}`)), http.StatusNotFound, api)
+	})
+	t.Run("NotGithub", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "POST", "/pull_requests/"+otherPullRequest.ID.Hex()+"/review/", bytes.NewBuffer([]byte(`{"event": "approve"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"pull request is not from Github","code":"bad_request"}`, string(body))
+	})
+}
+
+func TestPullRequestMerge(t *testing.T) {
+	authToken := login("test_pull_request_merge@resonant-kelpie-404a42.netlify.app", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	url := "/pull_requests/" + primitive.NewObjectID().Hex() + "/merge/"
+
+	UnauthorizedTest(t, "POST", url, nil)
+	t.Run("InvalidMergeMethod", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "POST", url, bytes.NewBuffer([]byte(`{"merge_method": "octopus"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"merge_method must be one of merge, squash, or rebase","code":"invalid_parameter","field_errors":[{"field":"merge_method"}]}`, string(body))
+	})
+	t.Run("PullRequestNotFound", func(t *testing.T) {
+		ServeRequest(t, authToken, "POST", url, nil, http.StatusNotFound, api)
+	})
+}
+
+func TestPullRequestReviewBlock(t *testing.T) {
+	authToken := login("test_pull_request_review_block@resonant-kelpie-404a42.netlify.app", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	getPullRequest := func(idExternal string, requiredAction string) *database.PullRequest {
+		pullRequest, err := database.GetOrCreatePullRequest(
+			context.Background(),
+			api.DB,
+			userID,
+			idExternal,
+			external.TASK_SOURCE_ID_GITHUB_PR,
+			&database.PullRequest{
+				UserID:         userID,
+				IDExternal:     idExternal,
+				SourceID:       external.TASK_SOURCE_ID_GITHUB_PR,
+				Title:          "Fix login",
+				RequiredAction: requiredAction,
+			},
+		)
+		assert.NoError(t, err)
+		return pullRequest
+	}
+	reviewPullRequest := getPullRequest("review_block_pr", external.ActionReviewPR)
+	waitingPullRequest := getPullRequest("waiting_pr", external.ActionWaitingOnReview)
+	body := []byte(`{"account_id": "duck@test.com"}`)
+
+	UnauthorizedTest(t, "POST", "/pull_requests/"+reviewPullRequest.ID.Hex()+"/review_block/", nil)
+	t.Run("MissingAccountID", func(t *testing.T) {
+		ServeRequest(t, authToken, "POST", "/pull_requests/"+reviewPullRequest.ID.Hex()+"/review_block/", bytes.NewBuffer([]byte(`{}`)), http.StatusBadRequest, api)
+	})
+	t.Run("PullRequestNotFound", func(t *testing.T) {
+		ServeRequest(t, authToken, "POST", "/pull_requests/"+primitive.NewObjectID().Hex()+"/review_block/", bytes.NewBuffer(body), http.StatusNotFound, api)
+	})
+	t.Run("NotWaitingOnReview", func(t *testing.T) {
+		response := ServeRequest(t, authToken, "POST", "/pull_requests/"+waitingPullRequest.ID.Hex()+"/review_block/", bytes.NewBuffer(body), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"pull request is not waiting on a review from the user","code":"bad_request"}`, string(response))
+	})
+	t.Run("AlreadyScheduled", func(t *testing.T) {
+		event, err := database.UpdateOrCreateCalendarEvent(context.Background(), api.DB, userID, "review_block_event", external.TASK_SOURCE_ID_GCAL, database.CalendarEvent{
+			UserID:              userID,
+			IDExternal:          "review_block_event",
+			SourceID:            external.TASK_SOURCE_ID_GCAL,
+			DatetimeStart:       primitive.NewDateTimeFromTime(api.GetCurrentTime().Add(time.Hour)),
+			DatetimeEnd:         primitive.NewDateTimeFromTime(api.GetCurrentTime().Add(2 * time.Hour)),
+			LinkedPullRequestID: reviewPullRequest.ID,
+		}, nil)
+		assert.NoError(t, err)
+		response := ServeRequest(t, authToken, "POST", "/pull_requests/"+reviewPullRequest.ID.Hex()+"/review_block/", bytes.NewBuffer(body), http.StatusOK, api)
+		var result PullRequestReviewBlockResult
+		err = json.Unmarshal(response, &result)
+		assert.NoError(t, err)
+		assert.Equal(t, event.ID, result.EventID)
+	})
+}
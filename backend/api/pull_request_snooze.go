@@ -0,0 +1,101 @@
+package api
+
+import (
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PullRequestSnoozeParams struct {
+	SnoozedUntil *time.Time `json:"snoozed_until" binding:"required"`
+}
+
+// PullRequestSnooze hides the pull request from the PR list and overview until the given time
+func (api *API) PullRequestSnooze(c *gin.Context) {
+	var params PullRequestSnoozeParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	if !params.SnoozedUntil.After(api.GetCurrentTime()) {
+		HandleBadRequest(c, "'snoozed_until' must be in the future", "snoozed_until")
+		return
+	}
+	pullRequest, ok := api.getPullRequest(c)
+	if !ok {
+		return
+	}
+	err = database.SnoozePullRequest(c.Request.Context(), api.DB, pullRequest.UserID, pullRequest.ID, params.SnoozedUntil)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update pull request")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+func (api *API) PullRequestUnsnooze(c *gin.Context) {
+	pullRequest, ok := api.getPullRequest(c)
+	if !ok {
+		return
+	}
+	err := database.SnoozePullRequest(c.Request.Context(), api.DB, pullRequest.UserID, pullRequest.ID, nil)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update pull request")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+// PullRequestMute hides the pull request from the PR list and overview until its required action changes,
+// e.g. until CI finishes or someone else reviews it
+func (api *API) PullRequestMute(c *gin.Context) {
+	pullRequest, ok := api.getPullRequest(c)
+	if !ok {
+		return
+	}
+	if pullRequest.RequiredAction == "" {
+		HandleBadRequest(c, "pull request has no required action to mute")
+		return
+	}
+	err := database.MutePullRequest(c.Request.Context(), api.DB, pullRequest.UserID, pullRequest.ID, pullRequest.RequiredAction)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update pull request")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+func (api *API) PullRequestUnmute(c *gin.Context) {
+	pullRequest, ok := api.getPullRequest(c)
+	if !ok {
+		return
+	}
+	err := database.MutePullRequest(c.Request.Context(), api.DB, pullRequest.UserID, pullRequest.ID, "")
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update pull request")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+// getPullRequest loads the pull request in the URL, responding with a 404 if it doesn't belong to the user
+func (api *API) getPullRequest(c *gin.Context) (*database.PullRequest, bool) {
+	pullRequestID, err := primitive.ObjectIDFromHex(c.Param("pull_request_id"))
+	if err != nil {
+		Handle404(c)
+		return nil, false
+	}
+	pullRequest, err := database.GetPullRequest(c.Request.Context(), api.DB, pullRequestID, getUserIDFromContext(c))
+	if err != nil {
+		Handle404(c)
+		return nil, false
+	}
+	return pullRequest, true
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestPullRequestSnoozeAndMute(t *testing.T) {
+	authToken := login("test_pull_request_snooze@resonant-kelpie-404a42.netlify.app", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	isCompleted := false
+	pullRequest, err := database.GetOrCreatePullRequest(
+		context.Background(),
+		api.DB,
+		userID,
+		"snooze_pr",
+		external.TASK_SOURCE_ID_GITHUB_PR,
+		&database.PullRequest{
+			UserID:         userID,
+			IDExternal:     "snooze_pr",
+			IsCompleted:    &isCompleted,
+			SourceID:       external.TASK_SOURCE_ID_GITHUB_PR,
+			RepositoryID:   "snooze_repo",
+			RepositoryName: "dankmemes/ExampleRepository",
+			RequiredAction: external.ActionReviewPR,
+		},
+	)
+	assert.NoError(t, err)
+	url := "/pull_requests/" + pullRequest.ID.Hex()
+	inAnHour := time.Now().Add(time.Hour)
+	anHourAgo := time.Now().Add(-time.Hour)
+	getListedPullRequestIDs := func() []string {
+		var repositories []RepositoryResult
+		err := json.Unmarshal(ServeRequest(t, authToken, "GET", "/pull_requests/", nil, http.StatusOK, api), &repositories)
+		assert.NoError(t, err)
+		pullRequestIDs := []string{}
+		for _, repository := range repositories {
+			for _, pullRequest := range repository.PullRequests {
+				pullRequestIDs = append(pullRequestIDs, pullRequest.ID)
+			}
+		}
+		return pullRequestIDs
+	}
+
+	UnauthorizedTest(t, "POST", url+"/snooze/", nil)
+	UnauthorizedTest(t, "POST", url+"/mute/", nil)
+	t.Run("SnoozeNotFound", func(t *testing.T) {
+		body, _ := json.Marshal(PullRequestSnoozeParams{SnoozedUntil: &inAnHour})
+		ServeRequest(t, authToken, "POST", "/pull_requests/"+primitive.NewObjectID().Hex()+"/snooze/", bytes.NewBuffer(body), http.StatusNotFound, api)
+	})
+	t.Run("SnoozeInPast", func(t *testing.T) {
+		body, _ := json.Marshal(PullRequestSnoozeParams{SnoozedUntil: &anHourAgo})
+		response := ServeRequest(t, authToken, "POST", url+"/snooze/", bytes.NewBuffer(body), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"'snoozed_until' must be in the future","code":"invalid_parameter","field_errors":[{"field":"snoozed_until"}]}`, string(response))
+	})
+	t.Run("Snooze", func(t *testing.T) {
+		body, _ := json.Marshal(PullRequestSnoozeParams{SnoozedUntil: &inAnHour})
+		ServeRequest(t, authToken, "POST", url+"/snooze/", bytes.NewBuffer(body), http.StatusOK, api)
+		assert.NotContains(t, getListedPullRequestIDs(), pullRequest.ID.Hex())
+
+		ServeRequest(t, authToken, "DELETE", url+"/snooze/", nil, http.StatusOK, api)
+		assert.Contains(t, getListedPullRequestIDs(), pullRequest.ID.Hex())
+	})
+	t.Run("Mute", func(t *testing.T) {
+		ServeRequest(t, authToken, "POST", url+"/mute/", nil, http.StatusOK, api)
+		assert.NotContains(t, getListedPullRequestIDs(), pullRequest.ID.Hex())
+
+		// the pull request shows up again once it needs something else
+		_, err := database.GetPullRequestCollection(api.DB).UpdateOne(
+			context.Background(),
+			bson.M{"_id": pullRequest.ID},
+			bson.M{"$set": bson.M{"required_action": external.ActionMergePR}},
+		)
+		assert.NoError(t, err)
+		assert.Contains(t, getListedPullRequestIDs(), pullRequest.ID.Hex())
+	})
+	t.Run("Unmute", func(t *testing.T) {
+		ServeRequest(t, authToken, "POST", url+"/mute/", nil, http.StatusOK, api)
+		assert.NotContains(t, getListedPullRequestIDs(), pullRequest.ID.Hex())
+		ServeRequest(t, authToken, "DELETE", url+"/mute/", nil, http.StatusOK, api)
+		assert.Contains(t, getListedPullRequestIDs(), pullRequest.ID.Hex())
+	})
+}
@@ -2,9 +2,11 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"time"
 
+	"github.com/franchizzle/task-manager/backend/constants"
 	"github.com/franchizzle/task-manager/backend/external"
 
 	"github.com/franchizzle/task-manager/backend/database"
@@ -20,6 +22,25 @@ const (
 	PR_COLOR_GRAY   = "gray"
 )
 
+const (
+	PR_GROUP_BY_REPOSITORY = "repository"
+	PR_GROUP_BY_ACTION     = "action"
+)
+
+type PullRequestsListParams struct {
+	GroupBy    string `form:"group_by"`
+	GroupLimit *int   `form:"group_limit"`
+}
+
+// PullRequestGroupResult is a bucket of the grouped PR list. Count includes the pull requests cut off by
+// the group limit
+type PullRequestGroupResult struct {
+	ID           string               `json:"id"`
+	Name         string               `json:"name"`
+	Count        int                  `json:"count"`
+	PullRequests []*PullRequestResult `json:"pull_requests"`
+}
+
 type RepositoryResult struct {
 	ID           string               `json:"id"`
 	Name         string               `json:"name"`
@@ -27,22 +48,38 @@ type RepositoryResult struct {
 }
 
 type PullRequestResult struct {
-	ID            string               `json:"id"`
-	Title         string               `json:"title"`
-	Body          string               `json:"body"`
-	Number        int                  `json:"number"`
-	Status        PullRequestStatus    `json:"status"`
-	Author        string               `json:"author"`
-	Comments      []PullRequestComment `json:"comments"`
-	NumComments   int                  `json:"num_comments"`
-	NumCommits    int                  `json:"num_commits"`
-	CreatedAt     string               `json:"created_at"`
-	Branch        string               `json:"branch"`
-	BaseBranch    string               `json:"base_branch"`
-	Deeplink      string               `json:"deeplink"`
-	Additions     int                  `json:"additions"`
-	Deletions     int                  `json:"deletions"`
-	LastUpdatedAt string               `json:"last_updated_at"`
+	ID             string                    `json:"id"`
+	Title          string                    `json:"title"`
+	Body           string                    `json:"body"`
+	Number         int                       `json:"number"`
+	Status         PullRequestStatus         `json:"status"`
+	Author         string                    `json:"author"`
+	Comments       []PullRequestComment      `json:"comments"`
+	NumComments    int                       `json:"num_comments"`
+	NumCommits     int                       `json:"num_commits"`
+	CreatedAt      string                    `json:"created_at"`
+	Branch         string                    `json:"branch"`
+	BaseBranch     string                    `json:"base_branch"`
+	IsDraft        bool                      `json:"is_draft"`
+	Labels         []string                  `json:"labels"`
+	CommitStatuses []PullRequestCommitStatus `json:"commit_statuses"`
+	Deployments    []PullRequestDeployment   `json:"deployments"`
+	Deeplink       string                    `json:"deeplink"`
+	Additions      int                       `json:"additions"`
+	Deletions      int                       `json:"deletions"`
+	LastUpdatedAt  string                    `json:"last_updated_at"`
+}
+
+type PullRequestCommitStatus struct {
+	Context   string `json:"context"`
+	State     string `json:"state"`
+	TargetURL string `json:"target_url"`
+}
+
+type PullRequestDeployment struct {
+	Environment string `json:"environment"`
+	State       string `json:"state"`
+	URL         string `json:"url"`
 }
 
 type PullRequestComment struct {
@@ -71,11 +108,59 @@ func (api *API) PullRequestsList(c *gin.Context) {
 	userIDHex, _ := c.Get("user")
 	userID := userIDHex.(primitive.ObjectID)
 
-	pullRequests, err := database.GetPullRequests(db, userID, &[]bson.M{{"is_completed": false}})
+	pagination, err := getPagination(c)
+	if err != nil {
+		HandleBadRequest(c, err.Error())
+		return
+	}
+	var params PullRequestsListParams
+	err = c.ShouldBindQuery(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	groupLimit := constants.DEFAULT_PAGE_LIMIT
+	if params.GroupBy != "" {
+		if params.GroupBy != PR_GROUP_BY_REPOSITORY && params.GroupBy != PR_GROUP_BY_ACTION {
+			HandleBadRequest(c, "group_by must be one of repository or action", "group_by")
+			return
+		}
+		if pagination != nil {
+			HandleBadRequest(c, "group_by can't be used with limit or cursor", "group_by")
+			return
+		}
+		if params.GroupLimit != nil {
+			groupLimit = *params.GroupLimit
+		}
+		if groupLimit < 1 || groupLimit > constants.MAX_PAGE_LIMIT {
+			HandleBadRequest(c, fmt.Sprintf("group_limit must be between 1 and %d", constants.MAX_PAGE_LIMIT), "group_limit")
+			return
+		}
+	}
+	filters := append([]bson.M{{"is_completed": false}}, database.GetUnhiddenPullRequestFilters(api.GetCurrentTime())...)
+	if pagination != nil {
+		pullRequests, nextCursor, err := database.FindPageWithCollection[database.PullRequest](c.Request.Context(), database.GetPullRequestCollection(db), userID, &filters, *pagination)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to fetch pull requests page")
+			Handle500(c)
+			return
+		}
+		c.JSON(200, PaginatedResult[*RepositoryResult]{
+			Results:    api.groupPullRequestsByRepository(pullRequests),
+			NextCursor: nextCursor,
+		})
+		return
+	}
+
+	pullRequests, err := database.GetPullRequests(c.Request.Context(), db, userID, &filters)
 	if err != nil || pullRequests == nil {
 		Handle500(c)
 		return
 	}
+	if params.GroupBy != "" {
+		c.JSON(200, api.groupPullRequests(*pullRequests, params.GroupBy, groupLimit))
+		return
+	}
 
 	var repositories []database.Repository
 	repositoryCollection := database.GetRepositoryCollection(db)
@@ -93,9 +178,14 @@ func (api *API) PullRequestsList(c *gin.Context) {
 		return
 	}
 
+	c.JSON(200, api.groupPullRequestsByRepository(*pullRequests))
+}
+
+// groupPullRequestsByRepository sorts repositories by name and their pull requests by required action
+func (api *API) groupPullRequestsByRepository(pullRequests []database.PullRequest) []*RepositoryResult {
 	repositoryIDToResult := make(map[string]RepositoryResult)
 	repositoryIDToPullRequests := make(map[string][]*PullRequestResult)
-	for _, pullRequest := range *pullRequests {
+	for _, pullRequest := range pullRequests {
 		repositoryID := pullRequest.RepositoryID
 		repositoryResult := RepositoryResult{
 			ID:   repositoryID,
@@ -124,7 +214,43 @@ func (api *API) PullRequestsList(c *gin.Context) {
 	for _, repositoryResult := range repositoryResults {
 		api.sortPullRequestResults(repositoryResult.PullRequests)
 	}
-	c.JSON(200, repositoryResults)
+	return repositoryResults
+}
+
+// groupPullRequests buckets pull requests by repository, sorted by name, or by required action, in
+// the order the actions are worked on. Each group keeps at most limit pull requests
+func (api *API) groupPullRequests(pullRequests []database.PullRequest, groupBy string, limit int) []*PullRequestGroupResult {
+	groups := []*PullRequestGroupResult{}
+	keyToGroup := make(map[string]*PullRequestGroupResult)
+	for _, pullRequest := range pullRequests {
+		key, name := pullRequest.RepositoryID, pullRequest.RepositoryName
+		if groupBy == PR_GROUP_BY_ACTION {
+			key, name = pullRequest.RequiredAction, pullRequest.RequiredAction
+		}
+		group, exists := keyToGroup[key]
+		if !exists {
+			group = &PullRequestGroupResult{ID: key, Name: name, PullRequests: []*PullRequestResult{}}
+			keyToGroup[key] = group
+			groups = append(groups, group)
+		}
+		pullRequestResult := getResultFromPullRequest(pullRequest)
+		group.PullRequests = append(group.PullRequests, &pullRequestResult)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groupBy == PR_GROUP_BY_ACTION {
+			return external.ActionOrdering[groups[i].ID] < external.ActionOrdering[groups[j].ID]
+		}
+		return groups[i].Name < groups[j].Name
+	})
+	for _, group := range groups {
+		api.sortPullRequestResults(group.PullRequests)
+		group.Count = len(group.PullRequests)
+		if group.Count > limit {
+			group.PullRequests = group.PullRequests[:limit]
+		}
+	}
+	return groups
 }
 
 func (api *API) sortPullRequestResults(prResults []*PullRequestResult) {
@@ -159,6 +285,26 @@ func getResultFromPullRequest(pullRequest database.PullRequest) PullRequestResul
 			CreatedAt:       comment.CreatedAt.Time().UTC().Format(time.RFC3339),
 		})
 	}
+	labels := []string{}
+	if pullRequest.Labels != nil {
+		labels = pullRequest.Labels
+	}
+	commitStatuses := []PullRequestCommitStatus{}
+	for _, status := range pullRequest.CommitStatuses {
+		commitStatuses = append(commitStatuses, PullRequestCommitStatus{
+			Context:   status.Context,
+			State:     status.State,
+			TargetURL: status.TargetURL,
+		})
+	}
+	deployments := []PullRequestDeployment{}
+	for _, deployment := range pullRequest.Deployments {
+		deployments = append(deployments, PullRequestDeployment{
+			Environment: deployment.Environment,
+			State:       deployment.State,
+			URL:         deployment.URL,
+		})
+	}
 	return PullRequestResult{
 		ID:     pullRequest.ID.Hex(),
 		Title:  pullRequest.Title,
@@ -168,17 +314,21 @@ func getResultFromPullRequest(pullRequest database.PullRequest) PullRequestResul
 			Text:  pullRequest.RequiredAction,
 			Color: getColorFromRequiredAction(pullRequest.RequiredAction),
 		},
-		Author:        pullRequest.Author,
-		Comments:      comments,
-		NumComments:   pullRequest.CommentCount,
-		NumCommits:    pullRequest.CommitCount,
-		CreatedAt:     pullRequest.CreatedAtExternal.Time().UTC().Format(time.RFC3339),
-		Branch:        pullRequest.Branch,
-		BaseBranch:    pullRequest.BaseBranch,
-		Deeplink:      pullRequest.Deeplink,
-		Additions:     pullRequest.Additions,
-		Deletions:     pullRequest.Deletions,
-		LastUpdatedAt: pullRequest.LastUpdatedAt.Time().UTC().Format(time.RFC3339),
+		Author:         pullRequest.Author,
+		Comments:       comments,
+		NumComments:    pullRequest.CommentCount,
+		NumCommits:     pullRequest.CommitCount,
+		CreatedAt:      pullRequest.CreatedAtExternal.Time().UTC().Format(time.RFC3339),
+		Branch:         pullRequest.Branch,
+		BaseBranch:     pullRequest.BaseBranch,
+		IsDraft:        pullRequest.IsDraft != nil && *pullRequest.IsDraft,
+		Labels:         labels,
+		CommitStatuses: commitStatuses,
+		Deployments:    deployments,
+		Deeplink:       pullRequest.Deeplink,
+		Additions:      pullRequest.Additions,
+		Deletions:      pullRequest.Deletions,
+		LastUpdatedAt:  pullRequest.LastUpdatedAt.Time().UTC().Format(time.RFC3339),
 	}
 }
 
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"context"
+
 	"github.com/franchizzle/task-manager/backend/external"
 
 	"github.com/franchizzle/task-manager/backend/database"
@@ -10,35 +12,39 @@ import (
 
 func (api *API) PullRequestsFetch(c *gin.Context) {
 	userID := getUserIDFromContext(c)
-	tokens, err := database.GetAllExternalTokens(api.DB, userID)
+	tokens, err := database.GetAllExternalTokens(c.Request.Context(), api.DB, userID)
 	if err != nil {
 		Handle500(c)
 		return
 	}
 
-	currentPRs, err := database.GetActivePRs(api.DB, userID)
+	currentPRs, err := database.GetActivePRs(c.Request.Context(), api.DB, userID)
 	if err != nil {
 		Handle500(c)
 		return
 	}
 
-	fetchedPRs, failedFetchSources, err := api.fetchPRs(userID, tokens)
+	fetchedPRs, failedFetchSources, err := api.fetchPRs(c.Request.Context(), userID, tokens)
 	if err != nil {
 		Handle500(c)
 		return
 	}
 
-	err = api.adjustForCompletedPullRequests(api.DB, currentPRs, &fetchedPRs, failedFetchSources)
+	err = api.adjustForCompletedPullRequests(c.Request.Context(), api.DB, currentPRs, &fetchedPRs, failedFetchSources)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to adjust for completed tasks")
 		Handle500(c)
 		return
 	}
+	err = api.updateViewsLastFetched(c.Request.Context(), userID, []string{external.TASK_SERVICE_ID_GITHUB}, failedFetchSources)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update views last fetched")
+	}
 
 	c.JSON(200, gin.H{})
 }
 
-func (api *API) fetchPRs(userID interface{}, tokens []database.ExternalAPIToken) ([]*database.PullRequest, map[string]bool, error) {
+func (api *API) fetchPRs(ctx context.Context, userID interface{}, tokens []database.ExternalAPIToken) ([]*database.PullRequest, map[string]bool, error) {
 	pullRequestChannels := []chan external.PullRequestResult{}
 	// Loop through linked accounts and fetch relevant items
 	for _, token := range tokens {
@@ -49,7 +55,7 @@ func (api *API) fetchPRs(userID interface{}, tokens []database.ExternalAPIToken)
 		}
 		for _, taskSourceResult := range taskServiceResult.Sources {
 			var pullRequests = make(chan external.PullRequestResult)
-			go taskSourceResult.Source.GetPullRequests(api.DB, userID.(primitive.ObjectID), token.AccountID, pullRequests)
+			go taskSourceResult.Source.GetPullRequests(ctx, api.DB, userID.(primitive.ObjectID), token.AccountID, pullRequests)
 			pullRequestChannels = append(pullRequestChannels, pullRequests)
 		}
 	}
@@ -126,12 +126,15 @@ func TestPullRequestList(t *testing.T) {
 							LineNumberEnd:   420,
 							CreatedAt:       "2022-04-20T19:01:12Z",
 						}},
-						BaseBranch:    "base_branch",
-						CreatedAt:     "1970-01-01T00:00:00Z",
-						LastUpdatedAt: primitive.NewDateTimeFromTime(timePullRequestUpdated).Time().UTC().Format(time.RFC3339),
-						NumCommits:    7,
-						Additions:     690,
-						Deletions:     42,
+						BaseBranch:     "base_branch",
+						Labels:         []string{},
+						CommitStatuses: []PullRequestCommitStatus{},
+						Deployments:    []PullRequestDeployment{},
+						CreatedAt:      "1970-01-01T00:00:00Z",
+						LastUpdatedAt:  primitive.NewDateTimeFromTime(timePullRequestUpdated).Time().UTC().Format(time.RFC3339),
+						NumCommits:     7,
+						Additions:      690,
+						Deletions:      42,
 					},
 					{
 						Title: "fix the oopsie",
@@ -150,12 +153,15 @@ func TestPullRequestList(t *testing.T) {
 							LineNumberEnd:   420,
 							CreatedAt:       "2022-04-20T19:01:12Z",
 						}},
-						BaseBranch:    "base_branch",
-						CreatedAt:     "1970-01-01T00:00:00Z",
-						LastUpdatedAt: primitive.NewDateTimeFromTime(timePullRequestUpdated).Time().UTC().Format(time.RFC3339),
-						NumCommits:    7,
-						Additions:     690,
-						Deletions:     42,
+						BaseBranch:     "base_branch",
+						Labels:         []string{},
+						CommitStatuses: []PullRequestCommitStatus{},
+						Deployments:    []PullRequestDeployment{},
+						CreatedAt:      "1970-01-01T00:00:00Z",
+						LastUpdatedAt:  primitive.NewDateTimeFromTime(timePullRequestUpdated).Time().UTC().Format(time.RFC3339),
+						NumCommits:     7,
+						Additions:      690,
+						Deletions:      42,
 					},
 					{
 						Title: "fix the oopsie",
@@ -174,12 +180,15 @@ func TestPullRequestList(t *testing.T) {
 							LineNumberEnd:   420,
 							CreatedAt:       "2022-04-20T19:01:12Z",
 						}},
-						BaseBranch:    "base_branch",
-						CreatedAt:     "1970-01-01T00:00:00Z",
-						LastUpdatedAt: primitive.NewDateTimeFromTime(timePullRequestUpdated).Time().UTC().Format(time.RFC3339),
-						NumCommits:    7,
-						Additions:     690,
-						Deletions:     42,
+						BaseBranch:     "base_branch",
+						Labels:         []string{},
+						CommitStatuses: []PullRequestCommitStatus{},
+						Deployments:    []PullRequestDeployment{},
+						CreatedAt:      "1970-01-01T00:00:00Z",
+						LastUpdatedAt:  primitive.NewDateTimeFromTime(timePullRequestUpdated).Time().UTC().Format(time.RFC3339),
+						NumCommits:     7,
+						Additions:      690,
+						Deletions:      42,
 					},
 					{
 						Title: "fix the oopsie",
@@ -198,12 +207,15 @@ func TestPullRequestList(t *testing.T) {
 							LineNumberEnd:   420,
 							CreatedAt:       "2022-04-20T19:01:12Z",
 						}},
-						BaseBranch:    "base_branch",
-						CreatedAt:     "1970-01-01T00:00:00Z",
-						LastUpdatedAt: primitive.NewDateTimeFromTime(timePullRequestUpdated).Time().UTC().Format(time.RFC3339),
-						NumCommits:    7,
-						Additions:     690,
-						Deletions:     42,
+						BaseBranch:     "base_branch",
+						Labels:         []string{},
+						CommitStatuses: []PullRequestCommitStatus{},
+						Deployments:    []PullRequestDeployment{},
+						CreatedAt:      "1970-01-01T00:00:00Z",
+						LastUpdatedAt:  primitive.NewDateTimeFromTime(timePullRequestUpdated).Time().UTC().Format(time.RFC3339),
+						NumCommits:     7,
+						Additions:      690,
+						Deletions:      42,
 					},
 					{
 						Title: "fix the oopsie",
@@ -222,12 +234,15 @@ func TestPullRequestList(t *testing.T) {
 							LineNumberEnd:   420,
 							CreatedAt:       "2022-04-20T19:01:12Z",
 						}},
-						BaseBranch:    "base_branch",
-						CreatedAt:     "1970-01-01T00:00:00Z",
-						LastUpdatedAt: primitive.NewDateTimeFromTime(timePullRequestUpdated).Time().UTC().Format(time.RFC3339),
-						NumCommits:    7,
-						Additions:     690,
-						Deletions:     42,
+						BaseBranch:     "base_branch",
+						Labels:         []string{},
+						CommitStatuses: []PullRequestCommitStatus{},
+						Deployments:    []PullRequestDeployment{},
+						CreatedAt:      "1970-01-01T00:00:00Z",
+						LastUpdatedAt:  primitive.NewDateTimeFromTime(timePullRequestUpdated).Time().UTC().Format(time.RFC3339),
+						NumCommits:     7,
+						Additions:      690,
+						Deletions:      42,
 					},
 					{
 						Title: "fix the oopsie",
@@ -246,12 +261,15 @@ func TestPullRequestList(t *testing.T) {
 							LineNumberEnd:   420,
 							CreatedAt:       "2022-04-20T19:01:12Z",
 						}},
-						BaseBranch:    "base_branch",
-						CreatedAt:     "1970-01-01T00:00:00Z",
-						LastUpdatedAt: primitive.NewDateTimeFromTime(timePullRequestUpdated).Time().UTC().Format(time.RFC3339),
-						NumCommits:    7,
-						Additions:     690,
-						Deletions:     42,
+						BaseBranch:     "base_branch",
+						Labels:         []string{},
+						CommitStatuses: []PullRequestCommitStatus{},
+						Deployments:    []PullRequestDeployment{},
+						CreatedAt:      "1970-01-01T00:00:00Z",
+						LastUpdatedAt:  primitive.NewDateTimeFromTime(timePullRequestUpdated).Time().UTC().Format(time.RFC3339),
+						NumCommits:     7,
+						Additions:      690,
+						Deletions:      42,
 					},
 					{
 						Title: "fix the oopsie",
@@ -270,12 +288,15 @@ func TestPullRequestList(t *testing.T) {
 							LineNumberEnd:   420,
 							CreatedAt:       "2022-04-20T19:01:12Z",
 						}},
-						BaseBranch:    "base_branch",
-						CreatedAt:     "1970-01-01T00:00:00Z",
-						LastUpdatedAt: primitive.NewDateTimeFromTime(timePullRequestUpdated).Time().UTC().Format(time.RFC3339),
-						NumCommits:    7,
-						Additions:     690,
-						Deletions:     42,
+						BaseBranch:     "base_branch",
+						Labels:         []string{},
+						CommitStatuses: []PullRequestCommitStatus{},
+						Deployments:    []PullRequestDeployment{},
+						CreatedAt:      "1970-01-01T00:00:00Z",
+						LastUpdatedAt:  primitive.NewDateTimeFromTime(timePullRequestUpdated).Time().UTC().Format(time.RFC3339),
+						NumCommits:     7,
+						Additions:      690,
+						Deletions:      42,
 					},
 					{
 						Title: "fix the oopsie",
@@ -294,12 +315,15 @@ func TestPullRequestList(t *testing.T) {
 							LineNumberEnd:   420,
 							CreatedAt:       "2022-04-20T19:01:12Z",
 						}},
-						BaseBranch:    "base_branch",
-						CreatedAt:     "1970-01-01T00:00:00Z",
-						LastUpdatedAt: primitive.NewDateTimeFromTime(timePullRequestUpdated).Time().UTC().Format(time.RFC3339),
-						NumCommits:    7,
-						Additions:     690,
-						Deletions:     42,
+						BaseBranch:     "base_branch",
+						Labels:         []string{},
+						CommitStatuses: []PullRequestCommitStatus{},
+						Deployments:    []PullRequestDeployment{},
+						CreatedAt:      "1970-01-01T00:00:00Z",
+						LastUpdatedAt:  primitive.NewDateTimeFromTime(timePullRequestUpdated).Time().UTC().Format(time.RFC3339),
+						NumCommits:     7,
+						Additions:      690,
+						Deletions:      42,
 					},
 					{
 						Title: "fix the oopsie",
@@ -318,12 +342,15 @@ func TestPullRequestList(t *testing.T) {
 							LineNumberEnd:   420,
 							CreatedAt:       "2022-04-20T19:01:12Z",
 						}},
-						BaseBranch:    "base_branch",
-						CreatedAt:     "1970-01-01T00:00:00Z",
-						LastUpdatedAt: primitive.NewDateTimeFromTime(timePullRequestUpdated).Time().UTC().Format(time.RFC3339),
-						NumCommits:    7,
-						Additions:     690,
-						Deletions:     42,
+						BaseBranch:     "base_branch",
+						Labels:         []string{},
+						CommitStatuses: []PullRequestCommitStatus{},
+						Deployments:    []PullRequestDeployment{},
+						CreatedAt:      "1970-01-01T00:00:00Z",
+						LastUpdatedAt:  primitive.NewDateTimeFromTime(timePullRequestUpdated).Time().UTC().Format(time.RFC3339),
+						NumCommits:     7,
+						Additions:      690,
+						Deletions:      42,
 					},
 				},
 			},
@@ -348,12 +375,15 @@ func TestPullRequestList(t *testing.T) {
 							LineNumberEnd:   420,
 							CreatedAt:       "2022-04-20T19:01:12Z",
 						}},
-						BaseBranch:    "base_branch",
-						CreatedAt:     "1970-01-01T00:00:00Z",
-						LastUpdatedAt: primitive.NewDateTimeFromTime(timePullRequestUpdated).Time().UTC().Format(time.RFC3339),
-						NumCommits:    7,
-						Additions:     690,
-						Deletions:     42,
+						BaseBranch:     "base_branch",
+						Labels:         []string{},
+						CommitStatuses: []PullRequestCommitStatus{},
+						Deployments:    []PullRequestDeployment{},
+						CreatedAt:      "1970-01-01T00:00:00Z",
+						LastUpdatedAt:  primitive.NewDateTimeFromTime(timePullRequestUpdated).Time().UTC().Format(time.RFC3339),
+						NumCommits:     7,
+						Additions:      690,
+						Deletions:      42,
 					},
 					{
 						Title: "fix the oopsie",
@@ -372,12 +402,15 @@ func TestPullRequestList(t *testing.T) {
 							LineNumberEnd:   420,
 							CreatedAt:       "2022-04-20T19:01:12Z",
 						}},
-						BaseBranch:    "base_branch",
-						CreatedAt:     "1970-01-01T00:00:00Z",
-						LastUpdatedAt: primitive.NewDateTimeFromTime(timeHourEarlier).Time().UTC().Format(time.RFC3339),
-						NumCommits:    7,
-						Additions:     690,
-						Deletions:     42,
+						BaseBranch:     "base_branch",
+						Labels:         []string{},
+						CommitStatuses: []PullRequestCommitStatus{},
+						Deployments:    []PullRequestDeployment{},
+						CreatedAt:      "1970-01-01T00:00:00Z",
+						LastUpdatedAt:  primitive.NewDateTimeFromTime(timeHourEarlier).Time().UTC().Format(time.RFC3339),
+						NumCommits:     7,
+						Additions:      690,
+						Deletions:      42,
 					},
 				},
 			},
@@ -385,12 +418,78 @@ func TestPullRequestList(t *testing.T) {
 	})
 }
 
+func TestPullRequestListGrouped(t *testing.T) {
+	authToken := login("test_pull_request_list_grouped@resonant-kelpie-404a42.netlify.app", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	now := time.Now()
+	reviewPR1, err := createTestPullRequest(api.DB, userID, "stonks/b_repository", false, true, external.ActionReviewPR, now, "repository_b")
+	assert.NoError(t, err)
+	reviewPR2, err := createTestPullRequest(api.DB, userID, "stonks/a_repository", false, true, external.ActionReviewPR, now.Add(-time.Hour), "repository_a")
+	assert.NoError(t, err)
+	mergePR, err := createTestPullRequest(api.DB, userID, "stonks/a_repository", false, true, external.ActionMergePR, now, "repository_a")
+	assert.NoError(t, err)
+	_, err = createTestPullRequest(api.DB, userID, "stonks/a_repository", true, true, external.ActionFixFailedCI, now, "repository_a")
+	assert.NoError(t, err)
+
+	getGroups := func(url string) []PullRequestGroupResult {
+		var groups []PullRequestGroupResult
+		err := json.Unmarshal(ServeRequest(t, authToken, "GET", url, nil, http.StatusOK, api), &groups)
+		assert.NoError(t, err)
+		return groups
+	}
+	getIDs := func(group PullRequestGroupResult) []string {
+		ids := []string{}
+		for _, pullRequest := range group.PullRequests {
+			ids = append(ids, pullRequest.ID)
+		}
+		return ids
+	}
+
+	t.Run("InvalidGroupBy", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "GET", "/pull_requests/?group_by=author", nil, http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"group_by must be one of repository or action","code":"invalid_parameter","field_errors":[{"field":"group_by"}]}`, string(body))
+	})
+	t.Run("WithPagination", func(t *testing.T) {
+		ServeRequest(t, authToken, "GET", "/pull_requests/?group_by=action&limit=10", nil, http.StatusBadRequest, api)
+	})
+	t.Run("InvalidGroupLimit", func(t *testing.T) {
+		ServeRequest(t, authToken, "GET", "/pull_requests/?group_by=action&group_limit=0", nil, http.StatusBadRequest, api)
+	})
+	t.Run("Repository", func(t *testing.T) {
+		groups := getGroups("/pull_requests/?group_by=repository")
+		assert.Equal(t, 2, len(groups))
+		assert.Equal(t, "repository_a", groups[0].ID)
+		assert.Equal(t, "stonks/a_repository", groups[0].Name)
+		assert.Equal(t, 2, groups[0].Count)
+		assert.Equal(t, []string{reviewPR2.ID.Hex(), mergePR.ID.Hex()}, getIDs(groups[0]))
+		assert.Equal(t, "repository_b", groups[1].ID)
+		assert.Equal(t, []string{reviewPR1.ID.Hex()}, getIDs(groups[1]))
+	})
+	t.Run("Action", func(t *testing.T) {
+		groups := getGroups("/pull_requests/?group_by=action")
+		assert.Equal(t, 2, len(groups))
+		assert.Equal(t, external.ActionReviewPR, groups[0].ID)
+		assert.Equal(t, 2, groups[0].Count)
+		assert.Equal(t, []string{reviewPR1.ID.Hex(), reviewPR2.ID.Hex()}, getIDs(groups[0]))
+		assert.Equal(t, external.ActionMergePR, groups[1].ID)
+		assert.Equal(t, []string{mergePR.ID.Hex()}, getIDs(groups[1]))
+	})
+	t.Run("GroupLimit", func(t *testing.T) {
+		groups := getGroups("/pull_requests/?group_by=action&group_limit=1")
+		assert.Equal(t, 2, groups[0].Count)
+		assert.Equal(t, []string{reviewPR1.ID.Hex()}, getIDs(groups[0]))
+	})
+}
+
 func createTestPullRequest(db *mongo.Database, userID primitive.ObjectID, repositoryName string, isCompleted bool, isPullRequest bool, requiredAction string, lastUpdatedAt time.Time, repositoryID string) (*database.PullRequest, error) {
 	externalID := primitive.NewObjectID().Hex()
 	lastUpdatedAtPrimitive := primitive.NewDateTimeFromTime(lastUpdatedAt)
 	commentCreatedAtTime, _ := time.Parse(time.RFC3339, "2022-04-20T19:01:12Z")
 	commentCreatedAt := primitive.NewDateTimeFromTime(commentCreatedAtTime)
 	return database.GetOrCreatePullRequest(
+		context.Background(),
 		db,
 		userID,
 		externalID,
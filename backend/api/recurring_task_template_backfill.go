@@ -26,7 +26,7 @@ func (api *API) RecurringTaskTemplateBackfillTasks(c *gin.Context) {
 	userID := getUserIDFromContext(c)
 
 	var templates []database.RecurringTaskTemplate
-	err := database.FindWithCollection(database.GetRecurringTaskTemplateCollection(api.DB), userID, &[]bson.M{{"is_deleted": false}, {"is_enabled": true}}, &templates, nil)
+	err := database.FindWithCollection(c.Request.Context(), database.GetRecurringTaskTemplateCollection(api.DB), userID, &[]bson.M{{"is_deleted": false}, {"is_enabled": true}}, &templates, nil)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to fetch recurring task templates")
 		Handle500(c)
@@ -63,7 +63,7 @@ func (api *API) RecurringTaskTemplateBackfillTasks(c *gin.Context) {
 }
 
 func (api *API) backfillTemplate(c *gin.Context, template database.RecurringTaskTemplate) (time.Time, error) {
-	offset, err := GetTimezoneOffsetFromHeader(c)
+	offset, err := api.getTimezoneOffset(c)
 	if err != nil {
 		api.Logger.Error().Msg("unable to get localized time")
 		return api.GetCurrentTime(), err
@@ -62,7 +62,7 @@ func TestRecurringTaskTemplateBackfill(t *testing.T) {
 		router.ServeHTTP(recorder, request)
 		assert.Equal(t, http.StatusOK, recorder.Code)
 
-		tasks, err := database.GetActiveTasks(api.DB, userID)
+		tasks, err := database.GetActiveTasks(context.Background(), api.DB, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 6, len(*tasks))
 		assert.Equal(t, templateID, (*tasks)[5].RecurringTaskTemplateID)
@@ -107,7 +107,7 @@ func TestRecurringTaskTemplateBackfill(t *testing.T) {
 		router.ServeHTTP(recorder, request)
 		assert.Equal(t, http.StatusOK, recorder.Code)
 
-		tasks, err := database.GetActiveTasks(api.DB, userID)
+		tasks, err := database.GetActiveTasks(context.Background(), api.DB, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 6, len(*tasks))
 		assert.Equal(t, templateID, (*tasks)[5].RecurringTaskTemplateID)
@@ -153,7 +153,7 @@ func TestRecurringTaskTemplateBackfill(t *testing.T) {
 		router.ServeHTTP(recorder, request)
 		assert.Equal(t, http.StatusOK, recorder.Code)
 
-		tasks, err := database.GetActiveTasks(api.DB, userID)
+		tasks, err := database.GetActiveTasks(context.Background(), api.DB, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 7, len(*tasks))
 		assert.Equal(t, templateID, (*tasks)[5].RecurringTaskTemplateID)
@@ -201,7 +201,7 @@ func TestRecurringTaskTemplateBackfill(t *testing.T) {
 		router.ServeHTTP(recorder, request)
 		assert.Equal(t, http.StatusOK, recorder.Code)
 
-		tasks, err := database.GetActiveTasks(api.DB, userID)
+		tasks, err := database.GetActiveTasks(context.Background(), api.DB, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 6, len(*tasks))
 		assert.Equal(t, templateID, (*tasks)[5].RecurringTaskTemplateID)
@@ -246,7 +246,7 @@ func TestRecurringTaskTemplateBackfill(t *testing.T) {
 		router.ServeHTTP(recorder, request)
 		assert.Equal(t, http.StatusOK, recorder.Code)
 
-		tasks, err := database.GetActiveTasks(api.DB, userID)
+		tasks, err := database.GetActiveTasks(context.Background(), api.DB, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 6, len(*tasks))
 		assert.Equal(t, templateID, (*tasks)[5].RecurringTaskTemplateID)
@@ -294,7 +294,7 @@ func TestRecurringTaskTemplateBackfill(t *testing.T) {
 		router.ServeHTTP(recorder, request)
 		assert.Equal(t, http.StatusOK, recorder.Code)
 
-		tasks, err := database.GetActiveTasks(api.DB, userID)
+		tasks, err := database.GetActiveTasks(context.Background(), api.DB, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 7, len(*tasks))
 		assert.Equal(t, templateID, (*tasks)[5].RecurringTaskTemplateID)
@@ -24,7 +24,7 @@ func (api *API) RecurringTaskTemplateCreate(c *gin.Context) {
 	var templateCreateParams RecurringTaskTemplateCreateParams
 	err := c.BindJSON(&templateCreateParams)
 	if err != nil {
-		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		HandleBindError(c, &templateCreateParams, err, "invalid or missing parameter")
 		return
 	}
 
@@ -34,7 +34,7 @@ func (api *API) RecurringTaskTemplateCreate(c *gin.Context) {
 	if templateCreateParams.IDTaskSection != nil {
 		taskSection, err = getValidTaskSection(*templateCreateParams.IDTaskSection, userID, api.DB)
 		if err != nil {
-			c.JSON(400, gin.H{"detail": "'id_task_section' is not a valid ID"})
+			HandleBadRequest(c, "'id_task_section' is not a valid ID", "id_task_section")
 			return
 		}
 	}
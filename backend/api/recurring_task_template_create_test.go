@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -80,7 +81,7 @@ func TestRecurringTaskTemplateCreate(t *testing.T) {
 		assert.Equal(t, http.StatusOK, recorder.Code)
 
 		var templates []database.RecurringTaskTemplate
-		err = database.FindWithCollection(database.GetRecurringTaskTemplateCollection(api.DB), userID, &[]bson.M{{"is_deleted": false}}, &templates, nil)
+		err = database.FindWithCollection(context.Background(), database.GetRecurringTaskTemplateCollection(api.DB), userID, &[]bson.M{{"is_deleted": false}}, &templates, nil)
 		assert.NoError(t, err)
 		assert.Equal(t, "hello!", *(templates[0].Title))
 		assert.Equal(t, primitive.NewDateTimeFromTime(currentTime), templates[0].CreatedAt)
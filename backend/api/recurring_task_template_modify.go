@@ -36,7 +36,7 @@ func (api *API) RecurringTaskTemplateModify(c *gin.Context) {
 	var modifyParams RecurringTaskTemplateModifyParams
 	err = c.BindJSON(&modifyParams)
 	if err != nil {
-		c.JSON(400, gin.H{"detail": "parameter missing or malformatted"})
+		HandleBindError(c, &modifyParams, err, "parameter missing or malformatted")
 		return
 	}
 
@@ -46,22 +46,22 @@ func (api *API) RecurringTaskTemplateModify(c *gin.Context) {
 	if modifyParams.IDTaskSection != nil {
 		taskSection, err = getValidTaskSection(*modifyParams.IDTaskSection, userID, api.DB)
 		if err != nil {
-			c.JSON(400, gin.H{"detail": "'id_task_section' is not a valid ID"})
+			HandleBadRequest(c, "'id_task_section' is not a valid ID", "id_task_section")
 			return
 		}
 	}
 
 	var template database.RecurringTaskTemplate
-	result := database.FindOneWithCollection(database.GetRecurringTaskTemplateCollection(api.DB), userID, templateID)
+	result := database.FindOneWithCollection(c.Request.Context(), database.GetRecurringTaskTemplateCollection(api.DB), userID, templateID)
 	err = result.Decode(&template)
 	if err != nil {
-		c.JSON(404, gin.H{"detail": "template not found", "templateID": templateID})
+		HandleAPIError(c, NewAPIError(ErrorCodeNotFound, "template not found").WithMetadata("templateID", templateID))
 		return
 	}
 
 	// check if all fields are empty
 	if modifyParams == (RecurringTaskTemplateModifyParams{}) {
-		c.JSON(400, gin.H{"detail": "template changes missing"})
+		HandleBadRequest(c, "template changes missing")
 		return
 	}
 
@@ -94,7 +94,7 @@ func TestRecurringTaskTemplateModify(t *testing.T) {
 		assert.Equal(t, http.StatusOK, recorder.Code)
 
 		var templates []database.RecurringTaskTemplate
-		err = database.FindWithCollection(database.GetRecurringTaskTemplateCollection(api.DB), userID, &[]bson.M{{"is_deleted": false}}, &templates, nil)
+		err = database.FindWithCollection(context.Background(), database.GetRecurringTaskTemplateCollection(api.DB), userID, &[]bson.M{{"is_deleted": false}}, &templates, nil)
 		assert.NoError(t, err)
 		assert.Equal(t, "new title!", *(templates[0].Title))
 		assert.True(t, *templates[0].ReplaceExisting)
@@ -143,13 +143,13 @@ func TestRecurringTaskTemplateModify(t *testing.T) {
 		assert.True(t, *template.IsDeleted)
 
 		// check that task recurring task template IDs are correct
-		task1, err := database.GetTask(api.DB, task1ID, userID)
+		task1, err := database.GetTask(context.Background(), api.DB, task1ID, userID)
 		assert.NoError(t, err)
-		task2, err := database.GetTask(api.DB, task2ID, userID)
+		task2, err := database.GetTask(context.Background(), api.DB, task2ID, userID)
 		assert.NoError(t, err)
-		task3, err := database.GetTask(api.DB, task3ID, userID)
+		task3, err := database.GetTask(context.Background(), api.DB, task3ID, userID)
 		assert.NoError(t, err)
-		task4, err := database.GetTask(api.DB, task4ID, userID)
+		task4, err := database.GetTask(context.Background(), api.DB, task4ID, userID)
 		assert.NoError(t, err)
 
 		assert.Equal(t, task1.RecurringTaskTemplateID, templateID)
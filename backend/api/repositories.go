@@ -0,0 +1,68 @@
+package api
+
+import (
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type RepositoryFilterResult struct {
+	ID             primitive.ObjectID `json:"id"`
+	AccountID      string             `json:"account_id"`
+	Name           string             `json:"name"`
+	Deeplink       string             `json:"deeplink"`
+	IsInFilterList bool               `json:"is_in_filter_list"`
+}
+
+type RepositoryModifyParams struct {
+	IsInFilterList *bool `json:"is_in_filter_list" binding:"required"`
+}
+
+// RepositoriesList returns the user's Github repositories and Azure DevOps projects and whether each
+// is in its account's filter list. Whether the list is an allowlist or a denylist is set per account in settings
+func (api *API) RepositoriesList(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	repositories, err := database.GetRepositories(c.Request.Context(), api.DB, userID, nil)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	results := []RepositoryFilterResult{}
+	for _, repository := range *repositories {
+		results = append(results, RepositoryFilterResult{
+			ID:             repository.ID,
+			AccountID:      repository.AccountID,
+			Name:           repository.FullName,
+			Deeplink:       repository.Deeplink,
+			IsInFilterList: repository.IsInFilterList,
+		})
+	}
+	c.JSON(200, results)
+}
+
+func (api *API) RepositoryModify(c *gin.Context) {
+	repositoryID, err := primitive.ObjectIDFromHex(c.Param("repository_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	var params RepositoryModifyParams
+	err = c.BindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	userID := getUserIDFromContext(c)
+	err = database.UpdateRepositoryFilterList(c.Request.Context(), api.DB, userID, repositoryID, *params.IsInFilterList)
+	if err == mongo.ErrNoDocuments {
+		Handle404(c)
+		return
+	}
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update repository filter list")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
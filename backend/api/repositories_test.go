@@ -0,0 +1,56 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRepositories(t *testing.T) {
+	authToken := login("test_repositories@resonant-kelpie-404a42.netlify.app", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	insertResult, err := database.GetRepositoryCollection(api.DB).InsertOne(context.Background(), database.Repository{
+		UserID:       userID,
+		AccountID:    "account",
+		FullName:     "dankmemes/ExampleRepository",
+		RepositoryID: "123",
+	})
+	assert.NoError(t, err)
+	repositoryID := insertResult.InsertedID.(primitive.ObjectID)
+	url := "/repositories/" + repositoryID.Hex() + "/"
+
+	UnauthorizedTest(t, "GET", "/repositories/", nil)
+	UnauthorizedTest(t, "PATCH", url, nil)
+	t.Run("List", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "GET", "/repositories/", nil, http.StatusOK, api)
+		var result []RepositoryFilterResult
+		err := json.Unmarshal(body, &result)
+		assert.NoError(t, err)
+		assert.Equal(t, []RepositoryFilterResult{{
+			ID:        repositoryID,
+			AccountID: "account",
+			Name:      "dankmemes/ExampleRepository",
+		}}, result)
+	})
+	t.Run("MissingParameter", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", url, bytes.NewBuffer([]byte(`{}`)), http.StatusBadRequest, api)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", "/repositories/"+primitive.NewObjectID().Hex()+"/", bytes.NewBuffer([]byte(`{"is_in_filter_list": true}`)), http.StatusNotFound, api)
+	})
+	t.Run("Success", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", url, bytes.NewBuffer([]byte(`{"is_in_filter_list": true}`)), http.StatusOK, api)
+		repositories, err := database.GetRepositories(context.Background(), api.DB, userID, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(*repositories))
+		assert.True(t, (*repositories)[0].IsInFilterList)
+	})
+}
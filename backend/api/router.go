@@ -17,6 +17,10 @@ func GetRouter(handlers *API) *gin.Engine {
 	// Default 404 handler
 	router.NoRoute(Handle404)
 
+	// Health checks are registered ahead of the middleware so probes skip the fake lag and request logging
+	router.GET("/healthz", handlers.Healthz)
+	router.GET("/readyz", handlers.Readyz)
+
 	// Allow CORS for frontend API requests
 	router.Use(CORSMiddleware)
 
@@ -39,13 +43,24 @@ func GetRouter(handlers *API) *gin.Engine {
 
 	router.GET("/login/", handlers.Login)
 	router.GET("/login/callback/", handlers.LoginCallback)
+	router.GET("/login/providers/:provider_id/", handlers.LoginWithProvider)
+	router.GET("/login/providers/:provider_id/callback/", handlers.LoginWithProviderCallback)
+	router.POST("/login/email/", handlers.LoginEmail)
+	router.POST("/login/email/confirm/", handlers.LoginEmailConfirm)
 
 	router.POST("/waitlist/", handlers.WaitlistAdd)
 
 	router.POST("/tasks/create_external/slack/", handlers.SlackTaskCreate)
+	router.POST("/slack/commands/", handlers.SlackCommand)
 
 	router.POST("/linear/webhook/", handlers.LinearWebhook)
 
+	// calendar feeds are authenticated by the secret in the url so calendar apps can subscribe
+	router.GET("/feeds/:secret/calendar.ics", handlers.CalendarFeedICS)
+	router.GET("/booking/:secret/", handlers.AvailabilityShared)
+
+	router.GET("/v1/openapi.json", handlers.V1OpenAPISpec)
+
 	// Slack App (Workspace level) endpoint for oauth verification
 	// We need this as we don't actually use the token provided, but still need to access it to
 	// successfully install our app in a new Workspace
@@ -64,6 +79,10 @@ func GetRouter(handlers *API) *gin.Engine {
 	// only notes with is_shared=true can be shared
 	router.GET("/notes/detail/:note_id/", handlers.NoteDetails)
 	router.GET("/note/:note_id/", handlers.NotePreview)
+	router.GET("/p/:slug/", handlers.PublicNote)
+	router.GET("/avatars/:user_id/", handlers.AvatarGet)
+	// websocket requests can't set headers, so the editor also accepts a token query param
+	router.GET("/ws/notes/:note_id/", handlers.NoteCollaborate)
 
 	// Add middlewares
 	// Authorization middleware checks that the user is authorized to access the endpoint, and if not, returns a 401
@@ -74,14 +93,36 @@ func GetRouter(handlers *API) *gin.Engine {
 
 	router.GET("/linked_accounts/", handlers.LinkedAccountsList)
 	router.GET("/linked_accounts/supported_types/", handlers.SupportedAccountTypesList)
+	router.GET("/task_sources/", handlers.TaskSourcesList)
 	router.DELETE("/linked_accounts/:account_id/", handlers.DeleteLinkedAccount)
+	router.POST("/linked_accounts/:account_id/relink/", handlers.RelinkLinkedAccount)
+	router.POST("/link/azure_devops/token/", handlers.LinkAzureDevOpsToken)
+	router.POST("/link/apple/app_password/", handlers.LinkApple)
 
 	router.GET("/calendars/", handlers.CalendarsList)
+	router.GET("/contacts/", handlers.ContactsList)
+	router.POST("/calendars/upgrade_scopes/", handlers.CalendarScopeUpgrade)
+	router.POST("/google_tasks/upgrade_scopes/", handlers.GoogleTasksScopeUpgrade)
+	router.POST("/gmail/upgrade_scopes/", handlers.GmailScopeUpgrade)
+	router.GET("/gmail/labels/", handlers.GmailLabelsList)
+	router.PATCH("/gmail/task_label/", handlers.GmailTaskLabelModify)
+	router.GET("/calendar_feeds/", handlers.CalendarFeedsList)
+	router.POST("/calendar_feeds/", handlers.CalendarFeedCreate)
+	router.DELETE("/calendar_feeds/:feed_id/", handlers.CalendarFeedDelete)
 	router.GET("/events/", handlers.EventsList)
+	router.GET("/events/conflicts/", handlers.EventConflictsList)
+	router.GET("/events/search/", handlers.EventSearch)
 	router.POST("/events/create/:source_id/", handlers.EventCreate)
 	router.GET("/events/:event_id/", handlers.EventDetail)
 	router.DELETE("/events/delete/:event_id/", handlers.EventDelete)
 	router.PATCH("/events/modify/:event_id/", handlers.EventModify)
+	router.POST("/events/:event_id/note/", handlers.EventNoteCreate)
+	router.GET("/focus_time/preview/", handlers.FocusTimePreview)
+	router.POST("/focus_time/confirm/", handlers.FocusTimeConfirm)
+	router.GET("/availability/", handlers.AvailabilityGet)
+	router.GET("/availability/link/", handlers.AvailabilityLinkGet)
+	router.POST("/availability/link/", handlers.AvailabilityLinkCreate)
+	router.DELETE("/availability/link/", handlers.AvailabilityLinkDelete)
 
 	router.GET("/tasks/fetch/", handlers.TasksFetch)
 	router.GET("/tasks/v3/", handlers.TasksListV3)
@@ -90,6 +131,27 @@ func GetRouter(handlers *API) *gin.Engine {
 	router.PATCH("/tasks/modify/:task_id/", handlers.TaskModify)
 	router.GET("/tasks/detail/:task_id/", handlers.TaskDetail)
 	router.POST("/tasks/:task_id/comments/add/", handlers.TaskAddComment)
+	router.POST("/tasks/:task_id/comments/reactions/:comment_id/", handlers.TaskCommentReact)
+	router.PUT("/tasks/:task_id/comments/read/:comment_id/", handlers.TaskCommentRead)
+	router.PATCH("/tasks/:task_id/assign/", handlers.TaskAssign)
+	router.GET("/tasks/:task_id/activity/", handlers.TaskActivityList)
+	router.POST("/tasks/:task_id/shares/", handlers.TaskShareCreate)
+	router.GET("/tasks/:task_id/shares/", handlers.TaskSharesList)
+	router.DELETE("/tasks/:task_id/shares/:share_id/", handlers.TaskShareRevoke)
+	router.PATCH("/shareable_tasks/modify/:task_id/", handlers.ShareableTaskModify)
+	router.POST("/shareable_tasks/:task_id/comments/add/", handlers.ShareableTaskAddComment)
+	router.POST("/shareable_tasks/:task_id/comments/reactions/:comment_id/", handlers.ShareableTaskCommentReact)
+	router.PUT("/shareable_tasks/:task_id/comments/read/:comment_id/", handlers.ShareableTaskCommentRead)
+	router.GET("/shareable_tasks/:task_id/views/", handlers.ShareableTaskViewsList)
+	router.POST("/tasks/prioritize/", handlers.TaskPrioritize)
+	router.GET("/activity/", handlers.ActivityList)
+	router.GET("/security/audit_log/", handlers.AuditLogList)
+	router.GET("/sessions/", handlers.SessionsList)
+	router.DELETE("/sessions/", handlers.SessionsRevokeOthers)
+	router.DELETE("/sessions/:session_id/", handlers.SessionRevoke)
+	router.GET("/invite_codes/referral/", handlers.ReferralInviteCodeGet)
+	router.GET("/organization/sharing_policy/", handlers.OrganizationSharingPolicyGet)
+	router.PATCH("/organization/sharing_policy/", handlers.OrganizationSharingPolicyModify)
 
 	router.GET("/recurring_task_templates/", handlers.RecurringTaskTemplateList)
 	router.GET("/recurring_task_templates/v2/", handlers.RecurringTaskTemplateListV2)
@@ -100,43 +162,118 @@ func GetRouter(handlers *API) *gin.Engine {
 	router.GET("/notes/", handlers.NotesList)
 	router.PATCH("/notes/modify/:note_id/", handlers.NoteModify)
 	router.POST("/notes/create/", handlers.NoteCreate)
+	router.GET("/notes/trash/", handlers.NotesTrashList)
+	router.POST("/notes/restore/:note_id/", handlers.NoteRestore)
+	router.DELETE("/notes/delete/:note_id/", handlers.NoteDeletePermanently)
+	router.PUT("/notes/:note_id/slug/", handlers.NoteSlugClaim)
+	router.DELETE("/notes/:note_id/slug/", handlers.NoteSlugRevoke)
+	router.POST("/notes/:note_id/comments/add/", handlers.NoteAddComment)
+	router.POST("/notes/:note_id/extract_action_items/", handlers.NoteExtractActionItems)
+	router.GET("/notes/:note_id/action_items/", handlers.NoteActionItemsList)
+	router.GET("/notes/:note_id/views/", handlers.NoteViewsList)
+	router.GET("/notes/daily/:date/", handlers.DailyNoteGet)
+	router.PUT("/notes/daily/:date/", handlers.DailyNoteModify)
+	router.GET("/note_folders/", handlers.NoteFolderList)
+	router.POST("/note_folders/create/", handlers.NoteFolderAdd)
+	router.PATCH("/note_folders/modify/:folder_id/", handlers.NoteFolderModify)
+	router.DELETE("/note_folders/delete/:folder_id/", handlers.NoteFolderDelete)
+	router.POST("/action_items/:action_item_id/accept/", handlers.ActionItemAccept)
+	router.POST("/action_items/:action_item_id/dismiss/", handlers.ActionItemDismiss)
+
+	router.POST("/unfurl/", handlers.Unfurl)
 
 	router.GET("/ping_authed/", handlers.Ping)
 
 	router.GET("/settings/", handlers.SettingsList)
 	router.PATCH("/settings/", handlers.SettingsModify)
+	router.GET("/settings/default_sections/", handlers.DefaultSectionSettingsList)
+	router.PUT("/settings/default_sections/:source_id/", handlers.DefaultSectionSettingModify)
+	router.DELETE("/settings/default_sections/:source_id/", handlers.DefaultSectionSettingDelete)
+	router.PATCH("/settings/calendar_sync/", handlers.CalendarSyncModify)
 
 	router.POST("/log_events/", handlers.LogEventAdd)
 	router.POST("/feedback/", handlers.FeedbackAdd)
 
 	router.GET("/user_info/", handlers.UserInfoGet)
 	router.PATCH("/user_info/", handlers.UserInfoUpdate)
+	router.GET("/profile/", handlers.ProfileGet)
+	router.PATCH("/profile/", handlers.ProfileUpdate)
+	router.PUT("/profile/avatar/", handlers.ProfileAvatarUpload)
+	router.DELETE("/profile/avatar/", handlers.ProfileAvatarDelete)
+
+	router.DELETE("/account/", handlers.AccountDelete)
 
 	router.GET("/sections/", handlers.SectionList)
 	router.GET("/sections/v2/", handlers.SectionListV2)
 	router.POST("/sections/create/", handlers.SectionAdd)
 	router.PATCH("/sections/modify/:section_id/", handlers.SectionModify)
 	router.DELETE("/sections/delete/:section_id/", handlers.SectionDelete)
+	router.POST("/sections/archive/:section_id/", handlers.SectionArchive)
+	router.POST("/sections/unarchive/:section_id/", handlers.SectionUnarchive)
 
 	// Currently frontend is using endpoint with trailing slash, so we need to support both
 	router.GET("/overview/views", handlers.OverviewViewsList)
 	router.GET("/overview/views/", handlers.OverviewViewsList)
 
 	router.GET("/meeting_preparation_tasks/", handlers.MeetingPreparationTasksList)
+	router.GET("/meeting_preparation/rules/", handlers.MeetingPrepRulesGet)
+	router.PATCH("/meeting_preparation/rules/", handlers.MeetingPrepRulesModify)
 
 	router.POST("/overview/views/", handlers.OverviewViewAdd)
 	router.PATCH("/overview/views/bulk_modify/", handlers.OverviewViewBulkModify)
 	router.PATCH("/overview/views/:view_id/", handlers.OverviewViewModify)
+	router.PATCH("/overview/views/:view_id/linear/", handlers.OverviewLinearViewModify)
+	router.PATCH("/overview/views/:view_id/custom/", handlers.OverviewCustomViewModify)
+	router.POST("/overview/views/:view_id/refresh/", handlers.OverviewViewRefresh)
 	router.DELETE("/overview/views/:view_id/", handlers.OverviewViewDelete)
 	router.GET("/overview/supported_views/", handlers.OverviewSupportedViewsList)
 	router.GET("/overview/views/suggestion/", handlers.OverviewViewsSuggestion)
+	router.GET("/overview/views/suggestion/stream/", handlers.OverviewViewsSuggestionStream)
 	router.GET("/overview/views/suggestions_remaining/", handlers.OverviewViewsSuggestionsRemaining)
 
 	router.GET("/pull_requests/", handlers.PullRequestsList)
 	router.GET("/pull_requests/fetch/", handlers.PullRequestsFetch)
+	router.POST("/pull_requests/:pull_request_id/review/", handlers.PullRequestReview)
+	router.POST("/pull_requests/:pull_request_id/merge/", handlers.PullRequestMerge)
+	router.POST("/pull_requests/:pull_request_id/review_block/", handlers.PullRequestReviewBlock)
+	router.POST("/pull_requests/:pull_request_id/snooze/", handlers.PullRequestSnooze)
+	router.DELETE("/pull_requests/:pull_request_id/snooze/", handlers.PullRequestUnsnooze)
+	router.POST("/pull_requests/:pull_request_id/mute/", handlers.PullRequestMute)
+	router.DELETE("/pull_requests/:pull_request_id/mute/", handlers.PullRequestUnmute)
+
+	router.GET("/repositories/", handlers.RepositoriesList)
+	router.PATCH("/repositories/:repository_id/", handlers.RepositoryModify)
 
 	router.GET("/daily_task_completion/", handlers.DailyTaskCompletionList)
 
+	router.GET("/reports/weekly/", handlers.WeeklyReport)
+	router.GET("/analytics/meetings/", handlers.MeetingAnalytics)
+	router.GET("/analytics/tasks/", handlers.TaskAnalytics)
+
+	router.GET("/export/", handlers.Export)
+	router.GET("/stream/", handlers.Stream)
+	router.POST("/import/", handlers.Import)
+
+	// public API for third-party integrators, see v1.go
+	registerV1Routes(router, handlers)
+
+	// internal support tooling, only for users with an admin role
+	admin := router.Group("/admin", AdminMiddleware(handlers.DB))
+	admin.GET("/users/", handlers.AdminUserLookup)
+	admin.GET("/users/:user_id/", handlers.AdminUserGet)
+	admin.GET("/users/:user_id/linked_accounts/", handlers.AdminLinkedAccountsList)
+	admin.POST("/users/:user_id/resync/", handlers.AdminUserResync)
+	admin.POST("/users/:user_id/gpt_quota/reset/", handlers.AdminGPTQuotaReset)
+	admin.POST("/users/:user_id/impersonate/", handlers.AdminImpersonate)
+	admin.GET("/waitlist/", handlers.AdminWaitlistList)
+	admin.POST("/waitlist/approve/", handlers.AdminWaitlistApprove)
+	admin.GET("/invite_codes/", handlers.AdminInviteCodesList)
+	admin.POST("/invite_codes/create/", handlers.AdminInviteCodeCreate)
+
+	// invitees can join a team before business mode is enabled for them
+	router.GET("/dashboard/team/invites/", handlers.DashboardTeamInvitesList)
+	router.POST("/dashboard/team/invites/:team_member_id/accept/", handlers.DashboardTeamInviteAccept)
+
 	// Add business middleware. Endpoints below this require business mode to be enabled
 	router.Use(BusinessMiddleware(handlers.DB))
 	router.GET("/dashboard/data/", handlers.DashboardData)
@@ -144,6 +281,11 @@ func GetRouter(handlers *API) *gin.Engine {
 	router.POST("/dashboard/team_members/", handlers.DashboardTeamMemberCreate)
 	router.DELETE("/dashboard/team_members/:team_member_id/", handlers.DashboardTeamMemberDelete)
 	router.GET("/dashboard/data/fetch/", handlers.DashboardFetch)
+	router.GET("/dashboard/team/", handlers.DashboardTeamGet)
+	router.PATCH("/dashboard/team/", handlers.DashboardTeamModify)
+	router.POST("/dashboard/team/invites/", handlers.DashboardTeamInviteCreate)
+	router.PATCH("/dashboard/team/members/:team_member_id/", handlers.DashboardTeamMemberModify)
+	router.DELETE("/dashboard/team/members/:team_member_id/", handlers.DashboardTeamMemberRemove)
 	router.GET("/ping_business/", handlers.Ping)
 
 	return router
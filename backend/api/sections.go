@@ -2,10 +2,12 @@ package api
 
 import (
 	"context"
+	"io"
 	"sort"
 
 	"github.com/franchizzle/task-manager/backend/constants"
 	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/ordering"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -21,10 +23,16 @@ type SectionModifyParams struct {
 	Name       string `json:"name"`
 }
 
+type SectionArchiveParams struct {
+	// the section's open tasks are moved here, defaulting to the task inbox
+	MoveToSectionID *string `json:"move_to_section_id"`
+}
+
 type SectionResult struct {
 	ID         primitive.ObjectID `json:"id"`
 	IDOrdering int                `json:"id_ordering"`
 	Name       string             `json:"name"`
+	IsArchived bool               `json:"is_archived"`
 }
 
 func GetTaskIDs(tasks []*TaskResult) []string {
@@ -47,23 +55,24 @@ func (api *API) SectionListV2(c *gin.Context) {
 		return
 	}
 
-	activeTasks, err := database.GetActiveTasks(api.DB, userID)
+	activeTasks, err := database.GetActiveTasks(c.Request.Context(), api.DB, userID)
 	if err != nil {
 		Handle500(c)
 		return
 	}
-	completedTasks, err := database.GetCompletedTasks(api.DB, userID)
+	completedTasks, err := database.GetCompletedTasks(c.Request.Context(), api.DB, userID)
 	if err != nil {
 		Handle500(c)
 		return
 	}
-	deletedTasks, err := database.GetDeletedTasks(api.DB, userID)
+	deletedTasks, err := database.GetDeletedTasks(c.Request.Context(), api.DB, userID)
 	if err != nil {
 		Handle500(c)
 		return
 	}
 
 	allTasks, err := api.mergeTasksV3(
+		c.Request.Context(),
 		api.DB,
 		activeTasks,
 		completedTasks,
@@ -81,29 +90,37 @@ func (api *API) SectionListV2(c *gin.Context) {
 }
 
 func (api *API) SectionList(c *gin.Context) {
+	includeArchived, err := GetBooleanQueryParameter(c, constants.IncludeArchived)
+	if err != nil {
+		HandleBadRequest(c, err.Error())
+		return
+	}
 	userID, _ := c.Get("user")
 
-	sections, err := database.GetTaskSections(api.DB, userID.(primitive.ObjectID))
+	sections, err := database.GetTaskSections(c.Request.Context(), api.DB, userID.(primitive.ObjectID), includeArchived)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to fetch sections for user")
 		Handle500(c)
 		return
 	}
+	sort.SliceStable(*sections, func(i, j int) bool {
+		a := (*sections)[i]
+		b := (*sections)[j]
+		// preserve existing sort if no ordering set
+		if a.OrderingKey == "" && b.OrderingKey == "" && a.IDOrdering == 0 && b.IDOrdering == 0 {
+			return a.ID.Hex() < b.ID.Hex()
+		}
+		return ordering.Less(a.OrderingKey, a.IDOrdering, b.OrderingKey, b.IDOrdering)
+	})
 	sectionResults := []SectionResult{}
-	for _, section := range *sections {
+	for idx, section := range *sections {
 		sectionResults = append(sectionResults, SectionResult{
 			ID:         section.ID,
-			IDOrdering: section.IDOrdering,
+			IDOrdering: idx + 1,
 			Name:       section.Name,
+			IsArchived: section.IsArchived,
 		})
 	}
-	sort.SliceStable(sectionResults, func(i, j int) bool {
-		// preserve existing sort if no ordering ID set
-		if sectionResults[i].IDOrdering == 0 && sectionResults[j].IDOrdering == 0 {
-			return sectionResults[i].ID.Hex() < sectionResults[j].ID.Hex()
-		}
-		return sectionResults[i].IDOrdering < sectionResults[j].IDOrdering
-	})
 	c.JSON(200, sectionResults)
 }
 
@@ -112,7 +129,7 @@ func (api *API) SectionAdd(c *gin.Context) {
 	err := c.BindJSON(&params)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("error")
-		c.JSON(400, gin.H{"detail": "invalid or missing 'name' parameter"})
+		HandleBindError(c, &params, err, "invalid or missing 'name' parameter", "name")
 		return
 	}
 
@@ -149,7 +166,7 @@ func (api *API) SectionModify(c *gin.Context) {
 	err = c.BindJSON(&params)
 	if err != nil || (params.Name == "" && params.IDOrdering == 0) {
 		api.Logger.Error().Err(err).Msg("error")
-		c.JSON(400, gin.H{"detail": "invalid or missing task section modify parameter"})
+		HandleBindError(c, &params, err, "invalid or missing task section modify parameter")
 		return
 	}
 
@@ -182,7 +199,7 @@ func (api *API) SectionModify(c *gin.Context) {
 		return
 	}
 	if params.IDOrdering != 0 {
-		err = database.AdjustOrderingIDsForCollection(sectionCollection, userID, sectionID, params.IDOrdering)
+		err = database.MoveToPosition(c.Request.Context(), sectionCollection, userID, sectionID, nil, params.IDOrdering)
 		if err != nil {
 			Handle500(c)
 			return
@@ -238,5 +255,151 @@ func (api *API) SectionDelete(c *gin.Context) {
 		return
 	}
 
+	// new tasks from sources which landed in the section go back to the task inbox
+	_, err = database.GetDefaultSectionSettingsCollection(api.DB).DeleteMany(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"task_section_id": sectionID},
+			{"user_id": userID},
+		}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to delete default section settings")
+		Handle500(c)
+		return
+	}
+
+	c.JSON(200, gin.H{})
+}
+
+// SectionArchive hides the section, moving its open tasks to another section so that none are left behind
+func (api *API) SectionArchive(c *gin.Context) {
+	sectionID, err := primitive.ObjectIDFromHex(c.Param("section_id"))
+	if err != nil {
+		// This means the section ID is improperly formatted
+		Handle404(c)
+		return
+	}
+	var params SectionArchiveParams
+	err = c.ShouldBindJSON(&params)
+	if err != nil && err != io.EOF {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	sectionCollection := database.GetTaskSectionCollection(api.DB)
+	count, err := sectionCollection.CountDocuments(
+		c.Request.Context(),
+		bson.M{"$and": []bson.M{
+			{"_id": sectionID},
+			{"user_id": userID},
+		}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to find section")
+		Handle500(c)
+		return
+	}
+	if count != 1 {
+		Handle404(c)
+		return
+	}
+
+	moveToSectionID := constants.IDTaskSectionDefault
+	if params.MoveToSectionID != nil {
+		moveToSectionID, err = getValidTaskSection(*params.MoveToSectionID, userID, api.DB)
+		if err != nil || moveToSectionID == sectionID {
+			HandleBadRequest(c, "'move_to_section_id' is not a valid ID", "move_to_section_id")
+			return
+		}
+	}
+
+	movedTaskCount, err := database.MoveOpenTasksToSection(c.Request.Context(), api.DB, userID, sectionID, moveToSectionID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	_, err = database.GetRecurringTaskTemplateCollection(api.DB).UpdateMany(
+		c.Request.Context(),
+		bson.M{"$and": []bson.M{
+			{"id_task_section": sectionID},
+			{"user_id": userID},
+		}},
+		bson.M{"$set": bson.M{"id_task_section": moveToSectionID}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update recurring task templates")
+		Handle500(c)
+		return
+	}
+	_, err = database.GetDefaultSectionSettingsCollection(api.DB).UpdateMany(
+		c.Request.Context(),
+		bson.M{"$and": []bson.M{
+			{"task_section_id": sectionID},
+			{"user_id": userID},
+		}},
+		bson.M{"$set": bson.M{"task_section_id": moveToSectionID}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update default section settings")
+		Handle500(c)
+		return
+	}
+	_, err = database.GetViewCollection(api.DB).DeleteMany(
+		c.Request.Context(),
+		bson.M{"$and": []bson.M{
+			{"user_id": userID},
+			{"type": constants.ViewTaskSection},
+			{"task_section_id": sectionID},
+		}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to delete archived section views")
+		Handle500(c)
+		return
+	}
+
+	_, err = sectionCollection.UpdateOne(
+		c.Request.Context(),
+		bson.M{"$and": []bson.M{
+			{"_id": sectionID},
+			{"user_id": userID},
+		}},
+		bson.M{"$set": bson.M{"is_archived": true}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to archive section")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{"moved_task_count": movedTaskCount})
+}
+
+func (api *API) SectionUnarchive(c *gin.Context) {
+	sectionID, err := primitive.ObjectIDFromHex(c.Param("section_id"))
+	if err != nil {
+		// This means the section ID is improperly formatted
+		Handle404(c)
+		return
+	}
+	userID := getUserIDFromContext(c)
+	res, err := database.GetTaskSectionCollection(api.DB).UpdateOne(
+		c.Request.Context(),
+		bson.M{"$and": []bson.M{
+			{"_id": sectionID},
+			{"user_id": userID},
+		}},
+		bson.M{"$unset": bson.M{"is_archived": ""}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to unarchive section")
+		Handle500(c)
+		return
+	}
+	if res.MatchedCount != 1 {
+		Handle404(c)
+		return
+	}
 	c.JSON(200, gin.H{})
 }
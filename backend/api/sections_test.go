@@ -41,7 +41,7 @@ func TestSections(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"invalid or missing 'name' parameter\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"invalid or missing 'name' parameter\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"name\"}]}", string(body))
 	})
 	t.Run("BadPayloadCreate", func(t *testing.T) {
 		api, dbCleanup := GetAPIWithDBCleanup()
@@ -57,7 +57,7 @@ func TestSections(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"invalid or missing 'name' parameter\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"invalid or missing 'name' parameter\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"name\"}]}", string(body))
 	})
 	t.Run("CreateSuccess", func(t *testing.T) {
 		api, dbCleanup := GetAPIWithDBCleanup()
@@ -110,7 +110,7 @@ func TestSections(t *testing.T) {
 		assert.Equal(t, 2, len(sectionResult))
 		// should be in same order as created until ordering ID is set
 		assert.Equal(t, "important videos", sectionResult[0].Name)
-		assert.Equal(t, 0, sectionResult[0].IDOrdering)
+		assert.Equal(t, 1, sectionResult[0].IDOrdering)
 		assert.Equal(t, "important videos 2", sectionResult[1].Name)
 		assert.Equal(t, 2, sectionResult[1].IDOrdering)
 		createdTaskID = sectionResult[0].ID.Hex()
@@ -207,7 +207,7 @@ func TestSections(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"invalid or missing task section modify parameter\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"invalid or missing task section modify parameter\",\"code\":\"bad_request\"}", string(body))
 	})
 	t.Run("BadPayloadModify", func(t *testing.T) {
 		api, dbCleanup := GetAPIWithDBCleanup()
@@ -223,7 +223,7 @@ func TestSections(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"invalid or missing task section modify parameter\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"invalid or missing task section modify parameter\",\"code\":\"bad_request\"}", string(body))
 	})
 	t.Run("ModifyBadURL", func(t *testing.T) {
 		api, dbCleanup := GetAPIWithDBCleanup()
@@ -400,3 +400,76 @@ func TestSections(t *testing.T) {
 		checkTemplateSectionID(template4ID, section2ID)
 	})
 }
+
+func TestSectionArchive(t *testing.T) {
+	authToken := login("test_section_archive@resonant-kelpie-404a42.netlify.app", "")
+	otherToken := login("test_section_archive_other@resonant-kelpie-404a42.netlify.app", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+
+	createSection := func(t *testing.T, name string) primitive.ObjectID {
+		response := ServeRequest(t, authToken, http.MethodPost, "/sections/create/", bytes.NewBuffer([]byte(`{"name": "`+name+`"}`)), http.StatusCreated, api)
+		var result sectionCreateResponse
+		assert.NoError(t, json.Unmarshal(response, &result))
+		return result.ID
+	}
+	listSections := func(t *testing.T, query string) []SectionResult {
+		response := ServeRequest(t, authToken, http.MethodGet, "/sections/"+query, nil, http.StatusOK, api)
+		var sections []SectionResult
+		assert.NoError(t, json.Unmarshal(response, &sections))
+		return sections
+	}
+	archivedSectionID := createSection(t, "archived")
+	targetSectionID := createSection(t, "target")
+
+	notCompleted := false
+	completed := true
+	taskResult, err := database.GetTaskCollection(api.DB).InsertMany(context.Background(), []interface{}{
+		database.Task{UserID: userID, IDTaskSection: targetSectionID, IsCompleted: &notCompleted, IDOrdering: 1},
+		database.Task{UserID: userID, IDTaskSection: archivedSectionID, IsCompleted: &notCompleted, IDOrdering: 1},
+		database.Task{UserID: userID, IDTaskSection: archivedSectionID, IsCompleted: &notCompleted, IDOrdering: 2},
+		database.Task{UserID: userID, IDTaskSection: archivedSectionID, IsCompleted: &completed, IDOrdering: 3},
+	})
+	assert.NoError(t, err)
+
+	UnauthorizedTest(t, http.MethodPost, "/sections/archive/"+archivedSectionID.Hex()+"/", nil)
+	t.Run("OtherUsersSection", func(t *testing.T) {
+		ServeRequest(t, otherToken, http.MethodPost, "/sections/archive/"+archivedSectionID.Hex()+"/", nil, http.StatusNotFound, api)
+	})
+	t.Run("MoveToSameSection", func(t *testing.T) {
+		response := ServeRequest(t, authToken, http.MethodPost, "/sections/archive/"+archivedSectionID.Hex()+"/", bytes.NewBuffer([]byte(`{"move_to_section_id": "`+archivedSectionID.Hex()+`"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"'move_to_section_id' is not a valid ID","code":"invalid_parameter","field_errors":[{"field":"move_to_section_id"}]}`, string(response))
+	})
+	t.Run("Success", func(t *testing.T) {
+		response := ServeRequest(t, authToken, http.MethodPost, "/sections/archive/"+archivedSectionID.Hex()+"/", bytes.NewBuffer([]byte(`{"move_to_section_id": "`+targetSectionID.Hex()+`"}`)), http.StatusOK, api)
+		assert.Equal(t, `{"moved_task_count":2}`, string(response))
+
+		expectedSections := []primitive.ObjectID{targetSectionID, targetSectionID, targetSectionID, archivedSectionID}
+		expectedOrderings := []int{1, 2, 3, 3}
+		for idx, insertedID := range taskResult.InsertedIDs {
+			task, err := database.GetTask(context.Background(), api.DB, insertedID.(primitive.ObjectID), userID)
+			assert.NoError(t, err)
+			assert.Equal(t, expectedSections[idx], task.IDTaskSection)
+			assert.Equal(t, expectedOrderings[idx], task.IDOrdering)
+		}
+
+		sections := listSections(t, "")
+		assert.Equal(t, 1, len(sections))
+		assert.Equal(t, targetSectionID, sections[0].ID)
+		sections = listSections(t, "?include_archived=true")
+		assert.Equal(t, 2, len(sections))
+	})
+	t.Run("MoveToArchivedSection", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPost, "/sections/archive/"+targetSectionID.Hex()+"/", bytes.NewBuffer([]byte(`{"move_to_section_id": "`+archivedSectionID.Hex()+`"}`)), http.StatusBadRequest, api)
+	})
+	t.Run("Unarchive", func(t *testing.T) {
+		ServeRequest(t, otherToken, http.MethodPost, "/sections/unarchive/"+archivedSectionID.Hex()+"/", nil, http.StatusNotFound, api)
+		ServeRequest(t, authToken, http.MethodPost, "/sections/unarchive/"+archivedSectionID.Hex()+"/", nil, http.StatusOK, api)
+		sections := listSections(t, "")
+		assert.Equal(t, 2, len(sections))
+		for _, section := range sections {
+			assert.False(t, section.IsArchived)
+		}
+	})
+}
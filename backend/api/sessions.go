@@ -0,0 +1,125 @@
+package api
+
+import (
+	"strings"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type SessionResult struct {
+	ID         primitive.ObjectID `json:"id"`
+	Device     string             `json:"device"`
+	UserAgent  string             `json:"user_agent"`
+	IPAddress  string             `json:"ip_address"`
+	CreatedAt  string             `json:"created_at,omitempty"`
+	LastUsedAt string             `json:"last_used_at,omitempty"`
+	ExpiresAt  string             `json:"expires_at,omitempty"`
+	IsCurrent  bool               `json:"is_current"`
+}
+
+// checked in order, since e.g. Android user agents also mention Linux
+var sessionDevices = []struct {
+	UserAgentSubstring string
+	Device             string
+}{
+	{"Electron", "Desktop app"},
+	{"iPhone", "iPhone"},
+	{"iPad", "iPad"},
+	{"Android", "Android"},
+	{"Macintosh", "Mac"},
+	{"Windows", "Windows"},
+	{"Linux", "Linux"},
+}
+
+func (api *API) SessionsList(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	currentToken, _ := getToken(c)
+	sessions, err := database.GetInternalTokens(c.Request.Context(), api.DB, userID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	results := []SessionResult{}
+	for _, session := range *sessions {
+		results = append(results, SessionResult{
+			ID:         session.ID,
+			Device:     getSessionDevice(session.UserAgent),
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			CreatedAt:  formatSessionTime(session.CreatedAt),
+			LastUsedAt: formatSessionTime(session.LastUsedAt),
+			ExpiresAt:  formatSessionTime(session.ExpiresAt),
+			IsCurrent:  session.Token == currentToken,
+		})
+	}
+	c.JSON(200, results)
+}
+
+func (api *API) SessionRevoke(c *gin.Context) {
+	sessionID, err := primitive.ObjectIDFromHex(c.Param("session_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	userID := getUserIDFromContext(c)
+	result, err := database.GetInternalTokenCollection(api.DB).DeleteOne(
+		c.Request.Context(),
+		bson.M{"$and": []bson.M{
+			{"_id": sessionID},
+			{"user_id": userID},
+		}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to revoke session")
+		Handle500(c)
+		return
+	}
+	if result.DeletedCount == 0 {
+		Handle404(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+// SessionsRevokeOthers signs the user out everywhere except the session making the request
+func (api *API) SessionsRevokeOthers(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	currentToken, err := getToken(c)
+	if err != nil {
+		HandleBadRequest(c, "current session must use an auth token header")
+		return
+	}
+	result, err := database.GetInternalTokenCollection(api.DB).DeleteMany(
+		c.Request.Context(),
+		bson.M{"$and": []bson.M{
+			{"user_id": userID},
+			{"token": bson.M{"$ne": currentToken}},
+		}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to revoke other sessions")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{"revoked_count": result.DeletedCount})
+}
+
+func getSessionDevice(userAgent string) string {
+	for _, sessionDevice := range sessionDevices {
+		if strings.Contains(userAgent, sessionDevice.UserAgentSubstring) {
+			return sessionDevice.Device
+		}
+	}
+	return "Unknown device"
+}
+
+func formatSessionTime(dateTime primitive.DateTime) string {
+	if dateTime == 0 {
+		return ""
+	}
+	return dateTime.Time().UTC().Format(time.RFC3339)
+}
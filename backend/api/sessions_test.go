@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSessions(t *testing.T) {
+	authToken := login("test_sessions@resonant-kelpie-404a42.netlify.app", "")
+	secondAuthToken := login("test_sessions@resonant-kelpie-404a42.netlify.app", "")
+	thirdAuthToken := login("test_sessions@resonant-kelpie-404a42.netlify.app", "")
+	otherAuthToken := login("test_sessions_other@resonant-kelpie-404a42.netlify.app", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	secondSession, err := database.GetInternalToken(context.Background(), api.DB, secondAuthToken)
+	assert.NoError(t, err)
+
+	UnauthorizedTest(t, "GET", "/sessions/", nil)
+	t.Run("List", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "GET", "/sessions/", nil, http.StatusOK, api)
+		var result []SessionResult
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, 3, len(result))
+		currentCount := 0
+		for _, session := range result {
+			if session.IsCurrent {
+				currentCount++
+			}
+			assert.NotEmpty(t, session.ExpiresAt)
+		}
+		assert.Equal(t, 1, currentCount)
+	})
+	t.Run("RevokeOtherUsersSession", func(t *testing.T) {
+		ServeRequest(t, otherAuthToken, "DELETE", "/sessions/"+secondSession.ID.Hex()+"/", nil, http.StatusNotFound, api)
+	})
+	t.Run("Revoke", func(t *testing.T) {
+		ServeRequest(t, authToken, "DELETE", "/sessions/"+secondSession.ID.Hex()+"/", nil, http.StatusOK, api)
+		ServeRequest(t, secondAuthToken, "GET", "/sessions/", nil, http.StatusUnauthorized, api)
+		ServeRequest(t, authToken, "DELETE", "/sessions/"+primitive.NewObjectID().Hex()+"/", nil, http.StatusNotFound, api)
+	})
+	t.Run("RevokeOthers", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "DELETE", "/sessions/", nil, http.StatusOK, api)
+		assert.Equal(t, `{"revoked_count":1}`, string(body))
+		ServeRequest(t, thirdAuthToken, "GET", "/sessions/", nil, http.StatusUnauthorized, api)
+		ServeRequest(t, authToken, "GET", "/sessions/", nil, http.StatusOK, api)
+		ServeRequest(t, otherAuthToken, "GET", "/sessions/", nil, http.StatusOK, api)
+	})
+	t.Run("Expired", func(t *testing.T) {
+		expiredAuthToken := login("test_sessions_expired@resonant-kelpie-404a42.netlify.app", "")
+		_, err := database.GetInternalTokenCollection(api.DB).UpdateOne(
+			context.Background(),
+			bson.M{"token": expiredAuthToken},
+			bson.M{"$set": bson.M{"expires_at": primitive.NewDateTimeFromTime(time.Now().Add(-time.Minute))}},
+		)
+		assert.NoError(t, err)
+		ServeRequest(t, expiredAuthToken, "GET", "/sessions/", nil, http.StatusUnauthorized, api)
+	})
+	t.Run("SlidingRenewal", func(t *testing.T) {
+		staleLastUsed := primitive.NewDateTimeFromTime(time.Now().Add(-2 * time.Hour))
+		_, err := database.GetInternalTokenCollection(api.DB).UpdateOne(
+			context.Background(),
+			bson.M{"token": authToken},
+			bson.M{"$set": bson.M{"last_used_at": staleLastUsed}},
+		)
+		assert.NoError(t, err)
+		ServeRequest(t, authToken, "GET", "/sessions/", nil, http.StatusOK, api)
+		session, err := database.GetInternalToken(context.Background(), api.DB, authToken)
+		assert.NoError(t, err)
+		assert.Greater(t, session.LastUsedAt, staleLastUsed)
+		assert.True(t, session.ExpiresAt.Time().After(time.Now().Add(29*24*time.Hour)))
+	})
+}
+
+func TestGetSessionDevice(t *testing.T) {
+	assert.Equal(t, "Mac", getSessionDevice("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36"))
+	assert.Equal(t, "Android", getSessionDevice("Mozilla/5.0 (Linux; Android 13; Pixel 7) AppleWebKit/537.36"))
+	assert.Equal(t, "Desktop app", getSessionDevice("Mozilla/5.0 (Macintosh) GeneralTask/1.0 Electron/22.0.0"))
+	assert.Equal(t, "Unknown device", getSessionDevice(""))
+}
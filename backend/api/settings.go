@@ -32,14 +32,14 @@ func (api *API) SettingsModify(c *gin.Context) {
 	var settingsMap map[string]string
 	err := c.BindJSON(&settingsMap)
 	if err != nil {
-		c.JSON(400, gin.H{"detail": "parameters missing or malformatted."})
+		HandleBindError(c, &settingsMap, err, "parameters missing or malformatted.")
 		return
 	}
 	userID := getUserIDFromContext(c)
 	for key, value := range settingsMap {
 		err = settings.UpdateUserSetting(api.DB, userID, key, value)
 		if err != nil {
-			c.JSON(400, gin.H{"detail": fmt.Sprintf("failed to update settings: %v", err)})
+			HandleBadRequest(c, fmt.Sprintf("failed to update settings: %v", err))
 			return
 		}
 	}
@@ -93,7 +93,7 @@ func TestSettingsModify(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"parameters missing or malformatted.\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"parameters missing or malformatted.\",\"code\":\"bad_request\"}", string(body))
 	})
 	t.Run("InvalidPayload", func(t *testing.T) {
 		authToken := login("approved@resonant-kelpie-404a42.netlify.app", "")
@@ -111,7 +111,7 @@ func TestSettingsModify(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"parameters missing or malformatted.\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"parameters missing or malformatted.\",\"code\":\"bad_request\"}", string(body))
 	})
 	t.Run("BadKey", func(t *testing.T) {
 		authToken := login("approved@resonant-kelpie-404a42.netlify.app", "")
@@ -129,7 +129,7 @@ func TestSettingsModify(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"failed to update settings: invalid setting: dogecoin\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"failed to update settings: invalid setting: dogecoin\",\"code\":\"bad_request\"}", string(body))
 	})
 	t.Run("BadValue", func(t *testing.T) {
 		authToken := login("approved@resonant-kelpie-404a42.netlify.app", "")
@@ -147,7 +147,7 @@ func TestSettingsModify(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"failed to update settings: invalid value: tothemoon\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"failed to update settings: invalid value: tothemoon\",\"code\":\"bad_request\"}", string(body))
 	})
 	t.Run("Success", func(t *testing.T) {
 		authToken := login("approved@resonant-kelpie-404a42.netlify.app", "")
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ShareViewer struct {
+	ID    primitive.ObjectID `json:"id"`
+	Name  string             `json:"name"`
+	Email string             `json:"email"`
+}
+
+type ShareViewResult struct {
+	ID primitive.ObjectID `json:"id"`
+	// nil for viewers who weren't logged in
+	Viewer   *ShareViewer `json:"viewer"`
+	ViewedAt string       `json:"viewed_at"`
+}
+
+// recordShareView logs the logged in user, if any, opening a shared task or note. Owners opening
+// their own links aren't recorded
+func (api *API) recordShareView(c *gin.Context, shareView database.ShareView) {
+	if userIDRaw, exists := c.Get("user"); exists {
+		shareView.ViewerUserID = userIDRaw.(primitive.ObjectID)
+		if shareView.ViewerUserID == shareView.UserID {
+			return
+		}
+	}
+	err := database.InsertShareView(c.Request.Context(), api.DB, shareView)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to record share view")
+	}
+}
+
+func (api *API) ShareableTaskViewsList(c *gin.Context) {
+	taskID, err := primitive.ObjectIDFromHex(c.Param("task_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	userID := getUserIDFromContext(c)
+	_, err = database.GetTask(c.Request.Context(), api.DB, taskID, userID)
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	api.shareViewsPage(c, userID, bson.M{"task_id": taskID})
+}
+
+func (api *API) NoteViewsList(c *gin.Context) {
+	noteID, err := primitive.ObjectIDFromHex(c.Param("note_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	userID := getUserIDFromContext(c)
+	_, err = database.GetNote(c.Request.Context(), api.DB, noteID, userID)
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	api.shareViewsPage(c, userID, bson.M{"note_id": noteID})
+}
+
+// shareViewsPage responds with the newest views first, paginated like task activity
+func (api *API) shareViewsPage(c *gin.Context, userID primitive.ObjectID, filter bson.M) {
+	pagination, err := getPagination(c)
+	if err != nil {
+		HandleBadRequest(c, err.Error())
+		return
+	}
+	if pagination == nil {
+		pagination = &database.Pagination{}
+	}
+	views, nextCursor, err := database.FindPageWithCollection[database.ShareView](
+		c.Request.Context(),
+		database.GetShareViewCollection(api.DB),
+		userID,
+		&[]bson.M{filter},
+		*pagination,
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch share views")
+		Handle500(c)
+		return
+	}
+
+	viewers := map[primitive.ObjectID]*ShareViewer{}
+	results := []ShareViewResult{}
+	for _, view := range views {
+		result := ShareViewResult{
+			ID:       view.ID,
+			ViewedAt: view.ViewedAt.Time().UTC().Format(time.RFC3339),
+		}
+		if view.ViewerUserID != primitive.NilObjectID {
+			viewer, exists := viewers[view.ViewerUserID]
+			if !exists {
+				// deleted viewers show up as anonymous
+				user, err := database.GetUser(c.Request.Context(), api.DB, view.ViewerUserID)
+				if err == nil {
+					viewer = &ShareViewer{ID: user.ID, Name: user.Name, Email: user.Email}
+				}
+				viewers[view.ViewerUserID] = viewer
+			}
+			result.Viewer = viewer
+		}
+		results = append(results, result)
+	}
+	c.JSON(200, PaginatedResult[ShareViewResult]{
+		Results:    results,
+		NextCursor: nextCursor,
+	})
+}
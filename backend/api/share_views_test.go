@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestShareViews(t *testing.T) {
+	authToken := login("test_share_views@resonant-kelpie-404a42.netlify.app", "")
+	viewerToken := login("test_share_views_viewer@resonant-kelpie-404a42.netlify.app", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+
+	sharedAccess := database.SharedAccessPublic
+	sharedUntil := primitive.NewDateTimeFromTime(time.Now().Add(time.Hour))
+	taskResult, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), &database.Task{
+		UserID:       userID,
+		SharedUntil:  sharedUntil,
+		SharedAccess: &sharedAccess,
+	})
+	assert.NoError(t, err)
+	taskID := taskResult.InsertedID.(primitive.ObjectID).Hex()
+	noteResult, err := database.GetNoteCollection(api.DB).InsertOne(context.Background(), &database.Note{
+		UserID:       userID,
+		SharedUntil:  sharedUntil,
+		SharedAccess: &sharedAccess,
+	})
+	assert.NoError(t, err)
+	noteID := noteResult.InsertedID.(primitive.ObjectID).Hex()
+
+	serveAnonymousRequest := func(url string) {
+		request, _ := http.NewRequest(http.MethodGet, url, nil)
+		recorder := httptest.NewRecorder()
+		GetRouter(api).ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	}
+	getViews := func(t *testing.T, url string) []ShareViewResult {
+		body := ServeRequest(t, authToken, http.MethodGet, url, nil, http.StatusOK, api)
+		var result PaginatedResult[ShareViewResult]
+		assert.NoError(t, json.Unmarshal(body, &result))
+		return result.Results
+	}
+
+	UnauthorizedTest(t, http.MethodGet, "/shareable_tasks/"+taskID+"/views/", nil)
+	t.Run("NoViews", func(t *testing.T) {
+		assert.Equal(t, 0, len(getViews(t, "/shareable_tasks/"+taskID+"/views/")))
+	})
+	t.Run("TaskViews", func(t *testing.T) {
+		serveAnonymousRequest("/shareable_tasks/detail/" + taskID + "/")
+		ServeRequest(t, viewerToken, http.MethodGet, "/shareable_tasks/detail/"+taskID+"/", nil, http.StatusOK, api)
+		// the owner opening their own link isn't a view
+		ServeRequest(t, authToken, http.MethodGet, "/shareable_tasks/detail/"+taskID+"/", nil, http.StatusOK, api)
+
+		views := getViews(t, "/shareable_tasks/"+taskID+"/views/")
+		assert.Equal(t, 2, len(views))
+		// newest first
+		assert.Equal(t, "test_share_views_viewer@resonant-kelpie-404a42.netlify.app", views[0].Viewer.Email)
+		assert.Nil(t, views[1].Viewer)
+	})
+	t.Run("NoteViews", func(t *testing.T) {
+		serveAnonymousRequest("/notes/detail/" + noteID + "/")
+
+		views := getViews(t, "/notes/"+noteID+"/views/")
+		assert.Equal(t, 1, len(views))
+		assert.Nil(t, views[0].Viewer)
+	})
+	t.Run("NotOwner", func(t *testing.T) {
+		ServeRequest(t, viewerToken, http.MethodGet, "/shareable_tasks/"+taskID+"/views/", nil, http.StatusNotFound, api)
+		ServeRequest(t, viewerToken, http.MethodGet, "/notes/"+noteID+"/views/", nil, http.StatusNotFound, api)
+	})
+	t.Run("InvalidID", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodGet, "/shareable_tasks/123/views/", nil, http.StatusNotFound, api)
+	})
+}
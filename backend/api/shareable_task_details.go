@@ -4,46 +4,31 @@ import (
 	"fmt"
 
 	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/templating"
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type ShareableTaskDetailsResponse struct {
 	Task     *TaskResultV4   `json:"task"`
 	Subtasks []*TaskResultV4 `json:"subtasks"`
 	Domain   string          `json:"domain"`
+	// what the user opening the link can do: view, comment or edit
+	Permission string              `json:"permission"`
+	Owner      CollaboratorProfile `json:"owner"`
+	// the owner and comment authors, keyed by user ID, which is the external_id of their comments
+	Collaborators map[string]CollaboratorProfile `json:"collaborators"`
 }
 
+// ShareableTaskDetails is reachable without logging in. The task_id param is either a share token
+// or, for tasks shared before share tokens existed, the task's ID
 func (api *API) ShareableTaskDetails(c *gin.Context) {
-	taskIDHex := c.Param("task_id")
-	taskID, err := primitive.ObjectIDFromHex(taskIDHex)
-	if err != nil {
-		// This means the task ID is improperly formatted
-		Handle404(c)
-		return
-	}
-
-	/* We can't use getUserIDFromContext here because the "user" context field is potentially empty.
-	 * This is the case when an unauthenticated user hits this endpoint.
-	 */
-	var userID *primitive.ObjectID
-	if userIDRaw, exists := c.Get("user"); exists {
-		userIDValue := userIDRaw.(primitive.ObjectID)
-		userID = &userIDValue
-	}
-
-	task, err := database.GetSharedTask(api.DB, taskID, userID)
-	if err != nil {
-		Handle404(c)
-		return
-	}
+	task, permission := api.getSharedTaskFromParam(c, c.Param("task_id"))
 	if task == nil {
-		Handle404(c)
 		return
 	}
 
 	// Get subtasks for the shared task
-	subtasks, err := database.GetSubtasksFromTask(api.DB, task)
+	subtasks, err := database.GetSubtasksFromTask(c.Request.Context(), api.DB, task)
 	if err != nil {
 		Handle404(c)
 		return
@@ -51,11 +36,17 @@ func (api *API) ShareableTaskDetails(c *gin.Context) {
 	subtaskResults := []*TaskResultV4{}
 	for _, subtask := range *subtasks {
 		subtaskResult := api.taskToTaskResultV4(&subtask)
+		subtaskResult.BodyHTML, err = templating.RenderMarkdown(subtaskResult.Body)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to render subtask body")
+			Handle500(c)
+			return
+		}
 		subtaskResults = append(subtaskResults, subtaskResult)
 	}
 
 	// Get the domain of the task owner
-	taskOwner, err := database.GetUser(api.DB, task.UserID)
+	taskOwner, err := database.GetUser(c.Request.Context(), api.DB, task.UserID)
 	if err != nil {
 		Handle404(c)
 		return
@@ -67,10 +58,25 @@ func (api *API) ShareableTaskDetails(c *gin.Context) {
 	}
 
 	taskResult := api.taskToTaskResultV4(task)
+	taskResult.BodyHTML, err = templating.RenderMarkdown(taskResult.Body)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to render task body")
+		Handle500(c)
+		return
+	}
+	collaborators, err := api.getCollaboratorProfiles(c.Request.Context(), task.UserID, task.Comments)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	api.recordShareView(c, database.ShareView{UserID: task.UserID, TaskID: task.ID})
 	result := ShareableTaskDetailsResponse{
-		Task:     taskResult,
-		Domain:   fmt.Sprintf(`@%s`, taskOwnerDomain),
-		Subtasks: subtaskResults,
+		Task:          taskResult,
+		Domain:        fmt.Sprintf(`@%s`, taskOwnerDomain),
+		Subtasks:      subtaskResults,
+		Permission:    getSharedPermissionString(permission),
+		Owner:         getCollaboratorProfile(taskOwner),
+		Collaborators: collaborators,
 	}
 	c.JSON(200, result)
 }
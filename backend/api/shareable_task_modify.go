@@ -0,0 +1,156 @@
+package api
+
+import (
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// the fields users with edit permission can change on a task shared with them
+type ShareableTaskModifyParams struct {
+	Title          *string `json:"title" binding:"omitempty,min=1"`
+	Body           *string `json:"body"`
+	DueDate        *string `json:"due_date"`
+	TimeAllocation *int64  `json:"time_duration" binding:"omitempty,min=0"`
+	IsCompleted    *bool   `json:"is_completed"`
+}
+
+type ShareableTaskCommentParams struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// ShareableTaskModify lets logged in users from the owner's domain edit a task shared with edit
+// permission. The task_id param is a share token or task ID, as in ShareableTaskDetails
+func (api *API) ShareableTaskModify(c *gin.Context) {
+	var modifyParams ShareableTaskModifyParams
+	err := c.BindJSON(&modifyParams)
+	if err != nil {
+		HandleBindError(c, &modifyParams, err, "parameter missing or malformatted")
+		return
+	}
+	if modifyParams == (ShareableTaskModifyParams{}) {
+		HandleBadRequest(c, "task changes missing")
+		return
+	}
+
+	task, permission := api.getSharedTaskFromParam(c, c.Param("task_id"))
+	if task == nil {
+		return
+	}
+	if permission != database.SharedPermissionEdit {
+		HandleError(c, ErrorCodeForbidden, "not allowed to edit this task")
+		return
+	}
+
+	taskSourceResult, err := api.ExternalConfig.GetSourceResult(task.SourceID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to load external task source")
+		Handle500(c)
+		return
+	}
+	changeableFields := TaskItemChangeableFields{
+		Title:          modifyParams.Title,
+		Body:           modifyParams.Body,
+		DueDate:        modifyParams.DueDate,
+		TimeAllocation: modifyParams.TimeAllocation,
+		IsCompleted:    modifyParams.IsCompleted,
+	}
+	if !ValidateFields(c, &changeableFields, taskSourceResult, task) {
+		return
+	}
+	updateTask := database.Task{
+		Title:          changeableFields.Title,
+		Body:           changeableFields.Body,
+		TimeAllocation: changeableFields.TimeAllocation,
+		IsCompleted:    changeableFields.IsCompleted,
+		CompletedAt:    changeableFields.CompletedAt,
+		UpdatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+	}
+	if changeableFields.DueDate != nil {
+		updateTask.DueDate, err = parseTaskDueDate(*changeableFields.DueDate)
+		if err != nil {
+			HandleBadRequest(c, "due_date is not a valid date", "due_date")
+			return
+		}
+	}
+
+	// changes are made on behalf of the task's owner
+	err = taskSourceResult.Source.ModifyTask(c.Request.Context(), api.DB, task.UserID, task.SourceAccountID, task.IDExternal, &updateTask, task)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update external task source")
+		Handle500(c)
+		return
+	}
+	err = api.UpdateTaskInDBWithError(task, task.UserID, &updateTask)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+// ShareableTaskAddComment lets logged in users from the owner's domain comment on a task shared
+// with comment or edit permission
+func (api *API) ShareableTaskAddComment(c *gin.Context) {
+	var commentParams ShareableTaskCommentParams
+	err := c.BindJSON(&commentParams)
+	if err != nil {
+		HandleBindError(c, &commentParams, err, "parameter missing or malformatted")
+		return
+	}
+
+	task, permission := api.getSharedTaskFromParam(c, c.Param("task_id"))
+	if task == nil {
+		return
+	}
+	if permission != database.SharedPermissionComment && permission != database.SharedPermissionEdit {
+		HandleError(c, ErrorCodeForbidden, "not allowed to comment on this task")
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	user, err := database.GetUser(c.Request.Context(), api.DB, userID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	comment := database.Comment{
+		ExternalID: uuid.New().String(),
+		Body:       commentParams.Body,
+		User: database.ExternalUser{
+			ExternalID:  user.ID.Hex(),
+			Name:        user.Name,
+			DisplayName: user.Name,
+			Email:       user.Email,
+		},
+		CreatedAt: primitive.NewDateTimeFromTime(api.GetCurrentTime()),
+	}
+
+	taskSourceResult, err := api.ExternalConfig.GetSourceResult(task.SourceID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to load external task source")
+		Handle500(c)
+		return
+	}
+	err = taskSourceResult.Source.AddComment(c.Request.Context(), api.DB, task.UserID, task.SourceAccountID, comment, task)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update external task source")
+		Handle500(c)
+		return
+	}
+
+	comments := []database.Comment{}
+	if task.Comments != nil {
+		comments = *task.Comments
+	}
+	comments = append(comments, comment)
+	err = api.UpdateTaskInDBWithError(task, task.UserID, &database.Task{Comments: &comments})
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
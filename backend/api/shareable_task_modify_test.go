@@ -0,0 +1,93 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestShareableTaskModify(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+
+	authToken := login("test_shareable_task_modify@resonant-kelpie-404a42.netlify.app", "")
+	ownerID := getUserIDFromAuthToken(t, api.DB, authToken)
+	teammateAuthToken := login("test_shareable_task_modify_teammate@resonant-kelpie-404a42.netlify.app", "")
+	outsiderAuthToken := login("test_shareable_task_modify@outsider.com", "")
+
+	createSharedTask := func(permission *database.SharedPermission) string {
+		title := "shared task"
+		sharedAccess := database.SharedAccessPublic
+		mongoResult, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), &database.Task{
+			UserID:           ownerID,
+			Title:            &title,
+			SourceID:         external.TASK_SOURCE_ID_GT_TASK,
+			SharedUntil:      primitive.NewDateTimeFromTime(time.Now().Add(time.Hour)),
+			SharedAccess:     &sharedAccess,
+			SharedPermission: permission,
+		})
+		assert.NoError(t, err)
+		return mongoResult.InsertedID.(primitive.ObjectID).Hex()
+	}
+	commentPermission := database.SharedPermissionComment
+	editPermission := database.SharedPermissionEdit
+	viewTaskID := createSharedTask(nil)
+	commentTaskID := createSharedTask(&commentPermission)
+	editTaskID := createSharedTask(&editPermission)
+	modifyBody := `{"title": "new title"}`
+	commentBody := `{"body": "looks good"}`
+
+	UnauthorizedTest(t, http.MethodPatch, "/shareable_tasks/modify/"+editTaskID+"/", nil)
+	t.Run("ModifyNotShared", func(t *testing.T) {
+		ServeRequest(t, teammateAuthToken, http.MethodPatch, fmt.Sprintf("/shareable_tasks/modify/%s/", primitive.NewObjectID().Hex()), bytes.NewBuffer([]byte(modifyBody)), http.StatusNotFound, api)
+	})
+	t.Run("ModifyViewOnly", func(t *testing.T) {
+		ServeRequest(t, teammateAuthToken, http.MethodPatch, "/shareable_tasks/modify/"+viewTaskID+"/", bytes.NewBuffer([]byte(modifyBody)), http.StatusForbidden, api)
+	})
+	t.Run("ModifyCommentOnly", func(t *testing.T) {
+		ServeRequest(t, teammateAuthToken, http.MethodPatch, "/shareable_tasks/modify/"+commentTaskID+"/", bytes.NewBuffer([]byte(modifyBody)), http.StatusForbidden, api)
+	})
+	t.Run("ModifyOutsideDomain", func(t *testing.T) {
+		ServeRequest(t, outsiderAuthToken, http.MethodPatch, "/shareable_tasks/modify/"+editTaskID+"/", bytes.NewBuffer([]byte(modifyBody)), http.StatusForbidden, api)
+	})
+	t.Run("ModifyEmptyTitle", func(t *testing.T) {
+		response := ServeRequest(t, teammateAuthToken, http.MethodPatch, "/shareable_tasks/modify/"+editTaskID+"/", bytes.NewBuffer([]byte(`{"title": ""}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"'title' cannot be empty","code":"invalid_parameter","field_errors":[{"field":"title","message":"'title' cannot be empty"}]}`, string(response))
+	})
+	t.Run("ModifySuccess", func(t *testing.T) {
+		ServeRequest(t, teammateAuthToken, http.MethodPatch, "/shareable_tasks/modify/"+editTaskID+"/", bytes.NewBuffer([]byte(modifyBody)), http.StatusOK, api)
+		taskID, _ := primitive.ObjectIDFromHex(editTaskID)
+		task, err := database.GetTask(context.Background(), api.DB, taskID, ownerID)
+		assert.NoError(t, err)
+		assert.Equal(t, "new title", *task.Title)
+	})
+
+	UnauthorizedTest(t, http.MethodPost, "/shareable_tasks/"+commentTaskID+"/comments/add/", nil)
+	t.Run("CommentViewOnly", func(t *testing.T) {
+		ServeRequest(t, teammateAuthToken, http.MethodPost, "/shareable_tasks/"+viewTaskID+"/comments/add/", bytes.NewBuffer([]byte(commentBody)), http.StatusForbidden, api)
+	})
+	t.Run("CommentOutsideDomain", func(t *testing.T) {
+		ServeRequest(t, outsiderAuthToken, http.MethodPost, "/shareable_tasks/"+commentTaskID+"/comments/add/", bytes.NewBuffer([]byte(commentBody)), http.StatusForbidden, api)
+	})
+	t.Run("CommentMissingBody", func(t *testing.T) {
+		ServeRequest(t, teammateAuthToken, http.MethodPost, "/shareable_tasks/"+commentTaskID+"/comments/add/", bytes.NewBuffer([]byte(`{}`)), http.StatusBadRequest, api)
+	})
+	t.Run("CommentSuccess", func(t *testing.T) {
+		ServeRequest(t, teammateAuthToken, http.MethodPost, "/shareable_tasks/"+commentTaskID+"/comments/add/", bytes.NewBuffer([]byte(commentBody)), http.StatusOK, api)
+		ServeRequest(t, teammateAuthToken, http.MethodPost, "/shareable_tasks/"+editTaskID+"/comments/add/", bytes.NewBuffer([]byte(commentBody)), http.StatusOK, api)
+		taskID, _ := primitive.ObjectIDFromHex(commentTaskID)
+		task, err := database.GetTask(context.Background(), api.DB, taskID, ownerID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(*task.Comments))
+		assert.Equal(t, "looks good", (*task.Comments)[0].Body)
+		assert.Equal(t, "test_shareable_task_modify_teammate@resonant-kelpie-404a42.netlify.app", (*task.Comments)[0].User.Email)
+	})
+}
@@ -9,37 +9,32 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// ShareableTaskPreview serves link previews for shared tasks, then redirects to the task
 func (api *API) ShareableTaskPreview(c *gin.Context) {
-	taskIDHex := c.Param("task_id")
-	taskID, err := primitive.ObjectIDFromHex(taskIDHex)
-	if err != nil {
+	taskParam := c.Param("task_id")
+	if _, err := primitive.ObjectIDFromHex(taskParam); err != nil && !isTaskShareToken(taskParam) {
 		Handle404(c)
 		return
 	}
-
-	var userID *primitive.ObjectID
-	if userIDRaw, exists := c.Get("user"); exists {
-		userIDValue := userIDRaw.(primitive.ObjectID)
-		userID = &userIDValue
-	}
-
-	taskURL := getTaskURL(taskIDHex)
-	task, err := database.GetSharedTask(api.DB, taskID, userID)
+	taskURL := getTaskURL(taskParam)
+	task, isPasswordProtected, err := api.getPreviewTask(c, taskParam)
 	if err != nil {
 		NotFoundRedirect(c, taskURL)
 		return
 	}
 
-	taskOwner, err := database.GetUser(api.DB, task.UserID)
+	taskOwner, err := database.GetUser(c.Request.Context(), api.DB, task.UserID)
 	if err != nil {
 		NotFoundRedirect(c, taskURL)
 		return
 	}
 
 	previewTitle := ""
-	if task.Title != nil {
+	// the title is only shown to those who know the password
+	if task.Title != nil && !isPasswordProtected {
 		previewTitle = html.EscapeString(*task.Title)
 	}
+	previewOwner := html.EscapeString(taskOwner.Name)
 	body := []byte(`
 <!DOCTYPE html>
 <html>
@@ -50,11 +45,11 @@ func (api *API) ShareableTaskPreview(c *gin.Context) {
 	<meta property="og:title" content="` + previewTitle + `" />
 	<meta name="twitter:title" content="` + previewTitle + `">
 
-	<meta content="Task shared by ` + taskOwner.Name + ` via General Task." property="og:description">
-	<meta content="Task shared by ` + taskOwner.Name + ` via General Task." property="twitter:description">
+	<meta content="Task shared by ` + previewOwner + ` via General Task." property="og:description">
+	<meta content="Task shared by ` + previewOwner + ` via General Task." property="twitter:description">
 
 	<meta property="og:type" content="website" />
-	<meta property="og:url" content="` + config.GetConfigValue("SERVER_URL") + "task/" + task.ID.Hex() + `/" />
+	<meta property="og:url" content="` + config.GetSettings().ServerURL + "task/" + taskParam + `/" />
 </head>
 <body>
 </body>
@@ -62,6 +57,25 @@ func (api *API) ShareableTaskPreview(c *gin.Context) {
 	c.Data(200, "text/html; charset=utf-8", body)
 }
 
+// getPreviewTask loads the task for the link's task ID or share token, and whether the share needs a password
+func (api *API) getPreviewTask(c *gin.Context, taskParam string) (*database.Task, bool, error) {
+	taskID, err := primitive.ObjectIDFromHex(taskParam)
+	if err != nil {
+		share, task, err := api.getTaskShare(c.Request.Context(), taskParam)
+		if err != nil {
+			return nil, false, err
+		}
+		return task, share.PasswordHash != "", nil
+	}
+	var userID *primitive.ObjectID
+	if userIDRaw, exists := c.Get("user"); exists {
+		userIDValue := userIDRaw.(primitive.ObjectID)
+		userID = &userIDValue
+	}
+	task, err := database.GetSharedTask(c.Request.Context(), api.DB, taskID, userID)
+	return task, false, err
+}
+
 func getTaskURL(taskID string) string {
-	return config.GetConfigValue("HOME_URL") + "task/" + taskID
+	return config.GetSettings().HomeURL + "task/" + taskID
 }
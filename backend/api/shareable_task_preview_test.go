@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"testing"
@@ -8,6 +9,7 @@ import (
 	"github.com/franchizzle/task-manager/backend/database"
 	"github.com/franchizzle/task-manager/backend/testutils"
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -26,6 +28,7 @@ func TestShareableTaskPreview(t *testing.T) {
 	sharedAccessPublic := database.SharedAccessPublic
 	userID := getUserIDFromAuthToken(t, db, authToken)
 	task1, err := database.GetOrCreateTask(
+		context.Background(),
 		db,
 		userID,
 		"123abc",
@@ -39,6 +42,7 @@ func TestShareableTaskPreview(t *testing.T) {
 	)
 	assert.NoError(t, err)
 	task2, err := database.GetOrCreateTask(
+		context.Background(),
 		db,
 		userID,
 		"123abcdef",
@@ -52,6 +56,7 @@ func TestShareableTaskPreview(t *testing.T) {
 	)
 	assert.NoError(t, err)
 	task3, err := database.GetOrCreateTask(
+		context.Background(),
 		db,
 		userID,
 		"123abcdefg",
@@ -70,7 +75,7 @@ func TestShareableTaskPreview(t *testing.T) {
 
 	t.Run("MalformmatedTaskID", func(t *testing.T) {
 		response := ServeRequest(t, authToken, "GET", "/shareable_tasks/123/", nil, http.StatusNotFound, api)
-		assert.Equal(t, `{"detail":"not found"}`, string(response))
+		assert.Equal(t, `{"detail":"not found","code":"not_found"}`, string(response))
 	})
 	t.Run("InvalidTaskID", func(t *testing.T) {
 		invalidTaskID := primitive.NewObjectID().Hex()
@@ -131,4 +136,27 @@ func TestShareableTaskPreview(t *testing.T) {
 </body>
 </html>`, string(response))
 	})
+	t.Run("OwnerNameIsEscaped", func(t *testing.T) {
+		ownerToken := login("test_shareable_task_preview_escaped@resonant-kelpie-404a42.netlify.app", "")
+		ownerID := getUserIDFromAuthToken(t, db, ownerToken)
+		_, err := database.GetUserCollection(db).UpdateOne(context.Background(), bson.M{"_id": ownerID}, bson.M{"$set": bson.M{"name": `"><script>alert(1)</script>`}})
+		assert.NoError(t, err)
+		task, err := database.GetOrCreateTask(
+			context.Background(),
+			db,
+			ownerID,
+			"123escaped",
+			"foobar_source",
+			&database.Task{
+				UserID:       ownerID,
+				Title:        &title3,
+				SharedAccess: &sharedAccessPublic,
+				SharedUntil:  *testutils.CreateDateTime("9999-01-01"),
+			},
+		)
+		assert.NoError(t, err)
+		response := ServeRequest(t, "", "GET", fmt.Sprintf("/shareable_tasks/%s/", task.ID.Hex()), nil, http.StatusOK, api)
+		assert.Contains(t, string(response), `Task shared by &#34;&gt;&lt;script&gt;alert(1)&lt;/script&gt; via General Task.`)
+		assert.NotContains(t, string(response), "<script>")
+	})
 }
@@ -0,0 +1,211 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/config"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/franchizzle/task-manager/backend/settings"
+	"github.com/gin-gonic/gin"
+	"github.com/slack-go/slack"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	SLACK_COMMAND_ADD   = "add"
+	SLACK_COMMAND_TODAY = "today"
+	SLACK_COMMAND_DONE  = "done"
+	SLACK_COMMAND_USAGE = "Usage: `/gt add <title>`, `/gt today`, or `/gt done <n>`"
+)
+
+// SlackCommand  godoc
+// @Summary      Handles the /gt Slack slash command
+// @Description  Supports "/gt add <title>", "/gt today", and "/gt done <n>"
+// @Tags         slack
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        X-Slack-Request-Timestamp   header     string  true  "Request timestamp"
+// @Param        X-Slack-Signature           header     string  true  "Request signature"
+// @Success      200 {object} slack.Msg
+// @Failure      400 {object} string "invalid params"
+// @Router       /slack/commands/ [post]
+func (api *API) SlackCommand(c *gin.Context) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		HandleBadRequest(c, "unable to read request body")
+		return
+	}
+	// this is required, as the first read fully consumes the body
+	c.Request.Body = io.NopCloser(bytes.NewBuffer(body))
+
+	timestamp := c.Request.Header.Get("X-Slack-Request-Timestamp")
+	signature := c.Request.Header.Get("X-Slack-Signature")
+	err = authenticateSlackRequest(config.GetSettings().SlackSigningSecret, timestamp, signature, string(body))
+	if err != nil {
+		HandleBadRequest(c, "signing secret invalid")
+		return
+	}
+	err = validateSlackRequestTimestamp(timestamp, api.GetCurrentTime())
+	if err != nil {
+		HandleBadRequest(c, "request timestamp invalid")
+		return
+	}
+
+	command, err := slack.SlashCommandParse(c.Request)
+	if err != nil {
+		HandleBadRequest(c, "unable to parse command")
+		return
+	}
+
+	externalID := external.GenerateSlackUserID(command.TeamID, command.UserID)
+	externalToken, err := database.GetExternalToken(c.Request.Context(), api.DB, externalID, external.TASK_SOURCE_ID_SLACK_SAVED)
+	if err != nil {
+		c.JSON(200, getSlackCommandTextResponse("Link your Slack account in General Task settings to use `/gt`."))
+		return
+	}
+	userID := externalToken.UserID
+
+	subcommand, argument := parseSlackCommandText(command.Text)
+	switch subcommand {
+	case SLACK_COMMAND_ADD:
+		if argument == "" {
+			c.JSON(200, getSlackCommandTextResponse(SLACK_COMMAND_USAGE))
+			return
+		}
+		_, err = external.GeneralTaskTaskSource{}.CreateNewTask(c.Request.Context(), api.DB, userID, external.GeneralTaskDefaultAccountID, external.TaskCreationObject{
+			Title: argument,
+		})
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to create task from Slack command")
+			c.JSON(200, getSlackCommandTextResponse("Something went wrong creating your task."))
+			return
+		}
+		c.JSON(200, getSlackCommandTextResponse(fmt.Sprintf("Created task: *%s*", argument)))
+	case SLACK_COMMAND_TODAY:
+		tasks, err := api.getSlackCommandTodayTasks(c.Request.Context(), userID)
+		if err != nil {
+			c.JSON(200, getSlackCommandTextResponse("Something went wrong fetching your tasks."))
+			return
+		}
+		c.JSON(200, getSlackCommandTodayResponse(tasks))
+	case SLACK_COMMAND_DONE:
+		taskNumber, err := strconv.Atoi(argument)
+		if err != nil || taskNumber < 1 {
+			c.JSON(200, getSlackCommandTextResponse(SLACK_COMMAND_USAGE))
+			return
+		}
+		tasks, err := api.getSlackCommandTodayTasks(c.Request.Context(), userID)
+		if err != nil {
+			c.JSON(200, getSlackCommandTextResponse("Something went wrong fetching your tasks."))
+			return
+		}
+		if taskNumber > len(*tasks) {
+			c.JSON(200, getSlackCommandTextResponse(fmt.Sprintf("There is no task %d on today's list.", taskNumber)))
+			return
+		}
+		task := (*tasks)[taskNumber-1]
+		err = api.markTaskCompleteFromSlack(c.Request.Context(), userID, &task)
+		if err != nil {
+			c.JSON(200, getSlackCommandTextResponse("Something went wrong completing your task."))
+			return
+		}
+		c.JSON(200, getSlackCommandTextResponse(fmt.Sprintf("Marked *%s* as done :white_check_mark:", getSlackCommandTaskTitle(task))))
+	default:
+		c.JSON(200, getSlackCommandTextResponse(SLACK_COMMAND_USAGE))
+	}
+}
+
+// getSlackCommandTodayTasks returns active tasks due by the end of the user's day, in the order they
+// are numbered in the "/gt today" response
+func (api *API) getSlackCommandTodayTasks(ctx context.Context, userID primitive.ObjectID) (*[]database.Task, error) {
+	// slash commands carry no Timezone-Offset header, so the day is taken from the user's location
+	now := api.GetCurrentTime().In(settings.GetUserLocation(ctx, api.DB, userID))
+	endOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1)
+	findOptions := options.Find().SetSort(bson.D{{Key: "due_date", Value: 1}, {Key: "id_ordering", Value: 1}, {Key: "_id", Value: 1}})
+	tasks, err := database.GetTasks(ctx, api.DB, userID, &[]bson.M{
+		{"is_completed": false},
+		{"is_deleted": bson.M{"$ne": true}},
+		{"due_date": bson.M{"$lt": primitive.NewDateTimeFromTime(endOfDay)}},
+		{"due_date": bson.M{"$gt": primitive.NewDateTimeFromTime(time.Time{})}},
+	}, findOptions)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch tasks for Slack command")
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (api *API) markTaskCompleteFromSlack(ctx context.Context, userID primitive.ObjectID, task *database.Task) error {
+	taskSourceResult, err := api.ExternalConfig.GetSourceResult(task.SourceID)
+	if err != nil || !taskSourceResult.Details.IsCompletable {
+		api.Logger.Error().Err(err).Msg("task cannot be marked done from Slack")
+		return fmt.Errorf("task with source %s cannot be marked done", task.SourceID)
+	}
+	isCompleted := true
+	updateFields := database.Task{
+		IsCompleted: &isCompleted,
+		CompletedAt: primitive.NewDateTimeFromTime(api.GetCurrentTime()),
+	}
+	err = taskSourceResult.Source.ModifyTask(ctx, api.DB, userID, task.SourceAccountID, task.IDExternal, &updateFields, task)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update external task source from Slack")
+		return err
+	}
+	return api.UpdateTaskInDBWithError(task, userID, &updateFields)
+}
+
+func parseSlackCommandText(text string) (string, string) {
+	text = strings.TrimSpace(text)
+	subcommand, argument, _ := strings.Cut(text, " ")
+	return strings.ToLower(subcommand), strings.TrimSpace(argument)
+}
+
+func getSlackCommandTaskTitle(task database.Task) string {
+	if task.Title == nil || *task.Title == "" {
+		return "Untitled task"
+	}
+	return *task.Title
+}
+
+func getSlackCommandTextResponse(text string) slack.Msg {
+	return slack.Msg{
+		ResponseType: slack.ResponseTypeEphemeral,
+		Blocks: slack.Blocks{BlockSet: []slack.Block{
+			slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, text, false, false), nil, nil),
+		}},
+	}
+}
+
+func getSlackCommandTodayResponse(tasks *[]database.Task) slack.Msg {
+	if len(*tasks) == 0 {
+		return getSlackCommandTextResponse("Nothing due today :tada:")
+	}
+	blocks := []slack.Block{
+		slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, "Due today", false, false)),
+	}
+	lines := []string{}
+	for index, task := range *tasks {
+		line := fmt.Sprintf("%d. %s", index+1, getSlackCommandTaskTitle(task))
+		if task.Deeplink != "" {
+			line = fmt.Sprintf("%d. <%s|%s>", index+1, task.Deeplink, getSlackCommandTaskTitle(task))
+		}
+		lines = append(lines, line)
+	}
+	blocks = append(blocks,
+		slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, strings.Join(lines, "\n"), false, false), nil, nil),
+		slack.NewContextBlock("", slack.NewTextBlockObject(slack.MarkdownType, "Use `/gt done <n>` to mark a task as done", false, false)),
+	)
+	return slack.Msg{
+		ResponseType: slack.ResponseTypeEphemeral,
+		Blocks:       slack.Blocks{BlockSet: blocks},
+	}
+}
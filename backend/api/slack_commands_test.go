@@ -0,0 +1,155 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/config"
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/franchizzle/task-manager/backend/settings"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSlackCommand(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	router := GetRouter(api)
+
+	// set to dummy value because different secrets across Dev, CI, and Prod
+	config.SetForTesting(t, "SLACK_SIGNING_SECRET", "dummy value")
+
+	userID := primitive.NewObjectID()
+	_, err := database.GetExternalTokenCollection(api.DB).InsertOne(
+		context.Background(),
+		&database.ExternalAPIToken{
+			ServiceID: external.TASK_SERVICE_ID_SLACK,
+			AccountID: "command-team-command-user",
+			Token:     `{"access_token": "example token"}`,
+			UserID:    userID,
+		},
+	)
+	assert.NoError(t, err)
+
+	sendCommand := func(text string) (int, string) {
+		timestamp := fmt.Sprint(time.Now().Unix())
+		payload := url.Values{
+			"command": {"/gt"},
+			"team_id": {"command-team"},
+			"user_id": {"command-user"},
+			"text":    {text},
+		}.Encode()
+		request, _ := http.NewRequest("POST", "/slack/commands/", bytes.NewBuffer([]byte(payload)))
+		request.Header.Add("X-Slack-Request-Timestamp", timestamp)
+		request.Header.Add("X-Slack-Signature", generateSlackSignature(timestamp, payload))
+		request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		body, err := io.ReadAll(recorder.Body)
+		assert.NoError(t, err)
+		return recorder.Code, string(body)
+	}
+
+	t.Run("InvalidSignature", func(t *testing.T) {
+		request, _ := http.NewRequest("POST", "/slack/commands/", bytes.NewBuffer([]byte("text=today")))
+		request.Header.Add("X-Slack-Request-Timestamp", fmt.Sprint(time.Now().Unix()))
+		request.Header.Add("X-Slack-Signature", "invalid signature")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+	t.Run("Usage", func(t *testing.T) {
+		code, body := sendCommand("unknown")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "Usage:")
+	})
+	t.Run("TodayEmpty", func(t *testing.T) {
+		code, body := sendCommand("today")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "Nothing due today")
+	})
+	t.Run("AddAndComplete", func(t *testing.T) {
+		code, body := sendCommand("add write the launch post")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "Created task: *write the launch post*")
+
+		tasks, err := database.GetActiveTasks(context.Background(), api.DB, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(*tasks))
+		assert.Equal(t, external.TASK_SOURCE_ID_GT_TASK, (*tasks)[0].SourceID)
+
+		// give the task a due date so it shows up on today's list
+		dueDate := primitive.NewDateTimeFromTime(time.Now().Add(-time.Hour))
+		_, err = database.GetTaskCollection(api.DB).UpdateByID(context.Background(), (*tasks)[0].ID, bson.M{"$set": bson.M{"due_date": dueDate}})
+		assert.NoError(t, err)
+
+		code, body = sendCommand("today")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "1. write the launch post")
+
+		code, body = sendCommand("done 2")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "There is no task 2")
+
+		code, body = sendCommand("done 1")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "Marked *write the launch post* as done")
+
+		tasks, err = database.GetActiveTasks(context.Background(), api.DB, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(*tasks))
+	})
+}
+
+func TestGetSlackCommandTodayTasks(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+
+	userID := primitive.NewObjectID()
+	assert.NoError(t, settings.UpdateUserSetting(api.DB, userID, constants.SettingFieldTimezone, "Asia/Tokyo"))
+	// already March 2nd in Tokyo
+	testTime := time.Date(2023, time.March, 1, 20, 0, 0, 0, time.UTC)
+	api.OverrideTime = &testTime
+
+	notCompleted := false
+	for title, dueDate := range map[string]time.Time{
+		"due this evening in Tokyo": time.Date(2023, time.March, 2, 10, 0, 0, 0, time.UTC),
+		"due tomorrow in Tokyo":     time.Date(2023, time.March, 2, 16, 0, 0, 0, time.UTC),
+	} {
+		title := title
+		primitiveDueDate := primitive.NewDateTimeFromTime(dueDate)
+		_, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), database.Task{
+			UserID:      userID,
+			Title:       &title,
+			SourceID:    external.TASK_SOURCE_ID_GT_TASK,
+			IsCompleted: &notCompleted,
+			DueDate:     &primitiveDueDate,
+		})
+		assert.NoError(t, err)
+	}
+
+	tasks, err := api.getSlackCommandTodayTasks(context.Background(), userID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(*tasks))
+	assert.Equal(t, "due this evening in Tokyo", *(*tasks)[0].Title)
+}
+
+func TestParseSlackCommandText(t *testing.T) {
+	subcommand, argument := parseSlackCommandText("  ADD  buy milk ")
+	assert.Equal(t, "add", subcommand)
+	assert.Equal(t, "buy milk", argument)
+
+	subcommand, argument = parseSlackCommandText("today")
+	assert.Equal(t, "today", subcommand)
+	assert.Equal(t, "", argument)
+}
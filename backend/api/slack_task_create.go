@@ -8,11 +8,13 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/franchizzle/task-manager/backend/config"
-	"github.com/franchizzle/task-manager/backend/constants"
 	"github.com/franchizzle/task-manager/backend/database"
 	"github.com/franchizzle/task-manager/backend/external"
 	"github.com/franchizzle/task-manager/backend/logging"
@@ -25,6 +27,8 @@ const (
 	SLACK_MESSAGE_ACTION  = "message_action"
 	SLACK_VIEW_SUBMISSION = "view_submission"
 	MESSAGE_TYPE_DM       = "directmessage"
+	// Slack recommends rejecting requests older than five minutes to prevent replay attacks
+	SLACK_REQUEST_MAX_AGE = 5 * time.Minute
 )
 
 type SlackResponse struct {
@@ -91,12 +95,17 @@ func (api *API) SlackTaskCreate(c *gin.Context) {
 	logger := logging.GetSentryLogger()
 
 	// verification for security
-	slackSigningSecret := config.GetConfigValue("SLACK_SIGNING_SECRET")
+	slackSigningSecret := config.GetSettings().SlackSigningSecret
 	timestamp := c.Request.Header.Get("X-Slack-Request-Timestamp")
 	signature := c.Request.Header.Get("X-Slack-Signature")
 	err := authenticateSlackRequest(slackSigningSecret, timestamp, signature, string(body))
 	if err != nil {
-		c.JSON(400, gin.H{"detail": "signing secret invalid"})
+		HandleBadRequest(c, "signing secret invalid")
+		return
+	}
+	err = validateSlackRequestTimestamp(timestamp, api.GetCurrentTime())
+	if err != nil {
+		HandleBadRequest(c, "request timestamp invalid")
 		return
 	}
 
@@ -104,7 +113,7 @@ func (api *API) SlackTaskCreate(c *gin.Context) {
 	formData := []byte{}
 	err = c.Request.ParseForm()
 	if err != nil {
-		c.JSON(400, gin.H{"detail": "unable to parse payload"})
+		HandleBadRequest(c, "unable to parse payload")
 		return
 	}
 	if val, ok := c.Request.Form["payload"]; ok {
@@ -113,7 +122,7 @@ func (api *API) SlackTaskCreate(c *gin.Context) {
 		}
 	}
 	if len(formData) <= 0 {
-		c.JSON(400, gin.H{"detail": "payload not included in request"})
+		HandleBadRequest(c, "payload not included in request")
 		return
 	}
 
@@ -121,7 +130,7 @@ func (api *API) SlackTaskCreate(c *gin.Context) {
 	var requestParams SlackRequestParams
 	err = json.Unmarshal(formData, &requestParams)
 	if err != nil {
-		c.JSON(400, gin.H{"detail": "unable to process request payload"})
+		HandleBadRequest(c, "unable to process request payload")
 		return
 	}
 
@@ -129,13 +138,13 @@ func (api *API) SlackTaskCreate(c *gin.Context) {
 	var slackParams database.SlackMessageParams
 	err = json.Unmarshal(formData, &slackParams)
 	if err != nil {
-		c.JSON(400, gin.H{"detail": "unable to process task payload"})
+		HandleBadRequest(c, "unable to process task payload")
 		return
 	}
 
 	// extract external token for task and modal creation
 	externalID := external.GenerateSlackUserID(slackParams.Team.ID, slackParams.User.ID)
-	externalToken, err := database.GetExternalToken(api.DB, externalID, sourceID)
+	externalToken, err := database.GetExternalToken(c.Request.Context(), api.DB, externalID, sourceID)
 	if err != nil {
 		logger.Error().Err(err).Msg("error getting external token")
 		Handle500(c)
@@ -257,15 +266,14 @@ func (api *API) SlackTaskCreate(c *gin.Context) {
 		taskCreationObject := external.TaskCreationObject{
 			Title:              title,
 			SlackMessageParams: slackMetadataParams,
-			IDTaskSection:      constants.IDTaskSectionDefault,
 		}
 		if details != "" {
 			taskCreationObject.Body = details
 		}
 
-		_, err = source.CreateNewTask(api.DB, userID, externalID, taskCreationObject)
+		_, err = source.CreateNewTask(c.Request.Context(), api.DB, userID, externalID, taskCreationObject)
 		if err != nil {
-			c.JSON(503, gin.H{"detail": "failed to create task"})
+			HandleError(c, ErrorCodeServiceUnavailable, "failed to create task")
 			return
 		}
 
@@ -276,7 +284,7 @@ func (api *API) SlackTaskCreate(c *gin.Context) {
 		}
 		err = external.SendConfirmationResponse(*externalToken, url)
 		if err != nil {
-			c.JSON(500, gin.H{"detail": "failed to send ephemeral response"})
+			HandleError(c, ErrorCodeInternal, "failed to send ephemeral response")
 		}
 
 		c.JSON(200, gin.H{})
@@ -284,7 +292,7 @@ func (api *API) SlackTaskCreate(c *gin.Context) {
 	}
 
 	logger.Error().Err(err).Msg("message type not recognized")
-	c.JSON(501, gin.H{"detail": "method not recognized"})
+	HandleError(c, ErrorCodeNotImplemented, "method not recognized")
 }
 
 func authenticateSlackRequest(signingSecret string, timestamp string, signature string, body string) error {
@@ -299,6 +307,18 @@ func authenticateSlackRequest(signingSecret string, timestamp string, signature
 	return nil
 }
 
+func validateSlackRequestTimestamp(timestamp string, now time.Time) error {
+	timestampFloat, err := strconv.ParseFloat(timestamp, 64)
+	if err != nil {
+		return err
+	}
+	sentAt := time.Unix(int64(timestampFloat), 0)
+	if time.Duration(math.Abs(float64(now.Sub(sentAt)))) > SLACK_REQUEST_MAX_AGE {
+		return errors.New("stale request timestamp")
+	}
+	return nil
+}
+
 func getSlackMessageTitle(slackTask external.SlackSavedTaskSource, messageParams database.SlackMessageParams, externalToken *database.ExternalAPIToken) (string, error) {
 	title := ""
 
@@ -10,9 +10,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
-	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/franchizzle/task-manager/backend/config"
 	"github.com/franchizzle/task-manager/backend/database"
@@ -30,9 +30,10 @@ func TestSlackTaskCreate(t *testing.T) {
 	defer dbCleanup()
 
 	validTimestamp := "1355517523.000005"
+	requestTime := time.Unix(1355517523, 0)
+	api.OverrideTime = &requestTime
 	// set to dummy value because different secrets across Dev, CI, and Prod
-	os.Setenv("SLACK_SIGNING_SECRET", "dummy value")
-	defer os.Unsetenv("SLACK_SIGNING_SECRET")
+	config.SetForTesting(t, "SLACK_SIGNING_SECRET", "dummy value")
 
 	userID := primitive.NewObjectID()
 
@@ -49,7 +50,24 @@ func TestSlackTaskCreate(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"signing secret invalid\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"signing secret invalid\",\"code\":\"bad_request\"}", string(body))
+	})
+
+	t.Run("StaleTimestamp", func(t *testing.T) {
+		staleTimestamp := "1355510000.000005"
+		request, _ := http.NewRequest(
+			"POST",
+			"/tasks/create_external/slack/",
+			bytes.NewBuffer([]byte(`{"payload": []}`)))
+
+		request.Header.Add("X-Slack-Request-Timestamp", staleTimestamp)
+		request.Header.Add("X-Slack-Signature", generateSlackSignature(staleTimestamp, `{"payload": []}`))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		body, err := io.ReadAll(recorder.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "{\"detail\":\"request timestamp invalid\",\"code\":\"bad_request\"}", string(body))
 	})
 
 	t.Run("PayloadInvalid", func(t *testing.T) {
@@ -67,7 +85,7 @@ func TestSlackTaskCreate(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"payload not included in request\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"payload not included in request\",\"code\":\"bad_request\"}", string(body))
 	})
 
 	t.Run("TeamInvalid", func(t *testing.T) {
@@ -88,7 +106,7 @@ func TestSlackTaskCreate(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"internal server error\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"internal server error\",\"code\":\"internal_error\"}", string(body))
 	})
 
 	t.Run("InvalidOauthToken", func(t *testing.T) {
@@ -117,7 +135,7 @@ func TestSlackTaskCreate(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"internal server error\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"internal server error\",\"code\":\"internal_error\"}", string(body))
 	})
 
 	t.Run("NotOKMessageAction", func(t *testing.T) {
@@ -156,7 +174,7 @@ func TestSlackTaskCreate(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"internal server error\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"internal server error\",\"code\":\"internal_error\"}", string(body))
 	})
 
 	t.Run("SuccessMessageAction", func(t *testing.T) {
@@ -216,7 +234,7 @@ func TestSlackTaskCreate(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, "{}", string(body))
 
-		tasks, err := database.GetActiveTasks(db, userID)
+		tasks, err := database.GetActiveTasks(context.Background(), db, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(*tasks))
 		task := (*tasks)[0]
@@ -230,8 +248,7 @@ func TestGetSlackMessageTitle(t *testing.T) {
 
 	validTimestamp := "1355517523.000005"
 	// set to dummy value because different secrets across Dev, CI, and Prod
-	os.Setenv("SLACK_SIGNING_SECRET", "dummy value")
-	defer os.Unsetenv("SLACK_SIGNING_SECRET")
+	config.SetForTesting(t, "SLACK_SIGNING_SECRET", "dummy value")
 
 	server := getServerForSlack()
 	defer server.Close()
@@ -284,6 +301,14 @@ func getServerForSlackWithResponse(response string) *httptest.Server {
 	}))
 }
 
+func TestValidateSlackRequestTimestamp(t *testing.T) {
+	now := time.Unix(1661977103, 0)
+	assert.NoError(t, validateSlackRequestTimestamp("1661977103", now))
+	assert.NoError(t, validateSlackRequestTimestamp("1661977000.000100", now))
+	assert.Error(t, validateSlackRequestTimestamp("1661970000", now))
+	assert.Error(t, validateSlackRequestTimestamp("invalid", now))
+}
+
 func generateSlackSignature(timestamp string, payload string) string {
 	signingSecret := config.GetConfigValue("SLACK_SIGNING_SECRET")
 	hash := hmac.New(sha256.New, []byte(signingSecret))
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"io"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const STREAM_HEARTBEAT_INTERVAL = 30 * time.Second
+
+// collections watched for the event stream, keyed to the event name sent to clients
+var streamEventNames = map[string]string{
+	"tasks":           "task",
+	"pull_requests":   "pull_request",
+	"calendar_events": "calendar_event",
+}
+
+func getStreamCollections() []string {
+	collections := []string{}
+	for collection := range streamEventNames {
+		collections = append(collections, collection)
+	}
+	return collections
+}
+
+type StreamEvent struct {
+	ID        string `json:"id"`
+	Operation string `json:"operation"`
+}
+
+// Stream godoc
+// @Summary      Streams changes to the user's tasks, pull requests, and calendar events
+// @Description  Server-sent events named task, pull_request, or calendar_event, sent when a document is created or updated. Clients should refetch the changed item
+// @Tags         stream
+// @Produce      text/event-stream
+// @Success      200 {object} StreamEvent
+// @Router       /stream/ [get]
+func (api *API) Stream(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	events, unsubscribe := api.Changes.Subscribe(userID)
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// stop proxies from buffering the stream
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(200)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(STREAM_HEARTBEAT_INTERVAL)
+	defer heartbeat.Stop()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case <-heartbeat.C:
+			// comments are ignored by EventSource but keep idle connections from being closed
+			_, err := io.WriteString(w, ": heartbeat\n\n")
+			return err == nil
+		case event := <-events:
+			name, exists := streamEventNames[event.Collection]
+			if !exists {
+				return true
+			}
+			c.SSEvent(name, StreamEvent{ID: event.DocumentID.Hex(), Operation: event.OperationType})
+			return true
+		}
+	})
+}
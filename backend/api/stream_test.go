@@ -0,0 +1,34 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	authToken := login("test_stream@resonant-kelpie-404a42.netlify.app", "")
+
+	UnauthorizedTest(t, http.MethodGet, "/stream/", nil)
+	t.Run("Success", func(t *testing.T) {
+		server := httptest.NewServer(GetRouter(api))
+		defer server.Close()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/stream/", nil)
+		assert.NoError(t, err)
+		request.Header.Add("Authorization", "Bearer "+authToken)
+		response, err := http.DefaultClient.Do(request)
+		assert.NoError(t, err)
+		defer response.Body.Close()
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+		assert.Equal(t, "no-cache", response.Header.Get("Cache-Control"))
+	})
+}
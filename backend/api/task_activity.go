@@ -0,0 +1,133 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type TaskActivityResult struct {
+	ID        primitive.ObjectID `json:"id"`
+	TaskID    primitive.ObjectID `json:"task_id"`
+	Type      string             `json:"type"`
+	OldValue  string             `json:"old_value,omitempty"`
+	NewValue  string             `json:"new_value,omitempty"`
+	CreatedAt string             `json:"created_at"`
+}
+
+func (api *API) TaskActivityList(c *gin.Context) {
+	taskID, err := primitive.ObjectIDFromHex(c.Param("task_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	userID := getUserIDFromContext(c)
+	_, err = database.GetTask(c.Request.Context(), api.DB, taskID, userID)
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	api.taskActivityPage(c, userID, &[]bson.M{{"task_id": taskID}})
+}
+
+func (api *API) ActivityList(c *gin.Context) {
+	api.taskActivityPage(c, getUserIDFromContext(c), nil)
+}
+
+// taskActivityPage responds with the newest activity first, always paginated since history grows without bound
+func (api *API) taskActivityPage(c *gin.Context, userID primitive.ObjectID, additionalFilters *[]bson.M) {
+	pagination, err := getPagination(c)
+	if err != nil {
+		HandleBadRequest(c, err.Error())
+		return
+	}
+	if pagination == nil {
+		pagination = &database.Pagination{}
+	}
+	activities, nextCursor, err := database.FindPageWithCollection[database.TaskActivity](
+		c.Request.Context(),
+		database.GetTaskActivityCollection(api.DB),
+		userID,
+		additionalFilters,
+		*pagination,
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch task activity")
+		Handle500(c)
+		return
+	}
+	results := []TaskActivityResult{}
+	for _, activity := range activities {
+		results = append(results, TaskActivityResult{
+			ID:        activity.ID,
+			TaskID:    activity.TaskID,
+			Type:      activity.Type,
+			OldValue:  activity.OldValue,
+			NewValue:  activity.NewValue,
+			CreatedAt: activity.CreatedAt.Time().UTC().Format(time.RFC3339),
+		})
+	}
+	c.JSON(200, PaginatedResult[TaskActivityResult]{
+		Results:    results,
+		NextCursor: nextCursor,
+	})
+}
+
+// recordTaskActivity is best effort, as the change it describes has already been saved
+func (api *API) recordTaskActivity(ctx context.Context, activities []database.TaskActivity) {
+	err := database.InsertTaskActivities(ctx, api.DB, activities)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to record task activity")
+	}
+}
+
+// getTaskUpdateActivities describes the user visible changes an update makes to a task
+func getTaskUpdateActivities(task *database.Task, userID primitive.ObjectID, updateFields *database.Task) []database.TaskActivity {
+	activities := []database.TaskActivity{}
+	newActivity := func(activityType string, oldValue string, newValue string) {
+		activities = append(activities, database.TaskActivity{
+			UserID:   userID,
+			TaskID:   task.ID,
+			Type:     activityType,
+			OldValue: oldValue,
+			NewValue: newValue,
+		})
+	}
+
+	wasCompleted := task.IsCompleted != nil && *task.IsCompleted
+	if updateFields.IsCompleted != nil && *updateFields.IsCompleted && !wasCompleted {
+		newActivity(constants.TaskActivityCompleted, "", "")
+	}
+	if updateFields.DueDate != nil && (task.DueDate == nil || *task.DueDate != *updateFields.DueDate) {
+		newActivity(constants.TaskActivityDueDateChanged, formatActivityDate(task.DueDate), formatActivityDate(updateFields.DueDate))
+	}
+	if updateFields.Comments != nil {
+		previousCount := 0
+		if task.Comments != nil {
+			previousCount = len(*task.Comments)
+		}
+		// comments are only ever appended, so anything past the previous count is new
+		for index, comment := range *updateFields.Comments {
+			if index >= previousCount {
+				newActivity(constants.TaskActivityCommented, "", comment.Body)
+			}
+		}
+	}
+	if updateFields.SharedUntil != 0 && updateFields.SharedUntil != task.SharedUntil && updateFields.SharedUntil.Time().After(time.Now()) {
+		newActivity(constants.TaskActivityShared, "", updateFields.SharedUntil.Time().UTC().Format(time.RFC3339))
+	}
+	return activities
+}
+
+func formatActivityDate(date *primitive.DateTime) string {
+	// tasks without a due date may store the zero time or the unix epoch
+	if date == nil || date.Time().UTC().Year() <= 1971 {
+		return ""
+	}
+	return date.Time().UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTaskActivity(t *testing.T) {
+	authToken := login("test_task_activity@resonant-kelpie-404a42.netlify.app", "")
+	otherToken := login("test_task_activity_other@resonant-kelpie-404a42.netlify.app", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+
+	var taskID primitive.ObjectID
+	t.Run("Create", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "POST", "/tasks/create/"+external.TASK_SOURCE_ID_GT_TASK+"/", bytes.NewBuffer([]byte(`{"title": "write the spec"}`)), http.StatusOK, api)
+		var result map[string]primitive.ObjectID
+		assert.NoError(t, json.Unmarshal(body, &result))
+		taskID = result["task_id"]
+	})
+	t.Run("Modify", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", "/tasks/modify/"+taskID.Hex()+"/", bytes.NewBuffer([]byte(`{"due_date": "2023-05-01"}`)), http.StatusOK, api)
+		ServeRequest(t, authToken, "PATCH", "/tasks/modify/"+taskID.Hex()+"/", bytes.NewBuffer([]byte(`{"id_ordering": 2}`)), http.StatusOK, api)
+		ServeRequest(t, authToken, "PATCH", "/tasks/modify/"+taskID.Hex()+"/", bytes.NewBuffer([]byte(`{"is_completed": true}`)), http.StatusOK, api)
+	})
+	t.Run("TaskActivity", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "GET", "/tasks/"+taskID.Hex()+"/activity/", nil, http.StatusOK, api)
+		var result PaginatedResult[TaskActivityResult]
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, 4, len(result.Results))
+		// newest first
+		assert.Equal(t, constants.TaskActivityCompleted, result.Results[0].Type)
+		assert.Equal(t, constants.TaskActivityReordered, result.Results[1].Type)
+		assert.Equal(t, constants.TaskActivityDueDateChanged, result.Results[2].Type)
+		assert.Equal(t, "2023-05-01T00:00:00Z", result.Results[2].NewValue)
+		assert.Equal(t, constants.TaskActivityCreated, result.Results[3].Type)
+		assert.Equal(t, "write the spec", result.Results[3].NewValue)
+	})
+	t.Run("UserActivityPaginated", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "GET", "/activity/?limit=3", nil, http.StatusOK, api)
+		var result PaginatedResult[TaskActivityResult]
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, 3, len(result.Results))
+		assert.NotEmpty(t, result.NextCursor)
+	})
+	t.Run("OtherUser", func(t *testing.T) {
+		ServeRequest(t, otherToken, "GET", "/tasks/"+taskID.Hex()+"/activity/", nil, http.StatusNotFound, api)
+		body := ServeRequest(t, otherToken, "GET", "/activity/", nil, http.StatusOK, api)
+		assert.Equal(t, `{"results":[]}`, string(body))
+	})
+	UnauthorizedTest(t, "GET", "/activity/", nil)
+}
+
+func TestGetTaskUpdateActivities(t *testing.T) {
+	userID := primitive.NewObjectID()
+	completed := true
+	notCompleted := false
+	dueDate := primitive.NewDateTimeFromTime(time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC))
+	sharedUntil := primitive.NewDateTimeFromTime(time.Now().Add(time.Hour))
+	task := &database.Task{
+		ID:          primitive.NewObjectID(),
+		IsCompleted: &notCompleted,
+		Comments:    &[]database.Comment{{Body: "first"}},
+	}
+
+	t.Run("NoChanges", func(t *testing.T) {
+		assert.Equal(t, []database.TaskActivity{}, getTaskUpdateActivities(task, userID, &database.Task{}))
+	})
+	t.Run("AllChanges", func(t *testing.T) {
+		activities := getTaskUpdateActivities(task, userID, &database.Task{
+			IsCompleted: &completed,
+			DueDate:     &dueDate,
+			Comments:    &[]database.Comment{{Body: "first"}, {Body: "second"}},
+			SharedUntil: sharedUntil,
+		})
+		assert.Equal(t, 4, len(activities))
+		assert.Equal(t, constants.TaskActivityCompleted, activities[0].Type)
+		assert.Equal(t, constants.TaskActivityDueDateChanged, activities[1].Type)
+		assert.Equal(t, "", activities[1].OldValue)
+		assert.Equal(t, "2023-05-01T00:00:00Z", activities[1].NewValue)
+		assert.Equal(t, constants.TaskActivityCommented, activities[2].Type)
+		assert.Equal(t, "second", activities[2].NewValue)
+		assert.Equal(t, constants.TaskActivityShared, activities[3].Type)
+		assert.Equal(t, task.ID, activities[3].TaskID)
+		assert.Equal(t, userID, activities[3].UserID)
+	})
+	t.Run("AlreadyCompleted", func(t *testing.T) {
+		completedTask := &database.Task{ID: task.ID, IsCompleted: &completed}
+		assert.Equal(t, []database.TaskActivity{}, getTaskUpdateActivities(completedTask, userID, &database.Task{IsCompleted: &completed}))
+	})
+}
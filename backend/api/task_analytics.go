@@ -0,0 +1,296 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const TASK_ANALYTICS_DEFAULT_WEEKS = 8
+const TASK_ANALYTICS_MAX_WEEKS = 52
+const TASK_AGING_DEFAULT_DAYS = 30
+const TASK_AGING_MAX_TASKS = 50
+
+type TaskAnalyticsParams struct {
+	Weeks     *int `form:"weeks"`
+	AgingDays *int `form:"aging_days"`
+}
+
+type TaskAnalyticsWeek struct {
+	DateStart      string `json:"date_start"`
+	DateEnd        string `json:"date_end"`
+	CreatedCount   int    `json:"created_count"`
+	CompletedCount int    `json:"completed_count"`
+}
+
+type TaskAgingItem struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	SourceID string `json:"source_id"`
+	Deeplink string `json:"deeplink"`
+	AgeDays  int    `json:"age_days"`
+}
+
+type TaskAgingReport struct {
+	ThresholdDays int `json:"threshold_days"`
+	Count         int `json:"count"`
+	// the oldest of the tasks, oldest first
+	Tasks []TaskAgingItem `json:"tasks"`
+}
+
+type TaskAnalyticsResult struct {
+	Weeks []TaskAnalyticsWeek `json:"weeks"`
+	// for the tasks completed in these weeks, unset if there are none
+	MedianMinutesToComplete *int            `json:"median_minutes_to_complete"`
+	Aging                   TaskAgingReport `json:"aging"`
+}
+
+type taskWeekCount struct {
+	Week  int `bson:"_id"`
+	Count int `bson:"count"`
+}
+
+type agingTask struct {
+	ID        primitive.ObjectID `bson:"_id"`
+	Title     string             `bson:"title"`
+	SourceID  string             `bson:"source_id"`
+	Deeplink  string             `bson:"deeplink"`
+	CreatedAt primitive.DateTime `bson:"created_at"`
+}
+
+// tasks created before created_at_external existed fall back to the time in their ID
+var taskCreatedAtExpression = bson.D{{Key: "$ifNull", Value: bson.A{"$created_at_external", bson.D{{Key: "$toDate", Value: "$_id"}}}}}
+
+// TaskAnalytics godoc
+// @Summary      Returns how quickly the user gets through their tasks
+// @Description  Tasks created and completed per week, the median time to complete a task, and the tasks that have been open the longest. The last week ends at the end of today
+// @Tags         analytics
+// @Produce      json
+// @Param        weeks       query  int  false "number of weeks, 8 by default"
+// @Param        aging_days  query  int  false "how many days a task has to be open to be in the aging report, 30 by default"
+// @Success      200 {object} TaskAnalyticsResult
+// @Failure      400 {object} string "invalid params"
+// @Failure      500 {object} string "internal server error"
+// @Router       /analytics/tasks/ [get]
+func (api *API) TaskAnalytics(c *gin.Context) {
+	var params TaskAnalyticsParams
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	weeks := TASK_ANALYTICS_DEFAULT_WEEKS
+	if params.Weeks != nil {
+		weeks = *params.Weeks
+	}
+	if weeks < 1 || weeks > TASK_ANALYTICS_MAX_WEEKS {
+		HandleBadRequest(c, fmt.Sprintf("'weeks' must be between 1 and %d", TASK_ANALYTICS_MAX_WEEKS), "weeks")
+		return
+	}
+	agingDays := TASK_AGING_DEFAULT_DAYS
+	if params.AgingDays != nil {
+		agingDays = *params.AgingDays
+	}
+	if agingDays < 1 {
+		HandleBadRequest(c, "'aging_days' must be at least 1", "aging_days")
+		return
+	}
+	timezoneOffset, err := api.getTimezoneOffset(c)
+	if err != nil {
+		HandleBadRequest(c, err.Error())
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	timeNow := api.GetCurrentLocalizedTime(timezoneOffset)
+	periodEnd := time.Date(timeNow.Year(), timeNow.Month(), timeNow.Day(), 0, 0, 0, 0, timeNow.Location()).AddDate(0, 0, 1)
+	periodStart := periodEnd.AddDate(0, 0, -NUM_DAYS_IN_WEEK*weeks)
+
+	result := TaskAnalyticsResult{Weeks: []TaskAnalyticsWeek{}}
+	for index := 0; index < weeks; index++ {
+		weekStart := periodStart.AddDate(0, 0, NUM_DAYS_IN_WEEK*index)
+		result.Weeks = append(result.Weeks, TaskAnalyticsWeek{
+			DateStart: weekStart.Format("2006-01-02"),
+			DateEnd:   weekStart.AddDate(0, 0, NUM_DAYS_IN_WEEK-1).Format("2006-01-02"),
+		})
+	}
+	createdCounts, err := api.getTaskWeekCounts(c.Request.Context(), userID, bson.D{}, taskCreatedAtExpression, periodStart, periodEnd)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to count created tasks")
+		Handle500(c)
+		return
+	}
+	for _, weekCount := range createdCounts {
+		if weekCount.Week < len(result.Weeks) {
+			result.Weeks[weekCount.Week].CreatedCount = weekCount.Count
+		}
+	}
+	completedCounts, err := api.getTaskWeekCounts(c.Request.Context(), userID, bson.D{{Key: "is_completed", Value: true}}, "$completed_at", periodStart, periodEnd)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to count completed tasks")
+		Handle500(c)
+		return
+	}
+	for _, weekCount := range completedCounts {
+		if weekCount.Week < len(result.Weeks) {
+			result.Weeks[weekCount.Week].CompletedCount = weekCount.Count
+		}
+	}
+
+	result.MedianMinutesToComplete, err = api.getMedianMinutesToComplete(c.Request.Context(), userID, periodStart, periodEnd)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to get median time to complete")
+		Handle500(c)
+		return
+	}
+	result.Aging, err = api.getTaskAgingReport(c.Request.Context(), userID, timeNow, agingDays)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to get task aging report")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, result)
+}
+
+// getTaskWeekCounts counts the user's tasks matching the filter by which week from periodStart the
+// date expression falls in
+func (api *API) getTaskWeekCounts(ctx context.Context, userID primitive.ObjectID, filter bson.D, dateExpression interface{}, periodStart time.Time, periodEnd time.Time) ([]taskWeekCount, error) {
+	matchStage := bson.D{{Key: "$match", Value: append(bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "is_deleted", Value: bson.D{{Key: "$ne", Value: true}}},
+	}, filter...)}}
+	addDateStage := bson.D{{Key: "$addFields", Value: bson.D{{Key: "analytics_date", Value: dateExpression}}}}
+	periodMatchStage := bson.D{{Key: "$match", Value: bson.D{{Key: "analytics_date", Value: bson.D{
+		{Key: "$gte", Value: periodStart},
+		{Key: "$lt", Value: periodEnd},
+	}}}}}
+	// subtracting dates gives milliseconds
+	groupStage := bson.D{{Key: "$group", Value: bson.D{
+		{Key: "_id", Value: bson.D{{Key: "$floor", Value: bson.D{{Key: "$divide", Value: bson.A{
+			bson.D{{Key: "$subtract", Value: bson.A{"$analytics_date", periodStart}}},
+			(NUM_DAYS_IN_WEEK * NUM_HOURS_IN_DAY * time.Hour).Milliseconds(),
+		}}}}}},
+		{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+	}}}
+
+	pipeline := mongo.Pipeline{matchStage, addDateStage, periodMatchStage, groupStage}
+	cursor, err := database.GetTaskCollection(api.DB).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var weekCounts []taskWeekCount
+	if err = cursor.All(ctx, &weekCounts); err != nil {
+		return nil, err
+	}
+	return weekCounts, nil
+}
+
+// getMedianMinutesToComplete returns how long the tasks completed in the period took, from when
+// they were created, or nil if none were completed
+func (api *API) getMedianMinutesToComplete(ctx context.Context, userID primitive.ObjectID, periodStart time.Time, periodEnd time.Time) (*int, error) {
+	matchStage := bson.D{{Key: "$match", Value: bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "is_deleted", Value: bson.D{{Key: "$ne", Value: true}}},
+		{Key: "is_completed", Value: true},
+		{Key: "completed_at", Value: bson.D{
+			{Key: "$gte", Value: periodStart},
+			{Key: "$lt", Value: periodEnd},
+		}},
+	}}}
+	projectStage := bson.D{{Key: "$project", Value: bson.D{
+		{Key: "duration", Value: bson.D{{Key: "$subtract", Value: bson.A{"$completed_at", taskCreatedAtExpression}}}},
+	}}}
+	sortStage := bson.D{{Key: "$sort", Value: bson.D{{Key: "duration", Value: 1}}}}
+	// only the sorted durations are sent back, not the tasks
+	groupStage := bson.D{{Key: "$group", Value: bson.D{
+		{Key: "_id", Value: nil},
+		{Key: "durations", Value: bson.D{{Key: "$push", Value: "$duration"}}},
+	}}}
+
+	pipeline := mongo.Pipeline{matchStage, projectStage, sortStage, groupStage}
+	cursor, err := database.GetTaskCollection(api.DB).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var results []struct {
+		Durations []int64 `bson:"durations"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	if len(results) == 0 || len(results[0].Durations) == 0 {
+		return nil, nil
+	}
+	medianMinutes := int(time.Duration(getMedian(results[0].Durations)) * time.Millisecond / time.Minute)
+	return &medianMinutes, nil
+}
+
+// getMedian returns the median of the sorted values
+func getMedian(sortedValues []int64) int64 {
+	middle := len(sortedValues) / 2
+	if len(sortedValues)%2 == 1 {
+		return sortedValues[middle]
+	}
+	return (sortedValues[middle-1] + sortedValues[middle]) / 2
+}
+
+func (api *API) getTaskAgingReport(ctx context.Context, userID primitive.ObjectID, now time.Time, agingDays int) (TaskAgingReport, error) {
+	report := TaskAgingReport{ThresholdDays: agingDays, Tasks: []TaskAgingItem{}}
+	matchStage := bson.D{{Key: "$match", Value: bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "is_deleted", Value: bson.D{{Key: "$ne", Value: true}}},
+		{Key: "is_completed", Value: bson.D{{Key: "$ne", Value: true}}},
+	}}}
+	addCreatedAtStage := bson.D{{Key: "$addFields", Value: bson.D{{Key: "created_at", Value: taskCreatedAtExpression}}}}
+	agedMatchStage := bson.D{{Key: "$match", Value: bson.D{{Key: "created_at", Value: bson.D{{Key: "$lt", Value: now.AddDate(0, 0, -agingDays)}}}}}}
+	sortStage := bson.D{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}}}
+	facetStage := bson.D{{Key: "$facet", Value: bson.D{
+		{Key: "count", Value: bson.A{bson.D{{Key: "$count", Value: "count"}}}},
+		{Key: "tasks", Value: bson.A{
+			bson.D{{Key: "$limit", Value: TASK_AGING_MAX_TASKS}},
+			bson.D{{Key: "$project", Value: bson.D{
+				{Key: "title", Value: 1},
+				{Key: "source_id", Value: 1},
+				{Key: "deeplink", Value: 1},
+				{Key: "created_at", Value: 1},
+			}}},
+		}},
+	}}}
+
+	pipeline := mongo.Pipeline{matchStage, addCreatedAtStage, agedMatchStage, sortStage, facetStage}
+	cursor, err := database.GetTaskCollection(api.DB).Aggregate(ctx, pipeline)
+	if err != nil {
+		return report, err
+	}
+	var results []struct {
+		Count []struct {
+			Count int `bson:"count"`
+		} `bson:"count"`
+		Tasks []agingTask `bson:"tasks"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return report, err
+	}
+	if len(results) == 0 {
+		return report, nil
+	}
+	if len(results[0].Count) > 0 {
+		report.Count = results[0].Count[0].Count
+	}
+	for _, task := range results[0].Tasks {
+		report.Tasks = append(report.Tasks, TaskAgingItem{
+			ID:       task.ID.Hex(),
+			Title:    task.Title,
+			SourceID: task.SourceID,
+			Deeplink: task.Deeplink,
+			AgeDays:  int(now.Sub(task.CreatedAt.Time()) / (NUM_HOURS_IN_DAY * time.Hour)),
+		})
+	}
+	return report, nil
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGetMedian(t *testing.T) {
+	assert.Equal(t, int64(3), getMedian([]int64{3}))
+	assert.Equal(t, int64(2), getMedian([]int64{1, 2, 10}))
+	assert.Equal(t, int64(6), getMedian([]int64{1, 2, 10, 12}))
+}
+
+func TestTaskAnalytics(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	router := GetRouter(api)
+	// a Wednesday, so the last week starts on Thursday
+	currentTime := time.Date(2023, time.March, 8, 12, 0, 0, 0, time.UTC)
+	api.OverrideTime = &currentTime
+	authToken := login("task_analytics@resonant-kelpie-404a42.netlify.app", "")
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	completed := true
+	deleted := true
+	insertTask := func(title string, userID primitive.ObjectID, createdAgo time.Duration, completedAgo *time.Duration, isDeleted bool) {
+		task := database.Task{
+			UserID:            userID,
+			Title:             &title,
+			SourceID:          external.TASK_SOURCE_ID_GT_TASK,
+			CreatedAtExternal: primitive.NewDateTimeFromTime(currentTime.Add(-createdAgo)),
+		}
+		if completedAgo != nil {
+			task.IsCompleted = &completed
+			task.CompletedAt = primitive.NewDateTimeFromTime(currentTime.Add(-*completedAgo))
+		}
+		if isDeleted {
+			task.IsDeleted = &deleted
+		}
+		_, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), task)
+		assert.NoError(t, err)
+	}
+	day := 24 * time.Hour
+	hour := time.Hour
+	twoHours := 2 * time.Hour
+	tenDays := 10 * day
+	// this week
+	insertTask("Quick fix", userID, 3*hour, &hour, false)
+	insertTask("Open this week", userID, day, nil, false)
+	// last week
+	insertTask("Slow fix", userID, 12*day, &tenDays, false)
+	insertTask("Deleted", userID, 10*day, &twoHours, true)
+	// long open
+	insertTask("Old task", userID, 40*day, nil, false)
+	insertTask("Older task", userID, 90*day, nil, false)
+	insertTask("Other user's task", primitive.NewObjectID(), 90*day, nil, false)
+
+	getAnalytics := func(url string, expectedStatus int) []byte {
+		request, _ := http.NewRequest(http.MethodGet, url, nil)
+		request.Header.Set("Authorization", "Bearer "+authToken)
+		request.Header.Set("Timezone-Offset", "0")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, expectedStatus, recorder.Code)
+		body, err := io.ReadAll(recorder.Body)
+		assert.NoError(t, err)
+		return body
+	}
+
+	t.Run("InvalidParams", func(t *testing.T) {
+		getAnalytics("/analytics/tasks/?weeks=0", http.StatusBadRequest)
+		getAnalytics("/analytics/tasks/?weeks=53", http.StatusBadRequest)
+		getAnalytics("/analytics/tasks/?aging_days=0", http.StatusBadRequest)
+	})
+	t.Run("Success", func(t *testing.T) {
+		var result TaskAnalyticsResult
+		assert.NoError(t, json.Unmarshal(getAnalytics("/analytics/tasks/?weeks=2", http.StatusOK), &result))
+		assert.Equal(t, []TaskAnalyticsWeek{
+			{DateStart: "2023-02-23", DateEnd: "2023-03-01", CreatedCount: 1, CompletedCount: 1},
+			{DateStart: "2023-03-02", DateEnd: "2023-03-08", CreatedCount: 2, CompletedCount: 1},
+		}, result.Weeks)
+		// 2 hours and 2 days
+		assert.Equal(t, (2*60+2*24*60)/2, *result.MedianMinutesToComplete)
+		assert.Equal(t, 30, result.Aging.ThresholdDays)
+		assert.Equal(t, 2, result.Aging.Count)
+		assert.Equal(t, "Older task", result.Aging.Tasks[0].Title)
+		assert.Equal(t, 90, result.Aging.Tasks[0].AgeDays)
+		assert.Equal(t, "Old task", result.Aging.Tasks[1].Title)
+	})
+	t.Run("AgingDays", func(t *testing.T) {
+		var result TaskAnalyticsResult
+		assert.NoError(t, json.Unmarshal(getAnalytics("/analytics/tasks/?aging_days=60", http.StatusOK), &result))
+		assert.Equal(t, 8, len(result.Weeks))
+		assert.Equal(t, 1, result.Aging.Count)
+		assert.Equal(t, "Older task", result.Aging.Tasks[0].Title)
+	})
+	UnauthorizedTest(t, http.MethodGet, "/analytics/tasks/", nil)
+}
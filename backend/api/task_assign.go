@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/utils"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type TaskAssignParams struct {
+	// an empty assignee unassigns the task
+	AssigneeID string `json:"assignee_id"`
+}
+
+func (api *API) TaskAssign(c *gin.Context) {
+	taskID, err := primitive.ObjectIDFromHex(c.Param("task_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	var params TaskAssignParams
+	err = c.BindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	userID := getUserIDFromContext(c)
+	task, err := database.GetTask(c.Request.Context(), api.DB, taskID, userID)
+	if err != nil {
+		Handle404(c)
+		return
+	}
+
+	update := bson.M{"$unset": bson.M{"assignee_id": ""}}
+	if params.AssigneeID != "" {
+		assigneeID, err := primitive.ObjectIDFromHex(params.AssigneeID)
+		if err != nil {
+			HandleBadRequest(c, "invalid assignee_id", "assignee_id")
+			return
+		}
+		canAssign, err := canAssignTask(c.Request.Context(), api.DB, userID, assigneeID)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to check task assignee")
+			Handle500(c)
+			return
+		}
+		if !canAssign {
+			HandleBadRequest(c, "assignee must be on your team or share your email domain")
+			return
+		}
+		update = bson.M{"$set": bson.M{"assignee_id": assigneeID}}
+	}
+
+	_, err = database.GetTaskCollection(api.DB).UpdateOne(
+		c.Request.Context(),
+		bson.M{"$and": []bson.M{
+			{"_id": task.ID},
+			{"user_id": userID},
+		}},
+		update,
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to assign task")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+// canAssignTask checks that the assignee is in one of the user's dashboard teams, or shares a
+// (non open email provider) email domain with them
+func canAssignTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, assigneeID primitive.ObjectID) (bool, error) {
+	if userID == assigneeID {
+		return true, nil
+	}
+	assignee, err := database.GetUser(ctx, db, assigneeID)
+	if err == mongo.ErrNoDocuments {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	isTeammate, err := database.AreDashboardTeammates(ctx, db, userID, assignee.ID)
+	if err != nil || isTeammate {
+		return isTeammate, err
+	}
+	user, err := database.GetUser(ctx, db, userID)
+	if err != nil {
+		return false, err
+	}
+	userDomain, err := database.GetEmailDomain(user.Email)
+	if err != nil || utils.IsOpenEmailAddress(userDomain) {
+		return false, nil
+	}
+	assigneeDomain, err := database.GetEmailDomain(assignee.Email)
+	if err != nil {
+		return false, nil
+	}
+	return userDomain == assigneeDomain, nil
+}
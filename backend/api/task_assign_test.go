@@ -0,0 +1,90 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTaskAssign(t *testing.T) {
+	authToken := login("test_task_assign@resonant-kelpie-404a42.netlify.app", "")
+	coworkerToken := login("test_task_assign_coworker@resonant-kelpie-404a42.netlify.app", "")
+	outsiderToken := login("test_task_assign_outsider@gmail.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	coworkerID := getUserIDFromAuthToken(t, api.DB, coworkerToken)
+	outsiderID := getUserIDFromAuthToken(t, api.DB, outsiderToken)
+
+	completed := false
+	title := "assigned task"
+	insertResult, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), database.Task{
+		UserID:      userID,
+		IDExternal:  "assigned_task",
+		SourceID:    external.TASK_SOURCE_ID_GT_TASK,
+		Title:       &title,
+		IsCompleted: &completed,
+	})
+	assert.NoError(t, err)
+	taskID := insertResult.InsertedID.(primitive.ObjectID)
+	url := "/tasks/" + taskID.Hex() + "/assign/"
+
+	UnauthorizedTest(t, "PATCH", url, nil)
+	t.Run("TaskNotFound", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", "/tasks/"+primitive.NewObjectID().Hex()+"/assign/", bytes.NewBuffer([]byte(`{"assignee_id": "`+coworkerID.Hex()+`"}`)), http.StatusNotFound, api)
+	})
+	t.Run("NotOwner", func(t *testing.T) {
+		ServeRequest(t, coworkerToken, "PATCH", url, bytes.NewBuffer([]byte(`{"assignee_id": "`+coworkerID.Hex()+`"}`)), http.StatusNotFound, api)
+	})
+	t.Run("InvalidAssignee", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "PATCH", url, bytes.NewBuffer([]byte(`{"assignee_id": "invalid"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"invalid assignee_id","code":"invalid_parameter","field_errors":[{"field":"assignee_id"}]}`, string(body))
+	})
+	t.Run("AssigneeNotTeammate", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "PATCH", url, bytes.NewBuffer([]byte(`{"assignee_id": "`+outsiderID.Hex()+`"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"assignee must be on your team or share your email domain","code":"bad_request"}`, string(body))
+	})
+	t.Run("SameEmailDomain", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", url, bytes.NewBuffer([]byte(`{"assignee_id": "`+coworkerID.Hex()+`"}`)), http.StatusOK, api)
+		task, err := database.GetTask(context.Background(), api.DB, taskID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, coworkerID, task.AssigneeID)
+
+		view := database.View{UserID: coworkerID, Type: string(constants.ViewAssignedToMe)}
+		result, err := api.GetAssignedToMeOverviewResult(context.Background(), view, coworkerID, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(result.ViewItems))
+		assert.Equal(t, taskID, result.ViewItems[0].ID)
+		assert.Equal(t, &coworkerID, result.ViewItems[0].AssigneeID)
+	})
+	t.Run("DashboardTeammate", func(t *testing.T) {
+		team, err := database.GetOrCreateDashboardTeam(context.Background(), api.DB, userID)
+		assert.NoError(t, err)
+		_, err = database.GetDashboardTeamMemberCollection(api.DB).InsertOne(context.Background(), database.DashboardTeamMember{
+			TeamID:       team.ID,
+			UserID:       outsiderID,
+			Email:        "test_task_assign_outsider@gmail.com",
+			Role:         constants.DashboardTeamRoleMember,
+			InviteStatus: constants.DashboardTeamInviteStatusAccepted,
+		})
+		assert.NoError(t, err)
+
+		ServeRequest(t, authToken, "PATCH", url, bytes.NewBuffer([]byte(`{"assignee_id": "`+outsiderID.Hex()+`"}`)), http.StatusOK, api)
+		task, err := database.GetTask(context.Background(), api.DB, taskID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, outsiderID, task.AssigneeID)
+	})
+	t.Run("Unassign", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", url, bytes.NewBuffer([]byte(`{"assignee_id": ""}`)), http.StatusOK, api)
+		task, err := database.GetTask(context.Background(), api.DB, taskID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, primitive.NilObjectID, task.AssigneeID)
+	})
+}
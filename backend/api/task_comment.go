@@ -1,6 +1,7 @@
 package api
 
 import (
+	"github.com/franchizzle/task-manager/backend/constants"
 	"github.com/franchizzle/task-manager/backend/database"
 	"github.com/franchizzle/task-manager/backend/external"
 	"github.com/gin-gonic/gin"
@@ -19,23 +20,26 @@ func (api *API) TaskAddComment(c *gin.Context) {
 
 	userID := getUserIDFromContext(c)
 
-	task, err := database.GetTask(api.DB, taskID, userID)
+	task, err := database.GetTask(c.Request.Context(), api.DB, taskID, userID)
 	if err != nil {
-		c.JSON(404, gin.H{"detail": "task not found.", "taskId": taskID})
+		HandleAPIError(c, NewAPIError(ErrorCodeNotFound, "task not found.").WithMetadata("taskId", taskID))
 		return
 	}
 
 	var commentParams database.Comment
 	err = c.BindJSON(&commentParams)
 	if err != nil {
-		c.JSON(400, gin.H{"detail": "parameter missing or malformatted"})
+		HandleBindError(c, &commentParams, err, "parameter missing or malformatted")
 		return
 	}
 	// check if all fields are empty
-	if commentParams == (database.Comment{}) {
-		c.JSON(400, gin.H{"detail": "parameter missing"})
+	if commentParams.ExternalID == "" && commentParams.Body == "" && commentParams.User == (database.ExternalUser{}) && commentParams.CreatedAt == 0 {
+		HandleBadRequest(c, "parameter missing")
 		return
 	}
+	// reactions and read receipts are added through their own endpoints
+	commentParams.Reactions = nil
+	commentParams.ReadBy = nil
 
 	taskSourceResult, err := api.ExternalConfig.GetSourceResult(task.SourceID)
 	if err != nil {
@@ -44,11 +48,12 @@ func (api *API) TaskAddComment(c *gin.Context) {
 		return
 	}
 
-	if task.SourceID == external.TASK_SOURCE_ID_LINEAR {
+	// reactions and read receipts refer to comments by their external ID
+	if task.SourceID == external.TASK_SOURCE_ID_LINEAR || commentParams.ExternalID == "" {
 		commentParams.ExternalID = uuid.New().String()
 	}
 
-	err = taskSourceResult.Source.AddComment(api.DB, userID, task.SourceAccountID, commentParams, task)
+	err = taskSourceResult.Source.AddComment(c.Request.Context(), api.DB, userID, task.SourceAccountID, commentParams, task)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to update external task source")
 		Handle500(c)
@@ -65,6 +70,18 @@ func (api *API) TaskAddComment(c *gin.Context) {
 	updateTask := database.Task{
 		Comments: &comments,
 	}
-	api.UpdateTaskInDB(c, task, userID, &updateTask)
+	err = api.UpdateTaskInDBWithError(task, userID, &updateTask)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	// the comment is already saved, so failing to notify mentioned users shouldn't fail the request
+	err = api.notifyMentionedUsers(c.Request.Context(), userID, commentParams.Body, database.Notification{
+		Type:   constants.NotificationTypeTaskCommentMention,
+		TaskID: task.ID,
+	})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to notify mentioned users")
+	}
 	c.JSON(200, gin.H{})
 }
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"time"
+	"unicode"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// long enough for emoji built from several code points, like flags and skin tones
+const MAX_REACTION_EMOJI_LENGTH = 32
+
+type CommentReactionParams struct {
+	Emoji string `json:"emoji" binding:"required"`
+}
+
+type CommentReadParams struct {
+	IsRead *bool `json:"is_read" binding:"required"`
+}
+
+// TaskCommentReact toggles the user's reaction to a comment on their own task
+func (api *API) TaskCommentReact(c *gin.Context) {
+	var params CommentReactionParams
+	if !bindCommentReactionParams(c, &params) {
+		return
+	}
+	task, ok := api.getOwnTaskForComment(c)
+	if !ok {
+		return
+	}
+	api.updateTaskComment(c, task, func(comment *database.Comment, user *database.User) (bson.M, bson.M) {
+		return getCommentReactionUpdate(comment, params.Emoji, user)
+	})
+}
+
+// TaskCommentRead marks a comment on the user's own task as read or unread by them
+func (api *API) TaskCommentRead(c *gin.Context) {
+	var params CommentReadParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	task, ok := api.getOwnTaskForComment(c)
+	if !ok {
+		return
+	}
+	api.updateTaskComment(c, task, func(comment *database.Comment, user *database.User) (bson.M, bson.M) {
+		return getCommentReadUpdate(user, *params.IsRead, api.GetCurrentTime())
+	})
+}
+
+// ShareableTaskCommentReact toggles the user's reaction to a comment on a task shared with them.
+// Like commenting, it needs comment or edit permission
+func (api *API) ShareableTaskCommentReact(c *gin.Context) {
+	var params CommentReactionParams
+	if !bindCommentReactionParams(c, &params) {
+		return
+	}
+	task, permission := api.getSharedTaskFromParam(c, c.Param("task_id"))
+	if task == nil {
+		return
+	}
+	if permission != database.SharedPermissionComment && permission != database.SharedPermissionEdit {
+		HandleError(c, ErrorCodeForbidden, "not allowed to react to comments on this task")
+		return
+	}
+	api.updateTaskComment(c, task, func(comment *database.Comment, user *database.User) (bson.M, bson.M) {
+		return getCommentReactionUpdate(comment, params.Emoji, user)
+	})
+}
+
+// ShareableTaskCommentRead marks a comment on a task shared with the user as read or unread by them.
+// Anyone who can see the task can acknowledge its comments
+func (api *API) ShareableTaskCommentRead(c *gin.Context) {
+	var params CommentReadParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	task, _ := api.getSharedTaskFromParam(c, c.Param("task_id"))
+	if task == nil {
+		return
+	}
+	api.updateTaskComment(c, task, func(comment *database.Comment, user *database.User) (bson.M, bson.M) {
+		return getCommentReadUpdate(user, *params.IsRead, api.GetCurrentTime())
+	})
+}
+
+func bindCommentReactionParams(c *gin.Context, params *CommentReactionParams) bool {
+	err := c.BindJSON(params)
+	if err != nil {
+		HandleBindError(c, params, err, "invalid or missing parameter")
+		return false
+	}
+	if !isEmoji(params.Emoji) {
+		HandleBadRequest(c, "'emoji' must be a single emoji", "emoji")
+		return false
+	}
+	return true
+}
+
+// isEmoji is a loose check that rejects text, since emoji sequences can't be validated without a full emoji table
+func isEmoji(emoji string) bool {
+	if emoji == "" || len(emoji) > MAX_REACTION_EMOJI_LENGTH {
+		return false
+	}
+	for _, character := range emoji {
+		if character <= unicode.MaxASCII || unicode.IsSpace(character) || unicode.IsLetter(character) {
+			return false
+		}
+	}
+	return true
+}
+
+func (api *API) getOwnTaskForComment(c *gin.Context) (*database.Task, bool) {
+	taskID, err := primitive.ObjectIDFromHex(c.Param("task_id"))
+	if err != nil {
+		Handle404(c)
+		return nil, false
+	}
+	task, err := database.GetTask(c.Request.Context(), api.DB, taskID, getUserIDFromContext(c))
+	if err != nil {
+		HandleAPIError(c, NewAPIError(ErrorCodeNotFound, "task not found.").WithMetadata("taskId", taskID))
+		return nil, false
+	}
+	return task, true
+}
+
+// updateTaskComment applies the change to the comment in the URL on behalf of the logged in user and
+// responds with the updated comment. getUpdate is given the comment as it was loaded, and returns a
+// filter the comment must still match along with the update to apply to it
+func (api *API) updateTaskComment(c *gin.Context, task *database.Task, getUpdate func(comment *database.Comment, user *database.User) (bson.M, bson.M)) {
+	user, err := database.GetUser(c.Request.Context(), api.DB, getUserIDFromContext(c))
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	commentID := c.Param("comment_id")
+	comment := getTaskComment(task, commentID)
+	if comment == nil {
+		HandleAPIError(c, NewAPIError(ErrorCodeNotFound, "comment not found.").WithMetadata("commentId", commentID))
+		return
+	}
+	commentFilter, update := getUpdate(comment, user)
+	err = database.UpdateTaskComment(c.Request.Context(), api.DB, task.ID, commentID, commentFilter, update)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	// comments live on the task, which belongs to its owner
+	updatedTask, err := database.GetTask(c.Request.Context(), api.DB, task.ID, task.UserID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	comment = getTaskComment(updatedTask, commentID)
+	if comment == nil {
+		HandleAPIError(c, NewAPIError(ErrorCodeNotFound, "comment not found.").WithMetadata("commentId", commentID))
+		return
+	}
+	c.JSON(200, comment)
+}
+
+func getTaskComment(task *database.Task, commentID string) *database.Comment {
+	if task.Comments == nil {
+		return nil
+	}
+	for idx := range *task.Comments {
+		if (*task.Comments)[idx].ExternalID == commentID {
+			return &(*task.Comments)[idx]
+		}
+	}
+	return nil
+}
+
+// getCommentReactionUpdate adds the user's reaction with the emoji, or removes it if they'd already
+// reacted with it. Adding only matches while the reaction is missing, so a double submit adds it once
+func getCommentReactionUpdate(comment *database.Comment, emoji string, user *database.User) (bson.M, bson.M) {
+	for _, reaction := range comment.Reactions {
+		if reaction.Emoji == emoji && reaction.UserID == user.ID {
+			return bson.M{}, bson.M{"$pull": bson.M{"comments.$[comment].reactions": bson.M{"emoji": emoji, "user_id": user.ID}}}
+		}
+	}
+	return bson.M{"reactions": bson.M{"$not": bson.M{"$elemMatch": bson.M{"emoji": emoji, "user_id": user.ID}}}},
+		bson.M{"$push": bson.M{"comments.$[comment].reactions": database.CommentReaction{Emoji: emoji, UserID: user.ID, Name: user.Name}}}
+}
+
+// getCommentReadUpdate adds or removes the user's read receipt. Marking a comment read again keeps the first read time
+func getCommentReadUpdate(user *database.User, isRead bool, now time.Time) (bson.M, bson.M) {
+	if !isRead {
+		return bson.M{}, bson.M{"$pull": bson.M{"comments.$[comment].read_by": bson.M{"user_id": user.ID}}}
+	}
+	return bson.M{"read_by.user_id": bson.M{"$ne": user.ID}},
+		bson.M{"$push": bson.M{"comments.$[comment].read_by": database.CommentReadReceipt{UserID: user.ID, Name: user.Name, ReadAt: primitive.NewDateTimeFromTime(now)}}}
+}
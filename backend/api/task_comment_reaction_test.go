@@ -0,0 +1,144 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTaskCommentReactions(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+
+	authToken := login("test_task_comment_reactions@resonant-kelpie-404a42.netlify.app", "")
+	ownerID := getUserIDFromAuthToken(t, api.DB, authToken)
+	teammateAuthToken := login("test_task_comment_reactions_teammate@resonant-kelpie-404a42.netlify.app", "")
+	teammateID := getUserIDFromAuthToken(t, api.DB, teammateAuthToken)
+
+	createTask := func(permission *database.SharedPermission) string {
+		title := "shared task"
+		sharedAccess := database.SharedAccessPublic
+		mongoResult, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), &database.Task{
+			UserID:           ownerID,
+			Title:            &title,
+			SourceID:         external.TASK_SOURCE_ID_GT_TASK,
+			SharedUntil:      primitive.NewDateTimeFromTime(time.Now().Add(time.Hour)),
+			SharedAccess:     &sharedAccess,
+			SharedPermission: permission,
+			Comments:         &[]database.Comment{{ExternalID: "comment-1", Body: "ready for review"}},
+		})
+		assert.NoError(t, err)
+		return mongoResult.InsertedID.(primitive.ObjectID).Hex()
+	}
+	commentPermission := database.SharedPermissionComment
+	viewTaskID := createTask(nil)
+	commentTaskID := createTask(&commentPermission)
+	getComment := func(taskIDHex string) database.Comment {
+		taskID, _ := primitive.ObjectIDFromHex(taskIDHex)
+		task, err := database.GetTask(context.Background(), api.DB, taskID, ownerID)
+		assert.NoError(t, err)
+		return (*task.Comments)[0]
+	}
+	reactionBody := `{"emoji": "👍"}`
+
+	UnauthorizedTest(t, http.MethodPost, "/tasks/"+commentTaskID+"/comments/reactions/comment-1/", nil)
+	t.Run("InvalidEmoji", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPost, "/tasks/"+commentTaskID+"/comments/reactions/comment-1/", bytes.NewBuffer([]byte(`{"emoji": "lol"}`)), http.StatusBadRequest, api)
+	})
+	t.Run("CommentNotFound", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPost, "/tasks/"+commentTaskID+"/comments/reactions/not-a-comment/", bytes.NewBuffer([]byte(reactionBody)), http.StatusNotFound, api)
+	})
+	t.Run("NotOwnTask", func(t *testing.T) {
+		ServeRequest(t, teammateAuthToken, http.MethodPost, "/tasks/"+commentTaskID+"/comments/reactions/comment-1/", bytes.NewBuffer([]byte(reactionBody)), http.StatusNotFound, api)
+	})
+	t.Run("ReactSuccess", func(t *testing.T) {
+		response := ServeRequest(t, authToken, http.MethodPost, "/tasks/"+commentTaskID+"/comments/reactions/comment-1/", bytes.NewBuffer([]byte(reactionBody)), http.StatusOK, api)
+		var comment database.Comment
+		assert.NoError(t, json.Unmarshal(response, &comment))
+		assert.Equal(t, 1, len(comment.Reactions))
+		assert.Equal(t, "👍", comment.Reactions[0].Emoji)
+		assert.Equal(t, ownerID, comment.Reactions[0].UserID)
+	})
+	t.Run("SharedReactViewOnly", func(t *testing.T) {
+		ServeRequest(t, teammateAuthToken, http.MethodPost, "/shareable_tasks/"+viewTaskID+"/comments/reactions/comment-1/", bytes.NewBuffer([]byte(reactionBody)), http.StatusForbidden, api)
+	})
+	t.Run("SharedReactSuccess", func(t *testing.T) {
+		ServeRequest(t, teammateAuthToken, http.MethodPost, "/shareable_tasks/"+commentTaskID+"/comments/reactions/comment-1/", bytes.NewBuffer([]byte(reactionBody)), http.StatusOK, api)
+		comment := getComment(commentTaskID)
+		assert.Equal(t, 2, len(comment.Reactions))
+		assert.Equal(t, teammateID, comment.Reactions[1].UserID)
+	})
+	t.Run("ReactAgainRemoves", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPost, "/tasks/"+commentTaskID+"/comments/reactions/comment-1/", bytes.NewBuffer([]byte(reactionBody)), http.StatusOK, api)
+		comment := getComment(commentTaskID)
+		assert.Equal(t, 1, len(comment.Reactions))
+		assert.Equal(t, teammateID, comment.Reactions[0].UserID)
+	})
+
+	UnauthorizedTest(t, http.MethodPut, "/tasks/"+commentTaskID+"/comments/read/comment-1/", nil)
+	t.Run("ReadMissingParam", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPut, "/tasks/"+commentTaskID+"/comments/read/comment-1/", bytes.NewBuffer([]byte(`{}`)), http.StatusBadRequest, api)
+	})
+	t.Run("SharedReadViewOnly", func(t *testing.T) {
+		// acknowledging a comment only needs access to the task
+		ServeRequest(t, teammateAuthToken, http.MethodPut, "/shareable_tasks/"+viewTaskID+"/comments/read/comment-1/", bytes.NewBuffer([]byte(`{"is_read": true}`)), http.StatusOK, api)
+		comment := getComment(viewTaskID)
+		assert.Equal(t, 1, len(comment.ReadBy))
+		assert.Equal(t, teammateID, comment.ReadBy[0].UserID)
+	})
+	t.Run("ReadAndUnread", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPut, "/tasks/"+viewTaskID+"/comments/read/comment-1/", bytes.NewBuffer([]byte(`{"is_read": true}`)), http.StatusOK, api)
+		assert.Equal(t, 2, len(getComment(viewTaskID).ReadBy))
+		ServeRequest(t, authToken, http.MethodPut, "/tasks/"+viewTaskID+"/comments/read/comment-1/", bytes.NewBuffer([]byte(`{"is_read": false}`)), http.StatusOK, api)
+		comment := getComment(viewTaskID)
+		assert.Equal(t, 1, len(comment.ReadBy))
+		assert.Equal(t, teammateID, comment.ReadBy[0].UserID)
+	})
+}
+
+func TestIsEmoji(t *testing.T) {
+	assert.True(t, isEmoji("👍"))
+	assert.True(t, isEmoji("👍🏽"))
+	assert.True(t, isEmoji("🇺🇸"))
+	assert.False(t, isEmoji(""))
+	assert.False(t, isEmoji("ok"))
+	assert.False(t, isEmoji("👍 👍"))
+	assert.False(t, isEmoji("日本"))
+}
+
+func TestGetCommentReactionUpdate(t *testing.T) {
+	user := &database.User{ID: primitive.NewObjectID(), Name: "Jane"}
+	comment := &database.Comment{Reactions: []database.CommentReaction{{Emoji: "👍", UserID: primitive.NewObjectID()}}}
+
+	commentFilter, update := getCommentReactionUpdate(comment, "👍", user)
+	assert.Equal(t, bson.M{"reactions": bson.M{"$not": bson.M{"$elemMatch": bson.M{"emoji": "👍", "user_id": user.ID}}}}, commentFilter)
+	assert.Equal(t, bson.M{"$push": bson.M{"comments.$[comment].reactions": database.CommentReaction{Emoji: "👍", UserID: user.ID, Name: "Jane"}}}, update)
+
+	comment.Reactions = append(comment.Reactions, database.CommentReaction{Emoji: "👍", UserID: user.ID, Name: "Jane"})
+	commentFilter, update = getCommentReactionUpdate(comment, "👍", user)
+	assert.Equal(t, bson.M{}, commentFilter)
+	assert.Equal(t, bson.M{"$pull": bson.M{"comments.$[comment].reactions": bson.M{"emoji": "👍", "user_id": user.ID}}}, update)
+}
+
+func TestGetCommentReadUpdate(t *testing.T) {
+	user := &database.User{ID: primitive.NewObjectID(), Name: "Jane"}
+	now := time.Date(2023, time.January, 6, 9, 0, 0, 0, time.UTC)
+
+	// reading again doesn't match, which keeps the first read time
+	commentFilter, update := getCommentReadUpdate(user, true, now)
+	assert.Equal(t, bson.M{"read_by.user_id": bson.M{"$ne": user.ID}}, commentFilter)
+	assert.Equal(t, bson.M{"$push": bson.M{"comments.$[comment].read_by": database.CommentReadReceipt{UserID: user.ID, Name: "Jane", ReadAt: primitive.NewDateTimeFromTime(now)}}}, update)
+
+	commentFilter, update = getCommentReadUpdate(user, false, now)
+	assert.Equal(t, bson.M{}, commentFilter)
+	assert.Equal(t, bson.M{"$pull": bson.M{"comments.$[comment].read_by": bson.M{"user_id": user.ID}}}, update)
+}
@@ -19,7 +19,7 @@ type TaskCreateParams struct {
 	Title         string     `json:"title" binding:"required"`
 	Body          string     `json:"body"`
 	DueDate       *time.Time `json:"due_date"`
-	TimeDuration  *int       `json:"time_duration"`
+	TimeDuration  *int       `json:"time_duration" binding:"omitempty,min=0"`
 	IDTaskSection *string    `json:"id_task_section"`
 	ParentTaskID  *string    `json:"parent_task_id"`
 }
@@ -35,17 +35,17 @@ func (api *API) TaskCreate(c *gin.Context) {
 	var taskCreateParams TaskCreateParams
 	err = c.BindJSON(&taskCreateParams)
 	if err != nil {
-		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		HandleBindError(c, &taskCreateParams, err, "invalid or missing parameter")
 		return
 	}
 
 	userID := getUserIDFromContext(c)
 
-	IDTaskSection := constants.IDTaskSectionDefault
+	IDTaskSection := database.GetDefaultTaskSectionID(c.Request.Context(), api.DB, userID, sourceID)
 	if taskCreateParams.IDTaskSection != nil {
 		IDTaskSection, err = getValidTaskSection(*taskCreateParams.IDTaskSection, userID, api.DB)
 		if err != nil {
-			c.JSON(400, gin.H{"detail": "'id_task_section' is not a valid ID"})
+			HandleBadRequest(c, "'id_task_section' is not a valid ID", "id_task_section")
 			return
 		}
 	}
@@ -61,7 +61,7 @@ func (api *API) TaskCreate(c *gin.Context) {
 			}},
 		)
 		if err != nil || count <= 0 {
-			c.JSON(404, gin.H{"detail": "account ID not found"})
+			HandleError(c, ErrorCodeNotFound, "account ID not found")
 			return
 		}
 	} else {
@@ -69,10 +69,10 @@ func (api *API) TaskCreate(c *gin.Context) {
 		taskCreateParams.AccountID = external.GeneralTaskDefaultAccountID
 		var assignedUser *database.User
 		var tempTitle string
-		assignedUser, tempTitle, err = getValidExternalOwnerAssignedTask(api.DB, userID, taskCreateParams.Title)
+		assignedUser, tempTitle, err = getValidExternalOwnerAssignedTask(c.Request.Context(), api.DB, userID, taskCreateParams.Title)
 		if err == nil {
 			userID = assignedUser.ID
-			IDTaskSection = constants.IDTaskSectionDefault
+			IDTaskSection = database.GetDefaultTaskSectionID(c.Request.Context(), api.DB, userID, sourceID)
 			taskCreateParams.Title = tempTitle
 		}
 	}
@@ -87,7 +87,7 @@ func (api *API) TaskCreate(c *gin.Context) {
 	if taskCreateParams.ParentTaskID != nil {
 		parentID, err = getValidTask(*taskCreateParams.ParentTaskID, userID, api.DB)
 		if err != nil {
-			c.JSON(400, gin.H{"detail": "'parent_task_id' is not a valid ID"})
+			HandleBadRequest(c, "'parent_task_id' is not a valid ID", "parent_task_id")
 			return
 		}
 	}
@@ -100,9 +100,9 @@ func (api *API) TaskCreate(c *gin.Context) {
 		IDTaskSection:  IDTaskSection,
 		ParentTaskID:   parentID,
 	}
-	taskID, err := taskSourceResult.Source.CreateNewTask(api.DB, userID, taskCreateParams.AccountID, taskCreationObject)
+	taskID, err := taskSourceResult.Source.CreateNewTask(c.Request.Context(), api.DB, userID, taskCreateParams.AccountID, taskCreationObject)
 	if err != nil {
-		c.JSON(503, gin.H{"detail": "failed to create task"})
+		HandleError(c, ErrorCodeServiceUnavailable, "failed to create task")
 		return
 	}
 	// this database.Task is only used for IDTaskSection and ParentTaskID fields
@@ -112,9 +112,15 @@ func (api *API) TaskCreate(c *gin.Context) {
 		ParentTaskID:  parentID,
 	})
 	if err != nil {
-		c.JSON(500, gin.H{"detail": "failed to move task to front of folder"})
+		HandleError(c, ErrorCodeInternal, "failed to move task to front of folder")
 		return
 	}
+	api.recordTaskActivity(c.Request.Context(), []database.TaskActivity{{
+		UserID:   userID,
+		TaskID:   taskID,
+		Type:     constants.TaskActivityCreated,
+		NewValue: taskCreateParams.Title,
+	}})
 	c.JSON(200, gin.H{"task_id": taskID})
 }
 
@@ -124,7 +130,11 @@ func getValidTaskSection(taskSectionIDHex string, userID primitive.ObjectID, db
 		return primitive.NilObjectID, errors.New("malformatted task section")
 	}
 	taskSectionCollection := database.GetTaskSectionCollection(db)
-	count, err := taskSectionCollection.CountDocuments(context.Background(), bson.M{"$and": []bson.M{{"user_id": userID}, {"_id": IDTaskSection}}})
+	count, err := taskSectionCollection.CountDocuments(context.Background(), bson.M{"$and": []bson.M{
+		{"user_id": userID},
+		{"_id": IDTaskSection},
+		{"is_archived": bson.M{"$ne": true}},
+	}})
 	if (err != nil || count == int64(0)) &&
 		IDTaskSection != constants.IDTaskSectionDefault {
 		return primitive.NilObjectID, errors.New("task section ID not found")
@@ -37,7 +37,7 @@ func TestCreateTask(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"not found\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"not found\",\"code\":\"unauthorized\"}", string(body))
 	})
 	t.Run("UnsupportedSourceID", func(t *testing.T) {
 		request, _ := http.NewRequest(
@@ -50,7 +50,7 @@ func TestCreateTask(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"not found\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"not found\",\"code\":\"unauthorized\"}", string(body))
 	})
 	t.Run("MissingTitle", func(t *testing.T) {
 		request, _ := http.NewRequest(
@@ -63,7 +63,7 @@ func TestCreateTask(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"invalid or missing parameter\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"invalid or missing parameter\",\"code\":\"bad_request\"}", string(body))
 	})
 	t.Run("WrongAccountID", func(t *testing.T) {
 		// this currently isn't possible because only GT tasks are supported, but we should add this when it's possible
@@ -81,7 +81,7 @@ func TestCreateTask(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"'id_task_section' is not a valid ID\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"'id_task_section' is not a valid ID\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"id_task_section\"}]}", string(body))
 	})
 	t.Run("BadParentTaskID", func(t *testing.T) {
 		authToken = login("create_task_bad_task_id@resonant-kelpie-404a42.netlify.app", "")
@@ -96,7 +96,7 @@ func TestCreateTask(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"'parent_task_id' is not a valid ID\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"'parent_task_id' is not a valid ID\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"parent_task_id\"}]}", string(body))
 	})
 	t.Run("NoParentTaskInDB", func(t *testing.T) {
 		authToken = login("no_parent_task_in_db@resonant-kelpie-404a42.netlify.app", "")
@@ -112,7 +112,7 @@ func TestCreateTask(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"'parent_task_id' is not a valid ID\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"'parent_task_id' is not a valid ID\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"parent_task_id\"}]}", string(body))
 	})
 	t.Run("WrongUserIDForParent", func(t *testing.T) {
 		authToken = login("wrong_user_id_for_parent@resonant-kelpie-404a42.netlify.app", "")
@@ -133,7 +133,7 @@ func TestCreateTask(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"'parent_task_id' is not a valid ID\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"'parent_task_id' is not a valid ID\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"parent_task_id\"}]}", string(body))
 	})
 	t.Run("SuccessTitleOnly", func(t *testing.T) {
 		authToken = login("create_task_success_title_only@resonant-kelpie-404a42.netlify.app", "")
@@ -141,7 +141,7 @@ func TestCreateTask(t *testing.T) {
 
 		body := ServeRequest(t, authToken, "POST", "/tasks/create/gt_task/", bytes.NewBuffer([]byte(`{"title": "buy more dogecoin"}`)), http.StatusOK, nil)
 
-		tasks, err := database.GetActiveTasks(db, userID)
+		tasks, err := database.GetActiveTasks(context.Background(), db, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 6, len(*tasks))
 		task := (*tasks)[5]
@@ -161,7 +161,7 @@ func TestCreateTask(t *testing.T) {
 
 		ServeRequest(t, authToken, "POST", "/tasks/create/gt_task/", bytes.NewBuffer([]byte(`{"title": "buy more dogecoin AGAIN"}`)), http.StatusOK, nil)
 
-		tasks, err := database.GetActiveTasks(db, userID)
+		tasks, err := database.GetActiveTasks(context.Background(), db, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 7, len(*tasks))
 		task1 := (*tasks)[5]
@@ -183,7 +183,7 @@ func TestCreateTask(t *testing.T) {
 
 		body := ServeRequest(t, authToken, "POST", "/tasks/create/gt_task/", bytes.NewBuffer([]byte(`{"title": "<to john>buy more dogecoin"}`)), http.StatusOK, nil)
 
-		tasks, err := database.GetActiveTasks(db, johnUser.InsertedID.(primitive.ObjectID))
+		tasks, err := database.GetActiveTasks(context.Background(), db, johnUser.InsertedID.(primitive.ObjectID))
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(*tasks))
 		task := (*tasks)[0]
@@ -207,7 +207,7 @@ func TestCreateTask(t *testing.T) {
 
 		body := ServeRequest(t, authToken, "POST", "/tasks/create/gt_task/", bytes.NewBuffer([]byte(`{"title": "buy more dogecoin", "body": "seriously!", "due_date": "2020-12-09T16:09:53+00:00", "time_duration": 300, "id_task_section": "`+customSectionID.Hex()+`"}`)), http.StatusOK, nil)
 
-		tasks, err := database.GetActiveTasks(db, userID)
+		tasks, err := database.GetActiveTasks(context.Background(), db, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 6, len(*tasks))
 		task := (*tasks)[5]
@@ -231,7 +231,7 @@ func TestCreateTask(t *testing.T) {
 
 		body := ServeRequest(t, authToken, "POST", "/tasks/create/gt_task/", bytes.NewBuffer([]byte(`{"title": "buy more dogecoin", "body": "seriously!", "due_date": "2020-12-09T16:09:53+00:00", "time_duration": 300, "parent_task_id": "`+parentTaskID.Hex()+`"}`)), http.StatusOK, nil)
 
-		tasks, err := database.GetActiveTasks(db, userID)
+		tasks, err := database.GetActiveTasks(context.Background(), db, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 6, len(*tasks))
 		task := (*tasks)[5]
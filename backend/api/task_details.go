@@ -17,7 +17,7 @@ func (api *API) TaskDetail(c *gin.Context) {
 
 	userID := getUserIDFromContext(c)
 
-	task, err := database.GetTask(api.DB, taskID, userID)
+	task, err := database.GetTask(c.Request.Context(), api.DB, taskID, userID)
 	if err != nil {
 		Handle404(c)
 		return
@@ -70,7 +70,7 @@ func TestTaskDetail(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"not found\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"not found\",\"code\":\"unauthorized\"}", string(body))
 	})
 	t.Run("TaskDoesNotBelongToUser", func(t *testing.T) {
 		request, _ := http.NewRequest(
@@ -83,7 +83,7 @@ func TestTaskDetail(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"not found\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"not found\",\"code\":\"unauthorized\"}", string(body))
 	})
 	t.Run("Success", func(t *testing.T) {
 		request, _ := http.NewRequest(
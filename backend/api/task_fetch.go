@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -22,13 +23,13 @@ func (api *API) TasksFetch(c *gin.Context) {
 		return
 	}
 
-	currentTasks, err := database.GetActiveTasks(api.DB, userID.(primitive.ObjectID))
+	currentTasks, err := database.GetActiveTasks(c.Request.Context(), api.DB, userID.(primitive.ObjectID))
 	if err != nil {
 		Handle500(c)
 		return
 	}
 
-	fetchedTasks, failedFetchSources, err := api.fetchTasks(api.DB, userID)
+	fetchedTasks, failedFetchSources, err := api.fetchTasks(c.Request.Context(), api.DB, userID)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to fetch tasks")
 		Handle500(c)
@@ -43,12 +44,21 @@ func (api *API) TasksFetch(c *gin.Context) {
 		api.Logger.Error().Err(err).Msg("failed to update user last_refreshed")
 	}
 
-	err = api.adjustForCompletedTasks(api.DB, currentTasks, fetchedTasks, failedFetchSources)
+	err = api.adjustForCompletedTasks(c.Request.Context(), api.DB, currentTasks, fetchedTasks, failedFetchSources)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to adjust for completed tasks")
 		Handle500(c)
 		return
 	}
+	err = api.updateViewsLastFetched(c.Request.Context(), userID.(primitive.ObjectID), []string{
+		external.TASK_SERVICE_ID_GT,
+		external.TASK_SERVICE_ID_ATLASSIAN,
+		external.TASK_SERVICE_ID_LINEAR,
+		external.TASK_SERVICE_ID_SLACK,
+	}, failedFetchSources)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update views last fetched")
+	}
 
 	c.JSON(200, gin.H{})
 }
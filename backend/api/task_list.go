@@ -41,6 +41,9 @@ type MeetingPreparationParams struct {
 	DatetimeStart       string `json:"datetime_start"`
 	DatetimeEnd         string `json:"datetime_end"`
 	EventMovedOrDeleted bool   `json:"event_moved_or_deleted"`
+	// the colors the event is shown in on the calendar
+	ColorBackground string `json:"color_background,omitempty"`
+	ColorForeground string `json:"color_foreground,omitempty"`
 }
 
 type TaskResult struct {
@@ -59,6 +62,7 @@ type TaskResult struct {
 	IsDeleted                bool                         `json:"is_deleted"`
 	IsMeetingPreparationTask bool                         `json:"is_meeting_preparation_task"`
 	RecurringTaskTemplateID  primitive.ObjectID           `json:"recurring_task_template_id,omitempty"`
+	AssigneeID               *primitive.ObjectID          `json:"assignee_id,omitempty"`
 	ExternalStatus           *externalStatus              `json:"external_status,omitempty"`
 	AllStatuses              []*externalStatus            `json:"all_statuses,omitempty"`
 	ExternalPriority         *externalPriority            `json:"priority,omitempty"`
@@ -71,6 +75,7 @@ type TaskResult struct {
 	CreatedAt                string                       `json:"created_at,omitempty"`
 	UpdatedAt                string                       `json:"updated_at,omitempty"`
 	CompletedAt              primitive.DateTime           `json:"completed_at,omitempty"`
+	Labels                   []string                     `json:"labels,omitempty"`
 }
 
 type TaskSection struct {
@@ -95,18 +100,18 @@ type Recipient struct {
 
 type TaskGroupType string
 
-func (api *API) fetchTasks(db *mongo.Database, userID interface{}) (*[]*database.Task, map[string]bool, error) {
+func (api *API) fetchTasks(ctx context.Context, db *mongo.Database, userID interface{}) (*[]*database.Task, map[string]bool, error) {
 	var tokens []database.ExternalAPIToken
 	externalAPITokenCollection := database.GetExternalTokenCollection(db)
 	cursor, err := externalAPITokenCollection.Find(
-		context.Background(),
+		ctx,
 		bson.M{"user_id": userID},
 	)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to fetch api tokens")
 		return nil, nil, err
 	}
-	err = cursor.All(context.Background(), &tokens)
+	err = cursor.All(ctx, &tokens)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to iterate through api tokens")
 		return nil, nil, err
@@ -116,7 +121,11 @@ func (api *API) fetchTasks(db *mongo.Database, userID interface{}) (*[]*database
 		AccountID: external.GeneralTaskDefaultAccountID,
 		ServiceID: external.TASK_SERVICE_ID_GT,
 	})
+	return api.fetchTasksForTokens(ctx, db, userID.(primitive.ObjectID), tokens, false)
+}
 
+// fetchTasksForTokens loads the tasks from each token's sources, forceFullRefresh skips any partial refreshes
+func (api *API) fetchTasksForTokens(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, tokens []database.ExternalAPIToken, forceFullRefresh bool) (*[]*database.Task, map[string]bool, error) {
 	taskChannels := []chan external.TaskResult{}
 	// Loop through linked accounts and fetch relevant items
 	for _, token := range tokens {
@@ -129,10 +138,10 @@ func (api *API) fetchTasks(db *mongo.Database, userID interface{}) (*[]*database
 			var tasks = make(chan external.TaskResult)
 
 			// DO NOT COMMENT OUT BELOW LOGIC: CAN LEAD TO RATE LIMITING ISSUES
-			if token.ServiceID == external.TASK_SERVICE_ID_LINEAR && shouldPartialRefreshLinear(token) {
-				go api.getActiveLinearTasksFromDBForToken(token.UserID, token.AccountID, tasks)
+			if !forceFullRefresh && token.ServiceID == external.TASK_SERVICE_ID_LINEAR && shouldPartialRefreshLinear(token) {
+				go api.getActiveLinearTasksFromDBForToken(ctx, token.UserID, token.AccountID, tasks)
 			} else {
-				go taskSourceResult.Source.GetTasks(api.DB, userID.(primitive.ObjectID), token.AccountID, tasks)
+				go taskSourceResult.Source.GetTasks(ctx, api.DB, userID, token.AccountID, tasks)
 				// TODO update last full refresh after we fetch the tasks
 				err := api.updateLastFullRefreshTime(token)
 				if err != nil {
@@ -148,7 +157,7 @@ func (api *API) fetchTasks(db *mongo.Database, userID interface{}) (*[]*database
 	for _, taskChannel := range taskChannels {
 		taskResult := <-taskChannel
 		if taskResult.Error != nil {
-			isBadToken := external.CheckAndHandleBadToken(taskResult.Error, db, userID.(primitive.ObjectID), taskResult.AccountID, taskResult.SourceID)
+			isBadToken := external.CheckAndHandleBadToken(ctx, taskResult.Error, db, userID, taskResult.AccountID, taskResult.SourceID)
 			if !isBadToken {
 				api.Logger.Error().Err(taskResult.Error).Msg("failed to load task source")
 			}
@@ -161,6 +170,7 @@ func (api *API) fetchTasks(db *mongo.Database, userID interface{}) (*[]*database
 }
 
 func (api *API) adjustForCompletedTasks(
+	ctx context.Context,
 	db *mongo.Database,
 	currentTasks *[]database.Task,
 	fetchedTasks *[]*database.Task,
@@ -178,7 +188,7 @@ func (api *API) adjustForCompletedTasks(
 			continue
 		}
 		if !newTaskIDs[currentTask.ID] && !failedFetchSources[currentTask.SourceID] && !currentTask.IsMeetingPreparationTask {
-			err := database.MarkCompleteWithCollection(database.GetTaskCollection(db), currentTask.ID)
+			err := database.MarkCompleteWithCollection(ctx, database.GetTaskCollection(db), currentTask.ID)
 			if err != nil {
 				api.Logger.Error().Err(err).Msg("failed to complete task")
 				return err
@@ -189,6 +199,7 @@ func (api *API) adjustForCompletedTasks(
 }
 
 func (api *API) adjustForCompletedPullRequests(
+	ctx context.Context,
 	db *mongo.Database,
 	currentPullRequests *[]database.PullRequest,
 	fetchedPullRequests *[]*database.PullRequest,
@@ -202,54 +213,55 @@ func (api *API) adjustForCompletedPullRequests(
 	// There's a more efficient way to do this but this way is easy to understand
 	for _, currentPullRequest := range *currentPullRequests {
 		if !newPRIDs[currentPullRequest.ID] && !failedFetchSources[currentPullRequest.SourceID] {
-			err := database.MarkCompleteWithCollection(database.GetPullRequestCollection(db), currentPullRequest.ID)
+			err := database.MarkCompleteWithCollection(ctx, database.GetPullRequestCollection(db), currentPullRequest.ID)
 			if err != nil {
 				api.Logger.Error().Err(err).Msg("failed to complete pull request")
 				return err
 			}
+			api.recordPullRequestMerge(ctx, db, currentPullRequest)
 		}
 	}
 	return nil
 }
 
-func (api *API) updateOrderingIDsV2(db *mongo.Database, tasks *[]*TaskResult) error {
-	tasksCollection := database.GetTaskCollection(db)
-	orderingID := 1
-	for _, task := range *tasks {
-		task.IDOrdering = orderingID
-		orderingID += 1
-		res, err := tasksCollection.UpdateOne(
-			context.Background(),
-			bson.M{"_id": task.ID},
-			bson.M{"$set": bson.M{"id_ordering": task.IDOrdering}},
-		)
-		if err != nil {
-			api.Logger.Error().Err(err).Msg("failed to update task ordering ID")
-			return err
-		}
-		if res.MatchedCount != 1 {
-			api.Logger.Error().Interface("taskResult", task).Msgf("did not find task to update ordering ID (ID=%v)", task.ID)
-		}
+// recordPullRequestMerge stores when a pull request which is no longer open was merged, as it may
+// have been closed without merging instead. Failures are only logged, as the PR is still complete
+func (api *API) recordPullRequestMerge(ctx context.Context, db *mongo.Database, pullRequest database.PullRequest) {
+	if pullRequest.MergedAt != 0 || pullRequest.RepositoryName == "" || pullRequest.Number == 0 {
+		return
+	}
+	taskSourceResult, err := api.ExternalConfig.GetSourceResult(pullRequest.SourceID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to load external task source")
+		return
+	}
+	githubPR, ok := taskSourceResult.Source.(external.GithubPRSource)
+	if !ok {
+		return
+	}
+	mergedAt, err := githubPR.GetPullRequestMergedAt(ctx, db, pullRequest.UserID, pullRequest.SourceAccountID, &pullRequest)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch Github PR merge state")
+		return
+	}
+	if mergedAt == nil {
+		return
+	}
+	err = database.SetPullRequestMergedAt(ctx, db, pullRequest.ID, *mergedAt)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to record Github PR merge")
+	}
+}
 
-		subtaskOrderingID := 1
-		for _, subtask := range task.SubTasks {
-			subtask.IDOrdering = subtaskOrderingID
-			subtaskOrderingID += 1
-			res, err := tasksCollection.UpdateOne(
-				context.Background(),
-				bson.M{"_id": subtask.ID},
-				bson.M{"$set": bson.M{"id_ordering": subtask.IDOrdering}},
-			)
-			if err != nil {
-				api.Logger.Error().Err(err).Msg("failed to update subtask ordering ID")
-				return err
-			}
-			if res.MatchedCount != 1 {
-				api.Logger.Error().Interface("taskResult", subtask).Msgf("did not find subtask to update ordering ID (ID=%v)", subtask.ID)
-			}
+// setOrderingIDs numbers the sorted tasks and their subtasks by position. Their ordering keys decide
+// the order, so the positions don't need to be written back
+func setOrderingIDs(tasks []*TaskResult) {
+	for idx, task := range tasks {
+		task.IDOrdering = idx + 1
+		for subtaskIdx, subtask := range task.SubTasks {
+			subtask.IDOrdering = subtaskIdx + 1
 		}
 	}
-	return nil
 }
 
 func (api *API) taskListToTaskResultList(tasks *[]database.Task, userID primitive.ObjectID) []*TaskResult {
@@ -353,6 +365,14 @@ func (api *API) taskBaseToTaskResult(t *database.Task, userID primitive.ObjectID
 		UpdatedAt:                t.UpdatedAt.Time().UTC().Format(time.RFC3339),
 	}
 
+	if t.AssigneeID != primitive.NilObjectID {
+		assigneeID := t.AssigneeID
+		taskResult.AssigneeID = &assigneeID
+	}
+	if t.Labels != nil {
+		taskResult.Labels = *t.Labels
+	}
+
 	if t.Status != nil && *t.Status != (database.ExternalTaskStatus{}) {
 		taskResult.ExternalStatus = &externalStatus{
 			IDExternal: t.Status.ExternalID,
@@ -388,6 +408,8 @@ func (api *API) taskBaseToTaskResult(t *database.Task, userID primitive.ObjectID
 			DatetimeStart:       t.MeetingPreparationParams.DatetimeStart.Time().UTC().Format(time.RFC3339),
 			DatetimeEnd:         t.MeetingPreparationParams.DatetimeEnd.Time().UTC().Format(time.RFC3339),
 			EventMovedOrDeleted: t.MeetingPreparationParams.EventMovedOrDeleted,
+			ColorBackground:     t.MeetingPreparationParams.ColorBackground,
+			ColorForeground:     t.MeetingPreparationParams.ColorForeground,
 		}
 	}
 
@@ -426,8 +448,8 @@ func (api *API) taskBaseToTaskResult(t *database.Task, userID primitive.ObjectID
 	return taskResult
 }
 
-func (api *API) getSubtaskResults(taskID primitive.ObjectID, userID primitive.ObjectID) []*TaskResult {
-	subtasks, err := database.GetTasks(api.DB, userID, &[]bson.M{{"parent_task_id": taskID}}, nil)
+func (api *API) getSubtaskResults(ctx context.Context, taskID primitive.ObjectID, userID primitive.ObjectID) []*TaskResult {
+	subtasks, err := database.GetTasks(ctx, api.DB, userID, &[]bson.M{{"parent_task_id": taskID}}, nil)
 	if err == nil && len(*subtasks) > 0 {
 		subtaskResults := []*TaskResult{}
 		for _, subtask := range *subtasks {
@@ -441,7 +463,7 @@ func (api *API) getSubtaskResults(taskID primitive.ObjectID, userID primitive.Ob
 	}
 }
 
-func (api *API) getActiveLinearTasksFromDBForToken(userID primitive.ObjectID, accountID string, result chan<- external.TaskResult) {
+func (api *API) getActiveLinearTasksFromDBForToken(ctx context.Context, userID primitive.ObjectID, accountID string, result chan<- external.TaskResult) {
 	taskCollection := database.GetTaskCollection(api.DB)
 	additionalFilters := []bson.M{
 		{"source_account_id": accountID},
@@ -450,7 +472,7 @@ func (api *API) getActiveLinearTasksFromDBForToken(userID primitive.ObjectID, ac
 		{"is_deleted": bson.M{"$ne": true}},
 	}
 	var tasks []database.Task
-	err := database.FindWithCollection(taskCollection, userID, &additionalFilters, &tasks, nil)
+	err := database.FindWithCollection(ctx, taskCollection, userID, &additionalFilters, &tasks, nil)
 	if err != nil {
 		result <- external.TaskResult{Error: err}
 		return
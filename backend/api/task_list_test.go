@@ -185,7 +185,7 @@ func TestGetSubtaskResults(t *testing.T) {
 
 	userID := primitive.NewObjectID()
 	t.Run("NoSubtasks", func(t *testing.T) {
-		results := api.getSubtaskResults(primitive.NewObjectID(), userID)
+		results := api.getSubtaskResults(context.Background(), primitive.NewObjectID(), userID)
 		assert.Equal(t, 0, len(results))
 	})
 	t.Run("SubtaskSuccess", func(t *testing.T) {
@@ -202,7 +202,7 @@ func TestGetSubtaskResults(t *testing.T) {
 		})
 		assert.NoError(t, err)
 
-		results := api.getSubtaskResults(parentTaskID, userID)
+		results := api.getSubtaskResults(context.Background(), parentTaskID, userID)
 		assert.Equal(t, 1, len(results))
 		assert.Equal(t, insertResult.InsertedID.(primitive.ObjectID), results[0].ID)
 	})
@@ -229,7 +229,7 @@ func TestUpdateLastFullRefreshTime(t *testing.T) {
 		err := api.updateLastFullRefreshTime(token)
 		assert.NoError(t, err)
 
-		response := database.FindOneExternalWithCollection(collection, userID, "external")
+		response := database.FindOneExternalWithCollection(context.Background(), collection, userID, "external")
 		var tokenDB database.ExternalAPIToken
 		response.Decode(&tokenDB)
 		assert.Less(t, (15 * time.Minute), time.Now().Sub(tokenDB.LastFullRefreshTime.Time()))
@@ -277,7 +277,7 @@ func TestGetActiveLinearTasksFromDBForToken(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		var tasks = make(chan external.TaskResult)
-		go api.getActiveLinearTasksFromDBForToken(userID, accountID, tasks)
+		go api.getActiveLinearTasksFromDBForToken(context.Background(), userID, accountID, tasks)
 		taskResult := <-tasks
 		assert.Equal(t, 1, len(taskResult.Tasks))
 	})
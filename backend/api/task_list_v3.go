@@ -7,6 +7,7 @@ import (
 	"github.com/franchizzle/task-manager/backend/constants"
 
 	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/ordering"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -25,23 +26,24 @@ func (api *API) TasksListV3(c *gin.Context) {
 		return
 	}
 
-	activeTasks, err := database.GetActiveTasks(api.DB, userID)
+	activeTasks, err := database.GetActiveTasks(c.Request.Context(), api.DB, userID)
 	if err != nil {
 		Handle500(c)
 		return
 	}
-	completedTasks, err := database.GetCompletedTasks(api.DB, userID)
+	completedTasks, err := database.GetCompletedTasks(c.Request.Context(), api.DB, userID)
 	if err != nil {
 		Handle500(c)
 		return
 	}
-	deletedTasks, err := database.GetDeletedTasks(api.DB, userID)
+	deletedTasks, err := database.GetDeletedTasks(c.Request.Context(), api.DB, userID)
 	if err != nil {
 		Handle500(c)
 		return
 	}
 
 	allTasks, err := api.mergeTasksV3(
+		c.Request.Context(),
 		api.DB,
 		activeTasks,
 		completedTasks,
@@ -52,10 +54,11 @@ func (api *API) TasksListV3(c *gin.Context) {
 		Handle500(c)
 		return
 	}
-	c.JSON(200, allTasks)
+	jsonWithETag(c, allTasks)
 }
 
 func (api *API) mergeTasksV3(
+	ctx context.Context,
 	db *mongo.Database,
 	activeTasks *[]database.Task,
 	completedTasks *[]database.Task,
@@ -87,10 +90,10 @@ func (api *API) mergeTasksV3(
 	sort.SliceStable(*activeTasks, func(i, j int) bool {
 		a := (*activeTasks)[i]
 		b := (*activeTasks)[j]
-		return a.IDOrdering < b.IDOrdering
+		return ordering.Less(a.OrderingKey, a.IDOrdering, b.OrderingKey, b.IDOrdering)
 	})
 
-	sections, err := api.extractSectionTasksV3(db, userID, activeTasks)
+	sections, err := api.extractSectionTasksV3(ctx, db, userID, activeTasks)
 	for _, section := range sections {
 		section.TaskIDs = GetTaskIDs(section.Tasks)
 	}
@@ -115,11 +118,12 @@ func (api *API) mergeTasksV3(
 }
 
 func (api *API) extractSectionTasksV3(
+	ctx context.Context,
 	db *mongo.Database,
 	userID primitive.ObjectID,
 	fetchedTasks *[]database.Task,
 ) ([]*TaskSection, error) {
-	userSections, err := database.GetTaskSections(db, userID)
+	userSections, err := database.GetTaskSections(ctx, db, userID, false)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to fetch task sections")
 		return []*TaskSection{}, err
@@ -133,11 +137,13 @@ func (api *API) extractSectionTasksV3(
 		},
 	}
 	sort.SliceStable(*userSections, func(i, j int) bool {
-		// preserve existing sort if no ordering ID set
-		if (*userSections)[i].IDOrdering == 0 && (*userSections)[j].IDOrdering == 0 {
-			return (*userSections)[i].ID.Hex() < (*userSections)[j].ID.Hex()
+		a := (*userSections)[i]
+		b := (*userSections)[j]
+		// preserve existing sort if no ordering set
+		if a.OrderingKey == "" && b.OrderingKey == "" && a.IDOrdering == 0 && b.IDOrdering == 0 {
+			return a.ID.Hex() < b.ID.Hex()
 		}
-		return (*userSections)[i].IDOrdering < (*userSections)[j].IDOrdering
+		return ordering.Less(a.OrderingKey, a.IDOrdering, b.OrderingKey, b.IDOrdering)
 	})
 	for _, userSection := range *userSections {
 		resultSections = append(resultSections, &TaskSection{
@@ -172,10 +178,7 @@ func (api *API) extractSectionTasksV3(
 		}
 	}
 	for _, resultSection := range resultSections {
-		err := api.updateOrderingIDsV2(db, &resultSection.Tasks)
-		if err != nil {
-			api.Logger.Error().Err(err).Msg("failed to update ordering ids")
-		}
+		setOrderingIDs(resultSection.Tasks)
 	}
 	return resultSections, nil
 }
@@ -2,14 +2,17 @@ package api
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	"github.com/franchizzle/task-manager/backend/constants"
 	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/ordering"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type TaskSourceV4 struct {
@@ -17,15 +20,24 @@ type TaskSourceV4 struct {
 	Logo string `json:"logo"`
 }
 
+type JIRAParamsResult struct {
+	Sprint           *database.JIRASprint      `json:"sprint,omitempty"`
+	AvailableSprints []database.JIRASprint     `json:"available_sprints"`
+	Epic             *database.JIRAEpic        `json:"epic,omitempty"`
+	Transitions      []database.JIRATransition `json:"transitions"`
+}
+
 type TaskResultV4 struct {
 	ID                       primitive.ObjectID           `json:"id"`
 	IDOrdering               int                          `json:"id_ordering"`
+	OrderingKey              string                       `json:"ordering_key,omitempty"`
 	IDFolder                 string                       `json:"id_folder,omitempty"`
 	IDParent                 string                       `json:"id_parent,omitempty"`
 	Source                   TaskSourceV4                 `json:"source"`
 	Deeplink                 string                       `json:"deeplink"`
 	Title                    string                       `json:"title"`
 	Body                     string                       `json:"body"`
+	BodyHTML                 string                       `json:"body_html,omitempty"`
 	DueDate                  string                       `json:"due_date"`
 	PriorityNormalized       float64                      `json:"priority_normalized"`
 	IsDone                   bool                         `json:"is_done"`
@@ -41,12 +53,17 @@ type TaskResultV4 struct {
 	SubTaskIDs               []primitive.ObjectID         `json:"subtask_ids,omitempty"`
 	NUXNumber                int                          `json:"id_nux_number,omitempty"`
 	LinearCycle              *database.LinearCycle        `json:"linear_cycle,omitempty"`
+	LinearProject            *database.LinearProject      `json:"linear_project,omitempty"`
+	LinearEstimate           *float64                     `json:"linear_estimate,omitempty"`
+	JIRAParams               *JIRAParamsResult            `json:"jira_params,omitempty"`
 	CreatedAt                string                       `json:"created_at,omitempty"`
 	UpdatedAt                string                       `json:"updated_at,omitempty"`
 	CompletedAt              string                       `json:"completed_at,omitempty"`
 	DeletedAt                string                       `json:"deleted_at,omitempty"`
 	SharedAccess             string                       `json:"shared_access,omitempty"`
+	SharedPermission         string                       `json:"shared_permission,omitempty"`
 	SharedUntil              string                       `json:"shared_until,omitempty"`
+	Labels                   []string                     `json:"labels,omitempty"`
 }
 
 func (api *API) TasksListV4(c *gin.Context) {
@@ -60,17 +77,32 @@ func (api *API) TasksListV4(c *gin.Context) {
 		return
 	}
 
-	activeTasks, err := database.GetActiveTasks(api.DB, userID)
+	pagination, err := getPagination(c)
+	if err != nil {
+		HandleBadRequest(c, err.Error())
+		return
+	}
+	fieldsOptions, err := getFieldsFindOptions(c, database.Task{}, taskRequiredFields)
+	if err != nil {
+		HandleBadRequest(c, err.Error())
+		return
+	}
+	if pagination != nil {
+		api.tasksPageV4(c, userID, *pagination, fieldsOptions)
+		return
+	}
+
+	activeTasks, err := database.GetActiveTasks(c.Request.Context(), api.DB, userID, fieldsOptions)
 	if err != nil {
 		Handle500(c)
 		return
 	}
-	completedTasks, err := database.GetCompletedTasks(api.DB, userID)
+	completedTasks, err := database.GetCompletedTasks(c.Request.Context(), api.DB, userID, fieldsOptions)
 	if err != nil {
 		Handle500(c)
 		return
 	}
-	deletedTasks, err := database.GetDeletedTasks(api.DB, userID)
+	deletedTasks, err := database.GetDeletedTasks(c.Request.Context(), api.DB, userID, fieldsOptions)
 	if err != nil {
 		Handle500(c)
 		return
@@ -95,7 +127,41 @@ func (api *API) TasksListV4(c *gin.Context) {
 			allTasksWithoutMeetingPreparation = append(allTasksWithoutMeetingPreparation, task)
 		}
 	}
-	c.JSON(200, allTasksWithoutMeetingPreparation)
+	jsonWithETag(c, allTasksWithoutMeetingPreparation)
+}
+
+// tasksPageV4 pages through top level tasks, returning each task's subtasks alongside it
+func (api *API) tasksPageV4(c *gin.Context, userID primitive.ObjectID, pagination database.Pagination, fieldsOptions *options.FindOptions) {
+	parentTasks, nextCursor, err := database.FindPageWithCollection[database.Task](
+		c.Request.Context(),
+		database.GetTaskCollection(api.DB),
+		userID,
+		&[]bson.M{
+			{"parent_task_id": bson.M{"$exists": false}},
+			{"is_meeting_preparation_task": bson.M{"$ne": true}},
+		},
+		pagination,
+		fieldsOptions,
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch tasks page")
+		Handle500(c)
+		return
+	}
+	parentTaskIDs := []primitive.ObjectID{}
+	for _, task := range parentTasks {
+		parentTaskIDs = append(parentTaskIDs, task.ID)
+	}
+	subtasks, err := database.GetTasks(c.Request.Context(), api.DB, userID, &[]bson.M{{"parent_task_id": bson.M{"$in": parentTaskIDs}}}, fieldsOptions)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	tasks := append(parentTasks, *subtasks...)
+	jsonWithETag(c, PaginatedResult[*TaskResultV4]{
+		Results:    api.taskListToTaskResultListV4(&tasks),
+		NextCursor: nextCursor,
+	})
 }
 
 func (api *API) mergeTasksV4(
@@ -109,9 +175,47 @@ func (api *API) mergeTasksV4(
 	allTasks = append(allTasks, *activeTasks...)
 	allTasks = append(allTasks, *completedTasks...)
 	allTasks = append(allTasks, *deletedTasks...)
+	renumberTasksV4(allTasks)
 	return api.taskListToTaskResultListV4(&allTasks), nil
 }
 
+// taskSiblingsV4 groups tasks the way moving a task picks its siblings
+type taskSiblingsV4 struct {
+	parentTaskID  primitive.ObjectID
+	taskSectionID primitive.ObjectID
+	isCompleted   bool
+	isDeleted     bool
+}
+
+// renumberTasksV4 sets each task's id_ordering to its place among its siblings. Moves only write to
+// the moved task, so the stored id_ordering goes stale; the order comes from the ordering keys.
+// Pages can't be renumbered without every sibling, so paginated clients sort by ordering_key instead
+func renumberTasksV4(tasks []database.Task) {
+	orderedIndexes := make([]int, len(tasks))
+	for idx := range tasks {
+		orderedIndexes[idx] = idx
+	}
+	sort.SliceStable(orderedIndexes, func(i, j int) bool {
+		a := tasks[orderedIndexes[i]]
+		b := tasks[orderedIndexes[j]]
+		return ordering.Less(a.OrderingKey, a.IDOrdering, b.OrderingKey, b.IDOrdering)
+	})
+	positions := map[taskSiblingsV4]int{}
+	for _, idx := range orderedIndexes {
+		task := &tasks[idx]
+		siblings := taskSiblingsV4{
+			parentTaskID: task.ParentTaskID,
+			isDeleted:    task.IsDeleted != nil && *task.IsDeleted,
+		}
+		if task.ParentTaskID == primitive.NilObjectID {
+			siblings.taskSectionID = task.IDTaskSection
+			siblings.isCompleted = task.IsCompleted != nil && *task.IsCompleted
+		}
+		positions[siblings]++
+		task.IDOrdering = positions[siblings]
+	}
+}
+
 // shares a lot of duplicate code with taskListToTaskResultList
 // TODO: remove taskListToTaskResultList when frontend switches to new endpoint
 func (api *API) taskListToTaskResultListV4(tasks *[]database.Task) []*TaskResultV4 {
@@ -209,6 +313,7 @@ func (api *API) taskToTaskResultV4(t *database.Task) *TaskResultV4 {
 	taskResult := &TaskResultV4{
 		ID:                 t.ID,
 		IDOrdering:         t.IDOrdering,
+		OrderingKey:        t.OrderingKey,
 		IDFolder:           t.IDTaskSection.Hex(),
 		Source:             taskSource,
 		Deeplink:           t.Deeplink,
@@ -227,6 +332,12 @@ func (api *API) taskToTaskResultV4(t *database.Task) *TaskResultV4 {
 		SharedUntil:        t.SharedUntil.Time().UTC().Format(time.RFC3339),
 		SharedAccess:       sharedAccess,
 	}
+	if t.SharedPermission != nil {
+		taskResult.SharedPermission = getSharedPermissionString(*t.SharedPermission)
+	}
+	if t.Labels != nil {
+		taskResult.Labels = *t.Labels
+	}
 
 	if t.ParentTaskID != primitive.NilObjectID {
 		taskResult.IDParent = t.ParentTaskID.Hex()
@@ -269,6 +380,8 @@ func (api *API) taskToTaskResultV4(t *database.Task) *TaskResultV4 {
 			DatetimeStart:       t.MeetingPreparationParams.DatetimeStart.Time().UTC().Format(time.RFC3339),
 			DatetimeEnd:         t.MeetingPreparationParams.DatetimeEnd.Time().UTC().Format(time.RFC3339),
 			EventMovedOrDeleted: t.MeetingPreparationParams.EventMovedOrDeleted,
+			ColorBackground:     t.MeetingPreparationParams.ColorBackground,
+			ColorForeground:     t.MeetingPreparationParams.ColorForeground,
 		}
 	}
 
@@ -303,6 +416,25 @@ func (api *API) taskToTaskResultV4(t *database.Task) *TaskResultV4 {
 	if t.LinearCycle.ID != "" {
 		taskResult.LinearCycle = &t.LinearCycle
 	}
+	if t.LinearProject.ID != "" {
+		taskResult.LinearProject = &t.LinearProject
+	}
+	taskResult.LinearEstimate = t.LinearEstimate
+
+	if t.JIRATaskParams != nil {
+		taskResult.JIRAParams = &JIRAParamsResult{
+			Sprint:           t.JIRATaskParams.Sprint,
+			AvailableSprints: t.JIRATaskParams.AvailableSprints,
+			Epic:             t.JIRATaskParams.Epic,
+			Transitions:      t.JIRATaskParams.Transitions,
+		}
+		if taskResult.JIRAParams.AvailableSprints == nil {
+			taskResult.JIRAParams.AvailableSprints = []database.JIRASprint{}
+		}
+		if taskResult.JIRAParams.Transitions == nil {
+			taskResult.JIRAParams.Transitions = []database.JIRATransition{}
+		}
+	}
 
 	return taskResult
 }
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTasksListV4Ordering(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+
+	authToken := login("test_tasks_list_v4_ordering@resonant-kelpie-404a42.netlify.app", "")
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	notCompleted := false
+	taskIDs := []primitive.ObjectID{}
+	for idx, title := range []string{"first", "second", "third"} {
+		title := title
+		result, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), database.Task{
+			UserID:        userID,
+			Title:         &title,
+			SourceID:      external.TASK_SOURCE_ID_GT_TASK,
+			IsCompleted:   &notCompleted,
+			IDOrdering:    idx + 1,
+			IDTaskSection: constants.IDTaskSectionDefault,
+		})
+		assert.NoError(t, err)
+		taskIDs = append(taskIDs, result.InsertedID.(primitive.ObjectID))
+	}
+
+	ServeRequest(t, authToken, http.MethodPatch, "/tasks/modify/"+taskIDs[2].Hex()+"/", bytes.NewBuffer([]byte(`{"id_ordering": 1}`)), http.StatusOK, api)
+
+	body := ServeRequest(t, authToken, http.MethodGet, "/tasks/v4/", nil, http.StatusOK, api)
+	var results []TaskResultV4
+	assert.NoError(t, json.Unmarshal(body, &results))
+	idOrderings := map[primitive.ObjectID]int{}
+	for _, result := range results {
+		idOrderings[result.ID] = result.IDOrdering
+		assert.NotEqual(t, "", result.OrderingKey)
+	}
+	assert.Equal(t, 2, idOrderings[taskIDs[0]])
+	assert.Equal(t, 3, idOrderings[taskIDs[1]])
+	assert.Equal(t, 1, idOrderings[taskIDs[2]])
+}
+
+func TestRenumberTasksV4(t *testing.T) {
+	completed := true
+	sectionID := primitive.NewObjectID()
+	parentTaskID := primitive.NewObjectID()
+	tasks := []database.Task{
+		{OrderingKey: "a2", IDOrdering: 1, IDTaskSection: sectionID},
+		// written by a move, so its id_ordering is stale
+		{OrderingKey: "a0", IDOrdering: 1, IDTaskSection: sectionID},
+		{OrderingKey: "a1", IDOrdering: 2, IDTaskSection: sectionID},
+		{OrderingKey: "a1", IDOrdering: 7, IDTaskSection: sectionID, IsCompleted: &completed},
+		{OrderingKey: "a3", IDOrdering: 4, ParentTaskID: parentTaskID},
+		{IDOrdering: 5, ParentTaskID: parentTaskID},
+	}
+	renumberTasksV4(tasks)
+	idOrderings := []int{}
+	for _, task := range tasks {
+		idOrderings = append(idOrderings, task.IDOrdering)
+	}
+	// the response keeps its order, only the numbers change
+	assert.Equal(t, []int{3, 1, 2, 1, 2, 1}, idOrderings)
+}
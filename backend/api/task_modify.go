@@ -10,11 +10,9 @@ import (
 	"github.com/franchizzle/task-manager/backend/constants"
 	"github.com/franchizzle/task-manager/backend/database"
 	"github.com/franchizzle/task-manager/backend/external"
-	"github.com/franchizzle/task-manager/backend/logging"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type TaskChangeable struct {
@@ -26,26 +24,30 @@ type TaskChangeable struct {
 	// Used to cache the current status before marking the task as done
 	PreviousStatus          *database.ExternalTaskStatus `json:"previous_status,omitempty" bson:"previous_status,omitempty"`
 	CompletedStatus         *database.ExternalTaskStatus `json:"completed_status,omitempty" bson:"completed_status,omitempty"`
-	RecurringTaskTemplateID *string                      `json:"recurring_task_template_id,omitempty" bson:"recurring_task_template_id,omitempty"`
+	RecurringTaskTemplateID *string                      `json:"recurring_task_template_id,omitempty" bson:"recurring_task_template_id,omitempty" binding:"omitempty,objectid"`
+	// 0 moves the issue back to the backlog
+	JIRASprintID *int `json:"jira_sprint_id,omitempty" bson:"jira_sprint_id,omitempty"`
 }
 
 type TaskItemChangeableFields struct {
-	Task           TaskChangeable     `json:"task,omitempty" bson:"task,omitempty"`
-	Title          *string            `json:"title,omitempty" bson:"title,omitempty"`
-	Body           *string            `json:"body,omitempty" bson:"body,omitempty"`
-	DueDate        *string            `json:"due_date,omitempty" bson:"due_date,omitempty"`
-	TimeAllocation *int64             `json:"time_duration,omitempty" bson:"time_allocated,omitempty"`
-	IsCompleted    *bool              `json:"is_completed,omitempty" bson:"is_completed,omitempty"`
-	CompletedAt    primitive.DateTime `json:"completed_at,omitempty" bson:"completed_at"`
-	IsDeleted      *bool              `json:"is_deleted,omitempty" bson:"is_deleted,omitempty"`
-	DeletedAt      primitive.DateTime `json:"deleted_at,omitempty" bson:"deleted_at"`
-	SharedAccess   *string            `json:"shared_access,omitempty" bson:"shared_access,omitempty"`
-	SharedUntil    primitive.DateTime `json:"shared_until,omitempty" bson:"shared_until,omitempty"`
+	Task             TaskChangeable     `json:"task,omitempty" bson:"task,omitempty"`
+	Title            *string            `json:"title,omitempty" bson:"title,omitempty" binding:"omitempty,min=1"`
+	Body             *string            `json:"body,omitempty" bson:"body,omitempty"`
+	DueDate          *string            `json:"due_date,omitempty" bson:"due_date,omitempty"`
+	TimeAllocation   *int64             `json:"time_duration,omitempty" bson:"time_allocated,omitempty" binding:"omitempty,min=0"`
+	IsCompleted      *bool              `json:"is_completed,omitempty" bson:"is_completed,omitempty"`
+	CompletedAt      primitive.DateTime `json:"completed_at,omitempty" bson:"completed_at"`
+	IsDeleted        *bool              `json:"is_deleted,omitempty" bson:"is_deleted,omitempty"`
+	DeletedAt        primitive.DateTime `json:"deleted_at,omitempty" bson:"deleted_at"`
+	SharedAccess     *string            `json:"shared_access,omitempty" bson:"shared_access,omitempty" binding:"omitempty,oneof=public domain"`
+	SharedPermission *string            `json:"shared_permission,omitempty" bson:"shared_permission,omitempty" binding:"omitempty,oneof=view comment edit"`
+	SharedUntil      primitive.DateTime `json:"shared_until,omitempty" bson:"shared_until,omitempty"`
+	Labels           *[]string          `json:"labels,omitempty" bson:"labels,omitempty"`
 }
 
 type TaskModifyParams struct {
 	IDOrdering    *int    `json:"id_ordering"`
-	IDTaskSection *string `json:"id_task_section"`
+	IDTaskSection *string `json:"id_task_section" binding:"omitempty,objectid"`
 	TaskItemChangeableFields
 }
 
@@ -61,29 +63,21 @@ func (api *API) TaskModify(c *gin.Context) {
 	var modifyParams TaskModifyParams
 	err = c.BindJSON(&modifyParams)
 	if err != nil {
-		c.JSON(400, gin.H{"detail": "parameter missing or malformatted"})
+		HandleBindError(c, &modifyParams, err, "parameter missing or malformatted")
 		return
 	}
 
-	if modifyParams.IDTaskSection != nil {
-		_, err = primitive.ObjectIDFromHex(*modifyParams.IDTaskSection)
-		if err != nil {
-			c.JSON(400, gin.H{"detail": "'id_task_section' is not a valid ID"})
-			return
-		}
-	}
-
 	userID := getUserIDFromContext(c)
 
-	task, err := database.GetTask(api.DB, taskID, userID)
+	task, err := database.GetTask(c.Request.Context(), api.DB, taskID, userID)
 	if err != nil {
-		c.JSON(404, gin.H{"detail": "task not found.", "taskId": taskID})
+		HandleAPIError(c, NewAPIError(ErrorCodeNotFound, "task not found.").WithMetadata("taskId", taskID))
 		return
 	}
 
 	// check if all fields are empty
 	if modifyParams == (TaskModifyParams{}) {
-		c.JSON(400, gin.H{"detail": "task changes missing"})
+		HandleBadRequest(c, "task changes missing")
 		return
 	}
 
@@ -101,20 +95,11 @@ func (api *API) TaskModify(c *gin.Context) {
 
 	var dueDate *primitive.DateTime
 	if modifyParams.TaskItemChangeableFields.DueDate != nil {
-		yearMonthDayDate, yearMonthDayErr := time.Parse(constants.YEAR_MONTH_DAY_FORMAT, *modifyParams.TaskItemChangeableFields.DueDate)
-		rfcDate, rfcErr := time.Parse(time.RFC3339, *modifyParams.TaskItemChangeableFields.DueDate)
-
-		if yearMonthDayErr != nil && rfcErr != nil {
-			c.JSON(400, gin.H{"detail": "due_date is not a valid date"})
+		dueDate, err = parseTaskDueDate(*modifyParams.TaskItemChangeableFields.DueDate)
+		if err != nil {
+			HandleBadRequest(c, "due_date is not a valid date", "due_date")
 			return
 		}
-		if yearMonthDayErr == nil {
-			result := primitive.NewDateTimeFromTime(yearMonthDayDate)
-			dueDate = &result
-		} else {
-			result := primitive.NewDateTimeFromTime(rfcDate)
-			dueDate = &result
-		}
 	}
 	if modifyParams.TaskItemChangeableFields != (TaskItemChangeableFields{}) {
 		updateTask := database.Task{
@@ -134,6 +119,7 @@ func (api *API) TaskModify(c *gin.Context) {
 			Status:             modifyParams.TaskItemChangeableFields.Task.Status,
 			PreviousStatus:     modifyParams.TaskItemChangeableFields.Task.PreviousStatus,
 			CompletedStatus:    modifyParams.TaskItemChangeableFields.Task.CompletedStatus,
+			Labels:             modifyParams.TaskItemChangeableFields.Labels,
 		}
 		if dueDate != nil {
 			updateTask.DueDate = dueDate
@@ -147,25 +133,43 @@ func (api *API) TaskModify(c *gin.Context) {
 			}
 			updateTask.RecurringTaskTemplateID = recurring_task_template_id
 		}
+		if modifyParams.TaskItemChangeableFields.Task.JIRASprintID != nil {
+			updateTask.JIRATaskParams = getJIRATaskParamsWithSprint(task, *modifyParams.TaskItemChangeableFields.Task.JIRASprintID)
+		}
 
-		if task.SourceID != external.TASK_SOURCE_ID_GT_TASK && (modifyParams.TaskItemChangeableFields.SharedUntil != 0 || modifyParams.TaskItemChangeableFields.SharedAccess != nil) {
-			c.JSON(400, gin.H{"detail": "only General Task tasks can be shared"})
+		if task.SourceID != external.TASK_SOURCE_ID_GT_TASK && (modifyParams.TaskItemChangeableFields.SharedUntil != 0 || modifyParams.TaskItemChangeableFields.SharedAccess != nil || modifyParams.TaskItemChangeableFields.SharedPermission != nil) {
+			HandleBadRequest(c, "only General Task tasks can be shared")
 			return
 		}
 		if modifyParams.TaskItemChangeableFields.SharedAccess != nil {
 			if *modifyParams.TaskItemChangeableFields.SharedAccess == constants.StringSharedAccessPublic {
 				sharedAccessPublic := database.SharedAccessPublic
 				updateTask.SharedAccess = &sharedAccessPublic
-			} else if *modifyParams.TaskItemChangeableFields.SharedAccess == constants.StringSharedAccessDomain {
+			} else {
 				sharedAccessDomain := database.SharedAccessDomain
 				updateTask.SharedAccess = &sharedAccessDomain
-			} else {
-				c.JSON(400, gin.H{"detail": "invalid shared access token"})
+			}
+		}
+		if modifyParams.TaskItemChangeableFields.SharedPermission != nil {
+			sharedPermission, _ := getSharedPermission(*modifyParams.TaskItemChangeableFields.SharedPermission)
+			updateTask.SharedPermission = &sharedPermission
+		}
+		if modifyParams.TaskItemChangeableFields.SharedAccess != nil || modifyParams.TaskItemChangeableFields.SharedUntil != 0 {
+			sharedAccess := task.SharedAccess
+			if updateTask.SharedAccess != nil {
+				sharedAccess = updateTask.SharedAccess
+			}
+			sharedUntil := task.SharedUntil
+			if updateTask.SharedUntil != 0 {
+				sharedUntil = updateTask.SharedUntil
+			}
+			// tasks without shared_access aren't shared, whatever shared_until is
+			if sharedAccess != nil && !api.checkSharingPolicy(c, userID, *sharedAccess, sharedUntil.Time()) {
 				return
 			}
 		}
 
-		err = taskSourceResult.Source.ModifyTask(api.DB, userID, task.SourceAccountID, task.IDExternal, &updateTask, task)
+		err = taskSourceResult.Source.ModifyTask(c.Request.Context(), api.DB, userID, task.SourceAccountID, task.IDExternal, &updateTask, task)
 		if err != nil {
 			api.Logger.Error().Err(err).Msg("failed to update external task source")
 			Handle500(c)
@@ -175,7 +179,7 @@ func (api *API) TaskModify(c *gin.Context) {
 		if modifyParams.TaskItemChangeableFields.Title != nil {
 			var assignedUser *database.User
 			var tempTitle string
-			assignedUser, tempTitle, err = getValidExternalOwnerAssignedTask(api.DB, userID, *(modifyParams.TaskItemChangeableFields.Title))
+			assignedUser, tempTitle, err = getValidExternalOwnerAssignedTask(c.Request.Context(), api.DB, userID, *(modifyParams.TaskItemChangeableFields.Title))
 			if err == nil {
 				updateTask.UserID = assignedUser.ID
 				updateTask.IDTaskSection = constants.IDTaskSectionDefault
@@ -183,6 +187,9 @@ func (api *API) TaskModify(c *gin.Context) {
 			}
 		}
 		api.UpdateTaskInDB(c, task, userID, &updateTask)
+		if updateTask.SharedUntil.Time().After(time.Now()) {
+			api.recordAuditEvent(c, userID, database.AuditLog{EventType: constants.AuditEventSharedLinkCreated, ResourceID: task.ID})
+		}
 	}
 
 	// handle reorder task
@@ -191,17 +198,38 @@ func (api *API) TaskModify(c *gin.Context) {
 		if err != nil {
 			return
 		}
+		reorderActivity := database.TaskActivity{UserID: userID, TaskID: taskID, Type: constants.TaskActivityReordered}
+		if modifyParams.IDTaskSection != nil && *modifyParams.IDTaskSection != task.IDTaskSection.Hex() {
+			reorderActivity.OldValue = task.IDTaskSection.Hex()
+			reorderActivity.NewValue = *modifyParams.IDTaskSection
+		}
+		api.recordTaskActivity(c.Request.Context(), []database.TaskActivity{reorderActivity})
 	}
 
 	c.JSON(200, gin.H{})
 }
 
+// parseTaskDueDate accepts either a 2006-01-02 date or an RFC 3339 datetime
+func parseTaskDueDate(dueDate string) (*primitive.DateTime, error) {
+	yearMonthDayDate, err := time.Parse(constants.YEAR_MONTH_DAY_FORMAT, dueDate)
+	if err == nil {
+		result := primitive.NewDateTimeFromTime(yearMonthDayDate)
+		return &result, nil
+	}
+	rfcDate, err := time.Parse(time.RFC3339, dueDate)
+	if err != nil {
+		return nil, err
+	}
+	result := primitive.NewDateTimeFromTime(rfcDate)
+	return &result, nil
+}
+
 func ValidateFields(c *gin.Context, updateFields *TaskItemChangeableFields, taskSourceResult *external.TaskSourceResult, task *database.Task) bool {
 	isTaskDeletedInRequest := updateFields.IsDeleted == nil || *updateFields.IsDeleted
 	isTaskDeletedInDb := task.IsDeleted != nil && *task.IsDeleted
 	isTaskDeleted := isTaskDeletedInRequest && isTaskDeletedInDb
 	if updateFields.IsCompleted != nil && *updateFields.IsCompleted && (!taskSourceResult.Details.IsCompletable || isTaskDeleted) {
-		c.JSON(400, gin.H{"detail": "cannot be marked done"})
+		HandleBadRequest(c, "cannot be marked done", "is_completed")
 		return false
 	}
 	if updateFields.Task.Status != nil {
@@ -212,7 +240,7 @@ func ValidateFields(c *gin.Context, updateFields *TaskItemChangeableFields, task
 			}
 		}
 		if statusToUpdateTo == nil {
-			c.JSON(400, gin.H{"detail": "status value not in all status field for task"})
+			HandleBadRequest(c, "status value not in all status field for task", "task.status")
 			return false
 		}
 		if statusToUpdateTo.IsCompletedStatus {
@@ -230,17 +258,8 @@ func ValidateFields(c *gin.Context, updateFields *TaskItemChangeableFields, task
 	if updateFields.IsDeleted != nil && *updateFields.IsDeleted {
 		updateFields.DeletedAt = primitive.NewDateTimeFromTime(time.Now())
 	}
-	if updateFields.Title != nil && *updateFields.Title == "" {
-		c.JSON(400, gin.H{"detail": "title cannot be empty"})
-		return false
-	}
 	if updateFields.TimeAllocation != nil {
-		if *updateFields.TimeAllocation < 0 {
-			c.JSON(400, gin.H{"detail": "time duration cannot be negative"})
-			return false
-		} else {
-			*updateFields.TimeAllocation *= constants.NANOSECONDS_IN_SECOND
-		}
+		*updateFields.TimeAllocation *= constants.NANOSECONDS_IN_SECOND
 	}
 	if updateFields.Task.ExternalPriority != nil {
 		matched := false
@@ -252,27 +271,73 @@ func ValidateFields(c *gin.Context, updateFields *TaskItemChangeableFields, task
 			}
 		}
 		if !matched {
-			c.JSON(400, gin.H{"detail": "priority value not valid for task"})
+			HandleBadRequest(c, "priority value not valid for task", "task.external_priority")
 			return false
 		}
 	}
+	if updateFields.Task.JIRASprintID != nil && *updateFields.Task.JIRASprintID != 0 {
+		if task.SourceID != external.TASK_SOURCE_ID_JIRA || task.JIRATaskParams == nil || getJIRASprint(task.JIRATaskParams.AvailableSprints, *updateFields.Task.JIRASprintID) == nil {
+			HandleBadRequest(c, "sprint value not valid for task", "task.jira_sprint_id")
+			return false
+		}
+	} else if updateFields.Task.JIRASprintID != nil && task.SourceID != external.TASK_SOURCE_ID_JIRA {
+		HandleBadRequest(c, "only JIRA tasks can be moved between sprints", "task.jira_sprint_id")
+		return false
+	}
 	return true
 }
 
+func getJIRASprint(sprints []database.JIRASprint, sprintID int) *database.JIRASprint {
+	for _, sprint := range sprints {
+		if sprint.ExternalID == sprintID {
+			// for implicit memory aliasing
+			result := sprint
+			return &result
+		}
+	}
+	return nil
+}
+
+// getJIRATaskParamsWithSprint copies the task's JIRA params so the whole subdocument can be $set
+// with the new sprint. A sprintID of 0 means the backlog
+func getJIRATaskParamsWithSprint(task *database.Task, sprintID int) *database.JIRATaskParams {
+	jiraTaskParams := database.JIRATaskParams{}
+	if task.JIRATaskParams != nil {
+		jiraTaskParams = *task.JIRATaskParams
+	}
+	jiraTaskParams.Sprint = getJIRASprint(jiraTaskParams.AvailableSprints, sprintID)
+	return &jiraTaskParams
+}
+
 // note: check usage of this function before using new fields of the 'task' parameter
 func (api *API) ReOrderTask(c *gin.Context, taskID primitive.ObjectID, userID primitive.ObjectID, IDOrdering *int, IDTaskSectionHex *string, task *database.Task) error {
 	taskCollection := database.GetTaskCollection(api.DB)
 	updateFields := bson.M{"has_been_reordered": true}
 
-	if IDOrdering != nil {
-		updateFields["id_ordering"] = *IDOrdering
-	}
-	var IDTaskSection primitive.ObjectID
+	IDTaskSection := task.IDTaskSection
 	if IDTaskSectionHex != nil {
 		IDTaskSection, _ = primitive.ObjectIDFromHex(*IDTaskSectionHex)
 		updateFields["id_task_section"] = IDTaskSection
-	} else {
-		IDTaskSection = task.IDTaskSection
+	}
+
+	if IDOrdering != nil {
+		// the task is placed before it changes section, so that a move within the same section
+		// counts the task's current place
+		siblingFilters := []bson.M{
+			{"is_deleted": bson.M{"$ne": true}},
+		}
+		if task.ParentTaskID != primitive.NilObjectID {
+			siblingFilters = append(siblingFilters, bson.M{"parent_task_id": task.ParentTaskID})
+		} else {
+			siblingFilters = append(siblingFilters, bson.M{"id_task_section": IDTaskSection})
+			siblingFilters = append(siblingFilters, bson.M{"is_completed": bson.M{"$ne": true}})
+		}
+		err := database.MoveToPosition(c.Request.Context(), taskCollection, userID, taskID, &siblingFilters, *IDOrdering)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to move task in db")
+			Handle500(c)
+			return err
+		}
 	}
 
 	result, err := taskCollection.UpdateOne(
@@ -292,86 +357,9 @@ func (api *API) ReOrderTask(c *gin.Context, taskID primitive.ObjectID, userID pr
 		Handle404(c)
 		return errors.New("task not found")
 	}
-
-	if IDOrdering == nil {
-		// if not updating the ordering of the task, then no need to move the other tasks
-		return nil
-	}
-
-	dbQuery := []bson.M{
-		{"_id": bson.M{"$ne": taskID}},
-		{"is_deleted": bson.M{"$ne": true}},
-		{"id_ordering": bson.M{"$gte": *IDOrdering}},
-		{"user_id": userID},
-	}
-	taskQuery := []bson.M{
-		{"user_id": userID},
-		{"is_deleted": bson.M{"$ne": true}},
-	}
-	if task.ParentTaskID != primitive.NilObjectID {
-		dbQuery = append(dbQuery, bson.M{"parent_task_id": task.ParentTaskID})
-		taskQuery = append(taskQuery, bson.M{"parent_task_id": task.ParentTaskID})
-	} else {
-		dbQuery = append(dbQuery, bson.M{"id_task_section": IDTaskSection})
-		dbQuery = append(dbQuery, bson.M{"is_completed": bson.M{"$ne": true}})
-		taskQuery = append(taskQuery, bson.M{"id_task_section": IDTaskSection})
-		taskQuery = append(taskQuery, bson.M{"is_completed": bson.M{"$ne": true}})
-	}
-
-	// Move back other tasks to ensure ordering is preserved
-	_, err = taskCollection.UpdateMany(
-		context.Background(),
-		bson.M{"$and": dbQuery},
-		bson.M{"$inc": bson.M{"id_ordering": 1}},
-	)
-	if err != nil {
-		api.Logger.Error().Err(err).Msg("failed to move back other tasks in db")
-		Handle500(c)
-		return err
-	}
-
-	// Remove gaps in ordering IDs
-	taskResults, err := api.getTaskResultsFromQuery(taskQuery, userID)
-	if err != nil {
-		api.Logger.Error().Err(err).Msg("failed to fetch tasks in db")
-		Handle500(c)
-		return err
-	}
-	err = api.updateOrderingIDsV2(api.DB, &taskResults)
-	if err != nil {
-		api.Logger.Error().Err(err).Msg("failed to update surrounding ordering IDs")
-		Handle500(c)
-		return err
-	}
-
 	return nil
 }
 
-func (api *API) getTaskResultsFromQuery(taskQuery []bson.M, userID primitive.ObjectID) ([]*TaskResult, error) {
-	taskCollection := database.GetTaskCollection(api.DB)
-	options := options.Find().SetSort(bson.M{"id_ordering": 1})
-	cursor, err := taskCollection.Find(
-		context.Background(),
-		bson.M{"$and": taskQuery},
-		options,
-	)
-	if err != nil {
-		api.Logger.Error().Err(err).Msg("failed to fetch tasks")
-		return nil, err
-	}
-
-	var tasks []database.Task
-	err = cursor.All(context.Background(), &tasks)
-	if err != nil {
-		logger := logging.GetSentryLogger()
-		logger.Error().Err(err).Msg("failed to fetch tasks for user")
-		return nil, err
-	}
-
-	taskList := api.taskListToTaskResultList(&tasks, userID)
-	return taskList, nil
-}
-
 func (api *API) UpdateTaskInDB(c *gin.Context, task *database.Task, userID primitive.ObjectID, updateFields *database.Task) {
 	err := api.UpdateTaskInDBWithError(task, userID, updateFields)
 	if err != nil {
@@ -409,5 +397,6 @@ func (api *API) UpdateTaskInDBWithError(task *database.Task, userID primitive.Ob
 		return errors.New("failed to update task")
 	}
 
+	api.recordTaskActivity(context.Background(), getTaskUpdateActivities(task, userID, updateFields))
 	return nil
 }
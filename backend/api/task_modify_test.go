@@ -17,6 +17,7 @@ import (
 	"github.com/franchizzle/task-manager/backend/constants"
 	"github.com/franchizzle/task-manager/backend/database"
 	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/franchizzle/task-manager/backend/ordering"
 	"github.com/franchizzle/task-manager/backend/utils"
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/bson"
@@ -104,7 +105,7 @@ func TestMarkAsDeleted(t *testing.T) {
 		taskUpdateServer = testutils.GetMockAPIServer(t, 200, response)
 		api.ExternalConfig.Linear.ConfigValues.TaskUpdateURL = &taskUpdateServer.URL
 
-		err := database.MarkCompleteWithCollection(database.GetTaskCollection(db), linearTaskID)
+		err := database.MarkCompleteWithCollection(context.Background(), database.GetTaskCollection(db), linearTaskID)
 		assert.NoError(t, err)
 		ServeRequest(t,
 			authToken,
@@ -114,7 +115,7 @@ func TestMarkAsDeleted(t *testing.T) {
 			http.StatusOK,
 			api,
 		)
-		tasks, err := database.GetDeletedTasks(db, userID)
+		tasks, err := database.GetDeletedTasks(context.Background(), db, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 0, len(*tasks))
 	})
@@ -279,7 +280,7 @@ func TestMarkAsComplete(t *testing.T) {
 	})
 
 	t.Run("CompletionFlagFalse", func(t *testing.T) {
-		err := database.MarkCompleteWithCollection(database.GetTaskCollection(db), linearTaskID)
+		err := database.MarkCompleteWithCollection(context.Background(), database.GetTaskCollection(db), linearTaskID)
 		assert.NoError(t, err)
 		request, _ := http.NewRequest(
 			"PATCH",
@@ -289,7 +290,7 @@ func TestMarkAsComplete(t *testing.T) {
 		recorder := httptest.NewRecorder()
 		router.ServeHTTP(recorder, request)
 		assert.Equal(t, http.StatusOK, recorder.Code)
-		tasks, err := database.GetCompletedTasks(db, userID)
+		tasks, err := database.GetCompletedTasks(context.Background(), db, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 0, len(*tasks))
 	})
@@ -549,10 +550,13 @@ func TestTaskReorder(t *testing.T) {
 		assert.Equal(t, 2, task.IDOrdering)
 		assert.Equal(t, originalTaskSectionID, task.IDTaskSection)
 		assert.True(t, task.HasBeenReordered)
+		movedOrderingKey := task.OrderingKey
 
+		// the other task keeps its ordering ID, but now sorts ahead of the moved one
 		err = taskCollection.FindOne(context.Background(), bson.M{"_id": taskToBeMovedID}).Decode(&task)
 		assert.NoError(t, err)
-		assert.Equal(t, 1, task.IDOrdering)
+		assert.Equal(t, 2, task.IDOrdering)
+		assert.True(t, ordering.Less(task.OrderingKey, task.IDOrdering, movedOrderingKey, 2))
 
 		err = taskCollection.FindOne(context.Background(), bson.M{"_id": taskToNotBeMovedID}).Decode(&task)
 		assert.NoError(t, err)
@@ -651,13 +655,16 @@ func TestTaskReorder(t *testing.T) {
 		var task database.Task
 		err = taskCollection.FindOne(context.Background(), bson.M{"_id": taskID}).Decode(&task)
 		assert.NoError(t, err)
-		assert.Equal(t, 2, task.IDOrdering)
+		// it's placed ahead of the subtask at position 2, counting itself
+		assert.Equal(t, 1, task.IDOrdering)
 		assert.Equal(t, parentTaskID, task.ParentTaskID)
 		assert.True(t, task.HasBeenReordered)
+		movedOrderingKey := task.OrderingKey
 
 		err = taskCollection.FindOne(context.Background(), bson.M{"_id": taskToBeMovedID}).Decode(&task)
 		assert.NoError(t, err)
-		assert.Equal(t, 3, task.IDOrdering)
+		assert.Equal(t, 2, task.IDOrdering)
+		assert.True(t, ordering.Less(movedOrderingKey, 1, task.OrderingKey, task.IDOrdering))
 
 		err = taskCollection.FindOne(context.Background(), bson.M{"_id": taskToNotBeMovedID}).Decode(&task)
 		assert.NoError(t, err)
@@ -690,7 +697,7 @@ func TestTaskReorder(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"task not found.\",\"taskId\":\""+taskIDHex+"\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"task not found.\",\"code\":\"not_found\",\"metadata\":{\"taskId\":\""+taskIDHex+"\"}}", string(body))
 	})
 	t.Run("MissingOrderingID", func(t *testing.T) {
 		authToken := login("approved@resonant-kelpie-404a42.netlify.app", "")
@@ -706,7 +713,7 @@ func TestTaskReorder(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"parameter missing or malformatted\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"parameter missing or malformatted\",\"code\":\"bad_request\"}", string(body))
 	})
 	t.Run("BadTaskID", func(t *testing.T) {
 		authToken := login("approved@resonant-kelpie-404a42.netlify.app", "")
@@ -723,7 +730,7 @@ func TestTaskReorder(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"task not found.\",\"taskId\":\""+taskIDHex+"\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"task not found.\",\"code\":\"not_found\",\"metadata\":{\"taskId\":\""+taskIDHex+"\"}}", string(body))
 	})
 	t.Run("WrongFormatTaskID", func(t *testing.T) {
 		authToken := login("approved@resonant-kelpie-404a42.netlify.app", "")
@@ -739,7 +746,7 @@ func TestTaskReorder(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"not found\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"not found\",\"code\":\"unauthorized\"}", string(body))
 	})
 	t.Run("BadTaskSectionIDFormat", func(t *testing.T) {
 		authToken := login("approved@resonant-kelpie-404a42.netlify.app", "")
@@ -755,7 +762,7 @@ func TestTaskReorder(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"'id_task_section' is not a valid ID\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"'id_task_section' is not a valid ID\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"id_task_section\",\"message\":\"'id_task_section' is not a valid ID\"}]}", string(body))
 	})
 	t.Run("OnlyReorderTaskSections", func(t *testing.T) {
 
@@ -925,7 +932,7 @@ func TestEditFields(t *testing.T) {
 
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"title cannot be empty\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"'title' cannot be empty\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"title\",\"message\":\"'title' cannot be empty\"}]}", string(body))
 	})
 
 	t.Run("Edit Body Success", func(t *testing.T) {
@@ -1059,7 +1066,7 @@ func TestEditFields(t *testing.T) {
 
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"due_date is not a valid date\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"due_date is not a valid date\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"due_date\"}]}", string(body))
 	})
 	t.Run("Modifying other fields does not change due date", func(t *testing.T) {
 		expectedTask := sampleTask
@@ -1157,7 +1164,7 @@ func TestEditFields(t *testing.T) {
 
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"time duration cannot be negative\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"'time_duration' cannot be negative\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"time_duration\",\"message\":\"'time_duration' cannot be negative\"}]}", string(body))
 	})
 	t.Run("Edit priority not in all priorities", func(t *testing.T) {
 		expectedTask := sampleTask
@@ -1182,7 +1189,7 @@ func TestEditFields(t *testing.T) {
 
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"priority value not valid for task\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"priority value not valid for task\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"task.external_priority\"}]}", string(body))
 	})
 	t.Run("Edit multiple fields success", func(t *testing.T) {
 		expectedTask := sampleTask
@@ -1294,7 +1301,7 @@ func TestEditFields(t *testing.T) {
 
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"title cannot be empty\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"'title' cannot be empty\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"title\",\"message\":\"'title' cannot be empty\"}]}", string(body))
 	})
 
 	t.Run("Edit zero fields", func(t *testing.T) {
@@ -1321,7 +1328,7 @@ func TestEditFields(t *testing.T) {
 
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"task changes missing\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"task changes missing\",\"code\":\"bad_request\"}", string(body))
 	})
 	t.Run("Assign to other General Task user", func(t *testing.T) {
 		api, dbCleanup := GetAPIWithDBCleanup()
@@ -1389,9 +1396,36 @@ func TestModifyShareableTask(t *testing.T) {
 		body := bytes.NewBuffer([]byte(`{"shared_access": "boop"}`))
 		url := "/tasks/modify/" + insertedTaskID.Hex() + "/"
 		responseBody := ServeRequest(t, authToken, "PATCH", url, body, http.StatusBadRequest, api)
-		expectedBody := `{"detail":"invalid shared access token"}`
+		expectedBody := `{"detail":"'shared_access' must be one of: public, domain","code":"invalid_parameter","field_errors":[{"field":"shared_access","message":"'shared_access' must be one of: public, domain"}]}`
 		assert.Equal(t, expectedBody, string(responseBody))
 	})
+	t.Run("InvalidSharedPermissionField", func(t *testing.T) {
+		insertResult, err := taskCollection.InsertOne(context.Background(), sampleTask)
+		assert.NoError(t, err)
+		insertedTaskID := insertResult.InsertedID.(primitive.ObjectID)
+
+		body := bytes.NewBuffer([]byte(`{"shared_permission": "boop"}`))
+		url := "/tasks/modify/" + insertedTaskID.Hex() + "/"
+		responseBody := ServeRequest(t, authToken, "PATCH", url, body, http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"'shared_permission' must be one of: view, comment, edit","code":"invalid_parameter","field_errors":[{"field":"shared_permission","message":"'shared_permission' must be one of: view, comment, edit"}]}`, string(responseBody))
+	})
+	t.Run("UpdateSharedPermission", func(t *testing.T) {
+		insertResult, err := taskCollection.InsertOne(context.Background(), sampleTask)
+		assert.NoError(t, err)
+		insertedTaskID := insertResult.InsertedID.(primitive.ObjectID)
+
+		body := bytes.NewBuffer([]byte(`{"shared_permission": "comment"}`))
+		url := fmt.Sprintf("/tasks/modify/%s/", insertedTaskID.Hex())
+		ServeRequest(t, authToken, "PATCH", url, body, http.StatusOK, api)
+
+		var updatedTask database.Task
+		err = taskCollection.FindOne(
+			context.Background(),
+			bson.M{"_id": insertedTaskID},
+		).Decode(&updatedTask)
+		assert.NoError(t, err)
+		assert.Equal(t, database.SharedPermissionComment, *updatedTask.SharedPermission)
+	})
 	t.Run("OnlyUpdateShareUntil", func(t *testing.T) {
 		expectedTask := sampleTask
 		sharedAccessPublic := database.SharedAccessDomain
@@ -1501,7 +1535,7 @@ func TestModifyShareableTask(t *testing.T) {
 		body := bytes.NewBuffer([]byte(`{"shared_access": "domain"}`))
 		url := fmt.Sprintf("/tasks/modify/%s/", insertedTaskID.Hex())
 		responseBody := ServeRequest(t, authToken, "PATCH", url, body, http.StatusBadRequest, api)
-		expectedBody := `{"detail":"only General Task tasks can be shared"}`
+		expectedBody := `{"detail":"only General Task tasks can be shared","code":"bad_request"}`
 		assert.Equal(t, expectedBody, string(responseBody))
 	})
 	t.Run("ModifyShareUntilInvalidSourceID", func(t *testing.T) {
@@ -1518,7 +1552,45 @@ func TestModifyShareableTask(t *testing.T) {
 		body := bytes.NewBuffer([]byte(`{"shared_until":"2021-01-01T00:00:00Z"}`))
 		url := fmt.Sprintf("/tasks/modify/%s/", insertedTaskID.Hex())
 		responseBody := ServeRequest(t, authToken, "PATCH", url, body, http.StatusBadRequest, api)
-		expectedBody := `{"detail":"only General Task tasks can be shared"}`
+		expectedBody := `{"detail":"only General Task tasks can be shared","code":"bad_request"}`
 		assert.Equal(t, expectedBody, string(responseBody))
 	})
+	t.Run("ModifyJIRASprintInvalidSourceID", func(t *testing.T) {
+		insertResult, err := taskCollection.InsertOne(context.Background(), sampleTask)
+		assert.NoError(t, err)
+		insertedTaskID := insertResult.InsertedID.(primitive.ObjectID)
+
+		body := bytes.NewBuffer([]byte(`{"task": {"jira_sprint_id": 0}}`))
+		url := fmt.Sprintf("/tasks/modify/%s/", insertedTaskID.Hex())
+		responseBody := ServeRequest(t, authToken, "PATCH", url, body, http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"only JIRA tasks can be moved between sprints","code":"invalid_parameter","field_errors":[{"field":"task.jira_sprint_id"}]}`, string(responseBody))
+	})
+	t.Run("ModifyJIRASprintNotAvailable", func(t *testing.T) {
+		jiraTask := sampleTask
+		jiraTask.SourceID = "jira"
+		jiraTask.JIRATaskParams = &database.JIRATaskParams{
+			Sprint:           &database.JIRASprint{ExternalID: 37, Name: "Sprint 37", State: "active", BoardID: 5},
+			AvailableSprints: []database.JIRASprint{{ExternalID: 37, Name: "Sprint 37", State: "active", BoardID: 5}},
+		}
+		insertResult, err := taskCollection.InsertOne(context.Background(), jiraTask)
+		assert.NoError(t, err)
+		insertedTaskID := insertResult.InsertedID.(primitive.ObjectID)
+
+		body := bytes.NewBuffer([]byte(`{"task": {"jira_sprint_id": 99}}`))
+		url := fmt.Sprintf("/tasks/modify/%s/", insertedTaskID.Hex())
+		responseBody := ServeRequest(t, authToken, "PATCH", url, body, http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"sprint value not valid for task","code":"invalid_parameter","field_errors":[{"field":"task.jira_sprint_id"}]}`, string(responseBody))
+	})
+}
+
+func TestGetJIRATaskParamsWithSprint(t *testing.T) {
+	sprint := database.JIRASprint{ExternalID: 38, Name: "Sprint 38", State: "future", BoardID: 5}
+	task := database.Task{JIRATaskParams: &database.JIRATaskParams{
+		Sprint:           &database.JIRASprint{ExternalID: 37, Name: "Sprint 37", State: "active", BoardID: 5},
+		AvailableSprints: []database.JIRASprint{sprint},
+	}}
+	params := getJIRATaskParamsWithSprint(&task, 38)
+	assert.Equal(t, &sprint, params.Sprint)
+	assert.Equal(t, 37, task.JIRATaskParams.Sprint.ExternalID)
+	assert.Nil(t, getJIRATaskParamsWithSprint(&task, 0).Sprint)
 }
@@ -0,0 +1,274 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/franchizzle/task-manager/backend/llm"
+	"github.com/franchizzle/task-manager/backend/ordering"
+	"github.com/franchizzle/task-manager/backend/settings"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type TaskPrioritizationResult struct {
+	TaskID        primitive.ObjectID `json:"task_id"`
+	IDTaskSection primitive.ObjectID `json:"id_task_section"`
+	// position within the task's section, to be applied with the task modify endpoint
+	IDOrdering int    `json:"id_ordering"`
+	Reasoning  string `json:"reasoning"`
+}
+
+type rankedTask struct {
+	task      database.Task
+	score     float64
+	reasoning string
+}
+
+// matches response lines such as "3: reasoning" or "3. reasoning"
+var prioritizedTaskLine = regexp.MustCompile(`^\s*(\d+)\s*[:.)-]\s*(.*)$`)
+
+// TaskPrioritize proposes an ordering for the user's active tasks based on their due dates, the
+// user's meetings for the rest of the day, and the pull requests waiting on them. Nothing is
+// reordered here, the client applies the ordering it accepts
+func (api *API) TaskPrioritize(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	user, err := database.GetUser(c.Request.Context(), api.DB, userID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to find user")
+		Handle500(c)
+		return
+	}
+	timezoneOffset, err := api.getTimezoneOffset(c)
+	if err != nil {
+		HandleBadRequest(c, err.Error())
+		return
+	}
+	smartPrioritizeEnabled, err := settings.GetUserSettingValue(api.DB, userID, settings.LabSmartPrioritizeEnabledSetting)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	if smartPrioritizeEnabled == constants.SettingFalse {
+		HandleBadRequest(c, "smart prioritize is not enabled")
+		return
+	}
+
+	tasks, err := api.getPrioritizableTasks(c.Request.Context(), userID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch tasks")
+		Handle500(c)
+		return
+	}
+	if len(tasks) == 0 {
+		c.JSON(200, []TaskPrioritizationResult{})
+		return
+	}
+	timeNow := api.GetCurrentLocalizedTime(timezoneOffset)
+	promptContext, err := api.getPrioritizationContext(c.Request.Context(), userID, timeNow)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to load prioritization context")
+		Handle500(c)
+		return
+	}
+	chunks := chunkTasksForPrompt(tasks, promptContext, timeNow)
+
+	hasSuggestionsLeft, err := api.hasGPTSuggestionsLeft(user, timezoneOffset)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("error fetching suggestions remaining")
+		Handle500(c)
+		return
+	}
+	if !hasSuggestionsLeft {
+		HandleBadRequest(c, "no remaining suggestions for user")
+		return
+	}
+	// a single prioritization counts once against the quota, no matter how many prompts it takes
+	err = api.decrementGPTRemainingByOne(user, timezoneOffset)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("unable to decrement suggestions remaining")
+		Handle500(c)
+		return
+	}
+
+	rankedTasks := []rankedTask{}
+	isPrioritized := map[primitive.ObjectID]bool{}
+	for _, chunk := range chunks {
+		completion, err := api.getLLMCompletion(c.Request.Context(), userID, llm.CompletionRequest{
+			MaxTokens:   1500,
+			Temperature: 0.2,
+			Prompt:      getPrioritizePrompt(chunk, promptContext, timeNow),
+		})
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to fetch prioritization")
+			Handle500(c)
+			return
+		}
+		rankedTasks = append(rankedTasks, rankChunk(chunk, completion)...)
+		for _, task := range chunk {
+			isPrioritized[task.ID] = true
+		}
+	}
+	// tasks that didn't fit in any prompt keep their current order after the prioritized ones
+	for _, task := range tasks {
+		if !isPrioritized[task.ID] {
+			rankedTasks = append(rankedTasks, rankedTask{task: task, score: 1})
+		}
+	}
+	sort.SliceStable(rankedTasks, func(i, j int) bool {
+		return rankedTasks[i].score < rankedTasks[j].score
+	})
+
+	results := []TaskPrioritizationResult{}
+	sectionOrdering := map[primitive.ObjectID]int{}
+	for _, rankedTask := range rankedTasks {
+		sectionOrdering[rankedTask.task.IDTaskSection]++
+		results = append(results, TaskPrioritizationResult{
+			TaskID:        rankedTask.task.ID,
+			IDTaskSection: rankedTask.task.IDTaskSection,
+			IDOrdering:    sectionOrdering[rankedTask.task.IDTaskSection],
+			Reasoning:     rankedTask.reasoning,
+		})
+	}
+	c.JSON(200, results)
+}
+
+// getPrioritizableTasks returns the user's active top level tasks in their current order
+func (api *API) getPrioritizableTasks(ctx context.Context, userID primitive.ObjectID) ([]database.Task, error) {
+	activeTasks, err := database.GetActiveTasks(ctx, api.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+	tasks := []database.Task{}
+	for _, task := range *activeTasks {
+		if task.ParentTaskID != primitive.NilObjectID || task.Title == nil {
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return ordering.Less(tasks[i].OrderingKey, tasks[i].IDOrdering, tasks[j].OrderingKey, tasks[j].IDOrdering)
+	})
+	return tasks, nil
+}
+
+// getPrioritizationContext describes the user's remaining meetings and pull requests waiting on them
+func (api *API) getPrioritizationContext(ctx context.Context, userID primitive.ObjectID, timeNow time.Time) (string, error) {
+	events, err := database.GetEventsUntilEndOfDay(ctx, api.DB, userID, timeNow, api.getWorkingHours(userID).EndOfDay(timeNow))
+	if err != nil {
+		return "", err
+	}
+	meetingTime := time.Duration(0)
+	for _, event := range *events {
+		meetingTime += event.DatetimeEnd.Time().Sub(event.DatetimeStart.Time())
+	}
+	pullRequests, err := database.GetActivePRs(ctx, api.DB, userID)
+	if err != nil {
+		return "", err
+	}
+	requiredActions := map[string]int{}
+	for _, pullRequest := range *pullRequests {
+		if pullRequest.RequiredAction != "" && pullRequest.RequiredAction != external.ActionNoneNeeded {
+			requiredActions[sanitizeGPTString(pullRequest.RequiredAction)]++
+		}
+	}
+	actions := []string{}
+	for action, count := range requiredActions {
+		actions = append(actions, fmt.Sprintf("%d that need \"%s\"", count, action))
+	}
+	sort.Strings(actions)
+
+	promptContext := fmt.Sprintf("I have %d meetings taking %d minutes for the rest of today.", len(*events), int(meetingTime.Minutes()))
+	if len(actions) > 0 {
+		promptContext += " I have pull requests waiting on me: " + strings.Join(actions, ", ") + "."
+	}
+	return promptContext, nil
+}
+
+// chunkTasksForPrompt splits tasks so that each prompt stays under the length limit
+func chunkTasksForPrompt(tasks []database.Task, promptContext string, timeNow time.Time) [][]database.Task {
+	isTooLong := func(chunk []database.Task) bool {
+		return utf8.RuneCountInString(getPrioritizePrompt(chunk, promptContext, timeNow)) > constants.MAX_GPT_PROMPT_LENGTH
+	}
+	chunks := [][]database.Task{}
+	chunk := []database.Task{}
+	for _, task := range tasks {
+		if isTooLong([]database.Task{task}) {
+			// left in its current position
+			continue
+		}
+		candidate := append(append([]database.Task{}, chunk...), task)
+		if isTooLong(candidate) {
+			chunks = append(chunks, chunk)
+			if len(chunks) == constants.MAX_PRIORITIZE_CHUNKS {
+				return chunks
+			}
+			candidate = []database.Task{task}
+		}
+		chunk = candidate
+	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+func getPrioritizeTaskLine(index int, task database.Task, timeNow time.Time) string {
+	line := fmt.Sprintf("%d. %s", index+1, sanitizeGPTString(*task.Title))
+	if task.DueDate != nil && task.DueDate.Time().Year() > 1970 {
+		line += " (due " + task.DueDate.Time().In(timeNow.Location()).Format(constants.YEAR_MONTH_DAY_FORMAT) + ")"
+	}
+	if task.PriorityNormalized != nil && *task.PriorityNormalized > 0 {
+		line += fmt.Sprintf(" (priority %d of 4, 1 is most urgent)", int(*task.PriorityNormalized))
+	}
+	return line
+}
+
+func getPrioritizePrompt(tasks []database.Task, promptContext string, timeNow time.Time) string {
+	taskLines := []string{}
+	for idx, task := range tasks {
+		taskLines = append(taskLines, getPrioritizeTaskLine(idx, task, timeNow))
+	}
+	return `Today is ` + timeNow.Format(constants.YEAR_MONTH_DAY_FORMAT) + `. ` + promptContext + ` These are my tasks:
+	` + strings.Join(taskLines, "\n\t") + `
+	I am an employee at a startup, and I value efficient engineering, unblocking my coworkers before starting my own work, being prepared for meetings, and meeting deadlines.
+	Order these tasks in the order I should complete them. Respond with one line per task in the format "<task number>: <short reason>". Do not use the first person in the reasoning. Do not use any profanity or offensive language.
+	`
+}
+
+// rankChunk scores each task in the chunk by its position in the response, from 0 for the first
+// task up to 1. Tasks missing from the response are placed after the ones that were ranked
+func rankChunk(chunk []database.Task, response string) []rankedTask {
+	ranked := []rankedTask{}
+	isRanked := map[int]bool{}
+	for _, line := range strings.Split(response, "\n") {
+		matches := prioritizedTaskLine.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		taskNumber, err := strconv.Atoi(matches[1])
+		if err != nil || taskNumber < 1 || taskNumber > len(chunk) || isRanked[taskNumber-1] {
+			continue
+		}
+		isRanked[taskNumber-1] = true
+		ranked = append(ranked, rankedTask{task: chunk[taskNumber-1], reasoning: strings.TrimSpace(matches[2])})
+	}
+	for idx, task := range chunk {
+		if !isRanked[idx] {
+			ranked = append(ranked, rankedTask{task: task})
+		}
+	}
+	for idx := range ranked {
+		ranked[idx].score = float64(idx) / float64(len(ranked))
+	}
+	return ranked
+}
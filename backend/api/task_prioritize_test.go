@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/franchizzle/task-manager/backend/llm"
+	"github.com/franchizzle/task-manager/backend/testutils"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTaskPrioritize(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	router := GetRouter(api)
+	currentTime := time.Now().UTC()
+	api.OverrideTime = &currentTime
+
+	authToken := login("test_task_prioritize@yahoo.com", "")
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	_, err := database.GetUserCollection(api.DB).UpdateOne(context.Background(), bson.M{"_id": userID}, bson.M{"$set": bson.M{"gpt_suggestions_left": constants.MAX_OVERVIEW_SUGGESTION, "gpt_last_suggestion_time": primitive.NewDateTimeFromTime(currentTime)}})
+	assert.NoError(t, err)
+
+	notCompleted := false
+	taskIDs := []primitive.ObjectID{}
+	for idx, title := range []string{"Write blog post", "Fix login bug", "Prepare board deck"} {
+		title := title
+		insertResult, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), database.Task{
+			UserID:        userID,
+			Title:         &title,
+			IsCompleted:   &notCompleted,
+			IDTaskSection: constants.IDTaskSectionDefault,
+			SourceID:      external.TASK_SOURCE_ID_GT_TASK,
+			IDOrdering:    idx + 1,
+		})
+		assert.NoError(t, err)
+		taskIDs = append(taskIDs, insertResult.InsertedID.(primitive.ObjectID))
+	}
+	prioritize := func(expectedStatus int) []byte {
+		request, _ := http.NewRequest("POST", "/tasks/prioritize/", nil)
+		request.Header.Set("Authorization", "Bearer "+authToken)
+		request.Header.Set("Timezone-Offset", "0")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, expectedStatus, recorder.Code)
+		body, err := io.ReadAll(recorder.Body)
+		assert.NoError(t, err)
+		return body
+	}
+
+	UnauthorizedTest(t, "POST", "/tasks/prioritize/", nil)
+	t.Run("LabSettingDisabled", func(t *testing.T) {
+		response := prioritize(http.StatusBadRequest)
+		assert.Equal(t, `{"detail":"smart prioritize is not enabled","code":"bad_request"}`, string(response))
+	})
+	t.Run("Success", func(t *testing.T) {
+		assert.NoError(t, database.UpdateUserSetting(context.Background(), api.DB, userID, constants.LabSmartPrioritizeEnabled, "true"))
+		server := testutils.GetMockAPIServer(t, http.StatusOK, `{"id": "1", "choices": [{"text": "\n2: Unblocks users\n3: Board meeting is soon"}], "usage": {"prompt_tokens": 120, "completion_tokens": 15}}`)
+		defer server.Close()
+		api.LLMClient = llm.NewOpenAIClient(llm.Config{BaseURL: server.URL})
+
+		var results []TaskPrioritizationResult
+		err := json.Unmarshal(prioritize(http.StatusOK), &results)
+		assert.NoError(t, err)
+		assert.Equal(t, []TaskPrioritizationResult{
+			{TaskID: taskIDs[1], IDTaskSection: constants.IDTaskSectionDefault, IDOrdering: 1, Reasoning: "Unblocks users"},
+			{TaskID: taskIDs[2], IDTaskSection: constants.IDTaskSectionDefault, IDOrdering: 2, Reasoning: "Board meeting is soon"},
+			{TaskID: taskIDs[0], IDTaskSection: constants.IDTaskSectionDefault, IDOrdering: 3},
+		}, results)
+
+		user, err := database.GetUser(context.Background(), api.DB, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, constants.MAX_OVERVIEW_SUGGESTION-1, user.GPTSuggestionsLeft)
+
+		var usage database.LLMUsage
+		err = database.GetLLMUsageCollection(api.DB).FindOne(context.Background(), bson.M{"user_id": userID}).Decode(&usage)
+		assert.NoError(t, err)
+		assert.Equal(t, llm.ProviderOpenAI, usage.Provider)
+		assert.Equal(t, currentTime.Format(constants.YEAR_MONTH_DAY_FORMAT), usage.Date)
+		assert.Equal(t, 1, usage.RequestCount)
+		assert.Equal(t, 120, usage.PromptTokens)
+		assert.Equal(t, 15, usage.CompletionTokens)
+	})
+}
+
+func TestChunkTasksForPrompt(t *testing.T) {
+	timeNow := time.Date(2023, time.January, 5, 9, 0, 0, 0, time.UTC)
+	getTasks := func(count int, title string) []database.Task {
+		tasks := []database.Task{}
+		for i := 0; i < count; i++ {
+			title := title
+			tasks = append(tasks, database.Task{ID: primitive.NewObjectID(), Title: &title})
+		}
+		return tasks
+	}
+	t.Run("SingleChunk", func(t *testing.T) {
+		chunks := chunkTasksForPrompt(getTasks(5, "short title"), "", timeNow)
+		assert.Equal(t, 1, len(chunks))
+		assert.Equal(t, 5, len(chunks[0]))
+	})
+	t.Run("SplitsLongPrompts", func(t *testing.T) {
+		tasks := getTasks(40, strings.Repeat("long title ", 20))
+		chunks := chunkTasksForPrompt(tasks, "", timeNow)
+		assert.Greater(t, len(chunks), 1)
+		assert.LessOrEqual(t, len(chunks), constants.MAX_PRIORITIZE_CHUNKS)
+		for _, chunk := range chunks {
+			assert.LessOrEqual(t, len(getPrioritizePrompt(chunk, "", timeNow)), constants.MAX_GPT_PROMPT_LENGTH)
+		}
+		assert.Equal(t, tasks[0].ID, chunks[0][0].ID)
+		assert.Equal(t, tasks[len(chunks[0])].ID, chunks[1][0].ID)
+	})
+	t.Run("SkipsTasksTooLongForAPrompt", func(t *testing.T) {
+		tasks := append(getTasks(1, strings.Repeat("a", constants.MAX_GPT_PROMPT_LENGTH)), getTasks(2, "short title")...)
+		chunks := chunkTasksForPrompt(tasks, "", timeNow)
+		assert.Equal(t, [][]database.Task{tasks[1:]}, chunks)
+	})
+}
+
+func TestRankChunk(t *testing.T) {
+	chunk := []database.Task{{ID: primitive.NewObjectID()}, {ID: primitive.NewObjectID()}, {ID: primitive.NewObjectID()}}
+	ranked := rankChunk(chunk, "3: most urgent\n3: duplicate\n7: out of range\nnot a task line\n1. next")
+	assert.Equal(t, 3, len(ranked))
+	assert.Equal(t, chunk[2].ID, ranked[0].task.ID)
+	assert.Equal(t, "most urgent", ranked[0].reasoning)
+	assert.Equal(t, chunk[0].ID, ranked[1].task.ID)
+	assert.Equal(t, chunk[1].ID, ranked[2].task.ID)
+	assert.Equal(t, "", ranked[2].reasoning)
+	assert.Equal(t, 0.0, ranked[0].score)
+}
@@ -0,0 +1,380 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	guuid "github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// password protected shares are opened by sending the password in this header
+const SHARE_PASSWORD_HEADER = "Share-Password"
+
+var errTaskShareNotFound = errors.New("task share not found")
+
+type TaskShareCreateParams struct {
+	ExpiresAt  *time.Time `json:"expires_at"`
+	Password   *string    `json:"password"`
+	Permission *string    `json:"permission"`
+}
+
+type TaskShareResult struct {
+	ID             string `json:"id"`
+	URL            string `json:"url"`
+	HasPassword    bool   `json:"has_password"`
+	Permission     string `json:"permission"`
+	ExpiresAt      string `json:"expires_at,omitempty"`
+	AccessCount    int    `json:"access_count"`
+	LastAccessedAt string `json:"last_accessed_at,omitempty"`
+	CreatedAt      string `json:"created_at"`
+}
+
+func isTaskShareToken(token string) bool {
+	_, err := guuid.Parse(token)
+	return err == nil
+}
+
+func getSharedPermission(permission string) (database.SharedPermission, bool) {
+	switch permission {
+	case constants.StringSharedPermissionView:
+		return database.SharedPermissionView, true
+	case constants.StringSharedPermissionComment:
+		return database.SharedPermissionComment, true
+	case constants.StringSharedPermissionEdit:
+		return database.SharedPermissionEdit, true
+	}
+	return database.SharedPermissionView, false
+}
+
+func getSharedPermissionString(permission database.SharedPermission) string {
+	switch permission {
+	case database.SharedPermissionComment:
+		return constants.StringSharedPermissionComment
+	case database.SharedPermissionEdit:
+		return constants.StringSharedPermissionEdit
+	}
+	return constants.StringSharedPermissionView
+}
+
+func getTaskShareResult(share database.TaskShare) TaskShareResult {
+	result := TaskShareResult{
+		ID:          share.ID.Hex(),
+		URL:         getTaskURL(share.Token),
+		HasPassword: share.PasswordHash != "",
+		Permission:  getSharedPermissionString(share.Permission),
+		AccessCount: share.AccessCount,
+		CreatedAt:   share.CreatedAt.Time().UTC().Format(time.RFC3339),
+	}
+	if share.ExpiresAt != 0 {
+		result.ExpiresAt = share.ExpiresAt.Time().UTC().Format(time.RFC3339)
+	}
+	if share.LastAccessedAt != 0 {
+		result.LastAccessedAt = share.LastAccessedAt.Time().UTC().Format(time.RFC3339)
+	}
+	return result
+}
+
+func (api *API) TaskShareCreate(c *gin.Context) {
+	taskID, err := primitive.ObjectIDFromHex(c.Param("task_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	var params TaskShareCreateParams
+	err = c.BindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	if params.ExpiresAt != nil && !params.ExpiresAt.After(api.GetCurrentTime()) {
+		HandleBadRequest(c, "'expires_at' must be in the future", "expires_at")
+		return
+	}
+	if params.Password != nil && *params.Password == "" {
+		HandleBadRequest(c, "'password' must not be empty", "password")
+		return
+	}
+	permission := database.SharedPermissionView
+	if params.Permission != nil {
+		var isValid bool
+		permission, isValid = getSharedPermission(*params.Permission)
+		if !isValid {
+			HandleBadRequest(c, "invalid permission", "permission")
+			return
+		}
+	}
+	userID := getUserIDFromContext(c)
+	task, err := database.GetTask(c.Request.Context(), api.DB, taskID, userID)
+	if err != nil || (task.IsDeleted != nil && *task.IsDeleted) {
+		Handle404(c)
+		return
+	}
+	// share links are public, and never expire unless expires_at is set
+	sharedUntil := time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+	if params.ExpiresAt != nil {
+		sharedUntil = *params.ExpiresAt
+	}
+	if !api.checkSharingPolicy(c, userID, database.SharedAccessPublic, sharedUntil) {
+		return
+	}
+
+	share := database.TaskShare{
+		ID:         primitive.NewObjectID(),
+		UserID:     userID,
+		TaskID:     taskID,
+		Token:      guuid.New().String(),
+		Permission: permission,
+		CreatedAt:  primitive.NewDateTimeFromTime(api.GetCurrentTime()),
+	}
+	if params.ExpiresAt != nil {
+		share.ExpiresAt = primitive.NewDateTimeFromTime(*params.ExpiresAt)
+	}
+	if params.Password != nil {
+		passwordHash, err := bcrypt.GenerateFromPassword([]byte(*params.Password), bcrypt.DefaultCost)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to hash share password")
+			Handle500(c)
+			return
+		}
+		share.PasswordHash = string(passwordHash)
+	}
+	_, err = database.GetTaskShareCollection(api.DB).InsertOne(c.Request.Context(), share)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to create task share")
+		Handle500(c)
+		return
+	}
+	c.JSON(201, getTaskShareResult(share))
+}
+
+func (api *API) TaskSharesList(c *gin.Context) {
+	taskID, err := primitive.ObjectIDFromHex(c.Param("task_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	userID := getUserIDFromContext(c)
+	var shares []database.TaskShare
+	err = database.FindWithCollection(
+		c.Request.Context(),
+		database.GetTaskShareCollection(api.DB),
+		userID,
+		&[]bson.M{{"task_id": taskID}},
+		&shares,
+		options.Find().SetSort(bson.M{"created_at": 1}),
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch task shares")
+		Handle500(c)
+		return
+	}
+	results := []TaskShareResult{}
+	for _, share := range shares {
+		results = append(results, getTaskShareResult(share))
+	}
+	c.JSON(200, results)
+}
+
+// TaskShareRevoke deletes the share, so its link stops working straight away
+func (api *API) TaskShareRevoke(c *gin.Context) {
+	taskID, err := primitive.ObjectIDFromHex(c.Param("task_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	shareID, err := primitive.ObjectIDFromHex(c.Param("share_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	userID := getUserIDFromContext(c)
+	deleteResult, err := database.GetTaskShareCollection(api.DB).DeleteOne(
+		c.Request.Context(),
+		bson.M{"$and": []bson.M{
+			{"_id": shareID},
+			{"task_id": taskID},
+			{"user_id": userID},
+		}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to revoke task share")
+		Handle500(c)
+		return
+	}
+	if deleteResult.DeletedCount == 0 {
+		Handle404(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+// getTaskShare returns the unexpired share with the token and the task it shares, without
+// checking the share's password
+func (api *API) getTaskShare(ctx context.Context, token string) (*database.TaskShare, *database.Task, error) {
+	share, err := database.GetTaskShareByToken(ctx, api.DB, token)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil, errTaskShareNotFound
+		}
+		return nil, nil, err
+	}
+	if share.ExpiresAt != 0 && !share.ExpiresAt.Time().After(api.GetCurrentTime()) {
+		return nil, nil, errTaskShareNotFound
+	}
+	task, err := database.GetTask(ctx, api.DB, share.TaskID, share.UserID)
+	if err != nil || (task.IsDeleted != nil && *task.IsDeleted) {
+		return nil, nil, errTaskShareNotFound
+	}
+	return share, task, nil
+}
+
+// getSharedTaskFromParam loads the task a shareable link points to, and what the user opening the
+// link is allowed to do with it. Links either hold a share token, or the ID of a task shared
+// through its shared_access field. Returns a nil task after writing the error response
+func (api *API) getSharedTaskFromParam(c *gin.Context, param string) (*database.Task, database.SharedPermission) {
+	/* We can't use getUserIDFromContext here because the "user" context field is potentially empty.
+	 * This is the case when an unauthenticated user hits this endpoint.
+	 */
+	var userID *primitive.ObjectID
+	if userIDRaw, exists := c.Get("user"); exists {
+		userIDValue := userIDRaw.(primitive.ObjectID)
+		userID = &userIDValue
+	}
+	var task *database.Task
+	permission := database.SharedPermissionView
+	if taskID, err := primitive.ObjectIDFromHex(param); err == nil {
+		task, err = database.GetSharedTask(c.Request.Context(), api.DB, taskID, userID)
+		if err != nil || task == nil {
+			Handle404(c)
+			return nil, permission
+		}
+		if task.SharedPermission != nil {
+			permission = *task.SharedPermission
+		}
+	} else {
+		var share *database.TaskShare
+		share, task, err = api.getTaskShare(c.Request.Context(), param)
+		if err != nil {
+			if err == errTaskShareNotFound {
+				Handle404(c)
+			} else {
+				Handle500(c)
+			}
+			return nil, permission
+		}
+		if share.PasswordHash != "" {
+			password := c.GetHeader(SHARE_PASSWORD_HEADER)
+			if password == "" {
+				HandleError(c, ErrorCodeUnauthorized, "password required")
+				return nil, permission
+			}
+			if !api.checkSharePassword(c, share, password) {
+				return nil, permission
+			}
+		}
+		err = database.RecordTaskShareAccess(c.Request.Context(), api.DB, share.ID, api.GetCurrentTime())
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to record task share access")
+		}
+		permission = share.Permission
+	}
+
+	if permission != database.SharedPermissionView && !api.isInTaskOwnerDomain(c.Request.Context(), task, userID) {
+		permission = database.SharedPermissionView
+	}
+	return task, permission
+}
+
+// checkSharePassword compares the password with the share's, holding off addresses which keep
+// getting it wrong. Returns false after writing the error response
+func (api *API) checkSharePassword(c *gin.Context, share *database.TaskShare, password string) bool {
+	now := api.GetCurrentTime()
+	ipAddress := c.ClientIP()
+	failures, err := database.GetSharePasswordFailuresSince(
+		c.Request.Context(),
+		api.DB,
+		share.ID,
+		ipAddress,
+		now.Add(-time.Duration(constants.SHARE_PASSWORD_FAILURE_INTERVAL)*time.Second),
+	)
+	if err != nil {
+		Handle500(c)
+		return false
+	}
+	retryAt := getSharePasswordRetryAt(failures)
+	if now.Before(retryAt) {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAt.Sub(now).Seconds()))))
+		HandleError(c, ErrorCodeTooManyRequests, "too many incorrect passwords, try again later")
+		return false
+	}
+	if bcrypt.CompareHashAndPassword([]byte(share.PasswordHash), []byte(password)) != nil {
+		err = database.CreateSharePasswordFailure(c.Request.Context(), api.DB, &database.SharePasswordFailure{
+			ShareID:   share.ID,
+			IPAddress: ipAddress,
+			CreatedAt: primitive.NewDateTimeFromTime(now),
+		})
+		if err != nil {
+			Handle500(c)
+			return false
+		}
+		HandleError(c, ErrorCodeUnauthorized, "incorrect password")
+		return false
+	}
+	return true
+}
+
+// getSharePasswordRetryAt is when the next password can be tried, given the recent failures with
+// the latest first. The wait doubles with each failure past the limit
+func getSharePasswordRetryAt(failures []database.SharePasswordFailure) time.Time {
+	if len(failures) < constants.SHARE_PASSWORD_MAX_FAILURES {
+		return time.Time{}
+	}
+	backoff := time.Duration(constants.SHARE_PASSWORD_BACKOFF) * time.Second
+	maxBackoff := time.Duration(constants.SHARE_PASSWORD_FAILURE_INTERVAL) * time.Second
+	for idx := constants.SHARE_PASSWORD_MAX_FAILURES; idx < len(failures) && backoff < maxBackoff; idx++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return failures[0].CreatedAt.Time().Add(backoff)
+}
+
+// isInTaskOwnerDomain is true when the user is logged in with the same email domain as the task's
+// owner. Only those users can comment on or edit a shared task
+func (api *API) isInTaskOwnerDomain(ctx context.Context, task *database.Task, userID *primitive.ObjectID) bool {
+	if userID == nil {
+		return false
+	}
+	if *userID == task.UserID {
+		return true
+	}
+	user, err := database.GetUser(ctx, api.DB, *userID)
+	if err != nil {
+		return false
+	}
+	taskOwner, err := database.GetUser(ctx, api.DB, task.UserID)
+	if err != nil {
+		return false
+	}
+	userDomain, err := database.GetEmailDomain(user.Email)
+	if err != nil {
+		return false
+	}
+	taskOwnerDomain, err := database.GetEmailDomain(taskOwner.Email)
+	if err != nil {
+		return false
+	}
+	return userDomain == taskOwnerDomain
+}
@@ -0,0 +1,187 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTaskShares(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+
+	authToken := login("test_task_shares@resonant-kelpie-404a42.netlify.app", "")
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+
+	title := "shared task"
+	mongoResult, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), &database.Task{
+		UserID: userID,
+		Title:  &title,
+	})
+	assert.NoError(t, err)
+	taskID := mongoResult.InsertedID.(primitive.ObjectID).Hex()
+	sharesURL := fmt.Sprintf("/tasks/%s/shares/", taskID)
+
+	createShare := func(t *testing.T, body string) TaskShareResult {
+		response := ServeRequest(t, authToken, http.MethodPost, sharesURL, bytes.NewBuffer([]byte(body)), http.StatusCreated, api)
+		var share TaskShareResult
+		err := json.Unmarshal(response, &share)
+		assert.NoError(t, err)
+		return share
+	}
+	getSharedTask := func(t *testing.T, share TaskShareResult, password string, expectedResponseCode int) []byte {
+		token := share.URL[strings.LastIndex(share.URL, "/")+1:]
+		request, _ := http.NewRequest(http.MethodGet, "/shareable_tasks/detail/"+token+"/", nil)
+		if password != "" {
+			request.Header.Add(SHARE_PASSWORD_HEADER, password)
+		}
+		recorder := httptest.NewRecorder()
+		GetRouter(api).ServeHTTP(recorder, request)
+		assert.Equal(t, expectedResponseCode, recorder.Code)
+		return recorder.Body.Bytes()
+	}
+
+	UnauthorizedTest(t, http.MethodPost, sharesURL, nil)
+	t.Run("InvalidTaskID", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPost, "/tasks/123/shares/", bytes.NewBuffer([]byte(`{}`)), http.StatusNotFound, api)
+	})
+	t.Run("TaskNotFound", func(t *testing.T) {
+		ServeRequest(t, authToken, http.MethodPost, fmt.Sprintf("/tasks/%s/shares/", primitive.NewObjectID().Hex()), bytes.NewBuffer([]byte(`{}`)), http.StatusNotFound, api)
+	})
+	t.Run("ExpiresInPast", func(t *testing.T) {
+		body := fmt.Sprintf(`{"expires_at": "%s"}`, time.Now().Add(-time.Hour).Format(time.RFC3339))
+		response := ServeRequest(t, authToken, http.MethodPost, sharesURL, bytes.NewBuffer([]byte(body)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"'expires_at' must be in the future","code":"invalid_parameter","field_errors":[{"field":"expires_at"}]}`, string(response))
+	})
+	t.Run("EmptyPassword", func(t *testing.T) {
+		response := ServeRequest(t, authToken, http.MethodPost, sharesURL, bytes.NewBuffer([]byte(`{"password": ""}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"'password' must not be empty","code":"invalid_parameter","field_errors":[{"field":"password"}]}`, string(response))
+	})
+	t.Run("InvalidPermission", func(t *testing.T) {
+		response := ServeRequest(t, authToken, http.MethodPost, sharesURL, bytes.NewBuffer([]byte(`{"permission": "admin"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"invalid permission","code":"invalid_parameter","field_errors":[{"field":"permission"}]}`, string(response))
+	})
+	t.Run("Success", func(t *testing.T) {
+		share := createShare(t, `{}`)
+		assert.False(t, share.HasPassword)
+		assert.Equal(t, "view", share.Permission)
+		assert.Equal(t, 0, share.AccessCount)
+
+		response := getSharedTask(t, share, "", http.StatusOK)
+		var result ShareableTaskDetailsResponse
+		err := json.Unmarshal(response, &result)
+		assert.NoError(t, err)
+		assert.Equal(t, taskID, result.Task.ID.Hex())
+		assert.Equal(t, title, result.Task.Title)
+		getSharedTask(t, share, "", http.StatusOK)
+
+		response = ServeRequest(t, authToken, http.MethodGet, sharesURL, nil, http.StatusOK, api)
+		var shares []TaskShareResult
+		err = json.Unmarshal(response, &shares)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(shares))
+		assert.Equal(t, share.ID, shares[0].ID)
+		assert.Equal(t, 2, shares[0].AccessCount)
+		assert.NotEmpty(t, shares[0].LastAccessedAt)
+	})
+	t.Run("Permission", func(t *testing.T) {
+		share := createShare(t, `{"permission": "edit"}`)
+		assert.Equal(t, "edit", share.Permission)
+
+		// logged out users can only view
+		response := getSharedTask(t, share, "", http.StatusOK)
+		var result ShareableTaskDetailsResponse
+		err := json.Unmarshal(response, &result)
+		assert.NoError(t, err)
+		assert.Equal(t, "view", result.Permission)
+
+		token := share.URL[strings.LastIndex(share.URL, "/")+1:]
+		teammateAuthToken := login("test_task_shares_teammate@resonant-kelpie-404a42.netlify.app", "")
+		ServeRequest(t, teammateAuthToken, http.MethodPatch, "/shareable_tasks/modify/"+token+"/", bytes.NewBuffer([]byte(`{"body": "edited"}`)), http.StatusOK, api)
+		response = ServeRequest(t, teammateAuthToken, http.MethodGet, "/shareable_tasks/detail/"+token+"/", nil, http.StatusOK, api)
+		err = json.Unmarshal(response, &result)
+		assert.NoError(t, err)
+		assert.Equal(t, "edit", result.Permission)
+		assert.Equal(t, "edited", result.Task.Body)
+	})
+	t.Run("Password", func(t *testing.T) {
+		share := createShare(t, `{"password": "hunter2"}`)
+		assert.True(t, share.HasPassword)
+
+		response := getSharedTask(t, share, "", http.StatusUnauthorized)
+		assert.Equal(t, `{"detail":"password required","code":"unauthorized"}`, string(response))
+		response = getSharedTask(t, share, "hunter3", http.StatusUnauthorized)
+		assert.Equal(t, `{"detail":"incorrect password","code":"unauthorized"}`, string(response))
+		getSharedTask(t, share, "hunter2", http.StatusOK)
+	})
+	t.Run("PasswordAttemptsLimited", func(t *testing.T) {
+		share := createShare(t, `{"password": "hunter2"}`)
+		for i := 0; i < constants.SHARE_PASSWORD_MAX_FAILURES; i++ {
+			getSharedTask(t, share, "hunter3", http.StatusUnauthorized)
+		}
+		// held off even with the right password
+		response := getSharedTask(t, share, "hunter2", http.StatusTooManyRequests)
+		assert.Equal(t, `{"detail":"too many incorrect passwords, try again later","code":"too_many_requests"}`, string(response))
+
+		// other shares are unaffected
+		otherShare := createShare(t, `{"password": "hunter2"}`)
+		getSharedTask(t, otherShare, "hunter2", http.StatusOK)
+	})
+	t.Run("Expired", func(t *testing.T) {
+		share := createShare(t, fmt.Sprintf(`{"expires_at": "%s"}`, time.Now().Add(time.Hour).Format(time.RFC3339)))
+		getSharedTask(t, share, "", http.StatusOK)
+
+		shareID, _ := primitive.ObjectIDFromHex(share.ID)
+		_, err := database.GetTaskShareCollection(api.DB).UpdateByID(
+			context.Background(),
+			shareID,
+			bson.M{"$set": bson.M{"expires_at": primitive.NewDateTimeFromTime(time.Now().Add(-time.Minute))}},
+		)
+		assert.NoError(t, err)
+		getSharedTask(t, share, "", http.StatusNotFound)
+	})
+	t.Run("Revoke", func(t *testing.T) {
+		share := createShare(t, `{}`)
+		getSharedTask(t, share, "", http.StatusOK)
+
+		ServeRequest(t, authToken, http.MethodDelete, sharesURL+share.ID+"/", nil, http.StatusOK, api)
+		getSharedTask(t, share, "", http.StatusNotFound)
+		ServeRequest(t, authToken, http.MethodDelete, sharesURL+share.ID+"/", nil, http.StatusNotFound, api)
+	})
+	t.Run("RevokeOtherUsersShare", func(t *testing.T) {
+		share := createShare(t, `{}`)
+		otherAuthToken := login("test_task_shares_other@resonant-kelpie-404a42.netlify.app", "")
+		ServeRequest(t, otherAuthToken, http.MethodDelete, sharesURL+share.ID+"/", nil, http.StatusNotFound, api)
+		getSharedTask(t, share, "", http.StatusOK)
+	})
+}
+
+func TestGetSharePasswordRetryAt(t *testing.T) {
+	lastFailure := time.Date(2023, time.January, 6, 9, 0, 0, 0, time.UTC)
+	getFailures := func(count int) []database.SharePasswordFailure {
+		failures := []database.SharePasswordFailure{}
+		for i := 0; i < count; i++ {
+			failures = append(failures, database.SharePasswordFailure{CreatedAt: primitive.NewDateTimeFromTime(lastFailure.Add(-time.Duration(i) * time.Second))})
+		}
+		return failures
+	}
+
+	assert.True(t, getSharePasswordRetryAt(nil).IsZero())
+	assert.True(t, getSharePasswordRetryAt(getFailures(constants.SHARE_PASSWORD_MAX_FAILURES-1)).IsZero())
+	assert.Equal(t, lastFailure.Add(time.Minute), getSharePasswordRetryAt(getFailures(constants.SHARE_PASSWORD_MAX_FAILURES)).UTC())
+	assert.Equal(t, lastFailure.Add(4*time.Minute), getSharePasswordRetryAt(getFailures(constants.SHARE_PASSWORD_MAX_FAILURES+2)).UTC())
+	// capped at the failure interval
+	assert.Equal(t, lastFailure.Add(time.Hour), getSharePasswordRetryAt(getFailures(constants.SHARE_PASSWORD_MAX_FAILURES+20)).UTC())
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/gin-gonic/gin"
+)
+
+type TaskSourceCapabilitiesResult struct {
+	ID                     string `json:"id"`
+	Name                   string `json:"name"`
+	Logo                   string `json:"logo"`
+	LogoV2                 string `json:"logo_v2"`
+	IsCompletable          bool   `json:"is_completable"`
+	CanCreateTask          bool   `json:"can_create_task"`
+	IsReplyable            bool   `json:"is_replyable"`
+	CanCreateCalendarEvent bool   `json:"can_create_calendar_event"`
+	SupportsComments       bool   `json:"supports_comments"`
+	SupportsEvents         bool   `json:"supports_events"`
+}
+
+// TaskSourcesList godoc
+// @Summary      Lists the task sources compiled into this server
+// @Description  includes what each source supports, so clients can hide actions a source can't handle
+// @Tags         tasks
+// @Success      200 {array} TaskSourceCapabilitiesResult
+// @Router       /task_sources/ [get]
+func (api *API) TaskSourcesList(c *gin.Context) {
+	results := []TaskSourceCapabilitiesResult{}
+	for _, registration := range external.GetRegisteredTaskSources() {
+		details := registration.Details
+		results = append(results, TaskSourceCapabilitiesResult{
+			ID:                     details.ID,
+			Name:                   details.Name,
+			Logo:                   details.Logo,
+			LogoV2:                 details.LogoV2,
+			IsCompletable:          details.IsCompletable,
+			CanCreateTask:          details.CanCreateTask,
+			IsReplyable:            details.IsReplyable,
+			CanCreateCalendarEvent: details.CanCreateCalendarEvent,
+			SupportsComments:       details.SupportsComments,
+			SupportsEvents:         details.SupportsEvents,
+		})
+	}
+	c.JSON(200, results)
+}
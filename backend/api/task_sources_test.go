@@ -0,0 +1,37 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskSourcesList(t *testing.T) {
+	authToken := login("test_task_sources_list@resonant-kelpie-404a42.netlify.app", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+
+	UnauthorizedTest(t, "GET", "/task_sources/", nil)
+	t.Run("Success", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "GET", "/task_sources/", nil, http.StatusOK, api)
+		var result []TaskSourceCapabilitiesResult
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, 15, len(result))
+		assert.Equal(t, TaskSourceCapabilitiesResult{
+			ID:               external.TASK_SOURCE_ID_LINEAR,
+			Name:             "Linear",
+			Logo:             "/images/linear.png",
+			LogoV2:           "linear",
+			IsCompletable:    true,
+			SupportsComments: true,
+		}, result[10])
+		assert.Equal(t, external.TASK_SOURCE_ID_GCAL, result[3].ID)
+		assert.True(t, result[3].SupportsEvents)
+		assert.True(t, result[3].CanCreateCalendarEvent)
+		assert.Equal(t, external.TASK_SOURCE_ID_ZENDESK, result[14].ID)
+		assert.True(t, result[14].SupportsComments)
+	})
+}
@@ -28,14 +28,14 @@ type createTestUserParams struct {
 func (api *API) CreateTestUser(c *gin.Context) {
 	if config.GetEnvironment() != config.Dev {
 		log.Error().Msg("CreateTestUser called in non-`dev` environment!")
-		c.JSON(http.StatusUnauthorized, gin.H{"detail": "not found"})
+		HandleError(c, ErrorCodeUnauthorized, "not found")
 		return
 	}
 	var params createTestUserParams
 	err := c.BindJSON(&params)
 	if err != nil {
 		log.Error().Err(err).Send()
-		c.JSON(400, gin.H{"detail": "parameter missing or malformatted"})
+		HandleBindError(c, &params, err, "parameter missing or malformatted")
 		return
 	}
 	authToken := login(params.Email, params.Name)
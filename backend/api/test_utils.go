@@ -63,6 +63,21 @@ func (c *MockHTTPClient) Get(url string) (*http.Response, error) {
 	return resp, err
 }
 
+type MockEmail struct {
+	ToEmail  string
+	Subject  string
+	HTMLBody string
+}
+
+type MockEmailSender struct {
+	SentEmails []MockEmail
+}
+
+func (sender *MockEmailSender) SendEmail(toEmail string, subject string, htmlBody string) error {
+	sender.SentEmails = append(sender.SentEmails, MockEmail{ToEmail: toEmail, Subject: subject, HTMLBody: htmlBody})
+	return nil
+}
+
 func login(email string, name string) string {
 	recorder := makeLoginCallbackRequest("googleToken", email, name, "example-token", "example-token", true, false)
 	for _, c := range recorder.Result().Cookies() {
@@ -108,7 +123,7 @@ func newStateToken(db *mongo.Database, authToken string, useDeeplink bool) (*str
 		userID = &token.UserID
 	}
 
-	return database.CreateStateToken(db, userID, useDeeplink)
+	return database.CreateStateToken(context.Background(), db, userID, useDeeplink)
 }
 
 func makeLoginCallbackRequest(
@@ -119,6 +134,18 @@ func makeLoginCallbackRequest(
 	stateTokenCookie string,
 	skipStateTokenCheck bool,
 	skipRefreshToken bool,
+) *httptest.ResponseRecorder {
+	userInfo := fmt.Sprintf("{\"sub\": \"goog12345_%s\", \"email\": \"%s\", \"name\": \"%s\"}", email, email, name)
+	return makeLoginCallbackRequestWithUserInfo(googleToken, userInfo, stateToken, stateTokenCookie, skipStateTokenCheck, skipRefreshToken)
+}
+
+func makeLoginCallbackRequestWithUserInfo(
+	googleToken string,
+	userInfo string,
+	stateToken string,
+	stateTokenCookie string,
+	skipStateTokenCheck bool,
+	skipRefreshToken bool,
 ) *httptest.ResponseRecorder {
 	mockConfig := MockGoogleConfig{}
 	mockToken := oauth2.Token{AccessToken: googleToken}
@@ -131,13 +158,7 @@ func makeLoginCallbackRequest(
 		"Get",
 		"https://www.googleapis.com/oauth2/v3/userinfo",
 	).Return(
-		&http.Response{
-			Body: io.NopCloser(bytes.NewBufferString(fmt.Sprintf(
-				"{\"sub\": \"goog12345_%s\", \"email\": \"%s\", \"name\": \"%s\"}",
-				email,
-				email,
-				name,
-			)))},
+		&http.Response{Body: io.NopCloser(bytes.NewBufferString(userInfo))},
 		nil,
 	)
 	mockClient.On(
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/unfurl"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type UnfurlParams struct {
+	URLs   []string `json:"urls"`
+	TaskID *string  `json:"task_id"`
+	NoteID *string  `json:"note_id"`
+}
+
+// Unfurl returns preview cards for the given links and for the links in the body of the given task
+// or note. Links without a preview are left out rather than failing the request
+func (api *API) Unfurl(c *gin.Context) {
+	var params UnfurlParams
+	err := c.BindJSON(&params)
+	if err != nil || (len(params.URLs) == 0 && params.TaskID == nil && params.NoteID == nil) {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	userID := getUserIDFromContext(c)
+
+	links := params.URLs
+	if params.TaskID != nil {
+		taskID, err := primitive.ObjectIDFromHex(*params.TaskID)
+		if err != nil {
+			HandleBadRequest(c, "invalid 'task_id'", "task_id")
+			return
+		}
+		task, err := database.GetTask(c.Request.Context(), api.DB, taskID, userID)
+		if err != nil {
+			Handle404(c)
+			return
+		}
+		if task.Body != nil {
+			links = append(links, unfurl.ExtractLinks(*task.Body)...)
+		}
+	}
+	if params.NoteID != nil {
+		noteID, err := primitive.ObjectIDFromHex(*params.NoteID)
+		if err != nil {
+			HandleBadRequest(c, "invalid 'note_id'", "note_id")
+			return
+		}
+		note, err := database.GetNote(c.Request.Context(), api.DB, noteID, userID)
+		if err != nil {
+			Handle404(c)
+			return
+		}
+		if note.Body != nil {
+			links = append(links, unfurl.ExtractLinks(*note.Body)...)
+		}
+	}
+	links = getDistinctLinks(links)
+	if len(links) > unfurl.MAX_URLS_PER_REQUEST {
+		HandleBadRequest(c, fmt.Sprintf("at most %d links can be unfurled at once", unfurl.MAX_URLS_PER_REQUEST))
+		return
+	}
+
+	previews := make([]*unfurl.Preview, len(links))
+	var wg sync.WaitGroup
+	for i, link := range links {
+		wg.Add(1)
+		go func(i int, link string) {
+			defer wg.Done()
+			// broken or private links are expected here, so failures aren't logged
+			preview, err := api.Unfurler.Unfurl(c.Request.Context(), link)
+			if err == nil {
+				previews[i] = preview
+			}
+		}(i, link)
+	}
+	wg.Wait()
+
+	results := []*unfurl.Preview{}
+	for _, preview := range previews {
+		if preview != nil {
+			results = append(results, preview)
+		}
+	}
+	c.JSON(200, results)
+}
+
+func getDistinctLinks(links []string) []string {
+	distinctLinks := []string{}
+	seen := map[string]bool{}
+	for _, link := range links {
+		if seen[link] {
+			continue
+		}
+		seen[link] = true
+		distinctLinks = append(distinctLinks, link)
+	}
+	return distinctLinks
+}
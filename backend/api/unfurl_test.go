@@ -0,0 +1,55 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestUnfurl(t *testing.T) {
+	authToken := login("test_unfurl@resonant-kelpie-404a42.netlify.app", "")
+	otherToken := login("test_unfurl_other@resonant-kelpie-404a42.netlify.app", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+
+	// links to internal addresses are never fetched, so these don't produce previews
+	body := "spec is at http://127.0.0.1/spec and http://169.254.169.254/latest/meta-data/"
+	taskResult, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), database.Task{
+		UserID: userID,
+		Body:   &body,
+	})
+	assert.NoError(t, err)
+	taskID := taskResult.InsertedID.(primitive.ObjectID)
+
+	UnauthorizedTest(t, "POST", "/unfurl/", nil)
+	t.Run("MissingParams", func(t *testing.T) {
+		ServeRequest(t, authToken, "POST", "/unfurl/", bytes.NewBuffer([]byte(`{}`)), http.StatusBadRequest, api)
+	})
+	t.Run("InvalidTaskID", func(t *testing.T) {
+		response := ServeRequest(t, authToken, "POST", "/unfurl/", bytes.NewBuffer([]byte(`{"task_id": "123"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"invalid 'task_id'","code":"invalid_parameter","field_errors":[{"field":"task_id"}]}`, string(response))
+	})
+	t.Run("TaskNotOwned", func(t *testing.T) {
+		ServeRequest(t, otherToken, "POST", "/unfurl/", bytes.NewBuffer([]byte(fmt.Sprintf(`{"task_id": "%s"}`, taskID.Hex()))), http.StatusNotFound, api)
+	})
+	t.Run("TooManyLinks", func(t *testing.T) {
+		links := []string{}
+		for i := 0; i < 11; i++ {
+			links = append(links, fmt.Sprintf(`"https://example.com/%d"`, i))
+		}
+		response := ServeRequest(t, authToken, "POST", "/unfurl/", bytes.NewBuffer([]byte(`{"urls": [`+strings.Join(links, ",")+`]}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"at most 10 links can be unfurled at once","code":"bad_request"}`, string(response))
+	})
+	t.Run("InternalLinksSkipped", func(t *testing.T) {
+		response := ServeRequest(t, authToken, "POST", "/unfurl/", bytes.NewBuffer([]byte(fmt.Sprintf(`{"task_id": "%s", "urls": ["file:///etc/passwd"]}`, taskID.Hex()))), http.StatusOK, api)
+		assert.Equal(t, `[]`, string(response))
+	})
+}
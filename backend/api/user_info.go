@@ -65,7 +65,7 @@ func (api *API) UserInfoUpdate(c *gin.Context) {
 	err := c.BindJSON(&params)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("error")
-		c.JSON(400, gin.H{"detail": "invalid or missing parameters."})
+		HandleBindError(c, &params, err, "invalid or missing parameters.")
 		return
 	}
 
@@ -73,7 +73,7 @@ func TestUserInfo(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"invalid or missing parameters.\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"invalid or missing parameters.\",\"code\":\"bad_request\"}", string(body))
 	})
 	t.Run("BadPayload", func(t *testing.T) {
 		api, dbCleanup := GetAPIWithDBCleanup()
@@ -89,7 +89,7 @@ func TestUserInfo(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"invalid or missing parameters.\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"invalid or missing parameters.\",\"code\":\"bad_request\"}", string(body))
 	})
 	t.Run("SuccessUpdate", func(t *testing.T) {
 		api, dbCleanup := GetAPIWithDBCleanup()
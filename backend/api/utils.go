@@ -4,16 +4,21 @@ import (
 	"context"
 	"errors"
 	"net/http"
-	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"golang.org/x/exp/slices"
 
+	"github.com/franchizzle/task-manager/backend/cache"
+	"github.com/franchizzle/task-manager/backend/collab"
 	"github.com/franchizzle/task-manager/backend/config"
+	"github.com/franchizzle/task-manager/backend/constants"
 	"github.com/franchizzle/task-manager/backend/database"
 	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/franchizzle/task-manager/backend/llm"
 	"github.com/franchizzle/task-manager/backend/logging"
+	"github.com/franchizzle/task-manager/backend/unfurl"
+	"github.com/franchizzle/task-manager/backend/utils"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -31,6 +36,12 @@ type API struct {
 	OverrideTime        *time.Time
 	DB                  *mongo.Database
 	DBCleanup           func()
+	EmailSender         utils.EmailSender
+	NoteEditors         *collab.Hub
+	Changes             *database.ChangeBroker
+	Unfurler            *unfurl.Unfurler
+	LLMClient           llm.LLMClient
+	SuggestionCache     cache.Cache
 }
 
 func GetAPIWithDBCleanup() (*API, func()) {
@@ -38,23 +49,30 @@ func GetAPIWithDBCleanup() (*API, func()) {
 	if err != nil {
 		log.Fatal().Msgf("Failed to connect to db, %+v", err)
 	}
-	return &API{ExternalConfig: external.GetConfig(), SkipStateTokenCheck: false, Logger: *logging.GetSentryLogger(), DB: dbh.DB}, dbh.CloseConnection
+	api := &API{ExternalConfig: external.GetConfig(), SkipStateTokenCheck: false, Logger: *logging.GetSentryLogger(), DB: dbh.DB, EmailSender: utils.MandrillEmailSender{}}
+	api.NoteEditors = collab.NewHub(api.saveCollaborativeNote)
+	api.Changes = database.NewChangeBroker(dbh.DB, getStreamCollections())
+	api.Unfurler = unfurl.NewUnfurler()
+	api.SuggestionCache = cache.NewMemoryCache(getOverviewSuggestionCacheTTL(), constants.OVERVIEW_SUGGESTION_CACHE_MAX_ENTRIES)
+	api.LLMClient, err = llm.NewClient(llm.GetConfig())
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to configure llm provider")
+	}
+	return api, dbh.CloseConnection
 }
 
 func getTokenFromCookie(c *gin.Context, db *mongo.Database) (*database.InternalAPIToken, error) {
 	authToken, err := c.Cookie("authToken")
 	if err != nil {
-		c.JSON(401, gin.H{"detail": "missing authToken cookie"})
+		HandleError(c, ErrorCodeUnauthorized, "missing authToken cookie")
 		return nil, errors.New("invalid auth token")
 	}
-	internalAPITokenCollection := database.GetInternalTokenCollection(db)
-	var internalToken database.InternalAPIToken
-	err = internalAPITokenCollection.FindOne(context.Background(), bson.M{"token": authToken}).Decode(&internalToken)
+	internalToken, err := database.GetInternalToken(c.Request.Context(), db, authToken)
 	if err != nil {
-		c.JSON(401, gin.H{"detail": "invalid auth token"})
+		HandleError(c, ErrorCodeUnauthorized, "invalid auth token")
 		return nil, errors.New("invalid auth token")
 	}
-	return &internalToken, nil
+	return internalToken, nil
 }
 
 // Ping godoc
@@ -80,12 +98,33 @@ func BusinessMiddleware(db *mongo.Database) func(c *gin.Context) {
 		var userObject database.User
 		err := userCollection.FindOne(context.Background(), bson.M{"_id": userID}).Decode(&userObject)
 		if err != nil || userObject.BusinessModeEnabled == nil || !*userObject.BusinessModeEnabled {
-			c.AbortWithStatusJSON(403, gin.H{"detail": "business access is required to use this endpoint"})
+			AbortWithAPIError(c, NewAPIError(ErrorCodeForbidden, "business access is required to use this endpoint"))
 			return
 		}
 	}
 }
 
+// AdminMiddleware limits the /admin/ endpoints to support users, outside of any impersonation session
+func AdminMiddleware(db *mongo.Database) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		handlerName := c.HandlerName()
+		if handlerName[len(handlerName)-9:] == "Handle404" {
+			// Do nothing if the route isn't recognized
+			return
+		}
+		if _, isImpersonating := c.Get("impersonator"); isImpersonating {
+			AbortWithAPIError(c, NewAPIError(ErrorCodeForbidden, "admin access is required to use this endpoint"))
+			return
+		}
+		user, err := database.GetUser(c.Request.Context(), db, getUserIDFromContext(c))
+		if err != nil || !adminRoles[user.Role] {
+			AbortWithAPIError(c, NewAPIError(ErrorCodeForbidden, "admin access is required to use this endpoint"))
+			return
+		}
+		c.Set("admin_role", user.Role)
+	}
+}
+
 // Middleware to get the user token from the request if it exists
 func UserTokenMiddleware(db *mongo.Database) func(c *gin.Context) {
 	return func(c *gin.Context) {
@@ -99,15 +138,36 @@ func UserTokenMiddleware(db *mongo.Database) func(c *gin.Context) {
 			// This means the auth token format was incorrect
 			return
 		}
-		internalAPITokenCollection := database.GetInternalTokenCollection(db)
-		var internalToken database.InternalAPIToken
-		err = internalAPITokenCollection.FindOne(context.Background(), bson.M{"token": token}).Decode(&internalToken)
-		if err == nil {
-			c.Set("user", internalToken.UserID)
+		internalToken, err := database.GetInternalToken(c.Request.Context(), db, token)
+		if err != nil {
+			return
+		}
+		c.Set("user", internalToken.UserID)
+		if internalToken.ImpersonatorID == primitive.NilObjectID {
+			renewSessionIfStale(c, db, internalToken)
+			return
+		}
+		c.Set("impersonator", internalToken.ImpersonatorID)
+		if !slices.Contains([]string{http.MethodGet, http.MethodHead, http.MethodOptions}, c.Request.Method) {
+			AbortWithAPIError(c, NewAPIError(ErrorCodeForbidden, "impersonation sessions are read-only"))
 		}
 	}
 }
 
+// renewSessionIfStale slides the session's expiry forward, writing at most once per renewal interval
+// rather than on every request
+func renewSessionIfStale(c *gin.Context, db *mongo.Database, internalToken *database.InternalAPIToken) {
+	now := time.Now()
+	renewAfter := internalToken.LastUsedAt.Time().Add(time.Duration(constants.SESSION_RENEWAL_INTERVAL) * time.Second)
+	if internalToken.ExpiresAt != 0 && now.Before(renewAfter) {
+		return
+	}
+	err := database.RenewInternalToken(c.Request.Context(), db, internalToken.ID, c.Request.UserAgent(), c.ClientIP(), now)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to renew session")
+	}
+}
+
 func AuthorizationMiddleware(db *mongo.Database) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		handlerName := c.HandlerName()
@@ -119,11 +179,11 @@ func AuthorizationMiddleware(db *mongo.Database) func(c *gin.Context) {
 			_, err := getToken(c)
 			if err != nil {
 				// This means the auth token format was incorrect
-				c.AbortWithStatusJSON(401, gin.H{"detail": "incorrect auth token format"})
+				AbortWithAPIError(c, NewAPIError(ErrorCodeUnauthorized, "incorrect auth token format"))
 				return
 			}
 			log.Error().Err(err).Msg("token auth failed")
-			c.AbortWithStatusJSON(401, gin.H{"detail": "unauthorized"})
+			AbortWithAPIError(c, NewAPIError(ErrorCodeUnauthorized, "unauthorized"))
 		}
 	}
 }
@@ -139,7 +199,7 @@ func LoggingMiddleware(db *mongo.Database) func(c *gin.Context) {
 		if !exists {
 			userID = primitive.NilObjectID
 		}
-		err := database.InsertLogEvent(db, userID.(primitive.ObjectID), eventType)
+		err := database.InsertLogEvent(c.Request.Context(), db, userID.(primitive.ObjectID), eventType)
 		if err != nil {
 			logger := logging.GetSentryLogger()
 			logger.Error().Err(err).Msg("error inserting log event")
@@ -159,15 +219,16 @@ func getToken(c *gin.Context) (string, error) {
 
 // CORSMiddleware sets CORS headers, abort if CORS preflight request is received
 func CORSMiddleware(c *gin.Context) {
-	allowedOrigins := strings.Split(config.GetConfigValue("ACCESS_CONTROL_ALLOW_ORIGINS"), ",")
-	if origin := c.Request.Header.Get("Origin"); slices.Contains(allowedOrigins, origin) {
+	settings := config.GetSettings()
+	if origin := c.Request.Header.Get("Origin"); slices.Contains(settings.AccessControlAllowOrigins, origin) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
 	} else {
-		c.Writer.Header().Set("Access-Control-Allow-Origin", config.GetConfigValue("DEFAULT_ACCESS_CONTROL_ALLOW_ORIGIN"))
+		c.Writer.Header().Set("Access-Control-Allow-Origin", settings.DefaultAccessControlAllowOrigin)
 	}
 
-	c.Writer.Header().Set("Access-Control-Allow-Headers", "Authorization,Access-Control-Allow-Origin,Access-Control-Allow-Headers,Access-Control-Allow-Methods,Content-Type,Timezone-Offset,sentry-trace,baggage")
+	c.Writer.Header().Set("Access-Control-Allow-Headers", "Authorization,Access-Control-Allow-Origin,Access-Control-Allow-Headers,Access-Control-Allow-Methods,Content-Type,Timezone-Offset,If-None-Match,Share-Password,sentry-trace,baggage")
 	c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, PATCH, DELETE")
+	c.Writer.Header().Set("Access-Control-Expose-Headers", "ETag")
 	if c.Request.Method == "OPTIONS" {
 		c.AbortWithStatus(http.StatusNoContent)
 	}
@@ -175,11 +236,11 @@ func CORSMiddleware(c *gin.Context) {
 }
 
 func Handle404(c *gin.Context) {
-	c.JSON(404, gin.H{"detail": "not found"})
+	HandleError(c, ErrorCodeNotFound, "not found")
 }
 
 func Handle500(c *gin.Context) {
-	c.JSON(500, gin.H{"detail": "internal server error"})
+	HandleError(c, ErrorCodeInternal, "internal server error")
 }
 
 func FakeLagMiddleware(c *gin.Context) {
@@ -189,7 +250,7 @@ func FakeLagMiddleware(c *gin.Context) {
 }
 
 func isLocalServer() bool {
-	return config.GetConfigValue("DB_NAME") == "main" && config.GetEnvironment() == config.Dev
+	return config.GetSettings().DBName == "main" && config.GetEnvironment() == config.Dev
 }
 
 func LogRequestMiddleware(db *mongo.Database) func(c *gin.Context) {
@@ -220,7 +281,8 @@ func LogRequestMiddleware(db *mongo.Database) func(c *gin.Context) {
 		}
 
 		status := c.Writer.Status()
-		database.LogRequestInfo(db, startTime, userObjectID, c.Request.URL.Path, time.Now().UnixMilli()-startTime.UnixMilli(), &objectID, status)
+		// the request context is cancelled once the client disconnects, which shouldn't drop the log
+		database.LogRequestInfo(context.Background(), db, startTime, userObjectID, c.Request.URL.Path, time.Now().UnixMilli()-startTime.UnixMilli(), &objectID, status)
 	}
 }
 
@@ -23,7 +23,7 @@ func TestCORSHeaders(t *testing.T) {
 
 		assert.Equal(t, http.StatusNoContent, recorder.Code)
 		headers := recorder.Result().Header
-		assert.Equal(t, "Authorization,Access-Control-Allow-Origin,Access-Control-Allow-Headers,Access-Control-Allow-Methods,Content-Type,Timezone-Offset,sentry-trace,baggage",
+		assert.Equal(t, "Authorization,Access-Control-Allow-Origin,Access-Control-Allow-Headers,Access-Control-Allow-Methods,Content-Type,Timezone-Offset,If-None-Match,Share-Password,sentry-trace,baggage",
 			headers.Get("Access-Control-Allow-Headers"))
 		assert.Equal(t, "http://localhost:3000", headers.Get("Access-Control-Allow-Origin"))
 		assert.Equal(t, "POST, OPTIONS, GET, PUT, PATCH, DELETE", headers.Get("Access-Control-Allow-Methods"))
@@ -36,7 +36,7 @@ func TestCORSHeaders(t *testing.T) {
 
 		assert.Equal(t, http.StatusNoContent, recorder.Code)
 		headers := recorder.Result().Header
-		assert.Equal(t, "Authorization,Access-Control-Allow-Origin,Access-Control-Allow-Headers,Access-Control-Allow-Methods,Content-Type,Timezone-Offset,sentry-trace,baggage",
+		assert.Equal(t, "Authorization,Access-Control-Allow-Origin,Access-Control-Allow-Headers,Access-Control-Allow-Methods,Content-Type,Timezone-Offset,If-None-Match,Share-Password,sentry-trace,baggage",
 			headers.Get("Access-Control-Allow-Headers"))
 		assert.Equal(t, "http://mobile.localhost.com:3000", headers.Get("Access-Control-Allow-Origin"))
 		assert.Equal(t, "POST, OPTIONS, GET, PUT, PATCH, DELETE", headers.Get("Access-Control-Allow-Methods"))
@@ -50,7 +50,7 @@ func TestCORSHeaders(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, recorder.Code)
 		headers := recorder.Result().Header
-		assert.Equal(t, "Authorization,Access-Control-Allow-Origin,Access-Control-Allow-Headers,Access-Control-Allow-Methods,Content-Type,Timezone-Offset,sentry-trace,baggage",
+		assert.Equal(t, "Authorization,Access-Control-Allow-Origin,Access-Control-Allow-Headers,Access-Control-Allow-Methods,Content-Type,Timezone-Offset,If-None-Match,Share-Password,sentry-trace,baggage",
 			headers.Get("Access-Control-Allow-Headers"))
 		assert.Equal(t, "http://localhost:3000", headers.Get("Access-Control-Allow-Origin"))
 		assert.Equal(t, "POST, OPTIONS, GET, PUT, PATCH, DELETE", headers.Get("Access-Control-Allow-Methods"))
@@ -65,7 +65,7 @@ func TestCORSHeaders(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, recorder.Code)
 		headers := recorder.Result().Header
-		assert.Equal(t, "Authorization,Access-Control-Allow-Origin,Access-Control-Allow-Headers,Access-Control-Allow-Methods,Content-Type,Timezone-Offset,sentry-trace,baggage",
+		assert.Equal(t, "Authorization,Access-Control-Allow-Origin,Access-Control-Allow-Headers,Access-Control-Allow-Methods,Content-Type,Timezone-Offset,If-None-Match,Share-Password,sentry-trace,baggage",
 			headers.Get("Access-Control-Allow-Headers"))
 		assert.Equal(t, "http://mobile.localhost.com:3000", headers.Get("Access-Control-Allow-Origin"))
 		assert.Equal(t, "POST, OPTIONS, GET, PUT, PATCH, DELETE", headers.Get("Access-Control-Allow-Methods"))
@@ -84,7 +84,7 @@ func TestAuthenticationMiddleware(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"incorrect auth token format\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"incorrect auth token format\",\"code\":\"unauthorized\"}", string(body))
 	})
 
 	t.Run("InvalidToken", func(t *testing.T) {
@@ -92,7 +92,7 @@ func TestAuthenticationMiddleware(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"unauthorized\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"unauthorized\",\"code\":\"unauthorized\"}", string(body))
 	})
 
 	t.Run("Valid", func(t *testing.T) {
@@ -112,7 +112,7 @@ func TestBusinessMiddleware(t *testing.T) {
 		assert.Equal(t, http.StatusForbidden, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"business access is required to use this endpoint\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"business access is required to use this endpoint\",\"code\":\"forbidden\"}", string(body))
 	})
 
 	t.Run("Success", func(t *testing.T) {
@@ -210,7 +210,7 @@ func Test404(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"not found\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"not found\",\"code\":\"unauthorized\"}", string(body))
 	})
 }
 
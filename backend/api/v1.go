@@ -0,0 +1,328 @@
+package api
+
+import (
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// The v1 result types are the public contract for third-party integrators. Fields can be added,
+// but renaming or removing one needs a new API version, so don't reuse the frontend result types here
+
+type V1Task struct {
+	ID                 string     `json:"id"`
+	Title              string     `json:"title"`
+	Body               string     `json:"body"`
+	SourceID           string     `json:"source_id" doc:"where the task came from, e.g. gt_task, jira, linear"`
+	Deeplink           string     `json:"deeplink,omitempty"`
+	SectionID          string     `json:"section_id,omitempty"`
+	ParentTaskID       string     `json:"parent_task_id,omitempty"`
+	AssigneeID         string     `json:"assignee_id,omitempty"`
+	PriorityNormalized *float64   `json:"priority_normalized,omitempty" doc:"0 is unset, 1 is the highest priority"`
+	DueDate            *time.Time `json:"due_date,omitempty"`
+	IsCompleted        bool       `json:"is_completed"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          *time.Time `json:"updated_at,omitempty"`
+	CompletedAt        *time.Time `json:"completed_at,omitempty"`
+}
+
+type V1Note struct {
+	ID            string     `json:"id"`
+	Title         string     `json:"title"`
+	Body          string     `json:"body"`
+	Author        string     `json:"author"`
+	LinkedEventID string     `json:"linked_event_id,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	SharedUntil   *time.Time `json:"shared_until,omitempty" doc:"the note is viewable by anyone with the link until this time"`
+}
+
+type V1Event struct {
+	ID             string    `json:"id"`
+	Title          string    `json:"title"`
+	Body           string    `json:"body"`
+	Location       string    `json:"location"`
+	SourceID       string    `json:"source_id"`
+	AccountID      string    `json:"account_id"`
+	CalendarID     string    `json:"calendar_id"`
+	Deeplink       string    `json:"deeplink,omitempty"`
+	CallURL        string    `json:"call_url,omitempty"`
+	AttendeeEmails []string  `json:"attendee_emails"`
+	DatetimeStart  time.Time `json:"datetime_start"`
+	DatetimeEnd    time.Time `json:"datetime_end"`
+}
+
+type V1PullRequest struct {
+	ID             string     `json:"id"`
+	Title          string     `json:"title"`
+	Number         int        `json:"number"`
+	Author         string     `json:"author"`
+	RepositoryID   string     `json:"repository_id"`
+	RepositoryName string     `json:"repository_name"`
+	Branch         string     `json:"branch"`
+	BaseBranch     string     `json:"base_branch"`
+	RequiredAction string     `json:"required_action" doc:"what the user needs to do next, e.g. Review PR, Fix Failed CI"`
+	Deeplink       string     `json:"deeplink"`
+	CommentCount   int        `json:"comment_count"`
+	Additions      int        `json:"additions"`
+	Deletions      int        `json:"deletions"`
+	IsCompleted    bool       `json:"is_completed"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+}
+
+type V1ListParams struct {
+	Limit  *int    `form:"limit" doc:"page size, defaults to 100 and can be at most 500"`
+	Cursor *string `form:"cursor" doc:"next_cursor from the previous page"`
+}
+
+type V1EventListParams struct {
+	V1ListParams
+	DatetimeStart *time.Time `form:"datetime_start" binding:"required" doc:"RFC 3339, only events ending after this are returned"`
+	DatetimeEnd   *time.Time `form:"datetime_end" binding:"required" doc:"RFC 3339, only events starting before this are returned"`
+}
+
+var v1Routes = []v1Route{
+	{Method: "GET", Path: "/v1/tasks/", Summary: "Lists incomplete tasks", Tag: "tasks", Params: V1ListParams{}, Response: PaginatedResult[V1Task]{}, Handler: (*API).V1TasksList},
+	{Method: "GET", Path: "/v1/tasks/:task_id/", Summary: "Returns a task", Tag: "tasks", Response: V1Task{}, Handler: (*API).V1TaskGet},
+	{Method: "GET", Path: "/v1/notes/", Summary: "Lists notes that aren't in the trash", Tag: "notes", Params: V1ListParams{}, Response: PaginatedResult[V1Note]{}, Handler: (*API).V1NotesList},
+	{Method: "GET", Path: "/v1/notes/:note_id/", Summary: "Returns a note", Tag: "notes", Response: V1Note{}, Handler: (*API).V1NoteGet},
+	{Method: "GET", Path: "/v1/events/", Summary: "Lists calendar events in a time range", Tag: "events", Params: V1EventListParams{}, Response: PaginatedResult[V1Event]{}, Handler: (*API).V1EventsList},
+	{Method: "GET", Path: "/v1/events/:event_id/", Summary: "Returns a calendar event", Tag: "events", Response: V1Event{}, Handler: (*API).V1EventGet},
+	{Method: "GET", Path: "/v1/pull_requests/", Summary: "Lists open pull requests", Tag: "pull_requests", Params: V1ListParams{}, Response: PaginatedResult[V1PullRequest]{}, Handler: (*API).V1PullRequestsList},
+	{Method: "GET", Path: "/v1/pull_requests/:pull_request_id/", Summary: "Returns a pull request", Tag: "pull_requests", Response: V1PullRequest{}, Handler: (*API).V1PullRequestGet},
+}
+
+func registerV1Routes(router *gin.Engine, handlers *API) {
+	for _, route := range v1Routes {
+		handler := route.Handler
+		router.Handle(route.Method, route.Path, func(c *gin.Context) {
+			handler(handlers, c)
+		})
+	}
+}
+
+func (api *API) V1OpenAPISpec(c *gin.Context) {
+	c.JSON(200, buildOpenAPISpec(v1Routes))
+}
+
+func (api *API) V1TasksList(c *gin.Context) {
+	filters := []bson.M{
+		{"is_completed": false},
+		{"is_deleted": bson.M{"$ne": true}},
+	}
+	v1ListPage(api, c, database.GetTaskCollection(api.DB), filters, taskToV1Task)
+}
+
+func (api *API) V1TaskGet(c *gin.Context) {
+	taskID, err := primitive.ObjectIDFromHex(c.Param("task_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	task, err := database.GetTask(c.Request.Context(), api.DB, taskID, getUserIDFromContext(c))
+	if err != nil || (task.IsDeleted != nil && *task.IsDeleted) {
+		Handle404(c)
+		return
+	}
+	c.JSON(200, taskToV1Task(*task))
+}
+
+func (api *API) V1NotesList(c *gin.Context) {
+	filters := []bson.M{{"is_deleted": bson.M{"$ne": true}}}
+	v1ListPage(api, c, database.GetNoteCollection(api.DB), filters, noteToV1Note)
+}
+
+func (api *API) V1NoteGet(c *gin.Context) {
+	noteID, err := primitive.ObjectIDFromHex(c.Param("note_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	note, err := database.GetNote(c.Request.Context(), api.DB, noteID, getUserIDFromContext(c))
+	if err != nil || (note.IsDeleted != nil && *note.IsDeleted) {
+		Handle404(c)
+		return
+	}
+	c.JSON(200, noteToV1Note(*note))
+}
+
+func (api *API) V1EventsList(c *gin.Context) {
+	var params V1EventListParams
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	filters := []bson.M{
+		{"datetime_end": bson.M{"$gt": primitive.NewDateTimeFromTime(*params.DatetimeStart)}},
+		{"datetime_start": bson.M{"$lt": primitive.NewDateTimeFromTime(*params.DatetimeEnd)}},
+	}
+	v1ListPage(api, c, database.GetCalendarEventCollection(api.DB), filters, eventToV1Event)
+}
+
+func (api *API) V1EventGet(c *gin.Context) {
+	eventID, err := primitive.ObjectIDFromHex(c.Param("event_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	event, err := database.GetCalendarEvent(c.Request.Context(), api.DB, eventID, getUserIDFromContext(c))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	c.JSON(200, eventToV1Event(*event))
+}
+
+func (api *API) V1PullRequestsList(c *gin.Context) {
+	filters := []bson.M{{"is_completed": false}}
+	v1ListPage(api, c, database.GetPullRequestCollection(api.DB), filters, pullRequestToV1PullRequest)
+}
+
+func (api *API) V1PullRequestGet(c *gin.Context) {
+	pullRequestID, err := primitive.ObjectIDFromHex(c.Param("pull_request_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	pullRequest, err := database.GetPullRequest(c.Request.Context(), api.DB, pullRequestID, getUserIDFromContext(c))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	c.JSON(200, pullRequestToV1PullRequest(*pullRequest))
+}
+
+// v1ListPage always paginates, unlike the internal list endpoints, so integrators don't need to
+// handle two response shapes
+func v1ListPage[T any, R any](api *API, c *gin.Context, collection *mongo.Collection, filters []bson.M, toResult func(T) R) {
+	pagination, err := getPagination(c)
+	if err != nil {
+		HandleBadRequest(c, err.Error())
+		return
+	}
+	if pagination == nil {
+		pagination = &database.Pagination{}
+	}
+	items, nextCursor, err := database.FindPageWithCollection[T](c.Request.Context(), collection, getUserIDFromContext(c), &filters, *pagination)
+	if err != nil {
+		api.Logger.Error().Err(err).Msgf("failed to fetch page from %s", collection.Name())
+		Handle500(c)
+		return
+	}
+	results := []R{}
+	for _, item := range items {
+		results = append(results, toResult(item))
+	}
+	c.JSON(200, PaginatedResult[R]{Results: results, NextCursor: nextCursor})
+}
+
+func getV1Time(datetime primitive.DateTime) *time.Time {
+	if datetime == 0 {
+		return nil
+	}
+	result := datetime.Time().UTC()
+	return &result
+}
+
+func taskToV1Task(task database.Task) V1Task {
+	result := V1Task{
+		ID:                 task.ID.Hex(),
+		SourceID:           task.SourceID,
+		Deeplink:           task.Deeplink,
+		PriorityNormalized: task.PriorityNormalized,
+		IsCompleted:        task.IsCompleted != nil && *task.IsCompleted,
+		CreatedAt:          task.ID.Timestamp().UTC(),
+		UpdatedAt:          getV1Time(task.UpdatedAt),
+		CompletedAt:        getV1Time(task.CompletedAt),
+	}
+	if task.CreatedAtExternal != 0 {
+		result.CreatedAt = task.CreatedAtExternal.Time().UTC()
+	}
+	if task.Title != nil {
+		result.Title = *task.Title
+	}
+	if task.Body != nil {
+		result.Body = *task.Body
+	}
+	if task.IDTaskSection != primitive.NilObjectID {
+		result.SectionID = task.IDTaskSection.Hex()
+	}
+	if task.ParentTaskID != primitive.NilObjectID {
+		result.ParentTaskID = task.ParentTaskID.Hex()
+	}
+	if task.AssigneeID != primitive.NilObjectID {
+		result.AssigneeID = task.AssigneeID.Hex()
+	}
+	if task.DueDate != nil {
+		result.DueDate = getV1Time(*task.DueDate)
+	}
+	return result
+}
+
+func noteToV1Note(note database.Note) V1Note {
+	result := V1Note{
+		ID:          note.ID.Hex(),
+		Author:      note.Author,
+		CreatedAt:   note.CreatedAt.Time().UTC(),
+		UpdatedAt:   note.UpdatedAt.Time().UTC(),
+		SharedUntil: getV1Time(note.SharedUntil),
+	}
+	if note.Title != nil {
+		result.Title = *note.Title
+	}
+	if note.Body != nil {
+		result.Body = *note.Body
+	}
+	if note.LinkedEventID != primitive.NilObjectID {
+		result.LinkedEventID = note.LinkedEventID.Hex()
+	}
+	return result
+}
+
+func eventToV1Event(event database.CalendarEvent) V1Event {
+	attendeeEmails := event.AttendeeEmails
+	if attendeeEmails == nil {
+		attendeeEmails = []string{}
+	}
+	return V1Event{
+		ID:             event.ID.Hex(),
+		Title:          event.Title,
+		Body:           event.Body,
+		Location:       event.Location,
+		SourceID:       event.SourceID,
+		AccountID:      event.SourceAccountID,
+		CalendarID:     event.CalendarID,
+		Deeplink:       event.Deeplink,
+		CallURL:        event.CallURL,
+		AttendeeEmails: attendeeEmails,
+		DatetimeStart:  event.DatetimeStart.Time().UTC(),
+		DatetimeEnd:    event.DatetimeEnd.Time().UTC(),
+	}
+}
+
+func pullRequestToV1PullRequest(pullRequest database.PullRequest) V1PullRequest {
+	return V1PullRequest{
+		ID:             pullRequest.ID.Hex(),
+		Title:          pullRequest.Title,
+		Number:         pullRequest.Number,
+		Author:         pullRequest.Author,
+		RepositoryID:   pullRequest.RepositoryID,
+		RepositoryName: pullRequest.RepositoryName,
+		Branch:         pullRequest.Branch,
+		BaseBranch:     pullRequest.BaseBranch,
+		RequiredAction: pullRequest.RequiredAction,
+		Deeplink:       pullRequest.Deeplink,
+		CommentCount:   pullRequest.CommentCount,
+		Additions:      pullRequest.Additions,
+		Deletions:      pullRequest.Deletions,
+		IsCompleted:    pullRequest.IsCompleted != nil && *pullRequest.IsCompleted,
+		CreatedAt:      getV1Time(pullRequest.CreatedAtExternal),
+		UpdatedAt:      getV1Time(pullRequest.LastUpdatedAt),
+	}
+}
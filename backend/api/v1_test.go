@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestV1API(t *testing.T) {
+	authToken := login("test_v1_api@resonant-kelpie-404a42.netlify.app", "")
+	otherToken := login("test_v1_api_other@resonant-kelpie-404a42.netlify.app", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	ctx := context.Background()
+
+	completedFalse := false
+	completedTrue := true
+	title := "ship the public api"
+	completedTitle := "already shipped"
+	dueDate := primitive.NewDateTimeFromTime(time.Date(2023, time.May, 1, 0, 0, 0, 0, time.UTC))
+	task, err := database.GetOrCreateTask(ctx, api.DB, userID, "v1_task", "gt_task", &database.Task{
+		UserID:      userID,
+		Title:       &title,
+		IsCompleted: &completedFalse,
+		SourceID:    "gt_task",
+		DueDate:     &dueDate,
+	})
+	assert.NoError(t, err)
+	_, err = database.GetOrCreateTask(ctx, api.DB, userID, "v1_task_completed", "gt_task", &database.Task{
+		UserID:      userID,
+		Title:       &completedTitle,
+		IsCompleted: &completedTrue,
+		SourceID:    "gt_task",
+	})
+	assert.NoError(t, err)
+	noteTitle := "api notes"
+	noteResult, err := database.GetNoteCollection(api.DB).InsertOne(ctx, database.Note{UserID: userID, Title: &noteTitle})
+	assert.NoError(t, err)
+	noteID := noteResult.InsertedID.(primitive.ObjectID)
+	eventStart := time.Date(2023, time.May, 1, 15, 0, 0, 0, time.UTC)
+	eventResult, err := database.GetCalendarEventCollection(api.DB).InsertOne(ctx, database.CalendarEvent{
+		UserID:        userID,
+		Title:         "api review",
+		DatetimeStart: primitive.NewDateTimeFromTime(eventStart),
+		DatetimeEnd:   primitive.NewDateTimeFromTime(eventStart.Add(time.Hour)),
+	})
+	assert.NoError(t, err)
+	eventID := eventResult.InsertedID.(primitive.ObjectID)
+	pullRequestResult, err := database.GetPullRequestCollection(api.DB).InsertOne(ctx, database.PullRequest{
+		UserID:      userID,
+		Title:       "add v1 api",
+		Number:      12,
+		IsCompleted: &completedFalse,
+	})
+	assert.NoError(t, err)
+	pullRequestID := pullRequestResult.InsertedID.(primitive.ObjectID)
+
+	UnauthorizedTest(t, "GET", "/v1/tasks/", nil)
+	t.Run("TasksList", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "GET", "/v1/tasks/", nil, http.StatusOK, api)
+		var result PaginatedResult[V1Task]
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, 1, len(result.Results))
+		assert.Equal(t, task.ID.Hex(), result.Results[0].ID)
+		assert.Equal(t, title, result.Results[0].Title)
+		assert.Equal(t, dueDate.Time().UTC(), *result.Results[0].DueDate)
+		assert.Empty(t, result.NextCursor)
+	})
+	t.Run("TaskGet", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "GET", "/v1/tasks/"+task.ID.Hex()+"/", nil, http.StatusOK, api)
+		var result V1Task
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, "gt_task", result.SourceID)
+		ServeRequest(t, otherToken, "GET", "/v1/tasks/"+task.ID.Hex()+"/", nil, http.StatusNotFound, api)
+		ServeRequest(t, authToken, "GET", "/v1/tasks/not_an_id/", nil, http.StatusNotFound, api)
+	})
+	t.Run("InvalidPagination", func(t *testing.T) {
+		ServeRequest(t, authToken, "GET", "/v1/tasks/?limit=0", nil, http.StatusBadRequest, api)
+	})
+	t.Run("Notes", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "GET", "/v1/notes/", nil, http.StatusOK, api)
+		var result PaginatedResult[V1Note]
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, 1, len(result.Results))
+		assert.Equal(t, noteTitle, result.Results[0].Title)
+		ServeRequest(t, authToken, "GET", "/v1/notes/"+noteID.Hex()+"/", nil, http.StatusOK, api)
+		ServeRequest(t, otherToken, "GET", "/v1/notes/"+noteID.Hex()+"/", nil, http.StatusNotFound, api)
+	})
+	t.Run("Events", func(t *testing.T) {
+		ServeRequest(t, authToken, "GET", "/v1/events/", nil, http.StatusBadRequest, api)
+		body := ServeRequest(t, authToken, "GET", "/v1/events/?datetime_start=2023-05-01T00:00:00Z&datetime_end=2023-05-02T00:00:00Z", nil, http.StatusOK, api)
+		var result PaginatedResult[V1Event]
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, 1, len(result.Results))
+		assert.Equal(t, eventID.Hex(), result.Results[0].ID)
+		assert.Equal(t, []string{}, result.Results[0].AttendeeEmails)
+		body = ServeRequest(t, authToken, "GET", "/v1/events/?datetime_start=2023-05-02T00:00:00Z&datetime_end=2023-05-03T00:00:00Z", nil, http.StatusOK, api)
+		assert.Equal(t, `{"results":[]}`, string(body))
+		ServeRequest(t, authToken, "GET", "/v1/events/"+eventID.Hex()+"/", nil, http.StatusOK, api)
+	})
+	t.Run("PullRequests", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "GET", "/v1/pull_requests/", nil, http.StatusOK, api)
+		var result PaginatedResult[V1PullRequest]
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, 1, len(result.Results))
+		assert.Equal(t, 12, result.Results[0].Number)
+		ServeRequest(t, authToken, "GET", "/v1/pull_requests/"+pullRequestID.Hex()+"/", nil, http.StatusOK, api)
+		ServeRequest(t, otherToken, "GET", "/v1/pull_requests/"+pullRequestID.Hex()+"/", nil, http.StatusNotFound, api)
+	})
+	t.Run("OpenAPISpec", func(t *testing.T) {
+		body := ServeRequest(t, "", "GET", "/v1/openapi.json", nil, http.StatusOK, api)
+		var spec struct {
+			OpenAPI string                            `json:"openapi"`
+			Paths   map[string]map[string]interface{} `json:"paths"`
+		}
+		assert.NoError(t, json.Unmarshal(body, &spec))
+		assert.Equal(t, OPENAPI_VERSION, spec.OpenAPI)
+		for _, route := range v1Routes {
+			assert.Contains(t, spec.Paths[getOpenAPIPath(route.Path)], "get")
+		}
+	})
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Params are validated as they're bound, using the rules in their binding tags. Along with the
+// validator's own rules (required, min, max, oneof, ...) these ones are available:
+//
+//	objectid: the string is a valid hex ObjectID
+func init() {
+	engine, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	_ = engine.RegisterValidation("objectid", func(field validator.FieldLevel) bool {
+		_, err := primitive.ObjectIDFromHex(field.Field().String())
+		return err == nil
+	})
+}
+
+// HandleBindError responds to a failed bind of params. If the params broke any of their binding
+// rules, each of the fields at fault is listed along with why. Otherwise the request couldn't be
+// parsed at all, and the detail is used as it is
+func HandleBindError(c *gin.Context, params interface{}, err error, detail string, fields ...string) {
+	fieldErrors := getValidationFieldErrors(params, err)
+	if len(fieldErrors) == 0 {
+		HandleBadRequest(c, detail, fields...)
+		return
+	}
+	apiError := NewAPIError(ErrorCodeInvalidParameter, fieldErrors[0].Message)
+	apiError.FieldErrors = fieldErrors
+	HandleAPIError(c, apiError)
+}
+
+func getValidationFieldErrors(params interface{}, err error) []FieldError {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil
+	}
+	fieldErrors := []FieldError{}
+	for _, validationError := range validationErrors {
+		field := getFieldPath(reflect.TypeOf(params), validationError.StructNamespace())
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:   field,
+			Message: getValidationMessage(reflect.TypeOf(params), field, validationError),
+		})
+	}
+	return fieldErrors
+}
+
+// getFieldPath converts the validator's namespace of Go field names (TaskModifyParams.Task.Status) to
+// the field's name in the request (task.status). Embedded structs are flattened, like they are by
+// encoding/json
+func getFieldPath(paramsType reflect.Type, structNamespace string) string {
+	segments := strings.Split(structNamespace, ".")
+	path := []string{}
+	currentType := paramsType
+	for _, segment := range segments[1:] {
+		for currentType.Kind() == reflect.Pointer || currentType.Kind() == reflect.Slice || currentType.Kind() == reflect.Array || currentType.Kind() == reflect.Map {
+			currentType = currentType.Elem()
+		}
+		fieldName, index := segment, ""
+		if indexStart := strings.Index(segment, "["); indexStart >= 0 {
+			fieldName, index = segment[:indexStart], segment[indexStart:]
+		}
+		if currentType.Kind() != reflect.Struct {
+			path = append(path, segment)
+			continue
+		}
+		field, exists := currentType.FieldByName(fieldName)
+		if !exists {
+			path = append(path, segment)
+			continue
+		}
+		currentType = field.Type
+		name := getRequestFieldName(field)
+		if field.Anonymous && name == "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		path = append(path, name+index)
+	}
+	return strings.Join(path, ".")
+}
+
+// getRequestFieldName is the field's name in a JSON body or query string, if it's been given one
+func getRequestFieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name := strings.Split(field.Tag.Get(tag), ",")[0]
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return ""
+}
+
+func getValidationMessage(paramsType reflect.Type, field string, validationError validator.FieldError) string {
+	isLength := validationError.Kind() == reflect.String || validationError.Kind() == reflect.Slice || validationError.Kind() == reflect.Map
+	lengthUnit := "items"
+	if validationError.Kind() == reflect.String {
+		lengthUnit = "characters"
+	}
+	param := validationError.Param()
+	switch validationError.Tag() {
+	case "required":
+		return fmt.Sprintf("'%s' is required", field)
+	case "objectid":
+		return fmt.Sprintf("'%s' is not a valid ID", field)
+	case "email":
+		return fmt.Sprintf("'%s' is not a valid email address", field)
+	case "gtfield":
+		// the param is the Go name of a sibling field
+		namespace := validationError.StructNamespace()
+		otherField := getFieldPath(paramsType, namespace[:strings.LastIndex(namespace, ".")+1]+param)
+		return fmt.Sprintf("'%s' must be after '%s'", field, otherField)
+	case "oneof":
+		return fmt.Sprintf("'%s' must be one of: %s", field, strings.Join(strings.Fields(param), ", "))
+	case "min":
+		if isLength && param == "1" {
+			return fmt.Sprintf("'%s' cannot be empty", field)
+		} else if isLength {
+			return fmt.Sprintf("'%s' must have at least %s %s", field, param, lengthUnit)
+		} else if param == "0" {
+			return fmt.Sprintf("'%s' cannot be negative", field)
+		}
+		return fmt.Sprintf("'%s' must be at least %s", field, param)
+	case "max":
+		if isLength {
+			return fmt.Sprintf("'%s' must have at most %s %s", field, param, lengthUnit)
+		}
+		return fmt.Sprintf("'%s' must be at most %s", field, param)
+	}
+	return fmt.Sprintf("'%s' is invalid", field)
+}
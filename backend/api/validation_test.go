@@ -0,0 +1,49 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetValidationFieldErrors(t *testing.T) {
+	t.Run("NotValidationError", func(t *testing.T) {
+		assert.Nil(t, getValidationFieldErrors(&TaskModifyParams{}, assert.AnError))
+	})
+	t.Run("Valid", func(t *testing.T) {
+		title := "title"
+		params := TaskModifyParams{TaskItemChangeableFields: TaskItemChangeableFields{Title: &title}}
+		assert.NoError(t, binding.Validator.ValidateStruct(&params))
+	})
+	t.Run("NestedAndEmbeddedFields", func(t *testing.T) {
+		title := ""
+		sectionID := "oops"
+		templateID := "oops"
+		params := TaskModifyParams{
+			IDTaskSection: &sectionID,
+			TaskItemChangeableFields: TaskItemChangeableFields{
+				Title: &title,
+				Task:  TaskChangeable{RecurringTaskTemplateID: &templateID},
+			},
+		}
+		err := binding.Validator.ValidateStruct(&params)
+		assert.Equal(t, []FieldError{
+			{Field: "id_task_section", Message: "'id_task_section' is not a valid ID"},
+			{Field: "task.recurring_task_template_id", Message: "'task.recurring_task_template_id' is not a valid ID"},
+			{Field: "title", Message: "'title' cannot be empty"},
+		}, getValidationFieldErrors(&params, err))
+	})
+	t.Run("RelatedFields", func(t *testing.T) {
+		start := time.Now()
+		end := start.Add(-time.Hour)
+		params := external.EventCreateObject{DatetimeStart: &start, DatetimeEnd: &end}
+		err := binding.Validator.ValidateStruct(&params)
+		assert.Equal(t, []FieldError{
+			{Field: "account_id", Message: "'account_id' is required"},
+			{Field: "datetime_end", Message: "'datetime_end' must be after 'datetime_start'"},
+		}, getValidationFieldErrors(&params, err))
+	})
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"context"
+	"strings"
+
+	"github.com/franchizzle/task-manager/backend/config"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AdminWaitlistParams struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending approved"`
+}
+
+type AdminWaitlistApproveParams struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type WaitlistEntryResult struct {
+	ID         primitive.ObjectID `json:"id"`
+	Email      string             `json:"email"`
+	HasAccess  bool               `json:"has_access"`
+	CreatedAt  string             `json:"created_at,omitempty"`
+	ApprovedAt string             `json:"approved_at,omitempty"`
+	InviteCode string             `json:"invite_code,omitempty"`
+	ReferredBy string             `json:"referred_by,omitempty"`
+}
+
+// authorizeNewUser is the access check for signing up. While the waitlist is enabled, new users need an
+// approved waitlist entry or an invite code with uses left, and anyone else is added to the waitlist
+func (api *API) authorizeNewUser(ctx context.Context, email string, inviteCode string) error {
+	if !config.GetSettings().WaitlistEnabled {
+		return nil
+	}
+	email = strings.ToLower(email)
+	entry, err := database.GetWaitlistEntry(ctx, api.DB, email)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return err
+	}
+	if entry != nil && entry.HasAccess {
+		return nil
+	}
+	if inviteCode != "" {
+		redeemedCode, err := database.RedeemInviteCode(ctx, api.DB, inviteCode)
+		if err == nil {
+			_, err = database.ApproveWaitlistEntry(ctx, api.DB, email, primitive.NilObjectID, redeemedCode, api.GetCurrentTime())
+			return err
+		} else if err != mongo.ErrNoDocuments {
+			return err
+		}
+	}
+	if entry == nil {
+		_, err = database.JoinWaitlist(ctx, api.DB, email, api.GetCurrentTime())
+		if err != nil {
+			return err
+		}
+	}
+	return NewAPIError(ErrorCodeForbidden, "user not approved for use")
+}
+
+// AdminWaitlistList lists the waitlist oldest first, optionally only the 'pending' or 'approved' entries
+func (api *API) AdminWaitlistList(c *gin.Context) {
+	var params AdminWaitlistParams
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	filter := bson.M{}
+	if params.Status != "" {
+		filter["has_access"] = params.Status == "approved"
+	}
+	cursor, err := database.GetWaitlistCollection(api.DB).Find(c.Request.Context(), filter, options.Find().SetSort(bson.M{"created_at": 1}))
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch waitlist")
+		Handle500(c)
+		return
+	}
+	var entries []database.WaitlistEntry
+	err = cursor.All(c.Request.Context(), &entries)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch waitlist")
+		Handle500(c)
+		return
+	}
+	results := []WaitlistEntryResult{}
+	for _, entry := range entries {
+		results = append(results, getWaitlistEntryResult(entry))
+	}
+	c.JSON(200, results)
+}
+
+// AdminWaitlistApprove gives the email access, adding it to the waitlist if it isn't on there already
+func (api *API) AdminWaitlistApprove(c *gin.Context) {
+	var params AdminWaitlistApproveParams
+	err := c.ShouldBindJSON(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing 'email' parameter.", "email")
+		return
+	}
+	entry, err := database.ApproveWaitlistEntry(c.Request.Context(), api.DB, strings.ToLower(params.Email), getUserIDFromContext(c), nil, api.GetCurrentTime())
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	c.JSON(200, getWaitlistEntryResult(*entry))
+}
+
+func getWaitlistEntryResult(entry database.WaitlistEntry) WaitlistEntryResult {
+	result := WaitlistEntryResult{
+		ID:         entry.ID,
+		Email:      entry.Email,
+		HasAccess:  entry.HasAccess,
+		CreatedAt:  formatSessionTime(entry.CreatedAt),
+		ApprovedAt: formatSessionTime(entry.ApprovedAt),
+		InviteCode: entry.InviteCode,
+	}
+	if entry.ReferredBy != primitive.NilObjectID {
+		result.ReferredBy = entry.ReferredBy.Hex()
+	}
+	return result
+}
@@ -1,19 +1,18 @@
 package api
 
 import (
-	"context"
 	"strings"
-	"time"
 
 	"github.com/franchizzle/task-manager/backend/database"
 	"github.com/franchizzle/task-manager/backend/utils"
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type WaitlistParams struct {
-	Email string `json:"email"`
+	Email      string `json:"email"`
+	InviteCode string `json:"invite_code"`
 }
 
 // WaitlistAdd   godoc
@@ -23,9 +22,10 @@ type WaitlistParams struct {
 // @Accept       json
 // @Produce      json
 // @Param        email      body      string  true  "email"
+// @Param        invite_code body     string  false "invite code, which gives the email access straight away"
 // @Success      201 {object} string "success"
 // @Failure      302 {object} string "email already added"
-// @Failure      400 {object} string "invalid params"
+// @Failure      400 {object} string "invalid params or invite code"
 // @Failure      500 {object} string "internal server error"
 // @Router       /waitlist/ [post]
 func (api *API) WaitlistAdd(c *gin.Context) {
@@ -33,39 +33,60 @@ func (api *API) WaitlistAdd(c *gin.Context) {
 	err := c.BindJSON(&params)
 	if err != nil || params.Email == "" {
 		api.Logger.Error().Err(err).Msg("error")
-		c.JSON(400, gin.H{"detail": "invalid or missing 'email' parameter."})
+		HandleBindError(c, &params, err, "invalid or missing 'email' parameter.", "email")
 		return
 	}
 	if !utils.IsEmailValid(params.Email) {
-		c.JSON(400, gin.H{"detail": "invalid email format."})
+		HandleBadRequest(c, "invalid email format.", "email")
 		return
 	}
 	email := strings.ToLower(params.Email)
 
-	waitlistCollection := database.GetWaitlistCollection(api.DB)
-
-	count, err := waitlistCollection.CountDocuments(context.Background(), bson.M{"email": email})
+	if params.InviteCode != "" {
+		api.waitlistAddWithInviteCode(c, email, params.InviteCode)
+		return
+	}
+	isNew, err := database.JoinWaitlist(c.Request.Context(), api.DB, email, api.GetCurrentTime())
 	if err != nil {
-		api.Logger.Error().Err(err).Msg("failed to query waitlist")
 		Handle500(c)
 		return
 	}
-	if count > 0 {
-		c.JSON(302, gin.H{"detail": "email already exists in system"})
+	if !isNew {
+		handleWaitlistEmailExists(c)
 		return
 	}
+	c.JSON(201, gin.H{})
+}
 
-	_, err = waitlistCollection.InsertOne(
-		context.Background(),
-		&database.WaitlistEntry{
-			Email:     email,
-			CreatedAt: primitive.NewDateTimeFromTime(time.Now()),
-		},
-	)
+// waitlistAddWithInviteCode gives the email access straight away, whether or not it was already waiting
+func (api *API) waitlistAddWithInviteCode(c *gin.Context, email string, code string) {
+	entry, err := database.GetWaitlistEntry(c.Request.Context(), api.DB, email)
+	if err != nil && err != mongo.ErrNoDocuments {
+		Handle500(c)
+		return
+	}
+	if entry != nil && entry.HasAccess {
+		handleWaitlistEmailExists(c)
+		return
+	}
+	inviteCode, err := database.RedeemInviteCode(c.Request.Context(), api.DB, code)
+	if err == mongo.ErrNoDocuments {
+		HandleBadRequest(c, "invalid or used up invite code", "invite_code")
+		return
+	} else if err != nil {
+		Handle500(c)
+		return
+	}
+	_, err = database.ApproveWaitlistEntry(c.Request.Context(), api.DB, email, primitive.NilObjectID, inviteCode, api.GetCurrentTime())
 	if err != nil {
-		api.Logger.Error().Err(err).Msg("failed to insert waitlist entry")
 		Handle500(c)
 		return
 	}
 	c.JSON(201, gin.H{})
 }
+
+// handleWaitlistEmailExists keeps the 302 the waitlist has always answered a duplicate email with,
+// which clients already rely on
+func handleWaitlistEmailExists(c *gin.Context) {
+	c.JSON(302, NewAPIError(ErrorCodeAlreadyExists, "email already exists in system").Response())
+}
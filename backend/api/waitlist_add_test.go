@@ -24,7 +24,7 @@ func TestWaitlistAdd(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"invalid or missing 'email' parameter.\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"invalid or missing 'email' parameter.\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"email\"}]}", string(body))
 	})
 	t.Run("MissingEmail", func(t *testing.T) {
 		api, dbCleanup := GetAPIWithDBCleanup()
@@ -39,7 +39,7 @@ func TestWaitlistAdd(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"invalid or missing 'email' parameter.\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"invalid or missing 'email' parameter.\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"email\"}]}", string(body))
 	})
 	t.Run("BadEmail", func(t *testing.T) {
 		api, dbCleanup := GetAPIWithDBCleanup()
@@ -54,11 +54,11 @@ func TestWaitlistAdd(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		body, err := io.ReadAll(recorder.Body)
 		assert.NoError(t, err)
-		assert.Equal(t, "{\"detail\":\"invalid email format.\"}", string(body))
+		assert.Equal(t, "{\"detail\":\"invalid email format.\",\"code\":\"invalid_parameter\",\"field_errors\":[{\"field\":\"email\"}]}", string(body))
 	})
 	t.Run("Success", func(t *testing.T) {
 		sendWaitlistRequest(t, http.StatusCreated, "{}")
-		sendWaitlistRequest(t, http.StatusFound, "{\"detail\":\"email already exists in system\"}")
+		sendWaitlistRequest(t, http.StatusFound, "{\"detail\":\"email already exists in system\",\"code\":\"already_exists\"}")
 
 		db, dbCleanup, err := database.GetDBConnection()
 		assert.NoError(t, err)
@@ -96,3 +96,25 @@ func sendWaitlistRequest(t *testing.T, expectedCode int, expectedResponse string
 	assert.NoError(t, err)
 	assert.Equal(t, expectedResponse, string(body))
 }
+
+func TestWaitlistAddInviteCode(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	_, err := database.GetInviteCodeCollection(api.DB).InsertOne(context.Background(), &database.InviteCode{Code: "WAITLISTADD", MaxUses: 1})
+	assert.NoError(t, err)
+
+	t.Run("InvalidCode", func(t *testing.T) {
+		response := ServeRequest(t, "", http.MethodPost, "/waitlist/", bytes.NewBuffer([]byte(`{"email": "invite_code@tesla.moon", "invite_code": "NOPE"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"invalid or used up invite code","code":"invalid_parameter","field_errors":[{"field":"invite_code"}]}`, string(response))
+	})
+	t.Run("Success", func(t *testing.T) {
+		ServeRequest(t, "", http.MethodPost, "/waitlist/", bytes.NewBuffer([]byte(`{"email": "invite_code@tesla.moon", "invite_code": "WAITLISTADD"}`)), http.StatusCreated, api)
+		entry, err := database.GetWaitlistEntry(context.Background(), api.DB, "invite_code@tesla.moon")
+		assert.NoError(t, err)
+		assert.True(t, entry.HasAccess)
+		assert.Equal(t, "WAITLISTADD", entry.InviteCode)
+	})
+	t.Run("UsedUp", func(t *testing.T) {
+		ServeRequest(t, "", http.MethodPost, "/waitlist/", bytes.NewBuffer([]byte(`{"email": "invite_code_2@tesla.moon", "invite_code": "WAITLISTADD"}`)), http.StatusBadRequest, api)
+	})
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/config"
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestWaitlistAccess(t *testing.T) {
+	config.SetForTesting(t, "WAITLIST_ENABLED", "true")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+
+	t.Run("NotApproved", func(t *testing.T) {
+		recorder := makeLoginCallbackRequest("noice420", "waitlisted@resonant-kelpie-404a42.netlify.app", "", "example-token", "example-token", true, false)
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+		assert.Equal(t, `{"detail":"user not approved for use","code":"forbidden"}`, recorder.Body.String())
+		_, err := database.GetUserByEmail(context.Background(), api.DB, "waitlisted@resonant-kelpie-404a42.netlify.app")
+		assert.Error(t, err)
+		entry, err := database.GetWaitlistEntry(context.Background(), api.DB, "waitlisted@resonant-kelpie-404a42.netlify.app")
+		assert.NoError(t, err)
+		assert.False(t, entry.HasAccess)
+	})
+	t.Run("Approved", func(t *testing.T) {
+		_, err := database.ApproveWaitlistEntry(context.Background(), api.DB, "waitlisted@resonant-kelpie-404a42.netlify.app", primitive.NilObjectID, nil, api.GetCurrentTime())
+		assert.NoError(t, err)
+		recorder := makeLoginCallbackRequest("noice420", "waitlisted@resonant-kelpie-404a42.netlify.app", "", "example-token", "example-token", true, false)
+		assert.Equal(t, http.StatusFound, recorder.Code)
+	})
+	t.Run("ExistingUser", func(t *testing.T) {
+		config.SetForTesting(t, "WAITLIST_ENABLED", "false")
+		login("waitlist_existing@resonant-kelpie-404a42.netlify.app", "")
+		config.SetForTesting(t, "WAITLIST_ENABLED", "true")
+		recorder := makeLoginCallbackRequest("noice420", "waitlist_existing@resonant-kelpie-404a42.netlify.app", "", "example-token", "example-token", true, false)
+		assert.Equal(t, http.StatusFound, recorder.Code)
+	})
+	t.Run("InviteCode", func(t *testing.T) {
+		referrerToken := login("waitlist_referrer@resonant-kelpie-404a42.netlify.app", "")
+		referrerID := getUserIDFromAuthToken(t, api.DB, referrerToken)
+		response := ServeRequest(t, referrerToken, http.MethodGet, "/invite_codes/referral/", nil, http.StatusOK, api)
+		var inviteCode InviteCodeResult
+		assert.NoError(t, json.Unmarshal(response, &inviteCode))
+		assert.True(t, inviteCode.IsReferral)
+		assert.Equal(t, constants.REFERRAL_INVITE_CODE_MAX_USES, inviteCode.MaxUses)
+
+		err := api.authorizeNewUser(context.Background(), "Referred@resonant-kelpie-404a42.netlify.app", "WRONG")
+		assert.Equal(t, NewAPIError(ErrorCodeForbidden, "user not approved for use"), err)
+		assert.NoError(t, api.authorizeNewUser(context.Background(), "Referred@resonant-kelpie-404a42.netlify.app", inviteCode.Code))
+		entry, err := database.GetWaitlistEntry(context.Background(), api.DB, "referred@resonant-kelpie-404a42.netlify.app")
+		assert.NoError(t, err)
+		assert.True(t, entry.HasAccess)
+		assert.Equal(t, referrerID, entry.ReferredBy)
+
+		response = ServeRequest(t, referrerToken, http.MethodGet, "/invite_codes/referral/", nil, http.StatusOK, api)
+		var usedInviteCode InviteCodeResult
+		assert.NoError(t, json.Unmarshal(response, &usedInviteCode))
+		assert.Equal(t, inviteCode.Code, usedInviteCode.Code)
+		assert.Equal(t, 1, usedInviteCode.Uses)
+	})
+}
+
+func TestAdminWaitlist(t *testing.T) {
+	adminToken := login("test_admin_waitlist@resonant-kelpie-404a42.netlify.app", "")
+	userToken := login("test_admin_waitlist_user@resonant-kelpie-404a42.netlify.app", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	adminID := getUserIDFromAuthToken(t, api.DB, adminToken)
+	_, err := database.GetUserCollection(api.DB).UpdateOne(context.Background(), bson.M{"_id": adminID}, bson.M{"$set": bson.M{"role": constants.UserRoleSupport}})
+	assert.NoError(t, err)
+	_, err = database.JoinWaitlist(context.Background(), api.DB, "pending@resonant-kelpie-404a42.netlify.app", api.GetCurrentTime())
+	assert.NoError(t, err)
+
+	getWaitlistEntry := func(t *testing.T, status string, email string) *WaitlistEntryResult {
+		response := ServeRequest(t, adminToken, http.MethodGet, "/admin/waitlist/?status="+status, nil, http.StatusOK, api)
+		var results []WaitlistEntryResult
+		assert.NoError(t, json.Unmarshal(response, &results))
+		for _, result := range results {
+			if result.Email == email {
+				return &result
+			}
+		}
+		return nil
+	}
+
+	t.Run("NotAdmin", func(t *testing.T) {
+		ServeRequest(t, userToken, http.MethodGet, "/admin/waitlist/", nil, http.StatusForbidden, api)
+		ServeRequest(t, userToken, http.MethodPost, "/admin/invite_codes/create/", bytes.NewBuffer([]byte(`{"max_uses": 3}`)), http.StatusForbidden, api)
+	})
+	t.Run("InvalidStatus", func(t *testing.T) {
+		ServeRequest(t, adminToken, http.MethodGet, "/admin/waitlist/?status=oops", nil, http.StatusBadRequest, api)
+	})
+	t.Run("Approve", func(t *testing.T) {
+		assert.NotNil(t, getWaitlistEntry(t, "pending", "pending@resonant-kelpie-404a42.netlify.app"))
+
+		ServeRequest(t, adminToken, http.MethodPost, "/admin/waitlist/approve/", bytes.NewBuffer([]byte(`{"email": "oops"}`)), http.StatusBadRequest, api)
+		ServeRequest(t, adminToken, http.MethodPost, "/admin/waitlist/approve/", bytes.NewBuffer([]byte(`{"email": "Pending@resonant-kelpie-404a42.netlify.app"}`)), http.StatusOK, api)
+		assert.Nil(t, getWaitlistEntry(t, "pending", "pending@resonant-kelpie-404a42.netlify.app"))
+		approved := getWaitlistEntry(t, "approved", "pending@resonant-kelpie-404a42.netlify.app")
+		assert.NotNil(t, approved)
+		assert.True(t, approved.HasAccess)
+		assert.NotEmpty(t, approved.ApprovedAt)
+	})
+	t.Run("InviteCodes", func(t *testing.T) {
+		ServeRequest(t, adminToken, http.MethodPost, "/admin/invite_codes/create/", bytes.NewBuffer([]byte(`{"max_uses": 0}`)), http.StatusBadRequest, api)
+		response := ServeRequest(t, adminToken, http.MethodPost, "/admin/invite_codes/create/", bytes.NewBuffer([]byte(`{"max_uses": 3}`)), http.StatusCreated, api)
+		var inviteCode InviteCodeResult
+		assert.NoError(t, json.Unmarshal(response, &inviteCode))
+		assert.NotEmpty(t, inviteCode.Code)
+		assert.False(t, inviteCode.IsReferral)
+
+		response = ServeRequest(t, adminToken, http.MethodGet, "/admin/invite_codes/", nil, http.StatusOK, api)
+		var inviteCodes []InviteCodeResult
+		assert.NoError(t, json.Unmarshal(response, &inviteCodes))
+		assert.Contains(t, inviteCodes, inviteCode)
+	})
+}
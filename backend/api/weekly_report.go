@@ -0,0 +1,208 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/templating"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const WEEKLY_REPORT_LOOKBACK_DAYS = 7
+
+type WeeklyReportParams struct {
+	SendEmail bool `form:"send_email"`
+}
+
+type WeeklyReportItem struct {
+	ID       string    `json:"id"`
+	Title    string    `json:"title"`
+	SourceID string    `json:"source_id"`
+	Deeplink string    `json:"deeplink"`
+	Datetime time.Time `json:"datetime"`
+}
+
+type WeeklyReport struct {
+	DatetimeStart      time.Time          `json:"datetime_start"`
+	DatetimeEnd        time.Time          `json:"datetime_end"`
+	CompletedTasks     []WeeklyReportItem `json:"completed_tasks"`
+	MergedPullRequests []WeeklyReportItem `json:"merged_pull_requests"`
+	Meetings           []WeeklyReportItem `json:"meetings"`
+	MeetingMinutes     int                `json:"meeting_minutes"`
+	EmailSent          bool               `json:"email_sent"`
+}
+
+// WeeklyReport godoc
+// @Summary      Returns a summary of the past week
+// @Description  Aggregates tasks completed, pull requests merged, and meetings attended over the last seven days
+// @Tags         reports
+// @Produce      json
+// @Param        send_email  query     bool  false  "also email the report to the user"
+// @Success      200 {object} WeeklyReport
+// @Failure      400 {object} string "invalid params"
+// @Failure      500 {object} string "internal server error"
+// @Router       /reports/weekly/ [get]
+func (api *API) WeeklyReport(c *gin.Context) {
+	var params WeeklyReportParams
+	err := c.BindQuery(&params)
+	if err != nil {
+		HandleBindError(c, &params, err, "invalid or missing parameter")
+		return
+	}
+	userID := getUserIDFromContext(c)
+
+	datetimeEnd := api.GetCurrentTime()
+	datetimeStart := datetimeEnd.AddDate(0, 0, -WEEKLY_REPORT_LOOKBACK_DAYS)
+	report, err := api.GetWeeklyReport(c.Request.Context(), userID, datetimeStart, datetimeEnd)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to generate weekly report")
+		Handle500(c)
+		return
+	}
+
+	if params.SendEmail {
+		user, err := database.GetUser(c.Request.Context(), api.DB, userID)
+		if err != nil {
+			Handle500(c)
+			return
+		}
+		htmlBody, err := templating.RenderEmail(getWeeklyReportEmailContent(report))
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to render weekly report email")
+			Handle500(c)
+			return
+		}
+		err = api.EmailSender.SendEmail(user.Email, "Your week in review", htmlBody)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to send weekly report email")
+			Handle500(c)
+			return
+		}
+		report.EmailSent = true
+	}
+	c.JSON(200, report)
+}
+
+func (api *API) GetWeeklyReport(ctx context.Context, userID primitive.ObjectID, datetimeStart time.Time, datetimeEnd time.Time) (*WeeklyReport, error) {
+	report := WeeklyReport{
+		DatetimeStart:      datetimeStart,
+		DatetimeEnd:        datetimeEnd,
+		CompletedTasks:     []WeeklyReportItem{},
+		MergedPullRequests: []WeeklyReportItem{},
+		Meetings:           []WeeklyReportItem{},
+	}
+
+	tasks, err := database.GetTasks(ctx, api.DB, userID, &[]bson.M{
+		{"is_completed": true},
+		{"is_deleted": bson.M{"$ne": true}},
+		{"completed_at": bson.M{"$gte": datetimeStart}},
+		{"completed_at": bson.M{"$lte": datetimeEnd}},
+	}, options.Find().SetSort(bson.M{"completed_at": 1}))
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range *tasks {
+		title := ""
+		if task.Title != nil {
+			title = *task.Title
+		}
+		report.CompletedTasks = append(report.CompletedTasks, WeeklyReportItem{
+			ID:       task.ID.Hex(),
+			Title:    title,
+			SourceID: task.SourceID,
+			Deeplink: task.Deeplink,
+			Datetime: task.CompletedAt.Time(),
+		})
+	}
+
+	pullRequests, err := database.GetPullRequests(ctx, api.DB, userID, &[]bson.M{
+		{"merged_at": bson.M{"$gte": datetimeStart}},
+		{"merged_at": bson.M{"$lte": datetimeEnd}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, pullRequest := range *pullRequests {
+		report.MergedPullRequests = append(report.MergedPullRequests, WeeklyReportItem{
+			ID:       pullRequest.ID.Hex(),
+			Title:    pullRequest.Title,
+			SourceID: pullRequest.SourceID,
+			Deeplink: pullRequest.Deeplink,
+			Datetime: pullRequest.MergedAt.Time(),
+		})
+	}
+
+	// out of office, focus time and working location events, as well as events linked to tasks,
+	// views, or pull requests, aren't meetings
+	events, err := database.GetCalendarEvents(ctx, api.DB, userID, &[]bson.M{
+		{"datetime_start": bson.M{"$gte": datetimeStart}},
+		{"datetime_end": bson.M{"$lte": datetimeEnd}},
+		{"category": bson.M{"$nin": []string{constants.EventCategoryOutOfOffice, constants.EventCategoryFocusTime, constants.EventCategoryWorkingLocation}}},
+		{"linked_task_id": bson.M{"$exists": false}},
+		{"linked_view_id": bson.M{"$exists": false}},
+		{"linked_pull_request_id": bson.M{"$exists": false}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	meetings := getMeetingEvents(*events)
+	// the same meeting on more than one calendar is only listed once
+	type meetingKey struct {
+		title string
+		start primitive.DateTime
+		end   primitive.DateTime
+	}
+	listedMeetings := make(map[meetingKey]bool)
+	for _, meeting := range meetings {
+		listedMeeting := meetingKey{title: meeting.Title, start: meeting.DatetimeStart, end: meeting.DatetimeEnd}
+		if listedMeetings[listedMeeting] {
+			continue
+		}
+		listedMeetings[listedMeeting] = true
+		report.Meetings = append(report.Meetings, WeeklyReportItem{
+			ID:       meeting.ID.Hex(),
+			Title:    meeting.Title,
+			SourceID: meeting.SourceID,
+			Deeplink: meeting.Deeplink,
+			Datetime: meeting.DatetimeStart.Time(),
+		})
+	}
+	report.MeetingMinutes = getOverlapMinutes(mergeMeetingWindows(meetings, datetimeStart.Location()), datetimeStart, datetimeEnd)
+	return &report, nil
+}
+
+func getWeeklyReportEmailContent(report *WeeklyReport) templating.EmailContent {
+	toEmailItems := func(reportItems []WeeklyReportItem) []templating.EmailItem {
+		emailItems := []templating.EmailItem{}
+		for _, item := range reportItems {
+			emailItems = append(emailItems, templating.EmailItem{
+				Title:  item.Title,
+				Detail: item.Datetime.Format(constants.DIGEST_DATE_FORMAT),
+				Link:   item.Deeplink,
+			})
+		}
+		return emailItems
+	}
+	return templating.EmailContent{
+		Heading: "Your week in review",
+		Subheading: fmt.Sprintf(
+			"%s - %s: %d tasks completed, %d pull requests merged, %d hours in meetings.",
+			report.DatetimeStart.Format(constants.DIGEST_DATE_FORMAT),
+			report.DatetimeEnd.Format(constants.DIGEST_DATE_FORMAT),
+			len(report.CompletedTasks),
+			len(report.MergedPullRequests),
+			report.MeetingMinutes/60,
+		),
+		Sections: []templating.EmailSection{
+			{Title: "Tasks completed", EmptyMessage: "No tasks completed this week.", Items: toEmailItems(report.CompletedTasks)},
+			{Title: "Pull requests merged", EmptyMessage: "No pull requests merged this week.", Items: toEmailItems(report.MergedPullRequests)},
+			{Title: "Meetings", EmptyMessage: "No meetings this week.", Items: toEmailItems(report.Meetings)},
+		},
+	}
+}
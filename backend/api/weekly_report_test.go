@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestWeeklyReport(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+
+	authToken := login("test_weekly_report@resonant-kelpie-404a42.netlify.app", "")
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+
+	testTime := time.Date(2023, time.January, 8, 20, 0, 0, 0, time.UTC)
+	api.OverrideTime = &testTime
+	withinWeek := testTime.AddDate(0, 0, -2)
+	beforeWeek := testTime.AddDate(0, 0, -10)
+
+	completedTrue := true
+	completedFalse := false
+	completedTitle := "shipped the feature"
+	oldTitle := "old task"
+	_, err := database.GetTaskCollection(api.DB).InsertMany(context.Background(), []interface{}{
+		database.Task{UserID: userID, Title: &completedTitle, IsCompleted: &completedTrue, CompletedAt: primitive.NewDateTimeFromTime(withinWeek), SourceID: external.TASK_SOURCE_ID_GT_TASK},
+		database.Task{UserID: userID, Title: &oldTitle, IsCompleted: &completedTrue, CompletedAt: primitive.NewDateTimeFromTime(beforeWeek), SourceID: external.TASK_SOURCE_ID_GT_TASK},
+		database.Task{UserID: userID, IsCompleted: &completedFalse, SourceID: external.TASK_SOURCE_ID_GT_TASK},
+		database.Task{UserID: primitive.NewObjectID(), IsCompleted: &completedTrue, CompletedAt: primitive.NewDateTimeFromTime(withinWeek)},
+	})
+	assert.NoError(t, err)
+	_, err = database.GetPullRequestCollection(api.DB).InsertMany(context.Background(), []interface{}{
+		database.PullRequest{UserID: userID, Title: "merged pr", IsCompleted: &completedTrue, CompletedAt: primitive.NewDateTimeFromTime(withinWeek), MergedAt: primitive.NewDateTimeFromTime(withinWeek)},
+		database.PullRequest{UserID: userID, Title: "closed pr", IsCompleted: &completedTrue, CompletedAt: primitive.NewDateTimeFromTime(withinWeek)},
+		database.PullRequest{UserID: userID, Title: "open pr", IsCompleted: &completedFalse},
+	})
+	assert.NoError(t, err)
+	_, err = database.GetCalendarEventCollection(api.DB).InsertMany(context.Background(), []interface{}{
+		database.CalendarEvent{UserID: userID, Title: "planning", DatetimeStart: primitive.NewDateTimeFromTime(withinWeek), DatetimeEnd: primitive.NewDateTimeFromTime(withinWeek.Add(90 * time.Minute))},
+		database.CalendarEvent{UserID: userID, Title: "focus block", LinkedTaskID: primitive.NewObjectID(), DatetimeStart: primitive.NewDateTimeFromTime(withinWeek), DatetimeEnd: primitive.NewDateTimeFromTime(withinWeek.Add(time.Hour))},
+		// the same meeting on another calendar
+		database.CalendarEvent{UserID: userID, Title: "planning", CalendarID: "other", DatetimeStart: primitive.NewDateTimeFromTime(withinWeek), DatetimeEnd: primitive.NewDateTimeFromTime(withinWeek.Add(90 * time.Minute))},
+		// overlaps the end of planning
+		database.CalendarEvent{UserID: userID, Title: "standup", DatetimeStart: primitive.NewDateTimeFromTime(withinWeek.Add(time.Hour)), DatetimeEnd: primitive.NewDateTimeFromTime(withinWeek.Add(2 * time.Hour))},
+		database.CalendarEvent{UserID: userID, Title: "out of office", Category: constants.EventCategoryOutOfOffice, DatetimeStart: primitive.NewDateTimeFromTime(withinWeek), DatetimeEnd: primitive.NewDateTimeFromTime(withinWeek.Add(3 * time.Hour))},
+		database.CalendarEvent{UserID: userID, Title: "offsite", DatetimeStart: primitive.NewDateTimeFromTime(beforeWeek.AddDate(0, 0, 4)), DatetimeEnd: primitive.NewDateTimeFromTime(beforeWeek.AddDate(0, 0, 5))},
+	})
+	assert.NoError(t, err)
+
+	UnauthorizedTest(t, http.MethodGet, "/reports/weekly/", nil)
+	t.Run("Success", func(t *testing.T) {
+		body := ServeRequest(t, authToken, http.MethodGet, "/reports/weekly/", nil, http.StatusOK, api)
+		var report WeeklyReport
+		assert.NoError(t, json.Unmarshal(body, &report))
+		assert.Equal(t, 1, len(report.CompletedTasks))
+		assert.Equal(t, "shipped the feature", report.CompletedTasks[0].Title)
+		assert.Equal(t, 1, len(report.MergedPullRequests))
+		assert.Equal(t, "merged pr", report.MergedPullRequests[0].Title)
+		assert.Equal(t, 2, len(report.Meetings))
+		meetingTitles := []string{}
+		for _, meeting := range report.Meetings {
+			meetingTitles = append(meetingTitles, meeting.Title)
+		}
+		assert.ElementsMatch(t, []string{"planning", "standup"}, meetingTitles)
+		assert.Equal(t, 120, report.MeetingMinutes)
+		assert.False(t, report.EmailSent)
+	})
+	t.Run("SendEmail", func(t *testing.T) {
+		sender := MockEmailSender{}
+		api.EmailSender = &sender
+		body := ServeRequest(t, authToken, http.MethodGet, "/reports/weekly/?send_email=true", nil, http.StatusOK, api)
+		var report WeeklyReport
+		assert.NoError(t, json.Unmarshal(body, &report))
+		assert.True(t, report.EmailSent)
+		assert.Equal(t, 1, len(sender.SentEmails))
+		assert.Equal(t, "test_weekly_report@resonant-kelpie-404a42.netlify.app", sender.SentEmails[0].ToEmail)
+		assert.Contains(t, sender.SentEmails[0].HTMLBody, "shipped the feature")
+		assert.Contains(t, sender.SentEmails[0].HTMLBody, "1 tasks completed, 1 pull requests merged, 2 hours in meetings")
+	})
+}
@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores values by key. Implementations must be safe for concurrent use
+type Cache interface {
+	Get(key string) (interface{}, bool)
+	Set(key string, value interface{})
+	DeletePrefix(prefix string)
+	Clear()
+}
+
+type memoryEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// MemoryCache is an in-process Cache. Entries expire after the ttl, and once maxEntries is reached
+// expired entries are dropped, followed by arbitrary ones if the cache is still full
+type MemoryCache struct {
+	ttl        time.Duration
+	maxEntries int
+	mutex      sync.Mutex
+	entries    map[string]memoryEntry
+	now        func() time.Time
+}
+
+func NewMemoryCache(ttl time.Duration, maxEntries int) *MemoryCache {
+	return &MemoryCache{ttl: ttl, maxEntries: maxEntries, entries: map[string]memoryEntry{}, now: time.Now}
+}
+
+func (memoryCache *MemoryCache) Get(key string) (interface{}, bool) {
+	memoryCache.mutex.Lock()
+	defer memoryCache.mutex.Unlock()
+	entry, exists := memoryCache.entries[key]
+	if !exists {
+		return nil, false
+	}
+	if !memoryCache.now().Before(entry.expiresAt) {
+		delete(memoryCache.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (memoryCache *MemoryCache) Set(key string, value interface{}) {
+	memoryCache.mutex.Lock()
+	defer memoryCache.mutex.Unlock()
+	if _, exists := memoryCache.entries[key]; !exists && len(memoryCache.entries) >= memoryCache.maxEntries {
+		memoryCache.evict()
+	}
+	memoryCache.entries[key] = memoryEntry{value: value, expiresAt: memoryCache.now().Add(memoryCache.ttl)}
+}
+
+func (memoryCache *MemoryCache) evict() {
+	now := memoryCache.now()
+	for key, entry := range memoryCache.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(memoryCache.entries, key)
+		}
+	}
+	for key := range memoryCache.entries {
+		if len(memoryCache.entries) < memoryCache.maxEntries {
+			return
+		}
+		delete(memoryCache.entries, key)
+	}
+}
+
+func (memoryCache *MemoryCache) DeletePrefix(prefix string) {
+	memoryCache.mutex.Lock()
+	defer memoryCache.mutex.Unlock()
+	for key := range memoryCache.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(memoryCache.entries, key)
+		}
+	}
+}
+
+func (memoryCache *MemoryCache) Clear() {
+	memoryCache.mutex.Lock()
+	defer memoryCache.mutex.Unlock()
+	memoryCache.entries = map[string]memoryEntry{}
+}
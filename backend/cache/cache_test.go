@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryCache(t *testing.T) {
+	t.Run("GetSet", func(t *testing.T) {
+		memoryCache := NewMemoryCache(time.Minute, 10)
+		_, exists := memoryCache.Get("key")
+		assert.False(t, exists)
+		memoryCache.Set("key", 1)
+		value, exists := memoryCache.Get("key")
+		assert.True(t, exists)
+		assert.Equal(t, 1, value)
+	})
+	t.Run("Expiry", func(t *testing.T) {
+		now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+		memoryCache := NewMemoryCache(time.Minute, 10)
+		memoryCache.now = func() time.Time { return now }
+		memoryCache.Set("key", 1)
+		now = now.Add(59 * time.Second)
+		_, exists := memoryCache.Get("key")
+		assert.True(t, exists)
+		now = now.Add(time.Second)
+		_, exists = memoryCache.Get("key")
+		assert.False(t, exists)
+		assert.Equal(t, 0, len(memoryCache.entries))
+	})
+	t.Run("MaxEntries", func(t *testing.T) {
+		now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+		memoryCache := NewMemoryCache(time.Minute, 2)
+		memoryCache.now = func() time.Time { return now }
+		memoryCache.Set("expired", 1)
+		now = now.Add(time.Minute)
+		memoryCache.Set("a", 2)
+		// the expired entry is evicted first
+		memoryCache.Set("b", 3)
+		_, exists := memoryCache.Get("a")
+		assert.True(t, exists)
+		_, exists = memoryCache.Get("b")
+		assert.True(t, exists)
+
+		// overwriting an existing key doesn't evict anything
+		memoryCache.Set("a", 4)
+		assert.Equal(t, 2, len(memoryCache.entries))
+
+		memoryCache.Set("c", 5)
+		assert.Equal(t, 2, len(memoryCache.entries))
+		_, exists = memoryCache.Get("c")
+		assert.True(t, exists)
+	})
+	t.Run("DeletePrefix", func(t *testing.T) {
+		memoryCache := NewMemoryCache(time.Minute, 10)
+		memoryCache.Set("user1:settings", 1)
+		memoryCache.Set("user1:sections", 2)
+		memoryCache.Set("user2:settings", 3)
+		memoryCache.DeletePrefix("user1:")
+		_, exists := memoryCache.Get("user1:settings")
+		assert.False(t, exists)
+		_, exists = memoryCache.Get("user1:sections")
+		assert.False(t, exists)
+		_, exists = memoryCache.Get("user2:settings")
+		assert.True(t, exists)
+	})
+	t.Run("Clear", func(t *testing.T) {
+		memoryCache := NewMemoryCache(time.Minute, 10)
+		memoryCache.Set("a", 1)
+		memoryCache.Set("b", 2)
+		memoryCache.Clear()
+		assert.Equal(t, 0, len(memoryCache.entries))
+	})
+}
@@ -0,0 +1,51 @@
+package collab
+
+import (
+	"errors"
+)
+
+// only recent history is kept; clients further behind than this must reload the document
+const MAX_DOCUMENT_HISTORY = 500
+
+var ErrRevisionTooOld = errors.New("revision is too old, reload the document")
+
+// Document is the server's authoritative copy of a text being edited. Each accepted batch of
+// operations produces a new revision
+type Document struct {
+	Text     string
+	Revision int
+	// history[i] holds the operations that produced revision historyStart+i+1
+	history      [][]Operation
+	historyStart int
+}
+
+func NewDocument(text string) *Document {
+	return &Document{Text: text}
+}
+
+// Apply rebases operations written against baseRevision onto the current revision and applies them,
+// returning the operations as they were applied so they can be sent to other editors
+func (document *Document) Apply(baseRevision int, operations []Operation) ([]Operation, error) {
+	if baseRevision > document.Revision || baseRevision < 0 {
+		return nil, errors.New("invalid revision")
+	}
+	if baseRevision < document.historyStart {
+		return nil, ErrRevisionTooOld
+	}
+	for _, concurrent := range document.history[baseRevision-document.historyStart:] {
+		operations, _ = TransformSequences(operations, concurrent)
+	}
+	text, err := ApplyOperations(document.Text, operations)
+	if err != nil {
+		return nil, err
+	}
+	document.Text = text
+	document.Revision += 1
+	document.history = append(document.history, operations)
+	if len(document.history) > MAX_DOCUMENT_HISTORY {
+		trimmed := len(document.history) - MAX_DOCUMENT_HISTORY
+		document.history = document.history[trimmed:]
+		document.historyStart += trimmed
+	}
+	return operations, nil
+}
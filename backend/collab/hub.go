@@ -0,0 +1,152 @@
+package collab
+
+import (
+	"sync"
+)
+
+const (
+	MessageTypeInit       = "init"
+	MessageTypeAck        = "ack"
+	MessageTypeOperations = "operations"
+	MessageTypeError      = "error"
+)
+
+// ClientMessage is sent by an editor with the revision its operations were written against
+type ClientMessage struct {
+	Revision   int         `json:"revision"`
+	Operations []Operation `json:"operations"`
+}
+
+type ServerMessage struct {
+	Type       string      `json:"type"`
+	Revision   int         `json:"revision"`
+	Text       string      `json:"text,omitempty"`
+	Operations []Operation `json:"operations,omitempty"`
+	Detail     string      `json:"detail,omitempty"`
+}
+
+// Client receives messages for a document. Send must not block
+type Client interface {
+	Send(message ServerMessage)
+}
+
+// Hub tracks the documents currently open for editing. A document is loaded when its first
+// editor joins and dropped when its last editor leaves
+type Hub struct {
+	// Save is called with the new text after every accepted change. It runs after the document is
+	// unlocked, so editors aren't held up by storage, and a slow save is skipped once a newer one lands
+	Save  func(documentID string, text string) error
+	mutex sync.Mutex
+	rooms map[string]*room
+}
+
+type room struct {
+	mutex    sync.Mutex
+	document *Document
+	clients  map[Client]bool
+	// saves are serialized separately from edits so an older revision never overwrites a newer one
+	saveMutex     sync.Mutex
+	savedRevision int
+}
+
+func NewHub(save func(documentID string, text string) error) *Hub {
+	return &Hub{Save: save, rooms: map[string]*room{}}
+}
+
+// Join registers the client as an editor of the document, loading it if needed, and sends it the current text
+func (hub *Hub) Join(documentID string, client Client, load func() (string, error)) error {
+	// the hub stays locked until the client is registered so a concurrent Leave can't drop the room
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+	documentRoom, exists := hub.rooms[documentID]
+	if !exists {
+		text, err := load()
+		if err != nil {
+			return err
+		}
+		documentRoom = &room{document: NewDocument(text), clients: map[Client]bool{}}
+		hub.rooms[documentID] = documentRoom
+	}
+
+	documentRoom.mutex.Lock()
+	defer documentRoom.mutex.Unlock()
+	documentRoom.clients[client] = true
+	client.Send(ServerMessage{Type: MessageTypeInit, Revision: documentRoom.document.Revision, Text: documentRoom.document.Text})
+	return nil
+}
+
+func (hub *Hub) Leave(documentID string, client Client) {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+	documentRoom, exists := hub.rooms[documentID]
+	if !exists {
+		return
+	}
+	documentRoom.mutex.Lock()
+	delete(documentRoom.clients, client)
+	isEmpty := len(documentRoom.clients) == 0
+	documentRoom.mutex.Unlock()
+	if isEmpty {
+		delete(hub.rooms, documentID)
+	}
+}
+
+// Submit applies an editor's operations, acknowledges them to the sender, and forwards the rebased
+// operations to every other editor
+func (hub *Hub) Submit(documentID string, client Client, message ClientMessage) {
+	hub.mutex.Lock()
+	documentRoom, exists := hub.rooms[documentID]
+	hub.mutex.Unlock()
+	if !exists {
+		client.Send(ServerMessage{Type: MessageTypeError, Detail: "document is not open"})
+		return
+	}
+
+	documentRoom.mutex.Lock()
+	document := documentRoom.document
+	applied, err := document.Apply(message.Revision, message.Operations)
+	if err != nil {
+		client.Send(ServerMessage{Type: MessageTypeError, Revision: document.Revision, Detail: err.Error()})
+		documentRoom.mutex.Unlock()
+		return
+	}
+	revision, text := document.Revision, document.Text
+	client.Send(ServerMessage{Type: MessageTypeAck, Revision: revision})
+	for otherClient := range documentRoom.clients {
+		if otherClient == client {
+			continue
+		}
+		otherClient.Send(ServerMessage{Type: MessageTypeOperations, Revision: revision, Operations: applied})
+	}
+	documentRoom.mutex.Unlock()
+
+	if hub.Save != nil {
+		hub.save(documentID, documentRoom, client, revision, text)
+	}
+}
+
+func (hub *Hub) save(documentID string, documentRoom *room, client Client, revision int, text string) {
+	documentRoom.saveMutex.Lock()
+	defer documentRoom.saveMutex.Unlock()
+	if revision <= documentRoom.savedRevision {
+		return
+	}
+	err := hub.Save(documentID, text)
+	if err != nil {
+		client.Send(ServerMessage{Type: MessageTypeError, Revision: revision, Detail: "failed to save document"})
+		return
+	}
+	documentRoom.savedRevision = revision
+}
+
+func (hub *Hub) editorCount(documentID string) int {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+	documentRoom, exists := hub.rooms[documentID]
+	if !exists {
+		return 0
+	}
+	documentRoom.mutex.Lock()
+	defer documentRoom.mutex.Unlock()
+	return len(documentRoom.clients)
+}
@@ -0,0 +1,127 @@
+package collab
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testClient struct {
+	messages []ServerMessage
+}
+
+func (client *testClient) Send(message ServerMessage) {
+	client.messages = append(client.messages, message)
+}
+
+func (client *testClient) lastMessage() ServerMessage {
+	return client.messages[len(client.messages)-1]
+}
+
+func TestDocumentApply(t *testing.T) {
+	t.Run("ConcurrentEdits", func(t *testing.T) {
+		document := NewDocument("hello")
+		_, err := document.Apply(0, []Operation{insertOp(5, " world")})
+		assert.NoError(t, err)
+		// written against revision 0, before " world" was added
+		applied, err := document.Apply(0, []Operation{insertOp(0, ">> ")})
+		assert.NoError(t, err)
+		assert.Equal(t, []Operation{insertOp(0, ">> ")}, applied)
+		assert.Equal(t, ">> hello world", document.Text)
+		assert.Equal(t, 2, document.Revision)
+	})
+	t.Run("InvalidRevision", func(t *testing.T) {
+		document := NewDocument("hello")
+		_, err := document.Apply(1, []Operation{insertOp(0, "x")})
+		assert.EqualError(t, err, "invalid revision")
+	})
+	t.Run("RevisionTooOld", func(t *testing.T) {
+		document := NewDocument("")
+		for i := 0; i < MAX_DOCUMENT_HISTORY+1; i++ {
+			_, err := document.Apply(document.Revision, []Operation{insertOp(0, "x")})
+			assert.NoError(t, err)
+		}
+		_, err := document.Apply(0, []Operation{insertOp(0, "y")})
+		assert.Equal(t, ErrRevisionTooOld, err)
+		_, err = document.Apply(1, []Operation{insertOp(0, "y")})
+		assert.NoError(t, err)
+	})
+	t.Run("InvalidOperationLeavesDocumentUnchanged", func(t *testing.T) {
+		document := NewDocument("abc")
+		_, err := document.Apply(0, []Operation{deleteOp(0, 10)})
+		assert.Error(t, err)
+		assert.Equal(t, "abc", document.Text)
+		assert.Equal(t, 0, document.Revision)
+	})
+}
+
+func TestHub(t *testing.T) {
+	saved := map[string]string{}
+	hub := NewHub(func(documentID string, text string) error {
+		saved[documentID] = text
+		return nil
+	})
+	loadCount := 0
+	load := func() (string, error) {
+		loadCount += 1
+		return "notes", nil
+	}
+
+	first := &testClient{}
+	second := &testClient{}
+	assert.NoError(t, hub.Join("doc", first, load))
+	assert.NoError(t, hub.Join("doc", second, load))
+	assert.Equal(t, 1, loadCount)
+	assert.Equal(t, ServerMessage{Type: MessageTypeInit, Revision: 0, Text: "notes"}, second.lastMessage())
+
+	hub.Submit("doc", first, ClientMessage{Revision: 0, Operations: []Operation{insertOp(5, "!")}})
+	assert.Equal(t, ServerMessage{Type: MessageTypeAck, Revision: 1}, first.lastMessage())
+	assert.Equal(t, ServerMessage{Type: MessageTypeOperations, Revision: 1, Operations: []Operation{insertOp(5, "!")}}, second.lastMessage())
+	assert.Equal(t, "notes!", saved["doc"])
+
+	// second hasn't seen revision 1 yet
+	hub.Submit("doc", second, ClientMessage{Revision: 0, Operations: []Operation{insertOp(5, "?")}})
+	assert.Equal(t, ServerMessage{Type: MessageTypeOperations, Revision: 2, Operations: []Operation{insertOp(6, "?")}}, first.lastMessage())
+	assert.Equal(t, "notes!?", saved["doc"])
+
+	hub.Submit("doc", second, ClientMessage{Revision: 5})
+	assert.Equal(t, MessageTypeError, second.lastMessage().Type)
+
+	hub.Leave("doc", first)
+	assert.Equal(t, 1, hub.editorCount("doc"))
+	hub.Leave("doc", second)
+	assert.Equal(t, 0, hub.editorCount("doc"))
+
+	// the document is reloaded from storage once everyone has left
+	assert.NoError(t, hub.Join("doc", first, load))
+	assert.Equal(t, 2, loadCount)
+
+	t.Run("LoadError", func(t *testing.T) {
+		err := hub.Join("missing", &testClient{}, func() (string, error) { return "", errors.New("not found") })
+		assert.EqualError(t, err, "not found")
+		assert.Equal(t, 0, hub.editorCount("missing"))
+	})
+	t.Run("SkipsStaleSave", func(t *testing.T) {
+		documentRoom := &room{document: NewDocument(""), clients: map[Client]bool{}, savedRevision: 3}
+		client := &testClient{}
+		hub.save("stale", documentRoom, client, 2, "older")
+		_, isSaved := saved["stale"]
+		assert.False(t, isSaved)
+		hub.save("stale", documentRoom, client, 4, "newer")
+		assert.Equal(t, "newer", saved["stale"])
+		assert.Equal(t, 4, documentRoom.savedRevision)
+	})
+	t.Run("SaveError", func(t *testing.T) {
+		failingHub := NewHub(func(documentID string, text string) error { return errors.New("down") })
+		client := &testClient{}
+		assert.NoError(t, failingHub.Join("doc", client, load))
+		failingHub.Submit("doc", client, ClientMessage{Revision: 0, Operations: []Operation{insertOp(0, "x")}})
+		assert.Equal(t, ServerMessage{Type: MessageTypeError, Revision: 1, Detail: "failed to save document"}, client.lastMessage())
+	})
+	t.Run("SubmitToClosedDocument", func(t *testing.T) {
+		client := &testClient{}
+		hub.Submit("closed", client, ClientMessage{})
+		assert.Equal(t, ServerMessage{Type: MessageTypeError, Detail: "document is not open"}, client.lastMessage())
+	})
+}
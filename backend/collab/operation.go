@@ -0,0 +1,142 @@
+package collab
+
+import (
+	"errors"
+	"unicode/utf8"
+)
+
+const (
+	OperationInsert = "insert"
+	OperationDelete = "delete"
+)
+
+// Operation is a single text edit. Positions and lengths count unicode code points, not bytes
+type Operation struct {
+	Type     string `json:"type"`
+	Position int    `json:"position"`
+	Text     string `json:"text,omitempty"`
+	Length   int    `json:"length,omitempty"`
+}
+
+func (op Operation) size() int {
+	if op.Type == OperationInsert {
+		return utf8.RuneCountInString(op.Text)
+	}
+	return op.Length
+}
+
+func (op Operation) isNoop() bool {
+	return op.size() == 0
+}
+
+// ApplyOperations applies the operations to the text in order
+func ApplyOperations(text string, operations []Operation) (string, error) {
+	runes := []rune(text)
+	for _, op := range operations {
+		if op.Position < 0 || op.Position > len(runes) {
+			return "", errors.New("operation position out of range")
+		}
+		switch op.Type {
+		case OperationInsert:
+			inserted := []rune(op.Text)
+			updated := make([]rune, 0, len(runes)+len(inserted))
+			updated = append(updated, runes[:op.Position]...)
+			updated = append(updated, inserted...)
+			runes = append(updated, runes[op.Position:]...)
+		case OperationDelete:
+			if op.Length < 0 || op.Position+op.Length > len(runes) {
+				return "", errors.New("operation length out of range")
+			}
+			runes = append(runes[:op.Position:op.Position], runes[op.Position+op.Length:]...)
+		default:
+			return "", errors.New("invalid operation type")
+		}
+	}
+	return string(runes), nil
+}
+
+// TransformSequences rebases two concurrent operation sequences on top of each other, so that
+// applying a then bPrime gives the same text as applying b then aPrime. b wins ties, meaning that
+// when both sides insert at the same position, b's text ends up first
+func TransformSequences(a []Operation, b []Operation) (aPrime []Operation, bPrime []Operation) {
+	if len(a) == 0 || len(b) == 0 {
+		return a, b
+	}
+	if len(a) == 1 && len(b) == 1 {
+		return transformPair(a[0], b[0])
+	}
+	if len(a) > 1 {
+		aFirst, bAfterFirst := TransformSequences(a[:1], b)
+		aRest, bAfterRest := TransformSequences(a[1:], bAfterFirst)
+		return append(aFirst, aRest...), bAfterRest
+	}
+	aAfterFirst, bFirst := TransformSequences(a, b[:1])
+	aAfterRest, bRest := TransformSequences(aAfterFirst, b[1:])
+	return aAfterRest, append(bFirst, bRest...)
+}
+
+func transformPair(a Operation, b Operation) ([]Operation, []Operation) {
+	if a.isNoop() || b.isNoop() {
+		return nonEmpty(a), nonEmpty(b)
+	}
+	switch {
+	case a.Type == OperationInsert && b.Type == OperationInsert:
+		if a.Position < b.Position {
+			return []Operation{a}, []Operation{shift(b, a.size())}
+		}
+		return []Operation{shift(a, b.size())}, []Operation{b}
+	case a.Type == OperationInsert && b.Type == OperationDelete:
+		bPrime, aPrime := transformDeleteAgainstInsert(b, a)
+		return aPrime, bPrime
+	case a.Type == OperationDelete && b.Type == OperationInsert:
+		return transformDeleteAgainstInsert(a, b)
+	default:
+		return nonEmpty(transformDeleteAgainstDelete(a, b)), nonEmpty(transformDeleteAgainstDelete(b, a))
+	}
+}
+
+// transformDeleteAgainstInsert returns the delete rebased after the insert and the insert rebased after the delete
+func transformDeleteAgainstInsert(del Operation, ins Operation) ([]Operation, []Operation) {
+	deleteEnd := del.Position + del.Length
+	if ins.Position <= del.Position {
+		return []Operation{shift(del, ins.size())}, []Operation{ins}
+	}
+	if ins.Position >= deleteEnd {
+		return []Operation{del}, []Operation{shift(ins, -del.Length)}
+	}
+	// the insert landed inside the deleted range; keep the inserted text by deleting around it
+	insertedLength := ins.size()
+	before := Operation{Type: OperationDelete, Position: del.Position, Length: ins.Position - del.Position}
+	after := Operation{Type: OperationDelete, Position: del.Position + insertedLength, Length: deleteEnd - ins.Position}
+	insPrime := ins
+	insPrime.Position = del.Position
+	return []Operation{before, after}, []Operation{insPrime}
+}
+
+// transformDeleteAgainstDelete returns a rebased after b, dropping the characters b already removed
+func transformDeleteAgainstDelete(a Operation, b Operation) Operation {
+	mapPosition := func(position int) int {
+		if position <= b.Position {
+			return position
+		}
+		if position < b.Position+b.Length {
+			return b.Position
+		}
+		return position - b.Length
+	}
+	start := mapPosition(a.Position)
+	end := mapPosition(a.Position + a.Length)
+	return Operation{Type: OperationDelete, Position: start, Length: end - start}
+}
+
+func shift(op Operation, offset int) Operation {
+	op.Position += offset
+	return op
+}
+
+func nonEmpty(op Operation) []Operation {
+	if op.isNoop() {
+		return []Operation{}
+	}
+	return []Operation{op}
+}
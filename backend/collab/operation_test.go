@@ -0,0 +1,92 @@
+package collab
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func insertOp(position int, text string) Operation {
+	return Operation{Type: OperationInsert, Position: position, Text: text}
+}
+
+func deleteOp(position int, length int) Operation {
+	return Operation{Type: OperationDelete, Position: position, Length: length}
+}
+
+func TestApplyOperations(t *testing.T) {
+	t.Run("Insert", func(t *testing.T) {
+		text, err := ApplyOperations("hello", []Operation{insertOp(5, " world")})
+		assert.NoError(t, err)
+		assert.Equal(t, "hello world", text)
+	})
+	t.Run("Delete", func(t *testing.T) {
+		text, err := ApplyOperations("hello world", []Operation{deleteOp(0, 6)})
+		assert.NoError(t, err)
+		assert.Equal(t, "world", text)
+	})
+	t.Run("Multibyte", func(t *testing.T) {
+		text, err := ApplyOperations("héllo", []Operation{deleteOp(1, 1), insertOp(1, "ë")})
+		assert.NoError(t, err)
+		assert.Equal(t, "hëllo", text)
+	})
+	t.Run("OutOfRange", func(t *testing.T) {
+		_, err := ApplyOperations("abc", []Operation{insertOp(4, "x")})
+		assert.EqualError(t, err, "operation position out of range")
+		_, err = ApplyOperations("abc", []Operation{deleteOp(2, 2)})
+		assert.EqualError(t, err, "operation length out of range")
+	})
+	t.Run("InvalidType", func(t *testing.T) {
+		_, err := ApplyOperations("abc", []Operation{{Type: "replace"}})
+		assert.EqualError(t, err, "invalid operation type")
+	})
+}
+
+// assertConverges checks that both orders of applying concurrent edits produce the same text
+func assertConverges(t *testing.T, text string, a []Operation, b []Operation, expected string) {
+	aPrime, bPrime := TransformSequences(a, b)
+	afterA, err := ApplyOperations(text, a)
+	assert.NoError(t, err)
+	resultAFirst, err := ApplyOperations(afterA, bPrime)
+	assert.NoError(t, err)
+	afterB, err := ApplyOperations(text, b)
+	assert.NoError(t, err)
+	resultBFirst, err := ApplyOperations(afterB, aPrime)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, resultAFirst)
+	assert.Equal(t, expected, resultBFirst)
+}
+
+func TestTransformSequences(t *testing.T) {
+	t.Run("InsertInsert", func(t *testing.T) {
+		assertConverges(t, "abc", []Operation{insertOp(1, "X")}, []Operation{insertOp(2, "Y")}, "aXbYc")
+	})
+	t.Run("InsertInsertTieFavorsB", func(t *testing.T) {
+		assertConverges(t, "abc", []Operation{insertOp(1, "X")}, []Operation{insertOp(1, "Y")}, "aYXbc")
+	})
+	t.Run("InsertBeforeDelete", func(t *testing.T) {
+		assertConverges(t, "abcdef", []Operation{insertOp(1, "X")}, []Operation{deleteOp(3, 2)}, "aXbcf")
+	})
+	t.Run("InsertAfterDelete", func(t *testing.T) {
+		assertConverges(t, "abcdef", []Operation{insertOp(5, "X")}, []Operation{deleteOp(1, 2)}, "adeXf")
+	})
+	t.Run("InsertInsideDeleteKeepsInsertedText", func(t *testing.T) {
+		assertConverges(t, "abcdef", []Operation{insertOp(3, "XY")}, []Operation{deleteOp(1, 4)}, "aXYf")
+		assertConverges(t, "abcdef", []Operation{deleteOp(1, 4)}, []Operation{insertOp(3, "XY")}, "aXYf")
+	})
+	t.Run("OverlappingDeletes", func(t *testing.T) {
+		assertConverges(t, "abcdefgh", []Operation{deleteOp(1, 4)}, []Operation{deleteOp(3, 4)}, "ah")
+	})
+	t.Run("SameDelete", func(t *testing.T) {
+		aPrime, bPrime := TransformSequences([]Operation{deleteOp(1, 2)}, []Operation{deleteOp(1, 2)})
+		assert.Equal(t, 0, len(aPrime))
+		assert.Equal(t, 0, len(bPrime))
+	})
+	t.Run("Sequences", func(t *testing.T) {
+		assertConverges(t, "the quick fox",
+			[]Operation{insertOp(10, "brown "), deleteOp(0, 4)},
+			[]Operation{deleteOp(4, 6), insertOp(4, "slow ")},
+			"slow brown fox",
+		)
+	})
+}
@@ -0,0 +1,349 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/joho/godotenv"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const MIN_SIGNING_SECRET_LENGTH = 16
+
+var subdomainRegex = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?$`)
+
+type OauthClientSettings struct {
+	ClientID     string
+	ClientSecret string
+}
+
+// Settings is the typed server configuration. Values come from the environment, falling back to .env
+type Settings struct {
+	Environment  string
+	LogLevel     string
+	ServerURL    string
+	HomeURL      string
+	CookieDomain string
+
+	AccessControlAllowOrigins       []string
+	DefaultAccessControlAllowOrigin string
+
+	MongoURI           string
+	MongoURIMigrations string
+	DBName             string
+	ReadCacheEnabled   bool
+
+	WaitlistEnabled                   bool
+	GithubPRFetchConcurrency          int
+	OverviewSuggestionCacheTTLMinutes int
+
+	GoogleOauth                     OauthClientSettings
+	GoogleOauthLoginRedirectURL     string
+	GoogleOauthAuthorizeRedirectURL string
+	GithubOauth                     OauthClientSettings
+	GithubLoginOauth                OauthClientSettings
+	MicrosoftOauth                  OauthClientSettings
+	SlackOauth                      OauthClientSettings
+	SlackSigningSecret              string
+	SlackWebhookFeedback            string
+	JiraOauth                       OauthClientSettings
+	AsanaOauth                      OauthClientSettings
+	LinearOauth                     OauthClientSettings
+	ZendeskOauth                    OauthClientSettings
+	ZendeskSubdomain                string
+	SalesforceOauth                 OauthClientSettings
+	SalesforceLoginURL              string
+	IntercomOauth                   OauthClientSettings
+	AzureDevOpsOauth                OauthClientSettings
+	PagerDutyOauth                  OauthClientSettings
+
+	OpenAIClientSecret string
+	LLMProvider        string
+	LLMAPIKey          string
+	LLMBaseURL         string
+	LLMModel           string
+	LLMAPIVersion      string
+
+	MandrillClientSecret   string
+	MagicLinkSigningSecret string
+}
+
+// SettingsError lists every invalid setting, so a bad deploy can be fixed in one go
+type SettingsError struct {
+	Problems []string
+}
+
+func (err *SettingsError) Error() string {
+	return "invalid configuration:\n  " + strings.Join(err.Problems, "\n  ")
+}
+
+var (
+	settingsOnce     sync.Once
+	cachedSettings   Settings
+	settingsProblems []string
+)
+
+// GetSettings returns the settings, which are read and validated once. Invalid settings are fatal,
+// so callers never see a half configured server
+func GetSettings() Settings {
+	if err := ValidateSettings(); err != nil {
+		log.Fatal().Msg(err.Error())
+	}
+	return cachedSettings
+}
+
+// ValidateSettings checks required settings are present and well formed. main calls it at startup,
+// so a bad deploy fails before serving any requests
+func ValidateSettings() error {
+	settingsOnce.Do(func() {
+		cachedSettings, settingsProblems = loadSettings()
+	})
+	if len(settingsProblems) > 0 {
+		return &SettingsError{Problems: settingsProblems}
+	}
+	return nil
+}
+
+// SetForTesting sets an environment variable for the duration of a test, reloading the settings
+// when it is set and again once it is restored
+func SetForTesting(t *testing.T, key string, value string) {
+	t.Cleanup(reloadSettings)
+	t.Setenv(key, value)
+	reloadSettings()
+}
+
+func reloadSettings() {
+	settingsOnce = sync.Once{}
+}
+
+type settingsLoader struct {
+	fileValues map[string]string
+	problems   []string
+}
+
+func loadSettings() (Settings, []string) {
+	fileValues, err := godotenv.Read(".env")
+	if err != nil {
+		// Works if running tests from a package directory
+		fileValues, err = godotenv.Read("../.env")
+		if err != nil {
+			fileValues = map[string]string{}
+		}
+	}
+	loader := &settingsLoader{fileValues: fileValues}
+	settings := Settings{
+		Environment:  loader.oneOf("ENVIRONMENT", Dev.String(), Prod.String()),
+		LogLevel:     loader.logLevel("LOG_LEVEL"),
+		ServerURL:    loader.baseURL("SERVER_URL"),
+		HomeURL:      loader.baseURL("HOME_URL"),
+		CookieDomain: loader.optional("COOKIE_DOMAIN"),
+
+		AccessControlAllowOrigins:       loader.list("ACCESS_CONTROL_ALLOW_ORIGINS"),
+		DefaultAccessControlAllowOrigin: loader.url("DEFAULT_ACCESS_CONTROL_ALLOW_ORIGIN", false),
+
+		MongoURI:           loader.mongoURI("MONGO_URI"),
+		MongoURIMigrations: loader.mongoURI("MONGO_URI_MIGRATIONS"),
+		DBName:             loader.required("DB_NAME"),
+		ReadCacheEnabled:   loader.boolean("READ_CACHE_ENABLED"),
+
+		WaitlistEnabled:                   loader.boolean("WAITLIST_ENABLED"),
+		GithubPRFetchConcurrency:          loader.positiveInteger("GITHUB_PR_FETCH_CONCURRENCY"),
+		OverviewSuggestionCacheTTLMinutes: loader.positiveInteger("OVERVIEW_SUGGESTION_CACHE_TTL_MINUTES"),
+
+		GoogleOauth:                     loader.oauthClient("GOOGLE_OAUTH"),
+		GoogleOauthLoginRedirectURL:     loader.url("GOOGLE_OAUTH_LOGIN_REDIRECT_URL", true),
+		GoogleOauthAuthorizeRedirectURL: loader.url("GOOGLE_OAUTH_AUTHORIZE_REDIRECT_URL", true),
+		GithubOauth:                     loader.oauthClient("GITHUB_OAUTH"),
+		GithubLoginOauth:                loader.oauthClient("GITHUB_LOGIN_OAUTH"),
+		MicrosoftOauth:                  loader.oauthClient("MICROSOFT_OAUTH"),
+		SlackOauth:                      loader.oauthClient("SLACK_OAUTH"),
+		SlackSigningSecret:              loader.required("SLACK_SIGNING_SECRET"),
+		SlackWebhookFeedback:            loader.url("SLACK_WEBHOOK_FEEDBACK", false),
+		JiraOauth:                       loader.oauthClient("JIRA_OAUTH"),
+		AsanaOauth:                      loader.oauthClient("ASANA_OAUTH"),
+		LinearOauth:                     loader.oauthClient("LINEAR_OAUTH"),
+		ZendeskOauth:                    loader.optionalOauthClient("ZENDESK_OAUTH"),
+		ZendeskSubdomain:                loader.subdomain("ZENDESK_SUBDOMAIN"),
+		SalesforceOauth:                 loader.optionalOauthClient("SALESFORCE_OAUTH"),
+		SalesforceLoginURL:              loader.url("SALESFORCE_LOGIN_URL", false),
+		IntercomOauth:                   loader.optionalOauthClient("INTERCOM_OAUTH"),
+		AzureDevOpsOauth:                loader.optionalOauthClient("AZURE_DEVOPS_OAUTH"),
+		PagerDutyOauth:                  loader.optionalOauthClient("PAGERDUTY_OAUTH"),
+
+		OpenAIClientSecret: loader.optional("OPEN_AI_CLIENT_SECRET"),
+		LLMProvider:        loader.optional("LLM_PROVIDER"),
+		LLMAPIKey:          loader.optional("LLM_API_KEY"),
+		LLMBaseURL:         loader.url("LLM_BASE_URL", false),
+		LLMModel:           loader.optional("LLM_MODEL"),
+		LLMAPIVersion:      loader.optional("LLM_API_VERSION"),
+
+		MandrillClientSecret:   loader.optional("MANDRILL_CLIENT_SECRET"),
+		MagicLinkSigningSecret: loader.signingSecret("MAGIC_LINK_SIGNING_SECRET"),
+	}
+	if settings.ZendeskOauth.ClientID != "" && settings.ZendeskSubdomain == "" {
+		loader.addProblem("ZENDESK_SUBDOMAIN", "is required when ZENDESK_OAUTH_CLIENT_ID is set")
+	}
+	if settings.MongoURIMigrations != "" && !strings.Contains(settings.MongoURIMigrations, "%s") {
+		loader.addProblem("MONGO_URI_MIGRATIONS", "must contain %s where the database name goes")
+	}
+	return settings, loader.problems
+}
+
+// optional matches GetConfigValue, where environment variables take precedence over .env
+func (loader *settingsLoader) optional(key string) string {
+	if value, exists := os.LookupEnv(key); exists {
+		return value
+	}
+	return loader.fileValues[key]
+}
+
+func (loader *settingsLoader) required(key string) string {
+	value := loader.optional(key)
+	if value == "" {
+		loader.addProblem(key, "is required")
+	}
+	return value
+}
+
+func (loader *settingsLoader) addProblem(key string, problem string) {
+	loader.problems = append(loader.problems, key+" "+problem)
+}
+
+func (loader *settingsLoader) oneOf(key string, choices ...string) string {
+	value := loader.optional(key)
+	for _, choice := range choices {
+		if value == choice {
+			return value
+		}
+	}
+	loader.addProblem(key, fmt.Sprintf("must be one of %s", strings.Join(choices, ", ")))
+	return ""
+}
+
+func (loader *settingsLoader) logLevel(key string) string {
+	value := loader.optional(key)
+	if _, err := zerolog.ParseLevel(value); err != nil {
+		loader.addProblem(key, "is not a valid log level")
+		return ""
+	}
+	return value
+}
+
+func (loader *settingsLoader) url(key string, isRequired bool) string {
+	var value string
+	if isRequired {
+		value = loader.required(key)
+	} else {
+		value = loader.optional(key)
+	}
+	if value == "" {
+		return ""
+	}
+	parsedURL, err := url.Parse(value)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		loader.addProblem(key, "must be an absolute http(s) URL")
+		return ""
+	}
+	return value
+}
+
+// baseURL is for URLs which paths get appended to, so they need a trailing slash
+func (loader *settingsLoader) baseURL(key string) string {
+	value := loader.url(key, true)
+	if value != "" && !strings.HasSuffix(value, "/") {
+		loader.addProblem(key, "must end with /")
+		return ""
+	}
+	return value
+}
+
+func (loader *settingsLoader) mongoURI(key string) string {
+	value := loader.required(key)
+	if value != "" && !strings.HasPrefix(value, "mongodb://") && !strings.HasPrefix(value, "mongodb+srv://") {
+		loader.addProblem(key, "must be a mongodb:// or mongodb+srv:// URI")
+		return ""
+	}
+	return value
+}
+
+func (loader *settingsLoader) list(key string) []string {
+	values := []string{}
+	for _, value := range strings.Split(loader.optional(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func (loader *settingsLoader) boolean(key string) bool {
+	value := loader.optional(key)
+	if value == "" {
+		return false
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		loader.addProblem(key, "must be true or false")
+		return false
+	}
+	return parsed
+}
+
+// positiveInteger returns zero when unset, so callers can fall back to their default
+func (loader *settingsLoader) positiveInteger(key string) int {
+	value := loader.optional(key)
+	if value == "" {
+		return 0
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 1 {
+		loader.addProblem(key, "must be a positive integer")
+		return 0
+	}
+	return parsed
+}
+
+func (loader *settingsLoader) oauthClient(prefix string) OauthClientSettings {
+	settings := OauthClientSettings{
+		ClientID:     loader.required(prefix + "_CLIENT_ID"),
+		ClientSecret: loader.required(prefix + "_CLIENT_SECRET"),
+	}
+	if strings.ContainsAny(settings.ClientID, " \t\n") {
+		loader.addProblem(prefix+"_CLIENT_ID", "must not contain whitespace")
+		settings.ClientID = ""
+	}
+	return settings
+}
+
+// optionalOauthClient is for integrations a deployment can leave out, which need both values or neither
+func (loader *settingsLoader) optionalOauthClient(prefix string) OauthClientSettings {
+	if loader.optional(prefix+"_CLIENT_ID") == "" && loader.optional(prefix+"_CLIENT_SECRET") == "" {
+		return OauthClientSettings{}
+	}
+	return loader.oauthClient(prefix)
+}
+
+func (loader *settingsLoader) subdomain(key string) string {
+	value := loader.optional(key)
+	if value != "" && !subdomainRegex.MatchString(value) {
+		loader.addProblem(key, "must only contain letters, numbers and hyphens")
+		return ""
+	}
+	return value
+}
+
+func (loader *settingsLoader) signingSecret(key string) string {
+	value := loader.required(key)
+	if value != "" && len(value) < MIN_SIGNING_SECRET_LENGTH {
+		loader.addProblem(key, fmt.Sprintf("must be at least %d characters", MIN_SIGNING_SECRET_LENGTH))
+		return ""
+	}
+	return value
+}
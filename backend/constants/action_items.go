@@ -0,0 +1,10 @@
+package constants
+
+const (
+	ActionItemStatusPending   string = "pending"
+	ActionItemStatusAccepted  string = "accepted"
+	ActionItemStatusDismissed string = "dismissed"
+)
+
+// caps how many action items are suggested from a single note
+const MAX_ACTION_ITEMS_PER_NOTE int = 20
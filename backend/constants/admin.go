@@ -0,0 +1,8 @@
+package constants
+
+// roles with access to the internal /admin/ endpoints. They're granted by setting the user's role directly
+const UserRoleAdmin = "admin"
+const UserRoleSupport = "support"
+
+// impersonation sessions are read-only and never renewed, so they end this long after being started
+const IMPERSONATION_SESSION_DURATION int = HOUR
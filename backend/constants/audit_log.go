@@ -0,0 +1,14 @@
+package constants
+
+const (
+	AuditEventLogin             string = "login"
+	AuditEventAccountLinked     string = "account_linked"
+	AuditEventAccountUnlinked   string = "account_unlinked"
+	AuditEventAccountRelinked   string = "account_relinked"
+	AuditEventTokenRefreshed    string = "token_refreshed"
+	AuditEventSharedLinkCreated string = "shared_link_created"
+	AuditEventDataExported      string = "data_exported"
+	AuditEventImpersonated      string = "impersonated"
+	AuditEventResynced          string = "resynced"
+	AuditEventGPTQuotaReset     string = "gpt_quota_reset"
+)
@@ -0,0 +1,10 @@
+package constants
+
+// kinds of calendar events. Events stored before categories existed have none, and are treated
+// as EventCategoryDefault
+const (
+	EventCategoryDefault         string = "default"
+	EventCategoryOutOfOffice     string = "out_of_office"
+	EventCategoryFocusTime       string = "focus_time"
+	EventCategoryWorkingLocation string = "working_location"
+)
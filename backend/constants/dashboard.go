@@ -2,4 +2,13 @@ package constants
 
 const DashboardGraphTypePRResponseTime = "pr_response_time_mins"
 const DashboardGraphTypeFocusTime = "focus_time_mins"
+const DashboardGraphTypePRCycleTime = "pr_cycle_time_mins"
+const DashboardGraphTypePRMergeCount = "pr_merge_count"
+const DashboardGraphTypePRReviewTurnaround = "pr_review_turnaround_mins"
 const UTC_OFFSET = 8
+
+const DashboardTeamRoleAdmin = "admin"
+const DashboardTeamRoleMember = "member"
+
+const DashboardTeamInviteStatusPending = "pending"
+const DashboardTeamInviteStatusAccepted = "accepted"
@@ -2,6 +2,17 @@ package constants
 
 const MAX_COMPLETED_TASKS = 100
 const MAX_DELETED_TASKS = 100
+const MAX_DELETED_NOTES = 100
+const MAX_EVENT_SEARCH_RESULTS = 50
+const DELETED_NOTE_RETENTION_DAYS = 30
 
 const COMMENT_TYPE_TOPLEVEL = "toplevel"
 const COMMENT_TYPE_INLINE = "inline"
+
+const DEFAULT_PAGE_LIMIT = 100
+const MAX_PAGE_LIMIT = 500
+
+const LOG_EVENT_RETENTION_DAYS = 180
+
+// state tokens and oauth1 request secrets only live for the length of an oauth flow
+const OAUTH_FLOW_RETENTION_HOURS = 24
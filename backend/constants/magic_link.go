@@ -0,0 +1,9 @@
+package constants
+
+// login links sent by email can be used once, within this long of being sent
+const MAGIC_LINK_DURATION int = 15 * MINUTE
+
+// at most MAGIC_LINK_MAX_PER_INTERVAL links are sent to an email in each MAGIC_LINK_RATE_INTERVAL, so
+// the endpoint can't be used to flood someone's inbox
+const MAGIC_LINK_RATE_INTERVAL int = HOUR
+const MAGIC_LINK_MAX_PER_INTERVAL int64 = 5
@@ -0,0 +1,9 @@
+package constants
+
+// rules applied to users who haven't saved their own. The default lead time covers the rest of the
+// day, matching how prep tasks were created before rules existed
+const (
+	MEETING_PREP_DEFAULT_LEAD_TIME_MINUTES int = DAY / MINUTE
+	MEETING_PREP_MAX_LEAD_TIME_MINUTES     int = DAY / MINUTE
+	MEETING_PREP_MAX_MINIMUM_ATTENDEES     int = 100
+)
@@ -11,3 +11,10 @@ const (
 	StringSharedAccessDomain           = "domain"
 	StringSharedAccessMeetingAttendees = "meeting_attendees"
 )
+
+// Valid strings for shared_permission field in task modify and share create requests
+const (
+	StringSharedPermissionView    = "view"
+	StringSharedPermissionComment = "comment"
+	StringSharedPermissionEdit    = "edit"
+)
@@ -0,0 +1,6 @@
+package constants
+
+const (
+	NotificationTypeTaskCommentMention string = "task_comment_mention"
+	NotificationTypeNoteCommentMention string = "note_comment_mention"
+)
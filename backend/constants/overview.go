@@ -1,5 +1,7 @@
 package constants
 
+import "time"
+
 type ViewType string
 
 const (
@@ -15,6 +17,10 @@ const (
 	ViewGithubName             = "Github"
 	ViewMeetingPreparationName = "Meeting Preparation"
 	ViewDueTodayName           = "Due Today"
+	ViewOverdueName            = "Overdue"
+	ViewAssignedToMeName       = "Assigned to Me"
+	ViewWaitingOnOthersName    = "Waiting on Others"
+	ViewJiraJQLName            = "Jira Query"
 )
 
 const (
@@ -25,12 +31,55 @@ const (
 	ViewGithub             ViewType = "github"
 	ViewMeetingPreparation ViewType = "meeting_preparation"
 	ViewDueToday           ViewType = "due_today"
+	ViewOverdue            ViewType = "overdue"
+	ViewAssignedToMe       ViewType = "assigned_to_me"
+	ViewWaitingOnOthers    ViewType = "waiting_on_others"
+	ViewJiraJQL            ViewType = "jira_jql"
+	ViewCustom             ViewType = "custom"
 )
 
 const (
 	MAX_OVERVIEW_SUGGESTION int = 5
 )
 
+// suggestions are reused until the user's views change, for up to the ttl unless overridden by
+// OVERVIEW_SUGGESTION_CACHE_TTL_MINUTES
+const (
+	DEFAULT_OVERVIEW_SUGGESTION_CACHE_TTL     = 30 * time.Minute
+	OVERVIEW_SUGGESTION_CACHE_MAX_ENTRIES int = 5000
+)
+
+// task prioritization splits the user's tasks across several prompts when they don't fit in one,
+// and leaves the rest in their current order once MAX_PRIORITIZE_CHUNKS prompts have been used
+const (
+	MAX_GPT_PROMPT_LENGTH int = 4000
+	MAX_PRIORITIZE_CHUNKS int = 3
+)
+
+// JQL views are synced with the rest of the user's JIRA issues, so their size is capped to keep
+// the sync from fanning out into thousands of per-issue requests
+const (
+	JIRA_JQL_VIEW_MAX_RESULTS int = 50
+	JIRA_JQL_MAX_LENGTH       int = 2000
+)
+
+// due date ranges custom views can filter on, relative to the start of the user's day
+const (
+	CustomViewDueWindowOverdue   = "overdue"
+	CustomViewDueWindowToday     = "today"
+	CustomViewDueWindowNext7Days = "next_7_days"
+	CustomViewDueWindowNoDueDate = "no_due_date"
+	CUSTOM_VIEW_NAME_MAX_LENGTH  = 100
+)
+
+// settings for narrowing down and ordering the Linear view by cycle
+const (
+	LinearCycleFilterCurrent  = "current"
+	LinearCycleFilterNext     = "next"
+	LinearCycleFilterPrevious = "previous"
+	LinearSortByCycle         = "cycle"
+)
+
 const (
 	ShowMovedOrDeleted       = "show_moved_or_deleted"
 	IgnoreMeetingPreparation = "ignore_meeting_preparation"
@@ -20,12 +20,28 @@ const (
 	SettingFieldGithubSortingDirection = "github_sorting_direction"
 	ChoiceKeyDescending                = "descending"
 	ChoiceKeyAscending                 = "ascending"
+	// Github repository filtering
+	SettingFieldGithubRepositoryFilterMode = "github_repository_filter_mode"
+	ChoiceKeyAllRepositories               = "all_repositories"
+	ChoiceKeyAllowlist                     = "allowlist"
+	ChoiceKeyDenylist                      = "denylist"
+	// Github PR draft, label and base branch filtering, applied when PRs are fetched. An empty label or
+	// base branch doesn't filter
+	SettingFieldGithubHideDrafts       = "github_hide_drafts"
+	SettingFieldGithubLabelFilter      = "github_label_filter"
+	SettingFieldGithubBaseBranchFilter = "github_base_branch_filter"
+	// Azure DevOps project filtering, using the repository filtering choices
+	SettingFieldAzureDevOpsProjectFilterMode = "azure_devops_project_filter_mode"
 	// Task sorting
 	SettingFieldTaskSortingPreference = "task_sorting_preference"
 	SettingFieldTaskSortingDirection  = "task_sorting_direction"
 	ChoiceKeyManual                   = "manual"
 	ChoiceKeyDueDate                  = "due_date"
 	ChoiceKeyPriority                 = "priority"
+	// Task sorting for the due today, overdue and waiting on others views, the keys are prefixed
+	// with the view type
+	SettingFieldViewTaskSortingPreference = "task_sorting_preference"
+	SettingFieldViewTaskSortingDirection  = "task_sorting_direction"
 	// Note sorting and filtering
 	SettingFieldNoteSortingPreference   = "note_sorting_preference"
 	SettingFieldNoteSortingDirection    = "note_sorting_direction"
@@ -46,6 +62,21 @@ const (
 	LabSmartPrioritizeEnabled = "lab_smart_prioritize_enabled"
 	// Misc settings
 	HasDismissedMulticalPrompt = "has_dismissed_multical_prompt"
+	// Email settings
+	SettingFieldDailyDigestEnabled = "daily_digest_enabled"
+	// Meeting notes settings
+	SettingFieldAutoMeetingNotesEnabled = "auto_meeting_notes_enabled"
+	// Working hours settings
+	SettingFieldWorkingHoursStart = "working_hours_start"
+	SettingFieldWorkingHoursEnd   = "working_hours_end"
+	SettingFieldWorkdays          = "workdays"
+	ChoiceKeyMondayToFriday       = "monday_to_friday"
+	ChoiceKeySundayToThursday     = "sunday_to_thursday"
+	ChoiceKeyMondayToSaturday     = "monday_to_saturday"
+	ChoiceKeyEveryDay             = "every_day"
+	// Timezone settings
+	SettingFieldTimezone       = "timezone"
+	ChoiceKeyTimezoneAutomatic = "automatic"
 )
 
 const (
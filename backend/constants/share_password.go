@@ -0,0 +1,8 @@
+package constants
+
+// after SHARE_PASSWORD_MAX_FAILURES wrong passwords for a share from one address within
+// SHARE_PASSWORD_FAILURE_INTERVAL, each further attempt waits SHARE_PASSWORD_BACKOFF after the last
+// one, doubling with every failure, so share passwords can't be guessed
+const SHARE_PASSWORD_FAILURE_INTERVAL int = HOUR
+const SHARE_PASSWORD_MAX_FAILURES int = 5
+const SHARE_PASSWORD_BACKOFF int = MINUTE
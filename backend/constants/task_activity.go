@@ -0,0 +1,10 @@
+package constants
+
+const (
+	TaskActivityCreated        string = "created"
+	TaskActivityCompleted      string = "completed"
+	TaskActivityReordered      string = "reordered"
+	TaskActivityCommented      string = "commented"
+	TaskActivityDueDateChanged string = "due_date_changed"
+	TaskActivityShared         string = "shared"
+)
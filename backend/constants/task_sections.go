@@ -15,3 +15,6 @@ const (
 	TaskSectionNameDone    string = "Done"
 	TaskSectionNameTrash   string = "Trash"
 )
+
+// query parameter for listing archived sections along with active ones
+const IncludeArchived = "include_archived"
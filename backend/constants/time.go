@@ -10,3 +10,9 @@ const YEAR int = 365 * DAY
 
 const NANOSECONDS_IN_SECOND int64 = 1000 * 1000
 const YEAR_MONTH_DAY_FORMAT string = "2006-01-02"
+const DIGEST_TIME_FORMAT string = "3:04 PM"
+const DIGEST_DATE_FORMAT string = "Jan 2"
+
+// sessions expire after going unused for SESSION_DURATION, and their expiry is pushed back at most once per SESSION_RENEWAL_INTERVAL
+const SESSION_DURATION int = MONTH
+const SESSION_RENEWAL_INTERVAL int = HOUR
@@ -0,0 +1,21 @@
+package constants
+
+// the token refresh job refreshes tokens expiring within TOKEN_REFRESH_WINDOW, so they don't expire
+// while being used
+const TOKEN_REFRESH_WINDOW int = 15 * MINUTE
+
+// failed refreshes are retried after TOKEN_REFRESH_BACKOFF, doubling each time up to
+// TOKEN_REFRESH_MAX_BACKOFF, and the token is marked bad after TOKEN_REFRESH_MAX_FAILURES in a row
+const TOKEN_REFRESH_BACKOFF int = 5 * MINUTE
+const TOKEN_REFRESH_MAX_BACKOFF int = 2 * HOUR
+const TOKEN_REFRESH_MAX_FAILURES int = 5
+
+// the number of token health events returned with each linked account
+const TOKEN_HEALTH_HISTORY_LIMIT int64 = 5
+
+const (
+	TokenHealthRefreshFailed string = "refresh_failed"
+	TokenHealthRecovered     string = "recovered"
+	TokenHealthBad           string = "bad"
+	TokenHealthRelinked      string = "relinked"
+)
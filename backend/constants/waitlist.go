@@ -0,0 +1,4 @@
+package constants
+
+// each user can share a referral invite code, which gets this many people past the waitlist
+const REFERRAL_INVITE_CODE_MAX_USES int = 5
@@ -0,0 +1,146 @@
+package database
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/logging"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	CHANGE_SUBSCRIBER_BUFFER = 100
+	CHANGE_WATCH_RETRY_DELAY = 5 * time.Second
+	// sent once the change stream is open, since changes made before then were not seen
+	CHANGE_STREAM_OPENED = "stream_opened"
+)
+
+// ChangeEvent describes a write to a watched collection. UserID is unset for deletes, since the
+// deleted document is no longer available to look up
+type ChangeEvent struct {
+	Collection    string
+	OperationType string
+	DocumentID    primitive.ObjectID
+	UserID        primitive.ObjectID
+}
+
+type changeStreamDocument struct {
+	OperationType string `bson:"operationType"`
+	Namespace     struct {
+		Collection string `bson:"coll"`
+	} `bson:"ns"`
+	DocumentKey struct {
+		ID primitive.ObjectID `bson:"_id"`
+	} `bson:"documentKey"`
+	FullDocument struct {
+		UserID primitive.ObjectID `bson:"user_id"`
+	} `bson:"fullDocument"`
+}
+
+// WatchChanges calls handle for every insert, update, replace, and delete in the given collections
+// until the context is cancelled or the change stream fails. handle is first called with a
+// CHANGE_STREAM_OPENED event once the stream is open. Change streams require a replica set
+func WatchChanges(ctx context.Context, db *mongo.Database, collections []string, handle func(ChangeEvent)) error {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"ns.coll":       bson.M{"$in": collections},
+			"operationType": bson.M{"$in": []string{"insert", "update", "replace", "delete"}},
+		}}},
+	}
+	stream, err := db.Watch(ctx, pipeline, options.ChangeStream().SetFullDocument(options.UpdateLookup))
+	if err != nil {
+		return err
+	}
+	defer stream.Close(context.Background())
+	handle(ChangeEvent{OperationType: CHANGE_STREAM_OPENED})
+	for stream.Next(ctx) {
+		var change changeStreamDocument
+		if err := stream.Decode(&change); err != nil {
+			return err
+		}
+		handle(ChangeEvent{
+			Collection:    change.Namespace.Collection,
+			OperationType: change.OperationType,
+			DocumentID:    change.DocumentKey.ID,
+			UserID:        change.FullDocument.UserID,
+		})
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return stream.Err()
+}
+
+// ChangeBroker shares a single change stream between all subscribers. The stream is opened when
+// the first subscriber arrives and closed when the last one leaves
+type ChangeBroker struct {
+	db          *mongo.Database
+	collections []string
+	mutex       sync.Mutex
+	subscribers map[chan ChangeEvent]primitive.ObjectID
+	cancel      context.CancelFunc
+}
+
+func NewChangeBroker(db *mongo.Database, collections []string) *ChangeBroker {
+	return &ChangeBroker{db: db, collections: collections, subscribers: map[chan ChangeEvent]primitive.ObjectID{}}
+}
+
+// Subscribe returns a channel of changes to documents owned by the user, or of every change if
+// userID is primitive.NilObjectID, along with a function to unsubscribe. Changes are dropped for
+// subscribers that fall behind, so they should be treated as hints to refetch
+func (broker *ChangeBroker) Subscribe(userID primitive.ObjectID) (<-chan ChangeEvent, func()) {
+	events := make(chan ChangeEvent, CHANGE_SUBSCRIBER_BUFFER)
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+	broker.subscribers[events] = userID
+	if broker.cancel == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		broker.cancel = cancel
+		go broker.watch(ctx)
+	}
+	unsubscribe := func() {
+		broker.mutex.Lock()
+		defer broker.mutex.Unlock()
+		if _, exists := broker.subscribers[events]; !exists {
+			return
+		}
+		delete(broker.subscribers, events)
+		if len(broker.subscribers) == 0 && broker.cancel != nil {
+			broker.cancel()
+			broker.cancel = nil
+		}
+	}
+	return events, unsubscribe
+}
+
+func (broker *ChangeBroker) watch(ctx context.Context) {
+	logger := logging.GetSentryLogger()
+	for ctx.Err() == nil {
+		err := WatchChanges(ctx, broker.db, broker.collections, broker.publish)
+		if err != nil {
+			logger.Error().Err(err).Msg("change stream failed")
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(CHANGE_WATCH_RETRY_DELAY):
+		}
+	}
+}
+
+func (broker *ChangeBroker) publish(event ChangeEvent) {
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+	for events, userID := range broker.subscribers {
+		if userID != primitive.NilObjectID && userID != event.UserID {
+			continue
+		}
+		select {
+		case events <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestChangeBroker(t *testing.T) {
+	db, dbCleanup, err := GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+
+	userID := primitive.NewObjectID()
+	broker := NewChangeBroker(db, []string{"tasks"})
+	userEvents, unsubscribeUser := broker.Subscribe(userID)
+	allEvents, unsubscribeAll := broker.Subscribe(primitive.NilObjectID)
+
+	userChange := ChangeEvent{Collection: "tasks", OperationType: "update", DocumentID: primitive.NewObjectID(), UserID: userID}
+	otherChange := ChangeEvent{Collection: "tasks", OperationType: "insert", DocumentID: primitive.NewObjectID(), UserID: primitive.NewObjectID()}
+	broker.publish(userChange)
+	broker.publish(otherChange)
+
+	assert.Equal(t, userChange, <-userEvents)
+	assert.Equal(t, 0, len(userEvents))
+	assert.Equal(t, userChange, <-allEvents)
+	assert.Equal(t, otherChange, <-allEvents)
+
+	unsubscribeUser()
+	// unsubscribing twice is a no-op
+	unsubscribeUser()
+	broker.publish(userChange)
+	assert.Equal(t, 0, len(userEvents))
+	assert.Equal(t, userChange, <-allEvents)
+	assert.NotNil(t, broker.cancel)
+
+	unsubscribeAll()
+	assert.Nil(t, broker.cancel)
+}
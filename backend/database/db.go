@@ -19,6 +19,9 @@ type DBHandle struct {
 
 const DB_CONNECTION_TIMEOUT = 10 * time.Second
 
+// queries are bounded by this unless their caller has already set a deadline
+const DB_QUERY_TIMEOUT = 10 * time.Second
+
 func (dbHandle *DBHandle) CloseConnection() {
 	if dbHandle.cleanup != nil {
 		(*dbHandle.cleanup)()
@@ -41,7 +44,7 @@ func CreateDBHandle() (*DBHandle, error) {
 // GetDBConnection returns a MongoDB client
 func GetDBConnection() (*mongo.Database, func(), error) {
 	// This code is drawn from https://github.com/mongodb/mongo-go-driver
-	client, err := mongo.NewClient(options.Client().ApplyURI(config.GetConfigValue("MONGO_URI")))
+	client, err := mongo.NewClient(options.Client().ApplyURI(config.GetSettings().MongoURI))
 	logger := logging.GetSentryLogger()
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to create mongo DB client")
@@ -72,5 +75,12 @@ func GetDBConnection() (*mongo.Database, func(), error) {
 		}
 	}
 
-	return client.Database(config.GetConfigValue("DB_NAME")), cleanup, nil
+	return client.Database(config.GetSettings().DBName), cleanup, nil
+}
+
+func withQueryTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, hasDeadline := ctx.Deadline(); hasDeadline {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, DB_QUERY_TIMEOUT)
 }
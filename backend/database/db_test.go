@@ -1,7 +1,9 @@
 package database
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -13,3 +15,29 @@ func TestCreateDBHandle(t *testing.T) {
 		assert.NotNil(t, dbh)
 	})
 }
+
+func TestWithQueryTimeout(t *testing.T) {
+	t.Run("DefaultTimeout", func(t *testing.T) {
+		ctx, cancel := withQueryTimeout(context.Background())
+		defer cancel()
+		deadline, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline)
+		assert.WithinDuration(t, time.Now().Add(DB_QUERY_TIMEOUT), deadline, time.Second)
+	})
+	t.Run("KeepsCallerDeadline", func(t *testing.T) {
+		parent, cancelParent := context.WithTimeout(context.Background(), time.Minute)
+		defer cancelParent()
+		ctx, cancel := withQueryTimeout(parent)
+		defer cancel()
+		parentDeadline, _ := parent.Deadline()
+		deadline, _ := ctx.Deadline()
+		assert.Equal(t, parentDeadline, deadline)
+	})
+	t.Run("CallerCancellation", func(t *testing.T) {
+		parent, cancelParent := context.WithCancel(context.Background())
+		ctx, cancel := withQueryTimeout(parent)
+		defer cancel()
+		cancelParent()
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	})
+}
@@ -2,11 +2,17 @@ package database
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"reflect"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/franchizzle/task-manager/backend/logging"
+	"github.com/franchizzle/task-manager/backend/ordering"
+	"github.com/google/uuid"
 	"golang.org/x/exp/slices"
 
 	"github.com/franchizzle/task-manager/backend/constants"
@@ -17,6 +23,7 @@ import (
 )
 
 func UpdateOrCreateTask(
+	ctx context.Context,
 	db *mongo.Database,
 	userID primitive.ObjectID,
 	IDExternal string,
@@ -28,7 +35,7 @@ func UpdateOrCreateTask(
 	taskCollection := GetTaskCollection(db)
 	logger := logging.GetSentryLogger()
 
-	mongoResult, err := FindOneAndUpdateWithCollection(taskCollection, userID, IDExternal, sourceID, fieldsToInsertIfMissing, fieldsToUpdate, additionalFilters)
+	mongoResult, err := FindOneAndUpdateWithCollection(ctx, taskCollection, userID, IDExternal, sourceID, fieldsToInsertIfMissing, fieldsToUpdate, additionalFilters)
 	if err != nil {
 		return nil, err
 	}
@@ -43,6 +50,7 @@ func UpdateOrCreateTask(
 }
 
 func UpdateOrCreateCalendarAccount(
+	ctx context.Context,
 	db *mongo.Database,
 	userID primitive.ObjectID,
 	IDExternal string,
@@ -50,7 +58,7 @@ func UpdateOrCreateCalendarAccount(
 	fields interface{},
 	additionalFilters *[]bson.M,
 ) (*CalendarAccount, error) {
-	mongoResult, err := FindOneAndUpdateWithCollection(GetCalendarAccountCollection(db), userID, IDExternal, sourceID, nil, fields, additionalFilters)
+	mongoResult, err := FindOneAndUpdateWithCollection(ctx, GetCalendarAccountCollection(db), userID, IDExternal, sourceID, nil, fields, additionalFilters)
 	if err != nil {
 		return nil, err
 	}
@@ -66,6 +74,7 @@ func UpdateOrCreateCalendarAccount(
 }
 
 func UpdateOrCreateCalendarEvent(
+	ctx context.Context,
 	db *mongo.Database,
 	userID primitive.ObjectID,
 	IDExternal string,
@@ -74,7 +83,7 @@ func UpdateOrCreateCalendarEvent(
 	additionalFilters *[]bson.M,
 ) (*CalendarEvent, error) {
 	eventCollection := GetCalendarEventCollection(db)
-	mongoResult, err := FindOneAndUpdateWithCollection(eventCollection, userID, IDExternal, sourceID, nil, fields, additionalFilters)
+	mongoResult, err := FindOneAndUpdateWithCollection(ctx, eventCollection, userID, IDExternal, sourceID, nil, fields, additionalFilters)
 	if err != nil {
 		return nil, err
 	}
@@ -90,6 +99,7 @@ func UpdateOrCreateCalendarEvent(
 }
 
 func UpdateOrCreatePullRequest(
+	ctx context.Context,
 	db *mongo.Database,
 	userID primitive.ObjectID,
 	IDExternal string,
@@ -98,7 +108,7 @@ func UpdateOrCreatePullRequest(
 	additionalFilters *[]bson.M,
 ) (*PullRequest, error) {
 	pullRequestCollection := GetPullRequestCollection(db)
-	mongoResult, err := FindOneAndUpdateWithCollection(pullRequestCollection, userID, IDExternal, sourceID, nil, fields, additionalFilters)
+	mongoResult, err := FindOneAndUpdateWithCollection(ctx, pullRequestCollection, userID, IDExternal, sourceID, nil, fields, additionalFilters)
 	if err != nil {
 		return nil, err
 	}
@@ -114,6 +124,7 @@ func UpdateOrCreatePullRequest(
 }
 
 func FindOneAndUpdateWithCollection(
+	ctx context.Context,
 	collection *mongo.Collection,
 	userID primitive.ObjectID,
 	IDExternal string,
@@ -122,37 +133,72 @@ func FindOneAndUpdateWithCollection(
 	fields interface{},
 	additionalFilters *[]bson.M,
 ) (*mongo.SingleResult, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	dbQuery := getDBQuery(userID, IDExternal, sourceID, additionalFilters)
-	// Unfortunately you cannot put both $set and $setOnInsert so they are separate operations
-
+	// the insert-only fields go in the same upsert, so a new document is never seen half created
+	update := bson.M{"$set": fields}
 	if fieldsToInsertIfMissing != nil {
-		_, err := collection.UpdateOne(
-			context.Background(),
-			dbQuery,
-			bson.M{"$setOnInsert": fieldsToInsertIfMissing},
-			options.Update().SetUpsert(true),
-		)
+		insertOnlyFields, err := getInsertOnlyFields(fieldsToInsertIfMissing, fields)
 		if err != nil {
 			logger := logging.GetSentryLogger()
 			logger.Error().Err(err).Msg("failed to update or create task")
 			return nil, err
 		}
+		if len(insertOnlyFields) > 0 {
+			update["$setOnInsert"] = insertOnlyFields
+		}
 	}
 
 	mongoResult := collection.FindOneAndUpdate(
-		context.Background(),
+		ctx,
 		dbQuery,
-		bson.M{"$set": fields},
+		update,
 		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
 	)
 
 	return mongoResult, nil
 }
 
-func GetTask(db *mongo.Database, itemID primitive.ObjectID, userID primitive.ObjectID) (*Task, error) {
+// getInsertOnlyFields drops the fields which are also being set, as mongo rejects an update which
+// touches the same path from both $set and $setOnInsert. The $set value would win anyway
+func getInsertOnlyFields(fieldsToInsertIfMissing interface{}, fields interface{}) (bson.M, error) {
+	insertOnlyFields, err := toBSONMap(fieldsToInsertIfMissing)
+	if err != nil {
+		return nil, err
+	}
+	fieldsToSet, err := toBSONMap(fields)
+	if err != nil {
+		return nil, err
+	}
+	for insertKey := range insertOnlyFields {
+		for setKey := range fieldsToSet {
+			if insertKey == setKey || strings.HasPrefix(insertKey, setKey+".") || strings.HasPrefix(setKey, insertKey+".") {
+				delete(insertOnlyFields, insertKey)
+				break
+			}
+		}
+	}
+	return insertOnlyFields, nil
+}
+
+func toBSONMap(fields interface{}) (bson.M, error) {
+	result := bson.M{}
+	if fields == nil {
+		return result, nil
+	}
+	marshalledFields, err := bson.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	err = bson.Unmarshal(marshalledFields, &result)
+	return result, err
+}
+
+func GetTask(ctx context.Context, db *mongo.Database, itemID primitive.ObjectID, userID primitive.ObjectID) (*Task, error) {
 	logger := logging.GetSentryLogger()
 	taskCollection := GetTaskCollection(db)
-	mongoResult := FindOneWithCollection(taskCollection, userID, itemID)
+	mongoResult := FindOneWithCollection(ctx, taskCollection, userID, itemID)
 
 	var task Task
 	err := mongoResult.Decode(&task)
@@ -163,10 +209,34 @@ func GetTask(db *mongo.Database, itemID primitive.ObjectID, userID primitive.Obj
 	return &task, nil
 }
 
-func GetPullRequest(db *mongo.Database, itemID primitive.ObjectID, userID primitive.ObjectID) (*PullRequest, error) {
+// UpdateTaskComment applies the update to one of the task's comments, which the update addresses as
+// comments.$[comment]. Only that comment's fields are written, so concurrent changes to the rest of
+// the task aren't lost. commentFilter further restricts when the comment matches; if it doesn't,
+// the task is left as it is
+func UpdateTaskComment(ctx context.Context, db *mongo.Database, taskID primitive.ObjectID, commentID string, commentFilter bson.M, update bson.M) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	commentMatch := bson.M{"external_id": commentID}
+	for key, value := range commentFilter {
+		commentMatch[key] = value
+	}
+	_, err := GetTaskCollection(db).UpdateOne(
+		ctx,
+		bson.M{"_id": taskID, "comments": bson.M{"$elemMatch": commentMatch}},
+		update,
+		options.Update().SetArrayFilters(options.ArrayFilters{Filters: []interface{}{bson.M{"comment.external_id": commentID}}}),
+	)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msgf("failed to update comment %s on task: %+v", commentID, taskID)
+		return err
+	}
+	return nil
+}
+
+func GetPullRequest(ctx context.Context, db *mongo.Database, itemID primitive.ObjectID, userID primitive.ObjectID) (*PullRequest, error) {
 	logger := logging.GetSentryLogger()
 	pullRequestCollection := GetPullRequestCollection(db)
-	mongoResult := FindOneWithCollection(pullRequestCollection, userID, itemID)
+	mongoResult := FindOneWithCollection(ctx, pullRequestCollection, userID, itemID)
 
 	var pullRequest PullRequest
 	err := mongoResult.Decode(&pullRequest)
@@ -178,10 +248,12 @@ func GetPullRequest(db *mongo.Database, itemID primitive.ObjectID, userID primit
 	return &pullRequest, nil
 }
 
-func GetNote(db *mongo.Database, itemID primitive.ObjectID, userID primitive.ObjectID) (*Note, error) {
+func GetNote(ctx context.Context, db *mongo.Database, itemID primitive.ObjectID, userID primitive.ObjectID) (*Note, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	logger := logging.GetSentryLogger()
 	mongoResult := GetNoteCollection(db).FindOne(
-		context.Background(),
+		ctx,
 		bson.M{"$and": []bson.M{
 			{"_id": itemID},
 			{"user_id": userID},
@@ -224,10 +296,12 @@ func CheckTaskSharingAccessValid(sharedAccess SharedAccess) bool {
 	return sharedAccess == SharedAccessDomain || sharedAccess == SharedAccessPublic
 }
 
-func GetSharedTask(db *mongo.Database, taskID primitive.ObjectID, userID *primitive.ObjectID) (*Task, error) {
+func GetSharedTask(ctx context.Context, db *mongo.Database, taskID primitive.ObjectID, userID *primitive.ObjectID) (*Task, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	logger := logging.GetSentryLogger()
 	mongoResult := GetTaskCollection(db).FindOne(
-		context.Background(),
+		ctx,
 		bson.M{"$and": []bson.M{
 			{"_id": taskID},
 			{"shared_until": bson.M{"$gte": time.Now()}},
@@ -254,12 +328,12 @@ func GetSharedTask(db *mongo.Database, taskID primitive.ObjectID, userID *primit
 		if userID == nil {
 			return nil, errors.New("user is not allowed to access this task")
 		}
-		user, err := GetUser(db, *userID)
+		user, err := GetUser(ctx, db, *userID)
 		if err != nil {
 			logger.Error().Err(err).Msgf("failed to get user: %+v", userID)
 			return nil, err
 		}
-		taskOwner, err := GetUser(db, task.UserID)
+		taskOwner, err := GetUser(ctx, db, task.UserID)
 		if err != nil {
 			logger.Error().Err(err).Msgf("failed to get user: %+v", task.UserID)
 			return nil, err
@@ -284,10 +358,12 @@ func GetSharedTask(db *mongo.Database, taskID primitive.ObjectID, userID *primit
 	return &task, nil
 }
 
-func GetSharedNote(db *mongo.Database, itemID primitive.ObjectID) (*Note, error) {
+func GetSharedNote(ctx context.Context, db *mongo.Database, itemID primitive.ObjectID) (*Note, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	logger := logging.GetSentryLogger()
 	mongoResult := GetNoteCollection(db).FindOne(
-		context.Background(),
+		ctx,
 		bson.M{"$and": []bson.M{
 			{"_id": itemID},
 			{"shared_until": bson.M{"$gte": time.Now()}},
@@ -307,10 +383,33 @@ func GetSharedNote(db *mongo.Database, itemID primitive.ObjectID) (*Note, error)
 	return &note, nil
 }
 
-func GetSharedNoteWithAuth(db *mongo.Database, itemID primitive.ObjectID, userID primitive.ObjectID) (*Note, error) {
+// GetPublicNoteBySlug returns the note the slug was claimed for, as long as it's still shared publicly
+func GetPublicNoteBySlug(ctx context.Context, db *mongo.Database, slug string) (*Note, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var note Note
+	err := GetNoteCollection(db).FindOne(
+		ctx,
+		bson.M{"$and": []bson.M{
+			{"slug": slug},
+			{"shared_until": bson.M{"$gte": time.Now()}},
+			{"is_deleted": bson.M{"$ne": true}},
+		}}).Decode(&note)
+	if err != nil {
+		return nil, err
+	}
+	if note.SharedAccess != nil && *note.SharedAccess != SharedAccessPublic {
+		return nil, mongo.ErrNoDocuments
+	}
+	return &note, nil
+}
+
+func GetSharedNoteWithAuth(ctx context.Context, db *mongo.Database, itemID primitive.ObjectID, userID primitive.ObjectID) (*Note, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	logger := logging.GetSentryLogger()
 	mongoResult := GetNoteCollection(db).FindOne(
-		context.Background(),
+		ctx,
 		bson.M{"$and": []bson.M{
 			{"_id": itemID},
 			{"shared_until": bson.M{"$gte": time.Now()}},
@@ -329,7 +428,7 @@ func GetSharedNoteWithAuth(db *mongo.Database, itemID primitive.ObjectID, userID
 			return nil, errors.New("invalid shared access value")
 		}
 
-		user, err := GetUser(db, userID)
+		user, err := GetUser(ctx, db, userID)
 		if err != nil {
 			logger.Error().Err(err).Msgf("failed to get user: %+v", userID)
 			return nil, err
@@ -337,7 +436,7 @@ func GetSharedNoteWithAuth(db *mongo.Database, itemID primitive.ObjectID, userID
 
 		// Check if the user is allowed to access the task
 		if *note.SharedAccess == SharedAccessDomain {
-			noteOwner, err := GetUser(db, note.UserID)
+			noteOwner, err := GetUser(ctx, db, note.UserID)
 			if err != nil {
 				logger.Error().Err(err).Msgf("failed to get user: %+v", note.UserID)
 				return nil, err
@@ -364,7 +463,7 @@ func GetSharedNoteWithAuth(db *mongo.Database, itemID primitive.ObjectID, userID
 
 			var event CalendarEvent
 			err := GetCalendarEventCollection(db).FindOne(
-				context.Background(),
+				ctx,
 				bson.M{
 					"$and": []bson.M{
 						{"_id": note.LinkedEventID},
@@ -387,10 +486,12 @@ func GetSharedNoteWithAuth(db *mongo.Database, itemID primitive.ObjectID, userID
 	return &note, nil
 }
 
-func GetTaskByExternalIDWithoutUser(db *mongo.Database, externalID string, logError bool) (*Task, error) {
+func GetTaskByExternalIDWithoutUser(ctx context.Context, db *mongo.Database, externalID string, logError bool) (*Task, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	taskCollection := GetTaskCollection(db)
 	mongoResult := taskCollection.FindOne(
-		context.Background(),
+		ctx,
 		bson.M{
 			"id_external": externalID,
 		})
@@ -407,10 +508,12 @@ func GetTaskByExternalIDWithoutUser(db *mongo.Database, externalID string, logEr
 	return &task, nil
 }
 
-func GetCalendarEventWithoutUserID(db *mongo.Database, itemID primitive.ObjectID) (*CalendarEvent, error) {
+func GetCalendarEventWithoutUserID(ctx context.Context, db *mongo.Database, itemID primitive.ObjectID) (*CalendarEvent, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	logger := logging.GetSentryLogger()
 	mongoResult := GetCalendarEventCollection(db).FindOne(
-		context.Background(),
+		ctx,
 		bson.M{"$and": []bson.M{
 			{"_id": itemID},
 		}})
@@ -424,10 +527,10 @@ func GetCalendarEventWithoutUserID(db *mongo.Database, itemID primitive.ObjectID
 	return &event, nil
 }
 
-func GetCalendarEvent(db *mongo.Database, itemID primitive.ObjectID, userID primitive.ObjectID) (*CalendarEvent, error) {
+func GetCalendarEvent(ctx context.Context, db *mongo.Database, itemID primitive.ObjectID, userID primitive.ObjectID) (*CalendarEvent, error) {
 	logger := logging.GetSentryLogger()
 	eventCollection := GetCalendarEventCollection(db)
-	mongoResult := FindOneWithCollection(eventCollection, userID, itemID)
+	mongoResult := FindOneWithCollection(ctx, eventCollection, userID, itemID)
 
 	var event CalendarEvent
 	err := mongoResult.Decode(&event)
@@ -438,10 +541,10 @@ func GetCalendarEvent(db *mongo.Database, itemID primitive.ObjectID, userID prim
 	return &event, nil
 }
 
-func GetCalendarEventByExternalId(db *mongo.Database, externalID string, userID primitive.ObjectID) (*CalendarEvent, error) {
+func GetCalendarEventByExternalId(ctx context.Context, db *mongo.Database, externalID string, userID primitive.ObjectID) (*CalendarEvent, error) {
 	logger := logging.GetSentryLogger()
 	eventCollection := GetCalendarEventCollection(db)
-	mongoResult := FindOneExternalWithCollection(eventCollection, userID, externalID)
+	mongoResult := FindOneExternalWithCollection(ctx, eventCollection, userID, externalID)
 	if mongoResult.Err() != nil {
 		return nil, mongoResult.Err()
 	}
@@ -455,11 +558,12 @@ func GetCalendarEventByExternalId(db *mongo.Database, externalID string, userID
 	return &event, nil
 }
 
-func GetPullRequestByExternalID(db *mongo.Database, externalID string, userID primitive.ObjectID) (*PullRequest, error) {
+func GetPullRequestByExternalID(ctx context.Context, db *mongo.Database, externalID string, userID primitive.ObjectID) (*PullRequest, error) {
 	logger := logging.GetSentryLogger()
 	var pullRequest PullRequest
 
 	err := FindOneExternalWithCollection(
+		ctx,
 		GetPullRequestCollection(db),
 		userID,
 		externalID,
@@ -474,11 +578,14 @@ func GetPullRequestByExternalID(db *mongo.Database, externalID string, userID pr
 }
 
 func FindOneExternalWithCollection(
+	ctx context.Context,
 	collection *mongo.Collection,
 	userID primitive.ObjectID,
 	externalID string) *mongo.SingleResult {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	return collection.FindOne(
-		context.Background(),
+		ctx,
 		bson.M{"$and": []bson.M{
 			{"id_external": externalID},
 			{"user_id": userID},
@@ -486,20 +593,23 @@ func FindOneExternalWithCollection(
 }
 
 func FindOneWithCollection(
+	ctx context.Context,
 	collection *mongo.Collection,
 	userID primitive.ObjectID,
 	itemID primitive.ObjectID) *mongo.SingleResult {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	return collection.FindOne(
-		context.Background(),
+		ctx,
 		bson.M{"$and": []bson.M{
 			{"_id": itemID},
 			{"user_id": userID},
 		}})
 }
 
-func GetOrCreateTask(db *mongo.Database, userID primitive.ObjectID, IDExternal string, sourceID string, fieldsToInsertIfMissing interface{}) (*Task, error) {
+func GetOrCreateTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, IDExternal string, sourceID string, fieldsToInsertIfMissing interface{}) (*Task, error) {
 	taskCollection := GetTaskCollection(db)
-	mongoResult := GetOrCreateWithCollection(taskCollection, userID, IDExternal, sourceID, fieldsToInsertIfMissing)
+	mongoResult := GetOrCreateWithCollection(ctx, taskCollection, userID, IDExternal, sourceID, fieldsToInsertIfMissing)
 	if mongoResult == nil {
 		return nil, errors.New("unable to create task")
 	}
@@ -515,8 +625,8 @@ func GetOrCreateTask(db *mongo.Database, userID primitive.ObjectID, IDExternal s
 	return &task, nil
 }
 
-func GetOrCreateNote(db *mongo.Database, userID primitive.ObjectID, IDExternal string, sourceID string, fieldsToInsertIfMissing interface{}) (*Note, error) {
-	mongoResult := GetOrCreateWithCollection(GetNoteCollection(db), userID, IDExternal, sourceID, fieldsToInsertIfMissing)
+func GetOrCreateNote(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, IDExternal string, sourceID string, fieldsToInsertIfMissing interface{}) (*Note, error) {
+	mongoResult := GetOrCreateWithCollection(ctx, GetNoteCollection(db), userID, IDExternal, sourceID, fieldsToInsertIfMissing)
 	if mongoResult == nil {
 		return nil, errors.New("unable to create task")
 	}
@@ -532,9 +642,9 @@ func GetOrCreateNote(db *mongo.Database, userID primitive.ObjectID, IDExternal s
 	return &note, nil
 }
 
-func GetOrCreateCalendarEvent(db *mongo.Database, userID primitive.ObjectID, IDExternal string, sourceID string, fieldsToInsertIfMissing interface{}) (*CalendarEvent, error) {
+func GetOrCreateCalendarEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, IDExternal string, sourceID string, fieldsToInsertIfMissing interface{}) (*CalendarEvent, error) {
 	eventCollection := GetCalendarEventCollection(db)
-	mongoResult := GetOrCreateWithCollection(eventCollection, userID, IDExternal, sourceID, fieldsToInsertIfMissing)
+	mongoResult := GetOrCreateWithCollection(ctx, eventCollection, userID, IDExternal, sourceID, fieldsToInsertIfMissing)
 	if mongoResult == nil {
 		return nil, errors.New("unable to create event")
 	}
@@ -550,9 +660,9 @@ func GetOrCreateCalendarEvent(db *mongo.Database, userID primitive.ObjectID, IDE
 	return &event, nil
 }
 
-func GetOrCreatePullRequest(db *mongo.Database, userID primitive.ObjectID, IDExternal string, sourceID string, fieldsToInsertIfMissing interface{}) (*PullRequest, error) {
+func GetOrCreatePullRequest(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, IDExternal string, sourceID string, fieldsToInsertIfMissing interface{}) (*PullRequest, error) {
 	pullRequestCollection := GetPullRequestCollection(db)
-	mongoResult := GetOrCreateWithCollection(pullRequestCollection, userID, IDExternal, sourceID, fieldsToInsertIfMissing)
+	mongoResult := GetOrCreateWithCollection(ctx, pullRequestCollection, userID, IDExternal, sourceID, fieldsToInsertIfMissing)
 	logger := logging.GetSentryLogger()
 
 	if mongoResult == nil {
@@ -571,28 +681,22 @@ func GetOrCreatePullRequest(db *mongo.Database, userID primitive.ObjectID, IDExt
 }
 
 func GetOrCreateWithCollection(
+	ctx context.Context,
 	collection *mongo.Collection,
 	userID primitive.ObjectID,
 	IDExternal string,
 	sourceID string,
 	fieldsToInsertIfMissing interface{}) *mongo.SingleResult {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	dbQuery := getDBQuery(userID, IDExternal, sourceID, nil)
 
-	_, err := collection.UpdateOne(
-		context.Background(),
+	// a single upsert, so the document found is never one another request has only half created
+	return collection.FindOneAndUpdate(
+		ctx,
 		dbQuery,
 		bson.M{"$setOnInsert": fieldsToInsertIfMissing},
-		options.Update().SetUpsert(true),
-	)
-	logger := logging.GetSentryLogger()
-	if err != nil {
-		logger.Error().Err(err).Msg("failed to get or create event")
-		return nil
-	}
-
-	return collection.FindOne(
-		context.Background(),
-		dbQuery,
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
 	)
 }
 
@@ -612,15 +716,17 @@ func getDBQuery(userID primitive.ObjectID, IDExternal string, sourceID string, a
 	return dbQuery
 }
 
-func GetActiveTasks(db *mongo.Database, userID primitive.ObjectID) (*[]Task, error) {
+func GetActiveTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, opts ...*options.FindOptions) (*[]Task, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	taskCollection := GetTaskCollection(db)
-	cursor, err := GetActiveItemsWithCollection(taskCollection, userID)
+	cursor, err := GetActiveItemsWithCollection(ctx, taskCollection, userID, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var tasks []Task
-	err = cursor.All(context.Background(), &tasks)
+	err = cursor.All(ctx, &tasks)
 	if err != nil {
 		logger := logging.GetSentryLogger()
 		logger.Error().Err(err).Msg("failed to fetch tasks for user")
@@ -630,11 +736,14 @@ func GetActiveTasks(db *mongo.Database, userID primitive.ObjectID) (*[]Task, err
 	return &tasks, nil
 }
 
-func GetNotes(db *mongo.Database, userID primitive.ObjectID) (*[]Note, error) {
+func GetNotes(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, opts ...*options.FindOptions) (*[]Note, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	noteCollection := GetNoteCollection(db)
 	cursor, err := noteCollection.Find(
-		context.Background(),
+		ctx,
 		bson.M{"user_id": userID},
+		opts...,
 	)
 	if err != nil {
 		logger := logging.GetSentryLogger()
@@ -643,7 +752,7 @@ func GetNotes(db *mongo.Database, userID primitive.ObjectID) (*[]Note, error) {
 	}
 
 	var notes []Note
-	err = cursor.All(context.Background(), &notes)
+	err = cursor.All(ctx, &notes)
 	if err != nil {
 		logger := logging.GetSentryLogger()
 		logger.Error().Err(err).Msg("failed to fetch notes for user")
@@ -653,15 +762,114 @@ func GetNotes(db *mongo.Database, userID primitive.ObjectID) (*[]Note, error) {
 	return &notes, nil
 }
 
-func GetActivePRs(db *mongo.Database, userID primitive.ObjectID) (*[]PullRequest, error) {
+func GetNotesInFolder(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, folderID primitive.ObjectID, findOptions *options.FindOptions) (*[]Note, error) {
+	var notes []Note
+	err := FindWithCollection(ctx, GetNoteCollection(db), userID, &[]bson.M{{"folder_id": folderID}}, &notes, findOptions)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch notes in folder")
+		return nil, err
+	}
+	return &notes, nil
+}
+
+// GetOrCreateDailyNote returns the user's note for the date, creating it with the title if there
+// isn't one yet
+func GetOrCreateDailyNote(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, date string, title string) (*Note, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	now := primitive.NewDateTimeFromTime(time.Now())
+	var note Note
+	err := GetNoteCollection(db).FindOneAndUpdate(
+		ctx,
+		bson.M{"$and": []bson.M{
+			{"user_id": userID},
+			{"daily_note_date": date},
+			{"is_deleted": bson.M{"$ne": true}},
+		}},
+		bson.M{"$setOnInsert": bson.M{
+			"title":      title,
+			"body":       "",
+			"created_at": now,
+			"updated_at": now,
+		}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&note)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to get or create daily note")
+		return nil, err
+	}
+	return &note, nil
+}
+
+// GetTasksCompletedBetween returns the top level tasks the user completed in the time range, in the
+// order they were completed
+func GetTasksCompletedBetween(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, start time.Time, end time.Time) (*[]Task, error) {
+	var tasks []Task
+	err := FindWithCollection(
+		ctx,
+		GetTaskCollection(db),
+		userID,
+		&[]bson.M{
+			{"is_completed": true},
+			{"is_deleted": bson.M{"$ne": true}},
+			{"parent_task_id": bson.M{"$exists": false}},
+			{"completed_at": bson.M{"$gte": primitive.NewDateTimeFromTime(start)}},
+			{"completed_at": bson.M{"$lt": primitive.NewDateTimeFromTime(end)}},
+		},
+		&tasks,
+		options.Find().SetSort(bson.M{"completed_at": 1}),
+	)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch completed tasks")
+		return nil, err
+	}
+	return &tasks, nil
+}
+
+func GetNoteFolders(ctx context.Context, db *mongo.Database, userID primitive.ObjectID) (*[]NoteFolder, error) {
+	var folders []NoteFolder
+	err := FindWithCollection(ctx, GetNoteFolderCollection(db), userID, nil, &folders, nil)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch note folders")
+		return nil, err
+	}
+	sort.SliceStable(folders, func(i, j int) bool {
+		return ordering.Less(folders[i].OrderingKey, folders[i].IDOrdering, folders[j].OrderingKey, folders[j].IDOrdering)
+	})
+	for idx := range folders {
+		folders[idx].IDOrdering = idx + 1
+	}
+	return &folders, nil
+}
+
+func GetNoteFolder(ctx context.Context, db *mongo.Database, folderID primitive.ObjectID, userID primitive.ObjectID) (*NoteFolder, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var folder NoteFolder
+	err := GetNoteFolderCollection(db).FindOne(
+		ctx,
+		bson.M{"$and": []bson.M{
+			{"_id": folderID},
+			{"user_id": userID},
+		}},
+	).Decode(&folder)
+	if err != nil {
+		return nil, err
+	}
+	return &folder, nil
+}
+
+func GetActivePRs(ctx context.Context, db *mongo.Database, userID primitive.ObjectID) (*[]PullRequest, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	pullRequestCollection := GetPullRequestCollection(db)
-	cursor, err := GetActiveItemsWithCollection(pullRequestCollection, userID)
+	cursor, err := GetActiveItemsWithCollection(ctx, pullRequestCollection, userID)
 	if err != nil {
 		return nil, err
 	}
 
 	var pullRequests []PullRequest
-	err = cursor.All(context.Background(), &pullRequests)
+	err = cursor.All(ctx, &pullRequests)
 	if err != nil {
 		logger := logging.GetSentryLogger()
 		logger.Error().Err(err).Msg("failed to fetch PRs for user")
@@ -670,9 +878,9 @@ func GetActivePRs(db *mongo.Database, userID primitive.ObjectID) (*[]PullRequest
 	return &pullRequests, nil
 }
 
-func GetActiveItemsWithCollection(collection *mongo.Collection, userID primitive.ObjectID) (*mongo.Cursor, error) {
+func GetActiveItemsWithCollection(ctx context.Context, collection *mongo.Collection, userID primitive.ObjectID, opts ...*options.FindOptions) (*mongo.Cursor, error) {
 	cursor, err := collection.Find(
-		context.Background(),
+		ctx,
 		bson.M{
 			"$and": []bson.M{
 				{"user_id": userID},
@@ -680,6 +888,7 @@ func GetActiveItemsWithCollection(collection *mongo.Collection, userID primitive
 				{"is_deleted": bson.M{"$ne": true}},
 			},
 		},
+		opts...,
 	)
 	if err != nil {
 		logger := logging.GetSentryLogger()
@@ -689,9 +898,9 @@ func GetActiveItemsWithCollection(collection *mongo.Collection, userID primitive
 	return cursor, nil
 }
 
-func GetTasks(db *mongo.Database, userID primitive.ObjectID, additionalFilters *[]bson.M, findOptions *options.FindOptions) (*[]Task, error) {
+func GetTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, additionalFilters *[]bson.M, findOptions *options.FindOptions) (*[]Task, error) {
 	var tasks []Task
-	err := FindWithCollection(GetTaskCollection(db), userID, additionalFilters, &tasks, findOptions)
+	err := FindWithCollection(ctx, GetTaskCollection(db), userID, additionalFilters, &tasks, findOptions)
 	if err != nil {
 		logger := logging.GetSentryLogger()
 		logger.Error().Err(err).Msg("failed to fetch items for user")
@@ -700,10 +909,35 @@ func GetTasks(db *mongo.Database, userID primitive.ObjectID, additionalFilters *
 	return &tasks, nil
 }
 
+// GetAssignedTasks returns the active tasks assigned to the user, across all task owners
+func GetAssignedTasks(ctx context.Context, db *mongo.Database, assigneeID primitive.ObjectID) (*[]Task, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	cursor, err := GetTaskCollection(db).Find(
+		ctx,
+		bson.M{"$and": []bson.M{
+			{"assignee_id": assigneeID},
+			{"is_completed": false},
+			{"is_deleted": bson.M{"$ne": true}},
+		}},
+	)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch assigned tasks")
+		return nil, err
+	}
+	var tasks []Task
+	err = cursor.All(ctx, &tasks)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to load assigned tasks")
+		return nil, err
+	}
+	return &tasks, nil
+}
+
 // will add helpers once we refactor tasks collection
-func GetPullRequests(db *mongo.Database, userID primitive.ObjectID, additionalFilters *[]bson.M) (*[]PullRequest, error) {
+func GetPullRequests(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, additionalFilters *[]bson.M) (*[]PullRequest, error) {
 	var pullRequests []PullRequest
-	err := FindWithCollection(GetPullRequestCollection(db), userID, additionalFilters, &pullRequests, nil)
+	err := FindWithCollection(ctx, GetPullRequestCollection(db), userID, additionalFilters, &pullRequests, nil)
 	if err != nil {
 		logger := logging.GetSentryLogger()
 		logger.Error().Err(err).Msg("failed to fetch pull requests for user")
@@ -712,39 +946,139 @@ func GetPullRequests(db *mongo.Database, userID primitive.ObjectID, additionalFi
 	return &pullRequests, nil
 }
 
-func FindWithCollection(collection *mongo.Collection, userID primitive.ObjectID, additionalFilters *[]bson.M, result interface{}, findOptions *options.FindOptions) error {
-	filter := bson.M{
-		"$and": []bson.M{
-			{"user_id": userID},
-		},
-	}
-	if additionalFilters != nil && len(*additionalFilters) > 0 {
-		for _, additionalFilter := range *additionalFilters {
-			filter["$and"] = append(filter["$and"].([]bson.M), additionalFilter)
-		}
-	}
+func FindWithCollection(ctx context.Context, collection *mongo.Collection, userID primitive.ObjectID, additionalFilters *[]bson.M, result interface{}, findOptions *options.FindOptions) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	if findOptions == nil {
 		findOptions = options.Find()
 	}
 
 	cursor, err := collection.Find(
-		context.Background(),
-		filter,
+		ctx,
+		getUserFilter(userID, additionalFilters),
 		findOptions,
 	)
 	if err != nil {
 		return err
 	}
-	return cursor.All(context.Background(), result)
+	return cursor.All(ctx, result)
+}
+
+// FindPageWithCollection returns up to pagination.Limit documents, newest first, starting after
+// pagination.Cursor. The returned cursor is empty once there are no more documents
+func FindPageWithCollection[T any](ctx context.Context, collection *mongo.Collection, userID primitive.ObjectID, additionalFilters *[]bson.M, pagination Pagination, opts ...*options.FindOptions) ([]T, string, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	filters := []bson.M{}
+	if additionalFilters != nil {
+		filters = append(filters, *additionalFilters...)
+	}
+	if pagination.Cursor != nil && *pagination.Cursor != "" {
+		afterID, err := DecodePageCursor(*pagination.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		filters = append(filters, bson.M{"_id": bson.M{"$lt": afterID}})
+	}
+	limit := constants.DEFAULT_PAGE_LIMIT
+	if pagination.Limit != nil {
+		limit = *pagination.Limit
+	}
+
+	// one extra document is fetched to tell whether there's another page
+	findOptions := options.Find().SetSort(bson.M{"_id": -1}).SetLimit(int64(limit + 1))
+	cursor, err := collection.Find(ctx, getUserFilter(userID, &filters), append(opts, findOptions)...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer cursor.Close(ctx)
+
+	results := []T{}
+	lastID := primitive.NilObjectID
+	for cursor.Next(ctx) {
+		if len(results) == limit {
+			return results, EncodePageCursor(lastID), nil
+		}
+		var result T
+		err = cursor.Decode(&result)
+		if err != nil {
+			return nil, "", err
+		}
+		results = append(results, result)
+		lastID = cursor.Current.Lookup("_id").ObjectID()
+	}
+	return results, "", cursor.Err()
+}
+
+func EncodePageCursor(id primitive.ObjectID) string {
+	return base64.RawURLEncoding.EncodeToString(id[:])
+}
+
+func DecodePageCursor(pageCursor string) (primitive.ObjectID, error) {
+	var id primitive.ObjectID
+	decoded, err := base64.RawURLEncoding.DecodeString(pageCursor)
+	if err != nil || len(decoded) != len(id) {
+		return id, errors.New("invalid cursor")
+	}
+	copy(id[:], decoded)
+	return id, nil
+}
+
+// GetFieldProjection builds a projection loading only the given fields of model. Fields are
+// checked against model's bson tags so a typo is reported rather than silently loading nothing
+func GetFieldProjection(model interface{}, fields []string) (bson.M, error) {
+	modelType := reflect.TypeOf(model)
+	if modelType.Kind() == reflect.Pointer {
+		modelType = modelType.Elem()
+	}
+	validFields := map[string]bool{}
+	for i := 0; i < modelType.NumField(); i++ {
+		fieldName := strings.Split(modelType.Field(i).Tag.Get("bson"), ",")[0]
+		if fieldName != "" && fieldName != "-" {
+			validFields[fieldName] = true
+		}
+	}
+
+	projection := bson.M{"_id": 1}
+	for _, field := range fields {
+		// nested fields such as meeting_preparation_params.datetime_start are checked by their top level field
+		if !validFields[strings.Split(field, ".")[0]] {
+			return nil, fmt.Errorf("invalid field: %s", field)
+		}
+		projection[field] = 1
+	}
+	// mongo rejects projections containing both a field and one of its subfields
+	for field := range projection {
+		if _, exists := projection[strings.Split(field, ".")[0]]; exists && strings.Contains(field, ".") {
+			delete(projection, field)
+		}
+	}
+	return projection, nil
+}
+
+func getUserFilter(userID primitive.ObjectID, additionalFilters *[]bson.M) bson.M {
+	filter := bson.M{
+		"$and": []bson.M{
+			{"user_id": userID},
+		},
+	}
+	if additionalFilters != nil && len(*additionalFilters) > 0 {
+		for _, additionalFilter := range *additionalFilters {
+			filter["$and"] = append(filter["$and"].([]bson.M), additionalFilter)
+		}
+	}
+	return filter
 }
 
-func GetCompletedTasks(db *mongo.Database, userID primitive.ObjectID) (*[]Task, error) {
+func GetCompletedTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, opts ...*options.FindOptions) (*[]Task, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	findOptions := options.Find()
 	findOptions.SetSort(bson.D{{Key: "completed_at", Value: -1}, {Key: "_id", Value: -1}})
 	findOptions.SetLimit(int64(constants.MAX_COMPLETED_TASKS))
 
 	cursor, err := GetTaskCollection(db).Find(
-		context.Background(),
+		ctx,
 		bson.M{
 			"$and": []bson.M{
 				{"user_id": userID},
@@ -753,7 +1087,7 @@ func GetCompletedTasks(db *mongo.Database, userID primitive.ObjectID) (*[]Task,
 				{"parent_task_id": bson.M{"$exists": false}},
 			},
 		},
-		findOptions,
+		append([]*options.FindOptions{findOptions}, opts...)...,
 	)
 	logger := logging.GetSentryLogger()
 	if err != nil {
@@ -761,14 +1095,14 @@ func GetCompletedTasks(db *mongo.Database, userID primitive.ObjectID) (*[]Task,
 		return nil, err
 	}
 	var tasks []Task
-	err = cursor.All(context.Background(), &tasks)
+	err = cursor.All(ctx, &tasks)
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to fetch tasks for user")
 		return nil, err
 	}
 
 	cursor, err = GetTaskCollection(db).Find(
-		context.Background(),
+		ctx,
 		bson.M{
 			"$and": []bson.M{
 				{"user_id": userID},
@@ -783,7 +1117,7 @@ func GetCompletedTasks(db *mongo.Database, userID primitive.ObjectID) (*[]Task,
 		return nil, err
 	}
 	var subtasks []Task
-	err = cursor.All(context.Background(), &subtasks)
+	err = cursor.All(ctx, &subtasks)
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to fetch completed subtasks for user")
 		return nil, err
@@ -792,17 +1126,17 @@ func GetCompletedTasks(db *mongo.Database, userID primitive.ObjectID) (*[]Task,
 	return &tasks, nil
 }
 
-func GetSubtasksFromTask(db *mongo.Database, task *Task) (*[]Task, error) {
-	return GetTasks(db, task.UserID, &[]bson.M{{"parent_task_id": task.ID}}, nil)
+func GetSubtasksFromTask(ctx context.Context, db *mongo.Database, task *Task) (*[]Task, error) {
+	return GetTasks(ctx, db, task.UserID, &[]bson.M{{"parent_task_id": task.ID}}, nil)
 }
 
-func GetDeletedTasks(db *mongo.Database, userID primitive.ObjectID) (*[]Task, error) {
+func GetDeletedTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, opts ...*options.FindOptions) (*[]Task, error) {
 	findOptions := options.Find()
 	findOptions.SetSort(bson.D{{Key: "deleted_at", Value: -1}, {Key: "_id", Value: -1}})
 	findOptions.SetLimit(int64(constants.MAX_DELETED_TASKS))
 	filter := []bson.M{{"is_deleted": true}}
 
-	tasks, err := GetTasks(db, userID, &filter, findOptions)
+	tasks, err := GetTasks(ctx, db, userID, &filter, options.MergeFindOptions(append([]*options.FindOptions{findOptions}, opts...)...))
 	if err != nil {
 		logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch deleted tasks for user")
 		return nil, err
@@ -810,9 +1144,47 @@ func GetDeletedTasks(db *mongo.Database, userID primitive.ObjectID) (*[]Task, er
 	return tasks, nil
 }
 
-func GetAllMeetingPreparationTasksUntilEndOfDay(db *mongo.Database, userID primitive.ObjectID, currentTime time.Time) (*[]Task, error) {
-	timeEndOfDay := time.Date(currentTime.Year(), currentTime.Month(), currentTime.Day(), 23, 59, 59, 0, currentTime.Location())
-	return GetTasks(db, userID,
+func GetDeletedNotes(ctx context.Context, db *mongo.Database, userID primitive.ObjectID) (*[]Note, error) {
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "deleted_at", Value: -1}, {Key: "_id", Value: -1}})
+	findOptions.SetLimit(int64(constants.MAX_DELETED_NOTES))
+
+	var notes []Note
+	err := FindWithCollection(ctx, GetNoteCollection(db), userID, &[]bson.M{{"is_deleted": true}}, &notes, findOptions)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch deleted notes for user")
+		return nil, err
+	}
+	return &notes, nil
+}
+
+// PurgeDeletedNotes permanently removes notes that have been in the trash since before the cutoff.
+// Notes deleted before deleted_at was recorded fall back to updated_at, which is set on deletion
+func PurgeDeletedNotes(ctx context.Context, db *mongo.Database, cutoff time.Time) (int64, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	result, err := GetNoteCollection(db).DeleteMany(
+		ctx,
+		bson.M{"$and": []bson.M{
+			{"is_deleted": true},
+			{"$or": []bson.M{
+				{"deleted_at": bson.M{"$lt": primitive.NewDateTimeFromTime(cutoff)}},
+				{"$and": []bson.M{
+					{"deleted_at": bson.M{"$exists": false}},
+					{"updated_at": bson.M{"$lt": primitive.NewDateTimeFromTime(cutoff)}},
+				}},
+			}},
+		}},
+	)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to purge deleted notes")
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+func GetAllMeetingPreparationTasksUntilEndOfDay(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, currentTime time.Time, timeEndOfDay time.Time) (*[]Task, error) {
+	return GetTasks(ctx, db, userID,
 		&[]bson.M{
 			{"is_meeting_preparation_task": true},
 			{"meeting_preparation_params.datetime_start": bson.M{"$gte": currentTime}},
@@ -822,8 +1194,8 @@ func GetAllMeetingPreparationTasksUntilEndOfDay(db *mongo.Database, userID primi
 	)
 }
 
-func GetMeetingPreparationTasks(db *mongo.Database, userID primitive.ObjectID) (*[]Task, error) {
-	return GetTasks(db, userID,
+func GetMeetingPreparationTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID) (*[]Task, error) {
+	return GetTasks(ctx, db, userID,
 		&[]bson.M{
 			{"is_completed": false},
 			{"is_deleted": bson.M{"$ne": true}},
@@ -833,13 +1205,15 @@ func GetMeetingPreparationTasks(db *mongo.Database, userID primitive.ObjectID) (
 	)
 }
 
-func GetEarlierCompletedMeetingPrepTasks(db *mongo.Database, userID primitive.ObjectID, currentTime time.Time) (*[]Task, error) {
+func GetEarlierCompletedMeetingPrepTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, currentTime time.Time) (*[]Task, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	findOptions := options.Find()
 	findOptions.SetSort(bson.D{{Key: "completed_at", Value: -1}, {Key: "_id", Value: -1}})
 	findOptions.SetLimit(int64(constants.MAX_COMPLETED_TASKS))
 
 	cursor, err := GetTaskCollection(db).Find(
-		context.Background(),
+		ctx,
 		bson.M{
 			"$and": []bson.M{
 				{"user_id": userID},
@@ -857,7 +1231,7 @@ func GetEarlierCompletedMeetingPrepTasks(db *mongo.Database, userID primitive.Ob
 		return nil, err
 	}
 	var tasks []Task
-	err = cursor.All(context.Background(), &tasks)
+	err = cursor.All(ctx, &tasks)
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to fetch tasks for user")
 		return nil, err
@@ -865,13 +1239,15 @@ func GetEarlierCompletedMeetingPrepTasks(db *mongo.Database, userID primitive.Ob
 	return &tasks, nil
 }
 
-func GetEarlierDeletedMeetingPrepTasks(db *mongo.Database, userID primitive.ObjectID, currentTime time.Time) (*[]Task, error) {
+func GetEarlierDeletedMeetingPrepTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, currentTime time.Time) (*[]Task, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	findOptions := options.Find()
 	findOptions.SetSort(bson.D{{Key: "deleted_at", Value: -1}, {Key: "_id", Value: -1}})
 	findOptions.SetLimit(int64(constants.MAX_DELETED_TASKS))
 
 	cursor, err := GetTaskCollection(db).Find(
-		context.Background(),
+		ctx,
 		bson.M{
 			"$and": []bson.M{
 				{"user_id": userID},
@@ -888,7 +1264,7 @@ func GetEarlierDeletedMeetingPrepTasks(db *mongo.Database, userID primitive.Obje
 		return nil, err
 	}
 	var tasks []Task
-	err = cursor.All(context.Background(), &tasks)
+	err = cursor.All(ctx, &tasks)
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to fetch tasks for user")
 		return nil, err
@@ -896,14 +1272,16 @@ func GetEarlierDeletedMeetingPrepTasks(db *mongo.Database, userID primitive.Obje
 	return &tasks, nil
 }
 
-func GetTaskSectionName(db *mongo.Database, taskSectionID primitive.ObjectID, userID primitive.ObjectID) (string, error) {
+func GetTaskSectionName(ctx context.Context, db *mongo.Database, taskSectionID primitive.ObjectID, userID primitive.ObjectID) (string, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	if taskSectionID == constants.IDTaskSectionDefault {
 		return GetDefaultSectionName(db, userID), nil
 	}
 
 	var taskSection TaskSection
 	err := GetTaskSectionCollection(db).FindOne(
-		context.Background(),
+		ctx,
 		bson.M{
 			"$and": []bson.M{
 				{"_id": taskSectionID},
@@ -915,10 +1293,9 @@ func GetTaskSectionName(db *mongo.Database, taskSectionID primitive.ObjectID, us
 	return taskSection.Name, err
 }
 
-// Get all events that start until the end of the day
-func GetEventsUntilEndOfDay(db *mongo.Database, userID primitive.ObjectID, currentTime time.Time) (*[]CalendarEvent, error) {
-	timeEndOfDay := time.Date(currentTime.Year(), currentTime.Month(), currentTime.Day(), 23, 59, 59, 0, currentTime.Location())
-	return GetCalendarEvents(db, userID, &[]bson.M{
+// Get all events that start from currentTime until the end of the day, which callers set from the user's working hours
+func GetEventsUntilEndOfDay(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, currentTime time.Time, timeEndOfDay time.Time) (*[]CalendarEvent, error) {
+	return GetCalendarEvents(ctx, db, userID, &[]bson.M{
 		{"datetime_start": bson.M{"$gte": currentTime}},
 		{"datetime_start": bson.M{"$lte": timeEndOfDay}},
 		{"linked_task_id": bson.M{"$exists": false}},
@@ -927,9 +1304,9 @@ func GetEventsUntilEndOfDay(db *mongo.Database, userID primitive.ObjectID, curre
 	})
 }
 
-func GetCalendarEvents(db *mongo.Database, userID primitive.ObjectID, additionalFilters *[]bson.M) (*[]CalendarEvent, error) {
+func GetCalendarEvents(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, additionalFilters *[]bson.M) (*[]CalendarEvent, error) {
 	var calendarEvents []CalendarEvent
-	err := FindWithCollection(GetCalendarEventCollection(db), userID, additionalFilters, &calendarEvents, nil)
+	err := FindWithCollection(ctx, GetCalendarEventCollection(db), userID, additionalFilters, &calendarEvents, nil)
 	if err != nil {
 		logger := logging.GetSentryLogger()
 		logger.Error().Err(err).Msg("failed to fetch events for user")
@@ -937,89 +1314,315 @@ func GetCalendarEvents(db *mongo.Database, userID primitive.ObjectID, additional
 	return &calendarEvents, err
 }
 
-func GetCalendarAccounts(db *mongo.Database, userID primitive.ObjectID) (*[]CalendarAccount, error) {
-	calendarAccountCollection := GetCalendarAccountCollection(db)
-	cursor, err := calendarAccountCollection.Find(
-		context.Background(),
-		bson.M{"user_id": userID},
-	)
+// SearchCalendarEvents returns the user's events whose title, body, location or attendee emails
+// match the query, optionally only those overlapping the time range
+func SearchCalendarEvents(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, query string, start *time.Time, end *time.Time) (*[]CalendarEvent, error) {
+	filters := []bson.M{{"$text": bson.M{"$search": query}}}
+	if start != nil {
+		filters = append(filters, bson.M{"datetime_end": bson.M{"$gt": *start}})
+	}
+	if end != nil {
+		filters = append(filters, bson.M{"datetime_start": bson.M{"$lt": *end}})
+	}
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "datetime_start", Value: 1}, {Key: "_id", Value: 1}})
+	findOptions.SetLimit(int64(constants.MAX_EVENT_SEARCH_RESULTS))
+
+	var calendarEvents []CalendarEvent
+	err := FindWithCollection(ctx, GetCalendarEventCollection(db), userID, &filters, &calendarEvents, findOptions)
 	if err != nil {
-		logger := logging.GetSentryLogger()
-		logger.Error().Err(err).Msg("failed to fetch calendar accounts for user")
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to search events for user")
 		return nil, err
 	}
+	return &calendarEvents, nil
+}
 
-	var accounts []CalendarAccount
-	err = cursor.All(context.Background(), &accounts)
+// GetCalendarEventColors returns the colors the event is shown in: its own if it has one, or else
+// its calendar's
+func GetCalendarEventColors(event CalendarEvent) (string, string) {
+	if event.ColorBackground != "" {
+		return event.ColorBackground, event.ColorForeground
+	}
+	return event.CalendarColorBackground, event.CalendarColorForeground
+}
+
+// GetOutOfOfficeEvents returns the user's out of office events overlapping the time range
+func GetOutOfOfficeEvents(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, start time.Time, end time.Time) (*[]CalendarEvent, error) {
+	return GetCalendarEvents(ctx, db, userID, &[]bson.M{
+		{"category": constants.EventCategoryOutOfOffice},
+		{"datetime_end": bson.M{"$gt": start}},
+		{"datetime_start": bson.M{"$lt": end}},
+	})
+}
+
+// IsOutOfOffice returns whether t is during any of the out of office events
+func IsOutOfOffice(outOfOfficeEvents []CalendarEvent, t time.Time) bool {
+	for _, event := range outOfOfficeEvents {
+		if !t.Before(event.DatetimeStart.Time()) && t.Before(event.DatetimeEnd.Time()) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClearCalendarEventColor removes the event's own color, so it's shown in its calendar's color again
+func ClearCalendarEventColor(ctx context.Context, db *mongo.Database, eventID primitive.ObjectID, userID primitive.ObjectID) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	_, err := GetCalendarEventCollection(db).UpdateOne(
+		ctx,
+		bson.M{"$and": []bson.M{{"_id": eventID}, {"user_id": userID}}},
+		bson.M{"$unset": bson.M{"color_id": "", "color_background": "", "color_foreground": ""}},
+	)
 	if err != nil {
 		logger := logging.GetSentryLogger()
-		logger.Error().Err(err).Msg("failed to fetch calendar accounts for user")
-		return nil, err
+		logger.Error().Err(err).Msg("failed to clear event color")
 	}
+	return err
+}
 
-	return &accounts, nil
+// UpdateCalendarAccountScopes updates the scopes of the calendar account, if it has been fetched yet
+func UpdateCalendarAccountScopes(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, sourceID string, scopes []string) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	_, err := GetCalendarAccountCollection(db).UpdateOne(
+		ctx,
+		bson.M{"user_id": userID, "id_external": accountID, "source_id": sourceID},
+		bson.M{"$set": bson.M{"scopes": scopes}},
+	)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to update calendar account scopes")
+	}
+	return err
 }
 
-func GetTaskSections(db *mongo.Database, userID primitive.ObjectID) (*[]TaskSection, error) {
-	var sections []TaskSection
-	err := FindWithCollection(GetTaskSectionCollection(db), userID, &[]bson.M{{"user_id": userID}}, &sections, nil)
-	logger := logging.GetSentryLogger()
+func GetCalendarAccount(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, sourceID string) (*CalendarAccount, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var account CalendarAccount
+	err := GetCalendarAccountCollection(db).FindOne(
+		ctx,
+		bson.M{"user_id": userID, "id_external": accountID, "source_id": sourceID},
+	).Decode(&account)
 	if err != nil {
-		logger.Error().Err(err).Msg("failed to load task sections")
+		if err != mongo.ErrNoDocuments {
+			logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch calendar account")
+		}
 		return nil, err
 	}
-	return &sections, nil
+	return &account, nil
 }
 
-func MarkCompleteWithCollection(collection *mongo.Collection, itemID primitive.ObjectID) error {
-	res, err := collection.UpdateOne(
-		context.Background(),
-		bson.M{"_id": itemID},
-		bson.M{"$set": bson.M{
-			"is_completed": true,
-			"completed_at": primitive.NewDateTimeFromTime(time.Now()),
-		}},
+// SetCalendarSyncDisabled turns syncing of one of the account's calendars on or off, returning
+// mongo.ErrNoDocuments if the account doesn't have the calendar
+func SetCalendarSyncDisabled(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, calendarID string, syncDisabled bool) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	result, err := GetCalendarAccountCollection(db).UpdateOne(
+		ctx,
+		bson.M{"user_id": userID, "id_external": accountID, "calendars.calendar_id": calendarID},
+		bson.M{"$set": bson.M{"calendars.$.sync_disabled": syncDisabled}},
 	)
 	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to update calendar sync")
 		return err
 	}
-	if res.MatchedCount != 1 {
-		return errors.New("did not find item to mark complete")
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
 	}
 	return nil
 }
 
-func GetUser(db *mongo.Database, userID primitive.ObjectID) (*User, error) {
-	var userObject User
-	err := GetUserCollection(db).FindOne(
-		context.Background(),
-		bson.M{"_id": userID},
-	).Decode(&userObject)
-	logger := logging.GetSentryLogger()
+func GetCalendarAccounts(ctx context.Context, db *mongo.Database, userID primitive.ObjectID) (*[]CalendarAccount, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	calendarAccountCollection := GetCalendarAccountCollection(db)
+	cursor, err := calendarAccountCollection.Find(
+		ctx,
+		bson.M{"user_id": userID},
+	)
 	if err != nil {
-		logger.Error().Err(err).Msg("failed to load user")
+		logger := logging.GetSentryLogger()
+		logger.Error().Err(err).Msg("failed to fetch calendar accounts for user")
 		return nil, err
 	}
-	return &userObject, nil
-}
-
-func GetGeneralTaskUserByName(db *mongo.Database, name string) (*User, error) {
-	var user User
 
-	if err := GetUserCollection(db).FindOne(
-		context.Background(),
-		bson.M{"email": name + "@resonant-kelpie-404a42.netlify.app"}).Decode(&user); err != nil {
+	var accounts []CalendarAccount
+	err = cursor.All(ctx, &accounts)
+	if err != nil {
+		logger := logging.GetSentryLogger()
+		logger.Error().Err(err).Msg("failed to fetch calendar accounts for user")
 		return nil, err
 	}
-	return &user, nil
+
+	return &accounts, nil
 }
 
-func CreateStateToken(db *mongo.Database, userID *primitive.ObjectID, useDeeplink bool) (*string, error) {
-	stateToken := &StateToken{UseDeeplink: useDeeplink}
-	if userID != nil {
-		stateToken.UserID = *userID
+// GetTaskSections returns the user's sections, leaving out archived ones unless includeArchived is set
+func GetTaskSections(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, includeArchived bool) (*[]TaskSection, error) {
+	sections, err := CachedRead(userID, "task_sections", func() ([]TaskSection, error) {
+		var sections []TaskSection
+		err := FindWithCollection(ctx, GetTaskSectionCollection(db), userID, &[]bson.M{{"user_id": userID}}, &sections, nil)
+		return sections, err
+	})
+	logger := logging.GetSentryLogger()
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to load task sections")
+		return nil, err
+	}
+	// copied since callers append to the result
+	sectionsCopy := []TaskSection{}
+	for _, section := range sections {
+		if includeArchived || !section.IsArchived {
+			sectionsCopy = append(sectionsCopy, section)
+		}
+	}
+	return &sectionsCopy, nil
+}
+
+// MoveOpenTasksToSection moves the section's open tasks to the end of another section, keeping their order
+func MoveOpenTasksToSection(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, fromSectionID primitive.ObjectID, toSectionID primitive.ObjectID) (int64, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	logger := logging.GetSentryLogger()
+	taskCollection := GetTaskCollection(db)
+	openTaskFilters := func(sectionID primitive.ObjectID) *[]bson.M {
+		return &[]bson.M{
+			{"id_task_section": sectionID},
+			{"is_completed": false},
+			{"is_deleted": bson.M{"$ne": true}},
+		}
+	}
+
+	var lastTasks []ReorderableSubmodel
+	err := FindWithCollection(ctx, taskCollection, userID, openTaskFilters(toSectionID), &lastTasks, options.Find().SetSort(reverseOrderingSort).SetLimit(1))
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to find last task in section")
+		return 0, err
+	}
+	lastOrderingKey := ""
+	lastOrderingID := 0
+	if len(lastTasks) > 0 {
+		lastOrderingKey = lastTasks[0].OrderingKey
+		lastOrderingID = lastTasks[0].IDOrdering
+	}
+
+	var tasksToMove []ReorderableSubmodel
+	err = FindWithCollection(ctx, taskCollection, userID, openTaskFilters(fromSectionID), &tasksToMove, options.Find().SetSort(orderingSort))
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to find tasks to move")
+		return 0, err
+	}
+	if len(tasksToMove) == 0 {
+		return 0, nil
+	}
+	orderingKeys, err := ordering.KeysBetween(lastOrderingKey, "", len(tasksToMove))
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to generate ordering keys")
+		return 0, err
+	}
+
+	var operations []mongo.WriteModel
+	for idx, task := range tasksToMove {
+		operation := mongo.NewUpdateOneModel()
+		// skip tasks which were moved or completed since they were looked up
+		operation.SetFilter(bson.M{"$and": []bson.M{
+			{"_id": task.ID},
+			{"user_id": userID},
+			{"id_task_section": fromSectionID},
+			{"is_completed": false},
+		}})
+		operation.SetUpdate(bson.M{"$set": bson.M{
+			"id_task_section": toSectionID,
+			"ordering_key":    orderingKeys[idx],
+			"id_ordering":     lastOrderingID + idx + 1,
+		}})
+		operations = append(operations, operation)
+	}
+	res, err := taskCollection.BulkWrite(ctx, operations)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to move tasks to section")
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+func MarkCompleteWithCollection(ctx context.Context, collection *mongo.Collection, itemID primitive.ObjectID) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	res, err := collection.UpdateOne(
+		ctx,
+		bson.M{"_id": itemID},
+		bson.M{"$set": bson.M{
+			"is_completed": true,
+			"completed_at": primitive.NewDateTimeFromTime(time.Now()),
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount != 1 {
+		return errors.New("did not find item to mark complete")
+	}
+	return nil
+}
+
+// SetPullRequestMergedAt records when the pull request was merged on Github
+func SetPullRequestMergedAt(ctx context.Context, db *mongo.Database, pullRequestID primitive.ObjectID, mergedAt time.Time) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	_, err := GetPullRequestCollection(db).UpdateOne(
+		ctx,
+		bson.M{"_id": pullRequestID},
+		bson.M{"$set": bson.M{"merged_at": primitive.NewDateTimeFromTime(mergedAt)}},
+	)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msgf("failed to set merged at for pull request: %+v", pullRequestID)
+		return err
+	}
+	return nil
+}
+
+func GetUser(ctx context.Context, db *mongo.Database, userID primitive.ObjectID) (*User, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	userObject, err := CachedRead(userID, "user", func() (User, error) {
+		var userObject User
+		err := GetUserCollection(db).FindOne(
+			ctx,
+			bson.M{"_id": userID},
+		).Decode(&userObject)
+		return userObject, err
+	})
+	logger := logging.GetSentryLogger()
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to load user")
+		return nil, err
 	}
-	cursor, err := GetStateTokenCollection(db).InsertOne(context.Background(), stateToken)
+	return &userObject, nil
+}
+
+func GetGeneralTaskUserByName(ctx context.Context, db *mongo.Database, name string) (*User, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var user User
+
+	if err := GetUserCollection(db).FindOne(
+		ctx,
+		bson.M{"email": name + "@resonant-kelpie-404a42.netlify.app"}).Decode(&user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func CreateStateToken(ctx context.Context, db *mongo.Database, userID *primitive.ObjectID, useDeeplink bool) (*string, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	stateToken := &StateToken{UseDeeplink: useDeeplink}
+	if userID != nil {
+		stateToken.UserID = *userID
+	}
+	cursor, err := GetStateTokenCollection(db).InsertOne(ctx, stateToken)
 	logger := logging.GetSentryLogger()
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to create new state token")
@@ -1029,7 +1632,22 @@ func CreateStateToken(db *mongo.Database, userID *primitive.ObjectID, useDeeplin
 	return &stateTokenStr, nil
 }
 
-func GetStateToken(db *mongo.Database, stateTokenID primitive.ObjectID, userID *primitive.ObjectID) (*StateToken, error) {
+// CreateRelinkStateToken creates a state token for linking an account again, so the callback replaces
+// the existing external token rather than linking another account
+func CreateRelinkStateToken(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, relinkTokenID primitive.ObjectID) (primitive.ObjectID, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	result, err := GetStateTokenCollection(db).InsertOne(ctx, &StateToken{UserID: userID, RelinkTokenID: relinkTokenID})
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to create relink state token")
+		return primitive.NilObjectID, err
+	}
+	return result.InsertedID.(primitive.ObjectID), nil
+}
+
+func GetStateToken(ctx context.Context, db *mongo.Database, stateTokenID primitive.ObjectID, userID *primitive.ObjectID) (*StateToken, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	var query bson.M
 	if userID == nil {
 		query = bson.M{"_id": stateTokenID}
@@ -1037,7 +1655,7 @@ func GetStateToken(db *mongo.Database, stateTokenID primitive.ObjectID, userID *
 		query = bson.M{"$and": []bson.M{{"user_id": *userID}, {"_id": stateTokenID}}}
 	}
 	var token StateToken
-	err := GetStateTokenCollection(db).FindOne(context.Background(), query).Decode(&token)
+	err := GetStateTokenCollection(db).FindOne(ctx, query).Decode(&token)
 	logger := logging.GetSentryLogger()
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to get state token")
@@ -1046,14 +1664,16 @@ func GetStateToken(db *mongo.Database, stateTokenID primitive.ObjectID, userID *
 	return &token, nil
 }
 
-func DeleteStateToken(db *mongo.Database, stateTokenID primitive.ObjectID, userID *primitive.ObjectID) error {
+func DeleteStateToken(ctx context.Context, db *mongo.Database, stateTokenID primitive.ObjectID, userID *primitive.ObjectID) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	var deletionQuery bson.M
 	if userID == nil {
 		deletionQuery = bson.M{"_id": stateTokenID}
 	} else {
 		deletionQuery = bson.M{"$and": []bson.M{{"user_id": *userID}, {"_id": stateTokenID}}}
 	}
-	result, err := GetStateTokenCollection(db).DeleteOne(context.Background(), deletionQuery)
+	result, err := GetStateTokenCollection(db).DeleteOne(ctx, deletionQuery)
 	logger := logging.GetSentryLogger()
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to delete state token")
@@ -1065,8 +1685,10 @@ func DeleteStateToken(db *mongo.Database, stateTokenID primitive.ObjectID, userI
 	return nil
 }
 
-func InsertLogEvent(db *mongo.Database, userID primitive.ObjectID, eventType string) error {
-	_, err := GetLogEventsCollection(db).InsertOne(context.Background(), &LogEvent{
+func InsertLogEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, eventType string) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	_, err := GetLogEventsCollection(db).InsertOne(ctx, &LogEvent{
 		UserID:    userID,
 		EventType: eventType,
 		CreatedAt: primitive.NewDateTimeFromTime(time.Now()),
@@ -1074,10 +1696,12 @@ func InsertLogEvent(db *mongo.Database, userID primitive.ObjectID, eventType str
 	return err
 }
 
-func GetExternalToken(db *mongo.Database, externalID string, serviceID string) (*ExternalAPIToken, error) {
+func GetExternalToken(ctx context.Context, db *mongo.Database, externalID string, serviceID string) (*ExternalAPIToken, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	var externalAPIToken ExternalAPIToken
 	err := GetExternalTokenCollection(db).FindOne(
-		context.Background(),
+		ctx,
 		bson.M{
 			"$and": []bson.M{
 				{"service_id": serviceID},
@@ -1093,10 +1717,12 @@ func GetExternalToken(db *mongo.Database, externalID string, serviceID string) (
 	return &externalAPIToken, nil
 }
 
-func GetExternalTokenByExternalID(db *mongo.Database, externalID string, serviceID string, logError bool) (*ExternalAPIToken, error) {
+func GetExternalTokenByExternalID(ctx context.Context, db *mongo.Database, externalID string, serviceID string, logError bool) (*ExternalAPIToken, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	var externalAPIToken ExternalAPIToken
 	err := GetExternalTokenCollection(db).FindOne(
-		context.Background(),
+		ctx,
 		bson.M{
 			"$and": []bson.M{
 				{"service_id": serviceID},
@@ -1114,9 +1740,10 @@ func GetExternalTokenByExternalID(db *mongo.Database, externalID string, service
 	return &externalAPIToken, nil
 }
 
-func GetExternalTokens(db *mongo.Database, userID primitive.ObjectID, serviceID string) (*[]ExternalAPIToken, error) {
+func GetExternalTokens(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, serviceID string) (*[]ExternalAPIToken, error) {
 	var tokens []ExternalAPIToken
 	err := FindWithCollection(
+		ctx,
 		GetExternalTokenCollection(db),
 		userID,
 		&[]bson.M{{"service_id": serviceID}},
@@ -1131,11 +1758,13 @@ func GetExternalTokens(db *mongo.Database, userID primitive.ObjectID, serviceID
 	return &tokens, nil
 }
 
-func GetAllExternalTokens(db *mongo.Database, userID primitive.ObjectID) ([]ExternalAPIToken, error) {
+func GetAllExternalTokens(ctx context.Context, db *mongo.Database, userID primitive.ObjectID) ([]ExternalAPIToken, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	var tokens []ExternalAPIToken
 	externalAPITokenCollection := GetExternalTokenCollection(db)
 	cursor, err := externalAPITokenCollection.Find(
-		context.Background(),
+		ctx,
 		bson.M{"user_id": userID},
 	)
 	logger := logging.GetSentryLogger()
@@ -1143,7 +1772,7 @@ func GetAllExternalTokens(db *mongo.Database, userID primitive.ObjectID) ([]Exte
 		logger.Error().Err(err).Msg("failed to fetch api tokens")
 		return []ExternalAPIToken{}, err
 	}
-	err = cursor.All(context.Background(), &tokens)
+	err = cursor.All(ctx, &tokens)
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to iterate through api tokens")
 		return []ExternalAPIToken{}, err
@@ -1151,81 +1780,236 @@ func GetAllExternalTokens(db *mongo.Database, userID primitive.ObjectID) ([]Exte
 	return tokens, nil
 }
 
-func GetDefaultSectionName(db *mongo.Database, userID primitive.ObjectID) string {
-	return constants.TaskSectionNameDefault
+// GetExternalTokensDueForRefresh returns the working tokens for the services whose next refresh
+// attempt isn't being held off after a failure
+func GetExternalTokensDueForRefresh(ctx context.Context, db *mongo.Database, serviceIDs []string, now time.Time) ([]ExternalAPIToken, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var tokens []ExternalAPIToken
+	cursor, err := GetExternalTokenCollection(db).Find(
+		ctx,
+		bson.M{
+			"is_bad_token": false,
+			"service_id":   bson.M{"$in": serviceIDs},
+			"$or": []bson.M{
+				{"next_refresh_attempt_at": bson.M{"$exists": false}},
+				{"next_refresh_attempt_at": bson.M{"$lte": primitive.NewDateTimeFromTime(now)}},
+			},
+		},
+	)
+	logger := logging.GetSentryLogger()
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch api tokens due for refresh")
+		return []ExternalAPIToken{}, err
+	}
+	err = cursor.All(ctx, &tokens)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to iterate through api tokens due for refresh")
+		return []ExternalAPIToken{}, err
+	}
+	return tokens, nil
+}
+
+func InsertExternalTokenHealthEvent(ctx context.Context, db *mongo.Database, event ExternalTokenHealthEvent) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	_, err := GetExternalTokenHealthCollection(db).InsertOne(ctx, event)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msgf("failed to record %s token health event", event.Status)
+	}
+	return err
 }
 
-func GetView(db *mongo.Database, userID primitive.ObjectID, viewID primitive.ObjectID) (*View, error) {
+// GetExternalTokenHealthEvents returns the token's most recent health events, newest first
+func GetExternalTokenHealthEvents(ctx context.Context, db *mongo.Database, tokenID primitive.ObjectID, limit int64) ([]ExternalTokenHealthEvent, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	events := []ExternalTokenHealthEvent{}
+	cursor, err := GetExternalTokenHealthCollection(db).Find(
+		ctx,
+		bson.M{"token_id": tokenID},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(limit),
+	)
 	logger := logging.GetSentryLogger()
-	viewCollection := GetViewCollection(db)
-	mongoResult := FindOneWithCollection(viewCollection, userID, viewID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch token health events")
+		return nil, err
+	}
+	err = cursor.All(ctx, &events)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to iterate through token health events")
+		return nil, err
+	}
+	return events, nil
+}
 
-	var view View
-	err := mongoResult.Decode(&view)
+func GetDefaultSectionName(db *mongo.Database, userID primitive.ObjectID) string {
+	return constants.TaskSectionNameDefault
+}
+
+// GetDefaultSectionSettings returns the user's default landing sections, one per source
+func GetDefaultSectionSettings(ctx context.Context, db *mongo.Database, userID primitive.ObjectID) (*[]DefaultSectionSettings, error) {
+	var settings []DefaultSectionSettings
+	err := FindWithCollection(
+		ctx,
+		GetDefaultSectionSettingsCollection(db),
+		userID,
+		&[]bson.M{{"source_id": bson.M{"$exists": true}}},
+		&settings,
+		options.Find().SetSort(bson.M{"source_id": 1}),
+	)
 	if err != nil {
-		logger.Error().Err(err).Msgf("failed to get view: %+v", viewID)
+		logger := logging.GetSentryLogger()
+		logger.Error().Err(err).Msg("failed to load default section settings")
 		return nil, err
 	}
-	return &view, nil
+	return &settings, nil
 }
 
-type ReorderableSubmodel struct {
-	ID         primitive.ObjectID `bson:"_id,omitempty"`
-	IDOrdering int                `bson:"id_ordering"`
+// GetDefaultTaskSectionID returns the section new tasks from the source should land in,
+// falling back to the task inbox. Sources only set it when a task is first fetched, so
+// tasks the user has since moved stay where they are
+func GetDefaultTaskSectionID(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, sourceID string) primitive.ObjectID {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var setting DefaultSectionSettings
+	err := GetDefaultSectionSettingsCollection(db).FindOne(
+		ctx,
+		bson.M{"$and": []bson.M{
+			{"user_id": userID},
+			{"source_id": sourceID},
+		}},
+	).Decode(&setting)
+	if err != nil {
+		if err != mongo.ErrNoDocuments {
+			logger := logging.GetSentryLogger()
+			logger.Error().Err(err).Msg("failed to load default section setting")
+		}
+		return constants.IDTaskSectionDefault
+	}
+	if setting.TaskSectionID == primitive.NilObjectID {
+		return constants.IDTaskSectionDefault
+	}
+	return setting.TaskSectionID
 }
 
-func AdjustOrderingIDsForCollection(collection *mongo.Collection, userID primitive.ObjectID, itemID primitive.ObjectID, orderingID int) error {
-	_, err := collection.UpdateMany(
-		context.Background(),
+// UpdateOrCreateDefaultSectionSetting sets the section new tasks from the source land in
+func UpdateOrCreateDefaultSectionSetting(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, sourceID string, taskSectionID primitive.ObjectID) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	_, err := GetDefaultSectionSettingsCollection(db).UpdateOne(
+		ctx,
 		bson.M{"$and": []bson.M{
-			{"_id": bson.M{"$ne": itemID}},
 			{"user_id": userID},
-			{"id_ordering": bson.M{"$gte": orderingID}},
+			{"source_id": sourceID},
 		}},
-		bson.M{"$inc": bson.M{"id_ordering": 1}},
+		bson.M{"$set": bson.M{
+			"user_id":         userID,
+			"source_id":       sourceID,
+			"task_section_id": taskSectionID,
+		}},
+		options.Update().SetUpsert(true),
 	)
-	logger := logging.GetSentryLogger()
 	if err != nil {
-		logger.Error().Err(err).Msg("failed to modify view id_orderings")
+		logger := logging.GetSentryLogger()
+		logger.Error().Err(err).Msg("failed to update default section setting")
 		return err
 	}
+	return nil
+}
 
-	// Normalize ordering IDs
-	var items []ReorderableSubmodel
+// UpdateGmailTaskLabel sets the label which turns the Google account's emails into tasks
+func UpdateGmailTaskLabel(ctx context.Context, db *mongo.Database, tokenID primitive.ObjectID, labelID string) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	_, err := GetExternalTokenCollection(db).UpdateOne(
+		ctx,
+		bson.M{"_id": tokenID},
+		bson.M{"$set": bson.M{"gmail_task_label_id": labelID}},
+	)
+	if err != nil {
+		logger := logging.GetSentryLogger()
+		logger.Error().Err(err).Msg("failed to update gmail task label")
+	}
+	return err
+}
 
-	options := options.Find().SetSort(bson.M{"id_ordering": 1})
-	cursor, err := collection.Find(context.Background(), bson.M{"user_id": userID}, options)
+// GetOrCreateExternalTaskSection returns the section synced from an external list, creating it the first
+// time the list is seen. The name is only set on creation, so renaming the section here sticks
+func GetOrCreateExternalTaskSection(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, sourceID string, idExternal string, name string) (primitive.ObjectID, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var section TaskSection
+	err := GetTaskSectionCollection(db).FindOneAndUpdate(
+		ctx,
+		bson.M{"$and": []bson.M{
+			{"user_id": userID},
+			{"source_id": sourceID},
+			{"id_external": idExternal},
+		}},
+		bson.M{"$setOnInsert": bson.M{"name": name}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&section)
 	if err != nil {
-		logger.Error().Err(err).Msg("failed to get items")
-		return err
+		logger := logging.GetSentryLogger()
+		logger.Error().Err(err).Msg("failed to get or create external task section")
+		return primitive.NilObjectID, err
+	}
+	return section.ID, nil
+}
+
+func GetView(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, viewID primitive.ObjectID) (*View, error) {
+	logger := logging.GetSentryLogger()
+	viewCollection := GetViewCollection(db)
+	mongoResult := FindOneWithCollection(ctx, viewCollection, userID, viewID)
+
+	var view View
+	err := mongoResult.Decode(&view)
+	if err != nil {
+		logger.Error().Err(err).Msgf("failed to get view: %+v", viewID)
+		return nil, err
+	}
+	return &view, nil
+}
+
+// UpdateViewsLastFetched records that the sources behind the user's matching views were just fetched
+func UpdateViewsLastFetched(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, additionalFilters *[]bson.M, lastFetched time.Time) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	filter := bson.M{"$and": []bson.M{{"user_id": userID}}}
+	if additionalFilters != nil && len(*additionalFilters) > 0 {
+		filter = bson.M{"$and": append([]bson.M{{"user_id": userID}}, *additionalFilters...)}
 	}
-	err = cursor.All(context.Background(), &items)
+	_, err := GetViewCollection(db).UpdateMany(
+		ctx,
+		filter,
+		bson.M{"$set": bson.M{"last_fetched": primitive.NewDateTimeFromTime(lastFetched)}},
+	)
 	if err != nil {
-		logger.Error().Err(err).Msg("failed to get items")
+		logger := logging.GetSentryLogger()
+		logger.Error().Err(err).Msg("failed to update views last fetched")
 		return err
 	}
+	return nil
+}
 
-	for index, item := range items {
-		newIDOrdering := index + 1
-		if item.IDOrdering != newIDOrdering {
-			_, err = collection.UpdateOne(
-				context.Background(),
-				bson.M{"$and": []bson.M{
-					{"_id": item.ID},
-					{"user_id": userID}},
-				},
-				bson.M{"$set": bson.M{"id_ordering": newIDOrdering}},
-			)
-			if err != nil {
-				logger.Error().Err(err).Msg("failed to update ordering ids")
-				return err
-			}
-		}
+// GetJiraJQLViews returns the JQL views saved against one of the user's linked JIRA accounts
+func GetJiraJQLViews(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string) ([]View, error) {
+	var views []View
+	err := FindWithCollection(ctx, GetViewCollection(db), userID, &[]bson.M{
+		{"type": string(constants.ViewJiraJQL)},
+		{"account_id": accountID},
+	}, &views, nil)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch JQL views")
+		return nil, err
 	}
-	return nil
+	return views, nil
 }
 
-func LogRequestInfo(db *mongo.Database, timestamp time.Time, userID primitive.ObjectID, method string, latencyMS int64, objectID *primitive.ObjectID, statusCode int) {
+func LogRequestInfo(ctx context.Context, db *mongo.Database, timestamp time.Time, userID primitive.ObjectID, method string, latencyMS int64, objectID *primitive.ObjectID, statusCode int) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	requestInfo := ServerRequestInfo{
 		Timestamp:  primitive.NewDateTimeFromTime(timestamp),
 		UserID:     userID,
@@ -1236,17 +2020,19 @@ func LogRequestInfo(db *mongo.Database, timestamp time.Time, userID primitive.Ob
 	if objectID != nil {
 		requestInfo.ObjectID = *objectID
 	}
-	_, err := GetServerRequestCollection(db).InsertOne(context.Background(), &requestInfo)
+	_, err := GetServerRequestCollection(db).InsertOne(ctx, &requestInfo)
 	if err != nil {
 		logger := logging.GetSentryLogger()
 		logger.Error().Err(err).Msg("failed to log server request of method " + method)
 	}
 }
 
-func UpdateUserSetting(db *mongo.Database, userID primitive.ObjectID, fieldKey string, fieldValue string) error {
+func UpdateUserSetting(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, fieldKey string, fieldValue string) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	settingCollection := GetUserSettingsCollection(db)
 	_, err := settingCollection.UpdateOne(
-		context.Background(),
+		ctx,
 		bson.M{"$and": []bson.M{
 			{"user_id": userID},
 			{"field_key": fieldKey},
@@ -1264,12 +2050,62 @@ func UpdateUserSetting(db *mongo.Database, userID primitive.ObjectID, fieldKey s
 	return nil
 }
 
-func GetOrCreateDashboardTeam(db *mongo.Database, userID primitive.ObjectID) (*DashboardTeam, error) {
+func GetUserSetting(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, fieldKey string) (*UserSetting, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var userSetting UserSetting
+	err := GetUserSettingsCollection(db).FindOne(
+		ctx,
+		bson.M{"$and": []bson.M{
+			{"user_id": userID},
+			{"field_key": fieldKey},
+		}},
+	).Decode(&userSetting)
+	if err != nil {
+		return nil, err
+	}
+	return &userSetting, nil
+}
+
+func GetRepositories(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, additionalFilters *[]bson.M) (*[]Repository, error) {
+	var repositories []Repository
+	err := FindWithCollection(ctx, GetRepositoryCollection(db), userID, additionalFilters, &repositories, options.Find().SetSort(bson.M{"full_name": 1}))
+	if err != nil {
+		logger := logging.GetSentryLogger()
+		logger.Error().Err(err).Msg("failed to fetch repositories for user")
+		return nil, err
+	}
+	return &repositories, nil
+}
+
+func UpdateRepositoryFilterList(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, repositoryID primitive.ObjectID, isInFilterList bool) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	res, err := GetRepositoryCollection(db).UpdateOne(
+		ctx,
+		bson.M{"$and": []bson.M{
+			{"_id": repositoryID},
+			{"user_id": userID},
+		}},
+		bson.M{"$set": bson.M{"is_in_filter_list": isInFilterList}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount != 1 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func GetOrCreateDashboardTeam(ctx context.Context, db *mongo.Database, userID primitive.ObjectID) (*DashboardTeam, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	teamCollection := GetDashboardTeamCollection(db)
 
 	var dashboardTeam DashboardTeam
 	err := teamCollection.FindOneAndUpdate(
-		context.Background(),
+		ctx,
 		bson.M{"user_id": userID},
 		bson.M{"$setOnInsert": DashboardTeam{
 			UserID:    userID,
@@ -1284,10 +2120,32 @@ func GetOrCreateDashboardTeam(db *mongo.Database, userID primitive.ObjectID) (*D
 	return &dashboardTeam, nil
 }
 
-func GetDashboardTeamMembers(db *mongo.Database, teamID primitive.ObjectID) (*[]DashboardTeamMember, error) {
+func GetAllDashboardTeams(ctx context.Context, db *mongo.Database) (*[]DashboardTeam, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	cursor, err := GetDashboardTeamCollection(db).Find(ctx, bson.M{})
+	if err != nil {
+		logger := logging.GetSentryLogger()
+		logger.Error().Err(err).Msg("failed to fetch dashboard teams")
+		return nil, err
+	}
+
+	var teams []DashboardTeam
+	err = cursor.All(ctx, &teams)
+	if err != nil {
+		logger := logging.GetSentryLogger()
+		logger.Error().Err(err).Msg("failed to load dashboard teams")
+		return nil, err
+	}
+	return &teams, nil
+}
+
+func GetDashboardTeamMembers(ctx context.Context, db *mongo.Database, teamID primitive.ObjectID) (*[]DashboardTeamMember, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
 	teamMemberCollection := GetDashboardTeamMemberCollection(db)
 	cursor, err := teamMemberCollection.Find(
-		context.Background(),
+		ctx,
 		bson.M{"team_id": teamID},
 	)
 	if err != nil {
@@ -1297,7 +2155,7 @@ func GetDashboardTeamMembers(db *mongo.Database, teamID primitive.ObjectID) (*[]
 	}
 
 	var teamMembers []DashboardTeamMember
-	err = cursor.All(context.Background(), &teamMembers)
+	err = cursor.All(ctx, &teamMembers)
 	if err != nil {
 		logger := logging.GetSentryLogger()
 		logger.Error().Err(err).Msg("failed to load team members")
@@ -1306,50 +2164,953 @@ func GetDashboardTeamMembers(db *mongo.Database, teamID primitive.ObjectID) (*[]
 	return &teamMembers, nil
 }
 
-func GetDashboardDataPoints(db *mongo.Database, teamID primitive.ObjectID, now time.Time, lookbackDays int) (*[]DashboardDataPoint, error) {
-	dataPointCollection := GetDashboardDataPointCollection(db)
-	cursor, err := dataPointCollection.Find(
-		context.Background(),
-		bson.M{"$and": []bson.M{
-			// this timestamp is approximate for now, will refine as needed
-			{"date": bson.M{"$gte": now.Add(-time.Hour * 24 * time.Duration(lookbackDays))}},
-			{"$or": []bson.M{
-				{"team_id": teamID},
-				{"team_id": bson.M{"$exists": false}},
-			}}}},
-	)
+func GetDashboardTeam(ctx context.Context, db *mongo.Database, teamID primitive.ObjectID) (*DashboardTeam, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var dashboardTeam DashboardTeam
+	err := GetDashboardTeamCollection(db).FindOne(ctx, bson.M{"_id": teamID}).Decode(&dashboardTeam)
 	if err != nil {
-		logger := logging.GetSentryLogger()
-		logger.Error().Err(err).Msg("failed to fetch data points")
 		return nil, err
 	}
+	return &dashboardTeam, nil
+}
 
-	var dataPoints []DashboardDataPoint
-	err = cursor.All(context.Background(), &dataPoints)
+// GetDashboardTeamForUser returns the team the user has joined through an invite, falling back to the team they own
+func GetDashboardTeamForUser(ctx context.Context, db *mongo.Database, userID primitive.ObjectID) (*DashboardTeam, error) {
+	membership, err := GetDashboardTeamMembership(ctx, db, userID)
+	if err == mongo.ErrNoDocuments {
+		return GetOrCreateDashboardTeam(ctx, db, userID)
+	}
 	if err != nil {
-		logger := logging.GetSentryLogger()
-		logger.Error().Err(err).Msg("failed to load data points")
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch dashboard team membership")
 		return nil, err
 	}
-	return &dataPoints, nil
-}
-
-func GetServerRequestCollection(db *mongo.Database) *mongo.Collection {
-	return db.Collection("server_requests")
+	return GetDashboardTeam(ctx, db, membership.TeamID)
 }
 
-func GetStateTokenCollection(db *mongo.Database) *mongo.Collection {
-	return db.Collection("state_tokens")
+// GetDashboardTeamMembership returns the user's accepted membership in another user's team
+func GetDashboardTeamMembership(ctx context.Context, db *mongo.Database, userID primitive.ObjectID) (*DashboardTeamMember, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var teamMember DashboardTeamMember
+	err := GetDashboardTeamMemberCollection(db).FindOne(
+		ctx,
+		bson.M{"$and": []bson.M{
+			{"user_id": userID},
+			{"invite_status": constants.DashboardTeamInviteStatusAccepted},
+		}},
+	).Decode(&teamMember)
+	if err != nil {
+		return nil, err
+	}
+	return &teamMember, nil
 }
 
-func GetTaskCollection(db *mongo.Database) *mongo.Collection {
-	return db.Collection("tasks")
+// GetDashboardTeamIDsForUser returns the teams the user owns or has joined
+func GetDashboardTeamIDsForUser(ctx context.Context, db *mongo.Database, userID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	var ownedTeams []DashboardTeam
+	err := FindWithCollection(ctx, GetDashboardTeamCollection(db), userID, &[]bson.M{}, &ownedTeams, nil)
+	if err != nil {
+		return nil, err
+	}
+	teamIDs := []primitive.ObjectID{}
+	for _, team := range ownedTeams {
+		teamIDs = append(teamIDs, team.ID)
+	}
+	membership, err := GetDashboardTeamMembership(ctx, db, userID)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+	if err == nil {
+		teamIDs = append(teamIDs, membership.TeamID)
+	}
+	return teamIDs, nil
+}
+
+func AreDashboardTeammates(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, otherUserID primitive.ObjectID) (bool, error) {
+	teamIDs, err := GetDashboardTeamIDsForUser(ctx, db, userID)
+	if err != nil {
+		return false, err
+	}
+	otherTeamIDs, err := GetDashboardTeamIDsForUser(ctx, db, otherUserID)
+	if err != nil {
+		return false, err
+	}
+	for _, teamID := range teamIDs {
+		for _, otherTeamID := range otherTeamIDs {
+			if teamID == otherTeamID {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func GetDashboardTeamMember(ctx context.Context, db *mongo.Database, teamMemberID primitive.ObjectID) (*DashboardTeamMember, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var teamMember DashboardTeamMember
+	err := GetDashboardTeamMemberCollection(db).FindOne(ctx, bson.M{"_id": teamMemberID}).Decode(&teamMember)
+	if err != nil {
+		return nil, err
+	}
+	return &teamMember, nil
+}
+
+func GetPendingDashboardTeamInvites(ctx context.Context, db *mongo.Database, email string) (*[]DashboardTeamMember, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	cursor, err := GetDashboardTeamMemberCollection(db).Find(
+		ctx,
+		bson.M{"$and": []bson.M{
+			{"email": email},
+			{"invite_status": constants.DashboardTeamInviteStatusPending},
+		}},
+	)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch dashboard team invites")
+		return nil, err
+	}
+	var invites []DashboardTeamMember
+	err = cursor.All(ctx, &invites)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to load dashboard team invites")
+		return nil, err
+	}
+	return &invites, nil
+}
+
+func GetDashboardDataPoints(ctx context.Context, db *mongo.Database, teamID primitive.ObjectID, now time.Time, lookbackDays int) (*[]DashboardDataPoint, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	dataPointCollection := GetDashboardDataPointCollection(db)
+	cursor, err := dataPointCollection.Find(
+		ctx,
+		bson.M{"$and": []bson.M{
+			// this timestamp is approximate for now, will refine as needed
+			{"date": bson.M{"$gte": now.Add(-time.Hour * 24 * time.Duration(lookbackDays))}},
+			{"$or": []bson.M{
+				{"team_id": teamID},
+				{"team_id": bson.M{"$exists": false}},
+			}}}},
+	)
+	if err != nil {
+		logger := logging.GetSentryLogger()
+		logger.Error().Err(err).Msg("failed to fetch data points")
+		return nil, err
+	}
+
+	var dataPoints []DashboardDataPoint
+	err = cursor.All(ctx, &dataPoints)
+	if err != nil {
+		logger := logging.GetSentryLogger()
+		logger.Error().Err(err).Msg("failed to load data points")
+		return nil, err
+	}
+	return &dataPoints, nil
+}
+
+func GetAvailabilityLinkBySecret(ctx context.Context, db *mongo.Database, secret string) (*AvailabilityLink, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var link AvailabilityLink
+	err := GetAvailabilityLinkCollection(db).FindOne(
+		ctx,
+		bson.M{"secret": secret},
+	).Decode(&link)
+	if err != nil {
+		if err != mongo.ErrNoDocuments {
+			logging.GetSentryLogger().Error().Err(err).Msg("failed to load availability link")
+		}
+		return nil, err
+	}
+	return &link, nil
+}
+
+// GetOrCreateAvailabilityLink returns the user's availability link, creating one with the secret if they don't have one
+func GetOrCreateAvailabilityLink(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, secret string, createdAt time.Time) (*AvailabilityLink, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var link AvailabilityLink
+	err := GetAvailabilityLinkCollection(db).FindOneAndUpdate(
+		ctx,
+		bson.M{"user_id": userID},
+		bson.M{"$setOnInsert": bson.M{"secret": secret, "created_at": primitive.NewDateTimeFromTime(createdAt)}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&link)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to create availability link")
+		return nil, err
+	}
+	return &link, nil
+}
+
+// JoinWaitlist adds the email to the waitlist without access, returning false if it was already there
+func JoinWaitlist(ctx context.Context, db *mongo.Database, email string, createdAt time.Time) (bool, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	result, err := GetWaitlistCollection(db).UpdateOne(
+		ctx,
+		bson.M{"email": email},
+		bson.M{"$setOnInsert": bson.M{"has_access": false, "created_at": primitive.NewDateTimeFromTime(createdAt)}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to join waitlist")
+		return false, err
+	}
+	return result.UpsertedCount > 0, nil
+}
+
+func GetWaitlistEntry(ctx context.Context, db *mongo.Database, email string) (*WaitlistEntry, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var entry WaitlistEntry
+	err := GetWaitlistCollection(db).FindOne(ctx, bson.M{"email": email}).Decode(&entry)
+	if err != nil {
+		if err != mongo.ErrNoDocuments {
+			logging.GetSentryLogger().Error().Err(err).Msg("failed to load waitlist entry")
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// ApproveWaitlistEntry gives the email access, adding it to the waitlist first if it isn't there yet.
+// Either the admin approving it or the invite code which was redeemed for it is recorded
+func ApproveWaitlistEntry(ctx context.Context, db *mongo.Database, email string, approvedBy primitive.ObjectID, inviteCode *InviteCode, approvedAt time.Time) (*WaitlistEntry, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	approval := bson.M{"has_access": true, "approved_at": primitive.NewDateTimeFromTime(approvedAt)}
+	if approvedBy != primitive.NilObjectID {
+		approval["approved_by"] = approvedBy
+	}
+	if inviteCode != nil {
+		approval["invite_code"] = inviteCode.Code
+		if inviteCode.IsReferral {
+			approval["referred_by"] = inviteCode.UserID
+		}
+	}
+	var entry WaitlistEntry
+	err := GetWaitlistCollection(db).FindOneAndUpdate(
+		ctx,
+		bson.M{"email": email},
+		bson.M{"$set": approval, "$setOnInsert": bson.M{"created_at": primitive.NewDateTimeFromTime(approvedAt)}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&entry)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to approve waitlist entry")
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// RedeemInviteCode uses up one of the code's uses, returning mongo.ErrNoDocuments if the code doesn't
+// exist or has none left
+func RedeemInviteCode(ctx context.Context, db *mongo.Database, code string) (*InviteCode, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var inviteCode InviteCode
+	err := GetInviteCodeCollection(db).FindOneAndUpdate(
+		ctx,
+		bson.M{"code": code, "$expr": bson.M{"$lt": bson.A{"$uses", "$max_uses"}}},
+		bson.M{"$inc": bson.M{"uses": 1}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&inviteCode)
+	if err != nil {
+		if err != mongo.ErrNoDocuments {
+			logging.GetSentryLogger().Error().Err(err).Msg("failed to redeem invite code")
+		}
+		return nil, err
+	}
+	return &inviteCode, nil
+}
+
+// GetOrCreateReferralInviteCode returns the user's referral code, creating it with the given code and
+// number of uses the first time
+func GetOrCreateReferralInviteCode(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, code string, maxUses int, createdAt time.Time) (*InviteCode, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var inviteCode InviteCode
+	err := GetInviteCodeCollection(db).FindOneAndUpdate(
+		ctx,
+		bson.M{"user_id": userID, "is_referral": true},
+		bson.M{"$setOnInsert": bson.M{"code": code, "max_uses": maxUses, "uses": 0, "created_at": primitive.NewDateTimeFromTime(createdAt)}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&inviteCode)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to create referral invite code")
+		return nil, err
+	}
+	return &inviteCode, nil
+}
+
+func CreateMagicLink(ctx context.Context, db *mongo.Database, magicLink *MagicLink) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	_, err := GetMagicLinkCollection(db).InsertOne(ctx, magicLink)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to create magic link")
+	}
+	return err
+}
+
+// CountMagicLinksSince counts the login links sent to the email since the time
+func CountMagicLinksSince(ctx context.Context, db *mongo.Database, email string, since time.Time) (int64, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	count, err := GetMagicLinkCollection(db).CountDocuments(ctx, bson.M{"email": email, "created_at": bson.M{"$gte": primitive.NewDateTimeFromTime(since)}})
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to count magic links")
+	}
+	return count, err
+}
+
+// UseMagicLink marks the link as used, returning mongo.ErrNoDocuments if it doesn't exist, has expired
+// or was already used
+func UseMagicLink(ctx context.Context, db *mongo.Database, nonce string, now time.Time) (*MagicLink, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var magicLink MagicLink
+	err := GetMagicLinkCollection(db).FindOneAndUpdate(
+		ctx,
+		bson.M{
+			"nonce":      nonce,
+			"used_at":    bson.M{"$exists": false},
+			"expires_at": bson.M{"$gt": primitive.NewDateTimeFromTime(now)},
+		},
+		bson.M{"$set": bson.M{"used_at": primitive.NewDateTimeFromTime(now)}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&magicLink)
+	if err != nil {
+		if err != mongo.ErrNoDocuments {
+			logging.GetSentryLogger().Error().Err(err).Msg("failed to use magic link")
+		}
+		return nil, err
+	}
+	return &magicLink, nil
+}
+
+func GetTaskShareByToken(ctx context.Context, db *mongo.Database, token string) (*TaskShare, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var share TaskShare
+	err := GetTaskShareCollection(db).FindOne(
+		ctx,
+		bson.M{"token": token},
+	).Decode(&share)
+	if err != nil {
+		if err != mongo.ErrNoDocuments {
+			logging.GetSentryLogger().Error().Err(err).Msg("failed to load task share")
+		}
+		return nil, err
+	}
+	return &share, nil
+}
+
+// RecordTaskShareAccess counts an access through the share
+func RecordTaskShareAccess(ctx context.Context, db *mongo.Database, shareID primitive.ObjectID, accessedAt time.Time) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	_, err := GetTaskShareCollection(db).UpdateOne(
+		ctx,
+		bson.M{"_id": shareID},
+		bson.M{
+			"$inc": bson.M{"access_count": 1},
+			"$set": bson.M{"last_accessed_at": primitive.NewDateTimeFromTime(accessedAt)},
+		},
+	)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to record task share access")
+	}
+	return err
+}
+
+func CreateSharePasswordFailure(ctx context.Context, db *mongo.Database, failure *SharePasswordFailure) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	_, err := GetSharePasswordFailureCollection(db).InsertOne(ctx, failure)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to create share password failure")
+	}
+	return err
+}
+
+// GetSharePasswordFailuresSince returns the wrong passwords for the share from the address since
+// the time, latest first
+func GetSharePasswordFailuresSince(ctx context.Context, db *mongo.Database, shareID primitive.ObjectID, ipAddress string, since time.Time) ([]SharePasswordFailure, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	cursor, err := GetSharePasswordFailureCollection(db).Find(
+		ctx,
+		bson.M{
+			"share_id":   shareID,
+			"ip_address": ipAddress,
+			"created_at": bson.M{"$gte": primitive.NewDateTimeFromTime(since)},
+		},
+		options.Find().SetSort(bson.M{"created_at": -1}),
+	)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch share password failures")
+		return nil, err
+	}
+	failures := []SharePasswordFailure{}
+	err = cursor.All(ctx, &failures)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to load share password failures")
+		return nil, err
+	}
+	return failures, nil
+}
+
+func GetCalendarFeedBySecret(ctx context.Context, db *mongo.Database, secret string) (*CalendarFeed, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var feed CalendarFeed
+	err := GetCalendarFeedCollection(db).FindOne(
+		ctx,
+		bson.M{"secret": secret},
+	).Decode(&feed)
+	if err != nil {
+		if err != mongo.ErrNoDocuments {
+			logging.GetSentryLogger().Error().Err(err).Msg("failed to load calendar feed")
+		}
+		return nil, err
+	}
+	return &feed, nil
+}
+
+func GetConditionalResponse(ctx context.Context, db *mongo.Database, cacheKey string) (*ConditionalResponse, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var response ConditionalResponse
+	err := GetConditionalResponseCollection(db).FindOne(
+		ctx,
+		bson.M{"cache_key": cacheKey},
+	).Decode(&response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func UpsertConditionalResponse(ctx context.Context, db *mongo.Database, response *ConditionalResponse) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	_, err := GetConditionalResponseCollection(db).UpdateOne(
+		ctx,
+		bson.M{"cache_key": response.CacheKey},
+		bson.M{"$set": response},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// GetMeetingPrepRules returns the user's saved rules, or the defaults if they haven't saved any
+func GetMeetingPrepRules(ctx context.Context, db *mongo.Database, userID primitive.ObjectID) (*MeetingPrepRules, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var rules MeetingPrepRules
+	err := GetMeetingPrepRulesCollection(db).FindOne(ctx, bson.M{"user_id": userID}).Decode(&rules)
+	if err == mongo.ErrNoDocuments {
+		return &MeetingPrepRules{
+			UserID:          userID,
+			IsEnabled:       true,
+			LeadTimeMinutes: constants.MEETING_PREP_DEFAULT_LEAD_TIME_MINUTES,
+		}, nil
+	}
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to load meeting prep rules")
+		return nil, err
+	}
+	return &rules, nil
+}
+
+// GetUsers returns the users with the given IDs, skipping any that don't exist
+func GetUsers(ctx context.Context, db *mongo.Database, userIDs []primitive.ObjectID) ([]User, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	users := []User{}
+	cursor, err := GetUserCollection(db).Find(ctx, bson.M{"_id": bson.M{"$in": userIDs}})
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch users")
+		return nil, err
+	}
+	err = cursor.All(ctx, &users)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to load users")
+		return nil, err
+	}
+	return users, nil
+}
+
+func GetAvatar(ctx context.Context, db *mongo.Database, userID primitive.ObjectID) (*Avatar, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var avatar Avatar
+	err := GetAvatarCollection(db).FindOne(ctx, bson.M{"user_id": userID}).Decode(&avatar)
+	if err != nil {
+		return nil, err
+	}
+	return &avatar, nil
+}
+
+// UpsertAvatar replaces the user's avatar and records when it changed, so avatar URLs can be cache busted
+func UpsertAvatar(ctx context.Context, db *mongo.Database, avatar *Avatar) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	avatar.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+	_, err := GetAvatarCollection(db).UpdateOne(
+		ctx,
+		bson.M{"user_id": avatar.UserID},
+		bson.M{"$set": bson.M{
+			"content_type": avatar.ContentType,
+			"data":         avatar.Data,
+			"updated_at":   avatar.UpdatedAt,
+		}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to save avatar")
+		return err
+	}
+	_, err = GetUserCollection(db).UpdateOne(ctx, bson.M{"_id": avatar.UserID}, bson.M{"$set": bson.M{"avatar_updated_at": avatar.UpdatedAt}})
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to update user avatar time")
+	}
+	return err
+}
+
+func DeleteAvatar(ctx context.Context, db *mongo.Database, userID primitive.ObjectID) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	_, err := GetAvatarCollection(db).DeleteOne(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to delete avatar")
+		return err
+	}
+	_, err = GetUserCollection(db).UpdateOne(ctx, bson.M{"_id": userID}, bson.M{"$unset": bson.M{"avatar_updated_at": ""}})
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to update user avatar time")
+	}
+	return err
+}
+
+func UpsertMeetingPrepRules(ctx context.Context, db *mongo.Database, rules *MeetingPrepRules) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	rules.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+	_, err := GetMeetingPrepRulesCollection(db).UpdateOne(
+		ctx,
+		bson.M{"user_id": rules.UserID},
+		bson.M{"$set": bson.M{
+			"is_enabled":        rules.IsEnabled,
+			"lead_time_minutes": rules.LeadTimeMinutes,
+			"minimum_attendees": rules.MinimumAttendees,
+			"calendars":         rules.Calendars,
+			"updated_at":        rules.UpdatedAt,
+		}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to save meeting prep rules")
+	}
+	return err
+}
+
+// GetUserIDsWithView returns every user who has added a view of the given type to their overview
+func GetUserIDsWithView(ctx context.Context, db *mongo.Database, viewType string) ([]primitive.ObjectID, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	values, err := GetViewCollection(db).Distinct(ctx, "user_id", bson.M{"type": viewType})
+	if err != nil {
+		return nil, err
+	}
+	userIDs := []primitive.ObjectID{}
+	for _, value := range values {
+		if userID, ok := value.(primitive.ObjectID); ok {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	return userIDs, nil
+}
+
+// GetEventNote returns the user's note linked to the event, skipping notes in the trash
+func GetEventNote(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, eventID primitive.ObjectID) (*Note, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var note Note
+	err := GetNoteCollection(db).FindOne(ctx, bson.M{"$and": []bson.M{
+		{"user_id": userID},
+		{"linked_event_id": eventID},
+		{"is_deleted": bson.M{"$ne": true}},
+	}}).Decode(&note)
+	if err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// GetEventsStartedBetween returns every user's events starting within [start, end)
+func GetEventsStartedBetween(ctx context.Context, db *mongo.Database, start time.Time, end time.Time) (*[]CalendarEvent, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	cursor, err := GetCalendarEventCollection(db).Find(ctx, bson.M{"$and": []bson.M{
+		{"datetime_start": bson.M{"$gte": start}},
+		{"datetime_start": bson.M{"$lt": end}},
+	}})
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch started events")
+		return nil, err
+	}
+	var events []CalendarEvent
+	err = cursor.All(ctx, &events)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch started events")
+		return nil, err
+	}
+	return &events, nil
+}
+
+func GetPendingActionItemSuggestions(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, noteID primitive.ObjectID) (*[]ActionItemSuggestion, error) {
+	var suggestions []ActionItemSuggestion
+	err := FindWithCollection(ctx, GetActionItemSuggestionCollection(db), userID, &[]bson.M{
+		{"note_id": noteID},
+		{"status": constants.ActionItemStatusPending},
+	}, &suggestions, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch action item suggestions")
+		return nil, err
+	}
+	return &suggestions, nil
+}
+
+func GetActionItemSuggestion(ctx context.Context, db *mongo.Database, suggestionID primitive.ObjectID, userID primitive.ObjectID) (*ActionItemSuggestion, error) {
+	var suggestion ActionItemSuggestion
+	err := FindOneWithCollection(ctx, GetActionItemSuggestionCollection(db), userID, suggestionID).Decode(&suggestion)
+	if err != nil {
+		return nil, err
+	}
+	return &suggestion, nil
+}
+
+// UpdatePendingActionItemSuggestion resolves a pending suggestion. It returns mongo.ErrNoDocuments
+// if the suggestion was already resolved, so that it can't be accepted twice
+func UpdatePendingActionItemSuggestion(ctx context.Context, db *mongo.Database, suggestionID primitive.ObjectID, userID primitive.ObjectID, fields bson.M) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	result, err := GetActionItemSuggestionCollection(db).UpdateOne(
+		ctx,
+		bson.M{"$and": []bson.M{
+			{"_id": suggestionID},
+			{"user_id": userID},
+			{"status": constants.ActionItemStatusPending},
+		}},
+		bson.M{"$set": fields},
+	)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to update action item suggestion")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// IncrementLLMUsage adds a completion's tokens to the user's usage for the day, which is in YYYY-MM-DD format
+func IncrementLLMUsage(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, provider string, model string, date string, promptTokens int, completionTokens int) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	_, err := GetLLMUsageCollection(db).UpdateOne(
+		ctx,
+		bson.M{"$and": []bson.M{
+			{"user_id": userID},
+			{"provider": provider},
+			{"model": model},
+			{"date": date},
+		}},
+		bson.M{"$inc": bson.M{
+			"request_count":     1,
+			"prompt_tokens":     promptTokens,
+			"completion_tokens": completionTokens,
+		}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to update llm usage")
+		return err
+	}
+	return nil
+}
+
+// CreateInternalToken starts a new session for the user
+func CreateInternalToken(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, userAgent string, ipAddress string) (*InternalAPIToken, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	now := time.Now()
+	internalToken := InternalAPIToken{
+		Token:      uuid.New().String(),
+		UserID:     userID,
+		UserAgent:  userAgent,
+		IPAddress:  ipAddress,
+		CreatedAt:  primitive.NewDateTimeFromTime(now),
+		LastUsedAt: primitive.NewDateTimeFromTime(now),
+		ExpiresAt:  primitive.NewDateTimeFromTime(now.Add(time.Duration(constants.SESSION_DURATION) * time.Second)),
+	}
+	result, err := GetInternalTokenCollection(db).InsertOne(ctx, &internalToken)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to create internal token")
+		return nil, err
+	}
+	internalToken.ID = result.InsertedID.(primitive.ObjectID)
+	return &internalToken, nil
+}
+
+// CreateImpersonationToken starts a read-only session as the user for the impersonator, which expires
+// after a fixed time rather than when it goes unused
+func CreateImpersonationToken(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, impersonatorID primitive.ObjectID) (*InternalAPIToken, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	now := time.Now()
+	internalToken := InternalAPIToken{
+		Token:          uuid.New().String(),
+		UserID:         userID,
+		CreatedAt:      primitive.NewDateTimeFromTime(now),
+		LastUsedAt:     primitive.NewDateTimeFromTime(now),
+		ExpiresAt:      primitive.NewDateTimeFromTime(now.Add(time.Duration(constants.IMPERSONATION_SESSION_DURATION) * time.Second)),
+		ImpersonatorID: impersonatorID,
+	}
+	result, err := GetInternalTokenCollection(db).InsertOne(ctx, &internalToken)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to create impersonation token")
+		return nil, err
+	}
+	internalToken.ID = result.InsertedID.(primitive.ObjectID)
+	return &internalToken, nil
+}
+
+// GetInternalToken returns the session for the token, unless it has expired
+func GetInternalToken(ctx context.Context, db *mongo.Database, token string) (*InternalAPIToken, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var internalToken InternalAPIToken
+	err := GetInternalTokenCollection(db).FindOne(
+		ctx,
+		bson.M{"$and": []bson.M{
+			{"token": token},
+			getUnexpiredTokenFilter(),
+		}},
+	).Decode(&internalToken)
+	if err != nil {
+		return nil, err
+	}
+	return &internalToken, nil
+}
+
+// GetInternalTokens returns the user's unexpired sessions, most recently used first
+func GetInternalTokens(ctx context.Context, db *mongo.Database, userID primitive.ObjectID) (*[]InternalAPIToken, error) {
+	var internalTokens []InternalAPIToken
+	err := FindWithCollection(
+		ctx,
+		GetInternalTokenCollection(db),
+		userID,
+		&[]bson.M{getUnexpiredTokenFilter()},
+		&internalTokens,
+		options.Find().SetSort(bson.D{{Key: "last_used_at", Value: -1}, {Key: "_id", Value: -1}}),
+	)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch internal tokens")
+		return nil, err
+	}
+	return &internalTokens, nil
+}
+
+// RenewInternalToken records that the session was used and pushes back its expiry
+func RenewInternalToken(ctx context.Context, db *mongo.Database, tokenID primitive.ObjectID, userAgent string, ipAddress string, now time.Time) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	_, err := GetInternalTokenCollection(db).UpdateOne(
+		ctx,
+		bson.M{"_id": tokenID},
+		bson.M{"$set": bson.M{
+			"user_agent":   userAgent,
+			"ip_address":   ipAddress,
+			"last_used_at": primitive.NewDateTimeFromTime(now),
+			"expires_at":   primitive.NewDateTimeFromTime(now.Add(time.Duration(constants.SESSION_DURATION) * time.Second)),
+		}},
+	)
+	return err
+}
+
+func getUnexpiredTokenFilter() bson.M {
+	return bson.M{"$or": []bson.M{
+		{"expires_at": bson.M{"$exists": false}},
+		{"expires_at": bson.M{"$gt": primitive.NewDateTimeFromTime(time.Now())}},
+	}}
+}
+
+func GetUserByEmail(ctx context.Context, db *mongo.Database, email string) (*User, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var user User
+	err := GetUserCollection(db).FindOne(ctx, bson.M{"email": email}).Decode(&user)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetDashboardTeammatesByEmail maps the lowercased email of every owner and accepted member of the
+// user's teams to their user ID
+func GetDashboardTeammatesByEmail(ctx context.Context, db *mongo.Database, userID primitive.ObjectID) (map[string]primitive.ObjectID, error) {
+	teamIDs, err := GetDashboardTeamIDsForUser(ctx, db, userID)
+	if err != nil {
+		return nil, err
+	}
+	teammates := make(map[string]primitive.ObjectID)
+	for _, teamID := range teamIDs {
+		team, err := GetDashboardTeam(ctx, db, teamID)
+		if err != nil {
+			return nil, err
+		}
+		owner, err := GetUser(ctx, db, team.UserID)
+		if err != nil {
+			return nil, err
+		}
+		teammates[strings.ToLower(owner.Email)] = owner.ID
+		teamMembers, err := GetDashboardTeamMembers(ctx, db, teamID)
+		if err != nil {
+			return nil, err
+		}
+		for _, teamMember := range *teamMembers {
+			if teamMember.InviteStatus != constants.DashboardTeamInviteStatusAccepted || teamMember.UserID == primitive.NilObjectID {
+				continue
+			}
+			teammates[strings.ToLower(teamMember.Email)] = teamMember.UserID
+		}
+	}
+	return teammates, nil
+}
+
+func InsertTaskActivities(ctx context.Context, db *mongo.Database, activities []TaskActivity) error {
+	if len(activities) == 0 {
+		return nil
+	}
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	documents := []interface{}{}
+	for _, activity := range activities {
+		activity.CreatedAt = primitive.NewDateTimeFromTime(time.Now())
+		documents = append(documents, activity)
+	}
+	_, err := GetTaskActivityCollection(db).InsertMany(ctx, documents)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to insert task activity")
+	}
+	return err
+}
+
+func GetOrganizationByDomain(ctx context.Context, db *mongo.Database, domain string) (*Organization, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var organization Organization
+	err := GetOrganizationCollection(db).FindOne(ctx, bson.M{"domain": domain}).Decode(&organization)
+	if err != nil {
+		if err != mongo.ErrNoDocuments {
+			logging.GetSentryLogger().Error().Err(err).Msgf("failed to get organization: %s", domain)
+		}
+		return nil, err
+	}
+	return &organization, nil
+}
+
+func InsertShareView(ctx context.Context, db *mongo.Database, shareView ShareView) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	shareView.ViewedAt = primitive.NewDateTimeFromTime(time.Now())
+	_, err := GetShareViewCollection(db).InsertOne(ctx, shareView)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to insert share view")
+	}
+	return err
+}
+
+func InsertAuditLog(ctx context.Context, db *mongo.Database, auditLog AuditLog) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	auditLog.CreatedAt = primitive.NewDateTimeFromTime(time.Now())
+	_, err := GetAuditLogCollection(db).InsertOne(ctx, auditLog)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msgf("failed to record %s audit log", auditLog.EventType)
+	}
+	return err
+}
+
+func EnqueueNotifications(ctx context.Context, db *mongo.Database, notifications []Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	documents := []interface{}{}
+	for _, notification := range notifications {
+		notification.IsSent = false
+		notification.CreatedAt = primitive.NewDateTimeFromTime(time.Now())
+		documents = append(documents, notification)
+	}
+	_, err := GetNotificationCollection(db).InsertMany(ctx, documents)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to enqueue notifications")
+	}
+	return err
+}
+
+// ClaimQueuedNotification marks the oldest unsent notification as sent and returns it. Claiming and
+// marking in one update keeps concurrent workers from delivering the same notification twice
+func ClaimQueuedNotification(ctx context.Context, db *mongo.Database) (*Notification, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	var notification Notification
+	err := GetNotificationCollection(db).FindOneAndUpdate(
+		ctx,
+		bson.M{"is_sent": false},
+		bson.M{"$set": bson.M{
+			"is_sent": true,
+			"sent_at": primitive.NewDateTimeFromTime(time.Now()),
+		}},
+		options.FindOneAndUpdate().SetSort(bson.M{"created_at": 1}).SetReturnDocument(options.After),
+	).Decode(&notification)
+	if err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+func GetServerRequestCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("server_requests")
+}
+
+func GetStateTokenCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("state_tokens")
+}
+
+func GetTaskCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("tasks")
 }
 
 func GetNoteCollection(db *mongo.Database) *mongo.Collection {
 	return db.Collection("notes")
 }
 
+func GetActionItemSuggestionCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("action_item_suggestions")
+}
+
+func GetLLMUsageCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("llm_usage")
+}
+
 func GetCalendarAccountCollection(db *mongo.Database) *mongo.Collection {
 	return db.Collection("calendar_accounts")
 }
@@ -1378,6 +3139,10 @@ func GetExternalTokenCollection(db *mongo.Database) *mongo.Collection {
 	return db.Collection("external_api_tokens")
 }
 
+func GetExternalTokenHealthCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("external_token_health")
+}
+
 func GetPullRequestCollection(db *mongo.Database) *mongo.Collection {
 	return db.Collection("pull_requests")
 }
@@ -1394,6 +3159,18 @@ func GetWaitlistCollection(db *mongo.Database) *mongo.Collection {
 	return db.Collection("waitlist")
 }
 
+func GetInviteCodeCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("invite_codes")
+}
+
+func GetSharePasswordFailureCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("share_password_failures")
+}
+
+func GetMagicLinkCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("magic_links")
+}
+
 func GetJiraSitesCollection(db *mongo.Database) *mongo.Collection {
 	return db.Collection("jira_sites")
 }
@@ -1438,6 +3215,58 @@ func GetDashboardTeamMemberCollection(db *mongo.Database) *mongo.Collection {
 	return db.Collection("dashboard_team_members")
 }
 
+func GetAvailabilityLinkCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("availability_links")
+}
+
+func GetTaskShareCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("task_shares")
+}
+
+func GetNoteFolderCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("note_folders")
+}
+
+func GetOrganizationCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("organizations")
+}
+
+func GetShareViewCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("share_views")
+}
+
+func GetCalendarFeedCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("calendar_feeds")
+}
+
+func GetAccountDeletionCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("account_deletions")
+}
+
+func GetConditionalResponseCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("conditional_responses")
+}
+
+func GetTaskActivityCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("task_activity")
+}
+
+func GetAuditLogCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("audit_logs")
+}
+
+func GetMeetingPrepRulesCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("meeting_prep_rules")
+}
+
+func GetNotificationCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("notifications")
+}
+
+func GetAvatarCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("avatars")
+}
+
 func HasUserGrantedMultiCalendarScope(scopes []string) bool {
 	return slices.Contains(scopes, "https://www.googleapis.com/auth/calendar")
 }
@@ -1445,3 +3274,71 @@ func HasUserGrantedMultiCalendarScope(scopes []string) bool {
 func HasUserGrantedPrimaryCalendarScope(scopes []string) bool {
 	return slices.Contains(scopes, "https://www.googleapis.com/auth/calendar.events")
 }
+
+// GetUnhiddenPullRequestFilters leaves out pull requests snoozed past now, and muted ones whose
+// required action hasn't changed since they were muted
+func GetUnhiddenPullRequestFilters(now time.Time) []bson.M {
+	return []bson.M{
+		{"$or": []bson.M{
+			{"snoozed_until": bson.M{"$exists": false}},
+			{"snoozed_until": bson.M{"$lte": primitive.NewDateTimeFromTime(now)}},
+		}},
+		{"$or": []bson.M{
+			{"muted_required_action": bson.M{"$exists": false}},
+			{"$expr": bson.M{"$ne": []string{"$muted_required_action", "$required_action"}}},
+		}},
+	}
+}
+
+// SnoozePullRequest hides the pull request until the given time, or unsnoozes it if the time is nil
+func SnoozePullRequest(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, pullRequestID primitive.ObjectID, snoozedUntil *time.Time) error {
+	update := bson.M{"$unset": bson.M{"snoozed_until": ""}}
+	if snoozedUntil != nil {
+		update = bson.M{"$set": bson.M{"snoozed_until": primitive.NewDateTimeFromTime(*snoozedUntil)}}
+	}
+	return updatePullRequest(ctx, db, userID, pullRequestID, update)
+}
+
+// MutePullRequest hides the pull request until its required action changes from the one given, or
+// unmutes it if the required action is empty
+func MutePullRequest(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, pullRequestID primitive.ObjectID, requiredAction string) error {
+	update := bson.M{"$unset": bson.M{"muted_required_action": ""}}
+	if requiredAction != "" {
+		update = bson.M{"$set": bson.M{"muted_required_action": requiredAction}}
+	}
+	return updatePullRequest(ctx, db, userID, pullRequestID, update)
+}
+
+func updatePullRequest(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, pullRequestID primitive.ObjectID, update bson.M) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	_, err := GetPullRequestCollection(db).UpdateOne(
+		ctx,
+		bson.M{"$and": []bson.M{{"_id": pullRequestID}, {"user_id": userID}}},
+		update,
+	)
+	if err != nil {
+		logger := logging.GetSentryLogger()
+		logger.Error().Err(err).Msg("failed to update pull request")
+	}
+	return err
+}
+
+// GetPullRequestFieldValues returns the distinct values of a string field across the user's pull
+// requests, sorted
+func GetPullRequestFieldValues(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, field string) ([]string, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	values, err := GetPullRequestCollection(db).Distinct(ctx, field, bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	fieldValues := []string{}
+	for _, value := range values {
+		if fieldValue, ok := value.(string); ok && fieldValue != "" {
+			fieldValues = append(fieldValues, fieldValue)
+		}
+	}
+	sort.Strings(fieldValues)
+	return fieldValues, nil
+}
@@ -12,6 +12,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func TestGetTasks(t *testing.T) {
@@ -22,6 +23,7 @@ func TestGetTasks(t *testing.T) {
 	notUserID := primitive.NewObjectID()
 	notCompleted := false
 	task1, err := GetOrCreateTask(
+		context.Background(),
 		db,
 		userID,
 		"123abc",
@@ -36,6 +38,7 @@ func TestGetTasks(t *testing.T) {
 	assert.NoError(t, err)
 	completed := true
 	task2, err := GetOrCreateTask(
+		context.Background(),
 		db,
 		userID,
 		"123abcde",
@@ -49,6 +52,7 @@ func TestGetTasks(t *testing.T) {
 	)
 	assert.NoError(t, err)
 	task3, err := GetOrCreateTask(
+		context.Background(),
 		db,
 		userID,
 		"123abcdef",
@@ -63,6 +67,7 @@ func TestGetTasks(t *testing.T) {
 	)
 	assert.NoError(t, err)
 	_, err = GetOrCreateTask(
+		context.Background(),
 		db,
 		notUserID,
 		"123abe",
@@ -77,20 +82,20 @@ func TestGetTasks(t *testing.T) {
 	assert.NoError(t, err)
 
 	t.Run("GetActiveTasks", func(t *testing.T) {
-		tasks, err := GetActiveTasks(db, userID)
+		tasks, err := GetActiveTasks(context.Background(), db, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(*tasks))
 		assert.Equal(t, task1.ID, (*tasks)[0].ID)
 	})
 	t.Run("GetCompletedTasks", func(t *testing.T) {
-		tasks, err := GetCompletedTasks(db, userID)
+		tasks, err := GetCompletedTasks(context.Background(), db, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 2, len(*tasks))
 		assert.Equal(t, task2.ID, (*tasks)[0].ID)
 		assert.Equal(t, task3.ID, (*tasks)[1].ID)
 	})
 	t.Run("GetTasks", func(t *testing.T) {
-		tasks, err := GetTasks(db, userID, nil, nil)
+		tasks, err := GetTasks(context.Background(), db, userID, nil, nil)
 		assert.NoError(t, err)
 		assert.Equal(t, 3, len(*tasks))
 		assert.Equal(t, task1.ID, (*tasks)[0].ID)
@@ -108,6 +113,7 @@ func TestGetDeletedTasks(t *testing.T) {
 	notDeleted := false
 	deleted := true
 	task2, err := GetOrCreateTask(
+		context.Background(),
 		db,
 		userID,
 		"123abcde",
@@ -121,6 +127,7 @@ func TestGetDeletedTasks(t *testing.T) {
 	)
 	assert.NoError(t, err)
 	_, err = GetOrCreateTask(
+		context.Background(),
 		db,
 		notUserID,
 		"123abe",
@@ -135,7 +142,7 @@ func TestGetDeletedTasks(t *testing.T) {
 	assert.NoError(t, err)
 
 	t.Run("Success", func(t *testing.T) {
-		tasks, err := GetDeletedTasks(db, userID)
+		tasks, err := GetDeletedTasks(context.Background(), db, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(*tasks))
 		assert.Equal(t, task2.ID, (*tasks)[0].ID)
@@ -143,6 +150,62 @@ func TestGetDeletedTasks(t *testing.T) {
 	})
 }
 
+func TestGetDeletedNotes(t *testing.T) {
+	db, dbCleanup, err := GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	userID := primitive.NewObjectID()
+	notDeleted := false
+	deleted := true
+	olderDeletedAt := primitive.NewDateTimeFromTime(time.Now().Add(-time.Hour))
+	newerDeletedAt := primitive.NewDateTimeFromTime(time.Now())
+	result, err := GetNoteCollection(db).InsertMany(context.Background(), []interface{}{
+		Note{UserID: userID, IsDeleted: &deleted, DeletedAt: olderDeletedAt},
+		Note{UserID: userID, IsDeleted: &deleted, DeletedAt: newerDeletedAt},
+		Note{UserID: userID, IsDeleted: &notDeleted},
+		Note{UserID: primitive.NewObjectID(), IsDeleted: &deleted},
+	})
+	assert.NoError(t, err)
+
+	notes, err := GetDeletedNotes(context.Background(), db, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(*notes))
+	assert.Equal(t, result.InsertedIDs[1], (*notes)[0].ID)
+	assert.Equal(t, result.InsertedIDs[0], (*notes)[1].ID)
+}
+
+func TestPurgeDeletedNotes(t *testing.T) {
+	db, dbCleanup, err := GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	userID := primitive.NewObjectID()
+	notDeleted := false
+	deleted := true
+	cutoff := time.Now().AddDate(0, 0, -30)
+	beforeCutoff := primitive.NewDateTimeFromTime(cutoff.Add(-time.Hour))
+	afterCutoff := primitive.NewDateTimeFromTime(cutoff.Add(time.Hour))
+	result, err := GetNoteCollection(db).InsertMany(context.Background(), []interface{}{
+		Note{UserID: userID, IsDeleted: &deleted, DeletedAt: beforeCutoff},
+		Note{UserID: userID, IsDeleted: &deleted, DeletedAt: afterCutoff},
+		// deleted before deleted_at was tracked
+		Note{UserID: userID, IsDeleted: &deleted, UpdatedAt: beforeCutoff},
+		Note{UserID: userID, IsDeleted: &notDeleted, UpdatedAt: beforeCutoff},
+	})
+	assert.NoError(t, err)
+
+	purgedCount, err := PurgeDeletedNotes(context.Background(), db, cutoff)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), purgedCount)
+
+	var notes []Note
+	err = FindWithCollection(context.Background(), GetNoteCollection(db), userID, &[]bson.M{}, &notes, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(notes))
+	for _, note := range notes {
+		assert.Contains(t, []interface{}{result.InsertedIDs[1], result.InsertedIDs[3]}, note.ID)
+	}
+}
+
 func TestGetMeetingPreparationTasks(t *testing.T) {
 	db, dbCleanup, err := GetDBConnection()
 	assert.NoError(t, err)
@@ -151,6 +214,7 @@ func TestGetMeetingPreparationTasks(t *testing.T) {
 	notUserID := primitive.NewObjectID()
 	notCompleted := false
 	validMeetingPrepTask, err := GetOrCreateTask(
+		context.Background(),
 		db,
 		userID,
 		"123abc",
@@ -166,6 +230,7 @@ func TestGetMeetingPreparationTasks(t *testing.T) {
 	assert.NoError(t, err)
 	// Not meeting preparation task
 	_, err = GetOrCreateTask(
+		context.Background(),
 		db,
 		userID,
 		"123abcde",
@@ -182,6 +247,7 @@ func TestGetMeetingPreparationTasks(t *testing.T) {
 	// Completed meeting preparation task
 	completed := true
 	_, err = GetOrCreateTask(
+		context.Background(),
 		db,
 		userID,
 		"123abcdef",
@@ -197,6 +263,7 @@ func TestGetMeetingPreparationTasks(t *testing.T) {
 	assert.NoError(t, err)
 	// Wrong user ID
 	_, err = GetOrCreateTask(
+		context.Background(),
 		db,
 		notUserID,
 		"123abe",
@@ -211,7 +278,7 @@ func TestGetMeetingPreparationTasks(t *testing.T) {
 	)
 	assert.NoError(t, err)
 	t.Run("Success", func(t *testing.T) {
-		tasks, err := GetMeetingPreparationTasks(db, userID)
+		tasks, err := GetMeetingPreparationTasks(context.Background(), db, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(*tasks))
 		assert.Equal(t, validMeetingPrepTask.ID, (*tasks)[0].ID)
@@ -319,17 +386,17 @@ func TestGetSharedTask(t *testing.T) {
 		publicTaskID := result.InsertedID.(primitive.ObjectID)
 
 		// Test that the task is returned when the user has same domain
-		task, err := GetSharedTask(db, publicTaskID, &userSameDomainID)
+		task, err := GetSharedTask(context.Background(), db, publicTaskID, &userSameDomainID)
 		assert.NoError(t, err)
 		assert.Equal(t, publicTaskID, task.ID)
 
 		// Test that the task is returned when the user has different domain
-		task, err = GetSharedTask(db, publicTaskID, &userDifferentDomainID)
+		task, err = GetSharedTask(context.Background(), db, publicTaskID, &userDifferentDomainID)
 		assert.NoError(t, err)
 		assert.Equal(t, publicTaskID, task.ID)
 
 		// Test that the task is return when owner is same as user
-		task, err = GetSharedTask(db, publicTaskID, &taskOwnerID)
+		task, err = GetSharedTask(context.Background(), db, publicTaskID, &taskOwnerID)
 		assert.NoError(t, err)
 		assert.Equal(t, publicTaskID, task.ID)
 	})
@@ -343,7 +410,7 @@ func TestGetSharedTask(t *testing.T) {
 		domainTaskID := result.InsertedID.(primitive.ObjectID)
 
 		// Test that the task is returned when the user has same domain
-		task, err := GetSharedTask(db, domainTaskID, &userSameDomainID)
+		task, err := GetSharedTask(context.Background(), db, domainTaskID, &userSameDomainID)
 		assert.NoError(t, err)
 		assert.Equal(t, domainTaskID, task.ID)
 	})
@@ -357,7 +424,7 @@ func TestGetSharedTask(t *testing.T) {
 		domainTaskID := result.InsertedID.(primitive.ObjectID)
 
 		// Test that the task is not returned when the user has different domain
-		task, err := GetSharedTask(db, domainTaskID, &userDifferentDomainID)
+		task, err := GetSharedTask(context.Background(), db, domainTaskID, &userDifferentDomainID)
 		assert.Error(t, err)
 		assert.Equal(t, "user domain does not match task owner domain", err.Error())
 		assert.Nil(t, task)
@@ -372,7 +439,7 @@ func TestGetSharedTask(t *testing.T) {
 		domainTaskID := result.InsertedID.(primitive.ObjectID)
 
 		// Test that the task is returned when owner is the user
-		task, err := GetSharedTask(db, domainTaskID, &taskOwnerID)
+		task, err := GetSharedTask(context.Background(), db, domainTaskID, &taskOwnerID)
 		assert.NoError(t, err)
 		assert.Equal(t, domainTaskID, task.ID)
 	})
@@ -387,7 +454,7 @@ func TestGetSharedTask(t *testing.T) {
 		assert.NoError(t, err)
 		taskID := result.InsertedID.(primitive.ObjectID)
 
-		task, err := GetSharedTask(db, taskID, &userSameDomainID)
+		task, err := GetSharedTask(context.Background(), db, taskID, &userSameDomainID)
 		assert.Error(t, err)
 		assert.Equal(t, "task is not shared", err.Error())
 		assert.Nil(t, task)
@@ -403,7 +470,7 @@ func TestGetSharedTask(t *testing.T) {
 		assert.NoError(t, err)
 		taskID := result.InsertedID.(primitive.ObjectID)
 
-		task, err := GetSharedTask(db, taskID, &userSameDomainID)
+		task, err := GetSharedTask(context.Background(), db, taskID, &userSameDomainID)
 		assert.Error(t, err)
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 		assert.Nil(t, task)
@@ -417,7 +484,7 @@ func TestGetSharedTask(t *testing.T) {
 		assert.NoError(t, err)
 		taskID := result.InsertedID.(primitive.ObjectID)
 
-		task, err := GetSharedTask(db, taskID, &userSameDomainID)
+		task, err := GetSharedTask(context.Background(), db, taskID, &userSameDomainID)
 		assert.Error(t, err)
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 		assert.Nil(t, task)
@@ -437,7 +504,7 @@ func TestGetSharedTask(t *testing.T) {
 		assert.NoError(t, err)
 		taskID := result.InsertedID.(primitive.ObjectID)
 
-		task, err := GetSharedTask(db, taskID, &userSameDomainID)
+		task, err := GetSharedTask(context.Background(), db, taskID, &userSameDomainID)
 		assert.Error(t, err)
 		assert.Equal(t, "invalid email address", err.Error())
 		assert.Nil(t, task)
@@ -458,7 +525,7 @@ func TestGetSharedTask(t *testing.T) {
 		})
 		assert.NoError(t, err)
 
-		task, err := GetSharedTask(db, taskID, &userWithInvalidDomainID)
+		task, err := GetSharedTask(context.Background(), db, taskID, &userWithInvalidDomainID)
 		assert.Error(t, err)
 		assert.Equal(t, "invalid email address", err.Error())
 		assert.Nil(t, task)
@@ -472,6 +539,7 @@ func TestGetNotes(t *testing.T) {
 	userID := primitive.NewObjectID()
 	notUserID := primitive.NewObjectID()
 	note1, err := GetOrCreateNote(
+		context.Background(),
 		db,
 		userID,
 		"123abc",
@@ -483,6 +551,7 @@ func TestGetNotes(t *testing.T) {
 	)
 	assert.NoError(t, err)
 	note2, err := GetOrCreateNote(
+		context.Background(),
 		db,
 		userID,
 		"123abcdef",
@@ -495,6 +564,7 @@ func TestGetNotes(t *testing.T) {
 	assert.NoError(t, err)
 	isDeleted := true
 	note3, err := GetOrCreateNote(
+		context.Background(),
 		db,
 		userID,
 		"123abcdefdogecoin",
@@ -507,6 +577,7 @@ func TestGetNotes(t *testing.T) {
 	)
 	assert.NoError(t, err)
 	_, err = GetOrCreateNote(
+		context.Background(),
 		db,
 		userID,
 		"123abc",
@@ -519,22 +590,22 @@ func TestGetNotes(t *testing.T) {
 	assert.NoError(t, err)
 
 	t.Run("GetNotes", func(t *testing.T) {
-		notes, err := GetNotes(db, userID)
+		notes, err := GetNotes(context.Background(), db, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 3, len(*notes))
 		assert.Equal(t, note1.ID, (*notes)[0].ID)
 		assert.Equal(t, note2.ID, (*notes)[1].ID)
 	})
 	t.Run("GetSharedNote", func(t *testing.T) {
-		_, err := GetSharedNote(db, note1.ID)
+		_, err := GetSharedNote(context.Background(), db, note1.ID)
 		assert.NoError(t, err)
 	})
 	t.Run("GetSharedNoteForExpiredNote", func(t *testing.T) {
-		_, err := GetSharedNote(db, note2.ID)
+		_, err := GetSharedNote(context.Background(), db, note2.ID)
 		assert.Error(t, err)
 	})
 	t.Run("GetSharedNoteForDeletedNote", func(t *testing.T) {
-		_, err := GetSharedNote(db, note3.ID)
+		_, err := GetSharedNote(context.Background(), db, note3.ID)
 		assert.Error(t, err)
 	})
 }
@@ -586,17 +657,17 @@ func TestGetSharedNoteWithAuth(t *testing.T) {
 		publicNoteID := result.InsertedID.(primitive.ObjectID)
 
 		// Test that the note is returned when the user has same domain
-		note, err := GetSharedNoteWithAuth(db, publicNoteID, userSameDomainID)
+		note, err := GetSharedNoteWithAuth(context.Background(), db, publicNoteID, userSameDomainID)
 		assert.NoError(t, err)
 		assert.Equal(t, publicNoteID, note.ID)
 
 		// Test that the note is returned when the user has different domain
-		note, err = GetSharedNoteWithAuth(db, publicNoteID, userDifferentDomainID)
+		note, err = GetSharedNoteWithAuth(context.Background(), db, publicNoteID, userDifferentDomainID)
 		assert.NoError(t, err)
 		assert.Equal(t, publicNoteID, note.ID)
 
 		// Test that the note is return when owner is same as user
-		note, err = GetSharedNoteWithAuth(db, publicNoteID, noteOwnerID)
+		note, err = GetSharedNoteWithAuth(context.Background(), db, publicNoteID, noteOwnerID)
 		assert.NoError(t, err)
 		assert.Equal(t, publicNoteID, note.ID)
 	})
@@ -610,7 +681,7 @@ func TestGetSharedNoteWithAuth(t *testing.T) {
 		domainNoteID := result.InsertedID.(primitive.ObjectID)
 
 		// Test that the task is returned when the user has same domain
-		note, err := GetSharedNoteWithAuth(db, domainNoteID, userSameDomainID)
+		note, err := GetSharedNoteWithAuth(context.Background(), db, domainNoteID, userSameDomainID)
 		assert.NoError(t, err)
 		assert.Equal(t, domainNoteID, note.ID)
 	})
@@ -624,7 +695,7 @@ func TestGetSharedNoteWithAuth(t *testing.T) {
 		domainNoteID := result.InsertedID.(primitive.ObjectID)
 
 		// Test that the note is not returned when the user has different domain
-		note, err := GetSharedNoteWithAuth(db, domainNoteID, userDifferentDomainID)
+		note, err := GetSharedNoteWithAuth(context.Background(), db, domainNoteID, userDifferentDomainID)
 		assert.Error(t, err)
 		assert.Equal(t, "user domain does not match note owner domain", err.Error())
 		assert.Nil(t, note)
@@ -639,7 +710,7 @@ func TestGetSharedNoteWithAuth(t *testing.T) {
 		domainNoteID := result.InsertedID.(primitive.ObjectID)
 
 		// Test that the note is returned when owner is the user
-		note, err := GetSharedNoteWithAuth(db, domainNoteID, noteOwnerID)
+		note, err := GetSharedNoteWithAuth(context.Background(), db, domainNoteID, noteOwnerID)
 		assert.NoError(t, err)
 		assert.Equal(t, domainNoteID, note.ID)
 	})
@@ -654,7 +725,7 @@ func TestGetSharedNoteWithAuth(t *testing.T) {
 		noteID := result.InsertedID.(primitive.ObjectID)
 
 		// should return because backwards compatibility
-		note, err := GetSharedNoteWithAuth(db, noteID, userSameDomainID)
+		note, err := GetSharedNoteWithAuth(context.Background(), db, noteID, userSameDomainID)
 		assert.NoError(t, err)
 		assert.Equal(t, noteID, note.ID)
 	})
@@ -669,7 +740,7 @@ func TestGetSharedNoteWithAuth(t *testing.T) {
 		assert.NoError(t, err)
 		noteID := result.InsertedID.(primitive.ObjectID)
 
-		note, err := GetSharedNoteWithAuth(db, noteID, userSameDomainID)
+		note, err := GetSharedNoteWithAuth(context.Background(), db, noteID, userSameDomainID)
 		assert.Error(t, err)
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 		assert.Nil(t, note)
@@ -683,7 +754,7 @@ func TestGetSharedNoteWithAuth(t *testing.T) {
 		assert.NoError(t, err)
 		noteID := result.InsertedID.(primitive.ObjectID)
 
-		note, err := GetSharedTask(db, noteID, &userSameDomainID)
+		note, err := GetSharedTask(context.Background(), db, noteID, &userSameDomainID)
 		assert.Error(t, err)
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 		assert.Nil(t, note)
@@ -703,7 +774,7 @@ func TestGetSharedNoteWithAuth(t *testing.T) {
 		assert.NoError(t, err)
 		noteID := result.InsertedID.(primitive.ObjectID)
 
-		note, err := GetSharedNoteWithAuth(db, noteID, userSameDomainID)
+		note, err := GetSharedNoteWithAuth(context.Background(), db, noteID, userSameDomainID)
 		assert.Error(t, err)
 		assert.Equal(t, "invalid email address", err.Error())
 		assert.Nil(t, note)
@@ -724,7 +795,7 @@ func TestGetSharedNoteWithAuth(t *testing.T) {
 		})
 		assert.NoError(t, err)
 
-		note, err := GetSharedNoteWithAuth(db, noteID, userWithInvalidDomainID)
+		note, err := GetSharedNoteWithAuth(context.Background(), db, noteID, userWithInvalidDomainID)
 		assert.Error(t, err)
 		assert.Equal(t, "invalid email address", err.Error())
 		assert.Nil(t, note)
@@ -746,7 +817,7 @@ func TestGetSharedNoteWithAuth(t *testing.T) {
 		assert.NoError(t, err)
 		noteID := result.InsertedID.(primitive.ObjectID)
 
-		note, err := GetSharedNoteWithAuth(db, noteID, userSameDomainID)
+		note, err := GetSharedNoteWithAuth(context.Background(), db, noteID, userSameDomainID)
 		assert.NoError(t, err)
 		assert.Equal(t, noteID, note.ID)
 	})
@@ -767,7 +838,7 @@ func TestGetSharedNoteWithAuth(t *testing.T) {
 		assert.NoError(t, err)
 		noteID := result.InsertedID.(primitive.ObjectID)
 
-		note, err := GetSharedNoteWithAuth(db, noteID, userDifferentDomainID)
+		note, err := GetSharedNoteWithAuth(context.Background(), db, noteID, userDifferentDomainID)
 		assert.NoError(t, err)
 		assert.Equal(t, noteID, note.ID)
 	})
@@ -788,7 +859,7 @@ func TestGetSharedNoteWithAuth(t *testing.T) {
 		assert.NoError(t, err)
 		noteID := result.InsertedID.(primitive.ObjectID)
 
-		note, err := GetSharedNoteWithAuth(db, noteID, userSameDomainID)
+		note, err := GetSharedNoteWithAuth(context.Background(), db, noteID, userSameDomainID)
 		assert.Error(t, err)
 		assert.Equal(t, "user not found in list of attendees", err.Error())
 		assert.Nil(t, note)
@@ -809,7 +880,7 @@ func TestGetSharedNoteWithAuth(t *testing.T) {
 		assert.NoError(t, err)
 		noteID := result.InsertedID.(primitive.ObjectID)
 
-		note, err := GetSharedNoteWithAuth(db, noteID, noteOwnerID)
+		note, err := GetSharedNoteWithAuth(context.Background(), db, noteID, noteOwnerID)
 		assert.NoError(t, err)
 		assert.Equal(t, noteID, note.ID)
 	})
@@ -823,6 +894,7 @@ func TestGetPullRequests(t *testing.T) {
 	notUserID := primitive.NewObjectID()
 	notCompleted := false
 	pr1, err := GetOrCreatePullRequest(
+		context.Background(),
 		db,
 		userID,
 		"123abc",
@@ -837,6 +909,7 @@ func TestGetPullRequests(t *testing.T) {
 	assert.NoError(t, err)
 	completed := true
 	pr2, err := GetOrCreatePullRequest(
+		context.Background(),
 		db,
 		userID,
 		"123abcde",
@@ -850,6 +923,7 @@ func TestGetPullRequests(t *testing.T) {
 	)
 	assert.NoError(t, err)
 	_, err = GetOrCreatePullRequest(
+		context.Background(),
 		db,
 		notUserID,
 		"123abe",
@@ -864,13 +938,13 @@ func TestGetPullRequests(t *testing.T) {
 	assert.NoError(t, err)
 
 	t.Run("GetActivePRs", func(t *testing.T) {
-		prs, err := GetActivePRs(db, userID)
+		prs, err := GetActivePRs(context.Background(), db, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(*prs))
 		assert.Equal(t, pr1.ID, (*prs)[0].ID)
 	})
 	t.Run("GetPullRequests", func(t *testing.T) {
-		prs, err := GetPullRequests(db, userID, nil)
+		prs, err := GetPullRequests(context.Background(), db, userID, nil)
 		assert.NoError(t, err)
 		assert.Equal(t, 2, len(*prs))
 		assert.Equal(t, pr1.ID, (*prs)[0].ID)
@@ -878,6 +952,85 @@ func TestGetPullRequests(t *testing.T) {
 	})
 }
 
+func TestFindPageWithCollection(t *testing.T) {
+	db, dbCleanup, err := GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	userID := primitive.NewObjectID()
+	taskCollection := GetTaskCollection(db)
+	taskIDs := []primitive.ObjectID{}
+	for i := 0; i < 3; i++ {
+		result, err := taskCollection.InsertOne(context.Background(), Task{UserID: userID})
+		assert.NoError(t, err)
+		taskIDs = append(taskIDs, result.InsertedID.(primitive.ObjectID))
+	}
+	_, err = taskCollection.InsertOne(context.Background(), Task{UserID: primitive.NewObjectID()})
+	assert.NoError(t, err)
+
+	t.Run("FirstPage", func(t *testing.T) {
+		limit := 2
+		tasks, nextCursor, err := FindPageWithCollection[Task](context.Background(), taskCollection, userID, nil, Pagination{Limit: &limit})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(tasks))
+		assert.Equal(t, taskIDs[2], tasks[0].ID)
+		assert.Equal(t, taskIDs[1], tasks[1].ID)
+		assert.Equal(t, EncodePageCursor(taskIDs[1]), nextCursor)
+	})
+	t.Run("LastPage", func(t *testing.T) {
+		limit := 2
+		pageCursor := EncodePageCursor(taskIDs[1])
+		tasks, nextCursor, err := FindPageWithCollection[Task](context.Background(), taskCollection, userID, nil, Pagination{Limit: &limit, Cursor: &pageCursor})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(tasks))
+		assert.Equal(t, taskIDs[0], tasks[0].ID)
+		assert.Empty(t, nextCursor)
+	})
+	t.Run("ExactFit", func(t *testing.T) {
+		limit := 3
+		tasks, nextCursor, err := FindPageWithCollection[Task](context.Background(), taskCollection, userID, nil, Pagination{Limit: &limit})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(tasks))
+		assert.Empty(t, nextCursor)
+	})
+	t.Run("InvalidCursor", func(t *testing.T) {
+		pageCursor := "notacursor"
+		_, _, err := FindPageWithCollection[Task](context.Background(), taskCollection, userID, nil, Pagination{Cursor: &pageCursor})
+		assert.EqualError(t, err, "invalid cursor")
+	})
+}
+
+func TestPageCursor(t *testing.T) {
+	id := primitive.NewObjectID()
+	decoded, err := DecodePageCursor(EncodePageCursor(id))
+	assert.NoError(t, err)
+	assert.Equal(t, id, decoded)
+
+	_, err = DecodePageCursor("abc")
+	assert.EqualError(t, err, "invalid cursor")
+}
+
+func TestGetFieldProjection(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		projection, err := GetFieldProjection(Task{}, []string{"title", "due_date"})
+		assert.NoError(t, err)
+		assert.Equal(t, bson.M{"_id": 1, "title": 1, "due_date": 1}, projection)
+	})
+	t.Run("Subfield", func(t *testing.T) {
+		projection, err := GetFieldProjection(&Task{}, []string{"meeting_preparation_params.datetime_start"})
+		assert.NoError(t, err)
+		assert.Equal(t, bson.M{"_id": 1, "meeting_preparation_params.datetime_start": 1}, projection)
+	})
+	t.Run("SubfieldOfProjectedField", func(t *testing.T) {
+		projection, err := GetFieldProjection(Task{}, []string{"meeting_preparation_params", "meeting_preparation_params.datetime_start"})
+		assert.NoError(t, err)
+		assert.Equal(t, bson.M{"_id": 1, "meeting_preparation_params": 1}, projection)
+	})
+	t.Run("InvalidField", func(t *testing.T) {
+		_, err := GetFieldProjection(Task{}, []string{"title", "password"})
+		assert.EqualError(t, err, "invalid field: password")
+	})
+}
+
 func TestUpdateOrCreateTask(t *testing.T) {
 	db, dbCleanup, err := GetDBConnection()
 	assert.NoError(t, err)
@@ -885,6 +1038,7 @@ func TestUpdateOrCreateTask(t *testing.T) {
 	userID := primitive.NewObjectID()
 	notCompleted := false
 	task1, err := GetOrCreateTask(
+		context.Background(),
 		db,
 		userID,
 		"123abc",
@@ -909,7 +1063,7 @@ func TestUpdateOrCreateTask(t *testing.T) {
 			Title: &title,
 		}
 
-		newTask, err := UpdateOrCreateTask(db, userID, task1.IDExternal, task1.SourceID, updateTask, updateTask2, nil)
+		newTask, err := UpdateOrCreateTask(context.Background(), db, userID, task1.IDExternal, task1.SourceID, updateTask, updateTask2, nil)
 		assert.NoError(t, err)
 		assert.Equal(t, task1.ID, newTask.ID)
 		assert.Equal(t, *updateTask2.Title, *newTask.Title)
@@ -921,13 +1075,33 @@ func TestUpdateOrCreateTask(t *testing.T) {
 			IsCompleted: &completed,
 		}
 
-		newTask, err := UpdateOrCreateTask(db, userID, task1.IDExternal, task1.SourceID, nil, updateTask, nil)
+		newTask, err := UpdateOrCreateTask(context.Background(), db, userID, task1.IDExternal, task1.SourceID, nil, updateTask, nil)
 		assert.NoError(t, err)
 		assert.Equal(t, task1.ID, newTask.ID)
 		assert.True(t, *newTask.IsCompleted)
 	})
 }
 
+func TestGetInsertOnlyFields(t *testing.T) {
+	title := "hello!"
+	completed := true
+	t.Run("DropsFieldsBeingSet", func(t *testing.T) {
+		fields, err := getInsertOnlyFields(bson.M{"title": title, "is_completed": completed}, &Task{Title: &title})
+		assert.NoError(t, err)
+		assert.Equal(t, bson.M{"is_completed": true}, fields)
+	})
+	t.Run("DropsConflictingPaths", func(t *testing.T) {
+		fields, err := getInsertOnlyFields(bson.M{"linear_params": bson.M{"cycle": 1}, "title": title}, bson.M{"linear_params.cycle": 2})
+		assert.NoError(t, err)
+		assert.Equal(t, bson.M{"title": title}, fields)
+	})
+	t.Run("NothingBeingSet", func(t *testing.T) {
+		fields, err := getInsertOnlyFields(bson.M{"title": title}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, bson.M{"title": title}, fields)
+	})
+}
+
 func TestUpdateOrCreatePullRequest(t *testing.T) {
 	db, dbCleanup, err := GetDBConnection()
 	assert.NoError(t, err)
@@ -935,6 +1109,7 @@ func TestUpdateOrCreatePullRequest(t *testing.T) {
 	userID := primitive.NewObjectID()
 	notCompleted := false
 	pr1, err := GetOrCreatePullRequest(
+		context.Background(),
 		db,
 		userID,
 		"123abc",
@@ -950,7 +1125,7 @@ func TestUpdateOrCreatePullRequest(t *testing.T) {
 
 	t.Run("CreateSuccess", func(t *testing.T) {
 		newUserID := primitive.NewObjectID()
-		newPR, err := UpdateOrCreatePullRequest(db, newUserID, "222aaa", "random_source", &PullRequest{
+		newPR, err := UpdateOrCreatePullRequest(context.Background(), db, newUserID, "222aaa", "random_source", &PullRequest{
 			IDExternal: "222aaa",
 			SourceID:   "random_source",
 			UserID:     newUserID,
@@ -958,7 +1133,7 @@ func TestUpdateOrCreatePullRequest(t *testing.T) {
 		}, nil)
 		assert.NoError(t, err)
 
-		respPR, err := GetPullRequestByExternalID(db, "222aaa", newUserID)
+		respPR, err := GetPullRequestByExternalID(context.Background(), db, "222aaa", newUserID)
 		assert.NoError(t, err)
 		assert.Equal(t, newPR.ID, respPR.ID)
 		assert.Equal(t, "new event", respPR.Title)
@@ -969,7 +1144,7 @@ func TestUpdateOrCreatePullRequest(t *testing.T) {
 			IsCompleted: &completed,
 		}
 
-		newPR, err := UpdateOrCreatePullRequest(db, userID, pr1.IDExternal, pr1.SourceID, updateTask, nil)
+		newPR, err := UpdateOrCreatePullRequest(context.Background(), db, userID, pr1.IDExternal, pr1.SourceID, updateTask, nil)
 		assert.NoError(t, err)
 		assert.Equal(t, pr1.ID, newPR.ID)
 		assert.True(t, *newPR.IsCompleted)
@@ -982,6 +1157,7 @@ func TestUpdateOrCreateCalendarEvent(t *testing.T) {
 	defer dbCleanup()
 	userID := primitive.NewObjectID()
 	event, err := GetOrCreateCalendarEvent(
+		context.Background(),
 		db,
 		userID,
 		"123abc",
@@ -997,7 +1173,7 @@ func TestUpdateOrCreateCalendarEvent(t *testing.T) {
 
 	t.Run("CreateSuccess", func(t *testing.T) {
 		newUserID := primitive.NewObjectID()
-		newEvent, err := UpdateOrCreateCalendarEvent(db, newUserID, "222aaa", "random_source", &CalendarEvent{
+		newEvent, err := UpdateOrCreateCalendarEvent(context.Background(), db, newUserID, "222aaa", "random_source", &CalendarEvent{
 			IDExternal: "222aaa",
 			SourceID:   "random_source",
 			UserID:     newUserID,
@@ -1005,7 +1181,7 @@ func TestUpdateOrCreateCalendarEvent(t *testing.T) {
 		}, nil)
 		assert.NoError(t, err)
 
-		respEvent, err := GetCalendarEvent(db, (*newEvent).ID, newUserID)
+		respEvent, err := GetCalendarEvent(context.Background(), db, (*newEvent).ID, newUserID)
 		assert.NoError(t, err)
 		assert.Equal(t, newEvent.ID, respEvent.ID)
 		assert.Equal(t, "new event", respEvent.Title)
@@ -1015,7 +1191,7 @@ func TestUpdateOrCreateCalendarEvent(t *testing.T) {
 			Title: "new title",
 		}
 
-		newPR, err := UpdateOrCreateCalendarEvent(db, userID, event.IDExternal, event.SourceID, updateEvent, nil)
+		newPR, err := UpdateOrCreateCalendarEvent(context.Background(), db, userID, event.IDExternal, event.SourceID, updateEvent, nil)
 		assert.NoError(t, err)
 		assert.Equal(t, event.ID, newPR.ID)
 		assert.Equal(t, updateEvent.Title, newPR.Title)
@@ -1029,6 +1205,7 @@ func TestGetTask(t *testing.T) {
 	userID := primitive.NewObjectID()
 	notCompleted := false
 	task1, err := GetOrCreateTask(
+		context.Background(),
 		db,
 		userID,
 		"123abc",
@@ -1043,17 +1220,17 @@ func TestGetTask(t *testing.T) {
 	assert.NoError(t, err)
 
 	t.Run("WrongID", func(t *testing.T) {
-		respTask, err := GetTask(db, primitive.NewObjectID(), userID)
+		respTask, err := GetTask(context.Background(), db, primitive.NewObjectID(), userID)
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 		assert.Nil(t, respTask)
 	})
 	t.Run("WrongUserID", func(t *testing.T) {
-		respTask, err := GetTask(db, task1.ID, primitive.NewObjectID())
+		respTask, err := GetTask(context.Background(), db, task1.ID, primitive.NewObjectID())
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 		assert.Nil(t, respTask)
 	})
 	t.Run("Success", func(t *testing.T) {
-		respTask, err := GetTask(db, task1.ID, userID)
+		respTask, err := GetTask(context.Background(), db, task1.ID, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, task1.ID, respTask.ID)
 	})
@@ -1066,6 +1243,7 @@ func TestGetPullRequest(t *testing.T) {
 	userID := primitive.NewObjectID()
 	notCompleted := false
 	pullRequest1, err := GetOrCreatePullRequest(
+		context.Background(),
 		db,
 		userID,
 		"123abc",
@@ -1080,17 +1258,17 @@ func TestGetPullRequest(t *testing.T) {
 	assert.NoError(t, err)
 
 	t.Run("WrongID", func(t *testing.T) {
-		pullRequest, err := GetPullRequest(db, primitive.NewObjectID(), userID)
+		pullRequest, err := GetPullRequest(context.Background(), db, primitive.NewObjectID(), userID)
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 		assert.Nil(t, pullRequest)
 	})
 	t.Run("WrongUserID", func(t *testing.T) {
-		pullRequest, err := GetPullRequest(db, pullRequest1.ID, primitive.NewObjectID())
+		pullRequest, err := GetPullRequest(context.Background(), db, pullRequest1.ID, primitive.NewObjectID())
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 		assert.Nil(t, pullRequest)
 	})
 	t.Run("Success", func(t *testing.T) {
-		pullRequest, err := GetPullRequest(db, pullRequest1.ID, userID)
+		pullRequest, err := GetPullRequest(context.Background(), db, pullRequest1.ID, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, pullRequest1.ID, pullRequest.ID)
 	})
@@ -1103,6 +1281,7 @@ func TestGetTaskByExternalIDWithoutUser(t *testing.T) {
 	userID := primitive.NewObjectID()
 	notCompleted := false
 	task1, err := GetOrCreateTask(
+		context.Background(),
 		db,
 		userID,
 		"123abd",
@@ -1117,12 +1296,12 @@ func TestGetTaskByExternalIDWithoutUser(t *testing.T) {
 	assert.NoError(t, err)
 
 	t.Run("WrongID", func(t *testing.T) {
-		respTask, err := GetTaskByExternalIDWithoutUser(db, "invalid", false)
+		respTask, err := GetTaskByExternalIDWithoutUser(context.Background(), db, "invalid", false)
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 		assert.Nil(t, respTask)
 	})
 	t.Run("Success", func(t *testing.T) {
-		respTask, err := GetTaskByExternalIDWithoutUser(db, "123abd", false)
+		respTask, err := GetTaskByExternalIDWithoutUser(context.Background(), db, "123abd", false)
 		assert.NoError(t, err)
 		assert.Equal(t, task1.ID, respTask.ID)
 	})
@@ -1134,6 +1313,7 @@ func TestGetCalendarEvent(t *testing.T) {
 	defer dbCleanup()
 	userID := primitive.NewObjectID()
 	event, err := GetOrCreateCalendarEvent(
+		context.Background(),
 		db,
 		userID,
 		"123abc",
@@ -1147,22 +1327,22 @@ func TestGetCalendarEvent(t *testing.T) {
 	assert.NoError(t, err)
 
 	t.Run("WrongID", func(t *testing.T) {
-		respEvent, err := GetCalendarEvent(db, primitive.NewObjectID(), userID)
+		respEvent, err := GetCalendarEvent(context.Background(), db, primitive.NewObjectID(), userID)
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 		assert.Nil(t, respEvent)
 	})
 	t.Run("WrongUserID", func(t *testing.T) {
-		respEvent, err := GetCalendarEvent(db, event.ID, primitive.NewObjectID())
+		respEvent, err := GetCalendarEvent(context.Background(), db, event.ID, primitive.NewObjectID())
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 		assert.Nil(t, respEvent)
 	})
 	t.Run("Success", func(t *testing.T) {
-		respEvent, err := GetCalendarEvent(db, event.ID, userID)
+		respEvent, err := GetCalendarEvent(context.Background(), db, event.ID, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, event.ID, respEvent.ID)
 	})
 	t.Run("SuccessWithoutUserID", func(t *testing.T) {
-		respEvent, err := GetCalendarEventWithoutUserID(db, event.ID)
+		respEvent, err := GetCalendarEventWithoutUserID(context.Background(), db, event.ID)
 		assert.NoError(t, err)
 		assert.Equal(t, event.ID, respEvent.ID)
 	})
@@ -1174,6 +1354,7 @@ func TestGetPullRequestByExternalID(t *testing.T) {
 	defer dbCleanup()
 	userID := primitive.NewObjectID()
 	pullRequest, err := GetOrCreatePullRequest(
+		context.Background(),
 		db,
 		userID,
 		"123abc",
@@ -1187,17 +1368,17 @@ func TestGetPullRequestByExternalID(t *testing.T) {
 	assert.NoError(t, err)
 
 	t.Run("WrongID", func(t *testing.T) {
-		respPR, err := GetPullRequestByExternalID(db, "wrong ID", userID)
+		respPR, err := GetPullRequestByExternalID(context.Background(), db, "wrong ID", userID)
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 		assert.Nil(t, respPR)
 	})
 	t.Run("WrongUserID", func(t *testing.T) {
-		respPR, err := GetPullRequestByExternalID(db, pullRequest.IDExternal, primitive.NewObjectID())
+		respPR, err := GetPullRequestByExternalID(context.Background(), db, pullRequest.IDExternal, primitive.NewObjectID())
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 		assert.Nil(t, respPR)
 	})
 	t.Run("Success", func(t *testing.T) {
-		respPR, err := GetPullRequestByExternalID(db, pullRequest.IDExternal, userID)
+		respPR, err := GetPullRequestByExternalID(context.Background(), db, pullRequest.IDExternal, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, pullRequest.ID, respPR.ID)
 	})
@@ -1209,6 +1390,7 @@ func TestMarkItemComplete(t *testing.T) {
 	defer dbCleanup()
 	userID := primitive.NewObjectID()
 	task1, err := GetOrCreateTask(
+		context.Background(),
 		db,
 		userID,
 		"123abc",
@@ -1221,8 +1403,8 @@ func TestMarkItemComplete(t *testing.T) {
 	)
 	assert.NoError(t, err)
 	t.Run("SuccessIdempotent", func(t *testing.T) {
-		MarkCompleteWithCollection(GetTaskCollection(db), task1.ID)
-		tasks, err := GetCompletedTasks(db, userID)
+		MarkCompleteWithCollection(context.Background(), GetTaskCollection(db), task1.ID)
+		tasks, err := GetCompletedTasks(context.Background(), db, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(*tasks))
 		updatedTask := (*tasks)[0]
@@ -1232,8 +1414,8 @@ func TestMarkItemComplete(t *testing.T) {
 		// ensure timestamp advances enough to be different
 		time.Sleep(time.Millisecond)
 
-		MarkCompleteWithCollection(GetTaskCollection(db), task1.ID)
-		tasks, err = GetCompletedTasks(db, userID)
+		MarkCompleteWithCollection(context.Background(), GetTaskCollection(db), task1.ID)
+		tasks, err = GetCompletedTasks(context.Background(), db, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(*tasks))
 		updatedTask2 := (*tasks)[0]
@@ -1262,7 +1444,7 @@ func TestGetEventsUntilEndOfDay(t *testing.T) {
 	assert.NoError(t, err)
 
 	t.Run("Success", func(t *testing.T) {
-		events, err := GetEventsUntilEndOfDay(db, userID, timeBase)
+		events, err := GetEventsUntilEndOfDay(context.Background(), db, userID, timeBase, timeDayLater.Add(-time.Second))
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(*events))
 		assert.Equal(t, eventID, (*events)[0].ID)
@@ -1276,7 +1458,7 @@ func TestTaskSectionName(t *testing.T) {
 	userID := primitive.NewObjectID()
 
 	t.Run("DefaultTaskSection", func(t *testing.T) {
-		name, err := GetTaskSectionName(db, constants.IDTaskSectionDefault, userID)
+		name, err := GetTaskSectionName(context.Background(), db, constants.IDTaskSectionDefault, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, "Task Inbox", name)
 	})
@@ -1293,7 +1475,7 @@ func TestTaskSectionName(t *testing.T) {
 		)
 		assert.NoError(t, err)
 
-		name, err := GetTaskSectionName(db, res.InsertedID.(primitive.ObjectID), userID)
+		name, err := GetTaskSectionName(context.Background(), db, res.InsertedID.(primitive.ObjectID), userID)
 		assert.NoError(t, err)
 		assert.Equal(t, sectionName, name)
 	})
@@ -1301,7 +1483,7 @@ func TestTaskSectionName(t *testing.T) {
 		db, dbCleanup, err := GetDBConnection()
 		assert.NoError(t, err)
 		defer dbCleanup()
-		name, err := GetTaskSectionName(db, primitive.NewObjectID(), userID)
+		name, err := GetTaskSectionName(context.Background(), db, primitive.NewObjectID(), userID)
 		assert.Error(t, err)
 		assert.Equal(t, "", name)
 	})
@@ -1315,7 +1497,7 @@ func TestGetView(t *testing.T) {
 
 	t.Run("ViewDoesNotExist", func(t *testing.T) {
 		viewID := primitive.NewObjectID()
-		_, err := GetView(db, userID, viewID)
+		_, err := GetView(context.Background(), db, userID, viewID)
 		assert.Error(t, err)
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 	})
@@ -1327,7 +1509,7 @@ func TestGetView(t *testing.T) {
 		assert.NoError(t, err)
 		viewID := mongoResult.InsertedID.(primitive.ObjectID)
 
-		_, err = GetView(db, userID, viewID)
+		_, err = GetView(context.Background(), db, userID, viewID)
 		assert.Error(t, err)
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 	})
@@ -1340,7 +1522,7 @@ func TestGetView(t *testing.T) {
 		assert.NoError(t, err)
 		viewID := mongoResult.InsertedID.(primitive.ObjectID)
 
-		view, err := GetView(db, userID, viewID)
+		view, err := GetView(context.Background(), db, userID, viewID)
 		assert.NoError(t, err)
 		assert.Equal(t, "custom type", view.Type)
 	})
@@ -1353,7 +1535,7 @@ func TestGetTaskSections(t *testing.T) {
 	userID := primitive.NewObjectID()
 
 	t.Run("NoTaskSections", func(t *testing.T) {
-		sections, err := GetTaskSections(db, userID)
+		sections, err := GetTaskSections(context.Background(), db, userID, false)
 		assert.NoError(t, err)
 		assert.Equal(t, 0, len(*sections))
 	})
@@ -1370,7 +1552,7 @@ func TestGetTaskSections(t *testing.T) {
 		)
 		assert.NoError(t, err)
 
-		sections, err := GetTaskSections(db, userID)
+		sections, err := GetTaskSections(context.Background(), db, userID, false)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(*sections))
 		assert.Equal(t, sectionName, (*sections)[0].Name)
@@ -1388,10 +1570,30 @@ func TestGetTaskSections(t *testing.T) {
 		)
 		assert.NoError(t, err)
 
-		sections, err := GetTaskSections(db, primitive.NewObjectID())
+		sections, err := GetTaskSections(context.Background(), db, primitive.NewObjectID(), false)
 		assert.NoError(t, err)
 		assert.Equal(t, 0, len(*sections))
 	})
+	t.Run("ArchivedTaskSection", func(t *testing.T) {
+		archivedUserID := primitive.NewObjectID()
+		_, err := GetTaskSectionCollection(db).InsertMany(
+			context.Background(),
+			[]interface{}{
+				&TaskSection{UserID: archivedUserID, Name: "active"},
+				&TaskSection{UserID: archivedUserID, Name: "archived", IsArchived: true},
+			},
+		)
+		assert.NoError(t, err)
+
+		sections, err := GetTaskSections(context.Background(), db, archivedUserID, false)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(*sections))
+		assert.Equal(t, "active", (*sections)[0].Name)
+
+		sections, err = GetTaskSections(context.Background(), db, archivedUserID, true)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(*sections))
+	})
 }
 
 func TestGetUser(t *testing.T) {
@@ -1413,11 +1615,11 @@ func TestGetUser(t *testing.T) {
 	assert.NoError(t, err)
 
 	t.Run("WrongUserID", func(t *testing.T) {
-		_, err := GetUser(db, primitive.NewObjectID())
+		_, err := GetUser(context.Background(), db, primitive.NewObjectID())
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 	})
 	t.Run("Success", func(t *testing.T) {
-		user, err := GetUser(db, userID)
+		user, err := GetUser(context.Background(), db, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, googleID, user.GoogleID)
 		assert.Equal(t, name, user.Name)
@@ -1444,11 +1646,11 @@ func TestGetGeneralTaskUserByName(t *testing.T) {
 	assert.NoError(t, err)
 
 	t.Run("WrongUserID", func(t *testing.T) {
-		_, err := GetGeneralTaskUserByName(db, "julian")
+		_, err := GetGeneralTaskUserByName(context.Background(), db, "julian")
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 	})
 	t.Run("Success", func(t *testing.T) {
-		user, err := GetGeneralTaskUserByName(db, "example")
+		user, err := GetGeneralTaskUserByName(context.Background(), db, "example")
 		assert.NoError(t, err)
 		assert.Equal(t, googleID, user.Email)
 		assert.Equal(t, name, user.Name)
@@ -1461,24 +1663,24 @@ func TestGetStateToken(t *testing.T) {
 	defer dbCleanup()
 	userID := primitive.NewObjectID()
 
-	tokenStr, err := CreateStateToken(db, &userID, false)
+	tokenStr, err := CreateStateToken(context.Background(), db, &userID, false)
 	assert.NoError(t, err)
 
 	tokenID, err := primitive.ObjectIDFromHex(*tokenStr)
 	assert.NoError(t, err)
 
 	t.Run("WrongStateToken", func(t *testing.T) {
-		token, err := GetStateToken(db, primitive.NewObjectID(), &userID)
+		token, err := GetStateToken(context.Background(), db, primitive.NewObjectID(), &userID)
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 		assert.Nil(t, token)
 	})
 	t.Run("SuccessNoUserID", func(t *testing.T) {
-		token, err := GetStateToken(db, tokenID, nil)
+		token, err := GetStateToken(context.Background(), db, tokenID, nil)
 		assert.NoError(t, err)
 		assert.Equal(t, tokenID, token.Token)
 	})
 	t.Run("Success", func(t *testing.T) {
-		token, err := GetStateToken(db, tokenID, &userID)
+		token, err := GetStateToken(context.Background(), db, tokenID, &userID)
 		assert.NoError(t, err)
 		assert.Equal(t, tokenID, token.Token)
 	})
@@ -1490,25 +1692,25 @@ func TestDeleteStateToken(t *testing.T) {
 	defer dbCleanup()
 	userID := primitive.NewObjectID()
 
-	tokenStr, err := CreateStateToken(db, &userID, false)
+	tokenStr, err := CreateStateToken(context.Background(), db, &userID, false)
 	assert.NoError(t, err)
 
 	tokenID, err := primitive.ObjectIDFromHex(*tokenStr)
 	assert.NoError(t, err)
 
 	t.Run("WrongStateToken", func(t *testing.T) {
-		err := DeleteStateToken(db, primitive.NewObjectID(), &userID)
+		err := DeleteStateToken(context.Background(), db, primitive.NewObjectID(), &userID)
 		assert.Error(t, err)
 	})
 	t.Run("Success", func(t *testing.T) {
-		token, err := GetStateToken(db, tokenID, &userID)
+		token, err := GetStateToken(context.Background(), db, tokenID, &userID)
 		assert.NoError(t, err)
 		assert.Equal(t, tokenID, token.Token)
 
-		err = DeleteStateToken(db, tokenID, &userID)
+		err = DeleteStateToken(context.Background(), db, tokenID, &userID)
 		assert.NoError(t, err)
 
-		token, err = GetStateToken(db, tokenID, &userID)
+		token, err = GetStateToken(context.Background(), db, tokenID, &userID)
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 	})
 }
@@ -1518,7 +1720,7 @@ func TestInsertLogEvent(t *testing.T) {
 	assert.NoError(t, err)
 	defer dbCleanup()
 	t.Run("Success", func(t *testing.T) {
-		InsertLogEvent(db, primitive.NewObjectID(), "dogecoin_to_the_moon")
+		InsertLogEvent(context.Background(), db, primitive.NewObjectID(), "dogecoin_to_the_moon")
 
 		logEventsCollection := GetLogEventsCollection(db)
 		count, err := logEventsCollection.CountDocuments(context.Background(), bson.M{"event_type": "dogecoin_to_the_moon"})
@@ -1546,11 +1748,11 @@ func TestGetExternalToken(t *testing.T) {
 	assert.NoError(t, err)
 
 	t.Run("WrongUserID", func(t *testing.T) {
-		_, err := GetExternalToken(db, "wrong account", serviceID)
+		_, err := GetExternalToken(context.Background(), db, "wrong account", serviceID)
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 	})
 	t.Run("Success", func(t *testing.T) {
-		token, err := GetExternalToken(db, accountID, serviceID)
+		token, err := GetExternalToken(context.Background(), db, accountID, serviceID)
 		assert.NoError(t, err)
 		assert.Equal(t, userID, token.UserID)
 		assert.Equal(t, serviceID, token.ServiceID)
@@ -1579,15 +1781,15 @@ func TestGetExternalTokeByExternalID(t *testing.T) {
 	assert.NoError(t, err)
 
 	t.Run("WrongExternalID", func(t *testing.T) {
-		_, err := GetExternalTokenByExternalID(db, "wrong account", serviceID, false)
+		_, err := GetExternalTokenByExternalID(context.Background(), db, "wrong account", serviceID, false)
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 	})
 	t.Run("WrongServiceID", func(t *testing.T) {
-		_, err := GetExternalTokenByExternalID(db, externalID, "wrong service", false)
+		_, err := GetExternalTokenByExternalID(context.Background(), db, externalID, "wrong service", false)
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 	})
 	t.Run("Success", func(t *testing.T) {
-		token, err := GetExternalTokenByExternalID(db, externalID, serviceID, false)
+		token, err := GetExternalTokenByExternalID(context.Background(), db, externalID, serviceID, false)
 		assert.NoError(t, err)
 		assert.Equal(t, userID, token.UserID)
 		assert.Equal(t, serviceID, token.ServiceID)
@@ -1610,22 +1812,22 @@ func TestGetExternalTokens(t *testing.T) {
 	assert.NoError(t, err)
 
 	t.Run("NoExternalTokens", func(t *testing.T) {
-		tokens, err := GetExternalTokens(db, userID, "")
+		tokens, err := GetExternalTokens(context.Background(), db, userID, "")
 		assert.NoError(t, err)
 		assert.Equal(t, 0, len(*tokens))
 	})
 	t.Run("WrongUserID", func(t *testing.T) {
-		tokens, err := GetExternalTokens(db, primitive.NewObjectID(), "elon")
+		tokens, err := GetExternalTokens(context.Background(), db, primitive.NewObjectID(), "elon")
 		assert.NoError(t, err)
 		assert.Equal(t, 0, len(*tokens))
 	})
 	t.Run("WrongServiceID", func(t *testing.T) {
-		tokens, err := GetExternalTokens(db, userID, "jeff")
+		tokens, err := GetExternalTokens(context.Background(), db, userID, "jeff")
 		assert.NoError(t, err)
 		assert.Equal(t, 0, len(*tokens))
 	})
 	t.Run("Success", func(t *testing.T) {
-		tokens, err := GetExternalTokens(db, userID, "elon")
+		tokens, err := GetExternalTokens(context.Background(), db, userID, "elon")
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(*tokens))
 		assert.Equal(t, "elon", (*tokens)[0].ServiceID)
@@ -1646,19 +1848,19 @@ func TestGetAllExternalTokens(t *testing.T) {
 	assert.NoError(t, err)
 
 	t.Run("NoExternalTokens", func(t *testing.T) {
-		tokens, err := GetAllExternalTokens(db, primitive.NewObjectID())
+		tokens, err := GetAllExternalTokens(context.Background(), db, primitive.NewObjectID())
 		assert.NoError(t, err)
 		assert.Equal(t, 0, len(tokens))
 	})
 	t.Run("Success", func(t *testing.T) {
-		tokens, err := GetAllExternalTokens(db, userID)
+		tokens, err := GetAllExternalTokens(context.Background(), db, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(tokens))
 		assert.Equal(t, userID, tokens[0].UserID)
 	})
 }
 
-func TestAdjustOrderingIDs(t *testing.T) {
+func TestMoveToPosition(t *testing.T) {
 	db, dbCleanup, err := GetDBConnection()
 	assert.NoError(t, err)
 	defer dbCleanup()
@@ -1671,14 +1873,47 @@ func TestAdjustOrderingIDs(t *testing.T) {
 	assert.NoError(t, err)
 	id4, err := createTestTaskSectionWithOrderingID(db, userID, 2)
 	assert.NoError(t, err)
+	collection := GetTaskSectionCollection(db)
 
-	t.Run("Success", func(t *testing.T) {
-		err := AdjustOrderingIDsForCollection(GetTaskSectionCollection(db), userID, id1, 1)
+	assertOrder := func(t *testing.T, expectedIDs ...primitive.ObjectID) {
+		var sections []ReorderableSubmodel
+		err := FindWithCollection(context.Background(), collection, userID, nil, &sections, options.Find().SetSort(orderingSort))
 		assert.NoError(t, err)
-		assertTaskSectionOrderingID(t, db, id1, 1)
-		assertTaskSectionOrderingID(t, db, id2, 2)
+		sectionIDs := []primitive.ObjectID{}
+		for _, section := range sections {
+			sectionIDs = append(sectionIDs, section.ID)
+		}
+		assert.Equal(t, expectedIDs, sectionIDs)
+	}
+
+	t.Run("MoveToStart", func(t *testing.T) {
+		err := MoveToPosition(context.Background(), collection, userID, id3, nil, 1)
+		assert.NoError(t, err)
+		assertOrder(t, id3, id1, id2, id4)
+		assertTaskSectionOrderingID(t, db, id3, 1)
+	})
+	t.Run("MoveDown", func(t *testing.T) {
+		// ahead of the section at position 4, counting the moved section
+		err := MoveToPosition(context.Background(), collection, userID, id3, nil, 4)
+		assert.NoError(t, err)
+		assertOrder(t, id1, id2, id3, id4)
+		// only the moved section is written to
 		assertTaskSectionOrderingID(t, db, id3, 3)
-		assertTaskSectionOrderingID(t, db, id4, 4)
+		assertTaskSectionOrderingID(t, db, id4, 2)
+	})
+	t.Run("MovePastEnd", func(t *testing.T) {
+		err := MoveToPosition(context.Background(), collection, userID, id1, nil, 10)
+		assert.NoError(t, err)
+		assertOrder(t, id2, id3, id4, id1)
+	})
+	t.Run("SiblingFilters", func(t *testing.T) {
+		err := MoveToPosition(context.Background(), collection, userID, id4, &[]bson.M{{"_id": bson.M{"$in": []primitive.ObjectID{id2, id3}}}}, 2)
+		assert.NoError(t, err)
+		assertOrder(t, id2, id4, id3, id1)
+	})
+	t.Run("WrongUser", func(t *testing.T) {
+		err := MoveToPosition(context.Background(), collection, primitive.NewObjectID(), id4, nil, 1)
+		assert.EqualError(t, err, "item to order not found")
 	})
 }
 
@@ -1689,7 +1924,7 @@ func TestUpdateUserSetting(t *testing.T) {
 	userID := primitive.NewObjectID()
 
 	t.Run("Success", func(t *testing.T) {
-		err := UpdateUserSetting(db, userID, "key", "value")
+		err := UpdateUserSetting(context.Background(), db, userID, "key", "value")
 		assert.NoError(t, err)
 		var userSetting UserSetting
 		err = GetUserSettingsCollection(db).FindOne(context.Background(), bson.M{"user_id": userID}, nil).Decode(&userSetting)
@@ -1708,7 +1943,7 @@ func TestLogRequestInfo(t *testing.T) {
 	taskID := primitive.NewObjectID()
 
 	t.Run("Success", func(t *testing.T) {
-		LogRequestInfo(db, time.Now(), userID, "/testing/", 100, &taskID, 200)
+		LogRequestInfo(context.Background(), db, time.Now(), userID, "/testing/", 100, &taskID, 200)
 		collection := GetServerRequestCollection(db)
 		cursor, err := collection.Find(context.Background(), bson.M{"user_id": userID})
 		assert.NoError(t, err)
@@ -1773,18 +2008,34 @@ func TestGetCalendarEventByExternalId(t *testing.T) {
 	assert.NoError(t, err)
 
 	t.Run("WrongID", func(t *testing.T) {
-		respEvent, err := GetCalendarEventByExternalId(db, "wrong ID", userID)
+		respEvent, err := GetCalendarEventByExternalId(context.Background(), db, "wrong ID", userID)
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 		assert.Nil(t, respEvent)
 	})
 	t.Run("WrongUserID", func(t *testing.T) {
-		respEvent, err := GetCalendarEventByExternalId(db, externalID, primitive.NewObjectID())
+		respEvent, err := GetCalendarEventByExternalId(context.Background(), db, externalID, primitive.NewObjectID())
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 		assert.Nil(t, respEvent)
 	})
 	t.Run("Success", func(t *testing.T) {
-		respEvent, err := GetCalendarEventByExternalId(db, externalID, userID)
+		respEvent, err := GetCalendarEventByExternalId(context.Background(), db, externalID, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, event, respEvent.ID)
 	})
 }
+
+func TestGetCalendarEventColors(t *testing.T) {
+	event := CalendarEvent{CalendarColorBackground: "#9fe1e7", CalendarColorForeground: "#000000"}
+	t.Run("CalendarColor", func(t *testing.T) {
+		background, foreground := GetCalendarEventColors(event)
+		assert.Equal(t, "#9fe1e7", background)
+		assert.Equal(t, "#000000", foreground)
+	})
+	t.Run("EventColor", func(t *testing.T) {
+		event.ColorBackground = "#dc2127"
+		event.ColorForeground = "#1d1d1d"
+		background, foreground := GetCalendarEventColors(event)
+		assert.Equal(t, "#dc2127", background)
+		assert.Equal(t, "#1d1d1d", foreground)
+	})
+}
@@ -0,0 +1,144 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/logging"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// building indexes on large collections can take a while on first boot
+const ENSURE_INDEXES_TIMEOUT = 5 * time.Minute
+
+type IndexDefinition struct {
+	Collection string
+	Keys       bson.D
+	Unique     bool
+	// Sparse leaves documents without the keys out of the index, so Unique only applies to the
+	// documents that have them
+	Sparse bool
+	// ExpireAfter makes this a TTL index. Set through RetentionPolicies rather than directly
+	ExpireAfter time.Duration
+}
+
+// IndexDefinitions covers the query shapes used in helpers.go. Add an entry here when adding a
+// query that filters or sorts on fields not already covered
+var IndexDefinitions = []IndexDefinition{
+	// getDBQuery lookups used by the GetOrCreate and UpdateOrCreate helpers
+	{Collection: "tasks", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "id_external", Value: 1}, {Key: "source_id", Value: 1}}},
+	{Collection: "pull_requests", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "id_external", Value: 1}, {Key: "source_id", Value: 1}}},
+	{Collection: "calendar_events", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "id_external", Value: 1}, {Key: "source_id", Value: 1}}},
+	{Collection: "calendar_accounts", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "id_external", Value: 1}, {Key: "source_id", Value: 1}}},
+	{Collection: "notes", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "id_external", Value: 1}, {Key: "source_id", Value: 1}}},
+	// active item lists
+	{Collection: "tasks", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "is_completed", Value: 1}, {Key: "is_deleted", Value: 1}}},
+	{Collection: "pull_requests", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "is_completed", Value: 1}, {Key: "is_deleted", Value: 1}}},
+	{Collection: "pull_requests", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "merged_at", Value: 1}}},
+	{Collection: "tasks", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "id_task_section", Value: 1}}},
+	{Collection: "tasks", Keys: bson.D{{Key: "parent_task_id", Value: 1}}},
+	{Collection: "tasks", Keys: bson.D{{Key: "id_external", Value: 1}}},
+	// meeting preparation tasks are looked up by the time of their event
+	{Collection: "tasks", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "is_meeting_preparation_task", Value: 1}, {Key: "meeting_preparation_params.datetime_start", Value: 1}}},
+	{Collection: "calendar_events", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "datetime_start", Value: 1}}},
+	{Collection: "meeting_prep_rules", Keys: bson.D{{Key: "user_id", Value: 1}}, Unique: true},
+	{Collection: "avatars", Keys: bson.D{{Key: "user_id", Value: 1}}, Unique: true},
+	{Collection: "views", Keys: bson.D{{Key: "type", Value: 1}, {Key: "user_id", Value: 1}}},
+	// meeting notes are created as events start
+	{Collection: "calendar_events", Keys: bson.D{{Key: "datetime_start", Value: 1}}},
+	{Collection: "notes", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "linked_event_id", Value: 1}}},
+	{Collection: "action_item_suggestions", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "note_id", Value: 1}, {Key: "status", Value: 1}}},
+	{Collection: "llm_usage", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "provider", Value: 1}, {Key: "model", Value: 1}, {Key: "date", Value: 1}}, Unique: true},
+	// sharing
+	{Collection: "tasks", Keys: bson.D{{Key: "shared_until", Value: 1}}},
+	{Collection: "notes", Keys: bson.D{{Key: "shared_until", Value: 1}}},
+	{Collection: "notes", Keys: bson.D{{Key: "slug", Value: 1}}, Unique: true, Sparse: true},
+	// trash retention
+	{Collection: "notes", Keys: bson.D{{Key: "is_deleted", Value: 1}, {Key: "deleted_at", Value: 1}}},
+	// tokens and users
+	{Collection: "internal_api_tokens", Keys: bson.D{{Key: "token", Value: 1}}},
+	{Collection: "internal_api_tokens", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "last_used_at", Value: -1}}},
+	{Collection: "external_api_tokens", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "service_id", Value: 1}}},
+	{Collection: "external_api_tokens", Keys: bson.D{{Key: "account_id", Value: 1}, {Key: "service_id", Value: 1}}},
+	{Collection: "external_api_tokens", Keys: bson.D{{Key: "is_bad_token", Value: 1}, {Key: "next_refresh_attempt_at", Value: 1}}},
+	{Collection: "external_token_health", Keys: bson.D{{Key: "token_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	{Collection: "users", Keys: bson.D{{Key: "email", Value: 1}}},
+	{Collection: "waitlist", Keys: bson.D{{Key: "email", Value: 1}}},
+	{Collection: "waitlist", Keys: bson.D{{Key: "has_access", Value: 1}, {Key: "created_at", Value: 1}}},
+	{Collection: "invite_codes", Keys: bson.D{{Key: "code", Value: 1}}, Unique: true},
+	{Collection: "invite_codes", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "is_referral", Value: 1}}},
+	{Collection: "magic_links", Keys: bson.D{{Key: "nonce", Value: 1}}, Unique: true},
+	{Collection: "magic_links", Keys: bson.D{{Key: "email", Value: 1}, {Key: "created_at", Value: 1}}},
+	{Collection: "user_settings", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "field_key", Value: 1}}},
+	{Collection: "task_sections", Keys: bson.D{{Key: "user_id", Value: 1}}},
+	{Collection: "tasks", Keys: bson.D{{Key: "assignee_id", Value: 1}}},
+	{Collection: "views", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "type", Value: 1}}},
+	{Collection: "tasks", Keys: bson.D{{Key: "jira_task_params.jql_view_ids", Value: 1}}},
+	{Collection: "repositories", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "account_id", Value: 1}}},
+	{Collection: "dashboard_team_members", Keys: bson.D{{Key: "team_id", Value: 1}}},
+	{Collection: "calendar_events", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "recurring_event_id", Value: 1}}},
+	// event search. A collection can only have one text index
+	{Collection: "calendar_events", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "title", Value: "text"}, {Key: "body", Value: "text"}, {Key: "location", Value: "text"}, {Key: "attendee_emails", Value: "text"}}},
+	{Collection: "calendar_feeds", Keys: bson.D{{Key: "secret", Value: 1}}, Unique: true},
+	{Collection: "availability_links", Keys: bson.D{{Key: "secret", Value: 1}}, Unique: true},
+	{Collection: "availability_links", Keys: bson.D{{Key: "user_id", Value: 1}}, Unique: true},
+	{Collection: "task_shares", Keys: bson.D{{Key: "token", Value: 1}}, Unique: true},
+	{Collection: "task_shares", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "task_id", Value: 1}}},
+	{Collection: "share_password_failures", Keys: bson.D{{Key: "share_id", Value: 1}, {Key: "ip_address", Value: 1}, {Key: "created_at", Value: -1}}},
+	{Collection: "note_folders", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "id_ordering", Value: 1}}},
+	{Collection: "notes", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "folder_id", Value: 1}}},
+	{Collection: "notes", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "daily_note_date", Value: 1}}},
+	{Collection: "organizations", Keys: bson.D{{Key: "domain", Value: 1}}, Unique: true},
+	{Collection: "share_views", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "task_id", Value: 1}}},
+	{Collection: "share_views", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "note_id", Value: 1}}},
+	{Collection: "conditional_responses", Keys: bson.D{{Key: "cache_key", Value: 1}}, Unique: true},
+	{Collection: "conditional_responses", Keys: bson.D{{Key: "user_id", Value: 1}}},
+	{Collection: "task_activity", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "task_id", Value: 1}}},
+	{Collection: "audit_logs", Keys: bson.D{{Key: "user_id", Value: 1}}},
+	{Collection: "notifications", Keys: bson.D{{Key: "is_sent", Value: 1}, {Key: "created_at", Value: 1}}},
+	{Collection: "default_section_settings", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "source_id", Value: 1}}},
+	{Collection: "tasks", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "id_task_section", Value: 1}, {Key: "ordering_key", Value: 1}}},
+	{Collection: "tasks", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "parent_task_id", Value: 1}, {Key: "ordering_key", Value: 1}}},
+	{Collection: "task_sections", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "ordering_key", Value: 1}}},
+	{Collection: "views", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "ordering_key", Value: 1}}},
+	{Collection: "note_folders", Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "ordering_key", Value: 1}}},
+}
+
+// EnsureIndexes creates any missing indexes from IndexDefinitions. Creating an index that already
+// exists is a no-op, so this is safe to run on every boot
+func EnsureIndexes(ctx context.Context, db *mongo.Database) error {
+	ctx, cancel := context.WithTimeout(ctx, ENSURE_INDEXES_TIMEOUT)
+	defer cancel()
+	logger := logging.GetSentryLogger()
+
+	modelsByCollection := map[string][]mongo.IndexModel{}
+	collections := []string{}
+	for _, definition := range append(IndexDefinitions, getTTLIndexDefinitions()...) {
+		if _, exists := modelsByCollection[definition.Collection]; !exists {
+			collections = append(collections, definition.Collection)
+		}
+		model := mongo.IndexModel{Keys: definition.Keys}
+		if definition.Unique {
+			model.Options = options.Index().SetUnique(true).SetSparse(definition.Sparse)
+		}
+		if definition.ExpireAfter > 0 {
+			model.Options = options.Index().SetExpireAfterSeconds(int32(definition.ExpireAfter.Seconds()))
+		}
+		modelsByCollection[definition.Collection] = append(modelsByCollection[definition.Collection], model)
+	}
+
+	var firstErr error
+	for _, collection := range collections {
+		_, err := db.Collection(collection).Indexes().CreateMany(ctx, modelsByCollection[collection])
+		if err != nil {
+			// keep going so one bad index doesn't leave every other collection unindexed
+			logger.Error().Err(err).Msgf("failed to create indexes for collection: %s", collection)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestEnsureIndexes(t *testing.T) {
+	db, dbCleanup, err := GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+
+	assert.NoError(t, EnsureIndexes(context.Background(), db))
+	// running again is a no-op
+	assert.NoError(t, EnsureIndexes(context.Background(), db))
+
+	cursor, err := GetTaskCollection(db).Indexes().List(context.Background())
+	assert.NoError(t, err)
+	var indexes []bson.M
+	assert.NoError(t, cursor.All(context.Background(), &indexes))
+	indexNames := []string{}
+	for _, index := range indexes {
+		indexNames = append(indexNames, index["name"].(string))
+	}
+	assert.Contains(t, indexNames, "user_id_1_id_external_1_source_id_1")
+	assert.Contains(t, indexNames, "user_id_1_is_completed_1_is_deleted_1")
+
+	cursor, err = GetCalendarFeedCollection(db).Indexes().List(context.Background())
+	assert.NoError(t, err)
+	indexes = []bson.M{}
+	assert.NoError(t, cursor.All(context.Background(), &indexes))
+	for _, index := range indexes {
+		if index["name"] == "secret_1" {
+			assert.Equal(t, true, index["unique"])
+		}
+	}
+
+	cursor, err = GetLogEventsCollection(db).Indexes().List(context.Background())
+	assert.NoError(t, err)
+	indexes = []bson.M{}
+	assert.NoError(t, cursor.All(context.Background(), &indexes))
+	foundTTLIndex := false
+	for _, index := range indexes {
+		if index["name"] == "created_at_1" {
+			foundTTLIndex = true
+			assert.EqualValues(t, 180*24*60*60, index["expireAfterSeconds"])
+		}
+	}
+	assert.True(t, foundTTLIndex)
+}
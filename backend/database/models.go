@@ -8,9 +8,13 @@ import (
 
 // User model
 type User struct {
-	ID                    primitive.ObjectID `bson:"_id,omitempty"`
-	GoogleID              string             `bson:"google_id"`
-	Email                 string             `bson:"email"`
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	GoogleID    string             `bson:"google_id"`
+	GithubID    string             `bson:"github_id,omitempty"`
+	MicrosoftID string             `bson:"microsoft_id,omitempty"`
+	Email       string             `bson:"email"`
+	// whether the provider the user signed up with verified the email, so other logins can be merged in
+	EmailVerified         bool               `bson:"email_verified,omitempty"`
 	Name                  string             `bson:"name"`
 	LastRefreshed         primitive.DateTime `bson:"last_refreshed,omitempty"`
 	AgreedToTerms         *bool              `bson:"agreed_to_terms,omitempty"`
@@ -21,6 +25,13 @@ type User struct {
 	LinearDisplayName     string             `bson:"linear_display_name"`
 	GPTSuggestionsLeft    int                `bson:"gpt_suggestions_left"`
 	GPTLastSuggestionTime primitive.DateTime `bson:"gpt_last_suggestion_time"`
+	// internal role for support tooling, see constants.UserRoleAdmin
+	Role string `bson:"role,omitempty"`
+	// profile, shown to collaborators on shared tasks and notes
+	GreetingName    string             `bson:"greeting_name,omitempty"`
+	Locale          string             `bson:"locale,omitempty"`
+	UseGravatar     *bool              `bson:"use_gravatar,omitempty"`
+	AvatarUpdatedAt primitive.DateTime `bson:"avatar_updated_at,omitempty"`
 }
 
 type UserChangeable struct {
@@ -33,9 +44,17 @@ type UserChangeable struct {
 
 // InternalAPIToken model
 type InternalAPIToken struct {
-	ID     primitive.ObjectID `bson:"_id,omitempty"`
-	Token  string             `bson:"token"`
-	UserID primitive.ObjectID `bson:"user_id"`
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	Token      string             `bson:"token"`
+	UserID     primitive.ObjectID `bson:"user_id"`
+	UserAgent  string             `bson:"user_agent,omitempty"`
+	IPAddress  string             `bson:"ip_address,omitempty"`
+	CreatedAt  primitive.DateTime `bson:"created_at,omitempty"`
+	LastUsedAt primitive.DateTime `bson:"last_used_at,omitempty"`
+	// tokens issued before sessions expired have no expiry until their first renewal
+	ExpiresAt primitive.DateTime `bson:"expires_at,omitempty"`
+	// set for read-only sessions started by support to see what the user sees
+	ImpersonatorID primitive.ObjectID `bson:"impersonator_id,omitempty"`
 }
 
 // ExternalAPIToken model
@@ -53,6 +72,25 @@ type ExternalAPIToken struct {
 	LastFullRefreshTime primitive.DateTime `bson:"last_full_refresh_time"`
 	Scopes              []string           `bson:"scopes"`
 	Timezone            string             `bson:"timezone"`
+	// set by the token refresh job, which retries failed refreshes with backoff before marking the token bad
+	RefreshFailureCount  int                `bson:"refresh_failure_count"`
+	NextRefreshAttemptAt primitive.DateTime `bson:"next_refresh_attempt_at"`
+	BadTokenAt           primitive.DateTime `bson:"bad_token_at"`
+	BadTokenReason       string             `bson:"bad_token_reason"`
+	// the label which turns a Google account's emails into tasks, left out so linking again keeps it
+	GmailTaskLabelID string `bson:"gmail_task_label_id,omitempty"`
+}
+
+// ExternalTokenHealthEvent records a change in whether a linked account's token works, so users can
+// see why an account needs relinking
+type ExternalTokenHealthEvent struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	TokenID   primitive.ObjectID `bson:"token_id"`
+	UserID    primitive.ObjectID `bson:"user_id"`
+	ServiceID string             `bson:"service_id"`
+	Status    string             `bson:"status"`
+	Detail    string             `bson:"detail"`
+	CreatedAt primitive.DateTime `bson:"created_at"`
 }
 
 type AtlassianSiteConfiguration struct {
@@ -73,6 +111,8 @@ type StateToken struct {
 	Token       primitive.ObjectID `bson:"_id,omitempty"`
 	UserID      primitive.ObjectID `bson:"user_id"`
 	UseDeeplink bool               `bson:"use_deeplink"`
+	// set when relinking, to the external token the new token replaces
+	RelinkTokenID primitive.ObjectID `bson:"relink_token_id,omitempty"`
 }
 
 type Oauth1RequestSecret struct {
@@ -89,6 +129,15 @@ const (
 	SharedAccessMeetingAttendees
 )
 
+// SharedPermission is what users opening a shared task can do with it
+type SharedPermission int
+
+const (
+	SharedPermissionView SharedPermission = iota
+	SharedPermissionComment
+	SharedPermissionEdit
+)
+
 type Task struct {
 	ID     primitive.ObjectID `bson:"_id,omitempty"`
 	UserID primitive.ObjectID `bson:"user_id,omitempty"`
@@ -99,6 +148,7 @@ type Task struct {
 	// generic task values (for all sources)
 	IDExternal         string              `bson:"id_external,omitempty"`
 	IDOrdering         int                 `bson:"id_ordering,omitempty"`
+	OrderingKey        string              `bson:"ordering_key,omitempty"`
 	IDTaskSection      primitive.ObjectID  `bson:"id_task_section,omitempty"`
 	IsCompleted        *bool               `bson:"is_completed,omitempty"`
 	IsDeleted          *bool               `bson:"is_deleted,omitempty"`
@@ -116,6 +166,7 @@ type Task struct {
 	CompletedAt        primitive.DateTime  `bson:"completed_at,omitempty"`
 	SharedUntil        primitive.DateTime  `bson:"shared_until,omitempty"`
 	SharedAccess       *SharedAccess       `bson:"shared_access,omitempty"`
+	SharedPermission   *SharedPermission   `bson:"shared_permission,omitempty"`
 	DeletedAt          primitive.DateTime  `bson:"deleted_at,omitempty"`
 	PriorityNormalized *float64            `bson:"priority_normalized,omitempty"`
 	TaskNumber         *int                `bson:"task_number,omitempty"`
@@ -138,6 +189,11 @@ type Task struct {
 	MeetingPreparationParams *MeetingPreparationParams `bson:"meeting_preparation_params,omitempty"`
 	IsMeetingPreparationTask bool                      `bson:"is_meeting_preparation_task,omitempty"`
 	LinearCycle              LinearCycle               `bson:"linear_cycle,omitempty"`
+	LinearProject            LinearProject             `bson:"linear_project,omitempty"`
+	LinearEstimate           *float64                  `bson:"linear_estimate,omitempty"`
+	// teammate the task has been assigned to, the task itself stays owned by UserID
+	AssigneeID primitive.ObjectID `bson:"assignee_id,omitempty"`
+	Labels     *[]string          `bson:"labels,omitempty"`
 }
 
 type RecurringTaskTemplate struct {
@@ -180,6 +236,7 @@ type PullRequest struct {
 	Author            string               `bson:"author,omitempty"`
 	Branch            string               `bson:"branch,omitempty"`
 	BaseBranch        string               `bson:"base_branch,omitempty"`
+	IsDraft           *bool                `bson:"is_draft,omitempty"`
 	RequiredAction    string               `bson:"required_action,omitempty"`
 	Comments          []PullRequestComment `bson:"comments,omitempty"`
 	CommentCount      int                  `bson:"comment_count,omitempty"`
@@ -190,6 +247,33 @@ type PullRequest struct {
 	LastFetched       primitive.DateTime   `bson:"last_fetched,omitempty"`
 	LastUpdatedAt     primitive.DateTime   `bson:"last_updated_at,omitempty"`
 	CompletedAt       primitive.DateTime   `bson:"completed_at,omitempty"`
+	// completed PRs may have been closed without merging, or only dropped out of the filters
+	MergedAt primitive.DateTime `bson:"merged_at,omitempty"`
+	// the first review submitted by someone other than the author
+	FirstReviewAt primitive.DateTime `bson:"first_review_at,omitempty"`
+	FirstReviewer string             `bson:"first_reviewer,omitempty"`
+	// hidden from the PR list and overview until then
+	SnoozedUntil primitive.DateTime `bson:"snoozed_until,omitempty"`
+	// hidden from the PR list and overview while the required action is still this one
+	MutedRequiredAction string `bson:"muted_required_action,omitempty"`
+	// not omitempty, so removing a PR's last label clears them
+	Labels []string `bson:"labels"`
+	// commit statuses and deployments for the head commit, only fetched when the user is the author or a reviewer
+	CommitStatuses []PullRequestCommitStatus `bson:"commit_statuses"`
+	Deployments    []PullRequestDeployment   `bson:"deployments"`
+}
+
+type PullRequestCommitStatus struct {
+	Context   string `bson:"context,omitempty"`
+	State     string `bson:"state,omitempty"`
+	TargetURL string `bson:"target_url,omitempty"`
+}
+
+// PullRequestDeployment is the latest deployment of the head commit to an environment
+type PullRequestDeployment struct {
+	Environment string `bson:"environment,omitempty"`
+	State       string `bson:"state,omitempty"`
+	URL         string `bson:"url,omitempty"`
 }
 
 type PullRequestComment struct {
@@ -209,6 +293,8 @@ type Calendar struct {
 	Title           string `bson:"title,omitempty"`
 	ColorBackground string `bson:"color_background,omitempty"`
 	ColorForeground string `bson:"color_foreground,omitempty"`
+	// calendars are synced unless the user turns it off, e.g. for subscribed holiday calendars
+	SyncDisabled bool `bson:"sync_disabled,omitempty"`
 }
 
 type CalendarAccount struct {
@@ -233,8 +319,10 @@ type CalendarEvent struct {
 	Body            string             `bson:"body,omitempty"`
 	Location        string             `bson:"location,omitempty"`
 	EventType       string             `bson:"event_type,omitempty"`
-	DatetimeEnd     primitive.DateTime `bson:"datetime_end,omitempty"`
-	DatetimeStart   primitive.DateTime `bson:"datetime_start,omitempty"`
+	// one of the EventCategory constants
+	Category      string             `bson:"category,omitempty"`
+	DatetimeEnd   primitive.DateTime `bson:"datetime_end,omitempty"`
+	DatetimeStart primitive.DateTime `bson:"datetime_start,omitempty"`
 	//time in nanoseconds
 	TimeAllocation      int64              `bson:"time_allocated"`
 	CallLogo            string             `bson:"call_logo,omitempty"`
@@ -248,6 +336,11 @@ type CalendarEvent struct {
 	ColorBackground     string             `bson:"color_background,omitempty"`
 	ColorForeground     string             `bson:"color_foreground,omitempty"`
 	AttendeeEmails      []string           `bson:"attendee_emails,omitempty"`
+	// the color of the calendar the event is on, which it's shown in unless it has its own color
+	CalendarColorBackground string `bson:"calendar_color_background,omitempty"`
+	CalendarColorForeground string `bson:"calendar_color_foreground,omitempty"`
+	// set on occurrences of a recurring event to the external ID of the series
+	RecurringEventID string `bson:"recurring_event_id,omitempty"`
 }
 
 type MeetingPreparationParams struct {
@@ -257,6 +350,27 @@ type MeetingPreparationParams struct {
 	DatetimeEnd                   primitive.DateTime `bson:"datetime_end,omitempty"`
 	HasBeenAutomaticallyCompleted bool               `bson:"has_been_automatically_completed,omitempty"`
 	EventMovedOrDeleted           bool               `bson:"event_moved_or_deleted,omitempty"`
+	ColorBackground               string             `bson:"color_background,omitempty"`
+	ColorForeground               string             `bson:"color_foreground,omitempty"`
+}
+
+// MeetingPrepRules decide which events get a meeting preparation task. Users who haven't saved
+// any get the defaults from GetMeetingPrepRules
+type MeetingPrepRules struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	UserID    primitive.ObjectID `bson:"user_id"`
+	IsEnabled bool               `bson:"is_enabled"`
+	// prep tasks are created once the event starts within this many minutes
+	LeadTimeMinutes  int `bson:"lead_time_minutes"`
+	MinimumAttendees int `bson:"minimum_attendees"`
+	// every calendar the user owns is included when empty
+	Calendars []MeetingPrepCalendar `bson:"calendars,omitempty"`
+	UpdatedAt primitive.DateTime    `bson:"updated_at,omitempty"`
+}
+
+type MeetingPrepCalendar struct {
+	AccountID  string `bson:"account_id" json:"account_id"`
+	CalendarID string `bson:"calendar_id" json:"calendar_id"`
 }
 
 type LinearCycle struct {
@@ -270,9 +384,44 @@ type LinearCycle struct {
 	IsNextCycle     bool               `bson:"is_next_cycle,omitempty" json:"is_next_cycle,omitempty"`
 }
 
+type LinearProject struct {
+	ID         string             `bson:"_id,omitempty" json:"id,omitempty"`
+	Name       string             `bson:"name,omitempty" json:"name,omitempty"`
+	State      string             `bson:"state,omitempty" json:"state,omitempty"`
+	TargetDate primitive.DateTime `bson:"target_date,omitempty" json:"target_date,omitempty"`
+}
+
 type JIRATaskParams struct {
 	HasPriorityField *bool `bson:"has_priority_field,omitempty"`
 	HasDueDateField  *bool `bson:"has_due_date_field,omitempty"`
+	// workflow transitions available from the issue's current status, which are usually a subset of AllStatuses
+	Transitions      []JIRATransition `bson:"transitions,omitempty"`
+	Sprint           *JIRASprint      `bson:"sprint,omitempty"`
+	AvailableSprints []JIRASprint     `bson:"available_sprints,omitempty"`
+	Epic             *JIRAEpic        `bson:"epic,omitempty"`
+	// JQL views the issue matched on the last sync. Issues that only matched a view and aren't
+	// assigned to the user are kept out of the regular JIRA view
+	JQLViewIDs      []primitive.ObjectID `bson:"jql_view_ids,omitempty"`
+	IsOnlyInJQLView bool                 `bson:"is_only_in_jql_view,omitempty"`
+}
+
+type JIRATransition struct {
+	ExternalID string `bson:"external_id" json:"external_id"`
+	Name       string `bson:"name" json:"name"`
+	ToStatusID string `bson:"to_status_id" json:"to_status_id"`
+}
+
+type JIRASprint struct {
+	ExternalID int    `bson:"external_id" json:"external_id"`
+	Name       string `bson:"name" json:"name"`
+	State      string `bson:"state" json:"state"`
+	BoardID    int    `bson:"board_id" json:"board_id"`
+}
+
+type JIRAEpic struct {
+	ExternalID int    `bson:"external_id" json:"external_id"`
+	Key        string `bson:"key" json:"key"`
+	Name       string `bson:"name" json:"name"`
 }
 
 // Note that this model is used in the request for Slack, and thus should match
@@ -319,6 +468,21 @@ type Comment struct {
 	Body       string             `bson:"body" json:"body"`
 	User       ExternalUser       `bson:"user" json:"user"`
 	CreatedAt  primitive.DateTime `bson:"created_at" json:"created_at"`
+	// syncing a task from its source replaces its comments, along with their reactions and read receipts
+	Reactions []CommentReaction    `bson:"reactions,omitempty" json:"reactions,omitempty"`
+	ReadBy    []CommentReadReceipt `bson:"read_by,omitempty" json:"read_by,omitempty"`
+}
+
+type CommentReaction struct {
+	Emoji  string             `bson:"emoji" json:"emoji"`
+	UserID primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Name   string             `bson:"name" json:"name"`
+}
+
+type CommentReadReceipt struct {
+	UserID primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Name   string             `bson:"name" json:"name"`
+	ReadAt primitive.DateTime `bson:"read_at" json:"read_at"`
 }
 
 type ExternalTaskStatus struct {
@@ -347,10 +511,38 @@ type UserSetting struct {
 }
 
 type WaitlistEntry struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	Email      string             `bson:"email"`
+	HasAccess  bool               `bson:"has_access"`
+	CreatedAt  primitive.DateTime `bson:"created_at"`
+	ApprovedAt primitive.DateTime `bson:"approved_at,omitempty"`
+	// the admin who approved the entry, unset when it was approved by an invite code
+	ApprovedBy primitive.ObjectID `bson:"approved_by,omitempty"`
+	InviteCode string             `bson:"invite_code,omitempty"`
+	// the user whose referral code was used to get access
+	ReferredBy primitive.ObjectID `bson:"referred_by,omitempty"`
+}
+
+// InviteCode lets whoever has it skip the waitlist, until it has been used MaxUses times. Referral
+// codes are the ones users share themselves, and each user has at most one
+type InviteCode struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	Code       string             `bson:"code"`
+	UserID     primitive.ObjectID `bson:"user_id"`
+	IsReferral bool               `bson:"is_referral"`
+	MaxUses    int                `bson:"max_uses"`
+	Uses       int                `bson:"uses"`
+	CreatedAt  primitive.DateTime `bson:"created_at"`
+}
+
+// MagicLink records a login link sent by email, so each one can only be used once
+type MagicLink struct {
 	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	Nonce     string             `bson:"nonce"`
 	Email     string             `bson:"email"`
-	HasAccess bool               `bson:"has_access"`
 	CreatedAt primitive.DateTime `bson:"created_at"`
+	ExpiresAt primitive.DateTime `bson:"expires_at"`
+	UsedAt    primitive.DateTime `bson:"used_at,omitempty"`
 }
 
 type FeedbackItem struct {
@@ -381,15 +573,20 @@ type ServerRequestInfo struct {
 }
 
 type TaskSection struct {
-	ID         primitive.ObjectID `bson:"_id,omitempty"`
-	IDOrdering int                `bson:"id_ordering"`
-	UserID     primitive.ObjectID `bson:"user_id"`
-	Name       string             `bson:"name"`
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	IDOrdering  int                `bson:"id_ordering"`
+	OrderingKey string             `bson:"ordering_key,omitempty"`
+	UserID      primitive.ObjectID `bson:"user_id"`
+	Name        string             `bson:"name"`
+	IsArchived  bool               `bson:"is_archived,omitempty"`
+	// set for sections synced from a list in another service, like a Google Tasks list
+	SourceID   string `bson:"source_id,omitempty"`
+	IDExternal string `bson:"id_external,omitempty"`
 }
 
 type Pagination struct {
-	Limit *int `form:"limit" json:"limit"`
-	Page  *int `form:"page" json:"page"`
+	Limit  *int    `form:"limit" json:"limit"`
+	Cursor *string `form:"cursor" json:"cursor"`
 }
 
 type Recipients struct {
@@ -407,32 +604,61 @@ type View struct {
 	ID            primitive.ObjectID `bson:"_id,omitempty"`
 	UserID        primitive.ObjectID `bson:"user_id"`
 	IDOrdering    int                `bson:"id_ordering"`
+	OrderingKey   string             `bson:"ordering_key,omitempty"`
 	Type          string             `bson:"type"`
 	IsReorderable bool               `bson:"is_reorderable"`
 	IsLinked      bool               `bson:"is_linked"`
 	GithubID      string             `bson:"github_id"`
 	TaskSectionID primitive.ObjectID `bson:"task_section_id"`
+	// chosen by the user for JIRA JQL and custom views
+	Name string `bson:"name,omitempty"`
+	// used by JIRA JQL views, which are backed by a saved query on one linked account
+	AccountID string `bson:"account_id,omitempty"`
+	JQL       string `bson:"jql,omitempty"`
+	// used by Linear views to narrow down and order issues by cycle
+	LinearCycleFilter string `bson:"linear_cycle_filter,omitempty"`
+	LinearSortBy      string `bson:"linear_sort_by,omitempty"`
+	// used by custom views to select the user's tasks
+	CustomFilter *CustomViewFilter `bson:"custom_filter,omitempty"`
+	// when the external source backing the view was last fetched
+	LastFetched primitive.DateTime `bson:"last_fetched,omitempty"`
+}
+
+// CustomViewFilter selects the open tasks matching all of the criteria which are set
+type CustomViewFilter struct {
+	SourceIDs     []string           `bson:"source_ids,omitempty"`
+	TaskSectionID primitive.ObjectID `bson:"task_section_id,omitempty"`
+	// matches tasks with any of the labels
+	Labels    []string `bson:"labels,omitempty"`
+	DueWindow string   `bson:"due_window,omitempty"`
 }
 
 type Repository struct {
-	ID           primitive.ObjectID `bson:"_id,omitempty"`
-	AccountID    string             `bson:"account_id"`
-	UserID       primitive.ObjectID `bson:"user_id"`
-	FullName     string             `bson:"full_name"`
-	RepositoryID string             `bson:"repository_id"`
-	Deeplink     string             `bson:"deeplink"`
+	ID             primitive.ObjectID `bson:"_id,omitempty"`
+	AccountID      string             `bson:"account_id"`
+	UserID         primitive.ObjectID `bson:"user_id"`
+	FullName       string             `bson:"full_name"`
+	RepositoryID   string             `bson:"repository_id"`
+	Deeplink       string             `bson:"deeplink"`
+	IsInFilterList bool               `bson:"is_in_filter_list"`
 }
 
 type DefaultSectionSettings struct {
 	ID           primitive.ObjectID `bson:"_id,omitempty"`
 	UserID       primitive.ObjectID `bson:"user_id"`
 	NameOverride string             `bson:"name_override"`
+	// new tasks from the source land in the section instead of the task inbox
+	SourceID      string             `bson:"source_id,omitempty"`
+	TaskSectionID primitive.ObjectID `bson:"task_section_id,omitempty"`
 }
 
 type Note struct {
 	ID            primitive.ObjectID `bson:"_id,omitempty"`
 	UserID        primitive.ObjectID `bson:"user_id"`
 	LinkedEventID primitive.ObjectID `bson:"linked_event_id,omitempty"`
+	FolderID      primitive.ObjectID `bson:"folder_id,omitempty"`
+	// set on the user's daily note for the date, formatted as YYYY-MM-DD
+	DailyNoteDate string             `bson:"daily_note_date,omitempty"`
 	Title         *string            `bson:"title,omitempty"`
 	Body          *string            `bson:"body,omitempty"`
 	Author        string             `bson:"author,omitempty"`
@@ -441,6 +667,43 @@ type Note struct {
 	SharedUntil   primitive.DateTime `bson:"shared_until,omitempty"`
 	SharedAccess  *SharedAccess      `bson:"shared_access,omitempty"`
 	IsDeleted     *bool              `bson:"is_deleted,omitempty"`
+	DeletedAt     primitive.DateTime `bson:"deleted_at,omitempty"`
+	IsPinned      *bool              `bson:"is_pinned,omitempty"`
+	IsArchived    *bool              `bson:"is_archived,omitempty"`
+	Slug          string             `bson:"slug,omitempty"`
+	Comments      *[]Comment         `bson:"comments,omitempty"`
+}
+
+type NoteFolder struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	IDOrdering  int                `bson:"id_ordering"`
+	OrderingKey string             `bson:"ordering_key,omitempty"`
+	UserID      primitive.ObjectID `bson:"user_id"`
+	Name        string             `bson:"name"`
+}
+
+// ActionItemSuggestion is a task suggested from a note's content. It stays pending until the user
+// accepts it, which creates the task, or dismisses it
+type ActionItemSuggestion struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	UserID    primitive.ObjectID `bson:"user_id"`
+	NoteID    primitive.ObjectID `bson:"note_id"`
+	Title     string             `bson:"title"`
+	Status    string             `bson:"status"`
+	TaskID    primitive.ObjectID `bson:"task_id,omitempty"`
+	CreatedAt primitive.DateTime `bson:"created_at"`
+}
+
+// LLMUsage counts the tokens used for a user with one provider and model on a day
+type LLMUsage struct {
+	ID               primitive.ObjectID `bson:"_id,omitempty"`
+	UserID           primitive.ObjectID `bson:"user_id"`
+	Provider         string             `bson:"provider"`
+	Model            string             `bson:"model"`
+	Date             string             `bson:"date"`
+	RequestCount     int                `bson:"request_count"`
+	PromptTokens     int                `bson:"prompt_tokens"`
+	CompletionTokens int                `bson:"completion_tokens"`
 }
 
 type DashboardDataPoint struct {
@@ -457,6 +720,8 @@ type DashboardTeam struct {
 	ID        primitive.ObjectID `bson:"_id,omitempty"`
 	UserID    primitive.ObjectID `bson:"user_id,omitempty"`
 	CreatedAt primitive.DateTime `bson:"created_at,omitempty"`
+	// anonymized teams only see team-level aggregates on the dashboard
+	IsAnonymized bool `bson:"is_anonymized,omitempty"`
 }
 
 type DashboardTeamMember struct {
@@ -466,4 +731,144 @@ type DashboardTeamMember struct {
 	GithubID  string             `bson:"github_id,omitempty"`
 	Name      string             `bson:"name,omitempty"`
 	CreatedAt primitive.DateTime `bson:"created_at,omitempty"`
+	// only set for members invited to join the team, members added by name alone are tracked but can't sign in
+	UserID       primitive.ObjectID `bson:"user_id,omitempty"`
+	Role         string             `bson:"role,omitempty"`
+	InviteStatus string             `bson:"invite_status,omitempty"`
+}
+
+type CalendarFeed struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	UserID    primitive.ObjectID `bson:"user_id"`
+	Secret    string             `bson:"secret"`
+	CreatedAt primitive.DateTime `bson:"created_at"`
+}
+
+// AvailabilityLink lets anyone with the secret see when the user is free, without any event details
+type AvailabilityLink struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	UserID    primitive.ObjectID `bson:"user_id"`
+	Secret    string             `bson:"secret"`
+	CreatedAt primitive.DateTime `bson:"created_at"`
+}
+
+// TaskShare lets anyone with the token open the task until the share expires or is revoked. Shares
+// with a password also need the password
+type TaskShare struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty"`
+	UserID         primitive.ObjectID `bson:"user_id"`
+	TaskID         primitive.ObjectID `bson:"task_id"`
+	Token          string             `bson:"token"`
+	PasswordHash   string             `bson:"password_hash,omitempty"`
+	Permission     SharedPermission   `bson:"permission"`
+	ExpiresAt      primitive.DateTime `bson:"expires_at,omitempty"`
+	AccessCount    int                `bson:"access_count"`
+	LastAccessedAt primitive.DateTime `bson:"last_accessed_at,omitempty"`
+	CreatedAt      primitive.DateTime `bson:"created_at"`
+}
+
+// SharePasswordFailure records a wrong password for a task share, so guesses can be limited
+type SharePasswordFailure struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	ShareID   primitive.ObjectID `bson:"share_id"`
+	IPAddress string             `bson:"ip_address"`
+	CreatedAt primitive.DateTime `bson:"created_at"`
+}
+
+// Organization holds the policy admins set for every user with an email address on the domain
+type Organization struct {
+	ID           primitive.ObjectID   `bson:"_id,omitempty"`
+	Domain       string               `bson:"domain"`
+	AdminUserIDs []primitive.ObjectID `bson:"admin_user_ids"`
+	// disallows public links, i.e. public shared_access and task share tokens
+	DisablePublicSharing bool `bson:"disable_public_sharing"`
+	// only allows sharing with users on the domain
+	RequireDomainSharing bool `bson:"require_domain_sharing"`
+	// 0 leaves how long tasks and notes can be shared for uncapped
+	MaxSharedDays int                `bson:"max_shared_days"`
+	CreatedAt     primitive.DateTime `bson:"created_at"`
+	UpdatedAt     primitive.DateTime `bson:"updated_at"`
+}
+
+// ShareView records someone opening a shared task or note
+type ShareView struct {
+	ID primitive.ObjectID `bson:"_id,omitempty"`
+	// the owner of the shared task or note
+	UserID primitive.ObjectID `bson:"user_id"`
+	TaskID primitive.ObjectID `bson:"task_id,omitempty"`
+	NoteID primitive.ObjectID `bson:"note_id,omitempty"`
+	// empty when the viewer isn't logged in
+	ViewerUserID primitive.ObjectID `bson:"viewer_user_id,omitempty"`
+	ViewedAt     primitive.DateTime `bson:"viewed_at"`
+}
+
+type AccountDeletionRecord struct {
+	ID               primitive.ObjectID `bson:"_id,omitempty"`
+	UserID           primitive.ObjectID `bson:"user_id"`
+	DeletedAt        primitive.DateTime `bson:"deleted_at"`
+	DeletedCounts    map[string]int64   `bson:"deleted_counts"`
+	AnonymizedCounts map[string]int64   `bson:"anonymized_counts"`
+	RevokedServices  []string           `bson:"revoked_services"`
+}
+
+// TaskActivity is a user visible history entry for a task, unlike log events which are only for analytics
+type TaskActivity struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty"`
+	UserID primitive.ObjectID `bson:"user_id"`
+	TaskID primitive.ObjectID `bson:"task_id"`
+	Type   string             `bson:"type"`
+	// the value before and after the change, e.g. due dates or the body of a new comment
+	OldValue  string             `bson:"old_value,omitempty"`
+	NewValue  string             `bson:"new_value,omitempty"`
+	CreatedAt primitive.DateTime `bson:"created_at"`
+}
+
+// AuditLog records a security relevant event on the user's account
+type AuditLog struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	UserID    primitive.ObjectID `bson:"user_id"`
+	EventType string             `bson:"event_type"`
+	ServiceID string             `bson:"service_id,omitempty"`
+	AccountID string             `bson:"account_id,omitempty"`
+	// the task or note for shared link events
+	ResourceID primitive.ObjectID `bson:"resource_id,omitempty"`
+	IPAddress  string             `bson:"ip_address,omitempty"`
+	UserAgent  string             `bson:"user_agent,omitempty"`
+	// the support user, for events caused through the admin endpoints
+	ActorID   primitive.ObjectID `bson:"actor_id,omitempty"`
+	CreatedAt primitive.DateTime `bson:"created_at"`
+}
+
+// Notification is queued for delivery to UserID, and marked sent once the notifications job emails it
+type Notification struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty"`
+	UserID       primitive.ObjectID `bson:"user_id"`
+	SenderUserID primitive.ObjectID `bson:"sender_user_id"`
+	Type         string             `bson:"type"`
+	TaskID       primitive.ObjectID `bson:"task_id,omitempty"`
+	NoteID       primitive.ObjectID `bson:"note_id,omitempty"`
+	Body         string             `bson:"body"`
+	IsSent       bool               `bson:"is_sent"`
+	CreatedAt    primitive.DateTime `bson:"created_at"`
+	SentAt       primitive.DateTime `bson:"sent_at,omitempty"`
+}
+
+// ConditionalResponse is the last response for an external GET, replayed when the service
+// reports the resource hasn't changed since
+type ConditionalResponse struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	UserID    primitive.ObjectID `bson:"user_id"`
+	CacheKey  string             `bson:"cache_key"`
+	ETag      string             `bson:"etag"`
+	Body      []byte             `bson:"body"`
+	UpdatedAt primitive.DateTime `bson:"updated_at"`
+}
+
+// Avatar is the profile picture the user uploaded, which takes the place of their Gravatar
+type Avatar struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	UserID      primitive.ObjectID `bson:"user_id"`
+	ContentType string             `bson:"content_type"`
+	Data        []byte             `bson:"data"`
+	UpdatedAt   primitive.DateTime `bson:"updated_at"`
 }
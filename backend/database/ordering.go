@@ -0,0 +1,201 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/franchizzle/task-manager/backend/logging"
+	"github.com/franchizzle/task-manager/backend/ordering"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ReorderableSubmodel struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	IDOrdering  int                `bson:"id_ordering"`
+	OrderingKey string             `bson:"ordering_key,omitempty"`
+}
+
+// the order items are listed in, matching ordering.Less
+var orderingSort = bson.D{{Key: "ordering_key", Value: 1}, {Key: "id_ordering", Value: 1}, {Key: "_id", Value: 1}}
+var reverseOrderingSort = bson.D{{Key: "ordering_key", Value: -1}, {Key: "id_ordering", Value: -1}, {Key: "_id", Value: -1}}
+
+// MoveToPosition moves the item ahead of whichever of its siblings is at the 1-indexed position,
+// counting the item itself if it's already one of them. Siblings are the user's items in the
+// collection matching siblingFilters. Only the moved item is written to, apart from the first move
+// among siblings which have never been ordered
+func MoveToPosition(ctx context.Context, collection *mongo.Collection, userID primitive.ObjectID, itemID primitive.ObjectID, siblingFilters *[]bson.M, position int) error {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	logger := logging.GetSentryLogger()
+	memberFilters := []bson.M{}
+	if siblingFilters != nil {
+		memberFilters = append(memberFilters, *siblingFilters...)
+	}
+	err := setMissingOrderingKeys(ctx, collection, userID, memberFilters)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to set missing ordering keys")
+		return err
+	}
+	filters := append([]bson.M{{"_id": bson.M{"$ne": itemID}}}, memberFilters...)
+
+	if position < 1 {
+		position = 1
+	}
+	itemFilters := append([]bson.M{{"_id": itemID}}, memberFilters...)
+	var items []ReorderableSubmodel
+	err = FindWithCollection(ctx, collection, userID, &itemFilters, &items, nil)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to find item to order")
+		return err
+	}
+	if len(items) > 0 {
+		// the item is already among its siblings, so moving it further down frees up its current place
+		itemsBefore, err := collection.CountDocuments(ctx, bson.M{"$and": append([]bson.M{
+			{"user_id": userID},
+			{"ordering_key": bson.M{"$lt": items[0].OrderingKey}},
+		}, filters...)})
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to count items")
+			return err
+		}
+		if position > int(itemsBefore)+1 {
+			position--
+		}
+	}
+
+	// the siblings on either side of the new position
+	skip := position - 2
+	limit := 2
+	if position == 1 {
+		skip = 0
+		limit = 1
+	}
+	var neighbours []ReorderableSubmodel
+	err = FindWithCollection(ctx, collection, userID, &filters, &neighbours, options.Find().SetSort(orderingSort).SetSkip(int64(skip)).SetLimit(int64(limit)))
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to find neighbouring items")
+		return err
+	}
+	if position > 1 && len(neighbours) == 0 {
+		// the position is past the end, so the item goes after the last sibling
+		err = FindWithCollection(ctx, collection, userID, &filters, &neighbours, options.Find().SetSort(reverseOrderingSort).SetLimit(1))
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to find last item")
+			return err
+		}
+		siblingCount, err := collection.CountDocuments(ctx, bson.M{"$and": append([]bson.M{{"user_id": userID}}, filters...)})
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to count items")
+			return err
+		}
+		position = int(siblingCount) + 1
+	}
+	before, after := "", ""
+	if position == 1 {
+		if len(neighbours) > 0 {
+			after = neighbours[0].OrderingKey
+		}
+	} else {
+		if len(neighbours) > 0 {
+			before = neighbours[0].OrderingKey
+		}
+		if len(neighbours) > 1 {
+			after = neighbours[1].OrderingKey
+		}
+	}
+	orderingKey, err := ordering.KeyBetween(before, after)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to generate ordering key")
+		return err
+	}
+
+	res, err := collection.UpdateOne(
+		ctx,
+		bson.M{"$and": []bson.M{
+			{"_id": itemID},
+			{"user_id": userID},
+		}},
+		bson.M{"$set": bson.M{"ordering_key": orderingKey, "id_ordering": position}},
+	)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update ordering key")
+		return err
+	}
+	if res.MatchedCount != 1 {
+		return errors.New("item to order not found")
+	}
+	return nil
+}
+
+// SetOrderingKeys orders the items as listed, returning how many of them were found
+func SetOrderingKeys(ctx context.Context, collection *mongo.Collection, userID primitive.ObjectID, orderedIDs []primitive.ObjectID) (int64, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	if len(orderedIDs) == 0 {
+		return 0, nil
+	}
+	orderingKeys, err := ordering.KeysBetween("", "", len(orderedIDs))
+	if err != nil {
+		return 0, err
+	}
+	var operations []mongo.WriteModel
+	for idx, itemID := range orderedIDs {
+		operation := mongo.NewUpdateOneModel()
+		operation.SetFilter(bson.M{"$and": []bson.M{
+			{"_id": itemID},
+			{"user_id": userID},
+		}})
+		operation.SetUpdate(bson.M{"$set": bson.M{"ordering_key": orderingKeys[idx], "id_ordering": idx + 1}})
+		operations = append(operations, operation)
+	}
+	result, err := collection.BulkWrite(ctx, operations)
+	if err != nil {
+		logger := logging.GetSentryLogger()
+		logger.Error().Err(err).Msg("failed to set ordering keys")
+		return 0, err
+	}
+	return result.MatchedCount, nil
+}
+
+// setMissingOrderingKeys gives keys to the siblings which don't have one yet. They already sort ahead
+// of the keyed siblings, so they're keyed in place before the first of those
+func setMissingOrderingKeys(ctx context.Context, collection *mongo.Collection, userID primitive.ObjectID, siblingFilters []bson.M) error {
+	unorderedFilters := append([]bson.M{{"ordering_key": bson.M{"$exists": false}}}, siblingFilters...)
+	var unorderedItems []ReorderableSubmodel
+	err := FindWithCollection(ctx, collection, userID, &unorderedFilters, &unorderedItems, options.Find().SetSort(orderingSort))
+	if err != nil || len(unorderedItems) == 0 {
+		return err
+	}
+
+	orderedFilters := append([]bson.M{{"ordering_key": bson.M{"$exists": true}}}, siblingFilters...)
+	var firstOrderedItems []ReorderableSubmodel
+	err = FindWithCollection(ctx, collection, userID, &orderedFilters, &firstOrderedItems, options.Find().SetSort(orderingSort).SetLimit(1))
+	if err != nil {
+		return err
+	}
+	after := ""
+	if len(firstOrderedItems) > 0 {
+		after = firstOrderedItems[0].OrderingKey
+	}
+	orderingKeys, err := ordering.KeysBetween("", after, len(unorderedItems))
+	if err != nil {
+		return err
+	}
+
+	var operations []mongo.WriteModel
+	for idx, item := range unorderedItems {
+		operation := mongo.NewUpdateOneModel()
+		// a concurrent move may have keyed the item already
+		operation.SetFilter(bson.M{"$and": []bson.M{
+			{"_id": item.ID},
+			{"ordering_key": bson.M{"$exists": false}},
+		}})
+		operation.SetUpdate(bson.M{"$set": bson.M{"ordering_key": orderingKeys[idx]}})
+		operations = append(operations, operation)
+	}
+	_, err = collection.BulkWrite(ctx, operations)
+	return err
+}
@@ -0,0 +1,113 @@
+package database
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/cache"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	READ_CACHE_TTL         = 10 * time.Minute
+	READ_CACHE_MAX_ENTRIES = 10000
+)
+
+// collections that cached reads depend on. Every cached key is prefixed with the owning user's ID
+// so a change only invalidates that user's entries
+var readCacheCollections = []string{"users", "task_sections", "views", "external_api_tokens", "calendar_accounts"}
+
+type readCache struct {
+	store      cache.Cache
+	mutex      sync.Mutex
+	isWatching bool
+	// bumped on every invalidation so reads that raced with a change aren't stored
+	generation int
+}
+
+var activeReadCache *readCache
+
+// EnableReadCache caches hot reads in the store, invalidated by a change stream on the collections
+// they're read from. Reads go straight to the database whenever the change stream is down
+func EnableReadCache(db *mongo.Database, store cache.Cache) {
+	activeReadCache = &readCache{store: store}
+	go activeReadCache.watch(db)
+}
+
+func (readCache *readCache) watch(db *mongo.Database) {
+	logger := logging.GetSentryLogger()
+	for {
+		err := WatchChanges(context.Background(), db, readCacheCollections, readCache.handleChange)
+		readCache.invalidate(func() {
+			readCache.isWatching = false
+			readCache.store.Clear()
+		})
+		logger.Error().Err(err).Msg("read cache change stream failed")
+		time.Sleep(CHANGE_WATCH_RETRY_DELAY)
+	}
+}
+
+func (readCache *readCache) handleChange(event ChangeEvent) {
+	readCache.invalidate(func() {
+		switch {
+		case event.OperationType == CHANGE_STREAM_OPENED:
+			readCache.isWatching = true
+			readCache.store.Clear()
+		case event.Collection == "users":
+			readCache.store.DeletePrefix(getReadCacheUserPrefix(event.DocumentID))
+		case event.UserID != primitive.NilObjectID:
+			readCache.store.DeletePrefix(getReadCacheUserPrefix(event.UserID))
+		default:
+			// deletes don't say which user owned the document
+			readCache.store.Clear()
+		}
+	})
+}
+
+func (readCache *readCache) invalidate(update func()) {
+	readCache.mutex.Lock()
+	defer readCache.mutex.Unlock()
+	readCache.generation += 1
+	update()
+}
+
+func getReadCacheUserPrefix(userID primitive.ObjectID) string {
+	return userID.Hex() + ":"
+}
+
+// CachedRead returns the cached value for the user's key, calling load on a miss. Cached values are
+// shared between callers, so anything returned by reference must be copied before it's modified
+func CachedRead[T any](userID primitive.ObjectID, key string, load func() (T, error)) (T, error) {
+	readCache := activeReadCache
+	if readCache == nil {
+		return load()
+	}
+	cacheKey := getReadCacheUserPrefix(userID) + key
+
+	readCache.mutex.Lock()
+	isWatching := readCache.isWatching
+	generation := readCache.generation
+	readCache.mutex.Unlock()
+	if !isWatching {
+		return load()
+	}
+	if value, exists := readCache.store.Get(cacheKey); exists {
+		if typedValue, ok := value.(T); ok {
+			return typedValue, nil
+		}
+	}
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+	readCache.mutex.Lock()
+	defer readCache.mutex.Unlock()
+	if readCache.isWatching && readCache.generation == generation {
+		readCache.store.Set(cacheKey, value)
+	}
+	return value, nil
+}
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/cache"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCachedRead(t *testing.T) {
+	userID := primitive.NewObjectID()
+	otherUserID := primitive.NewObjectID()
+	loadCount := 0
+	load := func() (string, error) {
+		loadCount += 1
+		return "value", nil
+	}
+	testReadCache := &readCache{store: cache.NewMemoryCache(time.Minute, 100)}
+	activeReadCache = testReadCache
+	defer func() { activeReadCache = nil }()
+
+	t.Run("NotWatching", func(t *testing.T) {
+		loadCount = 0
+		_, err := CachedRead(userID, "key", load)
+		assert.NoError(t, err)
+		_, err = CachedRead(userID, "key", load)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, loadCount)
+	})
+	testReadCache.handleChange(ChangeEvent{OperationType: CHANGE_STREAM_OPENED})
+	t.Run("Cached", func(t *testing.T) {
+		loadCount = 0
+		value, err := CachedRead(userID, "key", load)
+		assert.NoError(t, err)
+		assert.Equal(t, "value", value)
+		value, err = CachedRead(userID, "key", load)
+		assert.NoError(t, err)
+		assert.Equal(t, "value", value)
+		assert.Equal(t, 1, loadCount)
+	})
+	t.Run("LoadError", func(t *testing.T) {
+		_, err := CachedRead(userID, "error", func() (string, error) { return "", errors.New("failed") })
+		assert.EqualError(t, err, "failed")
+		_, exists := testReadCache.store.Get(getReadCacheUserPrefix(userID) + "error")
+		assert.False(t, exists)
+	})
+	t.Run("InvalidatedByUserChange", func(t *testing.T) {
+		loadCount = 0
+		CachedRead(userID, "key", load)
+		CachedRead(otherUserID, "key", load)
+		testReadCache.handleChange(ChangeEvent{Collection: "task_sections", OperationType: "update", UserID: userID})
+		CachedRead(userID, "key", load)
+		CachedRead(otherUserID, "key", load)
+		assert.Equal(t, 3, loadCount)
+	})
+	t.Run("InvalidatedByUserDocument", func(t *testing.T) {
+		loadCount = 0
+		CachedRead(userID, "key", load)
+		testReadCache.handleChange(ChangeEvent{Collection: "users", OperationType: "update", DocumentID: userID, UserID: primitive.NewObjectID()})
+		CachedRead(userID, "key", load)
+		assert.Equal(t, 2, loadCount)
+	})
+	t.Run("DeleteClearsEverything", func(t *testing.T) {
+		loadCount = 0
+		CachedRead(userID, "key", load)
+		CachedRead(otherUserID, "key", load)
+		testReadCache.handleChange(ChangeEvent{Collection: "views", OperationType: "delete", DocumentID: primitive.NewObjectID()})
+		CachedRead(userID, "key", load)
+		CachedRead(otherUserID, "key", load)
+		assert.Equal(t, 4, loadCount)
+	})
+	t.Run("ChangeDuringLoadIsNotCached", func(t *testing.T) {
+		loadCount = 0
+		CachedRead(userID, "racing", func() (string, error) {
+			testReadCache.handleChange(ChangeEvent{Collection: "users", OperationType: "update", DocumentID: userID})
+			return "stale", nil
+		})
+		value, _ := CachedRead(userID, "racing", load)
+		assert.Equal(t, "value", value)
+		assert.Equal(t, 1, loadCount)
+	})
+}
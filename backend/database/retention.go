@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type RetentionPolicy struct {
+	Collection string
+	Retention  time.Duration
+	// TTLField is the date field a TTL index expires documents on. Collections without one are purged
+	// by the retention job instead, using the creation time embedded in _id
+	TTLField string
+}
+
+// RetentionPolicies covers collections which would otherwise grow forever
+var RetentionPolicies = []RetentionPolicy{
+	{Collection: "log_events", Retention: constants.LOG_EVENT_RETENTION_DAYS * 24 * time.Hour, TTLField: "created_at"},
+	// state tokens are deleted once used, so anything left over is from an abandoned oauth flow
+	{Collection: "state_tokens", Retention: constants.OAUTH_FLOW_RETENTION_HOURS * time.Hour},
+	{Collection: "oauth1_request_secrets", Retention: constants.OAUTH_FLOW_RETENTION_HOURS * time.Hour},
+	// failures only matter while they hold off further guesses
+	{Collection: "share_password_failures", Retention: time.Duration(constants.SHARE_PASSWORD_FAILURE_INTERVAL) * time.Second, TTLField: "created_at"},
+	// links expire after MAGIC_LINK_DURATION, but are kept for the rate limit window since they're counted against it
+	{Collection: "magic_links", Retention: time.Duration(constants.MAGIC_LINK_RATE_INTERVAL) * time.Second, TTLField: "created_at"},
+}
+
+func getTTLIndexDefinitions() []IndexDefinition {
+	definitions := []IndexDefinition{}
+	for _, policy := range RetentionPolicies {
+		if policy.TTLField == "" {
+			continue
+		}
+		definitions = append(definitions, IndexDefinition{
+			Collection:  policy.Collection,
+			Keys:        bson.D{{Key: policy.TTLField, Value: 1}},
+			ExpireAfter: policy.Retention,
+		})
+	}
+	return definitions
+}
+
+// PurgeExpiredDocuments deletes documents in the collection created before the cutoff
+func PurgeExpiredDocuments(ctx context.Context, db *mongo.Database, collection string, cutoff time.Time) (int64, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+	result, err := db.Collection(collection).DeleteMany(
+		ctx,
+		bson.M{"_id": bson.M{"$lt": primitive.NewObjectIDFromTimestamp(cutoff)}},
+	)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msgf("failed to purge expired documents from collection: %s", collection)
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
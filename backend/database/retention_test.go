@@ -0,0 +1,44 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestPurgeExpiredDocuments(t *testing.T) {
+	db, dbCleanup, err := GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	userID := primitive.NewObjectID()
+	cutoff := time.Now().Add(-24 * time.Hour)
+	expiredID := primitive.NewObjectIDFromTimestamp(cutoff.Add(-time.Hour))
+	activeID := primitive.NewObjectIDFromTimestamp(cutoff.Add(time.Hour))
+	_, err = GetStateTokenCollection(db).InsertMany(context.Background(), []interface{}{
+		StateToken{Token: expiredID, UserID: userID},
+		StateToken{Token: activeID, UserID: userID},
+	})
+	assert.NoError(t, err)
+
+	purgedCount, err := PurgeExpiredDocuments(context.Background(), db, "state_tokens", cutoff)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, purgedCount, int64(1))
+
+	var stateTokens []StateToken
+	err = FindWithCollection(context.Background(), GetStateTokenCollection(db), userID, &[]bson.M{}, &stateTokens, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(stateTokens))
+	assert.Equal(t, activeID, stateTokens[0].Token)
+}
+
+func TestGetTTLIndexDefinitions(t *testing.T) {
+	definitions := getTTLIndexDefinitions()
+	assert.Equal(t, 1, len(definitions))
+	assert.Equal(t, "log_events", definitions[0].Collection)
+	assert.Equal(t, bson.D{{Key: "created_at", Value: 1}}, definitions[0].Keys)
+	assert.Equal(t, 180*24*time.Hour, definitions[0].ExpireAfter)
+}
@@ -0,0 +1,273 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/oauth2"
+)
+
+const (
+	AppleCalDAVURL  = "https://caldav.icloud.com/"
+	CalDAVDepthZero = "0"
+	CalDAVDepthOne  = "1"
+)
+
+// ErrInvalidAppleCredentials is returned when iCloud rejects the Apple ID and app-specific password
+var ErrInvalidAppleCredentials = errors.New("invalid apple id or app-specific password")
+
+type AppleConfigValues struct {
+	// CalDAVURL replaces https://caldav.icloud.com/ for discovering the user's calendars
+	CalDAVURL *string
+}
+
+type AppleConfig struct {
+	ConfigValues AppleConfigValues
+}
+
+// AppleService links iCloud accounts with an app-specific password, since Apple doesn't offer oauth for iCloud data
+type AppleService struct {
+	Config AppleConfig
+}
+
+type calDAVMultistatus struct {
+	Responses []calDAVResponse `xml:"DAV: response"`
+}
+
+type calDAVResponse struct {
+	Href      string           `xml:"DAV: href"`
+	Propstats []calDAVPropstat `xml:"DAV: propstat"`
+}
+
+type calDAVPropstat struct {
+	Prop   calDAVProp `xml:"DAV: prop"`
+	Status string     `xml:"DAV: status"`
+}
+
+type calDAVProp struct {
+	CurrentUserPrincipal string `xml:"DAV: current-user-principal>href"`
+	// the namespace of a path applies to its last element, which is a DAV: href here
+	CalendarHomeSet struct {
+		Href string `xml:"DAV: href"`
+	} `xml:"urn:ietf:params:xml:ns:caldav calendar-home-set"`
+	DisplayName  string `xml:"DAV: displayname"`
+	ResourceType struct {
+		Calendar *struct{} `xml:"urn:ietf:params:xml:ns:caldav calendar"`
+	} `xml:"DAV: resourcetype"`
+	SupportedComponents []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"urn:ietf:params:xml:ns:caldav supported-calendar-component-set>comp"`
+	ETag         string `xml:"DAV: getetag"`
+	CalendarData string `xml:"urn:ietf:params:xml:ns:caldav calendar-data"`
+}
+
+// AppleReminderList is a calendar collection which holds reminders
+type AppleReminderList struct {
+	URL  string
+	Name string
+}
+
+func (apple AppleService) GetLinkURL(stateTokenID primitive.ObjectID, userID primitive.ObjectID) (*string, error) {
+	return nil, errors.New("apple accounts are linked with an app-specific password")
+}
+
+func (apple AppleService) GetSignupURL(stateTokenID primitive.ObjectID, forcePrompt bool) (*string, error) {
+	return nil, errors.New("apple does not support signup")
+}
+
+func (apple AppleService) HandleLinkCallback(db *mongo.Database, params CallbackParams, userID primitive.ObjectID) error {
+	return errors.New("apple accounts are linked with an app-specific password")
+}
+
+func (apple AppleService) HandleSignupCallback(ctx context.Context, db *mongo.Database, params CallbackParams) (primitive.ObjectID, *bool, *string, error) {
+	return primitive.NilObjectID, nil, nil, errors.New("apple does not support signup")
+}
+
+// LinkAppSpecificPassword checks the credentials against iCloud and stores them as a basic auth token,
+// so they are used the same way as an oauth token from then on
+func (apple AppleService) LinkAppSpecificPassword(db *mongo.Database, userID primitive.ObjectID, appleID string, appSpecificPassword string) error {
+	token := &oauth2.Token{
+		AccessToken: base64.StdEncoding.EncodeToString([]byte(appleID + ":" + appSpecificPassword)),
+		TokenType:   "basic",
+	}
+	extCtx, cancel := context.WithTimeout(context.Background(), constants.ExternalTimeout)
+	defer cancel()
+	client := oauth2.NewClient(extCtx, oauth2.StaticTokenSource(token))
+	principalURL, err := getCalDAVPrincipalURL(client, apple.getCalDAVURL())
+	if err != nil {
+		return ErrInvalidAppleCredentials
+	}
+
+	tokenString, err := json.Marshal(&token)
+	if err != nil {
+		return err
+	}
+	dbCtx, cancel := context.WithTimeout(context.Background(), constants.DatabaseTimeout)
+	defer cancel()
+	_, err = database.GetExternalTokenCollection(db).UpdateOne(
+		dbCtx,
+		bson.M{"$and": []bson.M{{"user_id": userID}, {"service_id": TASK_SERVICE_ID_APPLE}, {"account_id": appleID}}},
+		bson.M{"$set": &database.ExternalAPIToken{
+			UserID:         userID,
+			ServiceID:      TASK_SERVICE_ID_APPLE,
+			Token:          string(tokenString),
+			AccountID:      appleID,
+			DisplayID:      appleID,
+			ExternalID:     principalURL,
+			IsUnlinkable:   true,
+			IsPrimaryLogin: false,
+		}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+func (apple AppleService) getCalDAVURL() string {
+	if apple.Config.ConfigValues.CalDAVURL != nil {
+		return *apple.Config.ConfigValues.CalDAVURL
+	}
+	return AppleCalDAVURL
+}
+
+// getAppleReminderLists returns the user's calendars which hold reminders, which iCloud keeps apart from
+// the calendars holding events
+func (apple AppleService) getAppleReminderLists(client *http.Client) ([]AppleReminderList, error) {
+	principalURL, err := getCalDAVPrincipalURL(client, apple.getCalDAVURL())
+	if err != nil {
+		return nil, err
+	}
+	homeSet, err := requestCalDAV(client, "PROPFIND", principalURL, CalDAVDepthZero,
+		`<d:propfind xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav"><d:prop><c:calendar-home-set/></d:prop></d:propfind>`)
+	if err != nil {
+		return nil, err
+	}
+	homeURL := ""
+	for _, response := range homeSet.Responses {
+		for _, propstat := range response.Propstats {
+			if propstat.Prop.CalendarHomeSet.Href != "" {
+				homeURL = propstat.Prop.CalendarHomeSet.Href
+			}
+		}
+	}
+	if homeURL == "" {
+		return nil, errors.New("calendar home not found")
+	}
+	homeURL, err = resolveCalDAVHref(principalURL, homeURL)
+	if err != nil {
+		return nil, err
+	}
+
+	calendars, err := requestCalDAV(client, "PROPFIND", homeURL, CalDAVDepthOne,
+		`<d:propfind xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav"><d:prop><d:resourcetype/><d:displayname/><c:supported-calendar-component-set/></d:prop></d:propfind>`)
+	if err != nil {
+		return nil, err
+	}
+	lists := []AppleReminderList{}
+	for _, response := range calendars.Responses {
+		for _, propstat := range response.Propstats {
+			if propstat.Prop.ResourceType.Calendar == nil || !supportsCalDAVComponent(propstat.Prop, "VTODO") {
+				continue
+			}
+			listURL, err := resolveCalDAVHref(homeURL, response.Href)
+			if err != nil {
+				return nil, err
+			}
+			lists = append(lists, AppleReminderList{URL: listURL, Name: propstat.Prop.DisplayName})
+		}
+	}
+	return lists, nil
+}
+
+func getCalDAVPrincipalURL(client *http.Client, calDAVURL string) (string, error) {
+	multistatus, err := requestCalDAV(client, "PROPFIND", calDAVURL, CalDAVDepthZero,
+		`<d:propfind xmlns:d="DAV:"><d:prop><d:current-user-principal/></d:prop></d:propfind>`)
+	if err != nil {
+		return "", err
+	}
+	for _, response := range multistatus.Responses {
+		for _, propstat := range response.Propstats {
+			if propstat.Prop.CurrentUserPrincipal != "" {
+				return resolveCalDAVHref(calDAVURL, propstat.Prop.CurrentUserPrincipal)
+			}
+		}
+	}
+	return "", errors.New("current user principal not found")
+}
+
+func supportsCalDAVComponent(prop calDAVProp, component string) bool {
+	for _, supportedComponent := range prop.SupportedComponents {
+		if supportedComponent.Name == component {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveCalDAVHref makes an href absolute, since iCloud returns paths for some hrefs and URLs on
+// another host for others
+func resolveCalDAVHref(requestURL string, href string) (string, error) {
+	base, err := url.Parse(requestURL)
+	if err != nil {
+		return "", err
+	}
+	reference, err := url.Parse(href)
+	if err != nil {
+		return "", err
+	}
+	return base.ResolveReference(reference).String(), nil
+}
+
+func requestCalDAV(client *http.Client, method string, requestURL string, depth string, body string) (*calDAVMultistatus, error) {
+	request, err := http.NewRequest(method, requestURL, bytes.NewBuffer([]byte(body)))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/xml; charset=utf-8")
+	request.Header.Set("Depth", depth)
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusMultiStatus {
+		logging.GetSentryLogger().Error().Str("responseBody", string(responseBody)).Msg("bad caldav response")
+		return nil, fmt.Errorf("bad status code: %d", response.StatusCode)
+	}
+	var multistatus calDAVMultistatus
+	err = xml.Unmarshal(responseBody, &multistatus)
+	if err != nil {
+		return nil, err
+	}
+	return &multistatus, nil
+}
+
+func getAppleHttpClient(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string) *http.Client {
+	externalToken, err := getExternalToken(ctx, db, userID, accountID, TASK_SERVICE_ID_APPLE)
+	if err != nil {
+		return nil
+	}
+	token, err := extractOauthToken(*externalToken)
+	if err != nil {
+		return nil
+	}
+	return oauth2.NewClient(ctx, oauth2.StaticTokenSource(&token))
+}
@@ -0,0 +1,316 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type AppleRemindersSource struct {
+	Apple AppleService
+}
+
+const (
+	VTodoStatusCompleted   = "COMPLETED"
+	VTodoStatusNeedsAction = "NEEDS-ACTION"
+	VTodoDateTimeFormat    = "20060102T150405Z"
+	VTodoDateFormat        = "20060102"
+)
+
+// incomplete reminders only, so reminders completed on the phone are completed here on the next refresh
+const appleRemindersQuery = `<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">` +
+	`<d:prop><d:getetag/><c:calendar-data/></d:prop>` +
+	`<c:filter><c:comp-filter name="VCALENDAR"><c:comp-filter name="VTODO">` +
+	`<c:prop-filter name="COMPLETED"><c:is-not-defined/></c:prop-filter>` +
+	`</c:comp-filter></c:comp-filter></c:filter>` +
+	`</c:calendar-query>`
+
+// AppleReminder holds the fields of a VTODO which are synced
+type AppleReminder struct {
+	UID         string
+	Summary     string
+	Description string
+	Due         string
+	Status      string
+}
+
+func (appleReminders AppleRemindersSource) GetEvents(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, startTime time.Time, endTime time.Time, scopes []string, result chan<- CalendarResult) {
+	result <- emptyCalendarResult(errors.New("apple reminders cannot fetch events"))
+}
+
+// GetTasks syncs the open reminders of each of the account's lists, with a section for each list
+func (appleReminders AppleRemindersSource) GetTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- TaskResult) {
+	client := getAppleHttpClient(ctx, db, userID, accountID)
+	if client == nil {
+		result <- emptyTaskResultWithSource(errors.New("failed to fetch apple token"), TASK_SOURCE_ID_APPLE_REMINDERS)
+		return
+	}
+	logger := logging.GetSentryLogger()
+	lists, err := appleReminders.Apple.getAppleReminderLists(client)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch apple reminder lists")
+		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_APPLE_REMINDERS)
+		return
+	}
+
+	var tasks []*database.Task
+	for _, list := range lists {
+		sectionID, err := database.GetOrCreateExternalTaskSection(ctx, db, userID, TASK_SOURCE_ID_APPLE_REMINDERS, list.URL, list.Name)
+		if err != nil {
+			result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_APPLE_REMINDERS)
+			return
+		}
+		multistatus, err := requestCalDAV(client, "REPORT", list.URL, CalDAVDepthOne, appleRemindersQuery)
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to fetch apple reminders")
+			result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_APPLE_REMINDERS)
+			return
+		}
+		for _, response := range multistatus.Responses {
+			for _, propstat := range response.Propstats {
+				if propstat.Prop.CalendarData == "" {
+					continue
+				}
+				reminder := parseAppleReminder(propstat.Prop.CalendarData)
+				if reminder == nil || reminder.Status == VTodoStatusCompleted {
+					continue
+				}
+				reminderURL, err := resolveCalDAVHref(list.URL, response.Href)
+				if err != nil {
+					result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_APPLE_REMINDERS)
+					return
+				}
+				task := getAppleRemindersTask(reminder, reminderURL, accountID)
+				task.UserID = userID
+				task.IDTaskSection = sectionID
+				isCompleted := false
+				dbTask, err := database.UpdateOrCreateTask(
+					ctx,
+					db,
+					userID,
+					task.IDExternal,
+					task.SourceID,
+					task,
+					database.Task{
+						Title:       task.Title,
+						Body:        task.Body,
+						DueDate:     task.DueDate,
+						IsCompleted: &isCompleted,
+					},
+					nil,
+				)
+				if err != nil {
+					result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_APPLE_REMINDERS)
+					return
+				}
+				task.HasBeenReordered = dbTask.HasBeenReordered
+				task.ID = dbTask.ID
+				task.IDOrdering = dbTask.IDOrdering
+				task.IDTaskSection = dbTask.IDTaskSection
+				task.TimeAllocation = dbTask.TimeAllocation
+				tasks = append(tasks, task)
+			}
+		}
+	}
+
+	result <- TaskResult{
+		Tasks: tasks,
+	}
+}
+
+// getAppleRemindersTask maps a reminder to a task. Writing back needs the reminder's resource, so its
+// URL is the external ID
+func getAppleRemindersTask(reminder *AppleReminder, reminderURL string, accountID string) *database.Task {
+	title := reminder.Summary
+	body := reminder.Description
+	task := &database.Task{
+		IDExternal:      reminderURL,
+		Deeplink:        "x-apple-reminderkit://REMCDReminder/" + reminder.UID,
+		SourceID:        TASK_SOURCE_ID_APPLE_REMINDERS,
+		Title:           &title,
+		Body:            &body,
+		SourceAccountID: accountID,
+	}
+	// only the date is kept, like the due dates of every other source
+	if len(reminder.Due) >= len(VTodoDateFormat) {
+		if dueDate, err := time.Parse(VTodoDateFormat, reminder.Due[:len(VTodoDateFormat)]); err == nil {
+			dueDatePrim := primitive.NewDateTimeFromTime(dueDate)
+			task.DueDate = &dueDatePrim
+		}
+	}
+	return task
+}
+
+// parseAppleReminder reads the first VTODO of the calendar data, or returns nil if there isn't one
+func parseAppleReminder(calendarData string) *AppleReminder {
+	var reminder *AppleReminder
+	for _, line := range unfoldVCalendarLines(calendarData) {
+		if line == "BEGIN:VTODO" && reminder == nil {
+			reminder = &AppleReminder{}
+			continue
+		}
+		if reminder == nil {
+			continue
+		}
+		if line == "END:VTODO" {
+			break
+		}
+		name, value, found := splitVCalendarProperty(line)
+		if !found {
+			continue
+		}
+		switch name {
+		case "UID":
+			reminder.UID = value
+		case "SUMMARY":
+			reminder.Summary = unescapeVCalendarText(value)
+		case "DESCRIPTION":
+			reminder.Description = unescapeVCalendarText(value)
+		case "DUE":
+			reminder.Due = value
+		case "STATUS":
+			reminder.Status = value
+		}
+	}
+	return reminder
+}
+
+// unfoldVCalendarLines joins the lines which were folded per RFC 5545 section 3.1
+func unfoldVCalendarLines(calendarData string) []string {
+	calendarData = strings.ReplaceAll(calendarData, "\r\n", "\n")
+	calendarData = strings.ReplaceAll(calendarData, "\n ", "")
+	calendarData = strings.ReplaceAll(calendarData, "\n\t", "")
+	lines := []string{}
+	for _, line := range strings.Split(calendarData, "\n") {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// splitVCalendarProperty returns the name of the property without its parameters, and its value
+func splitVCalendarProperty(line string) (string, string, bool) {
+	nameAndParams, value, found := strings.Cut(line, ":")
+	if !found {
+		return "", "", false
+	}
+	name, _, _ := strings.Cut(nameAndParams, ";")
+	return strings.ToUpper(name), value, true
+}
+
+func unescapeVCalendarText(text string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(text)
+}
+
+func (appleReminders AppleRemindersSource) GetPullRequests(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- PullRequestResult) {
+	result <- emptyPullRequestResult(nil, false)
+}
+
+// ModifyTask writes completion back to the reminder. Other changes stay on the task
+func (appleReminders AppleRemindersSource) ModifyTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, issueID string, updateFields *database.Task, task *database.Task) error {
+	if updateFields.IsCompleted == nil {
+		return nil
+	}
+	client := getAppleHttpClient(ctx, db, userID, accountID)
+	if client == nil {
+		return errors.New("failed to fetch apple token")
+	}
+	return updateAppleReminderCompletion(client, issueID, *updateFields.IsCompleted, time.Now())
+}
+
+// updateAppleReminderCompletion rewrites the reminder's status. The write is conditional on the reminder
+// being unchanged since it was read, so edits made on the phone in between aren't lost
+func updateAppleReminderCompletion(client *http.Client, reminderURL string, isCompleted bool, now time.Time) error {
+	getResponse, err := client.Get(reminderURL)
+	if err != nil {
+		return err
+	}
+	defer getResponse.Body.Close()
+	calendarData, err := io.ReadAll(getResponse.Body)
+	if err != nil {
+		return err
+	}
+	if getResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("bad status code: %d", getResponse.StatusCode)
+	}
+
+	updatedCalendarData := setVTodoCompletion(string(calendarData), isCompleted, now)
+	request, err := http.NewRequest("PUT", reminderURL, bytes.NewBuffer([]byte(updatedCalendarData)))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "text/calendar; charset=utf-8")
+	if etag := getResponse.Header.Get("ETag"); etag != "" {
+		request.Header.Set("If-Match", etag)
+	}
+	putResponse, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer putResponse.Body.Close()
+	if putResponse.StatusCode != http.StatusNoContent && putResponse.StatusCode != http.StatusCreated && putResponse.StatusCode != http.StatusOK {
+		logging.GetSentryLogger().Error().Int("statusCode", putResponse.StatusCode).Msg("failed to update apple reminder")
+		return fmt.Errorf("bad status code: %d", putResponse.StatusCode)
+	}
+	return nil
+}
+
+// setVTodoCompletion replaces the completion properties of the calendar data's VTODO
+func setVTodoCompletion(calendarData string, isCompleted bool, now time.Time) string {
+	timestamp := now.UTC().Format(VTodoDateTimeFormat)
+	lines := []string{}
+	isInVTodo := false
+	for _, line := range unfoldVCalendarLines(calendarData) {
+		if line == "BEGIN:VTODO" {
+			isInVTodo = true
+		}
+		if isInVTodo && line == "END:VTODO" {
+			isInVTodo = false
+			if isCompleted {
+				lines = append(lines, "STATUS:"+VTodoStatusCompleted, "COMPLETED:"+timestamp, "PERCENT-COMPLETE:100")
+			} else {
+				lines = append(lines, "STATUS:"+VTodoStatusNeedsAction)
+			}
+			lines = append(lines, "LAST-MODIFIED:"+timestamp)
+		}
+		if isInVTodo {
+			name, _, _ := splitVCalendarProperty(line)
+			if name == "STATUS" || name == "COMPLETED" || name == "PERCENT-COMPLETE" || name == "LAST-MODIFIED" {
+				continue
+			}
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\r\n") + "\r\n"
+}
+
+func (appleReminders AppleRemindersSource) CreateNewTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, task TaskCreationObject) (primitive.ObjectID, error) {
+	return primitive.NilObjectID, errors.New("has not been implemented yet")
+}
+
+func (appleReminders AppleRemindersSource) CreateNewEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error {
+	return errors.New("has not been implemented yet")
+}
+
+func (appleReminders AppleRemindersSource) ModifyEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, eventID string, updateFields *EventModifyObject) error {
+	return errors.New("has not been implemented yet")
+}
+
+func (appleReminders AppleRemindersSource) DeleteEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error {
+	return errors.New("has not been implemented yet")
+}
+
+func (appleReminders AppleRemindersSource) AddComment(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error {
+	return errors.New("has not been implemented yet")
+}
@@ -0,0 +1,146 @@
+package external
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const testAppleReminderData = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VTODO\r\n" +
+	"UID:reminder1\r\n" +
+	"SUMMARY:Buy milk\\, eggs\r\n" +
+	"DESCRIPTION:the oat one\\nand the\r\n  free range ones\r\n" +
+	"DUE;TZID=America/Los_Angeles:20230308T170000\r\n" +
+	"STATUS:NEEDS-ACTION\r\n" +
+	"LAST-MODIFIED:20230301T000000Z\r\n" +
+	"END:VTODO\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseAppleReminder(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		reminder := parseAppleReminder(testAppleReminderData)
+		assert.Equal(t, &AppleReminder{
+			UID:         "reminder1",
+			Summary:     "Buy milk, eggs",
+			Description: "the oat one\nand the free range ones",
+			Due:         "20230308T170000",
+			Status:      VTodoStatusNeedsAction,
+		}, reminder)
+	})
+	t.Run("NoVTodo", func(t *testing.T) {
+		assert.Nil(t, parseAppleReminder("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"))
+	})
+}
+
+func TestGetAppleRemindersTask(t *testing.T) {
+	t.Run("DueDate", func(t *testing.T) {
+		task := getAppleRemindersTask(parseAppleReminder(testAppleReminderData), "https://caldav.example.com/list1/reminder1.ics", "me@icloud.com")
+		assert.Equal(t, "https://caldav.example.com/list1/reminder1.ics", task.IDExternal)
+		assert.Equal(t, TASK_SOURCE_ID_APPLE_REMINDERS, task.SourceID)
+		assert.Equal(t, "x-apple-reminderkit://REMCDReminder/reminder1", task.Deeplink)
+		assert.Equal(t, "Buy milk, eggs", *task.Title)
+		assert.Equal(t, "me@icloud.com", task.SourceAccountID)
+		assert.Equal(t, primitive.NewDateTimeFromTime(time.Date(2023, time.March, 8, 0, 0, 0, 0, time.UTC)), *task.DueDate)
+	})
+	t.Run("NoDueDate", func(t *testing.T) {
+		task := getAppleRemindersTask(&AppleReminder{UID: "reminder1"}, "https://caldav.example.com/list1/reminder1.ics", "me@icloud.com")
+		assert.Nil(t, task.DueDate)
+	})
+}
+
+func TestSetVTodoCompletion(t *testing.T) {
+	now := time.Date(2023, time.March, 9, 12, 0, 0, 0, time.UTC)
+	t.Run("Complete", func(t *testing.T) {
+		calendarData := setVTodoCompletion(testAppleReminderData, true, now)
+		assert.Contains(t, calendarData, "STATUS:COMPLETED\r\nCOMPLETED:20230309T120000Z\r\nPERCENT-COMPLETE:100\r\nLAST-MODIFIED:20230309T120000Z\r\nEND:VTODO\r\n")
+		assert.NotContains(t, calendarData, "NEEDS-ACTION")
+		assert.NotContains(t, calendarData, "20230301T000000Z")
+		assert.Equal(t, VTodoStatusCompleted, parseAppleReminder(calendarData).Status)
+	})
+	t.Run("Reopen", func(t *testing.T) {
+		completedData := setVTodoCompletion(testAppleReminderData, true, now)
+		calendarData := setVTodoCompletion(completedData, false, now)
+		assert.NotContains(t, calendarData, "COMPLETED:")
+		assert.NotContains(t, calendarData, "PERCENT-COMPLETE")
+		assert.Equal(t, VTodoStatusNeedsAction, parseAppleReminder(calendarData).Status)
+	})
+}
+
+func TestUpdateAppleReminderCompletion(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/list1/reminder1.ics", r.URL.Path)
+			if r.Method == "GET" {
+				w.Header().Set("ETag", `"etag1"`)
+				w.Write([]byte(testAppleReminderData))
+				return
+			}
+			assert.Equal(t, "PUT", r.Method)
+			assert.Equal(t, `"etag1"`, r.Header.Get("If-Match"))
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, VTodoStatusCompleted, parseAppleReminder(string(body)).Status)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		err := updateAppleReminderCompletion(http.DefaultClient, server.URL+"/list1/reminder1.ics", true, time.Now())
+		assert.NoError(t, err)
+	})
+	t.Run("ChangedSinceRead", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "GET" {
+				w.Header().Set("ETag", `"etag1"`)
+				w.Write([]byte(testAppleReminderData))
+				return
+			}
+			w.WriteHeader(http.StatusPreconditionFailed)
+		}))
+		defer server.Close()
+		err := updateAppleReminderCompletion(http.DefaultClient, server.URL+"/list1/reminder1.ics", true, time.Now())
+		assert.EqualError(t, err, "bad status code: 412")
+	})
+}
+
+func TestGetAppleReminderLists(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PROPFIND", r.Method)
+		w.WriteHeader(http.StatusMultiStatus)
+		switch r.URL.Path {
+		case "/":
+			assert.Equal(t, CalDAVDepthZero, r.Header.Get("Depth"))
+			w.Write([]byte(`<multistatus xmlns="DAV:"><response><href>/</href><propstat><prop>` +
+				`<current-user-principal><href>/123/principal/</href></current-user-principal>` +
+				`</prop><status>HTTP/1.1 200 OK</status></propstat></response></multistatus>`))
+		case "/123/principal/":
+			w.Write([]byte(`<multistatus xmlns="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav"><response><href>/123/principal/</href><propstat><prop>` +
+				`<c:calendar-home-set><href>/123/calendars/</href></c:calendar-home-set>` +
+				`</prop><status>HTTP/1.1 200 OK</status></propstat></response></multistatus>`))
+		case "/123/calendars/":
+			assert.Equal(t, CalDAVDepthOne, r.Header.Get("Depth"))
+			w.Write([]byte(`<multistatus xmlns="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">` +
+				`<response><href>/123/calendars/</href><propstat><prop><resourcetype><collection/></resourcetype></prop></propstat></response>` +
+				`<response><href>/123/calendars/work/</href><propstat><prop><displayname>Work</displayname>` +
+				`<resourcetype><collection/><c:calendar/></resourcetype>` +
+				`<c:supported-calendar-component-set><c:comp name="VEVENT"/></c:supported-calendar-component-set></prop></propstat></response>` +
+				`<response><href>/123/calendars/groceries/</href><propstat><prop><displayname>Groceries</displayname>` +
+				`<resourcetype><collection/><c:calendar/></resourcetype>` +
+				`<c:supported-calendar-component-set><c:comp name="VTODO"/></c:supported-calendar-component-set></prop></propstat></response>` +
+				`</multistatus>`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	calDAVURL := server.URL + "/"
+	apple := AppleService{Config: AppleConfig{ConfigValues: AppleConfigValues{CalDAVURL: &calDAVURL}}}
+	lists, err := apple.getAppleReminderLists(http.DefaultClient)
+	assert.NoError(t, err)
+	assert.Equal(t, []AppleReminderList{{URL: server.URL + "/123/calendars/groceries/", Name: "Groceries"}}, lists)
+}
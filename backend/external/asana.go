@@ -30,9 +30,9 @@ type AsanaConfigValues struct {
 
 func getAsanaConfig() *OauthConfig {
 	return &OauthConfig{Config: &oauth2.Config{
-		ClientID:     config.GetConfigValue("ASANA_OAUTH_CLIENT_ID"),
-		ClientSecret: config.GetConfigValue("ASANA_OAUTH_CLIENT_SECRET"),
-		RedirectURL:  config.GetConfigValue("SERVER_URL") + "link/asana/callback/",
+		ClientID:     config.GetSettings().AsanaOauth.ClientID,
+		ClientSecret: config.GetSettings().AsanaOauth.ClientSecret,
+		RedirectURL:  config.GetSettings().ServerURL + "link/asana/callback/",
 		Scopes:       []string{},
 		Endpoint: oauth2.Endpoint{
 			AuthURL:  "https://app.asana.com/-/oauth_authorize",
@@ -103,10 +103,10 @@ func (asana AsanaService) HandleLinkCallback(db *mongo.Database, params Callback
 	return nil
 }
 
-func (asana AsanaService) HandleSignupCallback(db *mongo.Database, params CallbackParams) (primitive.ObjectID, *bool, *string, error) {
+func (asana AsanaService) HandleSignupCallback(ctx context.Context, db *mongo.Database, params CallbackParams) (primitive.ObjectID, *bool, *string, error) {
 	return primitive.NilObjectID, nil, nil, errors.New("asana does not support signup")
 }
 
-func getAsanaHttpClient(db *mongo.Database, userID primitive.ObjectID, accountID string) *http.Client {
-	return getExternalOauth2Client(db, userID, accountID, TASK_SERVICE_ID_ASANA, getAsanaConfig())
+func getAsanaHttpClient(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string) *http.Client {
+	return getExternalOauth2Client(ctx, db, userID, accountID, TASK_SERVICE_ID_ASANA, getAsanaConfig())
 }
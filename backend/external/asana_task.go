@@ -1,6 +1,7 @@
 package external
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -53,12 +54,12 @@ type AsanaTasksUpdateBody struct {
 	Data AsanaTasksUpdateFields `json:"data"`
 }
 
-func (asanaTask AsanaTaskSource) GetEvents(db *mongo.Database, userID primitive.ObjectID, accountID string, startTime time.Time, endTime time.Time, scopes []string, result chan<- CalendarResult) {
+func (asanaTask AsanaTaskSource) GetEvents(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, startTime time.Time, endTime time.Time, scopes []string, result chan<- CalendarResult) {
 	result <- emptyCalendarResult(errors.New("asana cannot fetch events"))
 }
 
-func (asanaTask AsanaTaskSource) GetTasks(db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- TaskResult) {
-	client := getAsanaHttpClient(db, userID, accountID)
+func (asanaTask AsanaTaskSource) GetTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- TaskResult) {
+	client := getAsanaHttpClient(ctx, db, userID, accountID)
 
 	userInfoURL := AsanaUserInfoURL
 	if asanaTask.Asana.ConfigValues.UserInfoURL != nil {
@@ -84,7 +85,7 @@ func (asanaTask AsanaTaskSource) GetTasks(db *mongo.Database, userID primitive.O
 		taskFetchURL = *asanaTask.Asana.ConfigValues.TaskFetchURL
 		client = http.DefaultClient
 	} else if client == nil {
-		client = getAsanaHttpClient(db, userID, accountID)
+		client = getAsanaHttpClient(ctx, db, userID, accountID)
 	}
 
 	var asanaTasks AsanaTasksResponse
@@ -95,6 +96,7 @@ func (asanaTask AsanaTaskSource) GetTasks(db *mongo.Database, userID primitive.O
 		return
 	}
 
+	defaultTaskSectionID := database.GetDefaultTaskSectionID(ctx, db, userID, TASK_SOURCE_ID_ASANA)
 	var tasks []*database.Task
 	for _, asanaTaskData := range asanaTasks.Data {
 		title := asanaTaskData.Name
@@ -102,7 +104,7 @@ func (asanaTask AsanaTaskSource) GetTasks(db *mongo.Database, userID primitive.O
 		task := &database.Task{
 			UserID:            userID,
 			IDExternal:        asanaTaskData.GID,
-			IDTaskSection:     constants.IDTaskSectionDefault,
+			IDTaskSection:     defaultTaskSectionID,
 			Deeplink:          asanaTaskData.PermalinkURL,
 			SourceID:          TASK_SOURCE_ID_ASANA,
 			Title:             &title,
@@ -117,6 +119,7 @@ func (asanaTask AsanaTaskSource) GetTasks(db *mongo.Database, userID primitive.O
 		}
 		isCompleted := false
 		dbTask, err := database.UpdateOrCreateTask(
+			ctx,
 			db,
 			userID,
 			task.IDExternal,
@@ -147,12 +150,12 @@ func (asanaTask AsanaTaskSource) GetTasks(db *mongo.Database, userID primitive.O
 	}
 }
 
-func (asanaTask AsanaTaskSource) GetPullRequests(db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- PullRequestResult) {
+func (asanaTask AsanaTaskSource) GetPullRequests(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- PullRequestResult) {
 	result <- emptyPullRequestResult(nil, false)
 }
 
-func (asanaTask AsanaTaskSource) ModifyTask(db *mongo.Database, userID primitive.ObjectID, accountID string, issueID string, updateFields *database.Task, task *database.Task) error {
-	client := getAsanaHttpClient(db, userID, accountID)
+func (asanaTask AsanaTaskSource) ModifyTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, issueID string, updateFields *database.Task, task *database.Task) error {
+	client := getAsanaHttpClient(ctx, db, userID, accountID)
 
 	taskUpdateURL := fmt.Sprintf(AsanaTasksURL+"%s/", issueID)
 	if asanaTask.Asana.ConfigValues.TaskUpdateURL != nil {
@@ -190,22 +193,22 @@ func (asanaTask AsanaTaskSource) GetTaskUpdateBody(updateFields *database.Task)
 	return &body
 }
 
-func (asanaTask AsanaTaskSource) CreateNewTask(db *mongo.Database, userID primitive.ObjectID, accountID string, task TaskCreationObject) (primitive.ObjectID, error) {
+func (asanaTask AsanaTaskSource) CreateNewTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, task TaskCreationObject) (primitive.ObjectID, error) {
 	return primitive.NilObjectID, errors.New("has not been implemented yet")
 }
 
-func (asanaTask AsanaTaskSource) CreateNewEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error {
+func (asanaTask AsanaTaskSource) CreateNewEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error {
 	return errors.New("has not been implemented yet")
 }
 
-func (asanaTask AsanaTaskSource) ModifyEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, eventID string, updateFields *EventModifyObject) error {
+func (asanaTask AsanaTaskSource) ModifyEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, eventID string, updateFields *EventModifyObject) error {
 	return errors.New("has not been implemented yet")
 }
 
-func (asanaTask AsanaTaskSource) DeleteEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error {
+func (asanaTask AsanaTaskSource) DeleteEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error {
 	return errors.New("has not been implemented yet")
 }
 
-func (asanaTask AsanaTaskSource) AddComment(db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error {
+func (asanaTask AsanaTaskSource) AddComment(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error {
 	return errors.New("has not been implemented yet")
 }
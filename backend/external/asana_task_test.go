@@ -35,7 +35,7 @@ func TestLoadAsanaTasks(t *testing.T) {
 		userID := primitive.NewObjectID()
 
 		var taskResult = make(chan TaskResult)
-		go asanaTask.GetTasks(db, userID, "sample_account@email.com", taskResult)
+		go asanaTask.GetTasks(context.Background(), db, userID, "sample_account@email.com", taskResult)
 		result := <-taskResult
 		assert.NotEqual(t, nil, result.Error)
 		assert.Equal(t, "bad status code: 400", result.Error.Error())
@@ -48,7 +48,7 @@ func TestLoadAsanaTasks(t *testing.T) {
 		userID := primitive.NewObjectID()
 
 		var taskResult = make(chan TaskResult)
-		go asanaTask.GetTasks(db, userID, "sample_account@email.com", taskResult)
+		go asanaTask.GetTasks(context.Background(), db, userID, "sample_account@email.com", taskResult)
 		result := <-taskResult
 		assert.NotEqual(t, nil, result.Error)
 		assert.Equal(t, "invalid character 'o' looking for beginning of value", result.Error.Error())
@@ -61,7 +61,7 @@ func TestLoadAsanaTasks(t *testing.T) {
 		userID := primitive.NewObjectID()
 
 		var taskResult = make(chan TaskResult)
-		go asanaTask.GetTasks(db, userID, "sample_account@email.com", taskResult)
+		go asanaTask.GetTasks(context.Background(), db, userID, "sample_account@email.com", taskResult)
 		result := <-taskResult
 		assert.NotEqual(t, nil, result.Error)
 		assert.Equal(t, "user has not workspaces", result.Error.Error())
@@ -77,7 +77,7 @@ func TestLoadAsanaTasks(t *testing.T) {
 		userID := primitive.NewObjectID()
 
 		var taskResult = make(chan TaskResult)
-		go asanaTask.GetTasks(db, userID, "sample_account@email.com", taskResult)
+		go asanaTask.GetTasks(context.Background(), db, userID, "sample_account@email.com", taskResult)
 		result := <-taskResult
 		assert.NotEqual(t, nil, result.Error)
 		assert.Equal(t, "bad status code: 409", result.Error.Error())
@@ -93,7 +93,7 @@ func TestLoadAsanaTasks(t *testing.T) {
 		userID := primitive.NewObjectID()
 
 		var taskResult = make(chan TaskResult)
-		go asanaTask.GetTasks(db, userID, "sample_account@email.com", taskResult)
+		go asanaTask.GetTasks(context.Background(), db, userID, "sample_account@email.com", taskResult)
 		result := <-taskResult
 		assert.NotEqual(t, nil, result.Error)
 		assert.Equal(t, "invalid character 'o' in literal true (expecting 'r')", result.Error.Error())
@@ -126,7 +126,7 @@ func TestLoadAsanaTasks(t *testing.T) {
 		}
 
 		var taskResult = make(chan TaskResult)
-		go asanaTask.GetTasks(db, userID, "sample_account@email.com", taskResult)
+		go asanaTask.GetTasks(context.Background(), db, userID, "sample_account@email.com", taskResult)
 		result := <-taskResult
 		assert.NoError(t, result.Error)
 		assert.Equal(t, 1, len(result.Tasks))
@@ -173,6 +173,7 @@ func TestLoadAsanaTasks(t *testing.T) {
 			TimeAllocation:    &allocation,
 		}
 		database.GetOrCreateTask(
+			context.Background(),
 			db,
 			userID,
 			"6942069420",
@@ -190,7 +191,7 @@ func TestLoadAsanaTasks(t *testing.T) {
 		expectedTask.Body = &correctBody
 
 		var taskResult = make(chan TaskResult)
-		go asanaTask.GetTasks(db, userID, "sample_account@email.com", taskResult)
+		go asanaTask.GetTasks(context.Background(), db, userID, "sample_account@email.com", taskResult)
 		result := <-taskResult
 		assert.NoError(t, result.Error)
 		assert.Equal(t, 1, len(result.Tasks))
@@ -222,7 +223,7 @@ func TestModifyAsanaTask(t *testing.T) {
 		userID := primitive.NewObjectID()
 
 		isCompleted := true
-		err := asanaTask.ModifyTask(db, userID, "sample_account@email.com", "6942069420", &database.Task{IsCompleted: &isCompleted}, nil)
+		err := asanaTask.ModifyTask(context.Background(), db, userID, "sample_account@email.com", "6942069420", &database.Task{IsCompleted: &isCompleted}, nil)
 		assert.NotEqual(t, nil, err)
 		assert.Equal(t, "bad status code: 400", err.Error())
 	})
@@ -233,7 +234,7 @@ func TestModifyAsanaTask(t *testing.T) {
 		userID := primitive.NewObjectID()
 
 		isCompleted := true
-		err := asanaTask.ModifyTask(db, userID, "sample_account@email.com", "6942069420", &database.Task{IsCompleted: &isCompleted}, nil)
+		err := asanaTask.ModifyTask(context.Background(), db, userID, "sample_account@email.com", "6942069420", &database.Task{IsCompleted: &isCompleted}, nil)
 		assert.NoError(t, err)
 	})
 	t.Run("MarkAsNotDoneSuccess", func(t *testing.T) {
@@ -243,7 +244,7 @@ func TestModifyAsanaTask(t *testing.T) {
 		userID := primitive.NewObjectID()
 
 		isCompleted := false
-		err := asanaTask.ModifyTask(db, userID, "sample_account@email.com", "6942069420", &database.Task{IsCompleted: &isCompleted}, nil)
+		err := asanaTask.ModifyTask(context.Background(), db, userID, "sample_account@email.com", "6942069420", &database.Task{IsCompleted: &isCompleted}, nil)
 		assert.NoError(t, err)
 	})
 	t.Run("UpdateFieldsAndMarkAsDoneSuccess", func(t *testing.T) {
@@ -257,7 +258,7 @@ func TestModifyAsanaTask(t *testing.T) {
 		isCompleted := true
 		dueDate := primitive.NewDateTimeFromTime(time.Now())
 
-		err := asanaTask.ModifyTask(db, userID, "sample_account@email.com", "6942069420", &database.Task{
+		err := asanaTask.ModifyTask(context.Background(), db, userID, "sample_account@email.com", "6942069420", &database.Task{
 			Title:       &newName,
 			Body:        &newBody,
 			DueDate:     &dueDate,
@@ -276,7 +277,7 @@ func TestModifyAsanaTask(t *testing.T) {
 		isCompleted := true
 		dueDate := primitive.NewDateTimeFromTime(time.Now())
 
-		err := asanaTask.ModifyTask(db, userID, "sample_account@email.com", "6942069420", &database.Task{
+		err := asanaTask.ModifyTask(context.Background(), db, userID, "sample_account@email.com", "6942069420", &database.Task{
 			Title:       &newName,
 			Body:        &newBody,
 			DueDate:     &dueDate,
@@ -295,7 +296,7 @@ func TestModifyAsanaTask(t *testing.T) {
 		newBody := "New Body"
 		dueDate := primitive.NewDateTimeFromTime(time.Now())
 
-		err := asanaTask.ModifyTask(db, userID, "sample_account@email.com", "6942069420", &database.Task{
+		err := asanaTask.ModifyTask(context.Background(), db, userID, "sample_account@email.com", "6942069420", &database.Task{
 			Title:   &newName,
 			Body:    &newBody,
 			DueDate: &dueDate,
@@ -312,7 +313,7 @@ func TestModifyAsanaTask(t *testing.T) {
 		newBody := "New Body"
 		dueDate := primitive.NewDateTimeFromTime(time.Now())
 
-		err := asanaTask.ModifyTask(db, userID, "sample_account@email.com", "6942069420", &database.Task{
+		err := asanaTask.ModifyTask(context.Background(), db, userID, "sample_account@email.com", "6942069420", &database.Task{
 			Title:   &newName,
 			Body:    &newBody,
 			DueDate: &dueDate,
@@ -331,7 +332,7 @@ func TestModifyAsanaTask(t *testing.T) {
 		isCompleted := false
 		dueDate := primitive.NewDateTimeFromTime(time.Now())
 
-		err := asanaTask.ModifyTask(db, userID, "sample_account@email.com", "6942069420", &database.Task{
+		err := asanaTask.ModifyTask(context.Background(), db, userID, "sample_account@email.com", "6942069420", &database.Task{
 			Title:       &newName,
 			Body:        &newBody,
 			DueDate:     &dueDate,
@@ -351,7 +352,7 @@ func TestModifyAsanaTask(t *testing.T) {
 		isCompleted := false
 		dueDate := primitive.NewDateTimeFromTime(time.Now())
 
-		err := asanaTask.ModifyTask(db, userID, "sample_account@email.com", "6942069420", &database.Task{
+		err := asanaTask.ModifyTask(context.Background(), db, userID, "sample_account@email.com", "6942069420", &database.Task{
 			Title:       &newName,
 			Body:        &newBody,
 			DueDate:     &dueDate,
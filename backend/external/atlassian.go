@@ -39,6 +39,7 @@ type AtlassianConfigValues struct {
 	FieldsListURL   *string
 	IssueUpdateURL  *string
 	IssueDeleteURL  *string
+	AgileURL        *string
 }
 
 // AtlassianConfig ...
@@ -62,13 +63,13 @@ type AtlassianService struct {
 
 func getAtlassianOauthConfig() OauthConfigWrapper {
 	atlassianConfig := &oauth2.Config{
-		ClientID:     config.GetConfigValue("JIRA_OAUTH_CLIENT_ID"),
-		ClientSecret: config.GetConfigValue("JIRA_OAUTH_CLIENT_SECRET"),
+		ClientID:     config.GetSettings().JiraOauth.ClientID,
+		ClientSecret: config.GetSettings().JiraOauth.ClientSecret,
 		Endpoint: oauth2.Endpoint{
 			AuthURL:  "https://auth.atlassian.com/authorize",
 			TokenURL: "https://auth.atlassian.com/oauth/token",
 		},
-		RedirectURL: config.GetConfigValue("SERVER_URL") + "link/atlassian/callback/",
+		RedirectURL: config.GetSettings().ServerURL + "link/atlassian/callback/",
 		Scopes:      []string{"read:jira-work", "read:jira-user", "write:jira-work", "offline_access"},
 	}
 	return &OauthConfig{Config: atlassianConfig}
@@ -156,7 +157,7 @@ func (atlassian AtlassianService) HandleLinkCallback(db *mongo.Database, params
 	return nil
 }
 
-func (atlassian AtlassianService) HandleSignupCallback(db *mongo.Database, params CallbackParams) (primitive.ObjectID, *bool, *string, error) {
+func (atlassian AtlassianService) HandleSignupCallback(ctx context.Context, db *mongo.Database, params CallbackParams) (primitive.ObjectID, *bool, *string, error) {
 	return primitive.NilObjectID, nil, nil, errors.New("atlassian does not support signup")
 }
 
@@ -201,8 +202,7 @@ func (atlassian AtlassianService) getSites(token *oauth2.Token) *[]AtlassianSite
 	return &AtlassianSites
 }
 
-func (atlassian AtlassianService) getSiteConfiguration(userID primitive.ObjectID) (*database.AtlassianSiteConfiguration, error) {
-	parentCtx := context.Background()
+func (atlassian AtlassianService) getSiteConfiguration(ctx context.Context, userID primitive.ObjectID) (*database.AtlassianSiteConfiguration, error) {
 	var siteConfiguration database.AtlassianSiteConfiguration
 	db, dbCleanup, err := database.GetDBConnection()
 	if err != nil {
@@ -211,7 +211,7 @@ func (atlassian AtlassianService) getSiteConfiguration(userID primitive.ObjectID
 	defer dbCleanup()
 
 	siteCollection := database.GetJiraSitesCollection(db)
-	dbCtx, cancel := context.WithTimeout(parentCtx, constants.DatabaseTimeout)
+	dbCtx, cancel := context.WithTimeout(ctx, constants.DatabaseTimeout)
 	defer cancel()
 	err = siteCollection.FindOne(dbCtx, bson.M{"user_id": userID}).Decode(&siteConfiguration)
 	if err != nil {
@@ -220,8 +220,7 @@ func (atlassian AtlassianService) getSiteConfiguration(userID primitive.ObjectID
 	return &siteConfiguration, nil
 }
 
-func (atlassian AtlassianService) getAndRefreshToken(userID primitive.ObjectID, accountID string) (*AtlassianAuthToken, error) {
-	parentCtx := context.Background()
+func (atlassian AtlassianService) getAndRefreshToken(ctx context.Context, userID primitive.ObjectID, accountID string) (*AtlassianAuthToken, error) {
 	var JIRAToken database.ExternalAPIToken
 
 	db, dbCleanup, err := database.GetDBConnection()
@@ -232,7 +231,7 @@ func (atlassian AtlassianService) getAndRefreshToken(userID primitive.ObjectID,
 
 	externalAPITokenCollection := database.GetExternalTokenCollection(db)
 
-	dbCtx, cancel := context.WithTimeout(parentCtx, constants.DatabaseTimeout)
+	dbCtx, cancel := context.WithTimeout(ctx, constants.DatabaseTimeout)
 	defer cancel()
 	err = externalAPITokenCollection.FindOne(
 		dbCtx,
@@ -253,7 +252,7 @@ func (atlassian AtlassianService) getAndRefreshToken(userID primitive.ObjectID,
 		logger.Error().Err(err).Msg("failed to parse JIRA token")
 		return nil, err
 	}
-	params := []byte(`{"grant_type": "refresh_token","client_id": "` + config.GetConfigValue("JIRA_OAUTH_CLIENT_ID") + `","client_secret": "` + config.GetConfigValue("JIRA_OAUTH_CLIENT_SECRET") + `","refresh_token": "` + token.RefreshToken + `"}`)
+	params := []byte(`{"grant_type": "refresh_token","client_id": "` + config.GetSettings().JiraOauth.ClientID + `","client_secret": "` + config.GetSettings().JiraOauth.ClientSecret + `","refresh_token": "` + token.RefreshToken + `"}`)
 	tokenURL := "https://auth.atlassian.com/oauth/token" //#nosec
 	if atlassian.Config.ConfigValues.TokenURL != nil {
 		tokenURL = *atlassian.Config.ConfigValues.TokenURL
@@ -298,6 +297,13 @@ func (atlassian AtlassianService) getAndRefreshToken(userID primitive.ObjectID,
 		logger.Error().Err(err).Msg("failed to create external token record")
 		return nil, errors.New("internal server error")
 	}
+	// failing to record the refresh shouldn't fail the request using the token
+	_ = database.InsertAuditLog(ctx, db, database.AuditLog{
+		UserID:    userID,
+		EventType: constants.AuditEventTokenRefreshed,
+		ServiceID: TASK_SERVICE_ID_ATLASSIAN,
+		AccountID: accountID,
+	})
 
 	return &newToken, nil
 }
@@ -0,0 +1,305 @@
+package external
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/franchizzle/task-manager/backend/config"
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/microsoft"
+)
+
+const (
+	AzureDevOpsBaseURL    = "https://dev.azure.com"
+	AzureDevOpsVSSPSURL   = "https://app.vssps.visualstudio.com"
+	AzureDevOpsAPIVersion = "7.0"
+	// the Azure DevOps resource, which Entra issues tokens for
+	AzureDevOpsScope = "499b84ac-1321-427f-aa17-267ca6975798/user_impersonation"
+)
+
+// ErrInvalidAzureDevOpsToken is returned when a personal access token can't be used with the organization
+var ErrInvalidAzureDevOpsToken = errors.New("invalid azure devops personal access token")
+
+type AzureDevOpsConfigValues struct {
+	// BaseURL replaces https://dev.azure.com for organization requests
+	BaseURL     *string
+	ProfileURL  *string
+	AccountsURL *string
+}
+
+type AzureDevOpsConfig struct {
+	OauthConfig  OauthConfigWrapper
+	ConfigValues AzureDevOpsConfigValues
+}
+
+type AzureDevOpsService struct {
+	Config AzureDevOpsConfig
+}
+
+type AzureDevOpsProfile struct {
+	ID           string `json:"id"`
+	EmailAddress string `json:"emailAddress"`
+}
+
+type AzureDevOpsAccountsResponse struct {
+	Value []struct {
+		AccountName string `json:"accountName"`
+	} `json:"value"`
+}
+
+type AzureDevOpsConnectionData struct {
+	AuthenticatedUser struct {
+		ID         string `json:"id"`
+		Properties struct {
+			Account struct {
+				Value string `json:"$value"`
+			} `json:"Account"`
+		} `json:"properties"`
+	} `json:"authenticatedUser"`
+}
+
+type AzureDevOpsProject struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type AzureDevOpsProjectsResponse struct {
+	Value []AzureDevOpsProject `json:"value"`
+}
+
+func getAzureDevOpsOauthConfig() *OauthConfig {
+	settings := config.GetSettings()
+	return &OauthConfig{Config: &oauth2.Config{
+		ClientID:     settings.AzureDevOpsOauth.ClientID,
+		ClientSecret: settings.AzureDevOpsOauth.ClientSecret,
+		RedirectURL:  settings.ServerURL + "link/azure_devops/callback/",
+		Scopes:       []string{AzureDevOpsScope, "offline_access"},
+		Endpoint:     microsoft.AzureADEndpoint("organizations"),
+	}}
+}
+
+// GetLinkURL is only available when an Entra app is configured. Otherwise accounts are linked with
+// a personal access token instead
+func (azureDevOps AzureDevOpsService) GetLinkURL(stateTokenID primitive.ObjectID, userID primitive.ObjectID) (*string, error) {
+	if config.GetSettings().AzureDevOpsOauth.ClientID == "" {
+		return nil, errors.New("azure devops oauth is not configured")
+	}
+	authURL := azureDevOps.Config.OauthConfig.AuthCodeURL(stateTokenID.Hex())
+	return &authURL, nil
+}
+
+func (azureDevOps AzureDevOpsService) GetSignupURL(stateTokenID primitive.ObjectID, forcePrompt bool) (*string, error) {
+	return nil, errors.New("azure devops does not support signup")
+}
+
+// HandleLinkCallback links every organization the user is a member of, since an Entra token isn't
+// scoped to a single organization like a personal access token is
+func (azureDevOps AzureDevOpsService) HandleLinkCallback(db *mongo.Database, params CallbackParams, userID primitive.ObjectID) error {
+	parentCtx := context.Background()
+	extCtx, cancel := context.WithTimeout(parentCtx, constants.ExternalTimeout)
+	defer cancel()
+	token, err := azureDevOps.Config.OauthConfig.Exchange(extCtx, *params.Oauth2Code)
+	logger := logging.GetSentryLogger()
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch token from Azure DevOps")
+		return errors.New("internal server error")
+	}
+
+	client := oauth2.NewClient(extCtx, oauth2.StaticTokenSource(token))
+	profile, err := azureDevOps.getProfile(client)
+	if err != nil || profile.EmailAddress == "" {
+		logger.Error().Err(err).Msg("failed to fetch azure devops profile")
+		return errors.New("internal server error")
+	}
+	organizations, err := azureDevOps.getOrganizations(client, profile.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch azure devops organizations")
+		return errors.New("internal server error")
+	}
+
+	err = azureDevOps.saveToken(db, userID, profile.EmailAddress, profile.ID, token)
+	if err != nil {
+		logger.Error().Err(err).Msg("error saving token")
+		return errors.New("internal server error")
+	}
+	for _, organization := range organizations {
+		err = azureDevOps.refreshProjects(db, userID, profile.EmailAddress, client, organization)
+		if err != nil {
+			// the token may not have access to every organization, which shouldn't fail the link
+			logger.Error().Err(err).Str("organization", organization).Msg("failed to fetch azure devops projects")
+		}
+	}
+	return nil
+}
+
+func (azureDevOps AzureDevOpsService) HandleSignupCallback(ctx context.Context, db *mongo.Database, params CallbackParams) (primitive.ObjectID, *bool, *string, error) {
+	return primitive.NilObjectID, nil, nil, errors.New("azure devops does not support signup")
+}
+
+// LinkPersonalAccessToken links an organization using a personal access token. The token is stored
+// as a basic auth token, so it is used the same way as an oauth token from then on
+func (azureDevOps AzureDevOpsService) LinkPersonalAccessToken(db *mongo.Database, userID primitive.ObjectID, organization string, personalAccessToken string) error {
+	token := &oauth2.Token{
+		AccessToken: base64.StdEncoding.EncodeToString([]byte(":" + personalAccessToken)),
+		TokenType:   "basic",
+	}
+	extCtx, cancel := context.WithTimeout(context.Background(), constants.ExternalTimeout)
+	defer cancel()
+	client := oauth2.NewClient(extCtx, oauth2.StaticTokenSource(token))
+	connectionData, err := azureDevOps.getConnectionData(client, organization)
+	if err != nil || connectionData.AuthenticatedUser.Properties.Account.Value == "" {
+		return ErrInvalidAzureDevOpsToken
+	}
+
+	accountID := connectionData.AuthenticatedUser.Properties.Account.Value
+	err = azureDevOps.saveToken(db, userID, accountID, connectionData.AuthenticatedUser.ID, token)
+	if err != nil {
+		return err
+	}
+	return azureDevOps.refreshProjects(db, userID, accountID, client, organization)
+}
+
+func (azureDevOps AzureDevOpsService) saveToken(db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, token *oauth2.Token) error {
+	tokenString, err := json.Marshal(&token)
+	if err != nil {
+		return err
+	}
+	dbCtx, cancel := context.WithTimeout(context.Background(), constants.DatabaseTimeout)
+	defer cancel()
+	_, err = database.GetExternalTokenCollection(db).UpdateOne(
+		dbCtx,
+		bson.M{"$and": []bson.M{{"user_id": userID}, {"service_id": TASK_SERVICE_ID_AZURE_DEVOPS}, {"account_id": accountID}}},
+		bson.M{"$set": &database.ExternalAPIToken{
+			UserID:         userID,
+			ServiceID:      TASK_SERVICE_ID_AZURE_DEVOPS,
+			Token:          string(tokenString),
+			AccountID:      accountID,
+			DisplayID:      accountID,
+			ExternalID:     externalID,
+			IsUnlinkable:   true,
+			IsPrimaryLogin: false,
+		}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+func (azureDevOps AzureDevOpsService) getProfile(client *http.Client) (*AzureDevOpsProfile, error) {
+	profileURL := AzureDevOpsVSSPSURL + "/_apis/profile/profiles/me?api-version=" + AzureDevOpsAPIVersion
+	if azureDevOps.Config.ConfigValues.ProfileURL != nil {
+		profileURL = *azureDevOps.Config.ConfigValues.ProfileURL
+		client = http.DefaultClient
+	}
+	var profile AzureDevOpsProfile
+	err := getJSON(client, profileURL, &profile)
+	if err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (azureDevOps AzureDevOpsService) getOrganizations(client *http.Client, memberID string) ([]string, error) {
+	accountsURL := fmt.Sprintf("%s/_apis/accounts?memberId=%s&api-version=%s", AzureDevOpsVSSPSURL, url.QueryEscape(memberID), AzureDevOpsAPIVersion)
+	if azureDevOps.Config.ConfigValues.AccountsURL != nil {
+		accountsURL = *azureDevOps.Config.ConfigValues.AccountsURL
+		client = http.DefaultClient
+	}
+	var accounts AzureDevOpsAccountsResponse
+	err := getJSON(client, accountsURL, &accounts)
+	if err != nil {
+		return nil, err
+	}
+	organizations := []string{}
+	for _, account := range accounts.Value {
+		organizations = append(organizations, account.AccountName)
+	}
+	return organizations, nil
+}
+
+// getConnectionData returns the identity the client authenticates as within the organization
+func (azureDevOps AzureDevOpsService) getConnectionData(client *http.Client, organization string) (*AzureDevOpsConnectionData, error) {
+	connectionDataURL, client := azureDevOps.getOrganizationURL(client, organization, "/_apis/connectionData?api-version="+AzureDevOpsAPIVersion)
+	var connectionData AzureDevOpsConnectionData
+	err := getJSON(client, connectionDataURL, &connectionData)
+	if err != nil {
+		return nil, err
+	}
+	return &connectionData, nil
+}
+
+// refreshProjects stores the organization's projects in the repositories collection, so they can be
+// added to the account's filter list like Github repositories
+func (azureDevOps AzureDevOpsService) refreshProjects(db *mongo.Database, userID primitive.ObjectID, accountID string, client *http.Client, organization string) error {
+	projectsURL, client := azureDevOps.getOrganizationURL(client, organization, "/_apis/projects?$top=500&api-version="+AzureDevOpsAPIVersion)
+	var projects AzureDevOpsProjectsResponse
+	err := getJSON(client, projectsURL, &projects)
+	if err != nil {
+		return err
+	}
+	for _, project := range projects.Value {
+		_, err = database.GetRepositoryCollection(db).UpdateOne(
+			context.Background(),
+			bson.M{"$and": []bson.M{
+				{"repository_id": project.ID},
+				{"account_id": accountID},
+				{"user_id": userID},
+			}},
+			bson.M{"$set": bson.M{
+				"full_name": organization + "/" + project.Name,
+				"deeplink":  fmt.Sprintf("%s/%s/%s", AzureDevOpsBaseURL, organization, url.PathEscape(project.Name)),
+			}},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getAzureDevOpsLinkedOrganizations returns the organizations the account has stored projects for
+func getAzureDevOpsLinkedOrganizations(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string) ([]string, error) {
+	projects, err := database.GetRepositories(ctx, db, userID, &[]bson.M{{"account_id": accountID}})
+	if err != nil {
+		return nil, err
+	}
+	organizations := []string{}
+	seen := map[string]bool{}
+	for _, project := range *projects {
+		organization, _, found := strings.Cut(project.FullName, "/")
+		if !found || seen[organization] {
+			continue
+		}
+		seen[organization] = true
+		organizations = append(organizations, organization)
+	}
+	return organizations, nil
+}
+
+// getOrganizationURL builds an API URL within the organization, path includes the query. Test overrides of the base URL
+// are served without auth, so the default client is used for them
+func (azureDevOps AzureDevOpsService) getOrganizationURL(client *http.Client, organization string, path string) (string, *http.Client) {
+	baseURL := AzureDevOpsBaseURL
+	if azureDevOps.Config.ConfigValues.BaseURL != nil {
+		baseURL = *azureDevOps.Config.ConfigValues.BaseURL
+		client = http.DefaultClient
+	}
+	return fmt.Sprintf("%s/%s%s", baseURL, url.PathEscape(organization), path), client
+}
+
+func getAzureDevOpsHttpClient(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string) *http.Client {
+	return getExternalOauth2Client(ctx, db, userID, accountID, TASK_SERVICE_ID_AZURE_DEVOPS, getAzureDevOpsOauthConfig())
+}
@@ -0,0 +1,580 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type AzureDevOpsSource struct {
+	AzureDevOps AzureDevOpsService
+}
+
+const (
+	// the work items batch endpoint accepts at most 200 IDs per request
+	AzureDevOpsWorkItemBatchSize = 200
+	// work items in any of these states have nothing left to do, across the default process templates
+	AzureDevOpsOpenWorkItemsQuery = "SELECT [System.Id] FROM WorkItems WHERE [System.AssignedTo] = @Me AND [System.State] NOT IN ('Closed', 'Done', 'Removed', 'Resolved', 'Completed', 'Cut') ORDER BY [System.ChangedDate] DESC"
+
+	AzureDevOpsStateCategoryProposed  = "Proposed"
+	AzureDevOpsStateCategoryCompleted = "Completed"
+
+	AzureDevOpsPolicyStatusApproved      = "approved"
+	AzureDevOpsPolicyStatusNotApplicable = "notApplicable"
+	AzureDevOpsPolicyStatusRejected      = "rejected"
+	AzureDevOpsPolicyStatusBroken        = "broken"
+	AzureDevOpsPolicyStatusQueued        = "queued"
+	AzureDevOpsPolicyStatusRunning       = "running"
+	AzureDevOpsPolicyTypeBuild           = "Build"
+
+	AzureDevOpsVoteApprovedWithSuggestions = 5
+	AzureDevOpsVoteNoVote                  = 0
+	AzureDevOpsMergeStatusConflicts        = "conflicts"
+)
+
+var azureDevOpsWorkItemFields = []string{
+	"System.Title",
+	"System.Description",
+	"System.TeamProject",
+	"System.WorkItemType",
+	"System.CreatedDate",
+	"Microsoft.VSTS.Scheduling.DueDate",
+}
+
+type AzureDevOpsWIQLResponse struct {
+	WorkItems []struct {
+		ID int `json:"id"`
+	} `json:"workItems"`
+}
+
+type AzureDevOpsWorkItemsBatchBody struct {
+	IDs    []int    `json:"ids"`
+	Fields []string `json:"fields"`
+}
+
+type AzureDevOpsWorkItemFields struct {
+	Title        string     `json:"System.Title"`
+	Description  string     `json:"System.Description"`
+	TeamProject  string     `json:"System.TeamProject"`
+	WorkItemType string     `json:"System.WorkItemType"`
+	CreatedDate  time.Time  `json:"System.CreatedDate"`
+	DueDate      *time.Time `json:"Microsoft.VSTS.Scheduling.DueDate"`
+}
+
+type AzureDevOpsWorkItem struct {
+	ID     int                       `json:"id"`
+	Fields AzureDevOpsWorkItemFields `json:"fields"`
+}
+
+type AzureDevOpsWorkItemsResponse struct {
+	Value []AzureDevOpsWorkItem `json:"value"`
+}
+
+type AzureDevOpsWorkItemState struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+}
+
+type AzureDevOpsWorkItemStatesResponse struct {
+	Value []AzureDevOpsWorkItemState `json:"value"`
+}
+
+// AzureDevOpsPatchOperation is a JSON Patch operation, which is how work items are updated
+type AzureDevOpsPatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value string `json:"value"`
+}
+
+type AzureDevOpsIdentity struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"`
+}
+
+type AzureDevOpsReviewer struct {
+	AzureDevOpsIdentity
+	Vote int `json:"vote"`
+}
+
+type AzureDevOpsPullRequest struct {
+	PullRequestID int                   `json:"pullRequestId"`
+	Title         string                `json:"title"`
+	Description   string                `json:"description"`
+	CreatedBy     AzureDevOpsIdentity   `json:"createdBy"`
+	CreationDate  time.Time             `json:"creationDate"`
+	SourceRefName string                `json:"sourceRefName"`
+	TargetRefName string                `json:"targetRefName"`
+	MergeStatus   string                `json:"mergeStatus"`
+	Reviewers     []AzureDevOpsReviewer `json:"reviewers"`
+	Repository    struct {
+		ID      string             `json:"id"`
+		Name    string             `json:"name"`
+		Project AzureDevOpsProject `json:"project"`
+	} `json:"repository"`
+}
+
+type AzureDevOpsPullRequestsResponse struct {
+	Value []AzureDevOpsPullRequest `json:"value"`
+}
+
+type AzureDevOpsPolicyEvaluation struct {
+	Status        string `json:"status"`
+	Configuration struct {
+		IsBlocking bool `json:"isBlocking"`
+		IsEnabled  bool `json:"isEnabled"`
+		Type       struct {
+			DisplayName string `json:"displayName"`
+		} `json:"type"`
+	} `json:"configuration"`
+}
+
+type AzureDevOpsPolicyEvaluationsResponse struct {
+	Value []AzureDevOpsPolicyEvaluation `json:"value"`
+}
+
+func (azureDevOpsSource AzureDevOpsSource) GetEvents(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, startTime time.Time, endTime time.Time, scopes []string, result chan<- CalendarResult) {
+	result <- emptyCalendarResult(errors.New("azure devops cannot fetch events"))
+}
+
+// GetTasks fetches the open work items assigned to the user in each linked organization, leaving out
+// projects excluded by the account's project filter
+func (azureDevOpsSource AzureDevOpsSource) GetTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- TaskResult) {
+	client := getAzureDevOpsHttpClient(ctx, db, userID, accountID)
+	logger := logging.GetSentryLogger()
+	organizations, projectFilter, projectIDs, err := azureDevOpsSource.loadProjects(db, userID, accountID, client)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to load azure devops projects")
+		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_AZURE_DEVOPS)
+		return
+	}
+
+	defaultTaskSectionID := database.GetDefaultTaskSectionID(ctx, db, userID, TASK_SOURCE_ID_AZURE_DEVOPS)
+	var tasks []*database.Task
+	for _, organization := range organizations {
+		workItems, err := azureDevOpsSource.getAssignedWorkItems(client, organization)
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to fetch azure devops work items")
+			result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_AZURE_DEVOPS)
+			return
+		}
+		for _, workItem := range workItems {
+			if !projectFilter.IncludesID(projectIDs[organization+"/"+workItem.Fields.TeamProject]) {
+				continue
+			}
+			task := getAzureDevOpsWorkItemTask(workItem, organization)
+			task.UserID = userID
+			task.IDTaskSection = defaultTaskSectionID
+			task.SourceAccountID = accountID
+			isCompleted := false
+			dbTask, err := database.UpdateOrCreateTask(
+				ctx,
+				db,
+				userID,
+				task.IDExternal,
+				task.SourceID,
+				task,
+				database.Task{
+					Title:       task.Title,
+					Body:        task.Body,
+					DueDate:     task.DueDate,
+					IsCompleted: &isCompleted,
+				},
+				nil,
+			)
+			if err != nil {
+				result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_AZURE_DEVOPS)
+				return
+			}
+			task.HasBeenReordered = dbTask.HasBeenReordered
+			task.ID = dbTask.ID
+			task.IDOrdering = dbTask.IDOrdering
+			task.IDTaskSection = dbTask.IDTaskSection
+			task.TimeAllocation = dbTask.TimeAllocation
+			tasks = append(tasks, task)
+		}
+	}
+
+	result <- TaskResult{
+		Tasks: tasks,
+	}
+}
+
+// getAzureDevOpsWorkItemTask maps a work item to a task. Work item IDs are only unique within an
+// organization, so the organization is part of the external ID
+func getAzureDevOpsWorkItemTask(workItem AzureDevOpsWorkItem, organization string) *database.Task {
+	title := workItem.Fields.Title
+	body := workItem.Fields.Description
+	task := &database.Task{
+		IDExternal:        fmt.Sprintf("%s/%d", organization, workItem.ID),
+		Deeplink:          fmt.Sprintf("%s/%s/%s/_workitems/edit/%d", AzureDevOpsBaseURL, organization, url.PathEscape(workItem.Fields.TeamProject), workItem.ID),
+		SourceID:          TASK_SOURCE_ID_AZURE_DEVOPS,
+		Title:             &title,
+		Body:              &body,
+		CreatedAtExternal: primitive.NewDateTimeFromTime(workItem.Fields.CreatedDate),
+	}
+	if workItem.Fields.DueDate != nil {
+		dueDate := primitive.NewDateTimeFromTime(*workItem.Fields.DueDate)
+		task.DueDate = &dueDate
+	}
+	return task
+}
+
+// loadProjects refreshes the stored projects for each organization the account has linked, returning
+// the organizations along with the project filter and a map from "{organization}/{project name}" to project ID
+func (azureDevOpsSource AzureDevOpsSource) loadProjects(db *mongo.Database, userID primitive.ObjectID, accountID string, client *http.Client) ([]string, *GithubRepositoryFilter, map[string]string, error) {
+	ctx := context.Background()
+	organizations, err := getAzureDevOpsLinkedOrganizations(ctx, db, userID, accountID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	for _, organization := range organizations {
+		err = azureDevOpsSource.AzureDevOps.refreshProjects(db, userID, accountID, client, organization)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	projectFilter, err := GetAzureDevOpsProjectFilter(ctx, db, userID, accountID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	projects, err := database.GetRepositories(ctx, db, userID, &[]bson.M{{"account_id": accountID}})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	projectIDs := map[string]string{}
+	for _, project := range *projects {
+		projectIDs[project.FullName] = project.RepositoryID
+	}
+	return organizations, projectFilter, projectIDs, nil
+}
+
+func (azureDevOpsSource AzureDevOpsSource) getAssignedWorkItems(client *http.Client, organization string) ([]AzureDevOpsWorkItem, error) {
+	wiqlURL, wiqlClient := azureDevOpsSource.AzureDevOps.getOrganizationURL(client, organization, "/_apis/wit/wiql?api-version="+AzureDevOpsAPIVersion)
+	queryJson, err := json.Marshal(map[string]string{"query": AzureDevOpsOpenWorkItemsQuery})
+	if err != nil {
+		return nil, err
+	}
+	var wiqlResponse AzureDevOpsWIQLResponse
+	err = requestJSON(wiqlClient, "POST", wiqlURL, string(queryJson), &wiqlResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	workItems := []AzureDevOpsWorkItem{}
+	batchURL, batchClient := azureDevOpsSource.AzureDevOps.getOrganizationURL(client, organization, "/_apis/wit/workitemsbatch?api-version="+AzureDevOpsAPIVersion)
+	for start := 0; start < len(wiqlResponse.WorkItems); start += AzureDevOpsWorkItemBatchSize {
+		end := start + AzureDevOpsWorkItemBatchSize
+		if end > len(wiqlResponse.WorkItems) {
+			end = len(wiqlResponse.WorkItems)
+		}
+		batchBody := AzureDevOpsWorkItemsBatchBody{Fields: azureDevOpsWorkItemFields}
+		for _, workItem := range wiqlResponse.WorkItems[start:end] {
+			batchBody.IDs = append(batchBody.IDs, workItem.ID)
+		}
+		batchJson, err := json.Marshal(batchBody)
+		if err != nil {
+			return nil, err
+		}
+		var batchResponse AzureDevOpsWorkItemsResponse
+		err = requestJSON(batchClient, "POST", batchURL, string(batchJson), &batchResponse)
+		if err != nil {
+			return nil, err
+		}
+		workItems = append(workItems, batchResponse.Value...)
+	}
+	return workItems, nil
+}
+
+// GetPullRequests fetches the active PRs the user created or is a reviewer on, in each project
+// included by the account's project filter
+func (azureDevOpsSource AzureDevOpsSource) GetPullRequests(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- PullRequestResult) {
+	client := getAzureDevOpsHttpClient(ctx, db, userID, accountID)
+	logger := logging.GetSentryLogger()
+	organizations, projectFilter, _, err := azureDevOpsSource.loadProjects(db, userID, accountID, client)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to load azure devops projects")
+		result <- emptyPullRequestResultWithSource(err, TASK_SOURCE_ID_AZURE_DEVOPS)
+		return
+	}
+	projects, err := database.GetRepositories(ctx, db, userID, &[]bson.M{{"account_id": accountID}})
+	if err != nil {
+		result <- emptyPullRequestResultWithSource(err, TASK_SOURCE_ID_AZURE_DEVOPS)
+		return
+	}
+
+	var pullRequests []*database.PullRequest
+	for _, organization := range organizations {
+		// identities are per organization, so the user's ID is looked up in each one
+		connectionData, err := azureDevOpsSource.AzureDevOps.getConnectionData(client, organization)
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to fetch azure devops user")
+			result <- emptyPullRequestResultWithSource(err, TASK_SOURCE_ID_AZURE_DEVOPS)
+			return
+		}
+		azureUserID := connectionData.AuthenticatedUser.ID
+		for _, project := range *projects {
+			projectOrganization, projectName, _ := strings.Cut(project.FullName, "/")
+			if projectOrganization != organization || !projectFilter.IncludesID(project.RepositoryID) {
+				continue
+			}
+			projectPullRequests, err := azureDevOpsSource.getUserPullRequests(client, organization, projectName, azureUserID)
+			if err != nil {
+				logger.Error().Err(err).Msg("failed to fetch azure devops pull requests")
+				result <- emptyPullRequestResultWithSource(err, TASK_SOURCE_ID_AZURE_DEVOPS)
+				return
+			}
+			for _, pullRequest := range projectPullRequests {
+				policyEvaluations, err := azureDevOpsSource.getPolicyEvaluations(client, organization, pullRequest)
+				if err != nil {
+					logger.Error().Err(err).Msg("failed to fetch azure devops policy evaluations")
+					result <- emptyPullRequestResultWithSource(err, TASK_SOURCE_ID_AZURE_DEVOPS)
+					return
+				}
+				dbPR := getAzureDevOpsPullRequest(pullRequest, organization, azureUserID, policyEvaluations)
+				dbPR.UserID = userID
+				dbPR.SourceAccountID = accountID
+				isCompleted := false
+				dbPR.IsCompleted = &isCompleted
+				savedPR, err := database.UpdateOrCreatePullRequest(
+					ctx,
+					db,
+					userID,
+					dbPR.IDExternal,
+					dbPR.SourceID,
+					dbPR,
+					nil)
+				if err != nil {
+					logger.Error().Err(err).Msg("failed to update or create pull request")
+					result <- emptyPullRequestResultWithSource(err, TASK_SOURCE_ID_AZURE_DEVOPS)
+					return
+				}
+				dbPR.ID = savedPR.ID
+				dbPR.IDOrdering = savedPR.IDOrdering
+				pullRequests = append(pullRequests, dbPR)
+			}
+		}
+	}
+
+	result <- PullRequestResult{
+		PullRequests: pullRequests,
+		SourceID:     TASK_SOURCE_ID_AZURE_DEVOPS,
+	}
+}
+
+// getUserPullRequests returns the project's active PRs created by or assigned for review to the user
+func (azureDevOpsSource AzureDevOpsSource) getUserPullRequests(client *http.Client, organization string, project string, azureUserID string) ([]AzureDevOpsPullRequest, error) {
+	pullRequests := []AzureDevOpsPullRequest{}
+	seen := map[int]bool{}
+	for _, criteria := range []string{"creatorId", "reviewerId"} {
+		pullRequestsURL, pullRequestsClient := azureDevOpsSource.AzureDevOps.getOrganizationURL(client, organization, fmt.Sprintf(
+			"/%s/_apis/git/pullrequests?searchCriteria.status=active&searchCriteria.%s=%s&api-version=%s",
+			url.PathEscape(project), criteria, url.QueryEscape(azureUserID), AzureDevOpsAPIVersion,
+		))
+		var response AzureDevOpsPullRequestsResponse
+		err := getJSON(pullRequestsClient, pullRequestsURL, &response)
+		if err != nil {
+			return nil, err
+		}
+		for _, pullRequest := range response.Value {
+			if seen[pullRequest.PullRequestID] {
+				continue
+			}
+			seen[pullRequest.PullRequestID] = true
+			pullRequests = append(pullRequests, pullRequest)
+		}
+	}
+	return pullRequests, nil
+}
+
+func (azureDevOpsSource AzureDevOpsSource) getPolicyEvaluations(client *http.Client, organization string, pullRequest AzureDevOpsPullRequest) ([]AzureDevOpsPolicyEvaluation, error) {
+	project := pullRequest.Repository.Project
+	artifactID := fmt.Sprintf("vstfs:///CodeReview/CodeReviewId/%s/%d", project.ID, pullRequest.PullRequestID)
+	evaluationsURL, evaluationsClient := azureDevOpsSource.AzureDevOps.getOrganizationURL(client, organization, fmt.Sprintf(
+		"/%s/_apis/policy/evaluations?artifactId=%s&api-version=%s-preview.1",
+		url.PathEscape(project.Name), url.QueryEscape(artifactID), AzureDevOpsAPIVersion,
+	))
+	var response AzureDevOpsPolicyEvaluationsResponse
+	err := getJSON(evaluationsClient, evaluationsURL, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Value, nil
+}
+
+// getAzureDevOpsPullRequest maps a PR to the Github PR model. Branch policies stand in for Github's
+// required checks, with build policies as CI and the other blocking policies deciding approval
+func getAzureDevOpsPullRequest(pullRequest AzureDevOpsPullRequest, organization string, azureUserID string, policyEvaluations []AzureDevOpsPolicyEvaluation) *database.PullRequest {
+	project := pullRequest.Repository.Project
+	return &database.PullRequest{
+		IDExternal:        fmt.Sprintf("%s/%d", organization, pullRequest.PullRequestID),
+		Deeplink:          fmt.Sprintf("%s/%s/%s/_git/%s/pullrequest/%d", AzureDevOpsBaseURL, organization, url.PathEscape(project.Name), url.PathEscape(pullRequest.Repository.Name), pullRequest.PullRequestID),
+		SourceID:          TASK_SOURCE_ID_AZURE_DEVOPS,
+		Title:             pullRequest.Title,
+		Body:              pullRequest.Description,
+		CreatedAtExternal: primitive.NewDateTimeFromTime(pullRequest.CreationDate),
+		RepositoryID:      pullRequest.Repository.ID,
+		RepositoryName:    project.Name + "/" + pullRequest.Repository.Name,
+		Number:            pullRequest.PullRequestID,
+		Author:            pullRequest.CreatedBy.DisplayName,
+		Branch:            strings.TrimPrefix(pullRequest.SourceRefName, "refs/heads/"),
+		BaseBranch:        strings.TrimPrefix(pullRequest.TargetRefName, "refs/heads/"),
+		RequiredAction:    getAzureDevOpsPullRequestRequiredAction(pullRequest, azureUserID, policyEvaluations),
+	}
+}
+
+func getAzureDevOpsPullRequestRequiredAction(pullRequest AzureDevOpsPullRequest, azureUserID string, policyEvaluations []AzureDevOpsPolicyEvaluation) string {
+	policiesApproved := true
+	checksDidFail := false
+	checksDidFinish := true
+	for _, evaluation := range policyEvaluations {
+		if !evaluation.Configuration.IsEnabled {
+			continue
+		}
+		isBuild := evaluation.Configuration.Type.DisplayName == AzureDevOpsPolicyTypeBuild
+		switch evaluation.Status {
+		case AzureDevOpsPolicyStatusQueued, AzureDevOpsPolicyStatusRunning:
+			if isBuild {
+				checksDidFinish = false
+			}
+		case AzureDevOpsPolicyStatusRejected, AzureDevOpsPolicyStatusBroken:
+			if isBuild && evaluation.Configuration.IsBlocking {
+				checksDidFail = true
+			}
+		}
+		if !isBuild && evaluation.Configuration.IsBlocking && evaluation.Status != AzureDevOpsPolicyStatusApproved && evaluation.Status != AzureDevOpsPolicyStatusNotApplicable {
+			policiesApproved = false
+		}
+	}
+
+	hasApproval := false
+	haveRequestedChanges := false
+	userIsReviewer := false
+	for _, reviewer := range pullRequest.Reviewers {
+		if reviewer.Vote >= AzureDevOpsVoteApprovedWithSuggestions {
+			hasApproval = true
+		}
+		if reviewer.Vote < AzureDevOpsVoteNoVote {
+			haveRequestedChanges = true
+		}
+		if reviewer.ID == azureUserID && reviewer.Vote == AzureDevOpsVoteNoVote {
+			userIsReviewer = true
+		}
+	}
+
+	return getPullRequestRequiredAction(GithubPRData{
+		RequestedReviewers:   len(pullRequest.Reviewers),
+		IsMergeable:          pullRequest.MergeStatus != AzureDevOpsMergeStatusConflicts,
+		IsApproved:           policiesApproved && hasApproval,
+		HaveRequestedChanges: haveRequestedChanges,
+		ChecksDidFail:        checksDidFail,
+		ChecksDidFinish:      checksDidFinish,
+		IsOwnedByUser:        pullRequest.CreatedBy.ID == azureUserID,
+		UserIsReviewer:       userIsReviewer,
+	})
+}
+
+// ModifyTask moves completed work items to their type's completed state, since state names vary by
+// process template. Reopened work items go back to the type's first proposed state
+func (azureDevOpsSource AzureDevOpsSource) ModifyTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, issueID string, updateFields *database.Task, task *database.Task) error {
+	organization, workItemID, found := strings.Cut(issueID, "/")
+	if !found {
+		return errors.New("invalid azure devops work item id")
+	}
+	client := getAzureDevOpsHttpClient(ctx, db, userID, accountID)
+	operations := []AzureDevOpsPatchOperation{}
+	if updateFields.Title != nil {
+		operations = append(operations, AzureDevOpsPatchOperation{Op: "add", Path: "/fields/System.Title", Value: *updateFields.Title})
+	}
+	if updateFields.Body != nil {
+		operations = append(operations, AzureDevOpsPatchOperation{Op: "add", Path: "/fields/System.Description", Value: *updateFields.Body})
+	}
+	if updateFields.IsCompleted != nil {
+		stateCategory := AzureDevOpsStateCategoryProposed
+		if *updateFields.IsCompleted {
+			stateCategory = AzureDevOpsStateCategoryCompleted
+		}
+		state, err := azureDevOpsSource.getWorkItemState(client, organization, workItemID, stateCategory)
+		if err != nil {
+			logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch azure devops work item states")
+			return err
+		}
+		operations = append(operations, AzureDevOpsPatchOperation{Op: "add", Path: "/fields/System.State", Value: state})
+	}
+	if len(operations) == 0 {
+		return nil
+	}
+
+	operationsJson, err := json.Marshal(operations)
+	if err != nil {
+		return err
+	}
+	updateURL, updateClient := azureDevOpsSource.AzureDevOps.getOrganizationURL(client, organization, fmt.Sprintf("/_apis/wit/workitems/%s?api-version=%s", workItemID, AzureDevOpsAPIVersion))
+	err = requestJSONWithContentType(updateClient, "PATCH", updateURL, "application/json-patch+json", string(operationsJson), EmptyResponsePlaceholder)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to update azure devops work item")
+	}
+	return err
+}
+
+// getWorkItemState returns the first state in the category for the work item's type
+func (azureDevOpsSource AzureDevOpsSource) getWorkItemState(client *http.Client, organization string, workItemID string, category string) (string, error) {
+	workItemURL, workItemClient := azureDevOpsSource.AzureDevOps.getOrganizationURL(client, organization, fmt.Sprintf(
+		"/_apis/wit/workitems/%s?fields=System.TeamProject,System.WorkItemType&api-version=%s", workItemID, AzureDevOpsAPIVersion,
+	))
+	var workItem AzureDevOpsWorkItem
+	err := getJSON(workItemClient, workItemURL, &workItem)
+	if err != nil {
+		return "", err
+	}
+	statesURL, statesClient := azureDevOpsSource.AzureDevOps.getOrganizationURL(client, organization, fmt.Sprintf(
+		"/%s/_apis/wit/workitemtypes/%s/states?api-version=%s",
+		url.PathEscape(workItem.Fields.TeamProject), url.PathEscape(workItem.Fields.WorkItemType), AzureDevOpsAPIVersion,
+	))
+	var states AzureDevOpsWorkItemStatesResponse
+	err = getJSON(statesClient, statesURL, &states)
+	if err != nil {
+		return "", err
+	}
+	return getAzureDevOpsStateForCategory(states.Value, category)
+}
+
+func getAzureDevOpsStateForCategory(states []AzureDevOpsWorkItemState, category string) (string, error) {
+	for _, state := range states {
+		if state.Category == category {
+			return state.Name, nil
+		}
+	}
+	return "", fmt.Errorf("work item type has no %s state", category)
+}
+
+func (azureDevOpsSource AzureDevOpsSource) CreateNewTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, task TaskCreationObject) (primitive.ObjectID, error) {
+	return primitive.NilObjectID, errors.New("has not been implemented yet")
+}
+
+func (azureDevOpsSource AzureDevOpsSource) CreateNewEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error {
+	return errors.New("has not been implemented yet")
+}
+
+func (azureDevOpsSource AzureDevOpsSource) ModifyEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, eventID string, updateFields *EventModifyObject) error {
+	return errors.New("has not been implemented yet")
+}
+
+func (azureDevOpsSource AzureDevOpsSource) DeleteEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error {
+	return errors.New("has not been implemented yet")
+}
+
+func (azureDevOpsSource AzureDevOpsSource) AddComment(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error {
+	return errors.New("has not been implemented yet")
+}
@@ -0,0 +1,149 @@
+package external
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGetAzureDevOpsWorkItemTask(t *testing.T) {
+	createdDate := time.Date(2023, time.March, 1, 9, 0, 0, 0, time.UTC)
+	dueDate := time.Date(2023, time.March, 8, 0, 0, 0, 0, time.UTC)
+	workItem := AzureDevOpsWorkItem{ID: 42, Fields: AzureDevOpsWorkItemFields{
+		Title:       "Fix the login page",
+		Description: "<div>It's broken</div>",
+		TeamProject: "Web App",
+		CreatedDate: createdDate,
+	}}
+
+	t.Run("NoDueDate", func(t *testing.T) {
+		task := getAzureDevOpsWorkItemTask(workItem, "contoso")
+		assert.Equal(t, "contoso/42", task.IDExternal)
+		assert.Equal(t, "https://dev.azure.com/contoso/Web%20App/_workitems/edit/42", task.Deeplink)
+		assert.Equal(t, TASK_SOURCE_ID_AZURE_DEVOPS, task.SourceID)
+		assert.Equal(t, "Fix the login page", *task.Title)
+		assert.Equal(t, "<div>It's broken</div>", *task.Body)
+		assert.Equal(t, primitive.NewDateTimeFromTime(createdDate), task.CreatedAtExternal)
+		assert.Nil(t, task.DueDate)
+	})
+	t.Run("DueDate", func(t *testing.T) {
+		workItem.Fields.DueDate = &dueDate
+		task := getAzureDevOpsWorkItemTask(workItem, "contoso")
+		assert.Equal(t, primitive.NewDateTimeFromTime(dueDate), *task.DueDate)
+	})
+}
+
+func TestGetAzureDevOpsStateForCategory(t *testing.T) {
+	states := []AzureDevOpsWorkItemState{
+		{Name: "New", Category: AzureDevOpsStateCategoryProposed},
+		{Name: "Active", Category: "InProgress"},
+		{Name: "Closed", Category: AzureDevOpsStateCategoryCompleted},
+		{Name: "Removed", Category: "Removed"},
+	}
+	state, err := getAzureDevOpsStateForCategory(states, AzureDevOpsStateCategoryCompleted)
+	assert.NoError(t, err)
+	assert.Equal(t, "Closed", state)
+	state, err = getAzureDevOpsStateForCategory(states, AzureDevOpsStateCategoryProposed)
+	assert.NoError(t, err)
+	assert.Equal(t, "New", state)
+	_, err = getAzureDevOpsStateForCategory(states[1:2], AzureDevOpsStateCategoryCompleted)
+	assert.EqualError(t, err, "work item type has no Completed state")
+}
+
+func getAzureDevOpsPolicyEvaluation(displayName string, status string) AzureDevOpsPolicyEvaluation {
+	evaluation := AzureDevOpsPolicyEvaluation{Status: status}
+	evaluation.Configuration.IsBlocking = true
+	evaluation.Configuration.IsEnabled = true
+	evaluation.Configuration.Type.DisplayName = displayName
+	return evaluation
+}
+
+func TestGetAzureDevOpsPullRequestRequiredAction(t *testing.T) {
+	userID := "user-guid"
+	reviewerID := "reviewer-guid"
+	getPullRequest := func(createdBy string, reviewers ...AzureDevOpsReviewer) AzureDevOpsPullRequest {
+		pullRequest := AzureDevOpsPullRequest{MergeStatus: "succeeded", Reviewers: reviewers}
+		pullRequest.CreatedBy.ID = createdBy
+		return pullRequest
+	}
+	getReviewer := func(id string, vote int) AzureDevOpsReviewer {
+		reviewer := AzureDevOpsReviewer{Vote: vote}
+		reviewer.ID = id
+		return reviewer
+	}
+	minimumReviewers := getAzureDevOpsPolicyEvaluation("Minimum number of reviewers", AzureDevOpsPolicyStatusApproved)
+	buildPassed := getAzureDevOpsPolicyEvaluation(AzureDevOpsPolicyTypeBuild, AzureDevOpsPolicyStatusApproved)
+
+	t.Run("AddReviewers", func(t *testing.T) {
+		action := getAzureDevOpsPullRequestRequiredAction(getPullRequest(userID), userID, nil)
+		assert.Equal(t, ActionAddReviewers, action)
+	})
+	t.Run("FixFailedCI", func(t *testing.T) {
+		buildFailed := getAzureDevOpsPolicyEvaluation(AzureDevOpsPolicyTypeBuild, AzureDevOpsPolicyStatusRejected)
+		action := getAzureDevOpsPullRequestRequiredAction(getPullRequest(userID, getReviewer(reviewerID, 10)), userID, []AzureDevOpsPolicyEvaluation{buildFailed})
+		assert.Equal(t, ActionFixFailedCI, action)
+	})
+	t.Run("OptionalBuildFailureIgnored", func(t *testing.T) {
+		buildFailed := getAzureDevOpsPolicyEvaluation(AzureDevOpsPolicyTypeBuild, AzureDevOpsPolicyStatusRejected)
+		buildFailed.Configuration.IsBlocking = false
+		action := getAzureDevOpsPullRequestRequiredAction(getPullRequest(userID, getReviewer(reviewerID, 10)), userID, []AzureDevOpsPolicyEvaluation{buildFailed, minimumReviewers})
+		assert.Equal(t, ActionMergePR, action)
+	})
+	t.Run("AddressComments", func(t *testing.T) {
+		action := getAzureDevOpsPullRequestRequiredAction(getPullRequest(userID, getReviewer(reviewerID, -5)), userID, []AzureDevOpsPolicyEvaluation{buildPassed})
+		assert.Equal(t, ActionAddressComments, action)
+	})
+	t.Run("FixMergeConflicts", func(t *testing.T) {
+		pullRequest := getPullRequest(userID, getReviewer(reviewerID, 10))
+		pullRequest.MergeStatus = AzureDevOpsMergeStatusConflicts
+		action := getAzureDevOpsPullRequestRequiredAction(pullRequest, userID, []AzureDevOpsPolicyEvaluation{buildPassed})
+		assert.Equal(t, ActionFixMergeConflicts, action)
+	})
+	t.Run("WaitingOnCI", func(t *testing.T) {
+		buildRunning := getAzureDevOpsPolicyEvaluation(AzureDevOpsPolicyTypeBuild, AzureDevOpsPolicyStatusRunning)
+		action := getAzureDevOpsPullRequestRequiredAction(getPullRequest(userID, getReviewer(reviewerID, 10)), userID, []AzureDevOpsPolicyEvaluation{buildRunning})
+		assert.Equal(t, ActionWaitingOnCI, action)
+	})
+	t.Run("MergePR", func(t *testing.T) {
+		action := getAzureDevOpsPullRequestRequiredAction(getPullRequest(userID, getReviewer(reviewerID, 5)), userID, []AzureDevOpsPolicyEvaluation{buildPassed, minimumReviewers})
+		assert.Equal(t, ActionMergePR, action)
+	})
+	t.Run("WaitingOnReviewPolicy", func(t *testing.T) {
+		minimumReviewersPending := getAzureDevOpsPolicyEvaluation("Minimum number of reviewers", AzureDevOpsPolicyStatusRejected)
+		action := getAzureDevOpsPullRequestRequiredAction(getPullRequest(userID, getReviewer(reviewerID, 10)), userID, []AzureDevOpsPolicyEvaluation{buildPassed, minimumReviewersPending})
+		assert.Equal(t, ActionWaitingOnReview, action)
+	})
+	t.Run("ReviewPR", func(t *testing.T) {
+		action := getAzureDevOpsPullRequestRequiredAction(getPullRequest(reviewerID, getReviewer(userID, 0)), userID, nil)
+		assert.Equal(t, ActionReviewPR, action)
+	})
+	t.Run("WaitingOnAuthor", func(t *testing.T) {
+		action := getAzureDevOpsPullRequestRequiredAction(getPullRequest(reviewerID, getReviewer(userID, -10)), userID, nil)
+		assert.Equal(t, ActionWaitingOnAuthor, action)
+	})
+}
+
+func TestGetAzureDevOpsPullRequest(t *testing.T) {
+	pullRequest := AzureDevOpsPullRequest{
+		PullRequestID: 7,
+		Title:         "Add dark mode",
+		SourceRefName: "refs/heads/feature/dark-mode",
+		TargetRefName: "refs/heads/main",
+	}
+	pullRequest.CreatedBy.DisplayName = "Sam Doe"
+	pullRequest.Repository.ID = "repo-guid"
+	pullRequest.Repository.Name = "web"
+	pullRequest.Repository.Project = AzureDevOpsProject{ID: "project-guid", Name: "Web App"}
+
+	dbPR := getAzureDevOpsPullRequest(pullRequest, "contoso", "user-guid", nil)
+	assert.Equal(t, "contoso/7", dbPR.IDExternal)
+	assert.Equal(t, "https://dev.azure.com/contoso/Web%20App/_git/web/pullrequest/7", dbPR.Deeplink)
+	assert.Equal(t, TASK_SOURCE_ID_AZURE_DEVOPS, dbPR.SourceID)
+	assert.Equal(t, "Web App/web", dbPR.RepositoryName)
+	assert.Equal(t, 7, dbPR.Number)
+	assert.Equal(t, "Sam Doe", dbPR.Author)
+	assert.Equal(t, "feature/dark-mode", dbPR.Branch)
+	assert.Equal(t, "main", dbPR.BaseBranch)
+}
@@ -0,0 +1,115 @@
+package external
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// bodies past this size aren't stored, so those resources are always fetched in full
+const ConditionalFetchMaxBodySize = 1 << 20
+
+// Github resources that are fetched on every PR refresh but rarely change. Github doesn't count
+// a 304 against the rate limit, so these cost nothing while unchanged
+var GithubConditionalResources = []*regexp.Regexp{
+	regexp.MustCompile(`/user/repos$`),
+	regexp.MustCompile(`/pulls/\d+/reviews$`),
+	regexp.MustCompile(`/pulls/\d+/comments$`),
+	regexp.MustCompile(`/issues/\d+/comments$`),
+	regexp.MustCompile(`/commits/[^/]+/check-runs$`),
+}
+
+// ConditionalFetchTransport sends GETs for matching resources with the ETag of the last response
+// seen for that resource. When the server answers 304 Not Modified, the stored body is replayed
+// as a 200 so callers don't need to know the request was conditional
+type ConditionalFetchTransport struct {
+	Base   http.RoundTripper
+	DB     *mongo.Database
+	UserID primitive.ObjectID
+	// distinguishes credentials, since the same URL returns different results per token
+	Key       string
+	Resources []*regexp.Regexp
+}
+
+func (transport *ConditionalFetchTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	base := transport.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if request.Method != http.MethodGet || !transport.matches(request) {
+		return base.RoundTrip(request)
+	}
+
+	logger := logging.GetSentryLogger()
+	cacheKey := transport.Key + " " + request.URL.String()
+	cachedResponse, err := database.GetConditionalResponse(request.Context(), transport.DB, cacheKey)
+	if err != nil && err != mongo.ErrNoDocuments {
+		logger.Error().Err(err).Msg("failed to load conditional response")
+	}
+	if cachedResponse != nil {
+		// RoundTrippers must not modify the caller's request
+		request = request.Clone(request.Context())
+		request.Header.Set("If-None-Match", cachedResponse.ETag)
+	}
+
+	response, err := base.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode == http.StatusNotModified && cachedResponse != nil {
+		response.Body.Close()
+		response.StatusCode = http.StatusOK
+		response.Status = "200 OK"
+		response.Header.Set("Content-Type", "application/json")
+		response.Body = io.NopCloser(bytes.NewReader(cachedResponse.Body))
+		response.ContentLength = int64(len(cachedResponse.Body))
+		return response, nil
+	}
+
+	etag := response.Header.Get("ETag")
+	if response.StatusCode != http.StatusOK || etag == "" {
+		return response, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(response.Body, ConditionalFetchMaxBodySize+1))
+	if err != nil {
+		response.Body.Close()
+		return nil, err
+	}
+	if len(body) > ConditionalFetchMaxBodySize {
+		// stitch the unread remainder back on rather than buffering the whole thing
+		response.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), response.Body), response.Body}
+		return response, nil
+	}
+	response.Body.Close()
+	response.Body = io.NopCloser(bytes.NewReader(body))
+	err = database.UpsertConditionalResponse(request.Context(), transport.DB, &database.ConditionalResponse{
+		UserID:    transport.UserID,
+		CacheKey:  cacheKey,
+		ETag:      etag,
+		Body:      body,
+		UpdatedAt: primitive.NewDateTimeFromTime(time.Now()),
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to save conditional response")
+	}
+	return response, nil
+}
+
+func (transport *ConditionalFetchTransport) matches(request *http.Request) bool {
+	for _, resource := range transport.Resources {
+		if resource.MatchString(request.URL.Path) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package external
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestConditionalFetchTransport(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+
+	etag := `"abc123"`
+	requestCount := 0
+	notModifiedCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount += 1
+		if r.Header.Get("If-None-Match") == etag {
+			notModifiedCount += 1
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"id": 1}]`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &ConditionalFetchTransport{
+		DB:        db,
+		UserID:    primitive.NewObjectID(),
+		Key:       primitive.NewObjectID().Hex(),
+		Resources: []*regexp.Regexp{regexp.MustCompile(`/reviews$`)},
+	}}
+	get := func(path string) (int, string) {
+		response, err := client.Get(server.URL + path)
+		assert.NoError(t, err)
+		defer response.Body.Close()
+		body, err := io.ReadAll(response.Body)
+		assert.NoError(t, err)
+		return response.StatusCode, string(body)
+	}
+
+	t.Run("ReplaysUnchangedResource", func(t *testing.T) {
+		statusCode, body := get("/pulls/1/reviews")
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, `[{"id": 1}]`, body)
+		assert.Equal(t, 0, notModifiedCount)
+
+		statusCode, body = get("/pulls/1/reviews")
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, `[{"id": 1}]`, body)
+		assert.Equal(t, 1, notModifiedCount)
+	})
+	t.Run("IgnoresOtherResources", func(t *testing.T) {
+		requestCount = 0
+		notModifiedCount = 0
+		get("/pulls/1/files")
+		get("/pulls/1/files")
+		assert.Equal(t, 2, requestCount)
+		assert.Equal(t, 0, notModifiedCount)
+	})
+}
+
+func TestGithubConditionalResources(t *testing.T) {
+	transport := ConditionalFetchTransport{Resources: GithubConditionalResources}
+	for path, expected := range map[string]bool{
+		"/user/repos":                              true,
+		"/repos/owner/repo/pulls/1/reviews":        true,
+		"/repos/owner/repo/pulls/1/comments":       true,
+		"/repos/owner/repo/issues/1/comments":      true,
+		"/repos/owner/repo/commits/abc/check-runs": true,
+		"/repos/owner/repo/pulls/1":                false,
+		"/repos/owner/repo/compare/main...dev":     false,
+	} {
+		request := httptest.NewRequest("GET", "https://api.github.com"+path, nil)
+		assert.Equal(t, expected, transport.matches(request), path)
+	}
+}
@@ -2,39 +2,65 @@ package external
 
 import (
 	"fmt"
+
+	"github.com/franchizzle/task-manager/backend/config"
 )
 
 const (
-	TASK_SERVICE_ID_ASANA     = "asana"
-	TASK_SERVICE_ID_ATLASSIAN = "atlassian"
-	TASK_SERVICE_ID_GT        = "gt"
-	TASK_SERVICE_ID_GITHUB    = "github"
-	TASK_SERVICE_ID_GOOGLE    = "google"
-	TASK_SERVICE_ID_LINEAR    = "linear"
-	TASK_SERVICE_ID_SLACK     = "slack"
-	TASK_SERVICE_ID_SLACK_APP = "slack_app"
+	TASK_SERVICE_ID_APPLE        = "apple"
+	TASK_SERVICE_ID_ASANA        = "asana"
+	TASK_SERVICE_ID_ATLASSIAN    = "atlassian"
+	TASK_SERVICE_ID_AZURE_DEVOPS = "azure_devops"
+	TASK_SERVICE_ID_GT           = "gt"
+	TASK_SERVICE_ID_GITHUB       = "github"
+	TASK_SERVICE_ID_GOOGLE       = "google"
+	TASK_SERVICE_ID_INTERCOM     = "intercom"
+	TASK_SERVICE_ID_LINEAR       = "linear"
+	TASK_SERVICE_ID_PAGERDUTY    = "pagerduty"
+	TASK_SERVICE_ID_SALESFORCE   = "salesforce"
+	TASK_SERVICE_ID_SLACK        = "slack"
+	TASK_SERVICE_ID_SLACK_APP    = "slack_app"
+	TASK_SERVICE_ID_ZENDESK      = "zendesk"
 
-	TASK_SOURCE_ID_ASANA       = "asana_task"
-	TASK_SOURCE_ID_GCAL        = "gcal"
-	TASK_SOURCE_ID_GITHUB_PR   = "github_pr"
-	TASK_SOURCE_ID_GT_TASK     = "gt_task"
-	TASK_SOURCE_ID_JIRA        = "jira"
-	TASK_SOURCE_ID_LINEAR      = "linear_task"
-	TASK_SOURCE_ID_SLACK_SAVED = "slack"
+	TASK_SOURCE_ID_APPLE_REMINDERS = "apple_reminders"
+	TASK_SOURCE_ID_ASANA           = "asana_task"
+	TASK_SOURCE_ID_AZURE_DEVOPS    = "azure_devops"
+	TASK_SOURCE_ID_GCAL            = "gcal"
+	TASK_SOURCE_ID_GMAIL           = "gmail"
+	TASK_SOURCE_ID_GOOGLE_TASKS    = "google_tasks"
+	TASK_SOURCE_ID_GITHUB_PR       = "github_pr"
+	TASK_SOURCE_ID_GT_TASK         = "gt_task"
+	TASK_SOURCE_ID_INTERCOM        = "intercom_conversation"
+	TASK_SOURCE_ID_JIRA            = "jira"
+	TASK_SOURCE_ID_LINEAR          = "linear_task"
+	TASK_SOURCE_ID_PAGERDUTY       = "pagerduty_incident"
+	TASK_SOURCE_ID_SALESFORCE      = "salesforce"
+	TASK_SOURCE_ID_SLACK_SAVED     = "slack"
+	TASK_SOURCE_ID_ZENDESK         = "zendesk_ticket"
 )
 
 type Config struct {
 	Github                GithubConfig
 	GoogleLoginConfig     OauthConfigWrapper
 	GoogleAuthorizeConfig OauthConfigWrapper
+	GithubLoginConfig     OauthConfigWrapper
+	MicrosoftLoginConfig  OauthConfigWrapper
 	Slack                 SlackConfig
 	SlackApp              SlackConfig
 	Linear                LinearConfig
 	Asana                 OauthConfigWrapper
 	Atlassian             AtlassianConfig
+	Salesforce            SalesforceConfig
+	Intercom              IntercomConfig
+	AzureDevOps           AzureDevOpsConfig
+	Apple                 AppleConfig
+	PagerDuty             PagerDutyConfig
+	Zendesk               ZendeskConfig
 	SlackOverrideURL      string
 	GoogleOverrideURLs    GoogleURLOverrides
-	OpenAIOverrideURL     string
+	RevokeOverrideURL     string
+	// the Github API URL used when signing in with Github
+	GithubLoginOverrideURL string
 }
 
 func GetConfig() Config {
@@ -42,12 +68,19 @@ func GetConfig() Config {
 	return Config{
 		GoogleLoginConfig:     getGoogleLoginConfig(),
 		GoogleAuthorizeConfig: getGoogleLinkConfig(),
+		GithubLoginConfig:     getGithubLoginConfig(),
+		MicrosoftLoginConfig:  getMicrosoftLoginConfig(),
 		Github:                GithubConfig{OauthConfig: getGithubConfig(), ConfigValues: GithubConfigValues{FetchExternalAPIToken: &fetchToken}},
 		Slack:                 getSlackConfig(),
 		SlackApp:              GetSlackAppConfig(),
 		Linear:                LinearConfig{OauthConfig: getLinearOauthConfig()},
 		Asana:                 getAsanaConfig(),
 		Atlassian:             AtlassianConfig{OauthConfig: getAtlassianOauthConfig()},
+		Salesforce:            SalesforceConfig{OauthConfig: getSalesforceOauthConfig()},
+		Intercom:              IntercomConfig{OauthConfig: getIntercomOauthConfig()},
+		AzureDevOps:           AzureDevOpsConfig{OauthConfig: getAzureDevOpsOauthConfig()},
+		Zendesk:               ZendeskConfig{OauthConfig: getZendeskOauthConfig()},
+		PagerDuty:             PagerDutyConfig{OauthConfig: getPagerDutyOauthConfig()},
 	}
 }
 
@@ -80,106 +113,75 @@ func (config Config) GetSourceResult(sourceID string) (*TaskSourceResult, error)
 	return &result, nil
 }
 
-func (config Config) getNameToSource() map[string]TaskSourceResult {
-	asanaService := AsanaService{Config: config.Asana}
-	atlassianService := AtlassianService{Config: config.Atlassian}
-	googleService := GoogleService{
-		LoginConfig:  config.GoogleLoginConfig,
-		LinkConfig:   config.GoogleAuthorizeConfig,
-		OverrideURLs: config.GoogleOverrideURLs,
+// GetMisconfiguredOauthConfigs returns the names of oauth configs missing a client ID or secret.
+// Configs which aren't backed by an oauth2 config, like test mocks, are skipped
+func (config Config) GetMisconfiguredOauthConfigs() []string {
+	oauthConfigs := []struct {
+		name        string
+		oauthConfig OauthConfigWrapper
+	}{
+		{"google_login", config.GoogleLoginConfig},
+		{"google_authorize", config.GoogleAuthorizeConfig},
+		{"github_login", config.GithubLoginConfig},
+		{"microsoft_login", config.MicrosoftLoginConfig},
+		{TASK_SERVICE_ID_GITHUB, config.Github.OauthConfig},
+		{TASK_SERVICE_ID_SLACK, config.Slack.OauthConfig},
+		{TASK_SERVICE_ID_SLACK_APP, config.SlackApp.OauthConfig},
+		{TASK_SERVICE_ID_LINEAR, config.Linear.OauthConfig},
+		{TASK_SERVICE_ID_ASANA, config.Asana},
+		{TASK_SERVICE_ID_ATLASSIAN, config.Atlassian.OauthConfig},
+	}
+	misconfigured := []string{}
+	for _, entry := range oauthConfigs {
+		oauthConfig, ok := entry.oauthConfig.(*OauthConfig)
+		if !ok || oauthConfig == nil {
+			continue
+		}
+		if oauthConfig.Config == nil || oauthConfig.Config.ClientID == "" || oauthConfig.Config.ClientSecret == "" {
+			misconfigured = append(misconfigured, entry.name)
+		}
 	}
-	linearService := LinearService{Config: config.Linear}
-	githubService := GithubService{Config: config.Github}
-	slackService := SlackService{Config: config.Slack}
+	return misconfigured
+}
 
-	return map[string]TaskSourceResult{
-		TASK_SOURCE_ID_ASANA: {
-			Details: TaskSourceAsana,
-			Source:  AsanaTaskSource{Asana: asanaService},
-		},
-		TASK_SOURCE_ID_GCAL: {
-			Details: TaskSourceGoogleCalendar,
-			Source:  GoogleCalendarSource{Google: googleService},
-		},
-		TASK_SOURCE_ID_GT_TASK: {
-			Details: TaskSourceGeneralTask,
-			Source:  GeneralTaskTaskSource{},
-		},
-		TASK_SOURCE_ID_JIRA: {
-			Details: TaskSourceJIRA,
-			Source:  JIRASource{Atlassian: atlassianService},
-		},
-		TASK_SOURCE_ID_LINEAR: {
-			Details: TaskSourceLinear,
-			Source:  LinearTaskSource{Linear: linearService},
-		},
-		TASK_SOURCE_ID_GITHUB_PR: {
-			Details: TaskSourceGithubPR,
-			Source:  GithubPRSource{Github: githubService},
-		},
-		TASK_SOURCE_ID_SLACK_SAVED: {
-			Details: TaskSourceSlackSaved,
-			Source:  SlackSavedTaskSource{Slack: slackService},
-		},
+func (config Config) getNameToSource() map[string]TaskSourceResult {
+	nameToSource := map[string]TaskSourceResult{}
+	for _, registration := range GetRegisteredTaskSources() {
+		nameToSource[registration.Details.ID] = TaskSourceResult{
+			Details: registration.Details,
+			Source:  registration.New(config),
+		}
 	}
+	return nameToSource
 }
 
 func (config Config) GetNameToService() map[string]TaskServiceResult {
-	asanaService := AsanaService{Config: config.Asana}
-	atlassianService := AtlassianService{Config: config.Atlassian}
-	linearService := LinearService{Config: config.Linear}
-	googleService := GoogleService{
-		LoginConfig:  config.GoogleLoginConfig,
-		LinkConfig:   config.GoogleAuthorizeConfig,
-		OverrideURLs: config.GoogleOverrideURLs,
+	nameToService := map[string]TaskServiceResult{}
+	for _, registration := range GetRegisteredTaskServices() {
+		nameToService[registration.ServiceID] = TaskServiceResult{
+			Service: registration.New(config),
+			Details: registration.Details,
+			Sources: config.getServiceSources(registration.ServiceID),
+		}
 	}
-	githubService := GithubService{Config: config.Github}
-	slackService := SlackService{Config: config.Slack}
+	return nameToService
+}
 
-	return map[string]TaskServiceResult{
-		TASK_SERVICE_ID_ASANA: {
-			Service: asanaService,
-			Details: TaskServiceAsana,
-			Sources: []TaskSourceResult{{Source: AsanaTaskSource{Asana: asanaService}, Details: TaskSourceAsana}},
-		},
-		TASK_SERVICE_ID_ATLASSIAN: {
-			Service: atlassianService,
-			Details: TaskServiceAtlassian,
-			Sources: []TaskSourceResult{{Source: JIRASource{Atlassian: atlassianService}, Details: TaskSourceJIRA}},
-		},
-		TASK_SERVICE_ID_GT: {
-			Service: GeneralTaskService{},
-			Details: TaskServiceGeneralTask,
-			Sources: []TaskSourceResult{{Source: GeneralTaskTaskSource{}, Details: TaskSourceGeneralTask}},
-		},
-		TASK_SERVICE_ID_GOOGLE: {
-			Service: googleService,
-			Details: TaskServiceGoogle,
-			Sources: []TaskSourceResult{
-				{Source: GoogleCalendarSource{Google: googleService}, Details: TaskSourceGoogleCalendar},
-			},
-		},
-		TASK_SERVICE_ID_SLACK: {
-			Service: SlackService{Config: config.Slack},
-			Details: TaskServiceSlack,
-			Sources: []TaskSourceResult{{Source: SlackSavedTaskSource{Slack: slackService}, Details: TaskSourceSlackSaved}},
-		},
-		TASK_SERVICE_ID_SLACK_APP: {
-			Service: SlackService{Config: config.SlackApp},
-			Details: TaskServiceSlack,
-			Sources: []TaskSourceResult{},
-		},
-		TASK_SERVICE_ID_GITHUB: {
-			Service: githubService,
-			Details: TaskServiceGithub,
-			Sources: []TaskSourceResult{{Source: GithubPRSource{Github: githubService}, Details: TaskSourceGithubPR}},
-		},
-		TASK_SERVICE_ID_LINEAR: {
-			Service: linearService,
-			Details: TaskServiceLinear,
-			Sources: []TaskSourceResult{{Source: LinearTaskSource{Linear: linearService}, Details: TaskSourceLinear}},
-		},
+// IsTaskServiceConfigured is false for the optional services a deployment hasn't set up, which
+// shouldn't be offered for linking
+func IsTaskServiceConfigured(serviceID string) bool {
+	settings := config.GetSettings()
+	switch serviceID {
+	case TASK_SERVICE_ID_ZENDESK:
+		return settings.ZendeskOauth.ClientID != "" && settings.ZendeskSubdomain != ""
+	case TASK_SERVICE_ID_SALESFORCE:
+		return settings.SalesforceOauth.ClientID != ""
+	case TASK_SERVICE_ID_INTERCOM:
+		return settings.IntercomOauth.ClientID != ""
+	case TASK_SERVICE_ID_PAGERDUTY:
+		return settings.PagerDutyOauth.ClientID != ""
 	}
+	return true
 }
 
 type AuthType string
@@ -187,6 +189,9 @@ type AuthType string
 var AuthTypeOauth2 AuthType = "oauth2"
 var AuthTypeOauth1 AuthType = "oauth1"
 
+// AuthTypeAppPassword is for services linked with credentials the user creates for us, rather than through a redirect
+var AuthTypeAppPassword AuthType = "app_password"
+
 type TaskServiceDetails struct {
 	ID           string
 	Name         string
@@ -260,6 +265,62 @@ var TaskServiceLinear = TaskServiceDetails{
 	IsLinkable:   true,
 	IsSignupable: false,
 }
+var TaskServiceZendesk = TaskServiceDetails{
+	ID:           TASK_SERVICE_ID_ZENDESK,
+	Name:         "Zendesk",
+	Logo:         "/images/zendesk.svg",
+	LogoV2:       "zendesk",
+	AuthType:     AuthTypeOauth2,
+	IsLinkable:   true,
+	IsSignupable: false,
+}
+var TaskServiceAzureDevOps = TaskServiceDetails{
+	ID:           TASK_SERVICE_ID_AZURE_DEVOPS,
+	Name:         "Azure DevOps",
+	Logo:         "/images/azure_devops.svg",
+	LogoV2:       "azure_devops",
+	AuthType:     AuthTypeOauth2,
+	IsLinkable:   true,
+	IsSignupable: false,
+}
+
+// TaskServiceApple isn't linkable through the link redirect, since it's linked with an app-specific password
+var TaskServiceApple = TaskServiceDetails{
+	ID:           TASK_SERVICE_ID_APPLE,
+	Name:         "Apple",
+	Logo:         "/images/apple.svg",
+	LogoV2:       "apple",
+	AuthType:     AuthTypeAppPassword,
+	IsLinkable:   false,
+	IsSignupable: false,
+}
+var TaskServicePagerDuty = TaskServiceDetails{
+	ID:           TASK_SERVICE_ID_PAGERDUTY,
+	Name:         "PagerDuty",
+	Logo:         "/images/pagerduty.svg",
+	LogoV2:       "pagerduty",
+	AuthType:     AuthTypeOauth2,
+	IsLinkable:   true,
+	IsSignupable: false,
+}
+var TaskServiceIntercom = TaskServiceDetails{
+	ID:           TASK_SERVICE_ID_INTERCOM,
+	Name:         "Intercom",
+	Logo:         "/images/intercom.svg",
+	LogoV2:       "intercom",
+	AuthType:     AuthTypeOauth2,
+	IsLinkable:   true,
+	IsSignupable: false,
+}
+var TaskServiceSalesforce = TaskServiceDetails{
+	ID:           TASK_SERVICE_ID_SALESFORCE,
+	Name:         "Salesforce",
+	Logo:         "/images/salesforce.svg",
+	LogoV2:       "salesforce",
+	AuthType:     AuthTypeOauth2,
+	IsLinkable:   true,
+	IsSignupable: false,
+}
 
 type TaskSourceDetails struct {
 	ID                     string
@@ -270,6 +331,8 @@ type TaskSourceDetails struct {
 	CanCreateTask          bool
 	IsReplyable            bool
 	CanCreateCalendarEvent bool
+	SupportsComments       bool
+	SupportsEvents         bool
 }
 
 var TaskSourceAsana = TaskSourceDetails{
@@ -281,6 +344,8 @@ var TaskSourceAsana = TaskSourceDetails{
 	CanCreateTask:          false,
 	IsReplyable:            false,
 	CanCreateCalendarEvent: false,
+	SupportsComments:       false,
+	SupportsEvents:         false,
 }
 var TaskSourceGeneralTask = TaskSourceDetails{
 	ID:                     TASK_SOURCE_ID_GT_TASK,
@@ -291,6 +356,8 @@ var TaskSourceGeneralTask = TaskSourceDetails{
 	CanCreateTask:          true,
 	IsReplyable:            false,
 	CanCreateCalendarEvent: false,
+	SupportsComments:       false,
+	SupportsEvents:         false,
 }
 var TaskSourceGoogleCalendar = TaskSourceDetails{
 	ID:                     TASK_SOURCE_ID_GCAL,
@@ -301,6 +368,32 @@ var TaskSourceGoogleCalendar = TaskSourceDetails{
 	CanCreateTask:          false,
 	IsReplyable:            false,
 	CanCreateCalendarEvent: true,
+	SupportsComments:       false,
+	SupportsEvents:         true,
+}
+var TaskSourceGmail = TaskSourceDetails{
+	ID:                     TASK_SOURCE_ID_GMAIL,
+	Name:                   "Gmail",
+	Logo:                   "/images/gmail.svg",
+	LogoV2:                 "gmail",
+	IsCompletable:          true,
+	CanCreateTask:          false,
+	IsReplyable:            false,
+	CanCreateCalendarEvent: false,
+	SupportsComments:       false,
+	SupportsEvents:         false,
+}
+var TaskSourceGoogleTasks = TaskSourceDetails{
+	ID:                     TASK_SOURCE_ID_GOOGLE_TASKS,
+	Name:                   "Google Tasks",
+	Logo:                   "/images/google_tasks.svg",
+	LogoV2:                 "google_tasks",
+	IsCompletable:          true,
+	CanCreateTask:          false,
+	IsReplyable:            false,
+	CanCreateCalendarEvent: false,
+	SupportsComments:       false,
+	SupportsEvents:         false,
 }
 var TaskSourceGithubPR = TaskSourceDetails{
 	ID:                     TASK_SOURCE_ID_GITHUB_PR,
@@ -311,6 +404,8 @@ var TaskSourceGithubPR = TaskSourceDetails{
 	CanCreateTask:          false,
 	IsReplyable:            false,
 	CanCreateCalendarEvent: false,
+	SupportsComments:       false,
+	SupportsEvents:         false,
 }
 var TaskSourceJIRA = TaskSourceDetails{
 	ID:                     TASK_SOURCE_ID_JIRA,
@@ -321,6 +416,8 @@ var TaskSourceJIRA = TaskSourceDetails{
 	CanCreateTask:          false,
 	IsReplyable:            false,
 	CanCreateCalendarEvent: false,
+	SupportsComments:       false,
+	SupportsEvents:         false,
 }
 var TaskSourceLinear = TaskSourceDetails{
 	ID:                     TASK_SOURCE_ID_LINEAR,
@@ -331,6 +428,8 @@ var TaskSourceLinear = TaskSourceDetails{
 	CanCreateTask:          false,
 	IsReplyable:            false,
 	CanCreateCalendarEvent: false,
+	SupportsComments:       true,
+	SupportsEvents:         false,
 }
 var TaskSourceSlackSaved = TaskSourceDetails{
 	ID:                     TASK_SOURCE_ID_SLACK_SAVED,
@@ -341,4 +440,78 @@ var TaskSourceSlackSaved = TaskSourceDetails{
 	CanCreateTask:          true,
 	IsReplyable:            false,
 	CanCreateCalendarEvent: false,
+	SupportsComments:       false,
+	SupportsEvents:         false,
+}
+var TaskSourceAppleReminders = TaskSourceDetails{
+	ID:                     TASK_SOURCE_ID_APPLE_REMINDERS,
+	Name:                   "Apple Reminders",
+	Logo:                   "/images/apple.svg",
+	LogoV2:                 "apple",
+	IsCompletable:          true,
+	CanCreateTask:          false,
+	IsReplyable:            false,
+	CanCreateCalendarEvent: false,
+	SupportsComments:       false,
+	SupportsEvents:         false,
+}
+var TaskSourcePagerDuty = TaskSourceDetails{
+	ID:                     TASK_SOURCE_ID_PAGERDUTY,
+	Name:                   "PagerDuty",
+	Logo:                   "/images/pagerduty.svg",
+	LogoV2:                 "pagerduty",
+	IsCompletable:          true,
+	CanCreateTask:          false,
+	IsReplyable:            false,
+	CanCreateCalendarEvent: false,
+	SupportsComments:       false,
+	SupportsEvents:         true,
+}
+var TaskSourceZendesk = TaskSourceDetails{
+	ID:                     TASK_SOURCE_ID_ZENDESK,
+	Name:                   "Zendesk",
+	Logo:                   "/images/zendesk.svg",
+	LogoV2:                 "zendesk",
+	IsCompletable:          true,
+	CanCreateTask:          false,
+	IsReplyable:            false,
+	CanCreateCalendarEvent: false,
+	SupportsComments:       true,
+	SupportsEvents:         false,
+}
+var TaskSourceSalesforce = TaskSourceDetails{
+	ID:                     TASK_SOURCE_ID_SALESFORCE,
+	Name:                   "Salesforce",
+	Logo:                   "/images/salesforce.svg",
+	LogoV2:                 "salesforce",
+	IsCompletable:          true,
+	CanCreateTask:          false,
+	IsReplyable:            false,
+	CanCreateCalendarEvent: false,
+	SupportsComments:       false,
+	SupportsEvents:         false,
+}
+var TaskSourceIntercom = TaskSourceDetails{
+	ID:                     TASK_SOURCE_ID_INTERCOM,
+	Name:                   "Intercom",
+	Logo:                   "/images/intercom.svg",
+	LogoV2:                 "intercom",
+	IsCompletable:          true,
+	CanCreateTask:          false,
+	IsReplyable:            false,
+	CanCreateCalendarEvent: false,
+	SupportsComments:       false,
+	SupportsEvents:         false,
+}
+var TaskSourceAzureDevOps = TaskSourceDetails{
+	ID:                     TASK_SOURCE_ID_AZURE_DEVOPS,
+	Name:                   "Azure DevOps",
+	Logo:                   "/images/azure_devops.svg",
+	LogoV2:                 "azure_devops",
+	IsCompletable:          true,
+	CanCreateTask:          false,
+	IsReplyable:            false,
+	CanCreateCalendarEvent: false,
+	SupportsComments:       false,
+	SupportsEvents:         false,
 }
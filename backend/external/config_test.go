@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
 )
 
 func TestGetConfig(t *testing.T) {
@@ -19,6 +20,18 @@ func TestGetConfig(t *testing.T) {
 	assert.Equal(t, LinearConfig{OauthConfig: getLinearOauthConfig()}, config.Linear)
 }
 
+func TestGetMisconfiguredOauthConfigs(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		assert.Equal(t, []string{}, GetConfig().GetMisconfiguredOauthConfigs())
+	})
+	t.Run("MissingSecret", func(t *testing.T) {
+		config := GetConfig()
+		config.Slack = SlackConfig{OauthConfig: &OauthConfig{Config: &oauth2.Config{ClientID: "client_id"}}}
+		config.Asana = &OauthConfig{}
+		assert.Equal(t, []string{TASK_SERVICE_ID_SLACK, TASK_SERVICE_ID_ASANA}, config.GetMisconfiguredOauthConfigs())
+	})
+}
+
 func TestGetTaskServiceResult(t *testing.T) {
 	config := GetConfig()
 
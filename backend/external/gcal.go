@@ -15,6 +15,7 @@ import (
 
 	"github.com/franchizzle/task-manager/backend/constants"
 	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/templating"
 	"github.com/franchizzle/task-manager/backend/utils"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
@@ -28,7 +29,38 @@ type GoogleCalendarSource struct {
 	Google GoogleService
 }
 
-func processAndStoreEvent(event *calendar.Event, db *mongo.Database, userID primitive.ObjectID, accountID string, calendarID string, colors *calendar.Colors) *database.CalendarEvent {
+// GoogleEventColors is Google Calendar's fixed palette of event colors, by color ID. Fetched events
+// use the palette from the colors endpoint; this is for updating an event's colors right after
+// changing them, without another request
+var GoogleEventColors = map[string]calendar.ColorDefinition{
+	"1":  {Background: "#a4bdfc", Foreground: "#1d1d1d"},
+	"2":  {Background: "#7ae7bf", Foreground: "#1d1d1d"},
+	"3":  {Background: "#dbadff", Foreground: "#1d1d1d"},
+	"4":  {Background: "#ff887c", Foreground: "#1d1d1d"},
+	"5":  {Background: "#fbd75b", Foreground: "#1d1d1d"},
+	"6":  {Background: "#ffb878", Foreground: "#1d1d1d"},
+	"7":  {Background: "#46d6db", Foreground: "#1d1d1d"},
+	"8":  {Background: "#e1e1e1", Foreground: "#1d1d1d"},
+	"9":  {Background: "#5484ed", Foreground: "#1d1d1d"},
+	"10": {Background: "#51b749", Foreground: "#1d1d1d"},
+	"11": {Background: "#dc2127", Foreground: "#1d1d1d"},
+}
+
+// getEventCategory maps Google's event types to event categories
+func getEventCategory(eventType string) string {
+	switch eventType {
+	case "outOfOffice":
+		return constants.EventCategoryOutOfOffice
+	case "focusTime":
+		return constants.EventCategoryFocusTime
+	case "workingLocation":
+		return constants.EventCategoryWorkingLocation
+	default:
+		return constants.EventCategoryDefault
+	}
+}
+
+func processAndStoreEvent(ctx context.Context, event *calendar.Event, db *mongo.Database, userID primitive.ObjectID, accountID string, calendarID string, colors *calendar.Colors, eventCalendar database.Calendar) *database.CalendarEvent {
 	//exclude all day events which won't have a start time.
 	if len(event.Start.DateTime) == 0 {
 		return &database.CalendarEvent{}
@@ -53,26 +85,33 @@ func processAndStoreEvent(event *calendar.Event, db *mongo.Database, userID prim
 	if calendarID == "primary" {
 		calendarID = accountID
 	}
+	// descriptions arrive as HTML written by anyone who can invite the user
+	body := templating.SanitizeHTML(event.Description)
 	dbEvent := &database.CalendarEvent{
-		UserID:          userID,
-		IDExternal:      event.Id,
-		CalendarID:      calendarID,
-		ColorID:         event.ColorId,
-		Deeplink:        fmt.Sprintf("%s&authuser=%s", event.HtmlLink, accountID),
-		SourceID:        TASK_SOURCE_ID_GCAL,
-		Title:           event.Summary,
-		Body:            event.Description,
-		EventType:       event.EventType,
-		Location:        event.Location,
-		TimeAllocation:  dbEndTime.Sub(dbStartTime).Nanoseconds(),
-		SourceAccountID: accountID,
-		DatetimeEnd:     primitive.NewDateTimeFromTime(dbEndTime),
-		DatetimeStart:   primitive.NewDateTimeFromTime(dbStartTime),
-		CanModify:       canModify,
-		CallURL:         conferenceCall.URL,
-		CallLogo:        conferenceCall.Logo,
-		CallPlatform:    conferenceCall.Platform,
-		AttendeeEmails:  attendeeEmails,
+		UserID:                  userID,
+		IDExternal:              event.Id,
+		CalendarID:              calendarID,
+		ColorID:                 event.ColorId,
+		Deeplink:                fmt.Sprintf("%s&authuser=%s", event.HtmlLink, accountID),
+		SourceID:                TASK_SOURCE_ID_GCAL,
+		Title:                   event.Summary,
+		Body:                    body,
+		EventType:               event.EventType,
+		Category:                getEventCategory(event.EventType),
+		Location:                event.Location,
+		TimeAllocation:          dbEndTime.Sub(dbStartTime).Nanoseconds(),
+		SourceAccountID:         accountID,
+		DatetimeEnd:             primitive.NewDateTimeFromTime(dbEndTime),
+		DatetimeStart:           primitive.NewDateTimeFromTime(dbStartTime),
+		CanModify:               canModify,
+		CallURL:                 conferenceCall.URL,
+		CallLogo:                conferenceCall.Logo,
+		CallPlatform:            conferenceCall.Platform,
+		AttendeeEmails:          attendeeEmails,
+		CalendarColorBackground: eventCalendar.ColorBackground,
+		CalendarColorForeground: eventCalendar.ColorForeground,
+		// events are fetched with SingleEvents, so recurring events arrive as their occurrences
+		RecurringEventID: event.RecurringEventId,
 	}
 	if colors != nil {
 		dbEvent.ColorBackground = colors.Event[event.ColorId].Background
@@ -80,6 +119,7 @@ func processAndStoreEvent(event *calendar.Event, db *mongo.Database, userID prim
 	}
 
 	dbEvent, err := database.UpdateOrCreateCalendarEvent(
+		ctx,
 		db,
 		userID,
 		dbEvent.IDExternal,
@@ -97,7 +137,7 @@ func processAndStoreEvent(event *calendar.Event, db *mongo.Database, userID prim
 	return dbEvent
 }
 
-func (googleCalendar GoogleCalendarSource) fetchEvents(calendarService *calendar.Service, db *mongo.Database, userID primitive.ObjectID, accountID string, calendarId string, startTime time.Time, endTime time.Time, result chan<- CalendarResult, colors *calendar.Colors) {
+func (googleCalendar GoogleCalendarSource) fetchEvents(ctx context.Context, calendarService *calendar.Service, db *mongo.Database, userID primitive.ObjectID, accountID string, calendarId string, startTime time.Time, endTime time.Time, result chan<- CalendarResult, colors *calendar.Colors, eventCalendar database.Calendar) {
 	calendarResponse, err := calendarService.Events.
 		List(calendarId).
 		TimeMin(startTime.Format(time.RFC3339)).
@@ -109,7 +149,7 @@ func (googleCalendar GoogleCalendarSource) fetchEvents(calendarService *calendar
 	logger := logging.GetSentryLogger()
 
 	if err != nil {
-		isBadToken := CheckAndHandleBadToken(err, db, userID, accountID, TASK_SERVICE_ID_GOOGLE)
+		isBadToken := CheckAndHandleBadToken(ctx, err, db, userID, accountID, TASK_SERVICE_ID_GOOGLE)
 		if !isBadToken {
 			logger.Error().Err(err).Msg("unable to load calendar events")
 		}
@@ -119,7 +159,7 @@ func (googleCalendar GoogleCalendarSource) fetchEvents(calendarService *calendar
 
 	var events []*database.CalendarEvent
 	for _, event := range calendarResponse.Items {
-		dbEvent := processAndStoreEvent(event, db, userID, accountID, calendarId, colors)
+		dbEvent := processAndStoreEvent(ctx, event, db, userID, accountID, calendarId, colors, eventCalendar)
 		if dbEvent != nil && !cmp.Equal(*dbEvent, (database.CalendarEvent{})) {
 			events = append(events, dbEvent)
 		}
@@ -127,13 +167,13 @@ func (googleCalendar GoogleCalendarSource) fetchEvents(calendarService *calendar
 	result <- CalendarResult{events, nil}
 }
 
-func (googleCalendar GoogleCalendarSource) GetEvents(db *mongo.Database, userID primitive.ObjectID, accountID string, startTime time.Time, endTime time.Time, scopes []string, result chan<- CalendarResult) {
-	calendarService, err := createGcalService(googleCalendar.Google.OverrideURLs.CalendarFetchURL, userID, accountID, context.Background(), db)
+func (googleCalendar GoogleCalendarSource) GetEvents(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, startTime time.Time, endTime time.Time, scopes []string, result chan<- CalendarResult) {
+	calendarService, err := createGcalService(googleCalendar.Google.OverrideURLs.CalendarFetchURL, userID, accountID, ctx, db)
 	if err != nil {
 		result <- emptyCalendarResult(err)
 		return
 	}
-	err = updateUserTimezone(calendarService, db, userID, accountID)
+	err = updateUserTimezone(ctx, calendarService, db, userID, accountID)
 	if err != nil {
 		log.Error().Err(err).Send()
 	}
@@ -144,6 +184,7 @@ func (googleCalendar GoogleCalendarSource) GetEvents(db *mongo.Database, userID
 		Scopes:     scopes,
 	}
 	var events []*database.CalendarEvent
+	syncDisabledCalendarIDs := getSyncDisabledCalendarIDs(ctx, db, userID, accountID)
 
 	fetchAllCalendars := false
 	var calendarList *calendar.CalendarList
@@ -164,22 +205,25 @@ func (googleCalendar GoogleCalendarSource) GetEvents(db *mongo.Database, userID
 	// If we can't fetch the calendar list, we try fetching just the primary calendar
 	if !fetchAllCalendars {
 		log.Debug().Err(err).Msgf("could not fetch calendar list for accountID: %s", accountID)
-		eventChannel := make(chan CalendarResult)
-		go googleCalendar.fetchEvents(calendarService, db, userID, accountID, "primary", startTime, endTime, eventChannel, colors)
-		eventResult := <-eventChannel
-		if eventResult.Error != nil {
-			result <- emptyCalendarResult(errors.New("failed to fetch events"))
+		if !syncDisabledCalendarIDs[accountID] {
+			eventChannel := make(chan CalendarResult)
+			go googleCalendar.fetchEvents(ctx, calendarService, db, userID, accountID, "primary", startTime, endTime, eventChannel, colors, database.Calendar{})
+			eventResult := <-eventChannel
+			if eventResult.Error != nil {
+				result <- emptyCalendarResult(errors.New("failed to fetch events"))
+			}
+			events = append(events, eventResult.CalendarEvents...)
 		}
-		events = append(events, eventResult.CalendarEvents...)
 		calendarAccount.Calendars = []database.Calendar{
 			{
-				CalendarID: accountID,
-				AccessRole: constants.AccessControlOwner,
-				ColorID:    "",
-				Title:      "",
+				CalendarID:   accountID,
+				AccessRole:   constants.AccessControlOwner,
+				ColorID:      "",
+				Title:        "",
+				SyncDisabled: syncDisabledCalendarIDs[accountID],
 			},
 		}
-		_, err = database.UpdateOrCreateCalendarAccount(db, userID, accountID, TASK_SOURCE_ID_GCAL, calendarAccount, nil)
+		_, err = database.UpdateOrCreateCalendarAccount(ctx, db, userID, accountID, TASK_SOURCE_ID_GCAL, calendarAccount, nil)
 		if err != nil {
 			result <- emptyCalendarResult(err)
 		}
@@ -195,14 +239,19 @@ func (googleCalendar GoogleCalendarSource) GetEvents(db *mongo.Database, userID
 			CalendarID: calendar.Id,
 			ColorID:    calendar.ColorId,
 			Title:      calendar.Summary,
+			// the calendar list doesn't know about the user's choice, so keep it from the stored account
+			SyncDisabled: syncDisabledCalendarIDs[calendar.Id],
 		}
 		if colors != nil {
 			cal.ColorBackground = colors.Calendar[calendar.ColorId].Background
 			cal.ColorForeground = colors.Calendar[calendar.ColorId].Foreground
 		}
 		calendars = append(calendars, cal)
+		if cal.SyncDisabled {
+			continue
+		}
 		eventChannel := make(chan CalendarResult)
-		go googleCalendar.fetchEvents(calendarService, db, userID, accountID, calendar.Id, startTime, endTime, eventChannel, colors)
+		go googleCalendar.fetchEvents(ctx, calendarService, db, userID, accountID, calendar.Id, startTime, endTime, eventChannel, colors, cal)
 		eventsChannels = append(eventsChannels, eventChannel)
 	}
 	for _, eventChannel := range eventsChannels {
@@ -213,31 +262,46 @@ func (googleCalendar GoogleCalendarSource) GetEvents(db *mongo.Database, userID
 		events = append(events, eventResult.CalendarEvents...)
 	}
 	calendarAccount.Calendars = calendars
-	_, err = database.UpdateOrCreateCalendarAccount(db, userID, accountID, TASK_SOURCE_ID_GCAL, calendarAccount, nil)
+	_, err = database.UpdateOrCreateCalendarAccount(ctx, db, userID, accountID, TASK_SOURCE_ID_GCAL, calendarAccount, nil)
 	if err != nil {
 		log.Error().Err(err).Msgf("could not create CalendarAccount: %+v", calendarAccount)
 	}
 	result <- CalendarResult{CalendarEvents: events, Error: nil}
 }
 
-func (googleCalendar GoogleCalendarSource) GetTasks(db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- TaskResult) {
+// getSyncDisabledCalendarIDs returns the calendars of the account the user has turned syncing off for
+func getSyncDisabledCalendarIDs(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string) map[string]bool {
+	calendarIDs := map[string]bool{}
+	calendarAccount, err := database.GetCalendarAccount(ctx, db, userID, accountID, TASK_SOURCE_ID_GCAL)
+	if err != nil {
+		return calendarIDs
+	}
+	for _, calendar := range calendarAccount.Calendars {
+		if calendar.SyncDisabled {
+			calendarIDs[calendar.CalendarID] = true
+		}
+	}
+	return calendarIDs
+}
+
+func (googleCalendar GoogleCalendarSource) GetTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- TaskResult) {
 	result <- emptyTaskResult(nil)
 }
 
-func (googleCalendar GoogleCalendarSource) GetPullRequests(db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- PullRequestResult) {
+func (googleCalendar GoogleCalendarSource) GetPullRequests(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- PullRequestResult) {
 	result <- emptyPullRequestResult(nil, false)
 }
 
-func (googleCalendar GoogleCalendarSource) CreateNewTask(db *mongo.Database, userID primitive.ObjectID, accountID string, task TaskCreationObject) (primitive.ObjectID, error) {
+func (googleCalendar GoogleCalendarSource) CreateNewTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, task TaskCreationObject) (primitive.ObjectID, error) {
 	return primitive.NilObjectID, errors.New("has not been implemented yet")
 }
 
-func (googleCalendar GoogleCalendarSource) AddComment(db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error {
+func (googleCalendar GoogleCalendarSource) AddComment(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error {
 	return errors.New("has not been implemented yet")
 }
 
-func (googleCalendar GoogleCalendarSource) CreateNewEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error {
-	calendarService, err := createGcalService(googleCalendar.Google.OverrideURLs.CalendarCreateURL, userID, accountID, context.Background(), db)
+func (googleCalendar GoogleCalendarSource) CreateNewEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error {
+	calendarService, err := createGcalService(googleCalendar.Google.OverrideURLs.CalendarCreateURL, userID, accountID, ctx, db)
 	if err != nil {
 		return err
 	}
@@ -281,9 +345,9 @@ func (googleCalendar GoogleCalendarSource) CreateNewEvent(db *mongo.Database, us
 	return nil
 }
 
-func (googleCalendar GoogleCalendarSource) DeleteEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error {
+func (googleCalendar GoogleCalendarSource) DeleteEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error {
 	// TODO: create a EventDeleteURL
-	calendarService, err := createGcalService(googleCalendar.Google.OverrideURLs.CalendarDeleteURL, userID, accountID, context.Background(), db)
+	calendarService, err := createGcalService(googleCalendar.Google.OverrideURLs.CalendarDeleteURL, userID, accountID, ctx, db)
 	if err != nil {
 		return err
 	}
@@ -304,29 +368,39 @@ func (googleCalendar GoogleCalendarSource) DeleteEvent(db *mongo.Database, userI
 }
 
 // returns true if the error was because of a bad token
-func CheckAndHandleBadToken(err error, db *mongo.Database, userID primitive.ObjectID, accountID string, serviceID string) bool {
+func CheckAndHandleBadToken(ctx context.Context, err error, db *mongo.Database, userID primitive.ObjectID, accountID string, serviceID string) bool {
 	if !strings.Contains(err.Error(), "oauth2: token expired and refresh token is not set") &&
 		!strings.Contains(err.Error(), "Token has been expired or revoked") &&
 		!strings.Contains(err.Error(), "Request had insufficient authentication scopes") {
 		return false
 	}
-	token, err := getExternalToken(db, userID, accountID, serviceID)
+	badTokenErr := err
+	token, err := getExternalToken(ctx, db, userID, accountID, serviceID)
 	logger := logging.GetSentryLogger()
 	if err != nil {
 		logger.Error().Str("userID", userID.Hex()).Str("accountID", accountID).Str("serviceID", serviceID).Err(err).Msg("unable to get external token")
 		return true
 	}
 
-	_, err = database.GetExternalTokenCollection(db).UpdateOne(
-		context.Background(),
-		bson.M{"_id": token.ID},
-		bson.M{"$set": bson.M{"is_bad_token": true}},
-	)
-	if err != nil {
-		logger.Error().Str("tokenID", token.ID.Hex()).Err(err).Msg("unable to update external token")
+	// keep when and why the token first broke, rather than the latest failure
+	if !token.IsBadToken {
+		now := time.Now()
+		_, err = database.GetExternalTokenCollection(db).UpdateOne(
+			ctx,
+			bson.M{"_id": token.ID},
+			bson.M{"$set": bson.M{
+				"is_bad_token":     true,
+				"bad_token_at":     primitive.NewDateTimeFromTime(now),
+				"bad_token_reason": badTokenErr.Error(),
+			}},
+		)
+		if err != nil {
+			logger.Error().Str("tokenID", token.ID.Hex()).Err(err).Msg("unable to update external token")
+		}
+		_ = database.InsertExternalTokenHealthEvent(ctx, db, newTokenHealthEvent(*token, constants.TokenHealthBad, badTokenErr.Error(), now))
 	}
 
-	err = database.UpdateUserSetting(db, userID, constants.HasDismissedMulticalPrompt, constants.SettingFalse)
+	err = database.UpdateUserSetting(ctx, db, userID, constants.HasDismissedMulticalPrompt, constants.SettingFalse)
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to set HasDismissedMulticalPrompt as false")
 	}
@@ -348,7 +422,10 @@ func GetConferenceCall(event *calendar.Event, accountID string) *utils.Conferenc
 			}
 		}
 	}
-	// then check the description for a conference URL
+	// then check the location, where links from conferencing apps are usually put, and the description
+	if conferenceCall == nil && event.Location != "" {
+		conferenceCall = utils.GetConferenceUrlFromString(event.Location)
+	}
 	if conferenceCall == nil && event.Description != "" {
 		conferenceCall = utils.GetConferenceUrlFromString(event.Description)
 	}
@@ -363,7 +440,7 @@ func GetConferenceCall(event *calendar.Event, accountID string) *utils.Conferenc
 	return &utils.ConferenceCall{}
 }
 
-func (googleCalendar GoogleCalendarSource) ModifyTask(db *mongo.Database, userID primitive.ObjectID, accountID string, issueID string, updateFields *database.Task, task *database.Task) error {
+func (googleCalendar GoogleCalendarSource) ModifyTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, issueID string, updateFields *database.Task, task *database.Task) error {
 	return nil
 }
 
@@ -378,8 +455,8 @@ func createGcalAttendees(attendees *[]Attendee) *[]*calendar.EventAttendee {
 	return &attendeesList
 }
 
-func (googleCalendar GoogleCalendarSource) ModifyEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, eventID string, updateFields *EventModifyObject) error {
-	calendarService, err := createGcalService(googleCalendar.Google.OverrideURLs.CalendarModifyURL, userID, accountID, context.Background(), db)
+func (googleCalendar GoogleCalendarSource) ModifyEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, eventID string, updateFields *EventModifyObject) error {
+	calendarService, err := createGcalService(googleCalendar.Google.OverrideURLs.CalendarModifyURL, userID, accountID, ctx, db)
 	if err != nil {
 		return err
 	}
@@ -394,6 +471,21 @@ func (googleCalendar GoogleCalendarSource) ModifyEvent(db *mongo.Database, userI
 	if updateFields.Description != nil {
 		gcalEvent.Description = *updateFields.Description
 	}
+	if updateFields.Attendees != nil {
+		gcalEvent.Attendees = *createGcalAttendees(updateFields.Attendees)
+	}
+	if updateFields.ColorID != nil {
+		gcalEvent.ColorId = *updateFields.ColorID
+		// an empty color ID is left out of the patch unless forced
+		gcalEvent.ForceSendFields = append(gcalEvent.ForceSendFields, "ColorId")
+	}
+	calendarID := accountID
+	if updateFields.CalendarID != "" {
+		calendarID = updateFields.CalendarID
+	}
+	if updateFields.Scope == EventModifyScopeSeries {
+		return modifyEventSeries(calendarService, calendarID, eventID, &gcalEvent, updateFields)
+	}
 	if updateFields.DatetimeStart != nil {
 		gcalEvent.Start = &calendar.EventDateTime{
 			DateTime: updateFields.DatetimeStart.Format(time.RFC3339),
@@ -404,13 +496,6 @@ func (googleCalendar GoogleCalendarSource) ModifyEvent(db *mongo.Database, userI
 			DateTime: updateFields.DatetimeEnd.Format(time.RFC3339),
 		}
 	}
-	if updateFields.Attendees != nil {
-		gcalEvent.Attendees = *createGcalAttendees(updateFields.Attendees)
-	}
-	calendarID := accountID
-	if updateFields.CalendarID != "" {
-		calendarID = updateFields.CalendarID
-	}
 	_, err = calendarService.Events.Patch(calendarID, eventID, &gcalEvent).Do()
 	if err != nil {
 		return err
@@ -418,6 +503,57 @@ func (googleCalendar GoogleCalendarSource) ModifyEvent(db *mongo.Database, userI
 	return nil
 }
 
+// modifyEventSeries patches the series the given occurrence belongs to. New start and end times
+// are for the occurrence, so the series is moved by as much as the occurrence would have moved
+func modifyEventSeries(calendarService *calendar.Service, calendarID string, instanceID string, gcalEvent *calendar.Event, updateFields *EventModifyObject) error {
+	instance, err := calendarService.Events.Get(calendarID, instanceID).Do()
+	if err != nil {
+		return err
+	}
+	if instance.RecurringEventId == "" {
+		return errors.New("event is not part of a recurring series")
+	}
+	if updateFields.DatetimeStart != nil || updateFields.DatetimeEnd != nil {
+		series, err := calendarService.Events.Get(calendarID, instance.RecurringEventId).Do()
+		if err != nil {
+			return err
+		}
+		gcalEvent.Start, err = shiftSeriesDateTime(series.Start, instance.Start, updateFields.DatetimeStart)
+		if err != nil {
+			return err
+		}
+		gcalEvent.End, err = shiftSeriesDateTime(series.End, instance.End, updateFields.DatetimeEnd)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = calendarService.Events.Patch(calendarID, instance.RecurringEventId, gcalEvent).Do()
+	return err
+}
+
+// shiftSeriesDateTime moves the series time by the difference between the occurrence's time and
+// newTime, keeping the series timezone so the recurrence rule still applies
+func shiftSeriesDateTime(seriesTime *calendar.EventDateTime, instanceTime *calendar.EventDateTime, newTime *time.Time) (*calendar.EventDateTime, error) {
+	if newTime == nil {
+		return nil, nil
+	}
+	if seriesTime == nil || instanceTime == nil || seriesTime.DateTime == "" || instanceTime.DateTime == "" {
+		return nil, errors.New("all day series cannot be moved")
+	}
+	seriesDatetime, err := time.Parse(time.RFC3339, seriesTime.DateTime)
+	if err != nil {
+		return nil, err
+	}
+	instanceDatetime, err := time.Parse(time.RFC3339, instanceTime.DateTime)
+	if err != nil {
+		return nil, err
+	}
+	return &calendar.EventDateTime{
+		DateTime: seriesDatetime.Add(newTime.Sub(instanceDatetime)).Format(time.RFC3339),
+		TimeZone: seriesTime.TimeZone,
+	}, nil
+}
+
 func createConferenceCallRequest() *calendar.ConferenceData {
 	// todo - add client generated requestId
 	return &calendar.ConferenceData{
@@ -443,7 +579,7 @@ func createGcalService(overrideURL *string, userID primitive.ObjectID, accountID
 			option.WithEndpoint(*overrideURL),
 		)
 	} else {
-		client := getGoogleHttpClient(db, userID, accountID)
+		client := getGoogleHttpClient(ctx, db, userID, accountID)
 		if client == nil {
 			logger.Error().Msg("failed to fetch google API token")
 			return nil, errors.New("failed to fetch google API token")
@@ -459,15 +595,15 @@ func createGcalService(overrideURL *string, userID primitive.ObjectID, accountID
 	return calendarService, nil
 }
 
-func updateUserTimezone(calendarService *calendar.Service, db *mongo.Database, userID primitive.ObjectID, accountID string) error {
+func updateUserTimezone(ctx context.Context, calendarService *calendar.Service, db *mongo.Database, userID primitive.ObjectID, accountID string) error {
 	setting, err := calendarService.Settings.Get("timezone").Do()
 	if err != nil {
 		return err
 	}
-	token, err := getExternalToken(db, userID, accountID, TASK_SERVICE_ID_GOOGLE)
+	token, err := getExternalToken(ctx, db, userID, accountID, TASK_SERVICE_ID_GOOGLE)
 	if err != nil {
 		return err
 	}
-	_, err = database.GetExternalTokenCollection(db).UpdateByID(context.Background(), token.ID, bson.M{"$set": bson.M{"timezone": setting.Value}})
+	_, err = database.GetExternalTokenCollection(db).UpdateByID(ctx, token.ID, bson.M{"$set": bson.M{"timezone": setting.Value}})
 	return err
 }
@@ -12,7 +12,9 @@ import (
 
 	"github.com/franchizzle/task-manager/backend/testutils"
 
+	"github.com/franchizzle/task-manager/backend/constants"
 	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/utils"
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -94,7 +96,7 @@ func TestGetEvents(t *testing.T) {
 				OverrideURLs: GoogleURLOverrides{CalendarFetchURL: &server.URL},
 			},
 		}
-		go googleCalendar.GetEvents(db, userID, accountID, time.Now(), time.Now(), nil, calendarResult)
+		go googleCalendar.GetEvents(context.Background(), db, userID, accountID, time.Now(), time.Now(), nil, calendarResult)
 		result := <-calendarResult
 		assert.NoError(t, result.Error)
 		assert.Equal(t, 2, len(result.CalendarEvents))
@@ -129,7 +131,7 @@ func TestGetEvents(t *testing.T) {
 		assert.Equal(t, "", calendarAccount.Calendars[0].ColorBackground)
 		assert.NoError(t, err)
 
-		token, err := getExternalToken(db, userID, accountID, TASK_SERVICE_ID_GOOGLE)
+		token, err := getExternalToken(context.Background(), db, userID, accountID, TASK_SERVICE_ID_GOOGLE)
 		assert.NoError(t, err)
 		assert.Equal(t, "America/Compton", token.Timezone)
 	})
@@ -167,7 +169,7 @@ func TestGetEvents(t *testing.T) {
 			DatetimeStart:   primitive.NewDateTimeFromTime(startTime),
 			DatetimeEnd:     primitive.NewDateTimeFromTime(oldEndtime),
 		}
-		database.GetOrCreateCalendarEvent(db, userID, "standard_event", TASK_SOURCE_ID_GCAL, standardDBEvent)
+		database.GetOrCreateCalendarEvent(context.Background(), db, userID, "standard_event", TASK_SOURCE_ID_GCAL, standardDBEvent)
 		// Rescheduling end time along shouldn't trigger a reset like in the next test case
 		standardDBEvent.DatetimeEnd = primitive.NewDateTimeFromTime(endTime)
 		standardDBEvent.Body = "new description"
@@ -192,7 +194,7 @@ func TestGetEvents(t *testing.T) {
 				OverrideURLs: GoogleURLOverrides{CalendarFetchURL: &server.URL},
 			},
 		}
-		go googleCalendar.GetEvents(db, userID, "exampleAccountID", time.Now(), time.Now(), nil, calendarResult)
+		go googleCalendar.GetEvents(context.Background(), db, userID, "exampleAccountID", time.Now(), time.Now(), nil, calendarResult)
 		result := <-calendarResult
 		assert.NoError(t, result.Error)
 		assert.Equal(t, 1, len(result.CalendarEvents))
@@ -246,7 +248,7 @@ func TestGetEvents(t *testing.T) {
 			DatetimeStart:   primitive.NewDateTimeFromTime(oldStartTime),
 			DatetimeEnd:     primitive.NewDateTimeFromTime(endTime),
 		}
-		database.GetOrCreateCalendarEvent(db, userID, "standard_event", TASK_SOURCE_ID_GCAL, standardDBEvent)
+		database.GetOrCreateCalendarEvent(context.Background(), db, userID, "standard_event", TASK_SOURCE_ID_GCAL, standardDBEvent)
 		standardDBEvent.DatetimeStart = primitive.NewDateTimeFromTime(startTime)
 
 		server := testutils.GetGcalFetchServer([]*calendar.Event{&standardEvent})
@@ -258,7 +260,7 @@ func TestGetEvents(t *testing.T) {
 				OverrideURLs: GoogleURLOverrides{CalendarFetchURL: &server.URL},
 			},
 		}
-		go googleCalendar.GetEvents(db, userID, "exampleAccountID", time.Now(), time.Now(), nil, calendarResult)
+		go googleCalendar.GetEvents(context.Background(), db, userID, "exampleAccountID", time.Now(), time.Now(), nil, calendarResult)
 		result := <-calendarResult
 		assert.NoError(t, result.Error)
 		assert.Equal(t, 1, len(result.CalendarEvents))
@@ -290,7 +292,7 @@ func TestGetEvents(t *testing.T) {
 		}
 		defer server.Close()
 		var calendarResult = make(chan CalendarResult)
-		go googleCalendar.GetEvents(db, primitive.NewObjectID(), "exampleAccountID", time.Now(), time.Now(), nil, calendarResult)
+		go googleCalendar.GetEvents(context.Background(), db, primitive.NewObjectID(), "exampleAccountID", time.Now(), time.Now(), nil, calendarResult)
 		result := <-calendarResult
 		assert.NoError(t, result.Error)
 		assert.Equal(t, 0, len(result.CalendarEvents))
@@ -359,7 +361,7 @@ func TestGetEvents(t *testing.T) {
 				OverrideURLs: GoogleURLOverrides{CalendarFetchURL: &server.URL},
 			},
 		}
-		go googleCalendar.GetEvents(db, userID, "exampleAccountID", time.Now(), time.Now(), nil, calendarResult)
+		go googleCalendar.GetEvents(context.Background(), db, userID, "exampleAccountID", time.Now(), time.Now(), nil, calendarResult)
 		result := <-calendarResult
 		assert.NoError(t, result.Error)
 		assert.Equal(t, 1, len(result.CalendarEvents))
@@ -431,7 +433,7 @@ func TestGetEvents(t *testing.T) {
 				OverrideURLs: GoogleURLOverrides{CalendarFetchURL: &server.URL},
 			},
 		}
-		go googleCalendar.GetEvents(db, userID, "exampleAccountID", time.Now(), time.Now(), []string{"https://www.googleapis.com/auth/calendar"}, calendarResult)
+		go googleCalendar.GetEvents(context.Background(), db, userID, "exampleAccountID", time.Now(), time.Now(), []string{"https://www.googleapis.com/auth/calendar"}, calendarResult)
 		result := <-calendarResult
 		assert.NoError(t, result.Error)
 		assert.Equal(t, 2, len(result.CalendarEvents)) // the event exists in both calendars
@@ -479,6 +481,51 @@ func TestGetEvents(t *testing.T) {
 		assert.Equal(t, "owner", calendarAccount.Calendars[0].AccessRole)
 		assert.Equal(t, "#000000", calendarAccount.Calendars[0].ColorBackground)
 	})
+	t.Run("SyncDisabledCalendar", func(t *testing.T) {
+		standardEvent := calendar.Event{
+			Created:        "2021-02-25T17:53:01.000Z",
+			Summary:        "Standard Event",
+			Start:          &calendar.EventDateTime{DateTime: "2021-03-06T15:00:00-05:00"},
+			End:            &calendar.EventDateTime{DateTime: "2021-03-06T15:30:00-05:00"},
+			HtmlLink:       "resonant-kelpie-404a42.netlify.app",
+			Id:             "standard_event",
+			Organizer:      &calendar.EventOrganizer{Self: true},
+			ServerResponse: googleapi.ServerResponse{HTTPStatusCode: 0},
+		}
+		server := testutils.GetGcalFetchServer([]*calendar.Event{&standardEvent})
+		defer server.Close()
+
+		userID := primitive.NewObjectID()
+		_, err := database.UpdateOrCreateCalendarAccount(context.Background(), db, userID, "exampleAccountID", TASK_SOURCE_ID_GCAL, &database.CalendarAccount{
+			UserID:     userID,
+			IDExternal: "exampleAccountID",
+			SourceID:   TASK_SOURCE_ID_GCAL,
+			Calendars: []database.Calendar{
+				{CalendarID: "primary"},
+				{CalendarID: "testuser@gmail.com", SyncDisabled: true},
+			},
+		}, nil)
+		assert.NoError(t, err)
+
+		var calendarResult = make(chan CalendarResult)
+		googleCalendar := GoogleCalendarSource{
+			Google: GoogleService{
+				OverrideURLs: GoogleURLOverrides{CalendarFetchURL: &server.URL},
+			},
+		}
+		go googleCalendar.GetEvents(context.Background(), db, userID, "exampleAccountID", time.Now(), time.Now(), []string{"https://www.googleapis.com/auth/calendar"}, calendarResult)
+		result := <-calendarResult
+		assert.NoError(t, result.Error)
+		assert.Equal(t, 1, len(result.CalendarEvents))
+		assert.Equal(t, "exampleAccountID", result.CalendarEvents[0].CalendarID)
+
+		calendarAccount, err := database.GetCalendarAccount(context.Background(), db, userID, "exampleAccountID", TASK_SOURCE_ID_GCAL)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(calendarAccount.Calendars))
+		assert.False(t, calendarAccount.Calendars[0].SyncDisabled)
+		assert.Equal(t, "testuser@gmail.com", calendarAccount.Calendars[1].CalendarID)
+		assert.True(t, calendarAccount.Calendars[1].SyncDisabled)
+	})
 }
 
 func TestCreateNewEvent(t *testing.T) {
@@ -507,7 +554,7 @@ func TestCreateNewEvent(t *testing.T) {
 				OverrideURLs: GoogleURLOverrides{CalendarCreateURL: &server.URL},
 			},
 		}
-		err := googleCalendar.CreateNewEvent(db, userID, "exampleAccountID", eventCreateObj)
+		err := googleCalendar.CreateNewEvent(context.Background(), db, userID, "exampleAccountID", eventCreateObj)
 		assert.Error(t, err)
 	})
 	t.Run("Success", func(t *testing.T) {
@@ -544,7 +591,7 @@ func TestCreateNewEvent(t *testing.T) {
 				OverrideURLs: GoogleURLOverrides{CalendarCreateURL: &server.URL},
 			},
 		}
-		err := googleCalendar.CreateNewEvent(db, userID, "exampleAccountID", eventCreateObj)
+		err := googleCalendar.CreateNewEvent(context.Background(), db, userID, "exampleAccountID", eventCreateObj)
 		assert.NoError(t, err)
 	})
 	t.Run("SuccessLinkedTask", func(t *testing.T) {
@@ -583,7 +630,7 @@ func TestCreateNewEvent(t *testing.T) {
 				OverrideURLs: GoogleURLOverrides{CalendarCreateURL: &server.URL},
 			},
 		}
-		err := googleCalendar.CreateNewEvent(db, userID, "exampleAccountID", eventCreateObj)
+		err := googleCalendar.CreateNewEvent(context.Background(), db, userID, "exampleAccountID", eventCreateObj)
 		assert.NoError(t, err)
 	})
 	t.Run("SuccessWithConferenceCall", func(t *testing.T) {
@@ -623,7 +670,7 @@ func TestCreateNewEvent(t *testing.T) {
 				OverrideURLs: GoogleURLOverrides{CalendarCreateURL: &server.URL},
 			},
 		}
-		err := googleCalendar.CreateNewEvent(db, userID, "exampleAccountID", eventCreateObj)
+		err := googleCalendar.CreateNewEvent(context.Background(), db, userID, "exampleAccountID", eventCreateObj)
 		assert.NoError(t, err)
 	})
 }
@@ -643,7 +690,7 @@ func TestDeleteEvent(t *testing.T) {
 				OverrideURLs: GoogleURLOverrides{CalendarDeleteURL: &server.URL},
 			},
 		}
-		err := googleCalendar.DeleteEvent(db, userID, "exampleAccountID", gcalEventID, "")
+		err := googleCalendar.DeleteEvent(context.Background(), db, userID, "exampleAccountID", gcalEventID, "")
 		assert.Error(t, err)
 	})
 	t.Run("Success", func(t *testing.T) {
@@ -659,7 +706,7 @@ func TestDeleteEvent(t *testing.T) {
 				OverrideURLs: GoogleURLOverrides{CalendarDeleteURL: &server.URL},
 			},
 		}
-		err := googleCalendar.DeleteEvent(db, userID, accountID, gcalEventID, "")
+		err := googleCalendar.DeleteEvent(context.Background(), db, userID, accountID, gcalEventID, "")
 		assert.NoError(t, err)
 	})
 }
@@ -686,7 +733,7 @@ func TestModifyEvent(t *testing.T) {
 		googleCalendar, server := getEventModifyGoogleCalendar(t, &expectedEvent, accountID, eventID)
 		defer server.Close()
 
-		err := googleCalendar.ModifyEvent(db, userID, accountID, eventID, &eventModifyObj)
+		err := googleCalendar.ModifyEvent(context.Background(), db, userID, accountID, eventID, &eventModifyObj)
 		assert.NoError(t, err)
 	})
 	t.Run("SuccessWithStartDate", func(t *testing.T) {
@@ -701,7 +748,7 @@ func TestModifyEvent(t *testing.T) {
 		googleCalendar, server := getEventModifyGoogleCalendar(t, &expectedEvent, accountID, eventID)
 		defer server.Close()
 
-		err := googleCalendar.ModifyEvent(db, userID, accountID, eventID, &eventModifyObj)
+		err := googleCalendar.ModifyEvent(context.Background(), db, userID, accountID, eventID, &eventModifyObj)
 		assert.NoError(t, err)
 	})
 	t.Run("SuccessWithEndDate", func(t *testing.T) {
@@ -716,7 +763,7 @@ func TestModifyEvent(t *testing.T) {
 		googleCalendar, server := getEventModifyGoogleCalendar(t, &expectedEvent, accountID, eventID)
 		defer server.Close()
 
-		err := googleCalendar.ModifyEvent(db, userID, accountID, eventID, &eventModifyObj)
+		err := googleCalendar.ModifyEvent(context.Background(), db, userID, accountID, eventID, &eventModifyObj)
 		assert.NoError(t, err)
 	})
 	t.Run("SuccessWithStartAndEndDate", func(t *testing.T) {
@@ -734,7 +781,7 @@ func TestModifyEvent(t *testing.T) {
 		googleCalendar, server := getEventModifyGoogleCalendar(t, &expectedEvent, accountID, eventID)
 		defer server.Close()
 
-		err := googleCalendar.ModifyEvent(db, userID, accountID, eventID, &eventModifyObj)
+		err := googleCalendar.ModifyEvent(context.Background(), db, userID, accountID, eventID, &eventModifyObj)
 		assert.NoError(t, err)
 	})
 	t.Run("SuccessWithSummaryAndDescription", func(t *testing.T) {
@@ -752,7 +799,7 @@ func TestModifyEvent(t *testing.T) {
 		googleCalendar, server := getEventModifyGoogleCalendar(t, &expectedEvent, accountID, eventID)
 		defer server.Close()
 
-		err := googleCalendar.ModifyEvent(db, userID, accountID, eventID, &eventModifyObj)
+		err := googleCalendar.ModifyEvent(context.Background(), db, userID, accountID, eventID, &eventModifyObj)
 		assert.NoError(t, err)
 	})
 	t.Run("EmptyModifyObject", func(t *testing.T) {
@@ -761,7 +808,7 @@ func TestModifyEvent(t *testing.T) {
 		googleCalendar, server := getEventModifyGoogleCalendar(t, &expectedEvent, accountID, eventID)
 		defer server.Close()
 
-		err := googleCalendar.ModifyEvent(db, userID, accountID, eventID, &eventModifyObj)
+		err := googleCalendar.ModifyEvent(context.Background(), db, userID, accountID, eventID, &eventModifyObj)
 		assert.NoError(t, err)
 	})
 	t.Run("ExternalError", func(t *testing.T) {
@@ -775,10 +822,69 @@ func TestModifyEvent(t *testing.T) {
 		googleCalendar, server := getEventModifyGoogleCalendar(t, nil, accountID, eventID)
 		defer server.Close()
 
-		err := googleCalendar.ModifyEvent(db, userID, accountID, eventID, &eventModifyObj)
+		err := googleCalendar.ModifyEvent(context.Background(), db, userID, accountID, eventID, &eventModifyObj)
 		assert.Error(t, err)
 	})
 }
+func TestModifyEventSeries(t *testing.T) {
+	db, dbCleanup, _ := database.GetDBConnection()
+	defer dbCleanup()
+	userID := primitive.NewObjectID()
+	accountID := "duccount_id"
+
+	getSeriesServer := func(expectedEvent *calendar.Event) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == fmt.Sprintf("/calendars/%s/events/series_id_20230301T170000Z", accountID):
+				w.Write([]byte(`{"id": "series_id_20230301T170000Z", "recurringEventId": "series_id", "start": {"dateTime": "2023-03-01T09:00:00-08:00"}, "end": {"dateTime": "2023-03-01T09:30:00-08:00"}}`))
+			case r.Method == http.MethodGet && r.URL.Path == fmt.Sprintf("/calendars/%s/events/series_id", accountID):
+				w.Write([]byte(`{"id": "series_id", "start": {"dateTime": "2023-01-04T09:00:00-08:00", "timeZone": "America/Los_Angeles"}, "end": {"dateTime": "2023-01-04T09:30:00-08:00", "timeZone": "America/Los_Angeles"}}`))
+			case r.Method == http.MethodPatch && r.URL.Path == fmt.Sprintf("/calendars/%s/events/series_id", accountID):
+				var requestEvent calendar.Event
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&requestEvent))
+				assertGcalCalendarEventsEqual(t, expectedEvent, &requestEvent)
+				w.Write([]byte(`{}`))
+			default:
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	}
+
+	t.Run("Summary", func(t *testing.T) {
+		summary := "weekly sync"
+		server := getSeriesServer(&calendar.Event{Summary: summary})
+		defer server.Close()
+		googleCalendar := GoogleCalendarSource{Google: GoogleService{OverrideURLs: GoogleURLOverrides{CalendarModifyURL: &server.URL}}}
+
+		err := googleCalendar.ModifyEvent(context.Background(), db, userID, accountID, "series_id_20230301T170000Z", &EventModifyObject{
+			AccountID: accountID,
+			Scope:     EventModifyScopeSeries,
+			Summary:   &summary,
+		})
+		assert.NoError(t, err)
+	})
+	t.Run("MovesSeriesByInstanceShift", func(t *testing.T) {
+		// the occurrence moves an hour later and gets 15 minutes longer
+		datetimeStart := time.Date(2023, time.March, 1, 18, 0, 0, 0, time.UTC)
+		datetimeEnd := time.Date(2023, time.March, 1, 18, 45, 0, 0, time.UTC)
+		server := getSeriesServer(&calendar.Event{
+			Start: &calendar.EventDateTime{DateTime: "2023-01-04T10:00:00-08:00", TimeZone: "America/Los_Angeles"},
+			End:   &calendar.EventDateTime{DateTime: "2023-01-04T10:45:00-08:00", TimeZone: "America/Los_Angeles"},
+		})
+		defer server.Close()
+		googleCalendar := GoogleCalendarSource{Google: GoogleService{OverrideURLs: GoogleURLOverrides{CalendarModifyURL: &server.URL}}}
+
+		err := googleCalendar.ModifyEvent(context.Background(), db, userID, accountID, "series_id_20230301T170000Z", &EventModifyObject{
+			AccountID:     accountID,
+			Scope:         EventModifyScopeSeries,
+			DatetimeStart: &datetimeStart,
+			DatetimeEnd:   &datetimeEnd,
+		})
+		assert.NoError(t, err)
+	})
+}
+
 func assertCalendarEventsEqual(t *testing.T, a *database.CalendarEvent, b *database.CalendarEvent) {
 	assert.Equal(t, a.DatetimeStart, b.DatetimeStart)
 	assert.Equal(t, a.DatetimeEnd, b.DatetimeEnd)
@@ -895,3 +1001,47 @@ func getEventModifyGoogleCalendar(t *testing.T, expectedEvent *calendar.Event, c
 	}
 	return googleCalendar, server
 }
+
+func TestGetEventCategory(t *testing.T) {
+	assert.Equal(t, constants.EventCategoryDefault, getEventCategory(""))
+	assert.Equal(t, constants.EventCategoryDefault, getEventCategory("default"))
+	assert.Equal(t, constants.EventCategoryOutOfOffice, getEventCategory("outOfOffice"))
+	assert.Equal(t, constants.EventCategoryFocusTime, getEventCategory("focusTime"))
+	assert.Equal(t, constants.EventCategoryWorkingLocation, getEventCategory("workingLocation"))
+}
+
+func TestGetConferenceCall(t *testing.T) {
+	t.Run("ConferenceData", func(t *testing.T) {
+		event := &calendar.Event{
+			ConferenceData: &calendar.ConferenceData{
+				ConferenceSolution: &calendar.ConferenceSolution{Name: "Google Meet", IconUri: "https://example.com/meet.png"},
+				EntryPoints:        []*calendar.EntryPoint{{Uri: "https://meet.google.com/abc-defg-hij"}},
+			},
+			Location: "https://zoom.us/j/4746676152",
+		}
+		conferenceCall := GetConferenceCall(event, "me@example.com")
+		assert.Equal(t, "Google Meet", conferenceCall.Platform)
+		assert.Equal(t, "https://meet.google.com/abc-defg-hij?authuser=me@example.com", conferenceCall.URL)
+	})
+	t.Run("Location", func(t *testing.T) {
+		event := &calendar.Event{
+			Location:    "https://acme.zoom.us/j/4746676152?pwd=abc",
+			Description: "Backup bridge: https://teams.microsoft.com/l/meetup-join/19%3ameeting_abc%40thread.v2/0",
+		}
+		conferenceCall := GetConferenceCall(event, "me@example.com")
+		assert.Equal(t, "Zoom", conferenceCall.Platform)
+		assert.Equal(t, "https://acme.zoom.us/j/4746676152?pwd=abc", conferenceCall.URL)
+	})
+	t.Run("Description", func(t *testing.T) {
+		event := &calendar.Event{
+			Location:    "Room 4B",
+			Description: `<a href="https://acme.webex.com/meet/jdoe">Join Webex meeting</a>`,
+		}
+		conferenceCall := GetConferenceCall(event, "me@example.com")
+		assert.Equal(t, "Webex", conferenceCall.Platform)
+		assert.Equal(t, "https://acme.webex.com/meet/jdoe", conferenceCall.URL)
+	})
+	t.Run("None", func(t *testing.T) {
+		assert.Equal(t, &utils.ConferenceCall{}, GetConferenceCall(&calendar.Event{Location: "Room 4B"}, "me@example.com"))
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 
 	"github.com/rs/zerolog/log"
@@ -29,11 +30,17 @@ type GithubConfigValues struct {
 	ListPullRequestReviewURL    *string
 	ListPullRequestReviewersURL *string
 	ListCheckRunsForRefURL      *string
+	GetCombinedStatusURL        *string
+	ListDeploymentsURL          *string
+	ListDeploymentStatusesURL   *string
 	ListPullRequestCommentsURL  *string
 	ListIssueCommentsURL        *string
 	ListRepositoriesURL         *string
 	ListUserTeamsURL            *string
 	PullRequestModifiedURL      *string
+	SubmitReviewURL             *string
+	MergePullRequestURL         *string
+	GetPullRequestURL           *string
 }
 
 type GithubConfig struct {
@@ -47,9 +54,9 @@ type GithubService struct {
 
 func getGithubConfig() *OauthConfig {
 	return &OauthConfig{Config: &oauth2.Config{
-		ClientID:     config.GetConfigValue("GITHUB_OAUTH_CLIENT_ID"),
-		ClientSecret: config.GetConfigValue("GITHUB_OAUTH_CLIENT_SECRET"),
-		RedirectURL:  config.GetConfigValue("SERVER_URL") + "link/github/callback/",
+		ClientID:     config.GetSettings().GithubOauth.ClientID,
+		ClientSecret: config.GetSettings().GithubOauth.ClientSecret,
+		RedirectURL:  config.GetSettings().ServerURL + "link/github/callback/",
 		Scopes:       []string{"repo"},
 		Endpoint: oauth2.Endpoint{
 			AuthURL:  "https://github.com/login/oauth/authorize",
@@ -58,11 +65,11 @@ func getGithubConfig() *OauthConfig {
 	}}
 }
 
-func GetGithubToken(externalAPITokenCollection *mongo.Collection, userID primitive.ObjectID, accountID string) (*oauth2.Token, error) {
+func GetGithubToken(ctx context.Context, externalAPITokenCollection *mongo.Collection, userID primitive.ObjectID, accountID string) (*oauth2.Token, error) {
 	var githubToken database.ExternalAPIToken
 
 	if err := externalAPITokenCollection.FindOne(
-		context.Background(),
+		ctx,
 		bson.M{"$and": []bson.M{
 			{"user_id": userID},
 			{"service_id": TASK_SERVICE_ID_GITHUB},
@@ -135,16 +142,39 @@ func (githubService GithubService) HandleLinkCallback(db *mongo.Database, params
 	return nil
 }
 
-func (github GithubService) HandleSignupCallback(db *mongo.Database, params CallbackParams) (primitive.ObjectID, *bool, *string, error) {
+func (github GithubService) HandleSignupCallback(ctx context.Context, db *mongo.Database, params CallbackParams) (primitive.ObjectID, *bool, *string, error) {
 	return primitive.NilObjectID, nil, nil, errors.New("github does not support signup")
 }
 
 func getGithubClientFromToken(ctx context.Context, token *oauth2.Token) *github.Client {
+	return github.NewClient(getGithubHTTPClient(ctx, token))
+}
+
+// getConditionalGithubClientFromToken returns a client that replays the user's stored responses
+// for GithubConditionalResources when Github reports they haven't changed
+func getConditionalGithubClientFromToken(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, token *oauth2.Token) *github.Client {
+	httpClient := getGithubHTTPClient(ctx, token)
+	httpClient.Transport = &ConditionalFetchTransport{
+		Base:      httpClient.Transport,
+		DB:        db,
+		UserID:    userID,
+		Key:       getGithubRateLimitKey(token),
+		Resources: GithubConditionalResources,
+	}
+	return github.NewClient(httpClient)
+}
+
+func getGithubHTTPClient(ctx context.Context, token *oauth2.Token) *http.Client {
 	tokenSource := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: token.AccessToken},
 	)
 	tokenClient := oauth2.NewClient(ctx, tokenSource)
-	return github.NewClient(tokenClient)
+	tokenClient.Transport = &githubRateLimitTransport{
+		Base:   tokenClient.Transport,
+		Key:    getGithubRateLimitKey(token),
+		Budget: githubRateLimits,
+	}
+	return tokenClient
 }
 
 func getGithubUserInfoFromToken(ctx context.Context, token *oauth2.Token, currentlyAuthedUserFilter string, overrideURL *string) (int64, string, error) {
@@ -0,0 +1,70 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/franchizzle/task-manager/backend/config"
+	"github.com/franchizzle/task-manager/backend/constants"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/oauth2"
+)
+
+// GithubLoginService signs users in with Github. It's a separate OAuth app from the one used to link
+// Github for pull requests, since it only asks for the user's profile and emails
+type GithubLoginService struct {
+	OauthConfig OauthConfigWrapper
+	OverrideURL string
+}
+
+func getGithubLoginConfig() *OauthConfig {
+	return &OauthConfig{Config: &oauth2.Config{
+		ClientID:     config.GetSettings().GithubLoginOauth.ClientID,
+		ClientSecret: config.GetSettings().GithubLoginOauth.ClientSecret,
+		RedirectURL:  config.GetSettings().ServerURL + "login/providers/github/callback/",
+		Scopes:       []string{"read:user", "user:email"},
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  "https://github.com/login/oauth/authorize",
+			TokenURL: "https://github.com/login/oauth/access_token",
+		},
+	}}
+}
+
+func (githubLogin GithubLoginService) GetLoginURL(stateTokenID primitive.ObjectID) string {
+	return githubLogin.OauthConfig.AuthCodeURL(stateTokenID.Hex())
+}
+
+func (githubLogin GithubLoginService) GetLoginUserInfo(ctx context.Context, code string) (*LoginUserInfo, error) {
+	extCtx, cancel := context.WithTimeout(ctx, constants.ExternalTimeout)
+	defer cancel()
+	token, err := githubLogin.OauthConfig.Exchange(extCtx, code)
+	if err != nil {
+		return nil, err
+	}
+	githubClient := getGithubClientFromToken(extCtx, token)
+	if githubLogin.OverrideURL != "" {
+		githubClient.BaseURL, _ = url.Parse(githubLogin.OverrideURL + "/")
+	}
+	githubUser, _, err := githubClient.Users.Get(extCtx, CurrentlyAuthedUserFilter)
+	if err != nil {
+		return nil, err
+	}
+	// the profile email is whatever the user chose to make public, so the primary email is used instead
+	emails, _, err := githubClient.Users.ListEmails(extCtx, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, email := range emails {
+		if email.GetPrimary() {
+			return &LoginUserInfo{
+				AccountID:     fmt.Sprint(githubUser.GetID()),
+				Email:         email.GetEmail(),
+				EmailVerified: email.GetVerified(),
+				Name:          githubUser.GetName(),
+			}, nil
+		}
+	}
+	return nil, errors.New("github user has no primary email")
+}
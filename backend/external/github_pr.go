@@ -9,7 +9,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/franchizzle/task-manager/backend/config"
 	"github.com/franchizzle/task-manager/backend/logging"
+	"github.com/rs/zerolog/log"
 	"golang.org/x/oauth2"
 
 	"github.com/franchizzle/task-manager/backend/constants"
@@ -39,6 +41,7 @@ const (
 	ActionAddressComments   string = "Address Comments"
 	ActionFixMergeConflicts string = "Fix Merge Conflicts"
 	ActionWaitingOnCI       string = "Waiting on CI"
+	ActionWaitingOnDeploy   string = "Waiting on Deploy"
 	ActionMergePR           string = "Merge PR"
 	ActionWaitingOnReview   string = "Waiting on Review"
 	ActionWaitingOnAuthor   string = "Waiting on Author"
@@ -52,10 +55,11 @@ var ActionOrdering = map[string]int{
 	ActionAddressComments:   3,
 	ActionFixMergeConflicts: 4,
 	ActionWaitingOnCI:       5,
-	ActionMergePR:           6,
-	ActionWaitingOnReview:   7,
-	ActionWaitingOnAuthor:   8,
-	ActionNoneNeeded:        9,
+	ActionWaitingOnDeploy:   6,
+	ActionMergePR:           7,
+	ActionWaitingOnReview:   8,
+	ActionWaitingOnAuthor:   9,
+	ActionNoneNeeded:        10,
 }
 
 const (
@@ -64,10 +68,25 @@ const (
 	ChecksConclusionTimedOut string = "timed_out"
 )
 
+const (
+	CommitStatusStatePending string = "pending"
+	CommitStatusStateFailure string = "failure"
+	CommitStatusStateError   string = "error"
+)
+
+const (
+	DeploymentStatePending    string = "pending"
+	DeploymentStateQueued     string = "queued"
+	DeploymentStateInProgress string = "in_progress"
+)
+
 const (
 	GithubAPIBaseURL string = "https://api.github.com/"
 )
 
+// number of PRs fetched at once for a user, unless overridden by GITHUB_PR_FETCH_CONCURRENCY
+const DefaultGithubPRFetchConcurrency = 8
+
 type GithubPRSource struct {
 	Github GithubService
 }
@@ -80,6 +99,7 @@ type GithubPRData struct {
 	HaveRequestedChanges bool
 	ChecksDidFail        bool
 	ChecksDidFinish      bool
+	DeploymentsPending   bool
 	IsOwnedByUser        bool
 	UserLogin            string
 	UserIsReviewer       bool
@@ -92,6 +112,7 @@ type GithubPRRequestData struct {
 	PullRequest *github.PullRequest
 	Token       *oauth2.Token
 	UserTeams   []*github.Team
+	RequestTime primitive.DateTime
 }
 
 type GithubUserResult struct {
@@ -111,33 +132,31 @@ type GithubRepositoriesResult struct {
 
 type ProcessRepositoryResult struct {
 	PullRequestChannels []chan *database.PullRequest
-	RequestTimes        []primitive.DateTime
 	Error               error
 	ShouldLog           bool
 }
 
-func (gitPR GithubPRSource) GetEvents(db *mongo.Database, userID primitive.ObjectID, accountID string, startTime time.Time, endTime time.Time, scopes []string, result chan<- CalendarResult) {
+func (gitPR GithubPRSource) GetEvents(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, startTime time.Time, endTime time.Time, scopes []string, result chan<- CalendarResult) {
 	result <- emptyCalendarResult(errors.New("github PR cannot fetch events"))
 }
 
-func (gitPR GithubPRSource) GetTasks(db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- TaskResult) {
+func (gitPR GithubPRSource) GetTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- TaskResult) {
 	result <- emptyTaskResult(nil)
 }
 
-func (gitPR GithubPRSource) GetPullRequests(db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- PullRequestResult) {
+func (gitPR GithubPRSource) GetPullRequests(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- PullRequestResult) {
 	logger := logging.GetSentryLogger()
-	err := database.InsertLogEvent(db, userID, "get_pull_requests")
+	err := database.InsertLogEvent(ctx, db, userID, "get_pull_requests")
 	if err != nil {
 		logger.Error().Err(err).Msg("error inserting log event")
 	}
-	parentCtx := context.Background()
 
 	var githubClient *github.Client
 	// need to copy github client for each async call so that override url setting is threadsafe
 	var githubClientUser *github.Client
 	var githubClientTeams *github.Client
 	var githubClientRepos *github.Client
-	extCtx, cancel := context.WithTimeout(parentCtx, constants.ExternalTimeout)
+	extCtx, cancel := context.WithTimeout(ctx, constants.ExternalTimeout)
 	defer cancel()
 
 	var token *oauth2.Token
@@ -145,7 +164,7 @@ func (gitPR GithubPRSource) GetPullRequests(db *mongo.Database, userID primitive
 		externalAPITokenCollection := database.GetExternalTokenCollection(db)
 		// need to do this to ensure `token` is the same `token` initialized above vs creating a new one with the := operator
 		var err error
-		token, err = GetGithubToken(externalAPITokenCollection, userID, accountID)
+		token, err = GetGithubToken(ctx, externalAPITokenCollection, userID, accountID)
 		if token == nil {
 			logger.Error().Msg("failed to fetch Github API token")
 			result <- emptyPullRequestResult(errors.New("failed to fetch Github API token"), false)
@@ -156,10 +175,10 @@ func (gitPR GithubPRSource) GetPullRequests(db *mongo.Database, userID primitive
 			return
 		}
 
-		githubClient = getGithubClientFromToken(extCtx, token)
+		githubClient = getConditionalGithubClientFromToken(extCtx, db, userID, token)
 		githubClientUser = getGithubClientFromToken(extCtx, token)
 		githubClientTeams = getGithubClientFromToken(extCtx, token)
-		githubClientRepos = getGithubClientFromToken(extCtx, token)
+		githubClientRepos = getConditionalGithubClientFromToken(extCtx, db, userID, token)
 	} else {
 		githubClient = github.NewClient(nil)
 		githubClientUser = github.NewClient(nil)
@@ -167,7 +186,13 @@ func (gitPR GithubPRSource) GetPullRequests(db *mongo.Database, userID primitive
 		githubClientRepos = github.NewClient(nil)
 	}
 
-	extCtx, cancel = context.WithTimeout(parentCtx, constants.ExternalTimeout)
+	if githubRateLimits.IsExhausted(getGithubRateLimitKey(token)) {
+		// every request would be rejected with a 403 until the budget resets, so keep the PRs we have
+		result <- emptyPullRequestResult(errors.New("Github API rate limit exhausted"), true)
+		return
+	}
+
+	extCtx, cancel = context.WithTimeout(ctx, constants.ExternalTimeout)
 	defer cancel()
 
 	userResultChan := make(chan GithubUserResult)
@@ -181,45 +206,67 @@ func (gitPR GithubPRSource) GetPullRequests(db *mongo.Database, userID primitive
 
 	userResult := <-userResultChan
 	if userResult.Error != nil || userResult.User == nil {
-		shouldLog := handleErrorLogging(userResult.Error, db, userID, "failed to fetch Github user")
+		shouldLog := handleErrorLogging(ctx, userResult.Error, db, userID, "failed to fetch Github user")
 		result <- emptyPullRequestResult(errors.New("failed to fetch Github user"), !shouldLog)
 		return
 	}
 
 	userTeamsResult := <-userTeamsResultChan
 	if userTeamsResult.Error != nil {
-		shouldLog := handleErrorLogging(userTeamsResult.Error, db, userID, "failed to fetch Github user teams")
+		shouldLog := handleErrorLogging(ctx, userTeamsResult.Error, db, userID, "failed to fetch Github user teams")
 		result <- emptyPullRequestResult(errors.New("failed to fetch Github user teams"), !shouldLog)
 		return
 	}
 
 	repositoriesResult := <-repositoriesResultChan
 	if repositoriesResult.Error != nil {
-		shouldLog := handleErrorLogging(repositoriesResult.Error, db, userID, "failed to fetch Github repos for user")
+		shouldLog := handleErrorLogging(ctx, repositoriesResult.Error, db, userID, "failed to fetch Github repos for user")
 		result <- emptyPullRequestResult(errors.New("failed to fetch Github repos for user"), !shouldLog)
 		return
 	}
 
+	repositoryFilter, err := GetGithubRepositoryFilter(ctx, db, userID, accountID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to load Github repository filter")
+		result <- emptyPullRequestResult(errors.New("failed to load Github repository filter"), false)
+		return
+	}
+	pullRequestFilter, err := GetGithubPullRequestFilter(ctx, db, userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to load Github PR filter")
+		result <- emptyPullRequestResult(errors.New("failed to load Github PR filter"), false)
+		return
+	}
+
+	// PR details for every repository share one pool so a user with many open PRs doesn't burst
+	concurrency := getGithubPRFetchConcurrency()
+	pool := NewWorkerPool(concurrency)
+	defer pool.Close()
+
 	processRepositoryResultChannels := []chan ProcessRepositoryResult{}
 	for _, repository := range repositoriesResult.Repositories {
 		processRepositoryResultChan := make(chan ProcessRepositoryResult)
-		go gitPR.processRepository(db, userID, accountID, repository, githubClient, token, userResult.User, userTeamsResult.UserTeams, processRepositoryResultChan)
+		go gitPR.processRepository(ctx, db, userID, accountID, repository, githubClient, token, userResult.User, userTeamsResult.UserTeams, repositoryFilter, pullRequestFilter, pool, processRepositoryResultChan)
 		processRepositoryResultChannels = append(processRepositoryResultChannels, processRepositoryResultChan)
 	}
 
 	var pullRequestChannels []chan *database.PullRequest
-	var requestTimes []primitive.DateTime
 	for _, processRepositoryResultChan := range processRepositoryResultChannels {
 		processRepositoryResult := <-processRepositoryResultChan
 		if processRepositoryResult.Error != nil {
 			result <- emptyPullRequestResult(errors.New("failed to process Github repo"), !processRepositoryResult.ShouldLog)
 		}
 		pullRequestChannels = append(pullRequestChannels, processRepositoryResult.PullRequestChannels...)
-		requestTimes = append(requestTimes, processRepositoryResult.RequestTimes...)
 	}
+	log.Info().
+		Str("user_id", userID.Hex()).
+		Int("pull_requests", len(pullRequestChannels)).
+		Int("concurrency", concurrency).
+		Int("max_queue_depth", pool.MaxQueueDepth()).
+		Msg("queued Github PR fetches")
 
 	var pullRequests []*database.PullRequest
-	for index, pullRequestChan := range pullRequestChannels {
+	for _, pullRequestChan := range pullRequestChannels {
 		pullRequest := <-pullRequestChan
 		// if nil, this means that the request ran into an error: continue and keep processing the rest
 		if pullRequest == nil {
@@ -234,8 +281,8 @@ func (gitPR GithubPRSource) GetPullRequests(db *mongo.Database, userID primitive
 
 		isCompleted := false
 		pullRequest.IsCompleted = &isCompleted
-		pullRequest.LastFetched = requestTimes[index]
 		dbPR, err := database.UpdateOrCreatePullRequest(
+			ctx,
 			db,
 			userID,
 			string(pullRequest.IDExternal),
@@ -259,29 +306,38 @@ func (gitPR GithubPRSource) GetPullRequests(db *mongo.Database, userID primitive
 	}
 }
 
-func (gitPR GithubPRSource) processRepository(db *mongo.Database, userID primitive.ObjectID, accountID string, repository *github.Repository, githubClient *github.Client, token *oauth2.Token, githubUser *github.User, userTeams []*github.Team, result chan<- ProcessRepositoryResult) {
-	err := updateOrCreateRepository(db, repository, accountID, userID)
+func (gitPR GithubPRSource) processRepository(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, repository *github.Repository, githubClient *github.Client, token *oauth2.Token, githubUser *github.User, userTeams []*github.Team, repositoryFilter *GithubRepositoryFilter, pullRequestFilter *GithubPullRequestFilter, pool *WorkerPool, result chan<- ProcessRepositoryResult) {
+	// excluded repositories are still stored so they can be added back to the filter list
+	err := updateOrCreateRepository(ctx, db, repository, accountID, userID)
 	if err != nil {
 		logging.GetSentryLogger().Error().Err(err).Msg("failed to update or create repository")
 		result <- ProcessRepositoryResult{Error: err}
 		return
 	}
-	extCtx, cancel := context.WithTimeout(context.Background(), constants.ExternalTimeout)
+	if !repositoryFilter.Includes(repository) {
+		result <- ProcessRepositoryResult{}
+		return
+	}
+	extCtx, cancel := context.WithTimeout(ctx, constants.ExternalTimeout)
 	defer cancel()
 	fetchedPullRequests, err := getGithubPullRequests(extCtx, githubClient, repository, gitPR.Github.Config.ConfigValues.ListPullRequestsURL)
 	if err != nil && shouldLogError(err) {
-		shouldLog := handleErrorLogging(err, db, userID, "failed to fetch Github PRs")
+		shouldLog := handleErrorLogging(ctx, err, db, userID, "failed to fetch Github PRs")
 		result <- ProcessRepositoryResult{Error: err, ShouldLog: shouldLog}
 		return
 	}
-	err = database.InsertLogEvent(db, userID, "list_pull_requests")
+	err = database.InsertLogEvent(ctx, db, userID, "list_pull_requests")
 	if err != nil {
 		logging.GetSentryLogger().Error().Err(err).Msg("failed to insert log event")
 	}
 	var pullRequestChannels []chan *database.PullRequest
-	var requestTimes []primitive.DateTime
 	for _, pullRequest := range fetchedPullRequests {
-		pullRequestChan := make(chan *database.PullRequest)
+		// filtered PRs aren't returned, so they're marked completed like those in excluded repositories
+		if !pullRequestFilter.Includes(pullRequest) {
+			continue
+		}
+		// buffered so workers don't wait on results being read in order
+		pullRequestChan := make(chan *database.PullRequest, 1)
 		requestData := GithubPRRequestData{
 			Client:      githubClient,
 			User:        githubUser,
@@ -289,16 +345,18 @@ func (gitPR GithubPRSource) processRepository(db *mongo.Database, userID primiti
 			PullRequest: pullRequest,
 			Token:       token,
 			UserTeams:   userTeams,
+			RequestTime: primitive.NewDateTimeFromTime(time.Now()),
 		}
-		requestTimes = append(requestTimes, primitive.NewDateTimeFromTime(time.Now()))
-		go gitPR.getPullRequestInfo(db, userID, accountID, requestData, pullRequestChan)
+		pool.Submit(func() {
+			gitPR.getPullRequestInfo(ctx, db, userID, accountID, requestData, pullRequestChan)
+		})
 		pullRequestChannels = append(pullRequestChannels, pullRequestChan)
 	}
-	result <- ProcessRepositoryResult{PullRequestChannels: pullRequestChannels, RequestTimes: requestTimes}
+	result <- ProcessRepositoryResult{PullRequestChannels: pullRequestChannels}
 }
 
-func (gitPR GithubPRSource) getPullRequestInfo(db *mongo.Database, userID primitive.ObjectID, accountID string, requestData GithubPRRequestData, result chan<- *database.PullRequest) {
-	err := database.InsertLogEvent(db, userID, "get_pull_request_info")
+func (gitPR GithubPRSource) getPullRequestInfo(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, requestData GithubPRRequestData, result chan<- *database.PullRequest) {
+	err := database.InsertLogEvent(ctx, db, userID, "get_pull_request_info")
 	if err != nil {
 		logging.GetSentryLogger().Error().Err(err).Msg("failed to insert log event")
 	}
@@ -308,7 +366,7 @@ func (gitPR GithubPRSource) getPullRequestInfo(db *mongo.Database, userID primit
 	pullRequest := requestData.PullRequest
 
 	// do the check
-	extCtx, cancel := context.WithTimeout(context.Background(), constants.ExternalTimeout)
+	extCtx, cancel := context.WithTimeout(ctx, constants.ExternalTimeout)
 	defer cancel()
 	hasBeenModified, cachedPR := pullRequestHasBeenModified(db, extCtx, userID, requestData, gitPR.Github.Config.ConfigValues.PullRequestModifiedURL)
 	if !hasBeenModified {
@@ -318,69 +376,99 @@ func (gitPR GithubPRSource) getPullRequestInfo(db *mongo.Database, userID primit
 
 	err = setOverrideURL(githubClient, gitPR.Github.Config.ConfigValues.ListPullRequestReviewURL)
 	if err != nil {
-		handleErrorLogging(err, db, userID, "failed to set override url for Github PR reviews")
+		handleErrorLogging(ctx, err, db, userID, "failed to set override url for Github PR reviews")
 		result <- nil
 		return
 	}
 	reviews, _, err := githubClient.PullRequests.ListReviews(extCtx, *repository.Owner.Login, *repository.Name, *pullRequest.Number, nil)
 	if err != nil {
-		handleErrorLogging(err, db, userID, "failed to fetch Github PR reviews")
+		handleErrorLogging(ctx, err, db, userID, "failed to fetch Github PR reviews")
 		result <- nil
 		return
 	}
 
 	// refresh context to prevent timeout
-	extCtx, cancel = context.WithTimeout(context.Background(), constants.ExternalTimeout)
+	extCtx, cancel = context.WithTimeout(ctx, constants.ExternalTimeout)
 	defer cancel()
-	comments, err := getComments(extCtx, githubClient, repository, pullRequest, reviews, gitPR.Github.Config.ConfigValues.ListPullRequestCommentsURL, gitPR.Github.Config.ConfigValues.ListIssueCommentsURL)
-	if err != nil {
-		handleErrorLogging(err, db, userID, "failed to fetch Github PR comments")
-		result <- nil
-		return
-	}
+	lastFetched := requestData.RequestTime
+	var comments []database.PullRequestComment
+	var additions, deletions, numCommits int
+	if githubRateLimits.IsLow(getGithubRateLimitKey(requestData.Token)) {
+		// comments and diff stats can wait until the budget resets. Leaving last_fetched as is
+		// means the next refresh sees this PR as modified and fetches them then
+		lastFetched = 0
+		if cachedPR != nil {
+			comments = cachedPR.Comments
+			additions = cachedPR.Additions
+			deletions = cachedPR.Deletions
+			numCommits = cachedPR.CommitCount
+		}
+	} else {
+		comments, err = getComments(extCtx, githubClient, repository, pullRequest, reviews, gitPR.Github.Config.ConfigValues.ListPullRequestCommentsURL, gitPR.Github.Config.ConfigValues.ListIssueCommentsURL)
+		if err != nil {
+			handleErrorLogging(ctx, err, db, userID, "failed to fetch Github PR comments")
+			result <- nil
+			return
+		}
 
-	additions, deletions, numCommits, err := getAdditionsDeletions(extCtx, githubClient, repository, pullRequest, gitPR.Github.Config.ConfigValues.CompareURL)
-	// if the comparison isn't found, still show the PR but with blank additions / deletions
-	// TODO: have frontend hide the additions / deletions when zeroed out
-	if err != nil && !strings.Contains(err.Error(), "404 Not Found") {
-		handleErrorLogging(err, db, userID, "failed to fetch Github PR additions / deletions")
-		result <- nil
-		return
+		additions, deletions, numCommits, err = getAdditionsDeletions(extCtx, githubClient, repository, pullRequest, gitPR.Github.Config.ConfigValues.CompareURL)
+		// if the comparison isn't found, still show the PR but with blank additions / deletions
+		// TODO: have frontend hide the additions / deletions when zeroed out
+		if err != nil && !strings.Contains(err.Error(), "404 Not Found") {
+			handleErrorLogging(ctx, err, db, userID, "failed to fetch Github PR additions / deletions")
+			result <- nil
+			return
+		}
 	}
 
 	requiredAction := ActionNoneNeeded
+	var commitStatuses []database.PullRequestCommitStatus
+	var deployments []database.PullRequestDeployment
 	isOwner := userIsOwner(githubUser, pullRequest)
 	if isOwner || userIsReviewer(githubUser, pullRequest, reviews, requestData.UserTeams) {
-		extCtx, cancel = context.WithTimeout(context.Background(), constants.ExternalTimeout)
+		extCtx, cancel = context.WithTimeout(ctx, constants.ExternalTimeout)
 		defer cancel()
 
 		reviewers, err := listReviewers(extCtx, githubClient, repository, pullRequest, gitPR.Github.Config.ConfigValues.ListPullRequestReviewersURL)
 		if err != nil {
-			handleErrorLogging(err, db, userID, "failed to fetch Github PR reviewers")
+			handleErrorLogging(ctx, err, db, userID, "failed to fetch Github PR reviewers")
 			result <- nil
 			return
 		}
 		requestedReviewers, err := getReviewerCount(extCtx, githubClient, repository, pullRequest, reviews, gitPR.Github.Config.ConfigValues.ListPullRequestReviewersURL)
 		if err != nil {
-			handleErrorLogging(err, db, userID, "failed to fetch Github PR reviewers")
+			handleErrorLogging(ctx, err, db, userID, "failed to fetch Github PR reviewers")
 			result <- nil
 			return
 		}
 		pullRequestFetch, _, err := githubClient.PullRequests.Get(extCtx, *repository.Owner.Login, *repository.Name, *pullRequest.Number)
 		if err != nil {
-			handleErrorLogging(err, db, userID, "failed to fetch Github PR")
+			handleErrorLogging(ctx, err, db, userID, "failed to fetch Github PR")
 			result <- nil
 			return
 		}
 		// check runs are individual tests that make up a check suite associated with a commit
 		checkRunsForCommit, err := listCheckRunsForCommit(extCtx, githubClient, repository, pullRequest, gitPR.Github.Config.ConfigValues.ListCheckRunsForRefURL)
 		if err != nil {
-			handleErrorLogging(err, db, userID, "failed to fetch Github PR check runs")
+			handleErrorLogging(ctx, err, db, userID, "failed to fetch Github PR check runs")
+			result <- nil
+			return
+		}
+		// commit statuses are the older API some CI providers still report through instead of check runs
+		commitStatuses, err = getCommitStatuses(extCtx, githubClient, repository, pullRequest, gitPR.Github.Config.ConfigValues.GetCombinedStatusURL)
+		if err != nil {
+			handleErrorLogging(ctx, err, db, userID, "failed to fetch Github PR commit statuses")
+			result <- nil
+			return
+		}
+		deployments, err = getDeployments(extCtx, githubClient, repository, pullRequest, gitPR.Github.Config.ConfigValues.ListDeploymentsURL, gitPR.Github.Config.ConfigValues.ListDeploymentStatusesURL)
+		if err != nil {
+			handleErrorLogging(ctx, err, db, userID, "failed to fetch Github PR deployments")
 			result <- nil
 			return
 		}
-		checksDidFail := checkRunsDidFail(checkRunsForCommit)
-		checksDidFinish := checkRunsDidFinish(checkRunsForCommit)
+		checksDidFail := checkRunsDidFail(checkRunsForCommit) || commitStatusesDidFail(commitStatuses)
+		checksDidFinish := checkRunsDidFinish(checkRunsForCommit) && commitStatusesDidFinish(commitStatuses)
 
 		requiredAction = getPullRequestRequiredAction(GithubPRData{
 			RequestedReviewers:   requestedReviewers,
@@ -390,12 +478,19 @@ func (gitPR GithubPRSource) getPullRequestInfo(db *mongo.Database, userID primit
 			HaveRequestedChanges: reviewersHaveRequestedChanges(reviews),
 			ChecksDidFail:        checksDidFail,
 			ChecksDidFinish:      checksDidFinish,
+			DeploymentsPending:   deploymentsArePending(deployments),
 			IsOwnedByUser:        isOwner,
 			UserLogin:            githubUser.GetLogin(),
 			UserIsReviewer:       userNeedsToSubmitReview(githubUser, reviewers, requestData.UserTeams),
 		})
 	}
 
+	firstReviewAt, firstReviewer := getFirstReview(reviews, pullRequest.User.GetLogin())
+	var firstReviewAtDateTime primitive.DateTime
+	if !firstReviewAt.IsZero() {
+		firstReviewAtDateTime = primitive.NewDateTimeFromTime(firstReviewAt)
+	}
+	isDraft := pullRequest.GetDraft()
 	result <- &database.PullRequest{
 		UserID:            userID,
 		IDExternal:        fmt.Sprint(pullRequest.GetID()),
@@ -411,23 +506,38 @@ func (gitPR GithubPRSource) getPullRequestInfo(db *mongo.Database, userID primit
 		Author:            pullRequest.User.GetLogin(),
 		Branch:            pullRequest.Head.GetRef(),
 		BaseBranch:        pullRequest.Base.GetRef(),
+		IsDraft:           &isDraft,
+		Labels:            getGithubPullRequestLabels(pullRequest),
+		CommitStatuses:    commitStatuses,
+		Deployments:       deployments,
 		RequiredAction:    requiredAction,
 		Comments:          comments,
 		CommentCount:      len(comments),
 		CommitCount:       numCommits,
 		Additions:         additions,
 		Deletions:         deletions,
+		LastFetched:       lastFetched,
 		LastUpdatedAt:     primitive.NewDateTimeFromTime(pullRequest.GetUpdatedAt()),
+		FirstReviewAt:     firstReviewAtDateTime,
+		FirstReviewer:     firstReviewer,
+	}
+}
+
+func getGithubPRFetchConcurrency() int {
+	concurrency := config.GetSettings().GithubPRFetchConcurrency
+	if concurrency < 1 {
+		return DefaultGithubPRFetchConcurrency
 	}
+	return concurrency
 }
 
-func handleErrorLogging(err error, db *mongo.Database, userID primitive.ObjectID, msg string) bool {
+func handleErrorLogging(ctx context.Context, err error, db *mongo.Database, userID primitive.ObjectID, msg string) bool {
 	shouldLog := shouldLogError(err)
 	if shouldLog {
 		logging.GetSentryLogger().Error().Err(err).Msg(msg)
 	}
 	if strings.Contains(err.Error(), "403 API rate limit") {
-		err := database.InsertLogEvent(db, userID, "github_pr_rate_limited")
+		err := database.InsertLogEvent(ctx, db, userID, "github_pr_rate_limited")
 		if err != nil {
 			logging.GetSentryLogger().Error().Err(err).Msg(msg)
 		}
@@ -462,7 +572,7 @@ func pullRequestHasBeenModified(db *mongo.Database, ctx context.Context, userID
 	token := requestData.Token
 	repository := requestData.Repository
 
-	dbPR, err := database.GetPullRequestByExternalID(db, fmt.Sprint(*pullRequest.ID), userID)
+	dbPR, err := database.GetPullRequestByExternalID(ctx, db, fmt.Sprint(*pullRequest.ID), userID)
 	if err != nil {
 		// if fail to fetch from DB, fetch from Github
 		if err != mongo.ErrNoDocuments {
@@ -491,6 +601,8 @@ func pullRequestHasBeenModified(db *mongo.Database, ctx context.Context, userID
 		logger.Error().Err(err).Msg("error with github http request")
 		return true, dbPR
 	}
+	defer resp.Body.Close()
+	githubRateLimits.Record(getGithubRateLimitKey(token), resp.Header)
 
 	return (resp.StatusCode != http.StatusNotModified), dbPR
 }
@@ -527,10 +639,10 @@ func getGithubRepositories(ctx context.Context, githubClient *github.Client, cur
 	result <- GithubRepositoriesResult{Repositories: repositories, Error: err}
 }
 
-func updateOrCreateRepository(db *mongo.Database, repository *github.Repository, accountID string, userID primitive.ObjectID) error {
+func updateOrCreateRepository(ctx context.Context, db *mongo.Database, repository *github.Repository, accountID string, userID primitive.ObjectID) error {
 	repositoryCollection := database.GetRepositoryCollection(db)
 	_, err := repositoryCollection.UpdateOne(
-		context.Background(),
+		ctx,
 		bson.M{"$and": []bson.M{
 			// TODO: add account_id to query once backfill is completed
 			{"repository_id": fmt.Sprint(repository.GetID())},
@@ -600,6 +712,68 @@ func listCheckRunsForCommit(ctx context.Context, githubClient *github.Client, re
 	return checkRuns, err
 }
 
+func getCommitStatuses(ctx context.Context, githubClient *github.Client, repository *github.Repository, pullRequest *github.PullRequest, overrideURL *string) ([]database.PullRequestCommitStatus, error) {
+	err := setOverrideURL(githubClient, overrideURL)
+	if err != nil {
+		return nil, err
+	}
+	combinedStatus, _, err := githubClient.Repositories.GetCombinedStatus(ctx, *repository.Owner.Login, *repository.Name, *pullRequest.Head.SHA, nil)
+	if err != nil {
+		return nil, err
+	}
+	// the combined status only has the latest status for each context
+	commitStatuses := []database.PullRequestCommitStatus{}
+	for _, status := range combinedStatus.Statuses {
+		commitStatuses = append(commitStatuses, database.PullRequestCommitStatus{
+			Context:   status.GetContext(),
+			State:     status.GetState(),
+			TargetURL: status.GetTargetURL(),
+		})
+	}
+	return commitStatuses, nil
+}
+
+// getDeployments returns the state of the latest deployment of the head commit to each environment
+func getDeployments(ctx context.Context, githubClient *github.Client, repository *github.Repository, pullRequest *github.PullRequest, overrideURLDeployments *string, overrideURLStatuses *string) ([]database.PullRequestDeployment, error) {
+	err := setOverrideURL(githubClient, overrideURLDeployments)
+	if err != nil {
+		return nil, err
+	}
+	githubDeployments, _, err := githubClient.Repositories.ListDeployments(ctx, *repository.Owner.Login, *repository.Name, &github.DeploymentsListOptions{SHA: *pullRequest.Head.SHA})
+	if err != nil {
+		return nil, err
+	}
+	deployments := []database.PullRequestDeployment{}
+	seenEnvironments := map[string]bool{}
+	// deployments are listed newest first
+	for _, githubDeployment := range githubDeployments {
+		if seenEnvironments[githubDeployment.GetEnvironment()] {
+			continue
+		}
+		seenEnvironments[githubDeployment.GetEnvironment()] = true
+
+		err = setOverrideURL(githubClient, overrideURLStatuses)
+		if err != nil {
+			return nil, err
+		}
+		statuses, _, err := githubClient.Repositories.ListDeploymentStatuses(ctx, *repository.Owner.Login, *repository.Name, githubDeployment.GetID(), &github.ListOptions{PerPage: 1})
+		if err != nil {
+			return nil, err
+		}
+		// a deployment without any statuses hasn't been picked up yet
+		deployment := database.PullRequestDeployment{
+			Environment: githubDeployment.GetEnvironment(),
+			State:       DeploymentStatePending,
+		}
+		if len(statuses) > 0 {
+			deployment.State = statuses[0].GetState()
+			deployment.URL = statuses[0].GetEnvironmentURL()
+		}
+		deployments = append(deployments, deployment)
+	}
+	return deployments, nil
+}
+
 func userIsOwner(githubUser *github.User, pullRequest *github.PullRequest) bool {
 	return (githubUser.ID != nil &&
 		pullRequest.User.ID != nil &&
@@ -651,6 +825,25 @@ func userIsReviewer(githubUser *github.User, pullRequest *github.PullRequest, re
 	return false
 }
 
+// getFirstReview returns when the first review by someone other than the author was submitted, and
+// by whom. Pending reviews haven't been submitted yet
+func getFirstReview(pullRequestReviews []*github.PullRequestReview, author string) (time.Time, string) {
+	firstReviewAt := time.Time{}
+	firstReviewer := ""
+	for _, review := range pullRequestReviews {
+		reviewer := review.GetUser().GetLogin()
+		submittedAt := review.GetSubmittedAt()
+		if reviewer == author || submittedAt.IsZero() {
+			continue
+		}
+		if firstReviewAt.IsZero() || submittedAt.Before(firstReviewAt) {
+			firstReviewAt = submittedAt
+			firstReviewer = reviewer
+		}
+	}
+	return firstReviewAt, firstReviewer
+}
+
 func pullRequestIsApproved(pullRequestReviews []*github.PullRequestReview) bool {
 	for _, review := range pullRequestReviews {
 		if review.State != nil && *review.State == StateApproved {
@@ -792,6 +985,33 @@ func checkRunsDidFail(checkRuns *github.ListCheckRunsResults) bool {
 	return false
 }
 
+func commitStatusesDidFinish(commitStatuses []database.PullRequestCommitStatus) bool {
+	for _, status := range commitStatuses {
+		if status.State == CommitStatusStatePending {
+			return false
+		}
+	}
+	return true
+}
+
+func commitStatusesDidFail(commitStatuses []database.PullRequestCommitStatus) bool {
+	for _, status := range commitStatuses {
+		if status.State == CommitStatusStateFailure || status.State == CommitStatusStateError {
+			return true
+		}
+	}
+	return false
+}
+
+func deploymentsArePending(deployments []database.PullRequestDeployment) bool {
+	for _, deployment := range deployments {
+		if deployment.State == DeploymentStatePending || deployment.State == DeploymentStateQueued || deployment.State == DeploymentStateInProgress {
+			return true
+		}
+	}
+	return false
+}
+
 func getPullRequestRequiredAction(data GithubPRData) string {
 	var action string
 	if data.IsOwnedByUser {
@@ -805,6 +1025,8 @@ func getPullRequestRequiredAction(data GithubPRData) string {
 			action = ActionFixMergeConflicts
 		} else if !data.ChecksDidFinish {
 			action = ActionWaitingOnCI
+		} else if data.DeploymentsPending {
+			action = ActionWaitingOnDeploy
 		} else if data.IsApproved {
 			action = ActionMergePR
 		} else {
@@ -821,27 +1043,27 @@ func getPullRequestRequiredAction(data GithubPRData) string {
 	return action
 }
 
-func (gitPR GithubPRSource) CreateNewTask(db *mongo.Database, userID primitive.ObjectID, accountID string, task TaskCreationObject) (primitive.ObjectID, error) {
+func (gitPR GithubPRSource) CreateNewTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, task TaskCreationObject) (primitive.ObjectID, error) {
 	return primitive.NilObjectID, errors.New("has not been implemented yet")
 }
 
-func (gitPR GithubPRSource) CreateNewEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error {
+func (gitPR GithubPRSource) CreateNewEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error {
 	return errors.New("has not been implemented yet")
 }
 
-func (gitPR GithubPRSource) DeleteEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error {
+func (gitPR GithubPRSource) DeleteEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error {
 	return errors.New("has not been implemented yet")
 }
 
-func (gitPR GithubPRSource) ModifyTask(db *mongo.Database, userID primitive.ObjectID, accountID string, issueID string, updateFields *database.Task, task *database.Task) error {
+func (gitPR GithubPRSource) ModifyTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, issueID string, updateFields *database.Task, task *database.Task) error {
 	// allow users to mark PR as done in GT even if it's not done in Github
 	return nil
 }
 
-func (gitPR GithubPRSource) ModifyEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, eventID string, updateFields *EventModifyObject) error {
+func (gitPR GithubPRSource) ModifyEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, eventID string, updateFields *EventModifyObject) error {
 	return errors.New("has not been implemented yet")
 }
 
-func (gitPR GithubPRSource) AddComment(db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error {
+func (gitPR GithubPRSource) AddComment(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error {
 	return errors.New("has not been implemented yet")
 }
@@ -0,0 +1,125 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/google/go-github/v45/github"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	ReviewEventApprove        string = "APPROVE"
+	ReviewEventRequestChanges string = "REQUEST_CHANGES"
+	ReviewEventComment        string = "COMMENT"
+)
+
+const (
+	MergeMethodMerge  string = "merge"
+	MergeMethodSquash string = "squash"
+	MergeMethodRebase string = "rebase"
+)
+
+type PullRequestReviewObject struct {
+	Event string
+	Body  string
+}
+
+// SubmitReview approves, requests changes on, or comments on a pull request as the user
+func (gitPR GithubPRSource) SubmitReview(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, pullRequest *database.PullRequest, review PullRequestReviewObject) error {
+	extCtx, cancel := context.WithTimeout(ctx, constants.ExternalTimeout)
+	defer cancel()
+	githubClient, err := gitPR.getActionGithubClient(extCtx, db, userID, accountID)
+	if err != nil {
+		return err
+	}
+	owner, repositoryName, err := splitRepositoryName(pullRequest.RepositoryName)
+	if err != nil {
+		return err
+	}
+	err = setOverrideURL(githubClient, gitPR.Github.Config.ConfigValues.SubmitReviewURL)
+	if err != nil {
+		return err
+	}
+	reviewRequest := github.PullRequestReviewRequest{Event: &review.Event}
+	if review.Body != "" {
+		reviewRequest.Body = &review.Body
+	}
+	_, _, err = githubClient.PullRequests.CreateReview(extCtx, owner, repositoryName, pullRequest.Number, &reviewRequest)
+	return err
+}
+
+// MergePullRequest merges a pull request as the user. An empty mergeMethod uses the repository's default
+func (gitPR GithubPRSource) MergePullRequest(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, pullRequest *database.PullRequest, mergeMethod string) error {
+	extCtx, cancel := context.WithTimeout(ctx, constants.ExternalTimeout)
+	defer cancel()
+	githubClient, err := gitPR.getActionGithubClient(extCtx, db, userID, accountID)
+	if err != nil {
+		return err
+	}
+	owner, repositoryName, err := splitRepositoryName(pullRequest.RepositoryName)
+	if err != nil {
+		return err
+	}
+	err = setOverrideURL(githubClient, gitPR.Github.Config.ConfigValues.MergePullRequestURL)
+	if err != nil {
+		return err
+	}
+	mergeResult, _, err := githubClient.PullRequests.Merge(extCtx, owner, repositoryName, pullRequest.Number, "", &github.PullRequestOptions{MergeMethod: mergeMethod})
+	if err != nil {
+		return err
+	}
+	if !mergeResult.GetMerged() {
+		return errors.New("pull request was not merged: " + mergeResult.GetMessage())
+	}
+	return nil
+}
+
+// GetPullRequestMergedAt returns when the pull request was merged, or nil if it is open or was
+// closed without merging
+func (gitPR GithubPRSource) GetPullRequestMergedAt(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, pullRequest *database.PullRequest) (*time.Time, error) {
+	extCtx, cancel := context.WithTimeout(ctx, constants.ExternalTimeout)
+	defer cancel()
+	githubClient, err := gitPR.getActionGithubClient(extCtx, db, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	owner, repositoryName, err := splitRepositoryName(pullRequest.RepositoryName)
+	if err != nil {
+		return nil, err
+	}
+	err = setOverrideURL(githubClient, gitPR.Github.Config.ConfigValues.GetPullRequestURL)
+	if err != nil {
+		return nil, err
+	}
+	githubPullRequest, _, err := githubClient.PullRequests.Get(extCtx, owner, repositoryName, pullRequest.Number)
+	if err != nil {
+		return nil, err
+	}
+	return githubPullRequest.MergedAt, nil
+}
+
+func (gitPR GithubPRSource) getActionGithubClient(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string) (*github.Client, error) {
+	if gitPR.Github.Config.ConfigValues.FetchExternalAPIToken == nil || !*gitPR.Github.Config.ConfigValues.FetchExternalAPIToken {
+		return github.NewClient(nil), nil
+	}
+	token, err := GetGithubToken(ctx, database.GetExternalTokenCollection(db), userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return getGithubClientFromToken(ctx, token), nil
+}
+
+// splitRepositoryName splits a repository's full name, e.g. "owner/repository"
+func splitRepositoryName(fullName string) (string, string, error) {
+	owner, repositoryName, found := strings.Cut(fullName, "/")
+	if !found || owner == "" || repositoryName == "" {
+		return "", "", errors.New("invalid repository name")
+	}
+	return owner, repositoryName, nil
+}
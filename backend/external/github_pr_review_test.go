@@ -0,0 +1,77 @@
+package external
+
+import (
+	"context"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/testutils"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func getReviewGithubPRSource(submitReviewURL *string, mergePullRequestURL *string) GithubPRSource {
+	fetchExternalAPIToken := false
+	return GithubPRSource{
+		Github: GithubService{
+			Config: GithubConfig{
+				ConfigValues: GithubConfigValues{
+					FetchExternalAPIToken: &fetchExternalAPIToken,
+					SubmitReviewURL:       submitReviewURL,
+					MergePullRequestURL:   mergePullRequestURL,
+				},
+			},
+		},
+	}
+}
+
+func TestSubmitReview(t *testing.T) {
+	pullRequest := &database.PullRequest{RepositoryName: "dankmemes/ExampleRepository", Number: 1}
+	review := PullRequestReviewObject{Event: ReviewEventApprove}
+
+	t.Run("Success", func(t *testing.T) {
+		server := testutils.GetMockAPIServer(t, 200, `{"id": 80, "state": "APPROVED"}`)
+		defer server.Close()
+		githubPR := getReviewGithubPRSource(&server.URL, nil)
+		err := githubPR.SubmitReview(context.Background(), nil, primitive.NewObjectID(), "account", pullRequest, review)
+		assert.NoError(t, err)
+	})
+	t.Run("GithubError", func(t *testing.T) {
+		server := testutils.GetMockAPIServer(t, 422, `{"message": "Can not approve your own pull request"}`)
+		defer server.Close()
+		githubPR := getReviewGithubPRSource(&server.URL, nil)
+		err := githubPR.SubmitReview(context.Background(), nil, primitive.NewObjectID(), "account", pullRequest, review)
+		assert.ErrorContains(t, err, "Can not approve your own pull request")
+	})
+	t.Run("InvalidRepositoryName", func(t *testing.T) {
+		githubPR := getReviewGithubPRSource(nil, nil)
+		err := githubPR.SubmitReview(context.Background(), nil, primitive.NewObjectID(), "account", &database.PullRequest{RepositoryName: "ExampleRepository"}, review)
+		assert.EqualError(t, err, "invalid repository name")
+	})
+}
+
+func TestMergePullRequest(t *testing.T) {
+	pullRequest := &database.PullRequest{RepositoryName: "dankmemes/ExampleRepository", Number: 1}
+
+	t.Run("Success", func(t *testing.T) {
+		server := testutils.GetMockAPIServer(t, 200, `{"merged": true, "message": "Pull Request successfully merged"}`)
+		defer server.Close()
+		githubPR := getReviewGithubPRSource(nil, &server.URL)
+		err := githubPR.MergePullRequest(context.Background(), nil, primitive.NewObjectID(), "account", pullRequest, MergeMethodSquash)
+		assert.NoError(t, err)
+	})
+	t.Run("NotMerged", func(t *testing.T) {
+		server := testutils.GetMockAPIServer(t, 200, `{"merged": false, "message": "Base branch was modified"}`)
+		defer server.Close()
+		githubPR := getReviewGithubPRSource(nil, &server.URL)
+		err := githubPR.MergePullRequest(context.Background(), nil, primitive.NewObjectID(), "account", pullRequest, "")
+		assert.EqualError(t, err, "pull request was not merged: Base branch was modified")
+	})
+	t.Run("GithubError", func(t *testing.T) {
+		server := testutils.GetMockAPIServer(t, 405, `{"message": "Pull Request is not mergeable"}`)
+		defer server.Close()
+		githubPR := getReviewGithubPRSource(nil, &server.URL)
+		err := githubPR.MergePullRequest(context.Background(), nil, primitive.NewObjectID(), "account", pullRequest, "")
+		assert.ErrorContains(t, err, "Pull Request is not mergeable")
+	})
+}
@@ -29,7 +29,7 @@ func TestMarkGithubPRTaskAsDone(t *testing.T) {
 		userID := primitive.NewObjectID()
 
 		isCompleted := true
-		err := githubSource.ModifyTask(nil, userID, "sample_account@email.com", "6942069420", &database.Task{IsCompleted: &isCompleted}, nil)
+		err := githubSource.ModifyTask(context.Background(), nil, userID, "sample_account@email.com", "6942069420", &database.Task{IsCompleted: &isCompleted}, nil)
 		assert.NoError(t, err)
 	})
 }
@@ -72,6 +72,14 @@ func TestGetPullRequests(t *testing.T) {
 	listCheckRunsForRefURL := &githubListCheckRunsForRefServer.URL
 	defer githubListCheckRunsForRefServer.Close()
 
+	githubCombinedStatusServer := testutils.GetMockAPIServer(t, 200, testutils.EmptyCombinedStatusPayload)
+	combinedStatusURL := &githubCombinedStatusServer.URL
+	defer githubCombinedStatusServer.Close()
+
+	githubListDeploymentsServer := testutils.GetMockAPIServer(t, 200, `[]`)
+	listDeploymentsURL := &githubListDeploymentsServer.URL
+	defer githubListDeploymentsServer.Close()
+
 	githubListPullRequestCommentsServer := testutils.GetMockAPIServer(t, 200, testutils.PullRequestCommentsPayload)
 	listPullRequestCommentsURL := &githubListPullRequestCommentsServer.URL
 	defer githubListPullRequestCommentsServer.Close()
@@ -103,6 +111,8 @@ func TestGetPullRequests(t *testing.T) {
 					ListPullRequestReviewURL:    pullRequestReviewURL,
 					ListPullRequestReviewersURL: pullRequestReviewersURL,
 					ListCheckRunsForRefURL:      listCheckRunsForRefURL,
+					GetCombinedStatusURL:        combinedStatusURL,
+					ListDeploymentsURL:          listDeploymentsURL,
 					ListUserTeamsURL:            listUserTeamsURL,
 					PullRequestModifiedURL:      pullRequestModifiedURL,
 				},
@@ -114,7 +124,7 @@ func TestGetPullRequests(t *testing.T) {
 		userId := primitive.NewObjectID()
 
 		var pullRequests = make(chan PullRequestResult)
-		go githubPR.GetPullRequests(db, userId, "exampleAccountID", pullRequests)
+		go githubPR.GetPullRequests(context.Background(), db, userId, "exampleAccountID", pullRequests)
 		result := <-pullRequests
 
 		assert.NoError(t, result.Error)
@@ -153,7 +163,7 @@ func TestGetPullRequests(t *testing.T) {
 		githubPR.Github.Config.ConfigValues.ListPullRequestsURL = userNotRelevantPullRequestsURL
 
 		var pullRequests = make(chan PullRequestResult)
-		go githubPR.GetPullRequests(db, userId, "exampleAccountID", pullRequests)
+		go githubPR.GetPullRequests(context.Background(), db, userId, "exampleAccountID", pullRequests)
 		result := <-pullRequests
 
 		assert.NoError(t, result.Error)
@@ -206,7 +216,7 @@ func TestGetPullRequests(t *testing.T) {
 		githubPR.Github.Config.ConfigValues.PullRequestModifiedURL = pullRequestNotModifiedURL
 
 		var pullRequests = make(chan PullRequestResult)
-		go githubPR.GetPullRequests(db, userID, "exampleAccountID", pullRequests)
+		go githubPR.GetPullRequests(context.Background(), db, userID, "exampleAccountID", pullRequests)
 		result := <-pullRequests
 
 		assert.NoError(t, result.Error)
@@ -219,7 +229,7 @@ func TestGetPullRequests(t *testing.T) {
 		githubPR.Github.Config.ConfigValues.PullRequestModifiedURL = pullRequestModifiedURL
 
 		pullRequests = make(chan PullRequestResult)
-		go githubPR.GetPullRequests(db, userID, "exampleAccountID", pullRequests)
+		go githubPR.GetPullRequests(context.Background(), db, userID, "exampleAccountID", pullRequests)
 		result = <-pullRequests
 		assert.NoError(t, result.Error)
 		assert.Equal(t, 1, len(result.PullRequests))
@@ -234,7 +244,7 @@ func TestGetPullRequests(t *testing.T) {
 		githubPR.Github.Config.ConfigValues.ListPullRequestsURL = userNoPullRequestsURL
 
 		var pullRequests = make(chan PullRequestResult)
-		go githubPR.GetPullRequests(db, userId, "exampleAccountID", pullRequests)
+		go githubPR.GetPullRequests(context.Background(), db, userId, "exampleAccountID", pullRequests)
 		result := <-pullRequests
 		assert.NoError(t, result.Error)
 		assert.Equal(t, 0, len(result.PullRequests))
@@ -256,7 +266,7 @@ func TestGetPullRequests(t *testing.T) {
 		githubPR.Github.Config.ConfigValues.ListRepositoriesURL = userNoRepositoriesURL
 
 		var pullRequests = make(chan PullRequestResult)
-		go githubPR.GetPullRequests(db, userId, "exampleAccountID", pullRequests)
+		go githubPR.GetPullRequests(context.Background(), db, userId, "exampleAccountID", pullRequests)
 		result := <-pullRequests
 		assert.NoError(t, result.Error)
 		assert.Equal(t, 0, len(result.PullRequests))
@@ -274,7 +284,7 @@ func TestGetPullRequests(t *testing.T) {
 		githubPR.Github.Config.ConfigValues.GetUserURL = nil
 
 		var pullRequests = make(chan PullRequestResult)
-		go githubPR.GetPullRequests(db, userId, "exampleAccountID", pullRequests)
+		go githubPR.GetPullRequests(context.Background(), db, userId, "exampleAccountID", pullRequests)
 		result := <-pullRequests
 
 		assert.Equal(t, result.Error.Error(), "failed to fetch Github user")
@@ -658,6 +668,119 @@ func TestCheckRunsForCommit(t *testing.T) {
 	})
 }
 
+func TestGetCommitStatuses(t *testing.T) {
+	ctx := context.Background()
+	githubClient := github.NewClient(nil)
+
+	repository := &github.Repository{
+		Name: github.String("ExampleRepository"),
+		Owner: &github.User{
+			Login: github.String("chad1616"),
+		},
+	}
+	pullRequest := &github.PullRequest{
+		Number: github.Int(1),
+		Head: &github.PullRequestBranch{
+			SHA: github.String("abc123"),
+		},
+	}
+	t.Run("Success", func(t *testing.T) {
+		githubCombinedStatusServer := testutils.GetMockAPIServer(t, 200, testutils.CombinedStatusPayload)
+		combinedStatusURL := &githubCombinedStatusServer.URL
+		defer githubCombinedStatusServer.Close()
+
+		commitStatuses, err := getCommitStatuses(ctx, githubClient, repository, pullRequest, combinedStatusURL)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []database.PullRequestCommitStatus{
+			{Context: "ci/build", State: "success", TargetURL: "https://ci.example.com/1"},
+			{Context: "ci/lint", State: "failure"},
+		}, commitStatuses)
+	})
+	t.Run("NoStatuses", func(t *testing.T) {
+		githubCombinedStatusServer := testutils.GetMockAPIServer(t, 200, testutils.EmptyCombinedStatusPayload)
+		combinedStatusURL := &githubCombinedStatusServer.URL
+		defer githubCombinedStatusServer.Close()
+
+		commitStatuses, err := getCommitStatuses(ctx, githubClient, repository, pullRequest, combinedStatusURL)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []database.PullRequestCommitStatus{}, commitStatuses)
+		// the combined state is pending when there are no statuses, which shouldn't hold up the PR
+		assert.True(t, commitStatusesDidFinish(commitStatuses))
+	})
+	t.Run("BadStatusCode", func(t *testing.T) {
+		githubCombinedStatusServer := testutils.GetMockAPIServer(t, 503, testutils.CombinedStatusPayload)
+		combinedStatusURL := &githubCombinedStatusServer.URL
+		defer githubCombinedStatusServer.Close()
+
+		commitStatuses, err := getCommitStatuses(ctx, githubClient, repository, pullRequest, combinedStatusURL)
+
+		assert.Error(t, err)
+		assert.Equal(t, fmt.Sprintf("GET %s/repos/chad1616/ExampleRepository/commits/abc123/status: 503  []", *combinedStatusURL), err.Error())
+		assert.Nil(t, commitStatuses)
+	})
+}
+
+func TestGetDeployments(t *testing.T) {
+	ctx := context.Background()
+	githubClient := github.NewClient(nil)
+
+	repository := &github.Repository{
+		Name: github.String("ExampleRepository"),
+		Owner: &github.User{
+			Login: github.String("chad1616"),
+		},
+	}
+	pullRequest := &github.PullRequest{
+		Number: github.Int(1),
+		Head: &github.PullRequestBranch{
+			SHA: github.String("abc123"),
+		},
+	}
+	githubDeploymentsServer := testutils.GetMockAPIServer(t, 200, testutils.DeploymentsPayload)
+	deploymentsURL := &githubDeploymentsServer.URL
+	defer githubDeploymentsServer.Close()
+
+	t.Run("Success", func(t *testing.T) {
+		githubDeploymentStatusesServer := testutils.GetMockAPIServer(t, 200, testutils.DeploymentStatusesPayload)
+		deploymentStatusesURL := &githubDeploymentStatusesServer.URL
+		defer githubDeploymentStatusesServer.Close()
+
+		deployments, err := getDeployments(ctx, githubClient, repository, pullRequest, deploymentsURL, deploymentStatusesURL)
+
+		assert.NoError(t, err)
+		// only the latest staging deployment is kept
+		assert.Equal(t, []database.PullRequestDeployment{
+			{Environment: "staging", State: "in_progress", URL: "https://staging.example.com"},
+			{Environment: "preview", State: "in_progress", URL: "https://staging.example.com"},
+		}, deployments)
+	})
+	t.Run("NoStatuses", func(t *testing.T) {
+		githubDeploymentStatusesServer := testutils.GetMockAPIServer(t, 200, `[]`)
+		deploymentStatusesURL := &githubDeploymentStatusesServer.URL
+		defer githubDeploymentStatusesServer.Close()
+
+		deployments, err := getDeployments(ctx, githubClient, repository, pullRequest, deploymentsURL, deploymentStatusesURL)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []database.PullRequestDeployment{
+			{Environment: "staging", State: DeploymentStatePending},
+			{Environment: "preview", State: DeploymentStatePending},
+		}, deployments)
+	})
+	t.Run("BadStatusCode", func(t *testing.T) {
+		githubDeploymentStatusesServer := testutils.GetMockAPIServer(t, 503, testutils.DeploymentStatusesPayload)
+		deploymentStatusesURL := &githubDeploymentStatusesServer.URL
+		defer githubDeploymentStatusesServer.Close()
+
+		deployments, err := getDeployments(ctx, githubClient, repository, pullRequest, deploymentsURL, deploymentStatusesURL)
+
+		assert.Error(t, err)
+		assert.Nil(t, deployments)
+	})
+}
+
 func TestUserIsReviewer(t *testing.T) {
 	testGithubUserReviewer := &github.User{
 		ID: github.Int64(1),
@@ -751,6 +874,32 @@ func TestPullRequestIsApproved(t *testing.T) {
 	})
 }
 
+func TestGetFirstReview(t *testing.T) {
+	author := &github.User{Login: github.String("author")}
+	reviewer1 := &github.User{Login: github.String("reviewer1")}
+	reviewer2 := &github.User{Login: github.String("reviewer2")}
+	firstReviewTime := time.Date(2023, time.January, 4, 10, 0, 0, 0, time.UTC)
+	secondReviewTime := firstReviewTime.Add(time.Hour)
+	authorReviewTime := firstReviewTime.Add(-time.Hour)
+
+	t.Run("NoReviews", func(t *testing.T) {
+		firstReviewAt, firstReviewer := getFirstReview([]*github.PullRequestReview{}, "author")
+		assert.True(t, firstReviewAt.IsZero())
+		assert.Equal(t, "", firstReviewer)
+	})
+	t.Run("EarliestReviewByOthers", func(t *testing.T) {
+		firstReviewAt, firstReviewer := getFirstReview([]*github.PullRequestReview{
+			{User: reviewer2, SubmittedAt: &secondReviewTime},
+			{User: author, SubmittedAt: &authorReviewTime},
+			// pending reviews haven't been submitted
+			{User: reviewer2},
+			{User: reviewer1, SubmittedAt: &firstReviewTime},
+		}, "author")
+		assert.Equal(t, firstReviewTime, firstReviewAt)
+		assert.Equal(t, "reviewer1", firstReviewer)
+	})
+}
+
 func TestGetComments(t *testing.T) {
 	context := context.Background()
 	githubClient := github.NewClient(nil)
@@ -1155,6 +1304,24 @@ func TestCheckRunsDidFinish(t *testing.T) {
 	})
 }
 
+func TestCommitStatusesDidFail(t *testing.T) {
+	assert.False(t, commitStatusesDidFail([]database.PullRequestCommitStatus{{State: "success"}, {State: "pending"}}))
+	assert.True(t, commitStatusesDidFail([]database.PullRequestCommitStatus{{State: "success"}, {State: "failure"}}))
+	assert.True(t, commitStatusesDidFail([]database.PullRequestCommitStatus{{State: "error"}}))
+}
+
+func TestCommitStatusesDidFinish(t *testing.T) {
+	assert.True(t, commitStatusesDidFinish([]database.PullRequestCommitStatus{{State: "success"}, {State: "failure"}}))
+	assert.False(t, commitStatusesDidFinish([]database.PullRequestCommitStatus{{State: "success"}, {State: "pending"}}))
+}
+
+func TestDeploymentsArePending(t *testing.T) {
+	assert.False(t, deploymentsArePending([]database.PullRequestDeployment{}))
+	assert.False(t, deploymentsArePending([]database.PullRequestDeployment{{State: "success"}, {State: "failure"}}))
+	assert.True(t, deploymentsArePending([]database.PullRequestDeployment{{State: "success"}, {State: "queued"}}))
+	assert.True(t, deploymentsArePending([]database.PullRequestDeployment{{State: "in_progress"}}))
+}
+
 func TestGetPullRequestRequiredAction(t *testing.T) {
 	reviewers := github.Reviewers{
 		Users: []*github.User{},
@@ -1209,6 +1376,18 @@ func TestGetPullRequestRequiredAction(t *testing.T) {
 		action := getPullRequestRequiredAction(pullRequestData)
 		assert.Equal(t, "Waiting on CI", action)
 	})
+	t.Run("WaitingOnDeploy", func(t *testing.T) {
+		pullRequestData := GithubPRData{
+			RequestedReviewers: 1,
+			IsMergeable:        true,
+			IsOwnedByUser:      true,
+			IsApproved:         true,
+			ChecksDidFinish:    true,
+			DeploymentsPending: true,
+		}
+		action := getPullRequestRequiredAction(pullRequestData)
+		assert.Equal(t, "Waiting on Deploy", action)
+	})
 	t.Run("MergePR", func(t *testing.T) {
 		pullRequestData := GithubPRData{
 			RequestedReviewers:   1,
@@ -1359,7 +1538,7 @@ func TestUpdateOrCreateRepository(t *testing.T) {
 	updateFullName := github.String("new_repository_name")
 	updateHTMLURL := github.String("http://new.me")
 	t.Run("SuccessCreate", func(t *testing.T) {
-		err = updateOrCreateRepository(db, repository, "testaccountID", userID)
+		err = updateOrCreateRepository(context.Background(), db, repository, "testaccountID", userID)
 		assert.NoError(t, err)
 
 		var result []database.Repository
@@ -1384,7 +1563,7 @@ func TestUpdateOrCreateRepository(t *testing.T) {
 		repository.FullName = updateFullName
 		repository.HTMLURL = updateHTMLURL
 
-		err = updateOrCreateRepository(db, repository, "testaccountID2", userID)
+		err = updateOrCreateRepository(context.Background(), db, repository, "testaccountID2", userID)
 		assert.NoError(t, err)
 
 		var result []database.Repository
@@ -1408,7 +1587,7 @@ func TestUpdateOrCreateRepository(t *testing.T) {
 		newFullName := github.String("bad_user_id_full_name")
 		repository.FullName = newFullName
 
-		err = updateOrCreateRepository(db, repository, "testaccountID", primitive.NewObjectID())
+		err = updateOrCreateRepository(context.Background(), db, repository, "testaccountID", primitive.NewObjectID())
 		assert.NoError(t, err)
 
 		var result []database.Repository
@@ -1431,7 +1610,7 @@ func TestUpdateOrCreateRepository(t *testing.T) {
 		repository.FullName = newFullName
 		repository.ID = github.Int64(0)
 
-		err = updateOrCreateRepository(db, repository, "testaccountID", userID)
+		err = updateOrCreateRepository(context.Background(), db, repository, "testaccountID", userID)
 		assert.NoError(t, err)
 
 		var result []database.Repository
@@ -0,0 +1,83 @@
+package external
+
+import (
+	"context"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/google/go-github/v45/github"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GithubPullRequestFilter decides which of the PRs in a synced repository are fetched, from the
+// user's draft, label and base branch settings
+type GithubPullRequestFilter struct {
+	HideDrafts bool
+	// empty to include PRs with any labels
+	Label string
+	// empty to include PRs into any branch
+	BaseBranch string
+}
+
+// GetGithubPullRequestFilter loads the user's PR filter. It reads the settings collection directly
+// because the settings package depends on this one
+func GetGithubPullRequestFilter(ctx context.Context, db *mongo.Database, userID primitive.ObjectID) (*GithubPullRequestFilter, error) {
+	hideDrafts, err := getGithubPullRequestFilterSetting(ctx, db, userID, constants.SettingFieldGithubHideDrafts)
+	if err != nil {
+		return nil, err
+	}
+	label, err := getGithubPullRequestFilterSetting(ctx, db, userID, constants.SettingFieldGithubLabelFilter)
+	if err != nil {
+		return nil, err
+	}
+	baseBranch, err := getGithubPullRequestFilterSetting(ctx, db, userID, constants.SettingFieldGithubBaseBranchFilter)
+	if err != nil {
+		return nil, err
+	}
+	return &GithubPullRequestFilter{
+		HideDrafts: hideDrafts == "true",
+		Label:      label,
+		BaseBranch: baseBranch,
+	}, nil
+}
+
+func getGithubPullRequestFilterSetting(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, fieldKey string) (string, error) {
+	userSetting, err := database.GetUserSetting(ctx, db, userID, fieldKey)
+	if err == mongo.ErrNoDocuments {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return userSetting.FieldValue, nil
+}
+
+// Includes returns whether the PR should be fetched. The PRs returned when listing a repository have
+// everything checked here, so filtered PRs don't cost any more requests
+func (filter GithubPullRequestFilter) Includes(pullRequest *github.PullRequest) bool {
+	if filter.HideDrafts && pullRequest.GetDraft() {
+		return false
+	}
+	if filter.BaseBranch != "" && pullRequest.GetBase().GetRef() != filter.BaseBranch {
+		return false
+	}
+	if filter.Label == "" {
+		return true
+	}
+	for _, label := range pullRequest.Labels {
+		if label.GetName() == filter.Label {
+			return true
+		}
+	}
+	return false
+}
+
+// getGithubPullRequestLabels returns the names of the PR's labels
+func getGithubPullRequestLabels(pullRequest *github.PullRequest) []string {
+	labels := []string{}
+	for _, label := range pullRequest.Labels {
+		labels = append(labels, label.GetName())
+	}
+	return labels
+}
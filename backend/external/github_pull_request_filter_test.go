@@ -0,0 +1,45 @@
+package external
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGithubPullRequestFilterIncludes(t *testing.T) {
+	pullRequest := &github.PullRequest{
+		Draft:  github.Bool(false),
+		Base:   &github.PullRequestBranch{Ref: github.String("main")},
+		Labels: []*github.Label{{Name: github.String("bug")}, {Name: github.String("frontend")}},
+	}
+	draftPullRequest := &github.PullRequest{Draft: github.Bool(true)}
+
+	t.Run("NoFilter", func(t *testing.T) {
+		filter := GithubPullRequestFilter{}
+		assert.True(t, filter.Includes(pullRequest))
+		assert.True(t, filter.Includes(draftPullRequest))
+	})
+	t.Run("HideDrafts", func(t *testing.T) {
+		filter := GithubPullRequestFilter{HideDrafts: true}
+		assert.True(t, filter.Includes(pullRequest))
+		assert.False(t, filter.Includes(draftPullRequest))
+	})
+	t.Run("Label", func(t *testing.T) {
+		assert.True(t, GithubPullRequestFilter{Label: "frontend"}.Includes(pullRequest))
+		assert.False(t, GithubPullRequestFilter{Label: "backend"}.Includes(pullRequest))
+		assert.False(t, GithubPullRequestFilter{Label: "frontend"}.Includes(draftPullRequest))
+	})
+	t.Run("BaseBranch", func(t *testing.T) {
+		assert.True(t, GithubPullRequestFilter{BaseBranch: "main"}.Includes(pullRequest))
+		assert.False(t, GithubPullRequestFilter{BaseBranch: "release"}.Includes(pullRequest))
+		assert.False(t, GithubPullRequestFilter{BaseBranch: "main"}.Includes(draftPullRequest))
+	})
+}
+
+func TestGetGithubPullRequestLabels(t *testing.T) {
+	assert.Equal(t, []string{}, getGithubPullRequestLabels(&github.PullRequest{}))
+	assert.Equal(t, []string{"bug", "frontend"}, getGithubPullRequestLabels(&github.PullRequest{
+		Labels: []*github.Label{{Name: github.String("bug")}, {Name: github.String("frontend")}},
+	}))
+}
@@ -0,0 +1,117 @@
+package external
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// below this fraction of the hourly limit, fetches that only refresh PR details are deferred
+const GithubRateLimitLowFraction = 0.1
+
+type GithubRateLimitStatus struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// GithubRateLimitBudget tracks the remaining Github API budget for each token, as reported by the
+// X-RateLimit headers on Github responses
+type GithubRateLimitBudget struct {
+	mutex    sync.Mutex
+	statuses map[string]GithubRateLimitStatus
+	now      func() time.Time
+}
+
+var githubRateLimits = NewGithubRateLimitBudget()
+
+func NewGithubRateLimitBudget() *GithubRateLimitBudget {
+	return &GithubRateLimitBudget{
+		statuses: map[string]GithubRateLimitStatus{},
+		now:      time.Now,
+	}
+}
+
+// Record updates the budget for key from a Github response's headers. Responses without rate
+// limit headers (e.g. from a test server) are ignored
+func (budget *GithubRateLimitBudget) Record(key string, header http.Header) {
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return
+	}
+	budget.mutex.Lock()
+	defer budget.mutex.Unlock()
+	budget.statuses[key] = GithubRateLimitStatus{
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     time.Unix(reset, 0),
+	}
+}
+
+// Status returns the last known budget for key, if it hasn't reset since
+func (budget *GithubRateLimitBudget) Status(key string) (GithubRateLimitStatus, bool) {
+	budget.mutex.Lock()
+	defer budget.mutex.Unlock()
+	status, exists := budget.statuses[key]
+	if !exists {
+		return GithubRateLimitStatus{}, false
+	}
+	if !budget.now().Before(status.Reset) {
+		delete(budget.statuses, key)
+		return GithubRateLimitStatus{}, false
+	}
+	return status, true
+}
+
+// IsLow reports whether non-urgent fetches should be deferred until the budget resets
+func (budget *GithubRateLimitBudget) IsLow(key string) bool {
+	status, exists := budget.Status(key)
+	return exists && float64(status.Remaining) < float64(status.Limit)*GithubRateLimitLowFraction
+}
+
+// IsExhausted reports whether any request made with key would be rejected until the budget resets
+func (budget *GithubRateLimitBudget) IsExhausted(key string) bool {
+	status, exists := budget.Status(key)
+	return exists && status.Remaining <= 0
+}
+
+// getGithubRateLimitKey identifies a token in the budget without keeping the token itself around
+func getGithubRateLimitKey(token *oauth2.Token) string {
+	if token == nil {
+		return ""
+	}
+	hash := sha256.Sum256([]byte(token.AccessToken))
+	return hex.EncodeToString(hash[:8])
+}
+
+// githubRateLimitTransport records the rate limit headers of every response into Budget
+type githubRateLimitTransport struct {
+	Base   http.RoundTripper
+	Key    string
+	Budget *GithubRateLimitBudget
+}
+
+func (transport *githubRateLimitTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	base := transport.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	response, err := base.RoundTrip(request)
+	if err == nil {
+		transport.Budget.Record(transport.Key, response.Header)
+	}
+	return response, err
+}
@@ -0,0 +1,105 @@
+package external
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+func getRateLimitHeader(limit int, remaining int, reset time.Time) http.Header {
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", fmt.Sprint(limit))
+	header.Set("X-RateLimit-Remaining", fmt.Sprint(remaining))
+	header.Set("X-RateLimit-Reset", fmt.Sprint(reset.Unix()))
+	return header
+}
+
+func TestGithubRateLimitBudget(t *testing.T) {
+	now := time.Unix(1660000000, 0)
+	reset := now.Add(time.Hour)
+	getBudget := func() *GithubRateLimitBudget {
+		budget := NewGithubRateLimitBudget()
+		budget.now = func() time.Time { return now }
+		return budget
+	}
+
+	t.Run("Unknown", func(t *testing.T) {
+		budget := getBudget()
+		_, exists := budget.Status("key")
+		assert.False(t, exists)
+		assert.False(t, budget.IsLow("key"))
+		assert.False(t, budget.IsExhausted("key"))
+	})
+	t.Run("Plenty", func(t *testing.T) {
+		budget := getBudget()
+		budget.Record("key", getRateLimitHeader(5000, 4000, reset))
+		status, exists := budget.Status("key")
+		assert.True(t, exists)
+		assert.Equal(t, GithubRateLimitStatus{Limit: 5000, Remaining: 4000, Reset: reset}, status)
+		assert.False(t, budget.IsLow("key"))
+		assert.False(t, budget.IsExhausted("key"))
+	})
+	t.Run("Low", func(t *testing.T) {
+		budget := getBudget()
+		budget.Record("key", getRateLimitHeader(5000, 499, reset))
+		assert.True(t, budget.IsLow("key"))
+		assert.False(t, budget.IsExhausted("key"))
+		assert.False(t, budget.IsLow("other_key"))
+	})
+	t.Run("Exhausted", func(t *testing.T) {
+		budget := getBudget()
+		budget.Record("key", getRateLimitHeader(5000, 0, reset))
+		assert.True(t, budget.IsLow("key"))
+		assert.True(t, budget.IsExhausted("key"))
+	})
+	t.Run("Reset", func(t *testing.T) {
+		budget := getBudget()
+		budget.Record("key", getRateLimitHeader(5000, 0, now))
+		assert.False(t, budget.IsExhausted("key"))
+		_, exists := budget.Status("key")
+		assert.False(t, exists)
+	})
+	t.Run("MissingHeaders", func(t *testing.T) {
+		budget := getBudget()
+		budget.Record("key", http.Header{})
+		_, exists := budget.Status("key")
+		assert.False(t, exists)
+	})
+}
+
+func TestGithubRateLimitTransport(t *testing.T) {
+	reset := time.Now().Add(time.Hour)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for key, values := range getRateLimitHeader(60, 12, reset) {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	budget := NewGithubRateLimitBudget()
+	key := getGithubRateLimitKey(&oauth2.Token{AccessToken: "sample-token"})
+	client := &http.Client{Transport: &githubRateLimitTransport{Key: key, Budget: budget}}
+	response, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	response.Body.Close()
+
+	status, exists := budget.Status(key)
+	assert.True(t, exists)
+	assert.Equal(t, 60, status.Limit)
+	assert.Equal(t, 12, status.Remaining)
+}
+
+func TestGetGithubRateLimitKey(t *testing.T) {
+	assert.Equal(t, "", getGithubRateLimitKey(nil))
+	key := getGithubRateLimitKey(&oauth2.Token{AccessToken: "sample-token"})
+	assert.Equal(t, 16, len(key))
+	assert.NotContains(t, key, "sample-token")
+	assert.Equal(t, key, getGithubRateLimitKey(&oauth2.Token{AccessToken: "sample-token"}))
+	assert.NotEqual(t, key, getGithubRateLimitKey(&oauth2.Token{AccessToken: "other-token"}))
+}
@@ -0,0 +1,88 @@
+package external
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/google/go-github/v45/github"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GithubRepositoryFilter decides which of a Github account's repositories are synced. Repositories
+// are added to the filter list by setting is_in_filter_list in the repositories collection
+type GithubRepositoryFilter struct {
+	Mode string
+	// repository IDs (as returned by Github) in the filter list
+	FilterList map[string]bool
+}
+
+// GetGithubRepositoryFilterFieldKey returns the settings key holding the filter mode for a Github account
+func GetGithubRepositoryFilterFieldKey(accountID string) string {
+	return accountID + "_" + constants.SettingFieldGithubRepositoryFilterMode
+}
+
+// GetGithubRepositoryFilter loads the user's repository filter for a Github account
+func GetGithubRepositoryFilter(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string) (*GithubRepositoryFilter, error) {
+	return getRepositoryFilter(ctx, db, userID, accountID, GetGithubRepositoryFilterFieldKey(accountID))
+}
+
+// GetAzureDevOpsProjectFilterFieldKey returns the settings key holding the project filter mode for an
+// Azure DevOps account
+func GetAzureDevOpsProjectFilterFieldKey(accountID string) string {
+	return accountID + "_" + constants.SettingFieldAzureDevOpsProjectFilterMode
+}
+
+// GetAzureDevOpsProjectFilter loads the user's project filter for an Azure DevOps account. Projects are
+// stored in the repositories collection, so they share the repository filter
+func GetAzureDevOpsProjectFilter(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string) (*GithubRepositoryFilter, error) {
+	return getRepositoryFilter(ctx, db, userID, accountID, GetAzureDevOpsProjectFilterFieldKey(accountID))
+}
+
+// getRepositoryFilter reads the settings collection directly because the settings package depends on this one
+func getRepositoryFilter(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, fieldKey string) (*GithubRepositoryFilter, error) {
+	filter := GithubRepositoryFilter{Mode: constants.ChoiceKeyAllRepositories, FilterList: map[string]bool{}}
+	userSetting, err := database.GetUserSetting(ctx, db, userID, fieldKey)
+	if err == mongo.ErrNoDocuments {
+		return &filter, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	filter.Mode = userSetting.FieldValue
+	if filter.Mode == constants.ChoiceKeyAllRepositories {
+		return &filter, nil
+	}
+	repositories, err := database.GetRepositories(ctx, db, userID, &[]bson.M{
+		{"account_id": accountID},
+		{"is_in_filter_list": true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, repository := range *repositories {
+		filter.FilterList[repository.RepositoryID] = true
+	}
+	return &filter, nil
+}
+
+// Includes returns whether PRs should be fetched for the repository
+func (filter GithubRepositoryFilter) Includes(repository *github.Repository) bool {
+	return filter.IncludesID(fmt.Sprint(repository.GetID()))
+}
+
+// IncludesID is Includes for repositories stored from other services, by their repository_id
+func (filter GithubRepositoryFilter) IncludesID(repositoryID string) bool {
+	inFilterList := filter.FilterList[repositoryID]
+	switch filter.Mode {
+	case constants.ChoiceKeyAllowlist:
+		return inFilterList
+	case constants.ChoiceKeyDenylist:
+		return !inFilterList
+	default:
+		return true
+	}
+}
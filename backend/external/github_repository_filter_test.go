@@ -0,0 +1,88 @@
+package external
+
+import (
+	"context"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/google/go-github/v45/github"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGithubRepositoryFilterIncludes(t *testing.T) {
+	listedRepository := &github.Repository{ID: github.Int64(1)}
+	otherRepository := &github.Repository{ID: github.Int64(2)}
+	filterList := map[string]bool{"1": true}
+
+	t.Run("AllRepositories", func(t *testing.T) {
+		filter := GithubRepositoryFilter{Mode: constants.ChoiceKeyAllRepositories, FilterList: filterList}
+		assert.True(t, filter.Includes(listedRepository))
+		assert.True(t, filter.Includes(otherRepository))
+	})
+	t.Run("Allowlist", func(t *testing.T) {
+		filter := GithubRepositoryFilter{Mode: constants.ChoiceKeyAllowlist, FilterList: filterList}
+		assert.True(t, filter.Includes(listedRepository))
+		assert.False(t, filter.Includes(otherRepository))
+	})
+	t.Run("Denylist", func(t *testing.T) {
+		filter := GithubRepositoryFilter{Mode: constants.ChoiceKeyDenylist, FilterList: filterList}
+		assert.False(t, filter.Includes(listedRepository))
+		assert.True(t, filter.Includes(otherRepository))
+	})
+	t.Run("IncludesID", func(t *testing.T) {
+		filter := GithubRepositoryFilter{Mode: constants.ChoiceKeyAllowlist, FilterList: map[string]bool{"project-guid": true}}
+		assert.True(t, filter.IncludesID("project-guid"))
+		assert.False(t, filter.IncludesID("other-project-guid"))
+	})
+}
+
+func TestGetGithubRepositoryFilter(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+
+	userID := primitive.NewObjectID()
+	accountID := "filter_account"
+	for _, repository := range []*github.Repository{
+		{ID: github.Int64(1), FullName: github.String("dankmemes/listed")},
+		{ID: github.Int64(2), FullName: github.String("dankmemes/unlisted")},
+	} {
+		err = updateOrCreateRepository(context.Background(), db, repository, accountID, userID)
+		assert.NoError(t, err)
+	}
+	// listed on a different account, so it shouldn't affect this one
+	err = updateOrCreateRepository(context.Background(), db, &github.Repository{ID: github.Int64(3)}, "other_account", userID)
+	assert.NoError(t, err)
+	repositories, err := database.GetRepositories(context.Background(), db, userID, nil)
+	assert.NoError(t, err)
+	for _, repository := range *repositories {
+		if repository.RepositoryID != "2" {
+			err = database.UpdateRepositoryFilterList(context.Background(), db, userID, repository.ID, true)
+			assert.NoError(t, err)
+		}
+	}
+
+	t.Run("DefaultsToAllRepositories", func(t *testing.T) {
+		filter, err := GetGithubRepositoryFilter(context.Background(), db, userID, accountID)
+		assert.NoError(t, err)
+		assert.Equal(t, constants.ChoiceKeyAllRepositories, filter.Mode)
+		assert.Empty(t, filter.FilterList)
+	})
+	t.Run("LoadsFilterList", func(t *testing.T) {
+		err := database.UpdateUserSetting(context.Background(), db, userID, GetGithubRepositoryFilterFieldKey(accountID), constants.ChoiceKeyAllowlist)
+		assert.NoError(t, err)
+		filter, err := GetGithubRepositoryFilter(context.Background(), db, userID, accountID)
+		assert.NoError(t, err)
+		assert.Equal(t, constants.ChoiceKeyAllowlist, filter.Mode)
+		assert.Equal(t, map[string]bool{"1": true}, filter.FilterList)
+	})
+	t.Run("UpdateKeepsFilterList", func(t *testing.T) {
+		err := updateOrCreateRepository(context.Background(), db, &github.Repository{ID: github.Int64(1), FullName: github.String("dankmemes/renamed")}, accountID, userID)
+		assert.NoError(t, err)
+		filter, err := GetGithubRepositoryFilter(context.Background(), db, userID, accountID)
+		assert.NoError(t, err)
+		assert.True(t, filter.FilterList["1"])
+	})
+}
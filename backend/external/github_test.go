@@ -19,7 +19,7 @@ func TestGetGithubToken(t *testing.T) {
 	userID := primitive.NewObjectID()
 
 	t.Run("NoDocument", func(t *testing.T) {
-		_, err := GetGithubToken(database.GetExternalTokenCollection(db), userID, "accountID")
+		_, err := GetGithubToken(context.Background(), database.GetExternalTokenCollection(db), userID, "accountID")
 		assert.Equal(t, mongo.ErrNoDocuments, err)
 	})
 	t.Run("Success", func(t *testing.T) {
@@ -30,7 +30,7 @@ func TestGetGithubToken(t *testing.T) {
 			Token:     `{"access_token":"example"}`,
 		})
 
-		result, err := GetGithubToken(database.GetExternalTokenCollection(db), userID, "accountID")
+		result, err := GetGithubToken(context.Background(), database.GetExternalTokenCollection(db), userID, "accountID")
 		assert.NoError(t, err)
 		assert.Equal(t, "example", result.AccessToken)
 	})
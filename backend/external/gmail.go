@@ -0,0 +1,232 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/exp/slices"
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+)
+
+type GmailSource struct {
+	Google GoogleService
+}
+
+const (
+	// the label used until the user picks one of their own
+	GmailDefaultTaskLabelID = "STARRED"
+	GmailInboxLabelID       = "INBOX"
+	GmailMaxThreads         = 50
+)
+
+// GetGmailTaskLabelID returns the label which turns the account's emails into tasks
+func GetGmailTaskLabelID(token *database.ExternalAPIToken) string {
+	if token.GmailTaskLabelID == "" {
+		return GmailDefaultTaskLabelID
+	}
+	return token.GmailTaskLabelID
+}
+
+func (gmailSource GmailSource) GetEvents(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, startTime time.Time, endTime time.Time, scopes []string, result chan<- CalendarResult) {
+	result <- emptyCalendarResult(nil)
+}
+
+// GetTasks syncs a task for each thread with the account's task label. Accounts which haven't
+// granted the gmail scope are skipped
+func (gmailSource GmailSource) GetTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- TaskResult) {
+	externalToken, err := getExternalToken(ctx, db, userID, accountID, TASK_SERVICE_ID_GOOGLE)
+	if err != nil {
+		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_GMAIL)
+		return
+	}
+	if !slices.Contains(externalToken.Scopes, GOOGLE_GMAIL_SCOPE) {
+		result <- emptyTaskResult(nil)
+		return
+	}
+	gmailService, err := gmailSource.createGmailService(db, userID, accountID)
+	if err != nil {
+		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_GMAIL)
+		return
+	}
+
+	extCtx, cancel := context.WithTimeout(ctx, constants.ExternalTimeout)
+	defer cancel()
+	logger := logging.GetSentryLogger()
+	// threads which lose the label are left out, so they are completed here on the next refresh
+	threadList, err := gmailService.Users.Threads.List("me").LabelIds(GetGmailTaskLabelID(externalToken)).MaxResults(GmailMaxThreads).Context(extCtx).Do()
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch gmail threads")
+		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_GMAIL)
+		return
+	}
+
+	var tasks []*database.Task
+	for _, threadItem := range threadList.Threads {
+		thread, err := gmailService.Users.Threads.Get("me", threadItem.Id).Format("metadata").MetadataHeaders("Subject").Context(extCtx).Do()
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to fetch gmail thread")
+			result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_GMAIL)
+			return
+		}
+		task := getGmailTask(thread, accountID)
+		task.UserID = userID
+		task.IDTaskSection = database.GetDefaultTaskSectionID(ctx, db, userID, TASK_SOURCE_ID_GMAIL)
+		isCompleted := false
+		dbTask, err := database.UpdateOrCreateTask(
+			ctx,
+			db,
+			userID,
+			task.IDExternal,
+			task.SourceID,
+			task,
+			database.Task{
+				Title:       task.Title,
+				Body:        task.Body,
+				IsCompleted: &isCompleted,
+			},
+			nil,
+		)
+		if err != nil {
+			result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_GMAIL)
+			return
+		}
+		task.HasBeenReordered = dbTask.HasBeenReordered
+		task.ID = dbTask.ID
+		task.IDOrdering = dbTask.IDOrdering
+		task.IDTaskSection = dbTask.IDTaskSection
+		task.TimeAllocation = dbTask.TimeAllocation
+		tasks = append(tasks, task)
+	}
+
+	result <- TaskResult{
+		Tasks: tasks,
+	}
+}
+
+// getGmailTask maps a thread to a task, titled with the subject of its first email
+func getGmailTask(thread *gmail.Thread, accountID string) *database.Task {
+	title := ""
+	body := thread.Snippet
+	task := &database.Task{
+		IDExternal:      thread.Id,
+		Deeplink:        fmt.Sprintf("https://mail.google.com/mail/?authuser=%s#all/%s", url.QueryEscape(accountID), thread.Id),
+		SourceID:        TASK_SOURCE_ID_GMAIL,
+		Title:           &title,
+		Body:            &body,
+		SourceAccountID: accountID,
+	}
+	if len(thread.Messages) == 0 {
+		return task
+	}
+	firstMessage := thread.Messages[0]
+	if firstMessage.Payload != nil {
+		for _, header := range firstMessage.Payload.Headers {
+			if header.Name == "Subject" {
+				title = header.Value
+				break
+			}
+		}
+	}
+	if title == "" {
+		title = "(no subject)"
+	}
+	// the snippet of the thread is the snippet of its latest email
+	body = thread.Messages[len(thread.Messages)-1].Snippet
+	task.CreatedAtExternal = primitive.NewDateTimeFromTime(time.UnixMilli(firstMessage.InternalDate))
+	return task
+}
+
+func (gmailSource GmailSource) GetPullRequests(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- PullRequestResult) {
+	result <- emptyPullRequestResult(nil, false)
+}
+
+// ModifyTask archives the thread and removes its task label when the task is completed, and labels
+// it again when the task is reopened. Other changes stay on the task
+func (gmailSource GmailSource) ModifyTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, issueID string, updateFields *database.Task, task *database.Task) error {
+	if updateFields.IsCompleted == nil {
+		return nil
+	}
+	externalToken, err := getExternalToken(ctx, db, userID, accountID, TASK_SERVICE_ID_GOOGLE)
+	if err != nil {
+		return err
+	}
+	gmailService, err := gmailSource.createGmailService(db, userID, accountID)
+	if err != nil {
+		return err
+	}
+	extCtx, cancel := context.WithTimeout(ctx, constants.ExternalTimeout)
+	defer cancel()
+	_, err = gmailService.Users.Threads.Modify("me", issueID, getGmailModifyThreadRequest(*updateFields.IsCompleted, GetGmailTaskLabelID(externalToken))).Context(extCtx).Do()
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to update gmail thread")
+	}
+	return err
+}
+
+func getGmailModifyThreadRequest(isCompleted bool, labelID string) *gmail.ModifyThreadRequest {
+	if isCompleted {
+		return &gmail.ModifyThreadRequest{RemoveLabelIds: []string{labelID, GmailInboxLabelID}}
+	}
+	return &gmail.ModifyThreadRequest{AddLabelIds: []string{labelID}}
+}
+
+// GetLabels returns the labels of the account which emails can be turned into tasks with
+func (gmailSource GmailSource) GetLabels(db *mongo.Database, userID primitive.ObjectID, accountID string) ([]*gmail.Label, error) {
+	gmailService, err := gmailSource.createGmailService(db, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	extCtx, cancel := context.WithTimeout(context.Background(), constants.ExternalTimeout)
+	defer cancel()
+	labelList, err := gmailService.Users.Labels.List("me").Context(extCtx).Do()
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch gmail labels")
+		return nil, err
+	}
+	return labelList.Labels, nil
+}
+
+func (gmailSource GmailSource) createGmailService(db *mongo.Database, userID primitive.ObjectID, accountID string) (*gmail.Service, error) {
+	ctx := context.Background()
+	if gmailSource.Google.OverrideURLs.GmailURL != nil {
+		return gmail.NewService(ctx, option.WithoutAuthentication(), option.WithEndpoint(*gmailSource.Google.OverrideURLs.GmailURL))
+	}
+	client := getGoogleHttpClient(ctx, db, userID, accountID)
+	if client == nil {
+		return nil, errors.New("failed to fetch google API token")
+	}
+	gmailService, err := gmail.NewService(ctx, option.WithHTTPClient(client))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create gmail service: %w", err)
+	}
+	return gmailService, nil
+}
+
+func (gmailSource GmailSource) CreateNewTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, task TaskCreationObject) (primitive.ObjectID, error) {
+	return primitive.NilObjectID, errors.New("has not been implemented yet")
+}
+
+func (gmailSource GmailSource) CreateNewEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error {
+	return errors.New("has not been implemented yet")
+}
+
+func (gmailSource GmailSource) ModifyEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, eventID string, updateFields *EventModifyObject) error {
+	return errors.New("has not been implemented yet")
+}
+
+func (gmailSource GmailSource) DeleteEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error {
+	return errors.New("has not been implemented yet")
+}
+
+func (gmailSource GmailSource) AddComment(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error {
+	return errors.New("has not been implemented yet")
+}
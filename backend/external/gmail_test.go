@@ -0,0 +1,78 @@
+package external
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/api/gmail/v1"
+)
+
+func TestGetGmailTaskLabelID(t *testing.T) {
+	assert.Equal(t, GmailDefaultTaskLabelID, GetGmailTaskLabelID(&database.ExternalAPIToken{}))
+	assert.Equal(t, "Label_1", GetGmailTaskLabelID(&database.ExternalAPIToken{GmailTaskLabelID: "Label_1"}))
+}
+
+func TestGetGmailTask(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		thread := &gmail.Thread{
+			Id:      "thread1",
+			Snippet: "sounds good",
+			Messages: []*gmail.Message{
+				{
+					InternalDate: 1678294800000,
+					Snippet:      "can you review the deck?",
+					Payload: &gmail.MessagePart{Headers: []*gmail.MessagePartHeader{
+						{Name: "From", Value: "boss@example.com"},
+						{Name: "Subject", Value: "Deck review"},
+					}},
+				},
+				{InternalDate: 1678298400000, Snippet: "sounds good"},
+			},
+		}
+		task := getGmailTask(thread, "me@example.com")
+		assert.Equal(t, "thread1", task.IDExternal)
+		assert.Equal(t, TASK_SOURCE_ID_GMAIL, task.SourceID)
+		assert.Equal(t, "https://mail.google.com/mail/?authuser=me%40example.com#all/thread1", task.Deeplink)
+		assert.Equal(t, "Deck review", *task.Title)
+		assert.Equal(t, "sounds good", *task.Body)
+		assert.Equal(t, "me@example.com", task.SourceAccountID)
+		assert.Equal(t, primitive.NewDateTimeFromTime(time.Date(2023, time.March, 8, 17, 0, 0, 0, time.UTC)), task.CreatedAtExternal)
+	})
+	t.Run("NoSubject", func(t *testing.T) {
+		thread := &gmail.Thread{Id: "thread1", Messages: []*gmail.Message{{Payload: &gmail.MessagePart{}}}}
+		task := getGmailTask(thread, "me@example.com")
+		assert.Equal(t, "(no subject)", *task.Title)
+	})
+}
+
+func TestGetGmailModifyThreadRequest(t *testing.T) {
+	t.Run("Complete", func(t *testing.T) {
+		request := getGmailModifyThreadRequest(true, "Label_1")
+		assert.Equal(t, []string{"Label_1", GmailInboxLabelID}, request.RemoveLabelIds)
+		assert.Empty(t, request.AddLabelIds)
+	})
+	t.Run("Reopen", func(t *testing.T) {
+		request := getGmailModifyThreadRequest(false, "Label_1")
+		assert.Equal(t, []string{"Label_1"}, request.AddLabelIds)
+		assert.Empty(t, request.RemoveLabelIds)
+	})
+}
+
+func TestGetGmailLabels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/gmail/v1/users/me/labels", r.URL.Path)
+		w.Write([]byte(`{"labels": [{"id": "STARRED", "name": "STARRED"}, {"id": "Label_1", "name": "todo"}]}`))
+	}))
+	defer server.Close()
+	source := GmailSource{Google: GoogleService{OverrideURLs: GoogleURLOverrides{GmailURL: &server.URL}}}
+	labels, err := source.GetLabels(nil, primitive.NewObjectID(), "me@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(labels))
+	assert.Equal(t, "Label_1", labels[1].Id)
+	assert.Equal(t, "todo", labels[1].Name)
+}
@@ -28,6 +28,8 @@ type GoogleURLOverrides struct {
 	CalendarCreateURL *string
 	CalendarModifyURL *string
 	CalendarDeleteURL *string
+	TasksURL          *string
+	GmailURL          *string
 }
 
 type GoogleService struct {
@@ -38,11 +40,21 @@ type GoogleService struct {
 
 // GoogleUserInfo ...
 type GoogleUserInfo struct {
-	SUB   string `json:"sub"`
-	EMAIL string `json:"email"`
-	Name  string `json:"name"`
+	SUB           string `json:"sub"`
+	EMAIL         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
 }
 
+// the scope for reading and writing all of the user's calendars, rather than just the events in their primary calendar
+const GOOGLE_MULTI_CALENDAR_SCOPE = "https://www.googleapis.com/auth/calendar"
+
+// the scope for syncing Google Tasks, which is only asked for once the user turns the sync on
+const GOOGLE_TASKS_SCOPE = "https://www.googleapis.com/auth/tasks"
+
+// the scope for turning labelled emails into tasks, and archiving them once the task is done
+const GOOGLE_GMAIL_SCOPE = "https://www.googleapis.com/auth/gmail.modify"
+
 // GoogleTokenInfo ...
 type GoogleTokenInfo struct {
 	Scope string `json:"scope"`
@@ -50,9 +62,9 @@ type GoogleTokenInfo struct {
 
 func getGoogleLoginConfig() OauthConfigWrapper {
 	googleConfig := &oauth2.Config{
-		ClientID:     config.GetConfigValue("GOOGLE_OAUTH_CLIENT_ID"),
-		ClientSecret: config.GetConfigValue("GOOGLE_OAUTH_CLIENT_SECRET"),
-		RedirectURL:  config.GetConfigValue("GOOGLE_OAUTH_LOGIN_REDIRECT_URL"),
+		ClientID:     config.GetSettings().GoogleOauth.ClientID,
+		ClientSecret: config.GetSettings().GoogleOauth.ClientSecret,
+		RedirectURL:  config.GetSettings().GoogleOauthLoginRedirectURL,
 		Scopes:       []string{"https://www.googleapis.com/auth/userinfo.email https://www.googleapis.com/auth/userinfo.profile https://www.googleapis.com/auth/calendar.events"},
 		Endpoint: oauth2.Endpoint{
 			AuthURL:  "https://accounts.google.com/o/oauth2/auth",
@@ -64,9 +76,9 @@ func getGoogleLoginConfig() OauthConfigWrapper {
 
 func getGoogleLinkConfig() OauthConfigWrapper {
 	googleConfig := &oauth2.Config{
-		ClientID:     config.GetConfigValue("GOOGLE_OAUTH_CLIENT_ID"),
-		ClientSecret: config.GetConfigValue("GOOGLE_OAUTH_CLIENT_SECRET"),
-		RedirectURL:  config.GetConfigValue("GOOGLE_OAUTH_AUTHORIZE_REDIRECT_URL"),
+		ClientID:     config.GetSettings().GoogleOauth.ClientID,
+		ClientSecret: config.GetSettings().GoogleOauth.ClientSecret,
+		RedirectURL:  config.GetSettings().GoogleOauthAuthorizeRedirectURL,
 		Scopes:       []string{"https://www.googleapis.com/auth/userinfo.email https://www.googleapis.com/auth/userinfo.profile https://www.googleapis.com/auth/calendar.events https://www.googleapis.com/auth/calendar"},
 		Endpoint: oauth2.Endpoint{
 			AuthURL:  "https://accounts.google.com/o/oauth2/auth",
@@ -76,8 +88,8 @@ func getGoogleLinkConfig() OauthConfigWrapper {
 	return &OauthConfig{Config: googleConfig}
 }
 
-func getGoogleHttpClient(db *mongo.Database, userID primitive.ObjectID, accountID string) *http.Client {
-	return getExternalOauth2Client(db, userID, accountID, TASK_SERVICE_ID_GOOGLE, getGoogleLoginConfig())
+func getGoogleHttpClient(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string) *http.Client {
+	return getExternalOauth2Client(ctx, db, userID, accountID, TASK_SERVICE_ID_GOOGLE, getGoogleLoginConfig())
 }
 
 func (Google GoogleService) GetLinkURL(stateTokenID primitive.ObjectID, userID primitive.ObjectID) (*string, error) {
@@ -85,6 +97,19 @@ func (Google GoogleService) GetLinkURL(stateTokenID primitive.ObjectID, userID p
 	return &authURL, nil
 }
 
+// GetScopeUpgradeURL returns the URL for granting another scope to an account that's already linked.
+// Incremental authorization only asks for the new scope, and the new token keeps the ones granted before
+func (Google GoogleService) GetScopeUpgradeURL(stateTokenID primitive.ObjectID, accountID string, scope string) string {
+	return Google.LinkConfig.AuthCodeURL(
+		stateTokenID.Hex(),
+		oauth2.AccessTypeOffline,
+		oauth2.ApprovalForce,
+		oauth2.SetAuthURLParam("scope", scope),
+		oauth2.SetAuthURLParam("include_granted_scopes", "true"),
+		oauth2.SetAuthURLParam("login_hint", accountID),
+	)
+}
+
 func (Google GoogleService) GetSignupURL(stateTokenID primitive.ObjectID, forcePrompt bool) (*string, error) {
 	var authURL string
 	includeGrantedScopes := oauth2.SetAuthURLParam("include_granted_scopes", "false")
@@ -149,7 +174,7 @@ func (Google GoogleService) HandleLinkCallback(db *mongo.Database, params Callba
 		return err
 	}
 
-	err = database.UpdateUserSetting(db, userID, constants.HasDismissedMulticalPrompt, "false")
+	err = database.UpdateUserSetting(context.Background(), db, userID, constants.HasDismissedMulticalPrompt, "false")
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to set HasDismissedMulticalPrompt as false")
 		return err
@@ -180,8 +205,8 @@ func hasUserGrantedCalendarScope(client *HTTPClient, token *oauth2.Token) bool {
 	return slices.Contains(scopes, "https://www.googleapis.com/auth/calendar.events") || slices.Contains(scopes, "https://www.googleapis.com/auth/calendar")
 }
 
-func (Google GoogleService) HandleSignupCallback(db *mongo.Database, params CallbackParams) (primitive.ObjectID, *bool, *string, error) {
-	parentCtx := context.Background()
+func (Google GoogleService) HandleSignupCallback(ctx context.Context, db *mongo.Database, params CallbackParams) (primitive.ObjectID, *bool, *string, error) {
+	parentCtx := ctx
 
 	extCtx, cancel := context.WithTimeout(parentCtx, constants.ExternalTimeout)
 	defer cancel()
@@ -218,28 +243,49 @@ func (Google GoogleService) HandleSignupCallback(db *mongo.Database, params Call
 	userCollection := database.GetUserCollection(db)
 
 	count, err := userCollection.CountDocuments(
-		context.Background(),
+		ctx,
 		bson.M{"google_id": userInfo.SUB},
 	)
 	if err != nil {
 		logger.Error().Err(err).Send()
 	}
 	userIsNew := count == int64(0)
+	if userIsNew && userInfo.EmailVerified {
+		// the user first signed in with another provider, so Google is merged into their account. Only
+		// accounts whose email that provider verified are merged, or anyone could sign up with someone
+		// else's email and wait for them to sign in with Google
+		result, err := userCollection.UpdateOne(
+			ctx,
+			bson.M{"email": strings.ToLower(userInfo.EMAIL), "email_verified": true, "google_id": bson.M{"$in": bson.A{nil, ""}}},
+			bson.M{"$set": bson.M{"google_id": userInfo.SUB}},
+		)
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to merge google account into existing user")
+			return primitive.NilObjectID, nil, nil, err
+		}
+		userIsNew = result.MatchedCount == 0
+	}
+	if userIsNew && params.AuthorizeNewUser != nil {
+		err = params.AuthorizeNewUser(userInfo.EMAIL)
+		if err != nil {
+			return primitive.NilObjectID, &userIsNew, nil, err
+		}
+	}
 
 	var user database.User
 
-	userNew := &database.User{GoogleID: userInfo.SUB, Email: userInfo.EMAIL, Name: userInfo.Name, CreatedAt: primitive.NewDateTimeFromTime(time.Now().UTC())}
+	userNew := &database.User{GoogleID: userInfo.SUB, Email: userInfo.EMAIL, EmailVerified: userInfo.EmailVerified, Name: userInfo.Name, CreatedAt: primitive.NewDateTimeFromTime(time.Now().UTC())}
 	userChangeable := &database.UserChangeable{Email: userInfo.EMAIL, Name: userInfo.Name}
 
 	log.Debug().Msgf("userNew: %+v", userNew)
-	userCollection.FindOneAndUpdate(context.Background(),
+	userCollection.FindOneAndUpdate(ctx,
 		bson.M{"google_id": userInfo.SUB},
 		bson.M{"$setOnInsert": userNew},
 		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After))
 
 	log.Debug().Msgf("userChangeable: %+v", userChangeable)
 	err = userCollection.FindOneAndUpdate(
-		context.Background(),
+		ctx,
 		bson.M{"google_id": userInfo.SUB},
 		bson.M{"$set": userChangeable},
 		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
@@ -264,7 +310,7 @@ func (Google GoogleService) HandleSignupCallback(db *mongo.Database, params Call
 		}
 		externalAPITokenCollection := database.GetExternalTokenCollection(db)
 		_, err = externalAPITokenCollection.UpdateOne(
-			context.Background(),
+			ctx,
 			bson.M{"$and": []bson.M{
 				{"user_id": user.ID},
 				{"service_id": TASK_SERVICE_ID_GOOGLE},
@@ -0,0 +1,255 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/exp/slices"
+	"google.golang.org/api/option"
+	"google.golang.org/api/tasks/v1"
+)
+
+type GoogleTasksSource struct {
+	Google GoogleService
+}
+
+const (
+	GoogleTasksStatusNeedsAction = "needsAction"
+	GoogleTasksStatusCompleted   = "completed"
+	// the list tasks land in when no list is picked, like tasks added from an assistant
+	GoogleTasksDefaultListID = "@default"
+)
+
+func (googleTasks GoogleTasksSource) GetEvents(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, startTime time.Time, endTime time.Time, scopes []string, result chan<- CalendarResult) {
+	result <- emptyCalendarResult(nil)
+}
+
+// GetTasks syncs the open tasks from each of the account's lists. The default list's tasks go to the
+// source's default section, and every other list gets a section of its own. Accounts which haven't
+// granted the tasks scope are skipped
+func (googleTasks GoogleTasksSource) GetTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- TaskResult) {
+	externalToken, err := getExternalToken(ctx, db, userID, accountID, TASK_SERVICE_ID_GOOGLE)
+	if err != nil {
+		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_GOOGLE_TASKS)
+		return
+	}
+	if !slices.Contains(externalToken.Scopes, GOOGLE_TASKS_SCOPE) {
+		result <- emptyTaskResult(nil)
+		return
+	}
+	tasksService, err := googleTasks.createTasksService(db, userID, accountID)
+	if err != nil {
+		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_GOOGLE_TASKS)
+		return
+	}
+
+	extCtx, cancel := context.WithTimeout(ctx, constants.ExternalTimeout)
+	defer cancel()
+	logger := logging.GetSentryLogger()
+	defaultList, err := tasksService.Tasklists.Get(GoogleTasksDefaultListID).Context(extCtx).Do()
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch default google tasks list")
+		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_GOOGLE_TASKS)
+		return
+	}
+	var taskLists []*tasks.TaskList
+	err = tasksService.Tasklists.List().Pages(extCtx, func(page *tasks.TaskLists) error {
+		taskLists = append(taskLists, page.Items...)
+		return nil
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch google tasks lists")
+		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_GOOGLE_TASKS)
+		return
+	}
+
+	var fetchedTasks []*database.Task
+	for _, taskList := range taskLists {
+		sectionID := database.GetDefaultTaskSectionID(ctx, db, userID, TASK_SOURCE_ID_GOOGLE_TASKS)
+		if taskList.Id != defaultList.Id {
+			sectionID, err = database.GetOrCreateExternalTaskSection(ctx, db, userID, TASK_SOURCE_ID_GOOGLE_TASKS, taskList.Id, taskList.Title)
+			if err != nil {
+				result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_GOOGLE_TASKS)
+				return
+			}
+		}
+
+		// completed tasks are left out, so tasks completed in Google are completed here on the next refresh
+		var googleTaskItems []*tasks.Task
+		err = tasksService.Tasks.List(taskList.Id).ShowCompleted(false).MaxResults(100).Pages(extCtx, func(page *tasks.Tasks) error {
+			googleTaskItems = append(googleTaskItems, page.Items...)
+			return nil
+		})
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to fetch google tasks")
+			result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_GOOGLE_TASKS)
+			return
+		}
+		for _, googleTask := range googleTaskItems {
+			// blank tasks are left behind when a task is added and never named
+			if googleTask.Title == "" {
+				continue
+			}
+			task := getGoogleTasksTask(googleTask, taskList.Id, accountID)
+			task.UserID = userID
+			task.IDTaskSection = sectionID
+			isCompleted := false
+			dbTask, err := database.UpdateOrCreateTask(
+				ctx,
+				db,
+				userID,
+				task.IDExternal,
+				task.SourceID,
+				task,
+				database.Task{
+					Title:       task.Title,
+					Body:        task.Body,
+					DueDate:     task.DueDate,
+					IsCompleted: &isCompleted,
+				},
+				nil,
+			)
+			if err != nil {
+				result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_GOOGLE_TASKS)
+				return
+			}
+			task.HasBeenReordered = dbTask.HasBeenReordered
+			task.ID = dbTask.ID
+			task.IDOrdering = dbTask.IDOrdering
+			task.IDTaskSection = dbTask.IDTaskSection
+			task.TimeAllocation = dbTask.TimeAllocation
+			fetchedTasks = append(fetchedTasks, task)
+		}
+	}
+
+	result <- TaskResult{
+		Tasks: fetchedTasks,
+	}
+}
+
+// getGoogleTasksTask maps a Google task to a task. Updating a task needs its list, so the list ID is
+// part of the external ID
+func getGoogleTasksTask(googleTask *tasks.Task, taskListID string, accountID string) *database.Task {
+	title := googleTask.Title
+	body := googleTask.Notes
+	task := &database.Task{
+		IDExternal:      taskListID + "/" + googleTask.Id,
+		Deeplink:        "https://tasks.google.com/?authuser=" + url.QueryEscape(accountID),
+		SourceID:        TASK_SOURCE_ID_GOOGLE_TASKS,
+		Title:           &title,
+		Body:            &body,
+		SourceAccountID: accountID,
+	}
+	// Google Tasks only keeps the date of the due date, which is sent as midnight UTC
+	if dueDate, err := time.Parse(time.RFC3339, googleTask.Due); err == nil {
+		dueDatePrim := primitive.NewDateTimeFromTime(dueDate)
+		task.DueDate = &dueDatePrim
+	}
+	return task
+}
+
+func (googleTasks GoogleTasksSource) GetPullRequests(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- PullRequestResult) {
+	result <- emptyPullRequestResult(nil, false)
+}
+
+func (googleTasks GoogleTasksSource) ModifyTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, issueID string, updateFields *database.Task, task *database.Task) error {
+	taskListID, googleTaskID, found := strings.Cut(issueID, "/")
+	if !found {
+		return errors.New("invalid google task id")
+	}
+	patch := getGoogleTasksPatch(updateFields)
+	if patch == nil {
+		return nil
+	}
+	tasksService, err := googleTasks.createTasksService(db, userID, accountID)
+	if err != nil {
+		return err
+	}
+	extCtx, cancel := context.WithTimeout(ctx, constants.ExternalTimeout)
+	defer cancel()
+	_, err = tasksService.Tasks.Patch(taskListID, googleTaskID, patch).Context(extCtx).Do()
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to update google task")
+	}
+	return err
+}
+
+// getGoogleTasksPatch returns the fields to update on the Google task, or nil if none of them changed
+func getGoogleTasksPatch(updateFields *database.Task) *tasks.Task {
+	patch := &tasks.Task{}
+	hasChanges := false
+	if updateFields.Title != nil {
+		patch.Title = *updateFields.Title
+		hasChanges = true
+	}
+	if updateFields.Body != nil {
+		patch.Notes = *updateFields.Body
+		// clearing the notes sends an empty string, which is otherwise left out
+		patch.ForceSendFields = append(patch.ForceSendFields, "Notes")
+		hasChanges = true
+	}
+	if updateFields.DueDate != nil {
+		dueDate := updateFields.DueDate.Time().UTC()
+		patch.Due = time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), 0, 0, 0, 0, time.UTC).Format(time.RFC3339)
+		hasChanges = true
+	}
+	if updateFields.IsCompleted != nil {
+		if *updateFields.IsCompleted {
+			patch.Status = GoogleTasksStatusCompleted
+		} else {
+			patch.Status = GoogleTasksStatusNeedsAction
+			// Google keeps the completion time unless it is cleared along with the status
+			patch.NullFields = append(patch.NullFields, "Completed")
+		}
+		hasChanges = true
+	}
+	if !hasChanges {
+		return nil
+	}
+	return patch
+}
+
+func (googleTasks GoogleTasksSource) createTasksService(db *mongo.Database, userID primitive.ObjectID, accountID string) (*tasks.Service, error) {
+	ctx := context.Background()
+	if googleTasks.Google.OverrideURLs.TasksURL != nil {
+		return tasks.NewService(ctx, option.WithoutAuthentication(), option.WithEndpoint(*googleTasks.Google.OverrideURLs.TasksURL))
+	}
+	client := getGoogleHttpClient(ctx, db, userID, accountID)
+	if client == nil {
+		return nil, errors.New("failed to fetch google API token")
+	}
+	tasksService, err := tasks.NewService(ctx, option.WithHTTPClient(client))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create google tasks service: %w", err)
+	}
+	return tasksService, nil
+}
+
+func (googleTasks GoogleTasksSource) CreateNewTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, task TaskCreationObject) (primitive.ObjectID, error) {
+	return primitive.NilObjectID, errors.New("has not been implemented yet")
+}
+
+func (googleTasks GoogleTasksSource) CreateNewEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error {
+	return errors.New("has not been implemented yet")
+}
+
+func (googleTasks GoogleTasksSource) ModifyEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, eventID string, updateFields *EventModifyObject) error {
+	return errors.New("has not been implemented yet")
+}
+
+func (googleTasks GoogleTasksSource) DeleteEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error {
+	return errors.New("has not been implemented yet")
+}
+
+func (googleTasks GoogleTasksSource) AddComment(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error {
+	return errors.New("has not been implemented yet")
+}
@@ -0,0 +1,89 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/api/tasks/v1"
+)
+
+func TestGetGoogleTasksTask(t *testing.T) {
+	googleTask := &tasks.Task{Id: "task1", Title: "Buy milk", Notes: "the oat one"}
+
+	t.Run("NoDueDate", func(t *testing.T) {
+		task := getGoogleTasksTask(googleTask, "list1", "me@example.com")
+		assert.Equal(t, "list1/task1", task.IDExternal)
+		assert.Equal(t, TASK_SOURCE_ID_GOOGLE_TASKS, task.SourceID)
+		assert.Equal(t, "https://tasks.google.com/?authuser=me%40example.com", task.Deeplink)
+		assert.Equal(t, "Buy milk", *task.Title)
+		assert.Equal(t, "the oat one", *task.Body)
+		assert.Equal(t, "me@example.com", task.SourceAccountID)
+		assert.Nil(t, task.DueDate)
+	})
+	t.Run("DueDate", func(t *testing.T) {
+		googleTask.Due = "2023-03-08T00:00:00.000Z"
+		task := getGoogleTasksTask(googleTask, "list1", "me@example.com")
+		assert.Equal(t, primitive.NewDateTimeFromTime(time.Date(2023, time.March, 8, 0, 0, 0, 0, time.UTC)), *task.DueDate)
+	})
+}
+
+func TestGetGoogleTasksPatch(t *testing.T) {
+	t.Run("NoChanges", func(t *testing.T) {
+		assert.Nil(t, getGoogleTasksPatch(&database.Task{}))
+	})
+	t.Run("Complete", func(t *testing.T) {
+		isCompleted := true
+		patch := getGoogleTasksPatch(&database.Task{IsCompleted: &isCompleted})
+		assert.Equal(t, GoogleTasksStatusCompleted, patch.Status)
+		assert.Empty(t, patch.NullFields)
+	})
+	t.Run("Reopen", func(t *testing.T) {
+		isCompleted := false
+		patch := getGoogleTasksPatch(&database.Task{IsCompleted: &isCompleted})
+		assert.Equal(t, GoogleTasksStatusNeedsAction, patch.Status)
+		assert.Equal(t, []string{"Completed"}, patch.NullFields)
+	})
+	t.Run("FieldsAndDueDate", func(t *testing.T) {
+		title := "Buy oat milk"
+		body := ""
+		dueDate := primitive.NewDateTimeFromTime(time.Date(2023, time.March, 8, 17, 30, 0, 0, time.UTC))
+		patch := getGoogleTasksPatch(&database.Task{Title: &title, Body: &body, DueDate: &dueDate})
+		assert.Equal(t, "Buy oat milk", patch.Title)
+		assert.Equal(t, "", patch.Notes)
+		assert.Equal(t, []string{"Notes"}, patch.ForceSendFields)
+		assert.Equal(t, "2023-03-08T00:00:00Z", patch.Due)
+	})
+}
+
+func TestModifyGoogleTask(t *testing.T) {
+	t.Run("InvalidID", func(t *testing.T) {
+		isCompleted := true
+		err := GoogleTasksSource{}.ModifyTask(context.Background(), nil, primitive.NewObjectID(), "me@example.com", "task1", &database.Task{IsCompleted: &isCompleted}, nil)
+		assert.EqualError(t, err, "invalid google task id")
+	})
+	t.Run("Complete", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "PATCH", r.Method)
+			assert.Equal(t, "/tasks/v1/lists/list1/tasks/task1", r.URL.Path)
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			var patch map[string]interface{}
+			assert.NoError(t, json.Unmarshal(body, &patch))
+			assert.Equal(t, map[string]interface{}{"status": GoogleTasksStatusCompleted}, patch)
+			w.Write([]byte(`{"id": "task1", "status": "completed"}`))
+		}))
+		defer server.Close()
+		source := GoogleTasksSource{Google: GoogleService{OverrideURLs: GoogleURLOverrides{TasksURL: &server.URL}}}
+		isCompleted := true
+		err := source.ModifyTask(context.Background(), nil, primitive.NewObjectID(), "me@example.com", "list1/task1", &database.Task{IsCompleted: &isCompleted}, nil)
+		assert.NoError(t, err)
+	})
+}
@@ -1,6 +1,7 @@
 package external
 
 import (
+	"context"
 	"errors"
 	"go.mongodb.org/mongo-driver/mongo"
 
@@ -23,6 +24,6 @@ func (generalTask GeneralTaskService) HandleLinkCallback(db *mongo.Database, par
 	return errors.New("general task service does not support linking")
 }
 
-func (generalTask GeneralTaskService) HandleSignupCallback(db *mongo.Database, params CallbackParams) (primitive.ObjectID, *bool, *string, error) {
+func (generalTask GeneralTaskService) HandleSignupCallback(ctx context.Context, db *mongo.Database, params CallbackParams) (primitive.ObjectID, *bool, *string, error) {
 	return primitive.NilObjectID, nil, nil, errors.New("general task service does not support signup")
 }
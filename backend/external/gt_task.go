@@ -9,7 +9,6 @@ import (
 
 	"github.com/franchizzle/task-manager/backend/logging"
 
-	"github.com/franchizzle/task-manager/backend/constants"
 	"github.com/franchizzle/task-manager/backend/database"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -17,14 +16,14 @@ import (
 
 type GeneralTaskTaskSource struct{}
 
-func (generalTask GeneralTaskTaskSource) GetEvents(db *mongo.Database, userID primitive.ObjectID, accountID string, startTime time.Time, endTime time.Time, scopes []string, result chan<- CalendarResult) {
+func (generalTask GeneralTaskTaskSource) GetEvents(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, startTime time.Time, endTime time.Time, scopes []string, result chan<- CalendarResult) {
 	result <- emptyCalendarResult(errors.New("GT task cannot fetch events"))
 }
 
-func (generalTask GeneralTaskTaskSource) GetTasks(db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- TaskResult) {
+func (generalTask GeneralTaskTaskSource) GetTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- TaskResult) {
 	taskCollection := database.GetTaskCollection(db)
 	cursor, err := taskCollection.Find(
-		context.Background(),
+		ctx,
 		bson.M{"$and": []bson.M{
 			{"user_id": userID},
 			{"source_id": TASK_SOURCE_ID_GT_TASK},
@@ -34,7 +33,7 @@ func (generalTask GeneralTaskTaskSource) GetTasks(db *mongo.Database, userID pri
 	)
 	var tasks []*database.Task
 	logger := logging.GetSentryLogger()
-	if err != nil || cursor.All(context.Background(), &tasks) != nil {
+	if err != nil || cursor.All(ctx, &tasks) != nil {
 		logger.Error().Err(err).Msg("failed to fetch general task tasks")
 		result <- emptyTaskResult(err)
 		return
@@ -42,14 +41,14 @@ func (generalTask GeneralTaskTaskSource) GetTasks(db *mongo.Database, userID pri
 	result <- TaskResult{Tasks: tasks, ServiceID: TASK_SERVICE_ID_GT, AccountID: accountID}
 }
 
-func (generalTask GeneralTaskTaskSource) GetPullRequests(db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- PullRequestResult) {
+func (generalTask GeneralTaskTaskSource) GetPullRequests(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- PullRequestResult) {
 	result <- emptyPullRequestResult(nil, false)
 }
 
-func (generalTask GeneralTaskTaskSource) CreateNewTask(db *mongo.Database, userID primitive.ObjectID, accountID string, task TaskCreationObject) (primitive.ObjectID, error) {
-	taskSection := constants.IDTaskSectionDefault
-	if task.IDTaskSection != primitive.NilObjectID {
-		taskSection = task.IDTaskSection
+func (generalTask GeneralTaskTaskSource) CreateNewTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, task TaskCreationObject) (primitive.ObjectID, error) {
+	taskSection := task.IDTaskSection
+	if taskSection == primitive.NilObjectID {
+		taskSection = database.GetDefaultTaskSectionID(ctx, db, userID, TASK_SOURCE_ID_GT_TASK)
 	}
 	timeAllocation := time.Hour.Nanoseconds()
 	completed := false
@@ -80,26 +79,27 @@ func (generalTask GeneralTaskTaskSource) CreateNewTask(db *mongo.Database, userI
 	}
 
 	taskCollection := database.GetTaskCollection(db)
-	insertResult, err := taskCollection.InsertOne(context.Background(), newTask)
+	insertResult, err := taskCollection.InsertOne(ctx, newTask)
 	return insertResult.InsertedID.(primitive.ObjectID), err
 }
 
-func (generalTask GeneralTaskTaskSource) CreateNewEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error {
+func (generalTask GeneralTaskTaskSource) CreateNewEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error {
 	return errors.New("has not been implemented yet")
 }
 
-func (generalTask GeneralTaskTaskSource) DeleteEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error {
+func (generalTask GeneralTaskTaskSource) DeleteEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error {
 	return errors.New("has not been implemented yet")
 }
 
-func (generalTask GeneralTaskTaskSource) ModifyTask(db *mongo.Database, userID primitive.ObjectID, accountID string, issueID string, updateFields *database.Task, task *database.Task) error {
+func (generalTask GeneralTaskTaskSource) ModifyTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, issueID string, updateFields *database.Task, task *database.Task) error {
 	return nil
 }
 
-func (generalTask GeneralTaskTaskSource) ModifyEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, eventID string, updateFields *EventModifyObject) error {
+func (generalTask GeneralTaskTaskSource) ModifyEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, eventID string, updateFields *EventModifyObject) error {
 	return errors.New("has not been implemented yet")
 }
 
-func (generalTask GeneralTaskTaskSource) AddComment(db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error {
-	return errors.New("has not been implemented yet")
+// AddComment has nothing to sync, since the caller saves the comment on the task like any other change
+func (generalTask GeneralTaskTaskSource) AddComment(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error {
+	return nil
 }
@@ -1,6 +1,7 @@
 package external
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -36,7 +37,7 @@ func TestLoadGeneralTaskTasks(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		var tasks = make(chan TaskResult)
-		go GeneralTaskTaskSource{}.GetTasks(db, userID, GeneralTaskDefaultAccountID, tasks)
+		go GeneralTaskTaskSource{}.GetTasks(context.Background(), db, userID, GeneralTaskDefaultAccountID, tasks)
 		result := <-tasks
 		assert.NoError(t, result.Error)
 		assert.Equal(t, 1, len(result.Tasks))
@@ -45,14 +46,14 @@ func TestLoadGeneralTaskTasks(t *testing.T) {
 	})
 	t.Run("WrongUserID", func(t *testing.T) {
 		var tasks = make(chan TaskResult)
-		go GeneralTaskTaskSource{}.GetTasks(db, primitive.NewObjectID(), GeneralTaskDefaultAccountID, tasks)
+		go GeneralTaskTaskSource{}.GetTasks(context.Background(), db, primitive.NewObjectID(), GeneralTaskDefaultAccountID, tasks)
 		result := <-tasks
 		assert.NoError(t, result.Error)
 		assert.Equal(t, 0, len(result.Tasks))
 	})
 	t.Run("WrongSourceAccountID", func(t *testing.T) {
 		var tasks = make(chan TaskResult)
-		go GeneralTaskTaskSource{}.GetTasks(db, userID, "other_account_id", tasks)
+		go GeneralTaskTaskSource{}.GetTasks(context.Background(), db, userID, "other_account_id", tasks)
 		result := <-tasks
 		assert.NoError(t, result.Error)
 		assert.Equal(t, 0, len(result.Tasks))
@@ -66,11 +67,11 @@ func TestCreateGeneralTaskTask(t *testing.T) {
 
 	t.Run("SuccessMinimumFields", func(t *testing.T) {
 		userID := primitive.NewObjectID()
-		_, err := GeneralTaskTaskSource{}.CreateNewTask(db, userID, GeneralTaskDefaultAccountID, TaskCreationObject{
+		_, err := GeneralTaskTaskSource{}.CreateNewTask(context.Background(), db, userID, GeneralTaskDefaultAccountID, TaskCreationObject{
 			Title: "send dogecoin to the moon",
 		})
 		assert.NoError(t, err)
-		tasks, err := database.GetActiveTasks(db, userID)
+		tasks, err := database.GetActiveTasks(context.Background(), db, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(*tasks))
 		task := (*tasks)[0]
@@ -82,7 +83,7 @@ func TestCreateGeneralTaskTask(t *testing.T) {
 		dueDate := time.Now()
 		timeAllocation := (time.Duration(2) * time.Hour).Nanoseconds()
 		parentTaskID := primitive.NewObjectID()
-		_, err := GeneralTaskTaskSource{}.CreateNewTask(db, userID, GeneralTaskDefaultAccountID, TaskCreationObject{
+		_, err := GeneralTaskTaskSource{}.CreateNewTask(context.Background(), db, userID, GeneralTaskDefaultAccountID, TaskCreationObject{
 			Title:          "send tesla stonk to the moon",
 			Body:           "body",
 			DueDate:        &dueDate,
@@ -90,7 +91,7 @@ func TestCreateGeneralTaskTask(t *testing.T) {
 			ParentTaskID:   parentTaskID,
 		})
 		assert.NoError(t, err)
-		tasks, err := database.GetActiveTasks(db, userID)
+		tasks, err := database.GetActiveTasks(context.Background(), db, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(*tasks))
 		task := (*tasks)[0]
@@ -114,12 +115,21 @@ func createTestTask(userID primitive.ObjectID) *database.Task {
 	}
 }
 
+func TestAddGeneralTaskComment(t *testing.T) {
+	// the comment is saved on the task by the caller, so there's nothing to sync
+	userID := primitive.NewObjectID()
+	generalTask := GeneralTaskTaskSource{}
+	err := generalTask.AddComment(context.Background(), nil, userID, GeneralTaskDefaultAccountID, database.Comment{Body: "looks good"}, createTestTask(userID))
+	assert.NoError(t, err)
+}
+
 func insertTestTasks(t *testing.T, userID primitive.ObjectID, tasks []*database.Task) {
 	db, dbCleanup, err := database.GetDBConnection()
 	assert.NoError(t, err)
 	defer dbCleanup()
 	for _, task := range tasks {
 		_, err := database.GetOrCreateTask(
+			context.Background(),
 			db,
 			userID,
 			task.IDExternal,
@@ -19,14 +19,25 @@ func getJSON(client *http.Client, url string, data interface{}) error {
 }
 
 func requestJSON(client *http.Client, method string, url string, body string, data interface{}) error {
+	return requestJSONWithContentType(client, method, url, "application/json", body, data)
+}
+
+// requestJSONWithContentType is for APIs which expect a JSON variant, like JSON Patch, for the request body
+func requestJSONWithContentType(client *http.Client, method string, url string, contentType string, body string, data interface{}) error {
 	request, err := http.NewRequest(method, url, bytes.NewBuffer([]byte(body)))
 	if err != nil {
 		return err
 	}
+	if body != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
 	response, err := client.Do(request)
 	if err != nil {
 		return err
 	}
+	if response.StatusCode == http.StatusNoContent {
+		return nil
+	}
 	responseBody, bodyErr := io.ReadAll(response.Body)
 	logger := logging.GetSentryLogger()
 	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
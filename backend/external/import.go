@@ -0,0 +1,141 @@
+package external
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+)
+
+const (
+	IMPORT_SOURCE_TODOIST = "todoist"
+	IMPORT_SOURCE_ASANA   = "asana"
+)
+
+// ImportedTask is a task parsed from a third party export file. IDExternal is prefixed with the
+// import source so repeated imports of the same file can be deduplicated
+type ImportedTask struct {
+	IDExternal  string
+	Title       string
+	Body        string
+	DueDate     *time.Time
+	IsCompleted bool
+}
+
+type ImportParser func(reader io.Reader) ([]ImportedTask, error)
+
+func GetImportParser(source string) (ImportParser, error) {
+	switch source {
+	case IMPORT_SOURCE_TODOIST:
+		return ParseTodoistExport, nil
+	case IMPORT_SOURCE_ASANA:
+		return ParseAsanaExport, nil
+	}
+	return nil, errors.New("unsupported import source")
+}
+
+// ParseTodoistExport parses the CSV produced by Todoist's "Export as a template" and project backups.
+// These files usually contain no task IDs, so the external ID is derived from the task's contents
+func ParseTodoistExport(reader io.Reader) ([]ImportedTask, error) {
+	rows, columns, err := readImportCSV(reader, []string{"TYPE", "CONTENT"})
+	if err != nil {
+		return nil, err
+	}
+	tasks := []ImportedTask{}
+	for _, row := range rows {
+		if strings.ToLower(getImportCSVValue(row, columns, "TYPE")) != "task" {
+			continue
+		}
+		title := getImportCSVValue(row, columns, "CONTENT")
+		if title == "" {
+			continue
+		}
+		body := getImportCSVValue(row, columns, "DESCRIPTION")
+		date := getImportCSVValue(row, columns, "DATE")
+		idExternal := getImportCSVValue(row, columns, "ID")
+		if idExternal == "" {
+			idExternal = hashImportFields(title, body, date)
+		}
+		tasks = append(tasks, ImportedTask{
+			IDExternal: IMPORT_SOURCE_TODOIST + "_" + idExternal,
+			Title:      title,
+			Body:       body,
+			// Todoist dates can be natural language ("every monday"), only absolute dates are kept
+			DueDate: parseImportDate(date),
+		})
+	}
+	return tasks, nil
+}
+
+// ParseAsanaExport parses the CSV produced by Asana's project "Export/Print > CSV"
+func ParseAsanaExport(reader io.Reader) ([]ImportedTask, error) {
+	rows, columns, err := readImportCSV(reader, []string{"Task ID", "Name"})
+	if err != nil {
+		return nil, err
+	}
+	tasks := []ImportedTask{}
+	for _, row := range rows {
+		taskID := getImportCSVValue(row, columns, "Task ID")
+		title := getImportCSVValue(row, columns, "Name")
+		if taskID == "" || title == "" {
+			continue
+		}
+		tasks = append(tasks, ImportedTask{
+			IDExternal:  IMPORT_SOURCE_ASANA + "_" + taskID,
+			Title:       title,
+			Body:        getImportCSVValue(row, columns, "Notes"),
+			DueDate:     parseImportDate(getImportCSVValue(row, columns, "Due Date")),
+			IsCompleted: getImportCSVValue(row, columns, "Completed At") != "",
+		})
+	}
+	return tasks, nil
+}
+
+func readImportCSV(reader io.Reader, requiredColumns []string) ([][]string, map[string]int, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil, errors.New("export file is empty")
+	}
+	columns := map[string]int{}
+	for index, column := range records[0] {
+		// Excel and Todoist both like to prepend a byte order mark
+		columns[strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))] = index
+	}
+	for _, column := range requiredColumns {
+		if _, exists := columns[column]; !exists {
+			return nil, nil, errors.New("export file is missing column " + column)
+		}
+	}
+	return records[1:], columns, nil
+}
+
+func getImportCSVValue(row []string, columns map[string]int, column string) string {
+	index, exists := columns[column]
+	if !exists || index >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[index])
+}
+
+func parseImportDate(value string) *time.Time {
+	date, err := time.Parse(constants.YEAR_MONTH_DAY_FORMAT, value)
+	if err != nil {
+		return nil
+	}
+	return &date
+}
+
+func hashImportFields(fields ...string) string {
+	hash := sha256.Sum256([]byte(strings.Join(fields, "\x00")))
+	return hex.EncodeToString(hash[:])[:24]
+}
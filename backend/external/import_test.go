@@ -0,0 +1,75 @@
+package external
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetImportParser(t *testing.T) {
+	_, err := GetImportParser("todoist")
+	assert.NoError(t, err)
+	_, err = GetImportParser("asana")
+	assert.NoError(t, err)
+	_, err = GetImportParser("trello")
+	assert.EqualError(t, err, "unsupported import source")
+}
+
+func TestParseTodoistExport(t *testing.T) {
+	t.Run("MissingColumns", func(t *testing.T) {
+		_, err := ParseTodoistExport(strings.NewReader("NAME,DATE\nfoo,bar\n"))
+		assert.EqualError(t, err, "export file is missing column TYPE")
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := ParseTodoistExport(strings.NewReader(""))
+		assert.EqualError(t, err, "export file is empty")
+	})
+	t.Run("Success", func(t *testing.T) {
+		export := "\ufeffTYPE,CONTENT,DESCRIPTION,PRIORITY,INDENT,AUTHOR,RESPONSIBLE,DATE,DATE_LANG,TIMEZONE\n" +
+			"section,Errands,,,,,,,,\n" +
+			"task,Buy milk,whole milk,4,1,Someone,,2023-02-01,en,UTC\n" +
+			"task,Call mom,,1,1,Someone,,every sunday,en,UTC\n" +
+			"note,some comment,,,,,,,,\n" +
+			",,,,,,,,,\n"
+		tasks, err := ParseTodoistExport(strings.NewReader(export))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(tasks))
+		assert.Equal(t, "Buy milk", tasks[0].Title)
+		assert.Equal(t, "whole milk", tasks[0].Body)
+		assert.Equal(t, time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), *tasks[0].DueDate)
+		assert.True(t, strings.HasPrefix(tasks[0].IDExternal, "todoist_"))
+		assert.Equal(t, "Call mom", tasks[1].Title)
+		assert.Nil(t, tasks[1].DueDate)
+
+		// parsing the same file twice yields the same external IDs so imports can be deduplicated
+		tasksAgain, err := ParseTodoistExport(strings.NewReader(export))
+		assert.NoError(t, err)
+		assert.Equal(t, tasks[0].IDExternal, tasksAgain[0].IDExternal)
+		assert.NotEqual(t, tasks[0].IDExternal, tasks[1].IDExternal)
+	})
+}
+
+func TestParseAsanaExport(t *testing.T) {
+	t.Run("MissingColumns", func(t *testing.T) {
+		_, err := ParseAsanaExport(strings.NewReader("Name\nfoo\n"))
+		assert.EqualError(t, err, "export file is missing column Task ID")
+	})
+	t.Run("Success", func(t *testing.T) {
+		export := "Task ID,Created At,Completed At,Last Modified,Name,Section/Column,Assignee,Assignee Email,Start Date,Due Date,Tags,Notes,Projects,Parent task\n" +
+			"1201,2023-01-01,,2023-01-02,Write spec,Todo,,,,2023-01-20,,\"first line\nsecond line\",Project,\n" +
+			"1202,2023-01-01,2023-01-03,2023-01-03,Done already,Done,,,,,,,Project,\n" +
+			"1203,2023-01-01,,2023-01-02,,Todo,,,,,,,Project,\n"
+		tasks, err := ParseAsanaExport(strings.NewReader(export))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(tasks))
+		assert.Equal(t, "asana_1201", tasks[0].IDExternal)
+		assert.Equal(t, "Write spec", tasks[0].Title)
+		assert.Equal(t, "first line\nsecond line", tasks[0].Body)
+		assert.Equal(t, time.Date(2023, 1, 20, 0, 0, 0, 0, time.UTC), *tasks[0].DueDate)
+		assert.False(t, tasks[0].IsCompleted)
+		assert.Equal(t, "asana_1202", tasks[1].IDExternal)
+		assert.True(t, tasks[1].IsCompleted)
+	})
+}
@@ -0,0 +1,160 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/franchizzle/task-manager/backend/config"
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/oauth2"
+)
+
+const (
+	IntercomAPIURL     = "https://api.intercom.io"
+	IntercomAPIVersion = "2.10"
+)
+
+type IntercomConfigValues struct {
+	MeURL                 *string
+	ConversationSearchURL *string
+	ConversationPartsURL  *string
+}
+
+type IntercomConfig struct {
+	OauthConfig  OauthConfigWrapper
+	ConfigValues IntercomConfigValues
+}
+
+type IntercomService struct {
+	Config IntercomConfig
+}
+
+type IntercomMeResponse struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+	App   struct {
+		IDCode string `json:"id_code"`
+	} `json:"app"`
+}
+
+// intercomTransport adds the headers every Intercom API request needs. Without an explicit version,
+// responses change shape whenever the workspace's default version is upgraded
+type intercomTransport struct {
+	base http.RoundTripper
+}
+
+func (transport intercomTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	request = request.Clone(request.Context())
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Intercom-Version", IntercomAPIVersion)
+	return transport.base.RoundTrip(request)
+}
+
+func getIntercomOauthConfig() *OauthConfig {
+	settings := config.GetSettings()
+	return &OauthConfig{Config: &oauth2.Config{
+		ClientID:     settings.IntercomOauth.ClientID,
+		ClientSecret: settings.IntercomOauth.ClientSecret,
+		RedirectURL:  settings.ServerURL + "link/intercom/callback/",
+		// scopes are chosen in the Intercom app settings rather than requested
+		Scopes: []string{},
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   "https://app.intercom.com/oauth",
+			TokenURL:  IntercomAPIURL + "/auth/eagle/token",
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+	}}
+}
+
+func (intercom IntercomService) GetLinkURL(stateTokenID primitive.ObjectID, userID primitive.ObjectID) (*string, error) {
+	authURL := intercom.Config.OauthConfig.AuthCodeURL(stateTokenID.Hex())
+	return &authURL, nil
+}
+
+func (intercom IntercomService) GetSignupURL(stateTokenID primitive.ObjectID, forcePrompt bool) (*string, error) {
+	return nil, errors.New("intercom does not support signup")
+}
+
+func (intercom IntercomService) HandleLinkCallback(db *mongo.Database, params CallbackParams, userID primitive.ObjectID) error {
+	parentCtx := context.Background()
+	extCtx, cancel := context.WithTimeout(parentCtx, constants.ExternalTimeout)
+	defer cancel()
+	token, err := intercom.Config.OauthConfig.Exchange(extCtx, *params.Oauth2Code)
+	logger := logging.GetSentryLogger()
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch token from Intercom")
+		return errors.New("internal server error")
+	}
+
+	client := oauth2.NewClient(extCtx, oauth2.StaticTokenSource(token))
+	me, err := intercom.getMe(&http.Client{Transport: intercomTransport{base: client.Transport}})
+	if err != nil || me.Email == "" {
+		logger.Error().Err(err).Msg("failed to fetch intercom admin info")
+		return errors.New("internal server error")
+	}
+
+	tokenString, err := json.Marshal(&token)
+	if err != nil {
+		logger.Error().Err(err).Msg("error parsing token")
+		return errors.New("internal server error")
+	}
+
+	dbCtx, cancel := context.WithTimeout(parentCtx, constants.DatabaseTimeout)
+	defer cancel()
+	accountID := me.Email
+	_, err = database.GetExternalTokenCollection(db).UpdateOne(
+		dbCtx,
+		bson.M{"$and": []bson.M{{"user_id": userID}, {"service_id": TASK_SERVICE_ID_INTERCOM}, {"account_id": accountID}}},
+		bson.M{"$set": &database.ExternalAPIToken{
+			UserID:         userID,
+			ServiceID:      TASK_SERVICE_ID_INTERCOM,
+			Token:          string(tokenString),
+			AccountID:      accountID,
+			DisplayID:      accountID,
+			ExternalID:     me.ID,
+			IsUnlinkable:   true,
+			IsPrimaryLogin: false,
+		}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		logger.Error().Err(err).Msg("error saving token")
+		return errors.New("internal server error")
+	}
+	return nil
+}
+
+func (intercom IntercomService) HandleSignupCallback(ctx context.Context, db *mongo.Database, params CallbackParams) (primitive.ObjectID, *bool, *string, error) {
+	return primitive.NilObjectID, nil, nil, errors.New("intercom does not support signup")
+}
+
+// getMe returns the admin the token belongs to, along with their workspace
+func (intercom IntercomService) getMe(client *http.Client) (*IntercomMeResponse, error) {
+	meURL := IntercomAPIURL + "/me"
+	if intercom.Config.ConfigValues.MeURL != nil {
+		meURL = *intercom.Config.ConfigValues.MeURL
+		client = http.DefaultClient
+	}
+	var me IntercomMeResponse
+	err := getJSON(client, meURL, &me)
+	if err != nil {
+		return nil, err
+	}
+	return &me, nil
+}
+
+func getIntercomHttpClient(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string) *http.Client {
+	client := getExternalOauth2Client(ctx, db, userID, accountID, TASK_SERVICE_ID_INTERCOM, getIntercomOauthConfig())
+	if client == nil {
+		return nil
+	}
+	return &http.Client{Transport: intercomTransport{base: client.Transport}}
+}
@@ -0,0 +1,234 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type IntercomConversationSource struct {
+	Intercom IntercomService
+}
+
+const IntercomConversationPageSize = 150
+
+type IntercomSearchFilter struct {
+	Field    string `json:"field"`
+	Operator string `json:"operator"`
+	Value    string `json:"value"`
+}
+
+type IntercomConversationSearchBody struct {
+	Query struct {
+		Operator string                 `json:"operator"`
+		Value    []IntercomSearchFilter `json:"value"`
+	} `json:"query"`
+	Pagination struct {
+		PerPage int `json:"per_page"`
+	} `json:"pagination"`
+}
+
+type IntercomConversation struct {
+	ID           string  `json:"id"`
+	Title        *string `json:"title"`
+	State        string  `json:"state"`
+	CreatedAt    int64   `json:"created_at"`
+	WaitingSince *int64  `json:"waiting_since"`
+	Source       struct {
+		Subject string `json:"subject"`
+		Body    string `json:"body"`
+		Author  struct {
+			Name  string `json:"name"`
+			Email string `json:"email"`
+		} `json:"author"`
+	} `json:"source"`
+}
+
+type IntercomConversationSearchResponse struct {
+	Conversations []IntercomConversation `json:"conversations"`
+}
+
+type IntercomConversationCloseBody struct {
+	MessageType string `json:"message_type"`
+	Type        string `json:"type"`
+	AdminID     string `json:"admin_id"`
+}
+
+func (intercomConversation IntercomConversationSource) GetEvents(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, startTime time.Time, endTime time.Time, scopes []string, result chan<- CalendarResult) {
+	result <- emptyCalendarResult(errors.New("intercom cannot fetch events"))
+}
+
+// GetTasks fetches the open conversations assigned to the user which are waiting on a reply from the
+// team. Closed conversations drop out of the results, so their tasks are completed on the next refresh
+func (intercomConversation IntercomConversationSource) GetTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- TaskResult) {
+	logger := logging.GetSentryLogger()
+	client := getIntercomHttpClient(ctx, db, userID, accountID)
+	if client == nil && intercomConversation.Intercom.Config.ConfigValues.MeURL == nil {
+		result <- emptyTaskResultWithSource(errors.New("failed to load intercom token"), TASK_SOURCE_ID_INTERCOM)
+		return
+	}
+	me, err := intercomConversation.Intercom.getMe(client)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to get intercom admin info")
+		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_INTERCOM)
+		return
+	}
+
+	searchURL := IntercomAPIURL + "/conversations/search"
+	if intercomConversation.Intercom.Config.ConfigValues.ConversationSearchURL != nil {
+		searchURL = *intercomConversation.Intercom.Config.ConfigValues.ConversationSearchURL
+		client = http.DefaultClient
+	}
+	var searchBody IntercomConversationSearchBody
+	searchBody.Query.Operator = "AND"
+	searchBody.Query.Value = []IntercomSearchFilter{
+		{Field: "admin_assignee_id", Operator: "=", Value: me.ID},
+		{Field: "state", Operator: "=", Value: "open"},
+	}
+	searchBody.Pagination.PerPage = IntercomConversationPageSize
+	bodyJson, err := json.Marshal(searchBody)
+	if err != nil {
+		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_INTERCOM)
+		return
+	}
+	var searchResponse IntercomConversationSearchResponse
+	err = requestJSON(client, "POST", searchURL, string(bodyJson), &searchResponse)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch intercom conversations")
+		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_INTERCOM)
+		return
+	}
+
+	defaultTaskSectionID := database.GetDefaultTaskSectionID(ctx, db, userID, TASK_SOURCE_ID_INTERCOM)
+	var tasks []*database.Task
+	for _, conversation := range searchResponse.Conversations {
+		// conversations where the team spoke last are waiting on the customer, not the user
+		if conversation.WaitingSince == nil || *conversation.WaitingSince == 0 {
+			continue
+		}
+		title := getIntercomConversationTitle(conversation)
+		body := conversation.Source.Body
+		task := &database.Task{
+			UserID:            userID,
+			IDExternal:        conversation.ID,
+			IDTaskSection:     defaultTaskSectionID,
+			Deeplink:          fmt.Sprintf("https://app.intercom.com/a/inbox/%s/inbox/conversation/%s", me.App.IDCode, conversation.ID),
+			SourceID:          TASK_SOURCE_ID_INTERCOM,
+			Title:             &title,
+			Body:              &body,
+			SourceAccountID:   accountID,
+			CreatedAtExternal: primitive.NewDateTimeFromTime(time.Unix(conversation.CreatedAt, 0)),
+		}
+		isCompleted := false
+		dbTask, err := database.UpdateOrCreateTask(
+			ctx,
+			db,
+			userID,
+			task.IDExternal,
+			task.SourceID,
+			task,
+			database.Task{
+				Title:       task.Title,
+				Body:        task.Body,
+				IsCompleted: &isCompleted,
+			},
+			nil,
+		)
+		if err != nil {
+			result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_INTERCOM)
+			return
+		}
+		task.HasBeenReordered = dbTask.HasBeenReordered
+		task.ID = dbTask.ID
+		task.IDOrdering = dbTask.IDOrdering
+		task.IDTaskSection = dbTask.IDTaskSection
+		task.TimeAllocation = dbTask.TimeAllocation
+		tasks = append(tasks, task)
+	}
+
+	result <- TaskResult{
+		Tasks: tasks,
+	}
+}
+
+// getIntercomConversationTitle prefers the title set by the team, then the email subject, then who
+// started the conversation, since chat conversations have neither
+func getIntercomConversationTitle(conversation IntercomConversation) string {
+	if conversation.Title != nil && *conversation.Title != "" {
+		return *conversation.Title
+	}
+	if conversation.Source.Subject != "" {
+		return conversation.Source.Subject
+	}
+	author := conversation.Source.Author.Name
+	if author == "" {
+		author = conversation.Source.Author.Email
+	}
+	if author == "" {
+		return "Intercom conversation"
+	}
+	return "Conversation with " + author
+}
+
+func (intercomConversation IntercomConversationSource) GetPullRequests(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- PullRequestResult) {
+	result <- emptyPullRequestResult(nil, false)
+}
+
+// ModifyTask closes the conversation when the task is completed. Nothing else maps to a conversation
+func (intercomConversation IntercomConversationSource) ModifyTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, issueID string, updateFields *database.Task, task *database.Task) error {
+	if updateFields.IsCompleted == nil || !*updateFields.IsCompleted {
+		return nil
+	}
+	externalToken, err := getExternalToken(ctx, db, userID, accountID, TASK_SERVICE_ID_INTERCOM)
+	if err != nil {
+		return err
+	}
+	client := getIntercomHttpClient(ctx, db, userID, accountID)
+	partsURL := fmt.Sprintf(IntercomAPIURL+"/conversations/%s/parts", issueID)
+	if intercomConversation.Intercom.Config.ConfigValues.ConversationPartsURL != nil {
+		partsURL = *intercomConversation.Intercom.Config.ConfigValues.ConversationPartsURL
+		client = http.DefaultClient
+	}
+	bodyJson, err := json.Marshal(IntercomConversationCloseBody{
+		MessageType: "close",
+		Type:        "admin",
+		AdminID:     externalToken.ExternalID,
+	})
+	if err != nil {
+		return err
+	}
+	err = requestJSON(client, "POST", partsURL, string(bodyJson), EmptyResponsePlaceholder)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to close intercom conversation")
+		return err
+	}
+	return nil
+}
+
+func (intercomConversation IntercomConversationSource) CreateNewTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, task TaskCreationObject) (primitive.ObjectID, error) {
+	return primitive.NilObjectID, errors.New("has not been implemented yet")
+}
+
+func (intercomConversation IntercomConversationSource) CreateNewEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error {
+	return errors.New("has not been implemented yet")
+}
+
+func (intercomConversation IntercomConversationSource) ModifyEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, eventID string, updateFields *EventModifyObject) error {
+	return errors.New("has not been implemented yet")
+}
+
+func (intercomConversation IntercomConversationSource) DeleteEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error {
+	return errors.New("has not been implemented yet")
+}
+
+func (intercomConversation IntercomConversationSource) AddComment(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error {
+	return errors.New("has not been implemented yet")
+}
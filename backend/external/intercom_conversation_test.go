@@ -0,0 +1,172 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/testutils"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestLoadIntercomConversations(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	taskCollection := database.GetTaskCollection(db)
+
+	meServerSuccess := testutils.GetMockAPIServer(t, 200, `{"type": "admin", "id": "814860", "email": "lead@example.com", "app": {"id_code": "abc123"}}`)
+	defer meServerSuccess.Close()
+
+	t.Run("BadMeStatusCode", func(t *testing.T) {
+		meServer := testutils.GetMockAPIServer(t, 401, "")
+		defer meServer.Close()
+		intercomConversation := IntercomConversationSource{Intercom: IntercomService{Config: IntercomConfig{ConfigValues: IntercomConfigValues{MeURL: &meServer.URL}}}}
+
+		var taskResult = make(chan TaskResult)
+		go intercomConversation.GetTasks(context.Background(), db, primitive.NewObjectID(), "lead@example.com", taskResult)
+		result := <-taskResult
+		assert.Error(t, result.Error)
+		assert.Equal(t, "bad status code: 401", result.Error.Error())
+		assert.Equal(t, 0, len(result.Tasks))
+	})
+	t.Run("BadSearchStatusCode", func(t *testing.T) {
+		searchServer := testutils.GetMockAPIServer(t, 500, "")
+		defer searchServer.Close()
+		intercomConversation := IntercomConversationSource{Intercom: IntercomService{Config: IntercomConfig{ConfigValues: IntercomConfigValues{
+			MeURL:                 &meServerSuccess.URL,
+			ConversationSearchURL: &searchServer.URL,
+		}}}}
+
+		var taskResult = make(chan TaskResult)
+		go intercomConversation.GetTasks(context.Background(), db, primitive.NewObjectID(), "lead@example.com", taskResult)
+		result := <-taskResult
+		assert.Error(t, result.Error)
+		assert.Equal(t, 0, len(result.Tasks))
+	})
+	t.Run("Success", func(t *testing.T) {
+		searchServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var searchBody IntercomConversationSearchBody
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.NoError(t, json.Unmarshal(body, &searchBody))
+			assert.Equal(t, "POST", r.Method)
+			assert.Equal(t, IntercomSearchFilter{Field: "admin_assignee_id", Operator: "=", Value: "814860"}, searchBody.Query.Value[0])
+			w.WriteHeader(200)
+			w.Write([]byte(`{"conversations": [
+				{"id": "1911", "title": null, "state": "open", "created_at": 1646128800, "waiting_since": 1646130000,
+					"source": {"subject": "", "body": "<p>My invoice is wrong</p>", "author": {"name": "Ada", "email": "ada@example.com"}}},
+				{"id": "1912", "title": "Refund", "state": "open", "created_at": 1646128800, "waiting_since": null,
+					"source": {"subject": "", "body": "<p>Thanks!</p>"}}
+			]}`))
+		}))
+		defer searchServer.Close()
+		intercomConversation := IntercomConversationSource{Intercom: IntercomService{Config: IntercomConfig{ConfigValues: IntercomConfigValues{
+			MeURL:                 &meServerSuccess.URL,
+			ConversationSearchURL: &searchServer.URL,
+		}}}}
+		userID := primitive.NewObjectID()
+
+		var taskResult = make(chan TaskResult)
+		go intercomConversation.GetTasks(context.Background(), db, userID, "lead@example.com", taskResult)
+		result := <-taskResult
+		assert.NoError(t, result.Error)
+		assert.Equal(t, 1, len(result.Tasks))
+		assert.Equal(t, "1911", result.Tasks[0].IDExternal)
+		assert.Equal(t, "Conversation with Ada", *result.Tasks[0].Title)
+		assert.Equal(t, "<p>My invoice is wrong</p>", *result.Tasks[0].Body)
+		assert.Equal(t, "https://app.intercom.com/a/inbox/abc123/inbox/conversation/1911", result.Tasks[0].Deeplink)
+
+		var taskFromDB database.Task
+		err := taskCollection.FindOne(context.Background(), bson.M{"user_id": userID}).Decode(&taskFromDB)
+		assert.NoError(t, err)
+		assert.Equal(t, TASK_SOURCE_ID_INTERCOM, taskFromDB.SourceID)
+		assert.False(t, *taskFromDB.IsCompleted)
+	})
+}
+
+func TestGetIntercomConversationTitle(t *testing.T) {
+	title := "Billing question"
+	conversation := IntercomConversation{Title: &title}
+	conversation.Source.Subject = "Re: invoice"
+	conversation.Source.Author.Email = "ada@example.com"
+	assert.Equal(t, "Billing question", getIntercomConversationTitle(conversation))
+
+	conversation.Title = nil
+	assert.Equal(t, "Re: invoice", getIntercomConversationTitle(conversation))
+
+	conversation.Source.Subject = ""
+	assert.Equal(t, "Conversation with ada@example.com", getIntercomConversationTitle(conversation))
+
+	conversation.Source.Author.Name = "Ada"
+	assert.Equal(t, "Conversation with Ada", getIntercomConversationTitle(conversation))
+
+	assert.Equal(t, "Intercom conversation", getIntercomConversationTitle(IntercomConversation{}))
+}
+
+func TestIntercomTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Accept"))
+		assert.Equal(t, IntercomAPIVersion, r.Header.Get("Intercom-Version"))
+		w.WriteHeader(200)
+		w.Write([]byte(`{"id": "814860"}`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: intercomTransport{base: http.DefaultTransport}}
+	var me IntercomMeResponse
+	assert.NoError(t, getJSON(client, server.URL, &me))
+	assert.Equal(t, "814860", me.ID)
+}
+
+func TestModifyIntercomConversation(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+
+	userID := primitive.NewObjectID()
+	_, err = database.GetExternalTokenCollection(db).InsertOne(context.Background(), database.ExternalAPIToken{
+		UserID:     userID,
+		ServiceID:  TASK_SERVICE_ID_INTERCOM,
+		AccountID:  "lead@example.com",
+		ExternalID: "814860",
+		Token:      `{"access_token": "token"}`,
+	})
+	assert.NoError(t, err)
+
+	isCompleted := true
+	t.Run("CompleteClosesConversation", func(t *testing.T) {
+		partsServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var closeBody IntercomConversationCloseBody
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.NoError(t, json.Unmarshal(body, &closeBody))
+			assert.Equal(t, IntercomConversationCloseBody{MessageType: "close", Type: "admin", AdminID: "814860"}, closeBody)
+			w.WriteHeader(200)
+			w.Write([]byte(`{"state": "closed"}`))
+		}))
+		defer partsServer.Close()
+		intercomConversation := IntercomConversationSource{Intercom: IntercomService{Config: IntercomConfig{ConfigValues: IntercomConfigValues{ConversationPartsURL: &partsServer.URL}}}}
+		err := intercomConversation.ModifyTask(context.Background(), db, userID, "lead@example.com", "1911", &database.Task{IsCompleted: &isCompleted}, nil)
+		assert.NoError(t, err)
+	})
+	t.Run("BadResponse", func(t *testing.T) {
+		partsServer := testutils.GetMockAPIServer(t, 404, "")
+		defer partsServer.Close()
+		intercomConversation := IntercomConversationSource{Intercom: IntercomService{Config: IntercomConfig{ConfigValues: IntercomConfigValues{ConversationPartsURL: &partsServer.URL}}}}
+		err := intercomConversation.ModifyTask(context.Background(), db, userID, "lead@example.com", "1911", &database.Task{IsCompleted: &isCompleted}, nil)
+		assert.Error(t, err)
+		assert.Equal(t, "bad status code: 404", err.Error())
+	})
+	t.Run("OtherFieldsIgnored", func(t *testing.T) {
+		title := "New title"
+		err := IntercomConversationSource{}.ModifyTask(context.Background(), db, userID, "lead@example.com", "1911", &database.Task{Title: &title}, nil)
+		assert.NoError(t, err)
+	})
+}
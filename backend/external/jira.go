@@ -2,11 +2,14 @@ package external
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/franchizzle/task-manager/backend/constants"
@@ -29,6 +32,7 @@ type JIRASource struct {
 
 type JIRATransition struct {
 	ID       string     `json:"id"`
+	Name     string     `json:"name"`
 	ToStatus JIRAStatus `json:"to"`
 }
 
@@ -90,13 +94,13 @@ func (jira JIRASource) getAPIBaseURL(siteConfiguration database.AtlassianSiteCon
 	return "https://api.atlassian.com/ex/jira/" + siteConfiguration.CloudID
 }
 
-func (jira JIRASource) GetEvents(db *mongo.Database, userID primitive.ObjectID, accountID string, startTime time.Time, endTime time.Time, scopes []string, result chan<- CalendarResult) {
+func (jira JIRASource) GetEvents(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, startTime time.Time, endTime time.Time, scopes []string, result chan<- CalendarResult) {
 	result <- emptyCalendarResult(errors.New("jira cannot fetch events"))
 }
 
-func (jira JIRASource) GetTasks(db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- TaskResult) {
-	authToken, _ := jira.Atlassian.getAndRefreshToken(userID, accountID)
-	siteConfiguration, _ := jira.Atlassian.getSiteConfiguration(userID)
+func (jira JIRASource) GetTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- TaskResult) {
+	authToken, _ := jira.Atlassian.getAndRefreshToken(ctx, userID, accountID)
+	siteConfiguration, _ := jira.Atlassian.getSiteConfiguration(ctx, userID)
 
 	if authToken == nil || siteConfiguration == nil {
 		result <- emptyTaskResultWithSource(errors.New("missing authToken or siteConfiguration"), TASK_SOURCE_ID_JIRA)
@@ -108,40 +112,39 @@ func (jira JIRASource) GetTasks(db *mongo.Database, userID primitive.ObjectID, a
 		apiBaseURL = *jira.Atlassian.Config.ConfigValues.APIBaseURL
 	}
 	JQL := "assignee=currentuser() AND statusCategory != Done"
-	req, err := http.NewRequest("GET", apiBaseURL+"/rest/api/3/search?jql="+url.QueryEscape(JQL), nil)
 	logger := logging.GetSentryLogger()
-	if err != nil {
-		logger.Error().Err(err).Msg("error forming search request")
-		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_JIRA)
-		return
-	}
-
-	req = addJIRARequestHeaders(req, authToken.AccessToken)
-	resp, err := http.DefaultClient.Do(req)
+	jiraTasks, err := jira.searchIssues(apiBaseURL, authToken.AccessToken, JQL, 0)
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to load search results")
 		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_JIRA)
 		return
 	}
 
-	taskData, err := io.ReadAll(resp.Body)
-	if err != nil {
-		logger.Error().Err(err).Msg("failed to read search response")
-		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_JIRA)
-		return
-	}
-	if resp.StatusCode != http.StatusOK {
-		logger.Error().Msgf("search failed: %s %v", taskData, resp.StatusCode)
-		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_JIRA)
-		return
+	// issues matching the user's JQL views are synced alongside their assigned issues so the views
+	// can be read from the database like every other view
+	assignedIssueIDs := map[string]bool{}
+	for _, jiraTask := range jiraTasks.Issues {
+		assignedIssueIDs[jiraTask.ID] = true
 	}
-
-	var jiraTasks JIRATaskList
-	err = json.Unmarshal(taskData, &jiraTasks)
+	issueJQLViewIDs := map[string][]primitive.ObjectID{}
+	jqlViews, err := database.GetJiraJQLViews(ctx, db, userID, accountID)
 	if err != nil {
-		logger.Error().Err(err).Msg("failed to parse JIRA tasks")
-		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_JIRA)
-		return
+		logger.Error().Err(err).Msg("failed to fetch JQL views")
+	}
+	for _, view := range jqlViews {
+		viewTasks, err := jira.searchIssues(apiBaseURL, authToken.AccessToken, view.JQL, constants.JIRA_JQL_VIEW_MAX_RESULTS)
+		if err != nil {
+			// the query was valid when saved, but fields or projects it references may have been removed since
+			logger.Error().Err(err).Msgf("failed to load JQL view results for view: %s", view.ID.Hex())
+			continue
+		}
+		for _, jiraTask := range viewTasks.Issues {
+			_, isSeen := issueJQLViewIDs[jiraTask.ID]
+			if !isSeen && !assignedIssueIDs[jiraTask.ID] {
+				jiraTasks.Issues = append(jiraTasks.Issues, jiraTask)
+			}
+			issueJQLViewIDs[jiraTask.ID] = append(issueJQLViewIDs[jiraTask.ID], view.ID)
+		}
 	}
 
 	statusMap, err := jira.GetListOfStatuses(siteConfiguration, userID, authToken.AccessToken)
@@ -161,6 +164,7 @@ func (jira JIRASource) GetTasks(db *mongo.Database, userID primitive.ObjectID, a
 	fieldChannelList := []chan JIRAFieldsResult{}
 	commentChannelList := []chan JIRACommentResult{}
 	transitionChannelList := []chan JIRATransitionList{}
+	agileChannelList := []chan JIRAAgileIssueResult{}
 	for _, jiraTask := range jiraTasks.Issues {
 		commentsChan := make(chan JIRACommentResult)
 		go jira.GetListOfComments(siteConfiguration, userID, jiraTask.ID, authToken.AccessToken, commentsChan)
@@ -173,8 +177,15 @@ func (jira JIRASource) GetTasks(db *mongo.Database, userID primitive.ObjectID, a
 		transitionChan := make(chan JIRATransitionList)
 		go jira.getTransitionList(siteConfiguration, jiraTask.ID, authToken.AccessToken, transitionChan)
 		transitionChannelList = append(transitionChannelList, transitionChan)
+
+		agileChan := make(chan JIRAAgileIssueResult)
+		go jira.getAgileIssue(siteConfiguration, jiraTask.ID, authToken.AccessToken, agileChan)
+		agileChannelList = append(agileChannelList, agileChan)
 	}
+	// sprints are shared by every issue on a board, so only fetch them once per board
+	boardSprints := map[int][]database.JIRASprint{}
 
+	defaultTaskSectionID := database.GetDefaultTaskSectionID(ctx, db, userID, TASK_SOURCE_ID_JIRA)
 	var tasks []*database.Task
 	for idx, jiraTask := range jiraTasks.Issues {
 		titleString := jiraTask.Fields.Summary
@@ -183,7 +194,7 @@ func (jira JIRASource) GetTasks(db *mongo.Database, userID primitive.ObjectID, a
 		task := &database.Task{
 			UserID:          userID,
 			IDExternal:      jiraTask.ID,
-			IDTaskSection:   constants.IDTaskSectionDefault,
+			IDTaskSection:   defaultTaskSectionID,
 			Deeplink:        siteConfiguration.SiteURL + "/browse/" + jiraTask.Key,
 			SourceID:        TASK_SOURCE_ID_JIRA,
 			Title:           &titleString,
@@ -210,16 +221,47 @@ func (jira JIRASource) GetTasks(db *mongo.Database, userID primitive.ObjectID, a
 			task.Comments = commentsOutput.CommentList
 		}
 
+		jiraTaskParams := database.JIRATaskParams{}
 		fieldsResult := fieldChannelList[idx]
 		fieldsOutput := <-fieldsResult
 		if fieldsOutput.Error != nil {
 			logger.Error().Err(fieldsOutput.Error).Msg("failed to fetch editable fields")
 		} else {
-			task.JIRATaskParams = &fieldsOutput.JIRATaskParams
+			jiraTaskParams = fieldsOutput.JIRATaskParams
 		}
 
 		transitionListResult := transitionChannelList[idx]
 		transitionList := <-transitionListResult
+		for _, transition := range transitionList.Transitions {
+			jiraTaskParams.Transitions = append(jiraTaskParams.Transitions, database.JIRATransition{
+				ExternalID: transition.ID,
+				Name:       transition.Name,
+				ToStatusID: transition.ToStatus.ID,
+			})
+		}
+
+		agileOutput := <-agileChannelList[idx]
+		if agileOutput.Error != nil {
+			// boards are optional in JIRA, so issues without agile fields are expected
+			logger.Debug().Err(agileOutput.Error).Msg("failed to fetch agile fields")
+		} else {
+			jiraTaskParams.Sprint = agileOutput.Sprint
+			jiraTaskParams.Epic = agileOutput.Epic
+			if agileOutput.Sprint != nil && agileOutput.Sprint.BoardID != 0 {
+				sprints, exists := boardSprints[agileOutput.Sprint.BoardID]
+				if !exists {
+					sprints, err = jira.getBoardSprints(siteConfiguration, agileOutput.Sprint.BoardID, authToken.AccessToken)
+					if err != nil {
+						logger.Error().Err(err).Msg("failed to fetch board sprints")
+					}
+					boardSprints[agileOutput.Sprint.BoardID] = sprints
+				}
+				jiraTaskParams.AvailableSprints = sprints
+			}
+		}
+		jiraTaskParams.JQLViewIDs = issueJQLViewIDs[jiraTask.ID]
+		jiraTaskParams.IsOnlyInJQLView = !assignedIssueIDs[jiraTask.ID]
+		task.JIRATaskParams = &jiraTaskParams
 		allStatuses, exists := statusMap[jiraTask.Fields.Project.ID]
 		if exists {
 			task.AllStatuses = allStatuses
@@ -297,6 +339,7 @@ func (jira JIRASource) GetTasks(db *mongo.Database, userID primitive.ObjectID, a
 		}
 
 		dbTask, err := database.UpdateOrCreateTask(
+			ctx,
 			db,
 			userID,
 			task.IDExternal,
@@ -320,7 +363,89 @@ func (jira JIRASource) GetTasks(db *mongo.Database, userID primitive.ObjectID, a
 	}
 }
 
-func (JIRA JIRASource) GetPullRequests(db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- PullRequestResult) {
+// searchIssues runs a JQL search. A maxResults of 0 uses JIRA's default page size
+func (jira JIRASource) searchIssues(apiBaseURL string, authToken string, JQL string, maxResults int) (*JIRATaskList, error) {
+	searchURL := apiBaseURL + "/rest/api/3/search?jql=" + url.QueryEscape(JQL)
+	if maxResults > 0 {
+		searchURL += "&maxResults=" + strconv.Itoa(maxResults)
+	}
+	req, err := http.NewRequest("GET", searchURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = addJIRARequestHeaders(req, authToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	taskData, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("search failed: %s %v", taskData, resp.StatusCode)
+	}
+
+	var jiraTasks JIRATaskList
+	err = json.Unmarshal(taskData, &jiraTasks)
+	if err != nil {
+		return nil, err
+	}
+	return &jiraTasks, nil
+}
+
+type JIRAJQLParseRequest struct {
+	Queries []string `json:"queries"`
+}
+
+type JIRAJQLParseResponse struct {
+	Queries []struct {
+		Errors []string `json:"errors"`
+	} `json:"queries"`
+}
+
+// ValidateJQL asks JIRA to parse the query against the account's site. It returns the problems
+// JIRA found with the query, or an error if the query couldn't be checked at all
+func (jira JIRASource) ValidateJQL(ctx context.Context, userID primitive.ObjectID, accountID string, JQL string) ([]string, error) {
+	authToken, _ := jira.Atlassian.getAndRefreshToken(ctx, userID, accountID)
+	siteConfiguration, _ := jira.Atlassian.getSiteConfiguration(ctx, userID)
+	if authToken == nil || siteConfiguration == nil {
+		return nil, errors.New("missing authToken or siteConfiguration")
+	}
+
+	requestBytes, err := json.Marshal(JIRAJQLParseRequest{Queries: []string{JQL}})
+	if err != nil {
+		return nil, err
+	}
+	apiBaseURL := jira.getJIRABaseURL(siteConfiguration, jira.Atlassian.Config.ConfigValues.APIBaseURL)
+	req, _ := http.NewRequest("POST", apiBaseURL+"/rest/api/3/jql/parse?validation=strict", bytes.NewBuffer(requestBytes))
+	req = addJIRARequestHeaders(req, authToken.AccessToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	responseBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	// JIRA responds with a 400 and the same body shape when the query is invalid
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusBadRequest {
+		return nil, fmt.Errorf("JQL parse failed: %s %v", responseBytes, resp.StatusCode)
+	}
+
+	var parseResponse JIRAJQLParseResponse
+	err = json.Unmarshal(responseBytes, &parseResponse)
+	if err != nil {
+		return nil, err
+	}
+	if len(parseResponse.Queries) != 1 {
+		return nil, errors.New("unexpected JQL parse response")
+	}
+	return parseResponse.Queries[0].Errors, nil
+}
+
+func (JIRA JIRASource) GetPullRequests(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- PullRequestResult) {
 	result <- emptyPullRequestResult(nil, false)
 }
 
@@ -546,21 +671,21 @@ func (jira JIRASource) GetListOfFields(siteConfiguration *database.AtlassianSite
 	}
 }
 
-func (jira JIRASource) CreateNewTask(db *mongo.Database, userID primitive.ObjectID, accountID string, task TaskCreationObject) (primitive.ObjectID, error) {
+func (jira JIRASource) CreateNewTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, task TaskCreationObject) (primitive.ObjectID, error) {
 	return primitive.NilObjectID, errors.New("has not been implemented yet")
 }
 
-func (jira JIRASource) CreateNewEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error {
+func (jira JIRASource) CreateNewEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error {
 	return errors.New("has not been implemented yet")
 }
 
-func (jira JIRASource) DeleteEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error {
+func (jira JIRASource) DeleteEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error {
 	return errors.New("has not been implemented yet")
 }
 
-func (jira JIRASource) ModifyTask(db *mongo.Database, userID primitive.ObjectID, accountID string, issueID string, updateFields *database.Task, task *database.Task) error {
-	token, _ := jira.Atlassian.getAndRefreshToken(userID, accountID)
-	siteConfiguration, _ := jira.Atlassian.getSiteConfiguration(userID)
+func (jira JIRASource) ModifyTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, issueID string, updateFields *database.Task, task *database.Task) error {
+	token, _ := jira.Atlassian.getAndRefreshToken(ctx, userID, accountID)
+	siteConfiguration, _ := jira.Atlassian.getSiteConfiguration(ctx, userID)
 	if token == nil || siteConfiguration == nil {
 		return errors.New("missing token or siteConfiguration")
 	}
@@ -583,6 +708,13 @@ func (jira JIRASource) ModifyTask(db *mongo.Database, userID primitive.ObjectID,
 		}
 	}
 
+	if updateFields.JIRATaskParams != nil {
+		err := jira.handleJIRASprintUpdate(siteConfiguration, token, issueID, updateFields.JIRATaskParams.Sprint)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -653,14 +785,14 @@ func (jira JIRASource) handleJIRAFieldUpdate(siteConfiguration *database.Atlassi
 		bodyBytes := json.RawMessage(*updateFields.Body)
 		updateRequest.Fields.Description = &bodyBytes
 	}
-	if (updateFields.ExternalPriority != nil && *updateFields.ExternalPriority != database.ExternalTaskPriority{}) && (task.JIRATaskParams != nil && *task.JIRATaskParams.HasPriorityField) {
+	if (updateFields.ExternalPriority != nil && *updateFields.ExternalPriority != database.ExternalTaskPriority{}) && (task.JIRATaskParams != nil && task.JIRATaskParams.HasPriorityField != nil && *task.JIRATaskParams.HasPriorityField) {
 		updateRequest.Fields.Priority = &JIRAPriority{
 			ID: updateFields.ExternalPriority.ExternalID,
 		}
 	}
 
 	var dueDateUpdateRequest JIRAUpdateRequestWithDueDate
-	if updateFields.DueDate != nil && (task.JIRATaskParams != nil && *task.JIRATaskParams.HasDueDateField) {
+	if updateFields.DueDate != nil && (task.JIRATaskParams != nil && task.JIRATaskParams.HasDueDateField != nil && *task.JIRATaskParams.HasDueDateField) {
 		dueDateUpdateRequest.Fields = JIRAUpdateFieldsWithDueDate{
 			Summary:     updateRequest.Fields.Summary,
 			Description: updateRequest.Fields.Description,
@@ -717,12 +849,14 @@ func (jira JIRASource) getTransitionList(siteConfiguration *database.AtlassianSi
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to request transitions")
 		result <- JIRATransitionList{}
+		return
 	}
 
 	responseBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to read http response body")
 		result <- JIRATransitionList{}
+		return
 	}
 
 	var transitionList JIRATransitionList
@@ -730,12 +864,13 @@ func (jira JIRASource) getTransitionList(siteConfiguration *database.AtlassianSi
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to parse JIRA transition list")
 		result <- JIRATransitionList{}
-
+		return
 	}
 	if len(transitionList.Transitions) == 0 {
 		err := errors.New("no JIRA transitions found in list")
 		logger.Error().Err(err).Msg("no JIRA transitions found in list")
 		result <- JIRATransitionList{}
+		return
 	}
 
 	result <- transitionList
@@ -773,11 +908,151 @@ func (jira JIRASource) executeFieldUpdate(apiBaseURL string, AtlassianAuthToken
 	return nil
 }
 
-func (jira JIRASource) ModifyEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, eventID string, updateFields *EventModifyObject) error {
+type JIRAAgileSprint struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	State         string `json:"state"`
+	OriginBoardID int    `json:"originBoardId"`
+}
+
+type JIRAAgileEpic struct {
+	ID   int    `json:"id"`
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+type JIRAAgileIssue struct {
+	Fields struct {
+		Sprint *JIRAAgileSprint `json:"sprint"`
+		Epic   *JIRAAgileEpic   `json:"epic"`
+	} `json:"fields"`
+}
+
+type JIRAAgileIssueResult struct {
+	Sprint *database.JIRASprint
+	Epic   *database.JIRAEpic
+	Error  error
+}
+
+type JIRASprintList struct {
+	Values []JIRAAgileSprint `json:"values"`
+}
+
+type JIRAIssueMoveRequest struct {
+	Issues []string `json:"issues"`
+}
+
+func (sprint JIRAAgileSprint) toDatabaseSprint() database.JIRASprint {
+	return database.JIRASprint{
+		ExternalID: sprint.ID,
+		Name:       sprint.Name,
+		State:      sprint.State,
+		BoardID:    sprint.OriginBoardID,
+	}
+}
+
+// getAgileIssue fetches the sprint and epic of an issue, which are only exposed by the agile API
+func (jira JIRASource) getAgileIssue(siteConfiguration *database.AtlassianSiteConfiguration, issueID string, authToken string, result chan<- JIRAAgileIssueResult) {
+	apiBaseURL := jira.getJIRABaseURL(siteConfiguration, jira.Atlassian.Config.ConfigValues.AgileURL)
+	req, _ := http.NewRequest("GET", apiBaseURL+"/rest/agile/1.0/issue/"+issueID+"?fields=sprint,epic", nil)
+	req = addJIRARequestHeaders(req, authToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		result <- JIRAAgileIssueResult{Error: err}
+		return
+	}
+	responseBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		result <- JIRAAgileIssueResult{Error: err}
+		return
+	}
+	if resp.StatusCode != http.StatusOK {
+		result <- JIRAAgileIssueResult{Error: errors.New("agile issue request failed with status " + resp.Status)}
+		return
+	}
+
+	var agileIssue JIRAAgileIssue
+	err = json.Unmarshal(responseBytes, &agileIssue)
+	if err != nil {
+		result <- JIRAAgileIssueResult{Error: err}
+		return
+	}
+	var agileResult JIRAAgileIssueResult
+	if agileIssue.Fields.Sprint != nil {
+		sprint := agileIssue.Fields.Sprint.toDatabaseSprint()
+		agileResult.Sprint = &sprint
+	}
+	if agileIssue.Fields.Epic != nil {
+		agileResult.Epic = &database.JIRAEpic{
+			ExternalID: agileIssue.Fields.Epic.ID,
+			Key:        agileIssue.Fields.Epic.Key,
+			Name:       agileIssue.Fields.Epic.Name,
+		}
+	}
+	result <- agileResult
+}
+
+// getBoardSprints returns the sprints an issue on the board can be moved into
+func (jira JIRASource) getBoardSprints(siteConfiguration *database.AtlassianSiteConfiguration, boardID int, authToken string) ([]database.JIRASprint, error) {
+	apiBaseURL := jira.getJIRABaseURL(siteConfiguration, jira.Atlassian.Config.ConfigValues.AgileURL)
+	sprintsURL := apiBaseURL + "/rest/agile/1.0/board/" + strconv.Itoa(boardID) + "/sprint?state=active,future"
+	req, _ := http.NewRequest("GET", sprintsURL, nil)
+	req = addJIRARequestHeaders(req, authToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	responseBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("sprint list request failed with status " + resp.Status)
+	}
+
+	var sprintList JIRASprintList
+	err = json.Unmarshal(responseBytes, &sprintList)
+	if err != nil {
+		return nil, err
+	}
+	sprints := []database.JIRASprint{}
+	for _, sprint := range sprintList.Values {
+		sprints = append(sprints, sprint.toDatabaseSprint())
+	}
+	return sprints, nil
+}
+
+// handleJIRASprintUpdate moves the issue into the sprint, or back to the backlog when sprint is nil
+func (jira JIRASource) handleJIRASprintUpdate(siteConfiguration *database.AtlassianSiteConfiguration, token *AtlassianAuthToken, issueID string, sprint *database.JIRASprint) error {
+	apiBaseURL := jira.getJIRABaseURL(siteConfiguration, jira.Atlassian.Config.ConfigValues.AgileURL)
+	moveURL := apiBaseURL + "/rest/agile/1.0/backlog/issue"
+	if sprint != nil {
+		moveURL = apiBaseURL + "/rest/agile/1.0/sprint/" + strconv.Itoa(sprint.ExternalID) + "/issue"
+	}
+	moveBytes, err := json.Marshal(JIRAIssueMoveRequest{Issues: []string{issueID}})
+	if err != nil {
+		return err
+	}
+	req, _ := http.NewRequest("POST", moveURL, bytes.NewBuffer(moveBytes))
+	req = addJIRARequestHeaders(req, token.AccessToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != 200 && resp.StatusCode != 204 {
+		return errors.New("unable to successfully move JIRA issue to sprint")
+	}
+	return nil
+}
+
+func (jira JIRASource) ModifyEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, eventID string, updateFields *EventModifyObject) error {
 	return errors.New("has not been implemented yet")
 }
 
-func (jira JIRASource) AddComment(db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error {
+func (jira JIRASource) AddComment(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error {
 	return errors.New("has not been implemented yet")
 }
 
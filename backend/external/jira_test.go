@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -32,7 +33,7 @@ func TestLoadJIRATasks(t *testing.T) {
 		var JIRATasks = make(chan TaskResult)
 		userID := primitive.NewObjectID()
 		JIRA := JIRASource{Atlassian: AtlassianService{}}
-		go JIRA.GetTasks(db, userID, "exampleAccountID", JIRATasks)
+		go JIRA.GetTasks(context.Background(), db, userID, "exampleAccountID", JIRATasks)
 		result := <-JIRATasks
 		assert.Equal(t, 0, len(result.Tasks))
 	})
@@ -41,7 +42,7 @@ func TestLoadJIRATasks(t *testing.T) {
 		tokenServer := getTokenServerForJIRA(t, http.StatusUnauthorized)
 		var JIRATasks = make(chan TaskResult)
 		JIRA := JIRASource{Atlassian: AtlassianService{Config: AtlassianConfig{ConfigValues: AtlassianConfigValues{TokenURL: &tokenServer.URL}}}}
-		go JIRA.GetTasks(db, *userID, accountID, JIRATasks)
+		go JIRA.GetTasks(context.Background(), db, *userID, accountID, JIRATasks)
 		result := <-JIRATasks
 		assert.Equal(t, 0, len(result.Tasks))
 	})
@@ -52,7 +53,7 @@ func TestLoadJIRATasks(t *testing.T) {
 		searchServer := getSearchServerForJIRA(t, http.StatusUnauthorized, false)
 		var JIRATasks = make(chan TaskResult)
 		JIRA := JIRASource{Atlassian: AtlassianService{Config: AtlassianConfig{ConfigValues: AtlassianConfigValues{APIBaseURL: &searchServer.URL, TokenURL: &tokenServer.URL}}}}
-		go JIRA.GetTasks(db, *userID, accountID, JIRATasks)
+		go JIRA.GetTasks(context.Background(), db, *userID, accountID, JIRATasks)
 		result := <-JIRATasks
 		assert.Equal(t, 0, len(result.Tasks))
 	})
@@ -62,7 +63,7 @@ func TestLoadJIRATasks(t *testing.T) {
 		searchServer := getSearchServerForJIRA(t, http.StatusOK, true)
 		var JIRATasks = make(chan TaskResult)
 		JIRA := JIRASource{Atlassian: AtlassianService{Config: AtlassianConfig{ConfigValues: AtlassianConfigValues{APIBaseURL: &searchServer.URL, TokenURL: &tokenServer.URL}}}}
-		go JIRA.GetTasks(db, *userID, accountID, JIRATasks)
+		go JIRA.GetTasks(context.Background(), db, *userID, accountID, JIRATasks)
 		result := <-JIRATasks
 		assert.Equal(t, 0, len(result.Tasks))
 	})
@@ -74,6 +75,8 @@ func TestLoadJIRATasks(t *testing.T) {
 		fieldsServer := getJIRAFieldsServer(t, http.StatusOK, []byte(`{"fields":{}}`))
 		commentsServer := getJIRACommentsServer(t, http.StatusOK, []byte(`{"comments": [{"id": "10000","author":{"accountId": "example-id-1", "displayName": "test"}},{"id": "10001","author":{"accountId": "example-id-2", "displayName": "test2"}}]}`))
 		transitionServer := getTransitionServerForJIRA(t, 200, false, true)
+		agileServer := getJIRAAgileServer(t)
+		defer agileServer.Close()
 
 		// ensure external API token values updated
 		var externalJIRAToken database.ExternalAPIToken
@@ -141,8 +144,8 @@ func TestLoadJIRATasks(t *testing.T) {
 		}
 
 		var JIRATasks = make(chan TaskResult)
-		JIRA := JIRASource{Atlassian: AtlassianService{Config: AtlassianConfig{ConfigValues: AtlassianConfigValues{APIBaseURL: &searchServer.URL, TokenURL: &tokenServer.URL, StatusListURL: &statusServer.URL, CommentsListURL: &commentsServer.URL, FieldsListURL: &fieldsServer.URL, TransitionURL: &transitionServer.URL}}}}
-		go JIRA.GetTasks(db, *userID, accountID, JIRATasks)
+		JIRA := JIRASource{Atlassian: AtlassianService{Config: AtlassianConfig{ConfigValues: AtlassianConfigValues{APIBaseURL: &searchServer.URL, TokenURL: &tokenServer.URL, StatusListURL: &statusServer.URL, CommentsListURL: &commentsServer.URL, FieldsListURL: &fieldsServer.URL, TransitionURL: &transitionServer.URL, AgileURL: &agileServer.URL}}}}
+		go JIRA.GetTasks(context.Background(), db, *userID, accountID, JIRATasks)
 		result := <-JIRATasks
 		assert.Equal(t, 1, len(result.Tasks))
 
@@ -154,6 +157,13 @@ func TestLoadJIRATasks(t *testing.T) {
 		assert.Equal(t, true, result.Tasks[0].AllStatuses[1].IsValidTransition)
 		assert.Equal(t, "new", result.Tasks[0].AllStatuses[0].Type)
 		assert.Equal(t, "done", result.Tasks[0].AllStatuses[1].Type)
+		assert.Equal(t, []database.JIRATransition{{ExternalID: "101", ToStatusID: "10003"}}, result.Tasks[0].JIRATaskParams.Transitions)
+		assert.Equal(t, &database.JIRASprint{ExternalID: 37, Name: "Sprint 37", State: "active", BoardID: 5}, result.Tasks[0].JIRATaskParams.Sprint)
+		assert.Equal(t, &database.JIRAEpic{ExternalID: 12, Key: "MOON-1", Name: "Moon landing"}, result.Tasks[0].JIRATaskParams.Epic)
+		assert.Equal(t, []database.JIRASprint{
+			{ExternalID: 37, Name: "Sprint 37", State: "active", BoardID: 5},
+			{ExternalID: 38, Name: "Sprint 38", State: "future", BoardID: 5},
+		}, result.Tasks[0].JIRATaskParams.AvailableSprints)
 
 		var taskFromDB database.Task
 		dbCtx, cancel = context.WithTimeout(parentCtx, constants.DatabaseTimeout)
@@ -222,6 +232,7 @@ func TestLoadJIRATasks(t *testing.T) {
 			Comments: &[]database.Comment{},
 		}
 		database.GetOrCreateTask(
+			context.Background(),
 			db,
 			*userID,
 			"42069",
@@ -231,7 +242,7 @@ func TestLoadJIRATasks(t *testing.T) {
 
 		var JIRATasks = make(chan TaskResult)
 		JIRA := JIRASource{Atlassian: AtlassianService{Config: AtlassianConfig{ConfigValues: AtlassianConfigValues{APIBaseURL: &searchServer.URL, TokenURL: &tokenServer.URL, StatusListURL: &statusServer.URL}}}}
-		go JIRA.GetTasks(db, *userID, accountID, JIRATasks)
+		go JIRA.GetTasks(context.Background(), db, *userID, accountID, JIRATasks)
 		result := <-JIRATasks
 		assert.Equal(t, 1, len(result.Tasks))
 
@@ -295,6 +306,7 @@ func TestLoadJIRATasks(t *testing.T) {
 			Comments: &[]database.Comment{},
 		}
 		database.GetOrCreateTask(
+			context.Background(),
 			db,
 			*userID,
 			"42069",
@@ -304,7 +316,7 @@ func TestLoadJIRATasks(t *testing.T) {
 
 		var JIRATasks = make(chan TaskResult)
 		JIRA := JIRASource{Atlassian: AtlassianService{Config: AtlassianConfig{ConfigValues: AtlassianConfigValues{APIBaseURL: &searchServer.URL, TokenURL: &tokenServer.URL, StatusListURL: &statusServer.URL, PriorityListURL: &server.URL}}}}
-		go JIRA.GetTasks(db, *userID, accountID, JIRATasks)
+		go JIRA.GetTasks(context.Background(), db, *userID, accountID, JIRATasks)
 		result := <-JIRATasks
 		assert.Equal(t, 1, len(result.Tasks))
 
@@ -362,6 +374,7 @@ func TestLoadJIRATasks(t *testing.T) {
 			Comments: &[]database.Comment{},
 		}
 		database.GetOrCreateTask(
+			context.Background(),
 			db,
 			*userID,
 			"42069",
@@ -371,7 +384,7 @@ func TestLoadJIRATasks(t *testing.T) {
 
 		var JIRATasks = make(chan TaskResult)
 		JIRA := JIRASource{Atlassian: AtlassianService{Config: AtlassianConfig{ConfigValues: AtlassianConfigValues{APIBaseURL: &searchServer.URL, TokenURL: &tokenServer.URL, StatusListURL: &statusServer.URL}}}}
-		go JIRA.GetTasks(db, *userID, accountID, JIRATasks)
+		go JIRA.GetTasks(context.Background(), db, *userID, accountID, JIRATasks)
 		result := <-JIRATasks
 		assert.Equal(t, 1, len(result.Tasks))
 
@@ -403,7 +416,7 @@ func TestGetStatuses(t *testing.T) {
 	t.Run("NoResponse", func(t *testing.T) {
 		JIRA := JIRASource{Atlassian: AtlassianService{}}
 
-		siteConfiguration, err := JIRA.Atlassian.getSiteConfiguration(*userID)
+		siteConfiguration, err := JIRA.Atlassian.getSiteConfiguration(context.Background(), *userID)
 		assert.NoError(t, err)
 
 		_, err = JIRA.GetListOfStatuses(siteConfiguration, *userID, "sample")
@@ -414,7 +427,7 @@ func TestGetStatuses(t *testing.T) {
 		defer server.Close()
 		JIRA := JIRASource{Atlassian: AtlassianService{Config: AtlassianConfig{ConfigValues: AtlassianConfigValues{StatusListURL: &server.URL}}}}
 
-		siteConfiguration, err := JIRA.Atlassian.getSiteConfiguration(*userID)
+		siteConfiguration, err := JIRA.Atlassian.getSiteConfiguration(context.Background(), *userID)
 		assert.NoError(t, err)
 
 		_, err = JIRA.GetListOfStatuses(siteConfiguration, *userID, "sample")
@@ -425,7 +438,7 @@ func TestGetStatuses(t *testing.T) {
 		defer server.Close()
 		JIRA := JIRASource{Atlassian: AtlassianService{Config: AtlassianConfig{ConfigValues: AtlassianConfigValues{StatusListURL: &server.URL}}}}
 
-		siteConfiguration, err := JIRA.Atlassian.getSiteConfiguration(*userID)
+		siteConfiguration, err := JIRA.Atlassian.getSiteConfiguration(context.Background(), *userID)
 		assert.NoError(t, err)
 
 		statusMap, err := JIRA.GetListOfStatuses(siteConfiguration, *userID, "sample")
@@ -451,7 +464,7 @@ func TestGetPriorities(t *testing.T) {
 		defer server.Close()
 		JIRA := JIRASource{Atlassian: AtlassianService{Config: AtlassianConfig{ConfigValues: AtlassianConfigValues{PriorityListURL: &server.URL}}}}
 
-		siteConfiguration, err := JIRA.Atlassian.getSiteConfiguration(*userID)
+		siteConfiguration, err := JIRA.Atlassian.getSiteConfiguration(context.Background(), *userID)
 		assert.NoError(t, err)
 
 		_, err = JIRA.GetListOfPriorities(siteConfiguration, *userID, "sample")
@@ -463,7 +476,7 @@ func TestGetPriorities(t *testing.T) {
 		defer server.Close()
 		JIRA := JIRASource{Atlassian: AtlassianService{Config: AtlassianConfig{ConfigValues: AtlassianConfigValues{PriorityListURL: &server.URL}}}}
 
-		siteConfiguration, err := JIRA.Atlassian.getSiteConfiguration(*userID)
+		siteConfiguration, err := JIRA.Atlassian.getSiteConfiguration(context.Background(), *userID)
 		assert.NoError(t, err)
 
 		priorities, err := JIRA.GetListOfPriorities(siteConfiguration, *userID, "sample")
@@ -489,7 +502,7 @@ func TestGetJIRAComments(t *testing.T) {
 		defer server.Close()
 		JIRA := JIRASource{Atlassian: AtlassianService{Config: AtlassianConfig{ConfigValues: AtlassianConfigValues{CommentsListURL: &server.URL}}}}
 
-		siteConfiguration, err := JIRA.Atlassian.getSiteConfiguration(*userID)
+		siteConfiguration, err := JIRA.Atlassian.getSiteConfiguration(context.Background(), *userID)
 		assert.NoError(t, err)
 
 		resultChan := make(chan JIRACommentResult)
@@ -505,7 +518,7 @@ func TestGetJIRAComments(t *testing.T) {
 		defer server.Close()
 		JIRA := JIRASource{Atlassian: AtlassianService{Config: AtlassianConfig{ConfigValues: AtlassianConfigValues{CommentsListURL: &server.URL}}}}
 
-		siteConfiguration, err := JIRA.Atlassian.getSiteConfiguration(*userID)
+		siteConfiguration, err := JIRA.Atlassian.getSiteConfiguration(context.Background(), *userID)
 		assert.NoError(t, err)
 
 		resultChan := make(chan JIRACommentResult)
@@ -788,6 +801,7 @@ func TestModifyJIRATask(t *testing.T) {
 		},
 	}
 	database.GetOrCreateTask(
+		context.Background(),
 		db,
 		*userID,
 		"test-issue-id-1",
@@ -801,7 +815,7 @@ func TestModifyJIRATask(t *testing.T) {
 		defer transitionServer.Close()
 		JIRA := JIRASource{Atlassian: AtlassianService{Config: AtlassianConfig{ConfigValues: AtlassianConfigValues{TransitionURL: &transitionServer.URL, TokenURL: &tokenServer.URL}}}}
 
-		err := JIRA.ModifyTask(db, *userID, account_id, "6942069420", &database.Task{Status: &database.ExternalTaskStatus{ExternalID: "10003"}}, &database.Task{})
+		err := JIRA.ModifyTask(context.Background(), db, *userID, account_id, "6942069420", &database.Task{Status: &database.ExternalTaskStatus{ExternalID: "10003"}}, &database.Task{})
 		assert.NotEqual(t, nil, err)
 		assert.Equal(t, `transition not found`, err.Error())
 	})
@@ -811,9 +825,37 @@ func TestModifyJIRATask(t *testing.T) {
 		defer transitionServer.Close()
 		JIRA := JIRASource{Atlassian: AtlassianService{Config: AtlassianConfig{ConfigValues: AtlassianConfigValues{TransitionURL: &transitionServer.URL, TokenURL: &tokenServer.URL}}}}
 
-		err := JIRA.ModifyTask(db, *userID, account_id, "6942069420", &database.Task{Status: &database.ExternalTaskStatus{ExternalID: "10003"}}, &database.Task{})
+		err := JIRA.ModifyTask(context.Background(), db, *userID, account_id, "6942069420", &database.Task{Status: &database.ExternalTaskStatus{ExternalID: "10003"}}, &database.Task{})
 		assert.NoError(t, err)
 	})
+	t.Run("MoveToSprintSuccess", func(t *testing.T) {
+		tokenServer := getTokenServerForJIRA(t, http.StatusOK)
+		agileServer := getJIRAAgileServer(t)
+		defer agileServer.Close()
+		JIRA := JIRASource{Atlassian: AtlassianService{Config: AtlassianConfig{ConfigValues: AtlassianConfigValues{AgileURL: &agileServer.URL, TokenURL: &tokenServer.URL}}}}
+
+		err := JIRA.ModifyTask(context.Background(), db, *userID, account_id, "6942069420", &database.Task{JIRATaskParams: &database.JIRATaskParams{Sprint: &database.JIRASprint{ExternalID: 38}}}, &database.Task{})
+		assert.NoError(t, err)
+	})
+	t.Run("MoveToBacklogSuccess", func(t *testing.T) {
+		tokenServer := getTokenServerForJIRA(t, http.StatusOK)
+		agileServer := getJIRAAgileServer(t)
+		defer agileServer.Close()
+		JIRA := JIRASource{Atlassian: AtlassianService{Config: AtlassianConfig{ConfigValues: AtlassianConfigValues{AgileURL: &agileServer.URL, TokenURL: &tokenServer.URL}}}}
+
+		err := JIRA.ModifyTask(context.Background(), db, *userID, account_id, "6942069420", &database.Task{JIRATaskParams: &database.JIRATaskParams{}}, &database.Task{})
+		assert.NoError(t, err)
+	})
+	t.Run("MoveToSprintBadResponse", func(t *testing.T) {
+		tokenServer := getTokenServerForJIRA(t, http.StatusOK)
+		agileServer := testutils.GetMockAPIServer(t, 400, "")
+		defer agileServer.Close()
+		JIRA := JIRASource{Atlassian: AtlassianService{Config: AtlassianConfig{ConfigValues: AtlassianConfigValues{AgileURL: &agileServer.URL, TokenURL: &tokenServer.URL}}}}
+
+		err := JIRA.ModifyTask(context.Background(), db, *userID, account_id, "6942069420", &database.Task{JIRATaskParams: &database.JIRATaskParams{Sprint: &database.JIRASprint{ExternalID: 38}}}, &database.Task{})
+		assert.Error(t, err)
+		assert.Equal(t, "unable to successfully move JIRA issue to sprint", err.Error())
+	})
 	t.Run("UpdateFieldsBadResponse", func(t *testing.T) {
 		tokenServer := getTokenServerForJIRA(t, http.StatusOK)
 		taskUpdateServer := testutils.GetMockAPIServer(t, 400, "")
@@ -822,7 +864,7 @@ func TestModifyJIRATask(t *testing.T) {
 
 		newTitle := "title!"
 
-		err := JIRA.ModifyTask(db, *userID, account_id, "6942069420", &database.Task{Title: &newTitle}, &database.Task{})
+		err := JIRA.ModifyTask(context.Background(), db, *userID, account_id, "6942069420", &database.Task{Title: &newTitle}, &database.Task{})
 		assert.NotEqual(t, nil, err)
 		assert.Equal(t, `unable to successfully make field update request`, err.Error())
 	})
@@ -836,7 +878,7 @@ func TestModifyJIRATask(t *testing.T) {
 		newBody := `{"body": "New Body"}`
 		dueDate := primitive.NewDateTimeFromTime(time.Now())
 
-		err := JIRA.ModifyTask(db, *userID, account_id, "6942069420", &database.Task{
+		err := JIRA.ModifyTask(context.Background(), db, *userID, account_id, "6942069420", &database.Task{
 			Title: &newName,
 			Body:  &newBody,
 			ExternalPriority: &database.ExternalTaskPriority{
@@ -855,7 +897,7 @@ func TestModifyJIRATask(t *testing.T) {
 
 		newName := "New Title"
 
-		err := JIRA.ModifyTask(db, *userID, account_id, "6942069420", &database.Task{
+		err := JIRA.ModifyTask(context.Background(), db, *userID, account_id, "6942069420", &database.Task{
 			Title:  &newName,
 			Status: &database.ExternalTaskStatus{ExternalID: "10003"},
 		}, &database.Task{})
@@ -869,7 +911,7 @@ func TestModifyJIRATask(t *testing.T) {
 
 		newBody := `{"body": ""}`
 
-		err := JIRA.ModifyTask(db, *userID, account_id, "6942069420", &database.Task{
+		err := JIRA.ModifyTask(context.Background(), db, *userID, account_id, "6942069420", &database.Task{
 			Body: &newBody,
 		}, &database.Task{})
 		assert.NoError(t, err)
@@ -884,7 +926,7 @@ func TestModifyJIRATask(t *testing.T) {
 		newBody := `{"body": "New Body"}`
 		dueDate := primitive.NewDateTimeFromTime(time.Unix(0, 0))
 
-		err := JIRA.ModifyTask(db, *userID, account_id, "6942069420", &database.Task{
+		err := JIRA.ModifyTask(context.Background(), db, *userID, account_id, "6942069420", &database.Task{
 			Title: &newName,
 			Body:  &newBody,
 			ExternalPriority: &database.ExternalTaskPriority{
@@ -903,7 +945,7 @@ func TestModifyJIRATask(t *testing.T) {
 		newName := ""
 		newBody := `{"body": "New Body"}`
 
-		err := JIRA.ModifyTask(db, *userID, account_id, "6942069420", &database.Task{
+		err := JIRA.ModifyTask(context.Background(), db, *userID, account_id, "6942069420", &database.Task{
 			Title: &newName,
 			Body:  &newBody,
 		}, &database.Task{})
@@ -917,7 +959,7 @@ func TestModifyJIRATask(t *testing.T) {
 		JIRA := JIRASource{Atlassian: AtlassianService{Config: AtlassianConfig{ConfigValues: AtlassianConfigValues{IssueDeleteURL: &deleteServer.URL, TokenURL: &tokenServer.URL}}}}
 
 		deleted := true
-		err := JIRA.ModifyTask(db, *userID, account_id, "6942069420", &database.Task{IsDeleted: &deleted}, &database.Task{})
+		err := JIRA.ModifyTask(context.Background(), db, *userID, account_id, "6942069420", &database.Task{IsDeleted: &deleted}, &database.Task{})
 		assert.NotEqual(t, nil, err)
 		assert.Equal(t, `unable to successfully delete JIRA task`, err.Error())
 	})
@@ -928,11 +970,12 @@ func TestModifyJIRATask(t *testing.T) {
 		JIRA := JIRASource{Atlassian: AtlassianService{Config: AtlassianConfig{ConfigValues: AtlassianConfigValues{IssueDeleteURL: &deleteServer.URL, TokenURL: &tokenServer.URL}}}}
 
 		deleted := true
-		err := JIRA.ModifyTask(db, *userID, account_id, "6942069420", &database.Task{IsDeleted: &deleted}, &database.Task{})
+		err := JIRA.ModifyTask(context.Background(), db, *userID, account_id, "6942069420", &database.Task{IsDeleted: &deleted}, &database.Task{})
 		assert.NoError(t, err)
 	})
 	t.Run("UndeleteFailure", func(t *testing.T) {
 		database.GetOrCreateTask(
+			context.Background(),
 			db,
 			*userID,
 			"test-issue-id-1",
@@ -940,7 +983,7 @@ func TestModifyJIRATask(t *testing.T) {
 			&expectedTask,
 		)
 
-		_, err := database.UpdateOrCreateTask(db, *userID, "test-issue-id-1", TASK_SOURCE_ID_JIRA, nil, bson.M{"is_deleted": true}, nil)
+		_, err := database.UpdateOrCreateTask(context.Background(), db, *userID, "test-issue-id-1", TASK_SOURCE_ID_JIRA, nil, bson.M{"is_deleted": true}, nil)
 		assert.NoError(t, err)
 
 		tokenServer := getTokenServerForJIRA(t, http.StatusOK)
@@ -949,8 +992,81 @@ func TestModifyJIRATask(t *testing.T) {
 		JIRA := JIRASource{Atlassian: AtlassianService{Config: AtlassianConfig{ConfigValues: AtlassianConfigValues{IssueDeleteURL: &deleteServer.URL, TokenURL: &tokenServer.URL}}}}
 
 		deleted := false
-		err = JIRA.ModifyTask(db, *userID, account_id, "6942069420", &database.Task{IsDeleted: &deleted}, &database.Task{})
+		err = JIRA.ModifyTask(context.Background(), db, *userID, account_id, "6942069420", &database.Task{IsDeleted: &deleted}, &database.Task{})
 		assert.Error(t, err)
 		assert.Equal(t, `cannot undelete JIRA tasks`, err.Error())
 	})
 }
+
+func getJIRAAgileServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/rest/agile/1.0/issue/"):
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"fields": {"sprint": {"id": 37, "name": "Sprint 37", "state": "active", "originBoardId": 5}, "epic": {"id": 12, "key": "MOON-1", "name": "Moon landing"}}}`))
+		case r.Method == "GET" && r.URL.Path == "/rest/agile/1.0/board/5/sprint":
+			assert.Equal(t, "active,future", r.URL.Query().Get("state"))
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"values": [{"id": 37, "name": "Sprint 37", "state": "active", "originBoardId": 5}, {"id": 38, "name": "Sprint 38", "state": "future", "originBoardId": 5}]}`))
+		case r.Method == "POST" && (r.URL.Path == "/rest/agile/1.0/sprint/38/issue" || r.URL.Path == "/rest/agile/1.0/backlog/issue"):
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, `{"issues":["6942069420"]}`, string(body))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestValidateJQL(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	externalAPITokenCollection := database.GetExternalTokenCollection(db)
+	AtlassianSiteCollection := database.GetJiraSitesCollection(db)
+
+	t.Run("MissingToken", func(t *testing.T) {
+		JIRA := JIRASource{Atlassian: AtlassianService{}}
+		_, err := JIRA.ValidateJQL(context.Background(), primitive.NewObjectID(), "exampleAccountID", "project = MOON")
+		assert.EqualError(t, err, "missing authToken or siteConfiguration")
+	})
+	t.Run("Valid", func(t *testing.T) {
+		userID, accountID := setupJIRA(t, externalAPITokenCollection, AtlassianSiteCollection)
+		tokenServer := getTokenServerForJIRA(t, http.StatusOK)
+		parseServer := getJQLParseServerForJIRA(t, http.StatusOK, []byte(`{"queries": [{"query": "project = MOON"}]}`))
+		JIRA := JIRASource{Atlassian: AtlassianService{Config: AtlassianConfig{ConfigValues: AtlassianConfigValues{APIBaseURL: &parseServer.URL, TokenURL: &tokenServer.URL}}}}
+		JQLErrors, err := JIRA.ValidateJQL(context.Background(), *userID, accountID, "project = MOON")
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(JQLErrors))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		userID, accountID := setupJIRA(t, externalAPITokenCollection, AtlassianSiteCollection)
+		tokenServer := getTokenServerForJIRA(t, http.StatusOK)
+		parseServer := getJQLParseServerForJIRA(t, http.StatusBadRequest, []byte(`{"queries": [{"query": "project = MOON", "errors": ["The value 'MOON' does not exist for the field 'project'."]}]}`))
+		JIRA := JIRASource{Atlassian: AtlassianService{Config: AtlassianConfig{ConfigValues: AtlassianConfigValues{APIBaseURL: &parseServer.URL, TokenURL: &tokenServer.URL}}}}
+		JQLErrors, err := JIRA.ValidateJQL(context.Background(), *userID, accountID, "project = MOON")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"The value 'MOON' does not exist for the field 'project'."}, JQLErrors)
+	})
+	t.Run("ParseFailed", func(t *testing.T) {
+		userID, accountID := setupJIRA(t, externalAPITokenCollection, AtlassianSiteCollection)
+		tokenServer := getTokenServerForJIRA(t, http.StatusOK)
+		parseServer := getJQLParseServerForJIRA(t, http.StatusUnauthorized, []byte(``))
+		JIRA := JIRASource{Atlassian: AtlassianService{Config: AtlassianConfig{ConfigValues: AtlassianConfigValues{APIBaseURL: &parseServer.URL, TokenURL: &tokenServer.URL}}}}
+		_, err := JIRA.ValidateJQL(context.Background(), *userID, accountID, "project = MOON")
+		assert.EqualError(t, err, "JQL parse failed:  401")
+	})
+}
+
+func getJQLParseServerForJIRA(t *testing.T, statusCode int, response []byte) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/rest/api/3/jql/parse?validation=strict", r.RequestURI)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"queries":["project = MOON"]}`, string(body))
+		w.WriteHeader(statusCode)
+		w.Write(response)
+	}))
+}
@@ -80,14 +80,14 @@ func (linear LinearService) HandleLinkCallback(db *mongo.Database, params Callba
 		return errors.New("internal server error")
 	}
 
-	accountID, externalID, err := getLinearEmailAndID(token, linear.Config.ConfigValues.UserInfoURL)
+	accountID, externalID, err := getLinearEmailAndID(parentCtx, token, linear.Config.ConfigValues.UserInfoURL)
 	if err != nil {
 		accountID = "" // TODO: maybe add a placeholder instead of empty string
 	}
 
 	externalAPITokenCollection := database.GetExternalTokenCollection(db)
 	_, err = externalAPITokenCollection.UpdateOne(
-		context.Background(),
+		parentCtx,
 		bson.M{"$and": []bson.M{{"user_id": userID}, {"service_id": TASK_SERVICE_ID_LINEAR}}},
 		bson.M{"$set": &database.ExternalAPIToken{
 			UserID:         userID,
@@ -109,8 +109,8 @@ func (linear LinearService) HandleLinkCallback(db *mongo.Database, params Callba
 	return nil
 }
 
-func getLinearEmailAndID(token *oauth2.Token, overrideURL *string) (string, string, error) {
-	client := getLinearClientFromToken(token, overrideURL)
+func getLinearEmailAndID(ctx context.Context, token *oauth2.Token, overrideURL *string) (string, string, error) {
+	client := getLinearClientFromToken(ctx, token, overrideURL)
 
 	var query struct {
 		Viewer struct {
@@ -119,7 +119,7 @@ func getLinearEmailAndID(token *oauth2.Token, overrideURL *string) (string, stri
 			Email graphql.String
 		}
 	}
-	err := client.Query(context.Background(), &query, nil)
+	err := client.Query(ctx, &query, nil)
 	logger := logging.GetSentryLogger()
 	if err != nil {
 		logger.Error().Err(err).Interface("query", query).Msg("could not execute query")
@@ -129,29 +129,29 @@ func getLinearEmailAndID(token *oauth2.Token, overrideURL *string) (string, stri
 	return string(query.Viewer.Email), string(query.Viewer.Id), nil
 }
 
-func (linear LinearService) HandleSignupCallback(db *mongo.Database, params CallbackParams) (primitive.ObjectID, *bool, *string, error) {
+func (linear LinearService) HandleSignupCallback(ctx context.Context, db *mongo.Database, params CallbackParams) (primitive.ObjectID, *bool, *string, error) {
 	return primitive.NilObjectID, nil, nil, errors.New("linear does not support signup")
 }
 
-func getLinearClientFromToken(token *oauth2.Token, overrideURL *string) *graphql.Client {
+func getLinearClientFromToken(ctx context.Context, token *oauth2.Token, overrideURL *string) *graphql.Client {
 	var client *graphql.Client
 	if overrideURL != nil {
 		client = graphql.NewClient(*overrideURL, nil)
 	} else {
-		httpClient := oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(token))
+		httpClient := oauth2.NewClient(ctx, oauth2.StaticTokenSource(token))
 		client = graphql.NewClient(LinearGraphqlEndpoint, httpClient)
 	}
 	return client
 }
 
-func GetLinearClient(overrideURL *string, db *mongo.Database, userID primitive.ObjectID, accountID string) (*graphql.Client, error) {
+func GetLinearClient(ctx context.Context, overrideURL *string, db *mongo.Database, userID primitive.ObjectID, accountID string) (*graphql.Client, error) {
 	var client *graphql.Client
 	var err error
 	logger := logging.GetSentryLogger()
 	if overrideURL != nil {
 		client = graphql.NewClient(*overrideURL, nil)
 	} else {
-		httpClient := getLinearHttpClient(db, userID, accountID)
+		httpClient := getLinearHttpClient(ctx, db, userID, accountID)
 		if httpClient == nil {
 			logger.Error().Msg("could not create linear client")
 			return nil, errors.New("could not create linear client")
@@ -165,14 +165,14 @@ func GetLinearClient(overrideURL *string, db *mongo.Database, userID primitive.O
 	return client, nil
 }
 
-func GetBasicLinearClient(overrideURL *string, db *mongo.Database, userID primitive.ObjectID, accountID string) (*graphqlBasic.Client, error) {
+func GetBasicLinearClient(ctx context.Context, overrideURL *string, db *mongo.Database, userID primitive.ObjectID, accountID string) (*graphqlBasic.Client, error) {
 	var client *graphqlBasic.Client
 	var err error
 	logger := logging.GetSentryLogger()
 	if overrideURL != nil {
 		client = graphqlBasic.NewClient(*overrideURL)
 	} else {
-		httpClient := getLinearHttpClient(db, userID, accountID)
+		httpClient := getLinearHttpClient(ctx, db, userID, accountID)
 		if httpClient == nil {
 			logger.Error().Msg("could not create linear client")
 			return nil, errors.New("could not create linear client")
@@ -186,15 +186,15 @@ func GetBasicLinearClient(overrideURL *string, db *mongo.Database, userID primit
 	return client, nil
 }
 
-func getLinearHttpClient(db *mongo.Database, userID primitive.ObjectID, accountID string) *http.Client {
-	return getExternalOauth2Client(db, userID, accountID, TASK_SERVICE_ID_LINEAR, getLinearOauthConfig())
+func getLinearHttpClient(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string) *http.Client {
+	return getExternalOauth2Client(ctx, db, userID, accountID, TASK_SERVICE_ID_LINEAR, getLinearOauthConfig())
 }
 
 func getLinearOauthConfig() *OauthConfig {
 	return &OauthConfig{Config: &oauth2.Config{
-		ClientID:     config.GetConfigValue("LINEAR_OAUTH_CLIENT_ID"),
-		ClientSecret: config.GetConfigValue("LINEAR_OAUTH_CLIENT_SECRET"),
-		RedirectURL:  config.GetConfigValue("SERVER_URL") + "link/linear/callback/",
+		ClientID:     config.GetSettings().LinearOauth.ClientID,
+		ClientSecret: config.GetSettings().LinearOauth.ClientSecret,
+		RedirectURL:  config.GetSettings().ServerURL + "link/linear/callback/",
 		Scopes:       []string{"read", "write"},
 		Endpoint: oauth2.Endpoint{
 			AuthURL:  LinearAuthUrl,
@@ -307,6 +307,14 @@ type linearAssignedIssuesQuery struct {
 				StartsAt graphql.String
 				EndsAt   graphql.String
 			}
+			Project struct {
+				Id         graphql.ID
+				Name       graphql.String
+				State      graphql.String
+				TargetDate graphql.String
+			}
+			// null when the issue hasn't been estimated, which is different from an estimate of 0
+			Estimate *graphql.Float
 		}
 	} `graphql:"issues(filter: {state: {type: {nin: [\"completed\", \"canceled\"]}}, assignee: {email: {eq: $email}}})"`
 	ActiveCycles   Cycles `graphql:" activeCycles: cycles (filter: {isActive: {eq: true}})"`
@@ -403,7 +411,7 @@ type linearUndeleteIssueQuery struct {
 	} `graphql:"issueUnarchive(id: $id)"`
 }
 
-func handleDeleteLinearIssue(client *graphqlBasic.Client, issueID string, updateFields *database.Task, task *database.Task) (bool, error) {
+func handleDeleteLinearIssue(ctx context.Context, client *graphqlBasic.Client, issueID string, updateFields *database.Task, task *database.Task) (bool, error) {
 	if updateFields.IsDeleted == nil {
 		return false, errors.New("cannot handle delete issue query without IsDeleted param set")
 	}
@@ -415,7 +423,7 @@ func handleDeleteLinearIssue(client *graphqlBasic.Client, issueID string, update
 		log.Debug().Msgf("sending request to Linear: %+v", request)
 		var query linearDeleteIssueQuery
 		logger := logging.GetSentryLogger()
-		if err := client.Run(context.Background(), request, &query); err != nil {
+		if err := client.Run(ctx, request, &query); err != nil {
 			logger.Error().Err(err).Msg("failed to delete linear issue")
 			return false, err
 		}
@@ -427,7 +435,7 @@ func handleDeleteLinearIssue(client *graphqlBasic.Client, issueID string, update
 		log.Debug().Msgf("sending request to Linear: %+v", request)
 		var query linearUndeleteIssueQuery
 		logger := logging.GetSentryLogger()
-		if err := client.Run(context.Background(), request, &query); err != nil {
+		if err := client.Run(ctx, request, &query); err != nil {
 			logger.Error().Err(err).Msg("failed to undelete linear issue")
 			return false, err
 		}
@@ -458,7 +466,7 @@ type linearCommentCreateQuery struct {
 	} `graphql:"commentCreate(input: {body: $body, issueId: $issueId, id: $id})"`
 }
 
-func handleMutateLinearIssue(client *graphqlBasic.Client, issueID string, updateFields *database.Task, task *database.Task) (bool, error) {
+func handleMutateLinearIssue(ctx context.Context, client *graphqlBasic.Client, issueID string, updateFields *database.Task, task *database.Task) (bool, error) {
 	updateIssueQueryStr := linearUpdateIssueQueryStr
 	if updateFields.Body != nil && *updateFields.Body == "" {
 		updateIssueQueryStr = linearUpdateIssueWithProsemirrorQueryStr
@@ -494,7 +502,7 @@ func handleMutateLinearIssue(client *graphqlBasic.Client, issueID string, update
 
 	log.Debug().Msgf("sending request to Linear: %+v", request)
 	var query linearUpdateIssueQuery
-	if err := client.Run(context.Background(), request, &query); err != nil {
+	if err := client.Run(ctx, request, &query); err != nil {
 		logger := logging.GetSentryLogger()
 		logger.Error().Err(err).Msg("failed to update linear issue")
 		return false, err
@@ -502,18 +510,18 @@ func handleMutateLinearIssue(client *graphqlBasic.Client, issueID string, update
 	return bool(query.IssueUpdate.Success), nil
 }
 
-func updateLinearIssue(client *graphqlBasic.Client, issueID string, updateFields *database.Task, task *database.Task) (bool, error) {
+func updateLinearIssue(ctx context.Context, client *graphqlBasic.Client, issueID string, updateFields *database.Task, task *database.Task) (bool, error) {
 	var success bool
 	var err error
 	if updateFields.IsDeleted != nil {
-		success, err = handleDeleteLinearIssue(client, issueID, updateFields, task)
+		success, err = handleDeleteLinearIssue(ctx, client, issueID, updateFields, task)
 	} else {
-		success, err = handleMutateLinearIssue(client, issueID, updateFields, task)
+		success, err = handleMutateLinearIssue(ctx, client, issueID, updateFields, task)
 	}
 	return success, err
 }
 
-func addLinearComment(client *graphqlBasic.Client, issueID string, comment database.Comment) error {
+func addLinearComment(ctx context.Context, client *graphqlBasic.Client, issueID string, comment database.Comment) error {
 	request := graphqlBasic.NewRequest(linearCommentCreateQueryStr)
 	request.Var("body", comment.Body)
 	request.Var("id", comment.ExternalID)
@@ -522,7 +530,7 @@ func addLinearComment(client *graphqlBasic.Client, issueID string, comment datab
 	log.Debug().Msgf("sending request to Linear: %+v", request)
 	var query linearCommentCreateQuery
 	logger := logging.GetSentryLogger()
-	if err := client.Run(context.Background(), request, &query); err != nil {
+	if err := client.Run(ctx, request, &query); err != nil {
 		logger.Error().Err(err).Msg("failed to create linear comment")
 		return err
 	}
@@ -534,9 +542,9 @@ func addLinearComment(client *graphqlBasic.Client, issueID string, comment datab
 	return nil
 }
 
-func getLinearUserInfoStruct(client *graphql.Client) (*linearUserInfoQuery, error) {
+func getLinearUserInfoStruct(ctx context.Context, client *graphql.Client) (*linearUserInfoQuery, error) {
 	var query linearUserInfoQuery
-	err := client.Query(context.Background(), &query, nil)
+	err := client.Query(ctx, &query, nil)
 	logger := logging.GetSentryLogger()
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to fetch user info")
@@ -545,26 +553,26 @@ func getLinearUserInfoStruct(client *graphql.Client) (*linearUserInfoQuery, erro
 	return &query, nil
 }
 
-func GetLinearUserInfoStructByID(client *graphqlBasic.Client, externalID string) (*LinearExternalUserInfoQuery, error) {
+func GetLinearUserInfoStructByID(ctx context.Context, client *graphqlBasic.Client, externalID string) (*LinearExternalUserInfoQuery, error) {
 	request := graphqlBasic.NewRequest(linearExternalUserInfoQueryString)
 	request.Var("id", externalID)
 
 	log.Debug().Msgf("sending request to Linear: %+v", request)
 	var query LinearExternalUserInfoQuery
 	logger := logging.GetSentryLogger()
-	if err := client.Run(context.Background(), request, &query); err != nil {
+	if err := client.Run(ctx, request, &query); err != nil {
 		logger.Error().Err(err).Msg("failed to fetch linear user info by ID")
 		return nil, err
 	}
 	return &query, nil
 }
 
-func getLinearAssignedIssues(client *graphql.Client, email graphql.String) (*linearAssignedIssuesQuery, error) {
+func getLinearAssignedIssues(ctx context.Context, client *graphql.Client, email graphql.String) (*linearAssignedIssuesQuery, error) {
 	variables := map[string]interface{}{
 		"email": email, // TODO: use ID instead of email to filter issues
 	}
 	var query linearAssignedIssuesQuery
-	err := client.Query(context.Background(), &query, variables)
+	err := client.Query(ctx, &query, variables)
 	logger := logging.GetSentryLogger()
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to fetch issues assigned to user")
@@ -574,9 +582,9 @@ func getLinearAssignedIssues(client *graphql.Client, email graphql.String) (*lin
 	return &query, nil
 }
 
-func GetLinearWorkflowStates(client *graphql.Client) (*linearWorkflowStatesQuery, error) {
+func GetLinearWorkflowStates(ctx context.Context, client *graphql.Client) (*linearWorkflowStatesQuery, error) {
 	var query linearWorkflowStatesQuery
-	err := client.Query(context.Background(), &query, nil)
+	err := client.Query(ctx, &query, nil)
 	logger := logging.GetSentryLogger()
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to fetch issues assigned to user")
@@ -20,19 +20,19 @@ type LinearTaskSource struct {
 	Linear LinearService
 }
 
-func (linearTask LinearTaskSource) GetEvents(db *mongo.Database, userID primitive.ObjectID, accountID string, startTime time.Time, endTime time.Time, scopes []string, result chan<- CalendarResult) {
+func (linearTask LinearTaskSource) GetEvents(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, startTime time.Time, endTime time.Time, scopes []string, result chan<- CalendarResult) {
 	result <- emptyCalendarResult(errors.New("linear task cannot fetch events"))
 }
 
-func (linearTask LinearTaskSource) GetTasks(db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- TaskResult) {
-	client, err := GetLinearClient(linearTask.Linear.Config.ConfigValues.UserInfoURL, db, userID, accountID)
+func (linearTask LinearTaskSource) GetTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- TaskResult) {
+	client, err := GetLinearClient(ctx, linearTask.Linear.Config.ConfigValues.UserInfoURL, db, userID, accountID)
 	logger := logging.GetSentryLogger()
 	if err != nil {
 		logger.Error().Err(err).Msg("unable to create linear client")
 		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_LINEAR)
 		return
 	}
-	meQuery, err := getLinearUserInfoStruct(client)
+	meQuery, err := getLinearUserInfoStruct(ctx, client)
 	if err != nil {
 		logger.Error().Err(err).Msg("unable to get linear user details")
 		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_LINEAR)
@@ -40,32 +40,32 @@ func (linearTask LinearTaskSource) GetTasks(db *mongo.Database, userID primitive
 	}
 	userChangeable := &database.UserChangeable{LinearName: string(meQuery.Viewer.Name), LinearDisplayName: string(meQuery.Viewer.DisplayName)}
 	database.GetUserCollection(db).FindOneAndUpdate(
-		context.Background(),
+		ctx,
 		bson.M{"_id": userID},
 		bson.M{"$set": userChangeable},
 		options.FindOneAndUpdate().SetReturnDocument(options.After),
 	)
 
-	client, err = GetLinearClient(linearTask.Linear.Config.ConfigValues.TaskFetchURL, db, userID, accountID)
+	client, err = GetLinearClient(ctx, linearTask.Linear.Config.ConfigValues.TaskFetchURL, db, userID, accountID)
 	if err != nil {
 		logger.Error().Err(err).Msg("unable to create linear client")
 		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_LINEAR)
 		return
 	}
-	issuesQuery, err := getLinearAssignedIssues(client, meQuery.Viewer.Email)
+	issuesQuery, err := getLinearAssignedIssues(ctx, client, meQuery.Viewer.Email)
 	if err != nil {
 		logger.Error().Err(err).Msg("unable to get linear issues assigned to user")
 		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_LINEAR)
 		return
 	}
 
-	client, err = GetLinearClient(linearTask.Linear.Config.ConfigValues.StatusFetchURL, db, userID, accountID)
+	client, err = GetLinearClient(ctx, linearTask.Linear.Config.ConfigValues.StatusFetchURL, db, userID, accountID)
 	if err != nil {
 		logger.Error().Err(err).Msg("unable to create linear client")
 		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_LINEAR)
 		return
 	}
-	statuses, err := GetLinearWorkflowStates(client)
+	statuses, err := GetLinearWorkflowStates(ctx, client)
 	if err != nil {
 		logger.Error().Err(err).Msg("unable to get linear workflow states")
 		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_LINEAR)
@@ -75,6 +75,7 @@ func (linearTask LinearTaskSource) GetTasks(db *mongo.Database, userID primitive
 
 	teamToCycles := getTeamToCyclesMap(issuesQuery)
 
+	defaultTaskSectionID := database.GetDefaultTaskSectionID(ctx, db, userID, TASK_SOURCE_ID_LINEAR)
 	var tasks []*database.Task
 	for _, linearIssue := range issuesQuery.Issues.Nodes {
 		createdAt, _ := time.Parse("2006-01-02T15:04:05.000Z", string(linearIssue.CreatedAt))
@@ -91,7 +92,7 @@ func (linearTask LinearTaskSource) GetTasks(db *mongo.Database, userID primitive
 		task := &database.Task{
 			UserID:             userID,
 			IDExternal:         linearIssue.Id.(string),
-			IDTaskSection:      constants.IDTaskSectionDefault,
+			IDTaskSection:      defaultTaskSectionID,
 			Deeplink:           string(linearIssue.Url),
 			SourceID:           TASK_SOURCE_ID_LINEAR,
 			Title:              &stringTitle,
@@ -145,6 +146,22 @@ func (linearTask LinearTaskSource) GetTasks(db *mongo.Database, userID primitive
 			}
 		}
 
+		if linearIssue.Project.Id != nil {
+			task.LinearProject = database.LinearProject{
+				ID:    linearIssue.Project.Id.(string),
+				Name:  string(linearIssue.Project.Name),
+				State: string(linearIssue.Project.State),
+			}
+			if linearIssue.Project.TargetDate != "" {
+				targetDate, _ := time.Parse(constants.YEAR_MONTH_DAY_FORMAT, string(linearIssue.Project.TargetDate))
+				task.LinearProject.TargetDate = primitive.NewDateTimeFromTime(targetDate)
+			}
+		}
+		if linearIssue.Estimate != nil {
+			estimate := float64(*linearIssue.Estimate)
+			task.LinearEstimate = &estimate
+		}
+
 		updateFields := database.Task{
 			Title:              task.Title,
 			Body:               task.Body,
@@ -154,6 +171,8 @@ func (linearTask LinearTaskSource) GetTasks(db *mongo.Database, userID primitive
 			IsCompleted:        task.IsCompleted,
 			PriorityNormalized: task.PriorityNormalized,
 			LinearCycle:        task.LinearCycle,
+			LinearProject:      task.LinearProject,
+			LinearEstimate:     task.LinearEstimate,
 		}
 
 		if linearIssue.DueDate != "" {
@@ -176,6 +195,7 @@ func (linearTask LinearTaskSource) GetTasks(db *mongo.Database, userID primitive
 		}
 
 		dbTask, err := database.UpdateOrCreateTask(
+			ctx,
 			db,
 			userID,
 			task.IDExternal,
@@ -201,18 +221,18 @@ func (linearTask LinearTaskSource) GetTasks(db *mongo.Database, userID primitive
 	result <- TaskResult{Tasks: tasks, ServiceID: TASK_SERVICE_ID_LINEAR, AccountID: accountID}
 }
 
-func (linearTask LinearTaskSource) GetPullRequests(db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- PullRequestResult) {
+func (linearTask LinearTaskSource) GetPullRequests(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- PullRequestResult) {
 	result <- emptyPullRequestResult(nil, false)
 }
 
-func (linearTask LinearTaskSource) ModifyTask(db *mongo.Database, userID primitive.ObjectID, accountID string, issueID string, updateFields *database.Task, task *database.Task) error {
-	client, err := GetBasicLinearClient(linearTask.Linear.Config.ConfigValues.TaskUpdateURL, db, userID, accountID)
+func (linearTask LinearTaskSource) ModifyTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, issueID string, updateFields *database.Task, task *database.Task) error {
+	client, err := GetBasicLinearClient(ctx, linearTask.Linear.Config.ConfigValues.TaskUpdateURL, db, userID, accountID)
 	logger := logging.GetSentryLogger()
 	if err != nil {
 		logger.Error().Err(err).Msg("unable to create linear client")
 		return err
 	}
-	success, err := updateLinearIssue(client, issueID, updateFields, task)
+	success, err := updateLinearIssue(ctx, client, issueID, updateFields, task)
 	if err != nil {
 		logger.Error().Err(err).Msg("unable to update linear issue")
 		return err
@@ -224,30 +244,30 @@ func (linearTask LinearTaskSource) ModifyTask(db *mongo.Database, userID primiti
 	return nil
 }
 
-func (linearTask LinearTaskSource) CreateNewTask(db *mongo.Database, userID primitive.ObjectID, accountID string, task TaskCreationObject) (primitive.ObjectID, error) {
+func (linearTask LinearTaskSource) CreateNewTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, task TaskCreationObject) (primitive.ObjectID, error) {
 	return primitive.NilObjectID, errors.New("has not been implemented yet")
 }
 
-func (linearTask LinearTaskSource) CreateNewEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error {
+func (linearTask LinearTaskSource) CreateNewEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error {
 	return errors.New("has not been implemented yet")
 }
 
-func (linearTask LinearTaskSource) DeleteEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error {
+func (linearTask LinearTaskSource) DeleteEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error {
 	return errors.New("has not been implemented yet")
 }
 
-func (linearTask LinearTaskSource) ModifyEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, eventID string, updateFields *EventModifyObject) error {
+func (linearTask LinearTaskSource) ModifyEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, eventID string, updateFields *EventModifyObject) error {
 	return errors.New("has not been implemented yet")
 }
 
-func (linearTask LinearTaskSource) AddComment(db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error {
-	client, err := GetBasicLinearClient(linearTask.Linear.Config.ConfigValues.TaskUpdateURL, db, userID, accountID)
+func (linearTask LinearTaskSource) AddComment(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error {
+	client, err := GetBasicLinearClient(ctx, linearTask.Linear.Config.ConfigValues.TaskUpdateURL, db, userID, accountID)
 	logger := logging.GetSentryLogger()
 	if err != nil {
 		logger.Error().Err(err).Msg("unable to create linear client")
 		return err
 	}
-	err = addLinearComment(client, task.IDExternal, comment)
+	err = addLinearComment(ctx, client, task.IDExternal, comment)
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to create linear comment")
 		return err
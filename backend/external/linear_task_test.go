@@ -125,7 +125,7 @@ func TestLoadLinearTasks(t *testing.T) {
 		}}
 
 		var taskResult = make(chan TaskResult)
-		go linearTask.GetTasks(db, userID, "sample_account@email.com", taskResult)
+		go linearTask.GetTasks(context.Background(), db, userID, "sample_account@email.com", taskResult)
 		result := <-taskResult
 		assert.NotEqual(t, nil, result.Error)
 		assert.Equal(t, `non-200 OK status code: 400 Bad Request body: ""`, result.Error.Error())
@@ -143,7 +143,7 @@ func TestLoadLinearTasks(t *testing.T) {
 		}}
 
 		var taskResult = make(chan TaskResult)
-		go linearTask.GetTasks(db, userID, "sample_account@email.com", taskResult)
+		go linearTask.GetTasks(context.Background(), db, userID, "sample_account@email.com", taskResult)
 		result := <-taskResult
 		assert.NotEqual(t, nil, result.Error)
 		assert.Equal(t, "invalid character 'o' looking for beginning of value", result.Error.Error())
@@ -162,7 +162,7 @@ func TestLoadLinearTasks(t *testing.T) {
 		}}
 
 		var taskResult = make(chan TaskResult)
-		go linearTask.GetTasks(db, userID, "sample_account@email.com", taskResult)
+		go linearTask.GetTasks(context.Background(), db, userID, "sample_account@email.com", taskResult)
 		result := <-taskResult
 		assert.NotEqual(t, nil, result.Error)
 		assert.Equal(t, `non-200 OK status code: 409 Conflict body: ""`, result.Error.Error())
@@ -181,7 +181,7 @@ func TestLoadLinearTasks(t *testing.T) {
 		}}
 
 		var taskResult = make(chan TaskResult)
-		go linearTask.GetTasks(db, userID, "sample_account@email.com", taskResult)
+		go linearTask.GetTasks(context.Background(), db, userID, "sample_account@email.com", taskResult)
 		result := <-taskResult
 		assert.NotEqual(t, nil, result.Error)
 		assert.Equal(t, "invalid character 'o' in literal true (expecting 'r')", result.Error.Error())
@@ -213,7 +213,7 @@ func TestLoadLinearTasks(t *testing.T) {
 		}}
 
 		var taskResult = make(chan TaskResult)
-		go linearTask.GetTasks(db, userID, "sample_account@email.com", taskResult)
+		go linearTask.GetTasks(context.Background(), db, userID, "sample_account@email.com", taskResult)
 		result := <-taskResult
 
 		assert.Error(t, result.Error)
@@ -293,7 +293,7 @@ func TestLoadLinearTasks(t *testing.T) {
 		}
 
 		var taskResult = make(chan TaskResult)
-		go linearTask.GetTasks(db, userID, "sample_account@email.com", taskResult)
+		go linearTask.GetTasks(context.Background(), db, userID, "sample_account@email.com", taskResult)
 		result := <-taskResult
 		assert.NoError(t, result.Error)
 		assert.Equal(t, 1, len(result.Tasks))
@@ -339,7 +339,7 @@ func TestLoadLinearTasks(t *testing.T) {
 
 		newUserID := primitive.NewObjectID()
 		var taskResult = make(chan TaskResult)
-		go linearTask.GetTasks(db, newUserID, "sample_account@email.com", taskResult)
+		go linearTask.GetTasks(context.Background(), db, newUserID, "sample_account@email.com", taskResult)
 		result := <-taskResult
 		assert.NoError(t, result.Error)
 		var userObject database.User
@@ -401,6 +401,7 @@ func TestLoadLinearTasks(t *testing.T) {
 		}
 		// need to update, because the previous test case has already created this task
 		database.UpdateOrCreateTask(
+			context.Background(),
 			db,
 			userID,
 			"test-issue-id-1",
@@ -429,7 +430,7 @@ func TestLoadLinearTasks(t *testing.T) {
 		}
 
 		var taskResult = make(chan TaskResult)
-		go linearTask.GetTasks(db, userID, "sample_account@email.com", taskResult)
+		go linearTask.GetTasks(context.Background(), db, userID, "sample_account@email.com", taskResult)
 		result := <-taskResult
 		assert.NoError(t, result.Error)
 		assert.Equal(t, 1, len(result.Tasks))
@@ -600,7 +601,7 @@ func TestLoadLinearTaskCycles(t *testing.T) {
 			},
 		}}
 		var taskResult = make(chan TaskResult)
-		go linearTaskSource.GetTasks(db, userID, "sample_account@email.com", taskResult)
+		go linearTaskSource.GetTasks(context.Background(), db, userID, "sample_account@email.com", taskResult)
 		result := <-taskResult
 		assert.NoError(t, result.Error)
 		assert.Equal(t, 1, len(result.Tasks))
@@ -732,6 +733,31 @@ func TestLoadLinearTaskCycles(t *testing.T) {
 		}
 		assertLinearCyclesEqual(t, expectedCycle, task.LinearCycle)
 	})
+	t.Run("NoProjectOrEstimate", func(t *testing.T) {
+		task := getLinearTaskWithCycles("null")
+		assert.Equal(t, database.LinearProject{}, task.LinearProject)
+		assert.Nil(t, task.LinearEstimate)
+	})
+	t.Run("ProjectAndEstimate", func(t *testing.T) {
+		// the cycle is the last field of the issue, so the project and estimate can be appended to it
+		task := getLinearTaskWithCycles(`null,
+			"project": {
+				"id": "project-id",
+				"name": "project name",
+				"state": "started",
+				"targetDate": "2023-04-01"
+			},
+			"estimate": 0`)
+		targetDate, _ := time.Parse(constants.YEAR_MONTH_DAY_FORMAT, "2023-04-01")
+		assert.Equal(t, database.LinearProject{
+			ID:         "project-id",
+			Name:       "project name",
+			State:      "started",
+			TargetDate: primitive.NewDateTimeFromTime(targetDate),
+		}, task.LinearProject)
+		assert.NotNil(t, task.LinearEstimate)
+		assert.Equal(t, float64(0), *task.LinearEstimate)
+	})
 }
 
 func TestModifyLinearTask(t *testing.T) {
@@ -779,6 +805,7 @@ func TestModifyLinearTask(t *testing.T) {
 		Comments: nil,
 	}
 	database.GetOrCreateTask(
+		context.Background(),
 		db,
 		userID,
 		"test-issue-id-1",
@@ -798,7 +825,7 @@ func TestModifyLinearTask(t *testing.T) {
 		}}
 
 		isCompleted := true
-		err := linearTask.ModifyTask(db, userID, "sample_account@email.com", "6942069420", &database.Task{IsCompleted: &isCompleted}, &database.Task{})
+		err := linearTask.ModifyTask(context.Background(), db, userID, "sample_account@email.com", "6942069420", &database.Task{IsCompleted: &isCompleted}, &database.Task{})
 		assert.NotEqual(t, nil, err)
 		assert.Equal(t, `decoding response: EOF`, err.Error())
 	})
@@ -819,7 +846,7 @@ func TestModifyLinearTask(t *testing.T) {
 		assert.NoError(t, err)
 		dueDatePrimitive := primitive.NewDateTimeFromTime(newDueDate)
 		priority := 3.0
-		err = linearTask.ModifyTask(db, userID, "sample_account@email.com", "6942069420", &database.Task{
+		err = linearTask.ModifyTask(context.Background(), db, userID, "sample_account@email.com", "6942069420", &database.Task{
 			Title:              &newName,
 			Body:               &newBody,
 			DueDate:            &dueDatePrimitive,
@@ -842,7 +869,7 @@ func TestModifyLinearTask(t *testing.T) {
 		newBody := "New Body"
 		isCompleted := true
 
-		err := linearTask.ModifyTask(db, userID, "sample_account@email.com", "6942069420", &database.Task{
+		err := linearTask.ModifyTask(context.Background(), db, userID, "sample_account@email.com", "6942069420", &database.Task{
 			Title:       &newName,
 			Body:        &newBody,
 			IsCompleted: &isCompleted,
@@ -864,7 +891,7 @@ func TestModifyLinearTask(t *testing.T) {
 		newName := "New Title"
 		newBody := "New Body"
 		dueDate := primitive.NewDateTimeFromTime(time.Time{})
-		err := linearTask.ModifyTask(db, userID, "sample_account@email.com", "6942069420", &database.Task{
+		err := linearTask.ModifyTask(context.Background(), db, userID, "sample_account@email.com", "6942069420", &database.Task{
 			Title:   &newName,
 			Body:    &newBody,
 			DueDate: &dueDate,
@@ -886,7 +913,7 @@ func TestModifyLinearTask(t *testing.T) {
 		newBody := ""
 		dueDate := primitive.NewDateTimeFromTime(time.Time{})
 
-		err := linearTask.ModifyTask(db, userID, "sample_account@email.com", "6942069420", &database.Task{
+		err := linearTask.ModifyTask(context.Background(), db, userID, "sample_account@email.com", "6942069420", &database.Task{
 			Title:   &newName,
 			Body:    &newBody,
 			DueDate: &dueDate,
@@ -908,7 +935,7 @@ func TestModifyLinearTask(t *testing.T) {
 		newBody := "New Body"
 		dueDate := primitive.NewDateTimeFromTime(time.Time{})
 
-		err := linearTask.ModifyTask(db, userID, "sample_account@email.com", "6942069420", &database.Task{
+		err := linearTask.ModifyTask(context.Background(), db, userID, "sample_account@email.com", "6942069420", &database.Task{
 			Title:   &newName,
 			Body:    &newBody,
 			DueDate: &dueDate,
@@ -930,7 +957,7 @@ func TestModifyLinearTask(t *testing.T) {
 		newBody := "New Body"
 		dueDate := primitive.NewDateTimeFromTime(time.Time{})
 
-		err := linearTask.ModifyTask(db, userID, "sample_account@email.com", "6942069420", &database.Task{
+		err := linearTask.ModifyTask(context.Background(), db, userID, "sample_account@email.com", "6942069420", &database.Task{
 			Title:   &newName,
 			Body:    &newBody,
 			DueDate: &dueDate,
@@ -964,7 +991,7 @@ func TestModifyLinearTask(t *testing.T) {
 		isCompleted := false
 		dueDate := primitive.NewDateTimeFromTime(time.Unix(0, 0))
 
-		err := linearTask.ModifyTask(db, userID, "sample_account@email.com", "6942069420", &database.Task{
+		err := linearTask.ModifyTask(context.Background(), db, userID, "sample_account@email.com", "6942069420", &database.Task{
 			Title:       &newName,
 			Body:        &newBody,
 			DueDate:     &dueDate,
@@ -994,7 +1021,7 @@ func TestModifyLinearTask(t *testing.T) {
 			context.Background(),
 			bson.M{"user_id": userID},
 		).Decode(&taskFromDB)
-		err := linearTask.ModifyTask(db, userID, "sample_account@email.com", "6942069420", &database.Task{
+		err := linearTask.ModifyTask(context.Background(), db, userID, "sample_account@email.com", "6942069420", &database.Task{
 			Title:       &newName,
 			Body:        &newBody,
 			DueDate:     &dueDate,
@@ -1009,7 +1036,7 @@ func TestModifyLinearTask(t *testing.T) {
 		var linearTask LinearTaskSource
 		linearTask.Linear.Config.ConfigValues.TaskUpdateURL = &taskUpdateServer.URL
 
-		err = linearTask.ModifyTask(db, userID, "sample_account@email.com", "6942069420", &database.Task{
+		err = linearTask.ModifyTask(context.Background(), db, userID, "sample_account@email.com", "6942069420", &database.Task{
 			IsDeleted: &_true,
 		}, nil)
 		assert.NoError(t, err)
@@ -1025,7 +1052,7 @@ func TestModifyLinearTask(t *testing.T) {
 			},
 		}}
 
-		err = linearTask.ModifyTask(db, userID, "sample_account@email.com", "6942069420", &database.Task{
+		err = linearTask.ModifyTask(context.Background(), db, userID, "sample_account@email.com", "6942069420", &database.Task{
 			IsDeleted: &_true,
 		}, nil)
 		assert.Error(t, err)
@@ -1037,7 +1064,7 @@ func TestModifyLinearTask(t *testing.T) {
 		var linearTask LinearTaskSource
 		linearTask.Linear.Config.ConfigValues.TaskUpdateURL = &taskUpdateServer.URL
 
-		err = linearTask.ModifyTask(db, userID, "sample_account@email.com", "6942069420", &database.Task{
+		err = linearTask.ModifyTask(context.Background(), db, userID, "sample_account@email.com", "6942069420", &database.Task{
 			IsDeleted: &_false,
 		}, nil)
 		assert.NoError(t, err)
@@ -1053,7 +1080,7 @@ func TestModifyLinearTask(t *testing.T) {
 			},
 		}}
 
-		err = linearTask.ModifyTask(db, userID, "sample_account@email.com", "6942069420", &database.Task{
+		err = linearTask.ModifyTask(context.Background(), db, userID, "sample_account@email.com", "6942069420", &database.Task{
 			IsDeleted: &_false,
 		}, nil)
 		assert.Error(t, err)
@@ -1082,7 +1109,7 @@ func TestAddComment(t *testing.T) {
 			Body: "example comment",
 		}
 
-		err := linearTask.AddComment(db, userID, "sample_account@email.com", comment, &database.Task{
+		err := linearTask.AddComment(context.Background(), db, userID, "sample_account@email.com", comment, &database.Task{
 			IDExternal: "24242424",
 		})
 		assert.EqualErrorf(t, err, err.Error(), "failed to create linear comment")
@@ -1101,7 +1128,7 @@ func TestAddComment(t *testing.T) {
 			Body: "example comment",
 		}
 
-		err := linearTask.AddComment(db, userID, "sample_account@email.com", comment, &database.Task{
+		err := linearTask.AddComment(context.Background(), db, userID, "sample_account@email.com", comment, &database.Task{
 			IDExternal: "24242424",
 		})
 		assert.EqualErrorf(t, err, err.Error(), "failed to create linear comment")
@@ -1121,7 +1148,7 @@ func TestAddComment(t *testing.T) {
 			ExternalID: "externalID",
 		}
 
-		err := linearTask.AddComment(db, userID, "sample_account@email.com", comment, &database.Task{
+		err := linearTask.AddComment(context.Background(), db, userID, "sample_account@email.com", comment, &database.Task{
 			IDExternal: "24242424",
 		})
 		assert.NoError(t, err)
@@ -0,0 +1,120 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	LOGIN_PROVIDER_ID_GITHUB    = "github"
+	LOGIN_PROVIDER_ID_MICROSOFT = "microsoft"
+)
+
+// ErrLoginEmailNotVerified is returned when someone signs in with an account whose email the provider
+// hasn't verified. Merging it into an existing user would let anyone claim their account, and creating
+// a user with it would let anyone claim the email before its owner signs up
+var ErrLoginEmailNotVerified = errors.New("login provider has not verified the email")
+
+// LoginUserInfo is the account the user signed in with
+type LoginUserInfo struct {
+	AccountID     string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// LoginProvider signs users in with an account from somewhere other than Google. Unlike a TaskService,
+// nothing is fetched with the account, so no token is kept once the user has signed in
+type LoginProvider interface {
+	GetLoginURL(stateTokenID primitive.ObjectID) string
+	GetLoginUserInfo(ctx context.Context, code string) (*LoginUserInfo, error)
+}
+
+// the user field each provider's account ID is kept in, like google_id is for Google
+var loginProviderUserFields = map[string]string{
+	LOGIN_PROVIDER_ID_GITHUB:    "github_id",
+	LOGIN_PROVIDER_ID_MICROSOFT: "microsoft_id",
+}
+
+func (config Config) GetLoginProvider(providerID string) (LoginProvider, error) {
+	switch providerID {
+	case LOGIN_PROVIDER_ID_GITHUB:
+		return GithubLoginService{OauthConfig: config.GithubLoginConfig, OverrideURL: config.GithubLoginOverrideURL}, nil
+	case LOGIN_PROVIDER_ID_MICROSOFT:
+		return MicrosoftLoginService{OauthConfig: config.MicrosoftLoginConfig}, nil
+	}
+	return nil, fmt.Errorf("login provider %s not found", providerID)
+}
+
+// GetOrCreateLoginUser returns the user who signed in with the provider's account, along with whether
+// they're new. The first time an account is used, it's merged into the existing user with the same
+// email, so the user has one account however they sign in. Either way the provider must have verified it.
+// authorizeNewUser is checked before creating a user, like CallbackParams.AuthorizeNewUser
+func GetOrCreateLoginUser(ctx context.Context, db *mongo.Database, providerID string, userInfo LoginUserInfo, authorizeNewUser func(email string) error) (primitive.ObjectID, bool, error) {
+	userField, exists := loginProviderUserFields[providerID]
+	if !exists {
+		return primitive.NilObjectID, false, fmt.Errorf("login provider %s not found", providerID)
+	}
+	if userInfo.AccountID == "" || userInfo.Email == "" {
+		return primitive.NilObjectID, false, errors.New("login provider did not return an account ID and email")
+	}
+	userCollection := database.GetUserCollection(db)
+	var user database.User
+	err := userCollection.FindOne(ctx, bson.M{userField: userInfo.AccountID}).Decode(&user)
+	if err == nil {
+		return user.ID, false, nil
+	} else if err != mongo.ErrNoDocuments {
+		return primitive.NilObjectID, false, err
+	}
+
+	if !userInfo.EmailVerified {
+		return primitive.NilObjectID, false, ErrLoginEmailNotVerified
+	}
+	email := strings.ToLower(userInfo.Email)
+	existingUser, err := database.GetUserByEmail(ctx, db, email)
+	if err == nil {
+		_, err = userCollection.UpdateOne(ctx, bson.M{"_id": existingUser.ID}, bson.M{"$set": bson.M{userField: userInfo.AccountID, "email_verified": true}})
+		if err != nil {
+			return primitive.NilObjectID, false, err
+		}
+		return existingUser.ID, false, nil
+	} else if err != mongo.ErrNoDocuments {
+		return primitive.NilObjectID, false, err
+	}
+
+	if authorizeNewUser != nil {
+		err = authorizeNewUser(email)
+		if err != nil {
+			return primitive.NilObjectID, false, err
+		}
+	}
+	// upserting on the account ID means two logins at once can't both create a user
+	result, err := userCollection.UpdateOne(
+		ctx,
+		bson.M{userField: userInfo.AccountID},
+		bson.M{"$setOnInsert": bson.M{
+			"email":          email,
+			"email_verified": true,
+			"name":           userInfo.Name,
+			"created_at":     primitive.NewDateTimeFromTime(time.Now().UTC()),
+		}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return primitive.NilObjectID, false, err
+	}
+	if result.UpsertedCount == 0 {
+		err = userCollection.FindOne(ctx, bson.M{userField: userInfo.AccountID}).Decode(&user)
+		return user.ID, false, err
+	}
+	return result.UpsertedID.(primitive.ObjectID), true, nil
+}
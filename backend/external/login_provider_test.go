@@ -0,0 +1,174 @@
+package external
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/oauth2"
+)
+
+func getLoginProviderServer(t *testing.T, tokenResponse string, routes map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/token" {
+			_, err := w.Write([]byte(tokenResponse))
+			assert.NoError(t, err)
+			return
+		}
+		body, exists := routes[r.URL.Path]
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write([]byte(body))
+		assert.NoError(t, err)
+	}))
+}
+
+func getTestLoginOauthConfig(server *httptest.Server) *OauthConfig {
+	return &OauthConfig{Config: &oauth2.Config{Endpoint: oauth2.Endpoint{TokenURL: server.URL + "/token", AuthStyle: oauth2.AuthStyleInParams}}}
+}
+
+func TestGithubLoginUserInfo(t *testing.T) {
+	tokenResponse := `{"access_token": "example-token", "token_type": "bearer"}`
+	t.Run("Success", func(t *testing.T) {
+		server := getLoginProviderServer(t, tokenResponse, map[string]string{
+			"/user":        `{"id": 1234, "login": "octocat", "name": "The Octocat"}`,
+			"/user/emails": `[{"email": "public@example.com", "primary": false, "verified": true}, {"email": "Octocat@example.com", "primary": true, "verified": true}]`,
+		})
+		defer server.Close()
+		githubLogin := GithubLoginService{OauthConfig: getTestLoginOauthConfig(server), OverrideURL: server.URL}
+		userInfo, err := githubLogin.GetLoginUserInfo(context.Background(), "code")
+		assert.NoError(t, err)
+		assert.Equal(t, &LoginUserInfo{AccountID: "1234", Email: "Octocat@example.com", EmailVerified: true, Name: "The Octocat"}, userInfo)
+	})
+	t.Run("UnverifiedEmail", func(t *testing.T) {
+		server := getLoginProviderServer(t, tokenResponse, map[string]string{
+			"/user":        `{"id": 1234, "login": "octocat"}`,
+			"/user/emails": `[{"email": "octocat@example.com", "primary": true, "verified": false}]`,
+		})
+		defer server.Close()
+		githubLogin := GithubLoginService{OauthConfig: getTestLoginOauthConfig(server), OverrideURL: server.URL}
+		userInfo, err := githubLogin.GetLoginUserInfo(context.Background(), "code")
+		assert.NoError(t, err)
+		assert.False(t, userInfo.EmailVerified)
+	})
+	t.Run("NoPrimaryEmail", func(t *testing.T) {
+		server := getLoginProviderServer(t, tokenResponse, map[string]string{
+			"/user":        `{"id": 1234, "login": "octocat"}`,
+			"/user/emails": `[]`,
+		})
+		defer server.Close()
+		githubLogin := GithubLoginService{OauthConfig: getTestLoginOauthConfig(server), OverrideURL: server.URL}
+		_, err := githubLogin.GetLoginUserInfo(context.Background(), "code")
+		assert.EqualError(t, err, "github user has no primary email")
+	})
+}
+
+func TestMicrosoftLoginUserInfo(t *testing.T) {
+	getIDToken := func(claims string) string {
+		return "header." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".signature"
+	}
+	t.Run("PersonalAccount", func(t *testing.T) {
+		idToken := getIDToken(`{"sub": "abc", "tid": "` + MICROSOFT_PERSONAL_ACCOUNT_TENANT_ID + `", "email": "user@outlook.com", "name": "User"}`)
+		server := getLoginProviderServer(t, `{"access_token": "example-token", "token_type": "bearer", "id_token": "`+idToken+`"}`, nil)
+		defer server.Close()
+		userInfo, err := MicrosoftLoginService{OauthConfig: getTestLoginOauthConfig(server)}.GetLoginUserInfo(context.Background(), "code")
+		assert.NoError(t, err)
+		assert.Equal(t, &LoginUserInfo{AccountID: "abc", Email: "user@outlook.com", EmailVerified: true, Name: "User"}, userInfo)
+	})
+	t.Run("WorkAccount", func(t *testing.T) {
+		idToken := getIDToken(`{"sub": "abc", "tid": "some-tenant", "email": "user@company.com"}`)
+		server := getLoginProviderServer(t, `{"access_token": "example-token", "token_type": "bearer", "id_token": "`+idToken+`"}`, nil)
+		defer server.Close()
+		userInfo, err := MicrosoftLoginService{OauthConfig: getTestLoginOauthConfig(server)}.GetLoginUserInfo(context.Background(), "code")
+		assert.NoError(t, err)
+		assert.False(t, userInfo.EmailVerified)
+	})
+	t.Run("WorkAccountVerifiedDomain", func(t *testing.T) {
+		idToken := getIDToken(`{"sub": "abc", "tid": "some-tenant", "email": "user@company.com", "xms_edov": true}`)
+		server := getLoginProviderServer(t, `{"access_token": "example-token", "token_type": "bearer", "id_token": "`+idToken+`"}`, nil)
+		defer server.Close()
+		userInfo, err := MicrosoftLoginService{OauthConfig: getTestLoginOauthConfig(server)}.GetLoginUserInfo(context.Background(), "code")
+		assert.NoError(t, err)
+		assert.True(t, userInfo.EmailVerified)
+	})
+	t.Run("MissingIDToken", func(t *testing.T) {
+		server := getLoginProviderServer(t, `{"access_token": "example-token", "token_type": "bearer"}`, nil)
+		defer server.Close()
+		_, err := MicrosoftLoginService{OauthConfig: getTestLoginOauthConfig(server)}.GetLoginUserInfo(context.Background(), "code")
+		assert.EqualError(t, err, "microsoft token response has no id token")
+	})
+	t.Run("MalformedIDToken", func(t *testing.T) {
+		_, err := parseMicrosoftIDTokenClaims("oops")
+		assert.EqualError(t, err, "malformed microsoft id token")
+	})
+}
+
+func TestGetOrCreateLoginUser(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	githubInfo := LoginUserInfo{AccountID: primitive.NewObjectID().Hex(), Email: "Login_Provider@resonant-kelpie-404a42.netlify.app", EmailVerified: true, Name: "Login Provider"}
+
+	t.Run("UnknownProvider", func(t *testing.T) {
+		_, _, err := GetOrCreateLoginUser(context.Background(), db, "myspace", githubInfo, nil)
+		assert.EqualError(t, err, "login provider myspace not found")
+	})
+	t.Run("NotAuthorized", func(t *testing.T) {
+		notAuthorized := errors.New("not authorized")
+		_, _, err := GetOrCreateLoginUser(context.Background(), db, LOGIN_PROVIDER_ID_GITHUB, githubInfo, func(email string) error {
+			assert.Equal(t, "login_provider@resonant-kelpie-404a42.netlify.app", email)
+			return notAuthorized
+		})
+		assert.Equal(t, notAuthorized, err)
+	})
+	t.Run("UnverifiedEmailOfNewUser", func(t *testing.T) {
+		unverifiedInfo := LoginUserInfo{AccountID: primitive.NewObjectID().Hex(), Email: "unverified_login_provider@resonant-kelpie-404a42.netlify.app"}
+		_, _, err := GetOrCreateLoginUser(context.Background(), db, LOGIN_PROVIDER_ID_GITHUB, unverifiedInfo, nil)
+		assert.Equal(t, ErrLoginEmailNotVerified, err)
+		_, err = database.GetUserByEmail(context.Background(), db, unverifiedInfo.Email)
+		assert.Equal(t, mongo.ErrNoDocuments, err)
+	})
+	var userID primitive.ObjectID
+	t.Run("NewUser", func(t *testing.T) {
+		userID, userIsNew, err := GetOrCreateLoginUser(context.Background(), db, LOGIN_PROVIDER_ID_GITHUB, githubInfo, nil)
+		assert.NoError(t, err)
+		assert.True(t, userIsNew)
+		user, err := database.GetUser(context.Background(), db, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, githubInfo.AccountID, user.GithubID)
+		assert.Equal(t, "login_provider@resonant-kelpie-404a42.netlify.app", user.Email)
+		assert.True(t, user.EmailVerified)
+	})
+	t.Run("ExistingUser", func(t *testing.T) {
+		existingUserID, userIsNew, err := GetOrCreateLoginUser(context.Background(), db, LOGIN_PROVIDER_ID_GITHUB, githubInfo, nil)
+		assert.NoError(t, err)
+		assert.False(t, userIsNew)
+		userID = existingUserID
+	})
+	t.Run("UnverifiedEmailOfExistingUser", func(t *testing.T) {
+		microsoftInfo := LoginUserInfo{AccountID: primitive.NewObjectID().Hex(), Email: githubInfo.Email}
+		_, _, err := GetOrCreateLoginUser(context.Background(), db, LOGIN_PROVIDER_ID_MICROSOFT, microsoftInfo, nil)
+		assert.Equal(t, ErrLoginEmailNotVerified, err)
+	})
+	t.Run("MergesSecondProvider", func(t *testing.T) {
+		microsoftInfo := LoginUserInfo{AccountID: primitive.NewObjectID().Hex(), Email: githubInfo.Email, EmailVerified: true}
+		mergedUserID, userIsNew, err := GetOrCreateLoginUser(context.Background(), db, LOGIN_PROVIDER_ID_MICROSOFT, microsoftInfo, nil)
+		assert.NoError(t, err)
+		assert.False(t, userIsNew)
+		assert.Equal(t, userID, mergedUserID)
+		user, err := database.GetUser(context.Background(), db, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, microsoftInfo.AccountID, user.MicrosoftID)
+		assert.Equal(t, githubInfo.AccountID, user.GithubID)
+	})
+}
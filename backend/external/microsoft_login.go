@@ -0,0 +1,88 @@
+package external
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/franchizzle/task-manager/backend/config"
+	"github.com/franchizzle/task-manager/backend/constants"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/microsoft"
+)
+
+// personal Microsoft accounts all belong to this tenant, and their emails are verified by Microsoft
+const MICROSOFT_PERSONAL_ACCOUNT_TENANT_ID = "9188040d-6c67-4c5b-b112-36a304b66dad"
+
+// MicrosoftLoginService signs users in with a personal, work or school Microsoft account
+type MicrosoftLoginService struct {
+	OauthConfig OauthConfigWrapper
+}
+
+type MicrosoftIDTokenClaims struct {
+	Subject  string `json:"sub"`
+	TenantID string `json:"tid"`
+	Email    string `json:"email"`
+	Name     string `json:"name"`
+	// set when the tenant's admin has verified they own the email's domain
+	EmailDomainOwnerVerified bool `json:"xms_edov"`
+}
+
+func getMicrosoftLoginConfig() *OauthConfig {
+	return &OauthConfig{Config: &oauth2.Config{
+		ClientID:     config.GetSettings().MicrosoftOauth.ClientID,
+		ClientSecret: config.GetSettings().MicrosoftOauth.ClientSecret,
+		RedirectURL:  config.GetSettings().ServerURL + "login/providers/microsoft/callback/",
+		Scopes:       []string{"openid", "email", "profile"},
+		Endpoint:     microsoft.AzureADEndpoint("common"),
+	}}
+}
+
+func (microsoftLogin MicrosoftLoginService) GetLoginURL(stateTokenID primitive.ObjectID) string {
+	return microsoftLogin.OauthConfig.AuthCodeURL(stateTokenID.Hex())
+}
+
+func (microsoftLogin MicrosoftLoginService) GetLoginUserInfo(ctx context.Context, code string) (*LoginUserInfo, error) {
+	extCtx, cancel := context.WithTimeout(ctx, constants.ExternalTimeout)
+	defer cancel()
+	token, err := microsoftLogin.OauthConfig.Exchange(extCtx, code)
+	if err != nil {
+		return nil, err
+	}
+	idToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, errors.New("microsoft token response has no id token")
+	}
+	claims, err := parseMicrosoftIDTokenClaims(idToken)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginUserInfo{
+		AccountID:     claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.TenantID == MICROSOFT_PERSONAL_ACCOUNT_TENANT_ID || claims.EmailDomainOwnerVerified,
+		Name:          claims.Name,
+	}, nil
+}
+
+// parseMicrosoftIDTokenClaims reads the claims without checking the token's signature, which is only
+// safe because the token came straight from Microsoft's token endpoint over TLS
+func parseMicrosoftIDTokenClaims(idToken string) (*MicrosoftIDTokenClaims, error) {
+	segments := strings.Split(idToken, ".")
+	if len(segments) != 3 {
+		return nil, errors.New("malformed microsoft id token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(segments[1])
+	if err != nil {
+		return nil, err
+	}
+	var claims MicrosoftIDTokenClaims
+	err = json.Unmarshal(payload, &claims)
+	if err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
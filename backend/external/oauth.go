@@ -33,6 +33,10 @@ func (c *OauthConfig) Client(ctx context.Context, t *oauth2.Token) HTTPClient {
 	return c.Config.Client(ctx, t)
 }
 
+func (c *OauthConfig) TokenSource(ctx context.Context, t *oauth2.Token) oauth2.TokenSource {
+	return c.Config.TokenSource(ctx, t)
+}
+
 // OauthConfigWrapper is the interface for interacting with the oauth2 config
 type OauthConfigWrapper interface {
 	AuthCodeURL(state string, opts ...oauth2.AuthCodeOption) string
@@ -40,9 +44,13 @@ type OauthConfigWrapper interface {
 	Exchange(ctx context.Context, code string, opts ...oauth2.AuthCodeOption) (*oauth2.Token, error)
 }
 
-func getExternalOauth2Client(db *mongo.Database, userID primitive.ObjectID, accountID string, serviceID string, oauthConfig OauthConfigWrapper) *http.Client {
-	parentCtx := context.Background()
-	externalToken, err := getExternalToken(db, userID, accountID, serviceID)
+// OauthTokenRefresher is implemented by oauth configs that can refresh tokens ahead of them being used
+type OauthTokenRefresher interface {
+	TokenSource(ctx context.Context, t *oauth2.Token) oauth2.TokenSource
+}
+
+func getExternalOauth2Client(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, serviceID string, oauthConfig OauthConfigWrapper) *http.Client {
+	externalToken, err := getExternalToken(ctx, db, userID, accountID, serviceID)
 	if err != nil {
 		return nil
 	}
@@ -51,14 +59,14 @@ func getExternalOauth2Client(db *mongo.Database, userID primitive.ObjectID, acco
 	if err != nil {
 		return nil
 	}
-	return oauthConfig.Client(parentCtx, &token).(*http.Client)
+	return oauthConfig.Client(ctx, &token).(*http.Client)
 }
 
-func getExternalToken(db *mongo.Database, userID primitive.ObjectID, accountID string, serviceID string) (*database.ExternalAPIToken, error) {
+func getExternalToken(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, serviceID string) (*database.ExternalAPIToken, error) {
 	var externalToken database.ExternalAPIToken
 
 	if err := database.GetExternalTokenCollection(db).FindOne(
-		context.Background(),
+		ctx,
 		bson.M{"$and": []bson.M{
 			{"user_id": userID},
 			{"service_id": serviceID},
@@ -19,7 +19,7 @@ func TestGetExternalOauth2Client(t *testing.T) {
 	serviceID := "example service"
 
 	t.Run("NoDocument", func(t *testing.T) {
-		client := getExternalOauth2Client(db, userID, accountID, serviceID, nil)
+		client := getExternalOauth2Client(context.Background(), db, userID, accountID, serviceID, nil)
 		assert.Nil(t, client)
 	})
 	t.Run("Invalid token", func(t *testing.T) {
@@ -30,7 +30,7 @@ func TestGetExternalOauth2Client(t *testing.T) {
 			AccountID: accountID,
 		})
 		assert.NoError(t, err)
-		client := getExternalOauth2Client(db, invalidUser, accountID, serviceID, &OauthConfig{})
+		client := getExternalOauth2Client(context.Background(), db, invalidUser, accountID, serviceID, &OauthConfig{})
 		assert.Nil(t, client)
 	})
 	t.Run("Success", func(t *testing.T) {
@@ -42,7 +42,7 @@ func TestGetExternalOauth2Client(t *testing.T) {
 		})
 		assert.NoError(t, err)
 
-		client := getExternalOauth2Client(db, userID, accountID, serviceID, &OauthConfig{})
+		client := getExternalOauth2Client(context.Background(), db, userID, accountID, serviceID, &OauthConfig{})
 		assert.NotNil(t, client)
 	})
 }
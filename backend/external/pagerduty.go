@@ -0,0 +1,170 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/franchizzle/task-manager/backend/config"
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/oauth2"
+)
+
+const (
+	PagerDutyAPIURL   = "https://api.pagerduty.com"
+	PagerDutyAppURL   = "https://app.pagerduty.com"
+	PagerDutyAcceptV2 = "application/vnd.pagerduty+json;version=2"
+)
+
+type PagerDutyConfigValues struct {
+	// BaseURL replaces https://api.pagerduty.com
+	BaseURL *string
+}
+
+type PagerDutyConfig struct {
+	OauthConfig  OauthConfigWrapper
+	ConfigValues PagerDutyConfigValues
+}
+
+type PagerDutyService struct {
+	Config PagerDutyConfig
+}
+
+type PagerDutyUser struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+}
+
+type PagerDutyUserResponse struct {
+	User PagerDutyUser `json:"user"`
+}
+
+// pagerDutyTransport asks for v2 of the API, which PagerDuty requires on every request
+type pagerDutyTransport struct {
+	base http.RoundTripper
+}
+
+func (transport pagerDutyTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	request = request.Clone(request.Context())
+	request.Header.Set("Accept", PagerDutyAcceptV2)
+	return transport.base.RoundTrip(request)
+}
+
+func getPagerDutyOauthConfig() *OauthConfig {
+	settings := config.GetSettings()
+	return &OauthConfig{Config: &oauth2.Config{
+		ClientID:     settings.PagerDutyOauth.ClientID,
+		ClientSecret: settings.PagerDutyOauth.ClientSecret,
+		RedirectURL:  settings.ServerURL + "link/pagerduty/callback/",
+		// write is needed to resolve incidents
+		Scopes: []string{"read", "write"},
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  PagerDutyAppURL + "/oauth/authorize",
+			TokenURL: PagerDutyAppURL + "/oauth/token",
+		},
+	}}
+}
+
+func (pagerDuty PagerDutyService) GetLinkURL(stateTokenID primitive.ObjectID, userID primitive.ObjectID) (*string, error) {
+	authURL := pagerDuty.Config.OauthConfig.AuthCodeURL(stateTokenID.Hex())
+	return &authURL, nil
+}
+
+func (pagerDuty PagerDutyService) GetSignupURL(stateTokenID primitive.ObjectID, forcePrompt bool) (*string, error) {
+	return nil, errors.New("pagerduty does not support signup")
+}
+
+func (pagerDuty PagerDutyService) HandleLinkCallback(db *mongo.Database, params CallbackParams, userID primitive.ObjectID) error {
+	parentCtx := context.Background()
+	extCtx, cancel := context.WithTimeout(parentCtx, constants.ExternalTimeout)
+	defer cancel()
+	token, err := pagerDuty.Config.OauthConfig.Exchange(extCtx, *params.Oauth2Code)
+	logger := logging.GetSentryLogger()
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch token from PagerDuty")
+		return errors.New("internal server error")
+	}
+
+	client := oauth2.NewClient(extCtx, oauth2.StaticTokenSource(token))
+	user, err := pagerDuty.getCurrentUser(&http.Client{Transport: pagerDutyTransport{base: client.Transport}})
+	if err != nil || user.Email == "" {
+		logger.Error().Err(err).Msg("failed to fetch pagerduty user info")
+		return errors.New("internal server error")
+	}
+
+	tokenString, err := json.Marshal(&token)
+	if err != nil {
+		logger.Error().Err(err).Msg("error parsing token")
+		return errors.New("internal server error")
+	}
+
+	dbCtx, cancel := context.WithTimeout(parentCtx, constants.DatabaseTimeout)
+	defer cancel()
+	accountID := user.Email
+	_, err = database.GetExternalTokenCollection(db).UpdateOne(
+		dbCtx,
+		bson.M{"$and": []bson.M{{"user_id": userID}, {"service_id": TASK_SERVICE_ID_PAGERDUTY}, {"account_id": accountID}}},
+		bson.M{"$set": &database.ExternalAPIToken{
+			UserID:         userID,
+			ServiceID:      TASK_SERVICE_ID_PAGERDUTY,
+			Token:          string(tokenString),
+			AccountID:      accountID,
+			DisplayID:      accountID,
+			ExternalID:     user.ID,
+			IsUnlinkable:   true,
+			IsPrimaryLogin: false,
+		}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		logger.Error().Err(err).Msg("error saving token")
+		return errors.New("internal server error")
+	}
+	return nil
+}
+
+func (pagerDuty PagerDutyService) HandleSignupCallback(ctx context.Context, db *mongo.Database, params CallbackParams) (primitive.ObjectID, *bool, *string, error) {
+	return primitive.NilObjectID, nil, nil, errors.New("pagerduty does not support signup")
+}
+
+func (pagerDuty PagerDutyService) getCurrentUser(client *http.Client) (*PagerDutyUser, error) {
+	userURL, client := pagerDuty.getAPIURL(client, "/users/me")
+	var userResponse PagerDutyUserResponse
+	err := getJSON(client, userURL, &userResponse)
+	if err != nil {
+		return nil, err
+	}
+	return &userResponse.User, nil
+}
+
+// getAPIURL builds an API URL, path includes the query. Test overrides of the base URL are served
+// without auth, so the default client is used for them
+func (pagerDuty PagerDutyService) getAPIURL(client *http.Client, path string) (string, *http.Client) {
+	if pagerDuty.Config.ConfigValues.BaseURL != nil {
+		return *pagerDuty.Config.ConfigValues.BaseURL + path, http.DefaultClient
+	}
+	return PagerDutyAPIURL + path, client
+}
+
+// getAccountAPIURL is getAPIURL with the client of the linked account, which isn't loaded for test overrides
+func (pagerDuty PagerDutyService) getAccountAPIURL(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, path string) (string, *http.Client) {
+	if pagerDuty.Config.ConfigValues.BaseURL != nil {
+		return pagerDuty.getAPIURL(nil, path)
+	}
+	return pagerDuty.getAPIURL(getPagerDutyHttpClient(ctx, db, userID, accountID), path)
+}
+
+func getPagerDutyHttpClient(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string) *http.Client {
+	client := getExternalOauth2Client(ctx, db, userID, accountID, TASK_SERVICE_ID_PAGERDUTY, getPagerDutyOauthConfig())
+	if client == nil {
+		return nil
+	}
+	return &http.Client{Transport: pagerDutyTransport{base: client.Transport}}
+}
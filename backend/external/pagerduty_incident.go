@@ -0,0 +1,321 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type PagerDutyIncidentSource struct {
+	PagerDuty PagerDutyService
+}
+
+const (
+	PagerDutyPageSize         = 100
+	PagerDutyUrgencyHigh      = "high"
+	PagerDutyStatusTriggered  = "triggered"
+	PagerDutyStatusAcked      = "acknowledged"
+	PagerDutyStatusResolved   = "resolved"
+	PagerDutyMyOnCallDeeplink = PagerDutyAppURL + "/my-on-call"
+)
+
+// pagerDutyPriorities maps the default PagerDuty priorities to the normalized priorities, where 1 is urgent
+// and 4 is low
+var pagerDutyPriorities = map[string]float64{
+	"P1": 1,
+	"P2": 2,
+	"P3": 3,
+	"P4": 4,
+	"P5": 4,
+}
+
+type PagerDutyReference struct {
+	ID      string `json:"id"`
+	Summary string `json:"summary"`
+	HTMLURL string `json:"html_url"`
+}
+
+type PagerDutyIncident struct {
+	ID             string              `json:"id"`
+	IncidentNumber int                 `json:"incident_number"`
+	Title          string              `json:"title"`
+	Status         string              `json:"status"`
+	Urgency        string              `json:"urgency"`
+	HTMLURL        string              `json:"html_url"`
+	CreatedAt      string              `json:"created_at"`
+	Service        PagerDutyReference  `json:"service"`
+	Priority       *PagerDutyReference `json:"priority"`
+}
+
+type PagerDutyIncidentsResponse struct {
+	Incidents []PagerDutyIncident `json:"incidents"`
+}
+
+type PagerDutyOnCall struct {
+	EscalationPolicy PagerDutyReference  `json:"escalation_policy"`
+	EscalationLevel  int                 `json:"escalation_level"`
+	Schedule         *PagerDutyReference `json:"schedule"`
+	// both are null when the user is always on call for the escalation policy
+	Start *string `json:"start"`
+	End   *string `json:"end"`
+}
+
+type PagerDutyOnCallsResponse struct {
+	OnCalls []PagerDutyOnCall `json:"oncalls"`
+}
+
+type PagerDutyIncidentUpdateBody struct {
+	Incident struct {
+		Type   string `json:"type"`
+		Status string `json:"status"`
+	} `json:"incident"`
+}
+
+// GetEvents adds the user's on-call shifts to the calendar. Shifts without an end, from always being on
+// call, cover the whole range
+func (pagerDutyIncident PagerDutyIncidentSource) GetEvents(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, startTime time.Time, endTime time.Time, scopes []string, result chan<- CalendarResult) {
+	externalToken, err := getExternalToken(ctx, db, userID, accountID, TASK_SERVICE_ID_PAGERDUTY)
+	if err != nil {
+		result <- emptyCalendarResult(err)
+		return
+	}
+	query := url.Values{}
+	query.Add("user_ids[]", externalToken.ExternalID)
+	query.Add("since", startTime.Format(time.RFC3339))
+	query.Add("until", endTime.Format(time.RFC3339))
+	query.Add("limit", fmt.Sprint(PagerDutyPageSize))
+	onCallsURL, client := pagerDutyIncident.PagerDuty.getAccountAPIURL(ctx, db, userID, accountID, "/oncalls?"+query.Encode())
+	if client == nil {
+		result <- emptyCalendarResult(errors.New("failed to load pagerduty token"))
+		return
+	}
+	var onCallsResponse PagerDutyOnCallsResponse
+	err = getJSON(client, onCallsURL, &onCallsResponse)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch pagerduty on-call shifts")
+		result <- emptyCalendarResult(err)
+		return
+	}
+
+	events := []*database.CalendarEvent{}
+	for _, event := range getPagerDutyOnCallEvents(onCallsResponse.OnCalls, accountID, startTime, endTime) {
+		event.UserID = userID
+		dbEvent, err := database.UpdateOrCreateCalendarEvent(
+			ctx,
+			db,
+			userID,
+			event.IDExternal,
+			event.SourceID,
+			event,
+			&[]bson.M{{"source_account_id": accountID}},
+		)
+		if err != nil {
+			result <- emptyCalendarResult(err)
+			return
+		}
+		events = append(events, dbEvent)
+	}
+	result <- CalendarResult{CalendarEvents: events}
+}
+
+// getPagerDutyOnCallEvents maps the on-call shifts to events. A shift on a schedule is listed once for each
+// escalation policy using the schedule, so those are only added once
+func getPagerDutyOnCallEvents(onCalls []PagerDutyOnCall, accountID string, startTime time.Time, endTime time.Time) []*database.CalendarEvent {
+	events := []*database.CalendarEvent{}
+	addedIDs := map[string]bool{}
+	for _, onCall := range onCalls {
+		shiftStart := startTime
+		if onCall.Start != nil {
+			if parsedStart, err := time.Parse(time.RFC3339, *onCall.Start); err == nil {
+				shiftStart = parsedStart
+			}
+		}
+		shiftEnd := endTime
+		if onCall.End != nil {
+			if parsedEnd, err := time.Parse(time.RFC3339, *onCall.End); err == nil {
+				shiftEnd = parsedEnd
+			}
+		}
+		idExternal := fmt.Sprintf("policy/%s/%d/%d", onCall.EscalationPolicy.ID, onCall.EscalationLevel, shiftStart.Unix())
+		title := "On call: " + onCall.EscalationPolicy.Summary
+		deeplink := PagerDutyMyOnCallDeeplink
+		if onCall.Schedule != nil {
+			idExternal = fmt.Sprintf("schedule/%s/%d", onCall.Schedule.ID, shiftStart.Unix())
+			title = "On call: " + onCall.Schedule.Summary
+			deeplink = onCall.Schedule.HTMLURL
+		}
+		if addedIDs[idExternal] {
+			continue
+		}
+		addedIDs[idExternal] = true
+		events = append(events, &database.CalendarEvent{
+			IDExternal:      idExternal,
+			CalendarID:      accountID,
+			Deeplink:        deeplink,
+			SourceID:        TASK_SOURCE_ID_PAGERDUTY,
+			Title:           title,
+			TimeAllocation:  shiftEnd.Sub(shiftStart).Nanoseconds(),
+			SourceAccountID: accountID,
+			DatetimeStart:   primitive.NewDateTimeFromTime(shiftStart),
+			DatetimeEnd:     primitive.NewDateTimeFromTime(shiftEnd),
+			CanModify:       false,
+		})
+	}
+	return events
+}
+
+// GetTasks syncs the incidents assigned to the user which are still open. Resolved incidents drop out of
+// the results, so their tasks are completed on the next refresh
+func (pagerDutyIncident PagerDutyIncidentSource) GetTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- TaskResult) {
+	externalToken, err := getExternalToken(ctx, db, userID, accountID, TASK_SERVICE_ID_PAGERDUTY)
+	if err != nil {
+		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_PAGERDUTY)
+		return
+	}
+	query := url.Values{}
+	query.Add("user_ids[]", externalToken.ExternalID)
+	query.Add("statuses[]", PagerDutyStatusTriggered)
+	query.Add("statuses[]", PagerDutyStatusAcked)
+	query.Add("limit", fmt.Sprint(PagerDutyPageSize))
+	incidentsURL, client := pagerDutyIncident.PagerDuty.getAccountAPIURL(ctx, db, userID, accountID, "/incidents?"+query.Encode())
+	if client == nil {
+		result <- emptyTaskResultWithSource(errors.New("failed to load pagerduty token"), TASK_SOURCE_ID_PAGERDUTY)
+		return
+	}
+	var incidentsResponse PagerDutyIncidentsResponse
+	err = getJSON(client, incidentsURL, &incidentsResponse)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch pagerduty incidents")
+		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_PAGERDUTY)
+		return
+	}
+
+	defaultTaskSectionID := database.GetDefaultTaskSectionID(ctx, db, userID, TASK_SOURCE_ID_PAGERDUTY)
+	var tasks []*database.Task
+	for _, incident := range incidentsResponse.Incidents {
+		task := getPagerDutyIncidentTask(incident, accountID)
+		task.UserID = userID
+		task.IDTaskSection = defaultTaskSectionID
+		isCompleted := false
+		dbTask, err := database.UpdateOrCreateTask(
+			ctx,
+			db,
+			userID,
+			task.IDExternal,
+			task.SourceID,
+			task,
+			database.Task{
+				Title:              task.Title,
+				Body:               task.Body,
+				PriorityNormalized: task.PriorityNormalized,
+				IsCompleted:        &isCompleted,
+			},
+			nil,
+		)
+		if err != nil {
+			result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_PAGERDUTY)
+			return
+		}
+		task.HasBeenReordered = dbTask.HasBeenReordered
+		task.ID = dbTask.ID
+		task.IDOrdering = dbTask.IDOrdering
+		task.IDTaskSection = dbTask.IDTaskSection
+		task.TimeAllocation = dbTask.TimeAllocation
+		tasks = append(tasks, task)
+	}
+
+	result <- TaskResult{
+		Tasks: tasks,
+	}
+}
+
+func getPagerDutyIncidentTask(incident PagerDutyIncident, accountID string) *database.Task {
+	title := fmt.Sprintf("#%d %s", incident.IncidentNumber, incident.Title)
+	body := fmt.Sprintf("Service: %s\nStatus: %s", incident.Service.Summary, incident.Status)
+	priorityNormalized := getPagerDutyIncidentPriority(incident)
+	task := &database.Task{
+		IDExternal:         incident.ID,
+		Deeplink:           incident.HTMLURL,
+		SourceID:           TASK_SOURCE_ID_PAGERDUTY,
+		Title:              &title,
+		Body:               &body,
+		SourceAccountID:    accountID,
+		PriorityNormalized: &priorityNormalized,
+	}
+	if createdAt, err := time.Parse(time.RFC3339, incident.CreatedAt); err == nil {
+		task.CreatedAtExternal = primitive.NewDateTimeFromTime(createdAt)
+	}
+	return task
+}
+
+// getPagerDutyIncidentPriority uses the incident's priority when the account has priorities turned on, and
+// its urgency otherwise. Incidents are paged, so even low urgency ones aren't given the lowest priority
+func getPagerDutyIncidentPriority(incident PagerDutyIncident) float64 {
+	if incident.Priority != nil {
+		if priority, ok := pagerDutyPriorities[incident.Priority.Summary]; ok {
+			return priority
+		}
+	}
+	if incident.Urgency == PagerDutyUrgencyHigh {
+		return 1
+	}
+	return 3
+}
+
+func (pagerDutyIncident PagerDutyIncidentSource) GetPullRequests(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- PullRequestResult) {
+	result <- emptyPullRequestResult(nil, false)
+}
+
+// ModifyTask resolves the incident when the task is completed. PagerDuty doesn't reopen incidents, so
+// nothing else maps to an incident
+func (pagerDutyIncident PagerDutyIncidentSource) ModifyTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, issueID string, updateFields *database.Task, task *database.Task) error {
+	if updateFields.IsCompleted == nil || !*updateFields.IsCompleted {
+		return nil
+	}
+	incidentURL, client := pagerDutyIncident.PagerDuty.getAccountAPIURL(ctx, db, userID, accountID, "/incidents/"+url.PathEscape(issueID))
+	if client == nil {
+		return errors.New("failed to load pagerduty token")
+	}
+	var updateBody PagerDutyIncidentUpdateBody
+	updateBody.Incident.Type = "incident_reference"
+	updateBody.Incident.Status = PagerDutyStatusResolved
+	bodyJson, err := json.Marshal(updateBody)
+	if err != nil {
+		return err
+	}
+	err = requestJSON(client, "PUT", incidentURL, string(bodyJson), EmptyResponsePlaceholder)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to resolve pagerduty incident")
+		return err
+	}
+	return nil
+}
+
+func (pagerDutyIncident PagerDutyIncidentSource) CreateNewTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, task TaskCreationObject) (primitive.ObjectID, error) {
+	return primitive.NilObjectID, errors.New("has not been implemented yet")
+}
+
+func (pagerDutyIncident PagerDutyIncidentSource) CreateNewEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error {
+	return errors.New("has not been implemented yet")
+}
+
+func (pagerDutyIncident PagerDutyIncidentSource) ModifyEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, eventID string, updateFields *EventModifyObject) error {
+	return errors.New("has not been implemented yet")
+}
+
+func (pagerDutyIncident PagerDutyIncidentSource) DeleteEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error {
+	return errors.New("has not been implemented yet")
+}
+
+func (pagerDutyIncident PagerDutyIncidentSource) AddComment(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error {
+	return errors.New("has not been implemented yet")
+}
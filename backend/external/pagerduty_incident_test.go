@@ -0,0 +1,120 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGetPagerDutyIncidentPriority(t *testing.T) {
+	t.Run("Priority", func(t *testing.T) {
+		assert.Equal(t, 2.0, getPagerDutyIncidentPriority(PagerDutyIncident{Urgency: PagerDutyUrgencyHigh, Priority: &PagerDutyReference{Summary: "P2"}}))
+		assert.Equal(t, 4.0, getPagerDutyIncidentPriority(PagerDutyIncident{Priority: &PagerDutyReference{Summary: "P5"}}))
+	})
+	t.Run("CustomPriority", func(t *testing.T) {
+		assert.Equal(t, 1.0, getPagerDutyIncidentPriority(PagerDutyIncident{Urgency: PagerDutyUrgencyHigh, Priority: &PagerDutyReference{Summary: "SEV-1"}}))
+	})
+	t.Run("Urgency", func(t *testing.T) {
+		assert.Equal(t, 1.0, getPagerDutyIncidentPriority(PagerDutyIncident{Urgency: PagerDutyUrgencyHigh}))
+		assert.Equal(t, 3.0, getPagerDutyIncidentPriority(PagerDutyIncident{Urgency: "low"}))
+	})
+}
+
+func TestGetPagerDutyIncidentTask(t *testing.T) {
+	task := getPagerDutyIncidentTask(PagerDutyIncident{
+		ID:             "PINC1",
+		IncidentNumber: 42,
+		Title:          "Checkout API error rate",
+		Status:         PagerDutyStatusAcked,
+		Urgency:        PagerDutyUrgencyHigh,
+		HTMLURL:        "https://example.pagerduty.com/incidents/PINC1",
+		CreatedAt:      "2023-03-08T17:00:00Z",
+		Service:        PagerDutyReference{Summary: "Checkout"},
+	}, "me@example.com")
+	assert.Equal(t, "PINC1", task.IDExternal)
+	assert.Equal(t, TASK_SOURCE_ID_PAGERDUTY, task.SourceID)
+	assert.Equal(t, "#42 Checkout API error rate", *task.Title)
+	assert.Equal(t, "Service: Checkout\nStatus: acknowledged", *task.Body)
+	assert.Equal(t, "https://example.pagerduty.com/incidents/PINC1", task.Deeplink)
+	assert.Equal(t, 1.0, *task.PriorityNormalized)
+	assert.Equal(t, primitive.NewDateTimeFromTime(time.Date(2023, time.March, 8, 17, 0, 0, 0, time.UTC)), task.CreatedAtExternal)
+}
+
+func TestGetPagerDutyOnCallEvents(t *testing.T) {
+	startTime := time.Date(2023, time.March, 6, 0, 0, 0, 0, time.UTC)
+	endTime := time.Date(2023, time.March, 13, 0, 0, 0, 0, time.UTC)
+	shiftStart := "2023-03-08T17:00:00Z"
+	shiftEnd := "2023-03-09T17:00:00Z"
+	schedule := &PagerDutyReference{ID: "PSCHED1", Summary: "Payments primary", HTMLURL: "https://example.pagerduty.com/schedules/PSCHED1"}
+
+	t.Run("ScheduleShiftListedOnce", func(t *testing.T) {
+		events := getPagerDutyOnCallEvents([]PagerDutyOnCall{
+			{EscalationPolicy: PagerDutyReference{ID: "PPOL1"}, EscalationLevel: 1, Schedule: schedule, Start: &shiftStart, End: &shiftEnd},
+			{EscalationPolicy: PagerDutyReference{ID: "PPOL2"}, EscalationLevel: 2, Schedule: schedule, Start: &shiftStart, End: &shiftEnd},
+		}, "me@example.com", startTime, endTime)
+		assert.Equal(t, 1, len(events))
+		assert.Equal(t, "schedule/PSCHED1/1678294800", events[0].IDExternal)
+		assert.Equal(t, "On call: Payments primary", events[0].Title)
+		assert.Equal(t, schedule.HTMLURL, events[0].Deeplink)
+		assert.Equal(t, TASK_SOURCE_ID_PAGERDUTY, events[0].SourceID)
+		assert.Equal(t, "me@example.com", events[0].SourceAccountID)
+		assert.Equal(t, primitive.NewDateTimeFromTime(time.Date(2023, time.March, 9, 17, 0, 0, 0, time.UTC)), events[0].DatetimeEnd)
+		assert.Equal(t, (24 * time.Hour).Nanoseconds(), events[0].TimeAllocation)
+	})
+	t.Run("AlwaysOnCall", func(t *testing.T) {
+		events := getPagerDutyOnCallEvents([]PagerDutyOnCall{
+			{EscalationPolicy: PagerDutyReference{ID: "PPOL1", Summary: "Payments"}, EscalationLevel: 1},
+		}, "me@example.com", startTime, endTime)
+		assert.Equal(t, 1, len(events))
+		assert.Equal(t, "On call: Payments", events[0].Title)
+		assert.Equal(t, PagerDutyMyOnCallDeeplink, events[0].Deeplink)
+		assert.Equal(t, primitive.NewDateTimeFromTime(startTime), events[0].DatetimeStart)
+		assert.Equal(t, primitive.NewDateTimeFromTime(endTime), events[0].DatetimeEnd)
+	})
+}
+
+func TestModifyPagerDutyIncident(t *testing.T) {
+	t.Run("Reopen", func(t *testing.T) {
+		isCompleted := false
+		err := PagerDutyIncidentSource{}.ModifyTask(context.Background(), nil, primitive.NewObjectID(), "me@example.com", "PINC1", &database.Task{IsCompleted: &isCompleted}, nil)
+		assert.NoError(t, err)
+	})
+	t.Run("Resolve", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "PUT", r.Method)
+			assert.Equal(t, "/incidents/PINC1", r.URL.Path)
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			var updateBody PagerDutyIncidentUpdateBody
+			assert.NoError(t, json.Unmarshal(body, &updateBody))
+			assert.Equal(t, "incident_reference", updateBody.Incident.Type)
+			assert.Equal(t, PagerDutyStatusResolved, updateBody.Incident.Status)
+			w.Write([]byte(`{"incident": {"id": "PINC1", "status": "resolved"}}`))
+		}))
+		defer server.Close()
+		source := PagerDutyIncidentSource{PagerDuty: PagerDutyService{Config: PagerDutyConfig{ConfigValues: PagerDutyConfigValues{BaseURL: &server.URL}}}}
+		isCompleted := true
+		err := source.ModifyTask(context.Background(), nil, primitive.NewObjectID(), "me@example.com", "PINC1", &database.Task{IsCompleted: &isCompleted}, nil)
+		assert.NoError(t, err)
+	})
+}
+
+func TestPagerDutyTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, PagerDutyAcceptV2, r.Header.Get("Accept"))
+		w.Write([]byte(`{"user": {"id": "PUSER1", "email": "me@example.com"}}`))
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: pagerDutyTransport{base: http.DefaultTransport}}
+	var userResponse PagerDutyUserResponse
+	assert.NoError(t, getJSON(client, server.URL+"/users/me", &userResponse))
+	assert.Equal(t, "PUSER1", userResponse.User.ID)
+}
@@ -0,0 +1,20 @@
+//go:build !no_apple
+
+package external
+
+func init() {
+	RegisterTaskService(TaskServiceRegistration{
+		ServiceID: TASK_SERVICE_ID_APPLE,
+		Details:   TaskServiceApple,
+		New: func(config Config) TaskService {
+			return AppleService{Config: config.Apple}
+		},
+	})
+	RegisterTaskSource(TaskSourceRegistration{
+		ServiceID: TASK_SERVICE_ID_APPLE,
+		Details:   TaskSourceAppleReminders,
+		New: func(config Config) TaskSource {
+			return AppleRemindersSource{Apple: AppleService{Config: config.Apple}}
+		},
+	})
+}
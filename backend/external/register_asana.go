@@ -0,0 +1,20 @@
+//go:build !no_asana
+
+package external
+
+func init() {
+	RegisterTaskService(TaskServiceRegistration{
+		ServiceID: TASK_SERVICE_ID_ASANA,
+		Details:   TaskServiceAsana,
+		New: func(config Config) TaskService {
+			return AsanaService{Config: config.Asana}
+		},
+	})
+	RegisterTaskSource(TaskSourceRegistration{
+		ServiceID: TASK_SERVICE_ID_ASANA,
+		Details:   TaskSourceAsana,
+		New: func(config Config) TaskSource {
+			return AsanaTaskSource{Asana: AsanaService{Config: config.Asana}}
+		},
+	})
+}
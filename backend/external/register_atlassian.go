@@ -0,0 +1,20 @@
+//go:build !no_atlassian
+
+package external
+
+func init() {
+	RegisterTaskService(TaskServiceRegistration{
+		ServiceID: TASK_SERVICE_ID_ATLASSIAN,
+		Details:   TaskServiceAtlassian,
+		New: func(config Config) TaskService {
+			return AtlassianService{Config: config.Atlassian}
+		},
+	})
+	RegisterTaskSource(TaskSourceRegistration{
+		ServiceID: TASK_SERVICE_ID_ATLASSIAN,
+		Details:   TaskSourceJIRA,
+		New: func(config Config) TaskSource {
+			return JIRASource{Atlassian: AtlassianService{Config: config.Atlassian}}
+		},
+	})
+}
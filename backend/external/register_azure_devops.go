@@ -0,0 +1,20 @@
+//go:build !no_azure_devops
+
+package external
+
+func init() {
+	RegisterTaskService(TaskServiceRegistration{
+		ServiceID: TASK_SERVICE_ID_AZURE_DEVOPS,
+		Details:   TaskServiceAzureDevOps,
+		New: func(config Config) TaskService {
+			return AzureDevOpsService{Config: config.AzureDevOps}
+		},
+	})
+	RegisterTaskSource(TaskSourceRegistration{
+		ServiceID: TASK_SERVICE_ID_AZURE_DEVOPS,
+		Details:   TaskSourceAzureDevOps,
+		New: func(config Config) TaskSource {
+			return AzureDevOpsSource{AzureDevOps: AzureDevOpsService{Config: config.AzureDevOps}}
+		},
+	})
+}
@@ -0,0 +1,20 @@
+//go:build !no_github
+
+package external
+
+func init() {
+	RegisterTaskService(TaskServiceRegistration{
+		ServiceID: TASK_SERVICE_ID_GITHUB,
+		Details:   TaskServiceGithub,
+		New: func(config Config) TaskService {
+			return GithubService{Config: config.Github}
+		},
+	})
+	RegisterTaskSource(TaskSourceRegistration{
+		ServiceID: TASK_SERVICE_ID_GITHUB,
+		Details:   TaskSourceGithubPR,
+		New: func(config Config) TaskSource {
+			return GithubPRSource{Github: GithubService{Config: config.Github}}
+		},
+	})
+}
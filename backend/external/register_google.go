@@ -0,0 +1,42 @@
+// Google is always built, as it is how users sign up
+
+package external
+
+func init() {
+	RegisterTaskService(TaskServiceRegistration{
+		ServiceID: TASK_SERVICE_ID_GOOGLE,
+		Details:   TaskServiceGoogle,
+		New: func(config Config) TaskService {
+			return getGoogleService(config)
+		},
+	})
+	RegisterTaskSource(TaskSourceRegistration{
+		ServiceID: TASK_SERVICE_ID_GOOGLE,
+		Details:   TaskSourceGoogleCalendar,
+		New: func(config Config) TaskSource {
+			return GoogleCalendarSource{Google: getGoogleService(config)}
+		},
+	})
+	RegisterTaskSource(TaskSourceRegistration{
+		ServiceID: TASK_SERVICE_ID_GOOGLE,
+		Details:   TaskSourceGmail,
+		New: func(config Config) TaskSource {
+			return GmailSource{Google: getGoogleService(config)}
+		},
+	})
+	RegisterTaskSource(TaskSourceRegistration{
+		ServiceID: TASK_SERVICE_ID_GOOGLE,
+		Details:   TaskSourceGoogleTasks,
+		New: func(config Config) TaskSource {
+			return GoogleTasksSource{Google: getGoogleService(config)}
+		},
+	})
+}
+
+func getGoogleService(config Config) GoogleService {
+	return GoogleService{
+		LoginConfig:  config.GoogleLoginConfig,
+		LinkConfig:   config.GoogleAuthorizeConfig,
+		OverrideURLs: config.GoogleOverrideURLs,
+	}
+}
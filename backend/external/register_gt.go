@@ -0,0 +1,20 @@
+// General Task is always built, as every user has its task source
+
+package external
+
+func init() {
+	RegisterTaskService(TaskServiceRegistration{
+		ServiceID: TASK_SERVICE_ID_GT,
+		Details:   TaskServiceGeneralTask,
+		New: func(config Config) TaskService {
+			return GeneralTaskService{}
+		},
+	})
+	RegisterTaskSource(TaskSourceRegistration{
+		ServiceID: TASK_SERVICE_ID_GT,
+		Details:   TaskSourceGeneralTask,
+		New: func(config Config) TaskSource {
+			return GeneralTaskTaskSource{}
+		},
+	})
+}
@@ -0,0 +1,20 @@
+//go:build !no_intercom
+
+package external
+
+func init() {
+	RegisterTaskService(TaskServiceRegistration{
+		ServiceID: TASK_SERVICE_ID_INTERCOM,
+		Details:   TaskServiceIntercom,
+		New: func(config Config) TaskService {
+			return IntercomService{Config: config.Intercom}
+		},
+	})
+	RegisterTaskSource(TaskSourceRegistration{
+		ServiceID: TASK_SERVICE_ID_INTERCOM,
+		Details:   TaskSourceIntercom,
+		New: func(config Config) TaskSource {
+			return IntercomConversationSource{Intercom: IntercomService{Config: config.Intercom}}
+		},
+	})
+}
@@ -0,0 +1,20 @@
+//go:build !no_linear
+
+package external
+
+func init() {
+	RegisterTaskService(TaskServiceRegistration{
+		ServiceID: TASK_SERVICE_ID_LINEAR,
+		Details:   TaskServiceLinear,
+		New: func(config Config) TaskService {
+			return LinearService{Config: config.Linear}
+		},
+	})
+	RegisterTaskSource(TaskSourceRegistration{
+		ServiceID: TASK_SERVICE_ID_LINEAR,
+		Details:   TaskSourceLinear,
+		New: func(config Config) TaskSource {
+			return LinearTaskSource{Linear: LinearService{Config: config.Linear}}
+		},
+	})
+}
@@ -0,0 +1,20 @@
+//go:build !no_pagerduty
+
+package external
+
+func init() {
+	RegisterTaskService(TaskServiceRegistration{
+		ServiceID: TASK_SERVICE_ID_PAGERDUTY,
+		Details:   TaskServicePagerDuty,
+		New: func(config Config) TaskService {
+			return PagerDutyService{Config: config.PagerDuty}
+		},
+	})
+	RegisterTaskSource(TaskSourceRegistration{
+		ServiceID: TASK_SERVICE_ID_PAGERDUTY,
+		Details:   TaskSourcePagerDuty,
+		New: func(config Config) TaskSource {
+			return PagerDutyIncidentSource{PagerDuty: PagerDutyService{Config: config.PagerDuty}}
+		},
+	})
+}
@@ -0,0 +1,20 @@
+//go:build !no_salesforce
+
+package external
+
+func init() {
+	RegisterTaskService(TaskServiceRegistration{
+		ServiceID: TASK_SERVICE_ID_SALESFORCE,
+		Details:   TaskServiceSalesforce,
+		New: func(config Config) TaskService {
+			return SalesforceService{Config: config.Salesforce}
+		},
+	})
+	RegisterTaskSource(TaskSourceRegistration{
+		ServiceID: TASK_SERVICE_ID_SALESFORCE,
+		Details:   TaskSourceSalesforce,
+		New: func(config Config) TaskSource {
+			return SalesforceTaskSource{Salesforce: SalesforceService{Config: config.Salesforce}}
+		},
+	})
+}
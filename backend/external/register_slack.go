@@ -0,0 +1,27 @@
+//go:build !no_slack
+
+package external
+
+func init() {
+	RegisterTaskService(TaskServiceRegistration{
+		ServiceID: TASK_SERVICE_ID_SLACK,
+		Details:   TaskServiceSlack,
+		New: func(config Config) TaskService {
+			return SlackService{Config: config.Slack}
+		},
+	})
+	RegisterTaskService(TaskServiceRegistration{
+		ServiceID: TASK_SERVICE_ID_SLACK_APP,
+		Details:   TaskServiceSlack,
+		New: func(config Config) TaskService {
+			return SlackService{Config: config.SlackApp}
+		},
+	})
+	RegisterTaskSource(TaskSourceRegistration{
+		ServiceID: TASK_SERVICE_ID_SLACK,
+		Details:   TaskSourceSlackSaved,
+		New: func(config Config) TaskSource {
+			return SlackSavedTaskSource{Slack: SlackService{Config: config.Slack}}
+		},
+	})
+}
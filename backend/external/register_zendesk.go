@@ -0,0 +1,20 @@
+//go:build !no_zendesk
+
+package external
+
+func init() {
+	RegisterTaskService(TaskServiceRegistration{
+		ServiceID: TASK_SERVICE_ID_ZENDESK,
+		Details:   TaskServiceZendesk,
+		New: func(config Config) TaskService {
+			return ZendeskService{Config: config.Zendesk}
+		},
+	})
+	RegisterTaskSource(TaskSourceRegistration{
+		ServiceID: TASK_SERVICE_ID_ZENDESK,
+		Details:   TaskSourceZendesk,
+		New: func(config Config) TaskSource {
+			return ZendeskTicketSource{Zendesk: ZendeskService{Config: config.Zendesk}}
+		},
+	})
+}
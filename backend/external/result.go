@@ -42,6 +42,12 @@ func emptyTaskResultWithSource(err error, sourceID string) TaskResult {
 	return result
 }
 
+func emptyPullRequestResultWithSource(err error, sourceID string) PullRequestResult {
+	result := emptyPullRequestResult(err, false)
+	result.SourceID = sourceID
+	return result
+}
+
 func emptyPullRequestResult(err error, suppressSentry bool) PullRequestResult {
 	return PullRequestResult{
 		PullRequests:   []*database.PullRequest{},
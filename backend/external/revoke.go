@@ -0,0 +1,68 @@
+package external
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"golang.org/x/oauth2"
+)
+
+const (
+	GOOGLE_REVOKE_URL = "https://oauth2.googleapis.com/revoke"
+	SLACK_REVOKE_URL  = "https://slack.com/api/auth.revoke"
+)
+
+// RevokeExternalToken invalidates the token with the service that issued it. Services without a
+// user-token revocation API are skipped and report false, the token is simply deleted on our side
+func RevokeExternalToken(token database.ExternalAPIToken, overrideURL string) (bool, error) {
+	var oauthToken oauth2.Token
+	err := json.Unmarshal([]byte(token.Token), &oauthToken)
+	if err != nil {
+		return false, err
+	}
+
+	var request *http.Request
+	switch token.ServiceID {
+	case TASK_SERVICE_ID_GOOGLE:
+		revokeURL := GOOGLE_REVOKE_URL
+		if overrideURL != "" {
+			revokeURL = overrideURL
+		}
+		// revoking the refresh token also revokes every access token issued from it
+		tokenToRevoke := oauthToken.RefreshToken
+		if tokenToRevoke == "" {
+			tokenToRevoke = oauthToken.AccessToken
+		}
+		request, err = http.NewRequest("POST", revokeURL, strings.NewReader(url.Values{"token": {tokenToRevoke}}.Encode()))
+		if err != nil {
+			return false, err
+		}
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	case TASK_SERVICE_ID_SLACK:
+		revokeURL := SLACK_REVOKE_URL
+		if overrideURL != "" {
+			revokeURL = overrideURL
+		}
+		request, err = http.NewRequest("POST", revokeURL, nil)
+		if err != nil {
+			return false, err
+		}
+		request.Header.Set("Authorization", "Bearer "+oauthToken.AccessToken)
+	default:
+		return false, nil
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return false, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("token revocation failed with status %d", response.StatusCode)
+	}
+	return true, nil
+}
@@ -0,0 +1,66 @@
+package external
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevokeExternalToken(t *testing.T) {
+	t.Run("UnsupportedService", func(t *testing.T) {
+		revoked, err := RevokeExternalToken(database.ExternalAPIToken{
+			ServiceID: TASK_SERVICE_ID_LINEAR,
+			Token:     `{"access_token":"token"}`,
+		}, "")
+		assert.NoError(t, err)
+		assert.False(t, revoked)
+	})
+	t.Run("InvalidToken", func(t *testing.T) {
+		_, err := RevokeExternalToken(database.ExternalAPIToken{ServiceID: TASK_SERVICE_ID_GOOGLE, Token: "not json"}, "")
+		assert.Error(t, err)
+	})
+	t.Run("GoogleRevokesRefreshToken", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, "token=refresh", string(body))
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		revoked, err := RevokeExternalToken(database.ExternalAPIToken{
+			ServiceID: TASK_SERVICE_ID_GOOGLE,
+			Token:     `{"access_token":"access","refresh_token":"refresh"}`,
+		}, server.URL)
+		assert.NoError(t, err)
+		assert.True(t, revoked)
+	})
+	t.Run("SlackUsesBearerToken", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer access", r.Header.Get("Authorization"))
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		revoked, err := RevokeExternalToken(database.ExternalAPIToken{
+			ServiceID: TASK_SERVICE_ID_SLACK,
+			Token:     `{"access_token":"access"}`,
+		}, server.URL)
+		assert.NoError(t, err)
+		assert.True(t, revoked)
+	})
+	t.Run("ServiceError", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+		revoked, err := RevokeExternalToken(database.ExternalAPIToken{
+			ServiceID: TASK_SERVICE_ID_GOOGLE,
+			Token:     `{"access_token":"access"}`,
+		}, server.URL)
+		assert.EqualError(t, err, "token revocation failed with status 400")
+		assert.False(t, revoked)
+	})
+}
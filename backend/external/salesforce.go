@@ -0,0 +1,176 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/config"
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/oauth2"
+)
+
+const (
+	SalesforceDefaultLoginURL = "https://login.salesforce.com"
+	SalesforceAPIVersion      = "58.0"
+)
+
+type SalesforceConfigValues struct {
+	UserInfoURL *string
+	QueryURL    *string
+	UpdateURL   *string
+}
+
+type SalesforceConfig struct {
+	OauthConfig  OauthConfigWrapper
+	ConfigValues SalesforceConfigValues
+}
+
+type SalesforceService struct {
+	Config SalesforceConfig
+}
+
+type SalesforceUserInfoResponse struct {
+	UserID string `json:"user_id"`
+	Email  string `json:"email"`
+	URLs   struct {
+		Rest string `json:"rest"`
+	} `json:"urls"`
+}
+
+// getRestURL returns the org's REST API root. Each org is served from its own instance, which is
+// only known once the user has signed in
+func (userInfo SalesforceUserInfoResponse) getRestURL() string {
+	return strings.Replace(userInfo.URLs.Rest, "{version}", SalesforceAPIVersion, 1)
+}
+
+func (userInfo SalesforceUserInfoResponse) getInstanceURL() string {
+	restURL, err := url.Parse(userInfo.URLs.Rest)
+	if err != nil {
+		return ""
+	}
+	return restURL.Scheme + "://" + restURL.Host
+}
+
+func getSalesforceLoginURL() string {
+	loginURL := config.GetSettings().SalesforceLoginURL
+	if loginURL == "" {
+		return SalesforceDefaultLoginURL
+	}
+	return strings.TrimSuffix(loginURL, "/")
+}
+
+func getSalesforceOauthConfig() *OauthConfig {
+	settings := config.GetSettings()
+	return &OauthConfig{Config: &oauth2.Config{
+		ClientID:     settings.SalesforceOauth.ClientID,
+		ClientSecret: settings.SalesforceOauth.ClientSecret,
+		RedirectURL:  settings.ServerURL + "link/salesforce/callback/",
+		Scopes:       []string{"api", "refresh_token"},
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  getSalesforceLoginURL() + "/services/oauth2/authorize",
+			TokenURL: getSalesforceLoginURL() + "/services/oauth2/token",
+		},
+	}}
+}
+
+func (salesforce SalesforceService) GetLinkURL(stateTokenID primitive.ObjectID, userID primitive.ObjectID) (*string, error) {
+	authURL := salesforce.Config.OauthConfig.AuthCodeURL(stateTokenID.Hex(), oauth2.AccessTypeOffline, oauth2.ApprovalForce)
+	return &authURL, nil
+}
+
+func (salesforce SalesforceService) GetSignupURL(stateTokenID primitive.ObjectID, forcePrompt bool) (*string, error) {
+	return nil, errors.New("salesforce does not support signup")
+}
+
+func (salesforce SalesforceService) HandleLinkCallback(db *mongo.Database, params CallbackParams, userID primitive.ObjectID) error {
+	parentCtx := context.Background()
+	extCtx, cancel := context.WithTimeout(parentCtx, constants.ExternalTimeout)
+	defer cancel()
+	token, err := salesforce.Config.OauthConfig.Exchange(extCtx, *params.Oauth2Code)
+	logger := logging.GetSentryLogger()
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch token from Salesforce")
+		return errors.New("internal server error")
+	}
+
+	client := oauth2.NewClient(extCtx, oauth2.StaticTokenSource(token))
+	userInfo, err := salesforce.getUserInfo(client)
+	if err != nil || userInfo.Email == "" {
+		logger.Error().Err(err).Msg("failed to fetch salesforce user info")
+		return errors.New("internal server error")
+	}
+
+	tokenString, err := json.Marshal(&token)
+	if err != nil {
+		logger.Error().Err(err).Msg("error parsing token")
+		return errors.New("internal server error")
+	}
+
+	dbCtx, cancel := context.WithTimeout(parentCtx, constants.DatabaseTimeout)
+	defer cancel()
+	accountID := userInfo.Email
+	_, err = database.GetExternalTokenCollection(db).UpdateOne(
+		dbCtx,
+		bson.M{"$and": []bson.M{{"user_id": userID}, {"service_id": TASK_SERVICE_ID_SALESFORCE}, {"account_id": accountID}}},
+		bson.M{"$set": &database.ExternalAPIToken{
+			UserID:         userID,
+			ServiceID:      TASK_SERVICE_ID_SALESFORCE,
+			Token:          string(tokenString),
+			AccountID:      accountID,
+			DisplayID:      accountID,
+			ExternalID:     userInfo.UserID,
+			IsUnlinkable:   true,
+			IsPrimaryLogin: false,
+		}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		logger.Error().Err(err).Msg("error saving token")
+		return errors.New("internal server error")
+	}
+	return nil
+}
+
+func (salesforce SalesforceService) HandleSignupCallback(ctx context.Context, db *mongo.Database, params CallbackParams) (primitive.ObjectID, *bool, *string, error) {
+	return primitive.NilObjectID, nil, nil, errors.New("salesforce does not support signup")
+}
+
+func (salesforce SalesforceService) getUserInfo(client *http.Client) (*SalesforceUserInfoResponse, error) {
+	userInfoURL := getSalesforceLoginURL() + "/services/oauth2/userinfo"
+	if salesforce.Config.ConfigValues.UserInfoURL != nil {
+		userInfoURL = *salesforce.Config.ConfigValues.UserInfoURL
+		client = http.DefaultClient
+	}
+	var userInfo SalesforceUserInfoResponse
+	err := getJSON(client, userInfoURL, &userInfo)
+	if err != nil {
+		return nil, err
+	}
+	return &userInfo, nil
+}
+
+// getSalesforceHttpClient always starts with a refreshed access token. Salesforce doesn't say when its
+// access tokens expire, so, like Atlassian, the refresh token is used each time the client is created
+func getSalesforceHttpClient(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string) *http.Client {
+	externalToken, err := getExternalToken(ctx, db, userID, accountID, TASK_SERVICE_ID_SALESFORCE)
+	if err != nil {
+		return nil
+	}
+	token, err := extractOauthToken(*externalToken)
+	if err != nil {
+		return nil
+	}
+	token.Expiry = time.Unix(1, 0)
+	return getSalesforceOauthConfig().Client(ctx, &token).(*http.Client)
+}
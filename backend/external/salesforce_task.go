@@ -0,0 +1,278 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type SalesforceTaskSource struct {
+	Salesforce SalesforceService
+}
+
+const (
+	SalesforceObjectTask        = "Task"
+	SalesforceObjectEvent       = "Event"
+	SalesforceObjectOpportunity = "Opportunity"
+	SalesforceTaskStatusDone    = "Completed"
+	// Salesforce responses don't use RFC 3339, the offset has no colon
+	SalesforceDateTimeFormat = "2006-01-02T15:04:05.000-0700"
+	SalesforceQueryLimit     = 200
+)
+
+// record ID prefixes are fixed for standard objects, so the object type can be told from the ID alone
+var salesforceKeyPrefixes = map[string]string{
+	"00T": SalesforceObjectTask,
+	"00U": SalesforceObjectEvent,
+	"006": SalesforceObjectOpportunity,
+}
+
+type SalesforceRecord struct {
+	Attributes struct {
+		Type string `json:"type"`
+	} `json:"attributes"`
+	ID            string `json:"Id"`
+	Subject       string `json:"Subject"`
+	Description   string `json:"Description"`
+	ActivityDate  string `json:"ActivityDate"`
+	StartDateTime string `json:"StartDateTime"`
+	Name          string `json:"Name"`
+	NextStep      string `json:"NextStep"`
+	CloseDate     string `json:"CloseDate"`
+	CreatedDate   string `json:"CreatedDate"`
+}
+
+type SalesforceQueryResponse struct {
+	Records []SalesforceRecord `json:"records"`
+}
+
+func (salesforceTask SalesforceTaskSource) GetEvents(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, startTime time.Time, endTime time.Time, scopes []string, result chan<- CalendarResult) {
+	result <- emptyCalendarResult(errors.New("salesforce cannot fetch events"))
+}
+
+// GetTasks syncs the user's open Tasks, their upcoming Events and the next steps on open
+// Opportunities they own
+func (salesforceTask SalesforceTaskSource) GetTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- TaskResult) {
+	logger := logging.GetSentryLogger()
+	client := getSalesforceHttpClient(ctx, db, userID, accountID)
+	if client == nil && salesforceTask.Salesforce.Config.ConfigValues.UserInfoURL == nil {
+		result <- emptyTaskResultWithSource(errors.New("failed to load salesforce token"), TASK_SOURCE_ID_SALESFORCE)
+		return
+	}
+	userInfo, err := salesforceTask.Salesforce.getUserInfo(client)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to get salesforce user info")
+		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_SALESFORCE)
+		return
+	}
+
+	ownerID := strings.ReplaceAll(userInfo.UserID, "'", "")
+	queries := []string{
+		fmt.Sprintf("SELECT Id, Subject, Description, ActivityDate, CreatedDate FROM Task WHERE OwnerId = '%s' AND IsClosed = false ORDER BY ActivityDate LIMIT %d", ownerID, SalesforceQueryLimit),
+		fmt.Sprintf("SELECT Id, Subject, Description, StartDateTime, CreatedDate FROM Event WHERE OwnerId = '%s' AND EndDateTime >= %s ORDER BY StartDateTime LIMIT %d", ownerID, time.Now().UTC().Format("2006-01-02T15:04:05Z"), SalesforceQueryLimit),
+		fmt.Sprintf("SELECT Id, Name, NextStep, CloseDate, CreatedDate FROM Opportunity WHERE OwnerId = '%s' AND IsClosed = false AND NextStep != null ORDER BY CloseDate LIMIT %d", ownerID, SalesforceQueryLimit),
+	}
+	queryURL := userInfo.getRestURL() + "query/"
+	if salesforceTask.Salesforce.Config.ConfigValues.QueryURL != nil {
+		queryURL = *salesforceTask.Salesforce.Config.ConfigValues.QueryURL
+		client = http.DefaultClient
+	}
+	var records []SalesforceRecord
+	for _, query := range queries {
+		var response SalesforceQueryResponse
+		err = getJSON(client, queryURL+"?q="+url.QueryEscape(query), &response)
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to fetch salesforce records")
+			result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_SALESFORCE)
+			return
+		}
+		records = append(records, response.Records...)
+	}
+
+	defaultTaskSectionID := database.GetDefaultTaskSectionID(ctx, db, userID, TASK_SOURCE_ID_SALESFORCE)
+	var tasks []*database.Task
+	for _, record := range records {
+		task := getSalesforceTask(record, userInfo.getInstanceURL())
+		task.UserID = userID
+		task.IDTaskSection = defaultTaskSectionID
+		task.SourceAccountID = accountID
+		updateFields := database.Task{
+			Title:   task.Title,
+			Body:    task.Body,
+			DueDate: task.DueDate,
+		}
+		// events can't be completed in Salesforce, so completing one here shouldn't be undone by the
+		// next sync. They drop off once they're over
+		if record.Attributes.Type != SalesforceObjectEvent {
+			isCompleted := false
+			updateFields.IsCompleted = &isCompleted
+		}
+		dbTask, err := database.UpdateOrCreateTask(
+			ctx,
+			db,
+			userID,
+			task.IDExternal,
+			task.SourceID,
+			task,
+			updateFields,
+			nil,
+		)
+		if err != nil {
+			result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_SALESFORCE)
+			return
+		}
+		task.HasBeenReordered = dbTask.HasBeenReordered
+		task.ID = dbTask.ID
+		task.IDOrdering = dbTask.IDOrdering
+		task.IDTaskSection = dbTask.IDTaskSection
+		task.TimeAllocation = dbTask.TimeAllocation
+		tasks = append(tasks, task)
+	}
+
+	result <- TaskResult{
+		Tasks: tasks,
+	}
+}
+
+func getSalesforceTask(record SalesforceRecord, instanceURL string) *database.Task {
+	title := record.Subject
+	body := record.Description
+	dueDate := record.ActivityDate
+	if record.Attributes.Type == SalesforceObjectOpportunity {
+		title = "Follow up: " + record.Name
+		body = record.NextStep
+		dueDate = record.CloseDate
+	}
+	task := &database.Task{
+		IDExternal: record.ID,
+		Deeplink:   fmt.Sprintf("%s/lightning/r/%s/%s/view", instanceURL, record.Attributes.Type, record.ID),
+		SourceID:   TASK_SOURCE_ID_SALESFORCE,
+		Title:      &title,
+		Body:       &body,
+	}
+	if createdAt, err := time.Parse(SalesforceDateTimeFormat, record.CreatedDate); err == nil {
+		task.CreatedAtExternal = primitive.NewDateTimeFromTime(createdAt)
+	}
+	if record.Attributes.Type == SalesforceObjectEvent {
+		if startTime, err := time.Parse(SalesforceDateTimeFormat, record.StartDateTime); err == nil {
+			dueDatePrim := primitive.NewDateTimeFromTime(startTime)
+			task.DueDate = &dueDatePrim
+		}
+	} else if dueDateTime, err := time.Parse(constants.YEAR_MONTH_DAY_FORMAT, dueDate); err == nil {
+		dueDatePrim := primitive.NewDateTimeFromTime(dueDateTime)
+		task.DueDate = &dueDatePrim
+	}
+	return task
+}
+
+func (salesforceTask SalesforceTaskSource) GetPullRequests(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- PullRequestResult) {
+	result <- emptyPullRequestResult(nil, false)
+}
+
+func (salesforceTask SalesforceTaskSource) ModifyTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, issueID string, updateFields *database.Task, task *database.Task) error {
+	objectType := getSalesforceObjectType(issueID)
+	body := getSalesforceUpdateBody(objectType, updateFields)
+	if len(body) == 0 {
+		return nil
+	}
+	bodyJson, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.GetSentryLogger()
+	client := getSalesforceHttpClient(ctx, db, userID, accountID)
+	updateURL := ""
+	if salesforceTask.Salesforce.Config.ConfigValues.UpdateURL != nil {
+		updateURL = *salesforceTask.Salesforce.Config.ConfigValues.UpdateURL
+		client = http.DefaultClient
+	} else {
+		if client == nil {
+			return errors.New("failed to load salesforce token")
+		}
+		userInfo, err := salesforceTask.Salesforce.getUserInfo(client)
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to get salesforce user info")
+			return err
+		}
+		updateURL = fmt.Sprintf("%ssobjects/%s/%s", userInfo.getRestURL(), objectType, issueID)
+	}
+	err = requestJSON(client, "PATCH", updateURL, string(bodyJson), EmptyResponsePlaceholder)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update salesforce record")
+		return err
+	}
+	return nil
+}
+
+func getSalesforceObjectType(recordID string) string {
+	if len(recordID) < 3 {
+		return ""
+	}
+	return salesforceKeyPrefixes[recordID[:3]]
+}
+
+// getSalesforceUpdateBody maps the fields which have a Salesforce equivalent. Completing an opportunity
+// follow-up clears its next step, and events have nothing to complete
+func getSalesforceUpdateBody(objectType string, updateFields *database.Task) map[string]interface{} {
+	body := map[string]interface{}{}
+	isCompleted := updateFields.IsCompleted != nil && *updateFields.IsCompleted
+	switch objectType {
+	case SalesforceObjectTask:
+		if updateFields.Title != nil {
+			body["Subject"] = *updateFields.Title
+		}
+		if updateFields.Body != nil {
+			body["Description"] = *updateFields.Body
+		}
+		if updateFields.DueDate != nil && updateFields.DueDate.Time().UTC().Year() > 1971 {
+			body["ActivityDate"] = updateFields.DueDate.Time().Format(constants.YEAR_MONTH_DAY_FORMAT)
+		}
+		if isCompleted {
+			body["Status"] = SalesforceTaskStatusDone
+		}
+	case SalesforceObjectEvent:
+		if updateFields.Title != nil {
+			body["Subject"] = *updateFields.Title
+		}
+		if updateFields.Body != nil {
+			body["Description"] = *updateFields.Body
+		}
+	case SalesforceObjectOpportunity:
+		if isCompleted {
+			body["NextStep"] = nil
+		}
+	}
+	return body
+}
+
+func (salesforceTask SalesforceTaskSource) CreateNewTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, task TaskCreationObject) (primitive.ObjectID, error) {
+	return primitive.NilObjectID, errors.New("has not been implemented yet")
+}
+
+func (salesforceTask SalesforceTaskSource) CreateNewEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error {
+	return errors.New("has not been implemented yet")
+}
+
+func (salesforceTask SalesforceTaskSource) ModifyEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, eventID string, updateFields *EventModifyObject) error {
+	return errors.New("has not been implemented yet")
+}
+
+func (salesforceTask SalesforceTaskSource) DeleteEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error {
+	return errors.New("has not been implemented yet")
+}
+
+func (salesforceTask SalesforceTaskSource) AddComment(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error {
+	return errors.New("has not been implemented yet")
+}
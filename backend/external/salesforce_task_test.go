@@ -0,0 +1,173 @@
+package external
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/testutils"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestLoadSalesforceTasks(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	taskCollection := database.GetTaskCollection(db)
+
+	userInfoServerSuccess := testutils.GetMockAPIServer(t, 200, `{"user_id": "005xx000001Sv6A", "email": "rep@example.com", "urls": {"rest": "https://acme.my.salesforce.com/services/data/v{version}/"}}`)
+	defer userInfoServerSuccess.Close()
+
+	t.Run("BadUserInfoStatusCode", func(t *testing.T) {
+		userInfoServer := testutils.GetMockAPIServer(t, 401, "")
+		defer userInfoServer.Close()
+		salesforceTask := SalesforceTaskSource{Salesforce: SalesforceService{Config: SalesforceConfig{ConfigValues: SalesforceConfigValues{UserInfoURL: &userInfoServer.URL}}}}
+
+		var taskResult = make(chan TaskResult)
+		go salesforceTask.GetTasks(context.Background(), db, primitive.NewObjectID(), "rep@example.com", taskResult)
+		result := <-taskResult
+		assert.Error(t, result.Error)
+		assert.Equal(t, "bad status code: 401", result.Error.Error())
+		assert.Equal(t, 0, len(result.Tasks))
+	})
+	t.Run("BadQueryStatusCode", func(t *testing.T) {
+		queryServer := testutils.GetMockAPIServer(t, 400, "")
+		defer queryServer.Close()
+		salesforceTask := SalesforceTaskSource{Salesforce: SalesforceService{Config: SalesforceConfig{ConfigValues: SalesforceConfigValues{
+			UserInfoURL: &userInfoServerSuccess.URL,
+			QueryURL:    &queryServer.URL,
+		}}}}
+
+		var taskResult = make(chan TaskResult)
+		go salesforceTask.GetTasks(context.Background(), db, primitive.NewObjectID(), "rep@example.com", taskResult)
+		result := <-taskResult
+		assert.Error(t, result.Error)
+		assert.Equal(t, 0, len(result.Tasks))
+	})
+	t.Run("Success", func(t *testing.T) {
+		queryServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query().Get("q")
+			assert.Contains(t, query, "OwnerId = '005xx000001Sv6A'")
+			w.WriteHeader(200)
+			switch {
+			case strings.Contains(query, "FROM Task"):
+				w.Write([]byte(`{"records": [{"attributes": {"type": "Task"}, "Id": "00Txx000001", "Subject": "Call Acme", "Description": "Renewal", "ActivityDate": "2022-03-04", "CreatedDate": "2022-03-01T10:00:00.000+0000"}]}`))
+			case strings.Contains(query, "FROM Event"):
+				w.Write([]byte(`{"records": [{"attributes": {"type": "Event"}, "Id": "00Uxx000001", "Subject": "Demo", "StartDateTime": "2022-03-05T15:30:00.000+0000", "CreatedDate": "2022-03-01T10:00:00.000+0000"}]}`))
+			default:
+				w.Write([]byte(`{"records": [{"attributes": {"type": "Opportunity"}, "Id": "006xx000001", "Name": "Acme expansion", "NextStep": "Send pricing", "CloseDate": "2022-03-31", "CreatedDate": "2022-03-01T10:00:00.000+0000"}]}`))
+			}
+		}))
+		defer queryServer.Close()
+		salesforceTask := SalesforceTaskSource{Salesforce: SalesforceService{Config: SalesforceConfig{ConfigValues: SalesforceConfigValues{
+			UserInfoURL: &userInfoServerSuccess.URL,
+			QueryURL:    &queryServer.URL,
+		}}}}
+		userID := primitive.NewObjectID()
+
+		var taskResult = make(chan TaskResult)
+		go salesforceTask.GetTasks(context.Background(), db, userID, "rep@example.com", taskResult)
+		result := <-taskResult
+		assert.NoError(t, result.Error)
+		assert.Equal(t, 3, len(result.Tasks))
+		assert.Equal(t, "Call Acme", *result.Tasks[0].Title)
+		assert.Equal(t, "https://acme.my.salesforce.com/lightning/r/Task/00Txx000001/view", result.Tasks[0].Deeplink)
+		assert.Equal(t, "Demo", *result.Tasks[1].Title)
+		assert.Equal(t, "Follow up: Acme expansion", *result.Tasks[2].Title)
+
+		count, err := taskCollection.CountDocuments(context.Background(), bson.M{"user_id": userID, "source_id": TASK_SOURCE_ID_SALESFORCE})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+	})
+}
+
+func TestGetSalesforceTask(t *testing.T) {
+	instanceURL := "https://acme.my.salesforce.com"
+	t.Run("Task", func(t *testing.T) {
+		record := SalesforceRecord{ID: "00Txx000001", Subject: "Call Acme", Description: "Renewal", ActivityDate: "2022-03-04", CreatedDate: "2022-03-01T10:00:00.000+0000"}
+		record.Attributes.Type = SalesforceObjectTask
+		task := getSalesforceTask(record, instanceURL)
+		assert.Equal(t, "00Txx000001", task.IDExternal)
+		assert.Equal(t, "Call Acme", *task.Title)
+		assert.Equal(t, "Renewal", *task.Body)
+		assert.Equal(t, time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC), task.DueDate.Time().UTC())
+		assert.Equal(t, time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC), task.CreatedAtExternal.Time().UTC())
+		assert.Equal(t, "https://acme.my.salesforce.com/lightning/r/Task/00Txx000001/view", task.Deeplink)
+	})
+	t.Run("EventDueAtStart", func(t *testing.T) {
+		record := SalesforceRecord{ID: "00Uxx000001", Subject: "Demo", StartDateTime: "2022-03-05T15:30:00.000-0800"}
+		record.Attributes.Type = SalesforceObjectEvent
+		task := getSalesforceTask(record, instanceURL)
+		assert.Equal(t, time.Date(2022, 3, 5, 23, 30, 0, 0, time.UTC), task.DueDate.Time().UTC())
+	})
+	t.Run("OpportunityFollowUp", func(t *testing.T) {
+		record := SalesforceRecord{ID: "006xx000001", Name: "Acme expansion", NextStep: "Send pricing", CloseDate: "2022-03-31"}
+		record.Attributes.Type = SalesforceObjectOpportunity
+		task := getSalesforceTask(record, instanceURL)
+		assert.Equal(t, "Follow up: Acme expansion", *task.Title)
+		assert.Equal(t, "Send pricing", *task.Body)
+		assert.Equal(t, time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC), task.DueDate.Time().UTC())
+	})
+	t.Run("NoDueDate", func(t *testing.T) {
+		record := SalesforceRecord{ID: "00Txx000002", Subject: "Someday"}
+		record.Attributes.Type = SalesforceObjectTask
+		assert.Nil(t, getSalesforceTask(record, instanceURL).DueDate)
+	})
+}
+
+func TestGetSalesforceUpdateBody(t *testing.T) {
+	isCompleted := true
+	title := "New subject"
+	t.Run("ObjectType", func(t *testing.T) {
+		assert.Equal(t, SalesforceObjectTask, getSalesforceObjectType("00Txx000001"))
+		assert.Equal(t, SalesforceObjectEvent, getSalesforceObjectType("00Uxx000001"))
+		assert.Equal(t, SalesforceObjectOpportunity, getSalesforceObjectType("006xx000001"))
+		assert.Equal(t, "", getSalesforceObjectType("001xx000001"))
+		assert.Equal(t, "", getSalesforceObjectType(""))
+	})
+	t.Run("CompleteTask", func(t *testing.T) {
+		body := getSalesforceUpdateBody(SalesforceObjectTask, &database.Task{IsCompleted: &isCompleted, Title: &title})
+		assert.Equal(t, map[string]interface{}{"Status": SalesforceTaskStatusDone, "Subject": title}, body)
+	})
+	t.Run("CompleteEvent", func(t *testing.T) {
+		assert.Empty(t, getSalesforceUpdateBody(SalesforceObjectEvent, &database.Task{IsCompleted: &isCompleted}))
+	})
+	t.Run("CompleteOpportunity", func(t *testing.T) {
+		body := getSalesforceUpdateBody(SalesforceObjectOpportunity, &database.Task{IsCompleted: &isCompleted, Title: &title})
+		assert.Equal(t, map[string]interface{}{"NextStep": nil}, body)
+	})
+}
+
+func TestModifySalesforceTask(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+
+	isCompleted := true
+	t.Run("BadResponse", func(t *testing.T) {
+		updateServer := testutils.GetMockAPIServer(t, 400, "")
+		defer updateServer.Close()
+		salesforceTask := SalesforceTaskSource{Salesforce: SalesforceService{Config: SalesforceConfig{ConfigValues: SalesforceConfigValues{UpdateURL: &updateServer.URL}}}}
+		err := salesforceTask.ModifyTask(context.Background(), db, primitive.NewObjectID(), "rep@example.com", "00Txx000001", &database.Task{IsCompleted: &isCompleted}, nil)
+		assert.Error(t, err)
+		assert.Equal(t, "bad status code: 400", err.Error())
+	})
+	t.Run("NoContentSuccess", func(t *testing.T) {
+		updateServer := testutils.GetMockAPIServer(t, 204, "")
+		defer updateServer.Close()
+		salesforceTask := SalesforceTaskSource{Salesforce: SalesforceService{Config: SalesforceConfig{ConfigValues: SalesforceConfigValues{UpdateURL: &updateServer.URL}}}}
+		err := salesforceTask.ModifyTask(context.Background(), db, primitive.NewObjectID(), "rep@example.com", "00Txx000001", &database.Task{IsCompleted: &isCompleted}, nil)
+		assert.NoError(t, err)
+	})
+	t.Run("EventCompletionStaysLocal", func(t *testing.T) {
+		salesforceTask := SalesforceTaskSource{}
+		err := salesforceTask.ModifyTask(context.Background(), db, primitive.NewObjectID(), "rep@example.com", "00Uxx000001", &database.Task{IsCompleted: &isCompleted}, nil)
+		assert.NoError(t, err)
+	})
+}
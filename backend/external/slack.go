@@ -40,9 +40,9 @@ type SlackService struct {
 func getSlackConfig() SlackConfig {
 	return SlackConfig{
 		OauthConfig: &OauthConfig{Config: &oauth2.Config{
-			ClientID:     config.GetConfigValue("SLACK_OAUTH_CLIENT_ID"),
-			ClientSecret: config.GetConfigValue("SLACK_OAUTH_CLIENT_SECRET"),
-			RedirectURL:  config.GetConfigValue("SERVER_URL") + "link/slack/callback/",
+			ClientID:     config.GetSettings().SlackOauth.ClientID,
+			ClientSecret: config.GetSettings().SlackOauth.ClientSecret,
+			RedirectURL:  config.GetSettings().ServerURL + "link/slack/callback/",
 			Scopes:       []string{"commands", "users:read"},
 			Endpoint: oauth2.Endpoint{
 				AuthURL:  "https://slack.com/oauth/authorize",
@@ -54,9 +54,9 @@ func getSlackConfig() SlackConfig {
 func GetSlackAppConfig() SlackConfig {
 	return SlackConfig{
 		OauthConfig: &OauthConfig{Config: &oauth2.Config{
-			ClientID:     config.GetConfigValue("SLACK_OAUTH_CLIENT_ID"),
-			ClientSecret: config.GetConfigValue("SLACK_OAUTH_CLIENT_SECRET"),
-			RedirectURL:  config.GetConfigValue("SERVER_URL") + "link_app/slack/",
+			ClientID:     config.GetSettings().SlackOauth.ClientID,
+			ClientSecret: config.GetSettings().SlackOauth.ClientSecret,
+			RedirectURL:  config.GetSettings().ServerURL + "link_app/slack/",
 			Scopes:       []string{"commands", "users:read"},
 			Endpoint: oauth2.Endpoint{
 				AuthURL:  "https://slack.com/oauth/authorize",
@@ -125,7 +125,7 @@ func (slackService SlackService) HandleLinkCallback(db *mongo.Database, params C
 	return nil
 }
 
-func (slackService SlackService) HandleSignupCallback(db *mongo.Database, params CallbackParams) (primitive.ObjectID, *bool, *string, error) {
+func (slackService SlackService) HandleSignupCallback(ctx context.Context, db *mongo.Database, params CallbackParams) (primitive.ObjectID, *bool, *string, error) {
 	return primitive.NilObjectID, nil, nil, errors.New("slack does not support signup")
 }
 
@@ -8,7 +8,6 @@ import (
 	"net/http"
 	"time"
 
-	"github.com/franchizzle/task-manager/backend/constants"
 	"github.com/franchizzle/task-manager/backend/logging"
 	"github.com/rs/zerolog/log"
 	"github.com/slack-go/slack"
@@ -29,17 +28,17 @@ type SlackAdditionalInformation struct {
 	Deeplink string
 }
 
-func (slackTask SlackSavedTaskSource) GetEvents(db *mongo.Database, userID primitive.ObjectID, accountID string, startTime time.Time, endTime time.Time, scopes []string, result chan<- CalendarResult) {
+func (slackTask SlackSavedTaskSource) GetEvents(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, startTime time.Time, endTime time.Time, scopes []string, result chan<- CalendarResult) {
 	result <- emptyCalendarResult(errors.New("slack saved cannot fetch events"))
 }
 
-func (slackTask SlackSavedTaskSource) GetTasks(db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- TaskResult) {
+func (slackTask SlackSavedTaskSource) GetTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- TaskResult) {
 	logger := logging.GetSentryLogger()
 
 	taskCollection := database.GetTaskCollection(db)
 
 	cursor, err := taskCollection.Find(
-		context.Background(),
+		ctx,
 		bson.M{"$and": []bson.M{
 			{"user_id": userID},
 			{"source_id": TASK_SOURCE_ID_SLACK_SAVED},
@@ -48,7 +47,7 @@ func (slackTask SlackSavedTaskSource) GetTasks(db *mongo.Database, userID primit
 		}},
 	)
 	var tasks []*database.Task
-	if err != nil || cursor.All(context.Background(), &tasks) != nil {
+	if err != nil || cursor.All(ctx, &tasks) != nil {
 		logger.Error().Err(err).Msg("failed to fetch slack tasks")
 		result <- emptyTaskResult(err)
 		return
@@ -56,21 +55,21 @@ func (slackTask SlackSavedTaskSource) GetTasks(db *mongo.Database, userID primit
 	result <- TaskResult{Tasks: tasks, ServiceID: TASK_SERVICE_ID_SLACK, AccountID: accountID}
 }
 
-func (slackTask SlackSavedTaskSource) GetPullRequests(db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- PullRequestResult) {
+func (slackTask SlackSavedTaskSource) GetPullRequests(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- PullRequestResult) {
 	result <- emptyPullRequestResult(nil, false)
 }
 
-func (slackTask SlackSavedTaskSource) ModifyTask(db *mongo.Database, userID primitive.ObjectID, accountID string, issueID string, updateFields *database.Task, task *database.Task) error {
+func (slackTask SlackSavedTaskSource) ModifyTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, issueID string, updateFields *database.Task, task *database.Task) error {
 	return nil
 }
 
-func (slackTask SlackSavedTaskSource) CreateNewTask(db *mongo.Database, userID primitive.ObjectID, accountID string, task TaskCreationObject) (primitive.ObjectID, error) {
-	taskSection := constants.IDTaskSectionDefault
-	if task.IDTaskSection != primitive.NilObjectID {
-		taskSection = task.IDTaskSection
+func (slackTask SlackSavedTaskSource) CreateNewTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, task TaskCreationObject) (primitive.ObjectID, error) {
+	taskSection := task.IDTaskSection
+	if taskSection == primitive.NilObjectID {
+		taskSection = database.GetDefaultTaskSectionID(ctx, db, userID, TASK_SOURCE_ID_SLACK_SAVED)
 	}
 
-	slackAdditionalInformation, err := slackTask.GetSlackAdditionalInformation(db, userID, accountID, task.SlackMessageParams)
+	slackAdditionalInformation, err := slackTask.GetSlackAdditionalInformation(ctx, db, userID, accountID, task.SlackMessageParams)
 	logger := logging.GetSentryLogger()
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to fetch Slack message params")
@@ -98,23 +97,23 @@ func (slackTask SlackSavedTaskSource) CreateNewTask(db *mongo.Database, userID p
 	}
 
 	taskCollection := database.GetTaskCollection(db)
-	insertResult, err := taskCollection.InsertOne(context.Background(), newTask)
+	insertResult, err := taskCollection.InsertOne(ctx, newTask)
 	return insertResult.InsertedID.(primitive.ObjectID), err
 }
 
-func (slackTask SlackSavedTaskSource) CreateNewEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error {
+func (slackTask SlackSavedTaskSource) CreateNewEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error {
 	return errors.New("has not been implemented yet")
 }
 
-func (slackTask SlackSavedTaskSource) DeleteEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error {
+func (slackTask SlackSavedTaskSource) DeleteEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error {
 	return errors.New("has not been implemented yet")
 }
 
-func (slackTask SlackSavedTaskSource) ModifyEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, eventID string, updateFields *EventModifyObject) error {
+func (slackTask SlackSavedTaskSource) ModifyEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, eventID string, updateFields *EventModifyObject) error {
 	return errors.New("has not been implemented yet")
 }
 
-func (slackTask SlackSavedTaskSource) AddComment(db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error {
+func (slackTask SlackSavedTaskSource) AddComment(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error {
 	return errors.New("has not been implemented yet")
 }
 
@@ -122,8 +121,8 @@ func GenerateSlackUserID(teamID string, userID string) string {
 	return teamID + "-" + userID
 }
 
-func (slackTask SlackSavedTaskSource) GetSlackAdditionalInformation(db *mongo.Database, userID primitive.ObjectID, accountID string, slackParams database.SlackMessageParams) (SlackAdditionalInformation, error) {
-	externalToken, err := getExternalToken(db, userID, accountID, TASK_SERVICE_ID_SLACK)
+func (slackTask SlackSavedTaskSource) GetSlackAdditionalInformation(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, slackParams database.SlackMessageParams) (SlackAdditionalInformation, error) {
+	externalToken, err := getExternalToken(ctx, db, userID, accountID, TASK_SERVICE_ID_SLACK)
 	if err != nil {
 		return SlackAdditionalInformation{}, err
 	}
@@ -1,6 +1,7 @@
 package external
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -37,7 +38,7 @@ func TestLoadSlackTasks(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		var tasks = make(chan TaskResult)
-		go SlackSavedTaskSource{}.GetTasks(db, userID, GeneralTaskDefaultAccountID, tasks)
+		go SlackSavedTaskSource{}.GetTasks(context.Background(), db, userID, GeneralTaskDefaultAccountID, tasks)
 		result := <-tasks
 		assert.NoError(t, result.Error)
 		assert.Equal(t, 1, len(result.Tasks))
@@ -46,14 +47,14 @@ func TestLoadSlackTasks(t *testing.T) {
 	})
 	t.Run("WrongUserID", func(t *testing.T) {
 		var tasks = make(chan TaskResult)
-		go SlackSavedTaskSource{}.GetTasks(db, primitive.NewObjectID(), GeneralTaskDefaultAccountID, tasks)
+		go SlackSavedTaskSource{}.GetTasks(context.Background(), db, primitive.NewObjectID(), GeneralTaskDefaultAccountID, tasks)
 		result := <-tasks
 		assert.NoError(t, result.Error)
 		assert.Equal(t, 0, len(result.Tasks))
 	})
 	t.Run("WrongSourceAccountID", func(t *testing.T) {
 		var tasks = make(chan TaskResult)
-		go SlackSavedTaskSource{}.GetTasks(db, userID, "other_account_id", tasks)
+		go SlackSavedTaskSource{}.GetTasks(context.Background(), db, userID, "other_account_id", tasks)
 		result := <-tasks
 		assert.NoError(t, result.Error)
 		assert.Equal(t, 0, len(result.Tasks))
@@ -115,7 +116,7 @@ func TestCreateSlackTask(t *testing.T) {
 	t.Run("SuccessSlackCreation", func(t *testing.T) {
 		userID := primitive.NewObjectID()
 		testTask.UserID = userID
-		_, err := SlackSavedTaskSource{}.CreateNewTask(db, userID, GeneralTaskDefaultAccountID, TaskCreationObject{
+		_, err := SlackSavedTaskSource{}.CreateNewTask(context.Background(), db, userID, GeneralTaskDefaultAccountID, TaskCreationObject{
 			Title: "send dogecoin to the moon",
 			SlackMessageParams: database.SlackMessageParams{
 				Channel: database.SlackChannel{
@@ -137,7 +138,7 @@ func TestCreateSlackTask(t *testing.T) {
 			},
 		})
 		assert.NoError(t, err)
-		tasks, err := database.GetActiveTasks(db, userID)
+		tasks, err := database.GetActiveTasks(context.Background(), db, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(*tasks))
 		task := (*tasks)[0]
@@ -151,7 +152,7 @@ func TestCreateSlackTask(t *testing.T) {
 		testTask.UserID = userID
 		taskSectionID := primitive.NewObjectID()
 		testTask.IDTaskSection = taskSectionID
-		_, err := SlackSavedTaskSource{}.CreateNewTask(db, userID, GeneralTaskDefaultAccountID, TaskCreationObject{
+		_, err := SlackSavedTaskSource{}.CreateNewTask(context.Background(), db, userID, GeneralTaskDefaultAccountID, TaskCreationObject{
 			Title: "send dogecoin to the moon",
 			SlackMessageParams: database.SlackMessageParams{
 				Channel: database.SlackChannel{
@@ -174,7 +175,7 @@ func TestCreateSlackTask(t *testing.T) {
 			IDTaskSection: taskSectionID,
 		})
 		assert.NoError(t, err)
-		tasks, err := database.GetActiveTasks(db, userID)
+		tasks, err := database.GetActiveTasks(context.Background(), db, userID)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(*tasks))
 		task := (*tasks)[0]
@@ -1,6 +1,8 @@
 package external
 
 import (
+	"context"
+
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -9,11 +11,13 @@ type TaskService interface {
 	GetLinkURL(stateTokenID primitive.ObjectID, userID primitive.ObjectID) (*string, error)
 	GetSignupURL(stateTokenID primitive.ObjectID, forcePrompt bool) (*string, error)
 	HandleLinkCallback(db *mongo.Database, params CallbackParams, userID primitive.ObjectID) error
-	HandleSignupCallback(db *mongo.Database, params CallbackParams) (primitive.ObjectID, *bool, *string, error)
+	HandleSignupCallback(ctx context.Context, db *mongo.Database, params CallbackParams) (primitive.ObjectID, *bool, *string, error)
 }
 
 type CallbackParams struct {
 	Oauth1Token    *string
 	Oauth1Verifier *string
 	Oauth2Code     *string
+	// AuthorizeNewUser is checked before a user is created on login, and its error is returned as it is
+	AuthorizeNewUser func(email string) error
 }
@@ -1,6 +1,7 @@
 package external
 
 import (
+	"context"
 	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
@@ -10,15 +11,15 @@ import (
 )
 
 type TaskSource interface {
-	GetEvents(db *mongo.Database, userID primitive.ObjectID, accountID string, startTime time.Time, endTime time.Time, scopes []string, result chan<- CalendarResult)
-	GetTasks(db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- TaskResult)
-	GetPullRequests(db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- PullRequestResult)
-	CreateNewTask(db *mongo.Database, userID primitive.ObjectID, accountID string, task TaskCreationObject) (primitive.ObjectID, error)
-	ModifyTask(db *mongo.Database, userID primitive.ObjectID, accountID string, issueID string, updateFields *database.Task, task *database.Task) error
-	CreateNewEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error
-	ModifyEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, eventID string, updateFields *EventModifyObject) error
-	DeleteEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error
-	AddComment(db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error
+	GetEvents(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, startTime time.Time, endTime time.Time, scopes []string, result chan<- CalendarResult)
+	GetTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- TaskResult)
+	GetPullRequests(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- PullRequestResult)
+	CreateNewTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, task TaskCreationObject) (primitive.ObjectID, error)
+	ModifyTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, issueID string, updateFields *database.Task, task *database.Task) error
+	CreateNewEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error
+	ModifyEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, eventID string, updateFields *EventModifyObject) error
+	DeleteEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error
+	AddComment(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error
 }
 
 type TaskCreationObject struct {
@@ -45,7 +46,7 @@ type EventCreateObject struct {
 	Description         string             `json:"description,omitempty"`
 	TimeZone            string             `json:"time_zone,omitempty"`
 	DatetimeStart       *time.Time         `json:"datetime_start" binding:"required"`
-	DatetimeEnd         *time.Time         `json:"datetime_end" binding:"required"`
+	DatetimeEnd         *time.Time         `json:"datetime_end" binding:"required,gtfield=DatetimeStart"`
 	Attendees           []Attendee         `json:"attendees,omitempty"`
 	AddConferenceCall   bool               `json:"add_conference_call,omitempty"`
 	LinkedTaskID        primitive.ObjectID `json:"task_id,omitempty"`
@@ -53,9 +54,17 @@ type EventCreateObject struct {
 	LinkedPullRequestID primitive.ObjectID `json:"pr_id,omitempty"`
 }
 
+const (
+	// EventModifyScopeInstance changes only the given occurrence of a recurring event
+	EventModifyScopeInstance = "instance"
+	// EventModifyScopeSeries changes every occurrence of the recurring event the given one belongs to
+	EventModifyScopeSeries = "series"
+)
+
 type EventModifyObject struct {
 	AccountID         string      `json:"account_id" binding:"required"`
 	CalendarID        string      `json:"calendar_id"`
+	Scope             string      `json:"scope"`
 	Summary           *string     `json:"summary"`
 	Location          *string     `json:"location"`
 	Description       *string     `json:"description"`
@@ -64,4 +73,6 @@ type EventModifyObject struct {
 	DatetimeEnd       *time.Time  `json:"datetime_end"`
 	Attendees         *[]Attendee `json:"attendees"`
 	AddConferenceCall *bool       `json:"add_conference_call"`
+	// an empty color ID resets the event to its calendar's color
+	ColorID *string `json:"color_id"`
 }
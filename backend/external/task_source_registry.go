@@ -0,0 +1,74 @@
+package external
+
+import (
+	"fmt"
+	"sort"
+)
+
+// TaskSourceRegistration describes a task source and how to build it from the external config
+type TaskSourceRegistration struct {
+	ServiceID string
+	Details   TaskSourceDetails
+	New       func(config Config) TaskSource
+}
+
+// TaskServiceRegistration describes a linkable service and how to build it from the external config
+type TaskServiceRegistration struct {
+	ServiceID string
+	Details   TaskServiceDetails
+	New       func(config Config) TaskService
+}
+
+var taskServiceRegistry = map[string]TaskServiceRegistration{}
+var taskSourceRegistry = map[string]TaskSourceRegistration{}
+
+// RegisterTaskService adds a service to the registry. Each integration registers its service and
+// sources from its register_<service>.go file, which is built unless the no_<service> tag is set
+func RegisterTaskService(registration TaskServiceRegistration) {
+	if _, exists := taskServiceRegistry[registration.ServiceID]; exists {
+		panic(fmt.Sprintf("task service %s registered twice", registration.ServiceID))
+	}
+	taskServiceRegistry[registration.ServiceID] = registration
+}
+
+// GetRegisteredTaskServices returns every registered service, ordered by ID
+func GetRegisteredTaskServices() []TaskServiceRegistration {
+	registrations := []TaskServiceRegistration{}
+	for _, registration := range taskServiceRegistry {
+		registrations = append(registrations, registration)
+	}
+	sort.Slice(registrations, func(i, j int) bool {
+		return registrations[i].ServiceID < registrations[j].ServiceID
+	})
+	return registrations
+}
+
+// RegisterTaskSource adds a task source to the registry, alongside the service it belongs to
+func RegisterTaskSource(registration TaskSourceRegistration) {
+	if _, exists := taskSourceRegistry[registration.Details.ID]; exists {
+		panic(fmt.Sprintf("task source %s registered twice", registration.Details.ID))
+	}
+	taskSourceRegistry[registration.Details.ID] = registration
+}
+
+// GetRegisteredTaskSources returns every registered task source, ordered by ID
+func GetRegisteredTaskSources() []TaskSourceRegistration {
+	registrations := []TaskSourceRegistration{}
+	for _, registration := range taskSourceRegistry {
+		registrations = append(registrations, registration)
+	}
+	sort.Slice(registrations, func(i, j int) bool {
+		return registrations[i].Details.ID < registrations[j].Details.ID
+	})
+	return registrations
+}
+
+func (config Config) getServiceSources(serviceID string) []TaskSourceResult {
+	sources := []TaskSourceResult{}
+	for _, registration := range GetRegisteredTaskSources() {
+		if registration.ServiceID == serviceID {
+			sources = append(sources, TaskSourceResult{Source: registration.New(config), Details: registration.Details})
+		}
+	}
+	return sources
+}
@@ -0,0 +1,113 @@
+package external
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRegisteredTaskSources(t *testing.T) {
+	sourceIDs := []string{}
+	for _, registration := range GetRegisteredTaskSources() {
+		sourceIDs = append(sourceIDs, registration.Details.ID)
+	}
+	assert.Equal(t, []string{
+		TASK_SOURCE_ID_APPLE_REMINDERS,
+		TASK_SOURCE_ID_ASANA,
+		TASK_SOURCE_ID_AZURE_DEVOPS,
+		TASK_SOURCE_ID_GCAL,
+		TASK_SOURCE_ID_GITHUB_PR,
+		TASK_SOURCE_ID_GMAIL,
+		TASK_SOURCE_ID_GOOGLE_TASKS,
+		TASK_SOURCE_ID_GT_TASK,
+		TASK_SOURCE_ID_INTERCOM,
+		TASK_SOURCE_ID_JIRA,
+		TASK_SOURCE_ID_LINEAR,
+		TASK_SOURCE_ID_PAGERDUTY,
+		TASK_SOURCE_ID_SALESFORCE,
+		TASK_SOURCE_ID_SLACK_SAVED,
+		TASK_SOURCE_ID_ZENDESK,
+	}, sourceIDs)
+}
+
+func TestGetRegisteredTaskServices(t *testing.T) {
+	serviceIDs := []string{}
+	for _, registration := range GetRegisteredTaskServices() {
+		serviceIDs = append(serviceIDs, registration.ServiceID)
+	}
+	assert.Equal(t, []string{
+		TASK_SERVICE_ID_APPLE,
+		TASK_SERVICE_ID_ASANA,
+		TASK_SERVICE_ID_ATLASSIAN,
+		TASK_SERVICE_ID_AZURE_DEVOPS,
+		TASK_SERVICE_ID_GITHUB,
+		TASK_SERVICE_ID_GOOGLE,
+		TASK_SERVICE_ID_GT,
+		TASK_SERVICE_ID_INTERCOM,
+		TASK_SERVICE_ID_LINEAR,
+		TASK_SERVICE_ID_PAGERDUTY,
+		TASK_SERVICE_ID_SALESFORCE,
+		TASK_SERVICE_ID_SLACK,
+		TASK_SERVICE_ID_SLACK_APP,
+		TASK_SERVICE_ID_ZENDESK,
+	}, serviceIDs)
+}
+
+func TestRegisterTaskService(t *testing.T) {
+	t.Run("Duplicate", func(t *testing.T) {
+		assert.PanicsWithValue(t, "task service gt registered twice", func() {
+			RegisterTaskService(TaskServiceRegistration{ServiceID: TASK_SERVICE_ID_GT, Details: TaskServiceGeneralTask})
+		})
+	})
+	t.Run("Success", func(t *testing.T) {
+		RegisterTaskService(TaskServiceRegistration{
+			ServiceID: "test_service",
+			Details:   TaskServiceGeneralTask,
+			New: func(config Config) TaskService {
+				return GeneralTaskService{}
+			},
+		})
+		defer delete(taskServiceRegistry, "test_service")
+
+		service, err := GetConfig().GetTaskServiceResult("test_service")
+		assert.NoError(t, err)
+		assert.Equal(t, GeneralTaskService{}, service.Service)
+		assert.Equal(t, []TaskSourceResult{}, service.Sources)
+	})
+}
+
+func TestRegisterTaskSource(t *testing.T) {
+	t.Run("Duplicate", func(t *testing.T) {
+		assert.PanicsWithValue(t, "task source gt_task registered twice", func() {
+			RegisterTaskSource(TaskSourceRegistration{ServiceID: TASK_SERVICE_ID_GT, Details: TaskSourceGeneralTask})
+		})
+	})
+	t.Run("Success", func(t *testing.T) {
+		details := TaskSourceDetails{ID: "test_source", SupportsComments: true}
+		RegisterTaskSource(TaskSourceRegistration{
+			ServiceID: TASK_SERVICE_ID_GT,
+			Details:   details,
+			New: func(config Config) TaskSource {
+				return GeneralTaskTaskSource{}
+			},
+		})
+		defer delete(taskSourceRegistry, "test_source")
+
+		source, err := GetConfig().GetSourceResult("test_source")
+		assert.NoError(t, err)
+		assert.Equal(t, details, source.Details)
+		service, err := GetConfig().GetTaskServiceResult(TASK_SERVICE_ID_GT)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(service.Sources))
+	})
+}
+
+func TestGetServiceSources(t *testing.T) {
+	config := GetConfig()
+	sources := config.getServiceSources(TASK_SERVICE_ID_LINEAR)
+	assert.Equal(t, []TaskSourceResult{{
+		Source:  LinearTaskSource{Linear: LinearService{Config: config.Linear}},
+		Details: TaskSourceLinear,
+	}}, sources)
+	assert.Equal(t, []TaskSourceResult{}, config.getServiceSources(TASK_SERVICE_ID_SLACK_APP))
+}
@@ -0,0 +1,140 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/oauth2"
+)
+
+// the services whose tokens are refreshed by the token refresh job. Atlassian isn't included since its
+// tokens are refreshed each time they're used
+var TokenRefreshServiceIDs = []string{
+	TASK_SERVICE_ID_ASANA,
+	TASK_SERVICE_ID_GITHUB,
+	TASK_SERVICE_ID_GOOGLE,
+	TASK_SERVICE_ID_LINEAR,
+	TASK_SERVICE_ID_SLACK,
+}
+
+// GetTokenRefresher returns the oauth config used to refresh the service's tokens
+func (config Config) GetTokenRefresher(serviceID string) (OauthTokenRefresher, bool) {
+	var oauthConfig OauthConfigWrapper
+	switch serviceID {
+	case TASK_SERVICE_ID_ASANA:
+		oauthConfig = config.Asana
+	case TASK_SERVICE_ID_GITHUB:
+		oauthConfig = config.Github.OauthConfig
+	case TASK_SERVICE_ID_GOOGLE:
+		// login and linked Google tokens share a client, so either config can refresh them
+		oauthConfig = config.GoogleLoginConfig
+	case TASK_SERVICE_ID_LINEAR:
+		oauthConfig = config.Linear.OauthConfig
+	case TASK_SERVICE_ID_SLACK:
+		oauthConfig = config.Slack.OauthConfig
+	default:
+		return nil, false
+	}
+	refresher, ok := oauthConfig.(OauthTokenRefresher)
+	return refresher, ok
+}
+
+// tokens without an expiry or refresh token, like Github's, never need refreshing
+func tokenNeedsRefresh(token oauth2.Token, now time.Time) bool {
+	if token.RefreshToken == "" || token.Expiry.IsZero() {
+		return false
+	}
+	return token.Expiry.Before(now.Add(time.Duration(constants.TOKEN_REFRESH_WINDOW) * time.Second))
+}
+
+// getTokenRefreshBackoff returns how long to wait before retrying after the number of failed refreshes
+func getTokenRefreshBackoff(failureCount int) time.Duration {
+	backoff := time.Duration(constants.TOKEN_REFRESH_BACKOFF) * time.Second
+	maxBackoff := time.Duration(constants.TOKEN_REFRESH_MAX_BACKOFF) * time.Second
+	for i := 1; i < failureCount && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
+
+// RefreshExternalToken refreshes the token if it expires soon, returning whether it was refreshed. A
+// failed refresh is retried with backoff, and the token is only marked bad once it has failed
+// TOKEN_REFRESH_MAX_FAILURES times in a row, since the provider being briefly unavailable doesn't mean
+// the account needs relinking. Failures and recoveries are recorded in the token's health history
+func RefreshExternalToken(ctx context.Context, db *mongo.Database, externalToken database.ExternalAPIToken, refresher OauthTokenRefresher, now time.Time) (bool, error) {
+	token, err := extractOauthToken(externalToken)
+	if err != nil {
+		return false, err
+	}
+	if !tokenNeedsRefresh(token, now) {
+		return false, nil
+	}
+
+	// leaving out the access token makes the token source use the refresh token
+	refreshedToken, refreshErr := refresher.TokenSource(ctx, &oauth2.Token{RefreshToken: token.RefreshToken}).Token()
+	if refreshErr != nil {
+		return false, recordTokenRefreshFailure(ctx, db, externalToken, refreshErr, now)
+	}
+	tokenString, err := json.Marshal(refreshedToken)
+	if err != nil {
+		return false, err
+	}
+	_, err = database.GetExternalTokenCollection(db).UpdateOne(
+		ctx,
+		bson.M{"_id": externalToken.ID},
+		bson.M{"$set": bson.M{
+			"token":                   string(tokenString),
+			"refresh_failure_count":   0,
+			"next_refresh_attempt_at": primitive.DateTime(0),
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+	if externalToken.RefreshFailureCount > 0 {
+		// failing to record the recovery shouldn't fail the refresh
+		_ = database.InsertExternalTokenHealthEvent(ctx, db, newTokenHealthEvent(externalToken, constants.TokenHealthRecovered, "", now))
+	}
+	return true, nil
+}
+
+func recordTokenRefreshFailure(ctx context.Context, db *mongo.Database, externalToken database.ExternalAPIToken, refreshErr error, now time.Time) error {
+	failureCount := externalToken.RefreshFailureCount + 1
+	status := constants.TokenHealthRefreshFailed
+	update := bson.M{
+		"refresh_failure_count":   failureCount,
+		"next_refresh_attempt_at": primitive.NewDateTimeFromTime(now.Add(getTokenRefreshBackoff(failureCount))),
+	}
+	if failureCount >= constants.TOKEN_REFRESH_MAX_FAILURES {
+		status = constants.TokenHealthBad
+		update["is_bad_token"] = true
+		update["bad_token_at"] = primitive.NewDateTimeFromTime(now)
+		update["bad_token_reason"] = refreshErr.Error()
+	}
+	_, err := database.GetExternalTokenCollection(db).UpdateOne(ctx, bson.M{"_id": externalToken.ID}, bson.M{"$set": update})
+	if err != nil {
+		return err
+	}
+	_ = database.InsertExternalTokenHealthEvent(ctx, db, newTokenHealthEvent(externalToken, status, refreshErr.Error(), now))
+	return refreshErr
+}
+
+func newTokenHealthEvent(externalToken database.ExternalAPIToken, status string, detail string, now time.Time) database.ExternalTokenHealthEvent {
+	return database.ExternalTokenHealthEvent{
+		TokenID:   externalToken.ID,
+		UserID:    externalToken.UserID,
+		ServiceID: externalToken.ServiceID,
+		Status:    status,
+		Detail:    detail,
+		CreatedAt: primitive.NewDateTimeFromTime(now),
+	}
+}
@@ -0,0 +1,136 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/oauth2"
+)
+
+func TestTokenNeedsRefresh(t *testing.T) {
+	now := time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)
+	assert.True(t, tokenNeedsRefresh(oauth2.Token{RefreshToken: "refresh", Expiry: now.Add(5 * time.Minute)}, now))
+	assert.True(t, tokenNeedsRefresh(oauth2.Token{RefreshToken: "refresh", Expiry: now.Add(-time.Hour)}, now))
+	assert.False(t, tokenNeedsRefresh(oauth2.Token{RefreshToken: "refresh", Expiry: now.Add(time.Hour)}, now))
+	assert.False(t, tokenNeedsRefresh(oauth2.Token{RefreshToken: "refresh"}, now))
+	assert.False(t, tokenNeedsRefresh(oauth2.Token{Expiry: now.Add(5 * time.Minute)}, now))
+}
+
+func TestGetTokenRefreshBackoff(t *testing.T) {
+	assert.Equal(t, 5*time.Minute, getTokenRefreshBackoff(1))
+	assert.Equal(t, 10*time.Minute, getTokenRefreshBackoff(2))
+	assert.Equal(t, 40*time.Minute, getTokenRefreshBackoff(4))
+	assert.Equal(t, 2*time.Hour, getTokenRefreshBackoff(10))
+}
+
+func TestRefreshExternalToken(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	now := time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)
+	tokenEndpointFails := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if tokenEndpointFails {
+			w.WriteHeader(http.StatusBadRequest)
+			_, err := w.Write([]byte(`{"error": "invalid_grant"}`))
+			assert.NoError(t, err)
+			return
+		}
+		_, err := w.Write([]byte(`{"access_token": "new-token", "token_type": "bearer", "expires_in": 3600}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	refresher := getTestLoginOauthConfig(server)
+
+	insertToken := func(t *testing.T, expiry time.Time) database.ExternalAPIToken {
+		tokenString, err := json.Marshal(oauth2.Token{AccessToken: "old-token", RefreshToken: "refresh-token", Expiry: expiry})
+		assert.NoError(t, err)
+		externalToken := database.ExternalAPIToken{
+			ServiceID: TASK_SERVICE_ID_GOOGLE,
+			UserID:    primitive.NewObjectID(),
+			Token:     string(tokenString),
+		}
+		result, err := database.GetExternalTokenCollection(db).InsertOne(context.Background(), &externalToken)
+		assert.NoError(t, err)
+		externalToken.ID = result.InsertedID.(primitive.ObjectID)
+		return externalToken
+	}
+	getToken := func(t *testing.T, tokenID primitive.ObjectID) database.ExternalAPIToken {
+		var externalToken database.ExternalAPIToken
+		assert.NoError(t, database.GetExternalTokenCollection(db).FindOne(context.Background(), bson.M{"_id": tokenID}).Decode(&externalToken))
+		return externalToken
+	}
+
+	t.Run("NotExpiringSoon", func(t *testing.T) {
+		tokenEndpointFails = false
+		externalToken := insertToken(t, now.Add(time.Hour))
+		refreshed, err := RefreshExternalToken(context.Background(), db, externalToken, refresher, now)
+		assert.NoError(t, err)
+		assert.False(t, refreshed)
+		assert.Equal(t, externalToken.Token, getToken(t, externalToken.ID).Token)
+	})
+	t.Run("Success", func(t *testing.T) {
+		tokenEndpointFails = false
+		externalToken := insertToken(t, now.Add(5*time.Minute))
+		refreshed, err := RefreshExternalToken(context.Background(), db, externalToken, refresher, now)
+		assert.NoError(t, err)
+		assert.True(t, refreshed)
+		token, err := extractOauthToken(getToken(t, externalToken.ID))
+		assert.NoError(t, err)
+		assert.Equal(t, "new-token", token.AccessToken)
+		// the refresh token is kept when the response doesn't include a new one
+		assert.Equal(t, "refresh-token", token.RefreshToken)
+	})
+	t.Run("RetriesBeforeMarkingBad", func(t *testing.T) {
+		tokenEndpointFails = true
+		externalToken := insertToken(t, now.Add(5*time.Minute))
+		for i := 1; i < constants.TOKEN_REFRESH_MAX_FAILURES; i++ {
+			attemptTime := now.Add(time.Duration(i) * time.Minute)
+			_, err := RefreshExternalToken(context.Background(), db, externalToken, refresher, attemptTime)
+			assert.Error(t, err)
+			externalToken = getToken(t, externalToken.ID)
+			assert.Equal(t, i, externalToken.RefreshFailureCount)
+			assert.False(t, externalToken.IsBadToken)
+			assert.Equal(t, primitive.NewDateTimeFromTime(attemptTime.Add(getTokenRefreshBackoff(i))), externalToken.NextRefreshAttemptAt)
+		}
+		brokeAt := now.Add(time.Hour)
+		_, err := RefreshExternalToken(context.Background(), db, externalToken, refresher, brokeAt)
+		assert.Error(t, err)
+		externalToken = getToken(t, externalToken.ID)
+		assert.True(t, externalToken.IsBadToken)
+		assert.Equal(t, primitive.NewDateTimeFromTime(brokeAt), externalToken.BadTokenAt)
+		assert.Contains(t, externalToken.BadTokenReason, "invalid_grant")
+
+		events, err := database.GetExternalTokenHealthEvents(context.Background(), db, externalToken.ID, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, constants.TOKEN_REFRESH_MAX_FAILURES, len(events))
+		assert.Equal(t, constants.TokenHealthBad, events[0].Status)
+		assert.Equal(t, constants.TokenHealthRefreshFailed, events[1].Status)
+	})
+	t.Run("Recovers", func(t *testing.T) {
+		tokenEndpointFails = true
+		externalToken := insertToken(t, now.Add(5*time.Minute))
+		_, err := RefreshExternalToken(context.Background(), db, externalToken, refresher, now)
+		assert.Error(t, err)
+		tokenEndpointFails = false
+		refreshed, err := RefreshExternalToken(context.Background(), db, getToken(t, externalToken.ID), refresher, now.Add(5*time.Minute))
+		assert.NoError(t, err)
+		assert.True(t, refreshed)
+		externalToken = getToken(t, externalToken.ID)
+		assert.Equal(t, 0, externalToken.RefreshFailureCount)
+		events, err := database.GetExternalTokenHealthEvents(context.Background(), db, externalToken.ID, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(events))
+		assert.Equal(t, constants.TokenHealthRecovered, events[0].Status)
+	})
+}
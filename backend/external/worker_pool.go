@@ -0,0 +1,67 @@
+package external
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// WorkerPool runs submitted tasks on a fixed number of goroutines
+type WorkerPool struct {
+	tasks         chan func()
+	queueDepth    int64
+	maxQueueDepth int64
+	waitGroup     sync.WaitGroup
+}
+
+func NewWorkerPool(concurrency int) *WorkerPool {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	pool := &WorkerPool{tasks: make(chan func(), concurrency)}
+	pool.waitGroup.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go pool.work()
+	}
+	return pool
+}
+
+func (pool *WorkerPool) work() {
+	defer pool.waitGroup.Done()
+	for task := range pool.tasks {
+		atomic.AddInt64(&pool.queueDepth, -1)
+		task()
+	}
+}
+
+// Submit queues task, blocking while the queue is full. Tasks shouldn't block on their callers,
+// e.g. results should go out on buffered channels, or the pool can deadlock
+func (pool *WorkerPool) Submit(task func()) {
+	depth := atomic.AddInt64(&pool.queueDepth, 1)
+	for {
+		maxDepth := atomic.LoadInt64(&pool.maxQueueDepth)
+		if depth <= maxDepth || atomic.CompareAndSwapInt64(&pool.maxQueueDepth, maxDepth, depth) {
+			break
+		}
+	}
+	pool.tasks <- task
+}
+
+// QueueDepth is the number of tasks submitted but not yet started
+func (pool *WorkerPool) QueueDepth() int {
+	return int(atomic.LoadInt64(&pool.queueDepth))
+}
+
+// MaxQueueDepth is the deepest the queue has been since the pool was created
+func (pool *WorkerPool) MaxQueueDepth() int {
+	return int(atomic.LoadInt64(&pool.maxQueueDepth))
+}
+
+// Close stops the workers once queued tasks finish. No tasks may be submitted after Close
+func (pool *WorkerPool) Close() {
+	close(pool.tasks)
+}
+
+// Wait blocks until the pool is closed and every queued task has finished
+func (pool *WorkerPool) Wait() {
+	pool.waitGroup.Wait()
+}
@@ -0,0 +1,72 @@
+package external
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerPool(t *testing.T) {
+	t.Run("RunsAllTasks", func(t *testing.T) {
+		pool := NewWorkerPool(3)
+		var completed int64
+		for i := 0; i < 20; i++ {
+			pool.Submit(func() {
+				atomic.AddInt64(&completed, 1)
+			})
+		}
+		pool.Close()
+		pool.Wait()
+		assert.Equal(t, int64(20), completed)
+		assert.Equal(t, 0, pool.QueueDepth())
+	})
+	t.Run("CapsConcurrency", func(t *testing.T) {
+		pool := NewWorkerPool(2)
+		var running int64
+		var maxRunning int64
+		var mutex sync.Mutex
+		for i := 0; i < 10; i++ {
+			pool.Submit(func() {
+				current := atomic.AddInt64(&running, 1)
+				mutex.Lock()
+				if current > maxRunning {
+					maxRunning = current
+				}
+				mutex.Unlock()
+				time.Sleep(5 * time.Millisecond)
+				atomic.AddInt64(&running, -1)
+			})
+		}
+		pool.Close()
+		pool.Wait()
+		assert.LessOrEqual(t, maxRunning, int64(2))
+	})
+	t.Run("TracksQueueDepth", func(t *testing.T) {
+		pool := NewWorkerPool(1)
+		release := make(chan bool)
+		started := make(chan bool)
+		pool.Submit(func() {
+			started <- true
+			<-release
+		})
+		<-started
+		pool.Submit(func() {})
+		assert.Equal(t, 1, pool.QueueDepth())
+		close(release)
+		pool.Close()
+		pool.Wait()
+		assert.Equal(t, 0, pool.QueueDepth())
+		assert.Equal(t, 1, pool.MaxQueueDepth())
+	})
+	t.Run("InvalidConcurrency", func(t *testing.T) {
+		pool := NewWorkerPool(0)
+		done := make(chan bool, 1)
+		pool.Submit(func() { done <- true })
+		assert.True(t, <-done)
+		pool.Close()
+		pool.Wait()
+	})
+}
@@ -0,0 +1,136 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/franchizzle/task-manager/backend/config"
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/oauth2"
+)
+
+type ZendeskConfigValues struct {
+	UserInfoURL     *string
+	TicketFetchURL  *string
+	TicketUpdateURL *string
+}
+
+type ZendeskConfig struct {
+	OauthConfig  OauthConfigWrapper
+	ConfigValues ZendeskConfigValues
+}
+
+type ZendeskService struct {
+	Config ZendeskConfig
+}
+
+type ZendeskUserInfoResponse struct {
+	User struct {
+		ID    int64  `json:"id"`
+		Email string `json:"email"`
+	} `json:"user"`
+}
+
+// getZendeskBaseURL returns the API root for the configured Zendesk account. Zendesk serves each
+// account from its own subdomain, so oauth apps are per account as well
+func getZendeskBaseURL() string {
+	return fmt.Sprintf("https://%s.zendesk.com", config.GetSettings().ZendeskSubdomain)
+}
+
+func getZendeskOauthConfig() *OauthConfig {
+	settings := config.GetSettings()
+	return &OauthConfig{Config: &oauth2.Config{
+		ClientID:     settings.ZendeskOauth.ClientID,
+		ClientSecret: settings.ZendeskOauth.ClientSecret,
+		RedirectURL:  settings.ServerURL + "link/zendesk/callback/",
+		Scopes:       []string{"read", "write"},
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  getZendeskBaseURL() + "/oauth/authorizations/new",
+			TokenURL: getZendeskBaseURL() + "/oauth/tokens",
+		},
+	}}
+}
+
+func (zendesk ZendeskService) GetLinkURL(stateTokenID primitive.ObjectID, userID primitive.ObjectID) (*string, error) {
+	if config.GetSettings().ZendeskSubdomain == "" {
+		return nil, errors.New("zendesk is not configured")
+	}
+	authURL := zendesk.Config.OauthConfig.AuthCodeURL(stateTokenID.Hex())
+	return &authURL, nil
+}
+
+func (zendesk ZendeskService) GetSignupURL(stateTokenID primitive.ObjectID, forcePrompt bool) (*string, error) {
+	return nil, errors.New("zendesk does not support signup")
+}
+
+func (zendesk ZendeskService) HandleLinkCallback(db *mongo.Database, params CallbackParams, userID primitive.ObjectID) error {
+	parentCtx := context.Background()
+	extCtx, cancel := context.WithTimeout(parentCtx, constants.ExternalTimeout)
+	defer cancel()
+	token, err := zendesk.Config.OauthConfig.Exchange(extCtx, *params.Oauth2Code)
+	logger := logging.GetSentryLogger()
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch token from Zendesk")
+		return errors.New("internal server error")
+	}
+
+	userInfoURL := getZendeskBaseURL() + "/api/v2/users/me.json"
+	client := oauth2.NewClient(extCtx, oauth2.StaticTokenSource(token))
+	if zendesk.Config.ConfigValues.UserInfoURL != nil {
+		userInfoURL = *zendesk.Config.ConfigValues.UserInfoURL
+		client = http.DefaultClient
+	}
+	var userInfo ZendeskUserInfoResponse
+	err = getJSON(client, userInfoURL, &userInfo)
+	if err != nil || userInfo.User.Email == "" {
+		logger.Error().Err(err).Msg("failed to fetch zendesk user info")
+		return errors.New("internal server error")
+	}
+
+	tokenString, err := json.Marshal(&token)
+	if err != nil {
+		logger.Error().Err(err).Msg("error parsing token")
+		return errors.New("internal server error")
+	}
+
+	dbCtx, cancel := context.WithTimeout(parentCtx, constants.DatabaseTimeout)
+	defer cancel()
+	accountID := userInfo.User.Email
+	_, err = database.GetExternalTokenCollection(db).UpdateOne(
+		dbCtx,
+		bson.M{"$and": []bson.M{{"user_id": userID}, {"service_id": TASK_SERVICE_ID_ZENDESK}, {"account_id": accountID}}},
+		bson.M{"$set": &database.ExternalAPIToken{
+			UserID:         userID,
+			ServiceID:      TASK_SERVICE_ID_ZENDESK,
+			Token:          string(tokenString),
+			AccountID:      accountID,
+			DisplayID:      accountID,
+			ExternalID:     fmt.Sprint(userInfo.User.ID),
+			IsUnlinkable:   true,
+			IsPrimaryLogin: false,
+		}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		logger.Error().Err(err).Msg("error saving token")
+		return errors.New("internal server error")
+	}
+	return nil
+}
+
+func (zendesk ZendeskService) HandleSignupCallback(ctx context.Context, db *mongo.Database, params CallbackParams) (primitive.ObjectID, *bool, *string, error) {
+	return primitive.NilObjectID, nil, nil, errors.New("zendesk does not support signup")
+}
+
+func getZendeskHttpClient(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string) *http.Client {
+	return getExternalOauth2Client(ctx, db, userID, accountID, TASK_SERVICE_ID_ZENDESK, getZendeskOauthConfig())
+}
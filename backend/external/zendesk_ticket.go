@@ -0,0 +1,232 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ZendeskTicketSource struct {
+	Zendesk ZendeskService
+}
+
+const (
+	ZendeskStatusSolved = "solved"
+	ZendeskStatusClosed = "closed"
+)
+
+type ZendeskSLAPolicyMetric struct {
+	BreachAt *time.Time `json:"breach_at"`
+	Stage    string     `json:"stage"`
+}
+
+type ZendeskTicket struct {
+	ID          int64      `json:"id"`
+	Subject     string     `json:"subject"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DueAt       *time.Time `json:"due_at"`
+	SLAs        struct {
+		PolicyMetrics []ZendeskSLAPolicyMetric `json:"policy_metrics"`
+	} `json:"slas"`
+}
+
+type ZendeskTicketsResponse struct {
+	Tickets []ZendeskTicket `json:"tickets"`
+}
+
+type ZendeskTicketComment struct {
+	Body   string `json:"body"`
+	Public bool   `json:"public"`
+}
+
+type ZendeskTicketUpdateFields struct {
+	Subject *string               `json:"subject,omitempty"`
+	Status  *string               `json:"status,omitempty"`
+	Comment *ZendeskTicketComment `json:"comment,omitempty"`
+}
+
+type ZendeskTicketUpdateBody struct {
+	Ticket ZendeskTicketUpdateFields `json:"ticket"`
+}
+
+func (zendeskTicket ZendeskTicketSource) GetEvents(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, startTime time.Time, endTime time.Time, scopes []string, result chan<- CalendarResult) {
+	result <- emptyCalendarResult(errors.New("zendesk cannot fetch events"))
+}
+
+func (zendeskTicket ZendeskTicketSource) GetTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- TaskResult) {
+	client := getZendeskHttpClient(ctx, db, userID, accountID)
+
+	userInfoURL := getZendeskBaseURL() + "/api/v2/users/me.json"
+	if zendeskTicket.Zendesk.Config.ConfigValues.UserInfoURL != nil {
+		userInfoURL = *zendeskTicket.Zendesk.Config.ConfigValues.UserInfoURL
+		client = http.DefaultClient
+	}
+
+	var userInfo ZendeskUserInfoResponse
+	err := getJSON(client, userInfoURL, &userInfo)
+	logger := logging.GetSentryLogger()
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to get zendesk user ID")
+		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_ZENDESK)
+		return
+	}
+
+	ticketFetchURL := fmt.Sprintf(getZendeskBaseURL()+"/api/v2/users/%d/tickets/assigned.json?include=slas&sort_by=updated_at&sort_order=desc", userInfo.User.ID)
+	if zendeskTicket.Zendesk.Config.ConfigValues.TicketFetchURL != nil {
+		ticketFetchURL = *zendeskTicket.Zendesk.Config.ConfigValues.TicketFetchURL
+		client = http.DefaultClient
+	}
+
+	var zendeskTickets ZendeskTicketsResponse
+	err = getJSON(client, ticketFetchURL, &zendeskTickets)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch zendesk tickets")
+		result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_ZENDESK)
+		return
+	}
+
+	defaultTaskSectionID := database.GetDefaultTaskSectionID(ctx, db, userID, TASK_SOURCE_ID_ZENDESK)
+	var tasks []*database.Task
+	for _, ticket := range zendeskTickets.Tickets {
+		// the assigned list includes resolved tickets, which have nothing left to do
+		if ticket.Status == ZendeskStatusSolved || ticket.Status == ZendeskStatusClosed {
+			continue
+		}
+		title := ticket.Subject
+		body := ticket.Description
+		task := &database.Task{
+			UserID:            userID,
+			IDExternal:        fmt.Sprint(ticket.ID),
+			IDTaskSection:     defaultTaskSectionID,
+			Deeplink:          fmt.Sprintf("%s/agent/tickets/%d", getZendeskBaseURL(), ticket.ID),
+			SourceID:          TASK_SOURCE_ID_ZENDESK,
+			Title:             &title,
+			Body:              &body,
+			SourceAccountID:   accountID,
+			CreatedAtExternal: primitive.NewDateTimeFromTime(ticket.CreatedAt),
+		}
+		if dueDate := getZendeskTicketDueDate(ticket); dueDate != nil {
+			dueDatePrim := primitive.NewDateTimeFromTime(*dueDate)
+			task.DueDate = &dueDatePrim
+		}
+		isCompleted := false
+		dbTask, err := database.UpdateOrCreateTask(
+			ctx,
+			db,
+			userID,
+			task.IDExternal,
+			task.SourceID,
+			task,
+			database.Task{
+				Title:       task.Title,
+				Body:        task.Body,
+				DueDate:     task.DueDate,
+				IsCompleted: &isCompleted,
+			},
+			nil,
+		)
+		if err != nil {
+			result <- emptyTaskResultWithSource(err, TASK_SOURCE_ID_ZENDESK)
+			return
+		}
+		task.HasBeenReordered = dbTask.HasBeenReordered
+		task.ID = dbTask.ID
+		task.IDOrdering = dbTask.IDOrdering
+		task.IDTaskSection = dbTask.IDTaskSection
+		task.TimeAllocation = dbTask.TimeAllocation
+		tasks = append(tasks, task)
+	}
+
+	result <- TaskResult{
+		Tasks: tasks,
+	}
+}
+
+// getZendeskTicketDueDate uses the earliest SLA breach still to come, so the task is due before any
+// target is missed. Tickets without an active SLA fall back to the due date set on task type tickets
+func getZendeskTicketDueDate(ticket ZendeskTicket) *time.Time {
+	var dueDate *time.Time
+	for _, metric := range ticket.SLAs.PolicyMetrics {
+		if metric.BreachAt == nil || metric.Stage == "achieved" {
+			continue
+		}
+		if dueDate == nil || metric.BreachAt.Before(*dueDate) {
+			dueDate = metric.BreachAt
+		}
+	}
+	if dueDate == nil {
+		return ticket.DueAt
+	}
+	return dueDate
+}
+
+func (zendeskTicket ZendeskTicketSource) GetPullRequests(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, result chan<- PullRequestResult) {
+	result <- emptyPullRequestResult(nil, false)
+}
+
+func (zendeskTicket ZendeskTicketSource) ModifyTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, issueID string, updateFields *database.Task, task *database.Task) error {
+	fields := ZendeskTicketUpdateFields{Subject: updateFields.Title}
+	if updateFields.IsCompleted != nil && *updateFields.IsCompleted {
+		status := ZendeskStatusSolved
+		fields.Status = &status
+	}
+	if fields.Subject == nil && fields.Status == nil {
+		return nil
+	}
+	err := zendeskTicket.updateTicket(ctx, db, userID, accountID, issueID, fields)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to update zendesk ticket")
+	}
+	return err
+}
+
+func (zendeskTicket ZendeskTicketSource) updateTicket(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, ticketID string, fields ZendeskTicketUpdateFields) error {
+	client := getZendeskHttpClient(ctx, db, userID, accountID)
+	ticketUpdateURL := fmt.Sprintf(getZendeskBaseURL()+"/api/v2/tickets/%s.json", ticketID)
+	if zendeskTicket.Zendesk.Config.ConfigValues.TicketUpdateURL != nil {
+		ticketUpdateURL = *zendeskTicket.Zendesk.Config.ConfigValues.TicketUpdateURL
+		client = http.DefaultClient
+	}
+	bodyJson, err := json.Marshal(ZendeskTicketUpdateBody{Ticket: fields})
+	if err != nil {
+		return err
+	}
+	return requestJSON(client, "PUT", ticketUpdateURL, string(bodyJson), EmptyResponsePlaceholder)
+}
+
+func (zendeskTicket ZendeskTicketSource) CreateNewTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, task TaskCreationObject) (primitive.ObjectID, error) {
+	return primitive.NilObjectID, errors.New("has not been implemented yet")
+}
+
+func (zendeskTicket ZendeskTicketSource) CreateNewEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error {
+	return errors.New("has not been implemented yet")
+}
+
+func (zendeskTicket ZendeskTicketSource) ModifyEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, eventID string, updateFields *EventModifyObject) error {
+	return errors.New("has not been implemented yet")
+}
+
+func (zendeskTicket ZendeskTicketSource) DeleteEvent(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error {
+	return errors.New("has not been implemented yet")
+}
+
+// AddComment posts the comment as an internal note, so it is only visible to agents and never emailed
+// to the requester
+func (zendeskTicket ZendeskTicketSource) AddComment(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error {
+	fields := ZendeskTicketUpdateFields{Comment: &ZendeskTicketComment{Body: comment.Body, Public: false}}
+	err := zendeskTicket.updateTicket(ctx, db, userID, accountID, task.IDExternal, fields)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to add zendesk internal note")
+	}
+	return err
+}
@@ -0,0 +1,183 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/testutils"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestLoadZendeskTickets(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	taskCollection := database.GetTaskCollection(db)
+
+	userInfoServerSuccess := testutils.GetMockAPIServer(t, 200, `{"user": {"id": 4242, "email": "agent@example.com"}}`)
+	defer userInfoServerSuccess.Close()
+
+	t.Run("BadUserInfoStatusCode", func(t *testing.T) {
+		userInfoServer := testutils.GetMockAPIServer(t, 401, "")
+		defer userInfoServer.Close()
+		zendeskTicket := ZendeskTicketSource{Zendesk: ZendeskService{Config: ZendeskConfig{ConfigValues: ZendeskConfigValues{UserInfoURL: &userInfoServer.URL}}}}
+
+		var taskResult = make(chan TaskResult)
+		go zendeskTicket.GetTasks(context.Background(), db, primitive.NewObjectID(), "agent@example.com", taskResult)
+		result := <-taskResult
+		assert.Error(t, result.Error)
+		assert.Equal(t, "bad status code: 401", result.Error.Error())
+		assert.Equal(t, 0, len(result.Tasks))
+	})
+	t.Run("BadTicketStatusCode", func(t *testing.T) {
+		ticketServer := testutils.GetMockAPIServer(t, 500, "")
+		defer ticketServer.Close()
+		zendeskTicket := ZendeskTicketSource{Zendesk: ZendeskService{Config: ZendeskConfig{ConfigValues: ZendeskConfigValues{
+			UserInfoURL:    &userInfoServerSuccess.URL,
+			TicketFetchURL: &ticketServer.URL,
+		}}}}
+
+		var taskResult = make(chan TaskResult)
+		go zendeskTicket.GetTasks(context.Background(), db, primitive.NewObjectID(), "agent@example.com", taskResult)
+		result := <-taskResult
+		assert.Error(t, result.Error)
+		assert.Equal(t, "bad status code: 500", result.Error.Error())
+		assert.Equal(t, 0, len(result.Tasks))
+	})
+	t.Run("Success", func(t *testing.T) {
+		ticketServer := testutils.GetMockAPIServer(t, 200, `{"tickets": [
+			{"id": 35436, "subject": "Printer on fire", "description": "Help!", "status": "open", "created_at": "2022-03-01T10:00:00Z",
+				"slas": {"policy_metrics": [
+					{"breach_at": "2022-03-02T10:00:00Z", "stage": "active", "metric": "next_reply_time"},
+					{"breach_at": "2022-03-01T18:00:00Z", "stage": "paused", "metric": "requester_wait_time"},
+					{"breach_at": "2022-03-01T12:00:00Z", "stage": "achieved", "metric": "first_reply_time"}
+				]}},
+			{"id": 35437, "subject": "Already fixed", "description": "", "status": "solved", "created_at": "2022-03-01T10:00:00Z"}
+		]}`)
+		defer ticketServer.Close()
+		zendeskTicket := ZendeskTicketSource{Zendesk: ZendeskService{Config: ZendeskConfig{ConfigValues: ZendeskConfigValues{
+			UserInfoURL:    &userInfoServerSuccess.URL,
+			TicketFetchURL: &ticketServer.URL,
+		}}}}
+		userID := primitive.NewObjectID()
+
+		title := "Printer on fire"
+		body := "Help!"
+		dueDate := primitive.NewDateTimeFromTime(time.Date(2022, 3, 1, 18, 0, 0, 0, time.UTC))
+		expectedTask := database.Task{
+			IDExternal:        "35436",
+			IDTaskSection:     constants.IDTaskSectionDefault,
+			Deeplink:          getZendeskBaseURL() + "/agent/tickets/35436",
+			Title:             &title,
+			Body:              &body,
+			SourceID:          TASK_SOURCE_ID_ZENDESK,
+			SourceAccountID:   "agent@example.com",
+			UserID:            userID,
+			CreatedAtExternal: primitive.NewDateTimeFromTime(time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)),
+			DueDate:           &dueDate,
+		}
+
+		var taskResult = make(chan TaskResult)
+		go zendeskTicket.GetTasks(context.Background(), db, userID, "agent@example.com", taskResult)
+		result := <-taskResult
+		assert.NoError(t, result.Error)
+		assert.Equal(t, 1, len(result.Tasks))
+		assertTasksEqual(t, &expectedTask, result.Tasks[0])
+
+		var taskFromDB database.Task
+		err := taskCollection.FindOne(context.Background(), bson.M{"user_id": userID}).Decode(&taskFromDB)
+		assert.NoError(t, err)
+		assertTasksEqual(t, &expectedTask, &taskFromDB)
+	})
+}
+
+func TestGetZendeskTicketDueDate(t *testing.T) {
+	earlier := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	later := time.Date(2022, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	t.Run("NoSLAOrDueDate", func(t *testing.T) {
+		assert.Nil(t, getZendeskTicketDueDate(ZendeskTicket{}))
+	})
+	t.Run("FallsBackToDueAt", func(t *testing.T) {
+		assert.Equal(t, &later, getZendeskTicketDueDate(ZendeskTicket{DueAt: &later}))
+	})
+	t.Run("EarliestBreachWins", func(t *testing.T) {
+		ticket := ZendeskTicket{DueAt: &later}
+		ticket.SLAs.PolicyMetrics = []ZendeskSLAPolicyMetric{{BreachAt: &later, Stage: "active"}, {BreachAt: &earlier, Stage: "active"}}
+		assert.Equal(t, &earlier, getZendeskTicketDueDate(ticket))
+	})
+	t.Run("SkipsAchievedMetrics", func(t *testing.T) {
+		ticket := ZendeskTicket{}
+		ticket.SLAs.PolicyMetrics = []ZendeskSLAPolicyMetric{{BreachAt: &earlier, Stage: "achieved"}, {BreachAt: &later, Stage: "active"}}
+		assert.Equal(t, &later, getZendeskTicketDueDate(ticket))
+	})
+}
+
+func TestModifyZendeskTicket(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+
+	t.Run("BadResponse", func(t *testing.T) {
+		ticketUpdateServer := testutils.GetMockAPIServer(t, 422, "")
+		defer ticketUpdateServer.Close()
+		zendeskTicket := ZendeskTicketSource{Zendesk: ZendeskService{Config: ZendeskConfig{ConfigValues: ZendeskConfigValues{TicketUpdateURL: &ticketUpdateServer.URL}}}}
+
+		isCompleted := true
+		err := zendeskTicket.ModifyTask(context.Background(), db, primitive.NewObjectID(), "agent@example.com", "35436", &database.Task{IsCompleted: &isCompleted}, nil)
+		assert.Error(t, err)
+		assert.Equal(t, "bad status code: 422", err.Error())
+	})
+	t.Run("MarkAsDoneSolvesTicket", func(t *testing.T) {
+		var requestBody ZendeskTicketUpdateBody
+		ticketUpdateServer := getZendeskRecordingServer(t, &requestBody)
+		defer ticketUpdateServer.Close()
+		zendeskTicket := ZendeskTicketSource{Zendesk: ZendeskService{Config: ZendeskConfig{ConfigValues: ZendeskConfigValues{TicketUpdateURL: &ticketUpdateServer.URL}}}}
+
+		isCompleted := true
+		title := "New subject"
+		err := zendeskTicket.ModifyTask(context.Background(), db, primitive.NewObjectID(), "agent@example.com", "35436", &database.Task{IsCompleted: &isCompleted, Title: &title}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, ZendeskStatusSolved, *requestBody.Ticket.Status)
+		assert.Equal(t, title, *requestBody.Ticket.Subject)
+		assert.Nil(t, requestBody.Ticket.Comment)
+	})
+	t.Run("NothingToUpdate", func(t *testing.T) {
+		zendeskTicket := ZendeskTicketSource{}
+		body := "only kept locally"
+		err := zendeskTicket.ModifyTask(context.Background(), db, primitive.NewObjectID(), "agent@example.com", "35436", &database.Task{Body: &body}, nil)
+		assert.NoError(t, err)
+	})
+	t.Run("AddCommentIsInternalNote", func(t *testing.T) {
+		var requestBody ZendeskTicketUpdateBody
+		ticketUpdateServer := getZendeskRecordingServer(t, &requestBody)
+		defer ticketUpdateServer.Close()
+		zendeskTicket := ZendeskTicketSource{Zendesk: ZendeskService{Config: ZendeskConfig{ConfigValues: ZendeskConfigValues{TicketUpdateURL: &ticketUpdateServer.URL}}}}
+
+		err := zendeskTicket.AddComment(context.Background(), db, primitive.NewObjectID(), "agent@example.com", database.Comment{Body: "Checked the logs"}, &database.Task{IDExternal: "35436"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Checked the logs", requestBody.Ticket.Comment.Body)
+		assert.False(t, requestBody.Ticket.Comment.Public)
+		assert.Nil(t, requestBody.Ticket.Status)
+	})
+}
+
+func getZendeskRecordingServer(t *testing.T, requestBody *ZendeskTicketUpdateBody) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(body, requestBody))
+		w.WriteHeader(200)
+		w.Write([]byte(`{"ticket": {}}`))
+	}))
+}
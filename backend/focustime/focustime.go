@@ -0,0 +1,204 @@
+package focustime
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/ordering"
+	"github.com/franchizzle/task-manager/backend/settings"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// windows shorter than this aren't worth scheduling into
+	MINIMUM_FOCUS_BLOCK     = 15 * time.Minute
+	DEFAULT_SCHEDULING_DAYS = 5
+	MAX_SCHEDULING_DAYS     = 14
+	// a pull request review gets a minimum block for every this many changed lines
+	REVIEW_LINES_PER_BLOCK = 100
+	MAX_REVIEW_BLOCK       = 2 * time.Hour
+)
+
+type Window struct {
+	Start time.Time
+	End   time.Time
+}
+
+type FocusBlock struct {
+	TaskID        primitive.ObjectID `json:"task_id"`
+	Title         string             `json:"title"`
+	DatetimeStart time.Time          `json:"datetime_start"`
+	DatetimeEnd   time.Time          `json:"datetime_end"`
+}
+
+// GetFocusBlocks proposes focus blocks for the user's unscheduled tasks between start and end.
+// Days are split on start's timezone, so it should be in the user's timezone
+func GetFocusBlocks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, start time.Time, end time.Time, workingHours settings.WorkingHours) ([]FocusBlock, error) {
+	tasks, err := GetUnscheduledTasks(ctx, db, userID, start)
+	if err != nil {
+		return nil, err
+	}
+	events, err := GetEventsBetween(ctx, db, userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return ScheduleTasks(tasks, GetFreeWindows(events, start, end, workingHours)), nil
+}
+
+// GetUnscheduledTasks returns the user's active top level tasks with a time allocation and no
+// upcoming event linked to them, in their current order
+func GetUnscheduledTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, now time.Time) ([]database.Task, error) {
+	activeTasks, err := database.GetActiveTasks(ctx, db, userID)
+	if err != nil {
+		return nil, err
+	}
+	linkedEvents, err := database.GetCalendarEvents(ctx, db, userID, &[]bson.M{
+		{"linked_task_id": bson.M{"$exists": true}},
+		{"datetime_end": bson.M{"$gt": now}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	isScheduled := map[primitive.ObjectID]bool{}
+	for _, event := range *linkedEvents {
+		isScheduled[event.LinkedTaskID] = true
+	}
+
+	tasks := []database.Task{}
+	for _, task := range *activeTasks {
+		if task.ParentTaskID != primitive.NilObjectID || task.Title == nil || task.TimeAllocation == nil || *task.TimeAllocation <= 0 || isScheduled[task.ID] {
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return ordering.Less(tasks[i].OrderingKey, tasks[i].IDOrdering, tasks[j].OrderingKey, tasks[j].IDOrdering)
+	})
+	return tasks, nil
+}
+
+// GetEventsBetween returns the user's events overlapping the time range
+func GetEventsBetween(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, start time.Time, end time.Time) ([]database.CalendarEvent, error) {
+	events, err := database.GetCalendarEvents(ctx, db, userID, &[]bson.M{
+		{"datetime_end": bson.M{"$gt": start}},
+		{"datetime_start": bson.M{"$lt": end}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return *events, nil
+}
+
+// GetFreeWindows returns the parts of the working hours between start and end that no event overlaps
+func GetFreeWindows(events []database.CalendarEvent, start time.Time, end time.Time, workingHours settings.WorkingHours) []Window {
+	busy := getBusyWindows(events, start.Location())
+
+	windows := []Window{}
+	for day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location()); day.Before(end); day = day.AddDate(0, 0, 1) {
+		if !workingHours.IsWorkday(day.Weekday()) {
+			continue
+		}
+		window := Window{Start: day.Add(workingHours.Start), End: day.Add(workingHours.End)}
+		if window.Start.Before(start) {
+			window.Start = start
+		}
+		if window.End.After(end) {
+			window.End = end
+		}
+		windows = append(windows, subtractBusy(window, busy, MINIMUM_FOCUS_BLOCK)...)
+	}
+	return windows
+}
+
+// GetFreeSlots returns the time between start and end that no event overlaps, in stretches of at
+// least minimumDuration
+func GetFreeSlots(events []database.CalendarEvent, start time.Time, end time.Time, minimumDuration time.Duration) []Window {
+	return subtractBusy(Window{Start: start, End: end}, getBusyWindows(events, start.Location()), minimumDuration)
+}
+
+// getBusyWindows returns the events' times sorted by start
+func getBusyWindows(events []database.CalendarEvent, location *time.Location) []Window {
+	busy := []Window{}
+	for _, event := range events {
+		busy = append(busy, Window{Start: event.DatetimeStart.Time().In(location), End: event.DatetimeEnd.Time().In(location)})
+	}
+	sort.Slice(busy, func(i, j int) bool {
+		return busy[i].Start.Before(busy[j].Start)
+	})
+	return busy
+}
+
+func subtractBusy(window Window, busy []Window, minimumDuration time.Duration) []Window {
+	windows := []Window{}
+	for _, busyWindow := range busy {
+		if !busyWindow.End.After(window.Start) || !busyWindow.Start.Before(window.End) {
+			continue
+		}
+		if busyWindow.Start.After(window.Start) {
+			windows = appendIfLongEnough(windows, Window{Start: window.Start, End: busyWindow.Start}, minimumDuration)
+		}
+		if busyWindow.End.After(window.Start) {
+			window.Start = busyWindow.End
+		}
+	}
+	return appendIfLongEnough(windows, window, minimumDuration)
+}
+
+func appendIfLongEnough(windows []Window, window Window, minimumDuration time.Duration) []Window {
+	if window.End.Sub(window.Start) < minimumDuration {
+		return windows
+	}
+	return append(windows, window)
+}
+
+// ScheduleTasks places each task at the start of the earliest free window its time allocation
+// fits in, in the order given. Tasks that don't fit in any window are left out
+func ScheduleTasks(tasks []database.Task, windows []Window) []FocusBlock {
+	remaining := append([]Window{}, windows...)
+	blocks := []FocusBlock{}
+	for _, task := range tasks {
+		duration := time.Duration(*task.TimeAllocation)
+		for idx, window := range remaining {
+			if window.End.Sub(window.Start) < duration {
+				continue
+			}
+			blocks = append(blocks, FocusBlock{
+				TaskID:        task.ID,
+				Title:         *task.Title,
+				DatetimeStart: window.Start,
+				DatetimeEnd:   window.Start.Add(duration),
+			})
+			remaining[idx].Start = window.Start.Add(duration)
+			break
+		}
+	}
+	return blocks
+}
+
+// GetReviewDuration sizes a pull request review block by the number of changed lines, from a
+// minimum block for small changes up to MAX_REVIEW_BLOCK
+func GetReviewDuration(additions int, deletions int) time.Duration {
+	blocks := (additions + deletions + REVIEW_LINES_PER_BLOCK - 1) / REVIEW_LINES_PER_BLOCK
+	duration := time.Duration(blocks) * MINIMUM_FOCUS_BLOCK
+	if duration < MINIMUM_FOCUS_BLOCK {
+		return MINIMUM_FOCUS_BLOCK
+	}
+	if duration > MAX_REVIEW_BLOCK {
+		return MAX_REVIEW_BLOCK
+	}
+	return duration
+}
+
+// GetEarliestSlot returns the start of the earliest window that duration fits in, or nil if none do
+func GetEarliestSlot(windows []Window, duration time.Duration) *Window {
+	for _, window := range windows {
+		if window.End.Sub(window.Start) >= duration {
+			return &Window{Start: window.Start, End: window.Start.Add(duration)}
+		}
+	}
+	return nil
+}
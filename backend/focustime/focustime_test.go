@@ -0,0 +1,168 @@
+package focustime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/settings"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func getEvent(start time.Time, end time.Time) database.CalendarEvent {
+	return database.CalendarEvent{DatetimeStart: primitive.NewDateTimeFromTime(start), DatetimeEnd: primitive.NewDateTimeFromTime(end)}
+}
+
+func getTask(title string, duration time.Duration) database.Task {
+	allocation := duration.Nanoseconds()
+	return database.Task{ID: primitive.NewObjectID(), Title: &title, TimeAllocation: &allocation}
+}
+
+func TestGetFreeWindows(t *testing.T) {
+	// a Friday
+	start := time.Date(2023, time.January, 6, 8, 0, 0, 0, time.UTC)
+	at := func(day int, hour int, minute int) time.Time {
+		return time.Date(2023, time.January, day, hour, minute, 0, 0, time.UTC)
+	}
+	t.Run("NoEvents", func(t *testing.T) {
+		windows := GetFreeWindows([]database.CalendarEvent{}, start, start.AddDate(0, 0, 4), settings.DefaultWorkingHours)
+		assert.Equal(t, []Window{
+			{Start: at(6, 9, 0), End: at(6, 17, 0)},
+			{Start: at(9, 9, 0), End: at(9, 17, 0)},
+		}, windows)
+	})
+	t.Run("StartsDuringWorkingHours", func(t *testing.T) {
+		windows := GetFreeWindows([]database.CalendarEvent{}, at(6, 13, 0), at(6, 15, 0), settings.DefaultWorkingHours)
+		assert.Equal(t, []Window{{Start: at(6, 13, 0), End: at(6, 15, 0)}}, windows)
+	})
+	t.Run("SkipsEvents", func(t *testing.T) {
+		events := []database.CalendarEvent{
+			getEvent(at(6, 11, 0), at(6, 12, 0)),
+			getEvent(at(6, 8, 0), at(6, 9, 30)),
+			// overlaps the previous event
+			getEvent(at(6, 11, 30), at(6, 13, 0)),
+			// leaves a gap too short for a focus block
+			getEvent(at(6, 13, 10), at(6, 16, 0)),
+		}
+		windows := GetFreeWindows(events, start, at(6, 23, 0), settings.DefaultWorkingHours)
+		assert.Equal(t, []Window{
+			{Start: at(6, 9, 30), End: at(6, 11, 0)},
+			{Start: at(6, 16, 0), End: at(6, 17, 0)},
+		}, windows)
+	})
+	t.Run("UsesStartTimezone", func(t *testing.T) {
+		location := time.FixedZone("", -8*60*60)
+		localStart := time.Date(2023, time.January, 6, 16, 0, 0, 0, location)
+		windows := GetFreeWindows([]database.CalendarEvent{}, localStart, localStart.Add(2*time.Hour), settings.DefaultWorkingHours)
+		assert.Equal(t, []Window{{Start: localStart, End: localStart.Add(time.Hour)}}, windows)
+	})
+}
+
+func TestGetFreeSlots(t *testing.T) {
+	start := time.Date(2023, time.January, 7, 6, 0, 0, 0, time.UTC)
+	events := []database.CalendarEvent{
+		getEvent(start.Add(time.Hour), start.Add(2*time.Hour)),
+		getEvent(start.Add(150*time.Minute), start.Add(3*time.Hour)),
+	}
+	t.Run("IgnoresWorkingHours", func(t *testing.T) {
+		slots := GetFreeSlots(events, start, start.Add(4*time.Hour), 30*time.Minute)
+		assert.Equal(t, []Window{
+			{Start: start, End: start.Add(time.Hour)},
+			{Start: start.Add(2 * time.Hour), End: start.Add(150 * time.Minute)},
+			{Start: start.Add(3 * time.Hour), End: start.Add(4 * time.Hour)},
+		}, slots)
+	})
+	t.Run("SkipsShortSlots", func(t *testing.T) {
+		slots := GetFreeSlots(events, start, start.Add(4*time.Hour), time.Hour)
+		assert.Equal(t, []Window{
+			{Start: start, End: start.Add(time.Hour)},
+			{Start: start.Add(3 * time.Hour), End: start.Add(4 * time.Hour)},
+		}, slots)
+	})
+}
+
+func TestScheduleTasks(t *testing.T) {
+	start := time.Date(2023, time.January, 6, 9, 0, 0, 0, time.UTC)
+	windows := []Window{
+		{Start: start, End: start.Add(time.Hour)},
+		{Start: start.Add(2 * time.Hour), End: start.Add(5 * time.Hour)},
+	}
+	tasks := []database.Task{
+		getTask("two hours", 2*time.Hour),
+		getTask("half hour", 30*time.Minute),
+		getTask("too long", 4*time.Hour),
+		getTask("hour", time.Hour),
+	}
+	blocks := ScheduleTasks(tasks, windows)
+	assert.Equal(t, []FocusBlock{
+		{TaskID: tasks[0].ID, Title: "two hours", DatetimeStart: start.Add(2 * time.Hour), DatetimeEnd: start.Add(4 * time.Hour)},
+		{TaskID: tasks[1].ID, Title: "half hour", DatetimeStart: start, DatetimeEnd: start.Add(30 * time.Minute)},
+		{TaskID: tasks[3].ID, Title: "hour", DatetimeStart: start.Add(4 * time.Hour), DatetimeEnd: start.Add(5 * time.Hour)},
+	}, blocks)
+	// the windows passed in aren't changed
+	assert.Equal(t, start, windows[0].Start)
+}
+
+func TestGetReviewDuration(t *testing.T) {
+	assert.Equal(t, MINIMUM_FOCUS_BLOCK, GetReviewDuration(0, 0))
+	assert.Equal(t, MINIMUM_FOCUS_BLOCK, GetReviewDuration(60, 40))
+	assert.Equal(t, 30*time.Minute, GetReviewDuration(60, 41))
+	assert.Equal(t, time.Hour, GetReviewDuration(350, 0))
+	assert.Equal(t, MAX_REVIEW_BLOCK, GetReviewDuration(5000, 2000))
+}
+
+func TestGetEarliestSlot(t *testing.T) {
+	start := time.Date(2023, time.January, 6, 9, 0, 0, 0, time.UTC)
+	windows := []Window{
+		{Start: start, End: start.Add(30 * time.Minute)},
+		{Start: start.Add(2 * time.Hour), End: start.Add(5 * time.Hour)},
+	}
+	assert.Equal(t, &Window{Start: start, End: start.Add(15 * time.Minute)}, GetEarliestSlot(windows, 15*time.Minute))
+	assert.Equal(t, &Window{Start: start.Add(2 * time.Hour), End: start.Add(3 * time.Hour)}, GetEarliestSlot(windows, time.Hour))
+	assert.Nil(t, GetEarliestSlot(windows, 4*time.Hour))
+}
+
+func TestGetUnscheduledTasks(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	ctx := context.Background()
+	now := time.Now()
+	userID := primitive.NewObjectID()
+
+	notCompleted := false
+	insertTask := func(title string, idOrdering int, timeAllocation *int64) primitive.ObjectID {
+		result, err := database.GetTaskCollection(db).InsertOne(ctx, database.Task{
+			UserID:         userID,
+			Title:          &title,
+			IsCompleted:    &notCompleted,
+			IDOrdering:     idOrdering,
+			TimeAllocation: timeAllocation,
+		})
+		assert.NoError(t, err)
+		return result.InsertedID.(primitive.ObjectID)
+	}
+	hour := time.Hour.Nanoseconds()
+	second := insertTask("second", 2, &hour)
+	first := insertTask("first", 1, &hour)
+	insertTask("no allocation", 3, nil)
+	scheduled := insertTask("scheduled", 4, &hour)
+	previouslyScheduled := insertTask("previously scheduled", 5, &hour)
+	for taskID, start := range map[primitive.ObjectID]time.Time{scheduled: now.Add(time.Hour), previouslyScheduled: now.Add(-2 * time.Hour)} {
+		event := getEvent(start, start.Add(time.Hour))
+		event.UserID = userID
+		event.LinkedTaskID = taskID
+		_, err := database.GetCalendarEventCollection(db).InsertOne(ctx, event)
+		assert.NoError(t, err)
+	}
+
+	tasks, err := GetUnscheduledTasks(ctx, db, userID, now)
+	assert.NoError(t, err)
+	taskIDs := []primitive.ObjectID{}
+	for _, task := range tasks {
+		taskIDs = append(taskIDs, task.ID)
+	}
+	assert.Equal(t, []primitive.ObjectID{first, second, previouslyScheduled}, taskIDs)
+}
@@ -47,3 +47,22 @@ func EnsureJobOnlyRunsOncePerHour(jobName string) (primitive.ObjectID, error) {
 	lockID := primitive.NewObjectID()
 	return lockID, lockClient.XLock(context.Background(), resourceName, lockID.Hex(), lock.LockDetails{})
 }
+
+func EnsureJobOnlyRunsOncePerInterval(jobName string, interval time.Duration) (primitive.ObjectID, error) {
+	db, cleanup, err := database.GetDBConnection()
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	defer cleanup()
+
+	lockClient := lock.NewClient(database.GetJobLocksCollection(db))
+	err = lockClient.CreateIndexes(context.Background())
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+
+	resourceName := jobName + "_" + time.Now().UTC().Truncate(interval).Format("01-02-2006 15:04")
+	// leave resource locked forever so all future job attempts in this interval will fail
+	lockID := primitive.NewObjectID()
+	return lockID, lockClient.XLock(context.Background(), resourceName, lockID.Hex(), lock.LockDetails{})
+}
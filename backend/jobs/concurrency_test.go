@@ -2,6 +2,7 @@ package jobs
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -24,4 +25,13 @@ func TestGetSettingsOptions(t *testing.T) {
 		_, err = EnsureJobOnlyRunsOncePerHour("foobar2")
 		assert.NoError(t, err)
 	})
+
+	t.Run("SuccessInterval", func(t *testing.T) {
+		_, err := EnsureJobOnlyRunsOncePerInterval("foobar", 5*time.Minute)
+		assert.NoError(t, err)
+		_, err = EnsureJobOnlyRunsOncePerInterval("foobar", 5*time.Minute)
+		assert.Error(t, err)
+		_, err = EnsureJobOnlyRunsOncePerInterval("foobar2", 5*time.Minute)
+		assert.NoError(t, err)
+	})
 }
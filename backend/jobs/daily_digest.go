@@ -0,0 +1,201 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"github.com/franchizzle/task-manager/backend/settings"
+	"github.com/franchizzle/task-manager/backend/templating"
+	"github.com/franchizzle/task-manager/backend/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// users receive their daily agenda this long before their working day starts
+const DAILY_DIGEST_LEAD_TIME = time.Hour
+
+func dailyDigestJob() {
+	// runs hourly so that each user receives the digest close to the morning in their timezone
+	_, err := EnsureJobOnlyRunsOncePerHour("daily_digest")
+	if err != nil {
+		return
+	}
+	db, cleanup, err := database.GetDBConnection()
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to connect to db for daily digest")
+		return
+	}
+	defer cleanup()
+	err = sendDailyDigests(db, utils.MandrillEmailSender{}, time.Now())
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to send daily digests")
+		return
+	}
+}
+
+func sendDailyDigests(db *mongo.Database, sender utils.EmailSender, now time.Time) error {
+	cursor, err := database.GetUserCollection(db).Find(context.Background(), bson.M{})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(context.Background())
+
+	logger := logging.GetSentryLogger()
+	for cursor.Next(context.Background()) {
+		var user database.User
+		err = cursor.Decode(&user)
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to decode user for daily digest")
+			continue
+		}
+		_, err = sendDailyDigestIfDue(db, sender, &user, now)
+		if err != nil {
+			logger.Error().Err(err).Msgf("failed to send daily digest for user %s", user.ID.Hex())
+		}
+	}
+	return cursor.Err()
+}
+
+// sendDailyDigestIfDue sends the digest if it is a workday and the hour before the user's working hours start in their timezone, they aren't out of office, and they have not opted out
+func sendDailyDigestIfDue(db *mongo.Database, sender utils.EmailSender, user *database.User, now time.Time) (bool, error) {
+	if user.Email == "" {
+		return false, nil
+	}
+	localNow := now.In(settings.GetUserLocation(context.Background(), db, user.ID))
+	workingHours, err := settings.GetWorkingHours(db, user.ID)
+	if err != nil {
+		return false, err
+	}
+	digestTime := workingHours.Start - DAILY_DIGEST_LEAD_TIME
+	if digestTime < 0 {
+		digestTime = 0
+	}
+	if !workingHours.IsWorkday(localNow.Weekday()) || localNow.Hour() != int(digestTime.Hours()) {
+		return false, nil
+	}
+	// skip days the user is out of office when their working day would start
+	workdayStart := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, localNow.Location()).Add(workingHours.Start)
+	outOfOfficeEvents, err := database.GetOutOfOfficeEvents(context.Background(), db, user.ID, workdayStart, workdayStart.Add(time.Second))
+	if err != nil {
+		return false, err
+	}
+	if database.IsOutOfOffice(*outOfOfficeEvents, workdayStart) {
+		return false, nil
+	}
+	digestEnabled, err := settings.GetUserSettingValue(db, user.ID, settings.DailyDigestEnabledSetting)
+	if err != nil {
+		return false, err
+	}
+	if digestEnabled == constants.SettingFalse {
+		return false, nil
+	}
+
+	content, err := getDailyDigestContent(db, user, localNow, workingHours)
+	if err != nil {
+		return false, err
+	}
+	htmlBody, err := templating.RenderEmail(*content)
+	if err != nil {
+		return false, err
+	}
+	err = sender.SendEmail(user.Email, "Your agenda for "+localNow.Format("Monday, January 2"), htmlBody)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func getDailyDigestContent(db *mongo.Database, user *database.User, localNow time.Time, workingHours settings.WorkingHours) (*templating.EmailContent, error) {
+	startOfDay := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, localNow.Location())
+
+	events, err := database.GetEventsUntilEndOfDay(context.Background(), db, user.ID, localNow, workingHours.EndOfDay(localNow))
+	if err != nil {
+		return nil, err
+	}
+	eventItems := []templating.EmailItem{}
+	for _, event := range *events {
+		eventItems = append(eventItems, templating.EmailItem{
+			Title:  event.Title,
+			Detail: event.DatetimeStart.Time().In(localNow.Location()).Format(constants.DIGEST_TIME_FORMAT),
+			Link:   event.Deeplink,
+		})
+	}
+
+	meetingPrepTasks, err := database.GetAllMeetingPreparationTasksUntilEndOfDay(context.Background(), db, user.ID, localNow, workingHours.EndOfDay(localNow))
+	if err != nil {
+		return nil, err
+	}
+	meetingPrepItems := []templating.EmailItem{}
+	for _, task := range *meetingPrepTasks {
+		if (task.IsCompleted != nil && *task.IsCompleted) || (task.IsDeleted != nil && *task.IsDeleted) {
+			continue
+		}
+		meetingPrepItems = append(meetingPrepItems, templating.EmailItem{
+			Title:  getDigestTaskTitle(task),
+			Detail: task.MeetingPreparationParams.DatetimeStart.Time().In(localNow.Location()).Format(constants.DIGEST_TIME_FORMAT),
+		})
+	}
+
+	overdueTasks, err := database.GetTasks(context.Background(), db, user.ID, &[]bson.M{
+		{"is_completed": false},
+		{"is_deleted": bson.M{"$ne": true}},
+		{"is_meeting_preparation_task": bson.M{"$ne": true}},
+		{"due_date": bson.M{"$lt": primitive.NewDateTimeFromTime(startOfDay)}},
+		{"due_date": bson.M{"$gt": primitive.NewDateTimeFromTime(time.Time{})}},
+	}, options.Find().SetSort(bson.M{"due_date": 1}))
+	if err != nil {
+		return nil, err
+	}
+	overdueItems := []templating.EmailItem{}
+	for _, task := range *overdueTasks {
+		overdueItems = append(overdueItems, templating.EmailItem{
+			Title:  getDigestTaskTitle(task),
+			Detail: "Due " + task.DueDate.Time().In(localNow.Location()).Format(constants.DIGEST_DATE_FORMAT),
+			Link:   task.Deeplink,
+		})
+	}
+
+	pullRequests, err := database.GetActivePRs(context.Background(), db, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	pullRequestItems := []templating.EmailItem{}
+	for _, pullRequest := range *pullRequests {
+		if pullRequest.RequiredAction != external.ActionReviewPR {
+			continue
+		}
+		pullRequestItems = append(pullRequestItems, templating.EmailItem{
+			Title:  pullRequest.Title,
+			Detail: pullRequest.RepositoryName,
+			Link:   pullRequest.Deeplink,
+		})
+	}
+
+	heading := "Good morning"
+	if user.Name != "" {
+		heading += ", " + user.Name
+	}
+	return &templating.EmailContent{
+		Heading:    heading,
+		Subheading: "Here's your agenda for " + localNow.Format("Monday, January 2") + ".",
+		Sections: []templating.EmailSection{
+			{Title: "Today's meetings", EmptyMessage: "No meetings today.", Items: eventItems},
+			{Title: "Meeting prep", EmptyMessage: "Nothing to prepare.", Items: meetingPrepItems},
+			{Title: "Overdue tasks", EmptyMessage: "Nothing overdue.", Items: overdueItems},
+			{Title: "Pull requests to review", EmptyMessage: "No reviews requested.", Items: pullRequestItems},
+		},
+	}, nil
+}
+
+func getDigestTaskTitle(task database.Task) string {
+	if task.Title == nil || *task.Title == "" {
+		return "Untitled task"
+	}
+	return *task.Title
+}
@@ -0,0 +1,141 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/franchizzle/task-manager/backend/settings"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type testEmail struct {
+	ToEmail  string
+	Subject  string
+	HTMLBody string
+}
+
+type testEmailSender struct {
+	SentEmails []testEmail
+}
+
+func (sender *testEmailSender) SendEmail(toEmail string, subject string, htmlBody string) error {
+	sender.SentEmails = append(sender.SentEmails, testEmail{ToEmail: toEmail, Subject: subject, HTMLBody: htmlBody})
+	return nil
+}
+
+func TestSendDailyDigestIfDue(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+
+	// 8:30am in New York
+	now, _ := time.Parse(time.RFC3339, "2023-04-20T12:30:00Z")
+	location, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	createUser := func(timezone string) *database.User {
+		user := database.User{ID: primitive.NewObjectID(), Email: "digest@example.com", Name: "Digest"}
+		_, err := database.GetUserCollection(db).InsertOne(context.Background(), user)
+		assert.NoError(t, err)
+		_, err = database.GetExternalTokenCollection(db).InsertOne(context.Background(), database.ExternalAPIToken{
+			UserID:    user.ID,
+			ServiceID: external.TASK_SERVICE_ID_GOOGLE,
+			Timezone:  timezone,
+		})
+		assert.NoError(t, err)
+		return &user
+	}
+
+	t.Run("WrongHour", func(t *testing.T) {
+		user := createUser("America/Los_Angeles")
+		sender := testEmailSender{}
+		sent, err := sendDailyDigestIfDue(db, &sender, user, now)
+		assert.NoError(t, err)
+		assert.False(t, sent)
+		assert.Equal(t, 0, len(sender.SentEmails))
+	})
+	t.Run("LaterWorkingHours", func(t *testing.T) {
+		user := createUser("America/New_York")
+		assert.NoError(t, settings.UpdateUserSetting(db, user.ID, constants.SettingFieldWorkingHoursStart, "10:00"))
+		sender := testEmailSender{}
+		sent, err := sendDailyDigestIfDue(db, &sender, user, now)
+		assert.NoError(t, err)
+		assert.False(t, sent)
+	})
+	t.Run("NotWorkday", func(t *testing.T) {
+		user := createUser("America/New_York")
+		sender := testEmailSender{}
+		sent, err := sendDailyDigestIfDue(db, &sender, user, now.AddDate(0, 0, 2))
+		assert.NoError(t, err)
+		assert.False(t, sent)
+	})
+	t.Run("OutOfOffice", func(t *testing.T) {
+		user := createUser("America/New_York")
+		_, err := database.GetCalendarEventCollection(db).InsertOne(context.Background(), database.CalendarEvent{
+			UserID:        user.ID,
+			Category:      constants.EventCategoryOutOfOffice,
+			DatetimeStart: primitive.NewDateTimeFromTime(time.Date(2023, 4, 20, 0, 0, 0, 0, location)),
+			DatetimeEnd:   primitive.NewDateTimeFromTime(time.Date(2023, 4, 21, 0, 0, 0, 0, location)),
+		})
+		assert.NoError(t, err)
+		sender := testEmailSender{}
+		sent, err := sendDailyDigestIfDue(db, &sender, user, now)
+		assert.NoError(t, err)
+		assert.False(t, sent)
+	})
+	t.Run("OptedOut", func(t *testing.T) {
+		user := createUser("America/New_York")
+		assert.NoError(t, database.UpdateUserSetting(context.Background(), db, user.ID, constants.SettingFieldDailyDigestEnabled, "false"))
+		sender := testEmailSender{}
+		sent, err := sendDailyDigestIfDue(db, &sender, user, now)
+		assert.NoError(t, err)
+		assert.False(t, sent)
+	})
+	t.Run("Success", func(t *testing.T) {
+		user := createUser("America/New_York")
+		notCompleted := false
+		overdueTitle := "overdue task"
+		overdueDate := primitive.NewDateTimeFromTime(now.Add(-48 * time.Hour))
+		futureTitle := "future task"
+		futureDate := primitive.NewDateTimeFromTime(now.Add(48 * time.Hour))
+		_, err := database.GetTaskCollection(db).InsertMany(context.Background(), []interface{}{
+			database.Task{UserID: user.ID, Title: &overdueTitle, DueDate: &overdueDate, IsCompleted: &notCompleted},
+			database.Task{UserID: user.ID, Title: &futureTitle, DueDate: &futureDate, IsCompleted: &notCompleted},
+		})
+		assert.NoError(t, err)
+		_, err = database.GetCalendarEventCollection(db).InsertOne(context.Background(), database.CalendarEvent{
+			UserID:        user.ID,
+			Title:         "design review",
+			DatetimeStart: primitive.NewDateTimeFromTime(time.Date(2023, 4, 20, 14, 0, 0, 0, location)),
+			DatetimeEnd:   primitive.NewDateTimeFromTime(time.Date(2023, 4, 20, 15, 0, 0, 0, location)),
+		})
+		assert.NoError(t, err)
+		_, err = database.GetPullRequestCollection(db).InsertMany(context.Background(), []interface{}{
+			database.PullRequest{UserID: user.ID, Title: "needs review", RequiredAction: external.ActionReviewPR, IsCompleted: &notCompleted},
+			database.PullRequest{UserID: user.ID, Title: "waiting on ci", RequiredAction: external.ActionWaitingOnCI, IsCompleted: &notCompleted},
+		})
+		assert.NoError(t, err)
+
+		sender := testEmailSender{}
+		sent, err := sendDailyDigestIfDue(db, &sender, user, now)
+		assert.NoError(t, err)
+		assert.True(t, sent)
+		assert.Equal(t, 1, len(sender.SentEmails))
+		email := sender.SentEmails[0]
+		assert.Equal(t, "digest@example.com", email.ToEmail)
+		assert.Equal(t, "Your agenda for Thursday, April 20", email.Subject)
+		assert.Contains(t, email.HTMLBody, "Good morning, Digest")
+		assert.Contains(t, email.HTMLBody, "design review")
+		assert.Contains(t, email.HTMLBody, "2:00 PM")
+		assert.Contains(t, email.HTMLBody, "overdue task")
+		assert.NotContains(t, email.HTMLBody, "future task")
+		assert.Contains(t, email.HTMLBody, "needs review")
+		assert.NotContains(t, email.HTMLBody, "waiting on ci")
+		assert.Contains(t, email.HTMLBody, "Nothing to prepare.")
+	})
+}
@@ -38,7 +38,7 @@ func updateGithubIndustryData(logID primitive.ObjectID, endCutoff time.Time, loo
 		return err
 	}
 	defer cleanup()
-	err = database.InsertLogEvent(db, logID, "github_industry_job_start"+strconv.Itoa(lookbackDays)+" "+endCutoff.Format("2006-1-2 15:4:5"))
+	err = database.InsertLogEvent(context.Background(), db, logID, "github_industry_job_start"+strconv.Itoa(lookbackDays)+" "+endCutoff.Format("2006-1-2 15:4:5"))
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to log event")
 	}
@@ -48,7 +48,7 @@ func updateGithubIndustryData(logID primitive.ObjectID, endCutoff time.Time, loo
 		logger.Error().Err(err).Msg("failed to fetch github PRs")
 		return err
 	}
-	err = database.InsertLogEvent(db, logID, "github_industry_job_fetched_prs"+strconv.Itoa(len(pullRequestIDToValue)))
+	err = database.InsertLogEvent(context.Background(), db, logID, "github_industry_job_fetched_prs"+strconv.Itoa(len(pullRequestIDToValue)))
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to log event")
 	}
@@ -57,79 +57,23 @@ func updateGithubIndustryData(logID primitive.ObjectID, endCutoff time.Time, loo
 	if err != nil {
 		return err
 	}
-	err = database.InsertLogEvent(db, logID, "github_industry_job_completed")
+	err = database.InsertLogEvent(context.Background(), db, logID, "github_industry_job_completed")
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to log event")
 	}
 	return nil
 }
 
-func UpdateGithubTeamData(userID primitive.ObjectID, endCutoff time.Time, lookbackDays int) error {
-	logger := logging.GetSentryLogger()
-	db, cleanup, err := database.GetDBConnection()
-	if err != nil {
-		return err
-	}
-	defer cleanup()
-	pullRequestIDToValue, err := getPullRequestsMapAfterCutoff(db, []bson.M{{"user_id": userID}}, getPullRequestCutoffTime(endCutoff, lookbackDays))
-	if err != nil {
-		logger.Error().Err(err).Msg("failed to fetch github PRs")
-		return err
-	}
-	team, err := database.GetOrCreateDashboardTeam(db, userID)
-	if err != nil {
-		logger.Error().Err(err).Msg("failed to get dashboard team")
-		return err
-	}
-	teamMembers, err := database.GetDashboardTeamMembers(db, team.ID)
-	if err != nil || teamMembers == nil {
-		logger.Error().Err(err).Msg("failed to get dashboard team members")
-		return err
-	}
-	authorToPullRequests := make(map[string]map[string]database.PullRequest)
-	for _, pullRequest := range pullRequestIDToValue {
-		for _, comment := range pullRequest.Comments {
-			if comment.Author != CODECOV_BOT && comment.Author != pullRequest.Author {
-				_, exists := authorToPullRequests[comment.Author]
-				if !exists {
-					authorToPullRequests[comment.Author] = make(map[string]database.PullRequest)
-				}
-				authorToPullRequests[comment.Author][pullRequest.IDExternal] = pullRequest
-			}
-		}
-	}
-	teamPullRequests := make(map[string]database.PullRequest)
-	for _, teamMember := range *teamMembers {
-		if teamMember.GithubID == "" {
-			continue
-		}
-		idToPullRequest, exists := authorToPullRequests[teamMember.GithubID]
-		if !exists {
-			continue
-		}
-		err = saveDataPointsForPullRequests(db, idToPullRequest, team.ID, teamMember.ID)
-		if err != nil {
-			logger.Error().Err(err).Msgf("failed to save team %s member %s data points", team.ID, teamMember.ID)
-			return err
-		}
-		for externalID, pullRequest := range idToPullRequest {
-			teamPullRequests[externalID] = pullRequest
-		}
-	}
-	err = saveDataPointsForPullRequests(db, teamPullRequests, team.ID, primitive.NilObjectID)
-	if err != nil {
-		logger.Error().Err(err).Msgf("failed to save team %s data points", team.ID)
-		return err
-	}
-	return nil
+func getPullRequestsMapAfterCutoff(db *mongo.Database, filters []bson.M, cutoffTime time.Time) (map[string]database.PullRequest, error) {
+	filters = append(filters, bson.M{"created_at_external": bson.M{"$gte": primitive.NewDateTimeFromTime(cutoffTime)}})
+	return getPullRequestsMap(db, filters)
 }
 
-func getPullRequestsMapAfterCutoff(db *mongo.Database, filters []bson.M, cutoffTime time.Time) (map[string]database.PullRequest, error) {
+func getPullRequestsMap(db *mongo.Database, filters []bson.M) (map[string]database.PullRequest, error) {
 	pullRequestCollection := database.GetPullRequestCollection(db)
 	findOptions := options.Find()
 	// sort by increasing last_fetched, so the more recently updated PRs override the more stale PRs when looping through
 	findOptions.SetSort(bson.D{{Key: "last_fetched", Value: 1}})
-	filters = append(filters, bson.M{"created_at_external": bson.M{"$gte": primitive.NewDateTimeFromTime(cutoffTime)}})
 	cursor, err := pullRequestCollection.Find(
 		context.Background(),
 		bson.M{"$and": filters},
@@ -151,7 +95,6 @@ func getPullRequestsMapAfterCutoff(db *mongo.Database, filters []bson.M, cutoffT
 }
 
 func saveDataPointsForPullRequests(db *mongo.Database, pullRequestIDToValue map[string]database.PullRequest, teamID primitive.ObjectID, individualID primitive.ObjectID) error {
-	logger := logging.GetSentryLogger()
 	dateToTotalResponseTime := make(map[primitive.DateTime]int)
 	dateToPRCount := make(map[primitive.DateTime]int)
 	for _, pullRequest := range pullRequestIDToValue {
@@ -167,28 +110,44 @@ func saveDataPointsForPullRequests(db *mongo.Database, pullRequestIDToValue map[
 			continue
 		}
 		responseTime := int(firstCommentTime.Sub(pullRequest.CreatedAtExternal.Time()).Minutes())
-		createdAt := pullRequest.CreatedAtExternal.Time()
-		pullRequestDate := primitive.NewDateTimeFromTime(time.Date(createdAt.Year(), createdAt.Month(), createdAt.Day(), constants.UTC_OFFSET, 0, 0, 0, time.UTC))
+		pullRequestDate := getDataPointDate(pullRequest.CreatedAtExternal.Time())
 		dateToTotalResponseTime[pullRequestDate] += responseTime
 		dateToPRCount[pullRequestDate] += 1
 	}
-	dataPointCollection := database.GetDashboardDataPointCollection(db)
-	for dateTime, totalResponseTime := range dateToTotalResponseTime {
-		pullRequestCount := dateToPRCount[dateTime]
-		if pullRequestCount == 0 {
-			logger.Error().Msg("pull request count is zero")
-			return errors.New("pull request count is zero")
+	dateToAverageResponseTime, err := getDailyAverages(dateToTotalResponseTime, dateToPRCount)
+	if err != nil {
+		return err
+	}
+	return saveDailyDataPoints(db, constants.DashboardGraphTypePRResponseTime, dateToAverageResponseTime, teamID, individualID)
+}
+
+func getDailyAverages(dateToTotal map[primitive.DateTime]int, dateToCount map[primitive.DateTime]int) (map[primitive.DateTime]int, error) {
+	dateToAverage := make(map[primitive.DateTime]int)
+	for dateTime, total := range dateToTotal {
+		count := dateToCount[dateTime]
+		if count == 0 {
+			logging.GetSentryLogger().Error().Msg("pull request count is zero")
+			return nil, errors.New("pull request count is zero")
 		}
-		averageResponseTime := totalResponseTime / pullRequestCount
+		dateToAverage[dateTime] = total / count
+	}
+	return dateToAverage, nil
+}
+
+// saveDailyDataPoints upserts one data point per date, so re-running over the same window replaces earlier values
+func saveDailyDataPoints(db *mongo.Database, graphType string, dateToValue map[primitive.DateTime]int, teamID primitive.ObjectID, individualID primitive.ObjectID) error {
+	logger := logging.GetSentryLogger()
+	dataPointCollection := database.GetDashboardDataPointCollection(db)
+	for dateTime, value := range dateToValue {
 		dashboardDataPoint := database.DashboardDataPoint{
-			GraphType: constants.DashboardGraphTypePRResponseTime,
-			Value:     averageResponseTime,
+			GraphType: graphType,
+			Value:     value,
 			Date:      dateTime,
 			CreatedAt: primitive.NewDateTimeFromTime(time.Now()),
 		}
 		filters := []bson.M{
 			{"date": dateTime},
-			{"graph_type": constants.DashboardGraphTypePRResponseTime},
+			{"graph_type": graphType},
 		}
 		if teamID != primitive.NilObjectID {
 			dashboardDataPoint.TeamID = teamID
@@ -198,7 +157,7 @@ func saveDataPointsForPullRequests(db *mongo.Database, pullRequestIDToValue map[
 		}
 		if individualID != primitive.NilObjectID {
 			dashboardDataPoint.IndividualID = individualID
-			filters = append(filters, bson.M{"individual_id": teamID})
+			filters = append(filters, bson.M{"individual_id": individualID})
 		} else {
 			filters = append(filters, bson.M{"individual_id": bson.M{"$exists": false}})
 		}
@@ -217,6 +176,11 @@ func saveDataPointsForPullRequests(db *mongo.Database, pullRequestIDToValue map[
 	return nil
 }
 
+// getDataPointDate buckets a time into the day its data point is stored under
+func getDataPointDate(t time.Time) primitive.DateTime {
+	return primitive.NewDateTimeFromTime(time.Date(t.Year(), t.Month(), t.Day(), constants.UTC_OFFSET, 0, 0, 0, time.UTC))
+}
+
 func getPullRequestCutoffTime(endCutoff time.Time, lookbackDays int) time.Time {
 	return endCutoff.Add(-time.Hour * 24 * time.Duration(lookbackDays))
 }
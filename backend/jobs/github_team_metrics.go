@@ -0,0 +1,223 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func githubTeamMetricsJob() {
+	_, err := EnsureJobOnlyRunsOnceToday("github_team_metrics")
+	if err != nil {
+		return
+	}
+	err = updateAllGithubTeamData(time.Now(), DEFAULT_LOOKBACK_DAYS)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to run github team metrics job")
+		return
+	}
+}
+
+func updateAllGithubTeamData(endCutoff time.Time, lookbackDays int) error {
+	logger := logging.GetSentryLogger()
+	db, cleanup, err := database.GetDBConnection()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	teams, err := database.GetAllDashboardTeams(context.Background(), db)
+	if err != nil {
+		return err
+	}
+	for _, team := range *teams {
+		// one team failing shouldn't keep the rest from updating
+		err = updateGithubTeamData(db, team.UserID, endCutoff, lookbackDays)
+		if err != nil {
+			logger.Error().Err(err).Msgf("failed to update team %s data points", team.ID.Hex())
+		}
+	}
+	return nil
+}
+
+func UpdateGithubTeamData(userID primitive.ObjectID, endCutoff time.Time, lookbackDays int) error {
+	db, cleanup, err := database.GetDBConnection()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	return updateGithubTeamData(db, userID, endCutoff, lookbackDays)
+}
+
+// updateGithubTeamData computes review response time, review turnaround, cycle time, and merge throughput for a team
+// and each of its members from the team owner's pull requests
+func updateGithubTeamData(db *mongo.Database, userID primitive.ObjectID, endCutoff time.Time, lookbackDays int) error {
+	logger := logging.GetSentryLogger()
+	cutoffTime := getPullRequestCutoffTime(endCutoff, lookbackDays)
+	pullRequestIDToValue, err := getPullRequestsMapAfterCutoff(db, []bson.M{{"user_id": userID}}, cutoffTime)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch github PRs")
+		return err
+	}
+	mergedPullRequestIDToValue, err := getMergedPullRequestsMapAfterCutoff(db, []bson.M{{"user_id": userID}}, cutoffTime)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch merged github PRs")
+		return err
+	}
+	team, err := database.GetOrCreateDashboardTeam(context.Background(), db, userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to get dashboard team")
+		return err
+	}
+	teamMembers, err := database.GetDashboardTeamMembers(context.Background(), db, team.ID)
+	if err != nil || teamMembers == nil {
+		logger.Error().Err(err).Msg("failed to get dashboard team members")
+		return err
+	}
+	reviewerToPullRequests := make(map[string]map[string]database.PullRequest)
+	for _, pullRequest := range pullRequestIDToValue {
+		for _, comment := range pullRequest.Comments {
+			if comment.Author != CODECOV_BOT && comment.Author != pullRequest.Author {
+				_, exists := reviewerToPullRequests[comment.Author]
+				if !exists {
+					reviewerToPullRequests[comment.Author] = make(map[string]database.PullRequest)
+				}
+				reviewerToPullRequests[comment.Author][pullRequest.IDExternal] = pullRequest
+			}
+		}
+	}
+	firstReviewerToPullRequests := make(map[string]map[string]database.PullRequest)
+	for _, pullRequest := range pullRequestIDToValue {
+		if pullRequest.FirstReviewer == "" {
+			continue
+		}
+		_, exists := firstReviewerToPullRequests[pullRequest.FirstReviewer]
+		if !exists {
+			firstReviewerToPullRequests[pullRequest.FirstReviewer] = make(map[string]database.PullRequest)
+		}
+		firstReviewerToPullRequests[pullRequest.FirstReviewer][pullRequest.IDExternal] = pullRequest
+	}
+	authorToMergedPullRequests := make(map[string]map[string]database.PullRequest)
+	for _, pullRequest := range mergedPullRequestIDToValue {
+		_, exists := authorToMergedPullRequests[pullRequest.Author]
+		if !exists {
+			authorToMergedPullRequests[pullRequest.Author] = make(map[string]database.PullRequest)
+		}
+		authorToMergedPullRequests[pullRequest.Author][pullRequest.IDExternal] = pullRequest
+	}
+
+	teamReviewedPullRequests := make(map[string]database.PullRequest)
+	teamMergedPullRequests := make(map[string]database.PullRequest)
+	teamFirstReviewedPullRequests := make(map[string]database.PullRequest)
+	for _, teamMember := range *teamMembers {
+		if teamMember.GithubID == "" {
+			continue
+		}
+		if idToPullRequest, exists := reviewerToPullRequests[teamMember.GithubID]; exists {
+			err = saveDataPointsForPullRequests(db, idToPullRequest, team.ID, teamMember.ID)
+			if err != nil {
+				logger.Error().Err(err).Msgf("failed to save team %s member %s data points", team.ID, teamMember.ID)
+				return err
+			}
+			for externalID, pullRequest := range idToPullRequest {
+				teamReviewedPullRequests[externalID] = pullRequest
+			}
+		}
+		if idToPullRequest, exists := firstReviewerToPullRequests[teamMember.GithubID]; exists {
+			err = saveReviewTurnaroundDataPointsForPullRequests(db, idToPullRequest, team.ID, teamMember.ID)
+			if err != nil {
+				logger.Error().Err(err).Msgf("failed to save team %s member %s review turnaround data points", team.ID, teamMember.ID)
+				return err
+			}
+			for externalID, pullRequest := range idToPullRequest {
+				teamFirstReviewedPullRequests[externalID] = pullRequest
+			}
+		}
+		if idToPullRequest, exists := authorToMergedPullRequests[teamMember.GithubID]; exists {
+			err = saveCompletionDataPointsForPullRequests(db, idToPullRequest, team.ID, teamMember.ID)
+			if err != nil {
+				logger.Error().Err(err).Msgf("failed to save team %s member %s completion data points", team.ID, teamMember.ID)
+				return err
+			}
+			for externalID, pullRequest := range idToPullRequest {
+				teamMergedPullRequests[externalID] = pullRequest
+			}
+		}
+	}
+	err = saveDataPointsForPullRequests(db, teamReviewedPullRequests, team.ID, primitive.NilObjectID)
+	if err != nil {
+		logger.Error().Err(err).Msgf("failed to save team %s data points", team.ID)
+		return err
+	}
+	err = saveCompletionDataPointsForPullRequests(db, teamMergedPullRequests, team.ID, primitive.NilObjectID)
+	if err != nil {
+		logger.Error().Err(err).Msgf("failed to save team %s completion data points", team.ID)
+		return err
+	}
+	err = saveReviewTurnaroundDataPointsForPullRequests(db, teamFirstReviewedPullRequests, team.ID, primitive.NilObjectID)
+	if err != nil {
+		logger.Error().Err(err).Msgf("failed to save team %s review turnaround data points", team.ID)
+		return err
+	}
+	return nil
+}
+
+// completed PRs include those closed without merging, so this filters on the merge time instead
+func getMergedPullRequestsMapAfterCutoff(db *mongo.Database, filters []bson.M, cutoffTime time.Time) (map[string]database.PullRequest, error) {
+	filters = append(
+		filters,
+		bson.M{"merged_at": bson.M{"$gte": primitive.NewDateTimeFromTime(cutoffTime)}},
+	)
+	return getPullRequestsMap(db, filters)
+}
+
+// saveCompletionDataPointsForPullRequests saves the average cycle time (creation to merge) and the number of PRs
+// merged on each day
+func saveCompletionDataPointsForPullRequests(db *mongo.Database, pullRequestIDToValue map[string]database.PullRequest, teamID primitive.ObjectID, individualID primitive.ObjectID) error {
+	dateToTotalCycleTime := make(map[primitive.DateTime]int)
+	dateToPRCount := make(map[primitive.DateTime]int)
+	for _, pullRequest := range pullRequestIDToValue {
+		if pullRequest.CreatedAtExternal == 0 || pullRequest.MergedAt == 0 {
+			continue
+		}
+		cycleTime := int(pullRequest.MergedAt.Time().Sub(pullRequest.CreatedAtExternal.Time()).Minutes())
+		mergedDate := getDataPointDate(pullRequest.MergedAt.Time())
+		dateToTotalCycleTime[mergedDate] += cycleTime
+		dateToPRCount[mergedDate] += 1
+	}
+	dateToAverageCycleTime, err := getDailyAverages(dateToTotalCycleTime, dateToPRCount)
+	if err != nil {
+		return err
+	}
+	err = saveDailyDataPoints(db, constants.DashboardGraphTypePRCycleTime, dateToAverageCycleTime, teamID, individualID)
+	if err != nil {
+		return err
+	}
+	return saveDailyDataPoints(db, constants.DashboardGraphTypePRMergeCount, dateToPRCount, teamID, individualID)
+}
+
+// saveReviewTurnaroundDataPointsForPullRequests saves the average time from review being requested to the first review
+// on each day. Reviews are requested when a pull request is opened, so that stands in for the request time
+func saveReviewTurnaroundDataPointsForPullRequests(db *mongo.Database, pullRequestIDToValue map[string]database.PullRequest, teamID primitive.ObjectID, individualID primitive.ObjectID) error {
+	dateToTotalTurnaround := make(map[primitive.DateTime]int)
+	dateToPRCount := make(map[primitive.DateTime]int)
+	for _, pullRequest := range pullRequestIDToValue {
+		if pullRequest.CreatedAtExternal == 0 || pullRequest.FirstReviewAt == 0 {
+			continue
+		}
+		turnaround := int(pullRequest.FirstReviewAt.Time().Sub(pullRequest.CreatedAtExternal.Time()).Minutes())
+		requestedDate := getDataPointDate(pullRequest.CreatedAtExternal.Time())
+		dateToTotalTurnaround[requestedDate] += turnaround
+		dateToPRCount[requestedDate] += 1
+	}
+	dateToAverageTurnaround, err := getDailyAverages(dateToTotalTurnaround, dateToPRCount)
+	if err != nil {
+		return err
+	}
+	return saveDailyDataPoints(db, constants.DashboardGraphTypePRReviewTurnaround, dateToAverageTurnaround, teamID, individualID)
+}
@@ -0,0 +1,184 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestUpdateGithubTeamDataCompletion(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+
+	nowTime, _ := time.Parse(time.RFC3339, "2023-04-20T19:01:12Z")
+	createdAt := nowTime.Add(-time.Hour * 24 * 5)
+	completedAt := createdAt.Add(time.Hour)
+	userID := primitive.NewObjectID()
+	team, err := database.GetOrCreateDashboardTeam(context.Background(), db, userID)
+	assert.NoError(t, err)
+	res, err := database.GetDashboardTeamMemberCollection(db).InsertOne(context.Background(), database.DashboardTeamMember{TeamID: team.ID, GithubID: "elon123"})
+	assert.NoError(t, err)
+	teamMemberID := res.InsertedID.(primitive.ObjectID)
+
+	isCompleted := true
+	for _, pullRequest := range []database.PullRequest{
+		{
+			IDExternal:        "#1",
+			Author:            "elon123",
+			CreatedAtExternal: primitive.NewDateTimeFromTime(createdAt),
+			CompletedAt:       primitive.NewDateTimeFromTime(completedAt),
+			MergedAt:          primitive.NewDateTimeFromTime(completedAt),
+		},
+		{
+			IDExternal:        "#2",
+			Author:            "elon123",
+			CreatedAtExternal: primitive.NewDateTimeFromTime(createdAt.Add(-time.Hour)),
+			CompletedAt:       primitive.NewDateTimeFromTime(completedAt),
+			MergedAt:          primitive.NewDateTimeFromTime(completedAt),
+		},
+		// not a team member
+		{
+			IDExternal:        "#3",
+			Author:            "gigachad",
+			CreatedAtExternal: primitive.NewDateTimeFromTime(createdAt),
+			CompletedAt:       primitive.NewDateTimeFromTime(completedAt),
+			MergedAt:          primitive.NewDateTimeFromTime(completedAt),
+		},
+		// merged before the cutoff
+		{
+			IDExternal:        "#4",
+			Author:            "elon123",
+			CreatedAtExternal: primitive.NewDateTimeFromTime(nowTime.Add(-time.Hour * 24 * 30)),
+			CompletedAt:       primitive.NewDateTimeFromTime(nowTime.Add(-time.Hour * 24 * 25)),
+			MergedAt:          primitive.NewDateTimeFromTime(nowTime.Add(-time.Hour * 24 * 25)),
+		},
+		// closed without merging
+		{
+			IDExternal:        "#5",
+			Author:            "elon123",
+			CreatedAtExternal: primitive.NewDateTimeFromTime(createdAt),
+			CompletedAt:       primitive.NewDateTimeFromTime(completedAt),
+		},
+	} {
+		pullRequest.UserID = userID
+		pullRequest.SourceID = "github_pr"
+		pullRequest.IsCompleted = &isCompleted
+		assert.NoError(t, createTestPullRequest(db, pullRequest))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		assert.NoError(t, updateGithubTeamData(db, userID, nowTime, 21))
+		// running again replaces the existing data points
+		assert.NoError(t, updateGithubTeamData(db, userID, nowTime, 21))
+
+		expectedDateTime, _ := time.Parse(time.RFC3339, "2023-04-15T08:00:00Z")
+		for _, individualID := range []primitive.ObjectID{teamMemberID, primitive.NilObjectID} {
+			individualFilter := bson.M{"individual_id": individualID}
+			if individualID == primitive.NilObjectID {
+				individualFilter = bson.M{"individual_id": bson.M{"$exists": false}}
+			}
+			cursor, err := database.GetDashboardDataPointCollection(db).Find(
+				context.Background(),
+				bson.M{"$and": []bson.M{{"team_id": team.ID}, individualFilter}},
+			)
+			assert.NoError(t, err)
+			var dataPoints []database.DashboardDataPoint
+			assert.NoError(t, cursor.All(context.Background(), &dataPoints))
+			assert.Equal(t, 2, len(dataPoints))
+			for _, dataPoint := range dataPoints {
+				assert.Equal(t, primitive.NewDateTimeFromTime(expectedDateTime), dataPoint.Date)
+				switch dataPoint.GraphType {
+				case constants.DashboardGraphTypePRCycleTime:
+					assert.Equal(t, 90, dataPoint.Value)
+				case constants.DashboardGraphTypePRMergeCount:
+					assert.Equal(t, 2, dataPoint.Value)
+				default:
+					t.Errorf("unexpected graph type: %s", dataPoint.GraphType)
+				}
+			}
+		}
+	})
+}
+
+func TestUpdateGithubTeamDataReviewTurnaround(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+
+	nowTime, _ := time.Parse(time.RFC3339, "2023-04-20T19:01:12Z")
+	createdAt := nowTime.Add(-time.Hour * 24 * 5)
+	userID := primitive.NewObjectID()
+	team, err := database.GetOrCreateDashboardTeam(context.Background(), db, userID)
+	assert.NoError(t, err)
+	res, err := database.GetDashboardTeamMemberCollection(db).InsertOne(context.Background(), database.DashboardTeamMember{TeamID: team.ID, GithubID: "reviewer1"})
+	assert.NoError(t, err)
+	reviewer1ID := res.InsertedID.(primitive.ObjectID)
+	res, err = database.GetDashboardTeamMemberCollection(db).InsertOne(context.Background(), database.DashboardTeamMember{TeamID: team.ID, GithubID: "reviewer2"})
+	assert.NoError(t, err)
+	reviewer2ID := res.InsertedID.(primitive.ObjectID)
+
+	for _, pullRequest := range []database.PullRequest{
+		{
+			IDExternal:        "#1",
+			Author:            "author",
+			CreatedAtExternal: primitive.NewDateTimeFromTime(createdAt),
+			FirstReviewAt:     primitive.NewDateTimeFromTime(createdAt.Add(time.Hour)),
+			FirstReviewer:     "reviewer1",
+		},
+		{
+			IDExternal:        "#2",
+			Author:            "author",
+			CreatedAtExternal: primitive.NewDateTimeFromTime(createdAt),
+			FirstReviewAt:     primitive.NewDateTimeFromTime(createdAt.Add(3 * time.Hour)),
+			FirstReviewer:     "reviewer2",
+		},
+		// not reviewed yet
+		{
+			IDExternal:        "#3",
+			Author:            "author",
+			CreatedAtExternal: primitive.NewDateTimeFromTime(createdAt),
+		},
+		// reviewed by someone outside the team
+		{
+			IDExternal:        "#4",
+			Author:            "author",
+			CreatedAtExternal: primitive.NewDateTimeFromTime(createdAt),
+			FirstReviewAt:     primitive.NewDateTimeFromTime(createdAt.Add(10 * time.Hour)),
+			FirstReviewer:     "gigachad",
+		},
+	} {
+		pullRequest.UserID = userID
+		pullRequest.SourceID = "github_pr"
+		assert.NoError(t, createTestPullRequest(db, pullRequest))
+	}
+
+	assert.NoError(t, updateGithubTeamData(db, userID, nowTime, 21))
+	expectedDateTime, _ := time.Parse(time.RFC3339, "2023-04-15T08:00:00Z")
+	getTurnaround := func(individualFilter bson.M) []database.DashboardDataPoint {
+		cursor, err := database.GetDashboardDataPointCollection(db).Find(
+			context.Background(),
+			bson.M{"$and": []bson.M{{"team_id": team.ID}, {"graph_type": constants.DashboardGraphTypePRReviewTurnaround}, individualFilter}},
+		)
+		assert.NoError(t, err)
+		var dataPoints []database.DashboardDataPoint
+		assert.NoError(t, cursor.All(context.Background(), &dataPoints))
+		return dataPoints
+	}
+	for individualFilter, expectedValue := range map[*bson.M]int{
+		{"individual_id": reviewer1ID}:              60,
+		{"individual_id": reviewer2ID}:              180,
+		{"individual_id": bson.M{"$exists": false}}: 120,
+	} {
+		dataPoints := getTurnaround(*individualFilter)
+		assert.Equal(t, 1, len(dataPoints))
+		assert.Equal(t, expectedValue, dataPoints[0].Value)
+		assert.Equal(t, primitive.NewDateTimeFromTime(expectedDateTime), dataPoints[0].Date)
+	}
+}
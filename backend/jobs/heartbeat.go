@@ -0,0 +1,30 @@
+package jobs
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+const WORKER_HEARTBEAT_INTERVAL = time.Minute
+
+// unix nanoseconds of the last heartbeat, zero until the scheduler first runs
+var lastWorkerHeartbeat int64
+
+func workerHeartbeatJob() {
+	RecordWorkerHeartbeat(time.Now())
+}
+
+// RecordWorkerHeartbeat marks the background workers as alive at the given time
+func RecordWorkerHeartbeat(now time.Time) {
+	atomic.StoreInt64(&lastWorkerHeartbeat, now.UnixNano())
+}
+
+// GetLastWorkerHeartbeat returns when the background workers last checked in, or the zero time if
+// they never have
+func GetLastWorkerHeartbeat() time.Time {
+	heartbeat := atomic.LoadInt64(&lastWorkerHeartbeat)
+	if heartbeat == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, heartbeat)
+}
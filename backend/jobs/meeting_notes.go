@@ -0,0 +1,76 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"github.com/franchizzle/task-manager/backend/meetingnotes"
+	"github.com/franchizzle/task-manager/backend/settings"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// events that started within this window get notes, so a run that is skipped or delayed doesn't
+// cause notes to be missed. Notes are only created once per event, so overlapping runs are harmless
+const MEETING_NOTES_LOOKBACK = 10 * time.Minute
+
+func meetingNotesJob() {
+	_, err := EnsureJobOnlyRunsOncePerInterval("meeting_notes", time.Minute)
+	if err != nil {
+		return
+	}
+	db, cleanup, err := database.GetDBConnection()
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to connect to db for meeting notes")
+		return
+	}
+	defer cleanup()
+	_, err = createMeetingNotesForStartedEvents(db, time.Now())
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to create meeting notes")
+	}
+}
+
+// createMeetingNotesForStartedEvents creates notes for meetings that just started, for users who
+// have turned on automatic meeting notes. Events without attendees aren't meetings, so they're skipped
+func createMeetingNotesForStartedEvents(db *mongo.Database, now time.Time) (int, error) {
+	logger := logging.GetSentryLogger()
+	events, err := database.GetEventsStartedBetween(context.Background(), db, now.Add(-MEETING_NOTES_LOOKBACK), now)
+	if err != nil {
+		return 0, err
+	}
+
+	isEnabledForUser := map[primitive.ObjectID]bool{}
+	createdCount := 0
+	for _, event := range *events {
+		if len(event.AttendeeEmails) == 0 || event.LinkedTaskID != primitive.NilObjectID || event.LinkedViewID != primitive.NilObjectID || event.LinkedPullRequestID != primitive.NilObjectID {
+			continue
+		}
+		isEnabled, exists := isEnabledForUser[event.UserID]
+		if !exists {
+			settingValue, err := settings.GetUserSettingValue(db, event.UserID, settings.AutoMeetingNotesEnabledSetting)
+			if err != nil {
+				logger.Error().Err(err).Msgf("failed to load meeting notes setting for user %s", event.UserID.Hex())
+				continue
+			}
+			isEnabled = settingValue != constants.SettingFalse
+			isEnabledForUser[event.UserID] = isEnabled
+		}
+		if !isEnabled {
+			continue
+		}
+		event := event
+		_, isCreated, err := meetingnotes.GetOrCreateEventNote(context.Background(), db, event.UserID, &event)
+		if err != nil {
+			logger.Error().Err(err).Msgf("failed to create meeting notes for event %s", event.ID.Hex())
+			continue
+		}
+		if isCreated {
+			createdCount++
+		}
+	}
+	return createdCount, nil
+}
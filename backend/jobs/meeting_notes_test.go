@@ -0,0 +1,64 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCreateMeetingNotesForStartedEvents(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	ctx := context.Background()
+	// far from the current time so that other tests' events aren't picked up
+	now := time.Date(2021, time.March, 6, 15, 0, 0, 0, time.UTC)
+
+	enabledUserID := primitive.NewObjectID()
+	disabledUserID := primitive.NewObjectID()
+	_, err = database.GetUserSettingsCollection(db).InsertOne(ctx, database.UserSetting{
+		UserID:     enabledUserID,
+		FieldKey:   constants.SettingFieldAutoMeetingNotesEnabled,
+		FieldValue: "true",
+	})
+	assert.NoError(t, err)
+
+	insertEvent := func(userID primitive.ObjectID, startedAgo time.Duration, attendeeEmails []string) primitive.ObjectID {
+		insertResult, err := database.GetCalendarEventCollection(db).InsertOne(ctx, database.CalendarEvent{
+			UserID:         userID,
+			Title:          "Team sync",
+			DatetimeStart:  primitive.NewDateTimeFromTime(now.Add(-startedAgo)),
+			DatetimeEnd:    primitive.NewDateTimeFromTime(now.Add(time.Hour)),
+			AttendeeEmails: attendeeEmails,
+		})
+		assert.NoError(t, err)
+		return insertResult.InsertedID.(primitive.ObjectID)
+	}
+	attendees := []string{"a@example.com", "b@example.com"}
+	startedEventID := insertEvent(enabledUserID, time.Minute, attendees)
+	insertEvent(enabledUserID, time.Minute, []string{})
+	insertEvent(enabledUserID, time.Hour, attendees)
+	insertEvent(disabledUserID, time.Minute, attendees)
+
+	createdCount, err := createMeetingNotesForStartedEvents(db, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, createdCount)
+
+	// notes aren't duplicated on later runs
+	createdCount, err = createMeetingNotesForStartedEvents(db, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, createdCount)
+
+	count, err := database.GetNoteCollection(db).CountDocuments(ctx, bson.M{"user_id": enabledUserID, "linked_event_id": startedEventID})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	count, err = database.GetNoteCollection(db).CountDocuments(ctx, bson.M{"user_id": disabledUserID})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
@@ -0,0 +1,49 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"github.com/franchizzle/task-manager/backend/meetingprep"
+	"github.com/franchizzle/task-manager/backend/settings"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const MEETING_PREP_JOB_INTERVAL = 5 * time.Minute
+
+func meetingPrepJob() {
+	_, err := EnsureJobOnlyRunsOncePerInterval("meeting_prep", MEETING_PREP_JOB_INTERVAL)
+	if err != nil {
+		return
+	}
+	db, cleanup, err := database.GetDBConnection()
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to connect to db for meeting prep")
+		return
+	}
+	defer cleanup()
+	err = syncMeetingPrepTasks(db, time.Now())
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to sync meeting prep tasks")
+	}
+}
+
+// syncMeetingPrepTasks syncs prep tasks for every user with the meeting prep view added. A failure
+// for one user is logged so that it doesn't hold up the rest
+func syncMeetingPrepTasks(db *mongo.Database, now time.Time) error {
+	logger := logging.GetSentryLogger()
+	userIDs, err := database.GetUserIDsWithView(context.Background(), db, string(constants.ViewMeetingPreparation))
+	if err != nil {
+		return err
+	}
+	for _, userID := range userIDs {
+		_, _, err := meetingprep.SyncPrepTasks(context.Background(), db, userID, now.In(settings.GetUserLocation(context.Background(), db, userID)))
+		if err != nil {
+			logger.Error().Err(err).Msgf("failed to sync meeting prep tasks for user %s", userID.Hex())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/settings"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSyncMeetingPrepTasks(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	ctx := context.Background()
+	now := time.Now()
+
+	createUserWithEvent := func(hasMeetingPrepView bool) primitive.ObjectID {
+		userID := primitive.NewObjectID()
+		// so the event is during working hours whenever the test runs
+		assert.NoError(t, settings.UpdateUserSetting(db, userID, constants.SettingFieldWorkingHoursStart, "00:00"))
+		assert.NoError(t, settings.UpdateUserSetting(db, userID, constants.SettingFieldWorkingHoursEnd, "24:00"))
+		assert.NoError(t, settings.UpdateUserSetting(db, userID, constants.SettingFieldWorkdays, constants.ChoiceKeyEveryDay))
+		_, err := database.UpdateOrCreateCalendarAccount(ctx, db, userID, "acctid", "foobar_source",
+			&database.CalendarAccount{
+				UserID:     userID,
+				IDExternal: "acctid",
+				Calendars:  []database.Calendar{{AccessRole: constants.AccessControlOwner, CalendarID: "calid"}},
+			}, nil)
+		assert.NoError(t, err)
+		_, err = database.GetCalendarEventCollection(db).InsertOne(ctx, database.CalendarEvent{
+			UserID:          userID,
+			IDExternal:      "event_" + userID.Hex(),
+			SourceID:        "gcal",
+			SourceAccountID: "acctid",
+			CalendarID:      "calid",
+			DatetimeStart:   primitive.NewDateTimeFromTime(now.Add(10 * time.Minute)),
+			DatetimeEnd:     primitive.NewDateTimeFromTime(now.Add(time.Hour)),
+		})
+		assert.NoError(t, err)
+		if hasMeetingPrepView {
+			_, err = database.GetViewCollection(db).InsertOne(ctx, database.View{
+				UserID: userID,
+				Type:   string(constants.ViewMeetingPreparation),
+			})
+			assert.NoError(t, err)
+		}
+		return userID
+	}
+	userWithView := createUserWithEvent(true)
+	userWithoutView := createUserWithEvent(false)
+
+	err = syncMeetingPrepTasks(db, now)
+	assert.NoError(t, err)
+
+	count, err := database.GetTaskCollection(db).CountDocuments(ctx, bson.M{"user_id": userWithView, "is_meeting_preparation_task": true})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	count, err = database.GetTaskCollection(db).CountDocuments(ctx, bson.M{"user_id": userWithoutView, "is_meeting_preparation_task": true})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
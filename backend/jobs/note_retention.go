@@ -0,0 +1,29 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/logging"
+)
+
+func deletedNoteRetentionJob() {
+	_, err := EnsureJobOnlyRunsOnceToday("deleted_note_retention")
+	if err != nil {
+		return
+	}
+	db, cleanup, err := database.GetDBConnection()
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to connect to db for deleted note retention")
+		return
+	}
+	defer cleanup()
+	cutoff := time.Now().AddDate(0, 0, -constants.DELETED_NOTE_RETENTION_DAYS)
+	purgedCount, err := database.PurgeDeletedNotes(context.Background(), db, cutoff)
+	if err != nil {
+		return
+	}
+	logging.GetSentryLogger().Info().Msgf("purged %d notes deleted before %s", purgedCount, cutoff.Format(time.RFC3339))
+}
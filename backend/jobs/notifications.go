@@ -0,0 +1,102 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"github.com/franchizzle/task-manager/backend/templating"
+	"github.com/franchizzle/task-manager/backend/utils"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// caps each run so that a large backlog is spread across runs instead of delaying other jobs
+const NOTIFICATION_BATCH_SIZE = 200
+
+func notificationsJob() {
+	db, cleanup, err := database.GetDBConnection()
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to connect to db for notifications")
+		return
+	}
+	defer cleanup()
+	_, err = sendQueuedNotifications(db, utils.MandrillEmailSender{})
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to send queued notifications")
+	}
+}
+
+// sendQueuedNotifications emails queued notifications until the queue is empty or the batch size is hit.
+// Notifications are claimed before sending, so a failed send is logged and dropped rather than retried
+func sendQueuedNotifications(db *mongo.Database, sender utils.EmailSender) (int, error) {
+	logger := logging.GetSentryLogger()
+	sentCount := 0
+	for attempt := 0; attempt < NOTIFICATION_BATCH_SIZE; attempt++ {
+		notification, err := database.ClaimQueuedNotification(context.Background(), db)
+		if err == mongo.ErrNoDocuments {
+			break
+		}
+		if err != nil {
+			return sentCount, err
+		}
+		err = sendNotification(db, sender, notification)
+		if err != nil {
+			logger.Error().Err(err).Msgf("failed to send notification %s", notification.ID.Hex())
+			continue
+		}
+		sentCount++
+	}
+	return sentCount, nil
+}
+
+func sendNotification(db *mongo.Database, sender utils.EmailSender, notification *database.Notification) error {
+	user, err := database.GetUser(context.Background(), db, notification.UserID)
+	if err != nil {
+		return err
+	}
+	if user.Email == "" {
+		return nil
+	}
+	mentioner, err := database.GetUser(context.Background(), db, notification.SenderUserID)
+	if err != nil {
+		return err
+	}
+	mentionerName := mentioner.Name
+	if mentionerName == "" {
+		mentionerName = mentioner.Email
+	}
+
+	item := templating.EmailItem{Title: notification.Body}
+	var subheading string
+	// comments can only be added by the owner of the task or note, so it's looked up as the sender
+	switch notification.Type {
+	case constants.NotificationTypeTaskCommentMention:
+		task, err := database.GetTask(context.Background(), db, notification.TaskID, notification.SenderUserID)
+		if err != nil {
+			return err
+		}
+		subheading = "On the task \"" + getDigestTaskTitle(*task) + "\""
+		item.Link = task.Deeplink
+	case constants.NotificationTypeNoteCommentMention:
+		note, err := database.GetNote(context.Background(), db, notification.NoteID, notification.SenderUserID)
+		if err != nil {
+			return err
+		}
+		noteTitle := "Untitled note"
+		if note.Title != nil && *note.Title != "" {
+			noteTitle = *note.Title
+		}
+		subheading = "On the note \"" + noteTitle + "\""
+	}
+
+	htmlBody, err := templating.RenderEmail(templating.EmailContent{
+		Heading:    mentionerName + " mentioned you",
+		Subheading: subheading,
+		Sections:   []templating.EmailSection{{Title: "Comment", Items: []templating.EmailItem{item}}},
+	})
+	if err != nil {
+		return err
+	}
+	return sender.SendEmail(user.Email, mentionerName+" mentioned you in a comment", htmlBody)
+}
@@ -0,0 +1,68 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSendQueuedNotifications(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+
+	mentioner := database.User{ID: primitive.NewObjectID(), Email: "mentioner@example.com", Name: "Mentioner"}
+	mentioned := database.User{ID: primitive.NewObjectID(), Email: "mentioned@example.com"}
+	_, err = database.GetUserCollection(db).InsertMany(context.Background(), []interface{}{mentioner, mentioned})
+	assert.NoError(t, err)
+	taskTitle := "ship the release"
+	insertResult, err := database.GetTaskCollection(db).InsertOne(context.Background(), database.Task{
+		UserID:   mentioner.ID,
+		Title:    &taskTitle,
+		Deeplink: "https://example.com/task",
+	})
+	assert.NoError(t, err)
+	taskID := insertResult.InsertedID.(primitive.ObjectID)
+
+	err = database.EnqueueNotifications(context.Background(), db, []database.Notification{{
+		UserID:       mentioned.ID,
+		SenderUserID: mentioner.ID,
+		Type:         constants.NotificationTypeTaskCommentMention,
+		TaskID:       taskID,
+		Body:         "@mentioned please take a look",
+	}})
+	assert.NoError(t, err)
+
+	// other packages' tests share the queue, so only look at emails for this test's user
+	getSentEmails := func(sender testEmailSender) []testEmail {
+		sentEmails := []testEmail{}
+		for _, email := range sender.SentEmails {
+			if email.ToEmail == mentioned.Email {
+				sentEmails = append(sentEmails, email)
+			}
+		}
+		return sentEmails
+	}
+
+	sender := testEmailSender{}
+	_, err = sendQueuedNotifications(db, &sender)
+	assert.NoError(t, err)
+	sentEmails := getSentEmails(sender)
+	assert.Equal(t, 1, len(sentEmails))
+	email := sentEmails[0]
+	assert.Equal(t, "mentioned@example.com", email.ToEmail)
+	assert.Equal(t, "Mentioner mentioned you in a comment", email.Subject)
+	assert.Contains(t, email.HTMLBody, "ship the release")
+	assert.Contains(t, email.HTMLBody, "@mentioned please take a look")
+	assert.Contains(t, email.HTMLBody, "https://example.com/task")
+
+	// already claimed notifications aren't sent again
+	sender = testEmailSender{}
+	_, err = sendQueuedNotifications(db, &sender)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(getSentEmails(sender)))
+}
@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// retentionJob purges collections whose retention policy can't be enforced with a TTL index
+func retentionJob() {
+	_, err := EnsureJobOnlyRunsOnceToday("retention")
+	if err != nil {
+		return
+	}
+	db, cleanup, err := database.GetDBConnection()
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to connect to db for retention")
+		return
+	}
+	defer cleanup()
+	purgeExpiredDocuments(db, time.Now())
+}
+
+func purgeExpiredDocuments(db *mongo.Database, now time.Time) {
+	for _, policy := range database.RetentionPolicies {
+		if policy.TTLField != "" {
+			continue
+		}
+		cutoff := now.Add(-policy.Retention)
+		purgedCount, err := database.PurgeExpiredDocuments(context.Background(), db, policy.Collection, cutoff)
+		if err != nil {
+			continue
+		}
+		logging.GetSentryLogger().Info().Msgf("purged %d documents from %s created before %s", purgedCount, policy.Collection, cutoff.Format(time.RFC3339))
+	}
+}
@@ -10,7 +10,17 @@ func GetScheduler() (*gocron.Scheduler, error) {
 	s := gocron.NewScheduler(time.UTC)
 
 	// job schedules
-	_, err := s.Every(1).Day().At("08:00").Do(githubIndustryJob)
+	_, err := s.Every(WORKER_HEARTBEAT_INTERVAL).Do(workerHeartbeatJob)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.Every(1).Day().At("08:00").Do(githubIndustryJob)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.Every(1).Day().At("08:00").Do(githubTeamMetricsJob)
 	if err != nil {
 		return nil, err
 	}
@@ -20,5 +30,40 @@ func GetScheduler() (*gocron.Scheduler, error) {
 		return nil, err
 	}
 
+	_, err = s.Every(1).Hour().Do(dailyDigestJob)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.Every(1).Day().At("09:00").Do(deletedNoteRetentionJob)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.Every(1).Day().At("09:00").Do(retentionJob)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.Every(1).Minute().Do(notificationsJob)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.Every(MEETING_PREP_JOB_INTERVAL).Do(meetingPrepJob)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.Every(1).Minute().Do(meetingNotesJob)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.Every(TOKEN_REFRESH_JOB_INTERVAL).Do(tokenRefreshJob)
+	if err != nil {
+		return nil, err
+	}
+
 	return s, nil
 }
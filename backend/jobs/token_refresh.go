@@ -0,0 +1,55 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const TOKEN_REFRESH_JOB_INTERVAL = 5 * time.Minute
+
+func tokenRefreshJob() {
+	_, err := EnsureJobOnlyRunsOncePerInterval("token_refresh", TOKEN_REFRESH_JOB_INTERVAL)
+	if err != nil {
+		return
+	}
+	db, cleanup, err := database.GetDBConnection()
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to connect to db for token refresh")
+		return
+	}
+	defer cleanup()
+	err = refreshExternalTokens(db, external.GetConfig(), time.Now())
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to refresh external tokens")
+	}
+}
+
+// refreshExternalTokens refreshes every token expiring soon. A failure for one token is logged so that
+// it doesn't hold up the rest
+func refreshExternalTokens(db *mongo.Database, externalConfig external.Config, now time.Time) error {
+	logger := logging.GetSentryLogger()
+	tokens, err := database.GetExternalTokensDueForRefresh(context.Background(), db, external.TokenRefreshServiceIDs, now)
+	if err != nil {
+		return err
+	}
+	refreshedCount := 0
+	for _, token := range tokens {
+		refresher, ok := externalConfig.GetTokenRefresher(token.ServiceID)
+		if !ok {
+			continue
+		}
+		refreshed, err := external.RefreshExternalToken(context.Background(), db, token, refresher, now)
+		if err != nil {
+			logger.Warn().Err(err).Msgf("failed to refresh %s token %s", token.ServiceID, token.ID.Hex())
+		} else if refreshed {
+			refreshedCount++
+		}
+	}
+	logger.Info().Msgf("refreshed %d external tokens", refreshedCount)
+	return nil
+}
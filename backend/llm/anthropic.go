@@ -0,0 +1,150 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+const (
+	ANTHROPIC_BASE_URL      = "https://api.anthropic.com"
+	ANTHROPIC_DEFAULT_MODEL = "claude-instant-1.2"
+	ANTHROPIC_API_VERSION   = "2023-06-01"
+)
+
+type AnthropicClient struct {
+	baseURL string
+	apiKey  string
+	model   string
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicMessagesRequest struct {
+	Model       string             `json:"model"`
+	MaxTokens   int                `json:"max_tokens"`
+	Temperature float32            `json:"temperature"`
+	Messages    []anthropicMessage `json:"messages"`
+	Stream      bool               `json:"stream,omitempty"`
+}
+
+type anthropicUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+type anthropicMessagesResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	Usage anthropicUsage `json:"usage"`
+}
+
+// anthropicStreamEvent holds the fields used from the message_start, content_block_delta,
+// message_delta and error events of a streamed message
+type anthropicStreamEvent struct {
+	Message struct {
+		Usage anthropicUsage `json:"usage"`
+	} `json:"message"`
+	Delta struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"delta"`
+	Usage anthropicUsage `json:"usage"`
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func NewAnthropicClient(llmConfig Config) *AnthropicClient {
+	baseURL := llmConfig.BaseURL
+	if baseURL == "" {
+		baseURL = ANTHROPIC_BASE_URL
+	}
+	model := llmConfig.Model
+	if model == "" {
+		model = ANTHROPIC_DEFAULT_MODEL
+	}
+	return &AnthropicClient{baseURL: strings.TrimSuffix(baseURL, "/"), apiKey: llmConfig.APIKey, model: model}
+}
+
+func (anthropic *AnthropicClient) Provider() string {
+	return ProviderAnthropic
+}
+
+func (anthropic *AnthropicClient) Model() string {
+	return anthropic.model
+}
+
+// Complete sends the prompt as a single user message
+func (anthropic *AnthropicClient) Complete(ctx context.Context, request CompletionRequest) (*Completion, error) {
+	var resp anthropicMessagesResponse
+	err := postJSON(ctx, anthropic.baseURL+"/v1/messages", anthropic.getHeaders(), anthropic.getMessagesRequest(request), &resp)
+	if err != nil {
+		return nil, err
+	}
+	text := ""
+	for _, content := range resp.Content {
+		if content.Type == "text" {
+			text += content.Text
+		}
+	}
+	return &Completion{
+		Text: text,
+		Usage: Usage{
+			PromptTokens:     resp.Usage.InputTokens,
+			CompletionTokens: resp.Usage.OutputTokens,
+		},
+	}, nil
+}
+
+func (anthropic *AnthropicClient) CompleteStream(ctx context.Context, request CompletionRequest, onText func(text string)) (*Completion, error) {
+	messagesRequest := anthropic.getMessagesRequest(request)
+	messagesRequest.Stream = true
+	completion := &Completion{}
+	err := postStream(ctx, anthropic.baseURL+"/v1/messages", anthropic.getHeaders(), messagesRequest, func(event string, data string) (bool, error) {
+		var streamEvent anthropicStreamEvent
+		err := json.Unmarshal([]byte(data), &streamEvent)
+		if err != nil {
+			return false, err
+		}
+		switch event {
+		case "message_start":
+			completion.Usage.PromptTokens = streamEvent.Message.Usage.InputTokens
+		case "content_block_delta":
+			if streamEvent.Delta.Type == "text_delta" {
+				completion.Text += streamEvent.Delta.Text
+				onText(streamEvent.Delta.Text)
+			}
+		case "message_delta":
+			completion.Usage.CompletionTokens = streamEvent.Usage.OutputTokens
+		case "message_stop":
+			return false, nil
+		case "error":
+			return false, errors.New(streamEvent.Error.Message)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return completion, nil
+}
+
+func (anthropic *AnthropicClient) getHeaders() map[string]string {
+	return map[string]string{"x-api-key": anthropic.apiKey, "anthropic-version": ANTHROPIC_API_VERSION}
+}
+
+func (anthropic *AnthropicClient) getMessagesRequest(request CompletionRequest) anthropicMessagesRequest {
+	return anthropicMessagesRequest{
+		Model:       anthropic.model,
+		MaxTokens:   request.MaxTokens,
+		Temperature: request.Temperature,
+		Messages:    []anthropicMessage{{Role: "user", Content: request.Prompt}},
+	}
+}
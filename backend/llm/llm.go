@@ -0,0 +1,91 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/franchizzle/task-manager/backend/config"
+)
+
+const (
+	ProviderOpenAI      = "openai"
+	ProviderAzureOpenAI = "azure_openai"
+	ProviderAnthropic   = "anthropic"
+	// any server exposing the OpenAI completions API, such as vLLM, llama.cpp or Ollama
+	ProviderLocal = "local"
+)
+
+type CompletionRequest struct {
+	Prompt      string
+	MaxTokens   int
+	Temperature float32
+}
+
+// Usage is the number of tokens billed by the provider for a completion
+type Usage struct {
+	PromptTokens     int
+	CompletionTokens int
+}
+
+type Completion struct {
+	Text  string
+	Usage Usage
+}
+
+// LLMClient completes prompts with a single provider and model
+type LLMClient interface {
+	Provider() string
+	Model() string
+	Complete(ctx context.Context, request CompletionRequest) (*Completion, error)
+	// CompleteStream calls onText with each piece of text as it's generated, and returns the full completion
+	CompleteStream(ctx context.Context, request CompletionRequest, onText func(text string)) (*Completion, error)
+}
+
+type Config struct {
+	Provider string
+	APIKey   string
+	// overrides the provider's default URL. Required for Azure OpenAI and local models
+	BaseURL string
+	// the deployment name for Azure OpenAI
+	Model string
+	// only used by Azure OpenAI
+	APIVersion string
+}
+
+// GetConfig reads the provider settings, falling back to OpenAI when no provider is configured
+func GetConfig() Config {
+	llmConfig := Config{
+		Provider:   config.GetSettings().LLMProvider,
+		APIKey:     config.GetSettings().LLMAPIKey,
+		BaseURL:    config.GetSettings().LLMBaseURL,
+		Model:      config.GetSettings().LLMModel,
+		APIVersion: config.GetSettings().LLMAPIVersion,
+	}
+	if llmConfig.Provider == "" {
+		llmConfig.Provider = ProviderOpenAI
+	}
+	if llmConfig.APIKey == "" && llmConfig.Provider == ProviderOpenAI {
+		llmConfig.APIKey = config.GetSettings().OpenAIClientSecret
+	}
+	return llmConfig
+}
+
+func NewClient(llmConfig Config) (LLMClient, error) {
+	switch llmConfig.Provider {
+	case ProviderOpenAI:
+		return NewOpenAIClient(llmConfig), nil
+	case ProviderAzureOpenAI:
+		if llmConfig.BaseURL == "" || llmConfig.Model == "" {
+			return nil, fmt.Errorf("%s requires a base url and a deployment name", llmConfig.Provider)
+		}
+		return NewAzureOpenAIClient(llmConfig), nil
+	case ProviderAnthropic:
+		return NewAnthropicClient(llmConfig), nil
+	case ProviderLocal:
+		if llmConfig.BaseURL == "" || llmConfig.Model == "" {
+			return nil, fmt.Errorf("%s requires a base url and a model", llmConfig.Provider)
+		}
+		return NewLocalClient(llmConfig), nil
+	}
+	return nil, fmt.Errorf("unknown llm provider '%s'", llmConfig.Provider)
+}
@@ -0,0 +1,178 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func getMockServer(t *testing.T, status int, response string, checkRequest func(r *http.Request, body map[string]interface{})) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestBody, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		body := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal(requestBody, &body))
+		checkRequest(r, body)
+		w.WriteHeader(status)
+		w.Write([]byte(response))
+	}))
+}
+
+func TestGetConfig(t *testing.T) {
+	t.Run("DefaultsToOpenAI", func(t *testing.T) {
+		llmConfig := GetConfig()
+		assert.Equal(t, ProviderOpenAI, llmConfig.Provider)
+		assert.Equal(t, "dummy_value", llmConfig.APIKey)
+	})
+	t.Run("Provider", func(t *testing.T) {
+		config.SetForTesting(t, "LLM_PROVIDER", ProviderAnthropic)
+		config.SetForTesting(t, "LLM_API_KEY", "anthropic_key")
+		llmConfig := GetConfig()
+		assert.Equal(t, ProviderAnthropic, llmConfig.Provider)
+		assert.Equal(t, "anthropic_key", llmConfig.APIKey)
+	})
+}
+
+func TestNewClient(t *testing.T) {
+	t.Run("OpenAI", func(t *testing.T) {
+		client, err := NewClient(Config{Provider: ProviderOpenAI})
+		assert.NoError(t, err)
+		assert.Equal(t, ProviderOpenAI, client.Provider())
+		assert.Equal(t, OPENAI_DEFAULT_MODEL, client.Model())
+	})
+	t.Run("Local", func(t *testing.T) {
+		client, err := NewClient(Config{Provider: ProviderLocal, BaseURL: "http://localhost:11434/v1", Model: "llama2"})
+		assert.NoError(t, err)
+		assert.Equal(t, ProviderLocal, client.Provider())
+		assert.Equal(t, "llama2", client.Model())
+	})
+	t.Run("MissingBaseURL", func(t *testing.T) {
+		_, err := NewClient(Config{Provider: ProviderLocal, Model: "llama2"})
+		assert.EqualError(t, err, "local requires a base url and a model")
+		_, err = NewClient(Config{Provider: ProviderAzureOpenAI, Model: "deployment"})
+		assert.EqualError(t, err, "azure_openai requires a base url and a deployment name")
+	})
+	t.Run("UnknownProvider", func(t *testing.T) {
+		_, err := NewClient(Config{Provider: "oops"})
+		assert.EqualError(t, err, "unknown llm provider 'oops'")
+	})
+}
+
+func TestOpenAIClient(t *testing.T) {
+	server := getMockServer(t, http.StatusOK, `{"id": "1", "choices": [{"text": "hello"}], "usage": {"prompt_tokens": 5, "completion_tokens": 2, "total_tokens": 7}}`, func(r *http.Request, body map[string]interface{}) {
+		assert.Equal(t, "/completions", r.URL.Path)
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+		assert.Equal(t, OPENAI_DEFAULT_MODEL, body["model"])
+		assert.Equal(t, "prompt", body["prompt"])
+	})
+	defer server.Close()
+
+	completion, err := NewOpenAIClient(Config{APIKey: "key", BaseURL: server.URL + "/"}).Complete(context.Background(), CompletionRequest{Prompt: "prompt", MaxTokens: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, &Completion{Text: "hello", Usage: Usage{PromptTokens: 5, CompletionTokens: 2}}, completion)
+}
+
+func TestAzureOpenAIClient(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		server := getMockServer(t, http.StatusOK, `{"id": "1", "choices": [{"text": "hello"}], "usage": {"prompt_tokens": 5, "completion_tokens": 2}}`, func(r *http.Request, body map[string]interface{}) {
+			assert.Equal(t, "/openai/deployments/my-deployment/completions", r.URL.Path)
+			assert.Equal(t, AZURE_OPENAI_DEFAULT_API_VERSION, r.URL.Query().Get("api-version"))
+			assert.Equal(t, "key", r.Header.Get("api-key"))
+			assert.Equal(t, "prompt", body["prompt"])
+		})
+		defer server.Close()
+
+		client := NewAzureOpenAIClient(Config{APIKey: "key", BaseURL: server.URL, Model: "my-deployment"})
+		completion, err := client.Complete(context.Background(), CompletionRequest{Prompt: "prompt", MaxTokens: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, &Completion{Text: "hello", Usage: Usage{PromptTokens: 5, CompletionTokens: 2}}, completion)
+	})
+	t.Run("ErrorStatus", func(t *testing.T) {
+		server := getMockServer(t, http.StatusUnauthorized, `{"error": "bad key"}`, func(r *http.Request, body map[string]interface{}) {})
+		defer server.Close()
+
+		client := NewAzureOpenAIClient(Config{APIKey: "key", BaseURL: server.URL, Model: "my-deployment"})
+		_, err := client.Complete(context.Background(), CompletionRequest{Prompt: "prompt"})
+		assert.EqualError(t, err, `error, status code: 401, body: {"error": "bad key"}`)
+	})
+	t.Run("NoChoices", func(t *testing.T) {
+		server := getMockServer(t, http.StatusOK, `{"id": "1", "choices": []}`, func(r *http.Request, body map[string]interface{}) {})
+		defer server.Close()
+
+		client := NewAzureOpenAIClient(Config{APIKey: "key", BaseURL: server.URL, Model: "my-deployment"})
+		_, err := client.Complete(context.Background(), CompletionRequest{Prompt: "prompt"})
+		assert.EqualError(t, err, "completion has no choices")
+	})
+}
+
+func TestAnthropicClient(t *testing.T) {
+	server := getMockServer(t, http.StatusOK, `{"content": [{"type": "text", "text": "hello"}], "usage": {"input_tokens": 5, "output_tokens": 2}}`, func(r *http.Request, body map[string]interface{}) {
+		assert.Equal(t, "/v1/messages", r.URL.Path)
+		assert.Equal(t, "key", r.Header.Get("x-api-key"))
+		assert.Equal(t, ANTHROPIC_API_VERSION, r.Header.Get("anthropic-version"))
+		assert.Equal(t, ANTHROPIC_DEFAULT_MODEL, body["model"])
+		assert.Equal(t, float64(10), body["max_tokens"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"role": "user", "content": "prompt"}}, body["messages"])
+	})
+	defer server.Close()
+
+	client := NewAnthropicClient(Config{APIKey: "key", BaseURL: server.URL})
+	completion, err := client.Complete(context.Background(), CompletionRequest{Prompt: "prompt", MaxTokens: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, &Completion{Text: "hello", Usage: Usage{PromptTokens: 5, CompletionTokens: 2}}, completion)
+}
+
+func TestCompleteStream(t *testing.T) {
+	t.Run("OpenAI", func(t *testing.T) {
+		response := "data: {\"choices\": [{\"text\": \"hel\"}]}\n\ndata: {\"choices\": [{\"text\": \"lo\"}]}\n\ndata: [DONE]\n\n"
+		server := getMockServer(t, http.StatusOK, response, func(r *http.Request, body map[string]interface{}) {
+			assert.Equal(t, "/completions", r.URL.Path)
+			assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+			assert.Equal(t, true, body["stream"])
+		})
+		defer server.Close()
+
+		texts := []string{}
+		completion, err := NewOpenAIClient(Config{APIKey: "key", BaseURL: server.URL}).CompleteStream(context.Background(), CompletionRequest{Prompt: "prompt"}, func(text string) {
+			texts = append(texts, text)
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"hel", "lo"}, texts)
+		assert.Equal(t, &Completion{Text: "hello"}, completion)
+	})
+	t.Run("Anthropic", func(t *testing.T) {
+		response := "event: message_start\ndata: {\"type\": \"message_start\", \"message\": {\"usage\": {\"input_tokens\": 5}}}\n\n" +
+			"event: content_block_delta\ndata: {\"type\": \"content_block_delta\", \"delta\": {\"type\": \"text_delta\", \"text\": \"hel\"}}\n\n" +
+			"event: ping\ndata: {\"type\": \"ping\"}\n\n" +
+			"event: content_block_delta\ndata: {\"type\": \"content_block_delta\", \"delta\": {\"type\": \"text_delta\", \"text\": \"lo\"}}\n\n" +
+			"event: message_delta\ndata: {\"type\": \"message_delta\", \"usage\": {\"output_tokens\": 2}}\n\n" +
+			"event: message_stop\ndata: {\"type\": \"message_stop\"}\n\n"
+		server := getMockServer(t, http.StatusOK, response, func(r *http.Request, body map[string]interface{}) {
+			assert.Equal(t, "/v1/messages", r.URL.Path)
+			assert.Equal(t, true, body["stream"])
+		})
+		defer server.Close()
+
+		texts := []string{}
+		completion, err := NewAnthropicClient(Config{APIKey: "key", BaseURL: server.URL}).CompleteStream(context.Background(), CompletionRequest{Prompt: "prompt"}, func(text string) {
+			texts = append(texts, text)
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"hel", "lo"}, texts)
+		assert.Equal(t, &Completion{Text: "hello", Usage: Usage{PromptTokens: 5, CompletionTokens: 2}}, completion)
+	})
+	t.Run("AnthropicError", func(t *testing.T) {
+		response := "event: error\ndata: {\"type\": \"error\", \"error\": {\"type\": \"overloaded_error\", \"message\": \"Overloaded\"}}\n\n"
+		server := getMockServer(t, http.StatusOK, response, func(r *http.Request, body map[string]interface{}) {})
+		defer server.Close()
+
+		_, err := NewAnthropicClient(Config{APIKey: "key", BaseURL: server.URL}).CompleteStream(context.Background(), CompletionRequest{Prompt: "prompt"}, func(text string) {})
+		assert.EqualError(t, err, "Overloaded")
+	})
+}
@@ -0,0 +1,159 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+
+	gogpt "github.com/sashabaranov/go-gpt3"
+)
+
+const (
+	OPENAI_DEFAULT_MODEL             = gogpt.GPT3TextDavinci003
+	AZURE_OPENAI_DEFAULT_API_VERSION = "2022-12-01"
+)
+
+// OpenAIClient is also used for local models, which serve the same completions API
+type OpenAIClient struct {
+	client   *gogpt.Client
+	apiKey   string
+	provider string
+	model    string
+}
+
+func NewOpenAIClient(llmConfig Config) *OpenAIClient {
+	client := gogpt.NewClient(llmConfig.APIKey)
+	if llmConfig.BaseURL != "" {
+		client.BaseURL = strings.TrimSuffix(llmConfig.BaseURL, "/")
+	}
+	model := llmConfig.Model
+	if model == "" {
+		model = OPENAI_DEFAULT_MODEL
+	}
+	return &OpenAIClient{client: client, apiKey: llmConfig.APIKey, provider: ProviderOpenAI, model: model}
+}
+
+func NewLocalClient(llmConfig Config) *OpenAIClient {
+	client := NewOpenAIClient(llmConfig)
+	client.provider = ProviderLocal
+	return client
+}
+
+func (openAI *OpenAIClient) Provider() string {
+	return openAI.provider
+}
+
+func (openAI *OpenAIClient) Model() string {
+	return openAI.model
+}
+
+func (openAI *OpenAIClient) Complete(ctx context.Context, request CompletionRequest) (*Completion, error) {
+	resp, err := openAI.client.CreateCompletion(ctx, getOpenAICompletionRequest(openAI.model, request))
+	if err != nil {
+		return nil, err
+	}
+	return getOpenAICompletion(resp)
+}
+
+// CompleteStream doesn't report usage, which OpenAI leaves out of streamed completions
+func (openAI *OpenAIClient) CompleteStream(ctx context.Context, request CompletionRequest, onText func(text string)) (*Completion, error) {
+	return streamOpenAICompletion(ctx, openAI.client.BaseURL+"/completions", map[string]string{"Authorization": "Bearer " + openAI.apiKey}, getOpenAICompletionRequest(openAI.model, request), onText)
+}
+
+// AzureOpenAIClient calls a model deployed to an Azure OpenAI resource. Azure serves the OpenAI
+// completions API under a per-deployment path and authenticates with an api-key header
+type AzureOpenAIClient struct {
+	baseURL    string
+	apiKey     string
+	deployment string
+	apiVersion string
+}
+
+func NewAzureOpenAIClient(llmConfig Config) *AzureOpenAIClient {
+	apiVersion := llmConfig.APIVersion
+	if apiVersion == "" {
+		apiVersion = AZURE_OPENAI_DEFAULT_API_VERSION
+	}
+	return &AzureOpenAIClient{
+		baseURL:    strings.TrimSuffix(llmConfig.BaseURL, "/"),
+		apiKey:     llmConfig.APIKey,
+		deployment: llmConfig.Model,
+		apiVersion: apiVersion,
+	}
+}
+
+func (azure *AzureOpenAIClient) Provider() string {
+	return ProviderAzureOpenAI
+}
+
+func (azure *AzureOpenAIClient) Model() string {
+	return azure.deployment
+}
+
+func (azure *AzureOpenAIClient) Complete(ctx context.Context, request CompletionRequest) (*Completion, error) {
+	var resp gogpt.CompletionResponse
+	err := postJSON(ctx, azure.getCompletionsURL(), map[string]string{"api-key": azure.apiKey}, getOpenAICompletionRequest("", request), &resp)
+	if err != nil {
+		return nil, err
+	}
+	return getOpenAICompletion(resp)
+}
+
+func (azure *AzureOpenAIClient) CompleteStream(ctx context.Context, request CompletionRequest, onText func(text string)) (*Completion, error) {
+	return streamOpenAICompletion(ctx, azure.getCompletionsURL(), map[string]string{"api-key": azure.apiKey}, getOpenAICompletionRequest("", request), onText)
+}
+
+func (azure *AzureOpenAIClient) getCompletionsURL() string {
+	return azure.baseURL + "/openai/deployments/" + url.PathEscape(azure.deployment) + "/completions?api-version=" + url.QueryEscape(azure.apiVersion)
+}
+
+func getOpenAICompletionRequest(model string, request CompletionRequest) gogpt.CompletionRequest {
+	return gogpt.CompletionRequest{
+		Model:       model,
+		Prompt:      request.Prompt,
+		MaxTokens:   request.MaxTokens,
+		Temperature: request.Temperature,
+		TopP:        1.0,
+		BestOf:      1,
+	}
+}
+
+func getOpenAICompletion(resp gogpt.CompletionResponse) (*Completion, error) {
+	if len(resp.Choices) == 0 {
+		return nil, errors.New("completion has no choices")
+	}
+	return &Completion{
+		Text: resp.Choices[0].Text,
+		Usage: Usage{
+			PromptTokens:     resp.Usage.PromptTokens,
+			CompletionTokens: resp.Usage.CompletionTokens,
+		},
+	}, nil
+}
+
+// streamOpenAICompletion reads a completion sent as server-sent events, which ends with a [DONE] message
+func streamOpenAICompletion(ctx context.Context, requestURL string, headers map[string]string, request gogpt.CompletionRequest, onText func(text string)) (*Completion, error) {
+	request.Stream = true
+	completion := &Completion{}
+	err := postStream(ctx, requestURL, headers, request, func(event string, data string) (bool, error) {
+		if data == "[DONE]" {
+			return false, nil
+		}
+		var resp gogpt.CompletionResponse
+		err := json.Unmarshal([]byte(data), &resp)
+		if err != nil {
+			return false, err
+		}
+		if len(resp.Choices) > 0 && resp.Choices[0].Text != "" {
+			completion.Text += resp.Choices[0].Text
+			onText(resp.Choices[0].Text)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return completion, nil
+}
@@ -0,0 +1,96 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const REQUEST_TIMEOUT = time.Minute
+
+var httpClient = &http.Client{Timeout: REQUEST_TIMEOUT}
+
+// streamed completions are only bounded by the request context
+var streamHTTPClient = &http.Client{}
+
+func postJSON(ctx context.Context, requestURL string, headers map[string]string, body interface{}, response interface{}) error {
+	request, err := newJSONRequest(ctx, requestURL, headers, body)
+	if err != nil {
+		return err
+	}
+	resp, err := send(httpClient, request)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(response)
+}
+
+// postStream reads a response sent as server-sent events, calling onEvent with each event's name
+// and data until it returns false or the response ends
+func postStream(ctx context.Context, requestURL string, headers map[string]string, body interface{}, onEvent func(event string, data string) (bool, error)) error {
+	request, err := newJSONRequest(ctx, requestURL, headers, body)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "text/event-stream")
+	resp, err := send(streamHTTPClient, request)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	event := ""
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "event:") {
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		} else if strings.HasPrefix(line, "data:") {
+			shouldContinue, err := onEvent(event, strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+			if err != nil || !shouldContinue {
+				return err
+			}
+		} else if line == "" {
+			event = ""
+		}
+	}
+	return scanner.Err()
+}
+
+func newJSONRequest(ctx context.Context, requestURL string, headers map[string]string, body interface{}) (*http.Request, error) {
+	requestBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+	return request, nil
+}
+
+func send(client *http.Client, request *http.Request) (*http.Response, error) {
+	resp, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+		// the error body is kept short, it can echo back the prompt
+		errorBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("error, status code: %d, body: %s", resp.StatusCode, errorBody)
+	}
+	return resp, nil
+}
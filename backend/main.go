@@ -1,8 +1,12 @@
 package main
 
 import (
+	"context"
+
 	"github.com/franchizzle/task-manager/backend/api"
+	"github.com/franchizzle/task-manager/backend/cache"
 	"github.com/franchizzle/task-manager/backend/config"
+	"github.com/franchizzle/task-manager/backend/database"
 	"github.com/franchizzle/task-manager/backend/jobs"
 	"github.com/franchizzle/task-manager/backend/logging"
 	"github.com/franchizzle/task-manager/backend/migrations"
@@ -24,15 +28,25 @@ func main() {
 	env := config.GetEnvironment()
 	utils.ConfigureLogger(env)
 	log.Info().Msgf("Starting server in %s environment", env)
-	// TODO: Validate .env/config at server startup
+	err := config.ValidateSettings()
+	if err != nil {
+		log.Fatal().Msg(err.Error())
+	}
 
-	err := migrations.RunMigrations("migrations")
+	err = migrations.RunMigrations("migrations")
 	logger := logging.GetSentryLogger()
 	if err != nil {
 		logger.Error().Err(err).Msg("error running migrations")
 	}
 	apiStruct, dbCleanup := api.GetAPIWithDBCleanup()
 	defer dbCleanup()
+	err = database.EnsureIndexes(context.Background(), apiStruct.DB)
+	if err != nil {
+		logger.Error().Err(err).Msg("error ensuring indexes")
+	}
+	if config.GetSettings().ReadCacheEnabled {
+		database.EnableReadCache(apiStruct.DB, cache.NewMemoryCache(database.READ_CACHE_TTL, database.READ_CACHE_MAX_ENTRIES))
+	}
 	scheduler, err := jobs.GetScheduler()
 	if err != nil {
 		logger.Error().Err(err).Msg("error getting job scheduler")
@@ -0,0 +1,69 @@
+package meetingnotes
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/templating"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// attendees keep access to the notes for a while after the meeting so they can follow up
+const MEETING_NOTES_SHARE_DURATION = time.Duration(constants.MONTH) * time.Second
+
+// GetOrCreateEventNote returns the note linked to the event, creating one pre-populated with the
+// event's attendees and agenda if there isn't one yet. The returned bool is true when the note was created
+func GetOrCreateEventNote(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, event *database.CalendarEvent) (*database.Note, bool, error) {
+	note, err := database.GetEventNote(ctx, db, userID, event.ID)
+	if err == nil {
+		return note, false, nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, false, err
+	}
+
+	title := event.Title
+	if title == "" {
+		title = "Meeting notes"
+	}
+	body := GetEventNoteBody(event)
+	sharedAccess := database.SharedAccessMeetingAttendees
+	now := time.Now()
+	note = &database.Note{
+		UserID:        userID,
+		LinkedEventID: event.ID,
+		Title:         &title,
+		Body:          &body,
+		CreatedAt:     primitive.NewDateTimeFromTime(now),
+		UpdatedAt:     primitive.NewDateTimeFromTime(now),
+		SharedUntil:   primitive.NewDateTimeFromTime(event.DatetimeEnd.Time().Add(MEETING_NOTES_SHARE_DURATION)),
+		SharedAccess:  &sharedAccess,
+	}
+	insertResult, err := database.GetNoteCollection(db).InsertOne(ctx, note)
+	if err != nil {
+		return nil, false, err
+	}
+	note.ID = insertResult.InsertedID.(primitive.ObjectID)
+	return note, true, nil
+}
+
+// GetEventNoteBody lists the event's attendees and the agenda from its description as markdown
+func GetEventNoteBody(event *database.CalendarEvent) string {
+	sections := []string{}
+	if len(event.AttendeeEmails) > 0 {
+		attendees := "## Attendees\n"
+		for _, attendeeEmail := range event.AttendeeEmails {
+			attendees += "- " + attendeeEmail + "\n"
+		}
+		sections = append(sections, attendees)
+	}
+	if agenda := templating.HTMLToText(event.Body); agenda != "" {
+		sections = append(sections, "## Agenda\n"+agenda+"\n")
+	}
+	sections = append(sections, "## Notes\n")
+	return strings.Join(sections, "\n")
+}
@@ -0,0 +1,51 @@
+package meetingnotes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGetEventNoteBody(t *testing.T) {
+	t.Run("AttendeesAndAgenda", func(t *testing.T) {
+		body := GetEventNoteBody(&database.CalendarEvent{
+			AttendeeEmails: []string{"a@example.com", "b@example.com"},
+			Body:           "<p>Topics:</p><ul><li>roadmap</li><li>hiring</li></ul>",
+		})
+		assert.Equal(t, "## Attendees\n- a@example.com\n- b@example.com\n\n## Agenda\nTopics:\n- roadmap\n- hiring\n\n## Notes\n", body)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, "## Notes\n", GetEventNoteBody(&database.CalendarEvent{}))
+	})
+}
+
+func TestGetOrCreateEventNote(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	userID := primitive.NewObjectID()
+	event := &database.CalendarEvent{
+		ID:             primitive.NewObjectID(),
+		UserID:         userID,
+		Title:          "Team sync",
+		DatetimeEnd:    primitive.NewDateTimeFromTime(time.Now().Add(time.Hour)),
+		AttendeeEmails: []string{"a@example.com"},
+	}
+
+	note, isCreated, err := GetOrCreateEventNote(context.Background(), db, userID, event)
+	assert.NoError(t, err)
+	assert.True(t, isCreated)
+	assert.Equal(t, "Team sync", *note.Title)
+	assert.Equal(t, event.ID, note.LinkedEventID)
+	assert.Equal(t, database.SharedAccessMeetingAttendees, *note.SharedAccess)
+	assert.True(t, note.SharedUntil.Time().After(event.DatetimeEnd.Time()))
+
+	existingNote, isCreated, err := GetOrCreateEventNote(context.Background(), db, userID, event)
+	assert.NoError(t, err)
+	assert.False(t, isCreated)
+	assert.Equal(t, note.ID, existingNote.ID)
+}
@@ -0,0 +1,180 @@
+package meetingprep
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/franchizzle/task-manager/backend/config"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PrepBrief is what the user has recently had going on with a meeting's attendees
+type PrepBrief struct {
+	LastSharedNote *database.Note
+	OpenTasks      []database.Task
+	PullRequests   []database.PullRequest
+}
+
+// GetPrepBrief assembles the brief for an event from the user's notes, tasks and pull requests
+func GetPrepBrief(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, event database.CalendarEvent) (*PrepBrief, error) {
+	brief := &PrepBrief{}
+	attendeeEmails := getOtherAttendeeEmails(event)
+	if len(attendeeEmails) == 0 {
+		return brief, nil
+	}
+
+	lastSharedNote, err := getLastSharedNote(ctx, db, userID, event, attendeeEmails)
+	if err != nil {
+		return nil, err
+	}
+	brief.LastSharedNote = lastSharedNote
+
+	tasks, err := database.GetActiveTasks(ctx, db, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range *tasks {
+		if !task.IsMeetingPreparationTask && taskMentionsAttendees(task, attendeeEmails) {
+			brief.OpenTasks = append(brief.OpenTasks, task)
+		}
+	}
+
+	pullRequests, err := database.GetActivePRs(ctx, db, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, pullRequest := range *pullRequests {
+		if pullRequestInvolvesAttendees(pullRequest, attendeeEmails) {
+			brief.PullRequests = append(brief.PullRequests, pullRequest)
+		}
+	}
+	return brief, nil
+}
+
+// getOtherAttendeeEmails returns the lowercased attendee emails, leaving out the calendar's own account
+func getOtherAttendeeEmails(event database.CalendarEvent) []string {
+	attendeeEmails := []string{}
+	for _, email := range event.AttendeeEmails {
+		email = strings.ToLower(email)
+		if email != "" && email != strings.ToLower(event.SourceAccountID) {
+			attendeeEmails = append(attendeeEmails, email)
+		}
+	}
+	return attendeeEmails
+}
+
+// getLastSharedNote returns the most recently updated note shared with the attendees of an earlier
+// meeting that any of these attendees were also in
+func getLastSharedNote(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, event database.CalendarEvent, attendeeEmails []string) (*database.Note, error) {
+	pastEvents, err := database.GetCalendarEvents(ctx, db, userID, &[]bson.M{
+		{"attendee_emails": bson.M{"$in": attendeeEmails}},
+		{"datetime_start": bson.M{"$lt": event.DatetimeStart}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	pastEventIDs := []primitive.ObjectID{}
+	for _, pastEvent := range *pastEvents {
+		if pastEvent.ID != event.ID {
+			pastEventIDs = append(pastEventIDs, pastEvent.ID)
+		}
+	}
+	if len(pastEventIDs) == 0 {
+		return nil, nil
+	}
+
+	var notes []database.Note
+	err = database.FindWithCollection(ctx, database.GetNoteCollection(db), userID, &[]bson.M{
+		{"linked_event_id": bson.M{"$in": pastEventIDs}},
+		{"shared_access": database.SharedAccessMeetingAttendees},
+		{"is_deleted": bson.M{"$ne": true}},
+	}, &notes, options.Find().SetSort(bson.M{"updated_at": -1}).SetLimit(1))
+	if err != nil || len(notes) == 0 {
+		return nil, err
+	}
+	return &notes[0], nil
+}
+
+// taskMentionsAttendees returns true if the task's title or body contains an attendee's email, or
+// @mentions an attendee by the username part of their email
+func taskMentionsAttendees(task database.Task, attendeeEmails []string) bool {
+	text := ""
+	if task.Title != nil {
+		text += *task.Title
+	}
+	if task.Body != nil {
+		text += "\n" + *task.Body
+	}
+	lowerText := strings.ToLower(text)
+	mentions := utils.ExtractMentions(text)
+	for _, email := range attendeeEmails {
+		if strings.Contains(lowerText, email) {
+			return true
+		}
+		for _, mention := range mentions {
+			if mention == getEmailUsername(email) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// pullRequestInvolvesAttendees matches GitHub logins against the username part of the attendee
+// emails, since GitHub doesn't give out the emails of pull request authors and reviewers
+func pullRequestInvolvesAttendees(pullRequest database.PullRequest, attendeeEmails []string) bool {
+	logins := []string{pullRequest.Author}
+	for _, comment := range pullRequest.Comments {
+		logins = append(logins, comment.Author)
+	}
+	for _, email := range attendeeEmails {
+		for _, login := range logins {
+			if login != "" && strings.ToLower(login) == getEmailUsername(email) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func getEmailUsername(email string) string {
+	username, _, _ := strings.Cut(email, "@")
+	return username
+}
+
+// GetPrepBriefBody formats the brief as the markdown body of the prep task, which is empty when
+// there's nothing to brief on
+func GetPrepBriefBody(brief *PrepBrief) string {
+	sections := []string{}
+	if brief.LastSharedNote != nil {
+		title := "Untitled note"
+		if brief.LastSharedNote.Title != nil && *brief.LastSharedNote.Title != "" {
+			title = *brief.LastSharedNote.Title
+		}
+		sections = append(sections, fmt.Sprintf("**Last shared note**\n[%s](%snote/%s)",
+			title, config.GetSettings().HomeURL, brief.LastSharedNote.ID.Hex()))
+	}
+	if len(brief.OpenTasks) > 0 {
+		lines := []string{"**Open tasks mentioning attendees**"}
+		for _, task := range brief.OpenTasks {
+			if task.Title != nil {
+				lines = append(lines, "- "+*task.Title)
+			}
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+	if len(brief.PullRequests) > 0 {
+		lines := []string{"**Related pull requests**"}
+		for _, pullRequest := range brief.PullRequests {
+			lines = append(lines, fmt.Sprintf("- [%s](%s) in %s", pullRequest.Title, pullRequest.Deeplink, pullRequest.RepositoryName))
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+	return strings.Join(sections, "\n\n")
+}
@@ -0,0 +1,58 @@
+package meetingprep
+
+import (
+	"testing"
+
+	"github.com/franchizzle/task-manager/backend/config"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGetOtherAttendeeEmails(t *testing.T) {
+	event := database.CalendarEvent{
+		SourceAccountID: "me@example.com",
+		AttendeeEmails:  []string{"Me@example.com", "Alice@Example.com", "", "bob@example.com"},
+	}
+	assert.Equal(t, []string{"alice@example.com", "bob@example.com"}, getOtherAttendeeEmails(event))
+}
+
+func TestTaskMentionsAttendees(t *testing.T) {
+	attendees := []string{"alice@example.com"}
+	getTask := func(title string, body string) database.Task {
+		return database.Task{Title: &title, Body: &body}
+	}
+	assert.True(t, taskMentionsAttendees(getTask("Send deck to Alice@example.com", ""), attendees))
+	assert.True(t, taskMentionsAttendees(getTask("Send deck", "ask @alice for numbers"), attendees))
+	assert.False(t, taskMentionsAttendees(getTask("Send deck", "ask @alicia for numbers"), attendees))
+	assert.False(t, taskMentionsAttendees(database.Task{}, attendees))
+}
+
+func TestPullRequestInvolvesAttendees(t *testing.T) {
+	attendees := []string{"alice@example.com"}
+	assert.True(t, pullRequestInvolvesAttendees(database.PullRequest{Author: "Alice"}, attendees))
+	assert.True(t, pullRequestInvolvesAttendees(database.PullRequest{
+		Author:   "bob",
+		Comments: []database.PullRequestComment{{Author: "alice"}},
+	}, attendees))
+	assert.False(t, pullRequestInvolvesAttendees(database.PullRequest{Author: "bob"}, attendees))
+}
+
+func TestGetPrepBriefBody(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, "", GetPrepBriefBody(&PrepBrief{}))
+	})
+	t.Run("Full", func(t *testing.T) {
+		noteID := primitive.NewObjectID()
+		noteTitle := "Weekly sync"
+		taskTitle := "Send deck"
+		brief := &PrepBrief{
+			LastSharedNote: &database.Note{ID: noteID, Title: &noteTitle},
+			OpenTasks:      []database.Task{{Title: &taskTitle}},
+			PullRequests:   []database.PullRequest{{Title: "Fix login", Deeplink: "https://github.com/org/repo/pull/1", RepositoryName: "org/repo"}},
+		}
+		assert.Equal(t, "**Last shared note**\n[Weekly sync]("+config.GetSettings().HomeURL+"note/"+noteID.Hex()+")\n\n"+
+			"**Open tasks mentioning attendees**\n- Send deck\n\n"+
+			"**Related pull requests**\n- [Fix login](https://github.com/org/repo/pull/1) in org/repo", GetPrepBriefBody(brief))
+	})
+}
@@ -0,0 +1,258 @@
+package meetingprep
+
+import (
+	"context"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"github.com/franchizzle/task-manager/backend/settings"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type calendarKey struct {
+	accountID  string
+	calendarID string
+}
+
+func getCalendarToAccessRole(calendarAccounts *[]database.CalendarAccount) map[calendarKey]string {
+	calendarToAccessRole := make(map[calendarKey]string)
+	for _, calendarAccount := range *calendarAccounts {
+		for _, calendar := range calendarAccount.Calendars {
+			calendarToAccessRole[calendarKey{calendarAccount.IDExternal, calendar.CalendarID}] = calendar.AccessRole
+		}
+		calendarToAccessRole[calendarKey{calendarAccount.IDExternal, "primary"}] = constants.AccessControlOwner
+	}
+	return calendarToAccessRole
+}
+
+// GetMatchingEvents filters events down to the ones the user's rules create prep tasks for. Working
+// hours are checked in now's timezone, so it should be in the user's timezone
+func GetMatchingEvents(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, events []database.CalendarEvent, now time.Time) ([]database.CalendarEvent, error) {
+	rules, err := database.GetMeetingPrepRules(ctx, db, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !rules.IsEnabled {
+		return []database.CalendarEvent{}, nil
+	}
+	calendarAccounts, err := database.GetCalendarAccounts(ctx, db, userID)
+	if err != nil {
+		return nil, err
+	}
+	calendarToAccessRole := getCalendarToAccessRole(calendarAccounts)
+	workingHours, err := settings.GetWorkingHours(db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	outOfOfficeEvents, err := getOutOfOfficeEventsForEvents(ctx, db, userID, events)
+	if err != nil {
+		return nil, err
+	}
+
+	matchingEvents := []database.CalendarEvent{}
+	for _, event := range events {
+		// no one prepares for meetings they'll be away for
+		if database.IsOutOfOffice(outOfOfficeEvents, event.DatetimeStart.Time()) {
+			continue
+		}
+		if eventMatchesRules(event, rules, calendarToAccessRole, workingHours, now) {
+			matchingEvents = append(matchingEvents, event)
+		}
+	}
+	return matchingEvents, nil
+}
+
+// getOutOfOfficeEventsForEvents returns the user's out of office events overlapping any of the events' start times
+func getOutOfOfficeEventsForEvents(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, events []database.CalendarEvent) ([]database.CalendarEvent, error) {
+	if len(events) == 0 {
+		return []database.CalendarEvent{}, nil
+	}
+	start, end := events[0].DatetimeStart.Time(), events[0].DatetimeStart.Time()
+	for _, event := range events {
+		if event.DatetimeStart.Time().Before(start) {
+			start = event.DatetimeStart.Time()
+		}
+		if event.DatetimeStart.Time().After(end) {
+			end = event.DatetimeStart.Time()
+		}
+	}
+	outOfOfficeEvents, err := database.GetOutOfOfficeEvents(ctx, db, userID, start, end.Add(time.Second))
+	if err != nil {
+		return nil, err
+	}
+	return *outOfOfficeEvents, nil
+}
+
+func eventMatchesRules(event database.CalendarEvent, rules *database.MeetingPrepRules, calendarToAccessRole map[calendarKey]string, workingHours settings.WorkingHours, now time.Time) bool {
+	// only create meeting prep tasks for "owned" calendars
+	if calendarToAccessRole[calendarKey{event.SourceAccountID, event.CalendarID}] != constants.AccessControlOwner {
+		return false
+	}
+	// out of office, focus time and working location events aren't meetings
+	if event.Category != "" && event.Category != constants.EventCategoryDefault {
+		return false
+	}
+	// events outside working hours are usually personal, so they don't need preparing for
+	if !workingHours.Contains(event.DatetimeStart.Time().In(now.Location())) {
+		return false
+	}
+	if len(rules.Calendars) > 0 {
+		isSelectedCalendar := false
+		for _, calendar := range rules.Calendars {
+			isSelectedCalendar = isSelectedCalendar || (calendar.AccountID == event.SourceAccountID && calendar.CalendarID == event.CalendarID)
+		}
+		if !isSelectedCalendar {
+			return false
+		}
+	}
+	if len(event.AttendeeEmails) < rules.MinimumAttendees {
+		return false
+	}
+	leadTime := time.Duration(rules.LeadTimeMinutes) * time.Minute
+	return event.DatetimeStart.Time().Before(now.Add(leadTime))
+}
+
+// GetOrCreatePrepTask returns the event's prep task, creating it if the event doesn't have one yet.
+// The returned bool is true when the task was created
+func GetOrCreatePrepTask(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, event database.CalendarEvent) (database.Task, bool, error) {
+	taskCollection := database.GetTaskCollection(db)
+	var task database.Task
+	err := taskCollection.FindOne(
+		ctx,
+		bson.M{"$and": []bson.M{
+			{"user_id": userID},
+			{"is_meeting_preparation_task": true},
+			{"meeting_preparation_params.id_external": event.IDExternal},
+			{"source_id": event.SourceID},
+		}},
+	).Decode(&task)
+	if err == nil {
+		return task, false, nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return database.Task{}, false, err
+	}
+
+	isCompleted := false
+	isDeleted := false
+	colorBackground, colorForeground := database.GetCalendarEventColors(event)
+	task = database.Task{
+		Title:                    &event.Title,
+		Body:                     getPrepTaskBody(ctx, db, userID, event),
+		UserID:                   userID,
+		IsCompleted:              &isCompleted,
+		IsDeleted:                &isDeleted,
+		SourceID:                 event.SourceID,
+		CreatedAtExternal:        primitive.NewDateTimeFromTime(time.Now()),
+		UpdatedAt:                primitive.NewDateTimeFromTime(time.Now()),
+		IsMeetingPreparationTask: true,
+		MeetingPreparationParams: &database.MeetingPreparationParams{
+			CalendarEventID: event.ID,
+			IDExternal:      event.IDExternal,
+			DatetimeStart:   event.DatetimeStart,
+			DatetimeEnd:     event.DatetimeEnd,
+			ColorBackground: colorBackground,
+			ColorForeground: colorForeground,
+		},
+	}
+	insertResult, err := taskCollection.InsertOne(ctx, task)
+	if err != nil {
+		return database.Task{}, false, err
+	}
+	task.ID = insertResult.InsertedID.(primitive.ObjectID)
+	return task, true, nil
+}
+
+// getPrepTaskBody returns the brief for a new prep task. The task is still worth creating without one,
+// so failures are only logged
+func getPrepTaskBody(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, event database.CalendarEvent) *string {
+	brief, err := GetPrepBrief(ctx, db, userID, event)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to assemble meeting prep brief")
+		return nil
+	}
+	body := GetPrepBriefBody(brief)
+	if body == "" {
+		return nil
+	}
+	return &body
+}
+
+// CleanupCancelledPrepTasks deletes open prep tasks for upcoming events that are no longer on the
+// user's calendar. Tasks for past events are left to be auto-completed instead
+func CleanupCancelledPrepTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, now time.Time) (int, error) {
+	var tasks []database.Task
+	err := database.FindWithCollection(ctx, database.GetTaskCollection(db), userID, &[]bson.M{
+		{"is_meeting_preparation_task": true},
+		{"is_completed": false},
+		{"is_deleted": bson.M{"$ne": true}},
+		{"meeting_preparation_params.datetime_start": bson.M{"$gte": now}},
+	}, &tasks, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	deletedCount := 0
+	for _, task := range tasks {
+		_, err := database.GetCalendarEventByExternalId(ctx, db, task.MeetingPreparationParams.IDExternal, userID)
+		if err == nil {
+			continue
+		}
+		if err != mongo.ErrNoDocuments {
+			return deletedCount, err
+		}
+		_, err = database.GetTaskCollection(db).UpdateOne(
+			ctx,
+			bson.M{"$and": []bson.M{{"_id": task.ID}, {"user_id": userID}}},
+			bson.M{"$set": bson.M{
+				"is_deleted": true,
+				"deleted_at": primitive.NewDateTimeFromTime(now),
+				"updated_at": primitive.NewDateTimeFromTime(now),
+				"meeting_preparation_params.event_moved_or_deleted": true,
+			}},
+		)
+		if err != nil {
+			return deletedCount, err
+		}
+		deletedCount++
+	}
+	return deletedCount, nil
+}
+
+// SyncPrepTasks creates prep tasks for the user's upcoming events that match their rules and
+// removes the ones whose event was cancelled. It returns how many tasks were created and deleted
+func SyncPrepTasks(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, now time.Time) (int, int, error) {
+	maxLeadTime := time.Duration(constants.MEETING_PREP_MAX_LEAD_TIME_MINUTES) * time.Minute
+	events, err := database.GetCalendarEvents(ctx, db, userID, &[]bson.M{
+		{"datetime_start": bson.M{"$gte": now}},
+		{"datetime_start": bson.M{"$lte": now.Add(maxLeadTime)}},
+		{"linked_task_id": bson.M{"$exists": false}},
+		{"linked_view_id": bson.M{"$exists": false}},
+		{"linked_pull_request_id": bson.M{"$exists": false}},
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	matchingEvents, err := GetMatchingEvents(ctx, db, userID, *events, now)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	createdCount := 0
+	for _, event := range matchingEvents {
+		_, isCreated, err := GetOrCreatePrepTask(ctx, db, userID, event)
+		if err != nil {
+			return createdCount, 0, err
+		}
+		if isCreated {
+			createdCount++
+		}
+	}
+	deletedCount, err := CleanupCancelledPrepTasks(ctx, db, userID, now)
+	return createdCount, deletedCount, err
+}
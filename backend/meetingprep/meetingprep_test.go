@@ -0,0 +1,182 @@
+package meetingprep
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/settings"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestEventMatchesRules(t *testing.T) {
+	now := time.Date(2022, time.January, 1, 12, 0, 0, 0, time.UTC)
+	calendarToAccessRole := map[calendarKey]string{
+		{"acctid", "primary"}:     constants.AccessControlOwner,
+		{"acctid", "calid"}:       constants.AccessControlOwner,
+		{"acctid", "other_calid"}: constants.AccessControlReader,
+	}
+	defaultRules := &database.MeetingPrepRules{IsEnabled: true, LeadTimeMinutes: 30}
+	allHours := settings.WorkingHours{Start: 0, End: 24 * time.Hour, Workdays: []time.Weekday{time.Saturday}}
+	getEvent := func(calendarID string, startsIn time.Duration, attendeeCount int) database.CalendarEvent {
+		attendeeEmails := []string{}
+		for i := 0; i < attendeeCount; i++ {
+			attendeeEmails = append(attendeeEmails, "attendee@example.com")
+		}
+		return database.CalendarEvent{
+			SourceAccountID: "acctid",
+			CalendarID:      calendarID,
+			DatetimeStart:   primitive.NewDateTimeFromTime(now.Add(startsIn)),
+			AttendeeEmails:  attendeeEmails,
+		}
+	}
+
+	t.Run("Match", func(t *testing.T) {
+		assert.True(t, eventMatchesRules(getEvent("calid", 10*time.Minute, 0), defaultRules, calendarToAccessRole, allHours, now))
+		assert.True(t, eventMatchesRules(getEvent("primary", 10*time.Minute, 0), defaultRules, calendarToAccessRole, allHours, now))
+	})
+	t.Run("NotOwnedCalendar", func(t *testing.T) {
+		assert.False(t, eventMatchesRules(getEvent("other_calid", 10*time.Minute, 0), defaultRules, calendarToAccessRole, allHours, now))
+		assert.False(t, eventMatchesRules(getEvent("unknown_calid", 10*time.Minute, 0), defaultRules, calendarToAccessRole, allHours, now))
+	})
+	t.Run("OutsideLeadTime", func(t *testing.T) {
+		assert.False(t, eventMatchesRules(getEvent("calid", 31*time.Minute, 0), defaultRules, calendarToAccessRole, allHours, now))
+	})
+	t.Run("MinimumAttendees", func(t *testing.T) {
+		rules := &database.MeetingPrepRules{IsEnabled: true, LeadTimeMinutes: 30, MinimumAttendees: 2}
+		assert.False(t, eventMatchesRules(getEvent("calid", 10*time.Minute, 1), rules, calendarToAccessRole, allHours, now))
+		assert.True(t, eventMatchesRules(getEvent("calid", 10*time.Minute, 2), rules, calendarToAccessRole, allHours, now))
+	})
+	t.Run("NotAMeeting", func(t *testing.T) {
+		event := getEvent("calid", 10*time.Minute, 0)
+		event.Category = constants.EventCategoryDefault
+		assert.True(t, eventMatchesRules(event, defaultRules, calendarToAccessRole, allHours, now))
+		for _, category := range []string{constants.EventCategoryOutOfOffice, constants.EventCategoryFocusTime, constants.EventCategoryWorkingLocation} {
+			event.Category = category
+			assert.False(t, eventMatchesRules(event, defaultRules, calendarToAccessRole, allHours, now))
+		}
+	})
+	t.Run("OutsideWorkingHours", func(t *testing.T) {
+		// now is a Saturday
+		assert.False(t, eventMatchesRules(getEvent("calid", 10*time.Minute, 0), defaultRules, calendarToAccessRole, settings.DefaultWorkingHours, now))
+		saturdayMornings := settings.WorkingHours{Start: 9 * time.Hour, End: 12 * time.Hour, Workdays: []time.Weekday{time.Saturday}}
+		assert.True(t, eventMatchesRules(getEvent("calid", -10*time.Minute, 0), defaultRules, calendarToAccessRole, saturdayMornings, now))
+		assert.False(t, eventMatchesRules(getEvent("calid", 10*time.Minute, 0), defaultRules, calendarToAccessRole, saturdayMornings, now))
+	})
+	t.Run("SelectedCalendars", func(t *testing.T) {
+		rules := &database.MeetingPrepRules{
+			IsEnabled:       true,
+			LeadTimeMinutes: 30,
+			Calendars:       []database.MeetingPrepCalendar{{AccountID: "acctid", CalendarID: "primary"}},
+		}
+		assert.True(t, eventMatchesRules(getEvent("primary", 10*time.Minute, 0), rules, calendarToAccessRole, allHours, now))
+		assert.False(t, eventMatchesRules(getEvent("calid", 10*time.Minute, 0), rules, calendarToAccessRole, allHours, now))
+	})
+}
+
+func TestSyncPrepTasks(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	ctx := context.Background()
+	// a Wednesday, during the default working hours
+	now := time.Date(2023, time.January, 4, 12, 0, 0, 0, time.UTC)
+	userID := primitive.NewObjectID()
+
+	_, err = database.UpdateOrCreateCalendarAccount(ctx, db, userID, "acctid", "foobar_source",
+		&database.CalendarAccount{
+			UserID:     userID,
+			IDExternal: "acctid",
+			Calendars:  []database.Calendar{{AccessRole: constants.AccessControlOwner, CalendarID: "calid"}},
+		}, nil)
+	assert.NoError(t, err)
+	err = database.UpsertMeetingPrepRules(ctx, db, &database.MeetingPrepRules{
+		UserID:           userID,
+		IsEnabled:        true,
+		LeadTimeMinutes:  30,
+		MinimumAttendees: 2,
+	})
+	assert.NoError(t, err)
+
+	insertEvent := func(idExternal string, startsIn time.Duration, attendeeEmails []string) {
+		_, err := database.GetCalendarEventCollection(db).InsertOne(ctx, database.CalendarEvent{
+			UserID:          userID,
+			IDExternal:      idExternal,
+			SourceID:        "gcal",
+			SourceAccountID: "acctid",
+			CalendarID:      "calid",
+			Title:           idExternal,
+			DatetimeStart:   primitive.NewDateTimeFromTime(now.Add(startsIn)),
+			DatetimeEnd:     primitive.NewDateTimeFromTime(now.Add(startsIn + time.Hour)),
+			AttendeeEmails:  attendeeEmails,
+		})
+		assert.NoError(t, err)
+	}
+	attendees := []string{"a@example.com", "b@example.com"}
+	insertEvent("team_sync", 10*time.Minute, attendees)
+	insertEvent("focus_time", 10*time.Minute, []string{})
+	insertEvent("planning", 2*time.Hour, attendees)
+
+	created, deleted, err := SyncPrepTasks(ctx, db, userID, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, created)
+	assert.Equal(t, 0, deleted)
+
+	// prep tasks aren't duplicated on later runs
+	created, deleted, err = SyncPrepTasks(ctx, db, userID, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, created)
+	assert.Equal(t, 0, deleted)
+
+	var tasks []database.Task
+	err = database.FindWithCollection(ctx, database.GetTaskCollection(db), userID, &[]bson.M{{"is_meeting_preparation_task": true}}, &tasks, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(tasks))
+	assert.Equal(t, "team_sync", *tasks[0].Title)
+
+	t.Run("CancelledEvent", func(t *testing.T) {
+		_, err := database.GetCalendarEventCollection(db).DeleteOne(ctx, bson.M{"user_id": userID, "id_external": "team_sync"})
+		assert.NoError(t, err)
+
+		created, deleted, err := SyncPrepTasks(ctx, db, userID, now)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, created)
+		assert.Equal(t, 1, deleted)
+
+		task, err := database.GetTask(ctx, db, tasks[0].ID, userID)
+		assert.NoError(t, err)
+		assert.True(t, *task.IsDeleted)
+		assert.True(t, task.MeetingPreparationParams.EventMovedOrDeleted)
+	})
+	t.Run("OutOfOffice", func(t *testing.T) {
+		_, err := database.GetCalendarEventCollection(db).InsertOne(ctx, database.CalendarEvent{
+			UserID:          userID,
+			IDExternal:      "vacation",
+			SourceID:        "gcal",
+			SourceAccountID: "acctid",
+			CalendarID:      "calid",
+			Category:        constants.EventCategoryOutOfOffice,
+			DatetimeStart:   primitive.NewDateTimeFromTime(now),
+			DatetimeEnd:     primitive.NewDateTimeFromTime(now.Add(time.Hour)),
+		})
+		assert.NoError(t, err)
+		insertEvent("retro", 15*time.Minute, attendees)
+
+		created, _, err := SyncPrepTasks(ctx, db, userID, now)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, created)
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		err := database.UpsertMeetingPrepRules(ctx, db, &database.MeetingPrepRules{UserID: userID, IsEnabled: false, LeadTimeMinutes: 30})
+		assert.NoError(t, err)
+		insertEvent("standup", 5*time.Minute, attendees)
+
+		created, _, err := SyncPrepTasks(ctx, db, userID, now)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, created)
+	})
+}
@@ -24,7 +24,7 @@ func RunMigrations(relativePath string) error {
 
 func getMigrate(relativePath string) (*migrate.Migrate, error) {
 	filePath := fmt.Sprintf("file://%s", relativePath)
-	fullDBURL := fmt.Sprintf(config.GetConfigValue("MONGO_URI_MIGRATIONS"), config.GetConfigValue("DB_NAME"))
+	fullDBURL := fmt.Sprintf(config.GetSettings().MongoURIMigrations, config.GetSettings().DBName)
 	migrate, err := migrate.New(filePath, fullDBURL)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,112 @@
+package ordering
+
+import (
+	"errors"
+	"strings"
+)
+
+// Ordering keys are compared as plain strings, so a key can always be generated between two others
+// without renumbering any of the items around it. Keys are made up of the characters below, which
+// are in ascending byte order, and never end in the lowest one so that there is room before them
+const DIGITS = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// KeyBetween returns a key which sorts after before and ahead of after. An empty before is the
+// start of the list and an empty after is the end
+func KeyBetween(before string, after string) (string, error) {
+	if !isValidKey(before) || !isValidKey(after) {
+		return "", errors.New("invalid ordering key")
+	}
+	if before != "" && after != "" && before >= after {
+		return "", errors.New("ordering keys are out of order")
+	}
+	return midpoint(before, after), nil
+}
+
+// KeysBetween returns count ascending keys between before and after, spread out so that the keys
+// stay short
+func KeysBetween(before string, after string, count int) ([]string, error) {
+	if count <= 0 {
+		return []string{}, nil
+	}
+	middle, err := KeyBetween(before, after)
+	if err != nil {
+		return nil, err
+	}
+	beforeKeys, err := KeysBetween(before, middle, (count-1)/2)
+	if err != nil {
+		return nil, err
+	}
+	afterKeys, err := KeysBetween(middle, after, count-1-len(beforeKeys))
+	if err != nil {
+		return nil, err
+	}
+	keys := append(beforeKeys, middle)
+	return append(keys, afterKeys...), nil
+}
+
+// Less reports whether the first item sorts ahead of the second. Items which have never been moved
+// have no key and come first, in the order of their legacy ordering IDs
+func Less(keyA string, orderingIDA int, keyB string, orderingIDB int) bool {
+	if (keyA == "") != (keyB == "") {
+		return keyA == ""
+	}
+	if keyA != keyB {
+		return keyA < keyB
+	}
+	return orderingIDA < orderingIDB
+}
+
+func isValidKey(key string) bool {
+	for _, character := range key {
+		if !strings.ContainsRune(DIGITS, character) {
+			return false
+		}
+	}
+	return !strings.HasSuffix(key, DIGITS[:1])
+}
+
+// midpoint finds the shortest key between before and after, treating before as padded with the
+// lowest digit and an empty after as the end of the list
+func midpoint(before string, after string) string {
+	if after != "" {
+		prefixLength := 0
+		for prefixLength < len(after) && digitAt(before, prefixLength) == after[prefixLength] {
+			prefixLength++
+		}
+		if prefixLength > 0 {
+			remainder := ""
+			if prefixLength < len(before) {
+				remainder = before[prefixLength:]
+			}
+			return after[:prefixLength] + midpoint(remainder, after[prefixLength:])
+		}
+	}
+
+	digitBefore := 0
+	if before != "" {
+		digitBefore = strings.IndexByte(DIGITS, before[0])
+	}
+	digitAfter := len(DIGITS)
+	if after != "" {
+		digitAfter = strings.IndexByte(DIGITS, after[0])
+	}
+	if digitAfter-digitBefore > 1 {
+		return string(DIGITS[(digitBefore+digitAfter+1)/2])
+	}
+	// the first digits are consecutive, so the key has to be longer than one of them
+	if len(after) > 1 {
+		return after[:1]
+	}
+	remainder := ""
+	if len(before) > 1 {
+		remainder = before[1:]
+	}
+	return string(DIGITS[digitBefore]) + midpoint(remainder, "")
+}
+
+func digitAt(key string, index int) byte {
+	if index < len(key) {
+		return key[index]
+	}
+	return DIGITS[0]
+}
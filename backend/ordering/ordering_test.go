@@ -0,0 +1,104 @@
+package ordering
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyBetween(t *testing.T) {
+	t.Run("EmptyList", func(t *testing.T) {
+		key, err := KeyBetween("", "")
+		assert.NoError(t, err)
+		assert.Equal(t, "V", key)
+	})
+	t.Run("Start", func(t *testing.T) {
+		key, err := KeyBetween("", "V")
+		assert.NoError(t, err)
+		assert.Equal(t, "G", key)
+	})
+	t.Run("End", func(t *testing.T) {
+		key, err := KeyBetween("V", "")
+		assert.NoError(t, err)
+		assert.Equal(t, "l", key)
+	})
+	t.Run("ConsecutiveDigits", func(t *testing.T) {
+		key, err := KeyBetween("V", "W")
+		assert.NoError(t, err)
+		assert.Equal(t, "VV", key)
+	})
+	t.Run("CommonPrefix", func(t *testing.T) {
+		key, err := KeyBetween("VV", "VW")
+		assert.NoError(t, err)
+		assert.Equal(t, "VVV", key)
+	})
+	t.Run("BeforeFirstDigit", func(t *testing.T) {
+		key, err := KeyBetween("", "1")
+		assert.NoError(t, err)
+		assert.Equal(t, "0V", key)
+	})
+	t.Run("OutOfOrder", func(t *testing.T) {
+		_, err := KeyBetween("W", "V")
+		assert.EqualError(t, err, "ordering keys are out of order")
+		_, err = KeyBetween("V", "V")
+		assert.EqualError(t, err, "ordering keys are out of order")
+	})
+	t.Run("InvalidKey", func(t *testing.T) {
+		_, err := KeyBetween("V0", "")
+		assert.EqualError(t, err, "invalid ordering key")
+		_, err = KeyBetween("", "V-")
+		assert.EqualError(t, err, "invalid ordering key")
+	})
+	t.Run("RepeatedInsertsStaySorted", func(t *testing.T) {
+		keys := []string{"V"}
+		// keep inserting at the front, the end and just after the first key
+		for i := 0; i < 200; i++ {
+			front, err := KeyBetween("", keys[0])
+			assert.NoError(t, err)
+			end, err := KeyBetween(keys[len(keys)-1], "")
+			assert.NoError(t, err)
+			middle, err := KeyBetween(front, keys[0])
+			assert.NoError(t, err)
+			keys = append([]string{front, middle}, append(keys, end)...)
+		}
+		assert.True(t, sort.StringsAreSorted(keys))
+		for _, key := range keys {
+			assert.True(t, isValidKey(key))
+		}
+	})
+}
+
+func TestKeysBetween(t *testing.T) {
+	t.Run("None", func(t *testing.T) {
+		keys, err := KeysBetween("", "", 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{}, keys)
+	})
+	t.Run("Many", func(t *testing.T) {
+		keys, err := KeysBetween("", "", 500)
+		assert.NoError(t, err)
+		assert.Equal(t, 500, len(keys))
+		assert.True(t, sort.StringsAreSorted(keys))
+		for idx, key := range keys {
+			assert.LessOrEqual(t, len(key), 3)
+			if idx > 0 {
+				assert.NotEqual(t, keys[idx-1], key)
+			}
+		}
+	})
+	t.Run("Bounded", func(t *testing.T) {
+		keys, err := KeysBetween("G", "H", 3)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"GG", "GV", "Gl"}, keys)
+	})
+}
+
+func TestLess(t *testing.T) {
+	assert.True(t, Less("", 2, "G", 1))
+	assert.False(t, Less("G", 1, "", 2))
+	assert.True(t, Less("G", 2, "V", 1))
+	assert.True(t, Less("", 1, "", 2))
+	assert.True(t, Less("V", 1, "V", 2))
+	assert.False(t, Less("V", 2, "V", 2))
+}
@@ -20,6 +20,8 @@ type SettingDefinition struct {
 	DefaultChoice string          `json:"-"`
 	Choices       []SettingChoice `json:"choices"`
 	Hidden        bool            `json:"-"`
+	// accepts values outside the choices, for choices which the read cache may not have caught up with
+	FreeForm bool `json:"-"`
 }
 
 type UserSetting struct {
@@ -98,6 +100,25 @@ var GithubSortingDirectionSetting = SettingDefinition{
 	},
 }
 
+var GithubRepositoryFilterSetting = SettingDefinition{
+	FieldKey:      constants.SettingFieldGithubRepositoryFilterMode,
+	DefaultChoice: constants.ChoiceKeyAllRepositories,
+	Choices: []SettingChoice{
+		{Key: constants.ChoiceKeyAllRepositories},
+		{Key: constants.ChoiceKeyAllowlist},
+		{Key: constants.ChoiceKeyDenylist},
+	},
+}
+
+var GithubHideDraftsSetting = SettingDefinition{
+	FieldKey:      constants.SettingFieldGithubHideDrafts,
+	DefaultChoice: "false",
+	Choices: []SettingChoice{
+		{Key: "true"},
+		{Key: "false"},
+	},
+}
+
 var TaskSortingPreferenceSetting = SettingDefinition{
 	FieldKey:      constants.SettingFieldTaskSortingPreference,
 	DefaultChoice: constants.ChoiceKeyManual,
@@ -119,6 +140,27 @@ var TaskSortingDirectionSetting = SettingDefinition{
 	},
 }
 
+// sorting for overview views which aren't manually ordered, see GetViewTaskSortingSettings
+var ViewTaskSortingPreferenceSetting = SettingDefinition{
+	FieldKey:      constants.SettingFieldViewTaskSortingPreference,
+	DefaultChoice: constants.ChoiceKeyDueDate,
+	Choices: []SettingChoice{
+		{Key: constants.ChoiceKeyDueDate},
+		{Key: constants.ChoiceKeyPriority},
+		{Key: constants.ChoiceKeyCreatedAt},
+		{Key: constants.ChoiceKeyUpdatedAt},
+	},
+}
+
+var ViewTaskSortingDirectionSetting = SettingDefinition{
+	FieldKey:      constants.SettingFieldViewTaskSortingDirection,
+	DefaultChoice: constants.ChoiceKeyAscending,
+	Choices: []SettingChoice{
+		{Key: constants.ChoiceKeyAscending},
+		{Key: constants.ChoiceKeyDescending},
+	},
+}
+
 var NoteSortingPreferenceSetting = SettingDefinition{
 	FieldKey:      constants.SettingFieldNoteSortingPreference,
 	DefaultChoice: constants.ChoiceKeyUpdatedAt,
@@ -191,6 +233,24 @@ var HasDismissedMulticalPromptSetting = SettingDefinition{
 	},
 }
 
+var DailyDigestEnabledSetting = SettingDefinition{
+	FieldKey:      constants.SettingFieldDailyDigestEnabled,
+	DefaultChoice: "true",
+	Choices: []SettingChoice{
+		{Key: "true"},
+		{Key: "false"},
+	},
+}
+
+var AutoMeetingNotesEnabledSetting = SettingDefinition{
+	FieldKey:      constants.SettingFieldAutoMeetingNotesEnabled,
+	DefaultChoice: "false",
+	Choices: []SettingChoice{
+		{Key: "true"},
+		{Key: "false"},
+	},
+}
+
 var LinearTaskFilteringSetting = SettingDefinition{
 	DefaultChoice: "all_cycles",
 	Choices: []SettingChoice{
@@ -204,11 +264,14 @@ var LinearTaskFilteringSetting = SettingDefinition{
 
 var TaskSectionSettingTypes = []string{"main", "overview"}
 
+var SortableTaskViewTypes = []constants.ViewType{constants.ViewDueToday, constants.ViewOverdue, constants.ViewWaitingOnOthers}
+
 var hardcodedSettings = []SettingDefinition{
 	// Github PR page settings
 	GithubFilteringSetting,
 	GithubSortingPreferenceSetting,
 	GithubSortingDirectionSetting,
+	GithubHideDraftsSetting,
 	// sidebar settings
 	SidebarLinearSetting,
 	SidebarJiraSetting,
@@ -227,10 +290,33 @@ var hardcodedSettings = []SettingDefinition{
 	LabSmartPrioritizeEnabledSetting,
 	// multical settings
 	HasDismissedMulticalPromptSetting,
+	// email settings
+	DailyDigestEnabledSetting,
+	// meeting notes settings
+	AutoMeetingNotesEnabledSetting,
+	// timezone settings
+	TimezoneSetting,
+	// working hours settings
+	WorkingHoursStartSetting,
+	WorkingHoursEndSetting,
+	WorkdaysSetting,
 }
 
 func GetSettingsOptions(db *mongo.Database, userID primitive.ObjectID) (*[]SettingDefinition, error) {
-	settingsOptions := hardcodedSettings
+	settingsOptions, err := database.CachedRead(userID, "settings_options", func() ([]SettingDefinition, error) {
+		return loadSettingsOptions(db, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// copied since the cached options are shared
+	settingsOptionsCopy := append([]SettingDefinition(nil), settingsOptions...)
+	return &settingsOptionsCopy, nil
+}
+
+func loadSettingsOptions(db *mongo.Database, userID primitive.ObjectID) ([]SettingDefinition, error) {
+	// copied so appending never writes into hardcodedSettings' backing array
+	settingsOptions := append([]SettingDefinition(nil), hardcodedSettings...)
 
 	githubViews, err := getGithubViews(db, userID)
 	if err != nil {
@@ -258,7 +344,60 @@ func GetSettingsOptions(db *mongo.Database, userID primitive.ObjectID) (*[]Setti
 		)
 	}
 
-	taskSections, err := database.GetTaskSections(db, userID)
+	// repository filtering is per Github account, the filter list itself lives in the repositories collection.
+	// Azure DevOps projects are filtered the same way
+	githubTokens, err := getGithubTokens(db, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, githubToken := range *githubTokens {
+		settingsOptions = append(settingsOptions, SettingDefinition{
+			FieldKey:      external.GetGithubRepositoryFilterFieldKey(githubToken.AccountID),
+			FieldName:     githubToken.DisplayID,
+			DefaultChoice: GithubRepositoryFilterSetting.DefaultChoice,
+			Choices:       GithubRepositoryFilterSetting.Choices,
+		})
+	}
+	azureDevOpsTokens, err := database.GetExternalTokens(context.Background(), db, userID, external.TASK_SERVICE_ID_AZURE_DEVOPS)
+	if err != nil {
+		return nil, err
+	}
+	for _, azureDevOpsToken := range *azureDevOpsTokens {
+		settingsOptions = append(settingsOptions, SettingDefinition{
+			FieldKey:      external.GetAzureDevOpsProjectFilterFieldKey(azureDevOpsToken.AccountID),
+			FieldName:     azureDevOpsToken.DisplayID,
+			DefaultChoice: GithubRepositoryFilterSetting.DefaultChoice,
+			Choices:       GithubRepositoryFilterSetting.Choices,
+		})
+	}
+
+	// label and base branch choices come from the user's PRs. Completed PRs are included so the
+	// choices don't narrow down to what the current filter lets through. The read cache doesn't watch
+	// pull requests, so newly fetched values are accepted before they show up in the choices
+	for _, pullRequestFilter := range []struct {
+		fieldKey string
+		field    string
+	}{
+		{constants.SettingFieldGithubLabelFilter, "labels"},
+		{constants.SettingFieldGithubBaseBranchFilter, "base_branch"},
+	} {
+		values, err := database.GetPullRequestFieldValues(context.Background(), db, userID, pullRequestFilter.field)
+		if err != nil {
+			return nil, err
+		}
+		choices := []SettingChoice{{Key: "", Name: ""}}
+		for _, value := range values {
+			choices = append(choices, SettingChoice{Key: value, Name: value})
+		}
+		settingsOptions = append(settingsOptions, SettingDefinition{
+			FieldKey:      pullRequestFilter.fieldKey,
+			DefaultChoice: "",
+			Choices:       choices,
+			FreeForm:      true,
+		})
+	}
+
+	taskSections, err := database.GetTaskSections(context.Background(), db, userID, false)
 	if err != nil {
 		return nil, err
 	}
@@ -302,7 +441,7 @@ func GetSettingsOptions(db *mongo.Database, userID primitive.ObjectID) (*[]Setti
 		Choices:       calendarChoices,
 	})
 
-	calendarAccounts, err := database.GetCalendarAccounts(db, userID)
+	calendarAccounts, err := database.GetCalendarAccounts(context.Background(), db, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -334,18 +473,27 @@ func GetSettingsOptions(db *mongo.Database, userID primitive.ObjectID) (*[]Setti
 	lineartaskFilterSettingOverviewPage.FieldKey = constants.SettingFieldLinearTaskFilteringPreference + "_overview"
 	settingsOptions = append(settingsOptions, lineartaskFilterSettingOverviewPage)
 
-	return &settingsOptions, nil
+	for _, viewType := range SortableTaskViewTypes {
+		sortingPreference, sortingDirection := GetViewTaskSortingSettings(viewType)
+		settingsOptions = append(settingsOptions, sortingPreference, sortingDirection)
+	}
+
+	return settingsOptions, nil
 }
 
 // this helper can't live in the db package because its use of the external package would cause an import cycle
 func getCalendarTokens(db *mongo.Database, userID primitive.ObjectID) (*[]database.ExternalAPIToken, error) {
 	// in the future, make sure we add other services here with calendars
-	return database.GetExternalTokens(db, userID, external.TASK_SERVICE_ID_GOOGLE)
+	return database.GetExternalTokens(context.Background(), db, userID, external.TASK_SERVICE_ID_GOOGLE)
+}
+
+func getGithubTokens(db *mongo.Database, userID primitive.ObjectID) (*[]database.ExternalAPIToken, error) {
+	return database.GetExternalTokens(context.Background(), db, userID, external.TASK_SERVICE_ID_GITHUB)
 }
 
 func getGithubViews(db *mongo.Database, userID primitive.ObjectID) (*[]database.View, error) {
 	var views []database.View
-	err := database.FindWithCollection(database.GetViewCollection(db), userID, &[]bson.M{{"user_id": userID}, {"type": constants.ViewGithub}}, &views, nil)
+	err := database.FindWithCollection(context.Background(), database.GetViewCollection(db), userID, &[]bson.M{{"user_id": userID}, {"type": constants.ViewGithub}}, &views, nil)
 	logger := logging.GetSentryLogger()
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to load github views")
@@ -358,6 +506,15 @@ func getGithubFieldKey(githubView database.View, suffix string) string {
 	return githubView.ID.Hex() + "_" + suffix
 }
 
+// GetViewTaskSortingSettings returns the sorting preference and direction settings for the view type
+func GetViewTaskSortingSettings(viewType constants.ViewType) (SettingDefinition, SettingDefinition) {
+	sortingPreference := ViewTaskSortingPreferenceSetting
+	sortingPreference.FieldKey = string(viewType) + "_" + ViewTaskSortingPreferenceSetting.FieldKey
+	sortingDirection := ViewTaskSortingDirectionSetting
+	sortingDirection.FieldKey = string(viewType) + "_" + ViewTaskSortingDirectionSetting.FieldKey
+	return sortingPreference, sortingDirection
+}
+
 func getTaskSectionFieldKey(taskSection database.TaskSection, suffix string, settingType string) string {
 	return taskSection.ID.Hex() + "_" + suffix + "_" + settingType
 }
@@ -407,6 +564,26 @@ func GetSettingValue(settings []database.UserSetting, searchSetting SettingDefin
 	return searchSetting.DefaultChoice
 }
 
+// GetUserSettingValue returns the user's value for a single setting, falling back to its default choice
+func GetUserSettingValue(db *mongo.Database, userID primitive.ObjectID, setting SettingDefinition) (string, error) {
+	var userSetting database.UserSetting
+	err := database.GetUserSettingsCollection(db).FindOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"user_id": userID},
+			{"field_key": setting.FieldKey},
+		}},
+	).Decode(&userSetting)
+	if err == mongo.ErrNoDocuments {
+		return setting.DefaultChoice, nil
+	}
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to load user setting")
+		return "", err
+	}
+	return userSetting.FieldValue, nil
+}
+
 func UpdateUserSetting(db *mongo.Database, userID primitive.ObjectID, fieldKey string, fieldValue string) error {
 	keyFound := false
 	valueFound := false
@@ -420,6 +597,7 @@ func UpdateUserSetting(db *mongo.Database, userID primitive.ObjectID, fieldKey s
 	for _, setting := range *settingsOptions {
 		if setting.FieldKey == fieldKey {
 			keyFound = true
+			valueFound = setting.FreeForm
 			for _, choice := range setting.Choices {
 				if choice.Key == fieldValue {
 					valueFound = true
@@ -434,7 +612,7 @@ func UpdateUserSetting(db *mongo.Database, userID primitive.ObjectID, fieldKey s
 	if !valueFound {
 		return errors.New("invalid value: " + fieldValue)
 	}
-	err = database.UpdateUserSetting(db, userID, fieldKey, fieldValue)
+	err = database.UpdateUserSetting(context.Background(), db, userID, fieldKey, fieldValue)
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to update user setting")
 		return errors.New("internal server error")
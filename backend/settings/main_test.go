@@ -76,7 +76,7 @@ func TestGetSettingsOptions(t *testing.T) {
 		&database.CalendarAccount{
 			UserID:     userID,
 			IDExternal: "b",
-			Calendars:  []database.Calendar{{"", "cal1", "", "title1", "", ""}, {"", "cal2", "", "title2", "", ""}},
+			Calendars:  []database.Calendar{{CalendarID: "cal1", Title: "title1"}, {CalendarID: "cal2", Title: "title2"}},
 		},
 	)
 	assert.NoError(t, err)
@@ -99,34 +99,63 @@ func TestGetSettingsOptions(t *testing.T) {
 	)
 	assert.NoError(t, err)
 
+	pullRequestCollection := database.GetPullRequestCollection(db)
+	_, err = pullRequestCollection.InsertOne(context.Background(), database.PullRequest{UserID: userID, BaseBranch: "main", Labels: []string{"frontend", "bug"}})
+	assert.NoError(t, err)
+	_, err = pullRequestCollection.InsertOne(context.Background(), database.PullRequest{UserID: userID, BaseBranch: "main", Labels: []string{"bug"}})
+	assert.NoError(t, err)
+	// wrong user id
+	_, err = pullRequestCollection.InsertOne(context.Background(), database.PullRequest{UserID: primitive.NewObjectID(), BaseBranch: "develop", Labels: []string{"docs"}})
+	assert.NoError(t, err)
+
 	t.Run("Success", func(t *testing.T) {
 		settings, err := GetSettingsOptions(db, userID)
 		assert.NoError(t, err)
-		assert.Equal(t, 30, len(*settings))
-		assert.Equal(t, "sidebar_linear_preference", (*settings)[3].FieldKey)
-		assert.Equal(t, "sidebar_jira_preference", (*settings)[4].FieldKey)
-		assert.Equal(t, "sidebar_github_preference", (*settings)[5].FieldKey)
-		assert.Equal(t, "sidebar_slack_preference", (*settings)[6].FieldKey)
-		assert.Equal(t, "note_sorting_preference", (*settings)[7].FieldKey)
-		assert.Equal(t, "note_sorting_direction", (*settings)[8].FieldKey)
-		assert.Equal(t, "note_filtering_preference", (*settings)[9].FieldKey)
-		assert.Equal(t, "recurring_task_filtering_preference", (*settings)[10].FieldKey)
-		assert.Equal(t, "collapse_empty_lists", (*settings)[11].FieldKey)
-		assert.Equal(t, "move_empty_lists_to_bottom", (*settings)[12].FieldKey)
-		assert.Equal(t, "lab_smart_prioritize_enabled", (*settings)[13].FieldKey)
-		assert.Equal(t, "has_dismissed_multical_prompt", (*settings)[14].FieldKey)
-		assert.Equal(t, insertedViewID+"_github_filtering_preference", (*settings)[15].FieldKey)
-		assert.Equal(t, insertedViewID+"_github_sorting_preference", (*settings)[16].FieldKey)
-		assert.Equal(t, insertedViewID+"_github_sorting_direction", (*settings)[17].FieldKey)
-		assert.Equal(t, insertedSectionID+"_task_sorting_preference_main", (*settings)[18].FieldKey)
-		assert.Equal(t, insertedSectionID+"_task_sorting_direction_main", (*settings)[19].FieldKey)
-		assert.Equal(t, insertedSectionID+"_task_sorting_preference_overview", (*settings)[20].FieldKey)
-		assert.Equal(t, insertedSectionID+"_task_sorting_direction_overview", (*settings)[21].FieldKey)
-		assert.Equal(t, "000000000000000000000001_task_sorting_preference_main", (*settings)[22].FieldKey)
-		assert.Equal(t, "000000000000000000000001_task_sorting_direction_main", (*settings)[23].FieldKey)
-		assert.Equal(t, "000000000000000000000001_task_sorting_preference_overview", (*settings)[24].FieldKey)
-		assert.Equal(t, "000000000000000000000001_task_sorting_direction_overview", (*settings)[25].FieldKey)
-		calendarSetting := (*settings)[26]
+		assert.Equal(t, 45, len(*settings))
+		assert.Equal(t, "github_hide_drafts", (*settings)[3].FieldKey)
+		assert.Equal(t, "sidebar_linear_preference", (*settings)[4].FieldKey)
+		assert.Equal(t, "sidebar_jira_preference", (*settings)[5].FieldKey)
+		assert.Equal(t, "sidebar_github_preference", (*settings)[6].FieldKey)
+		assert.Equal(t, "sidebar_slack_preference", (*settings)[7].FieldKey)
+		assert.Equal(t, "note_sorting_preference", (*settings)[8].FieldKey)
+		assert.Equal(t, "note_sorting_direction", (*settings)[9].FieldKey)
+		assert.Equal(t, "note_filtering_preference", (*settings)[10].FieldKey)
+		assert.Equal(t, "recurring_task_filtering_preference", (*settings)[11].FieldKey)
+		assert.Equal(t, "collapse_empty_lists", (*settings)[12].FieldKey)
+		assert.Equal(t, "move_empty_lists_to_bottom", (*settings)[13].FieldKey)
+		assert.Equal(t, "lab_smart_prioritize_enabled", (*settings)[14].FieldKey)
+		assert.Equal(t, "has_dismissed_multical_prompt", (*settings)[15].FieldKey)
+		assert.Equal(t, "daily_digest_enabled", (*settings)[16].FieldKey)
+		assert.Equal(t, "auto_meeting_notes_enabled", (*settings)[17].FieldKey)
+		assert.Equal(t, "timezone", (*settings)[18].FieldKey)
+		assert.Equal(t, "working_hours_start", (*settings)[19].FieldKey)
+		assert.Equal(t, "working_hours_end", (*settings)[20].FieldKey)
+		assert.Equal(t, "workdays", (*settings)[21].FieldKey)
+		assert.Equal(t, insertedViewID+"_github_filtering_preference", (*settings)[22].FieldKey)
+		assert.Equal(t, insertedViewID+"_github_sorting_preference", (*settings)[23].FieldKey)
+		assert.Equal(t, insertedViewID+"_github_sorting_direction", (*settings)[24].FieldKey)
+		labelSetting := (*settings)[25]
+		assert.Equal(t, constants.SettingFieldGithubLabelFilter, labelSetting.FieldKey)
+		assert.Equal(t, []SettingChoice{
+			{Key: "", Name: ""},
+			{Key: "bug", Name: "bug"},
+			{Key: "frontend", Name: "frontend"},
+		}, labelSetting.Choices)
+		baseBranchSetting := (*settings)[26]
+		assert.Equal(t, constants.SettingFieldGithubBaseBranchFilter, baseBranchSetting.FieldKey)
+		assert.Equal(t, []SettingChoice{
+			{Key: "", Name: ""},
+			{Key: "main", Name: "main"},
+		}, baseBranchSetting.Choices)
+		assert.Equal(t, insertedSectionID+"_task_sorting_preference_main", (*settings)[27].FieldKey)
+		assert.Equal(t, insertedSectionID+"_task_sorting_direction_main", (*settings)[28].FieldKey)
+		assert.Equal(t, insertedSectionID+"_task_sorting_preference_overview", (*settings)[29].FieldKey)
+		assert.Equal(t, insertedSectionID+"_task_sorting_direction_overview", (*settings)[30].FieldKey)
+		assert.Equal(t, "000000000000000000000001_task_sorting_preference_main", (*settings)[31].FieldKey)
+		assert.Equal(t, "000000000000000000000001_task_sorting_direction_main", (*settings)[32].FieldKey)
+		assert.Equal(t, "000000000000000000000001_task_sorting_preference_overview", (*settings)[33].FieldKey)
+		assert.Equal(t, "000000000000000000000001_task_sorting_direction_overview", (*settings)[34].FieldKey)
+		calendarSetting := (*settings)[35]
 		assert.Equal(t, constants.SettingFieldCalendarForNewTasks, calendarSetting.FieldKey)
 		assert.Equal(t, "a", calendarSetting.DefaultChoice)
 		assert.Equal(t, []SettingChoice{
@@ -134,12 +163,37 @@ func TestGetSettingsOptions(t *testing.T) {
 			{Key: "b", Name: "oof 2"},
 			{Key: "", Name: ""},
 		}, calendarSetting.Choices)
-		calendarIDSetting := (*settings)[27]
+		calendarIDSetting := (*settings)[36]
 		assert.Equal(t, constants.SettingFieldCalendarIDForNewTasks, calendarIDSetting.FieldKey)
 		assert.Equal(t, []SettingChoice{
 			{Key: "cal1", Name: "title1"},
 			{Key: "cal2", Name: "title2"},
 			{Key: "", Name: ""},
 		}, calendarIDSetting.Choices)
+		assert.Equal(t, "due_today_task_sorting_preference", (*settings)[len(*settings)-6].FieldKey)
+		assert.Equal(t, "due_today_task_sorting_direction", (*settings)[len(*settings)-5].FieldKey)
+		assert.Equal(t, "overdue_task_sorting_preference", (*settings)[len(*settings)-4].FieldKey)
+		assert.Equal(t, "overdue_task_sorting_direction", (*settings)[len(*settings)-3].FieldKey)
+		assert.Equal(t, "waiting_on_others_task_sorting_preference", (*settings)[len(*settings)-2].FieldKey)
+		assert.Equal(t, "waiting_on_others_task_sorting_direction", (*settings)[len(*settings)-1].FieldKey)
+	})
+}
+
+func TestUpdateUserSetting(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	userID := primitive.NewObjectID()
+
+	t.Run("InvalidChoice", func(t *testing.T) {
+		err := UpdateUserSetting(db, userID, constants.SettingFieldGithubHideDrafts, "maybe")
+		assert.EqualError(t, err, "invalid value: maybe")
+	})
+	t.Run("FreeFormValueNotInChoices", func(t *testing.T) {
+		// the label comes from a PR fetched after the options were loaded
+		assert.NoError(t, UpdateUserSetting(db, userID, constants.SettingFieldGithubLabelFilter, "new-label"))
+		setting, err := database.GetUserSetting(context.Background(), db, userID, constants.SettingFieldGithubLabelFilter)
+		assert.NoError(t, err)
+		assert.Equal(t, "new-label", setting.FieldValue)
 	})
 }
@@ -0,0 +1,123 @@
+package settings
+
+import (
+	"context"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/franchizzle/task-manager/backend/logging"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// timezones users can pick instead of the one detected from their browser or calendar
+var timezones = []string{
+	"Pacific/Honolulu",
+	"America/Anchorage",
+	"America/Los_Angeles",
+	"America/Phoenix",
+	"America/Denver",
+	"America/Chicago",
+	"America/Mexico_City",
+	"America/New_York",
+	"America/Toronto",
+	"America/Bogota",
+	"America/Halifax",
+	"America/Santiago",
+	"America/Sao_Paulo",
+	"America/Argentina/Buenos_Aires",
+	"America/St_Johns",
+	"Atlantic/Azores",
+	"UTC",
+	"Europe/London",
+	"Europe/Lisbon",
+	"Europe/Dublin",
+	"Europe/Paris",
+	"Europe/Berlin",
+	"Europe/Madrid",
+	"Europe/Amsterdam",
+	"Europe/Stockholm",
+	"Europe/Warsaw",
+	"Africa/Lagos",
+	"Africa/Cairo",
+	"Africa/Johannesburg",
+	"Europe/Athens",
+	"Europe/Istanbul",
+	"Europe/Moscow",
+	"Africa/Nairobi",
+	"Asia/Dubai",
+	"Asia/Tehran",
+	"Asia/Karachi",
+	"Asia/Kolkata",
+	"Asia/Kathmandu",
+	"Asia/Dhaka",
+	"Asia/Bangkok",
+	"Asia/Jakarta",
+	"Asia/Singapore",
+	"Asia/Shanghai",
+	"Asia/Hong_Kong",
+	"Asia/Taipei",
+	"Asia/Manila",
+	"Asia/Seoul",
+	"Asia/Tokyo",
+	"Australia/Perth",
+	"Australia/Adelaide",
+	"Australia/Brisbane",
+	"Australia/Sydney",
+	"Pacific/Auckland",
+}
+
+var TimezoneSetting = SettingDefinition{
+	FieldKey:      constants.SettingFieldTimezone,
+	DefaultChoice: constants.ChoiceKeyTimezoneAutomatic,
+	Choices:       getTimezoneChoices(),
+}
+
+func getTimezoneChoices() []SettingChoice {
+	choices := []SettingChoice{{Key: constants.ChoiceKeyTimezoneAutomatic}}
+	for _, timezone := range timezones {
+		choices = append(choices, SettingChoice{Key: timezone, Name: timezone})
+	}
+	return choices
+}
+
+// GetTimezoneOverride returns the timezone the user picked in their settings, or nil if they
+// left it to be detected automatically
+func GetTimezoneOverride(db *mongo.Database, userID primitive.ObjectID) (*time.Location, error) {
+	timezone, err := GetUserSettingValue(db, userID, TimezoneSetting)
+	if err != nil {
+		return nil, err
+	}
+	if timezone == constants.ChoiceKeyTimezoneAutomatic {
+		return nil, nil
+	}
+	return time.LoadLocation(timezone)
+}
+
+// GetUserLocation returns the timezone the user picked in their settings, or else the one reported
+// by their calendar account, defaulting to UTC
+func GetUserLocation(ctx context.Context, db *mongo.Database, userID primitive.ObjectID) *time.Location {
+	location, err := GetTimezoneOverride(db, userID)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to load timezone setting")
+	}
+	if location != nil {
+		return location
+	}
+	tokens, err := database.GetExternalTokens(ctx, db, userID, external.TASK_SERVICE_ID_GOOGLE)
+	if err != nil {
+		return time.UTC
+	}
+	for _, token := range *tokens {
+		if token.Timezone == "" {
+			continue
+		}
+		location, err := time.LoadLocation(token.Timezone)
+		if err == nil {
+			return location
+		}
+	}
+	return time.UTC
+}
@@ -0,0 +1,86 @@
+package settings
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"github.com/franchizzle/task-manager/backend/database"
+	"github.com/franchizzle/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTimezoneChoices(t *testing.T) {
+	for _, choice := range TimezoneSetting.Choices[1:] {
+		_, err := time.LoadLocation(choice.Key)
+		assert.NoError(t, err, choice.Key)
+	}
+}
+
+func TestGetTimezoneOverride(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+
+	t.Run("Automatic", func(t *testing.T) {
+		location, err := GetTimezoneOverride(db, primitive.NewObjectID())
+		assert.NoError(t, err)
+		assert.Nil(t, location)
+	})
+	t.Run("Override", func(t *testing.T) {
+		userID := primitive.NewObjectID()
+		err := UpdateUserSetting(db, userID, constants.SettingFieldTimezone, "Asia/Tokyo")
+		assert.NoError(t, err)
+		location, err := GetTimezoneOverride(db, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Asia/Tokyo", location.String())
+	})
+	t.Run("InvalidChoice", func(t *testing.T) {
+		err := UpdateUserSetting(db, primitive.NewObjectID(), constants.SettingFieldTimezone, "Not/AZone")
+		assert.Error(t, err)
+	})
+}
+
+func TestGetUserLocation(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+
+	t.Run("NoToken", func(t *testing.T) {
+		assert.Equal(t, time.UTC, GetUserLocation(context.Background(), db, primitive.NewObjectID()))
+	})
+	t.Run("InvalidTimezone", func(t *testing.T) {
+		userID := primitive.NewObjectID()
+		_, err := database.GetExternalTokenCollection(db).InsertOne(context.Background(), database.ExternalAPIToken{
+			UserID:    userID,
+			ServiceID: external.TASK_SERVICE_ID_GOOGLE,
+			Timezone:  "Not/AZone",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, time.UTC, GetUserLocation(context.Background(), db, userID))
+	})
+	t.Run("Success", func(t *testing.T) {
+		userID := primitive.NewObjectID()
+		_, err := database.GetExternalTokenCollection(db).InsertOne(context.Background(), database.ExternalAPIToken{
+			UserID:    userID,
+			ServiceID: external.TASK_SERVICE_ID_GOOGLE,
+			Timezone:  "Europe/Paris",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "Europe/Paris", GetUserLocation(context.Background(), db, userID).String())
+	})
+	t.Run("Setting", func(t *testing.T) {
+		userID := primitive.NewObjectID()
+		_, err := database.GetExternalTokenCollection(db).InsertOne(context.Background(), database.ExternalAPIToken{
+			UserID:    userID,
+			ServiceID: external.TASK_SERVICE_ID_GOOGLE,
+			Timezone:  "Europe/Paris",
+		})
+		assert.NoError(t, err)
+		err = UpdateUserSetting(db, userID, constants.SettingFieldTimezone, "Asia/Tokyo")
+		assert.NoError(t, err)
+		assert.Equal(t, "Asia/Tokyo", GetUserLocation(context.Background(), db, userID).String())
+	})
+}
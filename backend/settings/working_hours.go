@@ -0,0 +1,134 @@
+package settings
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/constants"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	WORKING_HOURS_INTERVAL = 30 * time.Minute
+	WORKING_HOURS_FORMAT   = "%02d:%02d"
+)
+
+// WorkingHours are the times of day the user works. Start and End are offsets from midnight in
+// the user's timezone
+type WorkingHours struct {
+	Start    time.Duration
+	End      time.Duration
+	Workdays []time.Weekday
+}
+
+var DefaultWorkingHours = WorkingHours{
+	Start:    9 * time.Hour,
+	End:      17 * time.Hour,
+	Workdays: workdayChoices[constants.ChoiceKeyMondayToFriday],
+}
+
+var workdayChoices = map[string][]time.Weekday{
+	constants.ChoiceKeyMondayToFriday:   {time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+	constants.ChoiceKeySundayToThursday: {time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday},
+	constants.ChoiceKeyMondayToSaturday: {time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday},
+	constants.ChoiceKeyEveryDay:         {time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday},
+}
+
+var WorkingHoursStartSetting = SettingDefinition{
+	FieldKey:      constants.SettingFieldWorkingHoursStart,
+	DefaultChoice: formatTimeOfDay(DefaultWorkingHours.Start),
+	Choices:       getTimeOfDayChoices(0, 24*time.Hour-WORKING_HOURS_INTERVAL),
+}
+
+var WorkingHoursEndSetting = SettingDefinition{
+	FieldKey:      constants.SettingFieldWorkingHoursEnd,
+	DefaultChoice: formatTimeOfDay(DefaultWorkingHours.End),
+	Choices:       getTimeOfDayChoices(WORKING_HOURS_INTERVAL, 24*time.Hour),
+}
+
+var WorkdaysSetting = SettingDefinition{
+	FieldKey:      constants.SettingFieldWorkdays,
+	DefaultChoice: constants.ChoiceKeyMondayToFriday,
+	Choices: []SettingChoice{
+		{Key: constants.ChoiceKeyMondayToFriday},
+		{Key: constants.ChoiceKeySundayToThursday},
+		{Key: constants.ChoiceKeyMondayToSaturday},
+		{Key: constants.ChoiceKeyEveryDay},
+	},
+}
+
+func formatTimeOfDay(offset time.Duration) string {
+	return fmt.Sprintf(WORKING_HOURS_FORMAT, int(offset.Hours()), int(offset.Minutes())%60)
+}
+
+func parseTimeOfDay(value string) (time.Duration, error) {
+	var hours, minutes int
+	_, err := fmt.Sscanf(value, WORKING_HOURS_FORMAT, &hours, &minutes)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute, nil
+}
+
+func getTimeOfDayChoices(first time.Duration, last time.Duration) []SettingChoice {
+	choices := []SettingChoice{}
+	for offset := first; offset <= last; offset += WORKING_HOURS_INTERVAL {
+		choices = append(choices, SettingChoice{Key: formatTimeOfDay(offset)})
+	}
+	return choices
+}
+
+// GetWorkingHours returns the user's working hours. If they end before they start, the defaults
+// are used instead
+func GetWorkingHours(db *mongo.Database, userID primitive.ObjectID) (WorkingHours, error) {
+	values := map[string]string{}
+	for _, setting := range []SettingDefinition{WorkingHoursStartSetting, WorkingHoursEndSetting, WorkdaysSetting} {
+		value, err := GetUserSettingValue(db, userID, setting)
+		if err != nil {
+			return DefaultWorkingHours, err
+		}
+		values[setting.FieldKey] = value
+	}
+	start, err := parseTimeOfDay(values[constants.SettingFieldWorkingHoursStart])
+	if err != nil {
+		return DefaultWorkingHours, err
+	}
+	end, err := parseTimeOfDay(values[constants.SettingFieldWorkingHoursEnd])
+	if err != nil {
+		return DefaultWorkingHours, err
+	}
+	if end <= start {
+		return DefaultWorkingHours, nil
+	}
+	return WorkingHours{Start: start, End: end, Workdays: workdayChoices[values[constants.SettingFieldWorkdays]]}, nil
+}
+
+func (workingHours WorkingHours) IsWorkday(weekday time.Weekday) bool {
+	for _, workday := range workingHours.Workdays {
+		if workday == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains returns whether t is during working hours, in t's timezone
+func (workingHours WorkingHours) Contains(t time.Time) bool {
+	if !workingHours.IsWorkday(t.Weekday()) {
+		return false
+	}
+	sinceMidnight := t.Sub(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()))
+	return sinceMidnight >= workingHours.Start && sinceMidnight < workingHours.End
+}
+
+// EndOfDay returns when the working day that now is in, or that is still to come today, ends.
+// After working hours and on days off, the day ends at midnight instead
+func (workingHours WorkingHours) EndOfDay(now time.Time) time.Time {
+	endOfDay := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 0, now.Location())
+	workdayEnd := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).Add(workingHours.End)
+	if workingHours.IsWorkday(now.Weekday()) && now.Before(workdayEnd) && workdayEnd.Before(endOfDay) {
+		return workdayEnd
+	}
+	return endOfDay
+}
@@ -0,0 +1,43 @@
+package settings
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkingHours(t *testing.T) {
+	// a Wednesday
+	wednesday := time.Date(2023, time.January, 4, 0, 0, 0, 0, time.UTC)
+	saturday := time.Date(2023, time.January, 7, 0, 0, 0, 0, time.UTC)
+
+	t.Run("ParseTimeOfDay", func(t *testing.T) {
+		offset, err := parseTimeOfDay("09:30")
+		assert.NoError(t, err)
+		assert.Equal(t, 9*time.Hour+30*time.Minute, offset)
+		assert.Equal(t, "24:00", formatTimeOfDay(24*time.Hour))
+		_, err = parseTimeOfDay("noon")
+		assert.Error(t, err)
+	})
+	t.Run("Choices", func(t *testing.T) {
+		assert.Equal(t, 48, len(WorkingHoursStartSetting.Choices))
+		assert.Equal(t, "00:00", WorkingHoursStartSetting.Choices[0].Key)
+		assert.Equal(t, "24:00", WorkingHoursEndSetting.Choices[len(WorkingHoursEndSetting.Choices)-1].Key)
+	})
+	t.Run("Contains", func(t *testing.T) {
+		assert.True(t, DefaultWorkingHours.Contains(wednesday.Add(9*time.Hour)))
+		assert.False(t, DefaultWorkingHours.Contains(wednesday.Add(17*time.Hour)))
+		assert.False(t, DefaultWorkingHours.Contains(wednesday.Add(8*time.Hour)))
+		assert.False(t, DefaultWorkingHours.Contains(saturday.Add(12*time.Hour)))
+	})
+	t.Run("EndOfDay", func(t *testing.T) {
+		assert.Equal(t, wednesday.Add(17*time.Hour), DefaultWorkingHours.EndOfDay(wednesday.Add(12*time.Hour)))
+		assert.Equal(t, wednesday.Add(17*time.Hour), DefaultWorkingHours.EndOfDay(wednesday.Add(7*time.Hour)))
+		endOfDay := time.Date(2023, time.January, 4, 23, 59, 59, 0, time.UTC)
+		assert.Equal(t, endOfDay, DefaultWorkingHours.EndOfDay(wednesday.Add(18*time.Hour)))
+		assert.Equal(t, time.Date(2023, time.January, 7, 23, 59, 59, 0, time.UTC), DefaultWorkingHours.EndOfDay(saturday.Add(12*time.Hour)))
+		allDay := WorkingHours{Start: 0, End: 24 * time.Hour, Workdays: DefaultWorkingHours.Workdays}
+		assert.Equal(t, endOfDay, allDay.EndOfDay(wednesday.Add(12*time.Hour)))
+	})
+}
@@ -17,7 +17,7 @@ type Message struct {
 
 func SendFeedbackMessage(text string) error {
 	// adapted from https://golangcode.com/send-slack-messages-without-a-library/
-	webhookURL := config.GetConfigValue("SLACK_WEBHOOK_FEEDBACK")
+	webhookURL := config.GetSettings().SlackWebhookFeedback
 	if len(webhookURL) == 0 {
 		return errors.New("missing slack webhook setting")
 	}
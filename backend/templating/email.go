@@ -0,0 +1,60 @@
+package templating
+
+import (
+	"bytes"
+	"html/template"
+)
+
+// EmailContent is the structured content of a transactional email (digests, reports, etc.)
+type EmailContent struct {
+	Heading    string
+	Subheading string
+	Sections   []EmailSection
+}
+
+type EmailSection struct {
+	Title string
+	// shown instead of the item list when the section has no items
+	EmptyMessage string
+	Items        []EmailItem
+}
+
+type EmailItem struct {
+	Title  string
+	Detail string
+	Link   string
+}
+
+var emailTemplate = template.Must(template.New("email").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <style>
+        html, body {
+            font-size: 16px;
+            font-family: "Gothic A1", sans-serif;
+        }
+        .detail {
+            color: #6b6b6b;
+            font-size: 14px;
+        }
+    </style>
+</head>
+<body>
+<h1>{{ .Heading }}</h1>
+{{ if .Subheading }}<p>{{ .Subheading }}</p>{{ end }}
+{{ range .Sections }}
+<h2>{{ .Title }}</h2>
+{{ if .Items }}<ul>
+{{ range .Items }}    <li>{{ if .Link }}<a href="{{ .Link }}">{{ .Title }}</a>{{ else }}{{ .Title }}{{ end }}{{ if .Detail }} <span class="detail">{{ .Detail }}</span>{{ end }}</li>
+{{ end }}</ul>{{ else }}<p class="detail">{{ .EmptyMessage }}</p>{{ end }}
+{{ end }}
+</body>
+</html>
+`))
+
+func RenderEmail(content EmailContent) (string, error) {
+	buffer := new(bytes.Buffer)
+	err := emailTemplate.Execute(buffer, content)
+	return buffer.String(), err
+}
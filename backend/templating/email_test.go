@@ -0,0 +1,43 @@
+package templating
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderEmail(t *testing.T) {
+	t.Run("Sections", func(t *testing.T) {
+		result, err := RenderEmail(EmailContent{
+			Heading:    "Good morning",
+			Subheading: "Monday, January 2",
+			Sections: []EmailSection{
+				{
+					Title: "Meetings",
+					Items: []EmailItem{
+						{Title: "Standup", Detail: "9:00 AM", Link: "https://example.com/event"},
+						{Title: "Lunch"},
+					},
+				},
+				{Title: "Overdue tasks", EmptyMessage: "Nothing overdue"},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Contains(t, result, "<h1>Good morning</h1>")
+		assert.Contains(t, result, "<p>Monday, January 2</p>")
+		assert.Contains(t, result, `<li><a href="https://example.com/event">Standup</a> <span class="detail">9:00 AM</span></li>`)
+		assert.Contains(t, result, "<li>Lunch</li>")
+		assert.Contains(t, result, `<p class="detail">Nothing overdue</p>`)
+	})
+	t.Run("EscapesContent", func(t *testing.T) {
+		result, err := RenderEmail(EmailContent{
+			Heading: "Digest",
+			Sections: []EmailSection{
+				{Title: "Tasks", Items: []EmailItem{{Title: "<script>alert(1)</script>", Link: "javascript:alert(1)"}}},
+			},
+		})
+		assert.NoError(t, err)
+		assert.NotContains(t, result, "<script>")
+		assert.NotContains(t, result, "javascript:alert")
+	})
+}
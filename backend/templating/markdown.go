@@ -0,0 +1,56 @@
+package templating
+
+import (
+	"bytes"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// note and task bodies are stored as raw markdown; HTML is only ever produced on the way out
+var markdownRenderer = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+)
+
+// UGCPolicy allows the formatting markdown produces (links, lists, tables, code) while removing
+// scripts, event handlers, iframes, and styles
+var htmlSanitizer = bluemonday.UGCPolicy().RequireNoFollowOnLinks(true).AddTargetBlankToFullyQualifiedLinks(true)
+
+var textStripper = bluemonday.StrictPolicy()
+
+var lineBreakTags = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|li|h[1-6])>`)
+var listItemTags = regexp.MustCompile(`(?i)<li[^>]*>`)
+var repeatedBlankLines = regexp.MustCompile(`\n{3,}`)
+
+// RenderMarkdown converts markdown to HTML that is safe to embed in a page
+func RenderMarkdown(markdown string) (string, error) {
+	var rendered bytes.Buffer
+	err := markdownRenderer.Convert([]byte(markdown), &rendered)
+	if err != nil {
+		return "", err
+	}
+	return SanitizeHTML(rendered.String()), nil
+}
+
+// SanitizeHTML strips dangerous elements and attributes from HTML received from external sources
+func SanitizeHTML(html string) string {
+	return htmlSanitizer.Sanitize(html)
+}
+
+// HTMLToText converts HTML received from external sources, such as calendar event descriptions,
+// into plain text that keeps line breaks and list items
+func HTMLToText(htmlText string) string {
+	text := listItemTags.ReplaceAllString(htmlText, "- ")
+	text = lineBreakTags.ReplaceAllString(text, "\n")
+	text = html.UnescapeString(textStripper.Sanitize(text))
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	text = repeatedBlankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(text)
+}
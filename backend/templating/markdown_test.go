@@ -0,0 +1,58 @@
+package templating
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	t.Run("Formatting", func(t *testing.T) {
+		html, err := RenderMarkdown("# Agenda\n\n- **first** item\n- [ ] todo\n\n`code`")
+		assert.NoError(t, err)
+		assert.Contains(t, html, "<h1")
+		assert.Contains(t, html, "<strong>first</strong>")
+		assert.Contains(t, html, "<code>code</code>")
+	})
+	t.Run("Links", func(t *testing.T) {
+		html, err := RenderMarkdown("[docs](https://example.com)")
+		assert.NoError(t, err)
+		assert.Equal(t, "<p><a href=\"https://example.com\" rel=\"nofollow noopener\" target=\"_blank\">docs</a></p>\n", html)
+	})
+	t.Run("StripsScripts", func(t *testing.T) {
+		html, err := RenderMarkdown("hello <script>alert(1)</script> <img src=x onerror=alert(1)>")
+		assert.NoError(t, err)
+		assert.NotContains(t, html, "<script")
+		assert.NotContains(t, html, "onerror")
+	})
+	t.Run("StripsJavascriptLinks", func(t *testing.T) {
+		html, err := RenderMarkdown("[click](javascript:alert(1))")
+		assert.NoError(t, err)
+		assert.NotContains(t, html, "javascript:")
+	})
+}
+
+func TestSanitizeHTML(t *testing.T) {
+	t.Run("KeepsFormatting", func(t *testing.T) {
+		assert.Equal(t, "<b>bold</b><br>next line", SanitizeHTML("<b>bold</b><br>next line"))
+	})
+	t.Run("StripsDangerousContent", func(t *testing.T) {
+		sanitized := SanitizeHTML(`<p onclick="steal()">hi</p><iframe src="https://evil.example"></iframe><style>body{}</style>`)
+		assert.Equal(t, "<p>hi</p>", sanitized)
+	})
+}
+
+func TestHTMLToText(t *testing.T) {
+	t.Run("LineBreaks", func(t *testing.T) {
+		assert.Equal(t, "Agenda\nfirst & second", HTMLToText("<b>Agenda</b><br>first &amp; second"))
+	})
+	t.Run("Lists", func(t *testing.T) {
+		assert.Equal(t, "Topics:\n- roadmap\n- hiring", HTMLToText("<p>Topics:</p><ul><li>roadmap</li><li>hiring</li></ul>"))
+	})
+	t.Run("StripsDangerousContent", func(t *testing.T) {
+		assert.Equal(t, "hi", HTMLToText(`<p onclick="steal()">hi</p><script>alert(1)</script>`))
+	})
+	t.Run("PlainText", func(t *testing.T) {
+		assert.Equal(t, "line one\n\nline two", HTMLToText("line one\n\n\n\nline two"))
+	})
+}
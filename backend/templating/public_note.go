@@ -0,0 +1,98 @@
+package templating
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+)
+
+// open graph descriptions are cut off by most link previews past this
+const PUBLIC_NOTE_DESCRIPTION_LENGTH = 200
+
+// PublicNote is a publicly shared note rendered as a standalone page
+type PublicNote struct {
+	Title  string
+	Author string
+	// already rendered and sanitized by RenderMarkdown
+	BodyHTML string
+	URL      string
+}
+
+type publicNotePage struct {
+	Title       string
+	Author      string
+	Description string
+	BodyHTML    template.HTML
+	URL         string
+}
+
+var publicNoteTemplate = template.Must(template.New("public_note").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+    <title>{{ .Title }}</title>
+    <meta name="description" content="{{ .Description }}">
+    <link rel="canonical" href="{{ .URL }}">
+
+    <meta property="og:type" content="article">
+    <meta property="og:title" content="{{ .Title }}">
+    <meta property="og:description" content="{{ .Description }}">
+    <meta property="og:url" content="{{ .URL }}">
+    <meta property="og:site_name" content="General Task">
+    <meta name="twitter:card" content="summary">
+    <meta name="twitter:title" content="{{ .Title }}">
+    <meta name="twitter:description" content="{{ .Description }}">
+    <style>
+        html, body {
+            font-size: 16px;
+            font-family: "Gothic A1", sans-serif;
+        }
+        main {
+            max-width: 720px;
+            margin: 0 auto;
+            padding: 24px;
+        }
+        .author {
+            color: #6b6b6b;
+            font-size: 14px;
+        }
+    </style>
+</head>
+<body>
+<main>
+<h1>{{ .Title }}</h1>
+{{ if .Author }}<p class="author">Shared by {{ .Author }}</p>{{ end }}
+{{ .BodyHTML }}
+</main>
+</body>
+</html>
+`))
+
+// RenderPublicNote renders the page for a public note link, with the metadata link previews use
+func RenderPublicNote(note PublicNote) (string, error) {
+	page := publicNotePage{
+		Title:       note.Title,
+		Author:      note.Author,
+		Description: getDescription(note.BodyHTML),
+		// the body was sanitized when it was rendered from markdown
+		BodyHTML: template.HTML(note.BodyHTML),
+		URL:      note.URL,
+	}
+	if page.Title == "" {
+		page.Title = "Untitled note"
+	}
+	buffer := new(bytes.Buffer)
+	err := publicNoteTemplate.Execute(buffer, page)
+	return buffer.String(), err
+}
+
+// getDescription returns the start of the body as plain text on one line
+func getDescription(bodyHTML string) string {
+	description := strings.Join(strings.Fields(HTMLToText(bodyHTML)), " ")
+	runes := []rune(description)
+	if len(runes) <= PUBLIC_NOTE_DESCRIPTION_LENGTH {
+		return description
+	}
+	return strings.TrimSpace(string(runes[:PUBLIC_NOTE_DESCRIPTION_LENGTH-1])) + "…"
+}
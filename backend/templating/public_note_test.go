@@ -0,0 +1,42 @@
+package templating
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderPublicNote(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		bodyHTML, err := RenderMarkdown("# Decisions\n\n- ship it")
+		assert.NoError(t, err)
+		page, err := RenderPublicNote(PublicNote{
+			Title:    "Planning \"sync\"",
+			Author:   "Jane",
+			BodyHTML: bodyHTML,
+			URL:      "https://api.example.com/p/planning-sync/",
+		})
+		assert.NoError(t, err)
+		assert.Contains(t, page, `<title>Planning &#34;sync&#34;</title>`)
+		assert.Contains(t, page, `<meta property="og:title" content="Planning &#34;sync&#34;">`)
+		assert.Contains(t, page, `<meta property="og:description" content="Decisions - ship it">`)
+		assert.Contains(t, page, `<meta property="og:url" content="https://api.example.com/p/planning-sync/">`)
+		assert.Contains(t, page, `<p class="author">Shared by Jane</p>`)
+		assert.Contains(t, page, "<li>ship it</li>")
+	})
+	t.Run("Untitled", func(t *testing.T) {
+		page, err := RenderPublicNote(PublicNote{})
+		assert.NoError(t, err)
+		assert.Contains(t, page, "<title>Untitled note</title>")
+		assert.NotContains(t, page, "Shared by")
+	})
+}
+
+func TestGetDescription(t *testing.T) {
+	assert.Equal(t, "", getDescription(""))
+	assert.Equal(t, "first second", getDescription("<p>first</p>\n<p>second</p>"))
+	description := getDescription("<p>" + strings.Repeat("word ", 100) + "</p>")
+	assert.Equal(t, PUBLIC_NOTE_DESCRIPTION_LENGTH, len([]rune(description)))
+	assert.True(t, strings.HasSuffix(description, "…"))
+}
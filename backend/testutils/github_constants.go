@@ -13,6 +13,10 @@ const (
 	EmptyCheckRunsForRefPayload        string = `{"total_count": 0, "check_runs": []}`
 	CheckRunsForRefPayload             string = `{"total_count": 1, "check_runs": [{"ID": 96024}]}`
 	CheckRunsForRefFailPayload         string = `{"total_count": 1, "check_runs": [{"ID": 96024, "status": "completed", "conclusion": "failure"}]}`
+	EmptyCombinedStatusPayload         string = `{"state": "pending", "total_count": 0, "statuses": []}`
+	CombinedStatusPayload              string = `{"state": "failure", "total_count": 2, "statuses": [{"context": "ci/build", "state": "success", "target_url": "https://ci.example.com/1"}, {"context": "ci/lint", "state": "failure"}]}`
+	DeploymentsPayload                 string = `[{"id": 3, "environment": "staging"}, {"id": 2, "environment": "preview"}, {"id": 1, "environment": "staging"}]`
+	DeploymentStatusesPayload          string = `[{"id": 1, "state": "in_progress", "environment_url": "https://staging.example.com"}]`
 	PullRequestCommentsPayload         string = `[{"id": 1, "body": "This is a comment", "user": {"login": "chad1616", "id": 1}, "created_at": "2011-01-26T19:01:12Z", "updated_at": "2011-01-26T19:01:12Z", "path": "tothemoon.txt", "start_line": 69, "line": 420}]`
 	IssueCommentPayload                string = `[{"id": 1, "body": "This is a issue comment", "user": {"login": "gigachad2022", "id": 1}, "created_at": "2011-01-26T19:01:12Z", "updated_at": "2011-01-26T19:01:12Z"}]`
 )
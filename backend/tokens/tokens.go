@@ -0,0 +1,81 @@
+package tokens
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	guuid "github.com/google/uuid"
+)
+
+var ErrInvalidToken = errors.New("invalid token")
+var ErrExpiredToken = errors.New("token has expired")
+
+// Tokens are a base64 JSON payload followed by its HMAC-SHA256 signature, so they can be handed out
+// (in an email link, for example) and later trusted without having been stored. Each one has a
+// purpose, so a token issued for one flow can't be replayed in another, and a nonce which callers
+// can record to make the token single-use
+type Claims struct {
+	Subject   string `json:"sub"`
+	Purpose   string `json:"purpose"`
+	Nonce     string `json:"nonce"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+type Issuer struct {
+	secret []byte
+}
+
+func NewIssuer(secret string) (*Issuer, error) {
+	if len(secret) < 16 {
+		return nil, errors.New("token signing secret must be at least 16 characters")
+	}
+	return &Issuer{secret: []byte(secret)}, nil
+}
+
+// Issue signs a token for the subject which expires after the ttl
+func (issuer *Issuer) Issue(subject string, purpose string, ttl time.Duration, now time.Time) (string, *Claims, error) {
+	claims := Claims{
+		Subject:   subject,
+		Purpose:   purpose,
+		Nonce:     guuid.New().String(),
+		ExpiresAt: now.Add(ttl).Unix(),
+	}
+	payload, err := json.Marshal(&claims)
+	if err != nil {
+		return "", nil, err
+	}
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+	return encodedPayload + "." + issuer.sign(encodedPayload), &claims, nil
+}
+
+// Verify returns the token's claims if it was issued by this issuer for the purpose, and hasn't expired
+func (issuer *Issuer) Verify(token string, purpose string, now time.Time) (*Claims, error) {
+	encodedPayload, signature, found := strings.Cut(token, ".")
+	if !found || !hmac.Equal([]byte(signature), []byte(issuer.sign(encodedPayload))) {
+		return nil, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	var claims Claims
+	err = json.Unmarshal(payload, &claims)
+	if err != nil || claims.Purpose != purpose {
+		return nil, ErrInvalidToken
+	}
+	if now.Unix() >= claims.ExpiresAt {
+		return nil, ErrExpiredToken
+	}
+	return &claims, nil
+}
+
+func (issuer *Issuer) sign(encodedPayload string) string {
+	mac := hmac.New(sha256.New, issuer.secret)
+	mac.Write([]byte(encodedPayload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
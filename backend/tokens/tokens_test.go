@@ -0,0 +1,63 @@
+package tokens
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIssuer(t *testing.T) {
+	_, err := NewIssuer("short")
+	assert.EqualError(t, err, "token signing secret must be at least 16 characters")
+	_, err = NewIssuer("a-long-enough-secret")
+	assert.NoError(t, err)
+}
+
+func TestIssueAndVerify(t *testing.T) {
+	issuer, err := NewIssuer("a-long-enough-secret")
+	assert.NoError(t, err)
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	token, issuedClaims, err := issuer.Issue("user@example.com", "magic_link", 15*time.Minute, now)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(15*time.Minute).Unix(), issuedClaims.ExpiresAt)
+	assert.NotEmpty(t, issuedClaims.Nonce)
+
+	t.Run("Success", func(t *testing.T) {
+		claims, err := issuer.Verify(token, "magic_link", now.Add(time.Minute))
+		assert.NoError(t, err)
+		assert.Equal(t, issuedClaims, claims)
+		assert.Equal(t, "user@example.com", claims.Subject)
+	})
+	t.Run("UniqueNonce", func(t *testing.T) {
+		_, otherClaims, err := issuer.Issue("user@example.com", "magic_link", 15*time.Minute, now)
+		assert.NoError(t, err)
+		assert.NotEqual(t, issuedClaims.Nonce, otherClaims.Nonce)
+	})
+	t.Run("Expired", func(t *testing.T) {
+		_, err := issuer.Verify(token, "magic_link", now.Add(15*time.Minute))
+		assert.Equal(t, ErrExpiredToken, err)
+	})
+	t.Run("WrongPurpose", func(t *testing.T) {
+		_, err := issuer.Verify(token, "password_reset", now)
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+	t.Run("WrongSecret", func(t *testing.T) {
+		otherIssuer, err := NewIssuer("a-different-long-secret")
+		assert.NoError(t, err)
+		_, err = otherIssuer.Verify(token, "magic_link", now)
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+	t.Run("TamperedPayload", func(t *testing.T) {
+		otherToken, _, err := issuer.Issue("attacker@example.com", "magic_link", 15*time.Minute, now)
+		assert.NoError(t, err)
+		_, err = issuer.Verify(otherToken[:len(otherToken)-43]+token[len(token)-43:], "magic_link", now)
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := issuer.Verify("oops", "magic_link", now)
+		assert.Equal(t, ErrInvalidToken, err)
+		_, err = issuer.Verify("", "magic_link", now)
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+}
@@ -0,0 +1,105 @@
+package unfurl
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+const FIGMA_OEMBED_URL = "https://www.figma.com/api/oembed"
+
+// FigmaAdapter uses Figma's oEmbed endpoint, since file pages render client side and have no
+// useful OpenGraph tags
+type FigmaAdapter struct {
+	OEmbedURL string
+}
+
+type oEmbedResponse struct {
+	Title        string `json:"title"`
+	ProviderName string `json:"provider_name"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	AuthorName   string `json:"author_name"`
+}
+
+func (adapter FigmaAdapter) Matches(link *url.URL) bool {
+	host := link.Hostname()
+	if host != "figma.com" && host != "www.figma.com" {
+		return false
+	}
+	for _, prefix := range []string{"/file/", "/design/", "/proto/", "/board/"} {
+		if strings.HasPrefix(link.Path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (adapter FigmaAdapter) Unfurl(ctx context.Context, fetcher *Fetcher, link *url.URL) (*Preview, error) {
+	oEmbedURL, err := url.Parse(adapter.OEmbedURL)
+	if err != nil {
+		return nil, err
+	}
+	query := oEmbedURL.Query()
+	query.Set("url", link.String())
+	oEmbedURL.RawQuery = query.Encode()
+
+	body, _, err := fetcher.Get(ctx, oEmbedURL.String(), "application/json")
+	if err != nil {
+		return nil, err
+	}
+	var response oEmbedResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, err
+	}
+	if response.Title == "" {
+		return nil, ErrPreviewUnavailable
+	}
+	siteName := response.ProviderName
+	if siteName == "" {
+		siteName = "Figma"
+	}
+	return &Preview{
+		Provider:    ProviderFigma,
+		Title:       response.Title,
+		Description: response.AuthorName,
+		ImageURL:    resolveImageURL(response.ThumbnailURL, link),
+		SiteName:    siteName,
+	}, nil
+}
+
+// GoogleDocsAdapter reads the title from the document's OpenGraph tags when it's public. Private
+// documents redirect to a sign in page, so those fall back to a generic card for the document type
+type GoogleDocsAdapter struct{}
+
+var googleDocsTypes = map[string]string{
+	"document":     "Google Docs",
+	"spreadsheets": "Google Sheets",
+	"presentation": "Google Slides",
+	"forms":        "Google Forms",
+	"drawings":     "Google Drawings",
+}
+
+func (adapter GoogleDocsAdapter) Matches(link *url.URL) bool {
+	return link.Hostname() == "docs.google.com" && getGoogleDocsSiteName(link) != ""
+}
+
+func (adapter GoogleDocsAdapter) Unfurl(ctx context.Context, fetcher *Fetcher, link *url.URL) (*Preview, error) {
+	siteName := getGoogleDocsSiteName(link)
+	preview, err := unfurlOpenGraph(ctx, fetcher, link, ProviderGoogleDocs)
+	if err != nil || preview.Title == "Google Docs: Sign-in" || strings.HasPrefix(preview.Title, "Sign in") {
+		return &Preview{
+			Provider: ProviderGoogleDocs,
+			Title:    siteName,
+			SiteName: siteName,
+		}, nil
+	}
+	preview.SiteName = siteName
+	return preview, nil
+}
+
+func getGoogleDocsSiteName(link *url.URL) string {
+	segments := strings.Split(strings.TrimPrefix(link.Path, "/"), "/")
+	return googleDocsTypes[segments[0]]
+}
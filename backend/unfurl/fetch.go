@@ -0,0 +1,111 @@
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	FETCH_TIMEOUT       = 5 * time.Second
+	MAX_RESPONSE_BYTES  = 1 << 20
+	MAX_FETCH_REDIRECTS = 3
+	FETCH_USER_AGENT    = "GeneralTaskBot/1.0 (+https://resonant-kelpie-404a42.netlify.app)"
+)
+
+// Fetcher makes requests to user-supplied links. Every connection is checked after DNS resolution,
+// so neither redirects nor rebinding a hostname can reach internal addresses
+type Fetcher struct {
+	client *http.Client
+	// checkAddress is replaced in tests, which serve from localhost
+	checkAddress func(network string, address string) error
+}
+
+func NewFetcher() *Fetcher {
+	fetcher := &Fetcher{checkAddress: checkPublicAddress}
+	dialer := &net.Dialer{
+		Timeout: FETCH_TIMEOUT,
+		Control: func(network string, address string, _ syscall.RawConn) error {
+			return fetcher.checkAddress(network, address)
+		},
+	}
+	fetcher.client = &http.Client{
+		Timeout: FETCH_TIMEOUT,
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   FETCH_TIMEOUT,
+			ResponseHeaderTimeout: FETCH_TIMEOUT,
+			MaxIdleConns:          10,
+			IdleConnTimeout:       30 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= MAX_FETCH_REDIRECTS {
+				return errors.New("too many redirects")
+			}
+			_, err := ParseLink(req.URL.String())
+			return err
+		},
+	}
+	return fetcher
+}
+
+// Get fetches the URL and returns at most MAX_RESPONSE_BYTES of the body along with its content type
+func (fetcher *Fetcher) Get(ctx context.Context, rawURL string, accept string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("User-Agent", FETCH_USER_AGENT)
+	req.Header.Set("Accept", accept)
+	resp, err := fetcher.client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MAX_RESPONSE_BYTES))
+	if err != nil {
+		return nil, "", err
+	}
+	return body, resp.Header.Get("Content-Type"), nil
+}
+
+func checkPublicAddress(network string, address string) error {
+	if !strings.HasPrefix(network, "tcp") {
+		return fmt.Errorf("network %s is not allowed", network)
+	}
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if port != "80" && port != "443" {
+		return fmt.Errorf("port %s is not allowed", port)
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !isPublicIP(ip) {
+		return fmt.Errorf("address %s is not allowed", host)
+	}
+	return nil
+}
+
+// carrier-grade NAT addresses aren't covered by net.IP.IsPrivate
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+func isPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		sharedAddressSpace.Contains(ip))
+}
@@ -0,0 +1,129 @@
+package unfurl
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+type pageMetadata struct {
+	Title       string
+	Description string
+	ImageURL    string
+	SiteName    string
+}
+
+func unfurlOpenGraph(ctx context.Context, fetcher *Fetcher, link *url.URL, provider string) (*Preview, error) {
+	body, contentType, err := fetcher.Get(ctx, link.String(), "text/html")
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(contentType, "text/html") {
+		return nil, ErrPreviewUnavailable
+	}
+	metadata := parsePageMetadata(body, link)
+	if metadata.Title == "" {
+		return nil, ErrPreviewUnavailable
+	}
+	return &Preview{
+		Provider:    provider,
+		Title:       metadata.Title,
+		Description: metadata.Description,
+		ImageURL:    metadata.ImageURL,
+		SiteName:    metadata.SiteName,
+	}, nil
+}
+
+// parsePageMetadata reads the OpenGraph tags in the page's head, falling back to the <title> and
+// description meta tag for pages without them
+func parsePageMetadata(body []byte, link *url.URL) pageMetadata {
+	var metadata pageMetadata
+	var fallbackTitle, fallbackDescription string
+	tokenizer := html.NewTokenizer(bytes.NewReader(body))
+	isInTitle := false
+	for {
+		tokenType := tokenizer.Next()
+		switch tokenType {
+		case html.ErrorToken:
+			return finishPageMetadata(metadata, fallbackTitle, fallbackDescription, link)
+		case html.TextToken:
+			if isInTitle && fallbackTitle == "" {
+				fallbackTitle = strings.TrimSpace(string(tokenizer.Text()))
+			}
+		case html.EndTagToken:
+			tagName, _ := tokenizer.TagName()
+			switch string(tagName) {
+			case "title":
+				isInTitle = false
+			case "head":
+				return finishPageMetadata(metadata, fallbackTitle, fallbackDescription, link)
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tagName, hasAttributes := tokenizer.TagName()
+			switch string(tagName) {
+			case "title":
+				isInTitle = tokenType == html.StartTagToken
+			case "body":
+				return finishPageMetadata(metadata, fallbackTitle, fallbackDescription, link)
+			case "meta":
+				if !hasAttributes {
+					continue
+				}
+				attributes := map[string]string{}
+				for {
+					key, value, more := tokenizer.TagAttr()
+					attributes[strings.ToLower(string(key))] = string(value)
+					if !more {
+						break
+					}
+				}
+				name := attributes["property"]
+				if name == "" {
+					name = attributes["name"]
+				}
+				content := strings.TrimSpace(attributes["content"])
+				switch strings.ToLower(name) {
+				case "og:title":
+					metadata.Title = content
+				case "og:description":
+					metadata.Description = content
+				case "og:image", "og:image:url":
+					if metadata.ImageURL == "" {
+						metadata.ImageURL = content
+					}
+				case "og:site_name":
+					metadata.SiteName = content
+				case "description":
+					fallbackDescription = content
+				}
+			}
+		}
+	}
+}
+
+func finishPageMetadata(metadata pageMetadata, fallbackTitle string, fallbackDescription string, link *url.URL) pageMetadata {
+	if metadata.Title == "" {
+		metadata.Title = fallbackTitle
+	}
+	if metadata.Description == "" {
+		metadata.Description = fallbackDescription
+	}
+	metadata.ImageURL = resolveImageURL(metadata.ImageURL, link)
+	return metadata
+}
+
+// resolveImageURL makes relative image paths absolute and drops anything that isn't http(s), since
+// the client renders the image directly
+func resolveImageURL(imageURL string, link *url.URL) string {
+	if imageURL == "" {
+		return ""
+	}
+	resolved, err := link.Parse(imageURL)
+	if err != nil || (resolved.Scheme != "http" && resolved.Scheme != "https") {
+		return ""
+	}
+	return resolved.String()
+}
@@ -0,0 +1,133 @@
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/franchizzle/task-manager/backend/cache"
+	"mvdan.cc/xurls/v2"
+)
+
+const (
+	PREVIEW_CACHE_TTL         = 6 * time.Hour
+	PREVIEW_CACHE_MAX_ENTRIES = 2000
+	// unfurling a link that failed is retried sooner, in case the failure was transient
+	FAILED_PREVIEW_CACHE_TTL = 10 * time.Minute
+	MAX_URLS_PER_REQUEST     = 10
+)
+
+const (
+	ProviderFigma      = "figma"
+	ProviderGoogleDocs = "google_docs"
+	ProviderWeb        = "web"
+)
+
+var ErrPreviewUnavailable = errors.New("no preview available for url")
+
+type Preview struct {
+	URL         string `json:"url"`
+	Provider    string `json:"provider"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	ImageURL    string `json:"image_url,omitempty"`
+	SiteName    string `json:"site_name,omitempty"`
+}
+
+// Adapter builds previews for the links of one site. Adapters are tried in order and the first
+// one that matches the link is used
+type Adapter interface {
+	Matches(link *url.URL) bool
+	Unfurl(ctx context.Context, fetcher *Fetcher, link *url.URL) (*Preview, error)
+}
+
+type Unfurler struct {
+	adapters    []Adapter
+	fetcher     *Fetcher
+	previews    cache.Cache
+	failedLinks cache.Cache
+}
+
+func NewUnfurler() *Unfurler {
+	return &Unfurler{
+		adapters: []Adapter{
+			FigmaAdapter{OEmbedURL: FIGMA_OEMBED_URL},
+			GoogleDocsAdapter{},
+		},
+		fetcher:     NewFetcher(),
+		previews:    cache.NewMemoryCache(PREVIEW_CACHE_TTL, PREVIEW_CACHE_MAX_ENTRIES),
+		failedLinks: cache.NewMemoryCache(FAILED_PREVIEW_CACHE_TTL, PREVIEW_CACHE_MAX_ENTRIES),
+	}
+}
+
+// Unfurl returns a preview for the link, using the matching adapter or the page's OpenGraph tags
+func (unfurler *Unfurler) Unfurl(ctx context.Context, rawURL string) (*Preview, error) {
+	link, err := ParseLink(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	key := link.String()
+	if preview, exists := unfurler.previews.Get(key); exists {
+		return preview.(*Preview), nil
+	}
+	if _, exists := unfurler.failedLinks.Get(key); exists {
+		return nil, ErrPreviewUnavailable
+	}
+
+	preview, err := unfurler.getPreview(ctx, link)
+	if err != nil {
+		unfurler.failedLinks.Set(key, true)
+		return nil, err
+	}
+	preview.URL = key
+	unfurler.previews.Set(key, preview)
+	return preview, nil
+}
+
+func (unfurler *Unfurler) getPreview(ctx context.Context, link *url.URL) (*Preview, error) {
+	for _, adapter := range unfurler.adapters {
+		if adapter.Matches(link) {
+			return adapter.Unfurl(ctx, unfurler.fetcher, link)
+		}
+	}
+	return unfurlOpenGraph(ctx, unfurler.fetcher, link, ProviderWeb)
+}
+
+// ParseLink only accepts absolute http(s) links without credentials, which is all a preview is
+// ever needed for
+func ParseLink(rawURL string) (*url.URL, error) {
+	link, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, err
+	}
+	if link.Scheme != "http" && link.Scheme != "https" {
+		return nil, errors.New("url scheme must be http or https")
+	}
+	if link.Hostname() == "" {
+		return nil, errors.New("url must have a host")
+	}
+	if link.User != nil {
+		return nil, errors.New("url must not contain credentials")
+	}
+	link.Fragment = ""
+	return link, nil
+}
+
+// ExtractLinks returns the distinct http(s) links in text, in the order they appear
+func ExtractLinks(text string) []string {
+	links := []string{}
+	seen := map[string]bool{}
+	for _, match := range xurls.Strict().FindAllString(text, -1) {
+		if !strings.HasPrefix(match, "http://") && !strings.HasPrefix(match, "https://") {
+			continue
+		}
+		if seen[match] {
+			continue
+		}
+		seen[match] = true
+		links = append(links, match)
+	}
+	return links
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"net/url"
 	"strings"
 
 	"mvdan.cc/xurls/v2"
@@ -12,27 +13,88 @@ type ConferenceCall struct {
 	URL      string `json:"url" bson:"url"`
 }
 
-var ConferencePatterns = map[string]ConferenceCall{
-	"meet.google.com": {
+// ConferenceLinkDetector recognizes the join links of a conferencing platform. Matching on the parsed URL
+// rather than the text keeps links to the platform's other pages, like downloads, from being taken as calls
+type ConferenceLinkDetector struct {
+	Platform string
+	Logo     string
+	Matches  func(link *url.URL) bool
+}
+
+// detectors are tried in the order they're registered
+var conferenceLinkDetectors []ConferenceLinkDetector
+
+func init() {
+	RegisterConferenceLinkDetector(ConferenceLinkDetector{
 		Platform: "Google Meet",
 		Logo:     "/images/google-meet.svg",
-	},
-	"zoom.us": {
+		Matches: func(link *url.URL) bool {
+			return link.Hostname() == "meet.google.com"
+		},
+	})
+	RegisterConferenceLinkDetector(ConferenceLinkDetector{
 		Platform: "Zoom",
 		Logo:     "/images/zoom.svg",
-	},
+		Matches: func(link *url.URL) bool {
+			// company accounts join through their own subdomain, and government accounts through zoomgov.com
+			return (isHostOrSubdomain(link, "zoom.us") || isHostOrSubdomain(link, "zoomgov.com")) &&
+				hasPathPrefix(link, "/j/", "/w/", "/s/", "/my/", "/wc/")
+		},
+	})
+	RegisterConferenceLinkDetector(ConferenceLinkDetector{
+		Platform: "Microsoft Teams",
+		Logo:     "/images/microsoft-teams.svg",
+		Matches: func(link *url.URL) bool {
+			return (link.Hostname() == "teams.microsoft.com" && hasPathPrefix(link, "/l/meetup-join/", "/meet/")) ||
+				(link.Hostname() == "teams.live.com" && hasPathPrefix(link, "/meet/"))
+		},
+	})
+	RegisterConferenceLinkDetector(ConferenceLinkDetector{
+		Platform: "Webex",
+		Logo:     "/images/webex.svg",
+		Matches: func(link *url.URL) bool {
+			// personal rooms are under /meet/, and scheduled meetings under the site's j.php or the join service
+			return isHostOrSubdomain(link, "webex.com") &&
+				(hasPathPrefix(link, "/meet/", "/join/", "/wbxmjs/joinservice/") || strings.HasSuffix(link.Path, "/j.php"))
+		},
+	})
+}
+
+// RegisterConferenceLinkDetector adds a platform to look for in event locations and descriptions
+func RegisterConferenceLinkDetector(detector ConferenceLinkDetector) {
+	conferenceLinkDetectors = append(conferenceLinkDetectors, detector)
 }
 
 // only return the first conference url - in the future we may want to return all of them
 func GetConferenceUrlFromString(text string) *ConferenceCall {
 	for _, match := range xurls.Strict().FindAllString(text, -1) {
-		for pattern, conferenceTemplate := range ConferencePatterns {
-			if strings.Contains(match, pattern) {
-				conference := conferenceTemplate
-				conference.URL = match
-				return &conference
+		link, err := url.Parse(match)
+		if err != nil {
+			continue
+		}
+		for _, detector := range conferenceLinkDetectors {
+			if detector.Matches(link) {
+				return &ConferenceCall{
+					Platform: detector.Platform,
+					Logo:     detector.Logo,
+					URL:      match,
+				}
 			}
 		}
 	}
 	return nil
 }
+
+func isHostOrSubdomain(link *url.URL, domain string) bool {
+	host := strings.ToLower(link.Hostname())
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+func hasPathPrefix(link *url.URL, prefixes ...string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(link.Path, prefix) {
+			return true
+		}
+	}
+	return false
+}
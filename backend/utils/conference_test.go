@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -46,9 +47,69 @@ func TestGetConferenceUrlFromString(t *testing.T) {
 		assert.Equal(t, expected, *conference)
 	})
 
+	t.Run("Zoom for Government URL", func(t *testing.T) {
+		conference := GetConferenceUrlFromString("https://agency.zoomgov.com/j/1612345678")
+		assert.Equal(t, "Zoom", conference.Platform)
+		assert.Equal(t, "https://agency.zoomgov.com/j/1612345678", conference.URL)
+	})
+
+	t.Run("Zoom download URL before join URL", func(t *testing.T) {
+		text := "Get Zoom at https://zoom.us/download then join https://zoom.us/j/4746676152"
+		conference := GetConferenceUrlFromString(text)
+		assert.Equal(t, "https://zoom.us/j/4746676152", conference.URL)
+	})
+
+	t.Run("Teams URL", func(t *testing.T) {
+		text := "Join on your computer: https://teams.microsoft.com/l/meetup-join/19%3ameeting_abc%40thread.v2/0?context=%7b%22Tid%22%3a%22t1%22%7d"
+		conference := GetConferenceUrlFromString(text)
+		expected := ConferenceCall{
+			Platform: "Microsoft Teams",
+			Logo:     "/images/microsoft-teams.svg",
+			URL:      "https://teams.microsoft.com/l/meetup-join/19%3ameeting_abc%40thread.v2/0?context=%7b%22Tid%22%3a%22t1%22%7d",
+		}
+		assert.Equal(t, expected, *conference)
+	})
+
+	t.Run("Teams personal URL", func(t *testing.T) {
+		conference := GetConferenceUrlFromString("https://teams.live.com/meet/9876543210")
+		assert.Equal(t, "Microsoft Teams", conference.Platform)
+	})
+
+	t.Run("Webex URLs", func(t *testing.T) {
+		for _, link := range []string{
+			"https://acme.webex.com/meet/jdoe",
+			"https://acme.webex.com/acme/j.php?MTID=m1234567890abcdef",
+		} {
+			conference := GetConferenceUrlFromString("Join: " + link)
+			assert.Equal(t, "Webex", conference.Platform)
+			assert.Equal(t, "/images/webex.svg", conference.Logo)
+			assert.Equal(t, link, conference.URL)
+		}
+	})
+
+	t.Run("Platform pages which aren't calls", func(t *testing.T) {
+		text := "Help at https://support.zoom.us/hc/en-us and https://www.webex.com/downloads.html or https://teams.microsoft.com/downloads"
+		assert.Nil(t, GetConferenceUrlFromString(text))
+	})
+
 	t.Run("Other URLs", func(t *testing.T) {
 		text := "This is very important https://youtu.be/dQw4w9WgXcQ"
 		conference := GetConferenceUrlFromString(text)
 		assert.Nil(t, conference)
 	})
 }
+
+func TestRegisterConferenceLinkDetector(t *testing.T) {
+	detectors := conferenceLinkDetectors
+	defer func() { conferenceLinkDetectors = detectors }()
+
+	RegisterConferenceLinkDetector(ConferenceLinkDetector{
+		Platform: "Whereby",
+		Logo:     "/images/whereby.svg",
+		Matches: func(link *url.URL) bool {
+			return link.Hostname() == "whereby.com"
+		},
+	})
+	conference := GetConferenceUrlFromString("https://whereby.com/standup")
+	assert.Equal(t, &ConferenceCall{Platform: "Whereby", Logo: "/images/whereby.svg", URL: "https://whereby.com/standup"}, conference)
+}
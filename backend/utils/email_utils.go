@@ -2,6 +2,7 @@ package utils
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"regexp"
@@ -46,12 +47,76 @@ func IsEmailValid(e string) bool {
 }
 
 const MANDRILL_SEND_URL = "https://mandrillapp.com/api/1.0/messages/send"
+const DEFAULT_FROM_EMAIL = "noreply@resonant-kelpie-404a42.netlify.app"
+
+// bounds each send, so a hung request can't stall the jobs sending emails
+var mandrillHTTPClient = &http.Client{Timeout: constants.ExternalTimeout}
+
+// EmailSender abstracts the delivery provider so that jobs can be tested without sending real emails
+type EmailSender interface {
+	SendEmail(toEmail string, subject string, htmlBody string) error
+}
+
+type MandrillEmailSender struct {
+	// OverrideURL is used to point the sender at a test server
+	OverrideURL string
+}
+
+type mandrillRequest struct {
+	Key     string          `json:"key"`
+	Message mandrillMessage `json:"message"`
+}
+
+type mandrillMessage struct {
+	FromEmail string              `json:"from_email"`
+	Subject   string              `json:"subject"`
+	HTML      string              `json:"html"`
+	To        []mandrillRecipient `json:"to"`
+}
+
+type mandrillRecipient struct {
+	Email string `json:"email"`
+	Type  string `json:"type"`
+}
+
+func (sender MandrillEmailSender) SendEmail(toEmail string, subject string, htmlBody string) error {
+	payload, err := json.Marshal(mandrillRequest{
+		Key: config.GetSettings().MandrillClientSecret,
+		Message: mandrillMessage{
+			FromEmail: DEFAULT_FROM_EMAIL,
+			Subject:   subject,
+			HTML:      htmlBody,
+			To:        []mandrillRecipient{{Email: toEmail, Type: "to"}},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	url := MANDRILL_SEND_URL
+	if sender.OverrideURL != "" {
+		url = sender.OverrideURL
+	}
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Content-Type", "application/json")
+	resp, err := mandrillHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.New("email send failed")
+	}
+	return nil
+}
 
 func TestMailchimpEmail() error {
-	testMessage := `{"key": "` + config.GetConfigValue("MANDRILL_CLIENT_SECRET") + `", "message": {"from_email": "julian@resonant-kelpie-404a42.netlify.app", "subject": "General Task Test", "text": "Testing emails from General Task!", "to": [{ "email": "julian@resonant-kelpie-404a42.netlify.app", "type": "to" }]}}`
+	testMessage := `{"key": "` + config.GetSettings().MandrillClientSecret + `", "message": {"from_email": "julian@resonant-kelpie-404a42.netlify.app", "subject": "General Task Test", "text": "Testing emails from General Task!", "to": [{ "email": "julian@resonant-kelpie-404a42.netlify.app", "type": "to" }]}}`
 	req, _ := http.NewRequest("POST", MANDRILL_SEND_URL, bytes.NewBuffer([]byte(testMessage)))
 	req.Header.Add("Content-Type", "application/json")
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := mandrillHTTPClient.Do(req)
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -45,3 +48,28 @@ func TestIsEmailValid(t *testing.T) {
 	assert.False(t, IsEmailValid("julian"))
 	assert.True(t, IsEmailValid("julian@gmail.com"))
 }
+
+func TestMandrillEmailSender(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var receivedBody []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			receivedBody, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		err := MandrillEmailSender{OverrideURL: server.URL}.SendEmail("test@example.com", "Subject", "<p>hello</p>")
+		assert.NoError(t, err)
+		assert.Contains(t, string(receivedBody), `"subject":"Subject"`)
+		assert.Contains(t, string(receivedBody), `"to":[{"email":"test@example.com","type":"to"}]`)
+	})
+	t.Run("Failure", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		err := MandrillEmailSender{OverrideURL: server.URL}.SendEmail("test@example.com", "Subject", "<p>hello</p>")
+		assert.EqualError(t, err, "email send failed")
+	})
+}
@@ -18,7 +18,7 @@ func ConfigureLogger(env config.Environment) {
 		log.Logger = CreateConsoleLogger().With().Caller().Logger()
 	}
 	zerolog.SetGlobalLevel(zerolog.DebugLevel)
-	logLevel, err := zerolog.ParseLevel(config.GetConfigValue("LOG_LEVEL"))
+	logLevel, err := zerolog.ParseLevel(config.GetSettings().LogLevel)
 	if err == nil && logLevel != zerolog.NoLevel {
 		zerolog.SetGlobalLevel(logLevel)
 	}
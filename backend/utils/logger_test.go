@@ -1,7 +1,6 @@
 package utils
 
 import (
-	"os"
 	"testing"
 
 	"github.com/franchizzle/task-manager/backend/config"
@@ -11,22 +10,20 @@ import (
 
 func TestConfigureLogger(t *testing.T) {
 	t.Run("info", func(t *testing.T) {
-		os.Setenv("LOG_LEVEL", "info")
+		config.SetForTesting(t, "LOG_LEVEL", "info")
 		ConfigureLogger(config.Dev)
 		infoEnabled := log.Info().Enabled()
 		assert.True(t, infoEnabled)
 		traceEnabled := log.Trace().Enabled()
 		assert.False(t, traceEnabled)
-		os.Unsetenv("LOG_LEVEL")
 	})
 	t.Run("panic", func(t *testing.T) {
-		os.Setenv("LOG_LEVEL", "panic")
+		config.SetForTesting(t, "LOG_LEVEL", "panic")
 		ConfigureLogger(config.Dev)
 		panicEnabled := log.Panic().Enabled()
 		assert.True(t, panicEnabled)
 		infoEnabled := log.Info().Enabled()
 		assert.False(t, infoEnabled)
-		os.Unsetenv("LOG_LEVEL")
 	})
 }
 
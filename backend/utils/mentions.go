@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// matches @username and @user@example.com, but not the domain half of a plain email address
+var mentionRegex = regexp.MustCompile(`(?:^|[^\w@.])@([\w.+-]+(?:@[\w-]+(?:\.[\w-]+)+)?)`)
+
+// ExtractMentions returns the lowercased, deduplicated usernames and emails mentioned in a comment body
+func ExtractMentions(body string) []string {
+	mentions := []string{}
+	seen := make(map[string]bool)
+	for _, match := range mentionRegex.FindAllStringSubmatch(body, -1) {
+		// trailing punctuation is usually the end of a sentence rather than part of the username
+		mention := strings.ToLower(strings.TrimRight(match[1], ".-"))
+		if mention == "" || seen[mention] {
+			continue
+		}
+		seen[mention] = true
+		mentions = append(mentions, mention)
+	}
+	return mentions
+}
+
+// IsEmailMention returns true if the mention is an email rather than a username
+func IsEmailMention(mention string) bool {
+	return strings.Contains(mention, "@")
+}
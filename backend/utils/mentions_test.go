@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractMentions(t *testing.T) {
+	assert.Equal(t, []string{}, ExtractMentions("no mentions here"))
+	assert.Equal(t, []string{"john"}, ExtractMentions("@john can you take a look?"))
+	assert.Equal(t, []string{"john", "jane.doe"}, ExtractMentions("thanks @John and @jane.doe."))
+	assert.Equal(t, []string{"jane@robinhood.com"}, ExtractMentions("cc @Jane@Robinhood.com, please review"))
+	assert.Equal(t, []string{"john"}, ExtractMentions("@john @john (@JOHN)"))
+	assert.Equal(t, []string{}, ExtractMentions("email john@robinhood.com directly"))
+	assert.Equal(t, []string{}, ExtractMentions("just an @ sign"))
+}
+
+func TestIsEmailMention(t *testing.T) {
+	assert.True(t, IsEmailMention("jane@robinhood.com"))
+	assert.False(t, IsEmailMention("jane"))
+}
@@ -310,7 +310,7 @@ export const getOverviewSmartSuggestion = async () => {
             return status < 500 // Resolve only if the status code is less than 500
         },
     })
-    if (res.data.error) throw res.data.error
+    if (res.data.detail) throw res.data.detail
     if (res.status !== 200) throw 'getOverviewSmartSuggestion failed'
     return res.data
 }
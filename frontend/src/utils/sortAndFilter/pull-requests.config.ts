@@ -28,6 +28,10 @@ const ACTION_FIX_MERGE_CONFLICTS = {
     description: 'Your PR has merge conflicts that need fixing before it can be merged',
 }
 const ACTION_WAITING_ON_CI = { text: 'Waiting on CI', description: 'The CI is currently still running' }
+const ACTION_WAITING_ON_DEPLOY = {
+    text: 'Waiting on Deploy',
+    description: 'A deployment of the PR is currently still in progress',
+}
 const ACTION_MERGE_PR = {
     text: 'Merge PR',
     description: 'The PR is approved and has a passing CI and is therefore ready to be merged',
@@ -52,6 +56,7 @@ export const PULL_REQUEST_ACTIONS = [
     ACTION_ADDRESS_COMMENTS,
     ACTION_FIX_MERGE_CONFLICTS,
     ACTION_WAITING_ON_CI,
+    ACTION_WAITING_ON_DEPLOY,
     ACTION_MERGE_PR,
     ACTION_WAITING_ON_REVIEW,
     ACTION_WAITING_ON_AUTHOR,
@@ -65,6 +70,7 @@ const PULL_REQUEST_REQUIRED_ACTIONS = [
     ACTION_ADDRESS_COMMENTS.text,
     ACTION_FIX_MERGE_CONFLICTS.text,
     ACTION_WAITING_ON_CI.text,
+    ACTION_WAITING_ON_DEPLOY.text,
     ACTION_MERGE_PR.text,
     ACTION_WAITING_ON_REVIEW.text,
     ACTION_WAITING_ON_AUTHOR.text,
@@ -76,6 +82,7 @@ const NON_ACTIONABLE_REQUIRED_ACTIONS = new Set([
     ACTION_WAITING_ON_AUTHOR.text,
     ACTION_NOT_ACTIONABLE.text,
     ACTION_WAITING_ON_CI.text,
+    ACTION_WAITING_ON_DEPLOY.text,
 ])
 
 const requiredActionToIndexMap = new Map<string, number>(